@@ -0,0 +1,102 @@
+package agent
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+
+	"golang.org/x/net/context"
+)
+
+//BanConfig holds configuration for BanCLI
+type BanConfig struct {
+	// Socket path of registration API
+	RegistrationUDSPath string
+	// SpiffeID of the agent being banned
+	SpiffeID string
+}
+
+// Validate will perform a basic validation on config fields
+func (c *BanConfig) Validate() (err error) {
+	if c.RegistrationUDSPath == "" {
+		return errors.New("a socket path for registration api is required")
+	}
+
+	if c.SpiffeID == "" {
+		return errors.New("a SPIFFE ID is required")
+	}
+
+	// make sure SPIFFE ID is well formed
+	c.SpiffeID, err = idutil.NormalizeSpiffeID(c.SpiffeID, idutil.AllowAnyTrustDomainAgent())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+//BanCLI command for banning nodes
+type BanCLI struct {
+	registrationClient registration.RegistrationClient
+}
+
+func (BanCLI) Synopsis() string {
+	return "Bans an attested agent given its SPIFFE ID"
+}
+
+func (c BanCLI) Help() string {
+	_, err := c.parseConfig([]string{"-h"})
+	return err.Error()
+}
+
+//Run will ban an agent given its spiffeID
+func (c BanCLI) Run(args []string) int {
+	ctx := context.Background()
+
+	config, err := c.parseConfig(args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if err = config.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if c.registrationClient == nil {
+		c.registrationClient, err = util.NewRegistrationClient(config.RegistrationUDSPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error establishing connection to the Registration API: %v \n", err)
+			return 1
+		}
+	}
+	banResponse, err := c.registrationClient.BanAgent(ctx, &registration.BanAgentRequest{SpiffeId: config.SpiffeID})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error banning agent: %v \n", err)
+		return 1
+	}
+
+	if banResponse.Node == nil {
+		fmt.Fprintln(os.Stderr, "Failed to ban agent")
+		return 1
+	}
+
+	fmt.Println("Agent banned successfully")
+	return 0
+}
+
+func (BanCLI) parseConfig(args []string) (*BanConfig, error) {
+	f := flag.NewFlagSet("agent ban", flag.ContinueOnError)
+	c := &BanConfig{}
+
+	f.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
+	f.StringVar(&c.SpiffeID, "spiffeID", "", "The SPIFFE ID of the agent to ban (agent identity)")
+
+	return c, f.Parse(args)
+}
@@ -0,0 +1,84 @@
+package agent
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spiffe/spire/proto/spire/common"
+
+	"github.com/spiffe/spire/proto/spire/api/registration"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spiffe/spire/test/mock/proto/api/registration"
+	"github.com/stretchr/testify/suite"
+)
+
+type BanTestSuite struct {
+	suite.Suite
+	cli        *BanCLI
+	mockClient *mock_registration.MockRegistrationClient
+	mockCtrl   *gomock.Controller
+}
+
+func (s *BanTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockClient = mock_registration.NewMockRegistrationClient(s.mockCtrl)
+	s.cli = &BanCLI{
+		registrationClient: s.mockClient,
+	}
+}
+
+func (s *BanTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func TestBanTestSuite(t *testing.T) {
+	suite.Run(t, new(BanTestSuite))
+}
+
+func (s *BanTestSuite) TestRun() {
+	spiffeIDToBan := "spiffe://example.org/spire/agent/join_token/token_a"
+	args := []string{"-spiffeID", spiffeIDToBan}
+
+	req := &registration.BanAgentRequest{
+		SpiffeId: spiffeIDToBan,
+	}
+
+	resp := &registration.BanAgentResponse{
+		Node: &common.AttestedNode{SpiffeId: spiffeIDToBan},
+	}
+
+	s.mockClient.EXPECT().BanAgent(gomock.Any(), req).Return(resp, nil)
+	s.Require().Equal(0, s.cli.Run(args))
+}
+
+func (s *BanTestSuite) TestRunExitsWithNonZeroCodeOnError() {
+	spiffeIDToBan := "spiffe://example.org/spire/agent/join_token/token_a"
+	args := []string{"-spiffeID", spiffeIDToBan}
+
+	req := &registration.BanAgentRequest{
+		SpiffeId: spiffeIDToBan,
+	}
+
+	s.mockClient.EXPECT().BanAgent(gomock.Any(), req).Return(nil, errors.New("Some error"))
+	s.Require().Equal(1, s.cli.Run(args))
+}
+
+func (s *BanTestSuite) TestRunExitsWithNonZeroCodeOnBanFailed() {
+	spiffeIDToBan := "spiffe://example.org/spire/agent/join_token/token_a"
+	args := []string{"-spiffeID", spiffeIDToBan}
+
+	req := &registration.BanAgentRequest{
+		SpiffeId: spiffeIDToBan,
+	}
+	resp := &registration.BanAgentResponse{}
+
+	s.mockClient.EXPECT().BanAgent(gomock.Any(), req).Return(resp, nil)
+	s.Require().Equal(1, s.cli.Run(args))
+}
+
+func (s *BanTestSuite) TestRunValidatesSpiffeID() {
+	spiffeIDToBan := "not//an//spiffe/id"
+	args := []string{"-spiffeID", spiffeIDToBan}
+	s.Require().Equal(1, s.cli.Run(args))
+}
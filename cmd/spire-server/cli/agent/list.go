@@ -5,8 +5,9 @@ import (
 	"flag"
 	"fmt"
 	"os"
-	"time"
+	"strconv"
 
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/spiffe/spire/cmd/spire-server/util"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
@@ -14,10 +15,41 @@ import (
 	"golang.org/x/net/context"
 )
 
+const (
+	// listAgentsPageSize is how many agents are requested at a time when
+	// listing agents
+	listAgentsPageSize = 1000
+)
+
+var selectorMatches = map[string]registration.ListEntriesRequest_SelectorMatch{
+	"superset": registration.ListEntriesRequest_SUPERSET,
+	"subset":   registration.ListEntriesRequest_SUBSET,
+	"exact":    registration.ListEntriesRequest_EXACT,
+}
+
 //ListConfig holds configuration for ListCLI
 type ListConfig struct {
 	// Socket path of registration API
 	RegistrationUDSPath string
+
+	// Attestation type of the agents to list
+	AttestationType string
+
+	// Expiry window of the agents to list (RFC3339)
+	ExpiresAfter  string
+	ExpiresBefore string
+
+	// Type and value are delimited by a colon (:)
+	// ex. "k8s_sat:cluster:demo"
+	Selectors StringsFlag
+
+	// How agents are matched against the selectors (superset, subset or
+	// exact)
+	MatchSelectorsOn string
+
+	// Whether to list banned ("true") or not banned ("false") agents. Empty
+	// lists both.
+	Banned string
 }
 
 // Validate will perform a basic validation on config fields
@@ -25,9 +57,52 @@ func (c *ListConfig) Validate() (err error) {
 	if c.RegistrationUDSPath == "" {
 		return errors.New("a socket path for registration api is required")
 	}
+
+	if _, ok := selectorMatches[c.MatchSelectorsOn]; !ok {
+		return fmt.Errorf("unsupported selector match %q", c.MatchSelectorsOn)
+	}
+
 	return nil
 }
 
+// makeRequest builds the request for the first page of agents matching the
+// configured filters
+func (c *ListConfig) makeRequest() (*registration.ListAgentsRequest, error) {
+	req := &registration.ListAgentsRequest{
+		AttestationType: c.AttestationType,
+		SelectorMatch:   selectorMatches[c.MatchSelectorsOn],
+		PageSize:        listAgentsPageSize,
+	}
+
+	var err error
+	req.ExpiresAfter, err = parseTimeFlag("expiresAfter", c.ExpiresAfter)
+	if err != nil {
+		return nil, err
+	}
+	req.ExpiresBefore, err = parseTimeFlag("expiresBefore", c.ExpiresBefore)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sel := range c.Selectors {
+		selector, err := parseSelector(sel)
+		if err != nil {
+			return nil, err
+		}
+		req.Selectors = append(req.Selectors, selector)
+	}
+
+	if c.Banned != "" {
+		banned, err := strconv.ParseBool(c.Banned)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -banned value %q: expected true or false", c.Banned)
+		}
+		req.Banned = &wrappers.BoolValue{Value: banned}
+	}
+
+	return req, nil
+}
+
 //ListCLI command for listing attested nodes
 type ListCLI struct {
 	registrationClient registration.RegistrationClient
@@ -58,6 +133,12 @@ func (c *ListCLI) Run(args []string) int {
 		return 1
 	}
 
+	req, err := config.makeRequest()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
 	if c.registrationClient == nil {
 		c.registrationClient, err = util.NewRegistrationClient(config.RegistrationUDSPath)
 		if err != nil {
@@ -66,21 +147,45 @@ func (c *ListCLI) Run(args []string) int {
 		}
 	}
 
-	listResponse, err := c.registrationClient.ListAgents(ctx, &registration.ListAgentsRequest{})
+	nodeList, err := c.listAgents(ctx, req)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error listing attested agents: %v \n", err)
 		return 1
 	}
-	c.nodeList = listResponse.Nodes
+	c.nodeList = nodeList
 	c.printAttestedNodes()
 	return 0
 }
 
+// listAgents pages through the agents matching the request
+func (c *ListCLI) listAgents(ctx context.Context, req *registration.ListAgentsRequest) ([]*common.AttestedNode, error) {
+	var nodes []*common.AttestedNode
+	for {
+		listResponse, err := c.registrationClient.ListAgents(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, listResponse.Nodes...)
+		if listResponse.NextPageToken == "" {
+			return nodes, nil
+		}
+		req.PageToken = listResponse.NextPageToken
+	}
+}
+
 func (ListCLI) parseConfig(args []string) (*ListConfig, error) {
 	f := flag.NewFlagSet("agent list", flag.ContinueOnError)
 	c := &ListConfig{}
 
 	f.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
+	f.StringVar(&c.AttestationType, "attestationType", "", "Only list agents attested with this attestation type")
+	f.StringVar(&c.ExpiresAfter, "expiresAfter", "", "Only list agents whose SVID expires at or after this time (RFC3339)")
+	f.StringVar(&c.ExpiresBefore, "expiresBefore", "", "Only list agents whose SVID expires before this time (RFC3339)")
+	f.StringVar(&c.MatchSelectorsOn, "matchSelectorsOn", "superset", "The match mode used when filtering by selectors. Options: exact, subset and superset")
+	f.StringVar(&c.Banned, "banned", "", "Only list banned (true) or not banned (false) agents")
+
+	f.Var(&c.Selectors, "selector", "A colon-delimited type:value node selector. Can be used more than once")
 
 	return c, f.Parse(args)
 }
@@ -95,10 +200,6 @@ func (c ListCLI) printAttestedNodes() {
 	}
 
 	for _, node := range c.nodeList {
-		fmt.Printf("Spiffe ID         : %s\n", node.SpiffeId)
-		fmt.Printf("Attestation type  : %s\n", node.AttestationDataType)
-		fmt.Printf("Expiration time   : %s\n", time.Unix(node.CertNotAfter, 0))
-		fmt.Printf("Serial number     : %s\n", node.CertSerialNumber)
-		fmt.Println()
+		printAttestedNode(node)
 	}
 }
@@ -3,8 +3,10 @@ package agent
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/mock/proto/api/registration"
@@ -35,7 +37,7 @@ func TestListTestSuite(t *testing.T) {
 }
 
 func (s *ListTestSuite) TestRun() {
-	req := &registration.ListAgentsRequest{}
+	req := &registration.ListAgentsRequest{PageSize: listAgentsPageSize}
 	resp := &registration.ListAgentsResponse{
 		Nodes: []*common.AttestedNode{
 			&common.AttestedNode{SpiffeId: "spiffe://example.org/spire/agent/join_token/token_a"},
@@ -47,7 +49,7 @@ func (s *ListTestSuite) TestRun() {
 }
 
 func (s *ListTestSuite) TestRunWithNoAgentsInDatastore() {
-	req := &registration.ListAgentsRequest{}
+	req := &registration.ListAgentsRequest{PageSize: listAgentsPageSize}
 	resp := &registration.ListAgentsResponse{}
 	s.mockClient.EXPECT().ListAgents(gomock.Any(), req).Return(resp, nil)
 	s.Require().Equal(0, s.cli.Run([]string{}))
@@ -55,8 +57,64 @@ func (s *ListTestSuite) TestRunWithNoAgentsInDatastore() {
 }
 
 func (s *ListTestSuite) TestRunExitsWithNonZeroCodeOnFailure() {
-	req := &registration.ListAgentsRequest{}
+	req := &registration.ListAgentsRequest{PageSize: listAgentsPageSize}
 	s.mockClient.EXPECT().ListAgents(gomock.Any(), req).Return(nil, errors.New("Some error"))
 	s.Require().Equal(1, s.cli.Run([]string{}))
 	s.Assert().Nil(s.cli.nodeList)
 }
+
+func (s *ListTestSuite) TestRunWithFilters() {
+	expiresAfter := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiresBefore := time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)
+	req := &registration.ListAgentsRequest{
+		AttestationType: "join_token",
+		ExpiresAfter:    expiresAfter.Unix(),
+		ExpiresBefore:   expiresBefore.Unix(),
+		Selectors: []*common.Selector{
+			{Type: "a", Value: "1"},
+			{Type: "b", Value: "2:3"},
+		},
+		SelectorMatch: registration.ListEntriesRequest_EXACT,
+		Banned:        &wrappers.BoolValue{Value: false},
+		PageSize:      listAgentsPageSize,
+	}
+	resp := &registration.ListAgentsResponse{}
+	s.mockClient.EXPECT().ListAgents(gomock.Any(), req).Return(resp, nil)
+	s.Require().Equal(0, s.cli.Run([]string{
+		"-attestationType", "join_token",
+		"-expiresAfter", "2019-01-01T00:00:00Z",
+		"-expiresBefore", "2019-02-01T00:00:00Z",
+		"-selector", "a:1",
+		"-selector", "b:2:3",
+		"-matchSelectorsOn", "exact",
+		"-banned", "false",
+	}))
+}
+
+func (s *ListTestSuite) TestRunPages() {
+	nodeA := &common.AttestedNode{SpiffeId: "spiffe://example.org/spire/agent/a"}
+	nodeB := &common.AttestedNode{SpiffeId: "spiffe://example.org/spire/agent/b"}
+	gomock.InOrder(
+		s.mockClient.EXPECT().ListAgents(gomock.Any(), &registration.ListAgentsRequest{
+			PageSize: listAgentsPageSize,
+		}).Return(&registration.ListAgentsResponse{
+			Nodes:         []*common.AttestedNode{nodeA},
+			NextPageToken: "a",
+		}, nil),
+		s.mockClient.EXPECT().ListAgents(gomock.Any(), &registration.ListAgentsRequest{
+			PageToken: "a",
+			PageSize:  listAgentsPageSize,
+		}).Return(&registration.ListAgentsResponse{
+			Nodes: []*common.AttestedNode{nodeB},
+		}, nil),
+	)
+	s.Require().Equal(0, s.cli.Run([]string{}))
+	s.Assert().Equal([]*common.AttestedNode{nodeA, nodeB}, s.cli.nodeList)
+}
+
+func (s *ListTestSuite) TestRunWithInvalidFlags() {
+	s.Require().Equal(1, s.cli.Run([]string{"-matchSelectorsOn", "any"}))
+	s.Require().Equal(1, s.cli.Run([]string{"-expiresAfter", "yesterday"}))
+	s.Require().Equal(1, s.cli.Run([]string{"-selector", "a"}))
+	s.Require().Equal(1, s.cli.Run([]string{"-banned", "maybe"}))
+}
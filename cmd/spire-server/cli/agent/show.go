@@ -0,0 +1,99 @@
+package agent
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+
+	"golang.org/x/net/context"
+)
+
+//ShowConfig holds configuration for ShowCLI
+type ShowConfig struct {
+	// Socket path of registration API
+	RegistrationUDSPath string
+	// SpiffeID of the agent being shown
+	SpiffeID string
+}
+
+// Validate will perform a basic validation on config fields
+func (c *ShowConfig) Validate() (err error) {
+	if c.RegistrationUDSPath == "" {
+		return errors.New("a socket path for registration api is required")
+	}
+
+	if c.SpiffeID == "" {
+		return errors.New("a SPIFFE ID is required")
+	}
+
+	// make sure SPIFFE ID is well formed
+	c.SpiffeID, err = idutil.NormalizeSpiffeID(c.SpiffeID, idutil.AllowAnyTrustDomainAgent())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+//ShowCLI command for showing the details of an attested node
+type ShowCLI struct {
+	registrationClient registration.RegistrationClient
+}
+
+func (ShowCLI) Synopsis() string {
+	return "Shows the details of an attested agent given its SPIFFE ID"
+}
+
+func (c ShowCLI) Help() string {
+	_, err := c.parseConfig([]string{"-h"})
+	return err.Error()
+}
+
+//Run will show an agent given its spiffeID
+func (c ShowCLI) Run(args []string) int {
+	ctx := context.Background()
+
+	config, err := c.parseConfig(args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if err = config.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if c.registrationClient == nil {
+		c.registrationClient, err = util.NewRegistrationClient(config.RegistrationUDSPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error establishing connection to the Registration API: %v \n", err)
+			return 1
+		}
+	}
+
+	fetchResponse, err := c.registrationClient.FetchAgent(ctx, &registration.FetchAgentRequest{SpiffeId: config.SpiffeID})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching agent: %v \n", err)
+		return 1
+	}
+
+	fmt.Printf("Found an attested agent given its SPIFFE ID\n\n")
+	printAttestedNode(fetchResponse.Node)
+	return 0
+}
+
+func (ShowCLI) parseConfig(args []string) (*ShowConfig, error) {
+	f := flag.NewFlagSet("agent show", flag.ContinueOnError)
+	c := &ShowConfig{}
+
+	f.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
+	f.StringVar(&c.SpiffeID, "spiffeID", "", "The SPIFFE ID of the agent to show (agent identity)")
+
+	return c, f.Parse(args)
+}
@@ -0,0 +1,70 @@
+package agent
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/mock/proto/api/registration"
+	"github.com/stretchr/testify/suite"
+)
+
+type ShowTestSuite struct {
+	suite.Suite
+	cli        *ShowCLI
+	mockClient *mock_registration.MockRegistrationClient
+	mockCtrl   *gomock.Controller
+}
+
+func (s *ShowTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockClient = mock_registration.NewMockRegistrationClient(s.mockCtrl)
+	s.cli = &ShowCLI{
+		registrationClient: s.mockClient,
+	}
+}
+
+func (s *ShowTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func TestShowTestSuite(t *testing.T) {
+	suite.Run(t, new(ShowTestSuite))
+}
+
+func (s *ShowTestSuite) TestRun() {
+	spiffeID := "spiffe://example.org/spire/agent/join_token/token_a"
+	args := []string{"-spiffeID", spiffeID}
+
+	req := &registration.FetchAgentRequest{
+		SpiffeId: spiffeID,
+	}
+	resp := &registration.FetchAgentResponse{
+		Node: &common.AttestedNode{
+			SpiffeId:  spiffeID,
+			Selectors: []*common.Selector{{Type: "a", Value: "1"}},
+		},
+	}
+
+	s.mockClient.EXPECT().FetchAgent(gomock.Any(), req).Return(resp, nil)
+	s.Require().Equal(0, s.cli.Run(args))
+}
+
+func (s *ShowTestSuite) TestRunExitsWithNonZeroCodeOnError() {
+	spiffeID := "spiffe://example.org/spire/agent/join_token/token_a"
+	args := []string{"-spiffeID", spiffeID}
+
+	req := &registration.FetchAgentRequest{
+		SpiffeId: spiffeID,
+	}
+
+	s.mockClient.EXPECT().FetchAgent(gomock.Any(), req).Return(nil, errors.New("Some error"))
+	s.Require().Equal(1, s.cli.Run(args))
+}
+
+func (s *ShowTestSuite) TestRunValidatesSpiffeID() {
+	s.Require().Equal(1, s.cli.Run([]string{}))
+	s.Require().Equal(1, s.cli.Run([]string{"-spiffeID", "spiffe://example.org/workload"}))
+}
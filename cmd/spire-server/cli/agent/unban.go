@@ -0,0 +1,102 @@
+package agent
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+
+	"golang.org/x/net/context"
+)
+
+//UnbanConfig holds configuration for UnbanCLI
+type UnbanConfig struct {
+	// Socket path of registration API
+	RegistrationUDSPath string
+	// SpiffeID of the agent being unbanned
+	SpiffeID string
+}
+
+// Validate will perform a basic validation on config fields
+func (c *UnbanConfig) Validate() (err error) {
+	if c.RegistrationUDSPath == "" {
+		return errors.New("a socket path for registration api is required")
+	}
+
+	if c.SpiffeID == "" {
+		return errors.New("a SPIFFE ID is required")
+	}
+
+	// make sure SPIFFE ID is well formed
+	c.SpiffeID, err = idutil.NormalizeSpiffeID(c.SpiffeID, idutil.AllowAnyTrustDomainAgent())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+//UnbanCLI command for unbanning nodes
+type UnbanCLI struct {
+	registrationClient registration.RegistrationClient
+}
+
+func (UnbanCLI) Synopsis() string {
+	return "Unbans a banned agent given its SPIFFE ID"
+}
+
+func (c UnbanCLI) Help() string {
+	_, err := c.parseConfig([]string{"-h"})
+	return err.Error()
+}
+
+//Run will unban an agent given its spiffeID
+func (c UnbanCLI) Run(args []string) int {
+	ctx := context.Background()
+
+	config, err := c.parseConfig(args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if err = config.Validate(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if c.registrationClient == nil {
+		c.registrationClient, err = util.NewRegistrationClient(config.RegistrationUDSPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error establishing connection to the Registration API: %v \n", err)
+			return 1
+		}
+	}
+	unbanResponse, err := c.registrationClient.UnbanAgent(ctx, &registration.UnbanAgentRequest{SpiffeId: config.SpiffeID})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error unbanning agent: %v \n", err)
+		return 1
+	}
+
+	if unbanResponse.Node == nil {
+		fmt.Fprintln(os.Stderr, "Failed to unban agent")
+		return 1
+	}
+
+	fmt.Println("Agent unbanned successfully")
+	return 0
+}
+
+func (UnbanCLI) parseConfig(args []string) (*UnbanConfig, error) {
+	f := flag.NewFlagSet("agent unban", flag.ContinueOnError)
+	c := &UnbanConfig{}
+
+	f.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
+	f.StringVar(&c.SpiffeID, "spiffeID", "", "The SPIFFE ID of the agent to unban (agent identity)")
+
+	return c, f.Parse(args)
+}
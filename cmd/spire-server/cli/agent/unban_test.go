@@ -0,0 +1,84 @@
+package agent
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spiffe/spire/proto/spire/common"
+
+	"github.com/spiffe/spire/proto/spire/api/registration"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spiffe/spire/test/mock/proto/api/registration"
+	"github.com/stretchr/testify/suite"
+)
+
+type UnbanTestSuite struct {
+	suite.Suite
+	cli        *UnbanCLI
+	mockClient *mock_registration.MockRegistrationClient
+	mockCtrl   *gomock.Controller
+}
+
+func (s *UnbanTestSuite) SetupTest() {
+	s.mockCtrl = gomock.NewController(s.T())
+	s.mockClient = mock_registration.NewMockRegistrationClient(s.mockCtrl)
+	s.cli = &UnbanCLI{
+		registrationClient: s.mockClient,
+	}
+}
+
+func (s *UnbanTestSuite) TearDownTest() {
+	s.mockCtrl.Finish()
+}
+
+func TestUnbanTestSuite(t *testing.T) {
+	suite.Run(t, new(UnbanTestSuite))
+}
+
+func (s *UnbanTestSuite) TestRun() {
+	spiffeIDToUnban := "spiffe://example.org/spire/agent/join_token/token_a"
+	args := []string{"-spiffeID", spiffeIDToUnban}
+
+	req := &registration.UnbanAgentRequest{
+		SpiffeId: spiffeIDToUnban,
+	}
+
+	resp := &registration.UnbanAgentResponse{
+		Node: &common.AttestedNode{SpiffeId: spiffeIDToUnban},
+	}
+
+	s.mockClient.EXPECT().UnbanAgent(gomock.Any(), req).Return(resp, nil)
+	s.Require().Equal(0, s.cli.Run(args))
+}
+
+func (s *UnbanTestSuite) TestRunExitsWithNonZeroCodeOnError() {
+	spiffeIDToUnban := "spiffe://example.org/spire/agent/join_token/token_a"
+	args := []string{"-spiffeID", spiffeIDToUnban}
+
+	req := &registration.UnbanAgentRequest{
+		SpiffeId: spiffeIDToUnban,
+	}
+
+	s.mockClient.EXPECT().UnbanAgent(gomock.Any(), req).Return(nil, errors.New("Some error"))
+	s.Require().Equal(1, s.cli.Run(args))
+}
+
+func (s *UnbanTestSuite) TestRunExitsWithNonZeroCodeOnUnbanFailed() {
+	spiffeIDToUnban := "spiffe://example.org/spire/agent/join_token/token_a"
+	args := []string{"-spiffeID", spiffeIDToUnban}
+
+	req := &registration.UnbanAgentRequest{
+		SpiffeId: spiffeIDToUnban,
+	}
+	resp := &registration.UnbanAgentResponse{}
+
+	s.mockClient.EXPECT().UnbanAgent(gomock.Any(), req).Return(resp, nil)
+	s.Require().Equal(1, s.cli.Run(args))
+}
+
+func (s *UnbanTestSuite) TestRunValidatesSpiffeID() {
+	spiffeIDToUnban := "not//an//spiffe/id"
+	args := []string{"-spiffeID", spiffeIDToUnban}
+	s.Require().Equal(1, s.cli.Run(args))
+}
@@ -0,0 +1,61 @@
+package agent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+// StringsFlag defines a custom type for string lists. Doing
+// this allows us to support repeatable string flags.
+type StringsFlag []string
+
+func (s *StringsFlag) String() string {
+	return fmt.Sprint(*s)
+}
+
+func (s *StringsFlag) Set(val string) error {
+	*s = append(*s, val)
+	return nil
+}
+
+// parseSelector parses a CLI string from type:value into a selector type.
+// Everything to the right of the first ":" is considered a selector value.
+func parseSelector(str string) (*common.Selector, error) {
+	parts := strings.SplitN(str, ":", 2)
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("selector \"%s\" must be formatted as type:value", str)
+	}
+
+	return &common.Selector{
+		Type:  parts[0],
+		Value: parts[1],
+	}, nil
+}
+
+// parseTimeFlag parses an RFC3339 time flag into seconds since unix epoch. An
+// empty value parses to zero.
+func parseTimeFlag(name, value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid -%s time %q: expected RFC3339 format", name, value)
+	}
+	return t.Unix(), nil
+}
+
+func printAttestedNode(node *common.AttestedNode) {
+	fmt.Printf("Spiffe ID         : %s\n", node.SpiffeId)
+	fmt.Printf("Attestation type  : %s\n", node.AttestationDataType)
+	fmt.Printf("Expiration time   : %s\n", time.Unix(node.CertNotAfter, 0))
+	fmt.Printf("Serial number     : %s\n", node.CertSerialNumber)
+	fmt.Printf("Banned            : %t\n", node.Banned)
+	for _, s := range node.Selectors {
+		fmt.Printf("Selector          : %s:%s\n", s.Type, s.Value)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,51 @@
+package ca
+
+import (
+	"context"
+	"flag"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+)
+
+// NewActivateCommand creates a new "activate" subcommand for "ca" command.
+func NewActivateCommand() cli.Command {
+	return newActivateCommand(defaultEnv, newClients)
+}
+
+func newActivateCommand(env *env, clientsMaker clientsMaker) cli.Command {
+	return adaptCommand(env, clientsMaker, new(activateCommand))
+}
+
+type activateCommand struct {
+	// Authority type (x509 or jwt)
+	kind string
+}
+
+func (c *activateCommand) name() string {
+	return "ca activate"
+}
+
+func (c *activateCommand) synopsis() string {
+	return "Activates the authority prepared in the next slot"
+}
+
+func (c *activateCommand) appendFlags(fs *flag.FlagSet) {
+	appendKindFlag(fs, &c.kind)
+}
+
+func (c *activateCommand) run(ctx context.Context, env *env, clients *clients) error {
+	kind, err := kindFromFlag(c.kind)
+	if err != nil {
+		return err
+	}
+
+	resp, err := clients.r.ActivateCA(ctx, &registration.ActivateCARequest{
+		Kind: kind,
+	})
+	if err != nil {
+		return err
+	}
+
+	return printSlot(env, resp.Slot)
+}
@@ -0,0 +1,140 @@
+package ca
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestList(t *testing.T) {
+	r := &fakeRegistrationClient{
+		listResp: &registration.ListCASlotsResponse{
+			X509Slots: []*registration.CASlot{
+				{SlotId: "A", State: registration.CASlot_ACTIVE, AuthorityId: "0102", IssuedAt: 1000, ExpiresAt: 2000},
+				{SlotId: "B", State: registration.CASlot_EMPTY},
+			},
+			JwtSlots: []*registration.CASlot{
+				{SlotId: "A", State: registration.CASlot_ACTIVE, AuthorityId: "KID1", IssuedAt: 1000, ExpiresAt: 2000},
+				{SlotId: "B", State: registration.CASlot_PREPARED, AuthorityId: "KID2", IssuedAt: 1500, ExpiresAt: 2500},
+			},
+		},
+	}
+	stdout, stderr, rc := runCommand(newListCommand, r)
+	require.Equal(t, 0, rc, stderr)
+	require.Equal(t, `X509 CA:
+Slot A: active
+  Authority ID: 0102
+  Issued at:    1970-01-01T00:16:40Z
+  Expires at:   1970-01-01T00:33:20Z
+Slot B: empty
+
+JWT key:
+Slot A: active
+  Authority ID: KID1
+  Issued at:    1970-01-01T00:16:40Z
+  Expires at:   1970-01-01T00:33:20Z
+Slot B: prepared
+  Authority ID: KID2
+  Issued at:    1970-01-01T00:25:00Z
+  Expires at:   1970-01-01T00:41:40Z
+`, stdout)
+}
+
+func TestPrepare(t *testing.T) {
+	r := &fakeRegistrationClient{
+		slot: &registration.CASlot{SlotId: "B", State: registration.CASlot_PREPARED, AuthorityId: "KID2", IssuedAt: 1500, ExpiresAt: 2500},
+	}
+	stdout, stderr, rc := runCommand(newPrepareCommand, r, "-type", "jwt")
+	require.Equal(t, 0, rc, stderr)
+	require.Equal(t, registration.CAKind_JWT_KEY, r.kind)
+	require.Equal(t, `Slot B: prepared
+  Authority ID: KID2
+  Issued at:    1970-01-01T00:25:00Z
+  Expires at:   1970-01-01T00:41:40Z
+`, stdout)
+}
+
+func TestActivate(t *testing.T) {
+	r := &fakeRegistrationClient{
+		err: status.Error(codes.FailedPrecondition, "no prepared X509 CA to activate"),
+	}
+	_, stderr, rc := runCommand(newActivateCommand, r)
+	require.Equal(t, 1, rc)
+	require.Equal(t, registration.CAKind_X509_CA, r.kind)
+	require.Equal(t, "rpc error: code = FailedPrecondition desc = no prepared X509 CA to activate\n", stderr)
+}
+
+func TestTaint(t *testing.T) {
+	r := &fakeRegistrationClient{}
+
+	_, stderr, rc := runCommand(newTaintCommand, r)
+	require.Equal(t, 1, rc)
+	require.Equal(t, "authorityID is required\n", stderr)
+
+	_, stderr, rc = runCommand(newTaintCommand, r, "-type", "foo", "-authorityID", "0102")
+	require.Equal(t, 1, rc)
+	require.Equal(t, "unsupported type \"foo\"\n", stderr)
+
+	stdout, stderr, rc := runCommand(newTaintCommand, r, "-authorityID", "0102")
+	require.Equal(t, 0, rc, stderr)
+	require.Equal(t, registration.CAKind_X509_CA, r.kind)
+	require.Equal(t, "0102", r.authorityID)
+	require.Equal(t, "authority tainted.\n", stdout)
+}
+
+func runCommand(newCommand func(*env, clientsMaker) cli.Command, r registration.RegistrationClient, args ...string) (string, string, int) {
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	cmd := newCommand(&env{stdout: stdout, stderr: stderr}, func(string) (*clients, error) {
+		return &clients{r: r}, nil
+	})
+	rc := cmd.Run(args)
+	return stdout.String(), stderr.String(), rc
+}
+
+type fakeRegistrationClient struct {
+	registration.RegistrationClient
+
+	listResp *registration.ListCASlotsResponse
+	slot     *registration.CASlot
+	err      error
+
+	kind        registration.CAKind
+	authorityID string
+}
+
+func (c *fakeRegistrationClient) ListCASlots(ctx context.Context, in *registration.ListCASlotsRequest, opts ...grpc.CallOption) (*registration.ListCASlotsResponse, error) {
+	return c.listResp, c.err
+}
+
+func (c *fakeRegistrationClient) PrepareCA(ctx context.Context, in *registration.PrepareCARequest, opts ...grpc.CallOption) (*registration.PrepareCAResponse, error) {
+	c.kind = in.Kind
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &registration.PrepareCAResponse{Slot: c.slot}, nil
+}
+
+func (c *fakeRegistrationClient) ActivateCA(ctx context.Context, in *registration.ActivateCARequest, opts ...grpc.CallOption) (*registration.ActivateCAResponse, error) {
+	c.kind = in.Kind
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &registration.ActivateCAResponse{Slot: c.slot}, nil
+}
+
+func (c *fakeRegistrationClient) TaintCA(ctx context.Context, in *registration.TaintCARequest, opts ...grpc.CallOption) (*registration.TaintCAResponse, error) {
+	c.kind = in.Kind
+	c.authorityID = in.AuthorityId
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &registration.TaintCAResponse{}, nil
+}
@@ -0,0 +1,57 @@
+package ca
+
+import (
+	"context"
+	"flag"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+)
+
+// NewListCommand creates a new "list" subcommand for "ca" command.
+func NewListCommand() cli.Command {
+	return newListCommand(defaultEnv, newClients)
+}
+
+func newListCommand(env *env, clientsMaker clientsMaker) cli.Command {
+	return adaptCommand(env, clientsMaker, new(listCommand))
+}
+
+type listCommand struct{}
+
+func (c *listCommand) name() string {
+	return "ca list"
+}
+
+func (c *listCommand) synopsis() string {
+	return "Lists the current and next X509 CA and JWT key slots"
+}
+
+func (c *listCommand) appendFlags(fs *flag.FlagSet) {
+}
+
+func (c *listCommand) run(ctx context.Context, env *env, clients *clients) error {
+	resp, err := clients.r.ListCASlots(ctx, &registration.ListCASlotsRequest{})
+	if err != nil {
+		return err
+	}
+
+	if err := env.Println("X509 CA:"); err != nil {
+		return err
+	}
+	for _, slot := range resp.X509Slots {
+		if err := printSlot(env, slot); err != nil {
+			return err
+		}
+	}
+
+	if err := env.Println("\nJWT key:"); err != nil {
+		return err
+	}
+	for _, slot := range resp.JwtSlots {
+		if err := printSlot(env, slot); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package ca
+
+import (
+	"context"
+	"flag"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+)
+
+// NewPrepareCommand creates a new "prepare" subcommand for "ca" command.
+func NewPrepareCommand() cli.Command {
+	return newPrepareCommand(defaultEnv, newClients)
+}
+
+func newPrepareCommand(env *env, clientsMaker clientsMaker) cli.Command {
+	return adaptCommand(env, clientsMaker, new(prepareCommand))
+}
+
+type prepareCommand struct {
+	// Authority type (x509 or jwt)
+	kind string
+}
+
+func (c *prepareCommand) name() string {
+	return "ca prepare"
+}
+
+func (c *prepareCommand) synopsis() string {
+	return "Prepares a new authority in the next slot"
+}
+
+func (c *prepareCommand) appendFlags(fs *flag.FlagSet) {
+	appendKindFlag(fs, &c.kind)
+}
+
+func (c *prepareCommand) run(ctx context.Context, env *env, clients *clients) error {
+	kind, err := kindFromFlag(c.kind)
+	if err != nil {
+		return err
+	}
+
+	resp, err := clients.r.PrepareCA(ctx, &registration.PrepareCARequest{
+		Kind: kind,
+	})
+	if err != nil {
+		return err
+	}
+
+	return printSlot(env, resp.Slot)
+}
@@ -0,0 +1,60 @@
+package ca
+
+import (
+	"context"
+	"errors"
+	"flag"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+)
+
+// NewTaintCommand creates a new "taint" subcommand for "ca" command.
+func NewTaintCommand() cli.Command {
+	return newTaintCommand(defaultEnv, newClients)
+}
+
+func newTaintCommand(env *env, clientsMaker clientsMaker) cli.Command {
+	return adaptCommand(env, clientsMaker, new(taintCommand))
+}
+
+type taintCommand struct {
+	// Authority type (x509 or jwt)
+	kind string
+
+	// ID of the authority to taint
+	authorityID string
+}
+
+func (c *taintCommand) name() string {
+	return "ca taint"
+}
+
+func (c *taintCommand) synopsis() string {
+	return "Taints an old authority so that SVIDs signed by it are rotated"
+}
+
+func (c *taintCommand) appendFlags(fs *flag.FlagSet) {
+	appendKindFlag(fs, &c.kind)
+	fs.StringVar(&c.authorityID, "authorityID", "", "ID of the authority to taint (see \"ca list\" output and the bundle)")
+}
+
+func (c *taintCommand) run(ctx context.Context, env *env, clients *clients) error {
+	if c.authorityID == "" {
+		return errors.New("authorityID is required")
+	}
+
+	kind, err := kindFromFlag(c.kind)
+	if err != nil {
+		return err
+	}
+
+	if _, err := clients.r.TaintCA(ctx, &registration.TaintCARequest{
+		Kind:        kind,
+		AuthorityId: c.authorityID,
+	}); err != nil {
+		return err
+	}
+
+	return env.Println("authority tainted.")
+}
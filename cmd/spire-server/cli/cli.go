@@ -6,10 +6,14 @@ import (
 	"github.com/mitchellh/cli"
 	"github.com/spiffe/spire/cmd/spire-server/cli/agent"
 	"github.com/spiffe/spire/cmd/spire-server/cli/bundle"
+	"github.com/spiffe/spire/cmd/spire-server/cli/ca"
 	"github.com/spiffe/spire/cmd/spire-server/cli/entry"
 	"github.com/spiffe/spire/cmd/spire-server/cli/healthcheck"
+	"github.com/spiffe/spire/cmd/spire-server/cli/jwt"
 	"github.com/spiffe/spire/cmd/spire-server/cli/run"
+	"github.com/spiffe/spire/cmd/spire-server/cli/svid"
 	"github.com/spiffe/spire/cmd/spire-server/cli/token"
+	"github.com/spiffe/spire/cmd/spire-server/cli/x509"
 	"github.com/spiffe/spire/pkg/common/version"
 )
 
@@ -17,12 +21,21 @@ func Run(args []string) int {
 	c := cli.NewCLI("spire-server", version.Version())
 	c.Args = args
 	c.Commands = map[string]cli.CommandFactory{
+		"agent ban": func() (cli.Command, error) {
+			return &agent.BanCLI{}, nil
+		},
 		"agent evict": func() (cli.Command, error) {
 			return &agent.EvictCLI{}, nil
 		},
 		"agent list": func() (cli.Command, error) {
 			return &agent.ListCLI{}, nil
 		},
+		"agent show": func() (cli.Command, error) {
+			return &agent.ShowCLI{}, nil
+		},
+		"agent unban": func() (cli.Command, error) {
+			return &agent.UnbanCLI{}, nil
+		},
 		"bundle show": func() (cli.Command, error) {
 			return bundle.NewShowCommand(), nil
 		},
@@ -35,6 +48,18 @@ func Run(args []string) int {
 		"bundle delete": func() (cli.Command, error) {
 			return bundle.NewDeleteCommand(), nil
 		},
+		"ca list": func() (cli.Command, error) {
+			return ca.NewListCommand(), nil
+		},
+		"ca prepare": func() (cli.Command, error) {
+			return ca.NewPrepareCommand(), nil
+		},
+		"ca activate": func() (cli.Command, error) {
+			return ca.NewActivateCommand(), nil
+		},
+		"ca taint": func() (cli.Command, error) {
+			return ca.NewTaintCommand(), nil
+		},
 		"experimental bundle show": func() (cli.Command, error) {
 			return bundle.NewExperimentalShowCommand(), nil
 		},
@@ -56,12 +81,27 @@ func Run(args []string) int {
 		"entry show": func() (cli.Command, error) {
 			return &entry.ShowCLI{}, nil
 		},
+		"jwt mint": func() (cli.Command, error) {
+			return jwt.NewMintCommand(), nil
+		},
 		"run": func() (cli.Command, error) {
 			return &run.RunCLI{}, nil
 		},
+		"svid history": func() (cli.Command, error) {
+			return svid.NewHistoryCommand(), nil
+		},
 		"token generate": func() (cli.Command, error) {
 			return &token.GenerateCLI{}, nil
 		},
+		"token list": func() (cli.Command, error) {
+			return &token.ListCLI{}, nil
+		},
+		"token revoke": func() (cli.Command, error) {
+			return &token.RevokeCLI{}, nil
+		},
+		"x509 mint": func() (cli.Command, error) {
+			return x509.NewMintCommand(), nil
+		},
 		"healthcheck": func() (cli.Command, error) {
 			return healthcheck.NewHealthCheckCommand(), nil
 		},
@@ -13,6 +13,7 @@ import (
 	"github.com/spiffe/spire/proto/spire/common"
 
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
 )
 
 type CreateConfig struct {
@@ -48,6 +49,15 @@ type CreateConfig struct {
 
 	// DNSNames entries for SVIDs based on this entry
 	DNSNames StringsFlag
+
+	// TTL of JWT-SVIDs based on this entry
+	JWTSVIDTTL int
+
+	// Claims added to JWT-SVIDs based on this entry, formatted as name=value
+	JWTSVIDClaims StringsFlag
+
+	// Path to a JSON file with the X509-SVID template of this entry
+	X509SVIDTemplate string
 }
 
 // Validate performs basic validation, even on fields that we
@@ -82,6 +92,10 @@ func (rc *CreateConfig) Validate() (err error) {
 		return errors.New("a TTL is required")
 	}
 
+	if rc.JWTSVIDTTL < 0 {
+		return errors.New("the JWT-SVID TTL cannot be negative")
+	}
+
 	// make sure all SPIFFE ID's are well formed
 	rc.SpiffeID, err = idutil.NormalizeSpiffeID(rc.SpiffeID, idutil.AllowAny())
 	if err != nil {
@@ -147,7 +161,13 @@ func (c CreateCLI) Run(args []string) int {
 		return 1
 	}
 
-	err = c.registerEntries(ctx, cl, entries)
+	// Entries from a data file are created in a single transaction, so a
+	// failure doesn't leave the file half applied
+	if config.Path != "" {
+		err = c.batchRegisterEntries(ctx, cl, entries)
+	} else {
+		err = c.registerEntries(ctx, cl, entries)
+	}
 	if err != nil {
 		fmt.Println(err.Error())
 		return 1
@@ -165,8 +185,21 @@ func (c CreateCLI) parseConfig(config *CreateConfig) ([]*common.RegistrationEntr
 		Downstream:  config.Downstream,
 		EntryExpiry: config.EntryExpiry,
 		DnsNames:    config.DNSNames,
+		JwtSvidTtl:  int32(config.JWTSVIDTTL),
 	}
 
+	claims, err := parseJWTSVIDClaims(config.JWTSVIDClaims)
+	if err != nil {
+		return nil, err
+	}
+	e.JwtSvidClaims = claims
+
+	template, err := parseX509SVIDTemplate(config.X509SVIDTemplate)
+	if err != nil {
+		return nil, err
+	}
+	e.X509SvidTemplate = template
+
 	// If the node flag is set, then set the Parent ID to the server's expected SPIFFE ID
 	if config.Node {
 		id, err := idutil.ParseSpiffeID(e.SpiffeId, idutil.AllowAny())
@@ -224,6 +257,40 @@ func (CreateCLI) registerEntries(ctx context.Context, c registration.Registratio
 	return nil
 }
 
+// batchRegisterEntries creates all of the entries or none of them
+func (CreateCLI) batchRegisterEntries(ctx context.Context, c registration.RegistrationClient, entries []*common.RegistrationEntry) error {
+	resp, err := c.BatchCreateEntry(ctx, &registration.BatchCreateEntryRequest{
+		Entries:      entries,
+		AllOrNothing: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for i, result := range resp.Results {
+		switch codes.Code(result.Code) {
+		case codes.OK:
+		case codes.Aborted:
+			// the entry was fine, but another one in the batch was not
+			continue
+		default:
+			failed++
+			fmt.Printf("FAILED to create the following entry: %s\n", result.Message)
+			printEntry(entries[i])
+		}
+	}
+	if failed > 0 {
+		msg := fmt.Sprintf("No entries were created: %d ", failed)
+		return errors.New(util.Pluralizer(msg, "entry", "entries", failed) + " failed")
+	}
+
+	for _, result := range resp.Results {
+		printEntry(result.Entry)
+	}
+	return nil
+}
+
 func (CreateCLI) newConfig(args []string) (*CreateConfig, error) {
 	f := flag.NewFlagSet("entry create", flag.ContinueOnError)
 	c := &CreateConfig{}
@@ -245,5 +312,9 @@ func (CreateCLI) newConfig(args []string) (*CreateConfig, error) {
 
 	f.Var(&c.DNSNames, "dns", "A DNS name that will be included in SVIDs issued based on this entry, where appropriate. Can be used more than once")
 
+	f.IntVar(&c.JWTSVIDTTL, "jwtSVIDTTL", 0, "The lifetime, in seconds, for JWT-SVIDs issued based on this registration entry. If unset, the server default is used")
+	f.Var(&c.JWTSVIDClaims, "jwtClaim", "A name=value claim that will be included in JWT-SVIDs issued based on this entry. Can be used more than once")
+	f.StringVar(&c.X509SVIDTemplate, "x509SVIDTemplate", "", "Path to a JSON file with the X509-SVID template of this entry (optional)")
+
 	return c, f.Parse(args)
 }
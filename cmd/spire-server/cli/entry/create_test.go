@@ -1,13 +1,18 @@
 package entry
 
 import (
+	"context"
 	"path"
 	"testing"
 
+	"github.com/golang/mock/gomock"
+	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
+	mock_registration "github.com/spiffe/spire/test/mock/proto/api/registration"
 	"github.com/spiffe/spire/test/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
 
 	cmdutil "github.com/spiffe/spire/cmd/spire-server/util"
 )
@@ -35,6 +40,10 @@ func TestCreateCLI(t *testing.T) {
 		"-dns", "ung1000",
 		"-dns", "aa2000",
 		"-dns", "zz2000",
+		"-jwtSVIDTTL", "300",
+		"-jwtClaim", "tenant=acme",
+		"-jwtClaim", "environment=prod",
+		"-x509SVIDTemplate", "template.json",
 	})
 	require.NoError(t, err)
 
@@ -48,6 +57,9 @@ func TestCreateCLI(t *testing.T) {
 		Admin:               true,
 		EntryExpiry:         1552410266,
 		DNSNames:            StringsFlag{"unu1000", "ung1000", "aa2000", "zz2000"},
+		JWTSVIDTTL:          300,
+		JWTSVIDClaims:       StringsFlag{"tenant=acme", "environment=prod"},
+		X509SVIDTemplate:    "template.json",
 	}
 
 	assert.Equal(t, createdConfig, c)
@@ -63,6 +75,9 @@ func TestCreateParseConfig(t *testing.T) {
 		FederatesWith:       StringsFlag{"spiffe://domain1.test", "spiffe://domain2.test"},
 		Admin:               true,
 		EntryExpiry:         1552410266,
+		JWTSVIDTTL:          300,
+		JWTSVIDClaims:       StringsFlag{"tenant=acme", "environment=prod"},
+		X509SVIDTemplate:    path.Join(util.ProjectRoot(), "test/fixture/registration/x509_svid_template.json"),
 	}
 
 	entries, err := CreateCLI{}.parseConfig(c)
@@ -82,6 +97,19 @@ func TestCreateParseConfig(t *testing.T) {
 		},
 		Admin:       true,
 		EntryExpiry: 1552410266,
+		JwtSvidTtl:  300,
+		JwtSvidClaims: map[string]string{
+			"tenant":      "acme",
+			"environment": "prod",
+		},
+		X509SvidTemplate: &common.X509SVIDTemplate{
+			Subject: &common.X509Subject{
+				Organization:       []string{"ACME"},
+				OrganizationalUnit: []string{"gateway"},
+			},
+			ExtraKeyUsages:    []string{"data_encipherment"},
+			ExtraExtKeyUsages: []string{"code_signing"},
+		},
 	}
 
 	expectedEntries := []*common.RegistrationEntry{expectedEntry}
@@ -150,6 +178,39 @@ func TestRegisterParseFile(t *testing.T) {
 	assert.Equal(t, expectedEntries, entries)
 }
 
+func TestBatchRegisterEntries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := mock_registration.NewMockRegistrationClient(ctrl)
+	entries := []*common.RegistrationEntry{
+		{SpiffeId: "spiffe://example.org/foo"},
+		{SpiffeId: "spiffe://example.org/bar"},
+	}
+	req := &registration.BatchCreateEntryRequest{
+		Entries:      entries,
+		AllOrNothing: true,
+	}
+
+	client.EXPECT().BatchCreateEntry(gomock.Any(), req).Return(&registration.BatchCreateEntryResponse{
+		Results: []*registration.BatchEntryResult{
+			{Entry: &common.RegistrationEntry{EntryId: "1", SpiffeId: "spiffe://example.org/foo"}},
+			{Entry: &common.RegistrationEntry{EntryId: "2", SpiffeId: "spiffe://example.org/bar"}},
+		},
+	}, nil)
+	err := CreateCLI{}.batchRegisterEntries(context.Background(), client, entries)
+	require.NoError(t, err)
+
+	client.EXPECT().BatchCreateEntry(gomock.Any(), req).Return(&registration.BatchCreateEntryResponse{
+		Results: []*registration.BatchEntryResult{
+			{Code: int32(codes.Aborted), Message: "batch aborted because another operation failed"},
+			{Code: int32(codes.AlreadyExists), Message: "entry already exists"},
+		},
+	}, nil)
+	err = CreateCLI{}.batchRegisterEntries(context.Background(), client, entries)
+	require.EqualError(t, err, "No entries were created: 1 entry failed")
+}
+
 func TestRegisterParseSelector(t *testing.T) {
 	str := "unix:uid:1000"
 	s, err := parseSelector(str)
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"strings"
 
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/spiffe/spire/cmd/spire-server/util"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	commonutil "github.com/spiffe/spire/pkg/common/util"
@@ -12,8 +14,22 @@ import (
 	"github.com/spiffe/spire/proto/spire/common"
 
 	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+const (
+	// listEntriesPageSize is how many entries are requested at a time when
+	// listing entries
+	listEntriesPageSize = 1000
+)
+
+var selectorMatches = map[string]registration.ListEntriesRequest_SelectorMatch{
+	"superset": registration.ListEntriesRequest_SUPERSET,
+	"subset":   registration.ListEntriesRequest_SUBSET,
+	"exact":    registration.ListEntriesRequest_EXACT,
+}
+
 // ShowConfig is a configuration struct for the
 // `spire-server entry show` CLI command
 type ShowConfig struct {
@@ -24,23 +40,33 @@ type ShowConfig struct {
 	// ex. "unix:uid:1000" or "spiffe_id:spiffe://example.org/foo"
 	Selectors StringsFlag
 
-	EntryID  string
-	ParentID string
-	SpiffeID string
+	// How entries are matched against the selectors (superset, subset or
+	// exact)
+	MatchSelectorsOn string
+
+	EntryID        string
+	ParentID       string
+	SpiffeID       string
+	SpiffeIDPrefix string
 
 	FederatesWith StringsFlag
 	Downstream    bool
+	Admin         bool
 }
 
 // Validate ensures that the values in ShowConfig are valid
 func (sc *ShowConfig) Validate() error {
 	// If entryID is given, it should be the only constraint
 	if sc.EntryID != "" {
-		if sc.ParentID != "" || sc.SpiffeID != "" || len(sc.Selectors) > 0 {
+		if sc.ParentID != "" || sc.SpiffeID != "" || sc.SpiffeIDPrefix != "" || len(sc.Selectors) > 0 {
 			return errors.New("The -entryID flag can't be combined with others")
 		}
 	}
 
+	if _, ok := selectorMatches[sc.MatchSelectorsOn]; !ok {
+		return fmt.Errorf("unsupported selector match %q", sc.MatchSelectorsOn)
+	}
+
 	return nil
 }
 
@@ -89,7 +115,6 @@ func (s *ShowCLI) Run(args []string) int {
 	}
 
 	commonutil.SortRegistrationEntries(s.Entries)
-	s.filterEntries()
 	s.printEntries()
 	return 0
 }
@@ -105,59 +130,113 @@ func (s *ShowCLI) fetchEntries(ctx context.Context) error {
 		return nil
 	}
 
-	// If we didn't get any args, fetch everything
-	if s.Config.ParentID == "" && s.Config.SpiffeID == "" && len(s.Config.Selectors) == 0 {
-		err := s.fetchAllEntries(ctx)
-		if err != nil {
-			fmt.Printf("Error fetching entries: %s\n", err)
-			return err
-		}
-
-		return nil
-	}
-
-	// Otherwise, fetch all records matching each constraint
-	err := s.fetchByParentID(ctx)
+	// Otherwise, list the records matching all of the constraints
+	err := s.listEntries(ctx)
 	if err != nil {
-		fmt.Printf("Error fetching by parent ID: %s", err)
+		fmt.Printf("Error fetching entries: %s\n", err)
 		return err
 	}
 
-	err = s.fetchBySpiffeID(ctx)
-	if err != nil {
-		fmt.Printf("Error fetching by SPIFFE ID: %s", err)
-		return err
-	}
+	return nil
+}
 
-	err = s.fetchBySelectors(ctx)
+// fetchByEntryID uses the configured EntryID to fetch the appropriate registration entry
+func (s *ShowCLI) fetchByEntryID(ctx context.Context, id string) error {
+	regID := &registration.RegistrationEntryID{Id: id}
+	entry, err := s.Client.FetchEntry(ctx, regID)
 	if err != nil {
-		fmt.Printf("Error fetching by selectors: %s", err)
 		return err
 	}
 
+	s.Entries = []*common.RegistrationEntry{entry}
 	return nil
 }
 
-func (s *ShowCLI) fetchAllEntries(ctx context.Context) error {
-	var err error
-	entries, err := s.Client.FetchEntries(ctx, &common.Empty{})
-	if err != nil {
-		return err
+// listEntries pages through the registration entries matching all of the
+// configured constraints, appending them to `entries`
+func (s *ShowCLI) listEntries(ctx context.Context) error {
+	req := &registration.ListEntriesRequest{
+		ParentId:       s.Config.ParentID,
+		SpiffeId:       s.Config.SpiffeID,
+		SpiffeIdPrefix: s.Config.SpiffeIDPrefix,
+		SelectorMatch:  selectorMatches[s.Config.MatchSelectorsOn],
+		FederatesWith:  s.Config.FederatesWith,
+		PageSize:       listEntriesPageSize,
 	}
 
-	s.Entries = entries.Entries
-	return nil
+	for _, sel := range s.Config.Selectors {
+		selector, err := parseSelector(sel)
+		if err != nil {
+			return err
+		}
+		req.Selectors = append(req.Selectors, selector)
+	}
+
+	// The boolean flags can only ask for entries that have them set
+	if s.Config.Downstream {
+		req.Downstream = &wrappers.BoolValue{Value: true}
+	}
+	if s.Config.Admin {
+		req.Admin = &wrappers.BoolValue{Value: true}
+	}
+
+	for {
+		resp, err := s.Client.ListEntries(ctx, req)
+		if status.Code(err) == codes.Unimplemented && req.PageToken == "" {
+			// Servers predating ListEntries can still be queried with the
+			// older RPCs, filtering the results here
+			return s.fetchEntriesLegacy(ctx)
+		}
+		if err != nil {
+			return err
+		}
+
+		s.Entries = append(s.Entries, resp.Entries...)
+		if resp.NextPageToken == "" {
+			return nil
+		}
+		req.PageToken = resp.NextPageToken
+	}
 }
 
-// fetchByEntryID uses the configured EntryID to fetch the appropriate registration entry
-func (s *ShowCLI) fetchByEntryID(ctx context.Context, id string) error {
-	regID := &registration.RegistrationEntryID{Id: id}
-	entry, err := s.Client.FetchEntry(ctx, regID)
+// fetchEntriesLegacy fetches the registration entries matching any of the
+// constraints using the RPCs available before ListEntries, then filters out
+// the entries not matching all of them.
+func (s *ShowCLI) fetchEntriesLegacy(ctx context.Context) error {
+	// If we didn't get any args, fetch everything
+	if s.Config.ParentID == "" && s.Config.SpiffeID == "" && len(s.Config.Selectors) == 0 {
+		err := s.fetchAllEntries(ctx)
+		if err != nil {
+			return err
+		}
+	} else {
+		// Otherwise, fetch all records matching each constraint
+		err := s.fetchByParentID(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = s.fetchBySpiffeID(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = s.fetchBySelectors(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.filterEntries()
+}
+
+func (s *ShowCLI) fetchAllEntries(ctx context.Context) error {
+	entries, err := s.Client.FetchEntries(ctx, &common.Empty{})
 	if err != nil {
 		return err
 	}
 
-	s.Entries = []*common.RegistrationEntry{entry}
+	s.Entries = entries.Entries
 	return nil
 }
 
@@ -193,8 +272,8 @@ func (s *ShowCLI) fetchBySpiffeID(ctx context.Context) error {
 	return nil
 }
 
-// fetchBySelectors fetches all registration entries containing the full
-// set of configured selectors, appending them to `entries`
+// fetchBySelectors fetches all registration entries containing any of the
+// configured selectors, appending them to `entries`
 func (s *ShowCLI) fetchBySelectors(ctx context.Context) error {
 	for _, sel := range s.Config.Selectors {
 		selector, err := parseSelector(sel)
@@ -214,8 +293,8 @@ func (s *ShowCLI) fetchBySelectors(ctx context.Context) error {
 }
 
 // filterEntries evicts any entries from the stored slice which
-// do not match every selector specified by the user
-func (s *ShowCLI) filterEntries() {
+// do not match every constraint specified by the user
+func (s *ShowCLI) filterEntries() error {
 	newSlice := []*common.RegistrationEntry{}
 	// Map used to skip duplicated entries.
 	matchingEntries := map[string]*common.RegistrationEntry{}
@@ -229,9 +308,14 @@ func (s *ShowCLI) filterEntries() {
 	}
 
 	for _, e := range s.Entries {
-		match, _ := hasSelectors(e, s.Config.Selectors)
-		if !match {
-			continue
+		if len(s.Config.Selectors) > 0 {
+			match, err := matchSelectors(e, s.Config.Selectors, s.Config.MatchSelectorsOn)
+			if err != nil {
+				return err
+			}
+			if !match {
+				continue
+			}
 		}
 
 		// If SpiffeID was specified, discard entries that don't match.
@@ -239,11 +323,22 @@ func (s *ShowCLI) filterEntries() {
 			continue
 		}
 
+		// If SpiffeIDPrefix was specified, discard entries that don't match.
+		if !strings.HasPrefix(e.SpiffeId, s.Config.SpiffeIDPrefix) {
+			continue
+		}
+
 		// If ParentID was specified, discard entries that don't match.
 		if s.Config.ParentID != "" && e.ParentId != s.Config.ParentID {
 			continue
 		}
 
+		// If Downstream or Admin were specified, discard entries that
+		// don't have them set.
+		if (s.Config.Downstream && !e.Downstream) || (s.Config.Admin && !e.Admin) {
+			continue
+		}
+
 		// If FederatesWith was specified, discard entries that don't match
 		if federatedIDs != nil {
 			found := false
@@ -266,6 +361,7 @@ func (s *ShowCLI) filterEntries() {
 	}
 
 	s.Entries = newSlice
+	return nil
 }
 
 func (s *ShowCLI) printEntries() {
@@ -286,7 +382,10 @@ func (s *ShowCLI) loadConfig(args []string) error {
 	f.StringVar(&c.EntryID, "entryID", "", "The Entry ID of the records to show")
 	f.StringVar(&c.ParentID, "parentID", "", "The Parent ID of the records to show")
 	f.StringVar(&c.SpiffeID, "spiffeID", "", "The SPIFFE ID of the records to show")
-	f.BoolVar(&c.Downstream, "downstream", false, "A boolean value that, when set, indicates that the entry describes a downstream SPIRE server")
+	f.StringVar(&c.SpiffeIDPrefix, "spiffeIDPrefix", "", "A prefix the SPIFFE ID of the records to show starts with")
+	f.StringVar(&c.MatchSelectorsOn, "matchSelectorsOn", "superset", "The match mode used when filtering by selectors. Options: exact, subset and superset")
+	f.BoolVar(&c.Downstream, "downstream", false, "A boolean value that, when set, only shows entries describing a downstream SPIRE server")
+	f.BoolVar(&c.Admin, "admin", false, "A boolean value that, when set, only shows admin entries")
 
 	f.Var(&c.Selectors, "selector", "A colon-delimited type:value selector. Can be used more than once")
 	f.Var(&c.FederatesWith, "federatesWith", "SPIFFE ID of a trust domain an entry is federate with. Can be used more than once")
@@ -309,6 +408,10 @@ func (s *ShowCLI) loadConfig(args []string) error {
 		}
 	}
 
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
 	s.Config = c
 	return nil
 }
@@ -1,14 +1,18 @@
 package entry
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
 	mock_registration "github.com/spiffe/spire/test/mock/proto/api/registration"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type ShowTestSuite struct {
@@ -52,6 +56,32 @@ func (s *ShowTestSuite) TestRunWithEntryID() {
 	s.Assert().Equal(s.registrationEntries(1), s.cli.Entries)
 }
 
+func (s *ShowTestSuite) TestRunWithEntryIDAndOtherFlags() {
+	args := []string{
+		"-entryID",
+		"123456",
+		"-parentID",
+		"spiffe://example.org/father",
+	}
+
+	s.Require().Equal(1, s.cli.Run(args))
+}
+
+func (s *ShowTestSuite) TestRunWithNoFlags() {
+	entries := s.registrationEntries(4)
+
+	req := &registration.ListEntriesRequest{
+		PageSize: listEntriesPageSize,
+	}
+	resp := &registration.ListEntriesResponse{Entries: entries}
+	s.mockClient.EXPECT().ListEntries(gomock.Any(), req).Return(resp, nil)
+
+	s.Require().Equal(0, s.cli.Run(nil))
+
+	util.SortRegistrationEntries(entries)
+	s.Assert().Equal(entries, s.cli.Entries)
+}
+
 func (s *ShowTestSuite) TestRunWithParentID() {
 	entries := s.registrationEntries(2)
 
@@ -60,9 +90,12 @@ func (s *ShowTestSuite) TestRunWithParentID() {
 		entries[0].ParentId,
 	}
 
-	req := &registration.ParentID{Id: entries[0].ParentId}
-	resp := &common.RegistrationEntries{Entries: entries}
-	s.mockClient.EXPECT().ListByParentID(gomock.Any(), req).Return(resp, nil)
+	req := &registration.ListEntriesRequest{
+		ParentId: entries[0].ParentId,
+		PageSize: listEntriesPageSize,
+	}
+	resp := &registration.ListEntriesResponse{Entries: entries}
+	s.mockClient.EXPECT().ListEntries(gomock.Any(), req).Return(resp, nil)
 
 	s.Require().Equal(0, s.cli.Run(args))
 
@@ -79,33 +112,205 @@ func (s *ShowTestSuite) TestRunWithSpiffeID() {
 		entry.SpiffeId,
 	}
 
-	req := &registration.SpiffeID{Id: entry.SpiffeId}
-	resp := &common.RegistrationEntries{Entries: entries}
-	s.mockClient.EXPECT().ListBySpiffeID(gomock.Any(), req).Return(resp, nil)
+	req := &registration.ListEntriesRequest{
+		SpiffeId: entry.SpiffeId,
+		PageSize: listEntriesPageSize,
+	}
+	resp := &registration.ListEntriesResponse{Entries: entries}
+	s.mockClient.EXPECT().ListEntries(gomock.Any(), req).Return(resp, nil)
 
 	s.Require().Equal(0, s.cli.Run(args))
 	s.Assert().Equal(entries, s.cli.Entries)
 }
 
-func (s *ShowTestSuite) TestRunWithSelector() {
+func (s *ShowTestSuite) TestRunWithSpiffeIDPrefix() {
+	entries := s.registrationEntries(4)[1:3]
+
+	args := []string{
+		"-spiffeIDPrefix",
+		"spiffe://example.org/d",
+	}
+
+	req := &registration.ListEntriesRequest{
+		SpiffeIdPrefix: "spiffe://example.org/d",
+		PageSize:       listEntriesPageSize,
+	}
+	resp := &registration.ListEntriesResponse{Entries: entries}
+	s.mockClient.EXPECT().ListEntries(gomock.Any(), req).Return(resp, nil)
+
+	s.Require().Equal(0, s.cli.Run(args))
+
+	util.SortRegistrationEntries(entries)
+	s.Assert().Equal(entries, s.cli.Entries)
+}
+
+func (s *ShowTestSuite) TestRunWithSelectors() {
 	entries := s.registrationEntries(2)
 
 	args := []string{
 		"-selector",
 		"foo:bar",
+		"-selector",
+		"bar:baz",
 	}
 
-	req := &common.Selector{Type: "foo", Value: "bar"}
-	resp := &common.RegistrationEntries{Entries: entries}
-	s.mockClient.EXPECT().ListBySelector(gomock.Any(), req).Return(resp, nil)
+	req := &registration.ListEntriesRequest{
+		Selectors: []*common.Selector{
+			{Type: "foo", Value: "bar"},
+			{Type: "bar", Value: "baz"},
+		},
+		SelectorMatch: registration.ListEntriesRequest_SUPERSET,
+		PageSize:      listEntriesPageSize,
+	}
+	resp := &registration.ListEntriesResponse{Entries: entries[1:2]}
+	s.mockClient.EXPECT().ListEntries(gomock.Any(), req).Return(resp, nil)
+
+	s.Require().Equal(0, s.cli.Run(args))
+	s.Assert().Equal(entries[1:2], s.cli.Entries)
+}
+
+func (s *ShowTestSuite) TestRunWithSelectorsMatchedExactly() {
+	entries := s.registrationEntries(1)
+
+	args := []string{
+		"-selector",
+		"foo:bar",
+		"-matchSelectorsOn",
+		"exact",
+	}
+
+	req := &registration.ListEntriesRequest{
+		Selectors:     []*common.Selector{{Type: "foo", Value: "bar"}},
+		SelectorMatch: registration.ListEntriesRequest_EXACT,
+		PageSize:      listEntriesPageSize,
+	}
+	resp := &registration.ListEntriesResponse{Entries: entries}
+	s.mockClient.EXPECT().ListEntries(gomock.Any(), req).Return(resp, nil)
+
+	s.Require().Equal(0, s.cli.Run(args))
+	s.Assert().Equal(entries, s.cli.Entries)
+}
+
+func (s *ShowTestSuite) TestRunWithUnsupportedSelectorMatch() {
+	args := []string{
+		"-selector",
+		"foo:bar",
+		"-matchSelectorsOn",
+		"any",
+	}
+
+	s.Require().Equal(1, s.cli.Run(args))
+}
+
+func (s *ShowTestSuite) TestRunWithParentIDAndSelectors() {
+	entries := s.registrationEntries(4)[2:4]
+
+	args := []string{
+		"-parentID",
+		entries[0].ParentId,
+		"-selector",
+		"bar:baz",
+	}
+
+	req := &registration.ListEntriesRequest{
+		ParentId:  entries[0].ParentId,
+		Selectors: []*common.Selector{{Type: "bar", Value: "baz"}},
+		PageSize:  listEntriesPageSize,
+	}
+	resp := &registration.ListEntriesResponse{Entries: entries[0:1]}
+	s.mockClient.EXPECT().ListEntries(gomock.Any(), req).Return(resp, nil)
 
 	s.Require().Equal(0, s.cli.Run(args))
+	s.Assert().Equal(entries[0:1], s.cli.Entries)
+}
+
+func (s *ShowTestSuite) TestRunWithFederatesWith() {
+	entries := s.registrationEntries(4)[2:3]
+
+	args := []string{
+		"-federatesWith",
+		"spiffe://domain.test",
+	}
+
+	req := &registration.ListEntriesRequest{
+		FederatesWith: []string{"spiffe://domain.test"},
+		PageSize:      listEntriesPageSize,
+	}
+	resp := &registration.ListEntriesResponse{Entries: entries}
+	s.mockClient.EXPECT().ListEntries(gomock.Any(), req).Return(resp, nil)
+
+	s.Require().Equal(0, s.cli.Run(args))
+	s.Assert().Equal(entries, s.cli.Entries)
+}
+
+func (s *ShowTestSuite) TestRunWithDownstreamAndAdmin() {
+	args := []string{
+		"-downstream",
+		"-admin",
+	}
+
+	req := &registration.ListEntriesRequest{
+		Downstream: &wrappers.BoolValue{Value: true},
+		Admin:      &wrappers.BoolValue{Value: true},
+		PageSize:   listEntriesPageSize,
+	}
+	resp := &registration.ListEntriesResponse{}
+	s.mockClient.EXPECT().ListEntries(gomock.Any(), req).Return(resp, nil)
+
+	s.Require().Equal(0, s.cli.Run(args))
+	s.Assert().Empty(s.cli.Entries)
+}
+
+func (s *ShowTestSuite) TestRunPagesThroughEntries() {
+	entries := s.registrationEntries(4)
+
+	req1 := &registration.ListEntriesRequest{
+		PageSize: listEntriesPageSize,
+	}
+	resp1 := &registration.ListEntriesResponse{
+		Entries:       entries[:3],
+		NextPageToken: "3",
+	}
+	req2 := &registration.ListEntriesRequest{
+		PageToken: "3",
+		PageSize:  listEntriesPageSize,
+	}
+	resp2 := &registration.ListEntriesResponse{
+		Entries: entries[3:],
+	}
+	gomock.InOrder(
+		s.mockClient.EXPECT().ListEntries(gomock.Any(), req1).Return(resp1, nil),
+		s.mockClient.EXPECT().ListEntries(gomock.Any(), req2).Return(resp2, nil),
+	)
+
+	s.Require().Equal(0, s.cli.Run(nil))
 
 	util.SortRegistrationEntries(entries)
 	s.Assert().Equal(entries, s.cli.Entries)
 }
 
-func (s *ShowTestSuite) TestRunWithSelectors() {
+func (s *ShowTestSuite) TestRunWithListError() {
+	s.mockClient.EXPECT().ListEntries(gomock.Any(), gomock.Any()).Return(nil, errors.New("oh no"))
+
+	s.Require().Equal(1, s.cli.Run(nil))
+}
+
+func (s *ShowTestSuite) TestRunLegacyWithNoFlags() {
+	s.expectListEntriesUnimplemented()
+
+	entries := s.registrationEntries(4)
+	resp := &common.RegistrationEntries{Entries: entries}
+	s.mockClient.EXPECT().FetchEntries(gomock.Any(), &common.Empty{}).Return(resp, nil)
+
+	s.Require().Equal(0, s.cli.Run(nil))
+
+	util.SortRegistrationEntries(entries)
+	s.Assert().Equal(entries, s.cli.Entries)
+}
+
+func (s *ShowTestSuite) TestRunLegacyWithSelectors() {
+	s.expectListEntriesUnimplemented()
+
 	entries := s.registrationEntries(2)
 
 	args := []string{
@@ -120,14 +325,45 @@ func (s *ShowTestSuite) TestRunWithSelectors() {
 	s.mockClient.EXPECT().ListBySelector(gomock.Any(), req).Return(resp, nil)
 
 	req = &common.Selector{Type: "bar", Value: "baz"}
-	resp.Entries = entries[1:2]
+	resp = &common.RegistrationEntries{Entries: entries[1:2]}
 	s.mockClient.EXPECT().ListBySelector(gomock.Any(), req).Return(resp, nil)
 
 	s.Require().Equal(0, s.cli.Run(args))
 	s.Assert().Equal(entries[1:2], s.cli.Entries)
 }
 
-func (s *ShowTestSuite) TestRunWithParentIDAndSelectors() {
+func (s *ShowTestSuite) TestRunLegacyWithSelectorsMatchedAsSubset() {
+	s.expectListEntriesUnimplemented()
+
+	entries := s.registrationEntries(3)
+
+	args := []string{
+		"-selector",
+		"foo:bar",
+		"-selector",
+		"bar:baz",
+		"-matchSelectorsOn",
+		"subset",
+	}
+
+	req := &common.Selector{Type: "foo", Value: "bar"}
+	resp := &common.RegistrationEntries{Entries: entries[0:2]}
+	s.mockClient.EXPECT().ListBySelector(gomock.Any(), req).Return(resp, nil)
+
+	req = &common.Selector{Type: "bar", Value: "baz"}
+	resp = &common.RegistrationEntries{Entries: entries[1:3]}
+	s.mockClient.EXPECT().ListBySelector(gomock.Any(), req).Return(resp, nil)
+
+	s.Require().Equal(0, s.cli.Run(args))
+
+	expectEntries := entries[0:2]
+	util.SortRegistrationEntries(expectEntries)
+	s.Assert().Equal(expectEntries, s.cli.Entries)
+}
+
+func (s *ShowTestSuite) TestRunLegacyWithParentIDAndSelectors() {
+	s.expectListEntriesUnimplemented()
+
 	entries := s.registrationEntries(4)[2:4]
 
 	args := []string{
@@ -152,7 +388,9 @@ func (s *ShowTestSuite) TestRunWithParentIDAndSelectors() {
 	s.Assert().Equal(expectEntries, s.cli.Entries)
 }
 
-func (s *ShowTestSuite) TestRunWithFederatesWith() {
+func (s *ShowTestSuite) TestRunLegacyWithFederatesWith() {
+	s.expectListEntriesUnimplemented()
+
 	resp := &common.RegistrationEntries{
 		Entries: s.registrationEntries(4),
 	}
@@ -170,6 +408,22 @@ func (s *ShowTestSuite) TestRunWithFederatesWith() {
 	s.Assert().Equal(expectEntries, s.cli.Entries)
 }
 
+func (s *ShowTestSuite) TestRunWithUnimplementedAfterFirstPage() {
+	gomock.InOrder(
+		s.mockClient.EXPECT().ListEntries(gomock.Any(), gomock.Any()).Return(&registration.ListEntriesResponse{
+			Entries:       s.registrationEntries(1),
+			NextPageToken: "1",
+		}, nil),
+		s.mockClient.EXPECT().ListEntries(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.Unimplemented, "unknown method")),
+	)
+
+	s.Require().Equal(1, s.cli.Run(nil))
+}
+
+func (s *ShowTestSuite) expectListEntriesUnimplemented() {
+	s.mockClient.EXPECT().ListEntries(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.Unimplemented, "unknown method ListEntries"))
+}
+
 // registrationEntries returns `count` registration entry records. At most 4.
 func (ShowTestSuite) registrationEntries(count int) []*common.RegistrationEntry {
 	selectors := []*common.Selector{
@@ -46,6 +46,15 @@ type UpdateConfig struct {
 
 	// DNSNames entries for SVIDs based on this entry
 	DNSNames StringsFlag
+
+	// TTL of JWT-SVIDs based on this entry
+	JWTSVIDTTL int
+
+	// Claims added to JWT-SVIDs based on this entry, formatted as name=value
+	JWTSVIDClaims StringsFlag
+
+	// Path to a JSON file with the X509-SVID template of this entry
+	X509SVIDTemplate string
 }
 
 // Validate performs basic validation, even on fields that we
@@ -80,6 +89,10 @@ func (rc *UpdateConfig) Validate() (err error) {
 		return errors.New("a TTL is required")
 	}
 
+	if rc.JWTSVIDTTL < 0 {
+		return errors.New("the JWT-SVID TTL cannot be negative")
+	}
+
 	// make sure all SPIFFE ID's are well formed
 	rc.SpiffeID, err = idutil.NormalizeSpiffeID(rc.SpiffeID, idutil.AllowAny())
 	if err != nil {
@@ -160,7 +173,20 @@ func (c UpdateCLI) parseConfig(config *UpdateConfig) ([]*common.RegistrationEntr
 		Downstream:  config.Downstream,
 		EntryExpiry: config.EntryExpiry,
 		DnsNames:    config.DNSNames,
+		JwtSvidTtl:  int32(config.JWTSVIDTTL),
+	}
+
+	claims, err := parseJWTSVIDClaims(config.JWTSVIDClaims)
+	if err != nil {
+		return nil, err
 	}
+	e.JwtSvidClaims = claims
+
+	template, err := parseX509SVIDTemplate(config.X509SVIDTemplate)
+	if err != nil {
+		return nil, err
+	}
+	e.X509SvidTemplate = template
 
 	selectors := []*common.Selector{}
 	for _, s := range config.Selectors {
@@ -231,5 +257,9 @@ func (UpdateCLI) newConfig(args []string) (*UpdateConfig, error) {
 
 	f.Var(&c.DNSNames, "dns", "A DNS name that will be included in SVIDs issued based on this entry, where appropriate. Can be used more than once")
 
+	f.IntVar(&c.JWTSVIDTTL, "jwtSVIDTTL", 0, "The lifetime, in seconds, for JWT-SVIDs issued based on this registration entry. If unset, the server default is used")
+	f.Var(&c.JWTSVIDClaims, "jwtClaim", "A name=value claim that will be included in JWT-SVIDs issued based on this entry. Can be used more than once")
+	f.StringVar(&c.X509SVIDTemplate, "x509SVIDTemplate", "", "Path to a JSON file with the X509-SVID template of this entry (optional)")
+
 	return c, f.Parse(args)
 }
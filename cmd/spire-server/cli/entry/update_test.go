@@ -35,6 +35,10 @@ func TestUpdateCLI(t *testing.T) {
 		"-dns", "ung1000",
 		"-dns", "aa2000",
 		"-dns", "zz2000",
+		"-jwtSVIDTTL", "300",
+		"-jwtClaim", "tenant=acme",
+		"-jwtClaim", "environment=prod",
+		"-x509SVIDTemplate", "template.json",
 	})
 	require.NoError(t, err)
 
@@ -48,6 +52,9 @@ func TestUpdateCLI(t *testing.T) {
 		Admin:               true,
 		EntryExpiry:         1552410266,
 		DNSNames:            StringsFlag{"unu1000", "ung1000", "aa2000", "zz2000"},
+		JWTSVIDTTL:          300,
+		JWTSVIDClaims:       StringsFlag{"tenant=acme", "environment=prod"},
+		X509SVIDTemplate:    "template.json",
 	}
 
 	assert.Equal(t, updatedConfig, c)
@@ -63,6 +70,9 @@ func TestUpdateParseConfig(t *testing.T) {
 		FederatesWith:       StringsFlag{"spiffe://domain1.test", "spiffe://domain2.test"},
 		Admin:               true,
 		EntryExpiry:         1552410266,
+		JWTSVIDTTL:          300,
+		JWTSVIDClaims:       StringsFlag{"tenant=acme", "environment=prod"},
+		X509SVIDTemplate:    path.Join(util.ProjectRoot(), "test/fixture/registration/x509_svid_template.json"),
 	}
 
 	entries, err := UpdateCLI{}.parseConfig(c)
@@ -82,6 +92,19 @@ func TestUpdateParseConfig(t *testing.T) {
 		},
 		Admin:       true,
 		EntryExpiry: 1552410266,
+		JwtSvidTtl:  300,
+		JwtSvidClaims: map[string]string{
+			"tenant":      "acme",
+			"environment": "prod",
+		},
+		X509SvidTemplate: &common.X509SVIDTemplate{
+			Subject: &common.X509Subject{
+				Organization:       []string{"ACME"},
+				OrganizationalUnit: []string{"gateway"},
+			},
+			ExtraKeyUsages:    []string{"data_encipherment"},
+			ExtraExtKeyUsages: []string{"code_signing"},
+		},
 	}
 
 	expectedEntries := []*common.RegistrationEntry{expectedEntry}
@@ -1,12 +1,37 @@
 package entry
 
 import (
+	"crypto/x509/pkix"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"sort"
 	"strings"
 
 	"github.com/spiffe/spire/proto/spire/common"
 )
 
+// matchSelectors takes a registration entry, a selector flag set and a match
+// mode (superset, subset or exact). It returns true if the selectors of the
+// registration entry match the set. An error is returned if we run into
+// trouble parsing the selector flags.
+func matchSelectors(entry *common.RegistrationEntry, flags StringsFlag, match string) (bool, error) {
+	switch match {
+	case "superset":
+		return hasSelectors(entry, flags)
+	case "subset":
+		return inSelectors(entry, flags)
+	case "exact":
+		ok, err := hasSelectors(entry, flags)
+		if !ok || err != nil {
+			return false, err
+		}
+		return inSelectors(entry, flags)
+	default:
+		return false, fmt.Errorf("unsupported selector match %q", match)
+	}
+}
+
 // hasSelectors takes a registration entry and a selector flag set. It returns
 // true if the registration entry possesses all selectors in the set. An error
 // is returned if we run into trouble parsing the selector flags.
@@ -17,7 +42,7 @@ func hasSelectors(entry *common.RegistrationEntry, flags StringsFlag) (bool, err
 			return false, err
 		}
 
-		if !hasSelector(entry, selector) {
+		if !hasSelector(entry.Selectors, selector) {
 			return false, nil
 		}
 	}
@@ -25,12 +50,34 @@ func hasSelectors(entry *common.RegistrationEntry, flags StringsFlag) (bool, err
 	return true, nil
 }
 
-// hasSelector returns true if the given registration entry includes the
-// selector in question.
-func hasSelector(entry *common.RegistrationEntry, selector *common.Selector) bool {
-	var found bool
+// inSelectors takes a registration entry and a selector flag set. It returns
+// true if all of the selectors of the registration entry are in the set. An
+// error is returned if we run into trouble parsing the selector flags.
+func inSelectors(entry *common.RegistrationEntry, flags StringsFlag) (bool, error) {
+	var selectors []*common.Selector
+	for _, f := range flags {
+		selector, err := parseSelector(f)
+		if err != nil {
+			return false, err
+		}
+		selectors = append(selectors, selector)
+	}
 
 	for _, s := range entry.Selectors {
+		if !hasSelector(selectors, s) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// hasSelector returns true if the given selectors include the selector in
+// question.
+func hasSelector(selectors []*common.Selector, selector *common.Selector) bool {
+	var found bool
+
+	for _, s := range selectors {
 		if s.Type == selector.Type && s.Value == selector.Value {
 			found = true
 			break
@@ -56,6 +103,39 @@ func parseSelector(str string) (*common.Selector, error) {
 	return s, nil
 }
 
+// parseJWTSVIDClaims parses name=value claim flags into a claims map
+func parseJWTSVIDClaims(flags StringsFlag) (map[string]string, error) {
+	if len(flags) == 0 {
+		return nil, nil
+	}
+	claims := make(map[string]string, len(flags))
+	for _, f := range flags {
+		parts := strings.SplitN(f, "=", 2)
+		if len(parts) < 2 || parts[0] == "" {
+			return nil, fmt.Errorf("JWT-SVID claim \"%s\" must be formatted as name=value", f)
+		}
+		claims[parts[0]] = parts[1]
+	}
+	return claims, nil
+}
+
+// parseX509SVIDTemplate reads an X509-SVID template from a JSON file. It
+// returns nil if no path is given.
+func parseX509SVIDTemplate(path string) (*common.X509SVIDTemplate, error) {
+	if path == "" {
+		return nil, nil
+	}
+	dat, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	template := new(common.X509SVIDTemplate)
+	if err := json.Unmarshal(dat, template); err != nil {
+		return nil, fmt.Errorf("unable to parse X509-SVID template %q: %v", path, err)
+	}
+	return template, nil
+}
+
 func printEntry(e *common.RegistrationEntry) {
 	fmt.Printf("Entry ID      : %s\n", e.EntryId)
 	fmt.Printf("SPIFFE ID     : %s\n", e.SpiffeId)
@@ -71,6 +151,10 @@ func printEntry(e *common.RegistrationEntry) {
 		fmt.Printf("TTL           : %d\n", e.Ttl)
 	}
 
+	if e.JwtSvidTtl != 0 {
+		fmt.Printf("JWT-SVID TTL  : %d\n", e.JwtSvidTtl)
+	}
+
 	for _, s := range e.Selectors {
 		fmt.Printf("Selector      : %s:%s\n", s.Type, s.Value)
 	}
@@ -84,9 +168,41 @@ func printEntry(e *common.RegistrationEntry) {
 		fmt.Printf("Admin         : %t\n", e.Admin)
 	}
 
+	claimNames := make([]string, 0, len(e.JwtSvidClaims))
+	for name := range e.JwtSvidClaims {
+		claimNames = append(claimNames, name)
+	}
+	sort.Strings(claimNames)
+	for _, name := range claimNames {
+		fmt.Printf("JWT Claim     : %s=%s\n", name, e.JwtSvidClaims[name])
+	}
+
+	if t := e.X509SvidTemplate; t != nil {
+		if t.Subject != nil {
+			fmt.Printf("X509 Subject  : %s\n", x509SubjectString(t.Subject))
+		}
+		for _, keyUsage := range t.ExtraKeyUsages {
+			fmt.Printf("Key Usage     : %s\n", keyUsage)
+		}
+		for _, extKeyUsage := range t.ExtraExtKeyUsages {
+			fmt.Printf("Ext Key Usage : %s\n", extKeyUsage)
+		}
+	}
+
 	fmt.Println()
 }
 
+func x509SubjectString(subject *common.X509Subject) string {
+	return pkix.Name{
+		Country:            subject.Country,
+		Organization:       subject.Organization,
+		OrganizationalUnit: subject.OrganizationalUnit,
+		Locality:           subject.Locality,
+		Province:           subject.Province,
+		CommonName:         subject.CommonName,
+	}.String()
+}
+
 // Define a custom type for string lists. Doing
 // this allows us to support repeatable string flags.
 type StringsFlag []string
@@ -1,10 +1,14 @@
 package entry
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestHasSelectors(t *testing.T) {
@@ -35,6 +39,75 @@ func TestHasSelectors(t *testing.T) {
 	a.False(hasSelectors(entry, selectorToFlag(selectors[2:4])))
 }
 
+func TestMatchSelectors(t *testing.T) {
+	selectors := []*common.Selector{
+		{Type: "foo", Value: "bar"},
+		{Type: "bar", Value: "bat"},
+		{Type: "bat", Value: "baz"},
+	}
+
+	entry := &common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/foo",
+		SpiffeId:  "spiffe://example.org/bar",
+		Selectors: selectors[0:2],
+	}
+
+	a := assert.New(t)
+	a.True(matchSelectors(entry, selectorToFlag(selectors[0:1]), "superset"))
+	a.False(matchSelectors(entry, selectorToFlag(selectors[0:3]), "superset"))
+	a.True(matchSelectors(entry, selectorToFlag(selectors[0:3]), "subset"))
+	a.False(matchSelectors(entry, selectorToFlag(selectors[0:1]), "subset"))
+	a.True(matchSelectors(entry, selectorToFlag(selectors[0:2]), "exact"))
+	a.False(matchSelectors(entry, selectorToFlag(selectors[0:1]), "exact"))
+	a.False(matchSelectors(entry, selectorToFlag(selectors[0:3]), "exact"))
+
+	_, err := matchSelectors(entry, selectorToFlag(selectors[0:1]), "any")
+	a.EqualError(err, `unsupported selector match "any"`)
+}
+
+func TestParseJWTSVIDClaims(t *testing.T) {
+	claims, err := parseJWTSVIDClaims(StringsFlag{"tenant=acme", "query=a=b", "empty="})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"tenant": "acme",
+		"query":  "a=b",
+		"empty":  "",
+	}, claims)
+
+	claims, err = parseJWTSVIDClaims(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, claims)
+
+	_, err = parseJWTSVIDClaims(StringsFlag{"tenant"})
+	assert.EqualError(t, err, `JWT-SVID claim "tenant" must be formatted as name=value`)
+
+	_, err = parseJWTSVIDClaims(StringsFlag{"=acme"})
+	assert.EqualError(t, err, `JWT-SVID claim "=acme" must be formatted as name=value`)
+}
+
+func TestParseX509SVIDTemplate(t *testing.T) {
+	template, err := parseX509SVIDTemplate("")
+	assert.NoError(t, err)
+	assert.Nil(t, template)
+
+	dir, err := ioutil.TempDir("", "entry-util-test-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "template.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"subject": {"common_name": "gateway"}}`), 0644))
+	template, err = parseX509SVIDTemplate(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "gateway", template.Subject.CommonName)
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("{"), 0644))
+	_, err = parseX509SVIDTemplate(path)
+	assert.Contains(t, err.Error(), "unable to parse X509-SVID template")
+
+	_, err = parseX509SVIDTemplate(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+}
+
 func selectorToFlag(selectors []*common.Selector) StringsFlag {
 	resp := StringsFlag{}
 	for _, s := range selectors {
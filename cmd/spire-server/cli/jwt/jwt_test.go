@@ -0,0 +1,110 @@
+package jwt
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMint(t *testing.T) {
+	r := &fakeRegistrationClient{
+		resp: &registration.MintJWTSVIDResponse{Token: "TOKEN"},
+	}
+	stdout, stderr, rc := runCommand(newMintCommand, r,
+		"-spiffeID", "spiffe://example.org/workload",
+		"-ttl", "60",
+		"-audience", "AUDIENCE1",
+		"-audience", "AUDIENCE2")
+	require.Equal(t, 0, rc, stderr)
+	require.Equal(t, &registration.MintJWTSVIDRequest{
+		SpiffeId: "spiffe://example.org/workload",
+		Audience: []string{"AUDIENCE1", "AUDIENCE2"},
+		Ttl:      60,
+	}, r.req)
+	require.Equal(t, "TOKEN\n", stdout)
+}
+
+func TestMintWritesFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spire-server-cli-test-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "token")
+
+	r := &fakeRegistrationClient{
+		resp: &registration.MintJWTSVIDResponse{Token: "TOKEN"},
+	}
+	stdout, stderr, rc := runCommand(newMintCommand, r,
+		"-spiffeID", "spiffe://example.org/workload",
+		"-audience", "AUDIENCE",
+		"-write", path)
+	require.Equal(t, 0, rc, stderr)
+	require.Equal(t, "JWT-SVID written to "+path+"\n", stdout)
+
+	token, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "TOKEN", string(token))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestMintWithMissingFlags(t *testing.T) {
+	r := &fakeRegistrationClient{}
+	_, stderr, rc := runCommand(newMintCommand, r, "-audience", "AUDIENCE")
+	require.Equal(t, 1, rc)
+	require.Equal(t, "a SPIFFE ID is required\n", stderr)
+
+	_, stderr, rc = runCommand(newMintCommand, r, "-spiffeID", "spiffe://example.org/workload")
+	require.Equal(t, 1, rc)
+	require.Equal(t, "at least one audience is required\n", stderr)
+	require.Nil(t, r.req)
+}
+
+func TestMintFailure(t *testing.T) {
+	r := &fakeRegistrationClient{
+		err: status.Error(codes.InvalidArgument, `"spiffe://otherdomain.test/workload" does not belong to trust domain "example.org"`),
+	}
+	_, stderr, rc := runCommand(newMintCommand, r,
+		"-spiffeID", "spiffe://otherdomain.test/workload",
+		"-audience", "AUDIENCE")
+	require.Equal(t, 1, rc)
+	require.Equal(t, "rpc error: code = InvalidArgument desc = \"spiffe://otherdomain.test/workload\" does not belong to trust domain \"example.org\"\n", stderr)
+}
+
+func runCommand(newCommand func(*env, clientsMaker) cli.Command, r registration.RegistrationClient, args ...string) (string, string, int) {
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	cmd := newCommand(&env{stdout: stdout, stderr: stderr}, func(string) (*clients, error) {
+		return &clients{r: r}, nil
+	})
+	rc := cmd.Run(args)
+	return stdout.String(), stderr.String(), rc
+}
+
+type fakeRegistrationClient struct {
+	registration.RegistrationClient
+
+	resp *registration.MintJWTSVIDResponse
+	err  error
+
+	req *registration.MintJWTSVIDRequest
+}
+
+func (c *fakeRegistrationClient) MintJWTSVID(ctx context.Context, in *registration.MintJWTSVIDRequest, opts ...grpc.CallOption) (*registration.MintJWTSVIDResponse, error) {
+	c.req = in
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.resp, nil
+}
@@ -0,0 +1,69 @@
+package jwt
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+)
+
+// NewMintCommand creates a new "mint" subcommand for "jwt" command.
+func NewMintCommand() cli.Command {
+	return newMintCommand(defaultEnv, newClients)
+}
+
+func newMintCommand(env *env, clientsMaker clientsMaker) cli.Command {
+	return adaptCommand(env, clientsMaker, new(mintCommand))
+}
+
+type mintCommand struct {
+	spiffeID string
+	ttl      int
+	audience stringsFlag
+	write    string
+}
+
+func (c *mintCommand) name() string {
+	return "jwt mint"
+}
+
+func (c *mintCommand) synopsis() string {
+	return "Mints a JWT-SVID"
+}
+
+func (c *mintCommand) appendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.spiffeID, "spiffeID", "", "SPIFFE ID of the JWT-SVID")
+	fs.IntVar(&c.ttl, "ttl", 0, "TTL of the JWT-SVID in seconds. If unset, the server default is used. The server caps the TTL")
+	fs.Var(&c.audience, "audience", "Audience of the JWT-SVID. Can be used more than once")
+	fs.StringVar(&c.write, "write", "", "File to write the token to, instead of stdout")
+}
+
+func (c *mintCommand) run(ctx context.Context, env *env, clients *clients) error {
+	if c.spiffeID == "" {
+		return errors.New("a SPIFFE ID is required")
+	}
+	if len(c.audience) == 0 {
+		return errors.New("at least one audience is required")
+	}
+
+	resp, err := clients.r.MintJWTSVID(ctx, &registration.MintJWTSVIDRequest{
+		SpiffeId: c.spiffeID,
+		Audience: c.audience,
+		Ttl:      int32(c.ttl),
+	})
+	if err != nil {
+		return err
+	}
+
+	if c.write == "" {
+		return env.Println(resp.Token)
+	}
+	if err := ioutil.WriteFile(c.write, []byte(resp.Token), 0600); err != nil {
+		return fmt.Errorf("unable to write %s: %v", c.write, err)
+	}
+	return env.Printf("JWT-SVID written to %s\n", c.write)
+}
@@ -24,6 +24,8 @@ import (
 	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/pkg/server"
 	bundleClient "github.com/spiffe/spire/pkg/server/bundle/client"
+	"github.com/spiffe/spire/pkg/server/ca"
+	"github.com/spiffe/spire/proto/spire/server/keymanager"
 )
 
 const (
@@ -31,6 +33,8 @@ const (
 	defaultSocketPath         = "/tmp/spire-registration.sock"
 	defaultLogLevel           = "INFO"
 	defaultBundleEndpointPort = 443
+
+	defaultRegistrationGatewayPort = 8443
 )
 
 // config contains all available configurables, arranged by section
@@ -42,19 +46,28 @@ type config struct {
 }
 
 type serverConfig struct {
-	BindAddress         string             `hcl:"bind_address"`
-	BindPort            int                `hcl:"bind_port"`
-	CASubject           *caSubjectConfig   `hcl:"ca_subject"`
-	CATTL               string             `hcl:"ca_ttl"`
-	DataDir             string             `hcl:"data_dir"`
-	Experimental        experimentalConfig `hcl:"experimental"`
-	LogFile             string             `hcl:"log_file"`
-	LogLevel            string             `hcl:"log_level"`
-	LogFormat           string             `hcl:"log_format"`
-	RegistrationUDSPath string             `hcl:"registration_uds_path"`
-	SVIDTTL             string             `hcl:"svid_ttl"`
-	TrustDomain         string             `hcl:"trust_domain"`
-	UpstreamBundle      bool               `hcl:"upstream_bundle"`
+	AuditLogFile         string             `hcl:"audit_log_file"`
+	BindAddress          string             `hcl:"bind_address"`
+	BindPort             int                `hcl:"bind_port"`
+	CAKeyType            string             `hcl:"ca_key_type"`
+	CASubject            *caSubjectConfig   `hcl:"ca_subject"`
+	CATTL                string             `hcl:"ca_ttl"`
+	DataDir              string             `hcl:"data_dir"`
+	EntryEventRetention  string             `hcl:"entry_event_retention"`
+	Experimental         experimentalConfig `hcl:"experimental"`
+	IssuanceLogFailOpen  bool               `hcl:"issuance_log_fail_open"`
+	IssuanceLogRetention string             `hcl:"issuance_log_retention"`
+	JWTKeyType           string             `hcl:"jwt_key_type"`
+	JWTSigningAlgorithm  string             `hcl:"jwt_signing_algorithm"`
+	LogFile              string             `hcl:"log_file"`
+	LogLevel             string             `hcl:"log_level"`
+	LogFormat            string             `hcl:"log_format"`
+	RegistrationPolicy   string             `hcl:"registration_policy_file"`
+	RegistrationUDSPath  string             `hcl:"registration_uds_path"`
+	SVIDTTL              string             `hcl:"svid_ttl"`
+	TaintedRemovalDelay  string             `hcl:"tainted_authority_removal_delay"`
+	TrustDomain          string             `hcl:"trust_domain"`
+	UpstreamBundle       bool               `hcl:"upstream_bundle"`
 
 	ConfigPath string
 
@@ -72,6 +85,15 @@ type experimentalConfig struct {
 	BundleEndpointAddress string                         `hcl:"bundle_endpoint_address"`
 	BundleEndpointPort    int                            `hcl:"bundle_endpoint_port"`
 	FederatesWith         map[string]federatesWithConfig `hcl:"federates_with"`
+
+	RegistrationGatewayEnabled bool   `hcl:"registration_gateway_enabled"`
+	RegistrationGatewayAddress string `hcl:"registration_gateway_address"`
+	RegistrationGatewayPort    int    `hcl:"registration_gateway_port"`
+
+	CAJournalInDataStore bool `hcl:"ca_journal_in_datastore"`
+
+	LeaderElection bool   `hcl:"leader_election"`
+	LeaderLeaseTTL string `hcl:"leader_lease_ttl"`
 }
 
 type caSubjectConfig struct {
@@ -246,6 +268,8 @@ func newServerConfig(c *config) (*server.Config, error) {
 	}
 
 	sc.DataDir = c.Server.DataDir
+	sc.RegistrationPolicyPath = c.Server.RegistrationPolicy
+	sc.AuditLogPath = c.Server.AuditLogFile
 
 	td, err := idutil.ParseSpiffeID("spiffe://"+c.Server.TrustDomain, idutil.AllowAnyTrustDomain())
 	if err != nil {
@@ -262,12 +286,18 @@ func newServerConfig(c *config) (*server.Config, error) {
 	sc.Log = logger
 
 	sc.UpstreamBundle = c.Server.UpstreamBundle
+	sc.IssuanceLogFailOpen = c.Server.IssuanceLogFailOpen
 	sc.Experimental.AllowAgentlessNodeAttestors = c.Server.Experimental.AllowAgentlessNodeAttestors
 	sc.Experimental.BundleEndpointEnabled = c.Server.Experimental.BundleEndpointEnabled
 	sc.Experimental.BundleEndpointAddress = &net.TCPAddr{
 		IP:   net.ParseIP(c.Server.Experimental.BundleEndpointAddress),
 		Port: c.Server.Experimental.BundleEndpointPort,
 	}
+	sc.Experimental.RegistrationGatewayEnabled = c.Server.Experimental.RegistrationGatewayEnabled
+	sc.Experimental.RegistrationGatewayAddress = &net.TCPAddr{
+		IP:   net.ParseIP(c.Server.Experimental.RegistrationGatewayAddress),
+		Port: c.Server.Experimental.RegistrationGatewayPort,
+	}
 
 	federatesWith := map[string]bundleClient.TrustDomainConfig{}
 	for trustDomain, config := range c.Server.Experimental.FederatesWith {
@@ -282,6 +312,15 @@ func newServerConfig(c *config) (*server.Config, error) {
 		}
 	}
 	sc.Experimental.FederatesWith = federatesWith
+	sc.Experimental.CAJournalInDataStore = c.Server.Experimental.CAJournalInDataStore
+	sc.Experimental.LeaderElection = c.Server.Experimental.LeaderElection
+	if c.Server.Experimental.LeaderLeaseTTL != "" {
+		ttl, err := time.ParseDuration(c.Server.Experimental.LeaderLeaseTTL)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse leader lease ttl %q: %v", c.Server.Experimental.LeaderLeaseTTL, err)
+		}
+		sc.Experimental.LeaderLeaseTTL = ttl
+	}
 
 	sc.ProfilingEnabled = c.Server.ProfilingEnabled
 	sc.ProfilingPort = c.Server.ProfilingPort
@@ -304,6 +343,54 @@ func newServerConfig(c *config) (*server.Config, error) {
 		sc.CATTL = ttl
 	}
 
+	if c.Server.TaintedRemovalDelay != "" {
+		delay, err := time.ParseDuration(c.Server.TaintedRemovalDelay)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse tainted authority removal delay %q: %v", c.Server.TaintedRemovalDelay, err)
+		}
+		sc.TaintedRemovalDelay = delay
+	}
+
+	if c.Server.IssuanceLogRetention != "" {
+		retention, err := time.ParseDuration(c.Server.IssuanceLogRetention)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse issuance log retention %q: %v", c.Server.IssuanceLogRetention, err)
+		}
+		sc.IssuanceLogRetention = retention
+	}
+
+	if c.Server.EntryEventRetention != "" {
+		retention, err := time.ParseDuration(c.Server.EntryEventRetention)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse entry event retention %q: %v", c.Server.EntryEventRetention, err)
+		}
+		sc.EntryEventRetention = retention
+	}
+
+	if c.Server.CAKeyType != "" {
+		keyType, err := keyTypeFromString(c.Server.CAKeyType)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing ca_key_type: %v", err)
+		}
+		sc.CAKeyType = keyType
+	}
+
+	if c.Server.JWTKeyType != "" {
+		keyType, err := keyTypeFromString(c.Server.JWTKeyType)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing jwt_key_type: %v", err)
+		}
+		sc.JWTKeyType = keyType
+	}
+
+	if c.Server.JWTSigningAlgorithm != "" {
+		alg, err := jwtSigningAlgorithmFromString(c.Server.JWTSigningAlgorithm, sc.JWTKeyType)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing jwt_signing_algorithm: %v", err)
+		}
+		sc.JWTSigningAlgorithm = alg
+	}
+
 	if subject := c.Server.CASubject; subject != nil {
 		sc.CASubject = pkix.Name{
 			Organization: subject.Organization,
@@ -353,6 +440,46 @@ func validateConfig(c *config) error {
 	return nil
 }
 
+func keyTypeFromString(s string) (keymanager.KeyType, error) {
+	switch strings.ToLower(s) {
+	case "rsa-2048":
+		return keymanager.KeyType_RSA_2048, nil
+	case "rsa-4096":
+		return keymanager.KeyType_RSA_4096, nil
+	case "ec-p256":
+		return keymanager.KeyType_EC_P256, nil
+	case "ec-p384":
+		return keymanager.KeyType_EC_P384, nil
+	default:
+		return keymanager.KeyType_UNSPECIFIED_KEY_TYPE, fmt.Errorf("key type %q is unknown; must be one of [rsa-2048, rsa-4096, ec-p256, ec-p384]", s)
+	}
+}
+
+// jwtSigningAlgorithmFromString parses a JWS algorithm, making sure that keys
+// of the given type (the default JWT key type if unspecified) can sign with it.
+func jwtSigningAlgorithmFromString(s string, keyType keymanager.KeyType) (string, error) {
+	if keyType == keymanager.KeyType_UNSPECIFIED_KEY_TYPE {
+		keyType = ca.DefaultJWTKeyType
+	}
+
+	alg := strings.ToUpper(s)
+	var ok bool
+	switch alg {
+	case "ES256":
+		ok = keyType == keymanager.KeyType_EC_P256
+	case "ES384":
+		ok = keyType == keymanager.KeyType_EC_P384
+	case "RS256", "PS256":
+		ok = keyType == keymanager.KeyType_RSA_2048 || keyType == keymanager.KeyType_RSA_4096
+	default:
+		return "", fmt.Errorf("algorithm %q is unknown; must be one of [ES256, ES384, RS256, PS256]", s)
+	}
+	if !ok {
+		return "", fmt.Errorf("algorithm %s cannot be used with JWT key type %s", alg, keyType)
+	}
+	return alg, nil
+}
+
 func defaultConfig() *config {
 	return &config{
 		Server: &serverConfig{
@@ -364,6 +491,9 @@ func defaultConfig() *config {
 			Experimental: experimentalConfig{
 				BundleEndpointAddress: "0.0.0.0",
 				BundleEndpointPort:    defaultBundleEndpointPort,
+
+				RegistrationGatewayAddress: "0.0.0.0",
+				RegistrationGatewayPort:    defaultRegistrationGatewayPort,
 			},
 		},
 	}
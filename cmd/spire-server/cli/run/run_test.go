@@ -10,6 +10,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/log"
 	"github.com/spiffe/spire/pkg/server"
+	"github.com/spiffe/spire/proto/spire/server/keymanager"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -192,6 +193,66 @@ func TestMergeInput(t *testing.T) {
 				require.Equal(t, "1h", c.Server.CATTL)
 			},
 		},
+		{
+			msg: "entry_event_retention should be configurable by file",
+			fileInput: func(c *config) {
+				c.Server.EntryEventRetention = "48h"
+			},
+			cliInput: func(c *serverConfig) {},
+			test: func(t *testing.T, c *config) {
+				require.Equal(t, "48h", c.Server.EntryEventRetention)
+			},
+		},
+		{
+			msg: "audit_log_file should be configurable by file",
+			fileInput: func(c *config) {
+				c.Server.AuditLogFile = "audit.log"
+			},
+			cliInput: func(c *serverConfig) {},
+			test: func(t *testing.T, c *config) {
+				require.Equal(t, "audit.log", c.Server.AuditLogFile)
+			},
+		},
+		{
+			msg: "registration_policy_file should be configurable by file",
+			fileInput: func(c *config) {
+				c.Server.RegistrationPolicy = "policy.conf"
+			},
+			cliInput: func(c *serverConfig) {},
+			test: func(t *testing.T, c *config) {
+				require.Equal(t, "policy.conf", c.Server.RegistrationPolicy)
+			},
+		},
+		{
+			msg: "issuance_log_retention should be configurable by file",
+			fileInput: func(c *config) {
+				c.Server.IssuanceLogRetention = "720h"
+			},
+			cliInput: func(c *serverConfig) {},
+			test: func(t *testing.T, c *config) {
+				require.Equal(t, "720h", c.Server.IssuanceLogRetention)
+			},
+		},
+		{
+			msg: "ca_key_type should be configurable by file",
+			fileInput: func(c *config) {
+				c.Server.CAKeyType = "rsa-2048"
+			},
+			cliInput: func(c *serverConfig) {},
+			test: func(t *testing.T, c *config) {
+				require.Equal(t, "rsa-2048", c.Server.CAKeyType)
+			},
+		},
+		{
+			msg: "jwt_key_type should be configurable by file",
+			fileInput: func(c *config) {
+				c.Server.JWTKeyType = "ec-p384"
+			},
+			cliInput: func(c *serverConfig) {},
+			test: func(t *testing.T, c *config) {
+				require.Equal(t, "ec-p384", c.Server.JWTKeyType)
+			},
+		},
 		{
 			msg: "data_dir should be configurable by file",
 			fileInput: func(c *config) {
@@ -620,6 +681,36 @@ func TestNewServerConfig(t *testing.T) {
 				require.True(t, c.Experimental.AllowAgentlessNodeAttestors)
 			},
 		},
+		{
+			msg: "ca_journal_in_datastore is configured correctly",
+			input: func(c *config) {
+				c.Server.Experimental.CAJournalInDataStore = true
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.True(t, c.Experimental.CAJournalInDataStore)
+			},
+		},
+		{
+			msg: "leader election is configured correctly",
+			input: func(c *config) {
+				c.Server.Experimental.LeaderElection = true
+				c.Server.Experimental.LeaderLeaseTTL = "1m"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.True(t, c.Experimental.LeaderElection)
+				require.Equal(t, time.Minute, c.Experimental.LeaderLeaseTTL)
+			},
+		},
+		{
+			msg:         "invalid leader_lease_ttl returns an error",
+			expectError: true,
+			input: func(c *config) {
+				c.Server.Experimental.LeaderLeaseTTL = "b"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg: "bundle endpoint is parsed and configured correctly",
 			input: func(c *config) {
@@ -633,6 +724,19 @@ func TestNewServerConfig(t *testing.T) {
 				require.Equal(t, 1337, c.Experimental.BundleEndpointAddress.Port)
 			},
 		},
+		{
+			msg: "registration gateway is parsed and configured correctly",
+			input: func(c *config) {
+				c.Server.Experimental.RegistrationGatewayEnabled = true
+				c.Server.Experimental.RegistrationGatewayAddress = "192.168.1.1"
+				c.Server.Experimental.RegistrationGatewayPort = 1337
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.True(t, c.Experimental.RegistrationGatewayEnabled)
+				require.Equal(t, "192.168.1.1", c.Experimental.RegistrationGatewayAddress.IP.String())
+				require.Equal(t, 1337, c.Experimental.RegistrationGatewayAddress.Port)
+			},
+		},
 		{
 			msg: "svid_ttl is correctly parsed",
 			input: func(c *config) {
@@ -671,6 +775,169 @@ func TestNewServerConfig(t *testing.T) {
 				require.Nil(t, c)
 			},
 		},
+		{
+			msg: "issuance_log_retention is correctly parsed",
+			input: func(c *config) {
+				c.Server.IssuanceLogRetention = "720h"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 720*time.Hour, c.IssuanceLogRetention)
+			},
+		},
+		{
+			msg: "issuance_log_fail_open is passed through",
+			input: func(c *config) {
+				c.Server.IssuanceLogFailOpen = true
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.True(t, c.IssuanceLogFailOpen)
+			},
+		},
+		{
+			msg:         "invalid issuance_log_retention returns an error",
+			expectError: true,
+			input: func(c *config) {
+				c.Server.IssuanceLogRetention = "b"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "tainted_authority_removal_delay is correctly parsed",
+			input: func(c *config) {
+				c.Server.TaintedRemovalDelay = "10m"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 10*time.Minute, c.TaintedRemovalDelay)
+			},
+		},
+		{
+			msg:         "invalid tainted_authority_removal_delay returns an error",
+			expectError: true,
+			input: func(c *config) {
+				c.Server.TaintedRemovalDelay = "b"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "audit_log_file is passed through",
+			input: func(c *config) {
+				c.Server.AuditLogFile = "audit.log"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, "audit.log", c.AuditLogPath)
+			},
+		},
+		{
+			msg: "registration_policy_file is passed through",
+			input: func(c *config) {
+				c.Server.RegistrationPolicy = "policy.conf"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, "policy.conf", c.RegistrationPolicyPath)
+			},
+		},
+		{
+			msg: "entry_event_retention is correctly parsed",
+			input: func(c *config) {
+				c.Server.EntryEventRetention = "48h"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, 48*time.Hour, c.EntryEventRetention)
+			},
+		},
+		{
+			msg:         "invalid entry_event_retention returns an error",
+			expectError: true,
+			input: func(c *config) {
+				c.Server.EntryEventRetention = "b"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:   "ca_key_type and jwt_key_type default to unspecified",
+			input: func(c *config) {},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, keymanager.KeyType_UNSPECIFIED_KEY_TYPE, c.CAKeyType)
+				require.Equal(t, keymanager.KeyType_UNSPECIFIED_KEY_TYPE, c.JWTKeyType)
+			},
+		},
+		{
+			msg: "ca_key_type and jwt_key_type are correctly parsed",
+			input: func(c *config) {
+				c.Server.CAKeyType = "rsa-4096"
+				c.Server.JWTKeyType = "RSA-2048"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, keymanager.KeyType_RSA_4096, c.CAKeyType)
+				require.Equal(t, keymanager.KeyType_RSA_2048, c.JWTKeyType)
+			},
+		},
+		{
+			msg:         "invalid ca_key_type returns an error",
+			expectError: true,
+			input: func(c *config) {
+				c.Server.CAKeyType = "rsa-1024"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "invalid jwt_key_type returns an error",
+			expectError: true,
+			input: func(c *config) {
+				c.Server.JWTKeyType = "ec-p521"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg: "jwt_signing_algorithm is correctly parsed",
+			input: func(c *config) {
+				c.Server.JWTKeyType = "rsa-2048"
+				c.Server.JWTSigningAlgorithm = "ps256"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, "PS256", c.JWTSigningAlgorithm)
+			},
+		},
+		{
+			msg: "jwt_signing_algorithm is checked against the default jwt_key_type",
+			input: func(c *config) {
+				c.Server.JWTSigningAlgorithm = "ES256"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Equal(t, "ES256", c.JWTSigningAlgorithm)
+			},
+		},
+		{
+			msg:         "jwt_signing_algorithm not matching jwt_key_type returns an error",
+			expectError: true,
+			input: func(c *config) {
+				c.Server.JWTKeyType = "ec-p384"
+				c.Server.JWTSigningAlgorithm = "ES256"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
+		{
+			msg:         "invalid jwt_signing_algorithm returns an error",
+			expectError: true,
+			input: func(c *config) {
+				c.Server.JWTSigningAlgorithm = "HS256"
+			},
+			test: func(t *testing.T, c *server.Config) {
+				require.Nil(t, c)
+			},
+		},
 		{
 			msg: "ca_subject is configured correctly",
 			input: func(c *config) {
@@ -0,0 +1,130 @@
+package svid
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+)
+
+// NewHistoryCommand creates a new "history" subcommand for "svid" command.
+func NewHistoryCommand() cli.Command {
+	return newHistoryCommand(defaultEnv, newClients)
+}
+
+func newHistoryCommand(env *env, clientsMaker clientsMaker) cli.Command {
+	return adaptCommand(env, clientsMaker, new(historyCommand))
+}
+
+type historyCommand struct {
+	spiffeID string
+	agentID  string
+	after    string
+	before   string
+}
+
+func (c *historyCommand) name() string {
+	return "svid history"
+}
+
+func (c *historyCommand) synopsis() string {
+	return "Lists SVIDs issued by the server"
+}
+
+func (c *historyCommand) appendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.spiffeID, "spiffeID", "", "Only list SVIDs issued for this SPIFFE ID")
+	fs.StringVar(&c.agentID, "agentID", "", "Only list SVIDs requested by the agent with this SPIFFE ID")
+	fs.StringVar(&c.after, "after", "", "Only list SVIDs issued at or after this time (RFC3339)")
+	fs.StringVar(&c.before, "before", "", "Only list SVIDs issued before this time (RFC3339)")
+}
+
+func (c *historyCommand) run(ctx context.Context, env *env, clients *clients) error {
+	issuedAfter, err := parseTimeFlag("after", c.after)
+	if err != nil {
+		return err
+	}
+	issuedBefore, err := parseTimeFlag("before", c.before)
+	if err != nil {
+		return err
+	}
+
+	stream, err := clients.r.ListIssuedSVIDs(ctx, &registration.ListIssuedSVIDsRequest{
+		SpiffeId:     c.spiffeID,
+		AgentId:      c.agentID,
+		IssuedAfter:  issuedAfter,
+		IssuedBefore: issuedBefore,
+	})
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	for {
+		svid, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			if err := env.Println(); err != nil {
+				return err
+			}
+		}
+		if err := printIssuedSVID(env, svid); err != nil {
+			return err
+		}
+		count++
+	}
+
+	if count == 0 {
+		return env.Println("No SVIDs found")
+	}
+	return nil
+}
+
+func parseTimeFlag(name, value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid -%s time %q: expected RFC3339 format", name, value)
+	}
+	return t.Unix(), nil
+}
+
+func printIssuedSVID(env *env, svid *registration.IssuedSVID) error {
+	lines := []struct {
+		label string
+		value string
+	}{
+		{"Type", strings.Replace(strings.ToLower(svid.Type.String()), "_", "-", -1)},
+		{"ID", svid.Id},
+		{"SPIFFE ID", svid.SpiffeId},
+		{"Entry ID", svid.EntryId},
+		{"Agent ID", svid.AgentId},
+		{"Authority ID", svid.AuthorityId},
+		{"Not before", formatTime(svid.NotBefore)},
+		{"Not after", formatTime(svid.NotAfter)},
+	}
+	for _, line := range lines {
+		if line.value == "" {
+			continue
+		}
+		if err := env.Printf("%-14s: %s\n", line.label, line.value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func formatTime(seconds int64) string {
+	return time.Unix(seconds, 0).UTC().Format(time.RFC3339)
+}
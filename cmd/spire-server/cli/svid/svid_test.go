@@ -0,0 +1,135 @@
+package svid
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHistory(t *testing.T) {
+	r := &fakeRegistrationClient{
+		svids: []*registration.IssuedSVID{
+			{
+				Type:        registration.IssuedSVID_X509_SVID,
+				Id:          "1234",
+				SpiffeId:    "spiffe://example.org/workload",
+				EntryId:     "ENTRYID",
+				AgentId:     "spiffe://example.org/agent",
+				AuthorityId: "0102",
+				NotBefore:   1000,
+				NotAfter:    2000,
+			},
+			{
+				Type:        registration.IssuedSVID_JWT_SVID,
+				Id:          "abcd",
+				SpiffeId:    "spiffe://example.org/workload",
+				AuthorityId: "KID1",
+				NotBefore:   1500,
+				NotAfter:    2500,
+			},
+		},
+	}
+	stdout, stderr, rc := runCommand(newHistoryCommand, r,
+		"-spiffeID", "spiffe://example.org/workload",
+		"-agentID", "spiffe://example.org/agent",
+		"-after", "1970-01-01T00:16:40Z",
+		"-before", "1970-01-01T00:33:20Z")
+	require.Equal(t, 0, rc, stderr)
+	require.Equal(t, &registration.ListIssuedSVIDsRequest{
+		SpiffeId:     "spiffe://example.org/workload",
+		AgentId:      "spiffe://example.org/agent",
+		IssuedAfter:  1000,
+		IssuedBefore: 2000,
+	}, r.req)
+	require.Equal(t, `Type          : x509-svid
+ID            : 1234
+SPIFFE ID     : spiffe://example.org/workload
+Entry ID      : ENTRYID
+Agent ID      : spiffe://example.org/agent
+Authority ID  : 0102
+Not before    : 1970-01-01T00:16:40Z
+Not after     : 1970-01-01T00:33:20Z
+
+Type          : jwt-svid
+ID            : abcd
+SPIFFE ID     : spiffe://example.org/workload
+Authority ID  : KID1
+Not before    : 1970-01-01T00:25:00Z
+Not after     : 1970-01-01T00:41:40Z
+`, stdout)
+}
+
+func TestHistoryWithNoResults(t *testing.T) {
+	r := &fakeRegistrationClient{}
+	stdout, stderr, rc := runCommand(newHistoryCommand, r)
+	require.Equal(t, 0, rc, stderr)
+	require.Equal(t, &registration.ListIssuedSVIDsRequest{}, r.req)
+	require.Equal(t, "No SVIDs found\n", stdout)
+}
+
+func TestHistoryWithInvalidTime(t *testing.T) {
+	r := &fakeRegistrationClient{}
+	_, stderr, rc := runCommand(newHistoryCommand, r, "-after", "yesterday")
+	require.Equal(t, 1, rc)
+	require.Equal(t, "invalid -after time \"yesterday\": expected RFC3339 format\n", stderr)
+	require.Nil(t, r.req)
+}
+
+func TestHistoryFailure(t *testing.T) {
+	r := &fakeRegistrationClient{
+		err: status.Error(codes.InvalidArgument, `"foo" is not a valid SPIFFE ID`),
+	}
+	_, stderr, rc := runCommand(newHistoryCommand, r, "-spiffeID", "foo")
+	require.Equal(t, 1, rc)
+	require.Equal(t, "rpc error: code = InvalidArgument desc = \"foo\" is not a valid SPIFFE ID\n", stderr)
+}
+
+func runCommand(newCommand func(*env, clientsMaker) cli.Command, r registration.RegistrationClient, args ...string) (string, string, int) {
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	cmd := newCommand(&env{stdout: stdout, stderr: stderr}, func(string) (*clients, error) {
+		return &clients{r: r}, nil
+	})
+	rc := cmd.Run(args)
+	return stdout.String(), stderr.String(), rc
+}
+
+type fakeRegistrationClient struct {
+	registration.RegistrationClient
+
+	svids []*registration.IssuedSVID
+	err   error
+
+	req *registration.ListIssuedSVIDsRequest
+}
+
+func (c *fakeRegistrationClient) ListIssuedSVIDs(ctx context.Context, in *registration.ListIssuedSVIDsRequest, opts ...grpc.CallOption) (registration.Registration_ListIssuedSVIDsClient, error) {
+	c.req = in
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &fakeListIssuedSVIDsClient{svids: c.svids}, nil
+}
+
+type fakeListIssuedSVIDsClient struct {
+	registration.Registration_ListIssuedSVIDsClient
+
+	svids []*registration.IssuedSVID
+}
+
+func (c *fakeListIssuedSVIDsClient) Recv() (*registration.IssuedSVID, error) {
+	if len(c.svids) == 0 {
+		return nil, io.EOF
+	}
+	svid := c.svids[0]
+	c.svids = c.svids[1:]
+	return svid, nil
+}
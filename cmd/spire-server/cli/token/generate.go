@@ -1,6 +1,7 @@
 package token
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"net/url"
@@ -25,6 +26,9 @@ type GenerateConfig struct {
 
 	// Token TTL in seconds
 	TTL int
+
+	// Number of times the token can be used
+	MaxUses int
 }
 
 func (GenerateCLI) Synopsis() string {
@@ -51,7 +55,7 @@ func (g GenerateCLI) Run(args []string) int {
 		return 1
 	}
 
-	token, err := g.createToken(ctx, c, config.TTL)
+	token, err := g.createToken(ctx, c, config.TTL, config.MaxUses)
 	if err != nil {
 		fmt.Println(err.Error())
 		return 1
@@ -73,9 +77,10 @@ func (g GenerateCLI) Run(args []string) int {
 }
 
 // createToken calls the registration API and creates a new token
-// with the given TTL. It returns the raw token and an error, if any
-func (GenerateCLI) createToken(ctx context.Context, c registration.RegistrationClient, ttl int) (string, error) {
-	req := &registration.JoinToken{Ttl: int32(ttl)}
+// with the given TTL and number of uses. It returns the raw token and an
+// error, if any
+func (GenerateCLI) createToken(ctx context.Context, c registration.RegistrationClient, ttl, maxUses int) (string, error) {
+	req := &registration.JoinToken{Ttl: int32(ttl), MaxUses: int32(maxUses)}
 	resp, err := c.CreateJoinToken(ctx, req)
 	if err != nil {
 		return "", err
@@ -119,6 +124,7 @@ func (GenerateCLI) newConfig(args []string) (GenerateConfig, error) {
 	c := GenerateConfig{}
 
 	flags.IntVar(&c.TTL, "ttl", 600, "Token TTL in seconds")
+	flags.IntVar(&c.MaxUses, "maxUses", 1, "Number of times the token can be used")
 	flags.StringVar(&c.SpiffeID, "spiffeID", "", "Additional SPIFFE ID to assign the token owner (optional)")
 	flags.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
 
@@ -127,5 +133,14 @@ func (GenerateCLI) newConfig(args []string) (GenerateConfig, error) {
 		return c, err
 	}
 
+	if c.MaxUses < 1 {
+		return c, errors.New("the token must be usable at least once")
+	}
+	// Nodes attesting with a multi-use token each get their own agent ID,
+	// so there is no single agent to create the vanity record for
+	if c.MaxUses > 1 && c.SpiffeID != "" {
+		return c, errors.New("a SPIFFE ID can only be assigned to single use tokens")
+	}
+
 	return c, nil
 }
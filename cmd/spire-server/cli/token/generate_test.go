@@ -21,11 +21,11 @@ func TestCreateToken(t *testing.T) {
 	defer ctrl.Finish()
 
 	c := mock_registration.NewMockRegistrationClient(ctrl)
-	req := &registration.JoinToken{Ttl: 60}
-	resp := &registration.JoinToken{Token: "foobar", Ttl: 60}
+	req := &registration.JoinToken{Ttl: 60, MaxUses: 1}
+	resp := &registration.JoinToken{Token: "foobar", Ttl: 60, MaxUses: 1}
 
 	c.EXPECT().CreateJoinToken(gomock.Any(), req).Return(resp, nil)
-	token, err := GenerateCLI{}.createToken(ctx, c, 60)
+	token, err := GenerateCLI{}.createToken(ctx, c, 60, 1)
 	require.NoError(t, err)
 	assert.Equal(t, "foobar", token)
 }
@@ -57,3 +57,15 @@ func TestCreateVanityRecord(t *testing.T) {
 	err = GenerateCLI{}.createVanityRecord(ctx, c, token, spiffeID)
 	assert.Error(t, err)
 }
+
+func TestNewConfigValidatesMaxUses(t *testing.T) {
+	_, err := GenerateCLI{}.newConfig([]string{"-maxUses", "0"})
+	assert.EqualError(t, err, "the token must be usable at least once")
+
+	_, err = GenerateCLI{}.newConfig([]string{"-maxUses", "2", "-spiffeID", "spiffe://example.org/VanityID"})
+	assert.EqualError(t, err, "a SPIFFE ID can only be assigned to single use tokens")
+
+	c, err := GenerateCLI{}.newConfig([]string{"-maxUses", "2"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, c.MaxUses)
+}
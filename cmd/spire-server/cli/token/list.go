@@ -0,0 +1,101 @@
+package token
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+
+	"golang.org/x/net/context"
+)
+
+type ListCLI struct{}
+
+type ListConfig struct {
+	// Socket path of registration API
+	RegistrationUDSPath string
+}
+
+func (ListCLI) Synopsis() string {
+	return "Lists outstanding join tokens"
+}
+
+func (l ListCLI) Help() string {
+	_, err := l.newConfig([]string{"-h"})
+	return err.Error()
+}
+
+func (l ListCLI) Run(args []string) int {
+	ctx := context.Background()
+
+	config, err := l.newConfig(args)
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	c, err := util.NewRegistrationClient(config.RegistrationUDSPath)
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	tokens, err := l.listTokens(ctx, c)
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	msg := fmt.Sprintf("Found %d join ", len(tokens))
+	msg = util.Pluralizer(msg, "token", "tokens", len(tokens))
+	fmt.Printf(msg + ":\n\n")
+	for _, token := range tokens {
+		printToken(token)
+	}
+	return 0
+}
+
+// listTokens calls the registration API and returns the outstanding tokens
+func (ListCLI) listTokens(ctx context.Context, c registration.RegistrationClient) ([]*registration.JoinToken, error) {
+	resp, err := c.ListJoinTokens(ctx, &registration.ListJoinTokensRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.JoinTokens, nil
+}
+
+func (ListCLI) newConfig(args []string) (ListConfig, error) {
+	flags := flag.NewFlagSet("list", flag.ContinueOnError)
+	c := ListConfig{}
+
+	flags.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return c, err
+	}
+
+	return c, nil
+}
+
+func printToken(token *registration.JoinToken) {
+	// tokens without a maximum number of uses are single use
+	maxUses := token.MaxUses
+	if maxUses < 1 {
+		maxUses = 1
+	}
+
+	fmt.Printf("Token             : %s\n", token.Token)
+	fmt.Printf("Expiration time   : %s\n", time.Unix(token.Expiry, 0))
+	fmt.Printf("Uses              : %d of %d\n", token.Uses, maxUses)
+	for _, s := range token.NodeSelectors {
+		fmt.Printf("Node selector     : %s:%s\n", s.Type, s.Value)
+	}
+	for _, e := range token.Entries {
+		fmt.Printf("Entry SPIFFE ID   : %s\n", e.SpiffeId)
+	}
+	fmt.Println()
+}
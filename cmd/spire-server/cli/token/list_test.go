@@ -0,0 +1,34 @@
+package token
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/spiffe/spire/test/mock/proto/api/registration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_registration.NewMockRegistrationClient(ctrl)
+	resp := &registration.ListJoinTokensResponse{
+		JoinTokens: []*registration.JoinToken{
+			{Token: "foo", Expiry: 1, MaxUses: 2, Uses: 1},
+			{Token: "bar", Expiry: 2},
+		},
+	}
+
+	c.EXPECT().ListJoinTokens(gomock.Any(), &registration.ListJoinTokensRequest{}).Return(resp, nil)
+	tokens, err := ListCLI{}.listTokens(ctx, c)
+	require.NoError(t, err)
+	assert.Equal(t, resp.JoinTokens, tokens)
+
+	c.EXPECT().ListJoinTokens(gomock.Any(), gomock.Any()).Return(nil, errors.New("oh no"))
+	_, err = ListCLI{}.listTokens(ctx, c)
+	assert.EqualError(t, err, "oh no")
+}
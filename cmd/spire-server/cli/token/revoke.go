@@ -0,0 +1,80 @@
+package token
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/spiffe/spire/cmd/spire-server/util"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+
+	"golang.org/x/net/context"
+)
+
+type RevokeCLI struct{}
+
+type RevokeConfig struct {
+	// Socket path of registration API
+	RegistrationUDSPath string
+
+	// The join token to revoke
+	Token string
+}
+
+func (RevokeCLI) Synopsis() string {
+	return "Revokes a join token so it can no longer be used"
+}
+
+func (r RevokeCLI) Help() string {
+	_, err := r.newConfig([]string{"-h"})
+	return err.Error()
+}
+
+func (r RevokeCLI) Run(args []string) int {
+	ctx := context.Background()
+
+	config, err := r.newConfig(args)
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	c, err := util.NewRegistrationClient(config.RegistrationUDSPath)
+	if err != nil {
+		fmt.Println(err.Error())
+		return 1
+	}
+
+	if err := r.revokeToken(ctx, c, config.Token); err != nil {
+		fmt.Printf("Error revoking token: %s\n", err.Error())
+		return 1
+	}
+
+	fmt.Println("Token revoked successfully")
+	return 0
+}
+
+// revokeToken calls the registration API and deletes the given token
+func (RevokeCLI) revokeToken(ctx context.Context, c registration.RegistrationClient, token string) error {
+	_, err := c.DeleteJoinToken(ctx, &registration.DeleteJoinTokenRequest{Token: token})
+	return err
+}
+
+func (RevokeCLI) newConfig(args []string) (RevokeConfig, error) {
+	flags := flag.NewFlagSet("revoke", flag.ContinueOnError)
+	c := RevokeConfig{}
+
+	flags.StringVar(&c.RegistrationUDSPath, "registrationUDSPath", util.DefaultSocketPath, "Registration API UDS path")
+	flags.StringVar(&c.Token, "token", "", "The join token to revoke")
+
+	err := flags.Parse(args)
+	if err != nil {
+		return c, err
+	}
+
+	if c.Token == "" {
+		return c, errors.New("a join token is required")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,35 @@
+package token
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/spiffe/spire/test/mock/proto/api/registration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRevokeToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	c := mock_registration.NewMockRegistrationClient(ctrl)
+	req := &registration.DeleteJoinTokenRequest{Token: "foobar"}
+	resp := &registration.DeleteJoinTokenResponse{
+		JoinToken: &registration.JoinToken{Token: "foobar"},
+	}
+
+	c.EXPECT().DeleteJoinToken(gomock.Any(), req).Return(resp, nil)
+	err := RevokeCLI{}.revokeToken(ctx, c, "foobar")
+	assert.NoError(t, err)
+
+	c.EXPECT().DeleteJoinToken(gomock.Any(), req).Return(nil, errors.New("no such join token"))
+	err = RevokeCLI{}.revokeToken(ctx, c, "foobar")
+	assert.EqualError(t, err, "no such join token")
+}
+
+func TestRevokeRequiresToken(t *testing.T) {
+	_, err := RevokeCLI{}.newConfig([]string{})
+	assert.EqualError(t, err, "a join token is required")
+}
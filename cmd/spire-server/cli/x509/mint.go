@@ -0,0 +1,129 @@
+package x509
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+)
+
+const (
+	svidFileName   = "svid.pem"
+	keyFileName    = "key.pem"
+	bundleFileName = "bundle.pem"
+)
+
+// NewMintCommand creates a new "mint" subcommand for "x509" command.
+func NewMintCommand() cli.Command {
+	return newMintCommand(defaultEnv, newClients)
+}
+
+func newMintCommand(env *env, clientsMaker clientsMaker) cli.Command {
+	return adaptCommand(env, clientsMaker, new(mintCommand))
+}
+
+type mintCommand struct {
+	spiffeID string
+	ttl      int
+	dnsNames stringsFlag
+	write    string
+}
+
+func (c *mintCommand) name() string {
+	return "x509 mint"
+}
+
+func (c *mintCommand) synopsis() string {
+	return "Mints an X509-SVID"
+}
+
+func (c *mintCommand) appendFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.spiffeID, "spiffeID", "", "SPIFFE ID of the X509-SVID")
+	fs.IntVar(&c.ttl, "ttl", 0, "TTL of the X509-SVID in seconds. If unset, the server default is used. The server caps the TTL")
+	fs.Var(&c.dnsNames, "dns", "DNS name of the X509-SVID. Can be used more than once")
+	fs.StringVar(&c.write, "write", "", "Directory to write the SVID (svid.pem), key (key.pem) and bundle (bundle.pem) to, instead of stdout")
+}
+
+func (c *mintCommand) run(ctx context.Context, env *env, clients *clients) error {
+	if c.spiffeID == "" {
+		return errors.New("a SPIFFE ID is required")
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return fmt.Errorf("unable to generate key: %v", err)
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{}, key)
+	if err != nil {
+		return fmt.Errorf("unable to create CSR: %v", err)
+	}
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return fmt.Errorf("unable to marshal key: %v", err)
+	}
+
+	resp, err := clients.r.MintX509SVID(ctx, &registration.MintX509SVIDRequest{
+		SpiffeId: c.spiffeID,
+		Csr:      csr,
+		Ttl:      int32(c.ttl),
+		DnsNames: c.dnsNames,
+	})
+	if err != nil {
+		return err
+	}
+
+	svidPEM := encodeCertificates(resp.SvidChain)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+	bundlePEM := encodeCertificates(resp.RootCas)
+
+	if c.write == "" {
+		if err := env.Printf("X509-SVID:\n%s\n", svidPEM); err != nil {
+			return err
+		}
+		if err := env.Printf("Private key:\n%s\n", keyPEM); err != nil {
+			return err
+		}
+		return env.Printf("Root CAs:\n%s", bundlePEM)
+	}
+
+	files := []struct {
+		name string
+		data []byte
+		mode os.FileMode
+	}{
+		{svidFileName, svidPEM, 0644},
+		{keyFileName, keyPEM, 0600},
+		{bundleFileName, bundlePEM, 0644},
+	}
+	for _, file := range files {
+		path := filepath.Join(c.write, file.name)
+		if err := ioutil.WriteFile(path, file.data, file.mode); err != nil {
+			return fmt.Errorf("unable to write %s: %v", path, err)
+		}
+	}
+	return env.Printf("X509-SVID written to %s\nPrivate key written to %s\nRoot CAs written to %s\n",
+		filepath.Join(c.write, svidFileName),
+		filepath.Join(c.write, keyFileName),
+		filepath.Join(c.write, bundleFileName))
+}
+
+func encodeCertificates(certs [][]byte) []byte {
+	buf := new(bytes.Buffer)
+	for _, cert := range certs {
+		// encoding to a buffer never fails
+		_ = pem.Encode(buf, &pem.Block{Type: "CERTIFICATE", Bytes: cert})
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,161 @@
+package x509
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestMint(t *testing.T) {
+	r := &fakeRegistrationClient{
+		resp: &registration.MintX509SVIDResponse{
+			SvidChain: [][]byte{[]byte("SVID")},
+			RootCas:   [][]byte{[]byte("ROOT1"), []byte("ROOT2")},
+		},
+	}
+	stdout, stderr, rc := runCommand(newMintCommand, r,
+		"-spiffeID", "spiffe://example.org/workload",
+		"-ttl", "60",
+		"-dns", "one.example.org",
+		"-dns", "two.example.org")
+	require.Equal(t, 0, rc, stderr)
+
+	csr := requireValidCSR(t, r.req.Csr)
+	r.req.Csr = nil
+	require.Equal(t, &registration.MintX509SVIDRequest{
+		SpiffeId: "spiffe://example.org/workload",
+		Ttl:      60,
+		DnsNames: []string{"one.example.org", "two.example.org"},
+	}, r.req)
+
+	blocks := decodePEM([]byte(stdout))
+	require.Len(t, blocks, 4)
+	require.Equal(t, "PRIVATE KEY", blocks[1].Type)
+	requireKeyMatchesCSR(t, blocks[1].Bytes, csr)
+	require.Equal(t, "X509-SVID:\n"+certPEM("SVID")+"\n"+
+		"Private key:\n"+string(pem.EncodeToMemory(blocks[1]))+"\n"+
+		"Root CAs:\n"+certPEM("ROOT1")+certPEM("ROOT2"), stdout)
+}
+
+func TestMintWritesFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spire-server-cli-test-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	r := &fakeRegistrationClient{
+		resp: &registration.MintX509SVIDResponse{
+			SvidChain: [][]byte{[]byte("SVID"), []byte("INTERMEDIATE")},
+			RootCas:   [][]byte{[]byte("ROOT")},
+		},
+	}
+	stdout, stderr, rc := runCommand(newMintCommand, r,
+		"-spiffeID", "spiffe://example.org/workload",
+		"-write", dir)
+	require.Equal(t, 0, rc, stderr)
+	require.Equal(t, "X509-SVID written to "+filepath.Join(dir, "svid.pem")+"\n"+
+		"Private key written to "+filepath.Join(dir, "key.pem")+"\n"+
+		"Root CAs written to "+filepath.Join(dir, "bundle.pem")+"\n", stdout)
+
+	svidPEM, err := ioutil.ReadFile(filepath.Join(dir, "svid.pem"))
+	require.NoError(t, err)
+	require.Equal(t, certPEM("SVID")+certPEM("INTERMEDIATE"), string(svidPEM))
+
+	bundlePEM, err := ioutil.ReadFile(filepath.Join(dir, "bundle.pem"))
+	require.NoError(t, err)
+	require.Equal(t, certPEM("ROOT"), string(bundlePEM))
+
+	info, err := os.Stat(filepath.Join(dir, "key.pem"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	key, err := pemutil.LoadPrivateKey(filepath.Join(dir, "key.pem"))
+	require.NoError(t, err)
+	require.Equal(t, requireValidCSR(t, r.req.Csr).PublicKey, key.(crypto.Signer).Public())
+}
+
+func TestMintWithoutSpiffeID(t *testing.T) {
+	r := &fakeRegistrationClient{}
+	_, stderr, rc := runCommand(newMintCommand, r)
+	require.Equal(t, 1, rc)
+	require.Equal(t, "a SPIFFE ID is required\n", stderr)
+	require.Nil(t, r.req)
+}
+
+func TestMintFailure(t *testing.T) {
+	r := &fakeRegistrationClient{
+		err: status.Error(codes.PermissionDenied, `SPIFFE ID "spiffe://example.org/not-admin" is not authorized`),
+	}
+	_, stderr, rc := runCommand(newMintCommand, r, "-spiffeID", "spiffe://example.org/workload")
+	require.Equal(t, 1, rc)
+	require.Equal(t, "rpc error: code = PermissionDenied desc = SPIFFE ID \"spiffe://example.org/not-admin\" is not authorized\n", stderr)
+}
+
+func runCommand(newCommand func(*env, clientsMaker) cli.Command, r registration.RegistrationClient, args ...string) (string, string, int) {
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+	cmd := newCommand(&env{stdout: stdout, stderr: stderr}, func(string) (*clients, error) {
+		return &clients{r: r}, nil
+	})
+	rc := cmd.Run(args)
+	return stdout.String(), stderr.String(), rc
+}
+
+func decodePEM(data []byte) []*pem.Block {
+	var blocks []*pem.Block
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
+func certPEM(der string) string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte(der)}))
+}
+
+func requireValidCSR(t *testing.T, der []byte) *x509.CertificateRequest {
+	csr, err := x509.ParseCertificateRequest(der)
+	require.NoError(t, err)
+	require.NoError(t, csr.CheckSignature())
+	return csr
+}
+
+func requireKeyMatchesCSR(t *testing.T, keyDER []byte, csr *x509.CertificateRequest) {
+	key, err := x509.ParsePKCS8PrivateKey(keyDER)
+	require.NoError(t, err)
+	require.Equal(t, csr.PublicKey, key.(crypto.Signer).Public())
+}
+
+type fakeRegistrationClient struct {
+	registration.RegistrationClient
+
+	resp *registration.MintX509SVIDResponse
+	err  error
+
+	req *registration.MintX509SVIDRequest
+}
+
+func (c *fakeRegistrationClient) MintX509SVID(ctx context.Context, in *registration.MintX509SVIDRequest, opts ...grpc.CallOption) (*registration.MintX509SVIDResponse, error) {
+	c.req = in
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.resp, nil
+}
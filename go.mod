@@ -45,6 +45,9 @@ require (
 	github.com/imdario/mergo v0.3.7
 	github.com/imkira/go-observer v1.0.3
 	github.com/jinzhu/gorm v1.9.9
+	github.com/lib/pq v1.1.1
+	github.com/mattn/go-sqlite3 v1.10.0
+	github.com/miekg/pkcs11 v1.0.3
 	github.com/mitchellh/cli v1.0.0
 	github.com/morikuni/aec v0.0.0-20170113033406-39771216ff4c // indirect
 	github.com/opencontainers/go-digest v1.0.0-rc1 // indirect
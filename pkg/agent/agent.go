@@ -2,6 +2,7 @@ package agent
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/pprof"
@@ -16,6 +17,7 @@ import (
 	"github.com/spiffe/spire/pkg/agent/manager"
 	common_catalog "github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/hostservices/metricsservice"
+	"github.com/spiffe/spire/pkg/common/nodeutil"
 	"github.com/spiffe/spire/pkg/common/profiling"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/util"
@@ -74,12 +76,12 @@ func (a *Agent) Run(ctx context.Context) error {
 
 	as, err := a.attest(ctx, cat, metrics)
 	if err != nil {
-		return err
+		return a.checkBanned(err)
 	}
 
 	manager, err := a.newManager(ctx, cat, metrics, as)
 	if err != nil {
-		return err
+		return a.checkBanned(err)
 	}
 
 	endpoints := a.newEndpoints(ctx, cat, metrics, manager)
@@ -92,7 +94,23 @@ func (a *Agent) Run(ctx context.Context) error {
 	if err == context.Canceled {
 		err = nil
 	}
-	return err
+	return a.checkBanned(err)
+}
+
+// checkBanned inspects an error that is stopping the agent. If the server
+// reported the agent as banned, the cached agent SVID is removed, so the
+// agent attests again once unbanned, and a clear error is returned instead
+// of the raw status.
+func (a *Agent) checkBanned(err error) error {
+	if !nodeutil.IsAgentBanned(err) {
+		return err
+	}
+
+	a.c.Log.Error("Agent is banned: removing SVID and shutting down")
+	if err := os.Remove(a.agentSVIDPath()); err != nil && !os.IsNotExist(err) {
+		a.c.Log.WithError(err).Warn("Failed to remove agent SVID")
+	}
+	return errors.New("agent is banned")
 }
 
 func (a *Agent) setupProfiling(ctx context.Context) (stop func()) {
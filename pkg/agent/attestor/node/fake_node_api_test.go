@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/nodeutil"
 	"github.com/spiffe/spire/proto/spire/api/node"
 	"github.com/spiffe/spire/proto/spire/common"
 	servernodeattestor "github.com/spiffe/spire/proto/spire/server/nodeattestor"
@@ -35,6 +36,7 @@ type fakeNodeAPIConfig struct {
 	OmitSVIDUpdate     bool
 	OverrideSVIDUpdate *node.X509SVIDUpdate
 	FailAttestCall     bool
+	BanAgent           bool
 }
 
 type fakeNodeAPI struct {
@@ -68,6 +70,10 @@ func (n *fakeNodeAPI) Attest(stream node.Node_AttestServer) error {
 			return errors.New("attestation has been purposefully failed")
 		}
 
+		if n.c.BanAgent {
+			return nodeutil.AgentBannedError()
+		}
+
 		csr, err := x509.ParseCertificateRequest(req.Csr)
 		if err != nil {
 			return err
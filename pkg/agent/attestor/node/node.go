@@ -18,6 +18,7 @@ import (
 	"github.com/spiffe/spire/pkg/agent/manager"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/grpcutil"
+	"github.com/spiffe/spire/pkg/common/nodeutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_agent "github.com/spiffe/spire/pkg/common/telemetry/agent"
 	telemetry_common "github.com/spiffe/spire/pkg/common/telemetry/common"
@@ -290,6 +291,10 @@ func (a *attestor) newSVID(ctx context.Context, key *ecdsa.PrivateKey, bundle *b
 
 		attestResp, err = attestStream.Recv()
 		if err != nil {
+			// the status is returned as is so the agent knows to stop
+			if nodeutil.IsAgentBanned(err) {
+				return nil, nil, err
+			}
 			return nil, nil, fmt.Errorf("attesting to SPIRE server: %v", err)
 		}
 
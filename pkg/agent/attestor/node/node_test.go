@@ -72,6 +72,7 @@ func TestAttestor(t *testing.T) {
 		storeKey                    crypto.PrivateKey
 		failFetchingAttestationData bool
 		failAttestCall              bool
+		banAgent                    bool
 	}{
 		{
 			name: "no bundle available",
@@ -198,6 +199,12 @@ func TestAttestor(t *testing.T) {
 			storeKey:        testKey,
 			failAttestCall:  true,
 		},
+		{
+			name:            "attestation refused for banned agent",
+			bootstrapBundle: caCert,
+			banAgent:        true,
+			err:             "rpc error: code = PermissionDenied desc = agent is banned",
+		},
 		{
 			name:            "malformed cached svid ignored",
 			bootstrapBundle: caCert,
@@ -259,6 +266,7 @@ func TestAttestor(t *testing.T) {
 				OmitSVIDUpdate:     testCase.omitSVIDUpdate,
 				OverrideSVIDUpdate: testCase.overrideSVIDUpdate,
 				FailAttestCall:     testCase.failAttestCall,
+				BanAgent:           testCase.banAgent,
 			})
 			defer serverDone()
 
@@ -127,6 +127,11 @@ func (c *client) FetchUpdates(ctx context.Context, req *node.FetchX509SVIDReques
 	regEntries := map[string]*common.RegistrationEntry{}
 	svids := map[string]*node.X509SVID{}
 	bundles := map[string]*common.Bundle{}
+	update := &Update{
+		Entries: regEntries,
+		SVIDs:   svids,
+		Bundles: bundles,
+	}
 	// Read all the server responses from the stream.
 	for {
 		resp, err := stream.Recv()
@@ -153,12 +158,12 @@ func (c *client) FetchUpdates(ctx context.Context, req *node.FetchX509SVIDReques
 		for trustDomainID, bundle := range resp.SvidUpdate.Bundles {
 			bundles[trustDomainID] = bundle
 		}
+		update.Revision = resp.SvidUpdate.Revision
+		update.Delta = resp.SvidUpdate.Delta
+		update.DeletedEntryIDs = append(update.DeletedEntryIDs, resp.SvidUpdate.DeletedEntryIds...)
+		update.EntryIDsDigest = resp.SvidUpdate.EntryIdsDigest
 	}
-	return &Update{
-		Entries: regEntries,
-		SVIDs:   svids,
-		Bundles: bundles,
-	}, nil
+	return update, nil
 }
 
 func (c *client) FetchJWTSVID(ctx context.Context, jsr *node.JSR) (*JWTSVID, error) {
@@ -49,6 +49,36 @@ func TestFetchUpdates(t *testing.T) {
 	assertNodeConnIsNotNil(t, client)
 }
 
+func TestFetchUpdatesWithDelta(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	nodeClient := mock_node.NewMockNodeClient(ctrl)
+	nodeFsc := mock_node.NewMockNode_FetchX509SVIDClient(ctrl)
+	client := createClient(t, nodeClient)
+
+	req := &node.FetchX509SVIDRequest{Revision: 3}
+	res := newTestFetchX509SVIDResponse()
+	res.SvidUpdate.Revision = 5
+	res.SvidUpdate.Delta = true
+	res.SvidUpdate.DeletedEntryIds = []string{"2"}
+	res.SvidUpdate.EntryIdsDigest = []byte{1, 2, 3}
+
+	nodeClient.EXPECT().FetchX509SVID(gomock.Any()).Return(nodeFsc, nil)
+	nodeFsc.EXPECT().Send(req)
+	nodeFsc.EXPECT().CloseSend()
+	nodeFsc.EXPECT().Recv().Return(res, nil)
+	nodeFsc.EXPECT().Recv().Return(nil, io.EOF)
+
+	update, err := client.FetchUpdates(context.Background(), req)
+	require.Nil(t, err)
+
+	assert.Equal(t, int64(5), update.Revision)
+	assert.True(t, update.Delta)
+	assert.Equal(t, []string{"2"}, update.DeletedEntryIDs)
+	assert.Equal(t, []byte{1, 2, 3}, update.EntryIDsDigest)
+	assert.Equal(t, res.SvidUpdate.RegistrationEntries[0], update.Entries["1"])
+}
+
 func newTestFetchX509SVIDRequest() *node.FetchX509SVIDRequest {
 	return &node.FetchX509SVIDRequest{
 		Csrs: map[string][]byte{
@@ -12,6 +12,19 @@ type Update struct {
 	Entries map[string]*common.RegistrationEntry
 	SVIDs   map[string]*node.X509SVID
 	Bundles map[string]*common.Bundle
+
+	// Revision is the datastore revision the update brings the agent to.
+	Revision int64
+
+	// Delta is true if Entries and Bundles only hold what changed since the
+	// revision provided in the request. Entries that have been deleted since
+	// are listed in DeletedEntryIDs.
+	Delta           bool
+	DeletedEntryIDs []string
+
+	// EntryIDsDigest is the digest of the IDs of all of the entries the agent
+	// is authorized for. It is only set for delta updates.
+	EntryIDsDigest []byte
 }
 
 func (u *Update) String() string {
@@ -248,9 +248,11 @@ func (h *Handler) composeX509SVIDResponse(update *cache.WorkloadUpdate) (*worklo
 	resp.FederatedBundles = make(map[string][]byte)
 
 	bundle := marshalBundle(update.Bundle.RootCAs())
+	resp.Crl = append(resp.Crl, update.Bundle.CRLs()...)
 
 	for id, federatedBundle := range update.FederatedBundles {
 		resp.FederatedBundles[id] = marshalBundle(federatedBundle.RootCAs())
+		resp.Crl = append(resp.Crl, federatedBundle.CRLs()...)
 	}
 
 	for _, identity := range update.Identities {
@@ -213,6 +213,17 @@ func (s *HandlerTestSuite) TestComposeX509Response() {
 	s.Assert().Equal(apiMsg, resp)
 }
 
+func (s *HandlerTestSuite) TestComposeX509ResponseWithCRLs() {
+	update := s.workloadUpdate()
+	update.Bundle = s.bundleWithCRL(update.Bundle, "CRL 1", "CRL 2")
+	federatedBundle := update.FederatedBundles["spiffe://otherdomain.test"]
+	update.FederatedBundles["spiffe://otherdomain.test"] = s.bundleWithCRL(federatedBundle, "FEDERATED CRL")
+
+	resp, err := s.h.composeX509SVIDResponse(update)
+	s.Require().NoError(err)
+	s.Require().Equal([][]byte{[]byte("CRL 1"), []byte("CRL 2"), []byte("FEDERATED CRL")}, resp.Crl)
+}
+
 func (s *HandlerTestSuite) TestFetchJWTSVID() {
 	audience := []string{"foo"}
 
@@ -673,6 +684,16 @@ func (s *HandlerTestSuite) workloadUpdate() *cache.WorkloadUpdate {
 	return update
 }
 
+func (s *HandlerTestSuite) bundleWithCRL(bundle *bundleutil.Bundle, crls ...string) *bundleutil.Bundle {
+	bundleProto := bundle.Proto()
+	for _, crl := range crls {
+		bundleProto.Crls = append(bundleProto.Crls, []byte(crl))
+	}
+	bundle, err := bundleutil.BundleFromProto(bundleProto)
+	s.Require().NoError(err)
+	return bundle
+}
+
 func (s *HandlerTestSuite) requireErrorContains(err error, contains string) {
 	s.Require().Error(err)
 	s.Require().Contains(err.Error(), contains)
@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"bytes"
 	"crypto/ecdsa"
 	"crypto/x509"
 	"sort"
@@ -10,6 +11,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_agent "github.com/spiffe/spire/pkg/common/telemetry/agent"
+	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/proto/spire/common"
 )
 
@@ -33,16 +35,33 @@ type WorkloadUpdate struct {
 // CacheUpdate holds information for an update to the cache.
 type CacheUpdate struct {
 	// Bundles is a set of ALL trust bundles available to the agent, keyed by
-	// trust domain id.
+	// trust domain id. For delta updates, it only holds the bundles that
+	// changed.
 	Bundles map[string]*bundleutil.Bundle
 
 	// RegistrationEntries is a set ALL registration entries available to the
-	// agent, keyed by registration entry id.
+	// agent, keyed by registration entry id. For delta updates, it only holds
+	// the entries that were added or changed.
 	RegistrationEntries map[string]*common.RegistrationEntry
 
 	// X509SVIDs is a set of updated X509-SVIDs that should be merged into
 	// the cache, keyed by registration entry id.
 	X509SVIDs map[string]*X509SVID
+
+	// Revision is the datastore revision the update brings the cache to.
+	Revision int64
+
+	// Delta is true if the update only holds the changes since the revision
+	// currently held by the cache.
+	Delta bool
+
+	// DeletedEntryIDs is a set of registration entry ids that should be
+	// removed from the cache. Only used for delta updates.
+	DeletedEntryIDs []string
+
+	// EntryIDsDigest is the digest of the ids of ALL registration entries
+	// available to the agent. Only used for delta updates.
+	EntryIDsDigest []byte
 }
 
 // X509SVID holds onto the SVID certificate chain and private key.
@@ -108,6 +127,10 @@ type Cache struct {
 
 	// bundles holds the trust bundles, keyed by trust domain id (i.e. "spiffe://domain.test")
 	bundles map[string]*bundleutil.Bundle
+
+	// revision is the datastore revision of the last update applied to the
+	// cache. It is zero if the next update needs to be a full one.
+	revision int64
 }
 
 func New(log logrus.FieldLogger, trustDomainID string, bundle *Bundle, metrics telemetry.Metrics) *Cache {
@@ -175,10 +198,24 @@ func (c *Cache) SubscribeToWorkloadUpdates(selectors []*common.Selector) Subscri
 	return sub
 }
 
+// Revision returns the datastore revision of the last update applied to the
+// cache. Updates fetched from the server should only hold the changes since
+// this revision.
+func (c *Cache) Revision() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.revision
+}
+
 func (c *Cache) Update(update *CacheUpdate, checkSVID func(*common.RegistrationEntry, *X509SVID)) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	c.revision = update.Revision
+	if update.Delta {
+		update = c.applyDelta(update)
+	}
+
 	// Remove bundles that no longer exist. The bundle for the agent trust
 	// domain should NOT be removed even if not present (which should only be
 	// the case if there is a bug on the server) since it is necessary to
@@ -346,6 +383,55 @@ func (c *Cache) Update(update *CacheUpdate, checkSVID func(*common.RegistrationE
 	}
 }
 
+// applyDelta merges a delta update with the cached registration entries and
+// bundles, returning an update that holds ALL of them. If the resulting set of
+// entries does not match the digest provided by the server, the revision is
+// reset so the next update fetched is a full one.
+func (c *Cache) applyDelta(update *CacheUpdate) *CacheUpdate {
+	entries := make(map[string]*common.RegistrationEntry, len(c.records)+len(update.RegistrationEntries))
+	for id, record := range c.records {
+		entries[id] = record.entry
+	}
+	for _, id := range update.DeletedEntryIDs {
+		delete(entries, id)
+	}
+	for id, entry := range update.RegistrationEntries {
+		entries[id] = entry
+	}
+
+	entryIDs := make([]string, 0, len(entries))
+	for id := range entries {
+		entryIDs = append(entryIDs, id)
+	}
+	if !bytes.Equal(util.DeriveEntryIDsDigest(entryIDs), update.EntryIDsDigest) {
+		c.log.Warn("Registration entries out of sync with the server; requesting a full update")
+		c.revision = 0
+	}
+
+	// Only keep the bundles that are still referenced by an entry. The bundle
+	// for the agent trust domain is always kept.
+	bundles := map[string]*bundleutil.Bundle{
+		c.trustDomainID: c.bundles[c.trustDomainID],
+	}
+	for _, entry := range entries {
+		for _, id := range entry.FederatesWith {
+			if bundle, ok := c.bundles[id]; ok {
+				bundles[id] = bundle
+			}
+		}
+	}
+	for id, bundle := range update.Bundles {
+		bundles[id] = bundle
+	}
+
+	return &CacheUpdate{
+		Bundles:             bundles,
+		RegistrationEntries: entries,
+		X509SVIDs:           update.X509SVIDs,
+		Revision:            update.Revision,
+	}
+}
+
 func (c *Cache) updateOrCreateRecord(newEntry *common.RegistrationEntry) (*cacheRecord, *common.RegistrationEntry) {
 	var existingEntry *common.RegistrationEntry
 	record, recordExists := c.records[newEntry.EntryId]
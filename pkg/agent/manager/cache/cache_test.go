@@ -11,6 +11,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_agent "github.com/spiffe/spire/pkg/common/telemetry/agent"
+	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/test/fakes/fakemetrics"
 	"github.com/stretchr/testify/assert"
@@ -485,6 +486,92 @@ func TestCheckSVIDCallback(t *testing.T) {
 	assert.Equal(t, 1, callCount)
 }
 
+func TestDeltaUpdate(t *testing.T) {
+	cache := newTestCache()
+
+	foo := makeRegistrationEntry("FOO", "A")
+	bar := makeRegistrationEntry("BAR", "B")
+	bar.FederatesWith = makeFederatesWith(otherBundleV1)
+	baz := makeRegistrationEntry("BAZ", "C")
+	cache.Update(&CacheUpdate{
+		Bundles:             makeBundles(bundleV1, otherBundleV1),
+		RegistrationEntries: makeRegistrationEntries(foo, bar, baz),
+		X509SVIDs:           makeX509SVIDs(foo, bar, baz),
+		Revision:            1,
+	}, nil)
+	assert.Equal(t, int64(1), cache.Revision())
+
+	subA := cache.SubscribeToWorkloadUpdates(makeSelectors("A"))
+	defer subA.Finish()
+	assertAnyWorkloadUpdate(t, subA)
+	subB := cache.SubscribeToWorkloadUpdates(makeSelectors("B"))
+	defer subB.Finish()
+	assertAnyWorkloadUpdate(t, subB)
+	subD := cache.SubscribeToWorkloadUpdates(makeSelectors("D"))
+	defer subD.Finish()
+	assertAnyWorkloadUpdate(t, subD)
+
+	// Change BAZ selectors, delete BAR (the only entry federating with the
+	// other domain) and leave FOO alone.
+	baz = makeRegistrationEntry("BAZ", "D")
+	var checked []string
+	cache.Update(&CacheUpdate{
+		RegistrationEntries: makeRegistrationEntries(baz),
+		Revision:            3,
+		Delta:               true,
+		DeletedEntryIDs:     []string{"BAR"},
+		EntryIDsDigest:      util.DeriveEntryIDsDigest([]string{"FOO", "BAZ"}),
+	}, func(entry *common.RegistrationEntry, svid *X509SVID) {
+		checked = append(checked, entry.EntryId)
+		assert.NotNil(t, svid)
+	})
+	assert.Equal(t, int64(3), cache.Revision())
+
+	// The unchanged entry is still checked
+	assert.ElementsMatch(t, []string{"FOO", "BAZ"}, checked)
+
+	assertNoWorkloadUpdate(t, subA)
+	assertWorkloadUpdateEqual(t, subB, &WorkloadUpdate{
+		Bundle: bundleV1,
+	})
+	assertWorkloadUpdateEqual(t, subD, &WorkloadUpdate{
+		Bundle:     bundleV1,
+		Identities: []Identity{{Entry: baz}},
+	})
+
+	// The bundle that is no longer referenced has been removed
+	assert.Equal(t, makeBundles(bundleV1), cache.SubscribeToBundleChanges().Value())
+	assert.Len(t, cache.Identities(), 2)
+}
+
+func TestDeltaUpdateWithDigestMismatch(t *testing.T) {
+	cache := newTestCache()
+
+	foo := makeRegistrationEntry("FOO", "A")
+	bar := makeRegistrationEntry("BAR", "B")
+	cache.Update(&CacheUpdate{
+		Bundles:             makeBundles(bundleV1),
+		RegistrationEntries: makeRegistrationEntries(foo, bar),
+		Revision:            1,
+	}, nil)
+
+	// BAR is no longer authorized, but was not deleted. The delta is still
+	// applied, but the revision is reset so a full update is requested.
+	cache.Update(&CacheUpdate{
+		Revision:       2,
+		Delta:          true,
+		EntryIDsDigest: util.DeriveEntryIDsDigest([]string{"FOO"}),
+	}, nil)
+	assert.Equal(t, int64(0), cache.Revision())
+
+	cache.Update(&CacheUpdate{
+		Bundles:             makeBundles(bundleV1),
+		RegistrationEntries: makeRegistrationEntries(foo),
+		Revision:            2,
+	}, nil)
+	assert.Equal(t, int64(2), cache.Revision())
+}
+
 func BenchmarkCacheGlobalNotification(b *testing.B) {
 	cache := newTestCache()
 
@@ -15,6 +15,8 @@ import (
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
 	"github.com/spiffe/spire/pkg/agent/svid"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/jwtsvid"
+	"github.com/spiffe/spire/pkg/common/nodeutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/proto/spire/agent/keymanager"
@@ -133,7 +135,7 @@ func (m *manager) FetchJWTSVID(ctx context.Context, spiffeID string, audience []
 	now := m.clk.Now()
 
 	cachedSVID, ok := m.cache.GetJWTSVID(spiffeID, audience)
-	if ok && !jwtSVIDExpiresSoon(cachedSVID, now) {
+	if ok && !jwtSVIDExpiresSoon(cachedSVID, now) && !m.jwtSVIDSignedByTaintedKey(cachedSVID) {
 		return cachedSVID, nil
 	}
 
@@ -164,7 +166,11 @@ func (m *manager) runSynchronizer(ctx context.Context) error {
 		select {
 		case <-t.C:
 			err := m.synchronize(ctx)
-			if err != nil {
+			switch {
+			case nodeutil.IsAgentBanned(err):
+				// there is no point in retrying until the agent is unbanned
+				return err
+			case err != nil:
 				// Just log the error to keep waiting for next sinchronization...
 				m.c.Log.WithError(err).Error("synchronize failed")
 			}
@@ -245,6 +251,21 @@ func (m *manager) storePrivateKey(ctx context.Context, key *ecdsa.PrivateKey) er
 	return nil
 }
 
+// jwtSVIDSignedByTaintedKey returns true if the JWT-SVID was signed by a key
+// that has been tainted in the trust domain bundle.
+func (m *manager) jwtSVIDSignedByTaintedKey(svid *client.JWTSVID) bool {
+	bundle := m.cache.Bundle()
+	if bundle == nil {
+		return false
+	}
+	keyID, err := jwtsvid.KeyIDFromToken(svid.Token)
+	if err != nil {
+		m.c.Log.WithError(err).Warn("Unable to determine key ID of cached JWT-SVID")
+		return false
+	}
+	return bundle.IsJWTSigningKeyTainted(keyID)
+}
+
 func jwtSVIDExpiresSoon(svid *client.JWTSVID, now time.Time) bool {
 	if jwtSVIDExpired(svid, now) {
 		return true
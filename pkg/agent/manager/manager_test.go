@@ -25,6 +25,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	commonutil "github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/pkg/common/x509util"
 	"github.com/spiffe/spire/proto/spire/agent/keymanager"
 	"github.com/spiffe/spire/proto/spire/api/node"
@@ -668,6 +669,95 @@ func TestSynchronizationUpdatesRegistrationEntries(t *testing.T) {
 		regEntriesFromIdentities(m.cache.Identities()))
 }
 
+func TestSynchronizationWithRevision(t *testing.T) {
+	dir := createTempDir(t)
+	defer removeTempDir(dir)
+
+	l, err := net.Listen("tcp", "localhost:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	var revisionsMtx sync.Mutex
+	var revisions []int64
+	fetch := func(h *mockNodeAPIHandler, req *node.FetchX509SVIDRequest, stream node.Node_FetchX509SVIDServer) error {
+		revisionsMtx.Lock()
+		revisions = append(revisions, req.Revision)
+		revisionsMtx.Unlock()
+
+		svids, err := h.makeSvids(req.Csrs)
+		if err != nil {
+			return err
+		}
+
+		// The first request gets a full update. Later ones only get the
+		// changes, which are none.
+		resp := newFetchX509SVIDResponse([]string{"resp2"}, svids, h.bundle)
+		resp.SvidUpdate.Revision = 5
+		if req.Revision > 0 {
+			var entryIDs []string
+			for _, entry := range resp.SvidUpdate.RegistrationEntries {
+				entryIDs = append(entryIDs, entry.EntryId)
+			}
+			resp.SvidUpdate.Delta = true
+			resp.SvidUpdate.RegistrationEntries = nil
+			resp.SvidUpdate.Bundles = nil
+			resp.SvidUpdate.EntryIdsDigest = commonutil.DeriveEntryIDsDigest(entryIDs)
+		}
+		return stream.Send(resp)
+	}
+
+	clk := clock.New()
+	apiHandler := newMockNodeAPIHandler(&mockNodeAPIHandlerConfig{
+		t:             t,
+		trustDomain:   trustDomain,
+		listener:      l,
+		fetchX509SVID: fetch,
+		svidTTL:       3,
+	}, clk)
+	apiHandler.start()
+	defer apiHandler.stop()
+
+	baseSVID, baseSVIDKey := apiHandler.newSVID("spiffe://"+trustDomain+"/spire/agent/join_token/abcd", 1*time.Hour)
+	cat := fakeagentcatalog.New()
+	cat.SetKeyManager(fakeagentcatalog.KeyManager(disk.New()))
+
+	c := &Config{
+		ServerAddr:      l.Addr().String(),
+		SVID:            baseSVID,
+		SVIDKey:         baseSVIDKey,
+		Log:             testLogger,
+		TrustDomain:     trustDomainID,
+		SVIDCachePath:   path.Join(dir, "svid.der"),
+		BundleCachePath: path.Join(dir, "bundle.der"),
+		Bundle:          apiHandler.bundle,
+		Metrics:         &telemetry.Blackhole{},
+		Clk:             clk,
+		Catalog:         cat,
+	}
+
+	m := newManager(t, c)
+
+	if err := m.Initialize(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.synchronize(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// The entries from the full update are kept, and signed, even though
+	// the following updates only held the changes.
+	compareRegistrationEntries(t,
+		regEntriesMap["resp2"],
+		regEntriesFromIdentities(m.cache.Identities()))
+	require.Equal(t, int64(5), m.cache.Revision())
+
+	revisionsMtx.Lock()
+	defer revisionsMtx.Unlock()
+	require.Equal(t, []int64{0, 5, 5}, revisions)
+}
+
 func TestSubscribersGetUpToDateBundle(t *testing.T) {
 	dir := createTempDir(t)
 	defer removeTempDir(dir)
@@ -1199,6 +1289,10 @@ func (h *mockNodeAPIHandler) FetchX509CASVID(ctx context.Context, req *node.Fetc
 	return nil, errors.New("oh noes")
 }
 
+func (h *mockNodeAPIHandler) PushJWTKeyUpstream(ctx context.Context, req *node.PushJWTKeyUpstreamRequest) (*node.PushJWTKeyUpstreamResponse, error) {
+	return nil, errors.New("oh noes")
+}
+
 func (h *mockNodeAPIHandler) start() {
 	s := grpc.NewServer(h.creds)
 	node.RegisterNodeServer(s, h)
@@ -37,6 +37,10 @@ func (m *manager) synchronize(ctx context.Context) (err error) {
 	// the values in `update` now belong to the cache. DO NOT MODIFY.
 	var csrs []csrRequest
 	var expiring int
+	bundle := update.Bundles[m.c.TrustDomain.String()]
+	if bundle == nil {
+		bundle = m.cache.Bundle()
+	}
 	m.cache.Update(update, func(entry *common.RegistrationEntry, svid *cache.X509SVID) {
 		var expiresAt time.Time
 		switch {
@@ -52,6 +56,13 @@ func (m *manager) synchronize(ctx context.Context) (err error) {
 			// SVID has expired
 			expiresAt = svid.Chain[0].NotAfter
 			expiring++
+		case bundle != nil && bundle.IssuedByTaintedRootCA(svid.Chain[0]):
+			// SVID was signed by a tainted authority
+			m.c.Log.WithFields(logrus.Fields{
+				telemetry.RegistrationID: entry.EntryId,
+				telemetry.SPIFFEID:       entry.SpiffeId,
+			}).Info("Cached X509 SVID was signed by a tainted authority; rotating")
+			expiresAt = svid.Chain[0].NotAfter
 		default:
 			// SVID is good
 			return
@@ -83,8 +94,10 @@ func (m *manager) fetchUpdates(ctx context.Context, csrs []csrRequest) (_ *cache
 	counter := telemetry_agent.StartManagerFetchUpdatesCall(m.c.Metrics)
 	defer counter.Done(&err)
 
+	// Only the changes since the revision held by the cache are requested.
 	req := &node.FetchX509SVIDRequest{
-		Csrs: make(map[string][]byte),
+		Csrs:     make(map[string][]byte),
+		Revision: m.cache.Revision(),
 	}
 
 	privateKeys := make(map[string]*ecdsa.PrivateKey, len(csrs))
@@ -141,6 +154,10 @@ func (m *manager) fetchUpdates(ctx context.Context, csrs []csrRequest) (_ *cache
 		Bundles:             bundles,
 		RegistrationEntries: update.Entries,
 		X509SVIDs:           byEntryID,
+		Revision:            update.Revision,
+		Delta:               update.Delta,
+		DeletedEntryIDs:     update.DeletedEntryIDs,
+		EntryIDsDigest:      update.EntryIDsDigest,
 	}, nil
 }
 
@@ -11,6 +11,7 @@ import (
 	"github.com/andres-erbsen/clock"
 	observer "github.com/imkira/go-observer"
 	"github.com/spiffe/spire/pkg/agent/client"
+	"github.com/spiffe/spire/pkg/common/nodeutil"
 	telemetry_agent "github.com/spiffe/spire/pkg/common/telemetry/agent"
 	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/proto/spire/agent/keymanager"
@@ -54,7 +55,13 @@ func (r *rotator) Run(ctx context.Context) error {
 			return nil
 		case <-t.C:
 			if r.shouldRotate() {
-				if err := r.rotateSVID(ctx); err != nil {
+				err := r.rotateSVID(ctx)
+				switch {
+				case nodeutil.IsAgentBanned(err):
+					// there is no point in retrying until the agent is unbanned
+					r.client.Release()
+					return err
+				case err != nil:
 					r.c.Log.WithError(err).Error("Could not rotate agent SVID")
 				}
 			}
@@ -82,7 +89,22 @@ func (r *rotator) shouldRotate() bool {
 	ttl := s.SVID[0].NotAfter.Sub(r.clk.Now())
 	watermark := s.SVID[0].NotAfter.Sub(s.SVID[0].NotBefore) / 2
 
-	return ttl <= watermark
+	return ttl <= watermark || r.isSignedByTaintedAuthority(s.SVID[0])
+}
+
+// isSignedByTaintedAuthority returns true if the SVID was signed by an
+// authority that has been tainted in the trust domain bundle.
+func (r *rotator) isSignedByTaintedAuthority(svid *x509.Certificate) bool {
+	r.bsm.RLock()
+	bundles := r.c.BundleStream.Value()
+	r.bsm.RUnlock()
+
+	bundle := bundles[r.c.TrustDomain.String()]
+	if bundle == nil || !bundle.IssuedByTaintedRootCA(svid) {
+		return false
+	}
+	r.c.Log.Info("Agent SVID was signed by a tainted authority; rotating")
+	return true
 }
 
 // rotateSVID asks SPIRE's server for a new agent's SVID.
@@ -13,6 +13,8 @@ import (
 	"github.com/spiffe/spire/pkg/agent/client"
 	"github.com/spiffe/spire/pkg/agent/manager/cache"
 	"github.com/spiffe/spire/pkg/agent/plugin/keymanager/memory"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/pkg/common/nodeutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/proto/spire/api/node"
 	"github.com/spiffe/spire/test/clock"
@@ -48,7 +50,9 @@ func (s *RotatorTestSuite) SetupTest() {
 
 	b, err := util.LoadBundleFixture()
 	s.Require().NoError(err)
-	s.bundle = observer.NewProperty(b)
+	s.bundle = observer.NewProperty(map[string]*bundleutil.Bundle{
+		"spiffe://example.org": bundleutil.BundleFromRootCAs("spiffe://example.org", b),
+	})
 
 	cat := fakeagentcatalog.New()
 	cat.SetKeyManager(fakeagentcatalog.KeyManager(memory.New()))
@@ -153,6 +157,39 @@ func (s *RotatorTestSuite) TestRunWithUpdates() {
 	s.Require().NoError(t.Wait())
 }
 
+func (s *RotatorTestSuite) TestRunStopsWhenAgentIsBanned() {
+	temp, err := util.NewSVIDTemplate(s.mockClock, "spiffe://example.org/test")
+	s.Require().NoError(err)
+	temp.NotBefore = s.mockClock.Now().Add(-1 * time.Hour)
+	temp.NotAfter = s.mockClock.Now()
+	badCert, _, err := util.SelfSign(temp)
+	s.Require().NoError(err)
+
+	s.r.state = observer.NewProperty(State{
+		SVID: []*x509.Certificate{badCert},
+	})
+
+	s.client.EXPECT().
+		FetchUpdates(gomock.Any(), gomock.Any()).
+		Return(nil, nodeutil.AgentBannedError())
+	s.client.EXPECT().Release()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.r.Run(context.Background())
+	}()
+
+	s.mockClock.WaitForTicker(time.Second, "timed out waiting for rotator to create a ticker")
+	s.mockClock.Add(s.r.c.Interval)
+
+	select {
+	case <-time.After(time.Second):
+		s.T().Error("timed out while waiting for the rotator to stop")
+	case err := <-errCh:
+		s.Require().True(nodeutil.IsAgentBanned(err), "expected banned error; got %v", err)
+	}
+}
+
 func (s *RotatorTestSuite) TestShouldRotate() {
 	// Cert that's valid for 1hr
 	temp, err := util.NewSVIDTemplate(s.mockClock, "spiffe://example.org/test")
@@ -179,6 +216,36 @@ func (s *RotatorTestSuite) TestShouldRotate() {
 	s.Assert().True(s.r.shouldRotate())
 }
 
+func (s *RotatorTestSuite) TestShouldRotateWhenAuthorityTainted() {
+	caTemp, err := util.NewCATemplate(s.mockClock, "example.org")
+	s.Require().NoError(err)
+	caCert, caKey, err := util.SelfSign(caTemp)
+	s.Require().NoError(err)
+
+	temp, err := util.NewSVIDTemplate(s.mockClock, "spiffe://example.org/spire/agent/1234")
+	s.Require().NoError(err)
+	cert, _, err := util.Sign(temp, caCert, caKey)
+	s.Require().NoError(err)
+
+	s.r.state = observer.NewProperty(State{
+		SVID: []*x509.Certificate{cert},
+	})
+
+	bundle := bundleutil.BundleFromRootCA("spiffe://example.org", caCert)
+	s.bundle.Update(map[string]*bundleutil.Bundle{"spiffe://example.org": bundle})
+	s.r.c.BundleStream.Next()
+	s.Assert().False(s.r.shouldRotate())
+
+	taintedBundle, ok, err := bundleutil.TaintX509Authority(bundle.Proto(), bundleutil.X509AuthorityID(caCert))
+	s.Require().NoError(err)
+	s.Require().True(ok)
+	tainted, err := bundleutil.BundleFromProto(taintedBundle)
+	s.Require().NoError(err)
+	s.bundle.Update(map[string]*bundleutil.Bundle{"spiffe://example.org": tainted})
+	s.r.c.BundleStream.Next()
+	s.Assert().True(s.r.shouldRotate())
+}
+
 func (s *RotatorTestSuite) TestRotateSVID() {
 	cert, _, err := util.LoadSVIDFixture()
 	s.Require().NoError(err)
@@ -18,11 +18,23 @@ func (fn AuthorizerFunc) AuthorizeCall(ctx context.Context, fullMethod string) (
 	return fn(ctx, fullMethod)
 }
 
+// RequestAuthorizer is optionally implemented by servers that also authorize
+// the requests received on a call, once the call itself is authorized.
+// Requests received on streams are authorized as they are received.
+type RequestAuthorizer interface {
+	AuthorizeRequest(ctx context.Context, fullMethod string, req interface{}) error
+}
+
 func UnaryAuthorizeCall(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 	ctx, err := authorizeCall(ctx, info.Server, info.FullMethod)
 	if err != nil {
 		return nil, err
 	}
+	if authorizer, ok := info.Server.(RequestAuthorizer); ok {
+		if err := authorizer.AuthorizeRequest(ctx, info.FullMethod, req); err != nil {
+			return nil, err
+		}
+	}
 	return handler(ctx, req)
 }
 
@@ -32,9 +44,12 @@ func StreamAuthorizeCall(srv interface{}, ss grpc.ServerStream, info *grpc.Strea
 		return err
 	}
 
+	authorizer, _ := srv.(RequestAuthorizer)
 	return handler(srv, serverStream{
 		ServerStream: ss,
 		ctx:          ctx,
+		fullMethod:   info.FullMethod,
+		authorizer:   authorizer,
 	})
 }
 
@@ -46,12 +61,25 @@ func authorizeCall(ctx context.Context, srv interface{}, fullMethod string) (con
 	return authorizer.AuthorizeCall(ctx, fullMethod)
 }
 
-// used to override the context on a stream
+// used to override the context on a stream and to authorize the requests
+// received on it
 type serverStream struct {
 	grpc.ServerStream
-	ctx context.Context
+	ctx        context.Context
+	fullMethod string
+	authorizer RequestAuthorizer
 }
 
 func (s serverStream) Context() context.Context {
 	return s.ctx
 }
+
+func (s serverStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.authorizer != nil {
+		return s.authorizer.AuthorizeRequest(s.ctx, s.fullMethod, m)
+	}
+	return nil
+}
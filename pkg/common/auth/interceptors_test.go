@@ -75,3 +75,72 @@ func TestStreamAuthorizeCall(t *testing.T) {
 	err = StreamAuthorizeCall(server, stream, info, handler)
 	require.EqualError(t, err, "error")
 }
+
+func TestUnaryAuthorizeRequest(t *testing.T) {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "resp", nil
+	}
+
+	// request authorizer fails authorization
+	server := requestAuthorizer{err: errors.New("no auth for this request")}
+	resp, err := UnaryAuthorizeCall(context.Background(), "req", &grpc.UnaryServerInfo{
+		Server:     &server,
+		FullMethod: "FOO",
+	}, handler)
+	require.EqualError(t, err, "no auth for this request")
+	require.Nil(t, resp)
+	require.Equal(t, []interface{}{"req"}, server.reqs)
+
+	// success
+	server = requestAuthorizer{}
+	resp, err = UnaryAuthorizeCall(context.Background(), "req", &grpc.UnaryServerInfo{
+		Server:     &server,
+		FullMethod: "FOO",
+	}, handler)
+	require.NoError(t, err)
+	require.Equal(t, "resp", resp)
+	require.Equal(t, []interface{}{"req"}, server.reqs)
+}
+
+func TestStreamAuthorizeRequest(t *testing.T) {
+	stream := serverStream{ServerStream: fakeServerStream{}, ctx: context.Background()}
+	info := &grpc.StreamServerInfo{FullMethod: "FOO"}
+	handler := func(server interface{}, stream grpc.ServerStream) error {
+		var req string
+		return stream.RecvMsg(&req)
+	}
+
+	// request authorizer fails authorization
+	server := requestAuthorizer{err: errors.New("no auth for this request")}
+	err := StreamAuthorizeCall(&server, stream, info, handler)
+	require.EqualError(t, err, "no auth for this request")
+	require.Len(t, server.reqs, 1)
+
+	// success
+	server = requestAuthorizer{}
+	err = StreamAuthorizeCall(&server, stream, info, handler)
+	require.NoError(t, err)
+	require.Len(t, server.reqs, 1)
+}
+
+type requestAuthorizer struct {
+	err  error
+	reqs []interface{}
+}
+
+func (a *requestAuthorizer) AuthorizeCall(ctx context.Context, fullMethod string) (context.Context, error) {
+	return ctx, nil
+}
+
+func (a *requestAuthorizer) AuthorizeRequest(ctx context.Context, fullMethod string, req interface{}) error {
+	a.reqs = append(a.reqs, req)
+	return a.err
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+}
+
+func (fakeServerStream) RecvMsg(m interface{}) error {
+	return nil
+}
@@ -1,8 +1,10 @@
 package bundleutil
 
 import (
+	"bytes"
 	"crypto"
 	"crypto/x509"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"time"
@@ -77,8 +79,10 @@ func (b *Bundle) TrustDomainID() string {
 	return b.b.TrustDomainId
 }
 
+// EqualTo returns true if the bundles have the same contents. The revision
+// assigned by the datastore is not compared.
 func (b *Bundle) EqualTo(other *Bundle) bool {
-	return proto.Equal(b.b, other.b)
+	return proto.Equal(withoutRevision(b.b), withoutRevision(other.b))
 }
 
 func (b *Bundle) RootCAs() []*x509.Certificate {
@@ -89,6 +93,42 @@ func (b *Bundle) JWTSigningKeys() map[string]crypto.PublicKey {
 	return b.jwtSigningKeys
 }
 
+// IssuedByTaintedRootCA returns true if the certificate was issued by a root
+// CA in the bundle whose key has been tainted, or by an X509 authority the
+// bundle lists as tainted.
+func (b *Bundle) IssuedByTaintedRootCA(cert *x509.Certificate) bool {
+	if len(cert.AuthorityKeyId) > 0 && stringInList(hex.EncodeToString(cert.AuthorityKeyId), b.b.TaintedX509AuthorityIds) {
+		return true
+	}
+	for i, rootCA := range b.b.RootCas {
+		if !rootCA.TaintedKey || i >= len(b.rootCAs) {
+			continue
+		}
+		taintedCA := b.rootCAs[i]
+		if len(cert.AuthorityKeyId) > 0 && len(taintedCA.SubjectKeyId) > 0 {
+			if bytes.Equal(cert.AuthorityKeyId, taintedCA.SubjectKeyId) {
+				return true
+			}
+			continue
+		}
+		if cert.CheckSignatureFrom(taintedCA) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// IsJWTSigningKeyTainted returns true if the JWT signing key with the given
+// key ID has been tainted.
+func (b *Bundle) IsJWTSigningKeyTainted(kid string) bool {
+	for _, jwtSigningKey := range b.b.JwtSigningKeys {
+		if jwtSigningKey.Kid == kid {
+			return jwtSigningKey.TaintedKey
+		}
+	}
+	return false
+}
+
 // RefreshHint returns the bundle refresh hint.
 func (b *Bundle) RefreshHint() time.Duration {
 	return time.Second * time.Duration(b.b.RefreshHint)
@@ -100,6 +140,12 @@ func (b *Bundle) SetRefreshHint(d time.Duration) {
 	b.b.RefreshHint = int64((d + (time.Second - 1)) / time.Second)
 }
 
+// CRLs returns the DER encoded certificate revocation lists published with
+// the bundle, one per issuing X509 CA.
+func (b *Bundle) CRLs() [][]byte {
+	return b.b.Crls
+}
+
 func (b *Bundle) AppendRootCA(rootCA *x509.Certificate) {
 	b.b.RootCas = append(b.b.RootCas, &common.Certificate{
 		DerBytes: rootCA.Raw,
@@ -184,28 +230,144 @@ func JWTSigningKeysFromBundleProto(b *common.Bundle) (map[string]crypto.PublicKe
 	return out, nil
 }
 
+// X509AuthorityID returns the identifier used to refer to an X509 authority,
+// i.e. the hex encoded subject key ID of the CA certificate.
+func X509AuthorityID(cert *x509.Certificate) string {
+	return hex.EncodeToString(cert.SubjectKeyId)
+}
+
+// TaintX509Authority marks the root CA identified by the authority ID as
+// tainted. It returns false if the root CA could not be found in the bundle.
+func TaintX509Authority(bundle *common.Bundle, authorityID string) (*common.Bundle, bool, error) {
+	bundle = cloneBundle(bundle)
+	for _, rootCA := range bundle.RootCas {
+		cert, err := x509.ParseCertificate(rootCA.DerBytes)
+		if err != nil {
+			return nil, false, fmt.Errorf("cannot parse certificate: %v", err)
+		}
+		if X509AuthorityID(cert) == authorityID {
+			rootCA.TaintedKey = true
+			return bundle, true, nil
+		}
+	}
+	return nil, false, nil
+}
+
+// AddTaintedX509Authority lists the X509 authority as tainted in the bundle.
+// It is used to taint an X509 authority that is not a root CA in the bundle,
+// e.g. an intermediate CA signed by an upstream root, without trusting it as
+// a root CA.
+func AddTaintedX509Authority(bundle *common.Bundle, authorityID string) *common.Bundle {
+	bundle = cloneBundle(bundle)
+	if !stringInList(authorityID, bundle.TaintedX509AuthorityIds) {
+		bundle.TaintedX509AuthorityIds = append(bundle.TaintedX509AuthorityIds, authorityID)
+	}
+	return bundle
+}
+
+// TaintJWTAuthority marks the JWT signing key with the given key ID as
+// tainted. It returns false if the key could not be found in the bundle.
+func TaintJWTAuthority(bundle *common.Bundle, kid string) (*common.Bundle, bool) {
+	bundle = cloneBundle(bundle)
+	for _, jwtSigningKey := range bundle.JwtSigningKeys {
+		if jwtSigningKey.Kid == kid {
+			jwtSigningKey.TaintedKey = true
+			return bundle, true
+		}
+	}
+	return nil, false
+}
+
+// RemoveTaintedAuthorities removes the tainted root CAs, tainted X509
+// authority IDs and JWT signing keys identified by the given authority IDs and
+// key IDs from the bundle. Only authorities that have been tainted are
+// removed.
+func RemoveTaintedAuthorities(bundle *common.Bundle, x509AuthorityIDs, jwtKeyIDs []string) (*common.Bundle, bool, error) {
+	newBundle := cloneBundle(bundle)
+	newBundle.RootCas = nil
+	newBundle.JwtSigningKeys = nil
+	newBundle.TaintedX509AuthorityIds = nil
+
+	changed := false
+	for _, authorityID := range bundle.TaintedX509AuthorityIds {
+		if stringInList(authorityID, x509AuthorityIDs) {
+			changed = true
+			continue
+		}
+		newBundle.TaintedX509AuthorityIds = append(newBundle.TaintedX509AuthorityIds, authorityID)
+	}
+
+	for _, rootCA := range bundle.RootCas {
+		if rootCA.TaintedKey {
+			cert, err := x509.ParseCertificate(rootCA.DerBytes)
+			if err != nil {
+				return nil, false, fmt.Errorf("cannot parse certificate: %v", err)
+			}
+			if stringInList(X509AuthorityID(cert), x509AuthorityIDs) {
+				changed = true
+				continue
+			}
+		}
+		newBundle.RootCas = append(newBundle.RootCas, rootCA)
+	}
+
+	for _, jwtSigningKey := range bundle.JwtSigningKeys {
+		if jwtSigningKey.TaintedKey && stringInList(jwtSigningKey.Kid, jwtKeyIDs) {
+			changed = true
+			continue
+		}
+		newBundle.JwtSigningKeys = append(newBundle.JwtSigningKeys, jwtSigningKey)
+	}
+
+	return newBundle, changed, nil
+}
+
+// MergeBundles adds the root CAs and JWT signing keys of b missing from a.
+// Root CAs are the same if their DER bytes are, and JWT signing keys if their
+// key IDs are. An authority tainted in either bundle is tainted in the merged
+// bundle, so merging an untainted copy can't restore trust in it.
 func MergeBundles(a, b *common.Bundle) (*common.Bundle, bool) {
 	c := cloneBundle(a)
 
-	rootCAs := make(map[string]bool)
-	for _, rootCA := range a.RootCas {
-		rootCAs[rootCA.String()] = true
+	rootCAs := make(map[string]*common.Certificate)
+	for _, rootCA := range c.RootCas {
+		rootCAs[string(rootCA.DerBytes)] = rootCA
 	}
-	jwtSigningKeys := make(map[string]bool)
-	for _, jwtSigningKey := range a.JwtSigningKeys {
-		jwtSigningKeys[jwtSigningKey.String()] = true
+	jwtSigningKeys := make(map[string]*common.PublicKey)
+	for _, jwtSigningKey := range c.JwtSigningKeys {
+		jwtSigningKeys[jwtSigningKey.Kid] = jwtSigningKey
 	}
 
 	var changed bool
 	for _, rootCA := range b.RootCas {
-		if !rootCAs[rootCA.String()] {
+		existing, ok := rootCAs[string(rootCA.DerBytes)]
+		switch {
+		case !ok:
+			rootCA = proto.Clone(rootCA).(*common.Certificate)
 			c.RootCas = append(c.RootCas, rootCA)
+			rootCAs[string(rootCA.DerBytes)] = rootCA
+			changed = true
+		case rootCA.TaintedKey && !existing.TaintedKey:
+			existing.TaintedKey = true
 			changed = true
 		}
 	}
 	for _, jwtSigningKey := range b.JwtSigningKeys {
-		if !jwtSigningKeys[jwtSigningKey.String()] {
+		existing, ok := jwtSigningKeys[jwtSigningKey.Kid]
+		switch {
+		case !ok:
+			jwtSigningKey = proto.Clone(jwtSigningKey).(*common.PublicKey)
 			c.JwtSigningKeys = append(c.JwtSigningKeys, jwtSigningKey)
+			jwtSigningKeys[jwtSigningKey.Kid] = jwtSigningKey
+			changed = true
+		case jwtSigningKey.TaintedKey && !existing.TaintedKey:
+			existing.TaintedKey = true
+			changed = true
+		}
+	}
+	for _, authorityID := range b.TaintedX509AuthorityIds {
+		if !stringInList(authorityID, c.TaintedX509AuthorityIds) {
+			c.TaintedX509AuthorityIds = append(c.TaintedX509AuthorityIds, authorityID)
 			changed = true
 		}
 	}
@@ -227,6 +389,7 @@ func PruneBundle(bundle *common.Bundle, expiration time.Time, log hclog.Logger)
 	// Creates new bundle with non expired certs only
 	newBundle := &common.Bundle{
 		TrustDomainId: bundle.TrustDomainId,
+		Crls:          bundle.Crls,
 	}
 	changed := false
 pruneRootCA:
@@ -272,3 +435,21 @@ pruneRootCA:
 func cloneBundle(b *common.Bundle) *common.Bundle {
 	return proto.Clone(b).(*common.Bundle)
 }
+
+func stringInList(s string, ss []string) bool {
+	for _, candidate := range ss {
+		if s == candidate {
+			return true
+		}
+	}
+	return false
+}
+
+func withoutRevision(b *common.Bundle) *common.Bundle {
+	if b.RevisionNumber == 0 {
+		return b
+	}
+	b = cloneBundle(b)
+	b.RevisionNumber = 0
+	return b
+}
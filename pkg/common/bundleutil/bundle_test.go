@@ -2,7 +2,11 @@ package bundleutil
 
 // Basic imports
 import (
+	"crypto/rand"
 	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"math/big"
 	"testing"
 	"time"
 
@@ -47,6 +51,18 @@ func (s *BundleUtilSuite) SetupTest() {
 	s.jwtKeyNotExpired = &common.PublicKey{NotAfter: nonExpiredKeyTime.Unix()}
 }
 
+func (s *BundleUtilSuite) TestEqualToIgnoresRevision() {
+	a := BundleFromRootCA("spiffe://example.org", s.certNotExpired)
+	b := BundleFromRootCA("spiffe://example.org", s.certNotExpired)
+	b.b.RevisionNumber = 2
+	s.True(a.EqualTo(b))
+	s.True(b.EqualTo(a))
+	s.Equal(int64(2), b.b.RevisionNumber)
+
+	c := BundleFromRootCA("spiffe://example.org", s.certExpired)
+	s.False(a.EqualTo(c))
+}
+
 func (s *BundleUtilSuite) TestPruneBundleFailIfNilBundle() {
 	newBundle, changed, err := PruneBundle(nil, time.Now(), hclog.NewNullLogger())
 	s.AssertErrorContains(err, "current bundle is nil")
@@ -107,6 +123,232 @@ func (s *BundleUtilSuite) TestPruneBundleSucceeds() {
 	s.True(changed)
 }
 
+func (s *BundleUtilSuite) TestPruneBundleKeepsCRL() {
+	bundle := s.createBundle(
+		[]*x509.Certificate{s.certNotExpired, s.certExpired},
+		[]*common.PublicKey{s.jwtKeyNotExpired},
+	)
+	bundle.Crls = [][]byte{[]byte("CRL")}
+
+	newBundle, changed, err := PruneBundle(bundle, s.currentTime, hclog.NewNullLogger())
+	s.NoError(err)
+	s.True(changed)
+	s.Equal([][]byte{[]byte("CRL")}, newBundle.Crls)
+}
+
+func (s *BundleUtilSuite) TestTaintX509Authority() {
+	ca1 := s.createCA(1)
+	ca2 := s.createCA(2)
+	bundle := s.createBundle([]*x509.Certificate{ca1, ca2}, nil)
+
+	newBundle, ok, err := TaintX509Authority(bundle, X509AuthorityID(ca2))
+	s.Require().NoError(err)
+	s.Require().True(ok)
+	s.False(newBundle.RootCas[0].TaintedKey)
+	s.True(newBundle.RootCas[1].TaintedKey)
+	// the original bundle is untouched
+	s.False(bundle.RootCas[1].TaintedKey)
+
+	newBundle, ok, err = TaintX509Authority(bundle, "deadbeef")
+	s.Require().NoError(err)
+	s.False(ok)
+	s.Nil(newBundle)
+}
+
+func (s *BundleUtilSuite) TestAddTaintedX509Authority() {
+	ca1 := s.createCA(1)
+	ca2 := s.createCA(2)
+	bundle := s.createBundle([]*x509.Certificate{ca1}, nil)
+
+	// the authority is listed as tainted without being added as a root CA
+	newBundle := AddTaintedX509Authority(bundle, X509AuthorityID(ca2))
+	s.Require().Len(newBundle.RootCas, 1)
+	s.False(newBundle.RootCas[0].TaintedKey)
+	s.Equal([]string{X509AuthorityID(ca2)}, newBundle.TaintedX509AuthorityIds)
+	// the original bundle is untouched
+	s.Empty(bundle.TaintedX509AuthorityIds)
+
+	// it is only listed once
+	newBundle = AddTaintedX509Authority(newBundle, X509AuthorityID(ca2))
+	s.Equal([]string{X509AuthorityID(ca2)}, newBundle.TaintedX509AuthorityIds)
+}
+
+func (s *BundleUtilSuite) TestTaintJWTAuthority() {
+	bundle := s.createBundle(nil, []*common.PublicKey{{Kid: "KID1"}, {Kid: "KID2"}})
+
+	newBundle, ok := TaintJWTAuthority(bundle, "KID1")
+	s.Require().True(ok)
+	s.True(newBundle.JwtSigningKeys[0].TaintedKey)
+	s.False(newBundle.JwtSigningKeys[1].TaintedKey)
+	s.False(bundle.JwtSigningKeys[0].TaintedKey)
+
+	newBundle, ok = TaintJWTAuthority(bundle, "KID3")
+	s.False(ok)
+	s.Nil(newBundle)
+}
+
+func (s *BundleUtilSuite) TestIssuedByTaintedRootCA() {
+	ca1 := s.createCA(1)
+	ca2 := s.createCA(2)
+	leaf := s.createLeaf(ca2)
+
+	bundleProto := s.createBundle([]*x509.Certificate{ca1, ca2}, nil)
+	bundle, err := BundleFromProto(bundleProto)
+	s.Require().NoError(err)
+	s.False(bundle.IssuedByTaintedRootCA(leaf))
+
+	bundleProto, _, err = TaintX509Authority(bundleProto, X509AuthorityID(ca1))
+	s.Require().NoError(err)
+	bundle, err = BundleFromProto(bundleProto)
+	s.Require().NoError(err)
+	s.False(bundle.IssuedByTaintedRootCA(leaf))
+
+	bundleProto, _, err = TaintX509Authority(bundleProto, X509AuthorityID(ca2))
+	s.Require().NoError(err)
+	bundle, err = BundleFromProto(bundleProto)
+	s.Require().NoError(err)
+	s.True(bundle.IssuedByTaintedRootCA(leaf))
+}
+
+func (s *BundleUtilSuite) TestIssuedByTaintedX509Authority() {
+	root := s.createCA(1)
+	intermediate := s.createCA(2)
+	leaf := s.createLeaf(intermediate)
+
+	bundleProto := s.createBundle([]*x509.Certificate{root}, nil)
+	bundle, err := BundleFromProto(bundleProto)
+	s.Require().NoError(err)
+	s.False(bundle.IssuedByTaintedRootCA(leaf))
+
+	bundle, err = BundleFromProto(AddTaintedX509Authority(bundleProto, X509AuthorityID(intermediate)))
+	s.Require().NoError(err)
+	s.True(bundle.IssuedByTaintedRootCA(leaf))
+	s.Len(bundle.RootCAs(), 1)
+}
+
+func (s *BundleUtilSuite) TestIsJWTSigningKeyTainted() {
+	bundle, err := BundleFromProto(&common.Bundle{
+		TrustDomainId: "spiffe://foo",
+		JwtSigningKeys: []*common.PublicKey{
+			{Kid: "KID1", PkixBytes: s.publicKeyBytes()},
+			{Kid: "KID2", PkixBytes: s.publicKeyBytes(), TaintedKey: true},
+		},
+	})
+	s.Require().NoError(err)
+	s.False(bundle.IsJWTSigningKeyTainted("KID1"))
+	s.True(bundle.IsJWTSigningKeyTainted("KID2"))
+	s.False(bundle.IsJWTSigningKeyTainted("KID3"))
+}
+
+func (s *BundleUtilSuite) TestRemoveTaintedAuthorities() {
+	ca1 := s.createCA(1)
+	ca2 := s.createCA(2)
+	bundle := s.createBundle([]*x509.Certificate{ca1, ca2}, []*common.PublicKey{{Kid: "KID1"}, {Kid: "KID2"}})
+
+	// authorities that are not tainted are not removed
+	newBundle, changed, err := RemoveTaintedAuthorities(bundle, []string{X509AuthorityID(ca1)}, []string{"KID1"})
+	s.Require().NoError(err)
+	s.False(changed)
+	s.Equal(bundle, newBundle)
+
+	bundle, _, err = TaintX509Authority(bundle, X509AuthorityID(ca1))
+	s.Require().NoError(err)
+	bundle, _ = TaintJWTAuthority(bundle, "KID1")
+
+	newBundle, changed, err = RemoveTaintedAuthorities(bundle, []string{X509AuthorityID(ca1)}, []string{"KID1"})
+	s.Require().NoError(err)
+	s.True(changed)
+	s.Equal(s.createBundle([]*x509.Certificate{ca2}, []*common.PublicKey{{Kid: "KID2"}}), newBundle)
+}
+
+func (s *BundleUtilSuite) TestRemoveTaintedX509AuthorityIDs() {
+	ca1 := s.createCA(1)
+	bundle := s.createBundle([]*x509.Certificate{ca1}, nil)
+	bundle = AddTaintedX509Authority(bundle, "0a")
+	bundle = AddTaintedX509Authority(bundle, "0b")
+
+	newBundle, changed, err := RemoveTaintedAuthorities(bundle, []string{"0a"}, nil)
+	s.Require().NoError(err)
+	s.True(changed)
+	s.Equal([]string{"0b"}, newBundle.TaintedX509AuthorityIds)
+	s.Len(newBundle.RootCas, 1)
+}
+
+func (s *BundleUtilSuite) TestMergeBundles() {
+	ca1 := s.createCA(1)
+	ca2 := s.createCA(2)
+	a := s.createBundle([]*x509.Certificate{ca1}, []*common.PublicKey{{Kid: "KID1"}})
+	b := s.createBundle([]*x509.Certificate{ca1, ca2}, []*common.PublicKey{{Kid: "KID1"}, {Kid: "KID2"}})
+
+	merged, changed := MergeBundles(a, b)
+	s.True(changed)
+	s.Equal(b, merged)
+
+	merged, changed = MergeBundles(merged, b)
+	s.False(changed)
+	s.Equal(b, merged)
+
+	// tainted X509 authority IDs are combined
+	merged, changed = MergeBundles(AddTaintedX509Authority(a, "0a"), AddTaintedX509Authority(b, "0b"))
+	s.True(changed)
+	s.Equal([]string{"0a", "0b"}, merged.TaintedX509AuthorityIds)
+}
+
+func (s *BundleUtilSuite) TestMergeBundlesKeepsTaints() {
+	ca1 := s.createCA(1)
+	ca2 := s.createCA(2)
+	untainted := s.createBundle([]*x509.Certificate{ca1, ca2}, []*common.PublicKey{{Kid: "KID1"}, {Kid: "KID2"}})
+	tainted, _, err := TaintX509Authority(untainted, X509AuthorityID(ca1))
+	s.Require().NoError(err)
+	tainted, _ = TaintJWTAuthority(tainted, "KID1")
+
+	// merging untainted copies of tainted authorities doesn't add them
+	merged, changed := MergeBundles(tainted, untainted)
+	s.False(changed)
+	s.Equal(tainted, merged)
+
+	// merging tainted copies of authorities taints them
+	merged, changed = MergeBundles(untainted, tainted)
+	s.True(changed)
+	s.Equal(tainted, merged)
+	s.False(untainted.RootCas[0].TaintedKey)
+
+	// so removing tainted authorities leaves no trusted copy behind
+	merged, changed, err = RemoveTaintedAuthorities(merged, []string{X509AuthorityID(ca1)}, []string{"KID1"})
+	s.Require().NoError(err)
+	s.True(changed)
+	s.Equal(s.createBundle([]*x509.Certificate{ca2}, []*common.PublicKey{{Kid: "KID2"}}), merged)
+}
+
+func (s *BundleUtilSuite) createCA(id int64) *x509.Certificate {
+	return createCertificate(s.T(), &x509.Certificate{
+		SerialNumber:          big.NewInt(id),
+		Subject:               pkix.Name{CommonName: fmt.Sprintf("CA %d", id)},
+		SubjectKeyId:          []byte{byte(id)},
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	})
+}
+
+func (s *BundleUtilSuite) createLeaf(ca *x509.Certificate) *x509.Certificate {
+	certDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(100),
+		Subject:      pkix.Name{CommonName: "LEAF"},
+		NotAfter:     time.Now().Add(time.Hour),
+	}, ca, testKey.Public(), testKey)
+	s.Require().NoError(err)
+	cert, err := x509.ParseCertificate(certDER)
+	s.Require().NoError(err)
+	return cert
+}
+
+func (s *BundleUtilSuite) publicKeyBytes() []byte {
+	pkixBytes, err := x509.MarshalPKIXPublicKey(testKey.Public())
+	s.Require().NoError(err)
+	return pkixBytes
+}
+
 func (s *BundleUtilSuite) createBundle(certs []*x509.Certificate, jwtKeys []*common.PublicKey) *common.Bundle {
 	bundle := BundleProtoFromRootCAs("spiffe://foo", certs)
 	bundle.JwtSigningKeys = jwtKeys
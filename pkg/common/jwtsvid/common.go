@@ -31,3 +31,16 @@ func GetTokenExpiry(token string) (time.Time, time.Time, error) {
 	expiresAt := time.Unix(claims.ExpiresAt, 0).UTC()
 	return issuedAt, expiresAt, nil
 }
+
+// GetTokenID returns the token ID (i.e. "jti" claim) of the token
+func GetTokenID(token string) (string, error) {
+	claims := new(jwt.StandardClaims)
+	_, _, err := new(jwt.Parser).ParseUnverified(token, claims)
+	if err != nil {
+		return "", err
+	}
+	if claims.Id == "" {
+		return "", errors.New("JWT missing jti claim")
+	}
+	return claims.Id, nil
+}
@@ -3,8 +3,11 @@ package jwtsvid
 import (
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
+	"crypto/rsa"
 	"encoding/asn1"
+	"fmt"
 	"math/big"
 
 	jwt "github.com/dgrijalva/jwt-go"
@@ -14,8 +17,88 @@ var (
 	signingMethodES256 = &signingMethodECDSA{
 		SigningMethodECDSA: jwt.SigningMethodES256,
 	}
+	signingMethodES384 = &signingMethodECDSA{
+		SigningMethodECDSA: jwt.SigningMethodES384,
+	}
+	signingMethodRS256 = &signingMethodRSA{
+		SigningMethodRSA: jwt.SigningMethodRS256,
+	}
+	signingMethodPS256 = &signingMethodRSA{
+		SigningMethodRSA: jwt.SigningMethodPS256.SigningMethodRSA,
+		pssOptions: &rsa.PSSOptions{
+			SaltLength: rsa.PSSSaltLengthEqualsHash,
+			Hash:       crypto.SHA256,
+		},
+	}
+
+	signingMethods = map[string]jwt.SigningMethod{
+		signingMethodES256.Alg(): signingMethodES256,
+		signingMethodES384.Alg(): signingMethodES384,
+		signingMethodRS256.Alg(): signingMethodRS256,
+		signingMethodPS256.Alg(): signingMethodPS256,
+	}
 )
 
+// ValidateAlgorithm returns an error if tokens can't be signed with the given
+// JWS algorithm and public key. An empty algorithm stands for the default
+// algorithm for the key.
+func ValidateAlgorithm(alg string, publicKey crypto.PublicKey) error {
+	_, err := signingMethodForKey(alg, publicKey)
+	return err
+}
+
+// DefaultAlgorithm returns the JWS algorithm used to sign tokens with the
+// given public key when no algorithm is explicitly requested. P-256 keys sign
+// ES256, P-384 keys sign ES384 and RSA keys sign RS256.
+func DefaultAlgorithm(publicKey crypto.PublicKey) (string, error) {
+	switch publicKey := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		switch publicKey.Curve {
+		case elliptic.P256():
+			return signingMethodES256.Alg(), nil
+		case elliptic.P384():
+			return signingMethodES384.Alg(), nil
+		default:
+			return "", fmt.Errorf("unsupported elliptic curve %q", publicKey.Curve.Params().Name)
+		}
+	case *rsa.PublicKey:
+		return signingMethodRS256.Alg(), nil
+	default:
+		return "", fmt.Errorf("unsupported public key type %T", publicKey)
+	}
+}
+
+// signingMethodForKey returns the signing method for the given algorithm,
+// verifying that it can be used with the public key. If alg is empty, the
+// default algorithm for the key is used.
+func signingMethodForKey(alg string, publicKey crypto.PublicKey) (jwt.SigningMethod, error) {
+	if alg == "" {
+		var err error
+		alg, err = DefaultAlgorithm(publicKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	method, ok := signingMethods[alg]
+	if !ok {
+		return nil, fmt.Errorf("unsupported token signature algorithm: %s", alg)
+	}
+
+	switch method := method.(type) {
+	case *signingMethodECDSA:
+		ecdsaPublicKey, ok := publicKey.(*ecdsa.PublicKey)
+		if !ok || ecdsaPublicKey.Curve.Params().BitSize != method.CurveBits {
+			return nil, fmt.Errorf("token signature algorithm %s does not match key type", alg)
+		}
+	case *signingMethodRSA:
+		if _, ok := publicKey.(*rsa.PublicKey); !ok {
+			return nil, fmt.Errorf("token signature algorithm %s does not match key type", alg)
+		}
+	}
+	return method, nil
+}
+
 // signingMethodECDSA is a copy of the implementation of the JWT package
 // modified to accomodate both an *ecdsa.PrivateKey and a crypto.Signer based
 // key. It can be thrown away as soon as
@@ -85,3 +168,48 @@ func (m *signingMethodECDSA) Sign(signingString string, key interface{}) (string
 
 	return jwt.EncodeSegment(out), nil
 }
+
+// signingMethodRSA signs with a crypto.Signer based RSA key, either using
+// PKCS #1 v1.5 or, if pssOptions is set, RSASSA-PSS. Verification is
+// delegated to the JWT package.
+type signingMethodRSA struct {
+	*jwt.SigningMethodRSA
+	pssOptions *rsa.PSSOptions
+}
+
+func (m *signingMethodRSA) Verify(signingString, signature string, key interface{}) error {
+	if m.pssOptions != nil {
+		return jwt.SigningMethodPS256.Verify(signingString, signature, key)
+	}
+	return m.SigningMethodRSA.Verify(signingString, signature, key)
+}
+
+func (m *signingMethodRSA) Sign(signingString string, key interface{}) (string, error) {
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return "", jwt.ErrInvalidKeyType
+	}
+
+	if _, ok := signer.Public().(*rsa.PublicKey); !ok {
+		return "", jwt.ErrInvalidKeyType
+	}
+
+	if !m.Hash.Available() {
+		return "", jwt.ErrHashUnavailable
+	}
+
+	hasher := m.Hash.New()
+	hasher.Write([]byte(signingString))
+
+	var opts crypto.SignerOpts = m.Hash
+	if m.pssOptions != nil {
+		opts = m.pssOptions
+	}
+
+	signatureBytes, err := signer.Sign(rand.Reader, hasher.Sum(nil), opts)
+	if err != nil {
+		return "", err
+	}
+
+	return jwt.EncodeSegment(signatureBytes), nil
+}
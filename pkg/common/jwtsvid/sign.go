@@ -2,7 +2,10 @@ package jwtsvid
 
 import (
 	"crypto"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/andres-erbsen/clock"
@@ -14,6 +17,20 @@ const (
 	keyIDHeader = "kid"
 )
 
+var (
+	// reservedClaims are the registered claims set by the signer, which
+	// cannot be overridden by additional claims
+	reservedClaims = map[string]bool{
+		"aud": true,
+		"exp": true,
+		"iat": true,
+		"iss": true,
+		"jti": true,
+		"nbf": true,
+		"sub": true,
+	}
+)
+
 type SignerConfig struct {
 	Clock clock.Clock
 }
@@ -31,10 +48,43 @@ func NewSigner(config SignerConfig) *Signer {
 	}
 }
 
+// SignToken signs a JWT-SVID using the default algorithm for the signer's key
+// type (see DefaultAlgorithm).
 func (s *Signer) SignToken(spiffeID string, audience []string, expires time.Time, signer crypto.Signer, kid string) (string, error) {
+	return s.signToken(spiffeID, audience, expires, signer, kid, "", "", nil)
+}
+
+// SignTokenWithAlgorithm signs a JWT-SVID using the given JWS algorithm (one
+// of ES256, ES384, RS256 or PS256). The algorithm must be compatible with the
+// signer's key type. If alg is empty, the default algorithm is used.
+func (s *Signer) SignTokenWithAlgorithm(spiffeID string, audience []string, expires time.Time, signer crypto.Signer, kid, alg string) (string, error) {
+	return s.signToken(spiffeID, audience, expires, signer, kid, alg, "", nil)
+}
+
+// SignTokenWithClaims signs a JWT-SVID like SignTokenWithAlgorithm, adding
+// the given claims to the registered ones. The claims cannot include
+// registered claims (see ValidateClaims).
+func (s *Signer) SignTokenWithClaims(spiffeID string, audience []string, expires time.Time, signer crypto.Signer, kid, alg string, claims map[string]string) (string, error) {
+	return s.signToken(spiffeID, audience, expires, signer, kid, alg, "", claims)
+}
+
+// SignTokenWithID signs a JWT-SVID like SignTokenWithClaims, also setting the
+// "jti" claim to the given token ID (see NewTokenID). Tokens signed by the
+// other methods have no "jti" claim.
+func (s *Signer) SignTokenWithID(spiffeID string, audience []string, expires time.Time, signer crypto.Signer, kid, alg string, jti string, claims map[string]string) (string, error) {
+	if jti == "" {
+		return "", errors.New("token ID is required")
+	}
+	return s.signToken(spiffeID, audience, expires, signer, kid, alg, jti, claims)
+}
+
+func (s *Signer) signToken(spiffeID string, audience []string, expires time.Time, signer crypto.Signer, kid, alg, jti string, extraClaims map[string]string) (string, error) {
 	if err := idutil.ValidateSpiffeID(spiffeID, idutil.AllowAnyTrustDomainWorkload()); err != nil {
 		return "", err
 	}
+	if err := ValidateClaims(extraClaims); err != nil {
+		return "", err
+	}
 
 	audience = pruneEmptyValues(audience)
 
@@ -48,14 +98,25 @@ func (s *Signer) SignToken(spiffeID string, audience []string, expires time.Time
 		return "", errors.New("kid is required")
 	}
 
+	method, err := signingMethodForKey(alg, signer.Public())
+	if err != nil {
+		return "", err
+	}
+
 	claims := jwt.MapClaims{
 		"sub": spiffeID,
 		"exp": expires.Unix(),
 		"aud": audienceClaim(audience),
 		"iat": s.c.Clock.Now().Unix(),
 	}
+	if jti != "" {
+		claims["jti"] = jti
+	}
+	for name, value := range extraClaims {
+		claims[name] = value
+	}
 
-	token := jwt.NewWithClaims(signingMethodES256, claims)
+	token := jwt.NewWithClaims(method, claims)
 	token.Header[keyIDHeader] = kid
 	signedToken, err := token.SignedString(signer)
 	if err != nil {
@@ -64,6 +125,29 @@ func (s *Signer) SignToken(spiffeID string, audience []string, expires time.Time
 	return signedToken, nil
 }
 
+// ValidateClaims validates claims to be added to JWT-SVIDs. Registered claims
+// set by the signer, like "sub" or "exp", cannot be added.
+func ValidateClaims(claims map[string]string) error {
+	for name := range claims {
+		if name == "" {
+			return errors.New("claim name cannot be empty")
+		}
+		if reservedClaims[name] {
+			return fmt.Errorf("claim %q is reserved", name)
+		}
+	}
+	return nil
+}
+
+// NewTokenID returns a random token ID for the "jti" claim
+func NewTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
 func pruneEmptyValues(values []string) []string {
 	pruned := make([]string, 0, len(values))
 	for _, value := range values {
@@ -4,6 +4,9 @@ import (
 	"context"
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
 	"testing"
@@ -75,6 +78,55 @@ func (s *TokenSuite) TestSignAndValidate() {
 	s.Require().NoError(err)
 	s.Require().Equal(fakeSpiffeID, spiffeID)
 	s.Require().NotEmpty(claims)
+
+	// tokens have no ID unless one is given
+	s.Require().NotContains(claims, "jti")
+	_, err = GetTokenID(token)
+	s.Require().EqualError(err, "JWT missing jti claim")
+}
+
+func (s *TokenSuite) TestSignWithID() {
+	jti, err := NewTokenID()
+	s.Require().NoError(err)
+	s.Require().Len(jti, 32)
+	otherJTI, err := NewTokenID()
+	s.Require().NoError(err)
+	s.Require().NotEqual(jti, otherJTI)
+
+	token, err := s.signer.SignTokenWithID(fakeSpiffeID, fakeAudience, time.Now().Add(time.Hour), s.key, "kid", "", jti, nil)
+	s.Require().NoError(err)
+	tokenJTI, err := GetTokenID(token)
+	s.Require().NoError(err)
+	s.Require().Equal(jti, tokenJTI)
+
+	_, err = s.signer.SignTokenWithID(fakeSpiffeID, fakeAudience, time.Now().Add(time.Hour), s.key, "kid", "", "", nil)
+	s.Require().EqualError(err, "token ID is required")
+}
+
+func (s *TokenSuite) TestSignAndValidateWithClaims() {
+	token, err := s.signer.SignTokenWithClaims(fakeSpiffeID, fakeAudience, time.Now().Add(time.Hour), s.key, "kid", "", map[string]string{
+		"tenant":      "acme",
+		"environment": "prod",
+	})
+	s.Require().NoError(err)
+
+	spiffeID, claims, err := ValidateToken(ctx, token, s.bundle, fakeAudience)
+	s.Require().NoError(err)
+	s.Require().Equal(fakeSpiffeID, spiffeID)
+	s.Require().Equal("acme", claims["tenant"])
+	s.Require().Equal("prod", claims["environment"])
+}
+
+func (s *TokenSuite) TestSignWithReservedClaims() {
+	_, err := s.signer.SignTokenWithClaims(fakeSpiffeID, fakeAudience, time.Now().Add(time.Hour), s.key, "kid", "", map[string]string{
+		"sub": "spiffe://example.org/admin",
+	})
+	s.Require().EqualError(err, `claim "sub" is reserved`)
+
+	_, err = s.signer.SignTokenWithClaims(fakeSpiffeID, fakeAudience, time.Now().Add(time.Hour), s.key, "kid", "", map[string]string{
+		"": "empty",
+	})
+	s.Require().EqualError(err, "claim name cannot be empty")
 }
 
 func (s *TokenSuite) TestSignAndValidateWithAudienceList() {
@@ -88,6 +140,90 @@ func (s *TokenSuite) TestSignAndValidateWithAudienceList() {
 	s.Require().NotEmpty(claims)
 }
 
+func (s *TokenSuite) TestSignAndValidateWithAlgorithms() {
+	ec384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	s.Require().NoError(err)
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+
+	for _, tt := range []struct {
+		key crypto.Signer
+		alg string
+		err string
+	}{
+		{key: s.key, alg: ""},
+		{key: s.key, alg: "ES256"},
+		{key: ec384Key, alg: ""},
+		{key: ec384Key, alg: "ES384"},
+		{key: rsaKey, alg: ""},
+		{key: rsaKey, alg: "RS256"},
+		{key: rsaKey, alg: "PS256"},
+		{key: s.key, alg: "ES384", err: "token signature algorithm ES384 does not match key type"},
+		{key: s.key, alg: "RS256", err: "token signature algorithm RS256 does not match key type"},
+		{key: rsaKey, alg: "ES256", err: "token signature algorithm ES256 does not match key type"},
+		{key: rsaKey, alg: "HS256", err: "unsupported token signature algorithm: HS256"},
+	} {
+		bundle := NewKeyStore(map[string]map[string]crypto.PublicKey{
+			"spiffe://example.org": {
+				"kid": tt.key.Public(),
+			},
+		})
+
+		token, err := s.signer.SignTokenWithAlgorithm(fakeSpiffeID, fakeAudience, time.Now().Add(time.Hour), tt.key, "kid", tt.alg)
+		if tt.err != "" {
+			s.Require().EqualError(err, tt.err)
+			s.Require().EqualError(ValidateAlgorithm(tt.alg, tt.key.Public()), tt.err)
+			continue
+		}
+		s.Require().NoError(err)
+		s.Require().NoError(ValidateAlgorithm(tt.alg, tt.key.Public()))
+
+		expectedAlg := tt.alg
+		if expectedAlg == "" {
+			expectedAlg, err = DefaultAlgorithm(tt.key.Public())
+			s.Require().NoError(err)
+		}
+		parsed, _, err := new(jwt.Parser).ParseUnverified(token, jwt.MapClaims{})
+		s.Require().NoError(err)
+		s.Require().Equal(expectedAlg, parsed.Header["alg"])
+
+		spiffeID, _, err := ValidateToken(ctx, token, bundle, fakeAudience)
+		s.Require().NoError(err, "alg=%s", expectedAlg)
+		s.Require().Equal(fakeSpiffeID, spiffeID)
+	}
+}
+
+func (s *TokenSuite) TestDefaultAlgorithm() {
+	alg, err := DefaultAlgorithm(s.key.Public())
+	s.Require().NoError(err)
+	s.Require().Equal("ES256", alg)
+
+	ec384Key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	s.Require().NoError(err)
+	alg, err = DefaultAlgorithm(ec384Key.Public())
+	s.Require().NoError(err)
+	s.Require().Equal("ES384", alg)
+
+	ec521Key, err := ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	s.Require().NoError(err)
+	_, err = DefaultAlgorithm(ec521Key.Public())
+	s.Require().EqualError(err, `unsupported elliptic curve "P-521"`)
+}
+
+func (s *TokenSuite) TestValidateAlgorithmKeyMismatch() {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+
+	// token signed with an RSA key that claims the kid of the EC key
+	token, err := s.signer.SignToken(fakeSpiffeID, fakeAudience, time.Now().Add(time.Hour), rsaKey, "kid")
+	s.Require().NoError(err)
+
+	spiffeID, claims, err := ValidateToken(ctx, token, s.bundle, fakeAudience)
+	s.Require().EqualError(err, "token signature algorithm RS256 does not match key type")
+	s.Require().Empty(spiffeID)
+	s.Require().Nil(claims)
+}
+
 func (s *TokenSuite) TestSignWithNoExpiration() {
 	_, err := s.signer.SignToken(fakeSpiffeID, fakeAudience, time.Time{}, s.key, "kid")
 	s.Require().EqualError(err, "expiration is required")
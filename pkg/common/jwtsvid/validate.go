@@ -37,7 +37,7 @@ func (t *keyStore) FindPublicKey(ctx context.Context, trustDomainId, keyID strin
 }
 
 func getSigningKey(ctx context.Context, keyStore KeyStore, t *jwt.Token, claims jwt.MapClaims) (string, interface{}, error) {
-	if t.Method.Alg() != jwt.SigningMethodES256.Alg() {
+	if _, ok := signingMethods[t.Method.Alg()]; !ok {
 		return "", nil, fmt.Errorf("unexpected token signature algorithm: %s", t.Method.Alg())
 	}
 	keyID, _ := t.Header[keyIDHeader].(string)
@@ -63,6 +63,12 @@ func getSigningKey(ctx context.Context, keyStore KeyStore, t *jwt.Token, claims
 		return "", nil, err
 	}
 
+	// make sure the algorithm in the header is appropriate for the key so a
+	// token can't coerce verification with a mismatched algorithm.
+	if _, err := signingMethodForKey(t.Method.Alg(), key); err != nil {
+		return "", nil, err
+	}
+
 	return id.String(), key, nil
 }
 
@@ -98,6 +104,20 @@ func ValidateToken(ctx context.Context, token string, keyStore KeyStore, audienc
 	return spiffeID, claims, nil
 }
 
+// KeyIDFromToken returns the key ID from the header of the token. The token
+// signature is NOT verified.
+func KeyIDFromToken(token string) (string, error) {
+	t, _, err := new(jwt.Parser).ParseUnverified(token, jwt.MapClaims{})
+	if err != nil {
+		return "", err
+	}
+	keyID, _ := t.Header[keyIDHeader].(string)
+	if keyID == "" {
+		return "", errors.New("token missing key id")
+	}
+	return keyID, nil
+}
+
 func stringInList(s string, ss []string) bool {
 	for _, candidate := range ss {
 		if s == candidate {
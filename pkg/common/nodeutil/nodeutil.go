@@ -0,0 +1,27 @@
+package nodeutil
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	agentBannedMessage = "agent is banned"
+)
+
+// AgentBannedError returns the status the server responds with when a banned
+// agent tries to attest or fetch SVIDs.
+func AgentBannedError() error {
+	return status.Error(codes.PermissionDenied, agentBannedMessage)
+}
+
+// IsAgentBanned returns true if the error is the status returned by the
+// server when the agent is banned. Agents receiving it should stop instead of
+// retrying.
+func IsAgentBanned(err error) bool {
+	st, ok := status.FromError(err)
+	if !ok {
+		return false
+	}
+	return st.Code() == codes.PermissionDenied && st.Message() == agentBannedMessage
+}
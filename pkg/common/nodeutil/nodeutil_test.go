@@ -0,0 +1,18 @@
+package nodeutil
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestIsAgentBanned(t *testing.T) {
+	require.True(t, IsAgentBanned(AgentBannedError()))
+	require.False(t, IsAgentBanned(nil))
+	require.False(t, IsAgentBanned(errors.New("agent is banned")))
+	require.False(t, IsAgentBanned(status.Error(codes.PermissionDenied, "agent is not attested or no longer valid")))
+	require.False(t, IsAgentBanned(status.Error(codes.Unknown, "agent is banned")))
+}
@@ -0,0 +1,297 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// TokenHeader is the header carrying the client token
+	TokenHeader = "X-Vault-Token"
+
+	// NamespaceHeader is the header carrying the Vault Enterprise namespace
+	NamespaceHeader = "X-Vault-Namespace"
+)
+
+// AuthInfo is the auth information returned by the login and renewal
+// endpoints
+type AuthInfo struct {
+	ClientToken   string `json:"client_token"`
+	LeaseDuration int    `json:"lease_duration"`
+	Renewable     bool   `json:"renewable"`
+}
+
+// TTL returns the TTL of the token. Zero means the token does not expire.
+func (a *AuthInfo) TTL() time.Duration {
+	return time.Duration(a.LeaseDuration) * time.Second
+}
+
+// Secret is the envelope of Vault API responses
+type Secret struct {
+	Data json.RawMessage `json:"data"`
+	Auth *AuthInfo       `json:"auth"`
+}
+
+// DecodeData decodes the data of the secret into out
+func (s *Secret) DecodeData(out interface{}) error {
+	if len(s.Data) == 0 {
+		return errors.New("response is missing data")
+	}
+	return json.Unmarshal(s.Data, out)
+}
+
+// ErrorResponse is the body of Vault API error responses
+type ErrorResponse struct {
+	Errors []string `json:"errors"`
+}
+
+// Error is returned when the Vault API responds with an error
+type Error struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Errors     []string
+}
+
+func (e *Error) Error() string {
+	if len(e.Errors) > 0 {
+		return fmt.Sprintf("%s %s failed (%d): %s", e.Method, e.Path, e.StatusCode, strings.Join(e.Errors, "; "))
+	}
+	return fmt.Sprintf("%s %s failed (%d)", e.Method, e.Path, e.StatusCode)
+}
+
+// IsNotFound returns true if the error is a Vault API error with a 404 status
+func IsNotFound(err error) bool {
+	vaultErr, ok := err.(*Error)
+	return ok && vaultErr.StatusCode == http.StatusNotFound
+}
+
+type tokenLookupData struct {
+	TTL       int  `json:"ttl"`
+	Renewable bool `json:"renewable"`
+}
+
+// Client is a minimal client for the subset of the Vault HTTP API used by
+// SPIRE plugins
+type Client struct {
+	config     *ClientConfig
+	httpClient *http.Client
+
+	mu    sync.RWMutex
+	token string
+}
+
+// NewClient returns a client for the Vault server in the given configuration.
+// The configuration must have been validated.
+func NewClient(config *ClientConfig) (*Client, error) {
+	tlsConfig, err := newTLSConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		config: config,
+		httpClient: &http.Client{
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: tlsConfig,
+			},
+			Timeout: 30 * time.Second,
+		},
+	}, nil
+}
+
+func (c *Client) SetToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+}
+
+func (c *Client) getToken() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.token
+}
+
+// Login authenticates using the configured auth method, setting the client
+// token on success
+func (c *Client) Login(ctx context.Context) (*AuthInfo, error) {
+	var auth *AuthInfo
+	var err error
+	switch {
+	case c.config.TokenAuth != nil:
+		c.SetToken(c.config.TokenAuth.Token)
+		auth, err = c.LookupSelf(ctx)
+	case c.config.AppRoleAuth != nil:
+		auth, err = c.LoginAppRole(ctx, c.config.AppRoleAuth.MountPoint, c.config.AppRoleAuth.RoleID, c.config.AppRoleAuth.SecretID)
+	case c.config.CertAuth != nil:
+		auth, err = c.LoginCert(ctx, c.config.CertAuth.MountPoint, c.config.CertAuth.RoleName)
+	default:
+		err = errors.New("no auth method configured")
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.SetToken(auth.ClientToken)
+	return auth, nil
+}
+
+// LookupSelf looks up the TTL and renewability of the client token
+func (c *Client) LookupSelf(ctx context.Context) (*AuthInfo, error) {
+	s, err := c.Read(ctx, "auth/token/lookup-self")
+	if err != nil {
+		return nil, err
+	}
+	data := new(tokenLookupData)
+	if err := s.DecodeData(data); err != nil {
+		return nil, fmt.Errorf("unable to decode token lookup data: %v", err)
+	}
+	return &AuthInfo{
+		ClientToken:   c.getToken(),
+		LeaseDuration: data.TTL,
+		Renewable:     data.Renewable,
+	}, nil
+}
+
+// RenewSelf renews the client token
+func (c *Client) RenewSelf(ctx context.Context) (*AuthInfo, error) {
+	s, err := c.Write(ctx, "auth/token/renew-self", struct{}{})
+	if err != nil {
+		return nil, err
+	}
+	return authFromSecret(s)
+}
+
+// LoginAppRole logs in using the AppRole auth method mounted at the given
+// mount point
+func (c *Client) LoginAppRole(ctx context.Context, mountPoint, roleID, secretID string) (*AuthInfo, error) {
+	s, err := c.Write(ctx, fmt.Sprintf("auth/%s/login", mountPoint), map[string]string{
+		"role_id":   roleID,
+		"secret_id": secretID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return authFromSecret(s)
+}
+
+// LoginCert logs in using the TLS certificate auth method mounted at the
+// given mount point. The client certificate is presented during the TLS
+// handshake.
+func (c *Client) LoginCert(ctx context.Context, mountPoint, roleName string) (*AuthInfo, error) {
+	body := map[string]string{}
+	if roleName != "" {
+		body["name"] = roleName
+	}
+	s, err := c.Write(ctx, fmt.Sprintf("auth/%s/login", mountPoint), body)
+	if err != nil {
+		return nil, err
+	}
+	return authFromSecret(s)
+}
+
+// Read reads the secret at the given path
+func (c *Client) Read(ctx context.Context, path string) (*Secret, error) {
+	return c.do(ctx, http.MethodGet, path, nil)
+}
+
+// List lists the keys at the given path
+func (c *Client) List(ctx context.Context, path string) ([]string, error) {
+	s, err := c.do(ctx, "LIST", path, nil)
+	if err != nil {
+		return nil, err
+	}
+	data := new(struct {
+		Keys []string `json:"keys"`
+	})
+	if err := s.DecodeData(data); err != nil {
+		return nil, fmt.Errorf("unable to decode list data: %v", err)
+	}
+	return data.Keys, nil
+}
+
+// Write writes the given data to the given path, returning the secret in the
+// response, if any
+func (c *Client) Write(ctx context.Context, path string, in interface{}) (*Secret, error) {
+	return c.do(ctx, http.MethodPost, path, in)
+}
+
+// Delete deletes the given path
+func (c *Client) Delete(ctx context.Context, path string) error {
+	_, err := c.do(ctx, http.MethodDelete, path, nil)
+	return err
+}
+
+func (c *Client) do(ctx context.Context, method, path string, in interface{}) (*Secret, error) {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, fmt.Sprintf("%s/v1/%s", strings.TrimSuffix(c.config.VaultAddr, "/"), path), body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if token := c.getToken(); token != "" {
+		req.Header.Set(TokenHeader, token)
+	}
+	if c.config.Namespace != "" {
+		req.Header.Set(NamespaceHeader, c.config.Namespace)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNoContent:
+		return &Secret{}, nil
+	case resp.StatusCode != http.StatusOK:
+		vaultErr := &Error{
+			Method:     method,
+			Path:       path,
+			StatusCode: resp.StatusCode,
+		}
+		errResp := new(ErrorResponse)
+		if err := json.Unmarshal(b, errResp); err == nil {
+			vaultErr.Errors = errResp.Errors
+		}
+		return nil, vaultErr
+	}
+
+	s := new(Secret)
+	if err := json.Unmarshal(b, s); err != nil {
+		return nil, fmt.Errorf("unable to decode response to %s %s: %v", method, path, err)
+	}
+	return s, nil
+}
+
+func authFromSecret(s *Secret) (*AuthInfo, error) {
+	if s.Auth == nil || s.Auth.ClientToken == "" {
+		return nil, errors.New("response is missing auth information")
+	}
+	return s.Auth, nil
+}
@@ -0,0 +1,136 @@
+package vault
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+
+	"github.com/spiffe/spire/pkg/common/pemutil"
+)
+
+const (
+	defaultAppRoleMountPoint = "approle"
+	defaultCertMountPoint    = "cert"
+)
+
+// ClientConfig is the configuration shared by plugins talking to Vault. It is
+// meant to be embedded (squashed) into the plugin configuration.
+type ClientConfig struct {
+	// VaultAddr is the URL of the Vault server. Defaults to the VAULT_ADDR
+	// environment variable.
+	VaultAddr string `hcl:"vault_addr"`
+
+	// Namespace is the Vault Enterprise namespace, if any
+	Namespace string `hcl:"namespace"`
+
+	// CACertPath is the path to a PEM file with the CA certificates used to
+	// verify the Vault server certificate. Defaults to the system roots.
+	CACertPath string `hcl:"ca_cert_path"`
+
+	// InsecureSkipVerify disables verification of the Vault server
+	// certificate. It should only be used for testing.
+	InsecureSkipVerify bool `hcl:"insecure_skip_verify"`
+
+	// Exactly one of the following auth methods must be configured
+	TokenAuth   *TokenAuthConfig   `hcl:"token_auth"`
+	AppRoleAuth *AppRoleAuthConfig `hcl:"approle_auth"`
+	CertAuth    *CertAuthConfig    `hcl:"cert_auth"`
+}
+
+type TokenAuthConfig struct {
+	// Token is the Vault token. Defaults to the VAULT_TOKEN environment
+	// variable.
+	Token string `hcl:"token"`
+}
+
+type AppRoleAuthConfig struct {
+	MountPoint string `hcl:"approle_auth_mount_point"`
+	RoleID     string `hcl:"approle_id"`
+	SecretID   string `hcl:"approle_secret_id"`
+}
+
+type CertAuthConfig struct {
+	MountPoint     string `hcl:"cert_auth_mount_point"`
+	RoleName       string `hcl:"cert_auth_role_name"`
+	ClientCertPath string `hcl:"client_cert_path"`
+	ClientKeyPath  string `hcl:"client_key_path"`
+}
+
+// Validate validates the configuration, filling in defaults from the
+// environment (via getenv) and for unset mount points.
+func (c *ClientConfig) Validate(getenv func(string) string) error {
+	if c.VaultAddr == "" {
+		c.VaultAddr = getenv("VAULT_ADDR")
+	}
+	if c.VaultAddr == "" {
+		return errors.New("vault_addr is required")
+	}
+
+	authMethods := 0
+	if c.TokenAuth != nil {
+		authMethods++
+		if c.TokenAuth.Token == "" {
+			c.TokenAuth.Token = getenv("VAULT_TOKEN")
+		}
+		if c.TokenAuth.Token == "" {
+			return errors.New("token is required for token_auth")
+		}
+	}
+	if c.AppRoleAuth != nil {
+		authMethods++
+		if c.AppRoleAuth.MountPoint == "" {
+			c.AppRoleAuth.MountPoint = defaultAppRoleMountPoint
+		}
+		if c.AppRoleAuth.RoleID == "" {
+			return errors.New("approle_id is required for approle_auth")
+		}
+		if c.AppRoleAuth.SecretID == "" {
+			return errors.New("approle_secret_id is required for approle_auth")
+		}
+	}
+	if c.CertAuth != nil {
+		authMethods++
+		if c.CertAuth.MountPoint == "" {
+			c.CertAuth.MountPoint = defaultCertMountPoint
+		}
+		if c.CertAuth.ClientCertPath == "" || c.CertAuth.ClientKeyPath == "" {
+			return errors.New("client_cert_path and client_key_path are required for cert_auth")
+		}
+	}
+	switch authMethods {
+	case 0:
+		return errors.New("one of token_auth, approle_auth or cert_auth is required")
+	case 1:
+		return nil
+	default:
+		return errors.New("only one of token_auth, approle_auth or cert_auth can be configured")
+	}
+}
+
+func newTLSConfig(config *ClientConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	}
+
+	if config.CACertPath != "" {
+		caCerts, err := pemutil.LoadCertificates(config.CACertPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load CA certificates: %v", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		for _, caCert := range caCerts {
+			tlsConfig.RootCAs.AddCert(caCert)
+		}
+	}
+
+	if config.CertAuth != nil {
+		clientCert, err := tls.LoadX509KeyPair(config.CertAuth.ClientCertPath, config.CertAuth.ClientKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,48 @@
+package vault
+
+import (
+	"context"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	hclog "github.com/hashicorp/go-hclog"
+)
+
+const (
+	// renewRetryInterval is how long to wait before retrying a failed token
+	// renewal
+	renewRetryInterval = 30 * time.Second
+)
+
+// RenewToken renews the client token at half of its TTL until the context is
+// canceled. If the token cannot be renewed, the client logs in again using
+// the configured auth method, if possible. It returns when the context is
+// canceled or the token is no longer renewable.
+func (c *Client) RenewToken(ctx context.Context, log hclog.Logger, clk clock.Clock, ttl time.Duration) {
+	wait := ttl / 2
+	for {
+		select {
+		case <-clk.After(wait):
+		case <-ctx.Done():
+			return
+		}
+
+		auth, err := c.RenewSelf(ctx)
+		if err != nil && c.config.TokenAuth == nil {
+			log.Warn("Unable to renew Vault token; logging in again", "error", err)
+			auth, err = c.Login(ctx)
+		}
+		if err != nil {
+			log.Error("Unable to renew Vault token", "error", err, "retry_interval", renewRetryInterval)
+			wait = renewRetryInterval
+			continue
+		}
+
+		if !auth.Renewable || auth.TTL() <= 0 {
+			log.Debug("Vault token is no longer renewable")
+			return
+		}
+		log.Debug("Renewed Vault token", "ttl", auth.TTL())
+		wait = auth.TTL() / 2
+	}
+}
@@ -18,6 +18,10 @@ const (
 	// to add clarity
 	Attest = "attest"
 
+	// Batch functionality related to operating on several entities at
+	// once; should be used with other tags to add clarity
+	Batch = "batch"
+
 	// Create functionality related to creating some entity; should be used with other tags
 	// to add clarity
 	Create = "create"
@@ -34,6 +38,11 @@ const (
 	// with other tags to add clarity
 	List = "list"
 
+	// Mint functionality related to minting some entity (such as an SVID)
+	// outside of the usual issuance flow; should be used with other tags to
+	// add clarity
+	Mint = "mint"
+
 	// Prepare functionality related to preparation of some entity; should be used with other tags
 	// to add clarity
 	Prepare = "prepare"
@@ -42,6 +51,14 @@ const (
 	// to add clarity
 	Prune = "prune"
 
+	// Publish functionality related to publishing some entity (such as a
+	// CRL); should be used with other tags to add clarity
+	Publish = "publish"
+
+	// Refresh functionality related to refreshing some entity (such as a
+	// cache); should be used with other tags to add clarity
+	Refresh = "refresh"
+
 	// Rotate functionality related to rotation of SVID; should be used with other tags
 	// to add clarity
 	Rotate = "rotate"
@@ -50,6 +67,10 @@ const (
 	// to add clarity
 	Sign = "sign"
 
+	// Taint functionality related to tainting some entity (such as a CA
+	// authority); should be used with other tags to add clarity
+	Taint = "taint"
+
 	// Sync functionality for syncing (such as CA manager updates). Should
 	// be used with other tags to add clarity
 	Sync = "sync"
@@ -57,6 +78,10 @@ const (
 	// Update functionality related to updating some entity; should be used
 	// with other tags to add clarity
 	Update = "update"
+
+	// Watch functionality related to watching some entity for changes;
+	// should be used with other tags to add clarity
+	Watch = "watch"
 )
 
 // Attribute metric tags or labels that are typically an attribute of a
@@ -65,16 +90,29 @@ const (
 	// Address tags some network address
 	Address = "address"
 
+	// Algorithm tags some signature algorithm (e.g. of a JWT key)
+	Algorithm = "algorithm"
+
 	// Attempt tags some count of attempts
 	Attempt = "attempt"
 
 	// Audience tags some audience for a token
 	Audience = "audience"
 
+	// AuthorityID tags some ID of a CA authority (e.g. the subject key ID of
+	// an X509 CA certificate)
+	AuthorityID = "authority_id"
+
 	// CallerID tags an API caller; should be used with other tags
 	// to add clarity
 	CallerID = "caller_id"
 
+	// CallerGID tags the group ID of an API caller over a unix domain socket
+	CallerGID = "caller_gid"
+
+	// CallerUID tags the user ID of an API caller over a unix domain socket
+	CallerUID = "caller_uid"
+
 	// CGroupPath tags a linux CGroup path, most likely for use in attestation
 	CGroupPath = "cgroup_path"
 
@@ -122,6 +160,13 @@ const (
 	// FederatedRemoved labels some count of federated bundles that have been removed from an entity
 	FederatedRemoved = "fed_rem"
 
+	// Hit tags a lookup answered from a cache; should be used with other tags
+	// to add clarity
+	Hit = "hit"
+
+	// HolderID tags the ID of the holder of a lease
+	HolderID = "holder_id"
+
 	// IssuedAt tags an issuance timestamp
 	IssuedAt = "issued_at"
 
@@ -132,9 +177,19 @@ const (
 	// Key IDs instead.
 	JWTKeys = "jwt_keys"
 
+	// KeyType tags some key type
+	KeyType = "key_type"
+
 	// Kid tags some key ID
 	Kid = "kid"
 
+	// Method tags the name of an RPC method
+	Method = "method"
+
+	// Miss tags a lookup that could not be answered from a cache; should be
+	// used with other tags to add clarity
+	Miss = "miss"
+
 	// Nonce tags some nonce for communication
 	Nonce = "nonce"
 
@@ -183,6 +238,9 @@ const (
 	// RetryInterval tags some interval for retry logic
 	RetryInterval = "retry_interval"
 
+	// Rule tags the name of an authorization policy rule
+	Rule = "rule"
+
 	// Seconds tags some count of seconds; should be used with other tags and message
 	// to add clarity
 	Seconds = "seconds"
@@ -217,6 +275,10 @@ const (
 	// SPIFFEID tags a SPIFFE ID
 	SPIFFEID = "spiffe_id"
 
+	// Staleness tags how long ago some data (such as a cache) was last known
+	// to be up to date
+	Staleness = "staleness"
+
 	// Subject tags some subject (likely a SPIFFE ID, and likely for a token); should be used
 	// with other tags to add clarity
 	Subject = "subject"
@@ -270,6 +332,9 @@ const (
 	// Attestor tags an attestor plugin/type (eg. gcp, aws...)
 	Attestor = "attestor"
 
+	// AuditLog functionality related to the audit log of API calls
+	AuditLog = "audit_log"
+
 	// Bundle functionality related to a bundle; should be used with other tags
 	// to add clarity
 	Bundle = "bundle"
@@ -290,6 +355,10 @@ const (
 	// Catalog functionality related to plugin catalog
 	Catalog = "catalog"
 
+	// CRL functionality related to a certificate revocation list; should be
+	// used with other tags to add clarity
+	CRL = "crl"
+
 	// Endpoints functionality related to agent/server endpoints
 	Endpoints = "endpoints"
 
@@ -297,6 +366,10 @@ const (
 	// to add clarity
 	Entry = "entry"
 
+	// EntryCache functionality related to the server cache of authorized
+	// registration entries
+	EntryCache = "entry_cache"
+
 	// Event tag some event that has occurred, for a notifier, watcher, listener, etc.
 	Event = "event"
 
@@ -307,6 +380,10 @@ const (
 	// with other tags to add clarity
 	FederatedBundle = "federated_bundle"
 
+	// IssuanceLog functionality related to the log of SVIDs signed by the
+	// server
+	IssuanceLog = "issuance_log"
+
 	// JoinToken functionality related to a join token; should be used
 	// with other tags to add clarity
 	JoinToken = "join_token"
@@ -319,6 +396,9 @@ const (
 	// to add clarity
 	JWTSVID = "jwt_svid"
 
+	// Leader functionality related to leader election among servers
+	Leader = "leader"
+
 	// Manager functionality related to a manager (such as CA manager); should be
 	// used with other tags to add clarity
 	Manager = "manager"
@@ -334,6 +414,9 @@ const (
 	// to add clarity
 	Notifier = "notifier"
 
+	// RegistrationManager functionality related to a registration manager
+	RegistrationManager = "registration_manager"
+
 	// ServerCA functionality related to a server CA; should be used with other tags
 	// to add clarity
 	ServerCA = "server_ca"
@@ -351,6 +434,10 @@ const (
 	// Telemetry tags a telemetry module
 	Telemetry = "telemetry"
 
+	// Tombstone functionality related to the record kept of a deleted entity;
+	// should be used with other tags to add clarity
+	Tombstone = "tombstone"
+
 	// X509CA functionality related to an x509 CA; should be used with other tags
 	// to add clarity
 	X509CA = "x509_ca"
@@ -387,6 +474,10 @@ const (
 	// with other tags to add clarity
 	RegistrationAPI = "registration_api"
 
+	// RegistrationGateway functionality related to the JSON over HTTPS
+	// gateway to the registration api
+	RegistrationGateway = "registration_gateway"
+
 	// SDSAPI functionality related to SDS; should be used with other tags
 	// to add clarity
 	SDSAPI = "sds_api"
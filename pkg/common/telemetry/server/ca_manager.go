@@ -11,6 +11,12 @@ func StartCAManagerPruneBundleCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.CA, telemetry.Manager, telemetry.Bundle, telemetry.Prune)
 }
 
+// StartCAManagerPublishCRLCall returns metric for
+// server CA manager publishing the CRL
+func StartCAManagerPublishCRLCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.CA, telemetry.Manager, telemetry.CRL, telemetry.Publish)
+}
+
 // StartServerCAManagerPrepareJWTKeyCall return metric for
 // Server CA Manager preparing a JWT Key
 func StartServerCAManagerPrepareJWTKeyCall(m telemetry.Metrics) *telemetry.CallCounter {
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/telemetry"
+)
+
+// Call Counters (timing and success metrics)
+// Allows adding labels in-code
+
+// StartEntryCacheRefreshCall returns metric for
+// for server entry cache refreshes
+func StartEntryCacheRefreshCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.EntryCache, telemetry.Refresh)
+}
+
+// End Call Counters
+
+// Counters (literal increments, not call counters)
+
+// IncrEntryCacheHitCounter indicate a lookup of authorized
+// entries answered by the server entry cache
+func IncrEntryCacheHitCounter(m telemetry.Metrics) {
+	m.IncrCounter([]string{telemetry.EntryCache, telemetry.Hit}, 1)
+}
+
+// IncrEntryCacheMissCounter indicate a lookup of authorized
+// entries that fell back to the datastore
+func IncrEntryCacheMissCounter(m telemetry.Metrics) {
+	m.IncrCounter([]string{telemetry.EntryCache, telemetry.Miss}, 1)
+}
+
+// End Counters
+
+// Gauge (remember previous value set)
+
+// SetEntryCacheStalenessGauge set gauge for the time, in seconds,
+// since the server entry cache was last refreshed
+func SetEntryCacheStalenessGauge(m telemetry.Metrics, staleness time.Duration) {
+	m.SetGauge([]string{telemetry.EntryCache, telemetry.Staleness}, float32(staleness.Seconds()))
+}
+
+// End Gauge
@@ -0,0 +1,13 @@
+package server
+
+import "github.com/spiffe/spire/pkg/common/telemetry"
+
+// Call Counters (timing and success metrics)
+// Allows adding labels in-code
+
+// StartIssuanceLogPruneCall returns metric for server issuance log pruning
+func StartIssuanceLogPruneCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.IssuanceLog, telemetry.Prune)
+}
+
+// End Call Counters
@@ -0,0 +1,17 @@
+package server
+
+import "github.com/spiffe/spire/pkg/common/telemetry"
+
+// Gauge (remember previous value set)
+
+// SetLeaderGauge set gauge for leader election, 1 if the server
+// is the leader, 0 otherwise
+func SetLeaderGauge(m telemetry.Metrics, leader bool) {
+	var val float32
+	if leader {
+		val = 1
+	}
+	m.SetGauge([]string{telemetry.Leader}, val)
+}
+
+// End Gauge
@@ -0,0 +1,26 @@
+package server
+
+import "github.com/spiffe/spire/pkg/common/telemetry"
+
+// Call Counters (timing and success metrics)
+// Allows adding labels in-code
+
+// StartRegistrationManagerPruneEntryCall returns metric for
+// for server registration manager entry pruning
+func StartRegistrationManagerPruneEntryCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationManager, telemetry.Entry, telemetry.Prune)
+}
+
+// StartRegistrationManagerPruneEntryTombstoneCall returns metric for
+// for server registration manager entry tombstone pruning
+func StartRegistrationManagerPruneEntryTombstoneCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationManager, telemetry.Entry, telemetry.Tombstone, telemetry.Prune)
+}
+
+// StartRegistrationManagerPruneEntryEventCall returns metric for
+// for server registration manager entry event pruning
+func StartRegistrationManagerPruneEntryEventCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationManager, telemetry.Entry, telemetry.Event, telemetry.Prune)
+}
+
+// End Call Counters
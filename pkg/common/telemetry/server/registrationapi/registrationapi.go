@@ -5,6 +5,30 @@ import "github.com/spiffe/spire/pkg/common/telemetry"
 // Call Counters (timing and success metrics)
 // Allows adding labels in-code
 
+// StartActivateCACall return metric
+// for server's registration API, on activating a CA authority
+func StartActivateCACall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.CA, telemetry.Activate)
+}
+
+// StartBatchCreateEntryCall return metric
+// for server's registration API, on creating a batch of entries
+func StartBatchCreateEntryCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.Entry, telemetry.Batch, telemetry.Create)
+}
+
+// StartBatchDeleteEntryCall return metric
+// for server's registration API, on deleting a batch of entries
+func StartBatchDeleteEntryCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.Entry, telemetry.Batch, telemetry.Delete)
+}
+
+// StartBatchUpdateEntryCall return metric
+// for server's registration API, on updating a batch of entries
+func StartBatchUpdateEntryCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.Entry, telemetry.Batch, telemetry.Update)
+}
+
 // StartCreateEntryCall return metric
 // for server's registration API, on creating an entry.
 func StartCreateEntryCall(m telemetry.Metrics) *telemetry.CallCounter {
@@ -35,6 +59,12 @@ func StartDeleteFedBundleCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.FederatedBundle, telemetry.Delete)
 }
 
+// StartDeleteJoinTokenCall return metric
+// for server's registration API, on deleting a join token
+func StartDeleteJoinTokenCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.JoinToken, telemetry.Delete)
+}
+
 // StartFetchBundleCall return metric
 // for server's registration API, on fetching a bundle
 func StartFetchBundleCall(m telemetry.Metrics) *telemetry.CallCounter {
@@ -53,6 +83,24 @@ func StartFetchFedBundleCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.FederatedBundle, telemetry.Fetch)
 }
 
+// StartListJoinTokensCall return metric
+// for server's registration API, on listing join tokens
+func StartListJoinTokensCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.JoinToken, telemetry.List)
+}
+
+// StartListCASlotsCall return metric
+// for server's registration API, on listing CA slots
+func StartListCASlotsCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.CA, telemetry.List)
+}
+
+// StartListIssuedSVIDsCall return metric
+// for server's registration API, on listing issued SVIDs
+func StartListIssuedSVIDsCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.IssuanceLog, telemetry.List)
+}
+
 // StartListEntriesCall return metric
 // for server's registration API, on listing entries
 func StartListEntriesCall(m telemetry.Metrics) *telemetry.CallCounter {
@@ -65,6 +113,30 @@ func StartListFedBundlesCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.FederatedBundle, telemetry.List)
 }
 
+// StartMintJWTSVIDCall return metric
+// for server's registration API, on minting a JWT SVID
+func StartMintJWTSVIDCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.JWTSVID, telemetry.Mint)
+}
+
+// StartMintX509SVIDCall return metric
+// for server's registration API, on minting an X509 SVID
+func StartMintX509SVIDCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.X509SVID, telemetry.Mint)
+}
+
+// StartPrepareCACall return metric
+// for server's registration API, on preparing a CA authority
+func StartPrepareCACall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.CA, telemetry.Prepare)
+}
+
+// StartTaintCACall return metric
+// for server's registration API, on tainting a CA authority
+func StartTaintCACall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.CA, telemetry.Taint)
+}
+
 // StartUpdateEntryCall return metric
 // for server's registration API, on updating an entry
 func StartUpdateEntryCall(m telemetry.Metrics) *telemetry.CallCounter {
@@ -77,6 +149,12 @@ func StartUpdateFedBundleCall(m telemetry.Metrics) *telemetry.CallCounter {
 	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.FederatedBundle, telemetry.Update)
 }
 
+// StartWatchEntriesCall return metric
+// for server's registration API, on watching entries for changes
+func StartWatchEntriesCall(m telemetry.Metrics) *telemetry.CallCounter {
+	return telemetry.StartCall(m, telemetry.RegistrationAPI, telemetry.Entry, telemetry.Watch)
+}
+
 // End Call Counters
 
 // Counters (literal increments, not call counters)
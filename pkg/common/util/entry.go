@@ -0,0 +1,52 @@
+package util
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+// ApplyRegistrationEntryMask returns a copy of the entry with the fields
+// selected by the mask taken from the update. A nil mask selects every field.
+// The entry ID and revision number are never changed.
+func ApplyRegistrationEntryMask(entry, update *common.RegistrationEntry, mask *common.RegistrationEntryMask) *common.RegistrationEntry {
+	out := proto.Clone(entry).(*common.RegistrationEntry)
+	update = proto.Clone(update).(*common.RegistrationEntry)
+
+	if mask == nil || mask.Selectors {
+		out.Selectors = update.Selectors
+	}
+	if mask == nil || mask.ParentId {
+		out.ParentId = update.ParentId
+	}
+	if mask == nil || mask.SpiffeId {
+		out.SpiffeId = update.SpiffeId
+	}
+	if mask == nil || mask.Ttl {
+		out.Ttl = update.Ttl
+	}
+	if mask == nil || mask.FederatesWith {
+		out.FederatesWith = update.FederatesWith
+	}
+	if mask == nil || mask.Admin {
+		out.Admin = update.Admin
+	}
+	if mask == nil || mask.Downstream {
+		out.Downstream = update.Downstream
+	}
+	if mask == nil || mask.EntryExpiry {
+		out.EntryExpiry = update.EntryExpiry
+	}
+	if mask == nil || mask.DnsNames {
+		out.DnsNames = update.DnsNames
+	}
+	if mask == nil || mask.X509SvidTemplate {
+		out.X509SvidTemplate = update.X509SvidTemplate
+	}
+	if mask == nil || mask.JwtSvidTtl {
+		out.JwtSvidTtl = update.JwtSvidTtl
+	}
+	if mask == nil || mask.JwtSvidClaims {
+		out.JwtSvidClaims = update.JwtSvidClaims
+	}
+	return out
+}
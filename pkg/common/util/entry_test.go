@@ -0,0 +1,50 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyRegistrationEntryMask(t *testing.T) {
+	entry := &common.RegistrationEntry{
+		EntryId:        "id",
+		ParentId:       "spiffe://example.org/parent",
+		SpiffeId:       "spiffe://example.org/foo",
+		Selectors:      []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		DnsNames:       []string{"foo.example.org"},
+		Ttl:            60,
+		RevisionNumber: 3,
+	}
+	update := &common.RegistrationEntry{
+		EntryId:        "other",
+		Selectors:      []*common.Selector{{Type: "unix", Value: "uid:2000"}},
+		DnsNames:       []string{"bar.example.org"},
+		RevisionNumber: 4,
+	}
+
+	// only the masked fields change
+	out := ApplyRegistrationEntryMask(entry, update, &common.RegistrationEntryMask{Selectors: true})
+	require.Equal(t, &common.RegistrationEntry{
+		EntryId:        "id",
+		ParentId:       "spiffe://example.org/parent",
+		SpiffeId:       "spiffe://example.org/foo",
+		Selectors:      []*common.Selector{{Type: "unix", Value: "uid:2000"}},
+		DnsNames:       []string{"foo.example.org"},
+		Ttl:            60,
+		RevisionNumber: 3,
+	}, out)
+
+	// a nil mask replaces every field
+	out = ApplyRegistrationEntryMask(entry, update, nil)
+	require.Equal(t, &common.RegistrationEntry{
+		EntryId:        "id",
+		Selectors:      []*common.Selector{{Type: "unix", Value: "uid:2000"}},
+		DnsNames:       []string{"bar.example.org"},
+		RevisionNumber: 3,
+	}, out)
+
+	// the inputs are left untouched
+	require.Equal(t, []string{"foo.example.org"}, entry.DnsNames)
+}
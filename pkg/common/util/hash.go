@@ -4,6 +4,8 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"hash"
+	"sort"
+	"strings"
 
 	"github.com/spiffe/spire/proto/spire/common"
 )
@@ -20,3 +22,15 @@ func DeriveRegEntryhash(entry *common.RegistrationEntry) (key string) {
 
 	return hex.EncodeToString(hashValue)
 }
+
+// DeriveEntryIDsDigest returns a SHA-256 digest of the given registration
+// entry IDs. The IDs are sorted before hashing so the digest does not depend
+// on their order. It is used by the server and the agent to detect when the
+// set of entries held by the agent has drifted from the authorized set.
+func DeriveEntryIDsDigest(entryIDs []string) []byte {
+	sorted := make([]string, len(entryIDs))
+	copy(sorted, entryIDs)
+	sort.Strings(sorted)
+	sum := sha256.Sum256([]byte(strings.Join(sorted, "\n")))
+	return sum[:]
+}
@@ -0,0 +1,94 @@
+package audit
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/sirupsen/logrus"
+)
+
+// Record is a single entry of the audit log. Records are written as JSON
+// lines. Fields are only ever added to the record, so consumers should
+// ignore fields they don't know about.
+type Record struct {
+	// Time the call started
+	Time time.Time `json:"time"`
+
+	// Method is the full gRPC method called (e.g.
+	// "/spire.api.registration.Registration/CreateEntry")
+	Method string `json:"method"`
+
+	// Caller identifies who made the call
+	Caller Caller `json:"caller"`
+
+	// Request is the request message in the proto3 JSON mapping, with
+	// secrets redacted. For streaming calls, it is the first request
+	// received.
+	Request json.RawMessage `json:"request,omitempty"`
+
+	// ResourceID identifies what the call created, when the request
+	// doesn't: the ID of the registration entry created by CreateEntry or
+	// the SPIFFE ID of the agent attested by Attest.
+	ResourceID string `json:"resource_id,omitempty"`
+
+	// Code is the gRPC status code of the call (e.g. "OK",
+	// "PermissionDenied")
+	Code string `json:"code"`
+
+	// Error is the error message of failed calls
+	Error string `json:"error,omitempty"`
+
+	// LatencyMS is how long the call took, in milliseconds
+	LatencyMS float64 `json:"latency_ms"`
+}
+
+// Caller identifies the caller. Callers over TLS have an address, and a
+// SPIFFE ID if they presented an SVID. Callers over the UDS have the
+// credentials of their process instead.
+type Caller struct {
+	Address  string  `json:"address,omitempty"`
+	SpiffeID string  `json:"spiffe_id,omitempty"`
+	UID      *uint32 `json:"uid,omitempty"`
+	GID      *uint32 `json:"gid,omitempty"`
+	PID      *int32  `json:"pid,omitempty"`
+}
+
+type Config struct {
+	// Writer the records are written to
+	Writer io.Writer
+
+	// Log is used to report records that can't be written
+	Log logrus.FieldLogger
+
+	Clock clock.Clock
+}
+
+// Logger writes audit records for the API calls it intercepts
+type Logger struct {
+	c Config
+
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+func New(c Config) *Logger {
+	if c.Clock == nil {
+		c.Clock = clock.New()
+	}
+	return &Logger{
+		c:   c,
+		enc: json.NewEncoder(c.Writer),
+	}
+}
+
+func (l *Logger) write(record *Record) {
+	l.mu.Lock()
+	err := l.enc.Encode(record)
+	l.mu.Unlock()
+	if err != nil {
+		l.c.Log.WithError(err).Error("Failed to write audit record")
+	}
+}
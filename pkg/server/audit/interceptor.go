@@ -0,0 +1,150 @@
+package audit
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/spiffe/spire/pkg/common/peertracker"
+	"github.com/spiffe/spire/proto/spire/api/node"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	registrationMethodPrefix = "/spire.api.registration.Registration/"
+	attestMethod             = "/spire.api.node.Node/Attest"
+)
+
+var requestMarshaler = &jsonpb.Marshaler{OrigName: true}
+
+// UnaryInterceptor records the calls to the Registration API. It should
+// come before the authorization interceptors so that unauthorized calls are
+// recorded too.
+func (l *Logger) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if !audited(info.FullMethod) {
+		return handler(ctx, req)
+	}
+
+	start := l.c.Clock.Now()
+	resp, err := handler(ctx, req)
+	l.write(l.newRecord(ctx, info.FullMethod, start, req, resourceID(resp), err))
+	return resp, err
+}
+
+// StreamInterceptor records the calls to the Registration API and the
+// attestation calls to the Node API. It should come before the
+// authorization interceptors so that unauthorized calls are recorded too.
+func (l *Logger) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !audited(info.FullMethod) {
+		return handler(srv, ss)
+	}
+
+	start := l.c.Clock.Now()
+	stream := &serverStream{ServerStream: ss}
+	err := handler(srv, stream)
+	l.write(l.newRecord(ss.Context(), info.FullMethod, start, stream.req, stream.resourceID, err))
+	return err
+}
+
+func (l *Logger) newRecord(ctx context.Context, fullMethod string, start time.Time, req interface{}, resourceID string, err error) *Record {
+	st := status.Convert(err)
+	record := &Record{
+		Time:       start.UTC(),
+		Method:     fullMethod,
+		Caller:     identifyCaller(ctx),
+		ResourceID: resourceID,
+		Code:       st.Code().String(),
+		Error:      st.Message(),
+		LatencyMS:  float64(l.c.Clock.Now().Sub(start)) / float64(time.Millisecond),
+	}
+
+	if msg, ok := redact(req).(proto.Message); ok {
+		data, err := requestMarshaler.MarshalToString(msg)
+		if err != nil {
+			l.c.Log.WithError(err).Error("Failed to marshal audited request")
+		} else {
+			record.Request = []byte(data)
+		}
+	}
+
+	return record
+}
+
+// audited returns true for the calls written to the audit log. Calls to the
+// Node API other than attestation are made routinely by every agent and
+// aren't audited.
+func audited(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, registrationMethodPrefix) || fullMethod == attestMethod
+}
+
+func identifyCaller(ctx context.Context) Caller {
+	var caller Caller
+	ctxPeer, ok := peer.FromContext(ctx)
+	if !ok {
+		return caller
+	}
+
+	switch authInfo := ctxPeer.AuthInfo.(type) {
+	case credentials.TLSInfo:
+		if ctxPeer.Addr != nil {
+			caller.Address = ctxPeer.Addr.String()
+		}
+		if len(authInfo.State.VerifiedChains) > 0 && len(authInfo.State.VerifiedChains[0]) > 0 {
+			if uris := authInfo.State.VerifiedChains[0][0].URIs; len(uris) == 1 {
+				caller.SpiffeID = uris[0].String()
+			}
+		}
+	case peertracker.AuthInfo:
+		uid, gid, pid := authInfo.Caller.UID, authInfo.Caller.GID, authInfo.Caller.PID
+		caller.UID = &uid
+		caller.GID = &gid
+		caller.PID = &pid
+	}
+	return caller
+}
+
+// resourceID returns the ID of what the call created, if the request
+// doesn't identify it
+func resourceID(resp interface{}) string {
+	switch resp := resp.(type) {
+	case *registration.RegistrationEntryID:
+		return resp.Id
+	case *node.AttestResponse:
+		if resp.SvidUpdate != nil {
+			for spiffeID := range resp.SvidUpdate.Svids {
+				return spiffeID
+			}
+		}
+	}
+	return ""
+}
+
+// serverStream keeps the first request received and what the call created
+type serverStream struct {
+	grpc.ServerStream
+	req        interface{}
+	resourceID string
+}
+
+func (s *serverStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.req == nil {
+		s.req = m
+	}
+	return nil
+}
+
+func (s *serverStream) SendMsg(m interface{}) error {
+	if id := resourceID(m); id != "" {
+		s.resourceID = id
+	}
+	return s.ServerStream.SendMsg(m)
+}
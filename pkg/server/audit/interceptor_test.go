@@ -0,0 +1,172 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/peertracker"
+	"github.com/spiffe/spire/proto/spire/api/node"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	tlsPeer = &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 12345},
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{
+					{URIs: []*url.URL{{Scheme: "spiffe", Host: "example.org", Path: "/admin"}}},
+				}},
+			},
+		},
+	}
+	udsPeer = &peer.Peer{
+		AuthInfo: peertracker.AuthInfo{
+			Caller: peertracker.CallerInfo{PID: 42, UID: 0, GID: 0},
+		},
+	}
+)
+
+func TestUnaryInterceptor(t *testing.T) {
+	clk := clock.NewMock(t)
+	buf := new(bytes.Buffer)
+	log, _ := test.NewNullLogger()
+	auditLog := New(Config{Writer: buf, Log: log, Clock: clk})
+	start := clk.Now().UTC().Format(time.RFC3339Nano)
+
+	call := func(ctx context.Context, method string, req interface{}, resp interface{}, err error) {
+		info := &grpc.UnaryServerInfo{FullMethod: method}
+		_, _ = auditLog.UnaryInterceptor(ctx, req, info, func(context.Context, interface{}) (interface{}, error) {
+			clk.Add(1500 * time.Microsecond)
+			return resp, err
+		})
+	}
+
+	// successful call over TLS
+	call(peer.NewContext(context.Background(), tlsPeer), "/spire.api.registration.Registration/CreateEntry",
+		&common.RegistrationEntry{ParentId: "spiffe://example.org/node", SpiffeId: "spiffe://example.org/workload"},
+		&registration.RegistrationEntryID{Id: "ENTRYID"}, nil)
+	requireRecord(t, buf, fmt.Sprintf(`{
+		"time": %q,
+		"method": "/spire.api.registration.Registration/CreateEntry",
+		"caller": {"address": "127.0.0.1:12345", "spiffe_id": "spiffe://example.org/admin"},
+		"request": {"parent_id": "spiffe://example.org/node", "spiffe_id": "spiffe://example.org/workload"},
+		"resource_id": "ENTRYID",
+		"code": "OK",
+		"latency_ms": 1.5
+	}`, start))
+
+	// failed call over the UDS with the join token redacted
+	start = clk.Now().UTC().Format(time.RFC3339Nano)
+	call(peer.NewContext(context.Background(), udsPeer), "/spire.api.registration.Registration/DeleteJoinToken",
+		&registration.DeleteJoinTokenRequest{Token: "SECRET"},
+		nil, status.Error(codes.NotFound, "no such token"))
+	requireRecord(t, buf, fmt.Sprintf(`{
+		"time": %q,
+		"method": "/spire.api.registration.Registration/DeleteJoinToken",
+		"caller": {"uid": 0, "gid": 0, "pid": 42},
+		"request": {"token": "REDACTED"},
+		"code": "NotFound",
+		"error": "no such token",
+		"latency_ms": 1.5
+	}`, start))
+
+	// calls to the Node API other than attestation aren't audited
+	call(peer.NewContext(context.Background(), tlsPeer), "/spire.api.node.Node/FetchJWTSVID",
+		&node.FetchJWTSVIDRequest{}, &node.FetchJWTSVIDResponse{}, nil)
+	require.Empty(t, buf.String())
+}
+
+func TestStreamInterceptor(t *testing.T) {
+	clk := clock.NewMock(t)
+	buf := new(bytes.Buffer)
+	log, _ := test.NewNullLogger()
+	auditLog := New(Config{Writer: buf, Log: log, Clock: clk})
+	start := clk.Now().UTC().Format(time.RFC3339Nano)
+
+	ss := &fakeServerStream{
+		ctx: peer.NewContext(context.Background(), &peer.Peer{
+			Addr:     tlsPeer.Addr,
+			AuthInfo: credentials.TLSInfo{},
+		}),
+		reqs: []*node.AttestRequest{
+			{
+				AttestationData: &common.AttestationData{Type: "x509pop", Data: []byte("SECRET")},
+				Csr:             []byte("CSR"),
+			},
+			{
+				Response: []byte("SECRET"),
+			},
+		},
+	}
+	info := &grpc.StreamServerInfo{FullMethod: "/spire.api.node.Node/Attest"}
+	err := auditLog.StreamInterceptor(nil, ss, info, func(srv interface{}, stream grpc.ServerStream) error {
+		for range ss.reqs {
+			if err := stream.RecvMsg(new(node.AttestRequest)); err != nil {
+				return err
+			}
+		}
+		clk.Add(time.Second)
+		return stream.SendMsg(&node.AttestResponse{
+			SvidUpdate: &node.X509SVIDUpdate{
+				Svids: map[string]*node.X509SVID{
+					"spiffe://example.org/spire/agent/x509pop/node": {},
+				},
+			},
+		})
+	})
+	require.NoError(t, err)
+
+	requireRecord(t, buf, fmt.Sprintf(`{
+		"time": %q,
+		"method": "/spire.api.node.Node/Attest",
+		"caller": {"address": "127.0.0.1:12345"},
+		"request": {"attestation_data": {"type": "x509pop"}, "csr": "Q1NS"},
+		"resource_id": "spiffe://example.org/spire/agent/x509pop/node",
+		"code": "OK",
+		"latency_ms": 1000
+	}`, start))
+}
+
+func requireRecord(t *testing.T, buf *bytes.Buffer, expected string) {
+	line, err := buf.ReadString('\n')
+	require.NoError(t, err)
+	require.JSONEq(t, expected, line)
+	require.Empty(t, buf.String(), "only one record expected")
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	reqs []*node.AttestRequest
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeServerStream) RecvMsg(m interface{}) error {
+	*(m.(*node.AttestRequest)) = *s.reqs[0]
+	s.reqs = s.reqs[1:]
+	return nil
+}
+
+func (s *fakeServerStream) SendMsg(m interface{}) error {
+	return nil
+}
@@ -0,0 +1,38 @@
+package audit
+
+import (
+	"github.com/golang/protobuf/proto"
+	"github.com/spiffe/spire/proto/spire/api/node"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+)
+
+const redacted = "REDACTED"
+
+// redact returns a copy of the request with secrets redacted. Join tokens
+// are replaced by "REDACTED". Attestation data and challenge responses may
+// hold credentials (e.g. join tokens, service account tokens) and are
+// removed.
+func redact(req interface{}) interface{} {
+	switch req := req.(type) {
+	case *registration.JoinToken:
+		req = proto.Clone(req).(*registration.JoinToken)
+		if req.Token != "" {
+			req.Token = redacted
+		}
+		return req
+	case *registration.DeleteJoinTokenRequest:
+		req = proto.Clone(req).(*registration.DeleteJoinTokenRequest)
+		if req.Token != "" {
+			req.Token = redacted
+		}
+		return req
+	case *node.AttestRequest:
+		req = proto.Clone(req).(*node.AttestRequest)
+		if req.AttestationData != nil {
+			req.AttestationData.Data = nil
+		}
+		req.Response = nil
+		return req
+	}
+	return req
+}
@@ -0,0 +1,252 @@
+package authpolicy
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/peertracker"
+)
+
+const (
+	EffectAllow = "allow"
+	EffectDeny  = "deny"
+)
+
+// spiffeIDFields are the names of the request fields holding SPIFFE IDs,
+// which are normalized before being matched
+var spiffeIDFields = map[string]bool{
+	"parent_id":      true,
+	"spiffe_id":      true,
+	"federates_with": true,
+}
+
+// Policy decides which calls callers are authorized to make. A call is
+// denied if any deny rule matches it, otherwise it is allowed if any allow
+// rule matches it. Calls not matched by any rule are denied.
+type Policy struct {
+	Rules []Rule `hcl:"rule"`
+}
+
+// Rule matches calls on the caller, the method called and the fields of the
+// request. Unset conditions match any call. Set conditions must all match.
+type Rule struct {
+	// Name identifies the rule in logs
+	Name string `hcl:",key"`
+
+	// Effect is either "allow" (the default) or "deny"
+	Effect string `hcl:"effect"`
+
+	// Callers are patterns matched against the SPIFFE ID of callers over
+	// TCP. Callers over the UDS don't have a SPIFFE ID.
+	Callers []string `hcl:"callers"`
+
+	// UIDs and GIDs are matched against the user and group of callers over
+	// the UDS.
+	UIDs []int `hcl:"uids"`
+	GIDs []int `hcl:"gids"`
+
+	// Methods are patterns matched against the name of the RPC called (e.g.
+	// "CreateEntry")
+	Methods []string `hcl:"methods"`
+
+	// Fields maps request field paths to the pattern the values of the
+	// field must match. Paths are made of proto field names separated by
+	// dots (e.g. "entries.parent_id"). Allow rules match when every value
+	// matches and deny rules when any value matches. SPIFFE IDs are matched
+	// once normalized. Requests without a value at the path don't match.
+	Fields map[string]string `hcl:"fields"`
+}
+
+// Input describes a call being authorized
+type Input struct {
+	// SpiffeID of the caller, if it called over TCP
+	SpiffeID string
+
+	// UnixCaller describes the caller, if it called over the UDS
+	UnixCaller *peertracker.CallerInfo
+
+	// Method is the name of the RPC called (e.g. "CreateEntry")
+	Method string
+
+	// Request is the request message of the call
+	Request interface{}
+}
+
+// Parse parses a policy in HCL or JSON
+func Parse(data []byte) (*Policy, error) {
+	policy := new(Policy)
+	if err := hcl.Decode(policy, string(data)); err != nil {
+		return nil, fmt.Errorf("unable to decode policy: %v", err)
+	}
+
+	for i, rule := range policy.Rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("rule %d has no name", i)
+		}
+		switch rule.Effect {
+		case "":
+			policy.Rules[i].Effect = EffectAllow
+		case EffectAllow, EffectDeny:
+		default:
+			return nil, fmt.Errorf("rule %q has unsupported effect %q", rule.Name, rule.Effect)
+		}
+	}
+
+	return policy, nil
+}
+
+// Evaluate returns the rule deciding the call and whether the call is
+// allowed. The rule is nil if no rule matches the call.
+func (p *Policy) Evaluate(input Input) (*Rule, bool) {
+	var allowedBy *Rule
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if !rule.matches(input) {
+			continue
+		}
+		if rule.Effect == EffectDeny {
+			return rule, false
+		}
+		if allowedBy == nil {
+			allowedBy = rule
+		}
+	}
+	return allowedBy, allowedBy != nil
+}
+
+func (r *Rule) matches(input Input) bool {
+	if len(r.Callers) > 0 && (input.SpiffeID == "" || !matchAny(r.Callers, input.SpiffeID)) {
+		return false
+	}
+	if len(r.UIDs) > 0 && (input.UnixCaller == nil || !containsInt(r.UIDs, int(input.UnixCaller.UID))) {
+		return false
+	}
+	if len(r.GIDs) > 0 && (input.UnixCaller == nil || !containsInt(r.GIDs, int(input.UnixCaller.GID))) {
+		return false
+	}
+	if len(r.Methods) > 0 && !matchAny(r.Methods, input.Method) {
+		return false
+	}
+	for path, pattern := range r.Fields {
+		if !r.matchesField(input.Request, strings.Split(path, "."), pattern) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesField matches the values at a field path of the request against a
+// pattern. Allow rules match when every value matches, so that no value is
+// allowed by accident. Deny rules match when any value matches, so that a
+// denied value can't hide among allowed ones.
+func (r *Rule) matchesField(request interface{}, path []string, pattern string) bool {
+	values := fieldValues(reflect.ValueOf(request), path)
+	if len(values) == 0 {
+		return false
+	}
+	if spiffeIDFields[path[len(path)-1]] {
+		normalizeSpiffeIDs(values)
+	}
+
+	for _, value := range values {
+		matched := matchPattern(pattern, value)
+		if r.Effect == EffectDeny && matched {
+			return true
+		}
+		if r.Effect != EffectDeny && !matched {
+			return false
+		}
+	}
+	return r.Effect != EffectDeny
+}
+
+// normalizeSpiffeIDs normalizes SPIFFE IDs the way the server does before
+// storing them, so that IDs differing only in case of the scheme or trust
+// domain match the same patterns. Values that aren't valid SPIFFE IDs are
+// left as is, since the server rejects them anyway.
+func normalizeSpiffeIDs(values []string) {
+	for i, value := range values {
+		if normalized, err := idutil.NormalizeSpiffeID(value, idutil.AllowAny()); err == nil {
+			values[i] = normalized
+		}
+	}
+}
+
+// matchPattern matches a value against a pattern. A pattern ending in "*"
+// matches any value starting with the rest of the pattern. Otherwise the
+// value must equal the pattern.
+func matchPattern(pattern, value string) bool {
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(value, strings.TrimSuffix(pattern, "*"))
+	}
+	return value == pattern
+}
+
+func matchAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if matchPattern(pattern, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldValues returns the values found at the field path of a generated proto
+// message, formatted as strings. Fields are looked up by the proto field
+// name recorded in the struct tags.
+func fieldValues(v reflect.Value, path []string) []string {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return fieldValues(v.Elem(), path)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			break
+		}
+		var values []string
+		for i := 0; i < v.Len(); i++ {
+			values = append(values, fieldValues(v.Index(i), path)...)
+		}
+		return values
+	}
+
+	if len(path) == 0 {
+		if v.Kind() == reflect.Struct {
+			return nil
+		}
+		return []string{fmt.Sprint(v.Interface())}
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	for i := 0; i < v.NumField(); i++ {
+		if protoFieldName(v.Type().Field(i)) == path[0] {
+			return fieldValues(v.Field(i), path[1:])
+		}
+	}
+	return nil
+}
+
+func protoFieldName(field reflect.StructField) string {
+	for _, part := range strings.Split(field.Tag.Get("protobuf"), ",") {
+		if strings.HasPrefix(part, "name=") {
+			return strings.TrimPrefix(part, "name=")
+		}
+	}
+	return ""
+}
@@ -0,0 +1,328 @@
+package authpolicy
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/spiffe/spire/pkg/common/peertracker"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/stretchr/testify/require"
+)
+
+const testPolicy = `
+rule "team-a-admins" {
+	callers = ["spiffe://example.org/team-a/admin"]
+	methods = ["CreateEntry"]
+	fields = {
+		"parent_id" = "spiffe://example.org/team-a/*"
+		"spiffe_id" = "spiffe://example.org/team-a/*"
+	}
+}
+
+rule "team-a-batches" {
+	callers = ["spiffe://example.org/team-a/admin"]
+	methods = ["BatchCreateEntry"]
+	fields = {
+		"entries.parent_id" = "spiffe://example.org/team-a/*"
+		"entries.spiffe_id" = "spiffe://example.org/team-a/*"
+	}
+}
+
+rule "operators" {
+	uids = [1000]
+	methods = ["Fetch*", "List*"]
+}
+
+rule "root" {
+	uids = [0]
+}
+
+rule "no-admin-entries" {
+	effect = "deny"
+	fields = {
+		"admin" = "true"
+	}
+}
+
+rule "no-team-a-admin-ids" {
+	effect = "deny"
+	fields = {
+		"entries.spiffe_id" = "spiffe://example.org/team-a/admin*"
+	}
+}
+`
+
+func TestParse(t *testing.T) {
+	policy, err := Parse([]byte(testPolicy))
+	require.NoError(t, err)
+	require.Len(t, policy.Rules, 6)
+	require.Equal(t, Rule{
+		Name:    "operators",
+		Effect:  EffectAllow,
+		UIDs:    []int{1000},
+		Methods: []string{"Fetch*", "List*"},
+	}, policy.Rules[2])
+	require.Equal(t, EffectDeny, policy.Rules[4].Effect)
+
+	// JSON is also supported
+	policy, err = Parse([]byte(`{"rule": [{"root": {"uids": [0]}}]}`))
+	require.NoError(t, err)
+	require.Equal(t, []Rule{{Name: "root", Effect: EffectAllow, UIDs: []int{0}}}, policy.Rules)
+}
+
+func TestParseFailures(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		policy string
+		err    string
+	}{
+		{
+			name:   "malformed",
+			policy: `rule "foo" {`,
+			err:    "unable to decode policy",
+		},
+		{
+			name:   "unnamed rule",
+			policy: `rule "" { uids = [0] }`,
+			err:    "rule 0 has no name",
+		},
+		{
+			name:   "unsupported effect",
+			policy: `rule "foo" { effect = "maybe" }`,
+			err:    `rule "foo" has unsupported effect "maybe"`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.policy))
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	policy, err := Parse([]byte(testPolicy))
+	require.NoError(t, err)
+
+	teamAAdmin := "spiffe://example.org/team-a/admin"
+	teamAEntry := &common.RegistrationEntry{
+		ParentId: "spiffe://example.org/team-a/node",
+		SpiffeId: "spiffe://example.org/team-a/workload",
+	}
+	teamBEntry := &common.RegistrationEntry{
+		ParentId: "spiffe://example.org/team-a/node",
+		SpiffeId: "spiffe://example.org/team-b/workload",
+	}
+	operator := &peertracker.CallerInfo{UID: 1000, GID: 1000}
+	root := &peertracker.CallerInfo{UID: 0, GID: 0}
+
+	for _, tt := range []struct {
+		name    string
+		input   Input
+		rule    string
+		allowed bool
+	}{
+		{
+			name: "team admin creates team entry",
+			input: Input{
+				SpiffeID: teamAAdmin,
+				Method:   "CreateEntry",
+				Request:  teamAEntry,
+			},
+			rule:    "team-a-admins",
+			allowed: true,
+		},
+		{
+			name: "team admin creates entry of other team",
+			input: Input{
+				SpiffeID: teamAAdmin,
+				Method:   "CreateEntry",
+				Request:  teamBEntry,
+			},
+		},
+		{
+			name: "team admin creates batch of team entries",
+			input: Input{
+				SpiffeID: teamAAdmin,
+				Method:   "BatchCreateEntry",
+				Request: &registration.BatchCreateEntryRequest{
+					Entries: []*common.RegistrationEntry{teamAEntry, teamAEntry},
+				},
+			},
+			rule:    "team-a-batches",
+			allowed: true,
+		},
+		{
+			name: "team admin creates batch with entry of other team",
+			input: Input{
+				SpiffeID: teamAAdmin,
+				Method:   "BatchCreateEntry",
+				Request: &registration.BatchCreateEntryRequest{
+					Entries: []*common.RegistrationEntry{teamAEntry, teamBEntry},
+				},
+			},
+		},
+		{
+			// deny rules match if any value matches
+			name: "team admin creates batch with denied entry",
+			input: Input{
+				SpiffeID: teamAAdmin,
+				Method:   "BatchCreateEntry",
+				Request: &registration.BatchCreateEntryRequest{
+					Entries: []*common.RegistrationEntry{teamAEntry, {
+						ParentId: "spiffe://example.org/team-a/node",
+						SpiffeId: "spiffe://example.org/team-a/admin2",
+					}},
+				},
+			},
+			rule: "no-team-a-admin-ids",
+		},
+		{
+			// SPIFFE IDs are matched the way the server stores them
+			name: "team admin creates team entry with uppercase trust domain",
+			input: Input{
+				SpiffeID: teamAAdmin,
+				Method:   "CreateEntry",
+				Request: &common.RegistrationEntry{
+					ParentId: "SPIFFE://EXAMPLE.ORG/team-a/node",
+					SpiffeId: "spiffe://Example.org/team-a/workload",
+				},
+			},
+			rule:    "team-a-admins",
+			allowed: true,
+		},
+		{
+			name: "team admin creates batch with denied entry with uppercase trust domain",
+			input: Input{
+				SpiffeID: teamAAdmin,
+				Method:   "BatchCreateEntry",
+				Request: &registration.BatchCreateEntryRequest{
+					Entries: []*common.RegistrationEntry{teamAEntry, {
+						ParentId: "spiffe://example.org/team-a/node",
+						SpiffeId: "SPIFFE://EXAMPLE.ORG/team-a/admin",
+					}},
+				},
+			},
+			rule: "no-team-a-admin-ids",
+		},
+		{
+			name: "team admin creates empty batch",
+			input: Input{
+				SpiffeID: teamAAdmin,
+				Method:   "BatchCreateEntry",
+				Request:  &registration.BatchCreateEntryRequest{},
+			},
+		},
+		{
+			name: "team admin deletes entry",
+			input: Input{
+				SpiffeID: teamAAdmin,
+				Method:   "DeleteEntry",
+				Request:  &registration.RegistrationEntryID{Id: "foo"},
+			},
+		},
+		{
+			name: "other caller creates team entry",
+			input: Input{
+				SpiffeID: "spiffe://example.org/team-b/admin",
+				Method:   "CreateEntry",
+				Request:  teamAEntry,
+			},
+		},
+		{
+			name: "operator lists entries",
+			input: Input{
+				UnixCaller: operator,
+				Method:     "ListEntries",
+				Request: &registration.ListEntriesRequest{
+					Downstream: &wrappers.BoolValue{Value: true},
+				},
+			},
+			rule:    "operators",
+			allowed: true,
+		},
+		{
+			name: "operator creates entry",
+			input: Input{
+				UnixCaller: operator,
+				Method:     "CreateEntry",
+				Request:    teamAEntry,
+			},
+		},
+		{
+			name: "root creates entry",
+			input: Input{
+				UnixCaller: root,
+				Method:     "CreateEntry",
+				Request:    teamAEntry,
+			},
+			rule:    "root",
+			allowed: true,
+		},
+		{
+			name: "deny rules take precedence",
+			input: Input{
+				UnixCaller: root,
+				Method:     "CreateEntry",
+				Request: &common.RegistrationEntry{
+					SpiffeId: "spiffe://example.org/admin",
+					Admin:    true,
+				},
+			},
+			rule: "no-admin-entries",
+		},
+		{
+			name: "TCP caller does not match UDS rules",
+			input: Input{
+				SpiffeID: "spiffe://example.org/operator",
+				Method:   "ListEntries",
+				Request:  &registration.ListEntriesRequest{},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			rule, allowed := policy.Evaluate(tt.input)
+			require.Equal(t, tt.allowed, allowed)
+			if tt.rule == "" {
+				require.Nil(t, rule)
+				return
+			}
+			require.NotNil(t, rule)
+			require.Equal(t, tt.rule, rule.Name)
+		})
+	}
+}
+
+func TestFieldValues(t *testing.T) {
+	req := &registration.BatchCreateEntryRequest{
+		Entries: []*common.RegistrationEntry{
+			{
+				Selectors: []*common.Selector{
+					{Type: "unix", Value: "uid:1000"},
+					{Type: "unix", Value: "gid:1000"},
+				},
+				Ttl: 60,
+			},
+			{
+				Selectors: []*common.Selector{
+					{Type: "k8s", Value: "ns:foo"},
+				},
+				DnsNames: []string{"a.example.org", "b.example.org"},
+			},
+		},
+	}
+
+	values := func(path ...string) []string {
+		return fieldValues(reflect.ValueOf(req), path)
+	}
+	require.Equal(t, []string{"unix", "unix", "k8s"}, values("entries", "selectors", "type"))
+	require.Equal(t, []string{"60", "0"}, values("entries", "ttl"))
+	require.Equal(t, []string{"a.example.org", "b.example.org"}, values("entries", "dns_names"))
+	require.Empty(t, values("entries", "selectors"))
+	require.Empty(t, values("entries", "unknown"))
+	require.Empty(t, values("entries", "ttl", "unknown"))
+}
@@ -0,0 +1,104 @@
+package authpolicy
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+)
+
+const (
+	reloadInterval = 5 * time.Second
+)
+
+type WatcherConfig struct {
+	// Path of the policy file
+	Path string
+
+	Log   logrus.FieldLogger
+	Clock clock.Clock
+}
+
+// Watcher holds the policy loaded from a policy file, reloading it whenever
+// the file changes. If the changed file can't be loaded, the policy
+// previously loaded stays in effect.
+type Watcher struct {
+	c WatcherConfig
+
+	mu     sync.RWMutex
+	data   []byte
+	policy *Policy
+}
+
+// NewWatcher loads the policy file. It fails if the file can't be loaded.
+func NewWatcher(c WatcherConfig) (*Watcher, error) {
+	if c.Clock == nil {
+		c.Clock = clock.New()
+	}
+	w := &Watcher{
+		c: c,
+	}
+	if _, err := w.reload(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Policy returns the policy currently in effect
+func (w *Watcher) Policy() *Policy {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.policy
+}
+
+// Run reloads the policy file when it changes until the context is canceled
+func (w *Watcher) Run(ctx context.Context) error {
+	ticker := w.c.Clock.Ticker(reloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			reloaded, err := w.reload()
+			switch {
+			case err != nil:
+				w.c.Log.WithError(err).WithField(telemetry.Path, w.c.Path).Error("Could not reload authorization policy; keeping the current policy")
+			case reloaded:
+				w.c.Log.WithField(telemetry.Path, w.c.Path).Info("Reloaded authorization policy")
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// reload loads the policy file if it changed since it was last loaded
+func (w *Watcher) reload() (bool, error) {
+	data, err := ioutil.ReadFile(w.c.Path)
+	if err != nil {
+		return false, err
+	}
+
+	w.mu.RLock()
+	unchanged := w.policy != nil && bytes.Equal(data, w.data)
+	w.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	policy, err := Parse(data)
+	if err != nil {
+		return false, err
+	}
+
+	w.mu.Lock()
+	w.data = data
+	w.policy = policy
+	w.mu.Unlock()
+	return true, nil
+}
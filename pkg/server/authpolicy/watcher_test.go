@@ -0,0 +1,94 @@
+package authpolicy
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWatcherFailsOnBadPolicy(t *testing.T) {
+	dir, path := writeTempPolicy(t, `rule "foo" {`)
+	defer os.RemoveAll(dir)
+
+	log, _ := test.NewNullLogger()
+	_, err := NewWatcher(WatcherConfig{Path: path, Log: log})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unable to decode policy")
+
+	_, err = NewWatcher(WatcherConfig{Path: filepath.Join(dir, "missing.hcl"), Log: log})
+	require.Error(t, err)
+}
+
+func TestWatcherReloadsPolicy(t *testing.T) {
+	dir, path := writeTempPolicy(t, `rule "foo" {}`)
+	defer os.RemoveAll(dir)
+
+	log, hook := test.NewNullLogger()
+	clk := clock.NewMock(t)
+	w, err := NewWatcher(WatcherConfig{Path: path, Log: log, Clock: clk})
+	require.NoError(t, err)
+	require.Equal(t, "foo", w.Policy().Rules[0].Name)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- w.Run(ctx)
+	}()
+	clk.WaitForTicker(time.Minute, "waiting for the reload ticker")
+
+	// unchanged files are not reloaded
+	policy := w.Policy()
+	tick(t, clk, hook)
+	require.True(t, policy == w.Policy())
+	require.Empty(t, hook.AllEntries())
+
+	// changed files are reloaded
+	require.NoError(t, ioutil.WriteFile(path, []byte(`rule "bar" {}`), 0600))
+	entry := tick(t, clk, hook)
+	require.Equal(t, logrus.InfoLevel, entry.Level)
+	require.Equal(t, "Reloaded authorization policy", entry.Message)
+	require.Equal(t, "bar", w.Policy().Rules[0].Name)
+
+	// bad files leave the current policy in effect
+	hook.Reset()
+	require.NoError(t, ioutil.WriteFile(path, []byte(`rule "baz" { effect = "maybe" }`), 0600))
+	entry = tick(t, clk, hook)
+	require.Equal(t, logrus.ErrorLevel, entry.Level)
+	require.Equal(t, "Could not reload authorization policy; keeping the current policy", entry.Message)
+	require.Equal(t, "bar", w.Policy().Rules[0].Name)
+
+	cancel()
+	require.NoError(t, <-errCh)
+}
+
+// tick advances the clock to the next reload and waits for it to be logged
+// when the reload is expected to log.
+func tick(t *testing.T, clk *clock.Mock, hook *test.Hook) *logrus.Entry {
+	before := len(hook.AllEntries())
+	clk.Add(reloadInterval)
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if entries := hook.AllEntries(); len(entries) > before {
+			return entries[len(entries)-1]
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return nil
+}
+
+func writeTempPolicy(t *testing.T, policy string) (string, string) {
+	dir, err := ioutil.TempDir("", "spire-server-authpolicy-")
+	require.NoError(t, err)
+	path := filepath.Join(dir, "policy.hcl")
+	require.NoError(t, ioutil.WriteFile(path, []byte(policy), 0600))
+	return dir, path
+}
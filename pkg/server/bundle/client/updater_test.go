@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/spiffe/spire/pkg/common/bundleutil"
+	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/proto/spire/server/datastore"
 	"github.com/spiffe/spire/test/fakes/fakedatastore"
 	"github.com/spiffe/spire/test/spiretest"
@@ -101,7 +102,7 @@ func TestBundleUpdater(t *testing.T) {
 			require.NoError(t, err)
 			if testCase.localBundle != nil {
 				require.NotNil(t, localBundle)
-				spiretest.RequireProtoEqual(t, testCase.localBundle.Proto(), localBundle.Proto())
+				spiretest.RequireProtoEqual(t, testCase.localBundle.Proto(), withoutRevision(localBundle.Proto()))
 			} else {
 				require.Nil(t, localBundle)
 			}
@@ -120,7 +121,7 @@ func TestBundleUpdater(t *testing.T) {
 			require.NotNil(t, resp)
 			if testCase.storedBundle != nil {
 				require.NotNil(t, resp.Bundle)
-				spiretest.RequireProtoEqual(t, testCase.storedBundle.Proto(), resp.Bundle)
+				spiretest.RequireProtoEqual(t, testCase.storedBundle.Proto(), withoutRevision(resp.Bundle))
 			} else {
 				require.Nil(t, resp.Bundle)
 			}
@@ -128,6 +129,12 @@ func TestBundleUpdater(t *testing.T) {
 	}
 }
 
+// withoutRevision clears the revision assigned to the bundle by the datastore
+func withoutRevision(bundle *common.Bundle) *common.Bundle {
+	bundle.RevisionNumber = 0
+	return bundle
+}
+
 type fakeClient struct {
 	bundle *bundleutil.Bundle
 	err    error
@@ -13,12 +13,14 @@ import (
 
 	"github.com/andres-erbsen/clock"
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
 	"github.com/spiffe/spire/pkg/common/x509util"
-	"github.com/spiffe/spire/proto/spire/api/node"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/proto/spire/server/datastore"
 	"github.com/zeebo/errs"
 )
 
@@ -36,7 +38,7 @@ const (
 type ServerCA interface {
 	SignX509SVID(ctx context.Context, params X509SVIDParams) ([]*x509.Certificate, error)
 	SignX509CASVID(ctx context.Context, params X509CASVIDParams) ([]*x509.Certificate, error)
-	SignJWTSVID(ctx context.Context, jsr *node.JSR) (string, error)
+	SignJWTSVID(ctx context.Context, params JWTSVIDParams) (string, error)
 
 	// Sign an SVID used to serve SPIRE server TLS endpoints
 	// This is required because in some cases, an UpstreamCA root is used to bootstrap
@@ -78,6 +80,18 @@ type X509SVIDParams struct {
 	// DNSList is used to add DNS SAN's to the X509 SVID. The first entry
 	// is also added as the CN.
 	DNSList []string
+
+	// Template customizes the subject and key usages of the SVID.
+	// It is validated before it is applied.
+	Template *common.X509SVIDTemplate
+
+	// EntryID is the ID of the registration entry the SVID is signed for,
+	// if any. It is recorded in the issuance log.
+	EntryID string
+
+	// AgentID is the SPIFFE ID of the agent requesting the SVID, if any. It
+	// is recorded in the issuance log.
+	AgentID string
 }
 
 // X509CASVIDParams are parameters relevant to X509 CA SVID creation
@@ -91,6 +105,39 @@ type X509CASVIDParams struct {
 	// TTL is the desired time-to-live of the SVID. Regardless of the TTL, the
 	// lifetime of the certificate will be capped to that of the signing cert.
 	TTL time.Duration
+
+	// EntryID is the ID of the registration entry the SVID is signed for,
+	// if any. It is recorded in the issuance log.
+	EntryID string
+
+	// AgentID is the SPIFFE ID of the agent requesting the SVID, if any. It
+	// is recorded in the issuance log.
+	AgentID string
+}
+
+// JWTSVIDParams are parameters relevant to JWT SVID creation
+type JWTSVIDParams struct {
+	// SPIFFE ID of the SVID
+	SpiffeID string
+
+	// TTL is the desired time-to-live of the SVID. Regardless of the TTL, the
+	// lifetime of the token will be capped to that of the signing key.
+	TTL time.Duration
+
+	// Audience is used for audience claims
+	Audience []string
+
+	// Claims are additional claims embedded in the SVID. Registered claims
+	// cannot be set.
+	Claims map[string]string
+
+	// EntryID is the ID of the registration entry the SVID is signed for,
+	// if any. It is recorded in the issuance log.
+	EntryID string
+
+	// AgentID is the SPIFFE ID of the agent requesting the SVID, if any. It
+	// is recorded in the issuance log.
+	AgentID string
 }
 
 // X509CASVIDParams are parameters relevant to X509 CA SVID creation
@@ -122,6 +169,15 @@ type JWTKey struct {
 
 	// NotAfter is the expiration time of the JWT key.
 	NotAfter time.Time
+
+	// Alg is the JWS algorithm used to sign JWT-SVIDs (e.g. PS256). If
+	// empty, the default algorithm for the key type is used.
+	Alg string
+}
+
+// IssuanceLog records the SVIDs signed by the CA
+type IssuanceLog interface {
+	RecordIssuedSVID(ctx context.Context, issuedSVID *datastore.IssuedSVID) error
 }
 
 type CAConfig struct {
@@ -131,6 +187,17 @@ type CAConfig struct {
 	X509SVIDTTL time.Duration
 	Clock       clock.Clock
 	CASubject   pkix.Name
+
+	// IssuanceLog, if set, records every SVID signed by the CA. Signing
+	// fails if the SVID cannot be recorded, unless IssuanceLogFailOpen is
+	// set.
+	IssuanceLog IssuanceLog
+
+	// IssuanceLogFailOpen, if set, only logs failures to record signed
+	// SVIDs in the issuance log instead of failing the signing. SVIDs that
+	// aren't recorded are missing from the issuance log and are not revoked
+	// when their agent is evicted.
+	IssuanceLogFailOpen bool
 }
 
 type CA struct {
@@ -240,6 +307,10 @@ func (ca *CA) signX509SVID(ctx context.Context, params X509SVIDParams, x509CA *X
 		template.DNSNames = params.DNSList
 	}
 
+	if err := applyX509SVIDTemplate(template, params.Template); err != nil {
+		return nil, err
+	}
+
 	cert, err := createCertificate(template, x509CA.Certificate, template.PublicKey, x509CA.Signer)
 	if err != nil {
 		return nil, errs.New("unable to create X509 SVID: %v", err)
@@ -247,6 +318,19 @@ func (ca *CA) signX509SVID(ctx context.Context, params X509SVIDParams, x509CA *X
 
 	spiffeID := cert.URIs[0].String()
 
+	if err := ca.recordIssuedSVID(ctx, &datastore.IssuedSVID{
+		Id:          cert.SerialNumber.String(),
+		Type:        datastore.IssuedSVID_X509_SVID,
+		SpiffeId:    spiffeID,
+		EntryId:     params.EntryID,
+		AgentId:     params.AgentID,
+		AuthorityId: bundleutil.X509AuthorityID(x509CA.Certificate),
+		NotBefore:   cert.NotBefore.Unix(),
+		NotAfter:    cert.NotAfter.Unix(),
+	}); err != nil {
+		return nil, err
+	}
+
 	ca.c.Log.WithFields(logrus.Fields{
 		telemetry.SPIFFEID:   spiffeID,
 		telemetry.Expiration: cert.NotAfter.Format(time.RFC3339),
@@ -295,6 +379,19 @@ func (ca *CA) SignX509CASVID(ctx context.Context, params X509CASVIDParams) ([]*x
 
 	spiffeID := cert.URIs[0].String()
 
+	if err := ca.recordIssuedSVID(ctx, &datastore.IssuedSVID{
+		Id:          cert.SerialNumber.String(),
+		Type:        datastore.IssuedSVID_X509_CA_SVID,
+		SpiffeId:    spiffeID,
+		EntryId:     params.EntryID,
+		AgentId:     params.AgentID,
+		AuthorityId: bundleutil.X509AuthorityID(x509CA.Certificate),
+		NotBefore:   cert.NotBefore.Unix(),
+		NotAfter:    cert.NotAfter.Unix(),
+	}); err != nil {
+		return nil, err
+	}
+
 	ca.c.Log.WithFields(logrus.Fields{
 		telemetry.SPIFFEID:   spiffeID,
 		telemetry.Expiration: cert.NotAfter.Format(time.RFC3339),
@@ -305,32 +402,76 @@ func (ca *CA) SignX509CASVID(ctx context.Context, params X509CASVIDParams) ([]*x
 	return makeSVIDCertChain(x509CA, cert), nil
 }
 
-func (ca *CA) SignJWTSVID(ctx context.Context, jsr *node.JSR) (string, error) {
+func (ca *CA) SignJWTSVID(ctx context.Context, params JWTSVIDParams) (string, error) {
 	jwtKey := ca.JWTKey()
 	if jwtKey == nil {
 		return "", errs.New("JWT key is not available for signing")
 	}
 
-	if err := idutil.ValidateSpiffeID(jsr.SpiffeId, idutil.AllowTrustDomainWorkload(ca.c.TrustDomain.Host)); err != nil {
+	if err := idutil.ValidateSpiffeID(params.SpiffeID, idutil.AllowTrustDomainWorkload(ca.c.TrustDomain.Host)); err != nil {
 		return "", err
 	}
 
-	ttl := time.Duration(jsr.Ttl) * time.Second
+	ttl := params.TTL
 	if ttl <= 0 {
 		ttl = DefaultJWTSVIDTTL
 	}
 	_, expiresAt := ca.capLifetime(ttl, jwtKey.NotAfter)
 
-	token, err := ca.jwtSigner.SignToken(jsr.SpiffeId, jsr.Audience, expiresAt, jwtKey.Signer, jwtKey.Kid)
+	// JWT-SVIDs only carry a token ID when one is needed to identify their
+	// record in the issuance log
+	var jti, token string
+	var err error
+	if ca.c.IssuanceLog == nil {
+		token, err = ca.jwtSigner.SignTokenWithClaims(params.SpiffeID, params.Audience, expiresAt, jwtKey.Signer, jwtKey.Kid, jwtKey.Alg, params.Claims)
+	} else {
+		jti, err = jwtsvid.NewTokenID()
+		if err != nil {
+			return "", errs.New("unable to generate JWT SVID token ID: %v", err)
+		}
+		token, err = ca.jwtSigner.SignTokenWithID(params.SpiffeID, params.Audience, expiresAt, jwtKey.Signer, jwtKey.Kid, jwtKey.Alg, jti, params.Claims)
+	}
 	if err != nil {
 		return "", errs.New("unable to sign JWT SVID: %v", err)
 	}
 
-	telemetry_server.IncrServerCASignJWTSVIDCounter(ca.c.Metrics, jsr.SpiffeId, jsr.Audience...)
+	if err := ca.recordIssuedSVID(ctx, &datastore.IssuedSVID{
+		Id:          jti,
+		Type:        datastore.IssuedSVID_JWT_SVID,
+		SpiffeId:    params.SpiffeID,
+		EntryId:     params.EntryID,
+		AgentId:     params.AgentID,
+		AuthorityId: jwtKey.Kid,
+		NotBefore:   ca.c.Clock.Now().Unix(),
+		NotAfter:    expiresAt.Unix(),
+	}); err != nil {
+		return "", err
+	}
+
+	telemetry_server.IncrServerCASignJWTSVIDCounter(ca.c.Metrics, params.SpiffeID, params.Audience...)
 
 	return token, nil
 }
 
+// recordIssuedSVID records the issued SVID in the issuance log, if one is
+// configured. Failing to record it fails the signing, unless the issuance log
+// is configured to fail open, in which case the failure is only logged.
+func (ca *CA) recordIssuedSVID(ctx context.Context, issuedSVID *datastore.IssuedSVID) error {
+	if ca.c.IssuanceLog == nil {
+		return nil
+	}
+	if err := ca.c.IssuanceLog.RecordIssuedSVID(ctx, issuedSVID); err != nil {
+		if !ca.c.IssuanceLogFailOpen {
+			return errs.New("unable to record issued SVID: %v", err)
+		}
+		ca.c.Log.WithError(err).WithFields(logrus.Fields{
+			telemetry.SPIFFEID: issuedSVID.SpiffeId,
+			telemetry.SVIDType: issuedSVID.Type.String(),
+		}).Error("Unable to record issued SVID")
+	}
+	return nil
+}
+
 func (ca *CA) capLifetime(ttl time.Duration, expirationCap time.Time) (notBefore, notAfter time.Time) {
 	now := ca.c.Clock.Now()
 	notBefore = now.Add(-backdate)
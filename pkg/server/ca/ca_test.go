@@ -5,18 +5,23 @@ import (
 	"crypto/rand"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"errors"
 	"math/big"
 	"net/url"
 	"testing"
 	"time"
 
+	jwt "github.com/dgrijalva/jwt-go"
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/pkg/common/pemutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/x509util"
-	"github.com/spiffe/spire/proto/spire/api/node"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/proto/spire/server/datastore"
 	"github.com/spiffe/spire/test/clock"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -42,6 +47,7 @@ type CATestSuite struct {
 	clock        *clock.Mock
 	upstreamCert *x509.Certificate
 	caCert       *x509.Certificate
+	issuanceLog  *fakeIssuanceLog
 
 	ca *CA
 }
@@ -57,6 +63,7 @@ func (s *CATestSuite) SetupSuite() {
 func (s *CATestSuite) SetupTest() {
 	log, logHook := test.NewNullLogger()
 	s.logHook = logHook
+	s.issuanceLog = new(fakeIssuanceLog)
 
 	s.ca = NewCA(CAConfig{
 		Log:     log,
@@ -70,6 +77,7 @@ func (s *CATestSuite) SetupTest() {
 		CASubject: pkix.Name{
 			CommonName: "TESTCA",
 		},
+		IssuanceLog: s.issuanceLog,
 	})
 	s.setX509CA(false)
 	s.setJWTKey()
@@ -172,6 +180,95 @@ func (s *CATestSuite) TestSignX509SVIDMultipleDNS() {
 	s.Require().Equal("somehost1", svid[0].Subject.CommonName)
 }
 
+func (s *CATestSuite) TestSignX509SVIDWithTemplate() {
+	params := s.createX509SVIDParams()
+	params.DNSList = []string{"somehost1"}
+	params.Template = &common.X509SVIDTemplate{
+		Subject: &common.X509Subject{
+			Country:            []string{"US"},
+			Organization:       []string{"ACME"},
+			OrganizationalUnit: []string{"gateway"},
+		},
+		ExtraKeyUsages:    []string{"content_commitment"},
+		ExtraExtKeyUsages: []string{"code_signing"},
+	}
+	svid, err := s.ca.SignX509SVID(ctx, params)
+	s.Require().NoError(err)
+	s.Require().Len(svid, 1)
+	s.Require().Equal([]string{"US"}, svid[0].Subject.Country)
+	s.Require().Equal([]string{"ACME"}, svid[0].Subject.Organization)
+	s.Require().Equal([]string{"gateway"}, svid[0].Subject.OrganizationalUnit)
+	// the first DNS name is still used when the template has no common name
+	s.Require().Equal("somehost1", svid[0].Subject.CommonName)
+	s.Require().Len(svid[0].URIs, 1)
+	s.Require().Equal(params.SpiffeID, svid[0].URIs[0].String())
+	s.Require().Equal(x509.KeyUsageKeyEncipherment|
+		x509.KeyUsageKeyAgreement|
+		x509.KeyUsageDigitalSignature|
+		x509.KeyUsageContentCommitment, svid[0].KeyUsage)
+	s.Require().Equal([]x509.ExtKeyUsage{
+		x509.ExtKeyUsageServerAuth,
+		x509.ExtKeyUsageClientAuth,
+		x509.ExtKeyUsageCodeSigning,
+	}, svid[0].ExtKeyUsage)
+	s.Require().False(svid[0].IsCA)
+}
+
+func (s *CATestSuite) TestSignX509SVIDWithTemplateCommonName() {
+	params := s.createX509SVIDParams()
+	params.DNSList = []string{"somehost1"}
+	params.Template = &common.X509SVIDTemplate{
+		Subject: &common.X509Subject{
+			CommonName: "gateway",
+		},
+	}
+	svid, err := s.ca.SignX509SVID(ctx, params)
+	s.Require().NoError(err)
+	s.Require().Len(svid, 1)
+	s.Require().Equal("gateway", svid[0].Subject.CommonName)
+	s.Require().Empty(svid[0].Subject.Organization)
+	s.Require().Equal(params.DNSList, svid[0].DNSNames)
+}
+
+func (s *CATestSuite) TestSignX509SVIDValidatesTemplate() {
+	for _, tt := range []struct {
+		name     string
+		template *common.X509SVIDTemplate
+		err      string
+	}{
+		{
+			name:     "CA key usage",
+			template: &common.X509SVIDTemplate{ExtraKeyUsages: []string{"cert_sign"}},
+			err:      `invalid X509-SVID template: key usage "cert_sign" is reserved for CA certificates`,
+		},
+		{
+			name:     "unsupported key usage",
+			template: &common.X509SVIDTemplate{ExtraKeyUsages: []string{"decipher_only"}},
+			err:      `invalid X509-SVID template: unsupported key usage "decipher_only"`,
+		},
+		{
+			name:     "unsupported extended key usage",
+			template: &common.X509SVIDTemplate{ExtraExtKeyUsages: []string{"any"}},
+			err:      `invalid X509-SVID template: unsupported extended key usage "any"`,
+		},
+		{
+			name: "empty subject attribute",
+			template: &common.X509SVIDTemplate{
+				Subject: &common.X509Subject{OrganizationalUnit: []string{" "}},
+			},
+			err: "invalid X509-SVID template: subject attributes cannot be empty",
+		},
+	} {
+		tt := tt
+		s.T().Run(tt.name, func(t *testing.T) {
+			params := s.createX509SVIDParams()
+			params.Template = tt.template
+			_, err := s.ca.SignX509SVID(ctx, params)
+			require.EqualError(t, err, tt.err)
+		})
+	}
+}
+
 func (s *CATestSuite) TestSignX509SVIDReturnsChainIfIntermediate() {
 	s.setX509CA(true)
 
@@ -220,12 +317,12 @@ func (s *CATestSuite) TestSignX509SVIDChangesSerialNumber() {
 
 func (s *CATestSuite) TestNoJWTKeySet() {
 	s.ca.SetJWTKey(nil)
-	_, err := s.ca.SignJWTSVID(ctx, s.generateJSR("example.org", 0))
+	_, err := s.ca.SignJWTSVID(ctx, s.createJWTSVIDParams("example.org", 0))
 	s.Require().EqualError(err, "JWT key is not available for signing")
 }
 
 func (s *CATestSuite) TestSignJWTSVIDUsesDefaultTTLIfTTLUnspecified() {
-	token, err := s.ca.SignJWTSVID(ctx, s.generateJSR("example.org", 0))
+	token, err := s.ca.SignJWTSVID(ctx, s.createJWTSVIDParams("example.org", 0))
 	s.Require().NoError(err)
 	issuedAt, expiresAt, err := jwtsvid.GetTokenExpiry(token)
 	s.Require().NoError(err)
@@ -234,7 +331,7 @@ func (s *CATestSuite) TestSignJWTSVIDUsesDefaultTTLIfTTLUnspecified() {
 }
 
 func (s *CATestSuite) TestSignJWTSVIDUsesTTLIfSpecified() {
-	token, err := s.ca.SignJWTSVID(ctx, s.generateJSR("example.org", time.Minute+time.Second))
+	token, err := s.ca.SignJWTSVID(ctx, s.createJWTSVIDParams("example.org", time.Minute+time.Second))
 	s.Require().NoError(err)
 	issuedAt, expiresAt, err := jwtsvid.GetTokenExpiry(token)
 	s.Require().NoError(err)
@@ -243,7 +340,7 @@ func (s *CATestSuite) TestSignJWTSVIDUsesTTLIfSpecified() {
 }
 
 func (s *CATestSuite) TestSignJWTSVIDCapsTTLToKeyExpiry() {
-	token, err := s.ca.SignJWTSVID(ctx, s.generateJSR("example.org", time.Hour))
+	token, err := s.ca.SignJWTSVID(ctx, s.createJWTSVIDParams("example.org", time.Hour))
 	s.Require().NoError(err)
 	issuedAt, expiresAt, err := jwtsvid.GetTokenExpiry(token)
 	s.Require().NoError(err)
@@ -253,16 +350,34 @@ func (s *CATestSuite) TestSignJWTSVIDCapsTTLToKeyExpiry() {
 
 func (s *CATestSuite) TestSignJWTSVIDValidatesJSR() {
 	// spiffe id for wrong trust domain
-	_, err := s.ca.SignJWTSVID(ctx, s.generateJSR("foo.com", 0))
+	_, err := s.ca.SignJWTSVID(ctx, s.createJWTSVIDParams("foo.com", 0))
 	s.Require().EqualError(err, `"spiffe://foo.com/workload" does not belong to trust domain "example.org"`)
 
 	// audience is required
-	noAudience := s.generateJSR("example.org", 0)
+	noAudience := s.createJWTSVIDParams("example.org", 0)
 	noAudience.Audience = nil
 	_, err = s.ca.SignJWTSVID(ctx, noAudience)
 	s.Require().EqualError(err, "unable to sign JWT SVID: audience is required")
 }
 
+func (s *CATestSuite) TestSignJWTSVIDWithClaims() {
+	params := s.createJWTSVIDParams("example.org", 0)
+	params.Claims = map[string]string{"tenant": "acme"}
+	token, err := s.ca.SignJWTSVID(ctx, params)
+	s.Require().NoError(err)
+
+	parsed, _, err := new(jwt.Parser).ParseUnverified(token, jwt.MapClaims{})
+	s.Require().NoError(err)
+	claims, ok := parsed.Claims.(jwt.MapClaims)
+	s.Require().True(ok)
+	s.Require().Equal("acme", claims["tenant"])
+
+	// registered claims cannot be overridden
+	params.Claims = map[string]string{"sub": "spiffe://example.org/admin"}
+	_, err = s.ca.SignJWTSVID(ctx, params)
+	s.Require().EqualError(err, `unable to sign JWT SVID: claim "sub" is reserved`)
+}
+
 func (s *CATestSuite) TestSignX509CASVIDNoCASet() {
 	s.ca.SetX509CA(nil)
 	_, err := s.ca.SignX509CASVID(ctx, s.createX509CASVIDParams("example.org"))
@@ -307,6 +422,117 @@ func (s *CATestSuite) TestSignCAX509SVIDValidatesTrustDomain() {
 	s.Require().EqualError(err, `"spiffe://foo.com" does not belong to trust domain "example.org"`)
 }
 
+func (s *CATestSuite) TestSignX509SVIDRecordsIssuance() {
+	params := s.createX509SVIDParams()
+	params.EntryID = "ENTRYID"
+	params.AgentID = "spiffe://example.org/spire/agent/foo"
+	svid, err := s.ca.SignX509SVID(ctx, params)
+	s.Require().NoError(err)
+
+	s.Require().Equal([]*datastore.IssuedSVID{
+		{
+			Id:          svid[0].SerialNumber.String(),
+			Type:        datastore.IssuedSVID_X509_SVID,
+			SpiffeId:    "spiffe://example.org/workload",
+			EntryId:     "ENTRYID",
+			AgentId:     "spiffe://example.org/spire/agent/foo",
+			AuthorityId: bundleutil.X509AuthorityID(s.caCert),
+			NotBefore:   svid[0].NotBefore.Unix(),
+			NotAfter:    svid[0].NotAfter.Unix(),
+		},
+	}, s.issuanceLog.issuedSVIDs)
+}
+
+func (s *CATestSuite) TestSignX509CASVIDRecordsIssuance() {
+	params := s.createX509CASVIDParams("example.org")
+	params.EntryID = "ENTRYID"
+	params.AgentID = "spiffe://example.org/spire/agent/foo"
+	svid, err := s.ca.SignX509CASVID(ctx, params)
+	s.Require().NoError(err)
+
+	s.Require().Equal([]*datastore.IssuedSVID{
+		{
+			Id:          svid[0].SerialNumber.String(),
+			Type:        datastore.IssuedSVID_X509_CA_SVID,
+			SpiffeId:    "spiffe://example.org",
+			EntryId:     "ENTRYID",
+			AgentId:     "spiffe://example.org/spire/agent/foo",
+			AuthorityId: bundleutil.X509AuthorityID(s.caCert),
+			NotBefore:   svid[0].NotBefore.Unix(),
+			NotAfter:    svid[0].NotAfter.Unix(),
+		},
+	}, s.issuanceLog.issuedSVIDs)
+}
+
+func (s *CATestSuite) TestSignJWTSVIDRecordsIssuance() {
+	params := s.createJWTSVIDParams("example.org", 0)
+	params.EntryID = "ENTRYID"
+	params.AgentID = "spiffe://example.org/spire/agent/foo"
+	token, err := s.ca.SignJWTSVID(ctx, params)
+	s.Require().NoError(err)
+	jti, err := jwtsvid.GetTokenID(token)
+	s.Require().NoError(err)
+
+	s.Require().Equal([]*datastore.IssuedSVID{
+		{
+			Id:          jti,
+			Type:        datastore.IssuedSVID_JWT_SVID,
+			SpiffeId:    "spiffe://example.org/workload",
+			EntryId:     "ENTRYID",
+			AgentId:     "spiffe://example.org/spire/agent/foo",
+			AuthorityId: "KID",
+			NotBefore:   s.clock.Now().Unix(),
+			NotAfter:    s.clock.Now().Add(DefaultJWTSVIDTTL).Unix(),
+		},
+	}, s.issuanceLog.issuedSVIDs)
+}
+
+func (s *CATestSuite) TestSignJWTSVIDWithoutIssuanceLog() {
+	s.ca.c.IssuanceLog = nil
+
+	// the token ID only identifies the record in the issuance log, so
+	// tokens are not given one if there is no issuance log
+	token, err := s.ca.SignJWTSVID(ctx, s.createJWTSVIDParams("example.org", 0))
+	s.Require().NoError(err)
+	_, err = jwtsvid.GetTokenID(token)
+	s.Require().EqualError(err, "JWT missing jti claim")
+}
+
+func (s *CATestSuite) TestSignFailsIfIssuanceCannotBeRecorded() {
+	s.issuanceLog.err = errors.New("oh no")
+
+	_, err := s.ca.SignX509SVID(ctx, s.createX509SVIDParams())
+	s.Require().EqualError(err, "unable to record issued SVID: oh no")
+
+	_, err = s.ca.SignX509CASVID(ctx, s.createX509CASVIDParams("example.org"))
+	s.Require().EqualError(err, "unable to record issued SVID: oh no")
+
+	_, err = s.ca.SignJWTSVID(ctx, s.createJWTSVIDParams("example.org", 0))
+	s.Require().EqualError(err, "unable to record issued SVID: oh no")
+}
+
+func (s *CATestSuite) TestSignSucceedsIfIssuanceCannotBeRecordedWhenFailingOpen() {
+	s.ca.c.IssuanceLogFailOpen = true
+	s.issuanceLog.err = errors.New("oh no")
+
+	_, err := s.ca.SignX509SVID(ctx, s.createX509SVIDParams())
+	s.Require().NoError(err)
+
+	_, err = s.ca.SignX509CASVID(ctx, s.createX509CASVIDParams("example.org"))
+	s.Require().NoError(err)
+
+	_, err = s.ca.SignJWTSVID(ctx, s.createJWTSVIDParams("example.org", 0))
+	s.Require().NoError(err)
+
+	var failures int
+	for _, entry := range s.logHook.AllEntries() {
+		if entry.Message == "Unable to record issued SVID" {
+			failures++
+		}
+	}
+	s.Require().Equal(3, failures)
+}
+
 func (s *CATestSuite) setX509CA(upstreamBundle bool) {
 	var upstreamChain []*x509.Certificate
 	if upstreamBundle {
@@ -357,11 +583,11 @@ func (s *CATestSuite) createCSR(csr *x509.CertificateRequest) []byte {
 	return csrBytes
 }
 
-func (s *CATestSuite) generateJSR(trustDomain string, ttl time.Duration) *node.JSR {
-	return &node.JSR{
-		SpiffeId: makeWorkloadID(trustDomain),
+func (s *CATestSuite) createJWTSVIDParams(trustDomain string, ttl time.Duration) JWTSVIDParams {
+	return JWTSVIDParams{
+		SpiffeID: makeWorkloadID(trustDomain),
 		Audience: []string{"AUDIENCE"},
-		Ttl:      int32(ttl / time.Second),
+		TTL:      ttl,
 	}
 }
 
@@ -396,3 +622,16 @@ func makeWorkloadID(trustDomain string) string {
 func makeTrustDomainID(trustDomain string) string {
 	return (&url.URL{Scheme: "spiffe", Host: trustDomain}).String()
 }
+
+type fakeIssuanceLog struct {
+	issuedSVIDs []*datastore.IssuedSVID
+	err         error
+}
+
+func (l *fakeIssuanceLog) RecordIssuedSVID(ctx context.Context, issuedSVID *datastore.IssuedSVID) error {
+	if l.err != nil {
+		return l.err
+	}
+	l.issuedSVIDs = append(l.issuedSVIDs, issuedSVID)
+	return nil
+}
@@ -1,6 +1,7 @@
 package ca
 
 import (
+	"context"
 	"crypto/x509"
 	"encoding/json"
 	"encoding/pem"
@@ -11,9 +12,14 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/diskutil"
 	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/proto/spire/server/datastore"
+	"github.com/spiffe/spire/proto/spire/server/keymanager"
 	"github.com/zeebo/errs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -23,27 +29,370 @@ const (
 
 	// journalPEMType is the type in the PEM header
 	journalPEMType = "SPIRE CA JOURNAL"
+
+	// journalUpdateAttempts is how many times an update is applied before
+	// giving up when other servers keep modifying the journal concurrently.
+	journalUpdateAttempts = 3
+)
+
+var (
+	// errJournalConflict is returned by a journal store when the journal was
+	// modified by another server since it was last loaded.
+	errJournalConflict = errs.New("journal was modified concurrently")
+
+	// errPreparationInProgress is returned when another server holds an
+	// unexpired claim on the preparation of the next X509 CA or JWT key.
+	errPreparationInProgress = errs.New("preparation in progress on another server")
+
+	// errJournalUnchanged is returned by update functions to signal that
+	// there is nothing to save.
+	errJournalUnchanged = errs.New("journal unchanged")
 )
 
-// Journal stores X509 CAs and JWT keys on disk as they are rotated by the
-// manager. The data format on disk is a PEM encoded protocol buffer.
+// journalStore persists the journal entries.
+type journalStore interface {
+	// load returns the persisted entries. An empty set of entries is
+	// returned if nothing has been persisted yet.
+	load(ctx context.Context) (*JournalEntries, error)
+
+	// save persists the entries. It returns errJournalConflict if the
+	// journal was modified by another server since it was last loaded or
+	// saved.
+	save(ctx context.Context, entries *JournalEntries) error
+}
+
+// Journal stores X509 CAs and JWT keys as they are rotated by the manager.
+// By default, the journal is stored on disk as a PEM encoded protocol buffer.
+// Servers sharing a datastore can instead store the journal in the datastore
+// so they agree on the active and next authorities.
 type Journal struct {
-	path string
+	store journalStore
 
 	mu      sync.RWMutex
 	entries *JournalEntries
+
+	// peerUpdated is set when a save conflict caused entries written by
+	// another server to be loaded outside of Refresh.
+	peerUpdated bool
 }
 
+// LoadJournal loads the journal from the given path on disk.
 func LoadJournal(path string) (*Journal, error) {
-	j := &Journal{
-		path:    path,
-		entries: new(JournalEntries),
+	return loadJournal(context.Background(), &diskJournalStore{path: path})
+}
+
+// LoadDataStoreJournal loads the journal with the given ID from the
+// datastore.
+func LoadDataStoreJournal(ctx context.Context, ds datastore.DataStore, id string) (*Journal, error) {
+	return loadJournal(ctx, &dataStoreJournalStore{ds: ds, id: id})
+}
+
+func loadJournal(ctx context.Context, store journalStore) (*Journal, error) {
+	entries, err := store.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Journal{
+		store:   store,
+		entries: entries,
+	}, nil
+}
+
+func (j *Journal) Entries() *JournalEntries {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+	return proto.Clone(j.entries).(*JournalEntries)
+}
+
+// Refresh reloads the journal entries from the store. It returns true if
+// the entries were changed by another server since they were last
+// refreshed.
+func (j *Journal) Refresh(ctx context.Context) (bool, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entries, err := j.store.load(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	changed := j.peerUpdated || !proto.Equal(entries, j.entries)
+	j.entries = entries
+	j.peerUpdated = false
+	return changed, nil
+}
+
+func (j *Journal) AppendX509CA(ctx context.Context, slotID string, issuedAt time.Time, keyType keymanager.KeyType, keyID string, x509CA *X509CA) error {
+	return j.update(ctx, func(entries *JournalEntries) error {
+		entries.X509CAs = append(entries.X509CAs, &X509CAEntry{
+			SlotId:        slotID,
+			IssuedAt:      issuedAt.Unix(),
+			Certificate:   x509CA.Certificate.Raw,
+			UpstreamChain: chainDER(x509CA.UpstreamChain),
+			KeyType:       keyType,
+			Status:        Status_PREPARED,
+			KeyId:         keyID,
+		})
+
+		exceeded := len(entries.X509CAs) - journalCap
+		if exceeded > 0 {
+			// make a new slice so we keep growing the backing array to drop the first
+			x509CAs := make([]*X509CAEntry, journalCap)
+			copy(x509CAs, entries.X509CAs[exceeded:])
+			entries.X509CAs = x509CAs
+		}
+
+		// the preparation is complete
+		entries.X509CAPreparation = nil
+		return nil
+	})
+}
+
+func (j *Journal) AppendJWTKey(ctx context.Context, slotID string, issuedAt time.Time, keyType keymanager.KeyType, keyID string, jwtKey *JWTKey) error {
+	pkixBytes, err := x509.MarshalPKIXPublicKey(jwtKey.Signer.Public())
+	if err != nil {
+		return errs.Wrap(err)
 	}
 
-	pemBytes, err := ioutil.ReadFile(path)
+	return j.update(ctx, func(entries *JournalEntries) error {
+		entries.JwtKeys = append(entries.JwtKeys, &JWTKeyEntry{
+			SlotId:    slotID,
+			IssuedAt:  issuedAt.Unix(),
+			Kid:       jwtKey.Kid,
+			PublicKey: pkixBytes,
+			NotAfter:  jwtKey.NotAfter.Unix(),
+			KeyType:   keyType,
+			Status:    Status_PREPARED,
+			KeyId:     keyID,
+			Alg:       jwtKey.Alg,
+		})
+
+		exceeded := len(entries.JwtKeys) - journalCap
+		if exceeded > 0 {
+			// make a new slice so we keep growing the backing array to drop the first
+			jwtKeys := make([]*JWTKeyEntry, journalCap)
+			copy(jwtKeys, entries.JwtKeys[exceeded:])
+			entries.JwtKeys = jwtKeys
+		}
+
+		// the preparation is complete
+		entries.JwtKeyPreparation = nil
+		return nil
+	})
+}
+
+// UpdateX509CAStatus updates the status of the X509 CA entry with the given
+// authority ID. If the status is TAINTED, the taint time is recorded as well.
+// It returns false if there is no such entry.
+func (j *Journal) UpdateX509CAStatus(ctx context.Context, authorityID string, status Status, now time.Time) (bool, error) {
+	var found bool
+	err := j.update(ctx, func(entries *JournalEntries) error {
+		var entry *X509CAEntry
+		for _, candidate := range entries.X509CAs {
+			id, err := x509CAEntryAuthorityID(candidate)
+			if err != nil {
+				return err
+			}
+			if id == authorityID {
+				entry = candidate
+			}
+		}
+		found = entry != nil
+		if !found {
+			return errJournalUnchanged
+		}
+
+		entry.Status = status
+		if status == Status_TAINTED {
+			entry.TaintedAt = now.Unix()
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return found, nil
+}
+
+// UpdateJWTKeyStatus updates the status of the JWT key entry with the given
+// key ID. If the status is TAINTED, the taint time is recorded as well. It
+// returns false if there is no such entry.
+func (j *Journal) UpdateJWTKeyStatus(ctx context.Context, kid string, status Status, now time.Time) (bool, error) {
+	var found bool
+	err := j.update(ctx, func(entries *JournalEntries) error {
+		var entry *JWTKeyEntry
+		for _, candidate := range entries.JwtKeys {
+			if candidate.Kid == kid {
+				entry = candidate
+			}
+		}
+		found = entry != nil
+		if !found {
+			return errJournalUnchanged
+		}
+
+		entry.Status = status
+		if status == Status_TAINTED {
+			entry.TaintedAt = now.Unix()
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return found, nil
+}
+
+// ClaimX509CAPreparation claims the preparation of the X509 CA for the given
+// slot until the claim expires or the X509 CA is appended. It fails with
+// errPreparationInProgress if another server holds an unexpired claim or has
+// modified the journal since it was last refreshed.
+func (j *Journal) ClaimX509CAPreparation(ctx context.Context, slotID string, now time.Time, ttl time.Duration) error {
+	return j.claim(ctx, func(entries *JournalEntries) error {
+		if isClaimed(entries.X509CAPreparation, now) {
+			return errPreparationInProgress
+		}
+		entries.X509CAPreparation = &Preparation{
+			SlotId:    slotID,
+			ExpiresAt: now.Add(ttl).Unix(),
+		}
+		return nil
+	})
+}
+
+// ReleaseX509CAPreparation releases a claim on the X509 CA preparation.
+func (j *Journal) ReleaseX509CAPreparation(ctx context.Context) error {
+	return j.update(ctx, func(entries *JournalEntries) error {
+		if entries.X509CAPreparation == nil {
+			return errJournalUnchanged
+		}
+		entries.X509CAPreparation = nil
+		return nil
+	})
+}
+
+// ClaimJWTKeyPreparation claims the preparation of the JWT key for the given
+// slot until the claim expires or the JWT key is appended. It fails with
+// errPreparationInProgress if another server holds an unexpired claim or has
+// modified the journal since it was last refreshed.
+func (j *Journal) ClaimJWTKeyPreparation(ctx context.Context, slotID string, now time.Time, ttl time.Duration) error {
+	return j.claim(ctx, func(entries *JournalEntries) error {
+		if isClaimed(entries.JwtKeyPreparation, now) {
+			return errPreparationInProgress
+		}
+		entries.JwtKeyPreparation = &Preparation{
+			SlotId:    slotID,
+			ExpiresAt: now.Add(ttl).Unix(),
+		}
+		return nil
+	})
+}
+
+// ReleaseJWTKeyPreparation releases a claim on the JWT key preparation.
+func (j *Journal) ReleaseJWTKeyPreparation(ctx context.Context) error {
+	return j.update(ctx, func(entries *JournalEntries) error {
+		if entries.JwtKeyPreparation == nil {
+			return errJournalUnchanged
+		}
+		entries.JwtKeyPreparation = nil
+		return nil
+	})
+}
+
+// seed populates an empty journal with the given entries, e.g. when moving
+// from the on-disk journal to the datastore. It returns false if the journal
+// is not empty.
+func (j *Journal) seed(ctx context.Context, seedEntries *JournalEntries) (bool, error) {
+	var seeded bool
+	err := j.update(ctx, func(entries *JournalEntries) error {
+		if len(entries.X509CAs) > 0 || len(entries.JwtKeys) > 0 {
+			return errJournalUnchanged
+		}
+		entries.X509CAs = seedEntries.X509CAs
+		entries.JwtKeys = seedEntries.JwtKeys
+		seeded = true
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return seeded, nil
+}
+
+// update applies fn to a copy of the entries and saves the result. If
+// another server modified the journal in the meantime, the latest entries
+// are loaded and fn is applied again.
+func (j *Journal) update(ctx context.Context, fn func(entries *JournalEntries) error) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for attempt := 1; ; attempt++ {
+		entries := proto.Clone(j.entries).(*JournalEntries)
+		if err := fn(entries); err != nil {
+			if err == errJournalUnchanged {
+				return nil
+			}
+			return err
+		}
+
+		err := j.store.save(ctx, entries)
+		switch {
+		case err == nil:
+			j.entries = entries
+			return nil
+		case err == errJournalConflict && attempt < journalUpdateAttempts:
+			latest, err := j.store.load(ctx)
+			if err != nil {
+				return err
+			}
+			j.entries = latest
+			j.peerUpdated = true
+		default:
+			return err
+		}
+	}
+}
+
+// claim applies fn to a copy of the entries and saves the result. Unlike
+// update, a conflict is not retried since the claim was decided on entries
+// that are no longer current.
+func (j *Journal) claim(ctx context.Context, fn func(entries *JournalEntries) error) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entries := proto.Clone(j.entries).(*JournalEntries)
+	if err := fn(entries); err != nil {
+		return err
+	}
+
+	switch err := j.store.save(ctx, entries); err {
+	case nil:
+		j.entries = entries
+		return nil
+	case errJournalConflict:
+		return errPreparationInProgress
+	default:
+		return err
+	}
+}
+
+func isClaimed(preparation *Preparation, now time.Time) bool {
+	return preparation != nil && preparation.ExpiresAt > now.Unix()
+}
+
+// diskJournalStore stores the journal on disk as a PEM encoded protocol
+// buffer.
+type diskJournalStore struct {
+	path string
+}
+
+func (s *diskJournalStore) load(ctx context.Context) (*JournalEntries, error) {
+	entries := new(JournalEntries)
+
+	pemBytes, err := ioutil.ReadFile(s.path)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return j, nil
+			return entries, nil
 		}
 		return nil, errs.Wrap(err)
 	}
@@ -55,85 +404,68 @@ func LoadJournal(path string) (*Journal, error) {
 		return nil, errs.New("invalid PEM block type %q", pemBlock.Type)
 	}
 
-	if err := proto.Unmarshal(pemBlock.Bytes, j.entries); err != nil {
+	if err := proto.Unmarshal(pemBlock.Bytes, entries); err != nil {
 		return nil, errs.New("unable to unmarshal entries: %v", err)
 	}
 
-	return j, nil
+	return entries, nil
 }
 
-func (j *Journal) Entries() *JournalEntries {
-	j.mu.RLock()
-	defer j.mu.RUnlock()
-	return proto.Clone(j.entries).(*JournalEntries)
+func (s *diskJournalStore) save(ctx context.Context, entries *JournalEntries) error {
+	return saveJournalEntries(s.path, entries)
 }
 
-func (j *Journal) AppendX509CA(slotID string, issuedAt time.Time, x509CA *X509CA) error {
-	j.mu.Lock()
-	defer j.mu.Unlock()
+// dataStoreJournalStore stores the journal in the datastore. Writes are
+// conditioned on the revision of the journal that was last loaded or saved.
+type dataStoreJournalStore struct {
+	ds       datastore.DataStore
+	id       string
+	revision int64
+}
 
-	backup := j.entries.X509CAs
-	j.entries.X509CAs = append(j.entries.X509CAs, &X509CAEntry{
-		SlotId:        slotID,
-		IssuedAt:      issuedAt.Unix(),
-		Certificate:   x509CA.Certificate.Raw,
-		UpstreamChain: chainDER(x509CA.UpstreamChain),
+func (s *dataStoreJournalStore) load(ctx context.Context) (*JournalEntries, error) {
+	resp, err := s.ds.FetchCAJournal(ctx, &datastore.FetchCAJournalRequest{
+		Id: s.id,
 	})
-
-	exceeded := len(j.entries.X509CAs) - journalCap
-	if exceeded > 0 {
-		// make a new slice so we keep growing the backing array to drop the first
-		x509CAs := make([]*X509CAEntry, journalCap)
-		copy(x509CAs, j.entries.X509CAs[exceeded:])
-		j.entries.X509CAs = x509CAs
+	if err != nil {
+		return nil, errs.New("unable to fetch journal: %v", err)
 	}
 
-	if err := j.save(); err != nil {
-		j.entries.X509CAs = backup
-		return err
+	entries := new(JournalEntries)
+	if resp.Journal == nil {
+		s.revision = 0
+		return entries, nil
 	}
-
-	return nil
+	if err := proto.Unmarshal(resp.Journal.Data, entries); err != nil {
+		return nil, errs.New("unable to unmarshal entries: %v", err)
+	}
+	s.revision = resp.Journal.Revision
+	return entries, nil
 }
 
-func (j *Journal) AppendJWTKey(slotID string, issuedAt time.Time, jwtKey *JWTKey) error {
-	j.mu.Lock()
-	defer j.mu.Unlock()
-
-	pkixBytes, err := x509.MarshalPKIXPublicKey(jwtKey.Signer.Public())
+func (s *dataStoreJournalStore) save(ctx context.Context, entries *JournalEntries) error {
+	entriesBytes, err := proto.Marshal(entries)
 	if err != nil {
 		return errs.Wrap(err)
 	}
 
-	backup := j.entries.JwtKeys
-	j.entries.JwtKeys = append(j.entries.JwtKeys, &JWTKeyEntry{
-		SlotId:    slotID,
-		IssuedAt:  issuedAt.Unix(),
-		Kid:       jwtKey.Kid,
-		PublicKey: pkixBytes,
-		NotAfter:  jwtKey.NotAfter.Unix(),
+	resp, err := s.ds.SetCAJournal(ctx, &datastore.SetCAJournalRequest{
+		Journal: &datastore.CAJournal{
+			Id:       s.id,
+			Data:     entriesBytes,
+			Revision: s.revision,
+		},
 	})
-
-	exceeded := len(j.entries.JwtKeys) - journalCap
-	if exceeded > 0 {
-		// make a new slice so we keep growing the backing array to drop the first
-		jwtKeys := make([]*JWTKeyEntry, journalCap)
-		copy(jwtKeys, j.entries.JwtKeys[exceeded:])
-		j.entries.JwtKeys = jwtKeys
-	}
-
-	if err := j.save(); err != nil {
-		j.entries.JwtKeys = backup
-		return err
+	switch {
+	case status.Code(err) == codes.Aborted:
+		return errJournalConflict
+	case err != nil:
+		return errs.New("unable to set journal: %v", err)
 	}
-
+	s.revision = resp.Journal.Revision
 	return nil
 }
 
-func (j *Journal) save() error {
-	return saveJournalEntries(j.path, j.entries)
-}
-
 func saveJournalEntries(path string, entries *JournalEntries) error {
 	entriesBytes, err := proto.Marshal(entries)
 	if err != nil {
@@ -288,3 +620,11 @@ func chainDER(chain []*x509.Certificate) [][]byte {
 	}
 	return der
 }
+
+func x509CAEntryAuthorityID(entry *X509CAEntry) (string, error) {
+	cert, err := x509.ParseCertificate(entry.Certificate)
+	if err != nil {
+		return "", errs.New("unable to parse CA certificate: %v", err)
+	}
+	return bundleutil.X509AuthorityID(cert), nil
+}
@@ -6,6 +6,7 @@ package ca
 import (
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	keymanager "github.com/spiffe/spire/proto/spire/server/keymanager"
 	math "math"
 )
 
@@ -20,6 +21,49 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type Status int32
+
+const (
+	// Entries written before the status was tracked
+	Status_UNKNOWN Status = 0
+	// The authority has been prepared but not activated
+	Status_PREPARED Status = 1
+	// The authority is the active authority
+	Status_ACTIVE Status = 2
+	// The authority has been replaced by a newer authority
+	Status_OLD Status = 3
+	// The authority has been tainted by an operator
+	Status_TAINTED Status = 4
+	// The tainted authority has been removed from the bundle
+	Status_REMOVED Status = 5
+)
+
+var Status_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "PREPARED",
+	2: "ACTIVE",
+	3: "OLD",
+	4: "TAINTED",
+	5: "REMOVED",
+}
+
+var Status_value = map[string]int32{
+	"UNKNOWN":  0,
+	"PREPARED": 1,
+	"ACTIVE":   2,
+	"OLD":      3,
+	"TAINTED":  4,
+	"REMOVED":  5,
+}
+
+func (x Status) String() string {
+	return proto.EnumName(Status_name, int32(x))
+}
+
+func (Status) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_04fd98cceb1b9191, []int{0}
+}
+
 type X509CAEntry struct {
 	// Which X509 CA slot this entry occupied.
 	SlotId string `protobuf:"bytes,1,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
@@ -28,7 +72,16 @@ type X509CAEntry struct {
 	// DER encoded CA certificate
 	Certificate []byte `protobuf:"bytes,3,opt,name=certificate,proto3" json:"certificate,omitempty"`
 	// DER encoded upstream CA chain. See the X509CA struct for details.
-	UpstreamChain        [][]byte `protobuf:"bytes,4,rep,name=upstream_chain,json=upstreamChain,proto3" json:"upstream_chain,omitempty"`
+	UpstreamChain [][]byte `protobuf:"bytes,4,rep,name=upstream_chain,json=upstreamChain,proto3" json:"upstream_chain,omitempty"`
+	// Key type of the CA signing key
+	KeyType keymanager.KeyType `protobuf:"varint,5,opt,name=key_type,json=keyType,proto3,enum=spire.server.keymanager.KeyType" json:"key_type,omitempty"`
+	// Status of the CA
+	Status Status `protobuf:"varint,6,opt,name=status,proto3,enum=Status" json:"status,omitempty"`
+	// When the CA was tainted (unix epoch in seconds)
+	TaintedAt int64 `protobuf:"varint,7,opt,name=tainted_at,json=taintedAt,proto3" json:"tainted_at,omitempty"`
+	// KeyManager key ID of the CA signing key. Entries written before the
+	// key ID was tracked use the key ID derived from the slot ID.
+	KeyId                string   `protobuf:"bytes,8,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -87,6 +140,34 @@ func (m *X509CAEntry) GetUpstreamChain() [][]byte {
 	return nil
 }
 
+func (m *X509CAEntry) GetKeyType() keymanager.KeyType {
+	if m != nil {
+		return m.KeyType
+	}
+	return keymanager.KeyType_UNSPECIFIED_KEY_TYPE
+}
+
+func (m *X509CAEntry) GetStatus() Status {
+	if m != nil {
+		return m.Status
+	}
+	return Status_UNKNOWN
+}
+
+func (m *X509CAEntry) GetTaintedAt() int64 {
+	if m != nil {
+		return m.TaintedAt
+	}
+	return 0
+}
+
+func (m *X509CAEntry) GetKeyId() string {
+	if m != nil {
+		return m.KeyId
+	}
+	return ""
+}
+
 type JWTKeyEntry struct {
 	// Which JWT Key slot this entry occupied.
 	SlotId string `protobuf:"bytes,1,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
@@ -97,7 +178,19 @@ type JWTKeyEntry struct {
 	// JWT key id (i.e. "kid" claim)
 	Kid string `protobuf:"bytes,4,opt,name=kid,proto3" json:"kid,omitempty"`
 	// PKIX encoded public key
-	PublicKey            []byte   `protobuf:"bytes,5,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	PublicKey []byte `protobuf:"bytes,5,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	// Key type of the JWT signing key
+	KeyType keymanager.KeyType `protobuf:"varint,6,opt,name=key_type,json=keyType,proto3,enum=spire.server.keymanager.KeyType" json:"key_type,omitempty"`
+	// Status of the JWT key
+	Status Status `protobuf:"varint,7,opt,name=status,proto3,enum=Status" json:"status,omitempty"`
+	// When the JWT key was tainted (unix epoch in seconds)
+	TaintedAt int64 `protobuf:"varint,8,opt,name=tainted_at,json=taintedAt,proto3" json:"tainted_at,omitempty"`
+	// KeyManager key ID of the JWT signing key. Entries written before the
+	// key ID was tracked use the key ID derived from the slot ID.
+	KeyId string `protobuf:"bytes,9,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	// JWS algorithm the JWT key signs with. Entries written before the
+	// algorithm was tracked use the default algorithm for the key type.
+	Alg                  string   `protobuf:"bytes,10,opt,name=alg,proto3" json:"alg,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -163,9 +256,98 @@ func (m *JWTKeyEntry) GetPublicKey() []byte {
 	return nil
 }
 
+func (m *JWTKeyEntry) GetKeyType() keymanager.KeyType {
+	if m != nil {
+		return m.KeyType
+	}
+	return keymanager.KeyType_UNSPECIFIED_KEY_TYPE
+}
+
+func (m *JWTKeyEntry) GetStatus() Status {
+	if m != nil {
+		return m.Status
+	}
+	return Status_UNKNOWN
+}
+
+func (m *JWTKeyEntry) GetTaintedAt() int64 {
+	if m != nil {
+		return m.TaintedAt
+	}
+	return 0
+}
+
+func (m *JWTKeyEntry) GetKeyId() string {
+	if m != nil {
+		return m.KeyId
+	}
+	return ""
+}
+
+func (m *JWTKeyEntry) GetAlg() string {
+	if m != nil {
+		return m.Alg
+	}
+	return ""
+}
+
+// Preparation is a claim on the preparation of the next X509 CA or JWT key
+// by one of the servers sharing the journal.
+type Preparation struct {
+	// Which slot is being prepared
+	SlotId string `protobuf:"bytes,1,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
+	// When the claim expires if the preparation is not completed (unix epoch
+	// in seconds)
+	ExpiresAt            int64    `protobuf:"varint,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Preparation) Reset()         { *m = Preparation{} }
+func (m *Preparation) String() string { return proto.CompactTextString(m) }
+func (*Preparation) ProtoMessage()    {}
+func (*Preparation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_04fd98cceb1b9191, []int{2}
+}
+
+func (m *Preparation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Preparation.Unmarshal(m, b)
+}
+func (m *Preparation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Preparation.Marshal(b, m, deterministic)
+}
+func (m *Preparation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Preparation.Merge(m, src)
+}
+func (m *Preparation) XXX_Size() int {
+	return xxx_messageInfo_Preparation.Size(m)
+}
+func (m *Preparation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Preparation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Preparation proto.InternalMessageInfo
+
+func (m *Preparation) GetSlotId() string {
+	if m != nil {
+		return m.SlotId
+	}
+	return ""
+}
+
+func (m *Preparation) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
 type JournalEntries struct {
 	X509CAs              []*X509CAEntry `protobuf:"bytes,1,rep,name=x509CAs,proto3" json:"x509CAs,omitempty"`
 	JwtKeys              []*JWTKeyEntry `protobuf:"bytes,2,rep,name=jwtKeys,proto3" json:"jwtKeys,omitempty"`
+	X509CAPreparation    *Preparation   `protobuf:"bytes,3,opt,name=x509CAPreparation,proto3" json:"x509CAPreparation,omitempty"`
+	JwtKeyPreparation    *Preparation   `protobuf:"bytes,4,opt,name=jwtKeyPreparation,proto3" json:"jwtKeyPreparation,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
 	XXX_unrecognized     []byte         `json:"-"`
 	XXX_sizecache        int32          `json:"-"`
@@ -175,7 +357,7 @@ func (m *JournalEntries) Reset()         { *m = JournalEntries{} }
 func (m *JournalEntries) String() string { return proto.CompactTextString(m) }
 func (*JournalEntries) ProtoMessage()    {}
 func (*JournalEntries) Descriptor() ([]byte, []int) {
-	return fileDescriptor_04fd98cceb1b9191, []int{2}
+	return fileDescriptor_04fd98cceb1b9191, []int{3}
 }
 
 func (m *JournalEntries) XXX_Unmarshal(b []byte) error {
@@ -210,32 +392,64 @@ func (m *JournalEntries) GetJwtKeys() []*JWTKeyEntry {
 	return nil
 }
 
+func (m *JournalEntries) GetX509CAPreparation() *Preparation {
+	if m != nil {
+		return m.X509CAPreparation
+	}
+	return nil
+}
+
+func (m *JournalEntries) GetJwtKeyPreparation() *Preparation {
+	if m != nil {
+		return m.JwtKeyPreparation
+	}
+	return nil
+}
+
 func init() {
+	proto.RegisterEnum("Status", Status_name, Status_value)
 	proto.RegisterType((*X509CAEntry)(nil), "X509CAEntry")
 	proto.RegisterType((*JWTKeyEntry)(nil), "JWTKeyEntry")
+	proto.RegisterType((*Preparation)(nil), "Preparation")
 	proto.RegisterType((*JournalEntries)(nil), "JournalEntries")
 }
 
 func init() { proto.RegisterFile("journal.proto", fileDescriptor_04fd98cceb1b9191) }
 
 var fileDescriptor_04fd98cceb1b9191 = []byte{
-	// 286 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x91, 0x41, 0x4b, 0xc3, 0x30,
-	0x14, 0xc7, 0xc9, 0x32, 0xb7, 0xf5, 0x75, 0x1b, 0x92, 0x8b, 0x81, 0x21, 0x84, 0x81, 0xd2, 0xd3,
-	0x10, 0xc5, 0x83, 0xc7, 0x39, 0x3c, 0xb8, 0xdd, 0x82, 0xa0, 0x78, 0xa9, 0x59, 0x9b, 0x61, 0xb6,
-	0xd9, 0x8c, 0xe4, 0x15, 0xed, 0x77, 0xf0, 0xe2, 0x37, 0x96, 0x74, 0x16, 0x7a, 0xf7, 0x96, 0xf7,
-	0x7b, 0x7f, 0xc2, 0x2f, 0xff, 0xc0, 0x68, 0x6b, 0x4b, 0x57, 0xa8, 0xfd, 0xec, 0xe0, 0x2c, 0xda,
-	0xe9, 0x37, 0x81, 0xf8, 0xe5, 0xf6, 0xea, 0x6e, 0x31, 0x7f, 0x28, 0xd0, 0x55, 0xec, 0x0c, 0xfa,
-	0x7e, 0x6f, 0x31, 0x35, 0x39, 0x27, 0x82, 0x24, 0x91, 0xec, 0x85, 0xf1, 0x31, 0x67, 0x13, 0x88,
-	0x8c, 0xf7, 0xa5, 0xce, 0x53, 0x85, 0xbc, 0x23, 0x48, 0x42, 0xe5, 0xe0, 0x08, 0xe6, 0xc8, 0x04,
-	0xc4, 0x99, 0x76, 0x68, 0x36, 0x26, 0x53, 0xa8, 0x39, 0x15, 0x24, 0x19, 0xca, 0x36, 0x62, 0x17,
-	0x30, 0x2e, 0x0f, 0x1e, 0x9d, 0x56, 0x1f, 0x69, 0xf6, 0xae, 0x4c, 0xc1, 0xbb, 0x82, 0x26, 0x43,
-	0x39, 0x6a, 0xe8, 0x22, 0xc0, 0xe9, 0x0f, 0x81, 0x78, 0xf9, 0xfc, 0xb4, 0xd2, 0xd5, 0x7f, 0x74,
-	0x26, 0x10, 0x15, 0x16, 0x53, 0xb5, 0x41, 0xed, 0x6a, 0x19, 0x2a, 0x07, 0x85, 0xc5, 0x79, 0x98,
-	0xd9, 0x29, 0xd0, 0x9d, 0xc9, 0x79, 0xb7, 0xbe, 0x2e, 0x1c, 0xd9, 0x39, 0xc0, 0xa1, 0x5c, 0xef,
-	0x4d, 0x96, 0xee, 0x74, 0xc5, 0x4f, 0x6a, 0xf9, 0xe8, 0x48, 0x56, 0xba, 0x9a, 0xbe, 0xc1, 0x78,
-	0x79, 0xec, 0x2c, 0x38, 0x19, 0xed, 0xd9, 0x25, 0xf4, 0xbf, 0xea, 0xce, 0x3c, 0x27, 0x82, 0x26,
-	0xf1, 0xf5, 0x70, 0xd6, 0xea, 0x50, 0x36, 0xcb, 0x90, 0xdb, 0x7e, 0xe2, 0x4a, 0x57, 0x9e, 0x77,
-	0xfe, 0x72, 0xad, 0xc7, 0xc9, 0x66, 0x79, 0xdf, 0x7d, 0xed, 0x64, 0x6a, 0xdd, 0xab, 0x7f, 0xe4,
-	0xe6, 0x37, 0x00, 0x00, 0xff, 0xff, 0x58, 0x50, 0x0f, 0x00, 0xa2, 0x01, 0x00, 0x00,
+	// 540 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x53, 0x5d, 0x6f, 0xd3, 0x30,
+	0x14, 0x25, 0x49, 0x97, 0x8f, 0x9b, 0x6e, 0x0a, 0x96, 0x10, 0x11, 0xd3, 0x44, 0x54, 0x09, 0x14,
+	0xf1, 0x90, 0xa1, 0x21, 0x1e, 0x80, 0xa7, 0xb0, 0xe6, 0xa1, 0x2b, 0xb4, 0x95, 0x29, 0x1b, 0xe2,
+	0x25, 0xf2, 0x1a, 0xaf, 0x78, 0xe9, 0x92, 0x28, 0x76, 0x61, 0xf9, 0x37, 0xfc, 0x0b, 0xfe, 0x0a,
+	0x3f, 0x07, 0x39, 0x6e, 0x45, 0xa6, 0x31, 0x09, 0xc1, 0x9b, 0xef, 0xf1, 0x39, 0xce, 0x3d, 0xe7,
+	0xde, 0xc0, 0xee, 0x65, 0xb9, 0xae, 0x0b, 0xb2, 0x8a, 0xaa, 0xba, 0x14, 0xe5, 0xa3, 0x90, 0x57,
+	0xac, 0xa6, 0x87, 0x9c, 0xd6, 0x5f, 0x69, 0x7d, 0x98, 0xd3, 0xe6, 0x8a, 0x14, 0x64, 0x79, 0xe3,
+	0xa8, 0x98, 0x83, 0xef, 0x3a, 0xb8, 0x9f, 0x5e, 0x3e, 0x7f, 0x75, 0x1c, 0x27, 0x85, 0xa8, 0x1b,
+	0xf4, 0x10, 0x2c, 0xbe, 0x2a, 0x45, 0xca, 0x32, 0x5f, 0x0b, 0xb4, 0xd0, 0xc1, 0xa6, 0x2c, 0x47,
+	0x19, 0xda, 0x07, 0x87, 0x71, 0xbe, 0xa6, 0x59, 0x4a, 0x84, 0xaf, 0x07, 0x5a, 0x68, 0x60, 0x5b,
+	0x01, 0xb1, 0x40, 0x01, 0xb8, 0x0b, 0x5a, 0x0b, 0x76, 0xc1, 0x16, 0x44, 0x50, 0xdf, 0x08, 0xb4,
+	0xb0, 0x8f, 0xbb, 0x10, 0x7a, 0x02, 0x7b, 0xeb, 0x8a, 0x8b, 0x9a, 0x92, 0xab, 0x74, 0xf1, 0x85,
+	0xb0, 0xc2, 0xef, 0x05, 0x46, 0xd8, 0xc7, 0xbb, 0x5b, 0xf4, 0x58, 0x82, 0xe8, 0x0d, 0xd8, 0x39,
+	0x6d, 0x52, 0xd1, 0x54, 0xd4, 0xdf, 0x09, 0xb4, 0x70, 0xef, 0x28, 0x88, 0x5a, 0x2f, 0x91, 0xf2,
+	0x12, 0x75, 0x0c, 0x8c, 0x69, 0x33, 0x6f, 0x2a, 0x8a, 0xad, 0x5c, 0x1d, 0xd0, 0x63, 0x30, 0xb9,
+	0x20, 0x62, 0xcd, 0x7d, 0xb3, 0x95, 0x5a, 0xd1, 0x87, 0xb6, 0xc4, 0x1b, 0x18, 0x1d, 0x00, 0x08,
+	0xc2, 0x0a, 0xa1, 0x4c, 0x58, 0xad, 0x09, 0x67, 0x83, 0xc4, 0x02, 0x3d, 0x00, 0x53, 0x7e, 0x9c,
+	0x65, 0xbe, 0xdd, 0x5a, 0xdf, 0xc9, 0x69, 0x33, 0xca, 0x06, 0x3f, 0x74, 0x70, 0x4f, 0xce, 0xe6,
+	0x63, 0xda, 0xfc, 0x4f, 0x44, 0xfb, 0xe0, 0x14, 0xa5, 0x48, 0xc9, 0x85, 0xa0, 0x75, 0x1b, 0x90,
+	0x81, 0xed, 0xa2, 0x14, 0xb1, 0xac, 0x91, 0x07, 0x46, 0xce, 0x32, 0xbf, 0xd7, 0x3e, 0x27, 0x8f,
+	0xb2, 0xd5, 0x6a, 0x7d, 0xbe, 0x62, 0x8b, 0x34, 0xa7, 0x4d, 0x1b, 0x45, 0x1f, 0x3b, 0x0a, 0x19,
+	0xd3, 0xe6, 0x46, 0x4e, 0xe6, 0xbf, 0xe7, 0x64, 0xfd, 0x4d, 0x4e, 0xf6, 0xdd, 0x39, 0x39, 0x9d,
+	0x9c, 0xa4, 0x09, 0xb2, 0x5a, 0xfa, 0xa0, 0x4c, 0x90, 0xd5, 0x72, 0x90, 0x80, 0x3b, 0xab, 0x69,
+	0x45, 0x6a, 0x22, 0x58, 0x59, 0xdc, 0x1d, 0xdc, 0x01, 0x00, 0xbd, 0x96, 0xdd, 0xf3, 0xdf, 0xc9,
+	0x39, 0x1b, 0x24, 0x16, 0x83, 0x9f, 0x1a, 0xec, 0x9d, 0xa8, 0xfd, 0x96, 0x13, 0x60, 0x94, 0xa3,
+	0xa7, 0x60, 0x5d, 0xb7, 0x5b, 0xcb, 0x7d, 0x2d, 0x30, 0x42, 0xf7, 0xa8, 0x1f, 0x75, 0xb6, 0x18,
+	0x6f, 0x2f, 0x25, 0xef, 0xf2, 0x9b, 0x18, 0xd3, 0x86, 0xfb, 0xfa, 0x86, 0xd7, 0x19, 0x25, 0xde,
+	0x5e, 0xa2, 0xd7, 0x70, 0x5f, 0x49, 0x3a, 0xfd, 0xb6, 0x53, 0x92, 0x8a, 0x0e, 0x86, 0x6f, 0xd3,
+	0xa4, 0x56, 0x3d, 0xd3, 0xd5, 0xf6, 0xfe, 0xa4, 0xbd, 0x45, 0x7b, 0x86, 0xc1, 0x54, 0xd9, 0x23,
+	0x17, 0xac, 0x8f, 0x93, 0xf1, 0x64, 0x7a, 0x36, 0xf1, 0xee, 0xa1, 0x3e, 0xd8, 0x33, 0x9c, 0xcc,
+	0x62, 0x9c, 0x0c, 0x3d, 0x0d, 0x01, 0x98, 0xf1, 0xf1, 0x7c, 0x74, 0x9a, 0x78, 0x3a, 0xb2, 0xc0,
+	0x98, 0xbe, 0x1b, 0x7a, 0x86, 0xe4, 0xcf, 0xe3, 0xd1, 0x64, 0x9e, 0x0c, 0xbd, 0x9e, 0x2c, 0x70,
+	0xf2, 0x7e, 0x7a, 0x9a, 0x0c, 0xbd, 0x9d, 0xb7, 0xbd, 0xcf, 0xfa, 0x82, 0x9c, 0x9b, 0xed, 0xff,
+	0xfd, 0xe2, 0xd7, 0x00, 0xc5, 0x7f, 0xa5, 0x44, 0x1a, 0x04, 0x00, 0x00,
 }
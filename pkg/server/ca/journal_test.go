@@ -1,15 +1,19 @@
 package ca
 
 import (
+	"crypto/rand"
 	"crypto/x509"
 	"encoding/pem"
 	"io/ioutil"
+	"math/big"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
+	"github.com/spiffe/spire/proto/spire/server/keymanager"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -139,21 +143,172 @@ func (s *JournalSuite) TestPersistence() {
 
 	journal := s.loadJournal()
 
-	err := journal.AppendX509CA("A", now, &X509CA{
+	err := journal.AppendX509CA(ctx, "A", now, keymanager.KeyType_EC_P384, "x509-CA-A", &X509CA{
 		Signer:        testSigner,
 		Certificate:   testChain[0],
 		UpstreamChain: testChain,
 	})
 	s.Require().NoError(err)
 
-	err = journal.AppendJWTKey("B", now, &JWTKey{
+	err = journal.AppendJWTKey(ctx, "B", now, keymanager.KeyType_EC_P256, "JWT-Signer-B", &JWTKey{
 		Signer:   testSigner,
 		Kid:      "KID",
 		NotAfter: now.Add(time.Hour),
 	})
 	s.Require().NoError(err)
 
-	s.requireProtoEqual(journal.Entries(), s.loadJournal().Entries())
+	entries := s.loadJournal().Entries()
+	s.requireProtoEqual(journal.Entries(), entries)
+	s.Require().Equal(keymanager.KeyType_EC_P384, entries.X509CAs[0].KeyType)
+	s.Require().Equal(keymanager.KeyType_EC_P256, entries.JwtKeys[0].KeyType)
+	s.Require().Equal("x509-CA-A", entries.X509CAs[0].KeyId)
+	s.Require().Equal("JWT-Signer-B", entries.JwtKeys[0].KeyId)
+}
+
+func (s *JournalSuite) TestDataStoreJournal() {
+	now := s.now()
+	ds := fakedatastore.New()
+
+	journal, err := LoadDataStoreJournal(ctx, ds, "spiffe://example.org")
+	s.Require().NoError(err)
+	s.Require().Empty(journal.Entries())
+
+	peer, err := LoadDataStoreJournal(ctx, ds, "spiffe://example.org")
+	s.Require().NoError(err)
+
+	s.Require().NoError(journal.AppendX509CA(ctx, "A", now, keymanager.KeyType_EC_P384, "x509-CA-A", &X509CA{
+		Signer:      testSigner,
+		Certificate: testChain[0],
+	}))
+
+	// the peer picks up the change on refresh
+	changed, err := peer.Refresh(ctx)
+	s.Require().NoError(err)
+	s.Require().True(changed)
+	s.requireProtoEqual(journal.Entries(), peer.Entries())
+	changed, err = peer.Refresh(ctx)
+	s.Require().NoError(err)
+	s.Require().False(changed)
+
+	// concurrent updates are merged
+	s.Require().NoError(journal.AppendJWTKey(ctx, "A", now, keymanager.KeyType_EC_P256, "JWT-Signer-A", &JWTKey{
+		Signer:   testSigner,
+		Kid:      "KID",
+		NotAfter: now.Add(time.Hour),
+	}))
+	s.Require().NoError(peer.AppendX509CA(ctx, "B", now, keymanager.KeyType_EC_P384, "x509-CA-B", &X509CA{
+		Signer:      testSigner,
+		Certificate: testChain[1],
+	}))
+	entries := peer.Entries()
+	s.Require().Len(entries.X509CAs, 2)
+	s.Require().Len(entries.JwtKeys, 1)
+
+	// entries loaded while resolving a conflict count as changed
+	changed, err = peer.Refresh(ctx)
+	s.Require().NoError(err)
+	s.Require().True(changed)
+
+	// a journal with another ID is independent
+	other, err := LoadDataStoreJournal(ctx, ds, "spiffe://otherdomain.org")
+	s.Require().NoError(err)
+	s.Require().Empty(other.Entries())
+}
+
+func (s *JournalSuite) TestPreparationClaims() {
+	now := s.now()
+	ds := fakedatastore.New()
+
+	journal, err := LoadDataStoreJournal(ctx, ds, "spiffe://example.org")
+	s.Require().NoError(err)
+	peer, err := LoadDataStoreJournal(ctx, ds, "spiffe://example.org")
+	s.Require().NoError(err)
+
+	s.Require().NoError(journal.ClaimX509CAPreparation(ctx, "A", now, time.Minute))
+
+	// claiming on stale entries fails
+	s.Require().Equal(errPreparationInProgress, peer.ClaimX509CAPreparation(ctx, "A", now, time.Minute))
+
+	// claiming while the claim is held fails
+	_, err = peer.Refresh(ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(errPreparationInProgress, peer.ClaimX509CAPreparation(ctx, "A", now, time.Minute))
+
+	// the JWT key claim is independent
+	s.Require().NoError(peer.ClaimJWTKeyPreparation(ctx, "A", now, time.Minute))
+
+	// appending completes the preparation
+	s.Require().NoError(journal.AppendX509CA(ctx, "A", now, keymanager.KeyType_EC_P384, "x509-CA-A", &X509CA{
+		Signer:      testSigner,
+		Certificate: testChain[0],
+	}))
+	s.Require().Nil(journal.Entries().X509CAPreparation)
+	s.Require().NotNil(journal.Entries().JwtKeyPreparation)
+
+	// releasing gives up the claim
+	s.Require().NoError(peer.ReleaseJWTKeyPreparation(ctx))
+	s.Require().Nil(peer.Entries().JwtKeyPreparation)
+
+	// expired claims can be taken over
+	_, err = journal.Refresh(ctx)
+	s.Require().NoError(err)
+	s.Require().NoError(journal.ClaimJWTKeyPreparation(ctx, "A", now, time.Minute))
+	_, err = peer.Refresh(ctx)
+	s.Require().NoError(err)
+	s.Require().Equal(errPreparationInProgress, peer.ClaimJWTKeyPreparation(ctx, "A", now.Add(30*time.Second), time.Minute))
+	s.Require().NoError(peer.ClaimJWTKeyPreparation(ctx, "A", now.Add(time.Minute), time.Minute))
+}
+
+func (s *JournalSuite) TestUpdateStatus() {
+	now := s.now()
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		SubjectKeyId:          []byte{1, 2, 3},
+		NotAfter:              now.Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, testSigner.Public(), testSigner)
+	s.Require().NoError(err)
+	caCert, err := x509.ParseCertificate(caDER)
+	s.Require().NoError(err)
+
+	journal := s.loadJournal()
+	s.Require().NoError(journal.AppendX509CA(ctx, "A", now, keymanager.KeyType_EC_P256, "x509-CA-A", &X509CA{
+		Signer:      testSigner,
+		Certificate: caCert,
+	}))
+	s.Require().NoError(journal.AppendJWTKey(ctx, "A", now, keymanager.KeyType_EC_P256, "JWT-Signer-A", &JWTKey{
+		Signer:   testSigner,
+		Kid:      "KID",
+		NotAfter: now.Add(time.Hour),
+	}))
+
+	entries := journal.Entries()
+	s.Require().Equal(Status_PREPARED, entries.X509CAs[0].Status)
+	s.Require().Equal(Status_PREPARED, entries.JwtKeys[0].Status)
+
+	found, err := journal.UpdateX509CAStatus(ctx, "010203", Status_TAINTED, now.Add(time.Minute))
+	s.Require().NoError(err)
+	s.Require().True(found)
+	found, err = journal.UpdateJWTKeyStatus(ctx, "KID", Status_ACTIVE, now.Add(time.Minute))
+	s.Require().NoError(err)
+	s.Require().True(found)
+
+	found, err = journal.UpdateX509CAStatus(ctx, "deadbeef", Status_TAINTED, now)
+	s.Require().NoError(err)
+	s.Require().False(found)
+	found, err = journal.UpdateJWTKeyStatus(ctx, "NOPE", Status_TAINTED, now)
+	s.Require().NoError(err)
+	s.Require().False(found)
+
+	// the status changes are persisted
+	entries = s.loadJournal().Entries()
+	s.Require().Equal(Status_TAINTED, entries.X509CAs[0].Status)
+	s.Require().Equal(now.Add(time.Minute).Unix(), entries.X509CAs[0].TaintedAt)
+	s.Require().Equal(Status_ACTIVE, entries.JwtKeys[0].Status)
+	s.Require().Zero(entries.JwtKeys[0].TaintedAt)
 }
 
 func (s *JournalSuite) TestX509CAOverflow() {
@@ -163,7 +318,7 @@ func (s *JournalSuite) TestX509CAOverflow() {
 
 	for i := 0; i < (journalCap + 1); i++ {
 		now = now.Add(time.Minute)
-		err := journal.AppendX509CA("A", now, &X509CA{
+		err := journal.AppendX509CA(ctx, "A", now, keymanager.KeyType_EC_P384, "x509-CA-A", &X509CA{
 			Signer:      testSigner,
 			Certificate: testChain[0],
 		})
@@ -184,7 +339,7 @@ func (s *JournalSuite) TestJWTKeyOverflow() {
 
 	for i := 0; i < (journalCap + 1); i++ {
 		now = now.Add(time.Minute)
-		err := journal.AppendJWTKey("B", now, &JWTKey{
+		err := journal.AppendJWTKey(ctx, "B", now, keymanager.KeyType_EC_P256, "JWT-Signer-B", &JWTKey{
 			Signer:   testSigner,
 			Kid:      "KID",
 			NotAfter: now.Add(time.Hour),
@@ -9,14 +9,18 @@ import (
 	"crypto/x509/pkix"
 	"errors"
 	"fmt"
+	"io"
 	"math/big"
 	"net/url"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/andres-erbsen/clock"
 	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/cryptoutil"
+	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
 	"github.com/spiffe/spire/pkg/common/util"
@@ -25,16 +29,46 @@ import (
 	"github.com/spiffe/spire/proto/spire/server/datastore"
 	"github.com/spiffe/spire/proto/spire/server/keymanager"
 	"github.com/spiffe/spire/proto/spire/server/notifier"
-	"github.com/spiffe/spire/proto/spire/server/upstreamca"
+	"github.com/spiffe/spire/proto/spire/server/upstreamauthority"
 	"github.com/zeebo/errs"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 const (
-	DefaultCATTL    = 24 * time.Hour
-	backdate        = time.Second * 10
-	rotateInterval  = time.Minute
-	pruneInterval   = 6 * time.Hour
-	safetyThreshold = 24 * time.Hour
+	DefaultCATTL         = 24 * time.Hour
+	DefaultX509CAKeyType = keymanager.KeyType_EC_P384
+	DefaultJWTKeyType    = keymanager.KeyType_EC_P256
+	backdate             = time.Second * 10
+	rotateInterval       = time.Minute
+	pruneInterval        = 6 * time.Hour
+	safetyThreshold      = 24 * time.Hour
+
+	// DefaultTaintedRemovalDelay is how long a tainted authority is kept in
+	// the bundle by default, giving agents the chance to rotate the SVIDs it
+	// signed before they stop being trusted.
+	DefaultTaintedRemovalDelay = time.Hour
+
+	// preparationClaimTTL is how long a server sharing the journal holds
+	// the claim on preparing an X509 CA or JWT key. Other servers wait for
+	// the preparation to complete or the claim to expire.
+	preparationClaimTTL = 5 * time.Minute
+
+	// journalPollInterval is how often a server sharing the journal checks
+	// whether another server finished preparing the initial authorities.
+	journalPollInterval = 5 * time.Second
+
+	// crlInterval is how often the revoked certificates are checked for
+	// changes that need to be published in the CRL.
+	crlInterval = time.Minute
+
+	// crlTTL is how long a published CRL is valid. The CRL is republished
+	// once it reaches half of its lifetime.
+	crlTTL = 24 * time.Hour
+
+	// issuedSVIDsPageSize is the page size used when listing the issued
+	// SVIDs to find the X509 CA that issued a revoked certificate.
+	issuedSVIDsPageSize = 1000
 )
 
 type CASetter interface {
@@ -49,10 +83,34 @@ type ManagerConfig struct {
 	UpstreamBundle bool
 	CATTL          time.Duration
 	CASubject      pkix.Name
+	X509CAKeyType  keymanager.KeyType
+	JWTKeyType     keymanager.KeyType
 	Dir            string
-	Log            logrus.FieldLogger
-	Metrics        telemetry.Metrics
-	Clock          clock.Clock
+
+	// JWTSigningAlgorithm is the JWS algorithm JWT keys sign with. It must
+	// be compatible with JWTKeyType. If empty, the default algorithm for
+	// the key type is used.
+	JWTSigningAlgorithm string
+
+	Log     logrus.FieldLogger
+	Metrics telemetry.Metrics
+	Clock   clock.Clock
+
+	// TaintedRemovalDelay is how long a tainted authority is kept in the
+	// bundle before it is removed. It should leave agents enough time to
+	// observe the taint and rotate the SVIDs signed by the authority.
+	TaintedRemovalDelay time.Duration
+
+	// JournalInDataStore, if true, keeps the journal in the datastore so
+	// that servers sharing the datastore and KeyManager share the same X509
+	// CA and JWT key slots.
+	JournalInDataStore bool
+
+	// IsLeader, if set, reports whether this server is the leader among the
+	// servers sharing the datastore. Only the leader prunes the bundle. When
+	// the journal is kept in the datastore, only the leader rotates the X509
+	// CA and JWT key; the other servers follow the rotations in the journal.
+	IsLeader func() bool
 }
 
 type Manager struct {
@@ -60,12 +118,24 @@ type Manager struct {
 	ca              ServerCA
 	bundleUpdatedCh chan struct{}
 
+	// mu protects the slots from concurrent rotation by the manager and
+	// operator initiated changes.
+	mu            sync.Mutex
 	currentX509CA *x509CASlot
 	nextX509CA    *x509CASlot
 	currentJWTKey *jwtKeySlot
 	nextJWTKey    *jwtKeySlot
 
 	journal *Journal
+
+	// upstreamMu protects the cancel functions of the streams opened to the
+	// upstream authority, which are kept open to receive updates to the
+	// upstream roots and JWT keys.
+	upstreamMu            sync.Mutex
+	upstreamWG            sync.WaitGroup
+	cancelMintX509CA      context.CancelFunc
+	cancelPublishJWTKey   context.CancelFunc
+	publishJWTKeyWarnOnce sync.Once
 }
 
 func NewManager(c ManagerConfig) *Manager {
@@ -75,6 +145,15 @@ func NewManager(c ManagerConfig) *Manager {
 	if c.Clock == nil {
 		c.Clock = clock.New()
 	}
+	if c.TaintedRemovalDelay <= 0 {
+		c.TaintedRemovalDelay = DefaultTaintedRemovalDelay
+	}
+	if c.X509CAKeyType == keymanager.KeyType_UNSPECIFIED_KEY_TYPE {
+		c.X509CAKeyType = DefaultX509CAKeyType
+	}
+	if c.JWTKeyType == keymanager.KeyType_UNSPECIFIED_KEY_TYPE {
+		c.JWTKeyType = DefaultJWTKeyType
+	}
 
 	return &Manager{
 		c:               c,
@@ -86,13 +165,35 @@ func (m *Manager) Initialize(ctx context.Context) error {
 	if err := m.loadJournal(ctx); err != nil {
 		return err
 	}
-	if err := m.rotate(ctx); err != nil {
-		return err
+	m.resumeUpstreamStreams(ctx)
+	for {
+		if err := m.rotate(ctx); err != nil {
+			return err
+		}
+		if m.hasCurrentAuthorities() {
+			return nil
+		}
+
+		// Another server sharing the journal is preparing the initial
+		// authorities. Wait for them to show up in the journal.
+		m.c.Log.Info("Waiting for another server to prepare the initial authorities")
+		select {
+		case <-m.c.Clock.After(journalPollInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
 	}
-	return nil
+}
+
+func (m *Manager) hasCurrentAuthorities() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return !m.currentX509CA.IsEmpty() && !m.currentJWTKey.IsEmpty()
 }
 
 func (m *Manager) Run(ctx context.Context) error {
+	defer m.closeUpstreamStreams()
+
 	if err := m.notifyBundleLoaded(ctx); err != nil {
 		return err
 	}
@@ -103,6 +204,9 @@ func (m *Manager) Run(ctx context.Context) error {
 		func(ctx context.Context) error {
 			return m.pruneBundleEvery(ctx, pruneInterval)
 		},
+		func(ctx context.Context) error {
+			return m.publishCRLEvery(ctx, crlInterval)
+		},
 		m.notifyOnBundleUpdate,
 	)
 	if err == context.Canceled {
@@ -118,14 +222,40 @@ func (m *Manager) rotateEvery(ctx context.Context, interval time.Duration) error
 	for {
 		select {
 		case <-ticker.C:
-			m.rotate(ctx)
+			if m.c.JournalInDataStore && !m.isLeader() {
+				m.followJournal(ctx)
+			} else {
+				m.rotate(ctx)
+			}
 		case <-ctx.Done():
 			return nil
 		}
 	}
 }
 
+// followJournal picks up the rotations done by the leader
+func (m *Manager) followJournal(ctx context.Context) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.syncJournal(ctx); err != nil {
+		m.c.Log.WithError(err).Error("Unable to sync journal")
+	}
+}
+
+func (m *Manager) isLeader() bool {
+	return m.c.IsLeader == nil || m.c.IsLeader()
+}
+
 func (m *Manager) rotate(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.syncJournal(ctx); err != nil {
+		m.c.Log.WithError(err).Error("Unable to sync journal")
+		return err
+	}
+
 	x509CAErr := m.rotateX509CA(ctx)
 	if x509CAErr != nil {
 		m.c.Log.WithError(x509CAErr).Error("Unable to rotate X509 CA")
@@ -136,7 +266,12 @@ func (m *Manager) rotate(ctx context.Context) error {
 		m.c.Log.WithError(jwtKeyErr).Error("Unable to rotate JWT key")
 	}
 
-	return errs.Combine(x509CAErr, jwtKeyErr)
+	taintedErr := m.removeTaintedAuthorities(ctx)
+	if taintedErr != nil {
+		m.c.Log.WithError(taintedErr).Error("Unable to remove tainted authorities from the bundle")
+	}
+
+	return errs.Combine(x509CAErr, jwtKeyErr, taintedErr)
 }
 
 func (m *Manager) rotateX509CA(ctx context.Context) error {
@@ -144,24 +279,32 @@ func (m *Manager) rotateX509CA(ctx context.Context) error {
 
 	// if there is no current keypair set, generate one
 	if m.currentX509CA.IsEmpty() {
-		if err := m.prepareX509CA(ctx, m.currentX509CA); err != nil {
+		switch err := m.prepareX509CA(ctx, m.currentX509CA); err {
+		case nil:
+		case errPreparationInProgress:
+			m.c.Log.Debug("Another server is preparing the X509 CA")
+			return nil
+		default:
 			return err
 		}
 		m.activateX509CA()
+		m.updateX509CAStatus(ctx, m.currentX509CA.x509CA, Status_ACTIVE)
 	}
 
 	// if there is no next keypair set and the current is within the
 	// preparation threshold, generate one.
 	if m.nextX509CA.IsEmpty() && m.currentX509CA.ShouldPrepareNext(now) {
-		if err := m.prepareX509CA(ctx, m.nextX509CA); err != nil {
+		switch err := m.prepareX509CA(ctx, m.nextX509CA); err {
+		case nil:
+		case errPreparationInProgress:
+			m.c.Log.Debug("Another server is preparing the next X509 CA")
+		default:
 			return err
 		}
 	}
 
 	if m.currentX509CA.ShouldActivateNext(now) {
-		m.currentX509CA, m.nextX509CA = m.nextX509CA, m.currentX509CA
-		m.nextX509CA.Reset()
-		m.activateX509CA()
+		m.rotateToNextX509CA(ctx)
 	}
 
 	ttl := m.currentX509CA.x509CA.Certificate.NotAfter.Sub(m.c.Clock.Now())
@@ -171,6 +314,19 @@ func (m *Manager) rotateX509CA(ctx context.Context) error {
 }
 
 func (m *Manager) prepareX509CA(ctx context.Context, slot *x509CASlot) (err error) {
+	if m.c.JournalInDataStore {
+		// Servers sharing the journal also share the KeyManager keys, so
+		// only one of them may prepare the slot at a time.
+		if err := m.journal.ClaimX509CAPreparation(ctx, slot.id, m.c.Clock.Now(), preparationClaimTTL); err != nil {
+			return err
+		}
+		defer func() {
+			if err != nil {
+				m.releaseX509CAPreparation(ctx)
+			}
+		}()
+	}
+
 	counter := telemetry_server.StartServerCAManagerPrepareX509CACall(m.c.Metrics)
 	defer counter.Done(&err)
 
@@ -181,16 +337,16 @@ func (m *Manager) prepareX509CA(ctx context.Context, slot *x509CASlot) (err erro
 
 	now := m.c.Clock.Now()
 	km := m.c.Catalog.GetKeyManager()
-	signer, err := cryptoutil.GenerateKeyAndSigner(ctx, km, slot.KmKeyID(), keymanager.KeyType_EC_P384)
+	signer, err := cryptoutil.GenerateKeyAndSigner(ctx, km, slot.KmKeyID(), m.c.X509CAKeyType)
 	if err != nil {
 		return err
 	}
 
 	var x509CA *X509CA
 	var trustBundle []*x509.Certificate
-	upstreamCA, useUpstream := m.c.Catalog.GetUpstreamCA()
+	upstreamAuthority, useUpstream := m.c.Catalog.GetUpstreamAuthority()
 	if useUpstream {
-		x509CA, trustBundle, err = UpstreamSignX509CA(ctx, signer, m.c.TrustDomain.Host, m.c.CASubject, upstreamCA, m.c.UpstreamBundle)
+		x509CA, trustBundle, err = m.upstreamSignX509CA(signer, upstreamAuthority)
 	} else {
 		notBefore := now.Add(-backdate)
 		notAfter := now.Add(m.c.CATTL)
@@ -207,7 +363,13 @@ func (m *Manager) prepareX509CA(ctx context.Context, slot *x509CASlot) (err erro
 	slot.issuedAt = now
 	slot.x509CA = x509CA
 
-	if err := m.journal.AppendX509CA(slot.id, slot.issuedAt, slot.x509CA); err != nil {
+	if err := m.journal.AppendX509CA(ctx, slot.id, slot.issuedAt, m.c.X509CAKeyType, slot.KmKeyID(), slot.x509CA); err != nil {
+		if m.c.JournalInDataStore {
+			// other servers must learn about the X509 CA before it can be
+			// used, since they would otherwise prepare the slot again.
+			slot.Reset()
+			return errs.New("unable to append X509 CA to journal: %v", err)
+		}
 		log.WithError(err).Error("Unable to append X509 CA to journal")
 	}
 
@@ -215,6 +377,7 @@ func (m *Manager) prepareX509CA(ctx context.Context, slot *x509CASlot) (err erro
 		telemetry.Slot:           slot.id,
 		telemetry.IssuedAt:       timeField(slot.issuedAt),
 		telemetry.Expiration:     timeField(slot.x509CA.Certificate.NotAfter),
+		telemetry.KeyType:        m.c.X509CAKeyType.String(),
 		telemetry.SelfSigned:     !useUpstream,
 		telemetry.UpstreamBundle: m.c.UpstreamBundle,
 	}).Info("X509 CA prepared")
@@ -236,152 +399,983 @@ func (m *Manager) rotateJWTKey(ctx context.Context) error {
 
 	// if there is no current keypair set, generate one
 	if m.currentJWTKey.IsEmpty() {
-		if err := m.prepareJWTKey(ctx, m.currentJWTKey); err != nil {
+		switch err := m.prepareJWTKey(ctx, m.currentJWTKey); err {
+		case nil:
+		case errPreparationInProgress:
+			m.c.Log.Debug("Another server is preparing the JWT key")
+			return nil
+		default:
 			return err
 		}
 		m.activateJWTKey()
+		m.updateJWTKeyStatus(ctx, m.currentJWTKey.jwtKey, Status_ACTIVE)
 	}
 
 	// if there is no next keypair set and the current is within the
 	// preparation threshold, generate one.
 	if m.nextJWTKey.IsEmpty() && m.currentJWTKey.ShouldPrepareNext(now) {
-		if err := m.prepareJWTKey(ctx, m.nextJWTKey); err != nil {
+		switch err := m.prepareJWTKey(ctx, m.nextJWTKey); err {
+		case nil:
+		case errPreparationInProgress:
+			m.c.Log.Debug("Another server is preparing the next JWT key")
+		default:
+			return err
+		}
+	}
+
+	if m.currentJWTKey.ShouldActivateNext(now) {
+		m.rotateToNextJWTKey(ctx)
+	}
+
+	return nil
+}
+
+func (m *Manager) prepareJWTKey(ctx context.Context, slot *jwtKeySlot) (err error) {
+	if m.c.JournalInDataStore {
+		// Servers sharing the journal also share the KeyManager keys, so
+		// only one of them may prepare the slot at a time.
+		if err := m.journal.ClaimJWTKeyPreparation(ctx, slot.id, m.c.Clock.Now(), preparationClaimTTL); err != nil {
+			return err
+		}
+		defer func() {
+			if err != nil {
+				m.releaseJWTKeyPreparation(ctx)
+			}
+		}()
+	}
+
+	counter := telemetry_server.StartServerCAManagerPrepareJWTKeyCall(m.c.Metrics)
+	defer counter.Done(&err)
+
+	log := m.c.Log.WithField(telemetry.Slot, slot.id)
+	log.Debug("Preparing JWT key")
+
+	slot.Reset()
+
+	now := m.c.Clock.Now()
+	notAfter := now.Add(m.c.CATTL)
+
+	km := m.c.Catalog.GetKeyManager()
+	signer, err := cryptoutil.GenerateKeyAndSigner(ctx, km, slot.KmKeyID(), m.c.JWTKeyType)
+	if err != nil {
+		return err
+	}
+
+	jwtKey, err := newJWTKey(signer, notAfter, m.c.JWTSigningAlgorithm)
+	if err != nil {
+		return err
+	}
+
+	publicKey, err := publicKeyFromJWTKey(jwtKey)
+	if err != nil {
+		return err
+	}
+
+	upstreamJWTKeys, err := m.publishJWTKey(publicKey)
+	if err != nil {
+		return err
+	}
+
+	if err := m.appendBundle(ctx, nil, []*common.PublicKey{publicKey}); err != nil {
+		return err
+	}
+
+	// the upstream JWT keys usually include the published key, which the
+	// bundle merge takes care of
+	if len(upstreamJWTKeys) > 0 {
+		if err := m.appendBundle(ctx, nil, upstreamJWTKeys); err != nil {
+			return err
+		}
+	}
+
+	slot.issuedAt = now
+	slot.jwtKey = jwtKey
+
+	if err := m.journal.AppendJWTKey(ctx, slot.id, slot.issuedAt, m.c.JWTKeyType, slot.KmKeyID(), slot.jwtKey); err != nil {
+		if m.c.JournalInDataStore {
+			// other servers must learn about the JWT key before it can be
+			// used, since they would otherwise prepare the slot again.
+			slot.Reset()
+			return errs.New("unable to append JWT key to journal: %v", err)
+		}
+		log.WithError(err).Error("Unable to append JWT key to journal")
+	}
+
+	m.c.Log.WithFields(logrus.Fields{
+		telemetry.Slot:       slot.id,
+		telemetry.IssuedAt:   timeField(slot.issuedAt),
+		telemetry.Expiration: timeField(slot.jwtKey.NotAfter),
+		telemetry.KeyType:    m.c.JWTKeyType.String(),
+		telemetry.Algorithm:  slot.jwtKey.Alg,
+	}).Info("JWT key prepared")
+	return nil
+}
+
+func (m *Manager) activateJWTKey() {
+	m.c.Log.WithFields(logrus.Fields{
+		telemetry.Slot:       m.currentJWTKey.id,
+		telemetry.IssuedAt:   timeField(m.currentJWTKey.issuedAt),
+		telemetry.Expiration: timeField(m.currentJWTKey.jwtKey.NotAfter),
+	}).Info("JWT key activated")
+	telemetry_server.IncrActivateJWTKeyManagerCounter(m.c.Metrics)
+	m.c.CA.SetJWTKey(m.currentJWTKey.jwtKey)
+}
+
+// rotateToNextX509CA activates the X509 CA in the next slot. The previously
+// active X509 CA is kept in the bundle until it expires or is tainted.
+func (m *Manager) rotateToNextX509CA(ctx context.Context) {
+	m.updateX509CAStatus(ctx, m.currentX509CA.x509CA, Status_OLD)
+	m.currentX509CA, m.nextX509CA = m.nextX509CA, m.currentX509CA
+	m.nextX509CA.Reset()
+	m.activateX509CA()
+	m.updateX509CAStatus(ctx, m.currentX509CA.x509CA, Status_ACTIVE)
+}
+
+// rotateToNextJWTKey activates the JWT key in the next slot. The previously
+// active JWT key is kept in the bundle until it expires or is tainted.
+func (m *Manager) rotateToNextJWTKey(ctx context.Context) {
+	m.updateJWTKeyStatus(ctx, m.currentJWTKey.jwtKey, Status_OLD)
+	m.currentJWTKey, m.nextJWTKey = m.nextJWTKey, m.currentJWTKey
+	m.nextJWTKey.Reset()
+	m.activateJWTKey()
+	m.updateJWTKeyStatus(ctx, m.currentJWTKey.jwtKey, Status_ACTIVE)
+}
+
+func (m *Manager) updateX509CAStatus(ctx context.Context, x509CA *X509CA, status Status) {
+	if x509CA == nil {
+		return
+	}
+	authorityID := bundleutil.X509AuthorityID(x509CA.Certificate)
+	if _, err := m.journal.UpdateX509CAStatus(ctx, authorityID, status, m.c.Clock.Now()); err != nil {
+		m.c.Log.WithError(err).WithField(telemetry.AuthorityID, authorityID).Error("Unable to update X509 CA status in journal")
+	}
+}
+
+func (m *Manager) updateJWTKeyStatus(ctx context.Context, jwtKey *JWTKey, status Status) {
+	if jwtKey == nil {
+		return
+	}
+	if _, err := m.journal.UpdateJWTKeyStatus(ctx, jwtKey.Kid, status, m.c.Clock.Now()); err != nil {
+		m.c.Log.WithError(err).WithField(telemetry.Kid, jwtKey.Kid).Error("Unable to update JWT key status in journal")
+	}
+}
+
+func (m *Manager) releaseX509CAPreparation(ctx context.Context) {
+	if err := m.journal.ReleaseX509CAPreparation(ctx); err != nil {
+		m.c.Log.WithError(err).Error("Unable to release X509 CA preparation claim")
+	}
+}
+
+func (m *Manager) releaseJWTKeyPreparation(ctx context.Context) {
+	if err := m.journal.ReleaseJWTKeyPreparation(ctx); err != nil {
+		m.c.Log.WithError(err).Error("Unable to release JWT key preparation claim")
+	}
+}
+
+// SlotInfo describes the contents of an X509 CA or JWT key slot.
+type SlotInfo struct {
+	// SlotID is the slot identifier (i.e. "A" or "B")
+	SlotID string
+
+	// Status is ACTIVE for the current slot, PREPARED for a prepared next
+	// slot and UNKNOWN if the slot is empty.
+	Status Status
+
+	// AuthorityID identifies the authority in the slot. For X509 CAs it is
+	// the hex encoded subject key ID of the CA certificate. For JWT keys it
+	// is the key ID.
+	AuthorityID string
+
+	// IssuedAt is when the authority was prepared
+	IssuedAt time.Time
+
+	// NotAfter is when the authority expires
+	NotAfter time.Time
+}
+
+// X509CASlots returns information about the current and next X509 CA slots.
+func (m *Manager) X509CASlots() (current, next SlotInfo) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return x509CASlotInfo(m.currentX509CA, Status_ACTIVE), x509CASlotInfo(m.nextX509CA, Status_PREPARED)
+}
+
+// JWTKeySlots returns information about the current and next JWT key slots.
+func (m *Manager) JWTKeySlots() (current, next SlotInfo) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return jwtKeySlotInfo(m.currentJWTKey, Status_ACTIVE), jwtKeySlotInfo(m.nextJWTKey, Status_PREPARED)
+}
+
+// PrepareNextX509CA prepares a new X509 CA in the next slot, regardless of
+// the preparation threshold. Any X509 CA already prepared in the next slot is
+// replaced.
+func (m *Manager) PrepareNextX509CA(ctx context.Context) (SlotInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.syncJournal(ctx); err != nil {
+		return SlotInfo{}, err
+	}
+
+	m.c.Log.WithField(telemetry.Slot, m.nextX509CA.id).Info("Operator requested X509 CA preparation")
+	// the replaced authority, if any, stays in the bundle until it expires
+	m.updateX509CAStatus(ctx, m.nextX509CA.x509CA, Status_OLD)
+	switch err := m.prepareX509CA(ctx, m.nextX509CA); err {
+	case nil:
+	case errPreparationInProgress:
+		return SlotInfo{}, status.Error(codes.Aborted, "another server is preparing the next X509 CA")
+	default:
+		return SlotInfo{}, err
+	}
+	return x509CASlotInfo(m.nextX509CA, Status_PREPARED), nil
+}
+
+// ActivateNextX509CA activates the X509 CA prepared in the next slot,
+// regardless of the activation threshold.
+func (m *Manager) ActivateNextX509CA(ctx context.Context) (SlotInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.syncJournal(ctx); err != nil {
+		return SlotInfo{}, err
+	}
+
+	if m.nextX509CA.IsEmpty() {
+		return SlotInfo{}, status.Error(codes.FailedPrecondition, "no prepared X509 CA to activate")
+	}
+
+	m.c.Log.WithField(telemetry.Slot, m.nextX509CA.id).Info("Operator requested X509 CA activation")
+	m.rotateToNextX509CA(ctx)
+	return x509CASlotInfo(m.currentX509CA, Status_ACTIVE), nil
+}
+
+// PrepareNextJWTKey prepares a new JWT key in the next slot, regardless of
+// the preparation threshold. Any JWT key already prepared in the next slot is
+// replaced.
+func (m *Manager) PrepareNextJWTKey(ctx context.Context) (SlotInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.syncJournal(ctx); err != nil {
+		return SlotInfo{}, err
+	}
+
+	m.c.Log.WithField(telemetry.Slot, m.nextJWTKey.id).Info("Operator requested JWT key preparation")
+	// the replaced authority, if any, stays in the bundle until it expires
+	m.updateJWTKeyStatus(ctx, m.nextJWTKey.jwtKey, Status_OLD)
+	switch err := m.prepareJWTKey(ctx, m.nextJWTKey); err {
+	case nil:
+	case errPreparationInProgress:
+		return SlotInfo{}, status.Error(codes.Aborted, "another server is preparing the next JWT key")
+	default:
+		return SlotInfo{}, err
+	}
+	return jwtKeySlotInfo(m.nextJWTKey, Status_PREPARED), nil
+}
+
+// ActivateNextJWTKey activates the JWT key prepared in the next slot,
+// regardless of the activation threshold.
+func (m *Manager) ActivateNextJWTKey(ctx context.Context) (SlotInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.syncJournal(ctx); err != nil {
+		return SlotInfo{}, err
+	}
+
+	if m.nextJWTKey.IsEmpty() {
+		return SlotInfo{}, status.Error(codes.FailedPrecondition, "no prepared JWT key to activate")
+	}
+
+	m.c.Log.WithField(telemetry.Slot, m.nextJWTKey.id).Info("Operator requested JWT key activation")
+	m.rotateToNextJWTKey(ctx)
+	return jwtKeySlotInfo(m.currentJWTKey, Status_ACTIVE), nil
+}
+
+// TaintX509Authority marks an old X509 authority as tainted in the bundle.
+// Agents rotate SVIDs signed by a tainted authority as soon as they observe
+// the taint. The authority is removed from the bundle after a grace period.
+// Neither the active nor the prepared X509 CA can be tainted.
+//
+// The authority ID is matched against the root CAs in the bundle and the
+// X509 CAs in the journal. When the bundle holds the upstream roots
+// (upstream_bundle), an X509 CA is an intermediate that is not in the
+// bundle; its authority ID is then listed as tainted in the bundle, without
+// trusting it as a root CA, so that agents can tell which SVIDs it signed. It
+// is removed along with the taint after the grace period.
+func (m *Manager) TaintX509Authority(ctx context.Context, authorityID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.syncJournal(ctx); err != nil {
+		return err
+	}
+
+	for _, slot := range []*x509CASlot{m.currentX509CA, m.nextX509CA} {
+		if !slot.IsEmpty() && bundleutil.X509AuthorityID(slot.x509CA.Certificate) == authorityID {
+			return status.Errorf(codes.FailedPrecondition, "X509 authority %q is in use by slot %q; activate or prepare a replacement first", authorityID, slot.id)
+		}
+	}
+
+	bundle, err := m.fetchRequiredBundle(ctx)
+	if err != nil {
+		return err
+	}
+	taintedBundle, ok, err := bundleutil.TaintX509Authority(bundle, authorityID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		cert, err := m.journalX509CACertificate(authorityID)
+		if err != nil {
+			return err
+		}
+		if cert == nil {
+			return status.Errorf(codes.NotFound, "no X509 authority %q in the bundle", authorityID)
+		}
+		taintedBundle = bundleutil.AddTaintedX509Authority(bundle, authorityID)
+	}
+	bundle = taintedBundle
+
+	found, err := m.journal.UpdateX509CAStatus(ctx, authorityID, Status_TAINTED, m.c.Clock.Now())
+	if err != nil {
+		return err
+	}
+	if !found {
+		return status.Errorf(codes.NotFound, "no X509 authority %q in the journal", authorityID)
+	}
+
+	if err := m.updateBundle(ctx, bundle); err != nil {
+		return err
+	}
+
+	m.c.Log.WithField(telemetry.AuthorityID, authorityID).Warn("X509 authority tainted")
+	return nil
+}
+
+// TaintJWTAuthority marks an old JWT key as tainted in the bundle. Agents
+// drop cached JWT-SVIDs signed by a tainted key. The key is removed from the
+// bundle after a grace period. Neither the active nor the prepared JWT key
+// can be tainted.
+func (m *Manager) TaintJWTAuthority(ctx context.Context, kid string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.syncJournal(ctx); err != nil {
+		return err
+	}
+
+	for _, slot := range []*jwtKeySlot{m.currentJWTKey, m.nextJWTKey} {
+		if !slot.IsEmpty() && slot.jwtKey.Kid == kid {
+			return status.Errorf(codes.FailedPrecondition, "JWT authority %q is in use by slot %q; activate or prepare a replacement first", kid, slot.id)
+		}
+	}
+
+	bundle, err := m.fetchRequiredBundle(ctx)
+	if err != nil {
+		return err
+	}
+	bundle, ok := bundleutil.TaintJWTAuthority(bundle, kid)
+	if !ok {
+		return status.Errorf(codes.NotFound, "no JWT authority %q in the bundle", kid)
+	}
+
+	found, err := m.journal.UpdateJWTKeyStatus(ctx, kid, Status_TAINTED, m.c.Clock.Now())
+	if err != nil {
+		return err
+	}
+	if !found {
+		return status.Errorf(codes.NotFound, "no JWT authority %q in the journal", kid)
+	}
+
+	if err := m.updateBundle(ctx, bundle); err != nil {
+		return err
+	}
+
+	m.c.Log.WithField(telemetry.Kid, kid).Warn("JWT authority tainted")
+	return nil
+}
+
+// removeTaintedAuthorities removes authorities from the bundle that were
+// tainted longer than the tainted removal delay ago.
+func (m *Manager) removeTaintedAuthorities(ctx context.Context) error {
+	removeBefore := m.c.Clock.Now().Add(-m.c.TaintedRemovalDelay).Unix()
+
+	entries := m.journal.Entries()
+	var x509AuthorityIDs []string
+	for _, entry := range entries.X509CAs {
+		if entry.Status == Status_TAINTED && entry.TaintedAt <= removeBefore {
+			authorityID, err := x509CAEntryAuthorityID(entry)
+			if err != nil {
+				return err
+			}
+			x509AuthorityIDs = append(x509AuthorityIDs, authorityID)
+		}
+	}
+	var jwtKeyIDs []string
+	for _, entry := range entries.JwtKeys {
+		if entry.Status == Status_TAINTED && entry.TaintedAt <= removeBefore {
+			jwtKeyIDs = append(jwtKeyIDs, entry.Kid)
+		}
+	}
+	if len(x509AuthorityIDs) == 0 && len(jwtKeyIDs) == 0 {
+		return nil
+	}
+
+	bundle, err := m.fetchRequiredBundle(ctx)
+	if err != nil {
+		return err
+	}
+	bundle, changed, err := bundleutil.RemoveTaintedAuthorities(bundle, x509AuthorityIDs, jwtKeyIDs)
+	if err != nil {
+		return err
+	}
+	if changed {
+		if err := m.updateBundle(ctx, bundle); err != nil {
 			return err
 		}
 	}
 
-	if m.currentJWTKey.ShouldActivateNext(now) {
-		m.currentJWTKey, m.nextJWTKey = m.nextJWTKey, m.currentJWTKey
-		m.nextJWTKey.Reset()
-		m.activateJWTKey()
+	now := m.c.Clock.Now()
+	for _, authorityID := range x509AuthorityIDs {
+		m.c.Log.WithField(telemetry.AuthorityID, authorityID).Info("Tainted X509 authority removed from bundle")
+		if _, err := m.journal.UpdateX509CAStatus(ctx, authorityID, Status_REMOVED, now); err != nil {
+			return err
+		}
+	}
+	for _, kid := range jwtKeyIDs {
+		m.c.Log.WithField(telemetry.Kid, kid).Info("Tainted JWT authority removed from bundle")
+		if _, err := m.journal.UpdateJWTKeyStatus(ctx, kid, Status_REMOVED, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// journalX509CACertificate returns the certificate of the X509 CA in the
+// journal with the given authority ID, or nil if there is none.
+func (m *Manager) journalX509CACertificate(authorityID string) (*x509.Certificate, error) {
+	for _, entry := range m.journal.Entries().X509CAs {
+		cert, err := x509.ParseCertificate(entry.Certificate)
+		if err != nil {
+			return nil, errs.New("unable to parse CA certificate: %v", err)
+		}
+		if bundleutil.X509AuthorityID(cert) == authorityID {
+			return cert, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *Manager) updateBundle(ctx context.Context, bundle *common.Bundle) error {
+	ds := m.c.Catalog.GetDataStore()
+	if _, err := ds.UpdateBundle(ctx, &datastore.UpdateBundleRequest{
+		Bundle: bundle,
+	}); err != nil {
+		return err
+	}
+
+	m.bundleUpdated()
+	return nil
+}
+
+func x509CASlotInfo(slot *x509CASlot, status Status) SlotInfo {
+	info := SlotInfo{
+		SlotID: slot.id,
+	}
+	if !slot.IsEmpty() {
+		info.Status = status
+		info.AuthorityID = bundleutil.X509AuthorityID(slot.x509CA.Certificate)
+		info.IssuedAt = slot.issuedAt
+		info.NotAfter = slot.x509CA.Certificate.NotAfter
+	}
+	return info
+}
+
+func jwtKeySlotInfo(slot *jwtKeySlot, status Status) SlotInfo {
+	info := SlotInfo{
+		SlotID: slot.id,
+	}
+	if !slot.IsEmpty() {
+		info.Status = status
+		info.AuthorityID = slot.jwtKey.Kid
+		info.IssuedAt = slot.issuedAt
+		info.NotAfter = slot.jwtKey.NotAfter
+	}
+	return info
+}
+
+func (m *Manager) pruneBundleEvery(ctx context.Context, interval time.Duration) error {
+	ticker := m.c.Clock.Ticker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if !m.isLeader() {
+				continue
+			}
+			if err := m.pruneBundle(ctx); err != nil {
+				m.c.Log.WithError(err).Error("Could not prune CA certificates")
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (m *Manager) pruneBundle(ctx context.Context) (err error) {
+	counter := telemetry_server.StartCAManagerPruneBundleCall(m.c.Metrics)
+	defer counter.Done(&err)
+
+	ds := m.c.Catalog.GetDataStore()
+	expiresBefore := m.c.Clock.Now().Add(-safetyThreshold)
+
+	resp, err := ds.PruneBundle(ctx, &datastore.PruneBundleRequest{
+		TrustDomainId: m.c.TrustDomain.String(),
+		ExpiresBefore: expiresBefore.Unix(),
+	})
+
+	if err != nil {
+		return fmt.Errorf("unable to prune bundle: %v", err)
+	}
+
+	if resp.BundleChanged {
+		telemetry_server.IncrManagerPrunedBundleCounter(m.c.Metrics)
+		m.bundleUpdated()
+	}
+
+	return nil
+}
+
+func (m *Manager) publishCRLEvery(ctx context.Context, interval time.Duration) error {
+	ticker := m.c.Clock.Ticker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if !m.isLeader() {
+				continue
+			}
+			if err := m.publishCRL(ctx); err != nil {
+				m.c.Log.WithError(err).Error("Could not publish CRL")
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// publishCRL prunes revocations of expired certificates and publishes a CRL
+// in the bundle for each X509 CA that may have issued unexpired SVIDs, signed
+// by that CA. A CRL is only re-signed when the set of revoked certificates
+// changes or the published CRL reaches half of its lifetime.
+func (m *Manager) publishCRL(ctx context.Context) (err error) {
+	counter := telemetry_server.StartCAManagerPublishCRLCall(m.c.Metrics)
+	defer counter.Done(&err)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.currentX509CA.IsEmpty() {
+		return nil
+	}
+
+	ds := m.c.Catalog.GetDataStore()
+	now := m.c.Clock.Now()
+
+	if _, err := ds.PruneRevokedCertificates(ctx, &datastore.PruneRevokedCertificatesRequest{
+		ExpiresBefore: now.Unix(),
+	}); err != nil {
+		return fmt.Errorf("unable to prune revoked certificates: %v", err)
+	}
+	if _, err := ds.PruneDownstreamCAs(ctx, &datastore.PruneDownstreamCAsRequest{
+		ExpiresBefore: now.Unix(),
+	}); err != nil {
+		return fmt.Errorf("unable to prune downstream CAs: %v", err)
+	}
+
+	resp, err := ds.ListRevokedCertificates(ctx, &datastore.ListRevokedCertificatesRequest{})
+	if err != nil {
+		return fmt.Errorf("unable to list revoked certificates: %v", err)
+	}
+	var revoked []revokedCertificate
+	for _, revokedCert := range resp.RevokedCertificates {
+		serialNumber, ok := new(big.Int).SetString(revokedCert.SerialNumber, 10)
+		if !ok {
+			m.c.Log.WithField(telemetry.SerialNumber, revokedCert.SerialNumber).Warn("Ignoring revoked certificate with malformed serial number")
+			continue
+		}
+		revoked = append(revoked, revokedCertificate{
+			RevokedCertificate: pkix.RevokedCertificate{
+				SerialNumber:   serialNumber,
+				RevocationTime: time.Unix(revokedCert.RevokedAt, 0).UTC(),
+			},
+		})
+	}
+
+	bundle, err := m.fetchRequiredBundle(ctx)
+	if err != nil {
+		return err
+	}
+
+	issuers, err := m.crlIssuers(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	if err := m.setRevokedCertificateAuthorities(ctx, revoked, issuers); err != nil {
+		return err
+	}
+
+	changed := false
+	var crls [][]byte
+	for _, x509CA := range issuers {
+		issuerRevoked := revokedCertificatesIssuedBy(revoked, bundleutil.X509AuthorityID(x509CA.Certificate))
+		crlBytes := publishedCRL(bundle.Crls, x509CA.Certificate)
+		if crlNeedsUpdate(crlBytes, x509CA.Certificate, issuerRevoked, now) {
+			crlBytes, err = x509CA.Certificate.CreateCRL(rand.Reader, x509CA.Signer, issuerRevoked, now, now.Add(crlTTL))
+			if err != nil {
+				return fmt.Errorf("unable to create CRL: %v", err)
+			}
+			changed = true
+		}
+		if len(crlBytes) > 0 {
+			crls = append(crls, crlBytes)
+		}
+	}
+	// CRLs of X509 CAs that expired or whose keys are gone are dropped.
+	if !changed && len(crls) == len(bundle.Crls) {
+		return nil
+	}
+
+	bundle.Crls = crls
+	if err := m.updateBundle(ctx, bundle); err != nil {
+		return err
+	}
+
+	m.c.Log.WithField(telemetry.Count, len(revoked)).Info("Published CRLs")
+	return nil
+}
+
+// revokedCertificate is a revoked certificate along with the authority ID of
+// the X509 CA that issued it, if known.
+type revokedCertificate struct {
+	pkix.RevokedCertificate
+	authorityID string
+}
+
+// setRevokedCertificateAuthorities looks up the X509 CA that issued each
+// revoked certificate in the issued SVIDs. Only SVIDs issued since around
+// the time the oldest of the given issuers became valid are considered.
+func (m *Manager) setRevokedCertificateAuthorities(ctx context.Context, revoked []revokedCertificate, issuers []*X509CA) error {
+	if len(revoked) == 0 {
+		return nil
+	}
+
+	bySerialNumber := make(map[string]*revokedCertificate, len(revoked))
+	for i := range revoked {
+		bySerialNumber[revoked[i].SerialNumber.String()] = &revoked[i]
+	}
+
+	var issuedAfter time.Time
+	for _, x509CA := range issuers {
+		if issuedAfter.IsZero() || x509CA.Certificate.NotBefore.Before(issuedAfter) {
+			issuedAfter = x509CA.Certificate.NotBefore
+		}
+	}
+
+	ds := m.c.Catalog.GetDataStore()
+	req := &datastore.ListIssuedSVIDsRequest{
+		IssuedAfter: issuedAfter.Add(-backdate).Unix(),
+		Pagination: &datastore.Pagination{
+			PageSize: issuedSVIDsPageSize,
+		},
+	}
+	for {
+		resp, err := ds.ListIssuedSVIDs(ctx, req)
+		if err != nil {
+			return fmt.Errorf("unable to list issued SVIDs: %v", err)
+		}
+		for _, issuedSVID := range resp.IssuedSvids {
+			if issuedSVID.Type == datastore.IssuedSVID_JWT_SVID {
+				continue
+			}
+			if revokedCert, ok := bySerialNumber[issuedSVID.Id]; ok {
+				revokedCert.authorityID = issuedSVID.AuthorityId
+			}
+		}
+		if len(resp.IssuedSvids) < issuedSVIDsPageSize {
+			return nil
+		}
+		req.Pagination = resp.Pagination
+	}
+}
+
+// revokedCertificatesIssuedBy returns the revoked certificates issued by the
+// X509 CA with the given authority ID. Revoked certificates whose issuer is
+// unknown, e.g. because the issued SVID record was pruned, are listed in the
+// CRL of every X509 CA.
+func revokedCertificatesIssuedBy(revoked []revokedCertificate, authorityID string) []pkix.RevokedCertificate {
+	var issued []pkix.RevokedCertificate
+	for _, revokedCert := range revoked {
+		if revokedCert.authorityID == "" || revokedCert.authorityID == authorityID {
+			issued = append(issued, revokedCert.RevokedCertificate)
+		}
+	}
+	return issued
+}
+
+// crlIssuers returns the X509 CAs that a CRL is published for: the current
+// X509 CA and the unexpired X509 CAs in the journal that may have issued
+// SVIDs. X509 CAs whose KeyManager key has since been replaced can no longer
+// sign a CRL and are skipped.
+func (m *Manager) crlIssuers(ctx context.Context, now time.Time) ([]*X509CA, error) {
+	current := m.currentX509CA.x509CA
+	issuers := []*X509CA{current}
+	seen := map[string]bool{
+		bundleutil.X509AuthorityID(current.Certificate): true,
+	}
+	for _, entry := range m.journal.Entries().X509CAs {
+		switch entry.Status {
+		case Status_PREPARED, Status_REMOVED:
+			continue
+		}
+		slot, badReason, err := m.loadX509CASlotFromEntry(ctx, entry)
+		switch {
+		case err != nil:
+			return nil, err
+		case badReason != "":
+			continue
+		}
+		cert := slot.x509CA.Certificate
+		authorityID := bundleutil.X509AuthorityID(cert)
+		if seen[authorityID] || !now.Before(cert.NotAfter) {
+			continue
+		}
+		seen[authorityID] = true
+		issuers = append(issuers, slot.x509CA)
+	}
+	return issuers, nil
+}
+
+func (m *Manager) appendBundle(ctx context.Context, caChain []*x509.Certificate, jwtSigningKeys []*common.PublicKey) error {
+	var rootCAs []*common.Certificate
+	for _, caCert := range caChain {
+		rootCAs = append(rootCAs, &common.Certificate{
+			DerBytes: caCert.Raw,
+		})
+	}
+
+	ds := m.c.Catalog.GetDataStore()
+	if _, err := ds.AppendBundle(ctx, &datastore.AppendBundleRequest{
+		Bundle: &common.Bundle{
+			TrustDomainId:  m.c.TrustDomain.String(),
+			RootCas:        rootCAs,
+			JwtSigningKeys: jwtSigningKeys,
+		},
+	}); err != nil {
+		return err
 	}
 
+	m.bundleUpdated()
 	return nil
 }
 
-func (m *Manager) prepareJWTKey(ctx context.Context, slot *jwtKeySlot) (err error) {
-	counter := telemetry_server.StartServerCAManagerPrepareJWTKeyCall(m.c.Metrics)
-	defer counter.Done(&err)
+// upstreamSignX509CA mints an X509 CA using the upstream authority. When
+// joining the upstream PKI, the stream is kept open to merge updates to the
+// upstream roots into the bundle until the next X509 CA is minted or the
+// manager stops. The stream outlives the caller, so it is not bound to the
+// caller's context.
+func (m *Manager) upstreamSignX509CA(signer crypto.Signer, upstreamAuthority upstreamauthority.UpstreamAuthority) (*X509CA, []*x509.Certificate, error) {
+	streamCtx, cancel := context.WithCancel(context.Background())
+	x509CA, trustBundle, stream, err := mintX509CA(streamCtx, signer, m.c.TrustDomain.Host, m.c.CASubject, upstreamAuthority, m.c.UpstreamBundle, m.c.CATTL)
+	if err != nil || !m.c.UpstreamBundle {
+		cancel()
+		return x509CA, trustBundle, err
+	}
+
+	m.startUpstreamStream(&m.cancelMintX509CA, cancel, func() {
+		m.receiveUpstreamX509Roots(streamCtx, stream)
+	})
+	return x509CA, trustBundle, nil
+}
 
-	log := m.c.Log.WithField(telemetry.Slot, slot.id)
-	log.Debug("Preparing JWT key")
+// resumeUpstreamStreams reopens the streams to the upstream authority for the
+// active X509 CA and JWT key loaded from the journal, since the streams opened
+// when they were prepared did not survive the restart. A new X509 CA is minted
+// for the active key to open the stream, but only the upstream roots are used.
+// Failures are logged, since the loaded authorities can still be used.
+func (m *Manager) resumeUpstreamStreams(ctx context.Context) {
+	upstreamAuthority, ok := m.c.Catalog.GetUpstreamAuthority()
+	if !ok || !m.c.UpstreamBundle {
+		return
+	}
 
-	slot.Reset()
+	m.mu.Lock()
+	defer m.mu.Unlock()
 
-	now := m.c.Clock.Now()
-	notAfter := now.Add(m.c.CATTL)
+	if !m.currentX509CA.IsEmpty() {
+		if err := m.resumeMintX509CAStream(ctx, upstreamAuthority, m.currentX509CA.x509CA.Signer); err != nil {
+			m.c.Log.WithError(err).Error("Unable to reopen upstream X509 roots stream")
+		}
+	}
+	if !m.currentJWTKey.IsEmpty() {
+		if err := m.resumePublishJWTKeyStream(ctx, m.currentJWTKey.jwtKey); err != nil {
+			m.c.Log.WithError(err).Error("Unable to reopen upstream JWT keys stream")
+		}
+	}
+}
 
-	km := m.c.Catalog.GetKeyManager()
-	signer, err := cryptoutil.GenerateKeyAndSigner(ctx, km, slot.KmKeyID(), keymanager.KeyType_EC_P256)
+func (m *Manager) resumeMintX509CAStream(ctx context.Context, upstreamAuthority upstreamauthority.UpstreamAuthority, signer crypto.Signer) error {
+	streamCtx, cancel := context.WithCancel(context.Background())
+	_, trustBundle, stream, err := mintX509CA(streamCtx, signer, m.c.TrustDomain.Host, m.c.CASubject, upstreamAuthority, true, m.c.CATTL)
 	if err != nil {
+		cancel()
 		return err
 	}
-
-	jwtKey, err := newJWTKey(signer, notAfter)
-	if err != nil {
+	// the upstream roots may have changed while the server was down
+	if err := m.appendBundle(ctx, trustBundle, nil); err != nil {
+		cancel()
 		return err
 	}
 
+	m.startUpstreamStream(&m.cancelMintX509CA, cancel, func() {
+		m.receiveUpstreamX509Roots(streamCtx, stream)
+	})
+	return nil
+}
+
+func (m *Manager) resumePublishJWTKeyStream(ctx context.Context, jwtKey *JWTKey) error {
 	publicKey, err := publicKeyFromJWTKey(jwtKey)
 	if err != nil {
 		return err
 	}
-
-	if err := m.appendBundle(ctx, nil, publicKey); err != nil {
+	// publishing the key again is harmless, since the upstream authority
+	// merges it into its bundle
+	upstreamJWTKeys, err := m.publishJWTKey(publicKey)
+	if err != nil {
 		return err
 	}
-
-	slot.issuedAt = now
-	slot.jwtKey = jwtKey
-
-	if err := m.journal.AppendJWTKey(slot.id, slot.issuedAt, slot.jwtKey); err != nil {
-		log.WithError(err).Error("Unable to append JWT key to journal")
+	if len(upstreamJWTKeys) > 0 {
+		return m.appendBundle(ctx, nil, upstreamJWTKeys)
 	}
-
-	m.c.Log.WithFields(logrus.Fields{
-		telemetry.Slot:       slot.id,
-		telemetry.IssuedAt:   timeField(slot.issuedAt),
-		telemetry.Expiration: timeField(slot.jwtKey.NotAfter),
-	}).Info("JWT key prepared")
 	return nil
 }
 
-func (m *Manager) activateJWTKey() {
-	m.c.Log.WithFields(logrus.Fields{
-		telemetry.Slot:       m.currentJWTKey.id,
-		telemetry.IssuedAt:   timeField(m.currentJWTKey.issuedAt),
-		telemetry.Expiration: timeField(m.currentJWTKey.jwtKey.NotAfter),
-	}).Info("JWT key activated")
-	telemetry_server.IncrActivateJWTKeyManagerCounter(m.c.Metrics)
-	m.c.CA.SetJWTKey(m.currentJWTKey.jwtKey)
-}
-
-func (m *Manager) pruneBundleEvery(ctx context.Context, interval time.Duration) error {
-	ticker := m.c.Clock.Ticker(interval)
-	defer ticker.Stop()
-
+func (m *Manager) receiveUpstreamX509Roots(ctx context.Context, stream upstreamauthority.UpstreamAuthority_MintX509CAClient) {
 	for {
-		select {
-		case <-ticker.C:
-			if err := m.pruneBundle(ctx); err != nil {
-				m.c.Log.WithError(err).Error("Could not prune CA certificates")
-			}
-		case <-ctx.Done():
-			return nil
+		resp, err := stream.Recv()
+		if err != nil {
+			m.logUpstreamStreamError(ctx, err, "Upstream X509 roots stream failed")
+			return
+		}
+		roots, err := parseCertificates(resp.UpstreamX509Roots)
+		if err != nil {
+			m.c.Log.WithError(err).Error("Unable to parse upstream X509 roots")
+			continue
+		}
+		if err := m.appendBundle(ctx, roots, nil); err != nil {
+			m.c.Log.WithError(err).Error("Unable to append upstream X509 roots to bundle")
+			continue
 		}
+		m.c.Log.WithField(telemetry.Count, len(roots)).Debug("Upstream X509 roots updated")
 	}
 }
 
-func (m *Manager) pruneBundle(ctx context.Context) (err error) {
-	counter := telemetry_server.StartCAManagerPruneBundleCall(m.c.Metrics)
-	defer counter.Done(&err)
+// publishJWTKey publishes the JWT key to the upstream authority, if one is
+// configured and the upstream PKI is joined, and returns the upstream JWT
+// keys. The stream is kept open to merge updates to the upstream JWT keys
+// into the bundle until the next JWT key is published or the manager stops.
+func (m *Manager) publishJWTKey(publicKey *common.PublicKey) ([]*common.PublicKey, error) {
+	upstreamAuthority, ok := m.c.Catalog.GetUpstreamAuthority()
+	if !ok || !m.c.UpstreamBundle {
+		return nil, nil
+	}
 
-	ds := m.c.Catalog.GetDataStore()
-	expiresBefore := m.c.Clock.Now().Add(-safetyThreshold)
+	streamCtx, cancel := context.WithCancel(context.Background())
+	var resp *upstreamauthority.PublishJWTKeyResponse
+	stream, err := upstreamAuthority.PublishJWTKey(streamCtx, &upstreamauthority.PublishJWTKeyRequest{
+		JwtKey: publicKey,
+	})
+	if err == nil {
+		resp, err = stream.Recv()
+	}
+	switch {
+	case status.Code(err) == codes.Unimplemented:
+		cancel()
+		m.publishJWTKeyWarnOnce.Do(func() {
+			m.c.Log.Warn("Upstream authority does not support publishing JWT keys; JWT-SVIDs will not be trusted outside of this trust domain")
+		})
+		return nil, nil
+	case err != nil:
+		cancel()
+		return nil, errs.New("upstream authority failed to publish JWT key: %v", err)
+	}
 
-	resp, err := ds.PruneBundle(ctx, &datastore.PruneBundleRequest{
-		TrustDomainId: m.c.TrustDomain.String(),
-		ExpiresBefore: expiresBefore.Unix(),
+	m.startUpstreamStream(&m.cancelPublishJWTKey, cancel, func() {
+		m.receiveUpstreamJWTKeys(streamCtx, stream)
 	})
+	return resp.UpstreamJwtKeys, nil
+}
 
-	if err != nil {
-		return fmt.Errorf("unable to prune bundle: %v", err)
+func (m *Manager) receiveUpstreamJWTKeys(ctx context.Context, stream upstreamauthority.UpstreamAuthority_PublishJWTKeyClient) {
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			m.logUpstreamStreamError(ctx, err, "Upstream JWT keys stream failed")
+			return
+		}
+		if err := m.appendBundle(ctx, nil, resp.UpstreamJwtKeys); err != nil {
+			m.c.Log.WithError(err).Error("Unable to append upstream JWT keys to bundle")
+			continue
+		}
+		m.c.Log.WithField(telemetry.Count, len(resp.UpstreamJwtKeys)).Debug("Upstream JWT keys updated")
 	}
+}
 
-	if resp.BundleChanged {
-		telemetry_server.IncrManagerPrunedBundleCounter(m.c.Metrics)
-		m.bundleUpdated()
+// startUpstreamStream cancels the previous stream of the same kind, since
+// only updates from the latest stream are relevant, and starts receiving
+// updates on the new one.
+func (m *Manager) startUpstreamStream(cancelPrevious *context.CancelFunc, cancel context.CancelFunc, receive func()) {
+	m.upstreamMu.Lock()
+	defer m.upstreamMu.Unlock()
+
+	if *cancelPrevious != nil {
+		(*cancelPrevious)()
 	}
+	*cancelPrevious = cancel
 
-	return nil
+	m.upstreamWG.Add(1)
+	go func() {
+		defer m.upstreamWG.Done()
+		receive()
+	}()
 }
 
-func (m *Manager) appendBundle(ctx context.Context, caChain []*x509.Certificate, jwtSigningKey *common.PublicKey) error {
-	var rootCAs []*common.Certificate
-	for _, caCert := range caChain {
-		rootCAs = append(rootCAs, &common.Certificate{
-			DerBytes: caCert.Raw,
-		})
+func (m *Manager) closeUpstreamStreams() {
+	m.upstreamMu.Lock()
+	for _, cancel := range []context.CancelFunc{m.cancelMintX509CA, m.cancelPublishJWTKey} {
+		if cancel != nil {
+			cancel()
+		}
 	}
+	m.cancelMintX509CA = nil
+	m.cancelPublishJWTKey = nil
+	m.upstreamMu.Unlock()
 
-	var jwtSigningKeys []*common.PublicKey
-	if jwtSigningKey != nil {
-		jwtSigningKeys = append(jwtSigningKeys, jwtSigningKey)
-	}
+	m.upstreamWG.Wait()
+}
 
-	ds := m.c.Catalog.GetDataStore()
-	if _, err := ds.AppendBundle(ctx, &datastore.AppendBundleRequest{
-		Bundle: &common.Bundle{
-			TrustDomainId:  m.c.TrustDomain.String(),
-			RootCas:        rootCAs,
-			JwtSigningKeys: jwtSigningKeys,
-		},
-	}); err != nil {
-		return err
+func (m *Manager) logUpstreamStreamError(ctx context.Context, err error, msg string) {
+	// the stream is expected to end when it is replaced or the manager
+	// stops, or when the upstream authority does not send updates
+	if err == io.EOF || ctx.Err() != nil {
+		return
 	}
-
-	m.bundleUpdated()
-	return nil
+	m.c.Log.WithError(err).Error(msg)
 }
 
 func (m *Manager) loadJournal(ctx context.Context) error {
@@ -400,80 +1394,175 @@ func (m *Manager) loadJournal(ctx context.Context) error {
 		return err
 	}
 
+	if m.c.JournalInDataStore {
+		journal, err = m.loadDataStoreJournal(ctx, journal)
+		if err != nil {
+			return err
+		}
+	}
+
 	m.journal = journal
 
 	entries := journal.Entries()
-
-	now := m.c.Clock.Now()
-
 	m.c.Log.WithFields(logrus.Fields{
 		telemetry.X509CAs: len(entries.X509CAs),
 		telemetry.JWTKeys: len(entries.JwtKeys),
 	}).Info("Journal loaded")
 
-	if len(entries.X509CAs) > 0 {
-		m.nextX509CA, err = m.tryLoadX509CASlotFromEntry(ctx, entries.X509CAs[len(entries.X509CAs)-1])
+	return m.loadSlots(ctx)
+}
+
+// loadDataStoreJournal loads the journal shared through the datastore. The
+// shared journal is seeded with the entries of the journal on disk, if any,
+// when no other server has written it yet.
+func (m *Manager) loadDataStoreJournal(ctx context.Context, diskJournal *Journal) (*Journal, error) {
+	journalID := m.c.TrustDomain.String()
+	m.c.Log.WithField(telemetry.TrustDomainID, journalID).Debug("Loading journal from datastore")
+	journal, err := LoadDataStoreJournal(ctx, m.c.Catalog.GetDataStore(), journalID)
+	if err != nil {
+		return nil, err
+	}
+
+	diskEntries := diskJournal.Entries()
+	if len(diskEntries.X509CAs) == 0 && len(diskEntries.JwtKeys) == 0 {
+		return journal, nil
+	}
+	seeded, err := journal.seed(ctx, diskEntries)
+	if err != nil {
+		return nil, err
+	}
+	if seeded {
+		m.c.Log.Info("Seeded datastore journal from journal on disk")
+	}
+	return journal, nil
+}
+
+// syncJournal loads changes made to a journal shared through the datastore
+// by other servers and reloads the slots if they changed.
+func (m *Manager) syncJournal(ctx context.Context) error {
+	if !m.c.JournalInDataStore {
+		return nil
+	}
+
+	changed, err := m.journal.Refresh(ctx)
+	if err != nil {
+		return err
+	}
+	if !changed {
+		return nil
+	}
+
+	m.c.Log.Debug("Journal changed by another server; reloading slots")
+	return m.loadSlots(ctx)
+}
+
+// loadSlots determines the current and next X509 CA and JWT key slots from
+// the journal entries. The current authorities are activated if they are not
+// already active and not within the activation threshold of the next ones.
+func (m *Manager) loadSlots(ctx context.Context) error {
+	entries := m.journal.Entries()
+	now := m.c.Clock.Now()
+
+	currentX509CA, nextX509CA, err := m.x509CASlotsFromEntries(ctx, entries.X509CAs)
+	if err != nil {
+		return err
+	}
+	currentJWTKey, nextJWTKey, err := m.jwtKeySlotsFromEntries(ctx, entries.JwtKeys)
+	if err != nil {
+		return err
+	}
+
+	activateX509CA := !currentX509CA.IsEmpty() && !sameX509CASlot(currentX509CA, m.currentX509CA)
+	m.currentX509CA, m.nextX509CA = currentX509CA, nextX509CA
+	if activateX509CA && !m.currentX509CA.ShouldActivateNext(now) {
+		// activate the X509CA immediately if it is set and not within
+		// activation time of the next X509CA.
+		m.activateX509CA()
+	}
+
+	activateJWTKey := !currentJWTKey.IsEmpty() && !sameJWTKeySlot(currentJWTKey, m.currentJWTKey)
+	m.currentJWTKey, m.nextJWTKey = currentJWTKey, nextJWTKey
+	if activateJWTKey && !m.currentJWTKey.ShouldActivateNext(now) {
+		// activate the JWT key immediately if it is set and not within
+		// activation time of the next JWT key.
+		m.activateJWTKey()
+	}
+
+	return nil
+}
+
+func (m *Manager) x509CASlotsFromEntries(ctx context.Context, entries []*X509CAEntry) (current, next *x509CASlot, err error) {
+	if len(entries) > 0 {
+		last := entries[len(entries)-1]
+		next, err = m.tryLoadX509CASlotFromEntry(ctx, last)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
-		// if the last entry is ok, then consider the next entry
-		if m.nextX509CA != nil && len(entries.X509CAs) > 1 {
-			m.currentX509CA, err = m.tryLoadX509CASlotFromEntry(ctx, entries.X509CAs[len(entries.X509CAs)-2])
-			if err != nil {
-				return err
+		// if the last entry is ok, then consider the most recent entry for
+		// the other slot, unless the last entry was already activated (e.g.
+		// by an operator).
+		if next != nil && last.Status != Status_ACTIVE {
+			for i := len(entries) - 2; i >= 0; i-- {
+				if entries[i].SlotId == last.SlotId {
+					continue
+				}
+				current, err = m.tryLoadX509CASlotFromEntry(ctx, entries[i])
+				if err != nil {
+					return nil, nil, err
+				}
+				break
 			}
 		}
 	}
 	switch {
-	case m.currentX509CA != nil:
+	case current != nil:
 		// both current and next are set
-	case m.nextX509CA != nil:
+	case next != nil:
 		// next is set but not current. swap them and initialize next with an empty slot.
-		m.currentX509CA, m.nextX509CA = m.nextX509CA, newX509CASlot(otherSlotID(m.nextX509CA.id))
+		current, next = next, newX509CASlot(otherSlotID(next.id))
 	default:
 		// neither are set. initialize them with empty slots.
-		m.currentX509CA = newX509CASlot("A")
-		m.nextX509CA = newX509CASlot("B")
-	}
-
-	if !m.currentX509CA.IsEmpty() && !m.currentX509CA.ShouldActivateNext(now) {
-		// activate the X509CA immediately if it is set and not within
-		// activation time of the next X509CA.
-		m.activateX509CA()
+		current = newX509CASlot("A")
+		next = newX509CASlot("B")
 	}
+	return current, next, nil
+}
 
-	if len(entries.JwtKeys) > 0 {
-		m.nextJWTKey, err = m.tryLoadJWTKeySlotFromEntry(ctx, entries.JwtKeys[len(entries.JwtKeys)-1])
+func (m *Manager) jwtKeySlotsFromEntries(ctx context.Context, entries []*JWTKeyEntry) (current, next *jwtKeySlot, err error) {
+	if len(entries) > 0 {
+		last := entries[len(entries)-1]
+		next, err = m.tryLoadJWTKeySlotFromEntry(ctx, last)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
-		// if the last entry is ok, then consider the next entry
-		if m.nextJWTKey != nil && len(entries.JwtKeys) > 1 {
-			m.currentJWTKey, err = m.tryLoadJWTKeySlotFromEntry(ctx, entries.JwtKeys[len(entries.JwtKeys)-2])
-			if err != nil {
-				return err
+		// if the last entry is ok, then consider the most recent entry for
+		// the other slot, unless the last entry was already activated (e.g.
+		// by an operator).
+		if next != nil && last.Status != Status_ACTIVE {
+			for i := len(entries) - 2; i >= 0; i-- {
+				if entries[i].SlotId == last.SlotId {
+					continue
+				}
+				current, err = m.tryLoadJWTKeySlotFromEntry(ctx, entries[i])
+				if err != nil {
+					return nil, nil, err
+				}
+				break
 			}
 		}
 	}
 	switch {
-	case m.currentJWTKey != nil:
+	case current != nil:
 		// both current and next are set
-	case m.nextJWTKey != nil:
+	case next != nil:
 		// next is set but not current. swap them and initialize next with an empty slot.
-		m.currentJWTKey, m.nextJWTKey = m.nextJWTKey, newJWTKeySlot(otherSlotID(m.nextJWTKey.id))
+		current, next = next, newJWTKeySlot(otherSlotID(next.id))
 	default:
 		// neither are set. initialize them with empty slots.
-		m.currentJWTKey = newJWTKeySlot("A")
-		m.nextJWTKey = newJWTKeySlot("B")
-	}
-
-	if !m.currentJWTKey.IsEmpty() && !m.currentJWTKey.ShouldActivateNext(now) {
-		// activate the JWT key immediately if it is set and not within
-		// activation time of the next JWT key.
-		m.activateJWTKey()
+		current = newJWTKeySlot("A")
+		next = newJWTKeySlot("B")
 	}
-
-	return nil
+	return current, next, nil
 }
 
 func (m *Manager) journalPath() string {
@@ -516,7 +1605,11 @@ func (m *Manager) loadX509CASlotFromEntry(ctx context.Context, entry *X509CAEntr
 		upstreamChain = append(upstreamChain, cert)
 	}
 
-	signer, err := m.makeSigner(ctx, x509CAKmKeyId(entry.SlotId))
+	keyID := entry.KeyId
+	if keyID == "" {
+		keyID = x509CAKmKeyId(entry.SlotId)
+	}
+	signer, err := m.makeSigner(ctx, keyID)
 	if err != nil {
 		return nil, "", err
 	}
@@ -566,7 +1659,11 @@ func (m *Manager) loadJWTKeySlotFromEntry(ctx context.Context, entry *JWTKeyEntr
 		return nil, "", errs.Wrap(err)
 	}
 
-	signer, err := m.makeSigner(ctx, jwtKeyKmKeyId(entry.SlotId))
+	keyID := entry.KeyId
+	if keyID == "" {
+		keyID = jwtKeyKmKeyId(entry.SlotId)
+	}
+	signer, err := m.makeSigner(ctx, keyID)
 	if err != nil {
 		return nil, "", err
 	}
@@ -585,6 +1682,7 @@ func (m *Manager) loadJWTKeySlotFromEntry(ctx context.Context, entry *JWTKeyEntr
 			Signer:   signer,
 			NotAfter: time.Unix(entry.NotAfter, 0),
 			Kid:      entry.Kid,
+			Alg:      entry.Alg,
 		},
 	}, "", nil
 }
@@ -825,6 +1923,30 @@ func (s *jwtKeySlot) ShouldActivateNext(now time.Time) bool {
 	return s.jwtKey == nil || now.After(activationThreshold(s.issuedAt, s.jwtKey.NotAfter))
 }
 
+// sameX509CASlot returns true if both slots hold the same X509 CA
+func sameX509CASlot(a, b *x509CASlot) bool {
+	switch {
+	case a == nil || b == nil:
+		return a == b
+	case a.IsEmpty() || b.IsEmpty():
+		return a.IsEmpty() == b.IsEmpty()
+	default:
+		return a.id == b.id && a.x509CA.Certificate.Equal(b.x509CA.Certificate)
+	}
+}
+
+// sameJWTKeySlot returns true if both slots hold the same JWT key
+func sameJWTKeySlot(a, b *jwtKeySlot) bool {
+	switch {
+	case a == nil || b == nil:
+		return a == b
+	case a.IsEmpty() || b.IsEmpty():
+		return a.IsEmpty() == b.IsEmpty()
+	default:
+		return a.id == b.id && a.jwtKey.Kid == b.jwtKey.Kid
+	}
+}
+
 func otherSlotID(id string) string {
 	if id == "A" {
 		return "B"
@@ -847,9 +1969,8 @@ func GenerateServerCACSR(signer crypto.Signer, trustDomain string, subject pkix.
 	}
 
 	template := x509.CertificateRequest{
-		Subject:            subject,
-		SignatureAlgorithm: x509.ECDSAWithSHA256,
-		URIs:               []*url.URL{spiffeID},
+		Subject: subject,
+		URIs:    []*url.URL{spiffeID},
 	}
 
 	csr, err := x509.CreateCertificateRequest(rand.Reader, &template, signer)
@@ -889,22 +2010,49 @@ func SelfSignX509CA(ctx context.Context, signer crypto.Signer, trustDomain strin
 	}, trustBundle, nil
 }
 
-func UpstreamSignX509CA(ctx context.Context, signer crypto.Signer, trustDomain string, subject pkix.Name, upstreamCA upstreamca.UpstreamCA, upstreamBundle bool) (*X509CA, []*x509.Certificate, error) {
+func UpstreamSignX509CA(ctx context.Context, signer crypto.Signer, trustDomain string, subject pkix.Name, upstreamAuthority upstreamauthority.UpstreamAuthority, upstreamBundle bool, preferredTTL time.Duration) (*X509CA, []*x509.Certificate, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	x509CA, trustBundle, _, err := mintX509CA(ctx, signer, trustDomain, subject, upstreamAuthority, upstreamBundle, preferredTTL)
+	return x509CA, trustBundle, err
+}
+
+// mintX509CA mints an X509 CA using the upstream authority. The stream is
+// returned after receiving the first response so the caller can receive
+// updates to the upstream roots. The stream is closed when the context is
+// canceled.
+func mintX509CA(ctx context.Context, signer crypto.Signer, trustDomain string, subject pkix.Name, upstreamAuthority upstreamauthority.UpstreamAuthority, upstreamBundle bool, preferredTTL time.Duration) (*X509CA, []*x509.Certificate, upstreamauthority.UpstreamAuthority_MintX509CAClient, error) {
 	csr, err := GenerateServerCACSR(signer, trustDomain, subject)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
-	resp, err := upstreamCA.SubmitCSR(ctx, &upstreamca.SubmitCSRRequest{
-		Csr: csr,
+	stream, err := upstreamAuthority.MintX509CA(ctx, &upstreamauthority.MintX509CARequest{
+		Csr:          csr,
+		PreferredTtl: int32(preferredTTL / time.Second),
 	})
 	if err != nil {
-		return nil, nil, errs.New("upstream CA failed with %v", err)
+		return nil, nil, nil, errs.New("upstream authority failed with %v", err)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, nil, nil, errs.New("upstream authority failed with %v", err)
 	}
 
-	caChain, trustBundle, err := parseUpstreamCACSRResponse(resp)
+	caChain, err := parseCertificates(resp.X509CaChain)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
+	}
+	if len(caChain) == 0 {
+		return nil, nil, nil, errs.New("upstream authority returned an empty X509 CA chain")
+	}
+	trustBundle, err := parseCertificates(resp.UpstreamX509Roots)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(trustBundle) == 0 {
+		return nil, nil, nil, errs.New("upstream authority returned no upstream X509 roots")
 	}
 
 	var upstreamChain []*x509.Certificate
@@ -912,7 +2060,7 @@ func UpstreamSignX509CA(ctx context.Context, signer crypto.Signer, trustDomain s
 		upstreamChain = caChain
 	} else {
 		// we don't want to join the upstream PKI. Use the server CA as the
-		// root, as if the upstreamCA was never configured.
+		// root, as if the upstream authority was never configured.
 		trustBundle = caChain[:1]
 	}
 
@@ -920,57 +2068,72 @@ func UpstreamSignX509CA(ctx context.Context, signer crypto.Signer, trustDomain s
 		Signer:        signer,
 		Certificate:   caChain[0],
 		UpstreamChain: upstreamChain,
-	}, trustBundle, nil
+	}, trustBundle, stream, nil
 }
 
-func parseUpstreamCACSRResponse(resp *upstreamca.SubmitCSRResponse) ([]*x509.Certificate, []*x509.Certificate, error) {
-	if resp.SignedCertificate != nil {
-		certChain, err := x509.ParseCertificates(resp.SignedCertificate.CertChain)
+func parseCertificates(rawCerts [][]byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for _, rawCert := range rawCerts {
+		cert, err := x509.ParseCertificate(rawCert)
 		if err != nil {
-			return nil, nil, err
-		}
-		if len(certChain) == 0 {
-			return nil, nil, errs.New("upstream CA returned an empty cert chain")
+			return nil, errs.New("unable to parse upstream certificate: %v", err)
 		}
-		trustBundle, err := x509.ParseCertificates(resp.SignedCertificate.Bundle)
+		certs = append(certs, cert)
+	}
+	return certs, nil
+}
+
+// publishedCRL returns the published CRL signed by the given CA, if any.
+func publishedCRL(crls [][]byte, caCert *x509.Certificate) []byte {
+	for _, crlBytes := range crls {
+		crl, err := x509.ParseCRL(crlBytes)
 		if err != nil {
-			return nil, nil, err
+			continue
 		}
-		if len(trustBundle) == 0 {
-			return nil, nil, errs.New("upstream CA returned an empty trust bundle")
+		if caCert.CheckCRLSignature(crl) == nil {
+			return crlBytes
 		}
-		return certChain, trustBundle, nil
+	}
+	return nil
+}
+
+// crlNeedsUpdate returns true if the published CRL does not list exactly the
+// revoked certificates, was not signed by the given CA, or has reached half
+// of its lifetime. A CRL is not published until the first certificate is
+// revoked.
+func crlNeedsUpdate(crlBytes []byte, caCert *x509.Certificate, revoked []pkix.RevokedCertificate, now time.Time) bool {
+	if len(crlBytes) == 0 {
+		return len(revoked) > 0
 	}
 
-	// This is an old response from the upstream CA. The assumption from the
-	// manager was that Cert contained a single certificate representing the
-	// newly signed CA certificate and UpstreamTrustBundle contained the rest
-	// of the full chain back to the upstream "root".
-	cert, err := x509.ParseCertificate(resp.DEPRECATEDCert)
+	crl, err := x509.ParseCRL(crlBytes)
 	if err != nil {
-		return nil, nil, err
+		return true
 	}
-	trustBundle, err := x509.ParseCertificates(resp.DEPRECATEDUpstreamTrustBundle)
-	if err != nil {
-		return nil, nil, err
+	if caCert.CheckCRLSignature(crl) != nil {
+		return true
 	}
 
-	certChain := []*x509.Certificate{cert}
+	thisUpdate := crl.TBSCertList.ThisUpdate
+	nextUpdate := crl.TBSCertList.NextUpdate
+	if !now.Before(thisUpdate.Add(nextUpdate.Sub(thisUpdate) / 2)) {
+		return true
+	}
 
-	switch len(trustBundle) {
-	case 0:
-		return nil, nil, errors.New("upstream CA returned an empty trust bundle")
-	case 1:
-		return certChain, trustBundle, nil
-	default:
-		// append the "intermediates" at the start of the upstream bundle
-		certChain = append(certChain, trustBundle[:len(trustBundle)-1]...)
-		// only consider the "root" of the upstream bundle as part of the
-		// trust bundle
-		trustBundle = trustBundle[len(trustBundle)-1:]
-		return certChain, trustBundle, nil
+	published := crl.TBSCertList.RevokedCertificates
+	if len(published) != len(revoked) {
+		return true
+	}
+	serialNumbers := make(map[string]bool)
+	for _, revokedCert := range published {
+		serialNumbers[revokedCert.SerialNumber.String()] = true
 	}
-	return certChain, trustBundle, nil
+	for _, revokedCert := range revoked {
+		if !serialNumbers[revokedCert.SerialNumber.String()] {
+			return true
+		}
+	}
+	return false
 }
 
 func preparationThreshold(issuedAt, notAfter time.Time) time.Time {
@@ -983,7 +2146,21 @@ func activationThreshold(issuedAt, notAfter time.Time) time.Time {
 	return notAfter.Add(-lifetime / 6)
 }
 
-func newJWTKey(signer crypto.Signer, expiresAt time.Time) (*JWTKey, error) {
+// newJWTKey returns a JWT key signing with the given algorithm, or with the
+// default algorithm for the key type if alg is empty. The algorithm is always
+// recorded so that the bundle can tell verifiers about it.
+func newJWTKey(signer crypto.Signer, expiresAt time.Time, alg string) (*JWTKey, error) {
+	if alg == "" {
+		var err error
+		alg, err = jwtsvid.DefaultAlgorithm(signer.Public())
+		if err != nil {
+			return nil, errs.Wrap(err)
+		}
+	}
+	if err := jwtsvid.ValidateAlgorithm(alg, signer.Public()); err != nil {
+		return nil, errs.Wrap(err)
+	}
+
 	kid, err := newKeyID()
 	if err != nil {
 		return nil, err
@@ -993,6 +2170,7 @@ func newJWTKey(signer crypto.Signer, expiresAt time.Time) (*JWTKey, error) {
 		Signer:   signer,
 		Kid:      kid,
 		NotAfter: expiresAt,
+		Alg:      alg,
 	}, nil
 }
 
@@ -1006,6 +2184,7 @@ func publicKeyFromJWTKey(jwtKey *JWTKey) (*common.PublicKey, error) {
 		PkixBytes: pkixBytes,
 		Kid:       jwtKey.Kid,
 		NotAfter:  jwtKey.NotAfter.Unix(),
+		Alg:       jwtKey.Alg,
 	}, nil
 }
 
@@ -1,8 +1,12 @@
 package ca
 
 import (
+	"bytes"
 	"context"
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"errors"
@@ -17,20 +21,25 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
 	"github.com/spiffe/spire/pkg/server/plugin/keymanager/memory"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/proto/spire/server/datastore"
+	"github.com/spiffe/spire/proto/spire/server/keymanager"
 	"github.com/spiffe/spire/proto/spire/server/notifier"
+	"github.com/spiffe/spire/proto/spire/server/upstreamauthority"
 	"github.com/spiffe/spire/proto/spire/server/upstreamca"
 	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/fakes/fakedatastore"
 	"github.com/spiffe/spire/test/fakes/fakemetrics"
 	"github.com/spiffe/spire/test/fakes/fakenotifier"
 	"github.com/spiffe/spire/test/fakes/fakeservercatalog"
+	"github.com/spiffe/spire/test/fakes/fakeupstreamauthority"
 	"github.com/spiffe/spire/test/fakes/fakeupstreamca"
 	"github.com/spiffe/spire/test/spiretest"
+	"google.golang.org/grpc/codes"
 )
 
 const (
@@ -141,6 +150,81 @@ func (s *ManagerSuite) TestSelfSigning() {
 	s.Empty(x509CA.UpstreamChain)
 }
 
+func (s *ManagerSuite) TestDefaultKeyTypes() {
+	s.initSelfSignedManager()
+
+	x509CAKey, ok := s.currentX509CA().Signer.Public().(*ecdsa.PublicKey)
+	s.Require().True(ok, "expected an EC X509 CA key")
+	s.Require().Equal(elliptic.P384(), x509CAKey.Curve)
+
+	jwtKey, ok := s.currentJWTKey().Signer.Public().(*ecdsa.PublicKey)
+	s.Require().True(ok, "expected an EC JWT key")
+	s.Require().Equal(elliptic.P256(), jwtKey.Curve)
+
+	entries := s.m.journal.Entries()
+	s.Require().Equal(keymanager.KeyType_EC_P384, entries.X509CAs[0].KeyType)
+	s.Require().Equal(keymanager.KeyType_EC_P256, entries.JwtKeys[0].KeyType)
+	s.Require().Equal("ES256", entries.JwtKeys[0].Alg)
+	s.Require().Equal("ES256", s.currentJWTKey().Alg)
+}
+
+func (s *ManagerSuite) TestConfiguredKeyTypes() {
+	c := s.selfSignedConfig()
+	c.X509CAKeyType = keymanager.KeyType_RSA_2048
+	c.JWTKeyType = keymanager.KeyType_EC_P384
+	s.m = NewManager(c)
+	s.Require().NoError(s.m.Initialize(context.Background()))
+
+	x509CA := s.currentX509CA()
+	x509CAKey, ok := x509CA.Signer.Public().(*rsa.PublicKey)
+	s.Require().True(ok, "expected an RSA X509 CA key")
+	s.Require().Equal(2048, x509CAKey.N.BitLen())
+	s.Require().Equal(x509.SHA256WithRSA, x509CA.Certificate.SignatureAlgorithm)
+
+	jwtKey, ok := s.currentJWTKey().Signer.Public().(*ecdsa.PublicKey)
+	s.Require().True(ok, "expected an EC JWT key")
+	s.Require().Equal(elliptic.P384(), jwtKey.Curve)
+
+	entries := s.m.journal.Entries()
+	s.Require().Equal(keymanager.KeyType_RSA_2048, entries.X509CAs[0].KeyType)
+	s.Require().Equal(keymanager.KeyType_EC_P384, entries.JwtKeys[0].KeyType)
+	s.requireBundleJWTKeys(s.currentJWTKey())
+
+	// the slots are reloaded from the journal with the same keys
+	s.m = NewManager(c)
+	s.Require().NoError(s.m.Initialize(context.Background()))
+	s.requireX509CAEqual(x509CA, s.currentX509CA())
+}
+
+func (s *ManagerSuite) TestConfiguredJWTSigningAlgorithm() {
+	c := s.selfSignedConfig()
+	c.JWTKeyType = keymanager.KeyType_RSA_2048
+	c.JWTSigningAlgorithm = "PS256"
+	s.m = NewManager(c)
+	s.Require().NoError(s.m.Initialize(context.Background()))
+
+	jwtKey := s.currentJWTKey()
+	s.Require().Equal("PS256", jwtKey.Alg)
+	s.Require().Equal("PS256", s.m.journal.Entries().JwtKeys[0].Alg)
+
+	// the algorithm is published alongside the key in the bundle
+	bundle := s.fetchBundle()
+	s.Require().Len(bundle.JwtSigningKeys, 1)
+	s.Require().Equal("PS256", bundle.JwtSigningKeys[0].Alg)
+
+	// the algorithm is restored from the journal
+	s.m = NewManager(c)
+	s.Require().NoError(s.m.Initialize(context.Background()))
+	s.requireJWTKeyEqual(jwtKey, s.currentJWTKey())
+}
+
+func (s *ManagerSuite) TestJWTSigningAlgorithmMustMatchKeyType() {
+	c := s.selfSignedConfig()
+	c.JWTSigningAlgorithm = "PS256"
+	s.m = NewManager(c)
+	s.Require().Error(s.m.Initialize(context.Background()))
+}
+
 func (s *ManagerSuite) TestUpstreamSignedWithoutUpstreamBundle() {
 	s.testUpstreamSignedWithoutUpstreamBundle(false)
 }
@@ -231,6 +315,113 @@ func (s *ManagerSuite) testUpstreamIntermediateSignedWithUpstreamBundle(useDepre
 	s.requireBundleRootCAs(upstreamCA.Root())
 }
 
+func (s *ManagerSuite) TestUpstreamAuthorityX509RootsUpdated() {
+	upstreamAuthority := fakeupstreamauthority.New(s.T(), fakeupstreamauthority.Config{
+		TrustDomain: testTrustDomain,
+	})
+	s.initUpstreamAuthorityManager(upstreamAuthority, true)
+	defer s.m.closeUpstreamStreams()
+
+	s.requireBundleRootCAs(upstreamAuthority.Root())
+
+	// updates to the upstream roots are merged into the bundle
+	newRoot := fakeupstreamca.New(s.T(), fakeupstreamca.Config{
+		TrustDomain: testTrustDomain,
+	}).Root()
+	s.m.dropBundleUpdated()
+	upstreamAuthority.AppendX509Root(newRoot)
+	s.waitForBundleUpdated()
+	s.requireBundleRootCAs(upstreamAuthority.Root(), newRoot)
+}
+
+func (s *ManagerSuite) TestUpstreamAuthorityPublishesJWTKey() {
+	upstreamJWTKey := &common.PublicKey{Kid: "UPSTREAM", PkixBytes: []byte("UPSTREAM")}
+	upstreamAuthority := fakeupstreamauthority.New(s.T(), fakeupstreamauthority.Config{
+		TrustDomain:     testTrustDomain,
+		UpstreamJWTKeys: []*common.PublicKey{upstreamJWTKey},
+	})
+	s.initUpstreamAuthorityManager(upstreamAuthority, true)
+	defer s.m.closeUpstreamStreams()
+
+	publicKey, err := publicKeyFromJWTKey(s.currentJWTKey())
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*common.PublicKey{publicKey}, upstreamAuthority.PublishedJWTKeys())
+	s.requireBundleJWTPublicKeys(publicKey, upstreamJWTKey)
+
+	// updates to the upstream JWT keys are merged into the bundle
+	newJWTKey := &common.PublicKey{Kid: "NEW", PkixBytes: []byte("NEW")}
+	s.m.dropBundleUpdated()
+	upstreamAuthority.AppendJWTKey(newJWTKey)
+	s.waitForBundleUpdated()
+	s.requireBundleJWTPublicKeys(publicKey, upstreamJWTKey, newJWTKey)
+}
+
+func (s *ManagerSuite) TestUpstreamAuthorityStreamsResumedAfterRestart() {
+	upstreamAuthority := fakeupstreamauthority.New(s.T(), fakeupstreamauthority.Config{
+		TrustDomain: testTrustDomain,
+	})
+	s.initUpstreamAuthorityManager(upstreamAuthority, true)
+	x509CA := s.currentX509CA()
+	jwtKey := s.currentJWTKey()
+	s.m.closeUpstreamStreams()
+
+	// the restarted manager loads the active authorities from the journal
+	// and reopens the streams for them
+	c := s.selfSignedConfig()
+	c.UpstreamBundle = true
+	s.m = NewManager(c)
+	s.Require().NoError(s.m.Initialize(context.Background()))
+	defer s.m.closeUpstreamStreams()
+	s.Require().Equal(x509CA.Certificate, s.currentX509CA().Certificate)
+	s.Require().Equal(jwtKey.Kid, s.currentJWTKey().Kid)
+
+	publicKey, err := publicKeyFromJWTKey(jwtKey)
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*common.PublicKey{publicKey, publicKey}, upstreamAuthority.PublishedJWTKeys())
+
+	newRoot := fakeupstreamca.New(s.T(), fakeupstreamca.Config{
+		TrustDomain: testTrustDomain,
+	}).Root()
+	s.m.dropBundleUpdated()
+	upstreamAuthority.AppendX509Root(newRoot)
+	s.waitForBundleUpdated()
+	s.requireBundleRootCAs(upstreamAuthority.Root(), newRoot)
+
+	newJWTKey := &common.PublicKey{Kid: "NEW", PkixBytes: []byte("NEW")}
+	s.m.dropBundleUpdated()
+	upstreamAuthority.AppendJWTKey(newJWTKey)
+	s.waitForBundleUpdated()
+	s.requireBundleJWTPublicKeys(publicKey, newJWTKey)
+}
+
+func (s *ManagerSuite) TestUpstreamAuthorityPublishJWTKeyUnimplemented() {
+	upstreamAuthority := fakeupstreamauthority.New(s.T(), fakeupstreamauthority.Config{
+		TrustDomain:           testTrustDomain,
+		DisallowPublishJWTKey: true,
+	})
+	s.initUpstreamAuthorityManager(upstreamAuthority, true)
+	defer s.m.closeUpstreamStreams()
+
+	// the JWT key is still added to the bundle
+	s.requireBundleJWTKeys(s.currentJWTKey())
+	s.Require().Contains(s.logMessages(), "Upstream authority does not support publishing JWT keys; JWT-SVIDs will not be trusted outside of this trust domain")
+}
+
+func (s *ManagerSuite) TestUpstreamAuthorityWithoutUpstreamBundle() {
+	upstreamAuthority := fakeupstreamauthority.New(s.T(), fakeupstreamauthority.Config{
+		TrustDomain: testTrustDomain,
+	})
+	s.initUpstreamAuthorityManager(upstreamAuthority, false)
+	defer s.m.closeUpstreamStreams()
+
+	// the upstream PKI is not joined, so the X509 CA is the root and the
+	// JWT key is not published
+	x509CA := s.currentX509CA()
+	s.requireBundleRootCAs(x509CA.Certificate)
+	s.requireBundleJWTKeys(s.currentJWTKey())
+	s.Require().Empty(upstreamAuthority.PublishedJWTKeys())
+}
+
 func (s *ManagerSuite) TestX509CARotation() {
 	notifier, notifyCh := fakenotifier.NotifyWaiter()
 	s.setNotifier(notifier)
@@ -398,6 +589,179 @@ func (s *ManagerSuite) TestJWTKeyRotation() {
 	s.Nil(s.nextJWTKey())
 }
 
+func (s *ManagerSuite) TestOperatorX509CARotation() {
+	s.initSelfSignedManager()
+	first := s.currentX509CA()
+
+	// activating without a prepared X509 CA fails
+	_, err := s.m.ActivateNextX509CA(ctx)
+	s.RequireGRPCStatus(err, codes.FailedPrecondition, "no prepared X509 CA to activate")
+
+	// preparing ahead of schedule adds the new X509 CA to the bundle
+	info, err := s.m.PrepareNextX509CA(ctx)
+	s.Require().NoError(err)
+	second := s.nextX509CA()
+	s.Require().NotNil(second)
+	s.Require().Equal(SlotInfo{
+		SlotID:      "B",
+		Status:      Status_PREPARED,
+		AuthorityID: bundleutil.X509AuthorityID(second.Certificate),
+		IssuedAt:    s.clock.Now(),
+		NotAfter:    second.Certificate.NotAfter,
+	}, info)
+	s.requireX509CAEqual(first, s.currentX509CA())
+	s.requireBundleRootCAs(first.Certificate, second.Certificate)
+
+	// activating ahead of schedule swaps the slots
+	info, err = s.m.ActivateNextX509CA(ctx)
+	s.Require().NoError(err)
+	s.Require().Equal("B", info.SlotID)
+	s.Require().Equal(Status_ACTIVE, info.Status)
+	s.requireX509CAEqual(second, s.currentX509CA())
+	s.Require().Nil(s.nextX509CA())
+
+	current, next := s.m.X509CASlots()
+	s.Require().Equal(info, current)
+	s.Require().Equal(SlotInfo{SlotID: "A"}, next)
+
+	// the early activation survives a restart
+	s.initSelfSignedManager()
+	s.requireX509CAEqual(second, s.currentX509CA())
+	s.Require().Nil(s.nextX509CA())
+}
+
+func (s *ManagerSuite) TestOperatorJWTKeyRotation() {
+	s.initSelfSignedManager()
+	first := s.currentJWTKey()
+
+	_, err := s.m.ActivateNextJWTKey(ctx)
+	s.RequireGRPCStatus(err, codes.FailedPrecondition, "no prepared JWT key to activate")
+
+	// preparing twice replaces the prepared key
+	_, err = s.m.PrepareNextJWTKey(ctx)
+	s.Require().NoError(err)
+	replaced := s.nextJWTKey()
+	info, err := s.m.PrepareNextJWTKey(ctx)
+	s.Require().NoError(err)
+	second := s.nextJWTKey()
+	s.requireJWTKeyNotEqual(replaced, second)
+	s.Require().Equal(second.Kid, info.AuthorityID)
+	s.requireBundleJWTKeys(first, replaced, second)
+
+	_, err = s.m.ActivateNextJWTKey(ctx)
+	s.Require().NoError(err)
+	s.requireJWTKeyEqual(second, s.currentJWTKey())
+	s.Require().Nil(s.nextJWTKey())
+
+	// the current key is loaded from the latest entry of the other slot
+	// on restart, not the replaced one.
+	s.initSelfSignedManager()
+	s.requireJWTKeyEqual(second, s.currentJWTKey())
+	s.Require().Nil(s.nextJWTKey())
+}
+
+func (s *ManagerSuite) TestTaintX509Authority() {
+	s.initSelfSignedManager()
+	first := s.currentX509CA()
+	firstID := bundleutil.X509AuthorityID(first.Certificate)
+
+	// the active X509 CA cannot be tainted
+	err := s.m.TaintX509Authority(ctx, firstID)
+	s.RequireGRPCStatusContains(err, codes.FailedPrecondition, "is in use by slot")
+
+	// nor can the prepared one
+	_, err = s.m.PrepareNextX509CA(ctx)
+	s.Require().NoError(err)
+	second := s.nextX509CA()
+	err = s.m.TaintX509Authority(ctx, bundleutil.X509AuthorityID(second.Certificate))
+	s.RequireGRPCStatusContains(err, codes.FailedPrecondition, "is in use by slot")
+
+	err = s.m.TaintX509Authority(ctx, "deadbeef")
+	s.RequireGRPCStatus(err, codes.NotFound, `no X509 authority "deadbeef" in the bundle`)
+
+	// once replaced, the old X509 CA can be tainted
+	_, err = s.m.ActivateNextX509CA(ctx)
+	s.Require().NoError(err)
+	s.Require().NoError(s.m.TaintX509Authority(ctx, firstID))
+
+	bundle := s.fetchBundle()
+	s.Require().Len(bundle.RootCas, 2)
+	s.Require().True(bundle.RootCas[0].TaintedKey)
+	s.Require().False(bundle.RootCas[1].TaintedKey)
+
+	// the tainted X509 CA stays in the bundle until the removal delay
+	// has elapsed
+	s.addTimeAndRotate(DefaultTaintedRemovalDelay - time.Minute)
+	s.Require().True(s.bundleHasRootCA(first.Certificate))
+
+	s.addTimeAndRotate(time.Minute)
+	s.Require().False(s.bundleHasRootCA(first.Certificate))
+	s.Require().True(s.bundleHasRootCA(second.Certificate))
+	s.Require().Equal(Status_REMOVED, s.m.journal.Entries().X509CAs[0].Status)
+}
+
+func (s *ManagerSuite) TestTaintX509AuthorityWithUpstreamBundle() {
+	upstreamCA := fakeupstreamca.New(s.T(), fakeupstreamca.Config{
+		TrustDomain: testTrustDomain,
+	})
+	s.cat.SetUpstreamCA(upstreamCA)
+	c := s.selfSignedConfig()
+	c.UpstreamBundle = true
+	c.TaintedRemovalDelay = 10 * time.Minute
+	s.m = NewManager(c)
+	s.Require().NoError(s.m.Initialize(ctx))
+	first := s.currentX509CA()
+
+	_, err := s.m.PrepareNextX509CA(ctx)
+	s.Require().NoError(err)
+	_, err = s.m.ActivateNextX509CA(ctx)
+	s.Require().NoError(err)
+
+	// the intermediate X509 CA is not in the bundle, which only holds the
+	// upstream root, so it is listed as tainted without becoming a root CA
+	authorityID := bundleutil.X509AuthorityID(first.Certificate)
+	s.Require().False(s.bundleHasRootCA(first.Certificate))
+	s.Require().NoError(s.m.TaintX509Authority(ctx, authorityID))
+
+	bundle := s.fetchBundle()
+	s.Require().Equal([]string{authorityID}, bundle.TaintedX509AuthorityIds)
+	s.requireBundleRootCAs(upstreamCA.Root())
+
+	// it is removed once the configured delay has elapsed
+	s.addTimeAndRotate(10*time.Minute - time.Second)
+	s.Require().Equal([]string{authorityID}, s.fetchBundle().TaintedX509AuthorityIds)
+	s.addTimeAndRotate(time.Second)
+	s.Require().Empty(s.fetchBundle().TaintedX509AuthorityIds)
+	s.requireBundleRootCAs(upstreamCA.Root())
+}
+
+func (s *ManagerSuite) TestTaintJWTAuthority() {
+	s.initSelfSignedManager()
+	first := s.currentJWTKey()
+
+	err := s.m.TaintJWTAuthority(ctx, first.Kid)
+	s.RequireGRPCStatusContains(err, codes.FailedPrecondition, "is in use by slot")
+
+	err = s.m.TaintJWTAuthority(ctx, "NOPE")
+	s.RequireGRPCStatus(err, codes.NotFound, `no JWT authority "NOPE" in the bundle`)
+
+	_, err = s.m.PrepareNextJWTKey(ctx)
+	s.Require().NoError(err)
+	second := s.nextJWTKey()
+	_, err = s.m.ActivateNextJWTKey(ctx)
+	s.Require().NoError(err)
+	s.Require().NoError(s.m.TaintJWTAuthority(ctx, first.Kid))
+
+	bundle := s.fetchBundle()
+	s.Require().Len(bundle.JwtSigningKeys, 2)
+	s.Require().True(bundle.JwtSigningKeys[0].TaintedKey)
+
+	s.addTimeAndRotate(DefaultTaintedRemovalDelay)
+	s.Require().False(s.bundleHasJWTKey(first.Kid))
+	s.Require().True(s.bundleHasJWTKey(second.Kid))
+	s.Require().Equal(Status_REMOVED, s.m.journal.Entries().JwtKeys[0].Status)
+}
+
 func (s *ManagerSuite) TestPrune() {
 	notifier, notifyCh := fakenotifier.NotifyWaiter()
 	s.setNotifier(notifier)
@@ -447,6 +811,113 @@ func (s *ManagerSuite) TestPrune() {
 	s.requireBundleJWTKeys(secondJWTKey)
 }
 
+func (s *ManagerSuite) TestPublishCRL() {
+	s.initSelfSignedManager()
+
+	// nothing is published until a certificate is revoked
+	s.Require().NoError(s.m.publishCRL(ctx))
+	s.Require().Empty(s.fetchBundle().Crls)
+
+	now := s.clock.Now()
+	_, err := s.ds.RevokeCertificate(ctx, &datastore.RevokeCertificateRequest{
+		RevokedCertificate: &datastore.RevokedCertificate{
+			SerialNumber: "1234",
+			ExpiresAt:    now.Add(crlTTL).Unix(),
+			RevokedAt:    now.Unix(),
+		},
+	})
+	s.Require().NoError(err)
+	s.Require().NoError(s.m.publishCRL(ctx))
+	firstX509CA := s.currentX509CA()
+	crls := s.fetchBundle().Crls
+	s.requireCRLs(crls, []*X509CA{firstX509CA}, "1234")
+
+	// the CRL is not re-signed if nothing changed
+	s.clock.Add(time.Minute)
+	s.Require().NoError(s.m.publishCRL(ctx))
+	s.Require().Equal(crls, s.fetchBundle().Crls)
+
+	// when the X509 CA rotates, a CRL is published for the new X509 CA and
+	// the CRL of the previous X509 CA, which issued SVIDs that are still
+	// valid, is kept.
+	_, err = s.m.PrepareNextX509CA(ctx)
+	s.Require().NoError(err)
+	_, err = s.m.ActivateNextX509CA(ctx)
+	s.Require().NoError(err)
+	secondX509CA := s.currentX509CA()
+	s.Require().NoError(s.m.publishCRL(ctx))
+	s.requireCRLs(s.fetchBundle().Crls, []*X509CA{secondX509CA, firstX509CA}, "1234")
+	s.Require().Equal(crls[0], s.fetchBundle().Crls[1])
+
+	// the CRL of the previous X509 CA is dropped once its KeyManager key is
+	// replaced by the next X509 CA
+	_, err = s.m.PrepareNextX509CA(ctx)
+	s.Require().NoError(err)
+	s.Require().NoError(s.m.publishCRL(ctx))
+	s.requireCRLs(s.fetchBundle().Crls, []*X509CA{secondX509CA}, "1234")
+	crls = s.fetchBundle().Crls
+
+	// the CRL is re-signed at half of its lifetime
+	s.clock.Add(crlTTL / 2)
+	s.Require().NoError(s.m.publishCRL(ctx))
+	s.Require().NotEqual(crls, s.fetchBundle().Crls)
+	s.requireCRLs(s.fetchBundle().Crls, []*X509CA{secondX509CA}, "1234")
+
+	// revocations are pruned once the revoked certificate expires
+	s.clock.Add(crlTTL / 2)
+	s.Require().NoError(s.m.publishCRL(ctx))
+	s.requireCRLs(s.fetchBundle().Crls, []*X509CA{secondX509CA})
+	resp, err := s.ds.ListRevokedCertificates(ctx, &datastore.ListRevokedCertificatesRequest{})
+	s.Require().NoError(err)
+	s.Require().Empty(resp.RevokedCertificates)
+}
+
+func (s *ManagerSuite) TestPublishCRLPerIssuer() {
+	s.initSelfSignedManager()
+	firstX509CA := s.currentX509CA()
+	_, err := s.m.PrepareNextX509CA(ctx)
+	s.Require().NoError(err)
+	_, err = s.m.ActivateNextX509CA(ctx)
+	s.Require().NoError(err)
+	secondX509CA := s.currentX509CA()
+
+	now := s.clock.Now()
+	issue := func(serialNumber string, x509CA *X509CA) {
+		_, err := s.ds.CreateIssuedSVID(ctx, &datastore.CreateIssuedSVIDRequest{
+			IssuedSvid: &datastore.IssuedSVID{
+				Id:          serialNumber,
+				Type:        datastore.IssuedSVID_X509_SVID,
+				AuthorityId: bundleutil.X509AuthorityID(x509CA.Certificate),
+				NotBefore:   now.Unix(),
+				NotAfter:    now.Add(crlTTL).Unix(),
+			},
+		})
+		s.Require().NoError(err)
+	}
+	issue("1111", firstX509CA)
+	issue("2222", secondX509CA)
+
+	// "3333" has no issued SVID record so its issuer is unknown
+	for _, serialNumber := range []string{"1111", "2222", "3333"} {
+		_, err := s.ds.RevokeCertificate(ctx, &datastore.RevokeCertificateRequest{
+			RevokedCertificate: &datastore.RevokedCertificate{
+				SerialNumber: serialNumber,
+				ExpiresAt:    now.Add(crlTTL).Unix(),
+				RevokedAt:    now.Unix(),
+			},
+		})
+		s.Require().NoError(err)
+	}
+
+	// each CRL only lists the certificates revoked from its issuer, plus the
+	// ones whose issuer is unknown
+	s.Require().NoError(s.m.publishCRL(ctx))
+	crls := s.fetchBundle().Crls
+	s.Require().Len(crls, 2)
+	s.requireCRLs(crls[:1], []*X509CA{secondX509CA}, "2222", "3333")
+	s.requireCRLs(crls[1:], []*X509CA{firstX509CA}, "1111", "3333")
+}
+
 func (s *ManagerSuite) TestMigration() {
 	// assert that we migrate on load by writing junk data to the old JSON file
 	// and making sure initialization fails. The journal tests exercise this
@@ -506,6 +977,189 @@ func (s *ManagerSuite) TestRunFailsIfNotifierFails() {
 	s.Equal("Notifier failed to handle event", entry.Message)
 }
 
+func (s *ManagerSuite) TestSharedJournal() {
+	s.initSharedManager()
+	peerCA := new(fakeCA)
+	peer := s.newSharedPeer(peerCA)
+	s.Require().NoError(peer.Initialize(ctx))
+
+	// the peer uses the authorities prepared by the first server
+	first := s.currentX509CA()
+	firstJWTKey := s.currentJWTKey()
+	s.requireX509CAEqual(first, peerCA.X509CA())
+	s.requireJWTKeyEqual(firstJWTKey, peerCA.JWTKey())
+	s.requireBundleRootCAs(first.Certificate)
+	s.requireBundleJWTKeys(firstJWTKey)
+
+	// the next authorities are prepared only once
+	s.addTimeAndRotate(prepareAfter + time.Minute)
+	s.Require().NoError(peer.rotate(ctx))
+	second := s.nextX509CA()
+	secondJWTKey := s.nextJWTKey()
+	s.Require().NotNil(second)
+	s.Require().NotNil(secondJWTKey)
+	s.requireX509CAEqual(second, peer.nextX509CA.x509CA)
+	s.requireJWTKeyEqual(secondJWTKey, peer.nextJWTKey.jwtKey)
+	s.requireBundleRootCAs(first.Certificate, second.Certificate)
+	s.requireBundleJWTKeys(firstJWTKey, secondJWTKey)
+
+	// and both servers activate them
+	s.addTimeAndRotate(activateAfter - prepareAfter)
+	s.Require().NoError(peer.rotate(ctx))
+	s.requireX509CAEqual(second, s.currentX509CA())
+	s.requireX509CAEqual(second, peerCA.X509CA())
+	s.requireJWTKeyEqual(secondJWTKey, s.currentJWTKey())
+	s.requireJWTKeyEqual(secondJWTKey, peerCA.JWTKey())
+
+	// the journal entries record the KeyManager key IDs
+	entries := s.m.journal.Entries()
+	s.Require().Equal("x509-CA-B", entries.X509CAs[len(entries.X509CAs)-1].KeyId)
+	s.Require().Equal("JWT-Signer-B", entries.JwtKeys[len(entries.JwtKeys)-1].KeyId)
+}
+
+func (s *ManagerSuite) TestSharedJournalOperatorRotation() {
+	s.initSharedManager()
+	peerCA := new(fakeCA)
+	peer := s.newSharedPeer(peerCA)
+	s.Require().NoError(peer.Initialize(ctx))
+
+	_, err := s.m.PrepareNextX509CA(ctx)
+	s.Require().NoError(err)
+	_, err = s.m.ActivateNextX509CA(ctx)
+	s.Require().NoError(err)
+	second := s.currentX509CA()
+
+	// the peer picks up the activation on the next rotation
+	s.Require().NoError(peer.rotate(ctx))
+	s.requireX509CAEqual(second, peerCA.X509CA())
+	s.Require().True(peer.nextX509CA.IsEmpty())
+
+	// operator calls on the peer see the latest journal right away
+	_, err = peer.ActivateNextX509CA(ctx)
+	s.RequireGRPCStatus(err, codes.FailedPrecondition, "no prepared X509 CA to activate")
+}
+
+func (s *ManagerSuite) TestSharedJournalPreparationClaim() {
+	s.initSharedManager()
+
+	journal, err := LoadDataStoreJournal(ctx, s.ds, testTrustDomainURL.String())
+	s.Require().NoError(err)
+	s.Require().NoError(journal.ClaimX509CAPreparation(ctx, "B", s.clock.Now(), prepareAfter+2*time.Minute))
+
+	// another server is preparing the next X509 CA
+	_, err = s.m.PrepareNextX509CA(ctx)
+	s.RequireGRPCStatus(err, codes.Aborted, "another server is preparing the next X509 CA")
+	s.Require().Nil(s.nextX509CA())
+
+	// scheduled preparation waits on the claim as well
+	s.addTimeAndRotate(prepareAfter + time.Minute)
+	s.Require().Nil(s.nextX509CA())
+	s.Require().NotNil(s.nextJWTKey())
+
+	// until the claim expires
+	s.addTimeAndRotate(time.Minute)
+	s.Require().NotNil(s.nextX509CA())
+}
+
+func (s *ManagerSuite) TestSharedJournalInitializeWaitsOnPreparation() {
+	journal, err := LoadDataStoreJournal(ctx, s.ds, testTrustDomainURL.String())
+	s.Require().NoError(err)
+	s.Require().NoError(journal.ClaimX509CAPreparation(ctx, "A", s.clock.Now(), preparationClaimTTL))
+
+	c := s.selfSignedConfig()
+	c.JournalInDataStore = true
+	s.m = NewManager(c)
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.m.Initialize(ctx)
+	}()
+
+	s.clock.WaitForAfter(time.Minute, "waiting for initialization to poll the journal")
+	s.Require().NoError(journal.ReleaseX509CAPreparation(ctx))
+	s.clock.Add(journalPollInterval)
+
+	select {
+	case err := <-errCh:
+		s.Require().NoError(err)
+	case <-time.After(time.Minute):
+		s.FailNow("timed out waiting for initialization")
+	}
+	s.Require().NotNil(s.currentX509CA())
+	s.Require().NotNil(s.currentJWTKey())
+}
+
+func (s *ManagerSuite) TestSharedJournalConcurrentInitialize() {
+	caA := new(fakeCA)
+	caB := new(fakeCA)
+	peers := []*Manager{s.newSharedPeer(caA), s.newSharedPeer(caB)}
+
+	errCh := make(chan error, len(peers))
+	for _, peer := range peers {
+		go func(peer *Manager) {
+			errCh <- peer.Initialize(ctx)
+		}(peer)
+	}
+
+	// the server that loses the race to create or claim the journal either
+	// reloads it or polls until the winner is done preparing
+	timeout := time.After(time.Minute)
+	for done := 0; done < len(peers); {
+		select {
+		case err := <-errCh:
+			s.Require().NoError(err)
+			done++
+		case <-time.After(10 * time.Millisecond):
+			s.clock.Add(journalPollInterval)
+		case <-timeout:
+			s.FailNow("timed out waiting for initialization")
+		}
+	}
+
+	// both servers use the same authorities
+	s.Require().NotNil(caA.X509CA())
+	s.Require().NotNil(caA.JWTKey())
+	s.requireX509CAEqual(caA.X509CA(), caB.X509CA())
+	s.requireJWTKeyEqual(caA.JWTKey(), caB.JWTKey())
+	s.requireBundleRootCAs(caA.X509CA().Certificate)
+}
+
+func (s *ManagerSuite) TestSharedJournalFollower() {
+	s.initSharedManager()
+	peerCA := new(fakeCA)
+	peer := s.newSharedPeer(peerCA)
+	peer.c.IsLeader = func() bool { return false }
+	s.Require().NoError(peer.Initialize(ctx))
+	first := s.currentX509CA()
+
+	// the follower does not prepare the next authorities
+	s.clock.Add(prepareAfter + time.Minute)
+	peer.followJournal(ctx)
+	s.Require().True(peer.nextX509CA.IsEmpty())
+	s.Require().True(peer.nextJWTKey.IsEmpty())
+	s.requireX509CAEqual(first, peerCA.X509CA())
+
+	// but picks up the authorities prepared and activated by the leader
+	s.Require().NoError(s.m.rotate(ctx))
+	second := s.nextX509CA()
+	peer.followJournal(ctx)
+	s.requireX509CAEqual(second, peer.nextX509CA.x509CA)
+
+	s.addTimeAndRotate(activateAfter - prepareAfter)
+	peer.followJournal(ctx)
+	s.requireX509CAEqual(second, peerCA.X509CA())
+	s.requireJWTKeyEqual(s.currentJWTKey(), peerCA.JWTKey())
+}
+
+func (s *ManagerSuite) TestSharedJournalSeededFromDisk() {
+	s.initSelfSignedManager()
+	x509CA, jwtKey := s.currentX509CA(), s.currentJWTKey()
+
+	s.initSharedManager()
+	s.requireX509CAEqual(x509CA, s.currentX509CA())
+	s.requireJWTKeyEqual(jwtKey, s.currentJWTKey())
+}
+
 func (s *ManagerSuite) initSelfSignedManager() {
 	s.cat.SetUpstreamCA(nil)
 	s.m = NewManager(s.selfSignedConfig())
@@ -521,6 +1175,32 @@ func (s *ManagerSuite) initUpstreamSignedManager(upstreamCA upstreamca.UpstreamC
 	s.NoError(s.m.Initialize(context.Background()))
 }
 
+func (s *ManagerSuite) initUpstreamAuthorityManager(upstreamAuthority upstreamauthority.UpstreamAuthority, upstreamBundle bool) {
+	s.cat.SetUpstreamAuthority(upstreamAuthority)
+
+	c := s.selfSignedConfig()
+	c.UpstreamBundle = upstreamBundle
+	s.m = NewManager(c)
+	s.NoError(s.m.Initialize(context.Background()))
+}
+
+func (s *ManagerSuite) initSharedManager() {
+	c := s.selfSignedConfig()
+	c.JournalInDataStore = true
+	s.m = NewManager(c)
+	s.NoError(s.m.Initialize(context.Background()))
+}
+
+// newSharedPeer returns a manager for another server sharing the datastore
+// and KeyManager.
+func (s *ManagerSuite) newSharedPeer(ca *fakeCA) *Manager {
+	c := s.selfSignedConfig()
+	c.CA = ca
+	c.Dir = s.TempDir()
+	c.JournalInDataStore = true
+	return NewManager(c)
+}
+
 func (s *ManagerSuite) setNotifier(notifier notifier.Notifier) {
 	s.cat.AddNotifier(fakeservercatalog.Notifier("fake", notifier))
 }
@@ -566,6 +1246,7 @@ type x509CAInfo struct {
 type jwtKeyInfo struct {
 	Signer   signerInfo
 	Kid      string
+	Alg      string
 	NotAfter time.Time
 }
 
@@ -586,6 +1267,7 @@ func (s *ManagerSuite) getJWTKeyInfo(jwtKey *JWTKey) jwtKeyInfo {
 	return jwtKeyInfo{
 		Signer:   s.getSignerInfo(jwtKey.Signer),
 		Kid:      jwtKey.Kid,
+		Alg:      jwtKey.Alg,
 		NotAfter: jwtKey.NotAfter,
 	}
 }
@@ -630,6 +1312,63 @@ func (s *ManagerSuite) requireBundleJWTKeys(jwtKeys ...*JWTKey) {
 	})
 }
 
+func (s *ManagerSuite) requireBundleJWTPublicKeys(publicKeys ...*common.PublicKey) {
+	bundle := s.fetchBundle()
+	s.RequireProtoEqual(&common.Bundle{
+		JwtSigningKeys: publicKeys,
+	}, &common.Bundle{
+		JwtSigningKeys: bundle.JwtSigningKeys,
+	})
+}
+
+func (s *ManagerSuite) waitForBundleUpdated() {
+	select {
+	case <-s.m.bundleUpdatedCh:
+	case <-time.After(time.Minute):
+		s.FailNow("timed out waiting for the bundle to be updated")
+	}
+}
+
+func (s *ManagerSuite) logMessages() []string {
+	var messages []string
+	for _, entry := range s.logHook.AllEntries() {
+		messages = append(messages, entry.Message)
+	}
+	return messages
+}
+
+func (s *ManagerSuite) bundleHasRootCA(rootCA *x509.Certificate) bool {
+	for _, certificate := range s.fetchBundle().RootCas {
+		if bytes.Equal(certificate.DerBytes, rootCA.Raw) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *ManagerSuite) bundleHasJWTKey(kid string) bool {
+	for _, jwtSigningKey := range s.fetchBundle().JwtSigningKeys {
+		if jwtSigningKey.Kid == kid {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *ManagerSuite) requireCRLs(crls [][]byte, x509CAs []*X509CA, serialNumbers ...string) {
+	s.Require().Len(crls, len(x509CAs))
+	for i, x509CA := range x509CAs {
+		crl, err := x509.ParseCRL(crls[i])
+		s.Require().NoError(err)
+		s.Require().NoError(x509CA.Certificate.CheckCRLSignature(crl))
+		var actual []string
+		for _, revoked := range crl.TBSCertList.RevokedCertificates {
+			actual = append(actual, revoked.SerialNumber.String())
+		}
+		s.Require().Equal(serialNumbers, actual)
+	}
+}
+
 func (s *ManagerSuite) fetchBundle() *common.Bundle {
 	return s.fetchBundleForTrustDomain(testTrustDomainURL.String())
 }
@@ -4,12 +4,40 @@ import (
 	"crypto"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"errors"
+	"fmt"
 	"math/big"
 	"net/url"
+	"strings"
 	"time"
 
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/x509util"
+	"github.com/spiffe/spire/proto/spire/common"
+)
+
+var (
+	// extraKeyUsages are the key usages a registration entry can add to
+	// its X509-SVIDs
+	extraKeyUsages = map[string]x509.KeyUsage{
+		"content_commitment": x509.KeyUsageContentCommitment,
+		"data_encipherment":  x509.KeyUsageDataEncipherment,
+	}
+
+	// caKeyUsages are the key usages that are never allowed on X509-SVIDs
+	// signed for registration entries
+	caKeyUsages = map[string]bool{
+		"cert_sign": true,
+		"crl_sign":  true,
+	}
+
+	// extraExtKeyUsages are the extended key usages a registration entry can
+	// add to its X509-SVIDs
+	extraExtKeyUsages = map[string]x509.ExtKeyUsage{
+		"code_signing":     x509.ExtKeyUsageCodeSigning,
+		"email_protection": x509.ExtKeyUsageEmailProtection,
+		"time_stamping":    x509.ExtKeyUsageTimeStamping,
+	}
 )
 
 func CreateServerCATemplate(spiffeID string, publicKey crypto.PublicKey, trustDomain string, notBefore, notAfter time.Time, serialNumber *big.Int, subject pkix.Name) (*x509.Certificate, error) {
@@ -73,3 +101,74 @@ func CreateX509SVIDTemplate(spiffeID string, publicKey crypto.PublicKey, trustDo
 		PublicKey:             publicKey,
 	}, nil
 }
+
+// ValidateX509SVIDTemplate validates the X509-SVID template of a registration
+// entry. Templates cannot make the SVID a CA.
+func ValidateX509SVIDTemplate(template *common.X509SVIDTemplate) error {
+	if template == nil {
+		return nil
+	}
+	if subject := template.Subject; subject != nil {
+		for _, values := range [][]string{
+			subject.Country,
+			subject.Organization,
+			subject.OrganizationalUnit,
+			subject.Locality,
+			subject.Province,
+		} {
+			for _, value := range values {
+				if strings.TrimSpace(value) == "" {
+					return errors.New("subject attributes cannot be empty")
+				}
+			}
+		}
+	}
+	for _, keyUsage := range template.ExtraKeyUsages {
+		if caKeyUsages[keyUsage] {
+			return fmt.Errorf("key usage %q is reserved for CA certificates", keyUsage)
+		}
+		if _, ok := extraKeyUsages[keyUsage]; !ok {
+			return fmt.Errorf("unsupported key usage %q", keyUsage)
+		}
+	}
+	for _, extKeyUsage := range template.ExtraExtKeyUsages {
+		if _, ok := extraExtKeyUsages[extKeyUsage]; !ok {
+			return fmt.Errorf("unsupported extended key usage %q", extKeyUsage)
+		}
+	}
+	return nil
+}
+
+// applyX509SVIDTemplate validates the X509-SVID template of a registration
+// entry and applies it to the certificate template.
+func applyX509SVIDTemplate(cert *x509.Certificate, template *common.X509SVIDTemplate) error {
+	if template == nil {
+		return nil
+	}
+	if err := ValidateX509SVIDTemplate(template); err != nil {
+		return fmt.Errorf("invalid X509-SVID template: %v", err)
+	}
+
+	if subject := template.Subject; subject != nil {
+		commonName := cert.Subject.CommonName
+		if subject.CommonName != "" {
+			commonName = subject.CommonName
+		}
+		cert.Subject = pkix.Name{
+			Country:            subject.Country,
+			Organization:       subject.Organization,
+			OrganizationalUnit: subject.OrganizationalUnit,
+			Locality:           subject.Locality,
+			Province:           subject.Province,
+			CommonName:         commonName,
+		}
+	}
+
+	for _, keyUsage := range template.ExtraKeyUsages {
+		cert.KeyUsage |= extraKeyUsages[keyUsage]
+	}
+	for _, extKeyUsage := range template.ExtraExtKeyUsages {
+		cert.ExtKeyUsage = append(cert.ExtKeyUsage, extraExtKeyUsages[extKeyUsage])
+	}
+	return nil
+}
@@ -2,12 +2,15 @@ package catalog
 
 import (
 	"context"
+	"errors"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	ds_sql "github.com/spiffe/spire/pkg/server/plugin/datastore/sql"
 	km_disk "github.com/spiffe/spire/pkg/server/plugin/keymanager/disk"
 	km_memory "github.com/spiffe/spire/pkg/server/plugin/keymanager/memory"
+	km_pkcs11 "github.com/spiffe/spire/pkg/server/plugin/keymanager/pkcs11"
+	km_vault "github.com/spiffe/spire/pkg/server/plugin/keymanager/vault"
 	na_aws_iid "github.com/spiffe/spire/pkg/server/plugin/nodeattestor/aws"
 	na_azure_msi "github.com/spiffe/spire/pkg/server/plugin/nodeattestor/azure"
 	na_gcp_iit "github.com/spiffe/spire/pkg/server/plugin/nodeattestor/gcp"
@@ -20,9 +23,11 @@ import (
 	nr_azure_msi "github.com/spiffe/spire/pkg/server/plugin/noderesolver/azure"
 	nr_noop "github.com/spiffe/spire/pkg/server/plugin/noderesolver/noop"
 	no_k8sbundle "github.com/spiffe/spire/pkg/server/plugin/notifier/k8sbundle"
+	up_wrapper "github.com/spiffe/spire/pkg/server/plugin/upstreamauthority"
 	up_awssecret "github.com/spiffe/spire/pkg/server/plugin/upstreamca/awssecret"
 	up_disk "github.com/spiffe/spire/pkg/server/plugin/upstreamca/disk"
 	up_spire "github.com/spiffe/spire/pkg/server/plugin/upstreamca/spire"
+	up_vault "github.com/spiffe/spire/pkg/server/plugin/upstreamca/vault"
 	common_services "github.com/spiffe/spire/proto/spire/common/hostservices"
 	"github.com/spiffe/spire/proto/spire/server/datastore"
 	"github.com/spiffe/spire/proto/spire/server/hostservices"
@@ -30,6 +35,7 @@ import (
 	"github.com/spiffe/spire/proto/spire/server/nodeattestor"
 	"github.com/spiffe/spire/proto/spire/server/noderesolver"
 	"github.com/spiffe/spire/proto/spire/server/notifier"
+	"github.com/spiffe/spire/proto/spire/server/upstreamauthority"
 	"github.com/spiffe/spire/proto/spire/server/upstreamca"
 )
 
@@ -37,7 +43,7 @@ type Catalog interface {
 	GetDataStore() datastore.DataStore
 	GetNodeAttestorNamed(name string) (nodeattestor.NodeAttestor, bool)
 	GetNodeResolverNamed(name string) (noderesolver.NodeResolver, bool)
-	GetUpstreamCA() (upstreamca.UpstreamCA, bool)
+	GetUpstreamAuthority() (upstreamauthority.UpstreamAuthority, bool)
 	GetKeyManager() keymanager.KeyManager
 	GetNotifiers() []Notifier
 }
@@ -57,6 +63,7 @@ func KnownPlugins() []catalog.PluginClient {
 		nodeattestor.PluginClient,
 		noderesolver.PluginClient,
 		upstreamca.PluginClient,
+		upstreamauthority.PluginClient,
 		keymanager.PluginClient,
 		notifier.PluginClient,
 	}
@@ -87,9 +94,14 @@ func BuiltIns() []catalog.Plugin {
 		up_disk.BuiltIn(),
 		up_awssecret.BuiltIn(),
 		up_spire.BuiltIn(),
+		up_vault.BuiltIn(),
+		// UpstreamAuthorities
+		up_spire.BuiltInUpstreamAuthority(),
 		// KeyManagers
 		km_disk.BuiltIn(),
 		km_memory.BuiltIn(),
+		km_pkcs11.BuiltIn(),
+		km_vault.BuiltIn(),
 		// Notifiers
 		no_k8sbundle.BuiltIn(),
 	}
@@ -101,12 +113,13 @@ type Notifier struct {
 }
 
 type Plugins struct {
-	DataStore     datastore.DataStore
-	NodeAttestors map[string]nodeattestor.NodeAttestor
-	NodeResolvers map[string]noderesolver.NodeResolver
-	UpstreamCA    *upstreamca.UpstreamCA
-	KeyManager    keymanager.KeyManager
-	Notifiers     []Notifier
+	DataStore         datastore.DataStore
+	NodeAttestors     map[string]nodeattestor.NodeAttestor
+	NodeResolvers     map[string]noderesolver.NodeResolver
+	UpstreamCA        *upstreamca.UpstreamCA
+	UpstreamAuthority *upstreamauthority.UpstreamAuthority
+	KeyManager        keymanager.KeyManager
+	Notifiers         []Notifier
 }
 
 var _ Catalog = (*Plugins)(nil)
@@ -125,9 +138,15 @@ func (p *Plugins) GetNodeResolverNamed(name string) (noderesolver.NodeResolver,
 	return n, ok
 }
 
-func (p *Plugins) GetUpstreamCA() (upstreamca.UpstreamCA, bool) {
-	if p.UpstreamCA != nil {
-		return *p.UpstreamCA, true
+// GetUpstreamAuthority returns the UpstreamAuthority plugin, if configured.
+// A configured UpstreamCA plugin is adapted to the UpstreamAuthority
+// interface.
+func (p *Plugins) GetUpstreamAuthority() (upstreamauthority.UpstreamAuthority, bool) {
+	switch {
+	case p.UpstreamAuthority != nil:
+		return *p.UpstreamAuthority, true
+	case p.UpstreamCA != nil:
+		return up_wrapper.Wrap(*p.UpstreamCA), true
 	}
 	return nil, false
 }
@@ -173,6 +192,10 @@ func Load(ctx context.Context, config Config) (*CatalogCloser, error) {
 	if err != nil {
 		return nil, err
 	}
+	if p.UpstreamCA != nil && p.UpstreamAuthority != nil {
+		closer.Close()
+		return nil, errors.New("only one of UpstreamCA or UpstreamAuthority plugins may be configured")
+	}
 	return &CatalogCloser{
 		Catalog: p,
 		Closer:  closer,
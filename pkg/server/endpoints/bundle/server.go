@@ -5,6 +5,7 @@ import (
 	"crypto"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/pem"
 	"net"
 	"net/http"
 
@@ -90,11 +91,18 @@ func (s *Server) serveHTTP(w http.ResponseWriter, req *http.Request) {
 		http.Error(w, "405 method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
-	if req.URL.Path != "/" {
+
+	switch req.URL.Path {
+	case "/":
+		s.serveBundle(w, req)
+	case "/crl":
+		s.serveCRL(w, req)
+	default:
 		http.NotFound(w, req)
-		return
 	}
+}
 
+func (s *Server) serveBundle(w http.ResponseWriter, req *http.Request) {
 	b, err := s.c.BundleGetter.GetBundle(req.Context())
 	if err != nil {
 		s.c.Log.WithError(err).Error("unable to retrieve local bundle")
@@ -120,6 +128,33 @@ func (s *Server) serveHTTP(w http.ResponseWriter, req *http.Request) {
 	w.Write(jsonBytes)
 }
 
+// serveCRL serves the CRLs published with the local bundle, one per issuing
+// X509 CA, as PEM encoded "X509 CRL" blocks
+func (s *Server) serveCRL(w http.ResponseWriter, req *http.Request) {
+	b, err := s.c.BundleGetter.GetBundle(req.Context())
+	if err != nil {
+		s.c.Log.WithError(err).Error("unable to retrieve local bundle")
+		http.Error(w, "500 unable to retrieve local bundle", http.StatusInternalServerError)
+		return
+	}
+
+	crls := b.CRLs()
+	if len(crls) == 0 {
+		http.NotFound(w, req)
+		return
+	}
+
+	var pemBytes []byte
+	for _, crl := range crls {
+		pemBytes = append(pemBytes, pem.EncodeToMemory(&pem.Block{
+			Type:  "X509 CRL",
+			Bytes: crl,
+		})...)
+	}
+	w.Header().Set("Content-Type", "application/x-pem-file")
+	w.Write(pemBytes)
+}
+
 func (s *Server) getCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
 	chain, privateKey, err := s.c.CredsGetter.GetServerCreds()
 	if err != nil {
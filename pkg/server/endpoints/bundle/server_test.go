@@ -36,6 +36,14 @@ func TestServer(t *testing.T) {
 	bundle := bundleutil.New("spiffe://domain.test")
 	bundle.AppendRootCA(serverCert)
 
+	// a bundle with published CRLs. the contents are opaque to the server.
+	bundleProto := bundle.Proto()
+	bundleProto.Crls = [][]byte{[]byte("CRL 1"), []byte("CRL 2")}
+	bundleWithCRL, err := bundleutil.BundleFromProto(bundleProto)
+	require.NoError(t, err)
+	crlPEM := "-----BEGIN X509 CRL-----\nQ1JMIDE=\n-----END X509 CRL-----\n" +
+		"-----BEGIN X509 CRL-----\nQ1JMIDI=\n-----END X509 CRL-----\n"
+
 	// even though this will be SPIFFE authentication in production, there is
 	// no functional change in the code based on the server certificate
 	// returned from the getter, so for test purposes we'll just use a
@@ -51,20 +59,22 @@ func TestServer(t *testing.T) {
 	}
 
 	testCases := []struct {
-		name       string
-		method     string
-		path       string
-		status     int
-		body       string
-		bundle     *bundleutil.Bundle
-		serverCert *x509.Certificate
-		reqErr     string
+		name        string
+		method      string
+		path        string
+		status      int
+		contentType string
+		body        string
+		bundle      *bundleutil.Bundle
+		serverCert  *x509.Certificate
+		reqErr      string
 	}{
 		{
-			name:   "success",
-			method: "GET",
-			path:   "/",
-			status: http.StatusOK,
+			name:        "success",
+			method:      "GET",
+			path:        "/",
+			status:      http.StatusOK,
+			contentType: "application/json",
 			body: fmt.Sprintf(`{
 				"keys": [
 					{
@@ -81,6 +91,25 @@ func TestServer(t *testing.T) {
 			bundle:     bundle,
 			serverCert: serverCert,
 		},
+		{
+			name:        "crl",
+			method:      "GET",
+			path:        "/crl",
+			status:      http.StatusOK,
+			contentType: "application/x-pem-file",
+			body:        crlPEM,
+			bundle:      bundleWithCRL,
+			serverCert:  serverCert,
+		},
+		{
+			name:       "no crl published",
+			method:     "GET",
+			path:       "/crl",
+			status:     http.StatusNotFound,
+			body:       "404 page not found\n",
+			bundle:     bundle,
+			serverCert: serverCert,
+		},
 		{
 			name:       "invalid method",
 			method:     "POST",
@@ -166,7 +195,10 @@ func TestServer(t *testing.T) {
 			require.NoError(t, err)
 
 			require.Equal(t, testCase.status, resp.StatusCode)
-			if testCase.status == http.StatusOK {
+			if testCase.contentType != "" {
+				require.Equal(t, testCase.contentType, resp.Header.Get("Content-Type"))
+			}
+			if testCase.contentType == "application/json" {
 				// we expect a JSON payload for 200
 				require.JSONEq(t, testCase.body, string(actual))
 			} else {
@@ -4,12 +4,16 @@ import (
 	"net"
 	"net/url"
 	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/peertracker"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/server/audit"
 	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/catalog"
+	"github.com/spiffe/spire/pkg/server/endpoints/node"
+	"github.com/spiffe/spire/pkg/server/endpoints/registration"
 	"github.com/spiffe/spire/pkg/server/svid"
 
 	"google.golang.org/grpc"
@@ -36,11 +40,33 @@ type Config struct {
 	// Server CA for signing SVIDs
 	ServerCA ca.ServerCA
 
+	// CA manager used for operator driven CA rotation
+	CAManager registration.CAManager
+
+	// Maximum TTL of the SVIDs minted through the Registration API
+	MaxMintedSVIDTTL time.Duration
+
+	// Authorization policy for the Registration API. If unset, callers over
+	// TCP must be admins.
+	RegistrationPolicy registration.PolicySource
+
+	// Audit log of the calls to the Registration API and of node
+	// attestations. If unset, calls aren't audited.
+	AuditLog *audit.Logger
+
+	// Fetches the entries agents are authorized for. If unset, the Node API
+	// queries the datastore.
+	EntryFetcher node.EntryFetcher
+
 	// Allow agentless spiffeIds when doing node attestation
 	AllowAgentlessNodeAttestors bool
 
 	BundleEndpointAddress *net.TCPAddr
 
+	// Address to serve the Registration API as JSON over HTTPS on. If unset,
+	// the registration gateway is disabled.
+	RegistrationGatewayAddress *net.TCPAddr
+
 	Log     logrus.FieldLogger
 	Metrics telemetry.Metrics
 }
@@ -11,6 +11,7 @@ import (
 	"os"
 	"sync"
 
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
 	"golang.org/x/net/context"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -21,6 +22,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/pkg/server/endpoints/bundle"
+	"github.com/spiffe/spire/pkg/server/endpoints/gateway"
 	"github.com/spiffe/spire/pkg/server/endpoints/node"
 	"github.com/spiffe/spire/pkg/server/endpoints/registration"
 	node_pb "github.com/spiffe/spire/proto/spire/api/node"
@@ -55,7 +57,7 @@ func (e *endpoints) ListenAndServe(ctx context.Context) error {
 	udsServer := e.createUDSServer(ctx)
 
 	e.registerNodeAPI(tcpServer)
-	e.registerRegistrationAPI(tcpServer, udsServer)
+	registrationHandler := e.registerRegistrationAPI(tcpServer, udsServer)
 
 	tasks := []func(context.Context) error{
 		func(ctx context.Context) error {
@@ -70,6 +72,10 @@ func (e *endpoints) ListenAndServe(ctx context.Context) error {
 		tasks = append(tasks, bundleServer.Run)
 	}
 
+	if gatewayServer, enabled := e.createRegistrationGatewayServer(ctx, registrationHandler); enabled {
+		tasks = append(tasks, gatewayServer.Run)
+	}
+
 	err := util.RunTasks(ctx, tasks...)
 	if err == context.Canceled {
 		err = nil
@@ -82,19 +88,32 @@ func (e *endpoints) createTCPServer(ctx context.Context) *grpc.Server {
 		GetConfigForClient: e.getTLSConfig(ctx),
 	}
 
+	unaryInterceptor, streamInterceptor := e.interceptors()
 	return grpc.NewServer(
-		grpc.UnaryInterceptor(auth.UnaryAuthorizeCall),
-		grpc.StreamInterceptor(auth.StreamAuthorizeCall),
+		grpc.UnaryInterceptor(unaryInterceptor),
+		grpc.StreamInterceptor(streamInterceptor),
 		grpc.Creds(credentials.NewTLS(tlsConfig)))
 }
 
 func (e *endpoints) createUDSServer(ctx context.Context) *grpc.Server {
+	unaryInterceptor, streamInterceptor := e.interceptors()
 	return grpc.NewServer(
-		grpc.UnaryInterceptor(auth.UnaryAuthorizeCall),
-		grpc.StreamInterceptor(auth.StreamAuthorizeCall),
+		grpc.UnaryInterceptor(unaryInterceptor),
+		grpc.StreamInterceptor(streamInterceptor),
 		grpc.Creds(peertracker.NewCredentials()))
 }
 
+// interceptors returns the interceptors authorizing the calls. When the audit
+// log is enabled, calls are audited before they are authorized so that
+// unauthorized calls are audited too.
+func (e *endpoints) interceptors() (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	if e.c.AuditLog == nil {
+		return auth.UnaryAuthorizeCall, auth.StreamAuthorizeCall
+	}
+	return grpc_middleware.ChainUnaryServer(e.c.AuditLog.UnaryInterceptor, auth.UnaryAuthorizeCall),
+		grpc_middleware.ChainStreamServer(e.c.AuditLog.StreamInterceptor, auth.StreamAuthorizeCall)
+}
+
 func (e *endpoints) createBundleEndpointServer() (*bundle.Server, bool) {
 	if e.c.BundleEndpointAddress == nil {
 		return nil, false
@@ -124,6 +143,27 @@ func (e *endpoints) createBundleEndpointServer() (*bundle.Server, bool) {
 	}), true
 }
 
+// createRegistrationGatewayServer creates the server exposing the
+// Registration API as JSON over HTTPS. Calls are intercepted like calls to
+// the gRPC servers so they are authorized (and audited) the same way.
+func (e *endpoints) createRegistrationGatewayServer(ctx context.Context, r *registration.Handler) (*gateway.Server, bool) {
+	if e.c.RegistrationGatewayAddress == nil {
+		return nil, false
+	}
+	e.c.Log.WithField(telemetry.Address, e.c.RegistrationGatewayAddress).Info("Serving registration gateway")
+
+	unaryInterceptor, _ := e.interceptors()
+	return gateway.NewServer(gateway.ServerConfig{
+		Address: e.c.RegistrationGatewayAddress.String(),
+		Handler: gateway.NewHandler(gateway.HandlerConfig{
+			Log:         e.c.Log.WithField(telemetry.SubsystemName, telemetry.RegistrationGateway),
+			Server:      r,
+			Interceptor: unaryInterceptor,
+		}),
+		GetTLSConfig: e.getRegistrationGatewayTLSConfig(ctx),
+	}), true
+}
+
 // registerNodeAPI creates a Node API handler and registers it against
 // the provided gRPC server.
 func (e *endpoints) registerNodeAPI(tcpServer *grpc.Server) {
@@ -134,6 +174,7 @@ func (e *endpoints) registerNodeAPI(tcpServer *grpc.Server) {
 		TrustDomain: e.c.TrustDomain,
 		ServerCA:    e.c.ServerCA,
 
+		EntryFetcher:                e.c.EntryFetcher,
 		AllowAgentlessNodeAttestors: e.c.AllowAgentlessNodeAttestors,
 	})
 	node_pb.RegisterNodeServer(tcpServer, n)
@@ -141,16 +182,22 @@ func (e *endpoints) registerNodeAPI(tcpServer *grpc.Server) {
 
 // registerRegistrationAPI creates a Registration API handler and registers
 // it against the provided gRPC.
-func (e *endpoints) registerRegistrationAPI(tcpServer, udpServer *grpc.Server) {
+func (e *endpoints) registerRegistrationAPI(tcpServer, udpServer *grpc.Server) *registration.Handler {
 	r := &registration.Handler{
 		Log:         e.c.Log.WithField(telemetry.SubsystemName, telemetry.RegistrationAPI),
 		Metrics:     e.c.Metrics,
 		Catalog:     e.c.Catalog,
 		TrustDomain: e.c.TrustDomain,
+		CAManager:   e.c.CAManager,
+		Policy:      e.c.RegistrationPolicy,
+
+		ServerCA:         e.c.ServerCA,
+		MaxMintedSVIDTTL: e.c.MaxMintedSVIDTTL,
 	}
 
 	registration_pb.RegisterRegistrationServer(tcpServer, r)
 	registration_pb.RegisterRegistrationServer(udpServer, r)
+	return r
 }
 
 // runTCPServer will start the server and block until it exits or we are dying.
@@ -240,6 +287,21 @@ func (e *endpoints) getTLSConfig(ctx context.Context) func(*tls.ClientHelloInfo)
 	}
 }
 
+// getRegistrationGatewayTLSConfig returns the TLS configuration of the
+// registration gateway. Unlike the Node API, which serves agents that don't
+// have an SVID yet, the gateway requires a client certificate.
+func (e *endpoints) getRegistrationGatewayTLSConfig(ctx context.Context) func(*tls.ClientHelloInfo) (*tls.Config, error) {
+	getTLSConfig := e.getTLSConfig(ctx)
+	return func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+		c, err := getTLSConfig(hello)
+		if err != nil {
+			return nil, err
+		}
+		c.ClientAuth = tls.RequireAndVerifyClientCert
+		return c, nil
+	}
+}
+
 // getCerts queries the datastore and returns a TLS serving certificate(s) plus
 // the current CA root bundle.
 func (e *endpoints) getCerts(ctx context.Context) ([]tls.Certificate, *x509.CertPool, error) {
@@ -0,0 +1,424 @@
+package gateway
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/spiffe/spire/proto/spire/common"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	registrationMethodPrefix = "/spire.api.registration.Registration/"
+
+	// maxBodySize is the maximum size of request bodies
+	maxBodySize = 4 << 20
+)
+
+var (
+	marshaler   = &jsonpb.Marshaler{OrigName: true}
+	unmarshaler = &jsonpb.Unmarshaler{}
+)
+
+type HandlerConfig struct {
+	Log logrus.FieldLogger
+
+	// Server is the Registration API server the calls are made to
+	Server registration.RegistrationServer
+
+	// Interceptor intercepts each call like it would intercept calls to
+	// the gRPC server, so that calls are authorized the same way
+	Interceptor grpc.UnaryServerInterceptor
+}
+
+// Handler serves the unary methods of the Registration API as JSON over
+// HTTP. Messages use the proto3 JSON mapping with the proto field names.
+// Errors are returned as a google.rpc.Status message along with the HTTP
+// status matching the gRPC status code.
+type Handler struct {
+	c HandlerConfig
+}
+
+func NewHandler(c HandlerConfig) *Handler {
+	return &Handler{
+		c: c,
+	}
+}
+
+// route maps requests to a Registration API method. Path segments of the
+// pattern set to "{}" match any segment, which is passed to the request
+// builder unescaped.
+type route struct {
+	method  string
+	pattern string
+	rpc     string
+	request func(r *http.Request, params []string) (proto.Message, error)
+	invoke  func(ctx context.Context, s registration.RegistrationServer, req interface{}) (interface{}, error)
+}
+
+var routes = []route{
+	{
+		method:  http.MethodGet,
+		pattern: "/v1/entries",
+		rpc:     "ListEntries",
+		request: func(r *http.Request, params []string) (proto.Message, error) {
+			req := new(registration.ListEntriesRequest)
+			return req, decodeQuery(r.URL.Query(), req)
+		},
+		invoke: func(ctx context.Context, s registration.RegistrationServer, req interface{}) (interface{}, error) {
+			return s.ListEntries(ctx, req.(*registration.ListEntriesRequest))
+		},
+	},
+	{
+		method:  http.MethodPost,
+		pattern: "/v1/entries",
+		rpc:     "CreateEntry",
+		request: func(r *http.Request, params []string) (proto.Message, error) {
+			req := new(common.RegistrationEntry)
+			return req, decodeBody(r, req)
+		},
+		invoke: func(ctx context.Context, s registration.RegistrationServer, req interface{}) (interface{}, error) {
+			return s.CreateEntry(ctx, req.(*common.RegistrationEntry))
+		},
+	},
+	{
+		method:  http.MethodPost,
+		pattern: "/v1/entries:batchCreate",
+		rpc:     "BatchCreateEntry",
+		request: func(r *http.Request, params []string) (proto.Message, error) {
+			req := new(registration.BatchCreateEntryRequest)
+			return req, decodeBody(r, req)
+		},
+		invoke: func(ctx context.Context, s registration.RegistrationServer, req interface{}) (interface{}, error) {
+			return s.BatchCreateEntry(ctx, req.(*registration.BatchCreateEntryRequest))
+		},
+	},
+	{
+		method:  http.MethodPost,
+		pattern: "/v1/entries:batchUpdate",
+		rpc:     "BatchUpdateEntry",
+		request: func(r *http.Request, params []string) (proto.Message, error) {
+			req := new(registration.BatchUpdateEntryRequest)
+			return req, decodeBody(r, req)
+		},
+		invoke: func(ctx context.Context, s registration.RegistrationServer, req interface{}) (interface{}, error) {
+			return s.BatchUpdateEntry(ctx, req.(*registration.BatchUpdateEntryRequest))
+		},
+	},
+	{
+		method:  http.MethodPost,
+		pattern: "/v1/entries:batchDelete",
+		rpc:     "BatchDeleteEntry",
+		request: func(r *http.Request, params []string) (proto.Message, error) {
+			req := new(registration.BatchDeleteEntryRequest)
+			return req, decodeBody(r, req)
+		},
+		invoke: func(ctx context.Context, s registration.RegistrationServer, req interface{}) (interface{}, error) {
+			return s.BatchDeleteEntry(ctx, req.(*registration.BatchDeleteEntryRequest))
+		},
+	},
+	{
+		method:  http.MethodGet,
+		pattern: "/v1/entries/{}",
+		rpc:     "FetchEntry",
+		request: func(r *http.Request, params []string) (proto.Message, error) {
+			return &registration.RegistrationEntryID{Id: params[0]}, nil
+		},
+		invoke: func(ctx context.Context, s registration.RegistrationServer, req interface{}) (interface{}, error) {
+			return s.FetchEntry(ctx, req.(*registration.RegistrationEntryID))
+		},
+	},
+	{
+		method:  http.MethodPut,
+		pattern: "/v1/entries/{}",
+		rpc:     "UpdateEntry",
+		request: func(r *http.Request, params []string) (proto.Message, error) {
+			req := new(registration.UpdateEntryRequest)
+			if err := decodeBody(r, req); err != nil {
+				return nil, err
+			}
+			if req.Entry == nil {
+				req.Entry = new(common.RegistrationEntry)
+			}
+			if req.Entry.EntryId != "" && req.Entry.EntryId != params[0] {
+				return nil, status.Error(codes.InvalidArgument, "entry ID in the body does not match the path")
+			}
+			req.Entry.EntryId = params[0]
+			return req, nil
+		},
+		invoke: func(ctx context.Context, s registration.RegistrationServer, req interface{}) (interface{}, error) {
+			return s.UpdateEntry(ctx, req.(*registration.UpdateEntryRequest))
+		},
+	},
+	{
+		method:  http.MethodDelete,
+		pattern: "/v1/entries/{}",
+		rpc:     "DeleteEntry",
+		request: func(r *http.Request, params []string) (proto.Message, error) {
+			return &registration.RegistrationEntryID{Id: params[0]}, nil
+		},
+		invoke: func(ctx context.Context, s registration.RegistrationServer, req interface{}) (interface{}, error) {
+			return s.DeleteEntry(ctx, req.(*registration.RegistrationEntryID))
+		},
+	},
+	{
+		method:  http.MethodGet,
+		pattern: "/v1/agents",
+		rpc:     "ListAgents",
+		request: func(r *http.Request, params []string) (proto.Message, error) {
+			req := new(registration.ListAgentsRequest)
+			return req, decodeQuery(r.URL.Query(), req)
+		},
+		invoke: func(ctx context.Context, s registration.RegistrationServer, req interface{}) (interface{}, error) {
+			return s.ListAgents(ctx, req.(*registration.ListAgentsRequest))
+		},
+	},
+	{
+		method:  http.MethodGet,
+		pattern: "/v1/agents/{}",
+		rpc:     "FetchAgent",
+		request: func(r *http.Request, params []string) (proto.Message, error) {
+			return &registration.FetchAgentRequest{SpiffeId: params[0]}, nil
+		},
+		invoke: func(ctx context.Context, s registration.RegistrationServer, req interface{}) (interface{}, error) {
+			return s.FetchAgent(ctx, req.(*registration.FetchAgentRequest))
+		},
+	},
+	{
+		method:  http.MethodDelete,
+		pattern: "/v1/agents/{}",
+		rpc:     "EvictAgent",
+		request: func(r *http.Request, params []string) (proto.Message, error) {
+			return &registration.EvictAgentRequest{SpiffeID: params[0]}, nil
+		},
+		invoke: func(ctx context.Context, s registration.RegistrationServer, req interface{}) (interface{}, error) {
+			return s.EvictAgent(ctx, req.(*registration.EvictAgentRequest))
+		},
+	},
+	{
+		method:  http.MethodPost,
+		pattern: "/v1/agents/{}/ban",
+		rpc:     "BanAgent",
+		request: func(r *http.Request, params []string) (proto.Message, error) {
+			return &registration.BanAgentRequest{SpiffeId: params[0]}, nil
+		},
+		invoke: func(ctx context.Context, s registration.RegistrationServer, req interface{}) (interface{}, error) {
+			return s.BanAgent(ctx, req.(*registration.BanAgentRequest))
+		},
+	},
+	{
+		method:  http.MethodPost,
+		pattern: "/v1/agents/{}/unban",
+		rpc:     "UnbanAgent",
+		request: func(r *http.Request, params []string) (proto.Message, error) {
+			return &registration.UnbanAgentRequest{SpiffeId: params[0]}, nil
+		},
+		invoke: func(ctx context.Context, s registration.RegistrationServer, req interface{}) (interface{}, error) {
+			return s.UnbanAgent(ctx, req.(*registration.UnbanAgentRequest))
+		},
+	},
+	{
+		method:  http.MethodGet,
+		pattern: "/v1/join_tokens",
+		rpc:     "ListJoinTokens",
+		request: func(r *http.Request, params []string) (proto.Message, error) {
+			return new(registration.ListJoinTokensRequest), nil
+		},
+		invoke: func(ctx context.Context, s registration.RegistrationServer, req interface{}) (interface{}, error) {
+			return s.ListJoinTokens(ctx, req.(*registration.ListJoinTokensRequest))
+		},
+	},
+	{
+		method:  http.MethodPost,
+		pattern: "/v1/join_tokens",
+		rpc:     "CreateJoinToken",
+		request: func(r *http.Request, params []string) (proto.Message, error) {
+			req := new(registration.JoinToken)
+			return req, decodeBody(r, req)
+		},
+		invoke: func(ctx context.Context, s registration.RegistrationServer, req interface{}) (interface{}, error) {
+			return s.CreateJoinToken(ctx, req.(*registration.JoinToken))
+		},
+	},
+	{
+		method:  http.MethodDelete,
+		pattern: "/v1/join_tokens/{}",
+		rpc:     "DeleteJoinToken",
+		request: func(r *http.Request, params []string) (proto.Message, error) {
+			return &registration.DeleteJoinTokenRequest{Token: params[0]}, nil
+		},
+		invoke: func(ctx context.Context, s registration.RegistrationServer, req interface{}) (interface{}, error) {
+			return s.DeleteJoinToken(ctx, req.(*registration.DeleteJoinTokenRequest))
+		},
+	},
+	{
+		method:  http.MethodGet,
+		pattern: "/v1/bundle",
+		rpc:     "FetchBundle",
+		request: func(r *http.Request, params []string) (proto.Message, error) {
+			return new(common.Empty), nil
+		},
+		invoke: func(ctx context.Context, s registration.RegistrationServer, req interface{}) (interface{}, error) {
+			return s.FetchBundle(ctx, req.(*common.Empty))
+		},
+	},
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rt, params, pathMatched := matchRoute(r)
+	switch {
+	case rt != nil:
+	case pathMatched:
+		st := status.Newf(codes.Unimplemented, "method %s not allowed on %s", r.Method, r.URL.Path)
+		h.writeMessage(w, http.StatusMethodNotAllowed, st.Proto())
+		return
+	default:
+		h.writeError(w, status.Errorf(codes.NotFound, "no such path %s", r.URL.Path))
+		return
+	}
+
+	req, err := rt.request(r, params)
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	ctx := r.Context()
+	if r.TLS != nil {
+		ctx = peer.NewContext(ctx, &peer.Peer{
+			Addr:     remoteAddr(r.RemoteAddr),
+			AuthInfo: credentials.TLSInfo{State: *r.TLS},
+		})
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     h.c.Server,
+		FullMethod: registrationMethodPrefix + rt.rpc,
+	}
+	resp, err := h.c.Interceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return rt.invoke(ctx, h.c.Server, req)
+	})
+	if err != nil {
+		h.writeError(w, err)
+		return
+	}
+
+	h.writeMessage(w, http.StatusOK, resp.(proto.Message))
+}
+
+func (h *Handler) writeError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	h.writeMessage(w, httpStatusFromCode(st.Code()), st.Proto())
+}
+
+func (h *Handler) writeMessage(w http.ResponseWriter, httpStatus int, msg proto.Message) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(httpStatus)
+	if err := marshaler.Marshal(w, msg); err != nil {
+		h.c.Log.WithError(err).Error("Failed to write response")
+	}
+}
+
+// matchRoute returns the route matching the request and the unescaped path
+// segments matched by the "{}" segments of the route pattern. If no route
+// matches, it returns whether a route matches the path for another method.
+func matchRoute(r *http.Request) (*route, []string, bool) {
+	segments := strings.Split(r.URL.EscapedPath(), "/")
+
+	pathMatched := false
+	for i := range routes {
+		rt := &routes[i]
+		params, ok := matchPattern(rt.pattern, segments)
+		if !ok {
+			continue
+		}
+		pathMatched = true
+		if rt.method == r.Method {
+			return rt, params, true
+		}
+	}
+	return nil, nil, pathMatched
+}
+
+func matchPattern(pattern string, segments []string) ([]string, bool) {
+	patternSegments := strings.Split(pattern, "/")
+	if len(patternSegments) != len(segments) {
+		return nil, false
+	}
+
+	var params []string
+	for i, patternSegment := range patternSegments {
+		if patternSegment != "{}" {
+			if patternSegment != segments[i] {
+				return nil, false
+			}
+			continue
+		}
+		param, err := url.PathUnescape(segments[i])
+		if err != nil || param == "" {
+			return nil, false
+		}
+		params = append(params, param)
+	}
+	return params, true
+}
+
+func decodeBody(r *http.Request, msg proto.Message) error {
+	if err := unmarshaler.Unmarshal(http.MaxBytesReader(nil, r.Body, maxBodySize), msg); err != nil && err != io.EOF {
+		return status.Errorf(codes.InvalidArgument, "unable to decode request body: %v", err)
+	}
+	return nil
+}
+
+// httpStatusFromCode maps gRPC status codes to HTTP status codes following
+// google/rpc/code.proto
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// remoteAddr is the address of the HTTP client, in the form of a net.Addr
+type remoteAddr string
+
+func (a remoteAddr) Network() string {
+	return "tcp"
+}
+
+func (a remoteAddr) String() string {
+	return string(a)
+}
@@ -0,0 +1,223 @@
+package gateway
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/auth"
+	"github.com/spiffe/spire/proto/spire/api/registration"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestHandler(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		noTLS      bool
+		status     int
+		respBody   string
+		fullMethod string
+		req        interface{}
+	}{
+		{
+			name:       "create entry",
+			method:     "POST",
+			path:       "/v1/entries",
+			body:       `{"parent_id": "spiffe://example.org/node", "spiffe_id": "spiffe://example.org/workload", "selectors": [{"type": "unix", "value": "uid:1000"}]}`,
+			status:     http.StatusOK,
+			respBody:   `{"id": "ENTRYID"}`,
+			fullMethod: "/spire.api.registration.Registration/CreateEntry",
+			req: &common.RegistrationEntry{
+				ParentId:  "spiffe://example.org/node",
+				SpiffeId:  "spiffe://example.org/workload",
+				Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+			},
+		},
+		{
+			name:       "list entries",
+			method:     "GET",
+			path:       "/v1/entries?parent_id=spiffe://example.org/node&selectors=unix:uid:1000&selectors=unix:gid:1000&selector_match=EXACT&admin=false&page_size=10",
+			status:     http.StatusOK,
+			respBody:   `{"entries": [{"entry_id": "ENTRYID"}]}`,
+			fullMethod: "/spire.api.registration.Registration/ListEntries",
+			req: &registration.ListEntriesRequest{
+				ParentId: "spiffe://example.org/node",
+				Selectors: []*common.Selector{
+					{Type: "unix", Value: "uid:1000"},
+					{Type: "unix", Value: "gid:1000"},
+				},
+				SelectorMatch: registration.ListEntriesRequest_EXACT,
+				Admin:         &wrappers.BoolValue{Value: false},
+				PageSize:      10,
+			},
+		},
+		{
+			name:       "update entry",
+			method:     "PUT",
+			path:       "/v1/entries/ENTRYID",
+			body:       `{"entry": {"ttl": 60}, "mask": {"ttl": true}}`,
+			status:     http.StatusOK,
+			respBody:   `{"entry_id": "ENTRYID", "ttl": 60}`,
+			fullMethod: "/spire.api.registration.Registration/UpdateEntry",
+			req: &registration.UpdateEntryRequest{
+				Entry: &common.RegistrationEntry{EntryId: "ENTRYID", Ttl: 60},
+				Mask:  &common.RegistrationEntryMask{Ttl: true},
+			},
+		},
+		{
+			name:     "update entry with mismatched ID",
+			method:   "PUT",
+			path:     "/v1/entries/ENTRYID",
+			body:     `{"entry": {"entry_id": "OTHER"}}`,
+			status:   http.StatusBadRequest,
+			respBody: `{"code": 3, "message": "entry ID in the body does not match the path"}`,
+		},
+		{
+			name:       "fetch agent",
+			method:     "GET",
+			path:       "/v1/agents/spiffe:%2F%2Fexample.org%2Fspire%2Fagent%2Fnode",
+			status:     http.StatusOK,
+			respBody:   `{"node": {"spiffe_id": "spiffe://example.org/spire/agent/node"}}`,
+			fullMethod: "/spire.api.registration.Registration/FetchAgent",
+			req:        &registration.FetchAgentRequest{SpiffeId: "spiffe://example.org/spire/agent/node"},
+		},
+		{
+			name:       "ban agent",
+			method:     "POST",
+			path:       "/v1/agents/spiffe:%2F%2Fexample.org%2Fspire%2Fagent%2Fnode/ban",
+			status:     http.StatusNotFound,
+			respBody:   `{"code": 5, "message": "no such node"}`,
+			fullMethod: "/spire.api.registration.Registration/BanAgent",
+			req:        &registration.BanAgentRequest{SpiffeId: "spiffe://example.org/spire/agent/node"},
+		},
+		{
+			name:     "unauthorized",
+			method:   "GET",
+			path:     "/v1/entries",
+			noTLS:    true,
+			status:   http.StatusForbidden,
+			respBody: `{"code": 7, "message": "no client certificate"}`,
+		},
+		{
+			name:     "malformed body",
+			method:   "POST",
+			path:     "/v1/entries",
+			body:     `{"parent_id": 1}`,
+			status:   http.StatusBadRequest,
+			respBody: `{"code": 3, "message": "unable to decode request body: json: cannot unmarshal number into Go value of type string"}`,
+		},
+		{
+			name:     "unknown query parameter",
+			method:   "GET",
+			path:     "/v1/entries?foo=bar",
+			status:   http.StatusBadRequest,
+			respBody: `{"code": 3, "message": "unknown query parameter \"foo\""}`,
+		},
+		{
+			name:     "invalid query parameter",
+			method:   "GET",
+			path:     "/v1/agents?banned=maybe",
+			status:   http.StatusBadRequest,
+			respBody: `{"code": 3, "message": "invalid query parameter \"banned\": strconv.ParseBool: parsing \"maybe\": invalid syntax"}`,
+		},
+		{
+			name:     "unknown path",
+			method:   "GET",
+			path:     "/v1/foo",
+			status:   http.StatusNotFound,
+			respBody: `{"code": 5, "message": "no such path /v1/foo"}`,
+		},
+		{
+			name:     "method not allowed",
+			method:   "PATCH",
+			path:     "/v1/entries/ENTRYID",
+			status:   http.StatusMethodNotAllowed,
+			respBody: `{"code": 12, "message": "method PATCH not allowed on /v1/entries/ENTRYID"}`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			server := &fakeRegistrationServer{}
+			log, _ := test.NewNullLogger()
+			handler := NewHandler(HandlerConfig{
+				Log:         log,
+				Server:      server,
+				Interceptor: auth.UnaryAuthorizeCall,
+			})
+
+			r := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if !tt.noTLS {
+				r.TLS = &tls.ConnectionState{}
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			require.Equal(t, tt.status, w.Code)
+			require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+			require.JSONEq(t, tt.respBody, w.Body.String())
+			require.Equal(t, tt.fullMethod, server.fullMethod)
+			require.Equal(t, tt.req, server.req)
+		})
+	}
+}
+
+type fakeRegistrationServer struct {
+	registration.RegistrationServer
+
+	fullMethod string
+	req        interface{}
+}
+
+func (s *fakeRegistrationServer) AuthorizeCall(ctx context.Context, fullMethod string) (context.Context, error) {
+	ctxPeer, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "no client certificate")
+	}
+	if _, ok := ctxPeer.AuthInfo.(credentials.TLSInfo); !ok {
+		return nil, status.Error(codes.PermissionDenied, "unexpected auth info")
+	}
+	s.fullMethod = fullMethod
+	return ctx, nil
+}
+
+func (s *fakeRegistrationServer) CreateEntry(ctx context.Context, req *common.RegistrationEntry) (*registration.RegistrationEntryID, error) {
+	s.req = req
+	return &registration.RegistrationEntryID{Id: "ENTRYID"}, nil
+}
+
+func (s *fakeRegistrationServer) ListEntries(ctx context.Context, req *registration.ListEntriesRequest) (*registration.ListEntriesResponse, error) {
+	s.req = req
+	return &registration.ListEntriesResponse{
+		Entries: []*common.RegistrationEntry{{EntryId: "ENTRYID"}},
+	}, nil
+}
+
+func (s *fakeRegistrationServer) UpdateEntry(ctx context.Context, req *registration.UpdateEntryRequest) (*common.RegistrationEntry, error) {
+	s.req = req
+	return req.Entry, nil
+}
+
+func (s *fakeRegistrationServer) FetchAgent(ctx context.Context, req *registration.FetchAgentRequest) (*registration.FetchAgentResponse, error) {
+	s.req = req
+	return &registration.FetchAgentResponse{
+		Node: &common.AttestedNode{SpiffeId: req.SpiffeId},
+	}, nil
+}
+
+func (s *fakeRegistrationServer) BanAgent(ctx context.Context, req *registration.BanAgentRequest) (*registration.BanAgentResponse, error) {
+	s.req = req
+	return nil, status.Error(codes.NotFound, "no such node")
+}
@@ -0,0 +1,129 @@
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/spiffe/spire/proto/spire/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	boolValueType = reflect.TypeOf(&wrappers.BoolValue{})
+	selectorsType = reflect.TypeOf([]*common.Selector(nil))
+)
+
+// decodeQuery sets the fields of a request message from query parameters
+// named after the proto field names. Repeated fields take every value of
+// the parameter. Selectors are formatted as "type:value" and enums are set
+// by name or number.
+func decodeQuery(query url.Values, msg proto.Message) error {
+	v := reflect.ValueOf(msg).Elem()
+
+	// decode in a stable order so the first bad parameter is reported
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		field, tag, ok := lookupField(v, name)
+		if !ok {
+			return status.Errorf(codes.InvalidArgument, "unknown query parameter %q", name)
+		}
+		if err := setField(field, tag, query[name]); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid query parameter %q: %v", name, err)
+		}
+	}
+	return nil
+}
+
+func lookupField(v reflect.Value, name string) (reflect.Value, string, bool) {
+	for i := 0; i < v.NumField(); i++ {
+		tag := v.Type().Field(i).Tag.Get("protobuf")
+		for _, part := range strings.Split(tag, ",") {
+			if part == "name="+name {
+				return v.Field(i), tag, true
+			}
+		}
+	}
+	return reflect.Value{}, "", false
+}
+
+func setField(field reflect.Value, tag string, values []string) error {
+	switch {
+	case field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.String:
+		field.Set(reflect.ValueOf(values))
+		return nil
+	case field.Type() == selectorsType:
+		selectors := make([]*common.Selector, 0, len(values))
+		for _, value := range values {
+			parts := strings.SplitN(value, ":", 2)
+			if len(parts) < 2 {
+				return fmt.Errorf("selector %q must be formatted as type:value", value)
+			}
+			selectors = append(selectors, &common.Selector{Type: parts[0], Value: parts[1]})
+		}
+		field.Set(reflect.ValueOf(selectors))
+		return nil
+	}
+
+	if len(values) != 1 {
+		return errors.New("expected a single value")
+	}
+	value := values[0]
+
+	switch {
+	case field.Type() == boolValueType:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.Set(reflect.ValueOf(&wrappers.BoolValue{Value: b}))
+	case field.Kind() == reflect.String:
+		field.SetString(value)
+	case field.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	case field.Kind() == reflect.Int32 && enumName(tag) != "":
+		n, ok := proto.EnumValueMap(enumName(tag))[value]
+		if !ok {
+			i, err := strconv.ParseInt(value, 10, 32)
+			if err != nil {
+				return fmt.Errorf("unknown value %q", value)
+			}
+			n = int32(i)
+		}
+		field.SetInt(int64(n))
+	case field.Kind() == reflect.Int32 || field.Kind() == reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(i)
+	default:
+		return errors.New("not supported as a query parameter")
+	}
+	return nil
+}
+
+func enumName(tag string) string {
+	for _, part := range strings.Split(tag, ",") {
+		if strings.HasPrefix(part, "enum=") {
+			return strings.TrimPrefix(part, "enum=")
+		}
+	}
+	return ""
+}
@@ -0,0 +1,89 @@
+package gateway
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/zeebo/errs"
+)
+
+const (
+	// readHeaderTimeout bounds the TLS handshake and the reading of the
+	// request headers so that slow clients can't hold connections open
+	readHeaderTimeout = 10 * time.Second
+
+	// readTimeout bounds the reading of the whole request, body included.
+	// Requests are small JSON documents.
+	readTimeout = 30 * time.Second
+
+	// idleTimeout is how long a keep-alive connection is kept open waiting
+	// for the next request
+	idleTimeout = 2 * time.Minute
+)
+
+type ServerConfig struct {
+	Address string
+	Handler http.Handler
+
+	// GetTLSConfig returns the TLS configuration for each client. It must
+	// require and verify client certificates.
+	GetTLSConfig func(*tls.ClientHelloInfo) (*tls.Config, error)
+
+	// test hooks
+	listen func(network, address string) (net.Listener, error)
+}
+
+// Server serves the gateway over HTTPS
+type Server struct {
+	c ServerConfig
+}
+
+func NewServer(config ServerConfig) *Server {
+	if config.listen == nil {
+		config.listen = net.Listen
+	}
+	return &Server{
+		c: config,
+	}
+}
+
+func (s *Server) Run(ctx context.Context) error {
+	listener, err := s.c.listen("tcp", s.c.Address)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+
+	// ServeTLS requires the base configuration to hold the server
+	// certificate, which comes with the configuration for each client
+	// instead
+	listener = tls.NewListener(listener, &tls.Config{
+		GetConfigForClient: s.c.GetTLSConfig,
+	})
+
+	server := s.newHTTPServer()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- errs.Wrap(server.Serve(listener))
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		server.Close()
+		return nil
+	}
+}
+
+func (s *Server) newHTTPServer() *http.Server {
+	return &http.Server{
+		Handler:           s.c.Handler,
+		ReadHeaderTimeout: readHeaderTimeout,
+		ReadTimeout:       readTimeout,
+		IdleTimeout:       idleTimeout,
+	}
+}
@@ -0,0 +1,108 @@
+package gateway
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerRequiresClientCertificate(t *testing.T) {
+	now := time.Now()
+	serverCert, serverKey := spiretest.SelfSignCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    now,
+		NotAfter:     now.Add(time.Hour),
+	})
+	clientCert, clientKey := spiretest.SelfSignCertificate(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		NotBefore:    now,
+		NotAfter:     now.Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+
+	urlCh := make(chan string, 1)
+	server := NewServer(ServerConfig{
+		Address: "127.0.0.1:0",
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, r.TLS.PeerCertificates[0].SerialNumber)
+		}),
+		GetTLSConfig: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			return &tls.Config{
+				ClientAuth: tls.RequireAndVerifyClientCert,
+				Certificates: []tls.Certificate{{
+					Certificate: [][]byte{serverCert.Raw},
+					PrivateKey:  serverKey,
+				}},
+				ClientCAs: clientCAs,
+			}, nil
+		},
+		listen: func(network, address string) (net.Listener, error) {
+			listener, err := net.Listen(network, address)
+			if err != nil {
+				return nil, err
+			}
+			urlCh <- fmt.Sprintf("https://%s/", listener.Addr())
+			return listener, nil
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.Run(ctx)
+	}()
+	url := <-urlCh
+
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(serverCert)
+	newClient := func(certs ...tls.Certificate) *http.Client {
+		return &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					RootCAs:      rootCAs,
+					Certificates: certs,
+				},
+			},
+		}
+	}
+
+	// without a client certificate
+	_, err := newClient().Get(url)
+	require.Error(t, err)
+
+	// with a client certificate
+	resp, err := newClient(tls.Certificate{
+		Certificate: [][]byte{clientCert.Raw},
+		PrivateKey:  clientKey,
+	}).Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.Equal(t, "2", string(body))
+
+	cancel()
+	require.NoError(t, <-errCh)
+}
+
+func TestServerTimeouts(t *testing.T) {
+	server := NewServer(ServerConfig{}).newHTTPServer()
+	require.Equal(t, readHeaderTimeout, server.ReadHeaderTimeout)
+	require.Equal(t, readTimeout, server.ReadTimeout)
+	require.Equal(t, idleTimeout, server.IdleTimeout)
+}
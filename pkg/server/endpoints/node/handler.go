@@ -2,7 +2,9 @@ package node
 
 import (
 	"crypto"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -13,13 +15,16 @@ import (
 	"time"
 
 	"github.com/andres-erbsen/clock"
+	"github.com/gofrs/uuid"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/jwtsvid"
+	"github.com/spiffe/spire/pkg/common/nodeutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_common "github.com/spiffe/spire/pkg/common/telemetry/common"
 	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
+	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/catalog"
 	"github.com/spiffe/spire/pkg/server/util/regentryutil"
@@ -35,6 +40,33 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// maxRevisionGap is the largest difference between the revision last seen by
+// an agent and the current datastore revision for which FetchX509SVID sends
+// only the changes. Agents further behind receive a full update.
+const maxRevisionGap = 10000
+
+// EntryFetcher fetches the registration entries an ID is authorized for,
+// along with the datastore revision they reflect
+type EntryFetcher interface {
+	FetchAuthorizedEntries(ctx context.Context, id string) ([]*common.RegistrationEntry, int64, error)
+}
+
+// NodeSelectorsUpdater is implemented by EntryFetchers that keep their own
+// copy of the node selectors (e.g. the entry cache). It is called after the
+// node selectors are set on attestation so the new selectors are used right
+// away.
+type NodeSelectorsUpdater interface {
+	UpdateNodeSelectors(spiffeID string, selectors []*common.Selector)
+}
+
+// EntryFetcherFunc is an adapter to use a function as an EntryFetcher
+type EntryFetcherFunc func(ctx context.Context, id string) ([]*common.RegistrationEntry, int64, error)
+
+// FetchAuthorizedEntries calls fn(ctx, id)
+func (fn EntryFetcherFunc) FetchAuthorizedEntries(ctx context.Context, id string) ([]*common.RegistrationEntry, int64, error) {
+	return fn(ctx, id)
+}
+
 type HandlerConfig struct {
 	Log         logrus.FieldLogger
 	Metrics     telemetry.Metrics
@@ -43,6 +75,10 @@ type HandlerConfig struct {
 	TrustDomain url.URL
 	Clock       clock.Clock
 
+	// EntryFetcher fetches the entries agents are authorized for when
+	// syncing or fetching JWT-SVIDs. If unset, the datastore is queried.
+	EntryFetcher EntryFetcher
+
 	// Allow agentless SPIFFE IDs when doing node attestation
 	AllowAgentlessNodeAttestors bool
 }
@@ -56,6 +92,12 @@ func NewHandler(config HandlerConfig) *Handler {
 	if config.Clock == nil {
 		config.Clock = clock.New()
 	}
+	if config.EntryFetcher == nil {
+		catalog := config.Catalog
+		config.EntryFetcher = EntryFetcherFunc(func(ctx context.Context, id string) ([]*common.RegistrationEntry, int64, error) {
+			return regentryutil.FetchRegistrationEntriesWithRevision(ctx, catalog.GetDataStore(), id)
+		})
+	}
 	return &Handler{
 		c:       config,
 		limiter: NewLimiter(config.Log),
@@ -162,10 +204,21 @@ func (h *Handler) Attest(stream node.Node_AttestServer) (err error) {
 		return errors.New("attestor returned unexpected response")
 	}
 
+	banned, err := h.isBanned(ctx, agentID)
+	switch {
+	case err != nil:
+		log.WithError(err).Error("Failed to determine if agent is banned")
+		return errors.New("failed to determine if agent is banned")
+	case banned:
+		log.Warn("Refusing to attest banned agent")
+		return nodeutil.AgentBannedError()
+	}
+
 	log.WithField("agent_id", agentID).Debugf("Signing CSR for Agent SVID")
 	svid, err := h.c.ServerCA.SignX509SVID(ctx, ca.X509SVIDParams{
 		SpiffeID:  agentID,
 		PublicKey: csr.PublicKey,
+		AgentID:   agentID,
 	})
 	if err != nil {
 		log.WithError(err).Error("Failed to sign CSR")
@@ -248,12 +301,21 @@ func (h *Handler) FetchX509SVID(server node.Node_FetchX509SVIDServer) (err error
 			return err
 		}
 
-		regEntries, err := regentryutil.FetchRegistrationEntries(ctx, h.c.Catalog.GetDataStore(), agentID)
+		regEntries, revision, err := h.c.EntryFetcher.FetchAuthorizedEntries(ctx, agentID)
 		if err != nil {
 			h.c.Log.Error(err)
 			return errors.New("failed to fetch agent registration entries")
 		}
 
+		var deletedEntryIDs []string
+		if isDeltaUpdate(request.Revision, revision) {
+			deletedEntryIDs, err = h.fetchDeletedEntryIDs(ctx, request.Revision, regEntries)
+			if err != nil {
+				h.c.Log.Error(err)
+				return errors.New("failed to fetch deleted registration entries")
+			}
+		}
+
 		// Only one of 'CSRs', 'DEPRECATEDCSRs' must be populated
 		if csrsLen != 0 && csrsLenDeprecated != 0 {
 			return errors.New("cannot use 'Csrs' and 'DeprecatedCsrs' on the same 'FetchX509Request'")
@@ -303,11 +365,7 @@ func (h *Handler) FetchX509SVID(server node.Node_FetchX509SVIDServer) (err error
 		}
 
 		err = server.Send(&node.FetchX509SVIDResponse{
-			SvidUpdate: &node.X509SVIDUpdate{
-				Svids:               svids,
-				RegistrationEntries: regEntries,
-				Bundles:             bundles,
-			},
+			SvidUpdate: makeX509SVIDUpdate(request.Revision, revision, deletedEntryIDs, svids, regEntries, bundles),
 		})
 		if err != nil {
 			h.c.Log.WithError(err).Error("Error sending FetchX509SVIDResponse")
@@ -360,6 +418,8 @@ func (h *Handler) FetchX509CASVID(ctx context.Context, req *node.FetchX509CASVID
 		SpiffeID:  csr.SpiffeID,
 		PublicKey: csr.PublicKey,
 		TTL:       time.Duration(entry.Ttl) * time.Second,
+		EntryID:   entry.EntryId,
+		AgentID:   entry.ParentId,
 	})
 	if err != nil {
 		return nil, err
@@ -376,6 +436,53 @@ func (h *Handler) FetchX509CASVID(ctx context.Context, req *node.FetchX509CASVID
 	}, nil
 }
 
+// PushJWTKeyUpstream appends the JWT signing key of a downstream SPIRE server
+// to the trust domain bundle and returns the JWT signing keys of the bundle.
+func (h *Handler) PushJWTKeyUpstream(ctx context.Context, req *node.PushJWTKeyUpstreamRequest) (_ *node.PushJWTKeyUpstreamResponse, err error) {
+	counter := telemetry.StartCall(h.c.Metrics, "node_api", "jwt_key", "push")
+	defer counter.Done(&err)
+
+	peerCert, ok := getPeerCertificate(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "downstream SVID is required for this request")
+	}
+
+	if _, ok := getDownstreamEntry(ctx); !ok {
+		return nil, status.Error(codes.PermissionDenied, "downstream entry is required for this request")
+	}
+
+	downstreamID, err := getSpiffeIDFromCert(peerCert)
+	if err != nil {
+		h.c.Log.Error(err)
+		return nil, err
+	}
+
+	if err := validateJWTKey(req.JwtKey); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ds := h.c.Catalog.GetDataStore()
+	resp, err := ds.AppendBundle(ctx, &datastore.AppendBundleRequest{
+		Bundle: &common.Bundle{
+			TrustDomainId:  h.c.TrustDomain.String(),
+			JwtSigningKeys: []*common.PublicKey{req.JwtKey},
+		},
+	})
+	if err != nil {
+		h.c.Log.WithError(err).Error("Failed to append downstream JWT key to bundle")
+		return nil, status.Errorf(codes.Internal, "failed to append JWT key to bundle: %v", err)
+	}
+
+	h.c.Log.WithFields(logrus.Fields{
+		telemetry.CallerID: downstreamID,
+		telemetry.Kid:      req.JwtKey.Kid,
+	}).Debug("Pushed downstream JWT key to bundle")
+
+	return &node.PushJWTKeyUpstreamResponse{
+		JwtSigningKeys: resp.Bundle.JwtSigningKeys,
+	}, nil
+}
+
 func (h *Handler) FetchJWTSVID(ctx context.Context, req *node.FetchJWTSVIDRequest) (resp *node.FetchJWTSVIDResponse, err error) {
 	counter := telemetry_server.StartNodeAPIFetchJWTSVIDCall(h.c.Metrics)
 	defer counter.Done(&err)
@@ -407,26 +514,38 @@ func (h *Handler) FetchJWTSVID(ctx context.Context, req *node.FetchJWTSVIDReques
 		return nil, err
 	}
 
-	ds := h.c.Catalog.GetDataStore()
-	regEntries, err := regentryutil.FetchRegistrationEntries(ctx, ds, agentID)
+	regEntries, _, err := h.c.EntryFetcher.FetchAuthorizedEntries(ctx, agentID)
 	if err != nil {
 		return nil, err
 	}
 
-	found := false
+	var entry *common.RegistrationEntry
 	for _, candidateEntry := range regEntries {
 		if candidateEntry.SpiffeId == req.Jsr.SpiffeId {
-			found = true
+			entry = candidateEntry
 			break
 		}
 	}
-	if !found {
+	if entry == nil {
 		err := fmt.Errorf("caller %q is not authorized for %q", agentID, req.Jsr.SpiffeId)
 		h.c.Log.Error(err)
 		return nil, err
 	}
 
-	token, err := h.c.ServerCA.SignJWTSVID(ctx, req.Jsr)
+	// the entry JWT-SVID TTL caps the requested TTL
+	ttl := req.Jsr.Ttl
+	if entry.JwtSvidTtl > 0 && (ttl <= 0 || ttl > entry.JwtSvidTtl) {
+		ttl = entry.JwtSvidTtl
+	}
+
+	token, err := h.c.ServerCA.SignJWTSVID(ctx, ca.JWTSVIDParams{
+		SpiffeID: req.Jsr.SpiffeId,
+		TTL:      time.Duration(ttl) * time.Second,
+		Audience: req.Jsr.Audience,
+		Claims:   entry.JwtSvidClaims,
+		EntryID:  entry.EntryId,
+		AgentID:  agentID,
+	})
 	if err != nil {
 		h.c.Log.Error(err)
 		return nil, err
@@ -464,11 +583,15 @@ func (h *Handler) AuthorizeCall(ctx context.Context, fullMethod string) (context
 
 		if err := h.validateAgentSVID(ctx, peerCert); err != nil {
 			h.c.Log.Error(err)
+			if _, ok := err.(agentBannedError); ok {
+				return nil, nodeutil.AgentBannedError()
+			}
 			return nil, status.Error(codes.PermissionDenied, "agent is not attested or no longer valid")
 		}
 
 		ctx = withPeerCertificate(ctx, peerCert)
-	case "/spire.api.node.Node/FetchX509CASVID":
+	case "/spire.api.node.Node/FetchX509CASVID",
+		"/spire.api.node.Node/PushJWTKeyUpstream":
 		peerCert, err := getPeerCertificateFromRequestContext(ctx)
 		if err != nil {
 			h.c.Log.Error(err)
@@ -509,6 +632,29 @@ func (h *Handler) isAttested(ctx context.Context, baseSpiffeID string) (bool, er
 	return false, nil
 }
 
+// agentBannedError is returned when validating the SVID of a banned agent
+type agentBannedError struct {
+	agentID string
+}
+
+func (e agentBannedError) Error() string {
+	return fmt.Sprintf("agent %q is banned", e.agentID)
+}
+
+// isBanned returns true if the node has attested before and is banned
+func (h *Handler) isBanned(ctx context.Context, agentID string) (bool, error) {
+	ds := h.c.Catalog.GetDataStore()
+
+	resp, err := ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{
+		SpiffeId: agentID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return resp.Node != nil && resp.Node.Banned, nil
+}
+
 func (h *Handler) validateAgentSVID(ctx context.Context, cert *x509.Certificate) error {
 	ds := h.c.Catalog.GetDataStore()
 
@@ -537,6 +683,20 @@ func (h *Handler) validateAgentSVID(ctx context.Context, cert *x509.Certificate)
 	if node == nil {
 		return fmt.Errorf("agent %q is not attested", agentID)
 	}
+	// banned agents also have their SVID revoked, so check this first to let
+	// the agent know why it is refused
+	if node.Banned {
+		return agentBannedError{agentID: agentID}
+	}
+
+	revoked, err := h.isRevoked(ctx, cert)
+	if err != nil {
+		return err
+	}
+	if revoked {
+		return fmt.Errorf("agent %q SVID has been revoked", agentID)
+	}
+
 	if node.CertSerialNumber != cert.SerialNumber.String() {
 		return fmt.Errorf("agent %q SVID does not match expected serial number", agentID)
 	}
@@ -555,9 +715,28 @@ func (h *Handler) validateDownstreamSVID(ctx context.Context, cert *x509.Certifi
 		return nil, fmt.Errorf("peer %q SVID has expired", peerID)
 	}
 
+	revoked, err := h.isRevoked(ctx, cert)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, fmt.Errorf("peer %q SVID has been revoked", peerID)
+	}
+
 	return h.getDownstreamEntry(ctx, peerID)
 }
 
+func (h *Handler) isRevoked(ctx context.Context, cert *x509.Certificate) (bool, error) {
+	ds := h.c.Catalog.GetDataStore()
+	resp, err := ds.FetchRevokedCertificate(ctx, &datastore.FetchRevokedCertificateRequest{
+		SerialNumber: cert.SerialNumber.String(),
+	})
+	if err != nil {
+		return false, err
+	}
+	return resp.RevokedCertificate != nil, nil
+}
+
 func (h *Handler) doAttestChallengeResponse(ctx context.Context,
 	nodeStream node.Node_AttestServer,
 	attestStream nodeattestor.NodeAttestor_AttestClient,
@@ -639,20 +818,49 @@ func (h *Handler) attestToken(ctx context.Context, attestationData *common.Attes
 		return nil, errors.New("invalid join token")
 	}
 
-	_, err = ds.DeleteJoinToken(ctx, &datastore.DeleteJoinTokenRequest{
-		Token: tokenValue,
+	if time.Unix(t.Expiry, 0).Before(h.c.Clock.Now()) {
+		_, err = ds.DeleteJoinToken(ctx, &datastore.DeleteJoinTokenRequest{
+			Token: tokenValue,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.New("join token expired")
+	}
+
+	// Every node attesting with a multi-use token needs its own agent ID.
+	// Since the token is still valid after being used, the agent ID holds a
+	// hash of the token instead of the token itself.
+	if t.MaxUses > 1 {
+		u, err := uuid.NewV4()
+		if err != nil {
+			return nil, err
+		}
+		tokenHash := sha256.Sum256([]byte(tokenValue))
+		agentID = (&url.URL{
+			Scheme: "spiffe",
+			Host:   h.c.TrustDomain.Host,
+			Path:   path.Join("spire", "agent", "join_token", hex.EncodeToString(tokenHash[:]), u.String()),
+		}).String()
+	}
+
+	// Using the token also creates its registration entries for the agent
+	consumeResp, err := ds.ConsumeJoinToken(ctx, &datastore.ConsumeJoinTokenRequest{
+		Token:   tokenValue,
+		AgentId: agentID,
 	})
 	if err != nil {
 		return nil, err
 	}
-
-	if time.Unix(t.Expiry, 0).Before(h.c.Clock.Now()) {
-		return nil, errors.New("join token expired")
+	if consumeResp.JoinToken == nil {
+		// the token was used up in the meantime
+		return nil, errors.New("no such token")
 	}
 
 	// If we're here, the token is valid
 	return &nodeattestor.AttestResponse{
-		AgentId: agentID,
+		AgentId:   agentID,
+		Selectors: consumeResp.JoinToken.NodeSelectors,
 	}, nil
 }
 
@@ -717,6 +925,10 @@ func (h *Handler) updateNodeSelectors(ctx context.Context,
 		return err
 	}
 
+	if updater, ok := h.c.EntryFetcher.(NodeSelectorsUpdater); ok {
+		updater.UpdateNodeSelectors(baseSpiffeID, selectors)
+	}
+
 	return nil
 }
 
@@ -727,7 +939,7 @@ func (h *Handler) getAttestResponse(ctx context.Context,
 	svids := make(map[string]*node.X509SVID)
 	svids[baseSpiffeID] = makeX509SVID(svid)
 
-	regEntries, err := regentryutil.FetchRegistrationEntries(ctx, h.c.Catalog.GetDataStore(), baseSpiffeID)
+	regEntries, _, err := h.c.EntryFetcher.FetchAuthorizedEntries(ctx, baseSpiffeID)
 	if err != nil {
 		return nil, err
 	}
@@ -839,7 +1051,7 @@ func (h *Handler) signCSRsLegacy(ctx context.Context,
 			}
 		} else {
 			signLog.Debug("Signing SVID")
-			svid, err := h.buildSVID(ctx, csr.SpiffeID, csr, regEntriesMap)
+			svid, err := h.buildSVID(ctx, csr.SpiffeID, callerID, csr, regEntriesMap)
 			if err != nil {
 				return nil, err
 			}
@@ -918,7 +1130,7 @@ func (h *Handler) signCSRs(ctx context.Context,
 			}
 		} else {
 			signLog.Debug("Signing SVID")
-			svid, err := h.buildSVID(ctx, entryID, csr, regEntriesMap)
+			svid, err := h.buildSVID(ctx, entryID, callerID, csr, regEntriesMap)
 			if err != nil {
 				return nil, nil, err
 			}
@@ -931,7 +1143,7 @@ func (h *Handler) signCSRs(ctx context.Context,
 	return svids, spiffeIDs, nil
 }
 
-func (h *Handler) buildSVID(ctx context.Context, id string, csr *CSR, regEntries map[string]*common.RegistrationEntry) (*node.X509SVID, error) {
+func (h *Handler) buildSVID(ctx context.Context, id, agentID string, csr *CSR, regEntries map[string]*common.RegistrationEntry) (*node.X509SVID, error) {
 	entry, ok := regEntries[id]
 	if !ok {
 		var idType string
@@ -948,6 +1160,9 @@ func (h *Handler) buildSVID(ctx context.Context, id string, csr *CSR, regEntries
 		PublicKey: csr.PublicKey,
 		TTL:       time.Duration(entry.Ttl) * time.Second,
 		DNSList:   entry.DnsNames,
+		Template:  entry.X509SvidTemplate,
+		EntryID:   entry.EntryId,
+		AgentID:   agentID,
 	})
 	if err != nil {
 		return nil, err
@@ -959,6 +1174,7 @@ func (h *Handler) buildBaseSVID(ctx context.Context, csr *CSR) (*node.X509SVID,
 	svid, err := h.c.ServerCA.SignX509SVID(ctx, ca.X509SVIDParams{
 		SpiffeID:  csr.SpiffeID,
 		PublicKey: csr.PublicKey,
+		AgentID:   csr.SpiffeID,
 	})
 	if err != nil {
 		return nil, nil, err
@@ -967,12 +1183,27 @@ func (h *Handler) buildBaseSVID(ctx context.Context, csr *CSR) (*node.X509SVID,
 	return makeX509SVID(svid), svid[0], nil
 }
 
+// buildCASVID signs a downstream CA. The downstream CA is recorded along with
+// the agent it was issued through so that it can be revoked if the agent is
+// evicted.
 func (h *Handler) buildCASVID(ctx context.Context, params ca.X509CASVIDParams) (*node.X509SVID, error) {
 	svid, err := h.c.ServerCA.SignX509CASVID(ctx, params)
 	if err != nil {
 		return nil, err
 	}
 
+	ds := h.c.Catalog.GetDataStore()
+	if _, err := ds.CreateDownstreamCA(ctx, &datastore.CreateDownstreamCARequest{
+		DownstreamCa: &datastore.DownstreamCA{
+			SerialNumber: svid[0].SerialNumber.String(),
+			SpiffeId:     params.SpiffeID,
+			AgentId:      params.AgentID,
+			ExpiresAt:    svid[0].NotAfter.Unix(),
+		},
+	}); err != nil {
+		return nil, err
+	}
+
 	return makeX509SVID(svid), nil
 }
 
@@ -1000,6 +1231,31 @@ func (h *Handler) getBundlesForEntries(ctx context.Context, regEntries []*common
 	return bundles, nil
 }
 
+// fetchDeletedEntryIDs fetches the IDs of the registration entries deleted
+// after the given revision. Entries deleted after the authorized entries were
+// fetched are left out; they are reported on the next request.
+func (h *Handler) fetchDeletedEntryIDs(ctx context.Context, revision int64, regEntries []*common.RegistrationEntry) ([]string, error) {
+	resp, err := h.c.Catalog.GetDataStore().ListRegistrationEntryTombstones(ctx, &datastore.ListRegistrationEntryTombstonesRequest{
+		AfterRevision: revision,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	authorized := make(map[string]bool, len(regEntries))
+	for _, entry := range regEntries {
+		authorized[entry.EntryId] = true
+	}
+
+	var entryIDs []string
+	for _, entryID := range resp.EntryIds {
+		if !authorized[entryID] {
+			entryIDs = append(entryIDs, entryID)
+		}
+	}
+	return entryIDs, nil
+}
+
 // getBundle fetches a bundle from the datastore, by trust domain
 func (h *Handler) getBundle(ctx context.Context, trustDomainID string) (*common.Bundle, error) {
 	ds := h.c.Catalog.GetDataStore()
@@ -1016,6 +1272,21 @@ func (h *Handler) getBundle(ctx context.Context, trustDomainID string) (*common.
 	return resp.Bundle, nil
 }
 
+func validateJWTKey(jwtKey *common.PublicKey) error {
+	switch {
+	case jwtKey == nil:
+		return errors.New("request missing JWT key")
+	case jwtKey.Kid == "":
+		return errors.New("JWT key is missing key ID")
+	case jwtKey.NotAfter == 0:
+		return errors.New("JWT key is missing expiration")
+	}
+	if _, err := x509.ParsePKIXPublicKey(jwtKey.PkixBytes); err != nil {
+		return fmt.Errorf("JWT key is malformed: %v", err)
+	}
+	return nil
+}
+
 type CSR struct {
 	SpiffeID  string
 	PublicKey crypto.PublicKey
@@ -1132,6 +1403,64 @@ func getSpiffeIDFromCert(cert *x509.Certificate) (string, error) {
 	return spiffeID.String(), nil
 }
 
+// makeX509SVIDUpdate builds the update sent to the agent. If the agent
+// provided the revision it last synchronized to, and that revision is not too
+// far behind, only the entries and bundles changed since then are included,
+// along with the IDs of the entries that were deleted. The digest of all
+// authorized entry IDs lets the agent detect entries it should no longer
+// hold (e.g. entries no longer authorized due to a change in the agent
+// selectors) and fall back to a full resync.
+func makeX509SVIDUpdate(lastRevision, revision int64, deletedEntryIDs []string,
+	svids map[string]*node.X509SVID, regEntries []*common.RegistrationEntry, bundles map[string]*common.Bundle) *node.X509SVIDUpdate {
+	if !isDeltaUpdate(lastRevision, revision) {
+		return &node.X509SVIDUpdate{
+			Svids:               svids,
+			RegistrationEntries: regEntries,
+			Bundles:             bundles,
+			Revision:            revision,
+		}
+	}
+
+	entryIDs := make([]string, 0, len(regEntries))
+	var changedEntries []*common.RegistrationEntry
+	changedBundles := make(map[string]*common.Bundle)
+	for _, entry := range regEntries {
+		entryIDs = append(entryIDs, entry.EntryId)
+		if entry.RevisionNumber <= lastRevision {
+			continue
+		}
+		changedEntries = append(changedEntries, entry)
+		// The agent may not have the bundles an updated entry federates
+		// with, so they are always included.
+		for _, trustDomainID := range entry.FederatesWith {
+			if bundle, ok := bundles[trustDomainID]; ok {
+				changedBundles[trustDomainID] = bundle
+			}
+		}
+	}
+	for trustDomainID, bundle := range bundles {
+		if bundle.RevisionNumber > lastRevision {
+			changedBundles[trustDomainID] = bundle
+		}
+	}
+
+	return &node.X509SVIDUpdate{
+		Svids:               svids,
+		RegistrationEntries: changedEntries,
+		Bundles:             changedBundles,
+		Revision:            revision,
+		Delta:               true,
+		DeletedEntryIds:     deletedEntryIDs,
+		EntryIdsDigest:      util.DeriveEntryIDsDigest(entryIDs),
+	}
+}
+
+// isDeltaUpdate returns true if an agent last synchronized to the given
+// revision can be sent only the changes up to the current revision.
+func isDeltaUpdate(lastRevision, revision int64) bool {
+	return lastRevision > 0 && lastRevision <= revision && revision-lastRevision <= maxRevisionGap
+}
+
 func makeX509SVID(svid []*x509.Certificate) *node.X509SVID {
 	var certChain []byte
 	for _, cert := range svid {
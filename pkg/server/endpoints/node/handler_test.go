@@ -1,28 +1,37 @@
 package node
 
 import (
+	"crypto/sha256"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"math/big"
 	"net"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	jwt "github.com/dgrijalva/jwt-go"
 	"github.com/gogo/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/spire/pkg/common/auth"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/spiffe/spire/pkg/common/nodeutil"
+	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_common "github.com/spiffe/spire/pkg/common/telemetry/common"
 	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
 	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/pkg/server/ca"
+	"github.com/spiffe/spire/pkg/server/issuancelog"
 	"github.com/spiffe/spire/proto/spire/api/node"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/proto/spire/server/datastore"
@@ -117,10 +126,16 @@ func (s *HandlerSuite) SetupTest() {
 
 	s.serverCA = fakeserverca.New(s.T(), trustDomain, &fakeserverca.Options{
 		Clock: s.clock,
+		IssuanceLog: issuancelog.New(issuancelog.Config{
+			DataStore: s.ds,
+			Log:       log,
+			Metrics:   telemetry.Blackhole{},
+			Clock:     s.clock,
+		}),
 	})
 	s.bundle = bundleutil.BundleProtoFromRootCAs(trustDomainID, s.serverCA.Bundle())
 
-	s.createBundle(s.bundle)
+	s.bundle = s.createBundle(s.bundle)
 
 	// Create server and agent SVIDs for TLS communication
 	serverSVID := s.makeSVID(serverID)
@@ -314,7 +329,7 @@ func (s *HandlerSuite) TestAttestSuccessWithDeprecatedCSR() {
 
 func (s *HandlerSuite) testAttestSuccess(csr []byte) {
 	// Create a federated bundle to return with the SVID update
-	s.createBundle(otherDomainBundle)
+	federatedBundle := s.createBundle(otherDomainBundle)
 
 	// Create a registration entry to return with the SVID update
 	entry := s.createRegistrationEntry(&common.RegistrationEntry{
@@ -334,7 +349,7 @@ func (s *HandlerSuite) testAttestSuccess(csr []byte) {
 
 	// assert update contents
 	s.Equal([]*common.RegistrationEntry{entry}, upd.RegistrationEntries)
-	s.assertBundlesInUpdate(upd, otherDomainBundle)
+	s.assertBundlesInUpdate(upd, federatedBundle)
 	svidChain := s.assertSVIDsInUpdate(upd, map[string]string{agentID: agentID})[0]
 
 	// Assert an attested node entry has been created
@@ -345,6 +360,12 @@ func (s *HandlerSuite) testAttestSuccess(csr []byte) {
 	s.Equal(svidChain[0].SerialNumber.String(), attestedNode.CertSerialNumber)
 	s.WithinDuration(svidChain[0].NotAfter, time.Unix(attestedNode.CertNotAfter, 0), 0)
 
+	// Assert the agent SVID has been recorded in the issuance log
+	issuedSVID := s.requireIssuedSVID(agentID)
+	s.Equal(svidChain[0].SerialNumber.String(), issuedSVID.Id)
+	s.Equal(agentID, issuedSVID.AgentId)
+	s.Empty(issuedSVID.EntryId)
+
 	// No selectors were returned and no resolvers were available, so the node
 	// selectors should be empty.
 	s.Empty(s.getNodeSelectors(agentID))
@@ -407,6 +428,36 @@ func (s *HandlerSuite) TestAttestReattestation() {
 	s.Equal(s.expectedMetrics.AllMetrics(), s.metrics.AllMetrics())
 }
 
+func (s *HandlerSuite) TestAttestWithBannedAgent() {
+	s.addAttestor("test", fakeservernodeattestor.Config{
+		Data: map[string]string{"data": "id"},
+	})
+
+	s.createAttestedNode(&common.AttestedNode{
+		SpiffeId:         agentID,
+		CertSerialNumber: "1",
+	})
+	s.setAttestedNodeBanned(agentID, true)
+
+	s.requireAttestFailure(&node.AttestRequest{
+		AttestationData: makeAttestationData("test", "data"),
+		Csr:             s.makeCSR(agentID),
+	}, agentID, codes.PermissionDenied, "agent is banned")
+
+	// the node is left untouched
+	attestedNode := s.fetchAttestedNode(agentID)
+	s.Require().NotNil(attestedNode)
+	s.Equal("1", attestedNode.CertSerialNumber)
+	s.True(attestedNode.Banned)
+
+	// once unbanned, the agent can attest again
+	s.setAttestedNodeBanned(agentID, false)
+	s.requireAttestSuccess(&node.AttestRequest{
+		AttestationData: makeAttestationData("test", "data"),
+		Csr:             s.makeCSR(agentID),
+	}, agentID)
+}
+
 func (s *HandlerSuite) TestAttestChallengeResponseSuccess() {
 	// Make sure reattestation is allowed by the attestor
 	s.addAttestor("test", fakeservernodeattestor.Config{
@@ -474,6 +525,86 @@ func (s *HandlerSuite) TestAttestWithValidJoinToken() {
 	s.Equal(s.expectedMetrics.AllMetrics(), s.metrics.AllMetrics())
 }
 
+func (s *HandlerSuite) TestAttestWithJoinTokenEntriesAndSelectors() {
+	_, err := s.ds.CreateJoinToken(context.Background(), &datastore.CreateJoinTokenRequest{
+		JoinToken: &datastore.JoinToken{
+			Token:  "TOKEN",
+			Expiry: s.clock.Now().Add(time.Second).Unix(),
+			NodeSelectors: []*common.Selector{
+				{Type: "token", Value: "batch"},
+			},
+			Entries: []*common.RegistrationEntry{
+				{
+					SpiffeId:  workloadID,
+					Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+				},
+			},
+		},
+	})
+	s.Require().NoError(err)
+
+	update := s.requireAttestSuccess(&node.AttestRequest{
+		AttestationData: makeAttestationData("join_token", "TOKEN"),
+		Csr:             s.makeCSR(joinTokenID),
+	}, joinTokenID)
+
+	// the token entries are created for the agent and returned right away
+	s.Require().Len(update.RegistrationEntries, 1)
+	s.Equal(joinTokenID, update.RegistrationEntries[0].ParentId)
+	s.Equal(workloadID, update.RegistrationEntries[0].SpiffeId)
+
+	s.Equal([]*common.Selector{
+		{Type: "token", Value: "batch"},
+	}, s.getNodeSelectors(joinTokenID))
+
+	s.Nil(s.fetchJoinToken("TOKEN"))
+
+	s.Equal(s.expectedMetrics.AllMetrics(), s.metrics.AllMetrics())
+}
+
+func (s *HandlerSuite) TestAttestWithMultiUseJoinToken() {
+	_, err := s.ds.CreateJoinToken(context.Background(), &datastore.CreateJoinTokenRequest{
+		JoinToken: &datastore.JoinToken{
+			Token:   "TOKEN",
+			Expiry:  s.clock.Now().Add(time.Second).Unix(),
+			MaxUses: 2,
+		},
+	})
+	s.Require().NoError(err)
+
+	attest := func() string {
+		update := s.requireAttestSuccess(&node.AttestRequest{
+			AttestationData: makeAttestationData("join_token", "TOKEN"),
+			Csr:             s.makeCSRWithoutURISAN(),
+		}, "")
+		s.Require().Len(update.Svids, 1)
+		for id := range update.Svids {
+			return id
+		}
+		return ""
+	}
+
+	// every node gets its own agent ID, which does not reveal the token
+	tokenHash := sha256.Sum256([]byte("TOKEN"))
+	agentIDPrefix := "spiffe://example.org/spire/agent/join_token/" + hex.EncodeToString(tokenHash[:]) + "/"
+	agentID1 := attest()
+	s.Require().True(strings.HasPrefix(agentID1, agentIDPrefix), "unexpected agent ID %q", agentID1)
+	s.NotContains(agentID1, "TOKEN")
+	token := s.fetchJoinToken("TOKEN")
+	s.Require().NotNil(token)
+	s.Equal(int32(1), token.Uses)
+
+	agentID2 := attest()
+	s.Require().True(strings.HasPrefix(agentID2, agentIDPrefix), "unexpected agent ID %q", agentID2)
+	s.NotEqual(agentID1, agentID2)
+	s.Nil(s.fetchJoinToken("TOKEN"))
+
+	s.requireAttestFailure(&node.AttestRequest{
+		AttestationData: makeAttestationData("join_token", "TOKEN"),
+		Csr:             s.makeCSRWithoutURISAN(),
+	}, noIDExpected, codes.Unknown, "failed to attest: no such token")
+}
+
 func (s *HandlerSuite) TestAttestWithOnlyAttestorSelectors() {
 	// configure the attestor to return selectors
 	s.addAttestor("test", fakeservernodeattestor.Config{
@@ -495,6 +626,39 @@ func (s *HandlerSuite) TestAttestWithOnlyAttestorSelectors() {
 	s.Equal(s.expectedMetrics.AllMetrics(), s.metrics.AllMetrics())
 }
 
+func (s *HandlerSuite) TestAttestWithEntryFetcher() {
+	s.addAttestor("test", fakeservernodeattestor.Config{
+		Data: map[string]string{"data": "id"},
+		Selectors: map[string][]string{
+			"id": {"test-attestor-value"},
+		},
+	})
+
+	// entries are fetched from the configured fetcher, which is told about
+	// the new node selectors first
+	entry := &common.RegistrationEntry{
+		EntryId:  "cached",
+		ParentId: agentID,
+		SpiffeId: workloadID,
+	}
+	fetcher := &fakeEntryFetcher{
+		entries: []*common.RegistrationEntry{entry},
+	}
+	s.handler.c.EntryFetcher = fetcher
+
+	upd := s.requireAttestSuccess(&node.AttestRequest{
+		AttestationData: makeAttestationData("test", "data"),
+		Csr:             s.makeCSR(agentID),
+	}, agentID)
+
+	s.RequireProtoListEqual([]*common.RegistrationEntry{entry}, upd.RegistrationEntries)
+	s.Equal(map[string][]*common.Selector{
+		agentID: {{Type: "test", Value: "test-attestor-value"}},
+	}, fetcher.nodeSelectors)
+
+	s.Equal(s.expectedMetrics.AllMetrics(), s.metrics.AllMetrics())
+}
+
 func (s *HandlerSuite) TestAttestWithOnlyResolverSelectors() {
 	// configure the attestor to return selectors
 	s.addAttestor("test", fakeservernodeattestor.Config{
@@ -610,7 +774,7 @@ func (s *HandlerSuite) TestFetchX509SVIDWithNoRegistrationEntries() {
 func (s *HandlerSuite) TestFetchX509SVIDWithNoCSRs() {
 	s.attestAgent()
 
-	s.createBundle(otherDomainBundle)
+	federatedBundle := s.createBundle(otherDomainBundle)
 	entry := s.createRegistrationEntry(&common.RegistrationEntry{
 		ParentId:      agentID,
 		SpiffeId:      workloadID,
@@ -619,8 +783,122 @@ func (s *HandlerSuite) TestFetchX509SVIDWithNoCSRs() {
 	upd := s.requireFetchX509SVIDSuccess(&node.FetchX509SVIDRequest{})
 
 	s.Equal([]*common.RegistrationEntry{entry}, upd.RegistrationEntries)
-	s.assertBundlesInUpdate(upd, otherDomainBundle)
+	s.assertBundlesInUpdate(upd, federatedBundle)
 	s.Empty(upd.Svids)
+	s.Equal(entry.RevisionNumber, upd.Revision)
+	s.False(upd.Delta)
+}
+
+func (s *HandlerSuite) TestFetchX509SVIDWithRevision() {
+	s.attestAgent()
+
+	federatedBundle := s.createBundle(otherDomainBundle)
+	entry1 := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:      agentID,
+		SpiffeId:      workloadID,
+		FederatesWith: []string{otherDomainID},
+	})
+	entry2 := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId: agentID,
+		SpiffeId: "spiffe://example.org/workload2",
+	})
+	upd := s.requireFetchX509SVIDSuccess(&node.FetchX509SVIDRequest{})
+	s.False(upd.Delta)
+	revision := upd.Revision
+
+	// Nothing changed since the last revision
+	upd = s.requireFetchX509SVIDSuccess(&node.FetchX509SVIDRequest{
+		Revision: revision,
+	})
+	s.True(upd.Delta)
+	s.Equal(revision, upd.Revision)
+	s.Empty(upd.RegistrationEntries)
+	s.Empty(upd.Bundles)
+	s.Empty(upd.DeletedEntryIds)
+	s.Equal(util.DeriveEntryIDsDigest([]string{entry1.EntryId, entry2.EntryId}), upd.EntryIdsDigest)
+
+	// Update one entry, delete another and create a new one. Only the
+	// changes, and the bundles related to them, are expected.
+	entry1.Ttl = 60
+	entry1 = s.updateRegistrationEntry(entry1)
+	s.deleteRegistrationEntry(entry2.EntryId)
+	entry3 := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId: agentID,
+		SpiffeId: "spiffe://example.org/workload3",
+	})
+	upd = s.requireFetchX509SVIDSuccess(&node.FetchX509SVIDRequest{
+		Revision: revision,
+	})
+	s.True(upd.Delta)
+	s.Equal(entry3.RevisionNumber, upd.Revision)
+	s.ElementsMatch([]*common.RegistrationEntry{entry1, entry3}, upd.RegistrationEntries)
+	s.Equal([]string{entry2.EntryId}, upd.DeletedEntryIds)
+	s.Len(upd.Bundles, 1)
+	s.True(proto.Equal(federatedBundle, upd.Bundles[otherDomainID]))
+	s.Equal(util.DeriveEntryIDsDigest([]string{entry1.EntryId, entry3.EntryId}), upd.EntryIdsDigest)
+}
+
+func (s *HandlerSuite) TestFetchX509SVIDWithRevisionAheadOfServer() {
+	s.attestAgent()
+
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId: agentID,
+		SpiffeId: workloadID,
+	})
+	upd := s.requireFetchX509SVIDSuccess(&node.FetchX509SVIDRequest{
+		Revision: entry.RevisionNumber + 1,
+	})
+	s.False(upd.Delta)
+	s.Equal(entry.RevisionNumber, upd.Revision)
+	s.Equal([]*common.RegistrationEntry{entry}, upd.RegistrationEntries)
+	s.assertBundlesInUpdate(upd)
+}
+
+func (s *HandlerSuite) TestFetchX509SVIDWithLargeRevisionGap() {
+	entries := []*common.RegistrationEntry{
+		{EntryId: "1", RevisionNumber: 1},
+		{EntryId: "2", RevisionNumber: maxRevisionGap + 2},
+	}
+	bundles := map[string]*common.Bundle{
+		trustDomainID: {TrustDomainId: trustDomainID, RevisionNumber: 1},
+	}
+
+	// Within the gap only the changes are sent
+	upd := makeX509SVIDUpdate(2, maxRevisionGap+2, []string{"3"}, nil, entries, bundles)
+	s.True(upd.Delta)
+	s.Equal(entries[1:], upd.RegistrationEntries)
+	s.Empty(upd.Bundles)
+	s.Equal([]string{"3"}, upd.DeletedEntryIds)
+
+	// Beyond the gap everything is sent
+	upd = makeX509SVIDUpdate(1, maxRevisionGap+2, nil, nil, entries, bundles)
+	s.False(upd.Delta)
+	s.Equal(int64(maxRevisionGap+2), upd.Revision)
+	s.Equal(entries, upd.RegistrationEntries)
+	s.Equal(bundles, upd.Bundles)
+	s.Empty(upd.DeletedEntryIds)
+	s.Empty(upd.EntryIdsDigest)
+}
+
+func (s *HandlerSuite) TestFetchX509SVIDWithEntryFetcher() {
+	s.attestAgent()
+
+	// entries are fetched from the configured fetcher rather than the
+	// datastore
+	entry := &common.RegistrationEntry{
+		EntryId:  "cached",
+		ParentId: agentID,
+		SpiffeId: workloadID,
+	}
+	s.handler.c.EntryFetcher = EntryFetcherFunc(func(ctx context.Context, id string) ([]*common.RegistrationEntry, int64, error) {
+		s.Equal(agentID, id)
+		return []*common.RegistrationEntry{entry}, 42, nil
+	})
+
+	upd := s.requireFetchX509SVIDSuccess(&node.FetchX509SVIDRequest{})
+	s.RequireProtoListEqual([]*common.RegistrationEntry{entry}, upd.RegistrationEntries)
+	s.Equal(int64(42), upd.Revision)
+	s.assertBundlesInUpdate(upd)
 }
 
 func (s *HandlerSuite) TestFetchX509SVIDWithMalformedCSR() {
@@ -741,7 +1019,7 @@ func (s *HandlerSuite) TestFetchX509CASVIDWithUnauthorizedDownstreamCSR() {
 func (s *HandlerSuite) TestFetchX509CASVID() {
 	s.attestAgent()
 
-	s.createRegistrationEntry(&common.RegistrationEntry{
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
 		ParentId:   trustDomainID,
 		SpiffeId:   agentID,
 		Downstream: true,
@@ -762,6 +1040,93 @@ func (s *HandlerSuite) TestFetchX509CASVID() {
 	s.Require().Len(chain, 1)
 	s.Empty(chain[0].DNSNames)
 	s.Equal("CN=FAKE SERVER CA,OU=DOWNSTREAM-1", chain[0].Subject.String())
+
+	// the downstream CA is recorded so it can be revoked along with the
+	// agent it was issued through
+	dsResp, err := s.ds.ListDownstreamCAs(context.Background(), &datastore.ListDownstreamCAsRequest{
+		AgentId: trustDomainID,
+	})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*datastore.DownstreamCA{
+		{
+			SerialNumber: chain[0].SerialNumber.String(),
+			SpiffeId:     trustDomainID,
+			AgentId:      trustDomainID,
+			ExpiresAt:    chain[0].NotAfter.Unix(),
+		},
+	}, dsResp.DownstreamCas)
+
+	issuedSVID := s.requireIssuedSVID(trustDomainID)
+	s.Equal(datastore.IssuedSVID_X509_CA_SVID, issuedSVID.Type)
+	s.Equal(chain[0].SerialNumber.String(), issuedSVID.Id)
+	s.Equal(entry.EntryId, issuedSVID.EntryId)
+	s.Equal(trustDomainID, issuedSVID.AgentId)
+}
+
+func (s *HandlerSuite) TestPushJWTKeyUpstream() {
+	s.attestAgent()
+
+	s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:   trustDomainID,
+		SpiffeId:   agentID,
+		Downstream: true,
+	})
+
+	pkixBytes, err := x509.MarshalPKIXPublicKey(testKey.Public())
+	s.Require().NoError(err)
+	jwtKey := &common.PublicKey{
+		Kid:       "DOWNSTREAM",
+		PkixBytes: pkixBytes,
+		NotAfter:  s.clock.Now().Add(time.Hour).Unix(),
+	}
+
+	resp, err := s.attestedClient.PushJWTKeyUpstream(context.Background(), &node.PushJWTKeyUpstreamRequest{
+		JwtKey: jwtKey,
+	})
+	s.Require().NoError(err)
+	expectedKeys := append(s.bundle.JwtSigningKeys, jwtKey)
+	s.RequireProtoListEqual(expectedKeys, resp.JwtSigningKeys)
+
+	// the key is appended to the trust domain bundle
+	bundleResp, err := s.ds.FetchBundle(context.Background(), &datastore.FetchBundleRequest{
+		TrustDomainId: trustDomainID,
+	})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual(expectedKeys, bundleResp.Bundle.JwtSigningKeys)
+}
+
+func (s *HandlerSuite) TestPushJWTKeyUpstreamWithInvalidKey() {
+	s.attestAgent()
+
+	s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:   trustDomainID,
+		SpiffeId:   agentID,
+		Downstream: true,
+	})
+
+	for _, tt := range []struct {
+		jwtKey *common.PublicKey
+		err    string
+	}{
+		{jwtKey: nil, err: "request missing JWT key"},
+		{jwtKey: &common.PublicKey{NotAfter: 1, PkixBytes: []byte("KEY")}, err: "JWT key is missing key ID"},
+		{jwtKey: &common.PublicKey{Kid: "KID", PkixBytes: []byte("KEY")}, err: "JWT key is missing expiration"},
+		{jwtKey: &common.PublicKey{Kid: "KID", NotAfter: 1, PkixBytes: []byte("KEY")}, err: "JWT key is malformed"},
+	} {
+		_, err := s.attestedClient.PushJWTKeyUpstream(context.Background(), &node.PushJWTKeyUpstreamRequest{
+			JwtKey: tt.jwtKey,
+		})
+		s.RequireGRPCStatusContains(err, codes.InvalidArgument, tt.err)
+	}
+}
+
+func (s *HandlerSuite) TestPushJWTKeyUpstreamWithUnauthorizedDownstream() {
+	s.attestAgent()
+
+	_, err := s.attestedClient.PushJWTKeyUpstream(context.Background(), &node.PushJWTKeyUpstreamRequest{
+		JwtKey: &common.PublicKey{Kid: "KID"},
+	})
+	s.RequireGRPCStatus(err, codes.PermissionDenied, "peer is not a valid downstream SPIRE server")
 }
 
 func (s *HandlerSuite) TestFetchX509SVIDWithWorkloadCSR() {
@@ -779,6 +1144,11 @@ func (s *HandlerSuite) TestFetchX509SVIDWithWorkloadCSR() {
 	s.Equal([]*common.RegistrationEntry{entry}, upd.RegistrationEntries)
 	s.assertBundlesInUpdate(upd)
 	s.assertSVIDsInUpdate(upd, map[string]string{entry.EntryId: workloadID})
+
+	issuedSVID := s.requireIssuedSVID(workloadID)
+	s.Equal(datastore.IssuedSVID_X509_SVID, issuedSVID.Type)
+	s.Equal(entry.EntryId, issuedSVID.EntryId)
+	s.Equal(agentID, issuedSVID.AgentId)
 }
 
 func (s *HandlerSuite) TestFetchX509SVIDWithWorkloadCSRLegacy() {
@@ -820,6 +1190,32 @@ func (s *HandlerSuite) TestFetchX509SVIDWithSingleDNS() {
 	s.Equal("somehost1", chains[0][0].Subject.CommonName)
 }
 
+func (s *HandlerSuite) TestFetchX509SVIDWithX509SVIDTemplate() {
+	s.attestAgent()
+
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId: agentID,
+		SpiffeId: workloadID,
+		X509SvidTemplate: &common.X509SVIDTemplate{
+			Subject: &common.X509Subject{
+				OrganizationalUnit: []string{"gateway"},
+				CommonName:         "gateway",
+			},
+		},
+	})
+
+	upd := s.requireFetchX509SVIDSuccess(&node.FetchX509SVIDRequest{
+		Csrs: s.makeCSRs(entry.EntryId, workloadID),
+	})
+
+	s.assertBundlesInUpdate(upd)
+	chains := s.assertSVIDsInUpdate(upd, map[string]string{entry.EntryId: workloadID})
+	s.Equal([]string{"gateway"}, chains[0][0].Subject.OrganizationalUnit)
+	s.Equal("gateway", chains[0][0].Subject.CommonName)
+	s.Require().Len(chains[0][0].URIs, 1)
+	s.Equal(workloadID, chains[0][0].URIs[0].String())
+}
+
 func (s *HandlerSuite) TestFetchX509SVIDWithSingleDNSLegacy() {
 	dnsList := []string{"somehost1"}
 
@@ -953,7 +1349,7 @@ func (s *HandlerSuite) TestFetchJWTSVIDWithUnauthorizedSPIFFEID() {
 func (s *HandlerSuite) TestFetchJWTSVIDWithWorkloadID() {
 	s.attestAgent()
 
-	s.createRegistrationEntry(&common.RegistrationEntry{
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
 		ParentId: agentID,
 		SpiffeId: workloadID,
 	})
@@ -968,6 +1364,64 @@ func (s *HandlerSuite) TestFetchJWTSVIDWithWorkloadID() {
 	s.NotEmpty(svid.Token)
 	s.Equal(s.clock.Now().Unix(), svid.IssuedAt)
 	s.Equal(s.clock.Now().Add(ca.DefaultJWTSVIDTTL).Unix(), svid.ExpiresAt)
+
+	jti, err := jwtsvid.GetTokenID(svid.Token)
+	s.Require().NoError(err)
+	issuedSVID := s.requireIssuedSVID(workloadID)
+	s.Equal(datastore.IssuedSVID_JWT_SVID, issuedSVID.Type)
+	s.Equal(jti, issuedSVID.Id)
+	s.Equal(entry.EntryId, issuedSVID.EntryId)
+	s.Equal(agentID, issuedSVID.AgentId)
+	s.Equal(svid.ExpiresAt, issuedSVID.NotAfter)
+}
+
+func (s *HandlerSuite) TestFetchJWTSVIDWithEntryTTLAndClaims() {
+	s.attestAgent()
+
+	s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:   agentID,
+		SpiffeId:   workloadID,
+		JwtSvidTtl: 60,
+		JwtSvidClaims: map[string]string{
+			"tenant": "acme",
+		},
+	})
+
+	// the entry TTL is used when the request has no TTL
+	svid := s.requireFetchJWTSVIDSuccess(&node.FetchJWTSVIDRequest{
+		Jsr: &node.JSR{
+			SpiffeId: workloadID,
+			Audience: []string{"audience"},
+		},
+	})
+	s.Equal(s.clock.Now().Add(time.Minute).Unix(), svid.ExpiresAt)
+
+	token, _, err := new(jwt.Parser).ParseUnverified(svid.Token, jwt.MapClaims{})
+	s.Require().NoError(err)
+	claims, ok := token.Claims.(jwt.MapClaims)
+	s.Require().True(ok)
+	s.Equal("acme", claims["tenant"])
+	s.Equal(workloadID, claims["sub"])
+
+	// the entry TTL caps the requested TTL
+	svid = s.requireFetchJWTSVIDSuccess(&node.FetchJWTSVIDRequest{
+		Jsr: &node.JSR{
+			SpiffeId: workloadID,
+			Audience: []string{"audience"},
+			Ttl:      3600,
+		},
+	})
+	s.Equal(s.clock.Now().Add(time.Minute).Unix(), svid.ExpiresAt)
+
+	// shorter requested TTLs are honored
+	svid = s.requireFetchJWTSVIDSuccess(&node.FetchJWTSVIDRequest{
+		Jsr: &node.JSR{
+			SpiffeId: workloadID,
+			Audience: []string{"audience"},
+			Ttl:      30,
+		},
+	})
+	s.Equal(s.clock.Now().Add(30*time.Second).Unix(), svid.ExpiresAt)
 }
 
 func (s *HandlerSuite) TestAuthorizeCallUnhandledMethod() {
@@ -994,10 +1448,18 @@ func (s *HandlerSuite) TestAuthorizeCallForFetchJWTSVID() {
 }
 
 func (s *HandlerSuite) TestAuthorizeCallForFetchX509CASVID() {
+	s.testAuthorizeCallRequiringDownstreamSVID("FetchX509CASVID")
+}
+
+func (s *HandlerSuite) TestAuthorizeCallForPushJWTKeyUpstream() {
+	s.testAuthorizeCallRequiringDownstreamSVID("PushJWTKeyUpstream")
+}
+
+func (s *HandlerSuite) testAuthorizeCallRequiringDownstreamSVID(method string) {
 	peerCert := s.downstreamSVID[0]
 	peerCtx := withPeerCert(context.Background(), s.downstreamSVID)
 
-	const fullMethod = "/spire.api.node.Node/FetchX509CASVID"
+	fullMethod := fmt.Sprintf("/spire.api.node.Node/%s", method)
 
 	// no peer context
 	ctx, err := s.handler.AuthorizeCall(context.Background(), fullMethod)
@@ -1042,6 +1504,12 @@ func (s *HandlerSuite) TestAuthorizeCallForFetchX509CASVID() {
 	s.Require().True(ok, "context has downstream entry")
 	s.RequireProtoEqual(downstreamEntry, actualEntry)
 
+	// revoked certificate
+	s.revokeCertificate(peerCert)
+	ctx, err = s.handler.AuthorizeCall(peerCtx, fullMethod)
+	s.RequireGRPCStatus(err, codes.PermissionDenied, "peer is not a valid downstream SPIRE server")
+	s.Require().Nil(ctx)
+	s.assertLastLogMessage(`peer "spiffe://example.org/downstream" SVID has been revoked`)
 }
 
 func (s *HandlerSuite) testAuthorizeCallRequiringAgentSVID(method string) {
@@ -1098,6 +1566,21 @@ func (s *HandlerSuite) testAuthorizeCallRequiringAgentSVID(method string) {
 	s.RequireGRPCStatus(err, codes.PermissionDenied, "agent is not attested or no longer valid")
 	s.Require().Nil(ctx)
 	s.assertLastLogMessage(`agent "spiffe://example.org/spire/agent/test/id" SVID does not match expected serial number`)
+
+	// revoked certificate
+	s.updateAttestedNode(agentID, peerCert.SerialNumber.String(), peerCert.NotAfter)
+	s.revokeCertificate(peerCert)
+	ctx, err = s.handler.AuthorizeCall(peerCtx, fullMethod)
+	s.RequireGRPCStatus(err, codes.PermissionDenied, "agent is not attested or no longer valid")
+	s.Require().Nil(ctx)
+	s.assertLastLogMessage(`agent "spiffe://example.org/spire/agent/test/id" SVID has been revoked`)
+
+	// banned agent (the SVID is revoked as well, but the ban is reported)
+	s.setAttestedNodeBanned(agentID, true)
+	ctx, err = s.handler.AuthorizeCall(peerCtx, fullMethod)
+	s.Require().True(nodeutil.IsAgentBanned(err), "expected the agent banned status; got %v", err)
+	s.Require().Nil(ctx)
+	s.assertLastLogMessage(`agent "spiffe://example.org/spire/agent/test/id" is banned`)
 }
 
 func (s *HandlerSuite) addAttestor(name string, config fakeservernodeattestor.Config) {
@@ -1112,11 +1595,33 @@ func (s *HandlerSuite) addResolver(name string, config fakenoderesolver.Config)
 	s.catalog.AddNodeResolverNamed(name, p)
 }
 
-func (s *HandlerSuite) createBundle(bundle *common.Bundle) {
-	_, err := s.ds.CreateBundle(context.Background(), &datastore.CreateBundleRequest{
+func (s *HandlerSuite) createBundle(bundle *common.Bundle) *common.Bundle {
+	resp, err := s.ds.CreateBundle(context.Background(), &datastore.CreateBundleRequest{
 		Bundle: bundle,
 	})
 	s.Require().NoError(err)
+	return resp.Bundle
+}
+
+func (s *HandlerSuite) revokeCertificate(cert *x509.Certificate) {
+	_, err := s.ds.RevokeCertificate(context.Background(), &datastore.RevokeCertificateRequest{
+		RevokedCertificate: &datastore.RevokedCertificate{
+			SerialNumber: cert.SerialNumber.String(),
+			ExpiresAt:    cert.NotAfter.Unix(),
+		},
+	})
+	s.Require().NoError(err)
+}
+
+// requireIssuedSVID returns the last SVID recorded in the issuance log for
+// the given SPIFFE ID
+func (s *HandlerSuite) requireIssuedSVID(spiffeID string) *datastore.IssuedSVID {
+	resp, err := s.ds.ListIssuedSVIDs(context.Background(), &datastore.ListIssuedSVIDsRequest{
+		BySpiffeId: &wrappers.StringValue{Value: spiffeID},
+	})
+	s.Require().NoError(err)
+	s.Require().NotEmpty(resp.IssuedSvids, "no SVID issued for %q", spiffeID)
+	return resp.IssuedSvids[len(resp.IssuedSvids)-1]
 }
 
 func (s *HandlerSuite) createJoinToken(token string, expiresAt time.Time) {
@@ -1157,6 +1662,14 @@ func (s *HandlerSuite) updateAttestedNode(spiffeID, serialNumber string, notAfte
 	s.Require().NoError(err)
 }
 
+func (s *HandlerSuite) setAttestedNodeBanned(spiffeID string, banned bool) {
+	_, err := s.ds.SetAttestedNodeBanned(context.Background(), &datastore.SetAttestedNodeBannedRequest{
+		SpiffeId: spiffeID,
+		Banned:   banned,
+	})
+	s.Require().NoError(err)
+}
+
 func (s *HandlerSuite) fetchAttestedNode(spiffeID string) *common.AttestedNode {
 	resp, err := s.ds.FetchAttestedNode(context.Background(), &datastore.FetchAttestedNodeRequest{
 		SpiffeId: spiffeID,
@@ -1186,6 +1699,22 @@ func (s *HandlerSuite) createRegistrationEntry(entry *common.RegistrationEntry)
 	return resp.Entry
 }
 
+func (s *HandlerSuite) updateRegistrationEntry(entry *common.RegistrationEntry) *common.RegistrationEntry {
+	resp, err := s.ds.UpdateRegistrationEntry(context.Background(), &datastore.UpdateRegistrationEntryRequest{
+		Entry: entry,
+	})
+	s.Require().NoError(err)
+	s.Require().NotNil(resp.Entry)
+	return resp.Entry
+}
+
+func (s *HandlerSuite) deleteRegistrationEntry(entryID string) {
+	_, err := s.ds.DeleteRegistrationEntry(context.Background(), &datastore.DeleteRegistrationEntryRequest{
+		EntryId: entryID,
+	})
+	s.Require().NoError(err)
+}
+
 func (s *HandlerSuite) requireAttestSuccess(req *node.AttestRequest, expectedSPIFFE string, responses ...string) *node.X509SVIDUpdate {
 	expectedCounter := telemetry_server.StartNodeAPIAttestCall(s.expectedMetrics)
 	defer expectedCounter.Done(nil)
@@ -1505,3 +2034,22 @@ func withPeerCert(ctx context.Context, certChain []*x509.Certificate) context.Co
 		},
 	})
 }
+
+type fakeEntryFetcher struct {
+	entries       []*common.RegistrationEntry
+	nodeSelectors map[string][]*common.Selector
+}
+
+func (f *fakeEntryFetcher) FetchAuthorizedEntries(ctx context.Context, id string) ([]*common.RegistrationEntry, int64, error) {
+	if _, ok := f.nodeSelectors[id]; !ok {
+		return nil, 0, errors.New("node selectors not updated")
+	}
+	return f.entries, 1, nil
+}
+
+func (f *fakeEntryFetcher) UpdateNodeSelectors(spiffeID string, selectors []*common.Selector) {
+	if f.nodeSelectors == nil {
+		f.nodeSelectors = make(map[string][]*common.Selector)
+	}
+	f.nodeSelectors[spiffeID] = selectors
+}
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"path"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,11 +16,15 @@ import (
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/sirupsen/logrus"
 	"github.com/spiffe/spire/pkg/common/idutil"
+	"github.com/spiffe/spire/pkg/common/jwtsvid"
 	"github.com/spiffe/spire/pkg/common/peertracker"
 	"github.com/spiffe/spire/pkg/common/selector"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	telemetry_common "github.com/spiffe/spire/pkg/common/telemetry/common"
 	telemetry_registrationapi "github.com/spiffe/spire/pkg/common/telemetry/server/registrationapi"
+	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/server/authpolicy"
+	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/catalog"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
@@ -30,6 +36,27 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+const (
+	// issuedSVIDsPageSize is how many issued SVID records are fetched from
+	// the datastore at a time when listing issued SVIDs
+	issuedSVIDsPageSize = 1000
+
+	// watchEntriesPageSize is how many entries or entry events are fetched
+	// from the datastore at a time when watching entries
+	watchEntriesPageSize = 1000
+)
+
+// watchEntriesPollInterval is how often the datastore is polled for
+// registration entry events while watching entries
+var watchEntriesPollInterval = time.Second
+
+// adminOnlyMethods are the methods only admins may call, even when allowed
+// by the authorization policy
+var adminOnlyMethods = map[string]bool{
+	"MintX509SVID": true,
+	"MintJWTSVID":  true,
+}
+
 var isDNSLabel = regexp.MustCompile(`^[a-zA-Z0-9]([-]*[a-zA-Z0-9])+$`).MatchString
 
 //Service is used to register SPIFFE IDs, and the attestation logic that should
@@ -39,6 +66,36 @@ type Handler struct {
 	Metrics     telemetry.Metrics
 	Catalog     catalog.Catalog
 	TrustDomain url.URL
+	CAManager   CAManager
+
+	// ServerCA signs the SVIDs minted by MintX509SVID and MintJWTSVID
+	ServerCA ca.ServerCA
+
+	// MaxMintedSVIDTTL caps the TTL of minted SVIDs. If zero,
+	// ca.DefaultX509SVIDTTL is used.
+	MaxMintedSVIDTTL time.Duration
+
+	// Policy, if set, authorizes each call. Otherwise callers over the UDS
+	// may call every method and callers over TCP must be admins.
+	Policy PolicySource
+}
+
+// PolicySource provides the authorization policy currently in effect
+type PolicySource interface {
+	Policy() *authpolicy.Policy
+}
+
+// CAManager is the subset of the server CA manager used for operator driven
+// CA rotation.
+type CAManager interface {
+	X509CASlots() (current, next ca.SlotInfo)
+	JWTKeySlots() (current, next ca.SlotInfo)
+	PrepareNextX509CA(ctx context.Context) (ca.SlotInfo, error)
+	PrepareNextJWTKey(ctx context.Context) (ca.SlotInfo, error)
+	ActivateNextX509CA(ctx context.Context) (ca.SlotInfo, error)
+	ActivateNextJWTKey(ctx context.Context) (ca.SlotInfo, error)
+	TaintX509Authority(ctx context.Context, authorityID string) error
+	TaintJWTAuthority(ctx context.Context, kid string) error
 }
 
 //Creates an entry in the Registration table,
@@ -156,19 +213,40 @@ func (h *Handler) UpdateEntry(
 		return nil, errors.New("Request is missing entry to update")
 	}
 
-	request.Entry, err = h.prepareRegistrationEntry(request.Entry, true)
+	ds := h.getDataStore()
+
+	// A partial update is validated as it would be applied to the stored
+	// entry. The datastore applies the masked fields again when updating, so
+	// concurrent changes to the other fields are preserved.
+	entry := request.Entry
+	if request.Mask != nil {
+		fetchResp, err := ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{
+			EntryId: entry.EntryId,
+		})
+		if err != nil {
+			h.Log.Error(err)
+			return nil, errors.New("Error trying to fetch entry")
+		}
+		if fetchResp.Entry == nil {
+			return nil, status.Error(codes.NotFound, "no such registration entry")
+		}
+		entry = util.ApplyRegistrationEntryMask(fetchResp.Entry, entry, request.Mask)
+		entry.RevisionNumber = request.Entry.RevisionNumber
+	}
+
+	entry, err = h.prepareRegistrationEntry(entry, true)
 	if err != nil {
 		h.Log.Error(err)
 		return nil, err
 	}
 
-	ds := h.getDataStore()
 	resp, err := ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
-		Entry: request.Entry,
+		Entry: entry,
+		Mask:  request.Mask,
 	})
 	if err != nil {
 		h.Log.Error(err)
-		return nil, fmt.Errorf("Failed to update registration entry: %v", err)
+		return nil, status.Errorf(status.Code(err), "Failed to update registration entry: %v", err)
 	}
 
 	telemetry_registrationapi.IncrRegistrationAPIUpdatedEntryCounter(h.Metrics)
@@ -296,6 +374,315 @@ func (h *Handler) ListBySpiffeID(
 	}, nil
 }
 
+// ListEntries lists the registration entries matching all of the filters set
+// on the request. If a page size is set, entries are listed a page at a time.
+func (h *Handler) ListEntries(ctx context.Context, request *registration.ListEntriesRequest) (_ *registration.ListEntriesResponse, err error) {
+	counter := telemetry_registrationapi.StartListEntriesCall(h.Metrics)
+	addCallerIDLabel(ctx, counter)
+	defer counter.Done(&err)
+
+	req, err := makeListRegistrationEntriesRequest(request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resp, err := h.getDataStore().ListRegistrationEntries(ctx, req)
+	if err != nil {
+		h.Log.Error(err)
+		return nil, err
+	}
+
+	response := &registration.ListEntriesResponse{
+		Entries: resp.Entries,
+	}
+	// a full page means there may be more entries to list
+	if request.PageSize > 0 && len(resp.Entries) == int(request.PageSize) && resp.Pagination != nil {
+		response.NextPageToken = resp.Pagination.Token
+	}
+	return response, nil
+}
+
+// BatchCreateEntry creates several registration entries at once. Entries are
+// validated as CreateEntry does, and those passing validation are created in
+// a single datastore transaction.
+func (h *Handler) BatchCreateEntry(ctx context.Context, request *registration.BatchCreateEntryRequest) (_ *registration.BatchCreateEntryResponse, err error) {
+	counter := telemetry_registrationapi.StartBatchCreateEntryCall(h.Metrics)
+	addCallerIDLabel(ctx, counter)
+	defer counter.Done(&err)
+
+	batch := newEntryBatch(len(request.Entries))
+	var entries []*common.RegistrationEntry
+	for i, entry := range request.Entries {
+		prepared, err := h.prepareRegistrationEntry(entry, false)
+		if err != nil {
+			batch.fail(i, codes.InvalidArgument, err.Error())
+			continue
+		}
+		batch.accept(i)
+		entries = append(entries, prepared)
+	}
+
+	response := &registration.BatchCreateEntryResponse{
+		Results: batch.results,
+	}
+	if batch.abort(request.AllOrNothing) || len(entries) == 0 {
+		return response, nil
+	}
+
+	resp, err := h.getDataStore().BatchCreateRegistrationEntries(ctx, &datastore.BatchCreateRegistrationEntriesRequest{
+		Entries:      entries,
+		AllOrNothing: request.AllOrNothing,
+	})
+	if err != nil {
+		h.Log.Error(err)
+		return nil, errors.New("Error trying to create entries")
+	}
+	batch.merge(resp.Results)
+
+	return response, nil
+}
+
+// BatchUpdateEntry updates several registration entries at once. Entries are
+// validated as UpdateEntry does, and those passing validation are updated in
+// a single datastore transaction.
+func (h *Handler) BatchUpdateEntry(ctx context.Context, request *registration.BatchUpdateEntryRequest) (_ *registration.BatchUpdateEntryResponse, err error) {
+	counter := telemetry_registrationapi.StartBatchUpdateEntryCall(h.Metrics)
+	addCallerIDLabel(ctx, counter)
+	defer counter.Done(&err)
+
+	batch := newEntryBatch(len(request.Entries))
+	var entries []*common.RegistrationEntry
+	for i, entry := range request.Entries {
+		prepared, err := h.prepareRegistrationEntry(entry, true)
+		if err != nil {
+			batch.fail(i, codes.InvalidArgument, err.Error())
+			continue
+		}
+		batch.accept(i)
+		entries = append(entries, prepared)
+	}
+
+	response := &registration.BatchUpdateEntryResponse{
+		Results: batch.results,
+	}
+	if batch.abort(request.AllOrNothing) || len(entries) == 0 {
+		return response, nil
+	}
+
+	resp, err := h.getDataStore().BatchUpdateRegistrationEntries(ctx, &datastore.BatchUpdateRegistrationEntriesRequest{
+		Entries:      entries,
+		AllOrNothing: request.AllOrNothing,
+	})
+	if err != nil {
+		h.Log.Error(err)
+		return nil, fmt.Errorf("Failed to update registration entries: %v", err)
+	}
+	batch.merge(resp.Results)
+
+	for _, result := range resp.Results {
+		if result.Code == int32(codes.OK) {
+			telemetry_registrationapi.IncrRegistrationAPIUpdatedEntryCounter(h.Metrics)
+		}
+	}
+
+	return response, nil
+}
+
+// BatchDeleteEntry deletes several registration entries at once, in a single
+// datastore transaction.
+func (h *Handler) BatchDeleteEntry(ctx context.Context, request *registration.BatchDeleteEntryRequest) (_ *registration.BatchDeleteEntryResponse, err error) {
+	counter := telemetry_registrationapi.StartBatchDeleteEntryCall(h.Metrics)
+	addCallerIDLabel(ctx, counter)
+	defer counter.Done(&err)
+
+	batch := newEntryBatch(len(request.Ids))
+	var entryIDs []string
+	for i, id := range request.Ids {
+		if id == "" {
+			batch.fail(i, codes.InvalidArgument, "missing registration entry id")
+			continue
+		}
+		batch.accept(i)
+		entryIDs = append(entryIDs, id)
+	}
+
+	response := &registration.BatchDeleteEntryResponse{
+		Results: batch.results,
+	}
+	if batch.abort(request.AllOrNothing) || len(entryIDs) == 0 {
+		return response, nil
+	}
+
+	resp, err := h.getDataStore().BatchDeleteRegistrationEntries(ctx, &datastore.BatchDeleteRegistrationEntriesRequest{
+		EntryIds:     entryIDs,
+		AllOrNothing: request.AllOrNothing,
+	})
+	if err != nil {
+		h.Log.Error(err)
+		return nil, errors.New("Error trying to delete entries")
+	}
+	batch.merge(resp.Results)
+
+	return response, nil
+}
+
+// WatchEntries streams changes to the registration entries as recorded in the
+// datastore change log. Unless the request resumes from a cursor, the stream
+// starts with a snapshot of every entry, ended by a SNAPSHOT_END event. Entries
+// changed while the snapshot is taken may be sent again as CREATE or UPDATE
+// events, so events should be applied by entry ID.
+func (h *Handler) WatchEntries(request *registration.WatchEntriesRequest, stream registration.Registration_WatchEntriesServer) (err error) {
+	counter := telemetry_registrationapi.StartWatchEntriesCall(h.Metrics)
+	addCallerIDLabel(stream.Context(), counter)
+	defer counter.Done(&err)
+
+	ctx := stream.Context()
+	ds := h.getDataStore()
+
+	var cursor int64
+	if request.Cursor != "" {
+		cursor, err = parseEntryEventCursor(request.Cursor)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+	} else {
+		cursor, err = h.sendEntrySnapshot(ctx, ds, stream)
+		if err != nil {
+			return err
+		}
+	}
+
+	ticker := time.NewTicker(watchEntriesPollInterval)
+	defer ticker.Stop()
+	for {
+		cursor, err = h.sendEntryEvents(ctx, ds, stream, cursor)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// sendEntrySnapshot sends every registration entry followed by SNAPSHOT_END.
+// It returns the cursor of the last event recorded before the entries were
+// listed.
+func (h *Handler) sendEntrySnapshot(ctx context.Context, ds datastore.DataStore, stream registration.Registration_WatchEntriesServer) (int64, error) {
+	// The change log is read first so that changes made while the entries
+	// are listed are sent afterwards instead of being missed
+	eventsResp, err := ds.ListRegistrationEntryEvents(ctx, &datastore.ListRegistrationEntryEventsRequest{
+		Limit: 1,
+	})
+	if err != nil {
+		h.Log.Error(err)
+		return 0, errors.New("Error trying to list entry events")
+	}
+	cursor := eventsResp.LatestId
+
+	req := &datastore.ListRegistrationEntriesRequest{
+		Pagination: &datastore.Pagination{
+			PageSize: watchEntriesPageSize,
+		},
+	}
+	for {
+		resp, err := ds.ListRegistrationEntries(ctx, req)
+		if err != nil {
+			h.Log.Error(err)
+			return 0, errors.New("Error trying to list entries")
+		}
+		for _, entry := range resp.Entries {
+			if err := stream.Send(&registration.EntryEvent{
+				Type:    registration.EntryEvent_SNAPSHOT,
+				EntryId: entry.EntryId,
+				Entry:   entry,
+			}); err != nil {
+				return 0, err
+			}
+		}
+		if len(resp.Entries) < watchEntriesPageSize || resp.Pagination == nil {
+			break
+		}
+		req.Pagination = resp.Pagination
+	}
+
+	if err := stream.Send(&registration.EntryEvent{
+		Type:   registration.EntryEvent_SNAPSHOT_END,
+		Cursor: formatEntryEventCursor(cursor),
+	}); err != nil {
+		return 0, err
+	}
+	return cursor, nil
+}
+
+// sendEntryEvents sends the events recorded after the cursor and returns the
+// cursor of the last event sent.
+func (h *Handler) sendEntryEvents(ctx context.Context, ds datastore.DataStore, stream registration.Registration_WatchEntriesServer, cursor int64) (int64, error) {
+	for {
+		resp, err := ds.ListRegistrationEntryEvents(ctx, &datastore.ListRegistrationEntryEventsRequest{
+			AfterId: cursor,
+			Limit:   watchEntriesPageSize,
+		})
+		if err != nil {
+			h.Log.Error(err)
+			return 0, errors.New("Error trying to list entry events")
+		}
+
+		if cursor > resp.LatestId {
+			return 0, status.Error(codes.InvalidArgument, "cursor is ahead of the entry change log")
+		}
+		// Events up to the cursor must be all that was pruned, otherwise
+		// changes would be missed. IDs are not necessarily consecutive, so
+		// the oldest event left can't tell.
+		if cursor < resp.PrunedId {
+			return 0, status.Error(codes.OutOfRange, "cursor has expired; watch without a cursor to get a new snapshot")
+		}
+
+		for _, event := range resp.Events {
+			out := &registration.EntryEvent{
+				EntryId: event.EntryId,
+				Cursor:  formatEntryEventCursor(event.Id),
+			}
+			switch event.Type {
+			case datastore.RegistrationEntryEvent_CREATE, datastore.RegistrationEntryEvent_UPDATE:
+				fetchResp, err := ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{
+					EntryId: event.EntryId,
+				})
+				if err != nil {
+					h.Log.Error(err)
+					return 0, errors.New("Error trying to fetch entry")
+				}
+				// the entry was deleted since; its DELETE event follows
+				if fetchResp.Entry == nil {
+					cursor = event.Id
+					continue
+				}
+				out.Type = registration.EntryEvent_UPDATE
+				if event.Type == datastore.RegistrationEntryEvent_CREATE {
+					out.Type = registration.EntryEvent_CREATE
+				}
+				out.Entry = fetchResp.Entry
+			case datastore.RegistrationEntryEvent_DELETE:
+				out.Type = registration.EntryEvent_DELETE
+			default:
+				return 0, fmt.Errorf("unknown entry event type %d", event.Type)
+			}
+
+			if err := stream.Send(out); err != nil {
+				return 0, err
+			}
+			cursor = event.Id
+		}
+
+		if len(resp.Events) < watchEntriesPageSize {
+			return cursor, nil
+		}
+	}
+}
+
 func (h *Handler) CreateFederatedBundle(
 	ctx context.Context, request *registration.FederatedBundle) (
 	response *common.Empty, err error) {
@@ -466,6 +853,9 @@ func (h *Handler) CreateJoinToken(
 	if request.Ttl < 1 {
 		return nil, errors.New("Ttl is required, you must provide one")
 	}
+	if request.MaxUses < 0 {
+		return nil, status.Error(codes.InvalidArgument, "max uses cannot be negative")
+	}
 
 	// Generate a token if one wasn't specified
 	if request.Token == "" {
@@ -476,13 +866,27 @@ func (h *Handler) CreateJoinToken(
 		request.Token = u.String()
 	}
 
+	for _, selector := range request.NodeSelectors {
+		if selector.Type == "" || selector.Value == "" {
+			return nil, status.Error(codes.InvalidArgument, "node selectors must have a type and a value")
+		}
+	}
+
+	entries, err := h.prepareJoinTokenEntries(request)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
 	ds := h.getDataStore()
 	expiry := time.Now().Unix() + int64(request.Ttl)
 
 	_, err = ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
 		JoinToken: &datastore.JoinToken{
-			Token:  request.Token,
-			Expiry: expiry,
+			Token:         request.Token,
+			Expiry:        expiry,
+			MaxUses:       request.MaxUses,
+			NodeSelectors: request.NodeSelectors,
+			Entries:       entries,
 		},
 	})
 	if err != nil {
@@ -490,9 +894,69 @@ func (h *Handler) CreateJoinToken(
 		return nil, errors.New("Failed to register token")
 	}
 
+	request.Entries = entries
 	return request, nil
 }
 
+// ListJoinTokens lists the outstanding join tokens
+func (h *Handler) ListJoinTokens(ctx context.Context, request *registration.ListJoinTokensRequest) (_ *registration.ListJoinTokensResponse, err error) {
+	counter := telemetry_registrationapi.StartListJoinTokensCall(h.Metrics)
+	addCallerIDLabel(ctx, counter)
+	defer counter.Done(&err)
+
+	ds := h.getDataStore()
+	resp, err := ds.ListJoinTokens(ctx, &datastore.ListJoinTokensRequest{})
+	if err != nil {
+		h.Log.WithError(err).Error("Failed to list join tokens")
+		return nil, status.Error(codes.Internal, "failed to list join tokens")
+	}
+
+	joinTokens := make([]*registration.JoinToken, 0, len(resp.JoinTokens))
+	for _, joinToken := range resp.JoinTokens {
+		joinTokens = append(joinTokens, joinTokenToProto(joinToken))
+	}
+
+	return &registration.ListJoinTokensResponse{
+		JoinTokens: joinTokens,
+	}, nil
+}
+
+// DeleteJoinToken deletes a join token so it can no longer be used
+func (h *Handler) DeleteJoinToken(ctx context.Context, request *registration.DeleteJoinTokenRequest) (_ *registration.DeleteJoinTokenResponse, err error) {
+	counter := telemetry_registrationapi.StartDeleteJoinTokenCall(h.Metrics)
+	addCallerIDLabel(ctx, counter)
+	defer counter.Done(&err)
+
+	if request.Token == "" {
+		return nil, status.Error(codes.InvalidArgument, "a join token is required")
+	}
+
+	ds := h.getDataStore()
+	fetchResp, err := ds.FetchJoinToken(ctx, &datastore.FetchJoinTokenRequest{
+		Token: request.Token,
+	})
+	if err != nil {
+		h.Log.WithError(err).Error("Failed to fetch join token")
+		return nil, status.Error(codes.Internal, "failed to fetch join token")
+	}
+	if fetchResp.JoinToken == nil {
+		return nil, status.Error(codes.NotFound, "no such join token")
+	}
+
+	deleteResp, err := ds.DeleteJoinToken(ctx, &datastore.DeleteJoinTokenRequest{
+		Token: request.Token,
+	})
+	if err != nil {
+		h.Log.WithError(err).Error("Failed to delete join token")
+		return nil, status.Error(codes.Internal, "failed to delete join token")
+	}
+
+	h.Log.Info("Join token deleted")
+	return &registration.DeleteJoinTokenResponse{
+		JoinToken: joinTokenToProto(deleteResp.JoinToken),
+	}, nil
+}
+
 // FetchBundle retrieves the CA bundle.
 func (h *Handler) FetchBundle(
 	ctx context.Context, request *common.Empty) (
@@ -518,10 +982,28 @@ func (h *Handler) FetchBundle(
 	}, nil
 }
 
-//EvictAgent removes a node from the attested nodes store
+//EvictAgent removes a node from the attested nodes store and revokes its SVID
+//along with any downstream CAs issued through it. Banned nodes cannot be
+//evicted, since that would remove the ban along with the node.
 func (h *Handler) EvictAgent(ctx context.Context, evictRequest *registration.EvictAgentRequest) (*registration.EvictAgentResponse, error) {
 	spiffeID := evictRequest.GetSpiffeID()
 	log := h.Log.WithField(telemetry.SPIFFEID, spiffeID)
+
+	node, err := h.fetchAttestedNode(ctx, spiffeID)
+	if err != nil {
+		log.WithError(err).Warn("Fail to evict agent")
+		return nil, err
+	}
+	if node != nil && node.Banned {
+		log.Warn("Refusing to evict banned agent")
+		return nil, status.Errorf(codes.FailedPrecondition, "agent %q is banned and must be unbanned before being evicted", spiffeID)
+	}
+
+	if err := h.revokeAgentCertificates(ctx, spiffeID); err != nil {
+		log.WithError(err).Warn("Fail to revoke agent certificates")
+		return nil, err
+	}
+
 	deletedNode, err := h.deleteAttestedNode(ctx, spiffeID)
 	if err != nil {
 		log.Warn("Fail to evict agent")
@@ -534,65 +1016,565 @@ func (h *Handler) EvictAgent(ctx context.Context, evictRequest *registration.Evi
 	}, nil
 }
 
-//ListAgents returns the list of attested nodes
-func (h *Handler) ListAgents(ctx context.Context, listReq *registration.ListAgentsRequest) (*registration.ListAgentsResponse, error) {
-	ds := h.Catalog.GetDataStore()
-	req := &datastore.ListAttestedNodesRequest{}
-	resp, err := ds.ListAttestedNodes(ctx, req)
+// BanAgent bans an attested node so it can neither attest nor fetch SVIDs, and
+// revokes its SVID along with any downstream CAs issued through it. Unlike
+// evicted nodes, banned nodes cannot simply attest again.
+func (h *Handler) BanAgent(ctx context.Context, banRequest *registration.BanAgentRequest) (*registration.BanAgentResponse, error) {
+	spiffeID, err := idutil.NormalizeSpiffeID(banRequest.SpiffeId, idutil.AllowTrustDomainAgent(h.TrustDomain.Host))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	log := h.Log.WithField(telemetry.SPIFFEID, spiffeID)
+
+	// Ban before revoking so the agent cannot attest again in between
+	node, err := h.setAttestedNodeBanned(ctx, spiffeID, true)
 	if err != nil {
+		log.WithError(err).Warn("Fail to ban agent")
 		return nil, err
 	}
-	return &registration.ListAgentsResponse{Nodes: resp.Nodes}, nil
-}
 
-func (h *Handler) deleteAttestedNode(ctx context.Context, agentID string) (*common.AttestedNode, error) {
-	if agentID == "" {
-		return nil, errors.New("empty agent ID")
+	if err := h.revokeAgentCertificates(ctx, spiffeID); err != nil {
+		log.WithError(err).Warn("Fail to revoke agent certificates")
+		return nil, err
 	}
 
-	ds := h.Catalog.GetDataStore()
-	req := &datastore.DeleteAttestedNodeRequest{
-		SpiffeId: agentID,
+	log.Info("Successfully banned agent")
+	return &registration.BanAgentResponse{
+		Node: node,
+	}, nil
+}
+
+// UnbanAgent unbans an attested node. Since its SVID was revoked when banned,
+// the agent has to attest again.
+func (h *Handler) UnbanAgent(ctx context.Context, unbanRequest *registration.UnbanAgentRequest) (*registration.UnbanAgentResponse, error) {
+	spiffeID, err := idutil.NormalizeSpiffeID(unbanRequest.SpiffeId, idutil.AllowTrustDomainAgent(h.TrustDomain.Host))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
+	log := h.Log.WithField(telemetry.SPIFFEID, spiffeID)
 
-	resp, err := ds.DeleteAttestedNode(ctx, req)
+	node, err := h.setAttestedNodeBanned(ctx, spiffeID, false)
 	if err != nil {
+		log.WithError(err).Warn("Fail to unban agent")
 		return nil, err
 	}
 
-	return resp.Node, nil
+	log.Info("Successfully unbanned agent")
+	return &registration.UnbanAgentResponse{
+		Node: node,
+	}, nil
 }
 
-func (h *Handler) isEntryUnique(ctx context.Context, ds datastore.DataStore, entry *common.RegistrationEntry) (bool, error) {
-	// First we get all the entries that matches the entry's spiffe id.
-	req := &datastore.ListRegistrationEntriesRequest{
-		BySpiffeId: &wrappers.StringValue{
-			Value: entry.SpiffeId,
-		},
-	}
-	res, err := ds.ListRegistrationEntries(ctx, req)
+// ListAgents lists the attested nodes matching all of the filters set on the
+// request, along with their node selectors. If a page size is set, nodes are
+// listed a page at a time.
+func (h *Handler) ListAgents(ctx context.Context, listReq *registration.ListAgentsRequest) (*registration.ListAgentsResponse, error) {
+	req, err := makeListAttestedNodesRequest(listReq)
 	if err != nil {
-		return false, err
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	for _, re := range res.Entries {
-		// If an existing entry matches the new entry's parent id also, we must check its
-		// selectors...
-		if re.ParentId == entry.ParentId {
-			reSelSet := selector.NewSetFromRaw(re.Selectors)
-			entrySelSet := selector.NewSetFromRaw(entry.Selectors)
-			if reSelSet.Equal(entrySelSet) {
-				return false, nil
-			}
-		}
+	ds := h.Catalog.GetDataStore()
+	resp, err := ds.ListAttestedNodes(ctx, req)
+	if err != nil {
+		return nil, err
 	}
 
-	return true, nil
+	response := &registration.ListAgentsResponse{
+		Nodes: resp.Nodes,
+	}
+	// a full page means there may be more nodes to list
+	if listReq.PageSize > 0 && len(resp.Nodes) == int(listReq.PageSize) && resp.Pagination != nil {
+		response.NextPageToken = resp.Pagination.Token
+	}
+	return response, nil
 }
 
-func (h *Handler) getDataStore() datastore.DataStore {
-	return h.Catalog.GetDataStore()
-}
+// FetchAgent returns an attested node along with its node selectors
+func (h *Handler) FetchAgent(ctx context.Context, fetchReq *registration.FetchAgentRequest) (*registration.FetchAgentResponse, error) {
+	spiffeID, err := idutil.NormalizeSpiffeID(fetchReq.SpiffeId, idutil.AllowTrustDomainAgent(h.TrustDomain.Host))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ds := h.Catalog.GetDataStore()
+	resp, err := ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{
+		SpiffeId: spiffeID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Node == nil {
+		return nil, status.Errorf(codes.NotFound, "no such agent %q", spiffeID)
+	}
+
+	selectorsResp, err := ds.GetNodeSelectors(ctx, &datastore.GetNodeSelectorsRequest{
+		SpiffeId: spiffeID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	node := resp.Node
+	if selectorsResp.Selectors != nil {
+		node.Selectors = selectorsResp.Selectors.Selectors
+	}
+	return &registration.FetchAgentResponse{
+		Node: node,
+	}, nil
+}
+
+// ListCASlots lists the current and next X509 CA and JWT key slots
+func (h *Handler) ListCASlots(ctx context.Context, request *registration.ListCASlotsRequest) (_ *registration.ListCASlotsResponse, err error) {
+	counter := telemetry_registrationapi.StartListCASlotsCall(h.Metrics)
+	addCallerIDLabel(ctx, counter)
+	defer counter.Done(&err)
+
+	if h.CAManager == nil {
+		return nil, status.Error(codes.Unimplemented, "CA manager is not available")
+	}
+
+	x509Current, x509Next := h.CAManager.X509CASlots()
+	jwtCurrent, jwtNext := h.CAManager.JWTKeySlots()
+	return &registration.ListCASlotsResponse{
+		X509Slots: []*registration.CASlot{caSlotFromInfo(x509Current), caSlotFromInfo(x509Next)},
+		JwtSlots:  []*registration.CASlot{caSlotFromInfo(jwtCurrent), caSlotFromInfo(jwtNext)},
+	}, nil
+}
+
+// PrepareCA prepares a new authority in the next slot
+func (h *Handler) PrepareCA(ctx context.Context, request *registration.PrepareCARequest) (_ *registration.PrepareCAResponse, err error) {
+	counter := telemetry_registrationapi.StartPrepareCACall(h.Metrics)
+	addCallerIDLabel(ctx, counter)
+	defer counter.Done(&err)
+
+	if h.CAManager == nil {
+		return nil, status.Error(codes.Unimplemented, "CA manager is not available")
+	}
+
+	var info ca.SlotInfo
+	switch request.Kind {
+	case registration.CAKind_X509_CA:
+		info, err = h.CAManager.PrepareNextX509CA(ctx)
+	case registration.CAKind_JWT_KEY:
+		info, err = h.CAManager.PrepareNextJWTKey(ctx)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unhandled CA kind %q", request.Kind)
+	}
+	if err != nil {
+		h.Log.WithError(err).Error("Failed to prepare CA")
+		return nil, err
+	}
+
+	return &registration.PrepareCAResponse{
+		Slot: caSlotFromInfo(info),
+	}, nil
+}
+
+// ActivateCA activates the authority prepared in the next slot
+func (h *Handler) ActivateCA(ctx context.Context, request *registration.ActivateCARequest) (_ *registration.ActivateCAResponse, err error) {
+	counter := telemetry_registrationapi.StartActivateCACall(h.Metrics)
+	addCallerIDLabel(ctx, counter)
+	defer counter.Done(&err)
+
+	if h.CAManager == nil {
+		return nil, status.Error(codes.Unimplemented, "CA manager is not available")
+	}
+
+	var info ca.SlotInfo
+	switch request.Kind {
+	case registration.CAKind_X509_CA:
+		info, err = h.CAManager.ActivateNextX509CA(ctx)
+	case registration.CAKind_JWT_KEY:
+		info, err = h.CAManager.ActivateNextJWTKey(ctx)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unhandled CA kind %q", request.Kind)
+	}
+	if err != nil {
+		h.Log.WithError(err).Error("Failed to activate CA")
+		return nil, err
+	}
+
+	return &registration.ActivateCAResponse{
+		Slot: caSlotFromInfo(info),
+	}, nil
+}
+
+// TaintCA marks an old authority as tainted
+func (h *Handler) TaintCA(ctx context.Context, request *registration.TaintCARequest) (_ *registration.TaintCAResponse, err error) {
+	counter := telemetry_registrationapi.StartTaintCACall(h.Metrics)
+	addCallerIDLabel(ctx, counter)
+	defer counter.Done(&err)
+
+	if h.CAManager == nil {
+		return nil, status.Error(codes.Unimplemented, "CA manager is not available")
+	}
+
+	if request.AuthorityId == "" {
+		return nil, status.Error(codes.InvalidArgument, "authority ID is required")
+	}
+
+	switch request.Kind {
+	case registration.CAKind_X509_CA:
+		err = h.CAManager.TaintX509Authority(ctx, request.AuthorityId)
+	case registration.CAKind_JWT_KEY:
+		err = h.CAManager.TaintJWTAuthority(ctx, request.AuthorityId)
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "unhandled CA kind %q", request.Kind)
+	}
+	if err != nil {
+		h.Log.WithError(err).WithField(telemetry.AuthorityID, request.AuthorityId).Error("Failed to taint CA")
+		return nil, err
+	}
+
+	return &registration.TaintCAResponse{}, nil
+}
+
+// ListIssuedSVIDs streams the records of SVIDs signed by the server that
+// match the request filters
+func (h *Handler) ListIssuedSVIDs(request *registration.ListIssuedSVIDsRequest, stream registration.Registration_ListIssuedSVIDsServer) (err error) {
+	counter := telemetry_registrationapi.StartListIssuedSVIDsCall(h.Metrics)
+	addCallerIDLabel(stream.Context(), counter)
+	defer counter.Done(&err)
+
+	req := &datastore.ListIssuedSVIDsRequest{
+		IssuedAfter:  request.IssuedAfter,
+		IssuedBefore: request.IssuedBefore,
+		Pagination: &datastore.Pagination{
+			PageSize: issuedSVIDsPageSize,
+		},
+	}
+	if request.SpiffeId != "" {
+		spiffeID, err := idutil.NormalizeSpiffeID(request.SpiffeId, idutil.AllowAny())
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		req.BySpiffeId = &wrappers.StringValue{Value: spiffeID}
+	}
+	if request.AgentId != "" {
+		agentID, err := idutil.NormalizeSpiffeID(request.AgentId, idutil.AllowAny())
+		if err != nil {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		req.ByAgentId = &wrappers.StringValue{Value: agentID}
+	}
+
+	// page through the records so large histories are not loaded into
+	// memory all at once
+	ds := h.getDataStore()
+	for {
+		resp, err := ds.ListIssuedSVIDs(stream.Context(), req)
+		if err != nil {
+			return err
+		}
+		for _, issuedSVID := range resp.IssuedSvids {
+			if err := stream.Send(issuedSVIDFromDataStore(issuedSVID)); err != nil {
+				return err
+			}
+		}
+		if len(resp.IssuedSvids) < issuedSVIDsPageSize {
+			return nil
+		}
+		req.Pagination = resp.Pagination
+	}
+}
+
+// MintX509SVID signs an X509-SVID for the public key of the CSR
+func (h *Handler) MintX509SVID(ctx context.Context, request *registration.MintX509SVIDRequest) (_ *registration.MintX509SVIDResponse, err error) {
+	counter := telemetry_registrationapi.StartMintX509SVIDCall(h.Metrics)
+	addCallerIDLabel(ctx, counter)
+	defer counter.Done(&err)
+
+	if h.ServerCA == nil {
+		return nil, status.Error(codes.Unimplemented, "server CA is not available")
+	}
+
+	spiffeID, err := idutil.NormalizeSpiffeID(request.SpiffeId, idutil.AllowTrustDomainWorkload(h.TrustDomain.Host))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if request.Ttl < 0 {
+		return nil, status.Error(codes.InvalidArgument, "TTL cannot be negative")
+	}
+	for _, dnsName := range request.DnsNames {
+		if err := validateDNS(dnsName); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid DNS name %q: %v", dnsName, err)
+		}
+	}
+
+	csr, err := x509.ParseCertificateRequest(request.Csr)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "unable to parse CSR: %v", err)
+	}
+	if err := csr.CheckSignature(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid CSR signature: %v", err)
+	}
+
+	svidChain, err := h.ServerCA.SignX509SVID(ctx, ca.X509SVIDParams{
+		SpiffeID:  spiffeID,
+		PublicKey: csr.PublicKey,
+		TTL:       h.mintedSVIDTTL(request.Ttl, 0),
+		DNSList:   request.DnsNames,
+	})
+	if err != nil {
+		h.Log.WithError(err).WithField(telemetry.SPIFFEID, spiffeID).Error("Failed to mint X509 SVID")
+		return nil, err
+	}
+
+	bundle, err := h.FetchBundle(ctx, &common.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	h.Log.WithFields(logrus.Fields{
+		telemetry.SPIFFEID: spiffeID,
+		telemetry.CallerID: getCallerID(ctx),
+	}).Info("Minted X509 SVID")
+
+	response := &registration.MintX509SVIDResponse{}
+	for _, cert := range svidChain {
+		response.SvidChain = append(response.SvidChain, cert.Raw)
+	}
+	for _, rootCA := range bundle.Bundle.RootCas {
+		response.RootCas = append(response.RootCas, rootCA.DerBytes)
+	}
+	return response, nil
+}
+
+// MintJWTSVID signs a JWT-SVID for the requested audience
+func (h *Handler) MintJWTSVID(ctx context.Context, request *registration.MintJWTSVIDRequest) (_ *registration.MintJWTSVIDResponse, err error) {
+	counter := telemetry_registrationapi.StartMintJWTSVIDCall(h.Metrics)
+	addCallerIDLabel(ctx, counter)
+	defer counter.Done(&err)
+
+	if h.ServerCA == nil {
+		return nil, status.Error(codes.Unimplemented, "server CA is not available")
+	}
+
+	spiffeID, err := idutil.NormalizeSpiffeID(request.SpiffeId, idutil.AllowTrustDomainWorkload(h.TrustDomain.Host))
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if request.Ttl < 0 {
+		return nil, status.Error(codes.InvalidArgument, "TTL cannot be negative")
+	}
+	if len(request.Audience) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one audience is required")
+	}
+
+	token, err := h.ServerCA.SignJWTSVID(ctx, ca.JWTSVIDParams{
+		SpiffeID: spiffeID,
+		TTL:      h.mintedSVIDTTL(request.Ttl, ca.DefaultJWTSVIDTTL),
+		Audience: request.Audience,
+	})
+	if err != nil {
+		h.Log.WithError(err).WithField(telemetry.SPIFFEID, spiffeID).Error("Failed to mint JWT SVID")
+		return nil, err
+	}
+
+	h.Log.WithFields(logrus.Fields{
+		telemetry.SPIFFEID: spiffeID,
+		telemetry.CallerID: getCallerID(ctx),
+	}).Info("Minted JWT SVID")
+
+	return &registration.MintJWTSVIDResponse{
+		Token: token,
+	}, nil
+}
+
+func (h *Handler) deleteAttestedNode(ctx context.Context, agentID string) (*common.AttestedNode, error) {
+	if agentID == "" {
+		return nil, errors.New("empty agent ID")
+	}
+
+	ds := h.Catalog.GetDataStore()
+	req := &datastore.DeleteAttestedNodeRequest{
+		SpiffeId: agentID,
+	}
+
+	resp, err := ds.DeleteAttestedNode(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Node, nil
+}
+
+func (h *Handler) fetchAttestedNode(ctx context.Context, agentID string) (*common.AttestedNode, error) {
+	if agentID == "" {
+		return nil, errors.New("empty agent ID")
+	}
+
+	ds := h.Catalog.GetDataStore()
+	resp, err := ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{
+		SpiffeId: agentID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Node, nil
+}
+
+func (h *Handler) setAttestedNodeBanned(ctx context.Context, agentID string, banned bool) (*common.AttestedNode, error) {
+	node, err := h.fetchAttestedNode(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+	if node == nil {
+		return nil, status.Errorf(codes.NotFound, "no such agent %q", agentID)
+	}
+
+	ds := h.Catalog.GetDataStore()
+	resp, err := ds.SetAttestedNodeBanned(ctx, &datastore.SetAttestedNodeBannedRequest{
+		SpiffeId: agentID,
+		Banned:   banned,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Node, nil
+}
+
+// revokeAgentCertificates revokes the SVID of the agent and the downstream
+// CAs issued through it so that they stop being trusted before they expire
+func (h *Handler) revokeAgentCertificates(ctx context.Context, agentID string) error {
+	if agentID == "" {
+		return errors.New("empty agent ID")
+	}
+
+	ds := h.Catalog.GetDataStore()
+	now := time.Now().Unix()
+
+	var revoked []*datastore.RevokedCertificate
+	nodeResp, err := ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{
+		SpiffeId: agentID,
+	})
+	if err != nil {
+		return err
+	}
+	if node := nodeResp.Node; node != nil && node.CertSerialNumber != "" {
+		revoked = append(revoked, &datastore.RevokedCertificate{
+			SerialNumber: node.CertSerialNumber,
+			SpiffeId:     node.SpiffeId,
+			ExpiresAt:    node.CertNotAfter,
+			RevokedAt:    now,
+		})
+	}
+
+	// the agent SVIDs issued before the current one are still valid until
+	// they expire, so they are revoked as well.
+	req := &datastore.ListIssuedSVIDsRequest{
+		BySpiffeId: &wrappers.StringValue{Value: agentID},
+		Pagination: &datastore.Pagination{
+			PageSize: issuedSVIDsPageSize,
+		},
+	}
+	for {
+		resp, err := ds.ListIssuedSVIDs(ctx, req)
+		if err != nil {
+			return err
+		}
+		for _, issuedSVID := range resp.IssuedSvids {
+			if issuedSVID.Type != datastore.IssuedSVID_X509_SVID || issuedSVID.NotAfter <= now {
+				continue
+			}
+			revoked = append(revoked, &datastore.RevokedCertificate{
+				SerialNumber: issuedSVID.Id,
+				SpiffeId:     issuedSVID.SpiffeId,
+				ExpiresAt:    issuedSVID.NotAfter,
+				RevokedAt:    now,
+			})
+		}
+		if len(resp.IssuedSvids) < issuedSVIDsPageSize {
+			break
+		}
+		req.Pagination = resp.Pagination
+	}
+
+	downstreamResp, err := ds.ListDownstreamCAs(ctx, &datastore.ListDownstreamCAsRequest{
+		AgentId: agentID,
+	})
+	if err != nil {
+		return err
+	}
+	for _, downstreamCA := range downstreamResp.DownstreamCas {
+		revoked = append(revoked, &datastore.RevokedCertificate{
+			SerialNumber: downstreamCA.SerialNumber,
+			SpiffeId:     downstreamCA.SpiffeId,
+			ExpiresAt:    downstreamCA.ExpiresAt,
+			RevokedAt:    now,
+		})
+	}
+
+	seen := make(map[string]bool)
+	for _, revokedCert := range revoked {
+		if seen[revokedCert.SerialNumber] {
+			continue
+		}
+		seen[revokedCert.SerialNumber] = true
+		if _, err := ds.RevokeCertificate(ctx, &datastore.RevokeCertificateRequest{
+			RevokedCertificate: revokedCert,
+		}); err != nil {
+			return err
+		}
+		h.Log.WithFields(logrus.Fields{
+			telemetry.SPIFFEID:     revokedCert.SpiffeId,
+			telemetry.SerialNumber: revokedCert.SerialNumber,
+		}).Info("Revoked certificate")
+	}
+	return nil
+}
+
+// mintedSVIDTTL returns the TTL of a minted SVID, using defaultTTL if the
+// TTL is not requested, capped to MaxMintedSVIDTTL.
+func (h *Handler) mintedSVIDTTL(ttl int32, defaultTTL time.Duration) time.Duration {
+	maxTTL := h.MaxMintedSVIDTTL
+	if maxTTL <= 0 {
+		maxTTL = ca.DefaultX509SVIDTTL
+	}
+
+	d := time.Duration(ttl) * time.Second
+	if d <= 0 {
+		d = defaultTTL
+	}
+	if d <= 0 || d > maxTTL {
+		d = maxTTL
+	}
+	return d
+}
+
+func (h *Handler) isEntryUnique(ctx context.Context, ds datastore.DataStore, entry *common.RegistrationEntry) (bool, error) {
+	// First we get all the entries that matches the entry's spiffe id.
+	req := &datastore.ListRegistrationEntriesRequest{
+		BySpiffeId: &wrappers.StringValue{
+			Value: entry.SpiffeId,
+		},
+	}
+	res, err := ds.ListRegistrationEntries(ctx, req)
+	if err != nil {
+		return false, err
+	}
+
+	for _, re := range res.Entries {
+		// If an existing entry matches the new entry's parent id also, we must check its
+		// selectors...
+		if re.ParentId == entry.ParentId {
+			reSelSet := selector.NewSetFromRaw(re.Selectors)
+			entrySelSet := selector.NewSetFromRaw(entry.Selectors)
+			if reSelSet.Equal(entrySelSet) {
+				return false, nil
+			}
+		}
+	}
+
+	return true, nil
+}
+
+func (h *Handler) getDataStore() datastore.DataStore {
+	return h.Catalog.GetDataStore()
+}
 
 func (h *Handler) prepareRegistrationEntry(entry *common.RegistrationEntry, forUpdate bool) (*common.RegistrationEntry, error) {
 	entry = cloneRegistrationEntry(entry)
@@ -608,6 +1590,18 @@ func (h *Handler) prepareRegistrationEntry(entry *common.RegistrationEntry, forU
 		}
 	}
 
+	if err := ca.ValidateX509SVIDTemplate(entry.X509SvidTemplate); err != nil {
+		return nil, fmt.Errorf("X509-SVID template failed validation: %v", err)
+	}
+
+	if entry.JwtSvidTtl < 0 {
+		return nil, errors.New("JWT-SVID TTL cannot be negative")
+	}
+
+	if err := jwtsvid.ValidateClaims(entry.JwtSvidClaims); err != nil {
+		return nil, fmt.Errorf("JWT-SVID claims failed validation: %v", err)
+	}
+
 	entry.ParentId, err = idutil.NormalizeSpiffeID(entry.ParentId, idutil.AllowAnyInTrustDomain(h.TrustDomain.Host))
 	if err != nil {
 		return nil, err
@@ -622,9 +1616,42 @@ func (h *Handler) prepareRegistrationEntry(entry *common.RegistrationEntry, forU
 	return entry, nil
 }
 
+// prepareJoinTokenEntries validates the entries to create when the join token
+// is used. The entries are validated as if parented to the agent ID of a
+// node attesting with the token, which is only known once the token is used.
+func (h *Handler) prepareJoinTokenEntries(joinToken *registration.JoinToken) ([]*common.RegistrationEntry, error) {
+	agentID := idutil.AgentID(h.TrustDomain.Host, path.Join("join_token", joinToken.Token))
+
+	var entries []*common.RegistrationEntry
+	for _, entry := range joinToken.Entries {
+		if entry.ParentId != "" {
+			return nil, errors.New("join token entries cannot have a parent ID")
+		}
+
+		entry = cloneRegistrationEntry(entry)
+		entry.ParentId = agentID
+		prepared, err := h.prepareRegistrationEntry(entry, false)
+		if err != nil {
+			return nil, err
+		}
+		prepared.ParentId = ""
+		entries = append(entries, prepared)
+	}
+	return entries, nil
+}
+
 func (h *Handler) AuthorizeCall(ctx context.Context, fullMethod string) (context.Context, error) {
-	// For the time being, authorization is not per-method. In other words, all or nothing.
-	callerID, err := authorizeCaller(ctx, h.getDataStore())
+	var callerID string
+	var err error
+	if h.Policy != nil && !adminOnlyMethods[path.Base(fullMethod)] {
+		// The policy is evaluated once the request is received
+		callerID, err = identifyCaller(ctx)
+	} else {
+		// Without a policy, authorization is not per-method. In other words, all or nothing.
+		// Methods minting SVIDs always require an admin, in addition to
+		// the policy.
+		callerID, err = authorizeCaller(ctx, h.getDataStore())
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -634,10 +1661,377 @@ func (h *Handler) AuthorizeCall(ctx context.Context, fullMethod string) (context
 	return ctx, nil
 }
 
+// AuthorizeRequest evaluates the authorization policy, if any, against the
+// caller, the method called and the request. Calls writing admin entries
+// must come from an admin as well, whatever the policy.
+func (h *Handler) AuthorizeRequest(ctx context.Context, fullMethod string, req interface{}) error {
+	if h.Policy == nil {
+		return nil
+	}
+
+	input := authpolicy.Input{
+		SpiffeID: getCallerID(ctx),
+		Method:   path.Base(fullMethod),
+	}
+	if ctxPeer, ok := peer.FromContext(ctx); ok {
+		if authInfo, ok := ctxPeer.AuthInfo.(peertracker.AuthInfo); ok {
+			input.UnixCaller = &authInfo.Caller
+		}
+	}
+
+	requests, stored, err := h.requestsToAuthorize(ctx, input.Method, req)
+	if err != nil {
+		return err
+	}
+
+	policy := h.Policy.Policy()
+	for _, request := range requests {
+		input.Request = request
+		if err := h.evaluatePolicy(policy, input); err != nil {
+			return err
+		}
+	}
+
+	if writesAdminEntry(input.Method, req, stored) {
+		if _, err := authorizeCaller(ctx, h.getDataStore()); err != nil {
+			h.Log.WithField(telemetry.Method, input.Method).WithField(telemetry.CallerID, input.SpiffeID).Debug("Call writing admin entries denied")
+			return status.Errorf(codes.PermissionDenied, "only admins may write admin entries: %v", err)
+		}
+	}
+	return nil
+}
+
+func (h *Handler) evaluatePolicy(policy *authpolicy.Policy, input authpolicy.Input) error {
+	rule, allowed := policy.Evaluate(input)
+	if allowed {
+		return nil
+	}
+
+	log := h.Log.WithField(telemetry.Method, input.Method)
+	if rule != nil {
+		log = log.WithField(telemetry.Rule, rule.Name)
+	}
+	if input.UnixCaller != nil {
+		log = log.WithField(telemetry.CallerUID, input.UnixCaller.UID).WithField(telemetry.CallerGID, input.UnixCaller.GID)
+	} else {
+		log = log.WithField(telemetry.CallerID, input.SpiffeID)
+	}
+	log.Debug("Call denied by authorization policy")
+	return status.Errorf(codes.PermissionDenied, "authorization policy denies calling %s", input.Method)
+}
+
+// requestsToAuthorize returns the requests the policy must allow for a call,
+// along with the stored entries the call changes or deletes. Otherwise
+// callers could change entries they may not write by naming them by ID, or
+// by leaving the fields matched by the policy out of the update mask.
+//
+// Updates must be allowed both as requested and with each stored entry in
+// place of the requested one. Deletes only carry entry IDs, so they are
+// authorized against the stored entries, found where create requests carry
+// them, and against the request itself for IDs of entries that don't exist.
+func (h *Handler) requestsToAuthorize(ctx context.Context, method string, req interface{}) ([]interface{}, []*common.RegistrationEntry, error) {
+	var requests []interface{}
+	switch method {
+	case "UpdateEntry":
+		r := req.(*registration.UpdateEntryRequest)
+		stored, _, err := h.fetchEntriesToAuthorize(ctx, r.GetEntry().GetEntryId())
+		if err != nil {
+			return nil, nil, err
+		}
+		requests = append(requests, req)
+		for _, entry := range stored {
+			requests = append(requests, &registration.UpdateEntryRequest{
+				Entry: entry,
+				Mask:  r.GetMask(),
+			})
+		}
+		return requests, stored, nil
+	case "BatchUpdateEntry":
+		r := req.(*registration.BatchUpdateEntryRequest)
+		var ids []string
+		for _, entry := range r.GetEntries() {
+			ids = append(ids, entry.GetEntryId())
+		}
+		stored, _, err := h.fetchEntriesToAuthorize(ctx, ids...)
+		if err != nil {
+			return nil, nil, err
+		}
+		requests = append(requests, req)
+		for _, entry := range stored {
+			requests = append(requests, &registration.BatchUpdateEntryRequest{
+				Entries:      []*common.RegistrationEntry{entry},
+				AllOrNothing: r.GetAllOrNothing(),
+			})
+		}
+		return requests, stored, nil
+	case "DeleteEntry":
+		stored, missing, err := h.fetchEntriesToAuthorize(ctx, req.(*registration.RegistrationEntryID).GetId())
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, entry := range stored {
+			requests = append(requests, entry)
+		}
+		if missing {
+			requests = append(requests, req)
+		}
+		return requests, stored, nil
+	case "BatchDeleteEntry":
+		r := req.(*registration.BatchDeleteEntryRequest)
+		stored, missing, err := h.fetchEntriesToAuthorize(ctx, r.GetIds()...)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, entry := range stored {
+			requests = append(requests, &registration.BatchCreateEntryRequest{
+				Entries:      []*common.RegistrationEntry{entry},
+				AllOrNothing: r.GetAllOrNothing(),
+			})
+		}
+		if missing || len(stored) == 0 {
+			requests = append(requests, req)
+		}
+		return requests, stored, nil
+	default:
+		return []interface{}{req}, nil, nil
+	}
+}
+
+// fetchEntriesToAuthorize fetches the stored entries with the given IDs. It
+// also returns whether any of the entries doesn't exist.
+func (h *Handler) fetchEntriesToAuthorize(ctx context.Context, ids ...string) (entries []*common.RegistrationEntry, missing bool, err error) {
+	ds := h.getDataStore()
+	for _, id := range ids {
+		if id == "" {
+			missing = true
+			continue
+		}
+		resp, err := ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{
+			EntryId: id,
+		})
+		if err != nil {
+			h.Log.WithError(err).Error("Failed to fetch entries to authorize")
+			return nil, false, status.Errorf(codes.Internal, "failed to fetch entries to authorize: %v", err)
+		}
+		if resp.Entry == nil {
+			missing = true
+			continue
+		}
+		entries = append(entries, resp.Entry)
+	}
+	return entries, missing, nil
+}
+
+// writesAdminEntry returns true if the call creates an admin entry, makes an
+// entry an admin, or changes or deletes a stored admin entry.
+func writesAdminEntry(method string, req interface{}, stored []*common.RegistrationEntry) bool {
+	for _, entry := range stored {
+		if entry.Admin {
+			return true
+		}
+	}
+
+	switch method {
+	case "CreateEntry":
+		return req.(*common.RegistrationEntry).GetAdmin()
+	case "BatchCreateEntry":
+		for _, entry := range req.(*registration.BatchCreateEntryRequest).GetEntries() {
+			if entry.GetAdmin() {
+				return true
+			}
+		}
+	case "UpdateEntry":
+		r := req.(*registration.UpdateEntryRequest)
+		return r.GetEntry().GetAdmin() && (r.GetMask() == nil || r.GetMask().Admin)
+	case "BatchUpdateEntry":
+		for _, entry := range req.(*registration.BatchUpdateEntryRequest).GetEntries() {
+			if entry.GetAdmin() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// entryBatch tracks the results of a batch operation on registration
+// entries. Entries failing validation in the handler are never sent to the
+// datastore, so the datastore results are mapped back to the request order.
+type entryBatch struct {
+	results []*registration.BatchEntryResult
+
+	// accepted holds the request index of each entry sent to the datastore
+	accepted []int
+}
+
+func newEntryBatch(n int) *entryBatch {
+	batch := &entryBatch{}
+	for i := 0; i < n; i++ {
+		batch.results = append(batch.results, &registration.BatchEntryResult{})
+	}
+	return batch
+}
+
+func (b *entryBatch) fail(i int, code codes.Code, message string) {
+	b.results[i].Code = int32(code)
+	b.results[i].Message = message
+}
+
+func (b *entryBatch) accept(i int) {
+	b.accepted = append(b.accepted, i)
+}
+
+// abort marks the accepted entries of an all-or-nothing batch as aborted if
+// any entry failed validation. It returns true if the batch was aborted.
+func (b *entryBatch) abort(allOrNothing bool) bool {
+	if !allOrNothing || len(b.accepted) == len(b.results) {
+		return false
+	}
+	for _, i := range b.accepted {
+		b.fail(i, codes.Aborted, "batch aborted because another operation failed")
+	}
+	return true
+}
+
+func (b *entryBatch) merge(results []*datastore.BatchRegistrationEntryResult) {
+	for j, result := range results {
+		if j >= len(b.accepted) {
+			break
+		}
+		i := b.accepted[j]
+		b.results[i].Code = result.Code
+		b.results[i].Message = result.Message
+		b.results[i].Entry = result.Entry
+	}
+}
+
+func formatEntryEventCursor(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+func parseEntryEventCursor(cursor string) (int64, error) {
+	id, err := strconv.ParseInt(cursor, 10, 64)
+	if err != nil || id < 0 {
+		return 0, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	return id, nil
+}
+
+func joinTokenToProto(joinToken *datastore.JoinToken) *registration.JoinToken {
+	return &registration.JoinToken{
+		Token:         joinToken.Token,
+		MaxUses:       joinToken.MaxUses,
+		NodeSelectors: joinToken.NodeSelectors,
+		Entries:       joinToken.Entries,
+		Expiry:        joinToken.Expiry,
+		Uses:          joinToken.Uses,
+	}
+}
+
 func cloneRegistrationEntry(entry *common.RegistrationEntry) *common.RegistrationEntry {
 	return proto.Clone(entry).(*common.RegistrationEntry)
 }
 
+func makeListRegistrationEntriesRequest(in *registration.ListEntriesRequest) (*datastore.ListRegistrationEntriesRequest, error) {
+	if in.PageSize < 0 {
+		return nil, errors.New("page size cannot be negative")
+	}
+
+	req := &datastore.ListRegistrationEntriesRequest{
+		ByAdmin:      in.Admin,
+		ByDownstream: in.Downstream,
+	}
+	if in.ParentId != "" {
+		parentID, err := idutil.NormalizeSpiffeID(in.ParentId, idutil.AllowAny())
+		if err != nil {
+			return nil, err
+		}
+		req.ByParentId = &wrappers.StringValue{Value: parentID}
+	}
+	if in.SpiffeId != "" {
+		spiffeID, err := idutil.NormalizeSpiffeID(in.SpiffeId, idutil.AllowAny())
+		if err != nil {
+			return nil, err
+		}
+		req.BySpiffeId = &wrappers.StringValue{Value: spiffeID}
+	}
+	if in.SpiffeIdPrefix != "" {
+		req.BySpiffeIdPrefix = &wrappers.StringValue{Value: in.SpiffeIdPrefix}
+	}
+	if len(in.Selectors) > 0 {
+		match, err := convertSelectorMatch(in.SelectorMatch)
+		if err != nil {
+			return nil, err
+		}
+		req.BySelectors = &datastore.BySelectors{
+			Selectors: in.Selectors,
+			Match:     match,
+		}
+	}
+	for _, trustDomain := range in.FederatesWith {
+		trustDomain, err := idutil.NormalizeSpiffeID(trustDomain, idutil.AllowAnyTrustDomain())
+		if err != nil {
+			return nil, err
+		}
+		req.ByFederatesWith = append(req.ByFederatesWith, trustDomain)
+	}
+	if in.PageSize > 0 {
+		req.Pagination = &datastore.Pagination{
+			Token:    in.PageToken,
+			PageSize: in.PageSize,
+		}
+	}
+	return req, nil
+}
+
+func makeListAttestedNodesRequest(in *registration.ListAgentsRequest) (*datastore.ListAttestedNodesRequest, error) {
+	if in.PageSize < 0 {
+		return nil, errors.New("page size cannot be negative")
+	}
+
+	req := &datastore.ListAttestedNodesRequest{
+		ByBanned:       in.Banned,
+		FetchSelectors: true,
+	}
+	if in.AttestationType != "" {
+		req.ByAttestationType = &wrappers.StringValue{Value: in.AttestationType}
+	}
+	if in.ExpiresAfter != 0 {
+		req.ByExpiresAfter = &wrappers.Int64Value{Value: in.ExpiresAfter}
+	}
+	if in.ExpiresBefore != 0 {
+		req.ByExpiresBefore = &wrappers.Int64Value{Value: in.ExpiresBefore}
+	}
+	if len(in.Selectors) > 0 {
+		match, err := convertSelectorMatch(in.SelectorMatch)
+		if err != nil {
+			return nil, err
+		}
+		req.BySelectorMatch = &datastore.BySelectors{
+			Selectors: in.Selectors,
+			Match:     match,
+		}
+	}
+	if in.PageSize > 0 {
+		req.Pagination = &datastore.Pagination{
+			Token:    in.PageToken,
+			PageSize: in.PageSize,
+		}
+	}
+	return req, nil
+}
+
+func convertSelectorMatch(in registration.ListEntriesRequest_SelectorMatch) (datastore.BySelectors_MatchBehavior, error) {
+	switch in {
+	case registration.ListEntriesRequest_SUPERSET:
+		return datastore.BySelectors_MATCH_SUPERSET, nil
+	case registration.ListEntriesRequest_SUBSET:
+		return datastore.BySelectors_MATCH_SUBSET, nil
+	case registration.ListEntriesRequest_EXACT:
+		return datastore.BySelectors_MATCH_EXACT, nil
+	}
+	return datastore.BySelectors_MATCH_EXACT, fmt.Errorf("unhandled selector match %q", in)
+}
+
 func convertDeleteBundleMode(in registration.DeleteFederatedBundleRequest_Mode) (datastore.DeleteBundleRequest_Mode, error) {
 	switch in {
 	case registration.DeleteFederatedBundleRequest_RESTRICT:
@@ -650,6 +2044,49 @@ func convertDeleteBundleMode(in registration.DeleteFederatedBundleRequest_Mode)
 	return datastore.DeleteBundleRequest_RESTRICT, fmt.Errorf("unhandled delete mode %q", in)
 }
 
+func caSlotFromInfo(info ca.SlotInfo) *registration.CASlot {
+	slot := &registration.CASlot{
+		SlotId:      info.SlotID,
+		AuthorityId: info.AuthorityID,
+	}
+	switch info.Status {
+	case ca.Status_ACTIVE:
+		slot.State = registration.CASlot_ACTIVE
+	case ca.Status_PREPARED:
+		slot.State = registration.CASlot_PREPARED
+	default:
+		slot.State = registration.CASlot_EMPTY
+	}
+	if !info.IssuedAt.IsZero() {
+		slot.IssuedAt = info.IssuedAt.Unix()
+	}
+	if !info.NotAfter.IsZero() {
+		slot.ExpiresAt = info.NotAfter.Unix()
+	}
+	return slot
+}
+
+func issuedSVIDFromDataStore(in *datastore.IssuedSVID) *registration.IssuedSVID {
+	out := &registration.IssuedSVID{
+		Id:          in.Id,
+		SpiffeId:    in.SpiffeId,
+		EntryId:     in.EntryId,
+		AgentId:     in.AgentId,
+		AuthorityId: in.AuthorityId,
+		NotBefore:   in.NotBefore,
+		NotAfter:    in.NotAfter,
+	}
+	switch in.Type {
+	case datastore.IssuedSVID_X509_CA_SVID:
+		out.Type = registration.IssuedSVID_X509_CA_SVID
+	case datastore.IssuedSVID_JWT_SVID:
+		out.Type = registration.IssuedSVID_JWT_SVID
+	default:
+		out.Type = registration.IssuedSVID_X509_SVID
+	}
+	return out
+}
+
 func getSpiffeIDFromCert(cert *x509.Certificate) (string, error) {
 	if len(cert.URIs) == 0 {
 		return "", errors.New("no SPIFFE ID in certificate")
@@ -662,6 +2099,38 @@ func getSpiffeIDFromCert(cert *x509.Certificate) (string, error) {
 }
 
 func authorizeCaller(ctx context.Context, ds datastore.DataStore) (spiffeID string, err error) {
+	spiffeID, err = identifyCaller(ctx)
+	if err != nil {
+		return "", err
+	}
+	if spiffeID == "" {
+		// The caller came over UDS and is therefore authorized. The file
+		// permissions on the UDS are restricted to processes belonging to the
+		// same user or group as the server.
+		return "", nil
+	}
+
+	resp, err := ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
+		BySpiffeId: &wrappers.StringValue{
+			Value: spiffeID,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for _, entry := range resp.Entries {
+		if entry.Admin {
+			return spiffeID, nil
+		}
+	}
+
+	return "", status.Errorf(codes.PermissionDenied, "SPIFFE ID %q is not authorized", spiffeID)
+}
+
+// identifyCaller returns the SPIFFE ID of callers over TCP. Callers over the
+// UDS don't have a SPIFFE ID.
+func identifyCaller(ctx context.Context) (spiffeID string, err error) {
 	ctxPeer, ok := peer.FromContext(ctx)
 	if !ok {
 		return "", status.Error(codes.PermissionDenied, "no peer information for caller")
@@ -684,32 +2153,14 @@ func authorizeCaller(ctx context.Context, ds datastore.DataStore) (spiffeID stri
 		if err != nil {
 			return "", status.Error(codes.PermissionDenied, err.Error())
 		}
+		return spiffeID, nil
 	case peertracker.AuthInfo:
-		// The caller came over UDS and is therefore authorized but does not
-		// provide a spiffeID. The file permissions on the UDS are restricted to
-		// processes belonging to the same user or group as the server.
+		// The caller came over UDS and does not provide a spiffeID
 		return "", nil
 	default:
 		// The caller came over an unknown transport
 		return "", status.Errorf(codes.PermissionDenied, "unsupported peer auth info type (%T)", authInfo)
 	}
-
-	resp, err := ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
-		BySpiffeId: &wrappers.StringValue{
-			Value: spiffeID,
-		},
-	})
-	if err != nil {
-		return "", err
-	}
-
-	for _, entry := range resp.Entries {
-		if entry.Admin {
-			return spiffeID, nil
-		}
-	}
-
-	return "", status.Errorf(codes.PermissionDenied, "SPIFFE ID %q is not authorized", spiffeID)
 }
 
 type callerIDKey struct{}
@@ -2,25 +2,39 @@ package registration
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/pem"
+	"fmt"
+	"io"
 	"net"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/sirupsen/logrus"
 	"github.com/sirupsen/logrus/hooks/test"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/peertracker"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/server/authpolicy"
+	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/proto/spire/api/registration"
 	"github.com/spiffe/spire/proto/spire/common"
 	"github.com/spiffe/spire/proto/spire/server/datastore"
+	"github.com/spiffe/spire/test/clock"
 	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/fakes/fakeserverca"
 	"github.com/spiffe/spire/test/fakes/fakeservercatalog"
+	"github.com/spiffe/spire/test/spiretest"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"google.golang.org/grpc"
@@ -28,6 +42,7 @@ import (
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
+	"gopkg.in/square/go-jose.v2/jwt"
 )
 
 var (
@@ -66,8 +81,11 @@ type HandlerSuite struct {
 	peer   *peer.Peer
 	server *grpc.Server
 
-	ds      *fakedatastore.DataStore
-	handler registration.RegistrationClient
+	ds        *fakedatastore.DataStore
+	caManager *fakeCAManager
+	clock     *clock.Mock
+	serverCA  *fakeserverca.CA
+	handler   registration.RegistrationClient
 }
 
 func (s *HandlerSuite) SetupTest() {
@@ -78,11 +96,24 @@ func (s *HandlerSuite) SetupTest() {
 	catalog := fakeservercatalog.New()
 	catalog.SetDataStore(s.ds)
 
+	s.caManager = &fakeCAManager{}
+	s.clock = clock.NewMock(s.T())
+	s.serverCA = fakeserverca.New(s.T(), "example.org", &fakeserverca.Options{
+		Clock: s.clock,
+	})
+
+	// poll for entry events often so watch tests don't have to wait long
+	watchEntriesPollInterval = 10 * time.Millisecond
+
 	handler := &Handler{
 		Log:         log,
 		Metrics:     telemetry.Blackhole{},
 		TrustDomain: url.URL{Scheme: "spiffe", Host: "example.org"},
 		Catalog:     catalog,
+		CAManager:   s.caManager,
+
+		ServerCA:         s.serverCA,
+		MaxMintedSVIDTTL: 10 * time.Minute,
 	}
 
 	// we need to test a streaming API. without doing the same codegen we
@@ -178,7 +209,9 @@ func (s *HandlerSuite) TestFetchFederatedBundle() {
 		}
 		s.Require().NoError(err)
 		s.Require().NotNil(response)
-		s.Require().Equal(bundleutil.BundleProtoFromRootCADER(testCase.TrustDomainId, []byte(testCase.CaCerts)), response.Bundle)
+		expected := bundleutil.BundleProtoFromRootCADER(testCase.TrustDomainId, []byte(testCase.CaCerts))
+		expected.RevisionNumber = 2
+		s.Require().Equal(expected, response.Bundle)
 	}
 }
 
@@ -209,6 +242,7 @@ func (s *HandlerSuite) TestListFederatedBundles() {
 			RootCas: []*common.Certificate{
 				{DerBytes: []byte("EXAMPLE2")},
 			},
+			RevisionNumber: 2,
 		},
 	}, bundle)
 
@@ -327,6 +361,38 @@ func (s *HandlerSuite) TestCreateEntry() {
 			},
 			Err: "empty or only whitespace",
 		},
+		{
+			Name: "Bad X509-SVID template",
+			Entry: &common.RegistrationEntry{
+				ParentId:  "spiffe://example.org/parent",
+				SpiffeId:  "spiffe://example.org/child",
+				Selectors: []*common.Selector{{Type: "B", Value: "b"}},
+				X509SvidTemplate: &common.X509SVIDTemplate{
+					ExtraKeyUsages: []string{"cert_sign"},
+				},
+			},
+			Err: `X509-SVID template failed validation: key usage "cert_sign" is reserved for CA certificates`,
+		},
+		{
+			Name: "Negative JWT-SVID TTL",
+			Entry: &common.RegistrationEntry{
+				ParentId:   "spiffe://example.org/parent",
+				SpiffeId:   "spiffe://example.org/child",
+				Selectors:  []*common.Selector{{Type: "B", Value: "b"}},
+				JwtSvidTtl: -1,
+			},
+			Err: "JWT-SVID TTL cannot be negative",
+		},
+		{
+			Name: "Reserved JWT-SVID claim",
+			Entry: &common.RegistrationEntry{
+				ParentId:      "spiffe://example.org/parent",
+				SpiffeId:      "spiffe://example.org/child",
+				Selectors:     []*common.Selector{{Type: "B", Value: "b"}},
+				JwtSvidClaims: map[string]string{"exp": "0"},
+			},
+			Err: `JWT-SVID claims failed validation: claim "exp" is reserved`,
+		},
 		{
 			Name: "Success",
 			Entry: &common.RegistrationEntry{
@@ -336,6 +402,30 @@ func (s *HandlerSuite) TestCreateEntry() {
 				DnsNames:  []string{"abcd.ef"},
 			},
 		},
+		{
+			Name: "Success with X509-SVID template",
+			Entry: &common.RegistrationEntry{
+				ParentId:  "spiffe://example.org/parent",
+				SpiffeId:  "spiffe://example.org/gateway",
+				Selectors: []*common.Selector{{Type: "C", Value: "c"}},
+				X509SvidTemplate: &common.X509SVIDTemplate{
+					Subject: &common.X509Subject{
+						OrganizationalUnit: []string{"gateway"},
+					},
+					ExtraKeyUsages: []string{"data_encipherment"},
+				},
+			},
+		},
+		{
+			Name: "Success with JWT-SVID TTL and claims",
+			Entry: &common.RegistrationEntry{
+				ParentId:      "spiffe://example.org/parent",
+				SpiffeId:      "spiffe://example.org/api",
+				Selectors:     []*common.Selector{{Type: "D", Value: "d"}},
+				JwtSvidTtl:    300,
+				JwtSvidClaims: map[string]string{"tenant": "acme"},
+			},
+		},
 		{
 			Name: "AlreadyExists",
 			Entry: &common.RegistrationEntry{
@@ -362,8 +452,9 @@ func (s *HandlerSuite) TestCreateEntry() {
 			})
 			require.NoError(t, err)
 			require.NotNil(t, entry)
-			// set ID (unknown before fetch) to do comparison
+			// set ID and revision (unknown before fetch) to do comparison
 			testCase.Entry.EntryId = entry.Entry.EntryId
+			testCase.Entry.RevisionNumber = entry.Entry.RevisionNumber
 			t.Logf("actual=%+v expected=%+v", entry.Entry, testCase.Entry)
 			require.True(t, proto.Equal(entry.Entry, testCase.Entry))
 		})
@@ -435,12 +526,82 @@ func (s *HandlerSuite) TestUpdateEntry() {
 				return
 			}
 			require.NoError(t, err)
+			// set revision (unknown before update) to do comparison
+			require.True(t, resp.RevisionNumber > entry.RevisionNumber)
+			testCase.Entry.RevisionNumber = resp.RevisionNumber
 			t.Logf("actual=%+v expected=%+v", resp, testCase.Entry)
 			require.True(t, proto.Equal(resp, testCase.Entry))
 		})
 	}
 }
 
+func (s *HandlerSuite) TestUpdateEntryWithStaleRevision() {
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/foo",
+		SpiffeId:  "spiffe://example.org/bar",
+		Selectors: []*common.Selector{{Type: "A", Value: "a"}},
+	})
+
+	entry.Ttl = 60
+	updated, err := s.handler.UpdateEntry(context.Background(), &registration.UpdateEntryRequest{
+		Entry: entry,
+	})
+	s.Require().NoError(err)
+	s.Require().True(updated.RevisionNumber > entry.RevisionNumber)
+
+	// the entry changed since it was fetched
+	entry.Ttl = 120
+	_, err = s.handler.UpdateEntry(context.Background(), &registration.UpdateEntryRequest{
+		Entry: entry,
+	})
+	s.requireGRPCStatusCode(err, codes.FailedPrecondition)
+
+	fetched, err := s.handler.FetchEntry(context.Background(), &registration.RegistrationEntryID{Id: entry.EntryId})
+	s.Require().NoError(err)
+	s.Require().Equal(int32(60), fetched.Ttl)
+}
+
+func (s *HandlerSuite) TestUpdateEntryWithMask() {
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/foo",
+		SpiffeId:  "spiffe://example.org/bar",
+		Selectors: []*common.Selector{{Type: "A", Value: "a"}},
+		DnsNames:  []string{"foo.example.org"},
+	})
+
+	// only the DNS names change
+	resp, err := s.handler.UpdateEntry(context.Background(), &registration.UpdateEntryRequest{
+		Entry: &common.RegistrationEntry{
+			EntryId:  entry.EntryId,
+			DnsNames: []string{"bar.example.org"},
+		},
+		Mask: &common.RegistrationEntryMask{DnsNames: true},
+	})
+	s.Require().NoError(err)
+
+	expected := proto.Clone(entry).(*common.RegistrationEntry)
+	expected.DnsNames = []string{"bar.example.org"}
+	expected.RevisionNumber = resp.RevisionNumber
+	s.Require().True(proto.Equal(expected, resp), "expected=%+v actual=%+v", expected, resp)
+
+	// masked fields are validated
+	_, err = s.handler.UpdateEntry(context.Background(), &registration.UpdateEntryRequest{
+		Entry: &common.RegistrationEntry{
+			EntryId:  entry.EntryId,
+			DnsNames: []string{" "},
+		},
+		Mask: &common.RegistrationEntryMask{DnsNames: true},
+	})
+	s.requireErrorContains(err, "empty or only whitespace")
+
+	// and the entry must exist
+	_, err = s.handler.UpdateEntry(context.Background(), &registration.UpdateEntryRequest{
+		Entry: &common.RegistrationEntry{EntryId: "X"},
+		Mask:  &common.RegistrationEntryMask{DnsNames: true},
+	})
+	s.requireGRPCStatusCode(err, codes.NotFound)
+}
+
 func (s *HandlerSuite) TestDeleteEntry() {
 	entry := s.createRegistrationEntry(&common.RegistrationEntry{
 		ParentId:  "spiffe://example.org/foo",
@@ -732,6 +893,323 @@ func (s *HandlerSuite) TestListBySpiffeID() {
 	s.Require().True(proto.Equal(entry2, resp.Entries[1]))
 }
 
+func (s *HandlerSuite) TestListEntries() {
+	s.createBundle(&common.Bundle{TrustDomainId: "spiffe://otherdomain.org"})
+
+	entry1 := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/parent",
+		SpiffeId:  "spiffe://example.org/foo",
+		Selectors: []*common.Selector{{Type: "A", Value: "a"}},
+	})
+	entry2 := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:      "spiffe://example.org/parent",
+		SpiffeId:      "spiffe://example.org/foo/bar",
+		Selectors:     []*common.Selector{{Type: "A", Value: "a"}, {Type: "B", Value: "b"}},
+		FederatesWith: []string{"spiffe://otherdomain.org"},
+		Admin:         true,
+	})
+	entry3 := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:   "spiffe://example.org/other",
+		SpiffeId:   "spiffe://example.org/baz",
+		Selectors:  []*common.Selector{{Type: "B", Value: "b"}},
+		Downstream: true,
+	})
+
+	testCases := []struct {
+		name    string
+		req     *registration.ListEntriesRequest
+		entries []*common.RegistrationEntry
+		err     string
+	}{
+		{
+			name:    "no filters",
+			req:     &registration.ListEntriesRequest{},
+			entries: []*common.RegistrationEntry{entry1, entry2, entry3},
+		},
+		{
+			name: "by parent id",
+			req: &registration.ListEntriesRequest{
+				ParentId: "spiffe://EXAMPLE.org/parent",
+			},
+			entries: []*common.RegistrationEntry{entry1, entry2},
+		},
+		{
+			name: "by malformed parent id",
+			req: &registration.ListEntriesRequest{
+				ParentId: "whatever",
+			},
+			err: `"whatever" is not a valid SPIFFE ID`,
+		},
+		{
+			name: "by spiffe id",
+			req: &registration.ListEntriesRequest{
+				SpiffeId: "spiffe://example.org/foo",
+			},
+			entries: []*common.RegistrationEntry{entry1},
+		},
+		{
+			name: "by spiffe id prefix",
+			req: &registration.ListEntriesRequest{
+				SpiffeIdPrefix: "spiffe://example.org/foo/",
+			},
+			entries: []*common.RegistrationEntry{entry2},
+		},
+		{
+			name: "by superset selectors",
+			req: &registration.ListEntriesRequest{
+				Selectors: []*common.Selector{{Type: "B", Value: "b"}},
+			},
+			entries: []*common.RegistrationEntry{entry2, entry3},
+		},
+		{
+			name: "by subset selectors",
+			req: &registration.ListEntriesRequest{
+				Selectors:     []*common.Selector{{Type: "A", Value: "a"}},
+				SelectorMatch: registration.ListEntriesRequest_SUBSET,
+			},
+			entries: []*common.RegistrationEntry{entry1},
+		},
+		{
+			name: "by exact selectors",
+			req: &registration.ListEntriesRequest{
+				Selectors:     []*common.Selector{{Type: "A", Value: "a"}, {Type: "B", Value: "b"}},
+				SelectorMatch: registration.ListEntriesRequest_EXACT,
+			},
+			entries: []*common.RegistrationEntry{entry2},
+		},
+		{
+			name: "by federates with",
+			req: &registration.ListEntriesRequest{
+				FederatesWith: []string{"spiffe://otherdomain.org"},
+			},
+			entries: []*common.RegistrationEntry{entry2},
+		},
+		{
+			name: "by downstream",
+			req: &registration.ListEntriesRequest{
+				Downstream: &wrappers.BoolValue{Value: true},
+			},
+			entries: []*common.RegistrationEntry{entry3},
+		},
+		{
+			name: "by not admin",
+			req: &registration.ListEntriesRequest{
+				Admin: &wrappers.BoolValue{Value: false},
+			},
+			entries: []*common.RegistrationEntry{entry1, entry3},
+		},
+		{
+			name: "combined",
+			req: &registration.ListEntriesRequest{
+				ParentId:  "spiffe://example.org/parent",
+				Selectors: []*common.Selector{{Type: "A", Value: "a"}},
+				Admin:     &wrappers.BoolValue{Value: true},
+			},
+			entries: []*common.RegistrationEntry{entry2},
+		},
+		{
+			name: "negative page size",
+			req: &registration.ListEntriesRequest{
+				PageSize: -1,
+			},
+			err: "page size cannot be negative",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		s.T().Run(testCase.name, func(t *testing.T) {
+			resp, err := s.handler.ListEntries(context.Background(), testCase.req)
+			if testCase.err != "" {
+				requireErrorContains(t, err, testCase.err)
+				requireGRPCStatusCode(t, err, codes.InvalidArgument)
+				return
+			}
+			require.NoError(t, err)
+			requireEntriesEqual(t, testCase.entries, resp.Entries)
+			require.Empty(t, resp.NextPageToken)
+		})
+	}
+}
+
+func (s *HandlerSuite) TestListEntriesPages() {
+	var entries []*common.RegistrationEntry
+	for i := 0; i < 5; i++ {
+		entries = append(entries, s.createRegistrationEntry(&common.RegistrationEntry{
+			ParentId:  "spiffe://example.org/parent",
+			SpiffeId:  fmt.Sprintf("spiffe://example.org/workload%d", i),
+			Selectors: []*common.Selector{{Type: "A", Value: "a"}},
+		}))
+	}
+
+	var actual []*common.RegistrationEntry
+	var pages int
+	req := &registration.ListEntriesRequest{
+		PageSize: 2,
+	}
+	for {
+		resp, err := s.handler.ListEntries(context.Background(), req)
+		s.Require().NoError(err)
+		s.Require().True(len(resp.Entries) <= 2)
+		actual = append(actual, resp.Entries...)
+		pages++
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	s.Require().Equal(3, pages)
+	requireEntriesEqual(s.T(), entries, actual)
+}
+
+func (s *HandlerSuite) TestBatchCreateEntry() {
+	existing := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/parent",
+		SpiffeId:  "spiffe://example.org/existing",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	})
+
+	good := &common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/parent",
+		SpiffeId:  "spiffe://example.org/good",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	}
+	badSpiffeID := &common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/parent",
+		SpiffeId:  "spiffe://otherdomain.org/bad",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	}
+	duplicate := &common.RegistrationEntry{
+		ParentId:  existing.ParentId,
+		SpiffeId:  existing.SpiffeId,
+		Selectors: existing.Selectors,
+	}
+
+	// all-or-nothing batches are aborted by validation failures
+	resp, err := s.handler.BatchCreateEntry(context.Background(), &registration.BatchCreateEntryRequest{
+		Entries:      []*common.RegistrationEntry{good, badSpiffeID},
+		AllOrNothing: true,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Results, 2)
+	s.Require().Equal(int32(codes.Aborted), resp.Results[0].Code)
+	s.Require().Equal(int32(codes.InvalidArgument), resp.Results[1].Code)
+
+	// ... and by datastore failures
+	resp, err = s.handler.BatchCreateEntry(context.Background(), &registration.BatchCreateEntryRequest{
+		Entries:      []*common.RegistrationEntry{good, duplicate},
+		AllOrNothing: true,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Results, 2)
+	s.Require().Equal(int32(codes.Aborted), resp.Results[0].Code)
+	s.Require().Equal(int32(codes.AlreadyExists), resp.Results[1].Code)
+
+	entries, err := s.handler.FetchEntries(context.Background(), &common.Empty{})
+	s.Require().NoError(err)
+	s.Require().Len(entries.Entries, 1)
+
+	resp, err = s.handler.BatchCreateEntry(context.Background(), &registration.BatchCreateEntryRequest{
+		Entries: []*common.RegistrationEntry{badSpiffeID, duplicate, good},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Results, 3)
+	s.Require().Equal(int32(codes.InvalidArgument), resp.Results[0].Code)
+	s.Require().Equal(`"spiffe://otherdomain.org/bad" does not belong to trust domain "example.org"`, resp.Results[0].Message)
+	s.Require().Equal(int32(codes.AlreadyExists), resp.Results[1].Code)
+	s.Require().Equal(int32(codes.OK), resp.Results[2].Code)
+	s.Require().NotEmpty(resp.Results[2].Entry.EntryId)
+	s.Require().Equal(good.SpiffeId, resp.Results[2].Entry.SpiffeId)
+
+	entries, err = s.handler.FetchEntries(context.Background(), &common.Empty{})
+	s.Require().NoError(err)
+	s.Require().Len(entries.Entries, 2)
+}
+
+func (s *HandlerSuite) TestBatchUpdateEntry() {
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/parent",
+		SpiffeId:  "spiffe://example.org/foo",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		Ttl:       1,
+	})
+
+	entry.Ttl = 2
+	missingID := &common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/parent",
+		SpiffeId:  "spiffe://example.org/bar",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	}
+	noSuchEntry := &common.RegistrationEntry{
+		EntryId:   "badid",
+		ParentId:  "spiffe://example.org/parent",
+		SpiffeId:  "spiffe://example.org/bar",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	}
+
+	resp, err := s.handler.BatchUpdateEntry(context.Background(), &registration.BatchUpdateEntryRequest{
+		Entries:      []*common.RegistrationEntry{entry, missingID},
+		AllOrNothing: true,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Results, 2)
+	s.Require().Equal(int32(codes.Aborted), resp.Results[0].Code)
+	s.Require().Equal(int32(codes.InvalidArgument), resp.Results[1].Code)
+	s.Require().Equal("missing registration entry id", resp.Results[1].Message)
+
+	resp, err = s.handler.BatchUpdateEntry(context.Background(), &registration.BatchUpdateEntryRequest{
+		Entries: []*common.RegistrationEntry{entry, missingID, noSuchEntry},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Results, 3)
+	s.Require().Equal(int32(codes.OK), resp.Results[0].Code)
+	s.Require().Equal(int32(codes.InvalidArgument), resp.Results[1].Code)
+	s.Require().Equal(int32(codes.NotFound), resp.Results[2].Code)
+	s.Require().Equal(int32(2), resp.Results[0].Entry.Ttl)
+
+	fetched, err := s.handler.FetchEntry(context.Background(), &registration.RegistrationEntryID{Id: entry.EntryId})
+	s.Require().NoError(err)
+	s.Require().Equal(int32(2), fetched.Ttl)
+}
+
+func (s *HandlerSuite) TestBatchDeleteEntry() {
+	entry1 := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/parent",
+		SpiffeId:  "spiffe://example.org/foo",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	})
+	entry2 := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/parent",
+		SpiffeId:  "spiffe://example.org/bar",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	})
+
+	resp, err := s.handler.BatchDeleteEntry(context.Background(), &registration.BatchDeleteEntryRequest{
+		Ids:          []string{entry1.EntryId, ""},
+		AllOrNothing: true,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Results, 2)
+	s.Require().Equal(int32(codes.Aborted), resp.Results[0].Code)
+	s.Require().Equal(int32(codes.InvalidArgument), resp.Results[1].Code)
+
+	resp, err = s.handler.BatchDeleteEntry(context.Background(), &registration.BatchDeleteEntryRequest{
+		Ids: []string{entry1.EntryId, "", "badid", entry2.EntryId},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Results, 4)
+	s.Require().Equal(int32(codes.OK), resp.Results[0].Code)
+	s.Require().Equal(int32(codes.InvalidArgument), resp.Results[1].Code)
+	s.Require().Equal(int32(codes.NotFound), resp.Results[2].Code)
+	s.Require().Equal(int32(codes.OK), resp.Results[3].Code)
+	s.Require().Equal(entry1.EntryId, resp.Results[0].Entry.EntryId)
+	s.Require().Equal(entry2.EntryId, resp.Results[3].Entry.EntryId)
+
+	entries, err := s.handler.FetchEntries(context.Background(), &common.Empty{})
+	s.Require().NoError(err)
+	s.Require().Empty(entries.Entries)
+}
+
 func (s *HandlerSuite) TestCreateJoinToken() {
 	// No ttl
 	resp, err := s.handler.CreateJoinToken(context.Background(), &registration.JoinToken{Token: "foo"})
@@ -755,6 +1233,129 @@ func (s *HandlerSuite) TestCreateJoinToken() {
 	s.Require().Nil(resp)
 }
 
+func (s *HandlerSuite) TestCreateJoinTokenWithEntriesAndSelectors() {
+	entry := &common.RegistrationEntry{
+		SpiffeId:  "spiffe://example.org/workload",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	}
+
+	// Negative max uses
+	resp, err := s.handler.CreateJoinToken(context.Background(), &registration.JoinToken{Token: "foo", Ttl: 1, MaxUses: -1})
+	s.requireErrorContains(err, "max uses cannot be negative")
+	s.Require().Equal(codes.InvalidArgument, status.Code(err))
+	s.Require().Nil(resp)
+
+	// Incomplete node selector
+	resp, err = s.handler.CreateJoinToken(context.Background(), &registration.JoinToken{
+		Token:         "foo",
+		Ttl:           1,
+		NodeSelectors: []*common.Selector{{Type: "token"}},
+	})
+	s.requireErrorContains(err, "node selectors must have a type and a value")
+	s.Require().Equal(codes.InvalidArgument, status.Code(err))
+	s.Require().Nil(resp)
+
+	// Entry with a parent ID
+	resp, err = s.handler.CreateJoinToken(context.Background(), &registration.JoinToken{
+		Token: "foo",
+		Ttl:   1,
+		Entries: []*common.RegistrationEntry{
+			{
+				ParentId:  "spiffe://example.org/parent",
+				SpiffeId:  entry.SpiffeId,
+				Selectors: entry.Selectors,
+			},
+		},
+	})
+	s.requireErrorContains(err, "join token entries cannot have a parent ID")
+	s.Require().Equal(codes.InvalidArgument, status.Code(err))
+	s.Require().Nil(resp)
+
+	// Entry with a SPIFFE ID in another trust domain
+	resp, err = s.handler.CreateJoinToken(context.Background(), &registration.JoinToken{
+		Token: "foo",
+		Ttl:   1,
+		Entries: []*common.RegistrationEntry{
+			{
+				SpiffeId:  "spiffe://otherdomain.org/workload",
+				Selectors: entry.Selectors,
+			},
+		},
+	})
+	s.Require().Equal(codes.InvalidArgument, status.Code(err))
+	s.Require().Nil(resp)
+
+	// Success
+	resp, err = s.handler.CreateJoinToken(context.Background(), &registration.JoinToken{
+		Token:         "foo",
+		Ttl:           60,
+		MaxUses:       3,
+		NodeSelectors: []*common.Selector{{Type: "token", Value: "batch"}},
+		Entries:       []*common.RegistrationEntry{entry},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal("foo", resp.Token)
+
+	token := s.fetchJoinToken("foo")
+	s.Require().NotNil(token)
+	s.Require().Equal(int32(3), token.MaxUses)
+	spiretest.RequireProtoListEqual(s.T(), []*common.Selector{{Type: "token", Value: "batch"}}, token.NodeSelectors)
+	spiretest.RequireProtoListEqual(s.T(), []*common.RegistrationEntry{entry}, token.Entries)
+}
+
+func (s *HandlerSuite) TestListJoinTokens() {
+	// No tokens
+	resp, err := s.handler.ListJoinTokens(context.Background(), &registration.ListJoinTokensRequest{})
+	s.Require().NoError(err)
+	s.Require().Empty(resp.JoinTokens)
+
+	token := &datastore.JoinToken{
+		Token:         "foo",
+		Expiry:        time.Now().Add(time.Hour).Unix(),
+		MaxUses:       2,
+		Uses:          1,
+		NodeSelectors: []*common.Selector{{Type: "token", Value: "batch"}},
+	}
+	_, err = s.ds.CreateJoinToken(context.Background(), &datastore.CreateJoinTokenRequest{
+		JoinToken: token,
+	})
+	s.Require().NoError(err)
+
+	resp, err = s.handler.ListJoinTokens(context.Background(), &registration.ListJoinTokensRequest{})
+	s.Require().NoError(err)
+	spiretest.RequireProtoListEqual(s.T(), []*registration.JoinToken{
+		{
+			Token:         "foo",
+			Expiry:        token.Expiry,
+			MaxUses:       2,
+			Uses:          1,
+			NodeSelectors: token.NodeSelectors,
+		},
+	}, resp.JoinTokens)
+}
+
+func (s *HandlerSuite) TestDeleteJoinToken() {
+	// No token
+	resp, err := s.handler.DeleteJoinToken(context.Background(), &registration.DeleteJoinTokenRequest{})
+	s.requireErrorContains(err, "a join token is required")
+	s.Require().Equal(codes.InvalidArgument, status.Code(err))
+	s.Require().Nil(resp)
+
+	// No such token
+	resp, err = s.handler.DeleteJoinToken(context.Background(), &registration.DeleteJoinTokenRequest{Token: "foo"})
+	s.requireErrorContains(err, "no such join token")
+	s.Require().Equal(codes.NotFound, status.Code(err))
+	s.Require().Nil(resp)
+
+	// Success
+	_, err = s.handler.CreateJoinToken(context.Background(), &registration.JoinToken{Token: "foo", Ttl: 60})
+	s.Require().NoError(err)
+	resp, err = s.handler.DeleteJoinToken(context.Background(), &registration.DeleteJoinTokenRequest{Token: "foo"})
+	s.Require().NoError(err)
+	s.Require().Equal("foo", resp.JoinToken.Token)
+	s.Require().Nil(s.fetchJoinToken("foo"))
+}
+
 func (s *HandlerSuite) TestFetchBundle() {
 	// No bundle
 	resp, err := s.handler.FetchBundle(context.Background(), &common.Empty{})
@@ -776,6 +1377,7 @@ func (s *HandlerSuite) TestFetchBundle() {
 			RootCas: []*common.Certificate{
 				{DerBytes: []byte("EXAMPLE")},
 			},
+			RevisionNumber: 1,
 		},
 	}, resp)
 }
@@ -790,6 +1392,64 @@ func (s *HandlerSuite) TestEvictAgent() {
 	s.Equal(evictResponse.Node, node, "Evict did not remove spiffeID: %q", spiffeIDToRemove)
 }
 
+func (s *HandlerSuite) TestEvictAgentRevokesCertificates() {
+	agentID := "spiffe://example.org/spire/agent/join_token/token_a"
+	otherAgentID := "spiffe://example.org/spire/agent/join_token/token_b"
+	ctx := context.Background()
+	_, err := s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{
+		Node: &common.AttestedNode{
+			SpiffeId:         agentID,
+			CertSerialNumber: "1",
+			CertNotAfter:     100,
+		},
+	})
+	s.Require().NoError(err)
+	for _, downstreamCA := range []*datastore.DownstreamCA{
+		{SerialNumber: "2", SpiffeId: "spiffe://example.org/downstream", AgentId: agentID, ExpiresAt: 200},
+		{SerialNumber: "3", SpiffeId: "spiffe://example.org/downstream", AgentId: otherAgentID, ExpiresAt: 300},
+	} {
+		_, err := s.ds.CreateDownstreamCA(ctx, &datastore.CreateDownstreamCARequest{
+			DownstreamCa: downstreamCA,
+		})
+		s.Require().NoError(err)
+	}
+	notAfter := time.Now().Add(time.Hour).Unix()
+	for _, issuedSVID := range []*datastore.IssuedSVID{
+		// the current agent SVID
+		{Id: "1", Type: datastore.IssuedSVID_X509_SVID, SpiffeId: agentID, NotAfter: notAfter},
+		// an earlier agent SVID that has not expired yet
+		{Id: "4", Type: datastore.IssuedSVID_X509_SVID, SpiffeId: agentID, NotAfter: notAfter},
+		// an earlier agent SVID that has expired
+		{Id: "5", Type: datastore.IssuedSVID_X509_SVID, SpiffeId: agentID, NotAfter: 100},
+		// an SVID of the other agent
+		{Id: "6", Type: datastore.IssuedSVID_X509_SVID, SpiffeId: otherAgentID, NotAfter: notAfter},
+	} {
+		_, err := s.ds.CreateIssuedSVID(ctx, &datastore.CreateIssuedSVIDRequest{
+			IssuedSvid: issuedSVID,
+		})
+		s.Require().NoError(err)
+	}
+
+	_, err = s.handler.EvictAgent(ctx, &registration.EvictAgentRequest{SpiffeID: agentID})
+	s.Require().NoError(err)
+
+	// the unexpired agent SVIDs and the downstream CAs issued through the
+	// agent are revoked, but not those issued to or through other agents
+	resp, err := s.ds.ListRevokedCertificates(ctx, &datastore.ListRevokedCertificatesRequest{})
+	s.Require().NoError(err)
+	s.Require().Len(resp.RevokedCertificates, 3)
+	s.Require().Equal("1", resp.RevokedCertificates[0].SerialNumber)
+	s.Require().Equal(agentID, resp.RevokedCertificates[0].SpiffeId)
+	s.Require().Equal(int64(100), resp.RevokedCertificates[0].ExpiresAt)
+	s.Require().NotZero(resp.RevokedCertificates[0].RevokedAt)
+	s.Require().Equal("2", resp.RevokedCertificates[1].SerialNumber)
+	s.Require().Equal("spiffe://example.org/downstream", resp.RevokedCertificates[1].SpiffeId)
+	s.Require().Equal(int64(200), resp.RevokedCertificates[1].ExpiresAt)
+	s.Require().Equal("4", resp.RevokedCertificates[2].SerialNumber)
+	s.Require().Equal(agentID, resp.RevokedCertificates[2].SpiffeId)
+	s.Require().Equal(notAfter, resp.RevokedCertificates[2].ExpiresAt)
+}
+
 func (s *HandlerSuite) TestEvictAgentWithNonExistentId() {
 	spiffeIDToAdd := "spiffe://example.org/spire/agent/join_token/token_a"
 	spiffeIDToRemove := "spiffe://example.org/spire/agent/join_token/token_b"
@@ -802,8 +1462,85 @@ func (s *HandlerSuite) TestEvictAgentWithNonExistentId() {
 	s.Error(err, "Evict should have failed")
 }
 
-func (s *HandlerSuite) TestListAgents() {
-	// Creating attested nodes list
+func (s *HandlerSuite) TestBanAgent() {
+	ctx := context.Background()
+	agentID := "spiffe://example.org/spire/agent/join_token/token_a"
+	_, err := s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{
+		Node: &common.AttestedNode{
+			SpiffeId:         agentID,
+			CertSerialNumber: "1",
+			CertNotAfter:     100,
+		},
+	})
+	s.Require().NoError(err)
+
+	resp, err := s.handler.BanAgent(ctx, &registration.BanAgentRequest{SpiffeId: agentID})
+	s.Require().NoError(err)
+	s.Require().True(resp.Node.Banned)
+
+	// the node is kept, and its SVID revoked
+	fetchResp, err := s.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: agentID})
+	s.Require().NoError(err)
+	s.Require().NotNil(fetchResp.Node)
+	s.Require().True(fetchResp.Node.Banned)
+
+	revokedResp, err := s.ds.ListRevokedCertificates(ctx, &datastore.ListRevokedCertificatesRequest{})
+	s.Require().NoError(err)
+	s.Require().Len(revokedResp.RevokedCertificates, 1)
+	s.Require().Equal("1", revokedResp.RevokedCertificates[0].SerialNumber)
+
+	// unban
+	unbanResp, err := s.handler.UnbanAgent(ctx, &registration.UnbanAgentRequest{SpiffeId: agentID})
+	s.Require().NoError(err)
+	s.Require().False(unbanResp.Node.Banned)
+
+	fetchResp, err = s.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: agentID})
+	s.Require().NoError(err)
+	s.Require().False(fetchResp.Node.Banned)
+}
+
+func (s *HandlerSuite) TestBanAgentWithNonExistentID() {
+	ctx := context.Background()
+	_, err := s.handler.BanAgent(ctx, &registration.BanAgentRequest{SpiffeId: "spiffe://example.org/spire/agent/join_token/token_a"})
+	s.requireGRPCStatusCode(err, codes.NotFound)
+	_, err = s.handler.UnbanAgent(ctx, &registration.UnbanAgentRequest{SpiffeId: "spiffe://example.org/spire/agent/join_token/token_a"})
+	s.requireGRPCStatusCode(err, codes.NotFound)
+}
+
+func (s *HandlerSuite) TestEvictBannedAgent() {
+	ctx := context.Background()
+	agentID := "spiffe://example.org/spire/agent/join_token/token_a"
+	s.createAttestedNode(agentID)
+
+	_, err := s.handler.BanAgent(ctx, &registration.BanAgentRequest{SpiffeId: agentID})
+	s.Require().NoError(err)
+
+	// evicting would lift the ban, so it is refused
+	_, err = s.handler.EvictAgent(ctx, &registration.EvictAgentRequest{SpiffeID: agentID})
+	s.requireGRPCStatusCode(err, codes.FailedPrecondition)
+
+	fetchResp, err := s.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: agentID})
+	s.Require().NoError(err)
+	s.Require().NotNil(fetchResp.Node)
+	s.Require().True(fetchResp.Node.Banned)
+
+	// once unbanned, the agent can be evicted
+	_, err = s.handler.UnbanAgent(ctx, &registration.UnbanAgentRequest{SpiffeId: agentID})
+	s.Require().NoError(err)
+	_, err = s.handler.EvictAgent(ctx, &registration.EvictAgentRequest{SpiffeID: agentID})
+	s.Require().NoError(err)
+}
+
+func (s *HandlerSuite) TestBanAgentWithInvalidID() {
+	ctx := context.Background()
+	_, err := s.handler.BanAgent(ctx, &registration.BanAgentRequest{SpiffeId: "spiffe://example.org/workload"})
+	s.requireGRPCStatusCode(err, codes.InvalidArgument)
+	_, err = s.handler.UnbanAgent(ctx, &registration.UnbanAgentRequest{SpiffeId: "spiffe://otherdomain.org/spire/agent/a"})
+	s.requireGRPCStatusCode(err, codes.InvalidArgument)
+}
+
+func (s *HandlerSuite) TestListAgents() {
+	// Creating attested nodes list
 	ctx := context.Background()
 	spiffeID1 := "spiffe://example.org/spire/agent/join_token/token_a"
 	spiffeID2 := "spiffe://example.org/spire/agent/join_token/token_b"
@@ -831,6 +1568,602 @@ func (s *HandlerSuite) TestListWithNoAgents() {
 	s.Len(listResponse.Nodes, 0)
 }
 
+func (s *HandlerSuite) TestListAgentsWithFilters() {
+	ctx := context.Background()
+	nodeA := s.createAttestedNodeWithSelectors(&common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/spire/agent/a",
+		AttestationDataType: "join_token",
+		CertSerialNumber:    "1",
+		CertNotAfter:        100,
+	}, &common.Selector{Type: "A", Value: "a"}, &common.Selector{Type: "B", Value: "b"})
+	nodeB := s.createAttestedNodeWithSelectors(&common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/spire/agent/b",
+		AttestationDataType: "x509pop",
+		CertSerialNumber:    "2",
+		CertNotAfter:        200,
+	}, &common.Selector{Type: "A", Value: "a"})
+	nodeC := s.createAttestedNodeWithSelectors(&common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/spire/agent/c",
+		AttestationDataType: "x509pop",
+		CertSerialNumber:    "3",
+		CertNotAfter:        300,
+		Banned:              true,
+	})
+
+	testCases := []struct {
+		name  string
+		req   *registration.ListAgentsRequest
+		nodes []*common.AttestedNode
+		err   string
+	}{
+		{
+			name:  "no filters",
+			req:   &registration.ListAgentsRequest{},
+			nodes: []*common.AttestedNode{nodeA, nodeB, nodeC},
+		},
+		{
+			name:  "by attestation type",
+			req:   &registration.ListAgentsRequest{AttestationType: "x509pop"},
+			nodes: []*common.AttestedNode{nodeB, nodeC},
+		},
+		{
+			name:  "by expiry window",
+			req:   &registration.ListAgentsRequest{ExpiresAfter: 200, ExpiresBefore: 300},
+			nodes: []*common.AttestedNode{nodeB},
+		},
+		{
+			name: "by selector superset",
+			req: &registration.ListAgentsRequest{
+				Selectors: []*common.Selector{{Type: "A", Value: "a"}},
+			},
+			nodes: []*common.AttestedNode{nodeA, nodeB},
+		},
+		{
+			name: "by selector exact",
+			req: &registration.ListAgentsRequest{
+				Selectors:     []*common.Selector{{Type: "A", Value: "a"}},
+				SelectorMatch: registration.ListEntriesRequest_EXACT,
+			},
+			nodes: []*common.AttestedNode{nodeB},
+		},
+		{
+			name:  "banned",
+			req:   &registration.ListAgentsRequest{Banned: &wrappers.BoolValue{Value: true}},
+			nodes: []*common.AttestedNode{nodeC},
+		},
+		{
+			name:  "not banned",
+			req:   &registration.ListAgentsRequest{Banned: &wrappers.BoolValue{Value: false}},
+			nodes: []*common.AttestedNode{nodeA, nodeB},
+		},
+		{
+			name: "negative page size",
+			req:  &registration.ListAgentsRequest{PageSize: -1},
+			err:  "page size cannot be negative",
+		},
+	}
+
+	for _, testCase := range testCases {
+		testCase := testCase
+		s.T().Run(testCase.name, func(t *testing.T) {
+			resp, err := s.handler.ListAgents(ctx, testCase.req)
+			if testCase.err != "" {
+				requireGRPCStatusCode(t, err, codes.InvalidArgument)
+				require.Contains(t, err.Error(), testCase.err)
+				return
+			}
+			require.NoError(t, err)
+			spiretest.RequireProtoListEqual(t, testCase.nodes, resp.Nodes)
+			require.Empty(t, resp.NextPageToken)
+		})
+	}
+}
+
+func (s *HandlerSuite) TestListAgentsPages() {
+	var nodes []*common.AttestedNode
+	for i := 0; i < 5; i++ {
+		nodes = append(nodes, s.createAttestedNode(fmt.Sprintf("spiffe://example.org/spire/agent/node%d", i)))
+	}
+
+	var actual []*common.AttestedNode
+	var pages int
+	req := &registration.ListAgentsRequest{
+		PageSize: 2,
+	}
+	for {
+		resp, err := s.handler.ListAgents(context.Background(), req)
+		s.Require().NoError(err)
+		s.Require().True(len(resp.Nodes) <= 2)
+		actual = append(actual, resp.Nodes...)
+		pages++
+		if resp.NextPageToken == "" {
+			break
+		}
+		req.PageToken = resp.NextPageToken
+	}
+	s.Require().Equal(3, pages)
+	spiretest.RequireProtoListEqual(s.T(), nodes, actual)
+}
+
+func (s *HandlerSuite) TestFetchAgent() {
+	ctx := context.Background()
+	node := s.createAttestedNodeWithSelectors(&common.AttestedNode{
+		SpiffeId:            "spiffe://example.org/spire/agent/a",
+		AttestationDataType: "join_token",
+		CertSerialNumber:    "1",
+		CertNotAfter:        100,
+	}, &common.Selector{Type: "A", Value: "a"})
+
+	resp, err := s.handler.FetchAgent(ctx, &registration.FetchAgentRequest{
+		SpiffeId: "spiffe://example.org/spire/agent/a",
+	})
+	s.Require().NoError(err)
+	spiretest.RequireProtoEqual(s.T(), node, resp.Node)
+
+	// unknown agent
+	_, err = s.handler.FetchAgent(ctx, &registration.FetchAgentRequest{
+		SpiffeId: "spiffe://example.org/spire/agent/b",
+	})
+	s.requireGRPCStatusCode(err, codes.NotFound)
+
+	// not an agent ID
+	_, err = s.handler.FetchAgent(ctx, &registration.FetchAgentRequest{
+		SpiffeId: "spiffe://example.org/workload",
+	})
+	s.requireGRPCStatusCode(err, codes.InvalidArgument)
+}
+
+func (s *HandlerSuite) TestListCASlots() {
+	issuedAt := time.Unix(1000, 0)
+	notAfter := time.Unix(2000, 0)
+	s.caManager.x509Current = ca.SlotInfo{SlotID: "A", Status: ca.Status_ACTIVE, AuthorityID: "0102", IssuedAt: issuedAt, NotAfter: notAfter}
+	s.caManager.x509Next = ca.SlotInfo{SlotID: "B"}
+	s.caManager.jwtCurrent = ca.SlotInfo{SlotID: "A", Status: ca.Status_ACTIVE, AuthorityID: "KID1"}
+	s.caManager.jwtNext = ca.SlotInfo{SlotID: "B", Status: ca.Status_PREPARED, AuthorityID: "KID2"}
+
+	resp, err := s.handler.ListCASlots(context.Background(), &registration.ListCASlotsRequest{})
+	s.Require().NoError(err)
+	s.Equal([]*registration.CASlot{
+		{SlotId: "A", State: registration.CASlot_ACTIVE, AuthorityId: "0102", IssuedAt: 1000, ExpiresAt: 2000},
+		{SlotId: "B", State: registration.CASlot_EMPTY},
+	}, resp.X509Slots)
+	s.Equal([]*registration.CASlot{
+		{SlotId: "A", State: registration.CASlot_ACTIVE, AuthorityId: "KID1"},
+		{SlotId: "B", State: registration.CASlot_PREPARED, AuthorityId: "KID2"},
+	}, resp.JwtSlots)
+}
+
+func (s *HandlerSuite) TestPrepareCA() {
+	s.caManager.prepared = ca.SlotInfo{SlotID: "B", Status: ca.Status_PREPARED, AuthorityID: "0102"}
+
+	resp, err := s.handler.PrepareCA(context.Background(), &registration.PrepareCARequest{Kind: registration.CAKind_X509_CA})
+	s.Require().NoError(err)
+	s.Equal(&registration.CASlot{SlotId: "B", State: registration.CASlot_PREPARED, AuthorityId: "0102"}, resp.Slot)
+	s.Equal("prepare x509", s.caManager.lastCall)
+
+	_, err = s.handler.PrepareCA(context.Background(), &registration.PrepareCARequest{Kind: registration.CAKind_JWT_KEY})
+	s.Require().NoError(err)
+	s.Equal("prepare jwt", s.caManager.lastCall)
+
+	_, err = s.handler.PrepareCA(context.Background(), &registration.PrepareCARequest{Kind: 99})
+	s.requireGRPCStatusCode(err, codes.InvalidArgument)
+}
+
+func (s *HandlerSuite) TestActivateCA() {
+	s.caManager.err = status.Error(codes.FailedPrecondition, "no prepared X509 CA to activate")
+	_, err := s.handler.ActivateCA(context.Background(), &registration.ActivateCARequest{Kind: registration.CAKind_X509_CA})
+	s.requireGRPCStatusCode(err, codes.FailedPrecondition)
+
+	s.caManager.err = nil
+	s.caManager.activated = ca.SlotInfo{SlotID: "B", Status: ca.Status_ACTIVE, AuthorityID: "KID2"}
+	resp, err := s.handler.ActivateCA(context.Background(), &registration.ActivateCARequest{Kind: registration.CAKind_JWT_KEY})
+	s.Require().NoError(err)
+	s.Equal(&registration.CASlot{SlotId: "B", State: registration.CASlot_ACTIVE, AuthorityId: "KID2"}, resp.Slot)
+	s.Equal("activate jwt", s.caManager.lastCall)
+}
+
+func (s *HandlerSuite) TestTaintCA() {
+	_, err := s.handler.TaintCA(context.Background(), &registration.TaintCARequest{Kind: registration.CAKind_X509_CA})
+	s.requireGRPCStatusCode(err, codes.InvalidArgument)
+
+	_, err = s.handler.TaintCA(context.Background(), &registration.TaintCARequest{Kind: registration.CAKind_X509_CA, AuthorityId: "0102"})
+	s.Require().NoError(err)
+	s.Equal("taint x509 0102", s.caManager.lastCall)
+
+	s.caManager.err = status.Error(codes.NotFound, `no JWT authority "KID" in the bundle`)
+	_, err = s.handler.TaintCA(context.Background(), &registration.TaintCARequest{Kind: registration.CAKind_JWT_KEY, AuthorityId: "KID"})
+	s.requireGRPCStatusCode(err, codes.NotFound)
+	s.Equal("taint jwt KID", s.caManager.lastCall)
+}
+
+func (s *HandlerSuite) TestListIssuedSVIDs() {
+	x509SVID := s.createIssuedSVID(&datastore.IssuedSVID{
+		Id:          "1",
+		Type:        datastore.IssuedSVID_X509_SVID,
+		SpiffeId:    "spiffe://example.org/foo",
+		EntryId:     "entry1",
+		AgentId:     "spiffe://example.org/spire/agent/foo",
+		AuthorityId: "authority",
+		NotBefore:   10,
+		NotAfter:    20,
+	})
+	jwtSVID := s.createIssuedSVID(&datastore.IssuedSVID{
+		Id:          "jti",
+		Type:        datastore.IssuedSVID_JWT_SVID,
+		SpiffeId:    "spiffe://example.org/foo",
+		EntryId:     "entry1",
+		AgentId:     "spiffe://example.org/spire/agent/bar",
+		AuthorityId: "kid",
+		NotBefore:   20,
+		NotAfter:    30,
+	})
+	x509CASVID := s.createIssuedSVID(&datastore.IssuedSVID{
+		Id:          "2",
+		Type:        datastore.IssuedSVID_X509_CA_SVID,
+		SpiffeId:    "spiffe://example.org",
+		EntryId:     "entry2",
+		AgentId:     "spiffe://example.org/spire/agent/bar",
+		AuthorityId: "authority",
+		NotBefore:   30,
+		NotAfter:    40,
+	})
+
+	for _, tt := range []struct {
+		name     string
+		req      *registration.ListIssuedSVIDsRequest
+		expected []*registration.IssuedSVID
+		err      string
+	}{
+		{
+			name:     "all",
+			req:      &registration.ListIssuedSVIDsRequest{},
+			expected: []*registration.IssuedSVID{x509SVID, jwtSVID, x509CASVID},
+		},
+		{
+			name: "by SPIFFE ID",
+			req: &registration.ListIssuedSVIDsRequest{
+				SpiffeId: "spiffe://example.org/foo",
+			},
+			expected: []*registration.IssuedSVID{x509SVID, jwtSVID},
+		},
+		{
+			name: "by agent ID",
+			req: &registration.ListIssuedSVIDsRequest{
+				AgentId: "spiffe://example.org/spire/agent/bar",
+			},
+			expected: []*registration.IssuedSVID{jwtSVID, x509CASVID},
+		},
+		{
+			name: "by time range",
+			req: &registration.ListIssuedSVIDsRequest{
+				IssuedAfter:  20,
+				IssuedBefore: 30,
+			},
+			expected: []*registration.IssuedSVID{jwtSVID},
+		},
+		{
+			name: "invalid SPIFFE ID",
+			req: &registration.ListIssuedSVIDsRequest{
+				SpiffeId: "foo",
+			},
+			err: `"foo" is not a valid SPIFFE ID`,
+		},
+	} {
+		tt := tt
+		s.T().Run(tt.name, func(t *testing.T) {
+			actual, err := s.listIssuedSVIDs(tt.req)
+			if tt.err != "" {
+				requireErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func (s *HandlerSuite) TestListIssuedSVIDsPages() {
+	for i := 0; i <= issuedSVIDsPageSize; i++ {
+		s.createIssuedSVID(&datastore.IssuedSVID{
+			Id: fmt.Sprint(i),
+		})
+	}
+
+	actual, err := s.listIssuedSVIDs(&registration.ListIssuedSVIDsRequest{})
+	s.Require().NoError(err)
+	s.Require().Len(actual, issuedSVIDsPageSize+1)
+	s.Require().Equal(fmt.Sprint(issuedSVIDsPageSize), actual[issuedSVIDsPageSize].Id)
+}
+
+func (s *HandlerSuite) TestMintX509SVID() {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.Require().NoError(err)
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{}, key)
+	s.Require().NoError(err)
+
+	s.createBundle(&common.Bundle{
+		TrustDomainId: "spiffe://example.org",
+		RootCas: []*common.Certificate{
+			{DerBytes: s.serverCA.Bundle()[0].Raw},
+		},
+	})
+
+	// SPIFFE ID outside of the trust domain
+	_, err = s.handler.MintX509SVID(context.Background(), &registration.MintX509SVIDRequest{
+		SpiffeId: "spiffe://otherdomain.test/workload",
+		Csr:      csr,
+	})
+	s.requireGRPCStatusCode(err, codes.InvalidArgument)
+
+	// reserved SPIFFE ID
+	_, err = s.handler.MintX509SVID(context.Background(), &registration.MintX509SVIDRequest{
+		SpiffeId: "spiffe://example.org/spire/agent/foo",
+		Csr:      csr,
+	})
+	s.requireGRPCStatusCode(err, codes.InvalidArgument)
+
+	// malformed CSR
+	_, err = s.handler.MintX509SVID(context.Background(), &registration.MintX509SVIDRequest{
+		SpiffeId: "spiffe://example.org/workload",
+		Csr:      []byte("NOT A CSR"),
+	})
+	s.requireErrorContains(err, "unable to parse CSR")
+	s.requireGRPCStatusCode(err, codes.InvalidArgument)
+
+	// invalid DNS name
+	_, err = s.handler.MintX509SVID(context.Background(), &registration.MintX509SVIDRequest{
+		SpiffeId: "spiffe://example.org/workload",
+		Csr:      csr,
+		DnsNames: []string{"-invalid"},
+	})
+	s.requireGRPCStatusCode(err, codes.InvalidArgument)
+
+	// the TTL is capped
+	resp, err := s.handler.MintX509SVID(context.Background(), &registration.MintX509SVIDRequest{
+		SpiffeId: "spiffe://example.org/workload",
+		Csr:      csr,
+		Ttl:      3600,
+		DnsNames: []string{"workload.example.org"},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(resp.SvidChain, 1)
+	s.Equal([][]byte{s.serverCA.Bundle()[0].Raw}, resp.RootCas)
+
+	svid, err := x509.ParseCertificate(resp.SvidChain[0])
+	s.Require().NoError(err)
+	s.Equal("spiffe://example.org/workload", svid.URIs[0].String())
+	s.Equal([]string{"workload.example.org"}, svid.DNSNames)
+	s.Equal(key.Public(), svid.PublicKey)
+	s.Equal(s.clock.Now().Add(10*time.Minute).UTC(), svid.NotAfter)
+
+	// the TTL defaults to the cap
+	resp, err = s.handler.MintX509SVID(context.Background(), &registration.MintX509SVIDRequest{
+		SpiffeId: "spiffe://example.org/workload",
+		Csr:      csr,
+	})
+	s.Require().NoError(err)
+	svid, err = x509.ParseCertificate(resp.SvidChain[0])
+	s.Require().NoError(err)
+	s.Equal(s.clock.Now().Add(10*time.Minute).UTC(), svid.NotAfter)
+}
+
+func (s *HandlerSuite) TestMintJWTSVID() {
+	// no audience
+	_, err := s.handler.MintJWTSVID(context.Background(), &registration.MintJWTSVIDRequest{
+		SpiffeId: "spiffe://example.org/workload",
+	})
+	s.requireErrorContains(err, "at least one audience is required")
+	s.requireGRPCStatusCode(err, codes.InvalidArgument)
+
+	// SPIFFE ID outside of the trust domain
+	_, err = s.handler.MintJWTSVID(context.Background(), &registration.MintJWTSVIDRequest{
+		SpiffeId: "spiffe://otherdomain.test/workload",
+		Audience: []string{"AUDIENCE"},
+	})
+	s.requireGRPCStatusCode(err, codes.InvalidArgument)
+
+	// the TTL defaults to the JWT SVID default
+	resp, err := s.handler.MintJWTSVID(context.Background(), &registration.MintJWTSVIDRequest{
+		SpiffeId: "spiffe://example.org/workload",
+		Audience: []string{"AUDIENCE"},
+	})
+	s.Require().NoError(err)
+	claims := s.parseJWTSVID(resp.Token)
+	s.Equal("spiffe://example.org/workload", claims.Subject)
+	s.Equal(jwt.Audience{"AUDIENCE"}, claims.Audience)
+	s.Equal(jwt.NewNumericDate(s.clock.Now().Add(ca.DefaultJWTSVIDTTL)), claims.Expiry)
+
+	// the TTL is capped
+	resp, err = s.handler.MintJWTSVID(context.Background(), &registration.MintJWTSVIDRequest{
+		SpiffeId: "spiffe://example.org/workload",
+		Audience: []string{"AUDIENCE"},
+		Ttl:      3600,
+	})
+	s.Require().NoError(err)
+	claims = s.parseJWTSVID(resp.Token)
+	s.Equal(jwt.NewNumericDate(s.clock.Now().Add(10*time.Minute)), claims.Expiry)
+}
+
+func (s *HandlerSuite) TestWatchEntries() {
+	entry1 := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/parent",
+		SpiffeId:  "spiffe://example.org/foo",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1"}},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := s.handler.WatchEntries(ctx, &registration.WatchEntriesRequest{})
+	s.Require().NoError(err)
+
+	// the watch starts with a snapshot of the existing entries
+	s.requireEntryEvent(stream, &registration.EntryEvent{
+		Type:    registration.EntryEvent_SNAPSHOT,
+		EntryId: entry1.EntryId,
+		Entry:   entry1,
+	})
+	s.requireEntryEvent(stream, &registration.EntryEvent{
+		Type:   registration.EntryEvent_SNAPSHOT_END,
+		Cursor: "1",
+	})
+
+	// followed by changes as they are made
+	entry2 := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/parent",
+		SpiffeId:  "spiffe://example.org/bar",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:2"}},
+	})
+	s.requireEntryEvent(stream, &registration.EntryEvent{
+		Type:    registration.EntryEvent_CREATE,
+		EntryId: entry2.EntryId,
+		Entry:   entry2,
+		Cursor:  "2",
+	})
+
+	entry1.Ttl = 60
+	updateResp, err := s.ds.UpdateRegistrationEntry(context.Background(), &datastore.UpdateRegistrationEntryRequest{
+		Entry: entry1,
+	})
+	s.Require().NoError(err)
+	s.requireEntryEvent(stream, &registration.EntryEvent{
+		Type:    registration.EntryEvent_UPDATE,
+		EntryId: entry1.EntryId,
+		Entry:   updateResp.Entry,
+		Cursor:  "3",
+	})
+
+	_, err = s.ds.DeleteRegistrationEntry(context.Background(), &datastore.DeleteRegistrationEntryRequest{
+		EntryId: entry2.EntryId,
+	})
+	s.Require().NoError(err)
+	s.requireEntryEvent(stream, &registration.EntryEvent{
+		Type:    registration.EntryEvent_DELETE,
+		EntryId: entry2.EntryId,
+		Cursor:  "4",
+	})
+	cancel()
+
+	// resuming from a cursor skips the snapshot and sends the changes made
+	// after the cursor
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	stream, err = s.handler.WatchEntries(ctx, &registration.WatchEntriesRequest{
+		Cursor: "2",
+	})
+	s.Require().NoError(err)
+	s.requireEntryEvent(stream, &registration.EntryEvent{
+		Type:    registration.EntryEvent_UPDATE,
+		EntryId: entry1.EntryId,
+		Entry:   updateResp.Entry,
+		Cursor:  "3",
+	})
+	s.requireEntryEvent(stream, &registration.EntryEvent{
+		Type:    registration.EntryEvent_DELETE,
+		EntryId: entry2.EntryId,
+		Cursor:  "4",
+	})
+}
+
+func (s *HandlerSuite) TestWatchEntriesSkipsDeletedEntries() {
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/parent",
+		SpiffeId:  "spiffe://example.org/foo",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1"}},
+	})
+	_, err := s.ds.DeleteRegistrationEntry(context.Background(), &datastore.DeleteRegistrationEntryRequest{
+		EntryId: entry.EntryId,
+	})
+	s.Require().NoError(err)
+
+	// the entry no longer exists, so only its deletion is sent
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := s.handler.WatchEntries(ctx, &registration.WatchEntriesRequest{
+		Cursor: "0",
+	})
+	s.Require().NoError(err)
+	s.requireEntryEvent(stream, &registration.EntryEvent{
+		Type:    registration.EntryEvent_DELETE,
+		EntryId: entry.EntryId,
+		Cursor:  "2",
+	})
+}
+
+func (s *HandlerSuite) TestWatchEntriesWithBadCursor() {
+	for i := 0; i < 3; i++ {
+		s.createRegistrationEntry(&common.RegistrationEntry{
+			ParentId:  "spiffe://example.org/parent",
+			SpiffeId:  fmt.Sprintf("spiffe://example.org/%d", i),
+			Selectors: []*common.Selector{{Type: "unix", Value: "uid:1"}},
+		})
+	}
+
+	// prune all but the latest event
+	_, err := s.ds.PruneRegistrationEntryEvents(context.Background(), &datastore.PruneRegistrationEntryEventsRequest{
+		CreatedBefore: time.Now().Add(time.Minute).Unix(),
+	})
+	s.Require().NoError(err)
+
+	for _, tt := range []struct {
+		name   string
+		cursor string
+		code   codes.Code
+	}{
+		{name: "malformed", cursor: "foo", code: codes.InvalidArgument},
+		{name: "negative", cursor: "-1", code: codes.InvalidArgument},
+		{name: "ahead of the log", cursor: "4", code: codes.InvalidArgument},
+		{name: "expired", cursor: "1", code: codes.OutOfRange},
+	} {
+		tt := tt
+		s.T().Run(tt.name, func(t *testing.T) {
+			stream, err := s.handler.WatchEntries(context.Background(), &registration.WatchEntriesRequest{
+				Cursor: tt.cursor,
+			})
+			require.NoError(t, err)
+			_, err = stream.Recv()
+			requireGRPCStatusCode(t, err, tt.code)
+		})
+	}
+
+	// the cursor of the latest retained event can still be resumed from
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream, err := s.handler.WatchEntries(ctx, &registration.WatchEntriesRequest{
+		Cursor: "3",
+	})
+	s.Require().NoError(err)
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/parent",
+		SpiffeId:  "spiffe://example.org/foo",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1"}},
+	})
+	s.requireEntryEvent(stream, &registration.EntryEvent{
+		Type:    registration.EntryEvent_CREATE,
+		EntryId: entry.EntryId,
+		Entry:   entry,
+		Cursor:  "4",
+	})
+}
+
+func TestCACallsWithoutCAManager(t *testing.T) {
+	h := &Handler{
+		Log:     logrus.New(),
+		Metrics: telemetry.Blackhole{},
+	}
+	_, err := h.ListCASlots(context.Background(), &registration.ListCASlotsRequest{})
+	requireGRPCStatusCode(t, err, codes.Unimplemented)
+	_, err = h.PrepareCA(context.Background(), &registration.PrepareCARequest{})
+	requireGRPCStatusCode(t, err, codes.Unimplemented)
+	_, err = h.ActivateCA(context.Background(), &registration.ActivateCARequest{})
+	requireGRPCStatusCode(t, err, codes.Unimplemented)
+	_, err = h.TaintCA(context.Background(), &registration.TaintCARequest{})
+	requireGRPCStatusCode(t, err, codes.Unimplemented)
+	_, err = h.MintX509SVID(context.Background(), &registration.MintX509SVIDRequest{})
+	requireGRPCStatusCode(t, err, codes.Unimplemented)
+	_, err = h.MintJWTSVID(context.Background(), &registration.MintJWTSVIDRequest{})
+	requireGRPCStatusCode(t, err, codes.Unimplemented)
+}
+
 func (s *HandlerSuite) createAttestedNode(spiffeID string) *common.AttestedNode {
 	createResponse, err := s.ds.CreateAttestedNode(context.Background(), &datastore.CreateAttestedNodeRequest{
 		Node: &common.AttestedNode{
@@ -841,6 +2174,27 @@ func (s *HandlerSuite) createAttestedNode(spiffeID string) *common.AttestedNode
 	return createResponse.Node
 }
 
+// createAttestedNodeWithSelectors creates the node and sets its node
+// selectors. The returned node includes the selectors.
+func (s *HandlerSuite) createAttestedNodeWithSelectors(node *common.AttestedNode, selectors ...*common.Selector) *common.AttestedNode {
+	ctx := context.Background()
+	_, err := s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{
+		Node: node,
+	})
+	s.Require().NoError(err, "Failed to create attested node")
+	if len(selectors) > 0 {
+		_, err = s.ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
+			Selectors: &datastore.NodeSelectors{
+				SpiffeId:  node.SpiffeId,
+				Selectors: selectors,
+			},
+		})
+		s.Require().NoError(err, "Failed to set node selectors")
+	}
+	node.Selectors = selectors
+	return node
+}
+
 func (s *HandlerSuite) TestAuthorizeCall() {
 	catalog := fakeservercatalog.New()
 	catalog.SetDataStore(s.ds)
@@ -937,6 +2291,249 @@ func (s *HandlerSuite) TestAuthorizeCall() {
 	}
 }
 
+func (s *HandlerSuite) TestAuthorizeWithPolicy() {
+	policy, err := authpolicy.Parse([]byte(`
+		rule "team-a" {
+			callers = ["spiffe://example.org/team-a/*"]
+			methods = ["CreateEntry"]
+			fields = {
+				"parent_id" = "spiffe://example.org/team-a/*"
+				"spiffe_id" = "spiffe://example.org/team-a/*"
+			}
+		}
+		rule "team-a-updates" {
+			callers = ["spiffe://example.org/team-a/*"]
+			methods = ["UpdateEntry"]
+			fields = {
+				"entry.parent_id" = "spiffe://example.org/team-a/*"
+				"entry.spiffe_id" = "spiffe://example.org/team-a/*"
+			}
+		}
+		rule "team-a-deletes" {
+			callers = ["spiffe://example.org/team-a/*"]
+			methods = ["DeleteEntry"]
+			fields = {
+				"parent_id" = "spiffe://example.org/team-a/*"
+				"spiffe_id" = "spiffe://example.org/team-a/*"
+			}
+		}
+		rule "operators" {
+			uids = [1000]
+			methods = ["List*"]
+		}
+		rule "root-updates" {
+			uids = [0]
+			methods = ["UpdateEntry"]
+		}
+		rule "team-a-minters" {
+			callers = ["spiffe://example.org/team-a/*"]
+			methods = ["Mint*"]
+		}
+	`))
+	s.Require().NoError(err)
+
+	catalog := fakeservercatalog.New()
+	catalog.SetDataStore(s.ds)
+	log, _ := test.NewNullLogger()
+	handler := &Handler{Log: log, Catalog: catalog, Policy: staticPolicy{policy: policy}}
+
+	tlsPeer := &peer.Peer{
+		AuthInfo: credentials.TLSInfo{
+			State: tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{
+					{URIs: []*url.URL{{Scheme: "spiffe", Host: "example.org", Path: "/team-a/admin"}}},
+				}},
+			},
+		},
+	}
+	udsPeer := func(uid uint32) *peer.Peer {
+		return &peer.Peer{
+			AuthInfo: peertracker.AuthInfo{
+				Caller: peertracker.CallerInfo{UID: uid},
+			},
+		}
+	}
+	teamAEntry := &common.RegistrationEntry{
+		ParentId: "spiffe://example.org/team-a/node",
+		SpiffeId: "spiffe://example.org/team-a/workload",
+	}
+	teamBEntry := &common.RegistrationEntry{
+		ParentId: "spiffe://example.org/team-b/node",
+		SpiffeId: "spiffe://example.org/team-b/workload",
+	}
+	teamAAdminEntry := &common.RegistrationEntry{
+		ParentId: "spiffe://example.org/team-a/node",
+		SpiffeId: "spiffe://example.org/team-a/workload",
+		Admin:    true,
+	}
+	storedTeamAEntry := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/team-a/node",
+		SpiffeId:  "spiffe://example.org/team-a/workload",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	})
+	storedTeamBEntry := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/team-b/node",
+		SpiffeId:  "spiffe://example.org/team-b/workload",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+	})
+	storedTeamAAdminEntry := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/team-a/node",
+		SpiffeId:  "spiffe://example.org/team-a/db",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		Admin:     true,
+	})
+	teamAUpdate := func(entryID string, admin bool, mask *common.RegistrationEntryMask) *registration.UpdateEntryRequest {
+		return &registration.UpdateEntryRequest{
+			Entry: &common.RegistrationEntry{
+				EntryId:   entryID,
+				ParentId:  "spiffe://example.org/team-a/node",
+				SpiffeId:  "spiffe://example.org/team-a/workload",
+				Selectors: []*common.Selector{{Type: "unix", Value: "uid:0"}},
+				Admin:     admin,
+			},
+			Mask: mask,
+		}
+	}
+
+	testCases := []struct {
+		Peer     *peer.Peer
+		Method   string
+		Request  interface{}
+		CallerID string
+		Err      string
+	}{
+		{
+			// callers over TCP no longer need to be admins
+			Peer:     tlsPeer,
+			Method:   "/spire.api.registration.Registration/CreateEntry",
+			Request:  teamAEntry,
+			CallerID: "spiffe://example.org/team-a/admin",
+		},
+		{
+			Peer:     tlsPeer,
+			Method:   "/spire.api.registration.Registration/CreateEntry",
+			Request:  teamBEntry,
+			CallerID: "spiffe://example.org/team-a/admin",
+			Err:      "authorization policy denies calling CreateEntry",
+		},
+		{
+			Peer:     tlsPeer,
+			Method:   "/spire.api.registration.Registration/DeleteEntry",
+			Request:  &registration.RegistrationEntryID{Id: "foo"},
+			CallerID: "spiffe://example.org/team-a/admin",
+			Err:      "authorization policy denies calling DeleteEntry",
+		},
+		{
+			// the policy may not grant creating admin entries
+			Peer:     tlsPeer,
+			Method:   "/spire.api.registration.Registration/CreateEntry",
+			Request:  teamAAdminEntry,
+			CallerID: "spiffe://example.org/team-a/admin",
+			Err:      "only admins may write admin entries",
+		},
+		{
+			Peer:     tlsPeer,
+			Method:   "/spire.api.registration.Registration/UpdateEntry",
+			Request:  teamAUpdate(storedTeamAEntry.EntryId, false, nil),
+			CallerID: "spiffe://example.org/team-a/admin",
+		},
+		{
+			// the stored entry must be allowed too, even when the mask
+			// leaves the matched fields out
+			Peer:     tlsPeer,
+			Method:   "/spire.api.registration.Registration/UpdateEntry",
+			Request:  teamAUpdate(storedTeamBEntry.EntryId, false, &common.RegistrationEntryMask{Selectors: true}),
+			CallerID: "spiffe://example.org/team-a/admin",
+			Err:      "authorization policy denies calling UpdateEntry",
+		},
+		{
+			Peer:     tlsPeer,
+			Method:   "/spire.api.registration.Registration/UpdateEntry",
+			Request:  teamAUpdate(storedTeamAEntry.EntryId, true, &common.RegistrationEntryMask{Admin: true}),
+			CallerID: "spiffe://example.org/team-a/admin",
+			Err:      "only admins may write admin entries",
+		},
+		{
+			// the admin field is only written when selected by the mask
+			Peer:     tlsPeer,
+			Method:   "/spire.api.registration.Registration/UpdateEntry",
+			Request:  teamAUpdate(storedTeamAEntry.EntryId, true, &common.RegistrationEntryMask{Selectors: true}),
+			CallerID: "spiffe://example.org/team-a/admin",
+		},
+		{
+			Peer:     tlsPeer,
+			Method:   "/spire.api.registration.Registration/UpdateEntry",
+			Request:  teamAUpdate(storedTeamAAdminEntry.EntryId, false, &common.RegistrationEntryMask{Selectors: true}),
+			CallerID: "spiffe://example.org/team-a/admin",
+			Err:      "only admins may write admin entries",
+		},
+		{
+			Peer:     tlsPeer,
+			Method:   "/spire.api.registration.Registration/DeleteEntry",
+			Request:  &registration.RegistrationEntryID{Id: storedTeamAEntry.EntryId},
+			CallerID: "spiffe://example.org/team-a/admin",
+		},
+		{
+			Peer:     tlsPeer,
+			Method:   "/spire.api.registration.Registration/DeleteEntry",
+			Request:  &registration.RegistrationEntryID{Id: storedTeamBEntry.EntryId},
+			CallerID: "spiffe://example.org/team-a/admin",
+			Err:      "authorization policy denies calling DeleteEntry",
+		},
+		{
+			Peer:     tlsPeer,
+			Method:   "/spire.api.registration.Registration/DeleteEntry",
+			Request:  &registration.RegistrationEntryID{Id: storedTeamAAdminEntry.EntryId},
+			CallerID: "spiffe://example.org/team-a/admin",
+			Err:      "only admins may write admin entries",
+		},
+		{
+			// callers over the UDS are admins
+			Peer:    udsPeer(0),
+			Method:  "/spire.api.registration.Registration/UpdateEntry",
+			Request: teamAUpdate(storedTeamAAdminEntry.EntryId, true, nil),
+		},
+		{
+			Peer:    udsPeer(1000),
+			Method:  "/spire.api.registration.Registration/ListEntries",
+			Request: &registration.ListEntriesRequest{},
+		},
+		{
+			Peer:    udsPeer(1000),
+			Method:  "/spire.api.registration.Registration/CreateEntry",
+			Request: teamAEntry,
+			Err:     "authorization policy denies calling CreateEntry",
+		},
+		{
+			Peer:    udsPeer(0),
+			Method:  "/spire.api.registration.Registration/ListEntries",
+			Request: &registration.ListEntriesRequest{},
+			Err:     "authorization policy denies calling ListEntries",
+		},
+	}
+
+	for _, testCase := range testCases {
+		s.T().Logf("case=%+v", testCase)
+		ctx := peer.NewContext(context.Background(), testCase.Peer)
+		ctx, err := handler.AuthorizeCall(ctx, testCase.Method)
+		s.Require().NoError(err)
+		s.Require().Equal(testCase.CallerID, getCallerID(ctx), "Caller SPIFFE ID on context")
+
+		err = handler.AuthorizeRequest(ctx, testCase.Method, testCase.Request)
+		if testCase.Err != "" {
+			s.requireErrorContains(err, testCase.Err)
+			s.requireGRPCStatusCode(err, codes.PermissionDenied)
+			continue
+		}
+		s.Require().NoError(err)
+	}
+
+	// minting SVIDs requires an admin, even when allowed by the policy
+	_, err = handler.AuthorizeCall(peer.NewContext(context.Background(), tlsPeer), "/spire.api.registration.Registration/MintJWTSVID")
+	s.requireErrorContains(err, `SPIFFE ID "spiffe://example.org/team-a/admin" is not authorized`)
+	s.requireGRPCStatusCode(err, codes.PermissionDenied)
+}
+
 func TestDNSValidation(t *testing.T) {
 	tests := []struct {
 		name string
@@ -1036,6 +2633,54 @@ func (s *HandlerSuite) createRegistrationEntry(entry *common.RegistrationEntry)
 	return resp.Entry
 }
 
+func (s *HandlerSuite) fetchJoinToken(token string) *datastore.JoinToken {
+	resp, err := s.ds.FetchJoinToken(context.Background(), &datastore.FetchJoinTokenRequest{
+		Token: token,
+	})
+	s.Require().NoError(err)
+	return resp.JoinToken
+}
+
+func (s *HandlerSuite) createIssuedSVID(issuedSVID *datastore.IssuedSVID) *registration.IssuedSVID {
+	_, err := s.ds.CreateIssuedSVID(context.Background(), &datastore.CreateIssuedSVIDRequest{
+		IssuedSvid: issuedSVID,
+	})
+	s.Require().NoError(err)
+	return issuedSVIDFromDataStore(issuedSVID)
+}
+
+func (s *HandlerSuite) listIssuedSVIDs(req *registration.ListIssuedSVIDsRequest) ([]*registration.IssuedSVID, error) {
+	stream, err := s.handler.ListIssuedSVIDs(context.Background(), req)
+	if err != nil {
+		return nil, err
+	}
+	var issuedSVIDs []*registration.IssuedSVID
+	for {
+		issuedSVID, err := stream.Recv()
+		if err == io.EOF {
+			return issuedSVIDs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		issuedSVIDs = append(issuedSVIDs, issuedSVID)
+	}
+}
+
+func (s *HandlerSuite) parseJWTSVID(token string) *jwt.Claims {
+	parsed, err := jwt.ParseSigned(token)
+	s.Require().NoError(err)
+	claims := new(jwt.Claims)
+	s.Require().NoError(parsed.UnsafeClaimsWithoutVerification(claims))
+	return claims
+}
+
+func (s *HandlerSuite) requireEntryEvent(stream registration.Registration_WatchEntriesClient, expected *registration.EntryEvent) {
+	actual, err := stream.Recv()
+	s.Require().NoError(err)
+	s.Require().True(proto.Equal(expected, actual), "expected %v; got %v", expected, actual)
+}
+
 func (s *HandlerSuite) requireErrorContains(err error, contains string) {
 	requireErrorContains(s.T(), err, contains)
 }
@@ -1048,6 +2693,17 @@ func (s *HandlerSuite) requireNotGRPCStatusCode(err error, code codes.Code) {
 	requireNotGRPCStatusCode(s.T(), err, code)
 }
 
+func requireEntriesEqual(t *testing.T, expected, actual []*common.RegistrationEntry) {
+	expected = append([]*common.RegistrationEntry(nil), expected...)
+	actual = append([]*common.RegistrationEntry(nil), actual...)
+	util.SortRegistrationEntries(expected)
+	util.SortRegistrationEntries(actual)
+	require.Len(t, actual, len(expected))
+	for i := range expected {
+		require.True(t, proto.Equal(expected[i], actual[i]), "expected %v; got %v", expected[i], actual[i])
+	}
+}
+
 func pemBytes(p []byte) []byte {
 	b, _ := pem.Decode(p)
 	if b != nil {
@@ -1070,3 +2726,60 @@ func requireNotGRPCStatusCode(t *testing.T, err error, code codes.Code) {
 	s := status.Convert(err)
 	require.NotEqual(t, code, s.Code(), "GRPC status code should not be %v", code)
 }
+
+type fakeCAManager struct {
+	x509Current ca.SlotInfo
+	x509Next    ca.SlotInfo
+	jwtCurrent  ca.SlotInfo
+	jwtNext     ca.SlotInfo
+	prepared    ca.SlotInfo
+	activated   ca.SlotInfo
+	err         error
+	lastCall    string
+}
+
+func (m *fakeCAManager) X509CASlots() (current, next ca.SlotInfo) {
+	return m.x509Current, m.x509Next
+}
+
+func (m *fakeCAManager) JWTKeySlots() (current, next ca.SlotInfo) {
+	return m.jwtCurrent, m.jwtNext
+}
+
+func (m *fakeCAManager) PrepareNextX509CA(ctx context.Context) (ca.SlotInfo, error) {
+	m.lastCall = "prepare x509"
+	return m.prepared, m.err
+}
+
+func (m *fakeCAManager) PrepareNextJWTKey(ctx context.Context) (ca.SlotInfo, error) {
+	m.lastCall = "prepare jwt"
+	return m.prepared, m.err
+}
+
+func (m *fakeCAManager) ActivateNextX509CA(ctx context.Context) (ca.SlotInfo, error) {
+	m.lastCall = "activate x509"
+	return m.activated, m.err
+}
+
+func (m *fakeCAManager) ActivateNextJWTKey(ctx context.Context) (ca.SlotInfo, error) {
+	m.lastCall = "activate jwt"
+	return m.activated, m.err
+}
+
+func (m *fakeCAManager) TaintX509Authority(ctx context.Context, authorityID string) error {
+	m.lastCall = "taint x509 " + authorityID
+	return m.err
+}
+
+func (m *fakeCAManager) TaintJWTAuthority(ctx context.Context, kid string) error {
+	m.lastCall = "taint jwt " + kid
+	return m.err
+}
+
+type staticPolicy struct {
+	policy *authpolicy.Policy
+}
+
+func (p staticPolicy) Policy() *authpolicy.Policy {
+	return p.policy
+}
@@ -0,0 +1,437 @@
+package entrycache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/golang/protobuf/ptypes/wrappers"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
+	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/server/util/regentryutil"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/proto/spire/server/datastore"
+)
+
+const (
+	// DefaultRefreshInterval is how often the datastore is checked for
+	// changes if not overridden by the config.
+	DefaultRefreshInterval = 5 * time.Second
+
+	// DefaultMaxStaleness is how long the index is used after the last
+	// successful refresh if not overridden by the config.
+	DefaultMaxStaleness = 30 * time.Second
+
+	// pageSize is the number of entries, or entry change events, listed at
+	// a time
+	pageSize = 1000
+)
+
+type Config struct {
+	DataStore datastore.DataStore
+	Log       logrus.FieldLogger
+	Metrics   telemetry.Metrics
+	Clock     clock.Clock
+
+	// RefreshInterval is how often the datastore is checked for changes
+	RefreshInterval time.Duration
+
+	// MaxStaleness is how long the index is used after the last successful
+	// refresh. Beyond that, lookups go to the datastore until the next
+	// successful refresh.
+	MaxStaleness time.Duration
+}
+
+// Cache keeps an in-memory index of the registration entries, the node
+// selectors and the parent/child relationships between entries. It answers
+// which entries an agent (or any other ID) is authorized for without
+// querying the datastore.
+//
+// The entries are loaded once and then kept up to date on an interval by
+// applying the registration entry change events recorded since the last
+// refresh. They are only loaded again if those events have been pruned. Node
+// selectors are not part of the entry change events, since they are set on
+// every attestation; instead, the nodes whose selectors changed since the
+// last refresh are listed and updated in place. Until the first refresh, or
+// if the index has not been refreshed in a while, lookups fall back to
+// querying the datastore.
+type Cache struct {
+	c Config
+
+	mu          sync.RWMutex
+	idx         *index
+	refreshedAt time.Time
+
+	// entries holds the registration entries keyed by entry ID. It is nil
+	// until the first refresh and only accessed by refresh.
+	entries map[string]*common.RegistrationEntry
+
+	// entryEventID is the ID of the latest registration entry change event
+	// applied to entries. It is only accessed by refresh.
+	entryEventID int64
+
+	// nodeSelectors holds the node selectors keyed by node SPIFFE ID. It is
+	// nil until the first refresh.
+	nodeSelectors map[string][]*common.Selector
+
+	// nodeSelectorsEventID is the ID of the latest node selectors change
+	// event applied to nodeSelectors
+	nodeSelectorsEventID int64
+}
+
+func New(c Config) *Cache {
+	if c.Clock == nil {
+		c.Clock = clock.New()
+	}
+	if c.RefreshInterval <= 0 {
+		c.RefreshInterval = DefaultRefreshInterval
+	}
+	if c.MaxStaleness <= 0 {
+		c.MaxStaleness = DefaultMaxStaleness
+	}
+	return &Cache{
+		c: c,
+	}
+}
+
+// FetchAuthorizedEntries returns the registration entries the given ID is
+// authorized for, along with the datastore revision they reflect. The entries
+// are copies that the caller is free to modify.
+func (c *Cache) FetchAuthorizedEntries(ctx context.Context, id string) ([]*common.RegistrationEntry, int64, error) {
+	entries, revision, ok := c.authorizedEntries(id)
+	if !ok {
+		telemetry_server.IncrEntryCacheMissCounter(c.c.Metrics)
+		return regentryutil.FetchRegistrationEntriesWithRevision(ctx, c.c.DataStore, id)
+	}
+
+	telemetry_server.IncrEntryCacheHitCounter(c.c.Metrics)
+	return entries, revision, nil
+}
+
+// UpdateNodeSelectors sets the selectors of a node in the index, so that the
+// selectors set on attestation are used right away instead of after the next
+// refresh.
+func (c *Cache) UpdateNodeSelectors(spiffeID string, selectors []*common.Selector) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.nodeSelectors != nil {
+		updateNodeSelectors(c.nodeSelectors, spiffeID, selectors)
+	}
+}
+
+func (c *Cache) authorizedEntries(id string) ([]*common.RegistrationEntry, int64, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.idx == nil || c.c.Clock.Now().Sub(c.refreshedAt) > c.c.MaxStaleness {
+		return nil, 0, false
+	}
+	return c.idx.authorizedEntries(id, c.nodeSelectors), c.idx.revision, true
+}
+
+// Run refreshes the index until the context is canceled
+func (c *Cache) Run(ctx context.Context) error {
+	ticker := c.c.Clock.Ticker(c.c.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.refresh(ctx); err != nil {
+			c.c.Log.WithError(err).Error("Could not refresh entry cache")
+		}
+		c.reportStaleness()
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (c *Cache) refresh(ctx context.Context) (err error) {
+	counter := telemetry_server.StartEntryCacheRefreshCall(c.c.Metrics)
+	defer counter.Done(&err)
+
+	now := c.c.Clock.Now()
+
+	revision, err := regentryutil.FetchRevision(ctx, c.c.DataStore)
+	if err != nil {
+		return err
+	}
+
+	c.mu.RLock()
+	idx := c.idx
+	loaded := c.nodeSelectors != nil
+	nodeSelectorsEventID := c.nodeSelectorsEventID
+	c.mu.RUnlock()
+
+	// Once loaded, only the nodes whose selectors changed are listed
+	selectorsReq := &datastore.ListNodeSelectorsRequest{}
+	if loaded {
+		selectorsReq.AfterEventId = &wrappers.Int64Value{Value: nodeSelectorsEventID}
+	}
+	selectorsResp, err := c.c.DataStore.ListNodeSelectors(ctx, selectorsReq)
+	if err != nil {
+		return err
+	}
+
+	changed, err := c.refreshEntries(ctx)
+	if err != nil {
+		return err
+	}
+	switch {
+	case idx == nil || changed:
+		idx = newIndex(revision, c.entries)
+	case idx.revision != revision:
+		idx = idx.withRevision(revision)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.idx = idx
+	if !loaded {
+		c.nodeSelectors = make(map[string][]*common.Selector, len(selectorsResp.Selectors))
+	}
+	for _, n := range selectorsResp.Selectors {
+		updateNodeSelectors(c.nodeSelectors, n.SpiffeId, n.Selectors)
+	}
+	c.nodeSelectorsEventID = selectorsResp.LatestEventId
+	c.refreshedAt = now
+	return nil
+}
+
+func (c *Cache) reportStaleness() {
+	c.mu.RLock()
+	refreshedAt := c.refreshedAt
+	c.mu.RUnlock()
+
+	if !refreshedAt.IsZero() {
+		telemetry_server.SetEntryCacheStalenessGauge(c.c.Metrics, c.c.Clock.Now().Sub(refreshedAt))
+	}
+}
+
+// refreshEntries brings the entries up to date by applying the registration
+// entry change events recorded since the last refresh. The entries are loaded
+// from scratch on the first refresh or when events may have been missed
+// because they were pruned. It returns whether the entries changed.
+func (c *Cache) refreshEntries(ctx context.Context) (bool, error) {
+	if c.entries == nil {
+		return true, c.loadEntries(ctx)
+	}
+
+	changed := false
+	for {
+		resp, err := c.c.DataStore.ListRegistrationEntryEvents(ctx, &datastore.ListRegistrationEntryEventsRequest{
+			AfterId: c.entryEventID,
+			Limit:   pageSize,
+		})
+		if err != nil {
+			return false, err
+		}
+		// Events up to the last one applied must be all that was pruned,
+		// otherwise changes would be missed.
+		if c.entryEventID < resp.PrunedId {
+			c.c.Log.Debug("Entry change events were pruned; reloading entry cache")
+			return true, c.loadEntries(ctx)
+		}
+
+		for _, event := range resp.Events {
+			switch event.Type {
+			case datastore.RegistrationEntryEvent_CREATE, datastore.RegistrationEntryEvent_UPDATE:
+				fetchResp, err := c.c.DataStore.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{
+					EntryId: event.EntryId,
+				})
+				if err != nil {
+					return false, err
+				}
+				// the entry was deleted since; its DELETE event follows
+				if fetchResp.Entry != nil {
+					c.entries[event.EntryId] = fetchResp.Entry
+				} else {
+					delete(c.entries, event.EntryId)
+				}
+			case datastore.RegistrationEntryEvent_DELETE:
+				delete(c.entries, event.EntryId)
+			default:
+				return false, fmt.Errorf("unknown entry event type %d", event.Type)
+			}
+			c.entryEventID = event.Id
+			changed = true
+		}
+
+		if len(resp.Events) < pageSize {
+			if changed {
+				c.c.Log.WithField(telemetry.Count, len(c.entries)).Debug("Entry cache updated")
+			}
+			return changed, nil
+		}
+	}
+}
+
+// loadEntries loads all of the registration entries from the datastore.
+func (c *Cache) loadEntries(ctx context.Context) error {
+	// The change log is read first so that changes made while the entries
+	// are listed are applied by the next refresh instead of being missed
+	eventsResp, err := c.c.DataStore.ListRegistrationEntryEvents(ctx, &datastore.ListRegistrationEntryEventsRequest{
+		Limit: 1,
+	})
+	if err != nil {
+		return err
+	}
+
+	entries := make(map[string]*common.RegistrationEntry)
+	req := &datastore.ListRegistrationEntriesRequest{
+		Pagination: &datastore.Pagination{
+			PageSize: pageSize,
+		},
+	}
+	for {
+		resp, err := c.c.DataStore.ListRegistrationEntries(ctx, req)
+		if err != nil {
+			return err
+		}
+		for _, entry := range resp.Entries {
+			entries[entry.EntryId] = entry
+		}
+		if len(resp.Entries) < pageSize || resp.Pagination == nil {
+			break
+		}
+		req.Pagination = resp.Pagination
+	}
+
+	c.entries = entries
+	c.entryEventID = eventsResp.LatestId
+	c.c.Log.WithField(telemetry.Count, len(entries)).Debug("Entry cache loaded")
+	return nil
+}
+
+func updateNodeSelectors(nodeSelectors map[string][]*common.Selector, spiffeID string, selectors []*common.Selector) {
+	if len(selectors) == 0 {
+		delete(nodeSelectors, spiffeID)
+		return
+	}
+	nodeSelectors[spiffeID] = selectors
+}
+
+type selector struct {
+	Type  string
+	Value string
+}
+
+func makeSelector(s *common.Selector) selector {
+	return selector{
+		Type:  s.Type,
+		Value: s.Value,
+	}
+}
+
+// index is an immutable snapshot of the entries at a given datastore revision.
+type index struct {
+	revision int64
+
+	// byParentID holds the entries keyed by parent ID
+	byParentID map[string][]*common.RegistrationEntry
+
+	// bySelector holds the entries keyed by each of their selectors
+	bySelector map[selector][]*common.RegistrationEntry
+}
+
+func newIndex(revision int64, entries map[string]*common.RegistrationEntry) *index {
+	idx := &index{
+		revision:   revision,
+		byParentID: make(map[string][]*common.RegistrationEntry),
+		bySelector: make(map[selector][]*common.RegistrationEntry),
+	}
+	for _, entry := range entries {
+		idx.byParentID[entry.ParentId] = append(idx.byParentID[entry.ParentId], entry)
+		for _, s := range entry.Selectors {
+			key := makeSelector(s)
+			idx.bySelector[key] = append(idx.bySelector[key], entry)
+		}
+	}
+	return idx
+}
+
+// withRevision returns a copy of the index at the given revision. The entries
+// are shared since neither index modifies them.
+func (idx *index) withRevision(revision int64) *index {
+	return &index{
+		revision:   revision,
+		byParentID: idx.byParentID,
+		bySelector: idx.bySelector,
+	}
+}
+
+// authorizedEntries returns the entries the given ID is authorized for, given
+// the node selectors keyed by node SPIFFE ID. It mirrors the datastore queries
+// done by regentryutil.FetchRegistrationEntries. The entries are returned as
+// copies (by util.DedupRegistrationEntries) so that callers can't modify the
+// index.
+func (idx *index) authorizedEntries(id string, nodeSelectors map[string][]*common.Selector) []*common.RegistrationEntry {
+	var entries []*common.RegistrationEntry
+	visited := make(map[string]bool)
+	pending := []string{id}
+	for len(pending) > 0 {
+		id := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+
+		for _, entry := range idx.directEntries(id, nodeSelectors[id]) {
+			entries = append(entries, entry)
+			pending = append(pending, entry.SpiffeId)
+		}
+	}
+	return util.DedupRegistrationEntries(entries)
+}
+
+// directEntries returns the entries the given ID is immediately authorized
+// for, i.e. the entries it is the parent of and the entries whose selectors
+// are a subset of its node selectors.
+func (idx *index) directEntries(id string, nodeSelectors []*common.Selector) []*common.RegistrationEntry {
+	if len(nodeSelectors) == 0 {
+		return idx.byParentID[id]
+	}
+
+	// Copy the child entries so appending the mapped ones does not modify
+	// the index.
+	entries := append([]*common.RegistrationEntry(nil), idx.byParentID[id]...)
+
+	set := make(map[selector]bool, len(nodeSelectors))
+	for _, s := range nodeSelectors {
+		set[makeSelector(s)] = true
+	}
+
+	// Any entry mapped to the node has at least one of the node selectors,
+	// so the candidates are limited to the entries indexed by them.
+	checked := make(map[*common.RegistrationEntry]bool)
+	for _, s := range nodeSelectors {
+		for _, entry := range idx.bySelector[makeSelector(s)] {
+			if checked[entry] {
+				continue
+			}
+			checked[entry] = true
+			if isSubset(entry.Selectors, set) {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	return entries
+}
+
+func isSubset(selectors []*common.Selector, set map[selector]bool) bool {
+	for _, s := range selectors {
+		if !set[makeSelector(s)] {
+			return false
+		}
+	}
+	return true
+}
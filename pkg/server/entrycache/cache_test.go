@@ -0,0 +1,378 @@
+package entrycache
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/sirupsen/logrus/hooks/test"
+	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
+	"github.com/spiffe/spire/pkg/server/util/regentryutil"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/proto/spire/server/datastore"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	serverID = "spiffe://example.org/spire/server"
+	agentID  = "spiffe://example.org/spire/agent/test/id"
+)
+
+func TestFetchAuthorizedEntries(t *testing.T) {
+	ctx := context.Background()
+	ds := fakedatastore.New()
+	metrics := fakemetrics.New()
+	cache := newTestCache(ds, clock.NewMock(t), metrics)
+
+	setNodeSelectors(t, ds, agentID, "node:a", "node:b")
+
+	// mapped to the agent through its node selectors
+	cluster := createEntry(t, ds, serverID, "spiffe://example.org/cluster", "node:a")
+	// not mapped since the agent lacks one of the selectors
+	createEntry(t, ds, serverID, "spiffe://example.org/other-cluster", "node:a", "node:c")
+	// children of the agent and the mapped entry
+	workload1 := createEntry(t, ds, agentID, "spiffe://example.org/workload1", "unix:uid:1000")
+	workload2 := createEntry(t, ds, "spiffe://example.org/cluster", "spiffe://example.org/workload2", "unix:uid:1001")
+	// a child of a workload, and a cycle back to it
+	nested := createEntry(t, ds, "spiffe://example.org/workload1", "spiffe://example.org/nested", "unix:uid:1002")
+	cycle := createEntry(t, ds, "spiffe://example.org/nested", "spiffe://example.org/workload1", "unix:uid:1003")
+	// unrelated
+	createEntry(t, ds, "spiffe://example.org/spire/agent/test/other", "spiffe://example.org/workload3", "unix:uid:1000")
+
+	expected, expectedRevision, err := regentryutil.FetchRegistrationEntriesWithRevision(ctx, ds, agentID)
+	require.NoError(t, err)
+	require.Len(t, expected, 5)
+	requireEntryIDs(t, expected, cluster, workload1, workload2, nested, cycle)
+
+	// before the first refresh, the datastore is used
+	entries, revision, err := cache.FetchAuthorizedEntries(ctx, agentID)
+	require.NoError(t, err)
+	require.Equal(t, expected, entries)
+	require.Equal(t, expectedRevision, revision)
+
+	// afterwards, the index is used and gives the same results
+	require.NoError(t, cache.refresh(ctx))
+	entries, revision, err = cache.FetchAuthorizedEntries(ctx, agentID)
+	require.NoError(t, err)
+	require.Equal(t, expected, entries)
+	require.Equal(t, expectedRevision, revision)
+
+	expectedMetrics := fakemetrics.New()
+	telemetry_server.IncrEntryCacheMissCounter(expectedMetrics)
+	telemetry_server.IncrEntryCacheHitCounter(expectedMetrics)
+	require.Equal(t, expectedMetrics.AllMetrics(), counters(metrics))
+}
+
+func TestRefreshPicksUpChanges(t *testing.T) {
+	ctx := context.Background()
+	ds := fakedatastore.New()
+	cache := newTestCache(ds, clock.NewMock(t), fakemetrics.New())
+
+	workload1 := createEntry(t, ds, agentID, "spiffe://example.org/workload1", "unix:uid:1000")
+	require.NoError(t, cache.refresh(ctx))
+	requireAuthorizedEntries(t, cache, workload1)
+
+	// changes are not visible until the next refresh
+	workload2 := createEntry(t, ds, serverID, "spiffe://example.org/workload2", "node:a")
+	setNodeSelectors(t, ds, agentID, "node:a")
+	requireAuthorizedEntries(t, cache, workload1)
+
+	require.NoError(t, cache.refresh(ctx))
+	requireAuthorizedEntries(t, cache, workload1, workload2)
+
+	_, err := ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{
+		EntryId: workload1,
+	})
+	require.NoError(t, err)
+	require.NoError(t, cache.refresh(ctx))
+	requireAuthorizedEntries(t, cache, workload2)
+
+	// the revision matches the datastore
+	revision, err := regentryutil.FetchRevision(ctx, ds)
+	require.NoError(t, err)
+	_, actual, err := cache.FetchAuthorizedEntries(ctx, agentID)
+	require.NoError(t, err)
+	require.Equal(t, revision, actual)
+}
+
+func TestRefreshAppliesEntryChangesIncrementally(t *testing.T) {
+	ctx := context.Background()
+	ds := &countingDataStore{DataStore: fakedatastore.New()}
+	cache := newTestCache(ds, clock.NewMock(t), fakemetrics.New())
+
+	workload1 := createEntry(t, ds, agentID, "spiffe://example.org/workload1", "unix:uid:1000")
+	workload2 := createEntry(t, ds, agentID, "spiffe://example.org/workload2", "unix:uid:1001")
+	require.NoError(t, cache.refresh(ctx))
+	requireAuthorizedEntries(t, cache, workload1, workload2)
+	require.Equal(t, 1, ds.listEntriesCalls)
+
+	// created, updated and deleted entries are applied from the change
+	// events without listing the entries again
+	workload3 := createEntry(t, ds, agentID, "spiffe://example.org/workload3", "unix:uid:1002")
+	_, err := ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			EntryId:   workload1,
+			ParentId:  "spiffe://example.org/spire/agent/test/other",
+			SpiffeId:  "spiffe://example.org/workload1",
+			Selectors: []*common.Selector{makeTestSelector("unix:uid:1000")},
+		},
+	})
+	require.NoError(t, err)
+	_, err = ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{
+		EntryId: workload2,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, cache.refresh(ctx))
+	requireAuthorizedEntries(t, cache, workload3)
+	require.Equal(t, 1, ds.listEntriesCalls)
+}
+
+func TestRefreshReloadsEntriesWhenEventsPruned(t *testing.T) {
+	ctx := context.Background()
+	ds := &countingDataStore{DataStore: fakedatastore.New()}
+	cache := newTestCache(ds, clock.NewMock(t), fakemetrics.New())
+
+	workload1 := createEntry(t, ds, agentID, "spiffe://example.org/workload1", "unix:uid:1000")
+	require.NoError(t, cache.refresh(ctx))
+	require.Equal(t, 1, ds.listEntriesCalls)
+
+	// the events of the changes since the last refresh are pruned, save for
+	// the latest one, so the entries have to be loaded again
+	workload2 := createEntry(t, ds, agentID, "spiffe://example.org/workload2", "unix:uid:1001")
+	workload3 := createEntry(t, ds, agentID, "spiffe://example.org/workload3", "unix:uid:1002")
+	_, err := ds.PruneRegistrationEntryEvents(ctx, &datastore.PruneRegistrationEntryEventsRequest{
+		CreatedBefore: time.Now().Add(time.Hour).Unix(),
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, cache.refresh(ctx))
+	requireAuthorizedEntries(t, cache, workload1, workload2, workload3)
+	require.Equal(t, 2, ds.listEntriesCalls)
+}
+
+func TestFetchAuthorizedEntriesReturnsCopies(t *testing.T) {
+	ctx := context.Background()
+	ds := fakedatastore.New()
+	cache := newTestCache(ds, clock.NewMock(t), fakemetrics.New())
+
+	createEntry(t, ds, agentID, "spiffe://example.org/workload1", "unix:uid:1000")
+	require.NoError(t, cache.refresh(ctx))
+
+	entries, _, err := cache.FetchAuthorizedEntries(ctx, agentID)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	expected := proto.Clone(entries[0])
+
+	// modifying the returned entries does not modify the index
+	entries[0].SpiffeId = "spiffe://example.org/modified"
+	entries[0].Selectors[0].Value = "uid:0"
+
+	entries, _, err = cache.FetchAuthorizedEntries(ctx, agentID)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.True(t, proto.Equal(expected, entries[0]), "index was modified through a returned entry")
+}
+
+func TestRefreshUpdatesNodeSelectors(t *testing.T) {
+	ctx := context.Background()
+	ds := fakedatastore.New()
+	cache := newTestCache(ds, clock.NewMock(t), fakemetrics.New())
+
+	workload1 := createEntry(t, ds, serverID, "spiffe://example.org/workload1", "node:a")
+	workload2 := createEntry(t, ds, serverID, "spiffe://example.org/workload2", "node:b")
+	setNodeSelectors(t, ds, agentID, "node:a")
+	require.NoError(t, cache.refresh(ctx))
+	requireAuthorizedEntries(t, cache, workload1)
+
+	cache.mu.RLock()
+	idx := cache.idx
+	cache.mu.RUnlock()
+
+	// node selector changes do not change the revision, so the entries are
+	// not reloaded but the node selectors are updated in place
+	setNodeSelectors(t, ds, agentID, "node:b")
+	require.NoError(t, cache.refresh(ctx))
+	requireAuthorizedEntries(t, cache, workload2)
+
+	// nodes without selectors left are removed
+	setNodeSelectors(t, ds, agentID)
+	require.NoError(t, cache.refresh(ctx))
+	requireAuthorizedEntries(t, cache)
+	require.Empty(t, cache.nodeSelectors)
+
+	cache.mu.RLock()
+	require.True(t, idx == cache.idx, "entries should not have been reloaded")
+	cache.mu.RUnlock()
+}
+
+func TestUpdateNodeSelectors(t *testing.T) {
+	ctx := context.Background()
+	ds := fakedatastore.New()
+	cache := newTestCache(ds, clock.NewMock(t), fakemetrics.New())
+
+	workload1 := createEntry(t, ds, serverID, "spiffe://example.org/workload1", "node:a")
+
+	// ignored until the cache is loaded
+	cache.UpdateNodeSelectors(agentID, []*common.Selector{makeTestSelector("node:a")})
+	require.Nil(t, cache.nodeSelectors)
+
+	require.NoError(t, cache.refresh(ctx))
+	requireAuthorizedEntries(t, cache)
+
+	// visible right away, without waiting for a refresh
+	cache.UpdateNodeSelectors(agentID, []*common.Selector{makeTestSelector("node:a")})
+	requireAuthorizedEntries(t, cache, workload1)
+
+	cache.UpdateNodeSelectors(agentID, nil)
+	requireAuthorizedEntries(t, cache)
+}
+
+func TestFetchAuthorizedEntriesWhenStale(t *testing.T) {
+	ctx := context.Background()
+	ds := fakedatastore.New()
+	clk := clock.NewMock(t)
+	metrics := fakemetrics.New()
+	cache := newTestCache(ds, clk, metrics)
+
+	workload1 := createEntry(t, ds, agentID, "spiffe://example.org/workload1", "unix:uid:1000")
+	require.NoError(t, cache.refresh(ctx))
+
+	// once the index is too old, the datastore is used again
+	workload2 := createEntry(t, ds, agentID, "spiffe://example.org/workload2", "unix:uid:1001")
+	clk.Add(DefaultMaxStaleness)
+	requireAuthorizedEntries(t, cache, workload1)
+	clk.Add(time.Second)
+	requireAuthorizedEntries(t, cache, workload1, workload2)
+
+	expectedMetrics := fakemetrics.New()
+	telemetry_server.IncrEntryCacheHitCounter(expectedMetrics)
+	telemetry_server.IncrEntryCacheMissCounter(expectedMetrics)
+	require.Equal(t, expectedMetrics.AllMetrics(), counters(metrics))
+}
+
+func TestRun(t *testing.T) {
+	ds := fakedatastore.New()
+	clk := clock.NewMock(t)
+	cache := newTestCache(ds, clk, fakemetrics.New())
+
+	workload1 := createEntry(t, ds, agentID, "spiffe://example.org/workload1", "unix:uid:1000")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- cache.Run(ctx)
+	}()
+
+	// the index is built right away...
+	clk.WaitForTicker(time.Minute, "waiting for the refresh ticker")
+	requireEventuallyAuthorized(t, cache, workload1)
+
+	// ...and rebuilt on the next tick after a change
+	workload2 := createEntry(t, ds, agentID, "spiffe://example.org/workload2", "unix:uid:1001")
+	clk.Add(DefaultRefreshInterval)
+	requireEventuallyAuthorized(t, cache, workload1, workload2)
+
+	cancel()
+	require.NoError(t, <-errCh)
+}
+
+// countingDataStore counts the calls listing the registration entries
+type countingDataStore struct {
+	datastore.DataStore
+
+	listEntriesCalls int
+}
+
+func (ds *countingDataStore) ListRegistrationEntries(ctx context.Context, req *datastore.ListRegistrationEntriesRequest) (*datastore.ListRegistrationEntriesResponse, error) {
+	ds.listEntriesCalls++
+	return ds.DataStore.ListRegistrationEntries(ctx, req)
+}
+
+func newTestCache(ds datastore.DataStore, clk *clock.Mock, metrics *fakemetrics.FakeMetrics) *Cache {
+	log, _ := test.NewNullLogger()
+	return New(Config{
+		DataStore: ds,
+		Log:       log,
+		Metrics:   metrics,
+		Clock:     clk,
+	})
+}
+
+func createEntry(t *testing.T, ds datastore.DataStore, parentID, spiffeID string, selectors ...string) string {
+	entry := &common.RegistrationEntry{
+		ParentId: parentID,
+		SpiffeId: spiffeID,
+	}
+	for _, s := range selectors {
+		entry.Selectors = append(entry.Selectors, makeTestSelector(s))
+	}
+	resp, err := ds.CreateRegistrationEntry(context.Background(), &datastore.CreateRegistrationEntryRequest{
+		Entry: entry,
+	})
+	require.NoError(t, err)
+	return resp.Entry.EntryId
+}
+
+func setNodeSelectors(t *testing.T, ds datastore.DataStore, spiffeID string, selectors ...string) {
+	nodeSelectors := &datastore.NodeSelectors{
+		SpiffeId: spiffeID,
+	}
+	for _, s := range selectors {
+		nodeSelectors.Selectors = append(nodeSelectors.Selectors, makeTestSelector(s))
+	}
+	_, err := ds.SetNodeSelectors(context.Background(), &datastore.SetNodeSelectorsRequest{
+		Selectors: nodeSelectors,
+	})
+	require.NoError(t, err)
+}
+
+// makeTestSelector splits "type:value" into a selector
+func makeTestSelector(s string) *common.Selector {
+	parts := strings.SplitN(s, ":", 2)
+	return &common.Selector{Type: parts[0], Value: parts[1]}
+}
+
+func requireAuthorizedEntries(t *testing.T, cache *Cache, entryIDs ...string) {
+	entries, _, err := cache.FetchAuthorizedEntries(context.Background(), agentID)
+	require.NoError(t, err)
+	requireEntryIDs(t, entries, entryIDs...)
+}
+
+func requireEntryIDs(t *testing.T, entries []*common.RegistrationEntry, entryIDs ...string) {
+	var actual []string
+	for _, entry := range entries {
+		actual = append(actual, entry.EntryId)
+	}
+	require.ElementsMatch(t, entryIDs, actual)
+}
+
+func requireEventuallyAuthorized(t *testing.T, cache *Cache, entryIDs ...string) {
+	deadline := time.Now().Add(time.Minute)
+	for {
+		// only look at the index; the datastore would always be up to date
+		entries, _, ok := cache.authorizedEntries(agentID)
+		if ok && len(entries) == len(entryIDs) {
+			requireEntryIDs(t, entries, entryIDs...)
+			return
+		}
+		require.True(t, time.Now().Before(deadline), "timed out waiting for the cache to refresh")
+		time.Sleep(time.Millisecond * 10)
+	}
+}
+
+// counters returns the counter metrics (i.e. hits and misses)
+func counters(metrics *fakemetrics.FakeMetrics) []fakemetrics.MetricItem {
+	var items []fakemetrics.MetricItem
+	for _, item := range metrics.AllMetrics() {
+		if item.Type == fakemetrics.IncrCounterType {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -42,10 +42,11 @@ func TestFetchX509IdentitySuccess(t *testing.T) {
 	}
 
 	ds := fakedatastore.New()
-	_, err := ds.CreateBundle(context.Background(), &datastore.CreateBundleRequest{
+	createResp, err := ds.CreateBundle(context.Background(), &datastore.CreateBundleRequest{
 		Bundle: bundle,
 	})
 	require.NoError(t, err)
+	bundle = createResp.Bundle
 
 	hs := New(Config{
 		TrustDomainID: "spiffe://domain.test",
@@ -0,0 +1,87 @@
+package issuancelog
+
+import (
+	"context"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
+	"github.com/spiffe/spire/proto/spire/server/datastore"
+)
+
+const (
+	// DefaultRetention is how long records are kept after the SVID expires
+	// if not overridden by the server config.
+	DefaultRetention = 30 * 24 * time.Hour
+
+	pruneInterval = time.Hour
+)
+
+type Config struct {
+	DataStore datastore.DataStore
+	Log       logrus.FieldLogger
+	Metrics   telemetry.Metrics
+	Clock     clock.Clock
+
+	// Retention is how long records are kept after the SVID expires
+	Retention time.Duration
+}
+
+// Log records the SVIDs signed by the server in the datastore and prunes
+// the records once they are past retention
+type Log struct {
+	c Config
+}
+
+func New(c Config) *Log {
+	if c.Clock == nil {
+		c.Clock = clock.New()
+	}
+	if c.Retention <= 0 {
+		c.Retention = DefaultRetention
+	}
+	return &Log{
+		c: c,
+	}
+}
+
+// RecordIssuedSVID writes a record of the issued SVID to the datastore
+func (l *Log) RecordIssuedSVID(ctx context.Context, issuedSVID *datastore.IssuedSVID) error {
+	_, err := l.c.DataStore.CreateIssuedSVID(ctx, &datastore.CreateIssuedSVIDRequest{
+		IssuedSvid: issuedSVID,
+	})
+	return err
+}
+
+// Run prunes the records past retention until the context is canceled
+func (l *Log) Run(ctx context.Context) error {
+	return l.pruneEvery(ctx)
+}
+
+func (l *Log) pruneEvery(ctx context.Context) error {
+	ticker := l.c.Clock.Ticker(pruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := l.prune(ctx); err != nil {
+				l.c.Log.WithError(err).Error("Could not prune issuance log")
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (l *Log) prune(ctx context.Context) (err error) {
+	counter := telemetry_server.StartIssuanceLogPruneCall(l.c.Metrics)
+	defer counter.Done(&err)
+
+	_, err = l.c.DataStore.PruneIssuedSVIDs(ctx, &datastore.PruneIssuedSVIDsRequest{
+		ExpiresBefore: l.c.Clock.Now().Add(-l.c.Retention).Unix(),
+	})
+	return err
+}
@@ -0,0 +1,86 @@
+package issuancelog
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/proto/spire/server/datastore"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordIssuedSVID(t *testing.T) {
+	ctx := context.Background()
+	ds := fakedatastore.New()
+	log, _ := test.NewNullLogger()
+
+	l := New(Config{
+		DataStore: ds,
+		Log:       log,
+		Metrics:   telemetry.Blackhole{},
+	})
+
+	issuedSVID := &datastore.IssuedSVID{
+		Id:          "1",
+		Type:        datastore.IssuedSVID_X509_SVID,
+		SpiffeId:    "spiffe://example.org/foo",
+		EntryId:     "entry",
+		AgentId:     "spiffe://example.org/spire/agent/foo",
+		AuthorityId: "authority",
+		NotBefore:   1,
+		NotAfter:    2,
+	}
+	require.NoError(t, l.RecordIssuedSVID(ctx, issuedSVID))
+	requireIssuedSVIDs(t, ds, "1")
+}
+
+func TestPrune(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewMock(t)
+	ds := fakedatastore.New()
+	log, _ := test.NewNullLogger()
+
+	l := New(Config{
+		DataStore: ds,
+		Log:       log,
+		Metrics:   telemetry.Blackhole{},
+		Clock:     clk,
+		Retention: time.Hour,
+	})
+
+	recordIssuedSVID(t, l, "expiring", clk.Now().Add(time.Minute))
+	recordIssuedSVID(t, l, "lasting", clk.Now().Add(time.Hour))
+
+	// nothing is past retention yet
+	clk.Add(time.Hour)
+	require.NoError(t, l.prune(ctx))
+	requireIssuedSVIDs(t, ds, "expiring", "lasting")
+
+	// only the record of the SVID that expired more than an hour ago is
+	// pruned
+	clk.Add(2 * time.Minute)
+	require.NoError(t, l.prune(ctx))
+	requireIssuedSVIDs(t, ds, "lasting")
+}
+
+func recordIssuedSVID(t *testing.T, l *Log, id string, notAfter time.Time) {
+	require.NoError(t, l.RecordIssuedSVID(context.Background(), &datastore.IssuedSVID{
+		Id:       id,
+		NotAfter: notAfter.Unix(),
+	}))
+}
+
+func requireIssuedSVIDs(t *testing.T, ds datastore.DataStore, ids ...string) {
+	resp, err := ds.ListIssuedSVIDs(context.Background(), &datastore.ListIssuedSVIDsRequest{})
+	require.NoError(t, err)
+
+	var actual []string
+	for _, issuedSVID := range resp.IssuedSvids {
+		actual = append(actual, issuedSVID.Id)
+	}
+	require.ElementsMatch(t, ids, actual)
+}
@@ -0,0 +1,237 @@
+package leader
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
+	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/proto/spire/server/datastore"
+)
+
+const (
+	DefaultLeaseName = "spire-server"
+	DefaultLeaseTTL  = 30 * time.Second
+
+	// renewalsPerLease is the number of times the lease is renewed within
+	// the lease TTL so that a failed renewal or two does not cost the
+	// leadership.
+	renewalsPerLease = 3
+
+	// releaseTimeout bounds how long releasing the lease can delay
+	// shutdown.
+	releaseTimeout = 5 * time.Second
+)
+
+type Config struct {
+	Log       logrus.FieldLogger
+	Metrics   telemetry.Metrics
+	DataStore datastore.DataStore
+	Clock     clock.Clock
+
+	// ID identifies this server as a lease holder. It must be unique among
+	// the servers sharing the datastore.
+	ID string
+
+	// LeaseName is the name of the lease the servers contend for
+	LeaseName string
+
+	// LeaseTTL is how long the lease is held without being renewed. Since the
+	// lease expiry is compared against the local clock of each contender, the
+	// clock skew between servers must stay well below the margin the leader
+	// keeps before the lease expires (LeaseTTL / renewalsPerLease).
+	LeaseTTL time.Duration
+}
+
+// Status is the leadership status reported by the health check
+type Status struct {
+	Leader   bool   `json:"leader"`
+	ID       string `json:"id"`
+	HolderID string `json:"holder_id,omitempty"`
+}
+
+// Elector elects a single leader among the servers sharing a datastore by
+// having them contend for a lease in the datastore. The server holding the
+// lease is the leader until it fails to renew the lease before it expires.
+type Elector struct {
+	c Config
+
+	mu        sync.Mutex
+	leader    bool
+	holderID  string
+	expiresAt time.Time
+	changed   chan struct{}
+}
+
+func New(c Config) *Elector {
+	if c.Clock == nil {
+		c.Clock = clock.New()
+	}
+	if c.LeaseName == "" {
+		c.LeaseName = DefaultLeaseName
+	}
+	if c.LeaseTTL <= 0 {
+		c.LeaseTTL = DefaultLeaseTTL
+	}
+	return &Elector{
+		c:       c,
+		changed: make(chan struct{}),
+	}
+}
+
+// Run contends for the lease, renewing it while held, until the context is
+// canceled. The lease is released on the way out so another server can take
+// over without waiting for it to expire.
+func (e *Elector) Run(ctx context.Context) error {
+	e.acquire(ctx)
+
+	ticker := e.c.Clock.Ticker(e.c.LeaseTTL / renewalsPerLease)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			e.acquire(ctx)
+		case <-ctx.Done():
+			e.release()
+			return nil
+		}
+	}
+}
+
+// IsLeader returns true if this server currently holds the lease
+func (e *Elector) IsLeader() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.leader
+}
+
+// Status implements the health check interface. Servers that are not the
+// leader are still healthy; the status details report the leadership.
+func (e *Elector) Status() (interface{}, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return Status{
+		Leader:   e.leader,
+		ID:       e.c.ID,
+		HolderID: e.holderID,
+	}, nil
+}
+
+// RunWhileLeader runs the tasks while this server is the leader. The tasks
+// are canceled when leadership is lost and started again when it is
+// regained. It returns when the context is canceled or a task fails while
+// this server is the leader.
+func (e *Elector) RunWhileLeader(ctx context.Context, tasks ...func(context.Context) error) error {
+	for {
+		leader, changed := e.state()
+		if !leader {
+			select {
+			case <-changed:
+				continue
+			case <-ctx.Done():
+				return nil
+			}
+		}
+
+		e.c.Log.Debug("Starting leader tasks")
+		tasksCtx, cancel := context.WithCancel(ctx)
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- util.RunTasks(tasksCtx, tasks...)
+		}()
+
+		select {
+		case <-changed:
+			e.c.Log.Debug("Stopping leader tasks")
+			cancel()
+			<-errCh
+		case err := <-errCh:
+			cancel()
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+func (e *Elector) state() (bool, <-chan struct{}) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.leader, e.changed
+}
+
+func (e *Elector) acquire(ctx context.Context) {
+	now := e.c.Clock.Now()
+	expiresAt := now.Add(e.c.LeaseTTL)
+
+	resp, err := e.c.DataStore.AcquireLease(ctx, &datastore.AcquireLeaseRequest{
+		Name:      e.c.LeaseName,
+		HolderId:  e.c.ID,
+		Now:       now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		e.c.Log.WithError(err).Warn("Unable to acquire leader lease")
+
+		e.mu.Lock()
+		defer e.mu.Unlock()
+		// Hold on to the leadership as long as the lease is not at risk of
+		// expiring before the next renewal.
+		if e.leader && !now.Add(e.c.LeaseTTL/renewalsPerLease).Before(e.expiresAt) {
+			e.setLeader(false)
+		}
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.holderID = resp.Lease.HolderId
+	if resp.Lease.HolderId == e.c.ID {
+		e.expiresAt = expiresAt
+		e.setLeader(true)
+	} else {
+		e.setLeader(false)
+	}
+}
+
+func (e *Elector) release() {
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.leader {
+		return
+	}
+	if _, err := e.c.DataStore.ReleaseLease(ctx, &datastore.ReleaseLeaseRequest{
+		Name:     e.c.LeaseName,
+		HolderId: e.c.ID,
+	}); err != nil {
+		e.c.Log.WithError(err).Warn("Unable to release leader lease")
+	}
+	e.holderID = ""
+	e.setLeader(false)
+}
+
+// setLeader updates the leadership, notifying anything waiting on a change.
+// The caller must hold the mutex.
+func (e *Elector) setLeader(leader bool) {
+	telemetry_server.SetLeaderGauge(e.c.Metrics, leader)
+	if e.leader == leader {
+		return
+	}
+	e.leader = leader
+	if leader {
+		e.c.Log.Info("Became leader")
+	} else {
+		e.c.Log.WithField(telemetry.HolderID, e.holderID).Info("No longer leader")
+	}
+	close(e.changed)
+	e.changed = make(chan struct{})
+}
@@ -0,0 +1,212 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/proto/spire/server/datastore"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/spiffe/spire/test/fakes/fakemetrics"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	ctx = context.Background()
+)
+
+func TestElection(t *testing.T) {
+	clk := clock.NewMock(t)
+	ds := fakedatastore.New()
+	a := newElector(t, "A", ds, clk)
+	b := newElector(t, "B", ds, clk)
+
+	// the first to acquire the lease is the leader
+	a.acquire(ctx)
+	b.acquire(ctx)
+	require.True(t, a.IsLeader())
+	require.False(t, b.IsLeader())
+	requireStatus(t, b, Status{Leader: false, ID: "B", HolderID: "A"})
+
+	// the leader keeps the lease by renewing it
+	for i := 0; i < renewalsPerLease*2; i++ {
+		clk.Add(DefaultLeaseTTL / renewalsPerLease)
+		a.acquire(ctx)
+		b.acquire(ctx)
+		require.True(t, a.IsLeader())
+		require.False(t, b.IsLeader())
+	}
+
+	// the lease changes hands once the leader stops renewing it
+	clk.Add(DefaultLeaseTTL)
+	b.acquire(ctx)
+	a.acquire(ctx)
+	require.False(t, a.IsLeader())
+	require.True(t, b.IsLeader())
+	requireStatus(t, a, Status{Leader: false, ID: "A", HolderID: "B"})
+	requireStatus(t, b, Status{Leader: true, ID: "B", HolderID: "B"})
+}
+
+func TestRunReleasesLease(t *testing.T) {
+	clk := clock.NewMock(t)
+	ds := fakedatastore.New()
+	a := newElector(t, "A", ds, clk)
+	b := newElector(t, "B", ds, clk)
+
+	ctx, cancel := context.WithCancel(ctx)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- a.Run(ctx)
+	}()
+	clk.WaitForTicker(time.Minute, "waiting for the elector to start")
+	require.True(t, a.IsLeader())
+
+	cancel()
+	require.NoError(t, <-errCh)
+	require.False(t, a.IsLeader())
+
+	// the lease is up for grabs without waiting for it to expire
+	b.acquire(context.Background())
+	require.True(t, b.IsLeader())
+}
+
+func TestAcquireFailure(t *testing.T) {
+	clk := clock.NewMock(t)
+	ds := &failingDataStore{DataStore: fakedatastore.New()}
+	a := newElector(t, "A", ds, clk)
+
+	a.acquire(ctx)
+	require.True(t, a.IsLeader())
+
+	// leadership survives failed renewals while the lease has time left
+	ds.err = errors.New("oh no")
+	clk.Add(DefaultLeaseTTL / renewalsPerLease)
+	a.acquire(ctx)
+	require.True(t, a.IsLeader())
+
+	// but is given up when the lease might expire before the next renewal
+	clk.Add(DefaultLeaseTTL / renewalsPerLease)
+	a.acquire(ctx)
+	require.False(t, a.IsLeader())
+}
+
+func TestRunWhileLeader(t *testing.T) {
+	clk := clock.NewMock(t)
+	ds := fakedatastore.New()
+	a := newElector(t, "A", ds, clk)
+	b := newElector(t, "B", ds, clk)
+
+	started := make(chan struct{}, 1)
+	stopped := make(chan struct{}, 1)
+	task := func(ctx context.Context) error {
+		started <- struct{}{}
+		<-ctx.Done()
+		stopped <- struct{}{}
+		return ctx.Err()
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- a.RunWhileLeader(ctx, task)
+	}()
+
+	// the task does not run until leadership is acquired
+	requireNoSignal(t, started)
+	a.acquire(ctx)
+	requireSignal(t, started)
+
+	// the task is stopped when leadership is lost...
+	clk.Add(DefaultLeaseTTL)
+	b.acquire(ctx)
+	a.acquire(ctx)
+	requireSignal(t, stopped)
+
+	// ...and started again when it is regained
+	clk.Add(DefaultLeaseTTL)
+	a.acquire(ctx)
+	requireSignal(t, started)
+
+	cancel()
+	requireSignal(t, stopped)
+	require.NoError(t, <-errCh)
+}
+
+func TestRunWhileLeaderFailsWithTask(t *testing.T) {
+	a := newElector(t, "A", fakedatastore.New(), clock.NewMock(t))
+	a.acquire(ctx)
+
+	err := a.RunWhileLeader(ctx, func(context.Context) error {
+		return errors.New("oh no")
+	})
+	require.EqualError(t, err, "oh no")
+}
+
+func TestLeaderGauge(t *testing.T) {
+	metrics := fakemetrics.New()
+	log, _ := test.NewNullLogger()
+	a := New(Config{
+		Log:       log,
+		Metrics:   metrics,
+		DataStore: fakedatastore.New(),
+		Clock:     clock.NewMock(t),
+		ID:        "A",
+	})
+
+	a.acquire(ctx)
+	a.release()
+	require.Equal(t, []fakemetrics.MetricItem{
+		{Type: fakemetrics.SetGaugeType, Key: []string{telemetry.Leader}, Val: 1},
+		{Type: fakemetrics.SetGaugeType, Key: []string{telemetry.Leader}, Val: 0},
+	}, metrics.AllMetrics())
+}
+
+func newElector(t *testing.T, id string, ds datastore.DataStore, clk *clock.Mock) *Elector {
+	log, _ := test.NewNullLogger()
+	return New(Config{
+		Log:       log,
+		Metrics:   telemetry.Blackhole{},
+		DataStore: ds,
+		Clock:     clk,
+		ID:        id,
+	})
+}
+
+func requireStatus(t *testing.T, e *Elector, expected Status) {
+	status, err := e.Status()
+	require.NoError(t, err)
+	require.Equal(t, expected, status)
+}
+
+func requireSignal(t *testing.T, ch <-chan struct{}) {
+	select {
+	case <-ch:
+	case <-time.After(time.Minute):
+		require.FailNow(t, "timed out waiting for signal")
+	}
+}
+
+func requireNoSignal(t *testing.T, ch <-chan struct{}) {
+	select {
+	case <-ch:
+		require.FailNow(t, "unexpected signal")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+type failingDataStore struct {
+	datastore.DataStore
+	err error
+}
+
+func (ds *failingDataStore) AcquireLease(ctx context.Context, req *datastore.AcquireLeaseRequest) (*datastore.AcquireLeaseResponse, error) {
+	if ds.err != nil {
+		return nil, ds.err
+	}
+	return ds.DataStore.AcquireLease(ctx, req)
+}
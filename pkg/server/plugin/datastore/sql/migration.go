@@ -14,7 +14,7 @@ import (
 
 const (
 	// version of the database in the code
-	codeVersion = 9
+	codeVersion = 22
 )
 
 func migrateDB(db *gorm.DB, dbType string, log hclog.Logger) (err error) {
@@ -83,6 +83,16 @@ func initDB(db *gorm.DB, dbType string, log hclog.Logger) (err error) {
 		&Selector{},
 		&Migration{},
 		&DNSName{},
+		&CAJournal{},
+		&Lease{},
+		&RevokedCertificate{},
+		&DownstreamCA{},
+		&IssuedSVID{},
+		&Revision{},
+		&RegisteredEntryTombstone{},
+		&RegisteredEntryEvent{},
+		&NodeSelectorsEvent{},
+		&RegisteredEntryEventsWatermark{},
 	}
 
 	if err := tableOptionsForDialect(tx, dbType).AutoMigrate(tables...).Error; err != nil {
@@ -90,6 +100,11 @@ func initDB(db *gorm.DB, dbType string, log hclog.Logger) (err error) {
 		return sqlError.Wrap(err)
 	}
 
+	if err := tx.Create(&Revision{}).Error; err != nil {
+		tx.Rollback()
+		return sqlError.Wrap(err)
+	}
+
 	if err := tx.Assign(Migration{Version: codeVersion}).FirstOrCreate(&Migration{}).Error; err != nil {
 		tx.Rollback()
 		return sqlError.Wrap(err)
@@ -138,6 +153,32 @@ func migrateVersion(tx *gorm.DB, version int, log hclog.Logger) (versionOut int,
 		err = migrateToV8(tx)
 	case 8:
 		err = migrateToV9(tx)
+	case 9:
+		err = migrateToV10(tx)
+	case 10:
+		err = migrateToV11(tx)
+	case 11:
+		err = migrateToV12(tx)
+	case 12:
+		err = migrateToV13(tx)
+	case 13:
+		err = migrateToV14(tx)
+	case 14:
+		err = migrateToV15(tx)
+	case 15:
+		err = migrateToV16(tx)
+	case 16:
+		err = migrateToV17(tx)
+	case 17:
+		err = migrateToV18(tx)
+	case 18:
+		err = migrateToV19(tx)
+	case 19:
+		err = migrateToV20(tx)
+	case 20:
+		err = migrateToV21(tx)
+	case 21:
+		err = migrateToV22(tx)
 	default:
 		err = sqlError.New("no migration support for version %d", version)
 	}
@@ -204,7 +245,7 @@ func migrateToV3(tx *gorm.DB) (err error) {
 		}
 	}
 
-	var attestedNodes []*AttestedNode
+	var attestedNodes []*V16AttestedNode
 	if err := tx.Find(&attestedNodes).Error; err != nil {
 		return sqlError.Wrap(err)
 	}
@@ -327,7 +368,117 @@ func migrateToV8(tx *gorm.DB) error {
 }
 
 func migrateToV9(tx *gorm.DB) error {
-	if err := tx.AutoMigrate(&RegisteredEntry{}, &Selector{}).Error; err != nil {
+	if err := tx.AutoMigrate(&V9RegisteredEntry{}, &Selector{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV10(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&CAJournal{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV11(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&Lease{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV12(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&RevokedCertificate{}, &DownstreamCA{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV13(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&IssuedSVID{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV14(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&V14RegisteredEntry{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV15(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&V15RegisteredEntry{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV16(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&RegisteredEntry{}, &Bundle{}, &Revision{}, &RegisteredEntryTombstone{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	// Existing entries and bundles are left at revision zero. Agents always
+	// receive them in their first (full) update.
+	if err := tx.Create(&Revision{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV17(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&AttestedNode{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	// The column is added as NULL for existing nodes, which would not match
+	// when filtering on nodes that are not banned.
+	if err := tx.Model(&AttestedNode{}).Where("banned IS NULL").Update("banned", false).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV18(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&JoinToken{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	// Existing tokens are single use and have not been used yet. The columns
+	// are added as NULL, which can't be loaded into the model.
+	if err := tx.Model(&JoinToken{}).Where("uses IS NULL").Updates(map[string]interface{}{
+		"max_uses": 0,
+		"uses":     0,
+	}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV19(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&RegisteredEntryEvent{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV20(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&NodeSelectorsEvent{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV21(tx *gorm.DB) error {
+	// Adds the index used to look up node selectors by type and value
+	if err := tx.AutoMigrate(&NodeSelector{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func migrateToV22(tx *gorm.DB) error {
+	if err := tx.AutoMigrate(&RegisteredEntryEventsWatermark{}).Error; err != nil {
 		return sqlError.Wrap(err)
 	}
 	return nil
@@ -462,6 +613,101 @@ func (V8RegisteredEntry) TableName() string {
 	return "registered_entries"
 }
 
+// V9RegisteredEntry holds a version 9 registered entry
+type V9RegisteredEntry struct {
+	Model
+
+	EntryID  string `gorm:"unique_index"`
+	SpiffeID string `gorm:"index"`
+	ParentID string `gorm:"index"`
+	// TTL of identities derived from this entry
+	TTL           int32
+	Selectors     []Selector
+	FederatesWith []Bundle `gorm:"many2many:federated_registration_entries;"`
+	Admin         bool
+	Downstream    bool
+	// (optional) expiry of this entry
+	Expiry int64
+	// (optional) DNS entries
+	DNSList []DNSName
+}
+
+// TableName gets table name for v9 registered entry
+func (V9RegisteredEntry) TableName() string {
+	return "registered_entries"
+}
+
+// V14RegisteredEntry holds a version 14 registered entry
+type V14RegisteredEntry struct {
+	Model
+
+	EntryID  string `gorm:"unique_index"`
+	SpiffeID string `gorm:"index"`
+	ParentID string `gorm:"index"`
+	// TTL of identities derived from this entry
+	TTL           int32
+	Selectors     []Selector
+	FederatesWith []Bundle `gorm:"many2many:federated_registration_entries;"`
+	Admin         bool
+	Downstream    bool
+	// (optional) expiry of this entry
+	Expiry int64
+	// (optional) DNS entries
+	DNSList []DNSName
+	// (optional) marshaled X509-SVID template
+	X509SVIDTemplate []byte `gorm:"column:x509_svid_template"`
+}
+
+// TableName gets table name for v14 registered entry
+func (V14RegisteredEntry) TableName() string {
+	return "registered_entries"
+}
+
+// V15RegisteredEntry holds a version 15 registered entry
+type V15RegisteredEntry struct {
+	Model
+
+	EntryID  string `gorm:"unique_index"`
+	SpiffeID string `gorm:"index"`
+	ParentID string `gorm:"index"`
+	// TTL of identities derived from this entry
+	TTL           int32
+	Selectors     []Selector
+	FederatesWith []Bundle `gorm:"many2many:federated_registration_entries;"`
+	Admin         bool
+	Downstream    bool
+	// (optional) expiry of this entry
+	Expiry int64
+	// (optional) DNS entries
+	DNSList []DNSName
+	// (optional) marshaled X509-SVID template
+	X509SVIDTemplate []byte `gorm:"column:x509_svid_template"`
+	// (optional) TTL of JWT-SVIDs derived from this entry
+	JWTSVIDTTL int32 `gorm:"column:jwt_svid_ttl"`
+	// (optional) JSON encoded claims added to JWT-SVIDs
+	JWTSVIDClaims []byte `gorm:"column:jwt_svid_claims"`
+}
+
+// TableName gets table name for v15 registered entry
+func (V15RegisteredEntry) TableName() string {
+	return "registered_entries"
+}
+
+// V16AttestedNode holds a version 16 attested node
+type V16AttestedNode struct {
+	Model
+
+	SpiffeID     string `gorm:"unique_index"`
+	DataType     string
+	SerialNumber string
+	ExpiresAt    time.Time
+}
+
+// TableName gets table name for v16 attested node
+func (V16AttestedNode) TableName() string {
+	return "attested_node_entries"
+}
+
 type V8Selector struct {
 	Model
 
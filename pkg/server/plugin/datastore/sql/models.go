@@ -18,6 +18,8 @@ type Bundle struct {
 
 	TrustDomain string `gorm:"not null;unique_index"`
 	Data        []byte `gorm:"size:16777215"` // make MySQL to use MEDIUMBLOB (max 24MB) - doesn't affect PostgreSQL/SQLite
+	// revision of the datastore when the bundle was last changed
+	Revision int64
 
 	FederatedEntries []RegisteredEntry `gorm:"many2many:federated_registration_entries;"`
 }
@@ -30,6 +32,8 @@ type AttestedNode struct {
 	DataType     string
 	SerialNumber string
 	ExpiresAt    time.Time
+	// whether the node is banned from attesting and fetching SVIDs
+	Banned bool
 }
 
 // TableName gets table name of AttestedNode
@@ -42,8 +46,8 @@ type NodeSelector struct {
 	Model
 
 	SpiffeID string `gorm:"unique_index:idx_node_resolver_map"`
-	Type     string `gorm:"unique_index:idx_node_resolver_map"`
-	Value    string `gorm:"unique_index:idx_node_resolver_map"`
+	Type     string `gorm:"unique_index:idx_node_resolver_map;index:idx_node_resolver_map_type_value"`
+	Value    string `gorm:"unique_index:idx_node_resolver_map;index:idx_node_resolver_map_type_value"`
 }
 
 // TableName gets table name of NodeSelector
@@ -68,6 +72,14 @@ type RegisteredEntry struct {
 	Expiry int64
 	// (optional) DNS entries
 	DNSList []DNSName
+	// (optional) marshaled X509-SVID template
+	X509SVIDTemplate []byte `gorm:"column:x509_svid_template"`
+	// (optional) TTL of JWT-SVIDs derived from this entry
+	JWTSVIDTTL int32 `gorm:"column:jwt_svid_ttl"`
+	// (optional) JSON encoded claims added to JWT-SVIDs
+	JWTSVIDClaims []byte `gorm:"column:jwt_svid_claims"`
+	// revision of the datastore when the entry was last changed
+	Revision int64 `gorm:"index"`
 }
 
 // JoinToken holds a join token
@@ -76,6 +88,14 @@ type JoinToken struct {
 
 	Token  string `gorm:"unique_index"`
 	Expiry int64
+	// number of times the token can be used (zero means a single use)
+	MaxUses int32
+	// number of times the token has been used
+	Uses int32
+	// marshaled common.Selectors given to nodes attesting with the token
+	NodeSelectors []byte
+	// marshaled common.RegistrationEntries created when the token is used
+	Entries []byte `gorm:"size:16777215"`
 }
 
 type Selector struct {
@@ -99,6 +119,104 @@ func (DNSName) TableName() string {
 	return "dns_names"
 }
 
+// CAJournal holds the journal of CA keys shared by the servers in an HA
+// deployment
+type CAJournal struct {
+	Model
+
+	JournalID string `gorm:"not null;unique_index"`
+	Data      []byte `gorm:"size:16777215"` // make MySQL to use MEDIUMBLOB (max 24MB) - doesn't affect PostgreSQL/SQLite
+	Revision  int64
+}
+
+// Lease holds a named lease used for leader election among servers sharing
+// the datastore
+type Lease struct {
+	Model
+
+	Name      string `gorm:"not null;unique_index"`
+	HolderID  string
+	ExpiresAt int64
+}
+
+// RevokedCertificate holds the serial number of a revoked certificate
+type RevokedCertificate struct {
+	Model
+
+	SerialNumber string `gorm:"not null;unique_index"`
+	SpiffeID     string
+	ExpiresAt    int64 `gorm:"index"`
+	RevokedAt    int64
+}
+
+// DownstreamCA holds a downstream CA certificate issued through an agent so
+// that it can be revoked if the agent is evicted
+type DownstreamCA struct {
+	Model
+
+	SerialNumber string `gorm:"not null;unique_index"`
+	SpiffeID     string
+	AgentID      string `gorm:"index"`
+	ExpiresAt    int64  `gorm:"index"`
+}
+
+// IssuedSVID holds a record of an SVID signed by the server
+type IssuedSVID struct {
+	Model
+
+	SVIDID      string `gorm:"column:svid_id;not null;index"`
+	Type        int32
+	SpiffeID    string `gorm:"index"`
+	EntryID     string
+	AgentID     string `gorm:"index"`
+	AuthorityID string
+	NotBefore   int64 `gorm:"index"`
+	NotAfter    int64 `gorm:"index"`
+}
+
+// Revision holds the current revision of the datastore. It is incremented
+// whenever a registration entry or bundle changes. There is a single row.
+type Revision struct {
+	Model
+
+	Value int64
+}
+
+// RegisteredEntryTombstone records the deletion of a registration entry so
+// that agents can be told about it without resending every entry
+type RegisteredEntryTombstone struct {
+	Model
+
+	EntryID  string
+	Revision int64 `gorm:"index"`
+}
+
+// RegisteredEntryEvent records a change made to a registration entry so
+// that watchers can follow changes without refetching every entry
+type RegisteredEntryEvent struct {
+	Model
+
+	EntryID string
+	Type    int32
+}
+
+// RegisteredEntryEventsWatermark holds the ID of the latest registration
+// entry event pruned, which tells pruned events apart from gaps in the IDs
+type RegisteredEntryEventsWatermark struct {
+	Model
+
+	PrunedID int64
+}
+
+// NodeSelectorsEvent records that the selectors of a node were set, so that
+// the change can be picked up without listing the selectors of every node.
+// Only the latest event of each node is kept.
+type NodeSelectorsEvent struct {
+	Model
+
+	SpiffeID string `gorm:"index"`
+}
+
 // Migration holds version information
 type Migration struct {
 	Model
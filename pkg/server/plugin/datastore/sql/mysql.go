@@ -104,3 +104,10 @@ func validateMySQLConfig(cfg *configuration) error {
 
 	return nil
 }
+
+// isMySQLUniqueViolation returns true if the error is a MySQL duplicate entry
+// error (ER_DUP_ENTRY)
+func isMySQLUniqueViolation(err error) bool {
+	mysqlErr, ok := err.(*mysqldriver.MySQLError)
+	return ok && mysqlErr.Number == 1062
+}
@@ -4,8 +4,13 @@ import (
 	"github.com/jinzhu/gorm"
 	// gorm postgres dialect init registration
 	_ "github.com/jinzhu/gorm/dialects/postgres"
+	"github.com/lib/pq"
 )
 
+// uniqueViolationCode is the postgres error code for unique constraint
+// violations
+const uniqueViolationCode = "23505"
+
 type postgres struct{}
 
 func (p postgres) connect(cfg *configuration) (*gorm.DB, error) {
@@ -16,3 +21,10 @@ func (p postgres) connect(cfg *configuration) (*gorm.DB, error) {
 	return db, nil
 
 }
+
+// isPostgresUniqueViolation returns true if the error is a postgres unique
+// constraint violation
+func isPostgresUniqueViolation(err error) bool {
+	pqErr, ok := err.(*pq.Error)
+	return ok && pqErr.Code == uniqueViolationCode
+}
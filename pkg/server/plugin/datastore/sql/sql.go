@@ -3,6 +3,7 @@ package sql
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/gofrs/uuid"
 	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes/wrappers"
 	hclog "github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/hcl"
 	"github.com/jinzhu/gorm"
@@ -25,6 +27,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/selector"
 	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/proto/spire/common"
 	spi "github.com/spiffe/spire/proto/spire/common/plugin"
 	"github.com/spiffe/spire/proto/spire/server/datastore"
@@ -245,6 +248,19 @@ func (ds *SQLPlugin) UpdateAttestedNode(ctx context.Context,
 	return resp, nil
 }
 
+// SetAttestedNodeBanned bans or unbans the given attested node
+func (ds *SQLPlugin) SetAttestedNodeBanned(ctx context.Context,
+	req *datastore.SetAttestedNodeBannedRequest) (resp *datastore.SetAttestedNodeBannedResponse, err error) {
+
+	if err := ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = setAttestedNodeBanned(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // DeleteAttestedNode deletes the given attested node
 func (ds *SQLPlugin) DeleteAttestedNode(ctx context.Context,
 	req *datastore.DeleteAttestedNodeRequest) (resp *datastore.DeleteAttestedNodeResponse, err error) {
@@ -286,6 +302,19 @@ func (ds *SQLPlugin) GetNodeSelectors(ctx context.Context,
 	return resp, nil
 }
 
+// ListNodeSelectors lists the node (agent) selectors of all nodes
+func (ds *SQLPlugin) ListNodeSelectors(ctx context.Context,
+	req *datastore.ListNodeSelectorsRequest) (resp *datastore.ListNodeSelectorsResponse, err error) {
+
+	if err := ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = listNodeSelectors(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // CreateRegistrationEntry stores the given registration entry
 func (ds *SQLPlugin) CreateRegistrationEntry(ctx context.Context,
 	req *datastore.CreateRegistrationEntryRequest) (resp *datastore.CreateRegistrationEntryResponse, err error) {
@@ -332,8 +361,11 @@ func (ds *SQLPlugin) ListRegistrationEntries(ctx context.Context,
 // UpdateRegistrationEntry updates an existing registration entry
 func (ds *SQLPlugin) UpdateRegistrationEntry(ctx context.Context,
 	req *datastore.UpdateRegistrationEntryRequest) (resp *datastore.UpdateRegistrationEntryResponse, err error) {
-	if err := validateRegistrationEntry(req.Entry); err != nil {
-		return nil, err
+	// Partial updates are validated once merged with the stored entry
+	if req.Mask == nil || req.Entry == nil {
+		if err := validateRegistrationEntry(req.Entry); err != nil {
+			return nil, err
+		}
 	}
 
 	if err := ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
@@ -358,6 +390,48 @@ func (ds *SQLPlugin) DeleteRegistrationEntry(ctx context.Context,
 	return resp, nil
 }
 
+// BatchCreateRegistrationEntries creates the given registration entries in a
+// single transaction
+func (ds *SQLPlugin) BatchCreateRegistrationEntries(ctx context.Context,
+	req *datastore.BatchCreateRegistrationEntriesRequest) (resp *datastore.BatchCreateRegistrationEntriesResponse, err error) {
+
+	if err := ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = batchCreateRegistrationEntries(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// BatchUpdateRegistrationEntries updates the given registration entries in a
+// single transaction
+func (ds *SQLPlugin) BatchUpdateRegistrationEntries(ctx context.Context,
+	req *datastore.BatchUpdateRegistrationEntriesRequest) (resp *datastore.BatchUpdateRegistrationEntriesResponse, err error) {
+
+	if err := ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = batchUpdateRegistrationEntries(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// BatchDeleteRegistrationEntries deletes the given registration entries in a
+// single transaction
+func (ds *SQLPlugin) BatchDeleteRegistrationEntries(ctx context.Context,
+	req *datastore.BatchDeleteRegistrationEntriesRequest) (resp *datastore.BatchDeleteRegistrationEntriesResponse, err error) {
+
+	if err := ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = batchDeleteRegistrationEntries(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // PruneRegistrationEntries takes a registration entry message, and deletes all entries which have expired
 // before the date in the message
 func (ds *SQLPlugin) PruneRegistrationEntries(ctx context.Context, req *datastore.PruneRegistrationEntriesRequest) (resp *datastore.PruneRegistrationEntriesResponse, err error) {
@@ -370,11 +444,71 @@ func (ds *SQLPlugin) PruneRegistrationEntries(ctx context.Context, req *datastor
 	return resp, nil
 }
 
+// ListRegistrationEntryTombstones lists the IDs of registration entries
+// deleted after the requested revision, along with the current revision
+func (ds *SQLPlugin) ListRegistrationEntryTombstones(ctx context.Context,
+	req *datastore.ListRegistrationEntryTombstonesRequest) (resp *datastore.ListRegistrationEntryTombstonesResponse, err error) {
+	if err := ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = listRegistrationEntryTombstones(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PruneRegistrationEntryTombstones deletes the tombstones of registration
+// entries deleted before the date in the request
+func (ds *SQLPlugin) PruneRegistrationEntryTombstones(ctx context.Context,
+	req *datastore.PruneRegistrationEntryTombstonesRequest) (resp *datastore.PruneRegistrationEntryTombstonesResponse, err error) {
+	if err := ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = pruneRegistrationEntryTombstones(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListRegistrationEntryEvents lists the registration entry change events
+// recorded after the event ID in the request
+func (ds *SQLPlugin) ListRegistrationEntryEvents(ctx context.Context,
+	req *datastore.ListRegistrationEntryEventsRequest) (resp *datastore.ListRegistrationEntryEventsResponse, err error) {
+	if err := ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = listRegistrationEntryEvents(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PruneRegistrationEntryEvents deletes the registration entry change events
+// recorded before the date in the request
+func (ds *SQLPlugin) PruneRegistrationEntryEvents(ctx context.Context,
+	req *datastore.PruneRegistrationEntryEventsRequest) (resp *datastore.PruneRegistrationEntryEventsResponse, err error) {
+	if err := ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = pruneRegistrationEntryEvents(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // CreateJoinToken takes a Token message and stores it
 func (ds *SQLPlugin) CreateJoinToken(ctx context.Context, req *datastore.CreateJoinTokenRequest) (resp *datastore.CreateJoinTokenResponse, err error) {
 	if req.JoinToken == nil || req.JoinToken.Token == "" || req.JoinToken.Expiry == 0 {
 		return nil, errors.New("token and expiry are required")
 	}
+	if req.JoinToken.MaxUses < 0 {
+		return nil, errors.New("max uses cannot be negative")
+	}
+	for _, entry := range req.JoinToken.Entries {
+		if err := validateRegistrationEntry(entry); err != nil {
+			return nil, err
+		}
+	}
 
 	if err := ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
 		resp, err = createJoinToken(tx, req)
@@ -397,6 +531,34 @@ func (ds *SQLPlugin) FetchJoinToken(ctx context.Context, req *datastore.FetchJoi
 	return resp, nil
 }
 
+// ListJoinTokens lists all join tokens
+func (ds *SQLPlugin) ListJoinTokens(ctx context.Context, req *datastore.ListJoinTokensRequest) (resp *datastore.ListJoinTokensResponse, err error) {
+	if err := ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = listJoinTokens(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ConsumeJoinToken uses the given join token once. The registration entries
+// of the token are created, parented to the given agent ID, and the token is
+// deleted once it has no uses left.
+func (ds *SQLPlugin) ConsumeJoinToken(ctx context.Context, req *datastore.ConsumeJoinTokenRequest) (resp *datastore.ConsumeJoinTokenResponse, err error) {
+	if req.Token == "" || req.AgentId == "" {
+		return nil, errors.New("token and agent ID are required")
+	}
+
+	if err := ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = consumeJoinToken(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // DeleteJoinToken deletes the given join token
 func (ds *SQLPlugin) DeleteJoinToken(ctx context.Context, req *datastore.DeleteJoinTokenRequest) (resp *datastore.DeleteJoinTokenResponse, err error) {
 	if err := ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
@@ -420,6 +582,169 @@ func (ds *SQLPlugin) PruneJoinTokens(ctx context.Context, req *datastore.PruneJo
 	return resp, nil
 }
 
+// FetchCAJournal fetches the CA journal with the given ID
+func (ds *SQLPlugin) FetchCAJournal(ctx context.Context, req *datastore.FetchCAJournalRequest) (resp *datastore.FetchCAJournalResponse, err error) {
+	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = fetchCAJournal(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// SetCAJournal sets the CA journal if the revision in the request matches
+// the stored revision
+func (ds *SQLPlugin) SetCAJournal(ctx context.Context, req *datastore.SetCAJournalRequest) (resp *datastore.SetCAJournalResponse, err error) {
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = setCAJournal(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// AcquireLease acquires or renews a lease. The lease is acquired if it is
+// not held, already held by the requesting holder, or has expired.
+func (ds *SQLPlugin) AcquireLease(ctx context.Context, req *datastore.AcquireLeaseRequest) (resp *datastore.AcquireLeaseResponse, err error) {
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = acquireLease(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ReleaseLease releases a lease if it is held by the requesting holder
+func (ds *SQLPlugin) ReleaseLease(ctx context.Context, req *datastore.ReleaseLeaseRequest) (resp *datastore.ReleaseLeaseResponse, err error) {
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = releaseLease(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// RevokeCertificate records the serial number of a revoked certificate.
+// Revoking a certificate that is already revoked is a no-op.
+func (ds *SQLPlugin) RevokeCertificate(ctx context.Context, req *datastore.RevokeCertificateRequest) (resp *datastore.RevokeCertificateResponse, err error) {
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = revokeCertificate(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// FetchRevokedCertificate fetches the revoked certificate with the given
+// serial number
+func (ds *SQLPlugin) FetchRevokedCertificate(ctx context.Context, req *datastore.FetchRevokedCertificateRequest) (resp *datastore.FetchRevokedCertificateResponse, err error) {
+	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = fetchRevokedCertificate(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListRevokedCertificates lists all revoked certificates
+func (ds *SQLPlugin) ListRevokedCertificates(ctx context.Context, req *datastore.ListRevokedCertificatesRequest) (resp *datastore.ListRevokedCertificatesResponse, err error) {
+	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = listRevokedCertificates(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PruneRevokedCertificates deletes all revoked certificates which expire
+// before the date in the request
+func (ds *SQLPlugin) PruneRevokedCertificates(ctx context.Context, req *datastore.PruneRevokedCertificatesRequest) (resp *datastore.PruneRevokedCertificatesResponse, err error) {
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = pruneRevokedCertificates(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CreateDownstreamCA records a downstream CA issued through an agent
+func (ds *SQLPlugin) CreateDownstreamCA(ctx context.Context, req *datastore.CreateDownstreamCARequest) (resp *datastore.CreateDownstreamCAResponse, err error) {
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = createDownstreamCA(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListDownstreamCAs lists downstream CAs, optionally filtered by the agent
+// they were issued through
+func (ds *SQLPlugin) ListDownstreamCAs(ctx context.Context, req *datastore.ListDownstreamCAsRequest) (resp *datastore.ListDownstreamCAsResponse, err error) {
+	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = listDownstreamCAs(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PruneDownstreamCAs deletes all downstream CAs which expire before the date
+// in the request
+func (ds *SQLPlugin) PruneDownstreamCAs(ctx context.Context, req *datastore.PruneDownstreamCAsRequest) (resp *datastore.PruneDownstreamCAsResponse, err error) {
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = pruneDownstreamCAs(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CreateIssuedSVID records an SVID signed by the server
+func (ds *SQLPlugin) CreateIssuedSVID(ctx context.Context, req *datastore.CreateIssuedSVIDRequest) (resp *datastore.CreateIssuedSVIDResponse, err error) {
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = createIssuedSVID(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListIssuedSVIDs lists the records of SVIDs signed by the server,
+// optionally filtered and paginated
+func (ds *SQLPlugin) ListIssuedSVIDs(ctx context.Context, req *datastore.ListIssuedSVIDsRequest) (resp *datastore.ListIssuedSVIDsResponse, err error) {
+	if err = ds.withReadTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = listIssuedSVIDs(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PruneIssuedSVIDs deletes the records of SVIDs which expire before the date
+// in the request
+func (ds *SQLPlugin) PruneIssuedSVIDs(ctx context.Context, req *datastore.PruneIssuedSVIDsRequest) (resp *datastore.PruneIssuedSVIDsResponse, err error) {
+	if err = ds.withWriteTx(ctx, func(tx *gorm.DB) (err error) {
+		resp, err = pruneIssuedSVIDs(tx, req)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // Configure parses HCL config payload into config struct, and opens new DB based on the result
 func (ds *SQLPlugin) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
 	config := &configuration{}
@@ -558,10 +883,17 @@ func createBundle(tx *gorm.DB, req *datastore.CreateBundleRequest) (*datastore.C
 		return nil, err
 	}
 
+	model.Revision, err = nextRevision(tx)
+	if err != nil {
+		return nil, err
+	}
+
 	if err := tx.Create(model).Error; err != nil {
 		return nil, sqlError.Wrap(err)
 	}
 
+	req.Bundle.RevisionNumber = model.Revision
+
 	return &datastore.CreateBundleResponse{
 		Bundle: req.Bundle,
 	}, nil
@@ -579,10 +911,15 @@ func updateBundle(tx *gorm.DB, req *datastore.UpdateBundleRequest) (*datastore.U
 	}
 
 	model.Data = newModel.Data
+	model.Revision, err = nextRevision(tx)
+	if err != nil {
+		return nil, err
+	}
 	if err := tx.Save(model).Error; err != nil {
 		return nil, sqlError.Wrap(err)
 	}
 
+	req.Bundle.RevisionNumber = model.Revision
 	return &datastore.UpdateBundleResponse{
 		Bundle: req.Bundle,
 	}, nil
@@ -652,9 +989,14 @@ func appendBundle(tx *gorm.DB, req *datastore.AppendBundleRequest) (*datastore.A
 			return nil, err
 		}
 		model.Data = newModel.Data
+		model.Revision, err = nextRevision(tx)
+		if err != nil {
+			return nil, err
+		}
 		if err := tx.Save(model).Error; err != nil {
 			return nil, sqlError.Wrap(err)
 		}
+		bundle.RevisionNumber = model.Revision
 	}
 
 	return &datastore.AppendBundleResponse{
@@ -676,8 +1018,28 @@ func deleteBundle(tx *gorm.DB, req *datastore.DeleteBundleRequest) (*datastore.D
 	}
 
 	if entriesCount > 0 {
+		revision, err := nextRevision(tx)
+		if err != nil {
+			return nil, err
+		}
+
 		switch req.Mode {
 		case datastore.DeleteBundleRequest_DELETE:
+			var entryIDs []string
+			if err := tx.Table("registered_entries").
+				Joins("INNER JOIN federated_registration_entries ON federated_registration_entries.registered_entry_id = registered_entries.id").
+				Where("federated_registration_entries.bundle_id = ?", model.ID).
+				Pluck("registered_entries.entry_id", &entryIDs).Error; err != nil {
+				return nil, sqlError.Wrap(err)
+			}
+			for _, entryID := range entryIDs {
+				if err := createRegistrationEntryTombstone(tx, entryID, revision); err != nil {
+					return nil, err
+				}
+				if err := createRegistrationEntryEvent(tx, entryID, datastore.RegistrationEntryEvent_DELETE); err != nil {
+					return nil, err
+				}
+			}
 			// TODO: figure out how to do this gracefully with GORM.
 			if err := tx.Exec(bindVars(tx, `DELETE FROM registered_entries WHERE id in (
 				SELECT
@@ -693,6 +1055,29 @@ func deleteBundle(tx *gorm.DB, req *datastore.DeleteBundleRequest) (*datastore.D
 				return nil, sqlError.Wrap(err)
 			}
 		case datastore.DeleteBundleRequest_DISSOCIATE:
+			// The entries no longer federate with the bundle, so they change
+			// along with it.
+			var entryIDs []string
+			if err := tx.Table("registered_entries").
+				Joins("INNER JOIN federated_registration_entries ON federated_registration_entries.registered_entry_id = registered_entries.id").
+				Where("federated_registration_entries.bundle_id = ?", model.ID).
+				Pluck("registered_entries.entry_id", &entryIDs).Error; err != nil {
+				return nil, sqlError.Wrap(err)
+			}
+			for _, entryID := range entryIDs {
+				if err := createRegistrationEntryEvent(tx, entryID, datastore.RegistrationEntryEvent_UPDATE); err != nil {
+					return nil, err
+				}
+			}
+			if err := tx.Exec(bindVars(tx, `UPDATE registered_entries SET revision = ? WHERE id in (
+				SELECT
+					registered_entry_id
+				FROM
+					federated_registration_entries
+				WHERE
+					bundle_id = ?)`), revision, model.ID).Error; err != nil {
+				return nil, sqlError.Wrap(err)
+			}
 			if err := entriesAssociation.Clear().Error; err != nil {
 				return nil, sqlError.Wrap(err)
 			}
@@ -793,6 +1178,7 @@ func createAttestedNode(tx *gorm.DB, req *datastore.CreateAttestedNodeRequest) (
 		DataType:     req.Node.AttestationDataType,
 		SerialNumber: req.Node.CertSerialNumber,
 		ExpiresAt:    time.Unix(req.Node.CertNotAfter, 0),
+		Banned:       req.Node.Banned,
 	}
 
 	if err := tx.Create(&model).Error; err != nil {
@@ -821,20 +1207,22 @@ func fetchAttestedNode(tx *gorm.DB, req *datastore.FetchAttestedNodeRequest) (*d
 func listAttestedNodes(tx *gorm.DB, req *datastore.ListAttestedNodesRequest) (*datastore.ListAttestedNodesResponse, error) {
 	p := req.Pagination
 	var err error
+	nodeTx := tx
 	if p != nil && p.PageSize > 0 {
-		tx, err = applyPagination(p, tx)
+		nodeTx, err = applyPagination(p, nodeTx)
 
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	if req.ByExpiresBefore != nil {
-		tx = tx.Where("expires_at < ?", time.Unix(req.ByExpiresBefore.Value, 0))
+	nodeTx, err = filterAttestedNodes(nodeTx, req)
+	if err != nil {
+		return nil, err
 	}
 
 	var models []AttestedNode
-	if err := tx.Find(&models).Error; err != nil {
+	if err := nodeTx.Find(&models).Error; err != nil {
 		return nil, sqlError.Wrap(err)
 	}
 
@@ -851,21 +1239,80 @@ func listAttestedNodes(tx *gorm.DB, req *datastore.ListAttestedNodesRequest) (*d
 	for _, model := range models {
 		resp.Nodes = append(resp.Nodes, modelToAttestedNode(model))
 	}
+
+	if req.FetchSelectors {
+		if err := fetchAttestedNodesSelectors(tx, resp.Nodes); err != nil {
+			return nil, err
+		}
+	}
 	return resp, nil
 }
 
-func updateAttestedNode(tx *gorm.DB, req *datastore.UpdateAttestedNodeRequest) (*datastore.UpdateAttestedNodeResponse, error) {
-	var model AttestedNode
-	if err := tx.Find(&model, "spiffe_id = ?", req.SpiffeId).Error; err != nil {
-		return nil, sqlError.Wrap(err)
+// filterAttestedNodes narrows down the attested nodes to those matching every
+// filter set on the request
+func filterAttestedNodes(tx *gorm.DB, req *datastore.ListAttestedNodesRequest) (*gorm.DB, error) {
+	if req.ByExpiresBefore != nil {
+		tx = tx.Where("expires_at < ?", time.Unix(req.ByExpiresBefore.Value, 0))
+	}
+	if req.ByExpiresAfter != nil {
+		tx = tx.Where("expires_at >= ?", time.Unix(req.ByExpiresAfter.Value, 0))
+	}
+	if req.ByAttestationType != nil {
+		tx = tx.Where("data_type = ?", req.ByAttestationType.Value)
+	}
+	if req.ByBanned != nil {
+		tx = tx.Where("banned = ?", req.ByBanned.Value)
+	}
+	if req.BySelectorMatch != nil && len(req.BySelectorMatch.Selectors) > 0 {
+		query, args, err := selectorMatchQuery("node_resolver_map_entries", "spiffe_id", req.BySelectorMatch)
+		if err != nil {
+			return nil, err
+		}
+		tx = tx.Where("spiffe_id IN ("+query+")", args...)
 	}
+	return tx, nil
+}
 
-	updates := AttestedNode{
-		SerialNumber: req.CertSerialNumber,
-		ExpiresAt:    time.Unix(req.CertNotAfter, 0),
+// fetchAttestedNodesSelectors populates the node selectors of the nodes
+func fetchAttestedNodesSelectors(tx *gorm.DB, nodes []*common.AttestedNode) error {
+	if len(nodes) == 0 {
+		return nil
 	}
 
-	if err := tx.Model(&model).Updates(updates).Error; err != nil {
+	nodesByID := make(map[string]*common.AttestedNode, len(nodes))
+	spiffeIDs := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		nodesByID[node.SpiffeId] = node
+		spiffeIDs = append(spiffeIDs, node.SpiffeId)
+	}
+
+	var models []NodeSelector
+	if err := tx.Where("spiffe_id IN (?)", spiffeIDs).Order("id").Find(&models).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+
+	for _, model := range models {
+		node := nodesByID[model.SpiffeID]
+		node.Selectors = append(node.Selectors, &common.Selector{
+			Type:  model.Type,
+			Value: model.Value,
+		})
+	}
+	return nil
+}
+
+func updateAttestedNode(tx *gorm.DB, req *datastore.UpdateAttestedNodeRequest) (*datastore.UpdateAttestedNodeResponse, error) {
+	var model AttestedNode
+	if err := tx.Find(&model, "spiffe_id = ?", req.SpiffeId).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	updates := AttestedNode{
+		SerialNumber: req.CertSerialNumber,
+		ExpiresAt:    time.Unix(req.CertNotAfter, 0),
+	}
+
+	if err := tx.Model(&model).Updates(updates).Error; err != nil {
 		return nil, sqlError.Wrap(err)
 	}
 
@@ -874,6 +1321,23 @@ func updateAttestedNode(tx *gorm.DB, req *datastore.UpdateAttestedNodeRequest) (
 	}, nil
 }
 
+func setAttestedNodeBanned(tx *gorm.DB, req *datastore.SetAttestedNodeBannedRequest) (*datastore.SetAttestedNodeBannedResponse, error) {
+	var model AttestedNode
+	if err := tx.Find(&model, "spiffe_id = ?", req.SpiffeId).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	// Updates() ignores zero values, so update the column explicitly to
+	// support unbanning.
+	if err := tx.Model(&model).Update("banned", req.Banned).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.SetAttestedNodeBannedResponse{
+		Node: modelToAttestedNode(model),
+	}, nil
+}
+
 func deleteAttestedNode(tx *gorm.DB, req *datastore.DeleteAttestedNodeRequest) (*datastore.DeleteAttestedNodeResponse, error) {
 	var model AttestedNode
 	if err := tx.Find(&model, "spiffe_id = ?", req.SpiffeId).Error; err != nil {
@@ -890,6 +1354,14 @@ func deleteAttestedNode(tx *gorm.DB, req *datastore.DeleteAttestedNodeRequest) (
 }
 
 func setNodeSelectors(tx *gorm.DB, req *datastore.SetNodeSelectorsRequest) (*datastore.SetNodeSelectorsResponse, error) {
+	// Node selectors are not part of the datastore revision, otherwise every
+	// attestation would look like a change to the registration entries.
+	// The change is recorded as an event instead. The revision row is locked
+	// so that events are committed in ID order.
+	if err := lockRevision(tx); err != nil {
+		return nil, err
+	}
+
 	if err := tx.Delete(NodeSelector{}, "spiffe_id = ?", req.Selectors.SpiffeId).Error; err != nil {
 		return nil, sqlError.Wrap(err)
 	}
@@ -905,6 +1377,10 @@ func setNodeSelectors(tx *gorm.DB, req *datastore.SetNodeSelectorsRequest) (*dat
 		}
 	}
 
+	if err := recordNodeSelectorsEvent(tx, req.Selectors.SpiffeId); err != nil {
+		return nil, err
+	}
+
 	return &datastore.SetNodeSelectorsResponse{}, nil
 }
 
@@ -929,6 +1405,77 @@ func getNodeSelectors(tx *gorm.DB, req *datastore.GetNodeSelectorsRequest) (*dat
 	}, nil
 }
 
+func listNodeSelectors(tx *gorm.DB, req *datastore.ListNodeSelectorsRequest) (*datastore.ListNodeSelectorsResponse, error) {
+	var latest NodeSelectorsEvent
+	if err := tx.Order("id DESC").First(&latest).Error; err != nil && err != gorm.ErrRecordNotFound {
+		return nil, sqlError.Wrap(err)
+	}
+	resp := &datastore.ListNodeSelectorsResponse{
+		LatestEventId: int64(latest.ID),
+	}
+
+	query := tx.Order("spiffe_id, id")
+	nodes := make(map[string]*datastore.NodeSelectors)
+	if req.AfterEventId != nil {
+		var events []NodeSelectorsEvent
+		if err := tx.Where("id > ?", req.AfterEventId.Value).Order("spiffe_id").Find(&events).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+		if len(events) == 0 {
+			return resp, nil
+		}
+		// Nodes left without selectors are listed too, so the change is not
+		// missed.
+		for _, event := range events {
+			if _, ok := nodes[event.SpiffeID]; ok {
+				continue
+			}
+			nodes[event.SpiffeID] = &datastore.NodeSelectors{
+				SpiffeId: event.SpiffeID,
+			}
+			resp.Selectors = append(resp.Selectors, nodes[event.SpiffeID])
+		}
+		query = query.Where("spiffe_id IN (SELECT spiffe_id FROM node_selectors_events WHERE id > ?)", req.AfterEventId.Value)
+	}
+
+	var models []NodeSelector
+	if err := query.Find(&models).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	for _, model := range models {
+		current, ok := nodes[model.SpiffeID]
+		if !ok {
+			current = &datastore.NodeSelectors{
+				SpiffeId: model.SpiffeID,
+			}
+			nodes[model.SpiffeID] = current
+			resp.Selectors = append(resp.Selectors, current)
+		}
+		current.Selectors = append(current.Selectors, &common.Selector{
+			Type:  model.Type,
+			Value: model.Value,
+		})
+	}
+	return resp, nil
+}
+
+// recordNodeSelectorsEvent records that the selectors of the node were set.
+// The new event is created before the previous ones of the node are deleted,
+// so the latest event ID is never handed out again.
+func recordNodeSelectorsEvent(tx *gorm.DB, spiffeID string) error {
+	event := &NodeSelectorsEvent{
+		SpiffeID: spiffeID,
+	}
+	if err := tx.Create(event).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	if err := tx.Where("spiffe_id = ? AND id < ?", spiffeID, event.ID).Delete(&NodeSelectorsEvent{}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
 func createRegistrationEntry(tx *gorm.DB,
 	req *datastore.CreateRegistrationEntryRequest) (*datastore.CreateRegistrationEntryResponse, error) {
 
@@ -937,20 +1484,43 @@ func createRegistrationEntry(tx *gorm.DB,
 		return nil, err
 	}
 
+	x509SVIDTemplate, err := marshalX509SVIDTemplate(req.Entry.X509SvidTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	jwtSVIDClaims, err := marshalJWTSVIDClaims(req.Entry.JwtSvidClaims)
+	if err != nil {
+		return nil, err
+	}
+
+	revision, err := nextRevision(tx)
+	if err != nil {
+		return nil, err
+	}
+
 	newRegisteredEntry := RegisteredEntry{
-		EntryID:    entryID,
-		SpiffeID:   req.Entry.SpiffeId,
-		ParentID:   req.Entry.ParentId,
-		TTL:        req.Entry.Ttl,
-		Admin:      req.Entry.Admin,
-		Downstream: req.Entry.Downstream,
-		Expiry:     req.Entry.EntryExpiry,
+		EntryID:          entryID,
+		SpiffeID:         req.Entry.SpiffeId,
+		ParentID:         req.Entry.ParentId,
+		TTL:              req.Entry.Ttl,
+		Admin:            req.Entry.Admin,
+		Downstream:       req.Entry.Downstream,
+		Expiry:           req.Entry.EntryExpiry,
+		X509SVIDTemplate: x509SVIDTemplate,
+		JWTSVIDTTL:       req.Entry.JwtSvidTtl,
+		JWTSVIDClaims:    jwtSVIDClaims,
+		Revision:         revision,
 	}
 
 	if err := tx.Create(&newRegisteredEntry).Error; err != nil {
 		return nil, sqlError.Wrap(err)
 	}
 
+	if err := createRegistrationEntryEvent(tx, entryID, datastore.RegistrationEntryEvent_CREATE); err != nil {
+		return nil, err
+	}
+
 	federatesWith, err := makeFederatesWith(tx, req.Entry.FederatesWith)
 	if err != nil {
 		return nil, err
@@ -1016,112 +1586,112 @@ func fetchRegistrationEntry(tx *gorm.DB,
 
 func listRegistrationEntries(tx *gorm.DB,
 	req *datastore.ListRegistrationEntriesRequest) (*datastore.ListRegistrationEntriesResponse, error) {
-	var p *datastore.Pagination
-	var err error
+	entryTx, err := filterRegistrationEntries(tx, req)
+	if err != nil {
+		return nil, err
+	}
 
-	// list of selector sets to match against
-	var selectorsList [][]*common.Selector
-	if req.BySelectors != nil && len(req.BySelectors.Selectors) > 0 {
-		selectorSet := selector.NewSetFromRaw(req.BySelectors.Selectors)
-		switch req.BySelectors.Match {
-		case datastore.BySelectors_MATCH_SUBSET:
-			for combination := range selectorSet.Power() {
-				selectorsList = append(selectorsList, combination.Raw())
-			}
-		case datastore.BySelectors_MATCH_EXACT:
-			selectorsList = append(selectorsList, selectorSet.Raw())
-		default:
-			return nil, fmt.Errorf("unhandled match behavior %q", req.BySelectors.Match)
-		}
+	entries, p, err := findRegisteredEntries(entryTx, req.Pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	respEntries, err := modelsToEntries(tx, entries)
+	if err != nil {
+		return nil, sqlError.Wrap(err)
 	}
 
-	// filter registration entries
-	entryTx := tx
+	return &datastore.ListRegistrationEntriesResponse{
+		Entries:    respEntries,
+		Pagination: p,
+	}, nil
+}
+
+// filterRegistrationEntries narrows down the registered entries to those
+// matching every filter set on the request. The filters are applied within a
+// single query so that pagination works over the combined result.
+func filterRegistrationEntries(tx *gorm.DB, req *datastore.ListRegistrationEntriesRequest) (*gorm.DB, error) {
 	if req.ByParentId != nil {
-		entryTx = entryTx.Where("parent_id = ?", req.ByParentId.Value)
+		tx = tx.Where("parent_id = ?", req.ByParentId.Value)
 	}
 	if req.BySpiffeId != nil {
-		entryTx = entryTx.Where("spiffe_id = ?", req.BySpiffeId.Value)
+		tx = tx.Where("spiffe_id = ?", req.BySpiffeId.Value)
 	}
-
-	if len(selectorsList) == 0 {
-		// no selectors to filter against.
-		var entries []RegisteredEntry
-		entries, p, err = findRegisteredEntries(entryTx, req.Pagination)
-		if err != nil {
-			return nil, sqlError.Wrap(err)
-		}
-
-		respEntries, err := modelsToEntries(tx, entries)
-		if err != nil {
-			return nil, sqlError.Wrap(err)
-		}
-
-		return &datastore.ListRegistrationEntriesResponse{
-			Entries:    respEntries,
-			Pagination: p,
-		}, nil
+	if req.BySpiffeIdPrefix != nil {
+		tx = tx.Where("spiffe_id LIKE ? ESCAPE '!'", escapeLike(req.BySpiffeIdPrefix.Value)+"%")
+	}
+	if req.ByAdmin != nil {
+		tx = tx.Where("admin = ?", req.ByAdmin.Value)
+	}
+	if req.ByDownstream != nil {
+		tx = tx.Where("downstream = ?", req.ByDownstream.Value)
 	}
 
-	modelsSet := make(map[uint]RegisteredEntry)
-	for _, selectors := range selectorsList {
-		refCount := make(map[uint]int)
-		for _, s := range selectors {
-			var results []Selector
-			if err := tx.Find(&results, "type = ? AND value = ?", s.Type, s.Value).Error; err != nil {
-				return nil, sqlError.Wrap(err)
-			}
-
-			for _, r := range results {
-				refCount[r.RegisteredEntryID]++
-			}
-		}
-
-		// exclude entry ids that don't have an exact number of selectors
-		entryIDs := make([]uint, 0, len(refCount))
-		for id, count := range refCount {
-			if count == len(selectors) {
-				entryIDs = append(entryIDs, id)
-			}
-		}
-		if len(entryIDs) == 0 {
-			continue
-		}
-
-		// fetch the entries in the id set, filtered by any parent/spiffe id filters
-		// applied globally
-		db := entryTx.Where(entryIDs)
-		var models []RegisteredEntry
-		models, p, err = findRegisteredEntries(db, req.Pagination)
+	if req.BySelectors != nil && len(req.BySelectors.Selectors) > 0 {
+		query, args, err := selectorMatchQuery("selectors", "registered_entry_id", req.BySelectors)
 		if err != nil {
 			return nil, err
 		}
-
-		for _, model := range models {
-			var count int
-			if err := tx.Model(&Selector{}).Where("registered_entry_id = ?", model.ID).Count(&count).Error; err != nil {
-				return nil, sqlError.Wrap(err)
-			}
-			if count == len(selectors) {
-				modelsSet[model.ID] = model
-			}
-		}
-	}
-
-	models := make([]RegisteredEntry, 0, len(modelsSet))
-	for _, model := range modelsSet {
-		models = append(models, model)
+		tx = tx.Where("id IN ("+query+")", args...)
+	}
+
+	if len(req.ByFederatesWith) > 0 {
+		tx = tx.Where("id IN (SELECT federated_registration_entries.registered_entry_id FROM federated_registration_entries "+
+			"INNER JOIN bundles ON bundles.id = federated_registration_entries.bundle_id "+
+			"WHERE bundles.trust_domain IN (?))", req.ByFederatesWith)
+	}
+
+	return tx, nil
+}
+
+// selectorMatchQuery returns a query selecting the owner IDs (i.e. the
+// ownerColumn) from a table of selectors, limited to the owners whose
+// selectors match, along with the query arguments. The selectors are looked
+// up by type and value first so that only the owners having at least one of
+// the requested selectors are grouped, instead of the whole table.
+func selectorMatchQuery(table, ownerColumn string, by *datastore.BySelectors) (string, []interface{}, error) {
+	selectors := selector.NewSetFromRaw(by.Selectors).Raw()
+
+	var conds []string
+	var matchArgs []interface{}
+	for _, s := range selectors {
+		conds = append(conds, "(type = ? AND value = ?)")
+		matchArgs = append(matchArgs, s.Type, s.Value)
+	}
+	matches := strings.Join(conds, " OR ")
+
+	// owners having all of the requested selectors, and possibly others
+	superset := fmt.Sprintf("SELECT %s FROM %s WHERE %s GROUP BY %s HAVING COUNT(*) = ?", ownerColumn, table, matches, ownerColumn)
+	supersetArgs := append(append([]interface{}{}, matchArgs...), len(selectors))
+
+	var query string
+	var args []interface{}
+	switch by.Match {
+	case datastore.BySelectors_MATCH_SUBSET:
+		// every selector of the owner is one of the requested selectors,
+		// among the owners having at least one of them
+		query = fmt.Sprintf("SELECT %s FROM %s WHERE %s IN (SELECT %s FROM %s WHERE %s) GROUP BY %s HAVING SUM(CASE WHEN %s THEN 0 ELSE 1 END) = 0",
+			ownerColumn, table, ownerColumn, ownerColumn, table, matches, ownerColumn, matches)
+		args = append(append(args, matchArgs...), matchArgs...)
+	case datastore.BySelectors_MATCH_SUPERSET:
+		query = superset
+		args = supersetArgs
+	case datastore.BySelectors_MATCH_EXACT:
+		// the owner has all of the requested selectors and no others
+		query = fmt.Sprintf("SELECT %s FROM %s WHERE %s IN (%s) GROUP BY %s HAVING COUNT(*) = ?",
+			ownerColumn, table, ownerColumn, superset, ownerColumn)
+		args = append(supersetArgs, len(selectors))
+	default:
+		return "", nil, fmt.Errorf("unhandled match behavior %q", by.Match)
 	}
 
-	entries, err := modelsToEntries(tx, models)
-	if err != nil {
-		return nil, sqlError.Wrap(err)
-	}
+	return query, args, nil
+}
 
-	return &datastore.ListRegistrationEntriesResponse{
-		Entries:    entries,
-		Pagination: p,
-	}, nil
+// escapeLike escapes the LIKE wildcards in s, using "!" as the escape
+// character since backslashes are treated differently by each database.
+func escapeLike(s string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(s)
 }
 
 // applyPagination  add order limit and token to current query
@@ -1180,6 +1750,25 @@ func updateRegistrationEntry(tx *gorm.DB,
 		return nil, sqlError.Wrap(err)
 	}
 
+	revision, err := nextRevision(tx)
+	if err != nil {
+		return nil, err
+	}
+	if err := bumpRegistrationEntryRevision(tx, entry, req.Entry.RevisionNumber, revision); err != nil {
+		return nil, err
+	}
+
+	if req.Mask != nil {
+		current, err := modelToEntry(tx, entry)
+		if err != nil {
+			return nil, err
+		}
+		req.Entry = util.ApplyRegistrationEntryMask(current, req.Entry, req.Mask)
+		if err := validateRegistrationEntry(req.Entry); err != nil {
+			return nil, err
+		}
+	}
+
 	// Delete existing selectors - we will write new ones
 	if err := tx.Exec("DELETE FROM selectors WHERE registered_entry_id = ?", entry.ID).Error; err != nil {
 		return nil, sqlError.Wrap(err)
@@ -1209,6 +1798,16 @@ func updateRegistrationEntry(tx *gorm.DB,
 		dnsList = append(dnsList, dns)
 	}
 
+	x509SVIDTemplate, err := marshalX509SVIDTemplate(req.Entry.X509SvidTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	jwtSVIDClaims, err := marshalJWTSVIDClaims(req.Entry.JwtSvidClaims)
+	if err != nil {
+		return nil, err
+	}
+
 	entry.SpiffeID = req.Entry.SpiffeId
 	entry.ParentID = req.Entry.ParentId
 	entry.TTL = req.Entry.Ttl
@@ -1217,10 +1816,18 @@ func updateRegistrationEntry(tx *gorm.DB,
 	entry.Downstream = req.Entry.Downstream
 	entry.Expiry = req.Entry.EntryExpiry
 	entry.DNSList = dnsList
+	entry.X509SVIDTemplate = x509SVIDTemplate
+	entry.JWTSVIDTTL = req.Entry.JwtSvidTtl
+	entry.JWTSVIDClaims = jwtSVIDClaims
+	entry.Revision = revision
 	if err := tx.Save(&entry).Error; err != nil {
 		return nil, sqlError.Wrap(err)
 	}
 
+	if err := createRegistrationEntryEvent(tx, entry.EntryID, datastore.RegistrationEntryEvent_UPDATE); err != nil {
+		return nil, err
+	}
+
 	federatesWith, err := makeFederatesWith(tx, req.Entry.FederatesWith)
 	if err != nil {
 		return nil, err
@@ -1231,11 +1838,37 @@ func updateRegistrationEntry(tx *gorm.DB,
 	}
 
 	req.Entry.EntryId = entry.EntryID
+	req.Entry.RevisionNumber = entry.Revision
 	return &datastore.UpdateRegistrationEntryResponse{
 		Entry: req.Entry,
 	}, nil
 }
 
+// bumpRegistrationEntryRevision sets the revision of the entry. If the
+// caller expects a revision, the entry is only updated if its stored revision
+// still matches, so that concurrent updates of the same entry cannot both
+// succeed.
+func bumpRegistrationEntryRevision(tx *gorm.DB, entry RegisteredEntry, expected, revision int64) error {
+	if expected == 0 {
+		return nil
+	}
+
+	if entry.Revision != expected {
+		return status.Errorf(codes.FailedPrecondition, "entry %q revision mismatch: expected %d, got %d", entry.EntryID, expected, entry.Revision)
+	}
+
+	result := tx.Model(&RegisteredEntry{}).
+		Where("id = ? AND revision = ?", entry.ID, expected).
+		UpdateColumn("revision", revision)
+	if err := result.Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	if result.RowsAffected == 0 {
+		return status.Errorf(codes.FailedPrecondition, "entry %q revision mismatch: expected %d", entry.EntryID, expected)
+	}
+	return nil
+}
+
 func deleteRegistrationEntry(tx *gorm.DB,
 	req *datastore.DeleteRegistrationEntryRequest) (*datastore.DeleteRegistrationEntryResponse, error) {
 
@@ -1249,7 +1882,12 @@ func deleteRegistrationEntry(tx *gorm.DB,
 		return nil, err
 	}
 
-	err = deleteRegistrationEntrySupport(tx, entry)
+	revision, err := nextRevision(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = deleteRegistrationEntrySupport(tx, entry, revision)
 	if err != nil {
 		return nil, err
 	}
@@ -1259,7 +1897,7 @@ func deleteRegistrationEntry(tx *gorm.DB,
 	}, nil
 }
 
-func deleteRegistrationEntrySupport(tx *gorm.DB, entry RegisteredEntry) error {
+func deleteRegistrationEntrySupport(tx *gorm.DB, entry RegisteredEntry, revision int64) error {
 	if err := tx.Model(&entry).Association("FederatesWith").Clear().Error; err != nil {
 		return err
 	}
@@ -1268,65 +1906,567 @@ func deleteRegistrationEntrySupport(tx *gorm.DB, entry RegisteredEntry) error {
 		return sqlError.Wrap(err)
 	}
 
+	if err := createRegistrationEntryEvent(tx, entry.EntryID, datastore.RegistrationEntryEvent_DELETE); err != nil {
+		return err
+	}
+
+	return createRegistrationEntryTombstone(tx, entry.EntryID, revision)
+}
+
+func createRegistrationEntryTombstone(tx *gorm.DB, entryID string, revision int64) error {
+	if err := tx.Create(&RegisteredEntryTombstone{
+		EntryID:  entryID,
+		Revision: revision,
+	}).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
 	return nil
 }
 
-func pruneRegistrationEntries(tx *gorm.DB, req *datastore.PruneRegistrationEntriesRequest) (*datastore.PruneRegistrationEntriesResponse, error) {
-	var registrationEntries []RegisteredEntry
-	if err := tx.Where("expiry != 0").Where("expiry < ?", req.ExpiresBefore).Find(&registrationEntries).Error; err != nil {
-		return nil, err
+func createRegistrationEntryEvent(tx *gorm.DB, entryID string, eventType datastore.RegistrationEntryEvent_Type) error {
+	if err := tx.Create(&RegisteredEntryEvent{
+		EntryID: entryID,
+		Type:    int32(eventType),
+	}).Error; err != nil {
+		return sqlError.Wrap(err)
 	}
+	return nil
+}
 
-	for _, entry := range registrationEntries {
-		if err := deleteRegistrationEntrySupport(tx, entry); err != nil {
+func batchCreateRegistrationEntries(tx *gorm.DB,
+	req *datastore.BatchCreateRegistrationEntriesRequest) (*datastore.BatchCreateRegistrationEntriesResponse, error) {
+
+	// Check every entry before creating any of them so an all-or-nothing
+	// batch never has to be rolled back, and so that entries that can't be
+	// created fail on their own instead of failing the whole batch
+	results := newBatchResults(len(req.Entries))
+	for i, entry := range req.Entries {
+		if err := validateRegistrationEntry(entry); err != nil {
+			setBatchResultError(results[i], codes.InvalidArgument, err.Error())
+			continue
+		}
+
+		ok, err := checkFederatedBundles(tx, results[i], entry.FederatesWith)
+		if err != nil {
 			return nil, err
 		}
-	}
+		if !ok {
+			continue
+		}
 
-	return &datastore.PruneRegistrationEntriesResponse{}, nil
-}
+		exists, err := registrationEntryExists(tx, entry)
+		if err != nil {
+			return nil, err
+		}
+		for j := 0; j < i && !exists; j++ {
+			exists = results[j].Code == int32(codes.OK) && sameRegistrationEntry(entry, req.Entries[j])
+		}
+		if exists {
+			setBatchResultError(results[i], codes.AlreadyExists, "entry already exists")
+		}
+	}
 
-func createJoinToken(tx *gorm.DB, req *datastore.CreateJoinTokenRequest) (*datastore.CreateJoinTokenResponse, error) {
-	t := JoinToken{
-		Token:  req.JoinToken.Token,
-		Expiry: req.JoinToken.Expiry,
+	resp := &datastore.BatchCreateRegistrationEntriesResponse{
+		Results: results,
+	}
+	if abortBatch(results, req.AllOrNothing) {
+		return resp, nil
 	}
 
-	if err := tx.Create(&t).Error; err != nil {
-		return nil, sqlError.Wrap(err)
+	for i, entry := range req.Entries {
+		if results[i].Code != int32(codes.OK) {
+			continue
+		}
+		created, err := createRegistrationEntry(tx, &datastore.CreateRegistrationEntryRequest{Entry: entry})
+		if err != nil {
+			return nil, err
+		}
+		results[i].Entry = created.Entry
 	}
 
-	return &datastore.CreateJoinTokenResponse{
-		JoinToken: req.JoinToken,
-	}, nil
+	return resp, nil
 }
 
-func fetchJoinToken(tx *gorm.DB, req *datastore.FetchJoinTokenRequest) (*datastore.FetchJoinTokenResponse, error) {
-	var model JoinToken
-	err := tx.Find(&model, "token = ?", req.Token).Error
-	if err == gorm.ErrRecordNotFound {
-		return &datastore.FetchJoinTokenResponse{}, nil
-	} else if err != nil {
-		return nil, sqlError.Wrap(err)
-	}
+func batchUpdateRegistrationEntries(tx *gorm.DB,
+	req *datastore.BatchUpdateRegistrationEntriesRequest) (*datastore.BatchUpdateRegistrationEntriesResponse, error) {
 
-	return &datastore.FetchJoinTokenResponse{
-		JoinToken: modelToJoinToken(model),
-	}, nil
-}
+	results := newBatchResults(len(req.Entries))
+	updated := make(map[string]bool)
+	for i, entry := range req.Entries {
+		if err := validateRegistrationEntry(entry); err != nil {
+			setBatchResultError(results[i], codes.InvalidArgument, err.Error())
+			continue
+		}
 
-func deleteJoinToken(tx *gorm.DB, req *datastore.DeleteJoinTokenRequest) (*datastore.DeleteJoinTokenResponse, error) {
-	var model JoinToken
-	if err := tx.Find(&model, "token = ?", req.Token).Error; err != nil {
-		return nil, sqlError.Wrap(err)
+		// An earlier update in the batch bumps the revision of the entry
+		if updated[entry.EntryId] && entry.RevisionNumber != 0 {
+			setBatchResultError(results[i], codes.FailedPrecondition, fmt.Sprintf("entry %q revision mismatch: updated earlier in the batch", entry.EntryId))
+			continue
+		}
+		updated[entry.EntryId] = true
+
+		model := RegisteredEntry{}
+		err := tx.Find(&model, "entry_id = ?", entry.EntryId).Error
+		switch {
+		case err == gorm.ErrRecordNotFound:
+			setBatchResultError(results[i], codes.NotFound, "no such registration entry")
+		case err != nil:
+			return nil, sqlError.Wrap(err)
+		case entry.RevisionNumber != 0 && entry.RevisionNumber != model.Revision:
+			setBatchResultError(results[i], codes.FailedPrecondition, fmt.Sprintf("entry %q revision mismatch: expected %d, got %d", model.EntryID, entry.RevisionNumber, model.Revision))
+		default:
+			if _, err := checkFederatedBundles(tx, results[i], entry.FederatesWith); err != nil {
+				return nil, err
+			}
+		}
 	}
 
-	if err := tx.Delete(&model).Error; err != nil {
-		return nil, sqlError.Wrap(err)
+	resp := &datastore.BatchUpdateRegistrationEntriesResponse{
+		Results: results,
+	}
+	if abortBatch(results, req.AllOrNothing) {
+		return resp, nil
+	}
+
+	for i, entry := range req.Entries {
+		if results[i].Code != int32(codes.OK) {
+			continue
+		}
+		updated, err := updateRegistrationEntry(tx, &datastore.UpdateRegistrationEntryRequest{
+			Entry: proto.Clone(entry).(*common.RegistrationEntry),
+		})
+		if err != nil {
+			return nil, err
+		}
+		results[i].Entry = updated.Entry
+	}
+
+	return resp, nil
+}
+
+func batchDeleteRegistrationEntries(tx *gorm.DB,
+	req *datastore.BatchDeleteRegistrationEntriesRequest) (*datastore.BatchDeleteRegistrationEntriesResponse, error) {
+
+	results := newBatchResults(len(req.EntryIds))
+	deleted := make(map[string]bool)
+	for i, entryID := range req.EntryIds {
+		if entryID == "" {
+			setBatchResultError(results[i], codes.InvalidArgument, "missing registration entry id")
+			continue
+		}
+
+		// An entry listed twice can only be deleted once
+		err := tx.Find(&RegisteredEntry{}, "entry_id = ?", entryID).Error
+		switch {
+		case err == gorm.ErrRecordNotFound || deleted[entryID]:
+			setBatchResultError(results[i], codes.NotFound, "no such registration entry")
+		case err != nil:
+			return nil, sqlError.Wrap(err)
+		default:
+			deleted[entryID] = true
+		}
+	}
+
+	resp := &datastore.BatchDeleteRegistrationEntriesResponse{
+		Results: results,
+	}
+	if abortBatch(results, req.AllOrNothing) {
+		return resp, nil
+	}
+
+	for i, entryID := range req.EntryIds {
+		if results[i].Code != int32(codes.OK) {
+			continue
+		}
+		deletedEntry, err := deleteRegistrationEntry(tx, &datastore.DeleteRegistrationEntryRequest{EntryId: entryID})
+		if err != nil {
+			return nil, err
+		}
+		results[i].Entry = deletedEntry.Entry
+	}
+
+	return resp, nil
+}
+
+// registrationEntryExists returns true if an entry with the same SPIFFE ID,
+// parent ID and selectors as the given entry is already stored
+func registrationEntryExists(tx *gorm.DB, entry *common.RegistrationEntry) (bool, error) {
+	resp, err := listRegistrationEntries(tx, &datastore.ListRegistrationEntriesRequest{
+		ByParentId: &wrappers.StringValue{
+			Value: entry.ParentId,
+		},
+		BySpiffeId: &wrappers.StringValue{
+			Value: entry.SpiffeId,
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for _, existing := range resp.Entries {
+		if sameRegistrationEntry(entry, existing) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func sameRegistrationEntry(a, b *common.RegistrationEntry) bool {
+	return a.SpiffeId == b.SpiffeId &&
+		a.ParentId == b.ParentId &&
+		selector.NewSetFromRaw(a.Selectors).Equal(selector.NewSetFromRaw(b.Selectors))
+}
+
+func newBatchResults(n int) []*datastore.BatchRegistrationEntryResult {
+	results := make([]*datastore.BatchRegistrationEntryResult, 0, n)
+	for i := 0; i < n; i++ {
+		results = append(results, &datastore.BatchRegistrationEntryResult{})
+	}
+	return results
+}
+
+func setBatchResultError(result *datastore.BatchRegistrationEntryResult, code codes.Code, message string) {
+	result.Code = int32(code)
+	result.Message = message
+}
+
+// abortBatch marks the results of the operations that would have succeeded
+// as aborted if the batch is all-or-nothing and any operation failed. It
+// returns true if the batch was aborted.
+func abortBatch(results []*datastore.BatchRegistrationEntryResult, allOrNothing bool) bool {
+	if !allOrNothing {
+		return false
+	}
+
+	failed := false
+	for _, result := range results {
+		if result.Code != int32(codes.OK) {
+			failed = true
+			break
+		}
+	}
+	if !failed {
+		return false
+	}
+
+	for _, result := range results {
+		if result.Code == int32(codes.OK) {
+			setBatchResultError(result, codes.Aborted, "batch aborted because another operation failed")
+		}
+	}
+	return true
+}
+
+func pruneRegistrationEntries(tx *gorm.DB, req *datastore.PruneRegistrationEntriesRequest) (*datastore.PruneRegistrationEntriesResponse, error) {
+	var registrationEntries []RegisteredEntry
+	if err := tx.Where("expiry != 0").Where("expiry < ?", req.ExpiresBefore).Find(&registrationEntries).Error; err != nil {
+		return nil, err
+	}
+
+	if len(registrationEntries) == 0 {
+		return &datastore.PruneRegistrationEntriesResponse{}, nil
+	}
+
+	revision, err := nextRevision(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range registrationEntries {
+		if err := deleteRegistrationEntrySupport(tx, entry, revision); err != nil {
+			return nil, err
+		}
+	}
+
+	return &datastore.PruneRegistrationEntriesResponse{}, nil
+}
+
+func listRegistrationEntryTombstones(tx *gorm.DB, req *datastore.ListRegistrationEntryTombstonesRequest) (*datastore.ListRegistrationEntryTombstonesResponse, error) {
+	revision, err := currentRevision(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	var tombstones []RegisteredEntryTombstone
+	if err := tx.Where("revision > ?", req.AfterRevision).Order("revision").Find(&tombstones).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	resp := &datastore.ListRegistrationEntryTombstonesResponse{
+		Revision: revision,
+	}
+	for _, tombstone := range tombstones {
+		resp.EntryIds = append(resp.EntryIds, tombstone.EntryID)
+	}
+	return resp, nil
+}
+
+func pruneRegistrationEntryTombstones(tx *gorm.DB, req *datastore.PruneRegistrationEntryTombstonesRequest) (*datastore.PruneRegistrationEntryTombstonesResponse, error) {
+	if err := tx.Where("created_at < ?", time.Unix(req.DeletedBefore, 0)).Delete(&RegisteredEntryTombstone{}).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.PruneRegistrationEntryTombstonesResponse{}, nil
+}
+
+func listRegistrationEntryEvents(tx *gorm.DB, req *datastore.ListRegistrationEntryEventsRequest) (*datastore.ListRegistrationEntryEventsResponse, error) {
+	var bounds struct {
+		OldestID int64
+		LatestID int64
+	}
+	if err := tx.Model(&RegisteredEntryEvent{}).
+		Select("COALESCE(MIN(id), 0) AS oldest_id, COALESCE(MAX(id), 0) AS latest_id").
+		Scan(&bounds).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	query := tx.Where("id > ?", req.AfterId).Order("id")
+	if req.Limit > 0 {
+		query = query.Limit(req.Limit)
+	}
+
+	var events []RegisteredEntryEvent
+	if err := query.Find(&events).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	prunedID, err := fetchRegistrationEntryEventsWatermark(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &datastore.ListRegistrationEntryEventsResponse{
+		OldestId: bounds.OldestID,
+		LatestId: bounds.LatestID,
+		PrunedId: prunedID,
+	}
+	for _, event := range events {
+		resp.Events = append(resp.Events, &datastore.RegistrationEntryEvent{
+			Id:        int64(event.ID),
+			Type:      datastore.RegistrationEntryEvent_Type(event.Type),
+			EntryId:   event.EntryID,
+			CreatedAt: event.CreatedAt.Unix(),
+		})
+	}
+	return resp, nil
+}
+
+func pruneRegistrationEntryEvents(tx *gorm.DB, req *datastore.PruneRegistrationEntryEventsRequest) (*datastore.PruneRegistrationEntryEventsResponse, error) {
+	// The latest event is always kept. Some databases hand out the ID of the
+	// last row again once it is deleted, which would let an event reuse the
+	// ID a watcher has already seen.
+	var latest RegisteredEntryEvent
+	switch err := tx.Order("id DESC").First(&latest).Error; {
+	case err == gorm.ErrRecordNotFound:
+		return &datastore.PruneRegistrationEntryEventsResponse{}, nil
+	case err != nil:
+		return nil, sqlError.Wrap(err)
+	}
+
+	prune := tx.Model(&RegisteredEntryEvent{}).Where("created_at < ? AND id < ?", time.Unix(req.CreatedBefore, 0), latest.ID)
+
+	var pruned struct {
+		PrunedID int64
+	}
+	if err := prune.Select("COALESCE(MAX(id), 0) AS pruned_id").Scan(&pruned).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	if pruned.PrunedID == 0 {
+		return &datastore.PruneRegistrationEntryEventsResponse{}, nil
+	}
+
+	if err := prune.Delete(&RegisteredEntryEvent{}).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	// Record the latest event pruned, so that watchers can tell whether they
+	// missed any events
+	watermark := RegisteredEntryEventsWatermark{}
+	if err := tx.FirstOrInit(&watermark).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	if pruned.PrunedID > watermark.PrunedID {
+		watermark.PrunedID = pruned.PrunedID
+		if err := tx.Save(&watermark).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+	}
+
+	return &datastore.PruneRegistrationEntryEventsResponse{}, nil
+}
+
+// fetchRegistrationEntryEventsWatermark returns the ID of the latest
+// registration entry event pruned, or zero if none have been
+func fetchRegistrationEntryEventsWatermark(tx *gorm.DB) (int64, error) {
+	var watermark struct {
+		PrunedID int64
+	}
+	if err := tx.Model(&RegisteredEntryEventsWatermark{}).
+		Select("COALESCE(MAX(pruned_id), 0) AS pruned_id").
+		Scan(&watermark).Error; err != nil {
+		return 0, sqlError.Wrap(err)
+	}
+	return watermark.PrunedID, nil
+}
+
+// nextRevision increments the revision of the datastore and returns it. The
+// update locks the revision row until the transaction ends, which serializes
+// changes to entries and bundles so they are committed in revision order.
+func nextRevision(tx *gorm.DB) (int64, error) {
+	if err := tx.Model(&Revision{}).UpdateColumn("value", gorm.Expr("value + 1")).Error; err != nil {
+		return 0, sqlError.Wrap(err)
+	}
+	return currentRevision(tx)
+}
+
+// lockRevision locks the revision row until the transaction ends without
+// changing the revision.
+func lockRevision(tx *gorm.DB) error {
+	if err := tx.Model(&Revision{}).UpdateColumn("value", gorm.Expr("value")).Error; err != nil {
+		return sqlError.Wrap(err)
+	}
+	return nil
+}
+
+func currentRevision(tx *gorm.DB) (int64, error) {
+	revision := new(Revision)
+	if err := tx.First(revision).Error; err != nil {
+		return 0, sqlError.Wrap(err)
+	}
+	return revision.Value, nil
+}
+
+func createJoinToken(tx *gorm.DB, req *datastore.CreateJoinTokenRequest) (*datastore.CreateJoinTokenResponse, error) {
+	nodeSelectors, err := marshalJoinTokenNodeSelectors(req.JoinToken.NodeSelectors)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := marshalJoinTokenEntries(req.JoinToken.Entries)
+	if err != nil {
+		return nil, err
+	}
+
+	t := JoinToken{
+		Token:         req.JoinToken.Token,
+		Expiry:        req.JoinToken.Expiry,
+		MaxUses:       req.JoinToken.MaxUses,
+		NodeSelectors: nodeSelectors,
+		Entries:       entries,
+	}
+
+	if err := tx.Create(&t).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.CreateJoinTokenResponse{
+		JoinToken: req.JoinToken,
+	}, nil
+}
+
+func fetchJoinToken(tx *gorm.DB, req *datastore.FetchJoinTokenRequest) (*datastore.FetchJoinTokenResponse, error) {
+	var model JoinToken
+	err := tx.Find(&model, "token = ?", req.Token).Error
+	if err == gorm.ErrRecordNotFound {
+		return &datastore.FetchJoinTokenResponse{}, nil
+	} else if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	joinToken, err := modelToJoinToken(model)
+	if err != nil {
+		return nil, err
+	}
+
+	return &datastore.FetchJoinTokenResponse{
+		JoinToken: joinToken,
+	}, nil
+}
+
+func listJoinTokens(tx *gorm.DB, req *datastore.ListJoinTokensRequest) (*datastore.ListJoinTokensResponse, error) {
+	var models []JoinToken
+	if err := tx.Order("expiry").Find(&models).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	resp := &datastore.ListJoinTokensResponse{}
+	for _, model := range models {
+		joinToken, err := modelToJoinToken(model)
+		if err != nil {
+			return nil, err
+		}
+		resp.JoinTokens = append(resp.JoinTokens, joinToken)
+	}
+
+	return resp, nil
+}
+
+func consumeJoinToken(tx *gorm.DB, req *datastore.ConsumeJoinTokenRequest) (*datastore.ConsumeJoinTokenResponse, error) {
+	// Count the use in a single statement, only while uses are left, so that
+	// concurrent attestations can't use the token more than allowed. A max
+	// uses of zero means a single use.
+	result := tx.Model(&JoinToken{}).
+		Where("token = ? AND uses < CASE WHEN max_uses > 0 THEN max_uses ELSE 1 END", req.Token).
+		UpdateColumn("uses", gorm.Expr("uses + 1"))
+	if result.Error != nil {
+		return nil, sqlError.Wrap(result.Error)
+	}
+	if result.RowsAffected == 0 {
+		// no such token, or no uses left
+		return &datastore.ConsumeJoinTokenResponse{}, nil
+	}
+
+	var model JoinToken
+	if err := tx.Find(&model, "token = ?", req.Token).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	if model.Uses >= model.MaxUses {
+		if err := tx.Delete(&model).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+	}
+
+	joinToken, err := modelToJoinToken(model)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &datastore.ConsumeJoinTokenResponse{
+		JoinToken: joinToken,
+	}
+	for _, entry := range joinToken.Entries {
+		entry = proto.Clone(entry).(*common.RegistrationEntry)
+		entry.ParentId = req.AgentId
+		createResp, err := createRegistrationEntry(tx, &datastore.CreateRegistrationEntryRequest{
+			Entry: entry,
+		})
+		if err != nil {
+			return nil, err
+		}
+		resp.Entries = append(resp.Entries, createResp.Entry)
+	}
+
+	return resp, nil
+}
+
+func deleteJoinToken(tx *gorm.DB, req *datastore.DeleteJoinTokenRequest) (*datastore.DeleteJoinTokenResponse, error) {
+	var model JoinToken
+	if err := tx.Find(&model, "token = ?", req.Token).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	if err := tx.Delete(&model).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	joinToken, err := modelToJoinToken(model)
+	if err != nil {
+		return nil, err
 	}
 
 	return &datastore.DeleteJoinTokenResponse{
-		JoinToken: modelToJoinToken(model),
+		JoinToken: joinToken,
 	}, nil
 }
 
@@ -1338,6 +2478,360 @@ func pruneJoinTokens(tx *gorm.DB, req *datastore.PruneJoinTokensRequest) (*datas
 	return &datastore.PruneJoinTokensResponse{}, nil
 }
 
+func fetchCAJournal(tx *gorm.DB, req *datastore.FetchCAJournalRequest) (*datastore.FetchCAJournalResponse, error) {
+	var model CAJournal
+	err := tx.Find(&model, "journal_id = ?", req.Id).Error
+	if err == gorm.ErrRecordNotFound {
+		return &datastore.FetchCAJournalResponse{}, nil
+	} else if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.FetchCAJournalResponse{
+		Journal: modelToCAJournal(model),
+	}, nil
+}
+
+// createCAJournal creates the journal. If another server created it
+// concurrently, the unique index on the journal ID fails the insert, which is
+// reported as a revision mismatch so that the caller reloads the journal.
+func createCAJournal(tx *gorm.DB, journal *datastore.CAJournal) (CAJournal, error) {
+	model := CAJournal{
+		JournalID: journal.Id,
+		Data:      journal.Data,
+		Revision:  1,
+	}
+	if err := tx.Create(&model).Error; err != nil {
+		if isUniqueViolation(err) {
+			return CAJournal{}, status.Errorf(codes.Aborted, "journal %q revision mismatch: expected %d, created concurrently", journal.Id, journal.Revision)
+		}
+		return CAJournal{}, sqlError.Wrap(err)
+	}
+	return model, nil
+}
+
+// isUniqueViolation returns true if the error is a unique constraint
+// violation reported by any of the supported databases
+func isUniqueViolation(err error) bool {
+	return isSQLiteUniqueViolation(err) || isMySQLUniqueViolation(err) || isPostgresUniqueViolation(err)
+}
+
+func setCAJournal(tx *gorm.DB, req *datastore.SetCAJournalRequest) (*datastore.SetCAJournalResponse, error) {
+	journal := req.Journal
+	if journal == nil {
+		return nil, sqlError.New("invalid request: missing journal")
+	}
+	if journal.Id == "" {
+		return nil, sqlError.New("invalid request: missing journal id")
+	}
+
+	var model CAJournal
+	err := tx.Find(&model, "journal_id = ?", journal.Id).Error
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		if journal.Revision != 0 {
+			return nil, status.Errorf(codes.Aborted, "journal %q revision mismatch: expected %d, got none", journal.Id, journal.Revision)
+		}
+		model, err = createCAJournal(tx, journal)
+		if err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, sqlError.Wrap(err)
+	default:
+		if model.Revision != journal.Revision {
+			return nil, status.Errorf(codes.Aborted, "journal %q revision mismatch: expected %d, got %d", journal.Id, journal.Revision, model.Revision)
+		}
+		// The update is conditioned on the revision so that a concurrent
+		// writer that raced past the check above still loses.
+		result := tx.Model(&CAJournal{}).
+			Where("id = ? AND revision = ?", model.ID, journal.Revision).
+			Updates(map[string]interface{}{
+				"data":     journal.Data,
+				"revision": journal.Revision + 1,
+			})
+		if err := result.Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+		if result.RowsAffected != 1 {
+			return nil, status.Errorf(codes.Aborted, "journal %q revision mismatch: expected %d", journal.Id, journal.Revision)
+		}
+		model.Data = journal.Data
+		model.Revision = journal.Revision + 1
+	}
+
+	return &datastore.SetCAJournalResponse{
+		Journal: modelToCAJournal(model),
+	}, nil
+}
+
+func acquireLease(tx *gorm.DB, req *datastore.AcquireLeaseRequest) (*datastore.AcquireLeaseResponse, error) {
+	if req.Name == "" {
+		return nil, sqlError.New("invalid request: missing lease name")
+	}
+	if req.HolderId == "" {
+		return nil, sqlError.New("invalid request: missing lease holder id")
+	}
+
+	var model Lease
+	err := tx.Find(&model, "name = ?", req.Name).Error
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		model = Lease{
+			Name:      req.Name,
+			HolderID:  req.HolderId,
+			ExpiresAt: req.ExpiresAt,
+		}
+		if err := tx.Create(&model).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+	case err != nil:
+		return nil, sqlError.Wrap(err)
+	case model.HolderID != req.HolderId && model.ExpiresAt > req.Now:
+		// Held by someone else. Return the current lease so the caller
+		// knows who holds it.
+	default:
+		// The update is conditioned on the lease not having changed since
+		// it was read so that only one of several concurrent acquirers of
+		// an expired lease wins.
+		result := tx.Model(&Lease{}).
+			Where("id = ? AND holder_id = ? AND expires_at = ?", model.ID, model.HolderID, model.ExpiresAt).
+			Updates(map[string]interface{}{
+				"holder_id":  req.HolderId,
+				"expires_at": req.ExpiresAt,
+			})
+		if err := result.Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+		if result.RowsAffected != 1 {
+			if err := tx.Find(&model, "name = ?", req.Name).Error; err != nil {
+				return nil, sqlError.Wrap(err)
+			}
+			break
+		}
+		model.HolderID = req.HolderId
+		model.ExpiresAt = req.ExpiresAt
+	}
+
+	return &datastore.AcquireLeaseResponse{
+		Lease: modelToLease(model),
+	}, nil
+}
+
+func releaseLease(tx *gorm.DB, req *datastore.ReleaseLeaseRequest) (*datastore.ReleaseLeaseResponse, error) {
+	var model Lease
+	err := tx.Find(&model, "name = ? AND holder_id = ?", req.Name, req.HolderId).Error
+	if err == gorm.ErrRecordNotFound {
+		return &datastore.ReleaseLeaseResponse{}, nil
+	} else if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	if err := tx.Delete(&model).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.ReleaseLeaseResponse{
+		Lease: modelToLease(model),
+	}, nil
+}
+
+func revokeCertificate(tx *gorm.DB, req *datastore.RevokeCertificateRequest) (*datastore.RevokeCertificateResponse, error) {
+	revoked := req.RevokedCertificate
+	if revoked == nil {
+		return nil, sqlError.New("invalid request: missing revoked certificate")
+	}
+	if revoked.SerialNumber == "" {
+		return nil, sqlError.New("invalid request: missing serial number")
+	}
+
+	var model RevokedCertificate
+	err := tx.Find(&model, "serial_number = ?", revoked.SerialNumber).Error
+	switch {
+	case err == gorm.ErrRecordNotFound:
+		model = RevokedCertificate{
+			SerialNumber: revoked.SerialNumber,
+			SpiffeID:     revoked.SpiffeId,
+			ExpiresAt:    revoked.ExpiresAt,
+			RevokedAt:    revoked.RevokedAt,
+		}
+		if err := tx.Create(&model).Error; err != nil {
+			return nil, sqlError.Wrap(err)
+		}
+	case err != nil:
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.RevokeCertificateResponse{
+		RevokedCertificate: modelToRevokedCertificate(model),
+	}, nil
+}
+
+func fetchRevokedCertificate(tx *gorm.DB, req *datastore.FetchRevokedCertificateRequest) (*datastore.FetchRevokedCertificateResponse, error) {
+	var model RevokedCertificate
+	err := tx.Find(&model, "serial_number = ?", req.SerialNumber).Error
+	if err == gorm.ErrRecordNotFound {
+		return &datastore.FetchRevokedCertificateResponse{}, nil
+	} else if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.FetchRevokedCertificateResponse{
+		RevokedCertificate: modelToRevokedCertificate(model),
+	}, nil
+}
+
+func listRevokedCertificates(tx *gorm.DB, req *datastore.ListRevokedCertificatesRequest) (*datastore.ListRevokedCertificatesResponse, error) {
+	var models []RevokedCertificate
+	if err := tx.Order("id").Find(&models).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	resp := new(datastore.ListRevokedCertificatesResponse)
+	for _, model := range models {
+		resp.RevokedCertificates = append(resp.RevokedCertificates, modelToRevokedCertificate(model))
+	}
+	return resp, nil
+}
+
+func pruneRevokedCertificates(tx *gorm.DB, req *datastore.PruneRevokedCertificatesRequest) (*datastore.PruneRevokedCertificatesResponse, error) {
+	if err := tx.Where("expires_at < ?", req.ExpiresBefore).Delete(&RevokedCertificate{}).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.PruneRevokedCertificatesResponse{}, nil
+}
+
+func createDownstreamCA(tx *gorm.DB, req *datastore.CreateDownstreamCARequest) (*datastore.CreateDownstreamCAResponse, error) {
+	downstreamCA := req.DownstreamCa
+	if downstreamCA == nil {
+		return nil, sqlError.New("invalid request: missing downstream CA")
+	}
+	if downstreamCA.SerialNumber == "" {
+		return nil, sqlError.New("invalid request: missing serial number")
+	}
+
+	model := DownstreamCA{
+		SerialNumber: downstreamCA.SerialNumber,
+		SpiffeID:     downstreamCA.SpiffeId,
+		AgentID:      downstreamCA.AgentId,
+		ExpiresAt:    downstreamCA.ExpiresAt,
+	}
+	if err := tx.Create(&model).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.CreateDownstreamCAResponse{
+		DownstreamCa: modelToDownstreamCA(model),
+	}, nil
+}
+
+func listDownstreamCAs(tx *gorm.DB, req *datastore.ListDownstreamCAsRequest) (*datastore.ListDownstreamCAsResponse, error) {
+	if req.AgentId != "" {
+		tx = tx.Where("agent_id = ?", req.AgentId)
+	}
+
+	var models []DownstreamCA
+	if err := tx.Order("id").Find(&models).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	resp := new(datastore.ListDownstreamCAsResponse)
+	for _, model := range models {
+		resp.DownstreamCas = append(resp.DownstreamCas, modelToDownstreamCA(model))
+	}
+	return resp, nil
+}
+
+func pruneDownstreamCAs(tx *gorm.DB, req *datastore.PruneDownstreamCAsRequest) (*datastore.PruneDownstreamCAsResponse, error) {
+	if err := tx.Where("expires_at < ?", req.ExpiresBefore).Delete(&DownstreamCA{}).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.PruneDownstreamCAsResponse{}, nil
+}
+
+func createIssuedSVID(tx *gorm.DB, req *datastore.CreateIssuedSVIDRequest) (*datastore.CreateIssuedSVIDResponse, error) {
+	issuedSVID := req.IssuedSvid
+	if issuedSVID == nil {
+		return nil, sqlError.New("invalid request: missing issued SVID")
+	}
+	if issuedSVID.Id == "" {
+		return nil, sqlError.New("invalid request: missing SVID ID")
+	}
+
+	model := IssuedSVID{
+		SVIDID:      issuedSVID.Id,
+		Type:        int32(issuedSVID.Type),
+		SpiffeID:    issuedSVID.SpiffeId,
+		EntryID:     issuedSVID.EntryId,
+		AgentID:     issuedSVID.AgentId,
+		AuthorityID: issuedSVID.AuthorityId,
+		NotBefore:   issuedSVID.NotBefore,
+		NotAfter:    issuedSVID.NotAfter,
+	}
+	if err := tx.Create(&model).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.CreateIssuedSVIDResponse{
+		IssuedSvid: modelToIssuedSVID(model),
+	}, nil
+}
+
+func listIssuedSVIDs(tx *gorm.DB, req *datastore.ListIssuedSVIDsRequest) (*datastore.ListIssuedSVIDsResponse, error) {
+	p := req.Pagination
+	var err error
+	if p != nil && p.PageSize > 0 {
+		tx, err = applyPagination(p, tx)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		tx = tx.Order("id")
+	}
+
+	if req.BySpiffeId != nil {
+		tx = tx.Where("spiffe_id = ?", req.BySpiffeId.Value)
+	}
+	if req.ByAgentId != nil {
+		tx = tx.Where("agent_id = ?", req.ByAgentId.Value)
+	}
+	if req.IssuedAfter != 0 {
+		tx = tx.Where("not_before >= ?", req.IssuedAfter)
+	}
+	if req.IssuedBefore != 0 {
+		tx = tx.Where("not_before < ?", req.IssuedBefore)
+	}
+
+	var models []IssuedSVID
+	if err := tx.Find(&models).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	if p != nil && p.PageSize > 0 && len(models) > 0 {
+		lastEntry := models[len(models)-1]
+		p.Token = fmt.Sprint(lastEntry.ID)
+	}
+
+	resp := &datastore.ListIssuedSVIDsResponse{
+		IssuedSvids: make([]*datastore.IssuedSVID, 0, len(models)),
+		Pagination:  p,
+	}
+	for _, model := range models {
+		resp.IssuedSvids = append(resp.IssuedSvids, modelToIssuedSVID(model))
+	}
+	return resp, nil
+}
+
+func pruneIssuedSVIDs(tx *gorm.DB, req *datastore.PruneIssuedSVIDsRequest) (*datastore.PruneIssuedSVIDsResponse, error) {
+	if err := tx.Where("not_after < ?", req.ExpiresBefore).Delete(&IssuedSVID{}).Error; err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+
+	return &datastore.PruneIssuedSVIDsResponse{}, nil
+}
+
 // modelToBundle converts the given bundle model to a Protobuf bundle message. It will also
 // include any embedded CACert models.
 func modelToBundle(model *Bundle) (*common.Bundle, error) {
@@ -1345,6 +2839,7 @@ func modelToBundle(model *Bundle) (*common.Bundle, error) {
 	if err := proto.Unmarshal(model.Data, bundle); err != nil {
 		return nil, sqlError.Wrap(err)
 	}
+	bundle.RevisionNumber = model.Revision
 
 	return bundle, nil
 }
@@ -1380,6 +2875,12 @@ func bundleToModel(pb *common.Bundle) (*Bundle, error) {
 		return nil, sqlError.Wrap(err)
 	}
 
+	// The revision is kept in its own column
+	if pb.RevisionNumber != 0 {
+		pb = proto.Clone(pb).(*common.Bundle)
+		pb.RevisionNumber = 0
+	}
+
 	data, err := proto.Marshal(pb)
 	if err != nil {
 		return nil, sqlError.Wrap(err)
@@ -1442,25 +2943,83 @@ func modelToEntry(tx *gorm.DB, model RegisteredEntry) (*common.RegistrationEntry
 		return nil, sqlError.Wrap(err)
 	}
 
+	x509SVIDTemplate, err := unmarshalX509SVIDTemplate(model.X509SVIDTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	jwtSVIDClaims, err := unmarshalJWTSVIDClaims(model.JWTSVIDClaims)
+	if err != nil {
+		return nil, err
+	}
+
 	var federatesWith []string
 	for _, bundle := range fetchedBundles {
 		federatesWith = append(federatesWith, bundle.TrustDomain)
 	}
 
 	return &common.RegistrationEntry{
-		EntryId:       model.EntryID,
-		Selectors:     selectors,
-		SpiffeId:      model.SpiffeID,
-		ParentId:      model.ParentID,
-		Ttl:           model.TTL,
-		FederatesWith: federatesWith,
-		Admin:         model.Admin,
-		Downstream:    model.Downstream,
-		EntryExpiry:   model.Expiry,
-		DnsNames:      dnsList,
+		EntryId:          model.EntryID,
+		Selectors:        selectors,
+		SpiffeId:         model.SpiffeID,
+		ParentId:         model.ParentID,
+		Ttl:              model.TTL,
+		FederatesWith:    federatesWith,
+		Admin:            model.Admin,
+		Downstream:       model.Downstream,
+		EntryExpiry:      model.Expiry,
+		DnsNames:         dnsList,
+		X509SvidTemplate: x509SVIDTemplate,
+		JwtSvidTtl:       model.JWTSVIDTTL,
+		JwtSvidClaims:    jwtSVIDClaims,
+		RevisionNumber:   model.Revision,
 	}, nil
 }
 
+func marshalX509SVIDTemplate(template *common.X509SVIDTemplate) ([]byte, error) {
+	if template == nil {
+		return nil, nil
+	}
+	data, err := proto.Marshal(template)
+	if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	return data, nil
+}
+
+func unmarshalX509SVIDTemplate(data []byte) (*common.X509SVIDTemplate, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	template := new(common.X509SVIDTemplate)
+	if err := proto.Unmarshal(data, template); err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	return template, nil
+}
+
+func marshalJWTSVIDClaims(claims map[string]string) ([]byte, error) {
+	if len(claims) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(claims)
+	if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	return data, nil
+}
+
+func unmarshalJWTSVIDClaims(data []byte) (map[string]string, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var claims map[string]string
+	if err := json.Unmarshal(data, &claims); err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	return claims, nil
+}
+
 func newRegistrationEntryID() (string, error) {
 	u, err := uuid.NewV4()
 	if err != nil {
@@ -1475,14 +3034,147 @@ func modelToAttestedNode(model AttestedNode) *common.AttestedNode {
 		AttestationDataType: model.DataType,
 		CertSerialNumber:    model.SerialNumber,
 		CertNotAfter:        model.ExpiresAt.Unix(),
+		Banned:              model.Banned,
+	}
+}
+
+func modelToCAJournal(model CAJournal) *datastore.CAJournal {
+	return &datastore.CAJournal{
+		Id:       model.JournalID,
+		Data:     model.Data,
+		Revision: model.Revision,
+	}
+}
+
+func modelToLease(model Lease) *datastore.Lease {
+	return &datastore.Lease{
+		Name:      model.Name,
+		HolderId:  model.HolderID,
+		ExpiresAt: model.ExpiresAt,
+	}
+}
+
+func modelToRevokedCertificate(model RevokedCertificate) *datastore.RevokedCertificate {
+	return &datastore.RevokedCertificate{
+		SerialNumber: model.SerialNumber,
+		SpiffeId:     model.SpiffeID,
+		ExpiresAt:    model.ExpiresAt,
+		RevokedAt:    model.RevokedAt,
+	}
+}
+
+func modelToDownstreamCA(model DownstreamCA) *datastore.DownstreamCA {
+	return &datastore.DownstreamCA{
+		SerialNumber: model.SerialNumber,
+		SpiffeId:     model.SpiffeID,
+		AgentId:      model.AgentID,
+		ExpiresAt:    model.ExpiresAt,
+	}
+}
+
+func modelToIssuedSVID(model IssuedSVID) *datastore.IssuedSVID {
+	return &datastore.IssuedSVID{
+		Id:          model.SVIDID,
+		Type:        datastore.IssuedSVID_Type(model.Type),
+		SpiffeId:    model.SpiffeID,
+		EntryId:     model.EntryID,
+		AgentId:     model.AgentID,
+		AuthorityId: model.AuthorityID,
+		NotBefore:   model.NotBefore,
+		NotAfter:    model.NotAfter,
 	}
 }
 
-func modelToJoinToken(model JoinToken) *datastore.JoinToken {
+func modelToJoinToken(model JoinToken) (*datastore.JoinToken, error) {
+	nodeSelectors, err := unmarshalJoinTokenNodeSelectors(model.NodeSelectors)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := unmarshalJoinTokenEntries(model.Entries)
+	if err != nil {
+		return nil, err
+	}
+
 	return &datastore.JoinToken{
-		Token:  model.Token,
-		Expiry: model.Expiry,
+		Token:         model.Token,
+		Expiry:        model.Expiry,
+		MaxUses:       model.MaxUses,
+		Uses:          model.Uses,
+		NodeSelectors: nodeSelectors,
+		Entries:       entries,
+	}, nil
+}
+
+func marshalJoinTokenNodeSelectors(selectors []*common.Selector) ([]byte, error) {
+	if len(selectors) == 0 {
+		return nil, nil
 	}
+	data, err := proto.Marshal(&common.Selectors{Entries: selectors})
+	if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	return data, nil
+}
+
+func unmarshalJoinTokenNodeSelectors(data []byte) ([]*common.Selector, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	selectors := new(common.Selectors)
+	if err := proto.Unmarshal(data, selectors); err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	return selectors.Entries, nil
+}
+
+func marshalJoinTokenEntries(entries []*common.RegistrationEntry) ([]byte, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	data, err := proto.Marshal(&common.RegistrationEntries{Entries: entries})
+	if err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	return data, nil
+}
+
+func unmarshalJoinTokenEntries(data []byte) ([]*common.RegistrationEntry, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	entries := new(common.RegistrationEntries)
+	if err := proto.Unmarshal(data, entries); err != nil {
+		return nil, sqlError.Wrap(err)
+	}
+	return entries.Entries, nil
+}
+
+// checkFederatedBundles makes sure there is a bundle for each of the trust
+// domains a batched entry federates with, which would otherwise fail the
+// whole batch when the entry is written. If a bundle is missing, the result
+// of the entry is set accordingly and false is returned.
+func checkFederatedBundles(tx *gorm.DB, result *datastore.BatchRegistrationEntryResult, ids []string) (bool, error) {
+	if len(ids) == 0 {
+		return true, nil
+	}
+
+	var trustDomains []string
+	if err := tx.Model(&Bundle{}).Where("trust_domain in (?)", ids).Pluck("trust_domain", &trustDomains).Error; err != nil {
+		return false, sqlError.Wrap(err)
+	}
+
+	found := make(map[string]bool)
+	for _, trustDomain := range trustDomains {
+		found[trustDomain] = true
+	}
+	for _, id := range ids {
+		if !found[id] {
+			setBatchResultError(result, codes.FailedPrecondition, fmt.Sprintf("unable to find federated bundle %q", id))
+			return false, nil
+		}
+	}
+	return true, nil
 }
 
 func makeFederatesWith(tx *gorm.DB, ids []string) ([]*Bundle, error) {
@@ -1555,6 +3247,11 @@ func (cfg *configuration) Validate() error {
 // Unknown.
 func gormToGRPCStatus(err error) error {
 	cause := errs.Unwrap(err)
+	if _, ok := status.FromError(cause); ok {
+		// already a gRPC status (e.g. a revision conflict); pass it along
+		return cause
+	}
+
 	code := codes.Unknown
 	switch {
 	case gorm.IsRecordNotFoundError(cause):
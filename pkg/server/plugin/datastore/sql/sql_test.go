@@ -12,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/spiffe/spire/pkg/common/bundleutil"
 	"github.com/spiffe/spire/pkg/common/util"
@@ -58,6 +59,11 @@ func (s *PluginSuite) SetupTest() {
 }
 
 func (s *PluginSuite) newPlugin() datastore.Plugin {
+	_, ds := s.newSQLPlugin()
+	return ds
+}
+
+func (s *PluginSuite) newSQLPlugin() (*SQLPlugin, datastore.Plugin) {
 	p := New()
 
 	var ds datastore.Plugin
@@ -89,7 +95,7 @@ func (s *PluginSuite) newPlugin() datastore.Plugin {
 	p.db.Raw("PRAGMA foreign_keys").Scan(&fk)
 	s.Require().Equal(fk.ForeignKeys, "1")
 
-	return ds
+	return p, ds
 }
 
 func (s *PluginSuite) TestInvalidPluginConfiguration() {
@@ -130,10 +136,12 @@ func (s *PluginSuite) TestBundleCRUD() {
 	s.RequireGRPCStatus(err, codes.NotFound, "datastore-sql: record not found")
 
 	// create
-	_, err = s.ds.CreateBundle(ctx, &datastore.CreateBundleRequest{
+	cresp, err := s.ds.CreateBundle(ctx, &datastore.CreateBundleRequest{
 		Bundle: bundle,
 	})
 	s.Require().NoError(err)
+	bundle.RevisionNumber = 1
+	s.AssertProtoEqual(bundle, cresp.Bundle)
 
 	// fetch
 	fresp, err = s.ds.FetchBundle(ctx, &datastore.FetchBundleRequest{TrustDomainId: "spiffe://foo"})
@@ -149,6 +157,7 @@ func (s *PluginSuite) TestBundleCRUD() {
 	bundle2 := bundleutil.BundleProtoFromRootCA(bundle.TrustDomainId, s.cacert)
 	appendedBundle := bundleutil.BundleProtoFromRootCAs(bundle.TrustDomainId,
 		[]*x509.Certificate{s.cert, s.cacert})
+	appendedBundle.RevisionNumber = 2
 
 	// append
 	aresp, err := s.ds.AppendBundle(ctx, &datastore.AppendBundleRequest{
@@ -172,6 +181,7 @@ func (s *PluginSuite) TestBundleCRUD() {
 		Bundle: bundle3,
 	})
 	s.Require().NoError(err)
+	bundle3.RevisionNumber = 3
 	s.AssertProtoEqual(bundle3, anresp.Bundle)
 
 	// update
@@ -179,6 +189,7 @@ func (s *PluginSuite) TestBundleCRUD() {
 		Bundle: bundle2,
 	})
 	s.Require().NoError(err)
+	bundle2.RevisionNumber = 4
 	s.AssertProtoEqual(bundle2, uresp.Bundle)
 
 	lresp, err = s.ds.ListBundles(ctx, &datastore.ListBundlesRequest{})
@@ -214,6 +225,7 @@ func (s *PluginSuite) TestSetBundle() {
 		Bundle: bundle,
 	})
 	s.Require().NoError(err)
+	bundle.RevisionNumber = 1
 	s.RequireProtoEqual(bundle, s.fetchBundle("spiffe://foo"))
 
 	// set the bundle and make sure it is updated
@@ -221,6 +233,7 @@ func (s *PluginSuite) TestSetBundle() {
 		Bundle: bundle2,
 	})
 	s.Require().NoError(err)
+	bundle2.RevisionNumber = 2
 	s.RequireProtoEqual(bundle2, s.fetchBundle("spiffe://foo"))
 }
 
@@ -278,6 +291,7 @@ func (s *PluginSuite) TestBundlePrune() {
 	// Fetch and verify pruned bundle is the expected
 	expectedPrunedBundle := bundleutil.BundleProtoFromRootCAs("spiffe://foo", []*x509.Certificate{s.cert})
 	expectedPrunedBundle.JwtSigningKeys = []*common.PublicKey{{NotAfter: nonExpiredKeyTime.Unix()}}
+	expectedPrunedBundle.RevisionNumber = 2
 	fresp, err := s.ds.FetchBundle(ctx, &datastore.FetchBundleRequest{TrustDomainId: "spiffe://foo"})
 	s.Require().NoError(err)
 	s.AssertProtoEqual(expectedPrunedBundle, fresp.Bundle)
@@ -526,6 +540,146 @@ func (s *PluginSuite) TestFetchAttestedNodesWithPagination() {
 	s.Require().Error(err, "could not parse token 'invalid int'")
 }
 
+func (s *PluginSuite) TestListAttestedNodesWithFilters() {
+	now := time.Now().Unix()
+	createNode := func(spiffeID, attestationType, serialNumber string, notAfter int64, selectors ...*common.Selector) *common.AttestedNode {
+		resp, err := s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{
+			Node: &common.AttestedNode{
+				SpiffeId:            spiffeID,
+				AttestationDataType: attestationType,
+				CertSerialNumber:    serialNumber,
+				CertNotAfter:        notAfter,
+			},
+		})
+		s.Require().NoError(err)
+		if len(selectors) > 0 {
+			s.setNodeSelectors(spiffeID, selectors)
+		}
+		node := resp.Node
+		node.Selectors = selectors
+		return node
+	}
+
+	node1 := createNode("spiffe://example.org/node1", "aws-tag", "1", now+3600,
+		&common.Selector{Type: "a", Value: "1"},
+		&common.Selector{Type: "b", Value: "2"})
+	node2 := createNode("spiffe://example.org/node2", "aws-tag", "2", now+7200,
+		&common.Selector{Type: "a", Value: "1"})
+	node3 := createNode("spiffe://example.org/node3", "join_token", "3", now-3600)
+
+	_, err := s.ds.SetAttestedNodeBanned(ctx, &datastore.SetAttestedNodeBannedRequest{
+		SpiffeId: node2.SpiffeId,
+		Banned:   true,
+	})
+	s.Require().NoError(err)
+	node2.Banned = true
+
+	tests := []struct {
+		name     string
+		req      *datastore.ListAttestedNodesRequest
+		expected []*common.AttestedNode
+	}{
+		{
+			name:     "no filters",
+			req:      &datastore.ListAttestedNodesRequest{FetchSelectors: true},
+			expected: []*common.AttestedNode{node1, node2, node3},
+		},
+		{
+			name: "by attestation type",
+			req: &datastore.ListAttestedNodesRequest{
+				ByAttestationType: &wrappers.StringValue{Value: "aws-tag"},
+				FetchSelectors:    true,
+			},
+			expected: []*common.AttestedNode{node1, node2},
+		},
+		{
+			name: "by expiry window",
+			req: &datastore.ListAttestedNodesRequest{
+				ByExpiresAfter:  &wrappers.Int64Value{Value: now},
+				ByExpiresBefore: &wrappers.Int64Value{Value: now + 5400},
+				FetchSelectors:  true,
+			},
+			expected: []*common.AttestedNode{node1},
+		},
+		{
+			name: "by banned",
+			req: &datastore.ListAttestedNodesRequest{
+				ByBanned:       &wrappers.BoolValue{Value: true},
+				FetchSelectors: true,
+			},
+			expected: []*common.AttestedNode{node2},
+		},
+		{
+			name: "by not banned",
+			req: &datastore.ListAttestedNodesRequest{
+				ByBanned:       &wrappers.BoolValue{Value: false},
+				FetchSelectors: true,
+			},
+			expected: []*common.AttestedNode{node1, node3},
+		},
+		{
+			name: "by superset selectors",
+			req: &datastore.ListAttestedNodesRequest{
+				BySelectorMatch: &datastore.BySelectors{
+					Selectors: []*common.Selector{{Type: "a", Value: "1"}},
+					Match:     datastore.BySelectors_MATCH_SUPERSET,
+				},
+				FetchSelectors: true,
+			},
+			expected: []*common.AttestedNode{node1, node2},
+		},
+		{
+			name: "by exact selectors",
+			req: &datastore.ListAttestedNodesRequest{
+				BySelectorMatch: &datastore.BySelectors{
+					Selectors: []*common.Selector{{Type: "a", Value: "1"}},
+					Match:     datastore.BySelectors_MATCH_EXACT,
+				},
+				FetchSelectors: true,
+			},
+			expected: []*common.AttestedNode{node2},
+		},
+		{
+			name: "combined with pagination",
+			req: &datastore.ListAttestedNodesRequest{
+				ByAttestationType: &wrappers.StringValue{Value: "aws-tag"},
+				BySelectorMatch: &datastore.BySelectors{
+					Selectors: []*common.Selector{{Type: "a", Value: "1"}},
+					Match:     datastore.BySelectors_MATCH_SUPERSET,
+				},
+				Pagination: &datastore.Pagination{
+					Token:    "1",
+					PageSize: 1,
+				},
+				FetchSelectors: true,
+			},
+			expected: []*common.AttestedNode{node2},
+		},
+		{
+			name: "without selectors",
+			req: &datastore.ListAttestedNodesRequest{
+				ByAttestationType: &wrappers.StringValue{Value: "join_token"},
+			},
+			expected: []*common.AttestedNode{node3},
+		},
+	}
+	for _, test := range tests {
+		s.T().Run(test.name, func(t *testing.T) {
+			resp, err := s.ds.ListAttestedNodes(ctx, test.req)
+			require.NoError(t, err)
+			s.RequireProtoListEqual(test.expected, resp.Nodes)
+		})
+	}
+
+	// selectors are only fetched when asked for
+	resp, err := s.ds.ListAttestedNodes(ctx, &datastore.ListAttestedNodesRequest{
+		ByAttestationType: &wrappers.StringValue{Value: "aws-tag"},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Nodes, 2)
+	s.Require().Empty(resp.Nodes[0].Selectors)
+}
+
 func (s *PluginSuite) TestUpdateAttestedNode() {
 	node := &common.AttestedNode{
 		SpiffeId:            "foo",
@@ -575,6 +729,54 @@ func (s *PluginSuite) TestUpdateAttestedNode() {
 	s.Equal(uexpires, fnode.CertNotAfter)
 }
 
+func (s *PluginSuite) TestSetAttestedNodeBanned() {
+	node := &common.AttestedNode{
+		SpiffeId:            "foo",
+		AttestationDataType: "aws-tag",
+		CertSerialNumber:    "badcafe",
+		CertNotAfter:        time.Now().Add(time.Hour).Unix(),
+	}
+
+	// ban non-existing attested node
+	_, err := s.ds.SetAttestedNodeBanned(ctx, &datastore.SetAttestedNodeBannedRequest{
+		SpiffeId: node.SpiffeId,
+		Banned:   true,
+	})
+	s.RequireGRPCStatus(err, codes.NotFound, "datastore-sql: record not found")
+
+	_, err = s.ds.CreateAttestedNode(ctx, &datastore.CreateAttestedNodeRequest{Node: node})
+	s.Require().NoError(err)
+
+	for _, banned := range []bool{true, false} {
+		resp, err := s.ds.SetAttestedNodeBanned(ctx, &datastore.SetAttestedNodeBannedRequest{
+			SpiffeId: node.SpiffeId,
+			Banned:   banned,
+		})
+		s.Require().NoError(err)
+		node.Banned = banned
+		s.RequireProtoEqual(node, resp.Node)
+
+		fresp, err := s.ds.FetchAttestedNode(ctx, &datastore.FetchAttestedNodeRequest{SpiffeId: node.SpiffeId})
+		s.Require().NoError(err)
+		s.RequireProtoEqual(node, fresp.Node)
+	}
+
+	// updating the serial number, e.g. when the node re-attests, does not
+	// change the banned state
+	_, err = s.ds.SetAttestedNodeBanned(ctx, &datastore.SetAttestedNodeBannedRequest{
+		SpiffeId: node.SpiffeId,
+		Banned:   true,
+	})
+	s.Require().NoError(err)
+	uresp, err := s.ds.UpdateAttestedNode(ctx, &datastore.UpdateAttestedNodeRequest{
+		SpiffeId:         node.SpiffeId,
+		CertSerialNumber: "deadbeef",
+		CertNotAfter:     node.CertNotAfter,
+	})
+	s.Require().NoError(err)
+	s.Require().True(uresp.Node.Banned)
+}
+
 func (s *PluginSuite) TestDeleteAttestedNode() {
 	entry := &common.AttestedNode{
 		SpiffeId:            "foo",
@@ -637,6 +839,71 @@ func (s *PluginSuite) TestNodeSelectors() {
 	s.RequireProtoListEqual(bar, selectors)
 }
 
+func (s *PluginSuite) TestListNodeSelectors() {
+	foo := []*common.Selector{
+		{Type: "FOO1", Value: "1"},
+		{Type: "FOO2", Value: "2"},
+	}
+	bar := []*common.Selector{
+		{Type: "BAR", Value: "FIGHT"},
+	}
+
+	// no selectors yet
+	s.Require().Empty(s.listNodeSelectors())
+
+	// nodes are listed in SPIFFE ID order, along with their selectors
+	s.setNodeSelectors("foo", foo)
+	s.setNodeSelectors("bar", bar)
+	s.setNodeSelectors("baz", nil)
+	s.RequireProtoListEqual([]*datastore.NodeSelectors{
+		{SpiffeId: "bar", Selectors: bar},
+		{SpiffeId: "foo", Selectors: foo},
+	}, s.listNodeSelectors())
+}
+
+func (s *PluginSuite) TestListNodeSelectorsAfterEvent() {
+	foo := []*common.Selector{
+		{Type: "FOO", Value: "1"},
+	}
+	bar := []*common.Selector{
+		{Type: "BAR", Value: "FIGHT"},
+	}
+
+	// no events yet
+	resp := s.listNodeSelectorsAfterEvent(0)
+	s.Require().Empty(resp.Selectors)
+	s.Require().Zero(resp.LatestEventId)
+
+	s.setNodeSelectors("foo", foo)
+	s.setNodeSelectors("bar", bar)
+	resp = s.listNodeSelectorsAfterEvent(0)
+	s.RequireProtoListEqual([]*datastore.NodeSelectors{
+		{SpiffeId: "bar", Selectors: bar},
+		{SpiffeId: "foo", Selectors: foo},
+	}, resp.Selectors)
+	latestEventID := resp.LatestEventId
+	s.Require().NotZero(latestEventID)
+
+	// nothing changed since the latest event
+	resp = s.listNodeSelectorsAfterEvent(latestEventID)
+	s.Require().Empty(resp.Selectors)
+	s.Require().Equal(latestEventID, resp.LatestEventId)
+
+	// only the nodes changed after the event are listed, including the ones
+	// left without selectors
+	s.setNodeSelectors("foo", nil)
+	resp = s.listNodeSelectorsAfterEvent(latestEventID)
+	s.RequireProtoListEqual([]*datastore.NodeSelectors{
+		{SpiffeId: "foo"},
+	}, resp.Selectors)
+	s.Require().True(resp.LatestEventId > latestEventID)
+
+	// the full listing still leaves out nodes without selectors
+	s.RequireProtoListEqual([]*datastore.NodeSelectors{
+		{SpiffeId: "bar", Selectors: bar},
+	}, s.listNodeSelectors())
+}
+
 func (s *PluginSuite) TestCreateRegistrationEntry() {
 	var validRegistrationEntries []*common.RegistrationEntry
 	s.getTestDataFromJSONFile(filepath.Join("testdata", "valid_registration_entries.json"), &validRegistrationEntries)
@@ -647,7 +914,9 @@ func (s *PluginSuite) TestCreateRegistrationEntry() {
 		s.NotNil(resp)
 		s.Require().NotNil(resp.Entry)
 		s.NotEmpty(resp.Entry.EntryId)
+		s.NotZero(resp.Entry.RevisionNumber)
 		resp.Entry.EntryId = ""
+		resp.Entry.RevisionNumber = 0
 		s.RequireProtoEqual(resp.Entry, validRegistrationEntry)
 	}
 }
@@ -679,12 +948,28 @@ func (s *PluginSuite) TestFetchRegistrationEntry() {
 			"abcd.efg",
 			"somehost",
 		},
+		X509SvidTemplate: &common.X509SVIDTemplate{
+			Subject: &common.X509Subject{
+				Organization:       []string{"ACME"},
+				OrganizationalUnit: []string{"gateway"},
+				CommonName:         "gateway.example.org",
+			},
+			ExtraKeyUsages:    []string{"data_encipherment"},
+			ExtraExtKeyUsages: []string{"code_signing"},
+		},
+
+		JwtSvidTtl: 300,
+		JwtSvidClaims: map[string]string{
+			"tenant":      "acme",
+			"environment": "prod",
+		},
 	}
 
 	createRegistrationEntryResponse, err := s.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{Entry: registeredEntry})
 	s.Require().NoError(err)
 	s.Require().NotNil(createRegistrationEntryResponse)
 	createdEntry := createRegistrationEntryResponse.Entry
+	s.RequireProtoEqual(registeredEntry.X509SvidTemplate, createdEntry.X509SvidTemplate)
 
 	fetchRegistrationEntryResponse, err := s.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{EntryId: createdEntry.EntryId})
 	s.Require().NoError(err)
@@ -1004,6 +1289,8 @@ func (s *PluginSuite) TestUpdateRegistrationEntry() {
 	})
 	s.Require().NoError(err)
 	s.Require().NotNil(updateRegistrationEntryResponse)
+	s.Require().Equal(entry.RevisionNumber+1, updateRegistrationEntryResponse.Entry.RevisionNumber)
+	entry.RevisionNumber = updateRegistrationEntryResponse.Entry.RevisionNumber
 
 	fetchRegistrationEntryResponse, err := s.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{EntryId: entry.EntryId})
 	s.Require().NoError(err)
@@ -1019,6 +1306,101 @@ func (s *PluginSuite) TestUpdateRegistrationEntry() {
 	s.RequireGRPCStatus(err, codes.NotFound, "datastore-sql: record not found")
 }
 
+func (s *PluginSuite) TestUpdateRegistrationEntryWithStaleRevision() {
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		Selectors: []*common.Selector{{Type: "Type1", Value: "Value1"}},
+		SpiffeId:  "spiffe://example.org/foo",
+		ParentId:  "spiffe://example.org/bar",
+		Ttl:       1,
+	})
+	staleRevision := entry.RevisionNumber
+
+	// updating with the current revision succeeds and bumps it
+	entry.Ttl = 2
+	resp, err := s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry: entry,
+	})
+	s.Require().NoError(err)
+	s.Require().True(resp.Entry.RevisionNumber > staleRevision)
+
+	// updating with the old revision fails
+	entry.Ttl = 3
+	entry.RevisionNumber = staleRevision
+	_, err = s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry: entry,
+	})
+	s.RequireGRPCStatusContains(err, codes.FailedPrecondition, "revision mismatch")
+	s.Require().Equal(int32(2), s.fetchRegistrationEntry(entry.EntryId).Ttl)
+
+	// no revision means an unconditional update
+	entry.RevisionNumber = 0
+	_, err = s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry: entry,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(int32(3), s.fetchRegistrationEntry(entry.EntryId).Ttl)
+
+	// batch updates report stale revisions per entry
+	entry.Ttl = 4
+	entry.RevisionNumber = staleRevision
+	batchResp, err := s.ds.BatchUpdateRegistrationEntries(ctx, &datastore.BatchUpdateRegistrationEntriesRequest{
+		Entries: []*common.RegistrationEntry{entry},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(int32(codes.FailedPrecondition), batchResp.Results[0].Code)
+	s.Require().Equal(int32(3), s.fetchRegistrationEntry(entry.EntryId).Ttl)
+}
+
+func (s *PluginSuite) TestUpdateRegistrationEntryWithMask() {
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		Selectors: []*common.Selector{{Type: "Type1", Value: "Value1"}},
+		SpiffeId:  "spiffe://example.org/foo",
+		ParentId:  "spiffe://example.org/bar",
+		DnsNames:  []string{"foo.example.org"},
+		Ttl:       1,
+	})
+
+	// only the selectors are updated
+	resp, err := s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			EntryId:   entry.EntryId,
+			Selectors: []*common.Selector{{Type: "Type2", Value: "Value2"}},
+		},
+		Mask: &common.RegistrationEntryMask{Selectors: true},
+	})
+	s.Require().NoError(err)
+
+	expected := proto.Clone(entry).(*common.RegistrationEntry)
+	expected.Selectors = []*common.Selector{{Type: "Type2", Value: "Value2"}}
+	expected.RevisionNumber = resp.Entry.RevisionNumber
+	s.RequireProtoEqual(expected, resp.Entry)
+	s.RequireProtoEqual(expected, s.fetchRegistrationEntry(entry.EntryId))
+
+	// only the DNS names are updated
+	resp, err = s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			EntryId:  entry.EntryId,
+			DnsNames: []string{"bar.example.org"},
+		},
+		Mask: &common.RegistrationEntryMask{DnsNames: true},
+	})
+	s.Require().NoError(err)
+
+	expected.DnsNames = []string{"bar.example.org"}
+	expected.RevisionNumber = resp.Entry.RevisionNumber
+	s.RequireProtoEqual(expected, resp.Entry)
+	s.RequireProtoEqual(expected, s.fetchRegistrationEntry(entry.EntryId))
+
+	// the merged entry must still be valid
+	_, err = s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			EntryId: entry.EntryId,
+		},
+		Mask: &common.RegistrationEntryMask{Selectors: true},
+	})
+	s.RequireGRPCStatus(err, codes.Unknown, "datastore-sql: invalid registration entry: missing selector list")
+}
+
 func (s *PluginSuite) TestDeleteRegistrationEntry() {
 	// delete non-existing
 	_, err := s.ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{EntryId: "badid"})
@@ -1052,6 +1434,223 @@ func (s *PluginSuite) TestDeleteRegistrationEntry() {
 	s.Require().Equal(entry1, delRes.Entry)
 }
 
+func (s *PluginSuite) TestBatchCreateRegistrationEntries() {
+	existing := s.createRegistrationEntry(&common.RegistrationEntry{
+		Selectors: []*common.Selector{{Type: "Type1", Value: "Value1"}},
+		SpiffeId:  "spiffe://example.org/foo",
+		ParentId:  "spiffe://example.org/bar",
+	})
+
+	entry1 := &common.RegistrationEntry{
+		Selectors: []*common.Selector{{Type: "Type2", Value: "Value2"}},
+		SpiffeId:  "spiffe://example.org/foo",
+		ParentId:  "spiffe://example.org/bar",
+	}
+	entry2 := &common.RegistrationEntry{
+		Selectors: []*common.Selector{{Type: "Type3", Value: "Value3"}},
+		SpiffeId:  "spiffe://example.org/baz",
+		ParentId:  "spiffe://example.org/bar",
+	}
+	invalid := &common.RegistrationEntry{
+		SpiffeId: "spiffe://example.org/baz",
+		ParentId: "spiffe://example.org/bar",
+	}
+	duplicate := &common.RegistrationEntry{
+		Selectors: existing.Selectors,
+		SpiffeId:  existing.SpiffeId,
+		ParentId:  existing.ParentId,
+	}
+
+	resp, err := s.ds.BatchCreateRegistrationEntries(ctx, &datastore.BatchCreateRegistrationEntriesRequest{
+		Entries: []*common.RegistrationEntry{entry1, invalid, duplicate, entry2, entry1},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Results, 5)
+
+	s.Require().Equal(int32(codes.OK), resp.Results[0].Code)
+	s.Require().Equal(int32(codes.InvalidArgument), resp.Results[1].Code)
+	s.Require().Equal(int32(codes.AlreadyExists), resp.Results[2].Code)
+	s.Require().Equal(int32(codes.OK), resp.Results[3].Code)
+	s.Require().Equal(int32(codes.AlreadyExists), resp.Results[4].Code)
+	s.Require().Nil(resp.Results[1].Entry)
+
+	s.RequireProtoEqual(resp.Results[0].Entry, s.fetchRegistrationEntry(resp.Results[0].Entry.EntryId))
+	s.RequireProtoEqual(resp.Results[3].Entry, s.fetchRegistrationEntry(resp.Results[3].Entry.EntryId))
+
+	listResp, err := s.ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{})
+	s.Require().NoError(err)
+	s.Require().Len(listResp.Entries, 3)
+}
+
+func (s *PluginSuite) TestBatchCreateRegistrationEntriesAllOrNothing() {
+	entry := &common.RegistrationEntry{
+		Selectors: []*common.Selector{{Type: "Type1", Value: "Value1"}},
+		SpiffeId:  "spiffe://example.org/foo",
+		ParentId:  "spiffe://example.org/bar",
+	}
+	invalid := &common.RegistrationEntry{
+		SpiffeId: "spiffe://example.org/baz",
+		ParentId: "spiffe://example.org/bar",
+	}
+
+	resp, err := s.ds.BatchCreateRegistrationEntries(ctx, &datastore.BatchCreateRegistrationEntriesRequest{
+		Entries:      []*common.RegistrationEntry{entry, invalid},
+		AllOrNothing: true,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Results, 2)
+	s.Require().Equal(int32(codes.Aborted), resp.Results[0].Code)
+	s.Require().Equal(int32(codes.InvalidArgument), resp.Results[1].Code)
+
+	listResp, err := s.ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{})
+	s.Require().NoError(err)
+	s.Require().Empty(listResp.Entries)
+
+	resp, err = s.ds.BatchCreateRegistrationEntries(ctx, &datastore.BatchCreateRegistrationEntriesRequest{
+		Entries:      []*common.RegistrationEntry{entry},
+		AllOrNothing: true,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Results, 1)
+	s.Require().Equal(int32(codes.OK), resp.Results[0].Code)
+	s.RequireProtoEqual(resp.Results[0].Entry, s.fetchRegistrationEntry(resp.Results[0].Entry.EntryId))
+}
+
+func (s *PluginSuite) TestBatchUpdateRegistrationEntries() {
+	entry1 := s.createRegistrationEntry(&common.RegistrationEntry{
+		Selectors: []*common.Selector{{Type: "Type1", Value: "Value1"}},
+		SpiffeId:  "spiffe://example.org/foo",
+		ParentId:  "spiffe://example.org/bar",
+		Ttl:       1,
+	})
+	entry2 := s.createRegistrationEntry(&common.RegistrationEntry{
+		Selectors: []*common.Selector{{Type: "Type2", Value: "Value2"}},
+		SpiffeId:  "spiffe://example.org/baz",
+		ParentId:  "spiffe://example.org/bar",
+		Ttl:       1,
+	})
+
+	entry1.Ttl = 2
+	entry2.Ttl = 2
+	missing := &common.RegistrationEntry{
+		EntryId:   "badid",
+		Selectors: []*common.Selector{{Type: "Type3", Value: "Value3"}},
+		SpiffeId:  "spiffe://example.org/qux",
+		ParentId:  "spiffe://example.org/bar",
+	}
+
+	// all-or-nothing leaves every entry untouched
+	resp, err := s.ds.BatchUpdateRegistrationEntries(ctx, &datastore.BatchUpdateRegistrationEntriesRequest{
+		Entries:      []*common.RegistrationEntry{entry1, missing},
+		AllOrNothing: true,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Results, 2)
+	s.Require().Equal(int32(codes.Aborted), resp.Results[0].Code)
+	s.Require().Equal(int32(codes.NotFound), resp.Results[1].Code)
+	s.Require().Equal(int32(1), s.fetchRegistrationEntry(entry1.EntryId).Ttl)
+
+	resp, err = s.ds.BatchUpdateRegistrationEntries(ctx, &datastore.BatchUpdateRegistrationEntriesRequest{
+		Entries: []*common.RegistrationEntry{entry1, missing, entry2},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Results, 3)
+	s.Require().Equal(int32(codes.OK), resp.Results[0].Code)
+	s.Require().Equal(int32(codes.NotFound), resp.Results[1].Code)
+	s.Require().Equal(int32(codes.OK), resp.Results[2].Code)
+
+	s.RequireProtoEqual(resp.Results[0].Entry, s.fetchRegistrationEntry(entry1.EntryId))
+	s.RequireProtoEqual(resp.Results[2].Entry, s.fetchRegistrationEntry(entry2.EntryId))
+	s.Require().Equal(int32(2), resp.Results[0].Entry.Ttl)
+	s.Require().Equal(int32(2), resp.Results[2].Entry.Ttl)
+}
+
+func (s *PluginSuite) TestBatchRegistrationEntriesWithMissingFederatedBundle() {
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		Selectors: []*common.Selector{{Type: "Type1", Value: "Value1"}},
+		SpiffeId:  "spiffe://example.org/foo",
+		ParentId:  "spiffe://example.org/bar",
+	})
+	federated := &common.RegistrationEntry{
+		Selectors:     []*common.Selector{{Type: "Type2", Value: "Value2"}},
+		SpiffeId:      "spiffe://example.org/baz",
+		ParentId:      "spiffe://example.org/bar",
+		FederatesWith: []string{"spiffe://otherdomain.org"},
+	}
+	other := &common.RegistrationEntry{
+		Selectors: []*common.Selector{{Type: "Type3", Value: "Value3"}},
+		SpiffeId:  "spiffe://example.org/qux",
+		ParentId:  "spiffe://example.org/bar",
+	}
+
+	// only the entry federating with an unknown trust domain fails
+	createResp, err := s.ds.BatchCreateRegistrationEntries(ctx, &datastore.BatchCreateRegistrationEntriesRequest{
+		Entries: []*common.RegistrationEntry{federated, other},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(createResp.Results, 2)
+	s.Require().Equal(int32(codes.FailedPrecondition), createResp.Results[0].Code)
+	s.Require().Equal(`unable to find federated bundle "spiffe://otherdomain.org"`, createResp.Results[0].Message)
+	s.Require().Equal(int32(codes.OK), createResp.Results[1].Code)
+	s.RequireProtoEqual(createResp.Results[1].Entry, s.fetchRegistrationEntry(createResp.Results[1].Entry.EntryId))
+
+	updated := proto.Clone(entry).(*common.RegistrationEntry)
+	updated.SpiffeId = "spiffe://example.org/quux"
+	updated.FederatesWith = []string{"spiffe://otherdomain.org"}
+	updateResp, err := s.ds.BatchUpdateRegistrationEntries(ctx, &datastore.BatchUpdateRegistrationEntriesRequest{
+		Entries: []*common.RegistrationEntry{updated, createResp.Results[1].Entry},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(updateResp.Results, 2)
+	s.Require().Equal(int32(codes.FailedPrecondition), updateResp.Results[0].Code)
+	s.Require().Equal(int32(codes.OK), updateResp.Results[1].Code)
+	s.Require().Equal("spiffe://example.org/foo", s.fetchRegistrationEntry(entry.EntryId).SpiffeId)
+}
+
+func (s *PluginSuite) TestBatchDeleteRegistrationEntries() {
+	entry1 := s.createRegistrationEntry(&common.RegistrationEntry{
+		Selectors: []*common.Selector{{Type: "Type1", Value: "Value1"}},
+		SpiffeId:  "spiffe://example.org/foo",
+		ParentId:  "spiffe://example.org/bar",
+	})
+	entry2 := s.createRegistrationEntry(&common.RegistrationEntry{
+		Selectors: []*common.Selector{{Type: "Type2", Value: "Value2"}},
+		SpiffeId:  "spiffe://example.org/baz",
+		ParentId:  "spiffe://example.org/bar",
+	})
+
+	resp, err := s.ds.BatchDeleteRegistrationEntries(ctx, &datastore.BatchDeleteRegistrationEntriesRequest{
+		EntryIds:     []string{entry1.EntryId, "badid"},
+		AllOrNothing: true,
+	})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Results, 2)
+	s.Require().Equal(int32(codes.Aborted), resp.Results[0].Code)
+	s.Require().Equal(int32(codes.NotFound), resp.Results[1].Code)
+	s.fetchRegistrationEntry(entry1.EntryId)
+
+	resp, err = s.ds.BatchDeleteRegistrationEntries(ctx, &datastore.BatchDeleteRegistrationEntriesRequest{
+		EntryIds: []string{entry1.EntryId, entry1.EntryId, "", entry2.EntryId},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(resp.Results, 4)
+	s.Require().Equal(int32(codes.OK), resp.Results[0].Code)
+	s.Require().Equal(int32(codes.NotFound), resp.Results[1].Code)
+	s.Require().Equal(int32(codes.InvalidArgument), resp.Results[2].Code)
+	s.Require().Equal(int32(codes.OK), resp.Results[3].Code)
+	s.RequireProtoEqual(entry1, resp.Results[0].Entry)
+	s.RequireProtoEqual(entry2, resp.Results[3].Entry)
+
+	listResp, err := s.ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{})
+	s.Require().NoError(err)
+	s.Require().Empty(listResp.Entries)
+
+	// deleted entries leave tombstones behind
+	tombstones, err := s.ds.ListRegistrationEntryTombstones(ctx, &datastore.ListRegistrationEntryTombstonesRequest{})
+	s.Require().NoError(err)
+	s.Require().ElementsMatch([]string{entry1.EntryId, entry2.EntryId}, tombstones.EntryIds)
+}
+
 func (s *PluginSuite) TestListParentIDEntries() {
 	allEntries := testutil.GetRegistrationEntries("entries.json")
 	tests := []struct {
@@ -1083,6 +1682,7 @@ func (s *PluginSuite) TestListParentIDEntries() {
 				require.NotNil(t, r)
 				require.NotNil(t, r.Entry)
 				entry.EntryId = r.Entry.EntryId
+				entry.RevisionNumber = r.Entry.RevisionNumber
 			}
 			result, err := ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
 				ByParentId: &wrappers.StringValue{
@@ -1131,6 +1731,7 @@ func (s *PluginSuite) TestListSelectorEntries() {
 				require.NotNil(t, r)
 				require.NotNil(t, r.Entry)
 				entry.EntryId = r.Entry.EntryId
+				entry.RevisionNumber = r.Entry.RevisionNumber
 			}
 			result, err := ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
 				BySelectors: &datastore.BySelectors{
@@ -1184,6 +1785,7 @@ func (s *PluginSuite) TestListMatchingEntries() {
 				require.NotNil(t, r)
 				require.NotNil(t, r.Entry)
 				entry.EntryId = r.Entry.EntryId
+				entry.RevisionNumber = r.Entry.RevisionNumber
 			}
 			result, err := ds.ListRegistrationEntries(ctx, &datastore.ListRegistrationEntriesRequest{
 				BySelectors: &datastore.BySelectors{
@@ -1199,29 +1801,180 @@ func (s *PluginSuite) TestListMatchingEntries() {
 	}
 }
 
-func (s *PluginSuite) TestRegistrationEntriesFederatesWithAgainstMissingBundle() {
-	// cannot federate with a trust bundle that does not exist
-	_, err := s.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
-		Entry: makeFederatedRegistrationEntry(),
-	})
-	s.RequireErrorContains(err, `unable to find federated bundle "spiffe://otherdomain.org"`)
-}
-
-func (s *PluginSuite) TestRegistrationEntriesFederatesWithSuccess() {
-	// create two bundles but only federate with one. having a second bundle
-	// has the side effect of asserting that only the code only associates
-	// the entry with the exact bundle referenced during creation.
+func (s *PluginSuite) TestListRegistrationEntriesWithFilters() {
 	s.createBundle("spiffe://otherdomain.org")
 	s.createBundle("spiffe://otherdomain2.org")
 
-	expected := s.createRegistrationEntry(makeFederatedRegistrationEntry())
-	// fetch the entry and make sure the federated trust ids come back
-	actual := s.fetchRegistrationEntry(expected.EntryId)
-	s.RequireProtoEqual(expected, actual)
-}
+	foo := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/node1",
+		SpiffeId:  "spiffe://example.org/foo",
+		Selectors: []*common.Selector{{Type: "a", Value: "1"}},
+	})
+	fooBar := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:      "spiffe://example.org/node1",
+		SpiffeId:      "spiffe://example.org/foo/bar",
+		Selectors:     []*common.Selector{{Type: "a", Value: "1"}, {Type: "b", Value: "2"}},
+		FederatesWith: []string{"spiffe://otherdomain.org"},
+		Admin:         true,
+	})
+	fooBaz := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:      "spiffe://example.org/node2",
+		SpiffeId:      "spiffe://example.org/foo_baz",
+		Selectors:     []*common.Selector{{Type: "b", Value: "2"}},
+		FederatesWith: []string{"spiffe://otherdomain.org", "spiffe://otherdomain2.org"},
+		Downstream:    true,
+	})
+	other := s.createRegistrationEntry(&common.RegistrationEntry{
+		ParentId:  "spiffe://example.org/node2",
+		SpiffeId:  "spiffe://example.org/fooxbaz",
+		Selectors: []*common.Selector{{Type: "a", Value: "1"}, {Type: "c", Value: "3"}},
+	})
 
-func (s *PluginSuite) TestDeleteBundleRestrictedByRegistrationEntries() {
-	// create the bundle and associated entry
+	tests := []struct {
+		name         string
+		req          *datastore.ListRegistrationEntriesRequest
+		expectedList []*common.RegistrationEntry
+	}{
+		{
+			name:         "no filters",
+			req:          &datastore.ListRegistrationEntriesRequest{},
+			expectedList: []*common.RegistrationEntry{foo, fooBar, fooBaz, other},
+		},
+		{
+			name: "by spiffe id prefix",
+			req: &datastore.ListRegistrationEntriesRequest{
+				BySpiffeIdPrefix: &wrappers.StringValue{Value: "spiffe://example.org/foo/"},
+			},
+			expectedList: []*common.RegistrationEntry{fooBar},
+		},
+		{
+			name: "by spiffe id prefix with wildcards",
+			req: &datastore.ListRegistrationEntriesRequest{
+				BySpiffeIdPrefix: &wrappers.StringValue{Value: "spiffe://example.org/foo_"},
+			},
+			expectedList: []*common.RegistrationEntry{fooBaz},
+		},
+		{
+			name: "by admin",
+			req: &datastore.ListRegistrationEntriesRequest{
+				ByAdmin: &wrappers.BoolValue{Value: true},
+			},
+			expectedList: []*common.RegistrationEntry{fooBar},
+		},
+		{
+			name: "by not downstream",
+			req: &datastore.ListRegistrationEntriesRequest{
+				ByDownstream: &wrappers.BoolValue{Value: false},
+			},
+			expectedList: []*common.RegistrationEntry{foo, fooBar, other},
+		},
+		{
+			name: "by federates with",
+			req: &datastore.ListRegistrationEntriesRequest{
+				ByFederatesWith: []string{"spiffe://otherdomain.org"},
+			},
+			expectedList: []*common.RegistrationEntry{fooBar, fooBaz},
+		},
+		{
+			name: "by federates with any",
+			req: &datastore.ListRegistrationEntriesRequest{
+				ByFederatesWith: []string{"spiffe://otherdomain2.org", "spiffe://unknown.org"},
+			},
+			expectedList: []*common.RegistrationEntry{fooBaz},
+		},
+		{
+			name: "by exact selectors",
+			req: &datastore.ListRegistrationEntriesRequest{
+				BySelectors: &datastore.BySelectors{
+					Selectors: []*common.Selector{{Type: "a", Value: "1"}, {Type: "b", Value: "2"}},
+					Match:     datastore.BySelectors_MATCH_EXACT,
+				},
+			},
+			expectedList: []*common.RegistrationEntry{fooBar},
+		},
+		{
+			name: "by subset selectors",
+			req: &datastore.ListRegistrationEntriesRequest{
+				BySelectors: &datastore.BySelectors{
+					Selectors: []*common.Selector{{Type: "a", Value: "1"}, {Type: "b", Value: "2"}},
+					Match:     datastore.BySelectors_MATCH_SUBSET,
+				},
+			},
+			expectedList: []*common.RegistrationEntry{foo, fooBar, fooBaz},
+		},
+		{
+			name: "by superset selectors",
+			req: &datastore.ListRegistrationEntriesRequest{
+				BySelectors: &datastore.BySelectors{
+					Selectors: []*common.Selector{{Type: "a", Value: "1"}},
+					Match:     datastore.BySelectors_MATCH_SUPERSET,
+				},
+			},
+			expectedList: []*common.RegistrationEntry{foo, fooBar, other},
+		},
+		{
+			name: "combined",
+			req: &datastore.ListRegistrationEntriesRequest{
+				ByParentId:       &wrappers.StringValue{Value: "spiffe://example.org/node1"},
+				BySpiffeIdPrefix: &wrappers.StringValue{Value: "spiffe://example.org/foo"},
+				BySelectors: &datastore.BySelectors{
+					Selectors: []*common.Selector{{Type: "a", Value: "1"}, {Type: "b", Value: "2"}},
+					Match:     datastore.BySelectors_MATCH_SUBSET,
+				},
+				ByFederatesWith: []string{"spiffe://otherdomain.org"},
+			},
+			expectedList: []*common.RegistrationEntry{fooBar},
+		},
+		{
+			name: "combined with pagination",
+			req: &datastore.ListRegistrationEntriesRequest{
+				BySelectors: &datastore.BySelectors{
+					Selectors: []*common.Selector{{Type: "a", Value: "1"}, {Type: "b", Value: "2"}},
+					Match:     datastore.BySelectors_MATCH_SUBSET,
+				},
+				ByFederatesWith: []string{"spiffe://otherdomain.org"},
+				Pagination: &datastore.Pagination{
+					Token:    "2",
+					PageSize: 1,
+				},
+			},
+			expectedList: []*common.RegistrationEntry{fooBaz},
+		},
+	}
+	for _, test := range tests {
+		s.T().Run(test.name, func(t *testing.T) {
+			resp, err := s.ds.ListRegistrationEntries(ctx, test.req)
+			require.NoError(t, err)
+			util.SortRegistrationEntries(test.expectedList)
+			util.SortRegistrationEntries(resp.Entries)
+			s.RequireProtoListEqual(test.expectedList, resp.Entries)
+		})
+	}
+}
+
+func (s *PluginSuite) TestRegistrationEntriesFederatesWithAgainstMissingBundle() {
+	// cannot federate with a trust bundle that does not exist
+	_, err := s.ds.CreateRegistrationEntry(ctx, &datastore.CreateRegistrationEntryRequest{
+		Entry: makeFederatedRegistrationEntry(),
+	})
+	s.RequireErrorContains(err, `unable to find federated bundle "spiffe://otherdomain.org"`)
+}
+
+func (s *PluginSuite) TestRegistrationEntriesFederatesWithSuccess() {
+	// create two bundles but only federate with one. having a second bundle
+	// has the side effect of asserting that only the code only associates
+	// the entry with the exact bundle referenced during creation.
+	s.createBundle("spiffe://otherdomain.org")
+	s.createBundle("spiffe://otherdomain2.org")
+
+	expected := s.createRegistrationEntry(makeFederatedRegistrationEntry())
+	// fetch the entry and make sure the federated trust ids come back
+	actual := s.fetchRegistrationEntry(expected.EntryId)
+	s.RequireProtoEqual(expected, actual)
+}
+
+func (s *PluginSuite) TestDeleteBundleRestrictedByRegistrationEntries() {
+	// create the bundle and associated entry
 	s.createBundle("spiffe://otherdomain.org")
 	s.createRegistrationEntry(makeFederatedRegistrationEntry())
 
@@ -1260,6 +2013,9 @@ func (s *PluginSuite) TestDeleteBundleDeleteRegistrationEntries() {
 
 	// make sure the unrelated entry still exists
 	s.fetchRegistrationEntry(unrelated.EntryId)
+
+	// make sure the deletion was recorded
+	s.Require().Equal([]string{entry.EntryId}, s.listRegistrationEntryTombstones(entry.RevisionNumber).EntryIds)
 }
 
 func (s *PluginSuite) TestDeleteBundleDissociateRegistrationEntries() {
@@ -1274,9 +2030,215 @@ func (s *PluginSuite) TestDeleteBundleDissociateRegistrationEntries() {
 	})
 	s.Require().NoError(err)
 
-	// make sure the entry still exists, albeit without an associated bundle
+	// make sure the entry still exists, albeit without an associated bundle,
+	// and that the change bumped its revision
+	revision := entry.RevisionNumber
 	entry = s.fetchRegistrationEntry(entry.EntryId)
 	s.Require().Empty(entry.FederatesWith)
+	s.Require().True(entry.RevisionNumber > revision)
+}
+
+func (s *PluginSuite) TestRevisions() {
+	// revisions start at zero
+	resp := s.listRegistrationEntryTombstones(0)
+	s.Require().Zero(resp.Revision)
+	s.Require().Empty(resp.EntryIds)
+
+	// each change to entries and bundles increments the revision
+	s.createBundle("spiffe://otherdomain.org")
+	s.Require().Equal(int64(1), s.fetchBundle("spiffe://otherdomain.org").RevisionNumber)
+
+	entry1 := s.createRegistrationEntry(&common.RegistrationEntry{
+		SpiffeId:  "spiffe://example.org/foo",
+		Selectors: []*common.Selector{{Type: "TYPE", Value: "VALUE"}},
+	})
+	s.Require().Equal(int64(2), entry1.RevisionNumber)
+
+	entry2 := s.createRegistrationEntry(&common.RegistrationEntry{
+		SpiffeId:  "spiffe://example.org/bar",
+		Selectors: []*common.Selector{{Type: "TYPE", Value: "VALUE"}},
+	})
+	s.Require().Equal(int64(3), entry2.RevisionNumber)
+
+	// changes to other objects don't
+	_, err := s.ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
+		JoinToken: &datastore.JoinToken{Token: "foobar", Expiry: time.Now().Unix()},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(int64(3), s.listRegistrationEntryTombstones(0).Revision)
+
+	// deletions are recorded as tombstones at the revision of the deletion
+	s.deleteRegistrationEntry(entry1.EntryId)
+	s.deleteRegistrationEntry(entry2.EntryId)
+
+	resp = s.listRegistrationEntryTombstones(0)
+	s.Require().Equal(int64(5), resp.Revision)
+	s.Require().Equal([]string{entry1.EntryId, entry2.EntryId}, resp.EntryIds)
+
+	// only tombstones after the requested revision are listed
+	resp = s.listRegistrationEntryTombstones(4)
+	s.Require().Equal(int64(5), resp.Revision)
+	s.Require().Equal([]string{entry2.EntryId}, resp.EntryIds)
+
+	resp = s.listRegistrationEntryTombstones(5)
+	s.Require().Equal(int64(5), resp.Revision)
+	s.Require().Empty(resp.EntryIds)
+
+	// node selector changes are recorded as events and do not increment the
+	// revision
+	s.setNodeSelectors("spiffe://example.org/node", []*common.Selector{{Type: "TYPE", Value: "VALUE"}})
+	s.Require().Equal(int64(5), s.listRegistrationEntryTombstones(5).Revision)
+}
+
+func (s *PluginSuite) TestPruneRegistrationEntryTombstones() {
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		SpiffeId:  "spiffe://example.org/foo",
+		Selectors: []*common.Selector{{Type: "TYPE", Value: "VALUE"}},
+	})
+	s.deleteRegistrationEntry(entry.EntryId)
+
+	// tombstones newer than the requested time are not pruned
+	_, err := s.ds.PruneRegistrationEntryTombstones(ctx, &datastore.PruneRegistrationEntryTombstonesRequest{
+		DeletedBefore: time.Now().Add(-time.Minute).Unix(),
+	})
+	s.Require().NoError(err)
+	s.Require().Equal([]string{entry.EntryId}, s.listRegistrationEntryTombstones(0).EntryIds)
+
+	// older tombstones are pruned, while the revision is retained
+	_, err = s.ds.PruneRegistrationEntryTombstones(ctx, &datastore.PruneRegistrationEntryTombstonesRequest{
+		DeletedBefore: time.Now().Add(time.Minute).Unix(),
+	})
+	s.Require().NoError(err)
+	resp := s.listRegistrationEntryTombstones(0)
+	s.Require().Equal(int64(2), resp.Revision)
+	s.Require().Empty(resp.EntryIds)
+}
+
+func (s *PluginSuite) TestRegistrationEntryEvents() {
+	// there are no events to start with
+	resp := s.listRegistrationEntryEvents(0, 0)
+	s.Require().Empty(resp.Events)
+	s.Require().Zero(resp.OldestId)
+	s.Require().Zero(resp.LatestId)
+	s.Require().Zero(resp.PrunedId)
+
+	// each entry mutation records an event
+	s.createBundle("spiffe://otherdomain.org")
+	entry1 := s.createRegistrationEntry(makeFederatedRegistrationEntry())
+	entry2 := s.createRegistrationEntry(&common.RegistrationEntry{
+		SpiffeId:  "spiffe://example.org/bar",
+		Selectors: []*common.Selector{{Type: "TYPE", Value: "VALUE"}},
+	})
+
+	entry2.Ttl = 60
+	_, err := s.ds.UpdateRegistrationEntry(ctx, &datastore.UpdateRegistrationEntryRequest{
+		Entry: entry2,
+	})
+	s.Require().NoError(err)
+
+	_, err = s.ds.DeleteBundle(ctx, &datastore.DeleteBundleRequest{
+		TrustDomainId: "spiffe://otherdomain.org",
+		Mode:          datastore.DeleteBundleRequest_DISSOCIATE,
+	})
+	s.Require().NoError(err)
+
+	s.deleteRegistrationEntry(entry2.EntryId)
+
+	resp = s.listRegistrationEntryEvents(0, 0)
+	s.Require().Equal(int64(1), resp.OldestId)
+	s.Require().Equal(int64(5), resp.LatestId)
+	s.requireRegistrationEntryEvents(resp.Events,
+		datastore.RegistrationEntryEvent_CREATE, entry1.EntryId,
+		datastore.RegistrationEntryEvent_CREATE, entry2.EntryId,
+		datastore.RegistrationEntryEvent_UPDATE, entry2.EntryId,
+		datastore.RegistrationEntryEvent_UPDATE, entry1.EntryId,
+		datastore.RegistrationEntryEvent_DELETE, entry2.EntryId,
+	)
+
+	// only events after the requested ID are listed, up to the limit
+	resp = s.listRegistrationEntryEvents(2, 2)
+	s.Require().Equal(int64(5), resp.LatestId)
+	s.requireRegistrationEntryEvents(resp.Events,
+		datastore.RegistrationEntryEvent_UPDATE, entry2.EntryId,
+		datastore.RegistrationEntryEvent_UPDATE, entry1.EntryId,
+	)
+
+	resp = s.listRegistrationEntryEvents(5, 0)
+	s.Require().Equal(int64(5), resp.LatestId)
+	s.Require().Empty(resp.Events)
+
+	// failed mutations don't record events
+	_, err = s.ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{
+		EntryId: entry2.EntryId,
+	})
+	s.Require().Error(err)
+	s.Require().Equal(int64(5), s.listRegistrationEntryEvents(0, 0).LatestId)
+}
+
+func (s *PluginSuite) TestDeleteBundleRecordsRegistrationEntryEvents() {
+	s.createBundle("spiffe://otherdomain.org")
+	entry := s.createRegistrationEntry(makeFederatedRegistrationEntry())
+
+	_, err := s.ds.DeleteBundle(ctx, &datastore.DeleteBundleRequest{
+		TrustDomainId: "spiffe://otherdomain.org",
+		Mode:          datastore.DeleteBundleRequest_DELETE,
+	})
+	s.Require().NoError(err)
+
+	s.requireRegistrationEntryEvents(s.listRegistrationEntryEvents(0, 0).Events,
+		datastore.RegistrationEntryEvent_CREATE, entry.EntryId,
+		datastore.RegistrationEntryEvent_DELETE, entry.EntryId,
+	)
+}
+
+func (s *PluginSuite) TestPruneRegistrationEntryEvents() {
+	entry := s.createRegistrationEntry(&common.RegistrationEntry{
+		SpiffeId:  "spiffe://example.org/foo",
+		Selectors: []*common.Selector{{Type: "TYPE", Value: "VALUE"}},
+	})
+	s.deleteRegistrationEntry(entry.EntryId)
+
+	// events newer than the requested time are not pruned
+	_, err := s.ds.PruneRegistrationEntryEvents(ctx, &datastore.PruneRegistrationEntryEventsRequest{
+		CreatedBefore: time.Now().Add(-time.Minute).Unix(),
+	})
+	s.Require().NoError(err)
+	s.Require().Len(s.listRegistrationEntryEvents(0, 0).Events, 2)
+
+	// older events are pruned, except for the latest one
+	_, err = s.ds.PruneRegistrationEntryEvents(ctx, &datastore.PruneRegistrationEntryEventsRequest{
+		CreatedBefore: time.Now().Add(time.Minute).Unix(),
+	})
+	s.Require().NoError(err)
+	resp := s.listRegistrationEntryEvents(0, 0)
+	s.Require().Equal(int64(2), resp.OldestId)
+	s.Require().Equal(int64(2), resp.LatestId)
+	s.Require().Equal(int64(1), resp.PrunedId)
+	s.requireRegistrationEntryEvents(resp.Events,
+		datastore.RegistrationEntryEvent_DELETE, entry.EntryId,
+	)
+
+	// the latest event pruned is kept track of across prunes
+	entry = s.createRegistrationEntry(&common.RegistrationEntry{
+		SpiffeId:  "spiffe://example.org/foo",
+		Selectors: []*common.Selector{{Type: "TYPE", Value: "VALUE"}},
+	})
+	_, err = s.ds.PruneRegistrationEntryEvents(ctx, &datastore.PruneRegistrationEntryEventsRequest{
+		CreatedBefore: time.Now().Add(-time.Minute).Unix(),
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(int64(1), s.listRegistrationEntryEvents(0, 0).PrunedId)
+
+	_, err = s.ds.PruneRegistrationEntryEvents(ctx, &datastore.PruneRegistrationEntryEventsRequest{
+		CreatedBefore: time.Now().Add(time.Minute).Unix(),
+	})
+	s.Require().NoError(err)
+	resp = s.listRegistrationEntryEvents(0, 0)
+	s.Require().Equal(int64(3), resp.OldestId)
+	s.Require().Equal(int64(2), resp.PrunedId)
+	s.requireRegistrationEntryEvents(resp.Events,
+		datastore.RegistrationEntryEvent_CREATE, entry.EntryId,
+	)
 }
 
 func (s *PluginSuite) TestCreateJoinToken() {
@@ -1354,6 +2316,177 @@ func (s *PluginSuite) TestDeleteJoinToken() {
 	s.AssertProtoEqual(joinToken2, resp.JoinToken)
 }
 
+func (s *PluginSuite) TestListJoinTokens() {
+	now := time.Now().Unix()
+	joinToken1 := &datastore.JoinToken{
+		Token:  "foobar",
+		Expiry: now + 10,
+	}
+	joinToken2 := &datastore.JoinToken{
+		Token:   "batbaz",
+		Expiry:  now,
+		MaxUses: 5,
+		NodeSelectors: []*common.Selector{
+			{Type: "type", Value: "value"},
+		},
+		Entries: []*common.RegistrationEntry{
+			{
+				SpiffeId:  "spiffe://example.org/foo",
+				Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+			},
+		},
+	}
+	for _, joinToken := range []*datastore.JoinToken{joinToken1, joinToken2} {
+		_, err := s.ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
+			JoinToken: joinToken,
+		})
+		s.Require().NoError(err)
+	}
+
+	resp, err := s.ds.ListJoinTokens(ctx, &datastore.ListJoinTokensRequest{})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*datastore.JoinToken{joinToken2, joinToken1}, resp.JoinTokens)
+}
+
+func (s *PluginSuite) TestConsumeJoinToken() {
+	now := time.Now().Unix()
+	entry := &common.RegistrationEntry{
+		SpiffeId:  "spiffe://example.org/foo",
+		Selectors: []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+		Ttl:       60,
+	}
+	joinToken := &datastore.JoinToken{
+		Token:   "foobar",
+		Expiry:  now,
+		MaxUses: 2,
+		NodeSelectors: []*common.Selector{
+			{Type: "type", Value: "value"},
+		},
+		Entries: []*common.RegistrationEntry{entry},
+	}
+	_, err := s.ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
+		JoinToken: joinToken,
+	})
+	s.Require().NoError(err)
+
+	consume := func(agentID string) *datastore.ConsumeJoinTokenResponse {
+		resp, err := s.ds.ConsumeJoinToken(ctx, &datastore.ConsumeJoinTokenRequest{
+			Token:   joinToken.Token,
+			AgentId: agentID,
+		})
+		s.Require().NoError(err)
+		return resp
+	}
+
+	// The first use is counted and the entries are created for the agent
+	resp := consume("spiffe://example.org/spire/agent/join_token/foobar/1")
+	s.Require().NotNil(resp.JoinToken)
+	s.Equal(int32(1), resp.JoinToken.Uses)
+	s.RequireProtoListEqual(joinToken.NodeSelectors, resp.JoinToken.NodeSelectors)
+	s.Require().Len(resp.Entries, 1)
+	s.Equal("spiffe://example.org/spire/agent/join_token/foobar/1", resp.Entries[0].ParentId)
+	s.Equal(entry.SpiffeId, resp.Entries[0].SpiffeId)
+	s.NotEmpty(resp.Entries[0].EntryId)
+
+	fetchResp, err := s.ds.FetchRegistrationEntry(ctx, &datastore.FetchRegistrationEntryRequest{
+		EntryId: resp.Entries[0].EntryId,
+	})
+	s.Require().NoError(err)
+	s.AssertProtoEqual(resp.Entries[0], fetchResp.Entry)
+
+	tokenResp, err := s.ds.FetchJoinToken(ctx, &datastore.FetchJoinTokenRequest{
+		Token: joinToken.Token,
+	})
+	s.Require().NoError(err)
+	s.Require().NotNil(tokenResp.JoinToken)
+	s.Equal(int32(1), tokenResp.JoinToken.Uses)
+
+	// The last use deletes the token
+	resp = consume("spiffe://example.org/spire/agent/join_token/foobar/2")
+	s.Require().NotNil(resp.JoinToken)
+	s.Equal(int32(2), resp.JoinToken.Uses)
+	s.Require().Len(resp.Entries, 1)
+	s.Equal("spiffe://example.org/spire/agent/join_token/foobar/2", resp.Entries[0].ParentId)
+
+	tokenResp, err = s.ds.FetchJoinToken(ctx, &datastore.FetchJoinTokenRequest{
+		Token: joinToken.Token,
+	})
+	s.Require().NoError(err)
+	s.Nil(tokenResp.JoinToken)
+
+	// The token can't be used anymore
+	resp = consume("spiffe://example.org/spire/agent/join_token/foobar/3")
+	s.Nil(resp.JoinToken)
+	s.Empty(resp.Entries)
+}
+
+func (s *PluginSuite) TestConsumeSingleUseJoinToken() {
+	_, err := s.ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
+		JoinToken: &datastore.JoinToken{
+			Token:  "foobar",
+			Expiry: time.Now().Unix(),
+		},
+	})
+	s.Require().NoError(err)
+
+	resp, err := s.ds.ConsumeJoinToken(ctx, &datastore.ConsumeJoinTokenRequest{
+		Token:   "foobar",
+		AgentId: "spiffe://example.org/spire/agent/join_token/foobar",
+	})
+	s.Require().NoError(err)
+	s.Require().NotNil(resp.JoinToken)
+	s.Empty(resp.Entries)
+
+	tokenResp, err := s.ds.FetchJoinToken(ctx, &datastore.FetchJoinTokenRequest{
+		Token: "foobar",
+	})
+	s.Require().NoError(err)
+	s.Nil(tokenResp.JoinToken)
+}
+
+func (s *PluginSuite) TestConsumeJoinTokenConcurrently() {
+	const maxUses = 3
+	_, err := s.ds.CreateJoinToken(ctx, &datastore.CreateJoinTokenRequest{
+		JoinToken: &datastore.JoinToken{
+			Token:   "foobar",
+			Expiry:  time.Now().Unix(),
+			MaxUses: maxUses,
+		},
+	})
+	s.Require().NoError(err)
+
+	// Only as many attempts as the token allows succeed, however they race
+	const attempts = 10
+	errCh := make(chan error, attempts)
+	usedCh := make(chan bool, attempts)
+	for i := 0; i < attempts; i++ {
+		agentID := fmt.Sprintf("spiffe://example.org/spire/agent/join_token/foobar/%d", i)
+		go func() {
+			resp, err := s.ds.ConsumeJoinToken(ctx, &datastore.ConsumeJoinTokenRequest{
+				Token:   "foobar",
+				AgentId: agentID,
+			})
+			errCh <- err
+			usedCh <- err == nil && resp.JoinToken != nil
+		}()
+	}
+
+	used := 0
+	for i := 0; i < attempts; i++ {
+		s.Require().NoError(<-errCh)
+		if <-usedCh {
+			used++
+		}
+	}
+	s.Equal(maxUses, used)
+
+	tokenResp, err := s.ds.FetchJoinToken(ctx, &datastore.FetchJoinTokenRequest{
+		Token: "foobar",
+	})
+	s.Require().NoError(err)
+	s.Nil(tokenResp.JoinToken)
+}
+
 func (s *PluginSuite) TestPruneJoinTokens() {
 	now := time.Now().Unix()
 	joinToken := &datastore.JoinToken{
@@ -1400,6 +2533,372 @@ func (s *PluginSuite) TestPruneJoinTokens() {
 	s.Nil(resp.JoinToken)
 }
 
+func (s *PluginSuite) TestCAJournal() {
+	// fetching a journal that does not exist returns nothing
+	fresp, err := s.ds.FetchCAJournal(ctx, &datastore.FetchCAJournalRequest{
+		Id: "spiffe://example.org",
+	})
+	s.Require().NoError(err)
+	s.Require().Nil(fresp.Journal)
+
+	// creating the journal requires a zero revision
+	_, err = s.ds.SetCAJournal(ctx, &datastore.SetCAJournalRequest{
+		Journal: &datastore.CAJournal{Id: "spiffe://example.org", Data: []byte("ONE"), Revision: 1},
+	})
+	s.RequireGRPCStatus(err, codes.Aborted, `journal "spiffe://example.org" revision mismatch: expected 1, got none`)
+
+	sresp, err := s.ds.SetCAJournal(ctx, &datastore.SetCAJournalRequest{
+		Journal: &datastore.CAJournal{Id: "spiffe://example.org", Data: []byte("ONE")},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(&datastore.CAJournal{Id: "spiffe://example.org", Data: []byte("ONE"), Revision: 1}, sresp.Journal)
+
+	// a second create loses
+	_, err = s.ds.SetCAJournal(ctx, &datastore.SetCAJournalRequest{
+		Journal: &datastore.CAJournal{Id: "spiffe://example.org", Data: []byte("TWO")},
+	})
+	s.RequireGRPCStatus(err, codes.Aborted, `journal "spiffe://example.org" revision mismatch: expected 0, got 1`)
+
+	// updating with the current revision bumps the revision
+	sresp, err = s.ds.SetCAJournal(ctx, &datastore.SetCAJournalRequest{
+		Journal: &datastore.CAJournal{Id: "spiffe://example.org", Data: []byte("TWO"), Revision: 1},
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(&datastore.CAJournal{Id: "spiffe://example.org", Data: []byte("TWO"), Revision: 2}, sresp.Journal)
+
+	// updating with a stale revision fails and leaves the journal alone
+	_, err = s.ds.SetCAJournal(ctx, &datastore.SetCAJournalRequest{
+		Journal: &datastore.CAJournal{Id: "spiffe://example.org", Data: []byte("THREE"), Revision: 1},
+	})
+	s.RequireGRPCStatus(err, codes.Aborted, `journal "spiffe://example.org" revision mismatch: expected 1, got 2`)
+
+	fresp, err = s.ds.FetchCAJournal(ctx, &datastore.FetchCAJournalRequest{
+		Id: "spiffe://example.org",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(&datastore.CAJournal{Id: "spiffe://example.org", Data: []byte("TWO"), Revision: 2}, fresp.Journal)
+
+	// journals are independent of each other
+	fresp, err = s.ds.FetchCAJournal(ctx, &datastore.FetchCAJournalRequest{
+		Id: "spiffe://otherdomain.org",
+	})
+	s.Require().NoError(err)
+	s.Require().Nil(fresp.Journal)
+}
+
+func (s *PluginSuite) TestCAJournalConcurrentCreate() {
+	p, ds := s.newSQLPlugin()
+
+	_, err := ds.SetCAJournal(ctx, &datastore.SetCAJournalRequest{
+		Journal: &datastore.CAJournal{Id: "spiffe://example.org", Data: []byte("ONE")},
+	})
+	s.Require().NoError(err)
+
+	// a server that did not find the journal and lost the race to create it
+	// hits the unique index, which is reported as a revision mismatch so
+	// that it reloads the journal instead of failing.
+	_, err = createCAJournal(p.db.DB, &datastore.CAJournal{Id: "spiffe://example.org", Data: []byte("TWO")})
+	s.RequireGRPCStatus(err, codes.Aborted, `journal "spiffe://example.org" revision mismatch: expected 0, created concurrently`)
+}
+
+func (s *PluginSuite) TestLease() {
+	acquire := func(holderID string, now, expiresAt int64) *datastore.Lease {
+		resp, err := s.ds.AcquireLease(ctx, &datastore.AcquireLeaseRequest{
+			Name:      "spire-server",
+			HolderId:  holderID,
+			Now:       now,
+			ExpiresAt: expiresAt,
+		})
+		s.Require().NoError(err)
+		return resp.Lease
+	}
+
+	// the first holder acquires the unheld lease
+	s.Require().Equal(&datastore.Lease{Name: "spire-server", HolderId: "A", ExpiresAt: 30}, acquire("A", 0, 30))
+
+	// another holder cannot acquire the lease before it expires
+	s.Require().Equal(&datastore.Lease{Name: "spire-server", HolderId: "A", ExpiresAt: 30}, acquire("B", 10, 40))
+
+	// the holder can renew the lease
+	s.Require().Equal(&datastore.Lease{Name: "spire-server", HolderId: "A", ExpiresAt: 50}, acquire("A", 20, 50))
+
+	// another holder can acquire the lease once it expires
+	s.Require().Equal(&datastore.Lease{Name: "spire-server", HolderId: "B", ExpiresAt: 80}, acquire("B", 50, 80))
+
+	// leases are independent of each other
+	resp, err := s.ds.AcquireLease(ctx, &datastore.AcquireLeaseRequest{
+		Name:      "other",
+		HolderId:  "A",
+		Now:       60,
+		ExpiresAt: 90,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(&datastore.Lease{Name: "other", HolderId: "A", ExpiresAt: 90}, resp.Lease)
+
+	// releasing a lease held by someone else does nothing
+	rresp, err := s.ds.ReleaseLease(ctx, &datastore.ReleaseLeaseRequest{
+		Name:     "spire-server",
+		HolderId: "A",
+	})
+	s.Require().NoError(err)
+	s.Require().Nil(rresp.Lease)
+
+	// the holder can release the lease, after which anyone can acquire it
+	rresp, err = s.ds.ReleaseLease(ctx, &datastore.ReleaseLeaseRequest{
+		Name:     "spire-server",
+		HolderId: "B",
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(&datastore.Lease{Name: "spire-server", HolderId: "B", ExpiresAt: 80}, rresp.Lease)
+	s.Require().Equal(&datastore.Lease{Name: "spire-server", HolderId: "A", ExpiresAt: 90}, acquire("A", 60, 90))
+
+	// name and holder are required
+	_, err = s.ds.AcquireLease(ctx, &datastore.AcquireLeaseRequest{HolderId: "A"})
+	s.RequireGRPCStatus(err, codes.Unknown, "datastore-sql: invalid request: missing lease name")
+	_, err = s.ds.AcquireLease(ctx, &datastore.AcquireLeaseRequest{Name: "spire-server"})
+	s.RequireGRPCStatus(err, codes.Unknown, "datastore-sql: invalid request: missing lease holder id")
+}
+
+func (s *PluginSuite) TestRevokedCertificates() {
+	revoked1 := &datastore.RevokedCertificate{
+		SerialNumber: "1",
+		SpiffeId:     "spiffe://example.org/spire/agent/foo",
+		ExpiresAt:    10,
+		RevokedAt:    1,
+	}
+	revoked2 := &datastore.RevokedCertificate{
+		SerialNumber: "2",
+		SpiffeId:     "spiffe://example.org/downstream",
+		ExpiresAt:    20,
+		RevokedAt:    2,
+	}
+
+	// serial numbers that have not been revoked are not found
+	fresp, err := s.ds.FetchRevokedCertificate(ctx, &datastore.FetchRevokedCertificateRequest{SerialNumber: "1"})
+	s.Require().NoError(err)
+	s.Require().Nil(fresp.RevokedCertificate)
+
+	for _, revoked := range []*datastore.RevokedCertificate{revoked1, revoked2} {
+		resp, err := s.ds.RevokeCertificate(ctx, &datastore.RevokeCertificateRequest{
+			RevokedCertificate: revoked,
+		})
+		s.Require().NoError(err)
+		s.RequireProtoEqual(revoked, resp.RevokedCertificate)
+	}
+
+	// revoking a revoked certificate again keeps the original revocation
+	resp, err := s.ds.RevokeCertificate(ctx, &datastore.RevokeCertificateRequest{
+		RevokedCertificate: &datastore.RevokedCertificate{SerialNumber: "1", ExpiresAt: 10, RevokedAt: 5},
+	})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(revoked1, resp.RevokedCertificate)
+
+	fresp, err = s.ds.FetchRevokedCertificate(ctx, &datastore.FetchRevokedCertificateRequest{SerialNumber: "1"})
+	s.Require().NoError(err)
+	s.RequireProtoEqual(revoked1, fresp.RevokedCertificate)
+
+	lresp, err := s.ds.ListRevokedCertificates(ctx, &datastore.ListRevokedCertificatesRequest{})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*datastore.RevokedCertificate{revoked1, revoked2}, lresp.RevokedCertificates)
+
+	// pruning only removes revocations of certificates that have expired
+	_, err = s.ds.PruneRevokedCertificates(ctx, &datastore.PruneRevokedCertificatesRequest{ExpiresBefore: 10})
+	s.Require().NoError(err)
+	lresp, err = s.ds.ListRevokedCertificates(ctx, &datastore.ListRevokedCertificatesRequest{})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*datastore.RevokedCertificate{revoked1, revoked2}, lresp.RevokedCertificates)
+
+	_, err = s.ds.PruneRevokedCertificates(ctx, &datastore.PruneRevokedCertificatesRequest{ExpiresBefore: 11})
+	s.Require().NoError(err)
+	lresp, err = s.ds.ListRevokedCertificates(ctx, &datastore.ListRevokedCertificatesRequest{})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*datastore.RevokedCertificate{revoked2}, lresp.RevokedCertificates)
+
+	// the serial number is required
+	_, err = s.ds.RevokeCertificate(ctx, &datastore.RevokeCertificateRequest{})
+	s.RequireGRPCStatus(err, codes.Unknown, "datastore-sql: invalid request: missing revoked certificate")
+	_, err = s.ds.RevokeCertificate(ctx, &datastore.RevokeCertificateRequest{
+		RevokedCertificate: &datastore.RevokedCertificate{},
+	})
+	s.RequireGRPCStatus(err, codes.Unknown, "datastore-sql: invalid request: missing serial number")
+}
+
+func (s *PluginSuite) TestDownstreamCAs() {
+	downstreamCA1 := &datastore.DownstreamCA{
+		SerialNumber: "1",
+		SpiffeId:     "spiffe://example.org/downstream1",
+		AgentId:      "spiffe://example.org/spire/agent/foo",
+		ExpiresAt:    10,
+	}
+	downstreamCA2 := &datastore.DownstreamCA{
+		SerialNumber: "2",
+		SpiffeId:     "spiffe://example.org/downstream2",
+		AgentId:      "spiffe://example.org/spire/agent/bar",
+		ExpiresAt:    20,
+	}
+
+	for _, downstreamCA := range []*datastore.DownstreamCA{downstreamCA1, downstreamCA2} {
+		resp, err := s.ds.CreateDownstreamCA(ctx, &datastore.CreateDownstreamCARequest{
+			DownstreamCa: downstreamCA,
+		})
+		s.Require().NoError(err)
+		s.RequireProtoEqual(downstreamCA, resp.DownstreamCa)
+	}
+
+	// serial numbers are unique
+	_, err := s.ds.CreateDownstreamCA(ctx, &datastore.CreateDownstreamCARequest{
+		DownstreamCa: downstreamCA1,
+	})
+	s.Require().Error(err)
+
+	lresp, err := s.ds.ListDownstreamCAs(ctx, &datastore.ListDownstreamCAsRequest{})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*datastore.DownstreamCA{downstreamCA1, downstreamCA2}, lresp.DownstreamCas)
+
+	lresp, err = s.ds.ListDownstreamCAs(ctx, &datastore.ListDownstreamCAsRequest{
+		AgentId: "spiffe://example.org/spire/agent/bar",
+	})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*datastore.DownstreamCA{downstreamCA2}, lresp.DownstreamCas)
+
+	_, err = s.ds.PruneDownstreamCAs(ctx, &datastore.PruneDownstreamCAsRequest{ExpiresBefore: 11})
+	s.Require().NoError(err)
+	lresp, err = s.ds.ListDownstreamCAs(ctx, &datastore.ListDownstreamCAsRequest{})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*datastore.DownstreamCA{downstreamCA2}, lresp.DownstreamCas)
+
+	// the serial number is required
+	_, err = s.ds.CreateDownstreamCA(ctx, &datastore.CreateDownstreamCARequest{})
+	s.RequireGRPCStatus(err, codes.Unknown, "datastore-sql: invalid request: missing downstream CA")
+	_, err = s.ds.CreateDownstreamCA(ctx, &datastore.CreateDownstreamCARequest{
+		DownstreamCa: &datastore.DownstreamCA{},
+	})
+	s.RequireGRPCStatus(err, codes.Unknown, "datastore-sql: invalid request: missing serial number")
+}
+
+func (s *PluginSuite) TestIssuedSVIDs() {
+	issuedSVID1 := &datastore.IssuedSVID{
+		Id:          "1",
+		Type:        datastore.IssuedSVID_X509_SVID,
+		SpiffeId:    "spiffe://example.org/foo",
+		EntryId:     "entry1",
+		AgentId:     "spiffe://example.org/spire/agent/foo",
+		AuthorityId: "authority1",
+		NotBefore:   10,
+		NotAfter:    20,
+	}
+	issuedSVID2 := &datastore.IssuedSVID{
+		Id:          "jti",
+		Type:        datastore.IssuedSVID_JWT_SVID,
+		SpiffeId:    "spiffe://example.org/foo",
+		EntryId:     "entry1",
+		AgentId:     "spiffe://example.org/spire/agent/bar",
+		AuthorityId: "kid",
+		NotBefore:   20,
+		NotAfter:    30,
+	}
+	issuedSVID3 := &datastore.IssuedSVID{
+		Id:          "2",
+		Type:        datastore.IssuedSVID_X509_CA_SVID,
+		SpiffeId:    "spiffe://example.org/downstream",
+		EntryId:     "entry2",
+		AgentId:     "spiffe://example.org/spire/agent/bar",
+		AuthorityId: "authority1",
+		NotBefore:   30,
+		NotAfter:    40,
+	}
+
+	for _, issuedSVID := range []*datastore.IssuedSVID{issuedSVID1, issuedSVID2, issuedSVID3} {
+		resp, err := s.ds.CreateIssuedSVID(ctx, &datastore.CreateIssuedSVIDRequest{
+			IssuedSvid: issuedSVID,
+		})
+		s.Require().NoError(err)
+		s.RequireProtoEqual(issuedSVID, resp.IssuedSvid)
+	}
+
+	for _, tt := range []struct {
+		name     string
+		req      *datastore.ListIssuedSVIDsRequest
+		expected []*datastore.IssuedSVID
+	}{
+		{
+			name:     "all",
+			req:      &datastore.ListIssuedSVIDsRequest{},
+			expected: []*datastore.IssuedSVID{issuedSVID1, issuedSVID2, issuedSVID3},
+		},
+		{
+			name: "by SPIFFE ID",
+			req: &datastore.ListIssuedSVIDsRequest{
+				BySpiffeId: &wrappers.StringValue{Value: "spiffe://example.org/foo"},
+			},
+			expected: []*datastore.IssuedSVID{issuedSVID1, issuedSVID2},
+		},
+		{
+			name: "by agent ID",
+			req: &datastore.ListIssuedSVIDsRequest{
+				ByAgentId: &wrappers.StringValue{Value: "spiffe://example.org/spire/agent/bar"},
+			},
+			expected: []*datastore.IssuedSVID{issuedSVID2, issuedSVID3},
+		},
+		{
+			name: "by time range",
+			req: &datastore.ListIssuedSVIDsRequest{
+				IssuedAfter:  15,
+				IssuedBefore: 30,
+			},
+			expected: []*datastore.IssuedSVID{issuedSVID2},
+		},
+		{
+			name: "combined filters",
+			req: &datastore.ListIssuedSVIDsRequest{
+				BySpiffeId:  &wrappers.StringValue{Value: "spiffe://example.org/foo"},
+				ByAgentId:   &wrappers.StringValue{Value: "spiffe://example.org/spire/agent/bar"},
+				IssuedAfter: 30,
+			},
+			expected: nil,
+		},
+	} {
+		tt := tt
+		s.T().Run(tt.name, func(t *testing.T) {
+			resp, err := s.ds.ListIssuedSVIDs(ctx, tt.req)
+			require.NoError(t, err)
+			spiretest.RequireProtoListEqual(t, tt.expected, resp.IssuedSvids)
+		})
+	}
+
+	// paginate through the records
+	pagination := &datastore.Pagination{PageSize: 2}
+	lresp, err := s.ds.ListIssuedSVIDs(ctx, &datastore.ListIssuedSVIDsRequest{
+		Pagination: pagination,
+	})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*datastore.IssuedSVID{issuedSVID1, issuedSVID2}, lresp.IssuedSvids)
+	lresp, err = s.ds.ListIssuedSVIDs(ctx, &datastore.ListIssuedSVIDsRequest{
+		Pagination: lresp.Pagination,
+	})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*datastore.IssuedSVID{issuedSVID3}, lresp.IssuedSvids)
+	lresp, err = s.ds.ListIssuedSVIDs(ctx, &datastore.ListIssuedSVIDsRequest{
+		Pagination: lresp.Pagination,
+	})
+	s.Require().NoError(err)
+	s.Require().Empty(lresp.IssuedSvids)
+
+	_, err = s.ds.PruneIssuedSVIDs(ctx, &datastore.PruneIssuedSVIDsRequest{ExpiresBefore: 30})
+	s.Require().NoError(err)
+	lresp, err = s.ds.ListIssuedSVIDs(ctx, &datastore.ListIssuedSVIDsRequest{})
+	s.Require().NoError(err)
+	s.RequireProtoListEqual([]*datastore.IssuedSVID{issuedSVID2, issuedSVID3}, lresp.IssuedSvids)
+
+	// the SVID ID is required
+	_, err = s.ds.CreateIssuedSVID(ctx, &datastore.CreateIssuedSVIDRequest{})
+	s.RequireGRPCStatus(err, codes.Unknown, "datastore-sql: invalid request: missing issued SVID")
+	_, err = s.ds.CreateIssuedSVID(ctx, &datastore.CreateIssuedSVIDRequest{
+		IssuedSvid: &datastore.IssuedSVID{},
+	})
+	s.RequireGRPCStatus(err, codes.Unknown, "datastore-sql: invalid request: missing SVID ID")
+}
+
 func (s *PluginSuite) TestGetPluginInfo() {
 	resp, err := s.ds.GetPluginInfo(ctx, &spi.GetPluginInfoRequest{})
 	s.Require().NoError(err)
@@ -1561,6 +3060,155 @@ func (s *PluginSuite) TestMigration() {
 			s.Require().True(db.Dialect().HasIndex("registered_entries", "idx_registered_entries_parent_id"))
 			s.Require().True(db.Dialect().HasIndex("registered_entries", "idx_registered_entries_spiffe_id"))
 			s.Require().True(db.Dialect().HasIndex("selectors", "idx_selectors_type_value"))
+		case 9:
+			// the ca_journals table should be created
+			resp, err := s.ds.SetCAJournal(context.Background(), &datastore.SetCAJournalRequest{
+				Journal: &datastore.CAJournal{Id: "spiffe://example.org", Data: []byte("DATA")},
+			})
+			s.Require().NoError(err)
+			s.Require().Equal(int64(1), resp.Journal.Revision)
+		case 10:
+			// the leases table should be created
+			resp, err := s.ds.AcquireLease(context.Background(), &datastore.AcquireLeaseRequest{
+				Name:      "spire-server",
+				HolderId:  "A",
+				Now:       1,
+				ExpiresAt: 2,
+			})
+			s.Require().NoError(err)
+			s.Require().Equal("A", resp.Lease.HolderId)
+		case 11:
+			// the revoked_certificates and downstream_cas tables should be
+			// created
+			_, err := s.ds.RevokeCertificate(context.Background(), &datastore.RevokeCertificateRequest{
+				RevokedCertificate: &datastore.RevokedCertificate{SerialNumber: "1"},
+			})
+			s.Require().NoError(err)
+			_, err = s.ds.CreateDownstreamCA(context.Background(), &datastore.CreateDownstreamCARequest{
+				DownstreamCa: &datastore.DownstreamCA{SerialNumber: "2"},
+			})
+			s.Require().NoError(err)
+		case 12:
+			// the issued_svids table should be created
+			_, err := s.ds.CreateIssuedSVID(context.Background(), &datastore.CreateIssuedSVIDRequest{
+				IssuedSvid: &datastore.IssuedSVID{Id: "1"},
+			})
+			s.Require().NoError(err)
+		case 13:
+			// the x509_svid_template column should be added
+			resp, err := s.ds.ListRegistrationEntries(context.Background(), &datastore.ListRegistrationEntriesRequest{})
+			s.Require().NoError(err)
+			s.Require().Len(resp.Entries, 1)
+			s.Require().Nil(resp.Entries[0].X509SvidTemplate)
+
+			template := &common.X509SVIDTemplate{
+				Subject:        &common.X509Subject{OrganizationalUnit: []string{"gateway"}},
+				ExtraKeyUsages: []string{"data_encipherment"},
+			}
+			resp.Entries[0].X509SvidTemplate = template
+			_, err = s.ds.UpdateRegistrationEntry(context.Background(), &datastore.UpdateRegistrationEntryRequest{
+				Entry: resp.Entries[0],
+			})
+			s.Require().NoError(err)
+
+			resp, err = s.ds.ListRegistrationEntries(context.Background(), &datastore.ListRegistrationEntriesRequest{})
+			s.Require().NoError(err)
+			s.Require().Len(resp.Entries, 1)
+			s.RequireProtoEqual(template, resp.Entries[0].X509SvidTemplate)
+		case 14:
+			// the jwt_svid_ttl and jwt_svid_claims columns should be added
+			resp, err := s.ds.ListRegistrationEntries(context.Background(), &datastore.ListRegistrationEntriesRequest{})
+			s.Require().NoError(err)
+			s.Require().Len(resp.Entries, 1)
+			s.Require().Zero(resp.Entries[0].JwtSvidTtl)
+			s.Require().Empty(resp.Entries[0].JwtSvidClaims)
+
+			resp.Entries[0].JwtSvidTtl = 300
+			resp.Entries[0].JwtSvidClaims = map[string]string{"tenant": "acme"}
+			_, err = s.ds.UpdateRegistrationEntry(context.Background(), &datastore.UpdateRegistrationEntryRequest{
+				Entry: resp.Entries[0],
+			})
+			s.Require().NoError(err)
+
+			resp, err = s.ds.ListRegistrationEntries(context.Background(), &datastore.ListRegistrationEntriesRequest{})
+			s.Require().NoError(err)
+			s.Require().Len(resp.Entries, 1)
+			s.Require().Equal(int32(300), resp.Entries[0].JwtSvidTtl)
+			s.Require().Equal(map[string]string{"tenant": "acme"}, resp.Entries[0].JwtSvidClaims)
+		case 15:
+			// existing entries and bundles should be at revision zero and
+			// the revision should be tracked from then on
+			resp, err := s.ds.ListRegistrationEntries(context.Background(), &datastore.ListRegistrationEntriesRequest{})
+			s.Require().NoError(err)
+			s.Require().Len(resp.Entries, 1)
+			s.Require().Zero(resp.Entries[0].RevisionNumber)
+
+			bundleResp, err := s.ds.FetchBundle(context.Background(), &datastore.FetchBundleRequest{
+				TrustDomainId: "spiffe://example.org",
+			})
+			s.Require().NoError(err)
+			s.Require().Zero(bundleResp.Bundle.RevisionNumber)
+
+			deleteResp, err := s.ds.DeleteRegistrationEntry(context.Background(), &datastore.DeleteRegistrationEntryRequest{
+				EntryId: resp.Entries[0].EntryId,
+			})
+			s.Require().NoError(err)
+
+			tombstonesResp, err := s.ds.ListRegistrationEntryTombstones(context.Background(), &datastore.ListRegistrationEntryTombstonesRequest{})
+			s.Require().NoError(err)
+			s.Require().Equal(int64(1), tombstonesResp.Revision)
+			s.Require().Equal([]string{deleteResp.Entry.EntryId}, tombstonesResp.EntryIds)
+		case 16:
+			// existing nodes should not be banned
+			resp, err := s.ds.ListAttestedNodes(context.Background(), &datastore.ListAttestedNodesRequest{
+				ByBanned: &wrappers.BoolValue{Value: false},
+			})
+			s.Require().NoError(err)
+			s.Require().Len(resp.Nodes, 1)
+			s.Require().False(resp.Nodes[0].Banned)
+		case 17:
+			// existing tokens should be single use and unused
+			resp, err := s.ds.FetchJoinToken(context.Background(), &datastore.FetchJoinTokenRequest{
+				Token: "foobar",
+			})
+			s.Require().NoError(err)
+			s.Require().NotNil(resp.JoinToken)
+			s.Require().Equal(int32(0), resp.JoinToken.MaxUses)
+			s.Require().Equal(int32(0), resp.JoinToken.Uses)
+		case 18:
+			// the change log starts empty and records changes from then on
+			resp := s.listRegistrationEntryEvents(0, 0)
+			s.Require().Empty(resp.Events)
+			s.Require().Zero(resp.LatestId)
+
+			s.deleteRegistrationEntry("f0373f87-a0f3-4c94-aa6a-a2f948bfc15a")
+			s.requireRegistrationEntryEvents(s.listRegistrationEntryEvents(0, 0).Events,
+				datastore.RegistrationEntryEvent_DELETE, "f0373f87-a0f3-4c94-aa6a-a2f948bfc15a",
+			)
+		case 19:
+			// node selectors set from then on are recorded as events
+			resp := s.listNodeSelectorsAfterEvent(0)
+			s.Require().Empty(resp.Selectors)
+			s.Require().Zero(resp.LatestEventId)
+
+			nodeID := "spiffe://example.org/spire/agent/x509pop/e81aef2e9178db3db836a1a85d362ca5b2241631"
+			selectors := []*common.Selector{{Type: "TYPE", Value: "VALUE"}}
+			s.setNodeSelectors(nodeID, selectors)
+			resp = s.listNodeSelectorsAfterEvent(0)
+			s.RequireProtoListEqual([]*datastore.NodeSelectors{
+				{SpiffeId: nodeID, Selectors: selectors},
+			}, resp.Selectors)
+			s.Require().NotZero(resp.LatestEventId)
+		case 20:
+			db, err := sqlite{}.connect(&configuration{
+				DatabaseType:     "sqlite3",
+				ConnectionString: fmt.Sprintf("file://%s", dbPath),
+			})
+			s.Require().NoError(err)
+			s.Require().True(db.Dialect().HasIndex("node_resolver_map_entries", "idx_node_resolver_map_type_value"))
+		case 21:
+			// nothing has been pruned yet
+			s.Require().Zero(s.listRegistrationEntryEvents(0, 0).PrunedId)
 		default:
 			s.T().Fatalf("no migration test added for version %d", i)
 		}
@@ -1637,6 +3285,44 @@ func (s *PluginSuite) fetchRegistrationEntry(entryID string) *common.Registratio
 	return resp.Entry
 }
 
+func (s *PluginSuite) deleteRegistrationEntry(entryID string) {
+	_, err := s.ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{
+		EntryId: entryID,
+	})
+	s.Require().NoError(err)
+}
+
+func (s *PluginSuite) listRegistrationEntryTombstones(afterRevision int64) *datastore.ListRegistrationEntryTombstonesResponse {
+	resp, err := s.ds.ListRegistrationEntryTombstones(ctx, &datastore.ListRegistrationEntryTombstonesRequest{
+		AfterRevision: afterRevision,
+	})
+	s.Require().NoError(err)
+	s.Require().NotNil(resp)
+	return resp
+}
+
+func (s *PluginSuite) listRegistrationEntryEvents(afterID int64, limit int32) *datastore.ListRegistrationEntryEventsResponse {
+	resp, err := s.ds.ListRegistrationEntryEvents(ctx, &datastore.ListRegistrationEntryEventsRequest{
+		AfterId: afterID,
+		Limit:   limit,
+	})
+	s.Require().NoError(err)
+	s.Require().NotNil(resp)
+	return resp
+}
+
+// requireRegistrationEntryEvents asserts the type and entry ID of each event,
+// which are passed in pairs
+func (s *PluginSuite) requireRegistrationEntryEvents(events []*datastore.RegistrationEntryEvent, expected ...interface{}) {
+	var actual []interface{}
+	for _, event := range events {
+		s.Require().NotZero(event.Id)
+		s.Require().NotZero(event.CreatedAt)
+		actual = append(actual, event.Type, event.EntryId)
+	}
+	s.Require().Equal(expected, actual)
+}
+
 func makeFederatedRegistrationEntry() *common.RegistrationEntry {
 	return &common.RegistrationEntry{
 		Selectors: []*common.Selector{
@@ -1658,6 +3344,22 @@ func (s *PluginSuite) getNodeSelectors(spiffeID string) []*common.Selector {
 	return resp.Selectors.Selectors
 }
 
+func (s *PluginSuite) listNodeSelectors() []*datastore.NodeSelectors {
+	resp, err := s.ds.ListNodeSelectors(ctx, &datastore.ListNodeSelectorsRequest{})
+	s.Require().NoError(err)
+	s.Require().NotNil(resp)
+	return resp.Selectors
+}
+
+func (s *PluginSuite) listNodeSelectorsAfterEvent(eventID int64) *datastore.ListNodeSelectorsResponse {
+	resp, err := s.ds.ListNodeSelectors(ctx, &datastore.ListNodeSelectorsRequest{
+		AfterEventId: &wrappers.Int64Value{Value: eventID},
+	})
+	s.Require().NoError(err)
+	s.Require().NotNil(resp)
+	return resp
+}
+
 func (s *PluginSuite) setNodeSelectors(spiffeID string, selectors []*common.Selector) {
 	resp, err := s.ds.SetNodeSelectors(ctx, &datastore.SetNodeSelectorsRequest{
 		Selectors: &datastore.NodeSelectors{
@@ -6,6 +6,7 @@ import (
 	"github.com/jinzhu/gorm"
 	// gorm sqlite dialect init registration
 	_ "github.com/jinzhu/gorm/dialects/sqlite"
+	sqlite3 "github.com/mattn/go-sqlite3"
 )
 
 type sqlite struct{}
@@ -54,3 +55,10 @@ func embellishSQLite3ConnString(connectionString string) (string, error) {
 	u.RawQuery = q.Encode()
 	return u.String(), nil
 }
+
+// isSQLiteUniqueViolation returns true if the error is a sqlite3 unique
+// constraint violation
+func isSQLiteUniqueViolation(err error) bool {
+	sqliteErr, ok := err.(sqlite3.Error)
+	return ok && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+}
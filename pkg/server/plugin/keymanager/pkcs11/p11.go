@@ -0,0 +1,289 @@
+package pkcs11
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/miekg/pkcs11"
+	"github.com/spiffe/spire/proto/spire/server/keymanager"
+)
+
+// p11Context is the subset of the PKCS#11 API used by the plugin. It is
+// satisfied by *pkcs11.Ctx and allows tests to substitute a fake module.
+type p11Context interface {
+	Initialize() error
+	Finalize() error
+	Destroy()
+	GetSlotList(tokenPresent bool) ([]uint, error)
+	GetTokenInfo(slotID uint) (pkcs11.TokenInfo, error)
+	OpenSession(slotID uint, flags uint) (pkcs11.SessionHandle, error)
+	CloseSession(sh pkcs11.SessionHandle) error
+	Login(sh pkcs11.SessionHandle, userType uint, pin string) error
+	FindObjectsInit(sh pkcs11.SessionHandle, temp []*pkcs11.Attribute) error
+	FindObjects(sh pkcs11.SessionHandle, max int) ([]pkcs11.ObjectHandle, bool, error)
+	FindObjectsFinal(sh pkcs11.SessionHandle) error
+	GetAttributeValue(sh pkcs11.SessionHandle, o pkcs11.ObjectHandle, a []*pkcs11.Attribute) ([]*pkcs11.Attribute, error)
+	GenerateKeyPair(sh pkcs11.SessionHandle, m []*pkcs11.Mechanism, public, private []*pkcs11.Attribute) (pkcs11.ObjectHandle, pkcs11.ObjectHandle, error)
+	DestroyObject(sh pkcs11.SessionHandle, oh pkcs11.ObjectHandle) error
+	SignInit(sh pkcs11.SessionHandle, m []*pkcs11.Mechanism, o pkcs11.ObjectHandle) error
+	Sign(sh pkcs11.SessionHandle, message []byte) ([]byte, error)
+}
+
+func newP11Context(modulePath string) (p11Context, error) {
+	ctx := pkcs11.New(modulePath)
+	if ctx == nil {
+		return nil, fmt.Errorf("unable to load PKCS#11 module %q", modulePath)
+	}
+	return ctx, nil
+}
+
+var (
+	oidNamedCurveP256 = asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7}
+	oidNamedCurveP384 = asn1.ObjectIdentifier{1, 3, 132, 0, 34}
+
+	// rsaExponent is the public exponent used for generated RSA keys (65537)
+	rsaExponent = []byte{0x01, 0x00, 0x01}
+)
+
+// keyPairTemplates returns the generation mechanism and the public and
+// private key templates used to generate a key pair of the given type. The
+// private key is marked sensitive and non-extractable so it never leaves the
+// token.
+func keyPairTemplates(label string, keyType keymanager.KeyType) ([]*pkcs11.Mechanism, []*pkcs11.Attribute, []*pkcs11.Attribute, error) {
+	public := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PUBLIC_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_VERIFY, true),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+		pkcs11.NewAttribute(pkcs11.CKA_ID, []byte(label)),
+	}
+	private := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PRIVATE_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_PRIVATE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_SIGN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, false),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+		pkcs11.NewAttribute(pkcs11.CKA_ID, []byte(label)),
+	}
+
+	var mechanism uint
+	switch keyType {
+	case keymanager.KeyType_EC_P256, keymanager.KeyType_EC_P384:
+		oid := oidNamedCurveP256
+		if keyType == keymanager.KeyType_EC_P384 {
+			oid = oidNamedCurveP384
+		}
+		ecParams, err := asn1.Marshal(oid)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		mechanism = pkcs11.CKM_EC_KEY_PAIR_GEN
+		public = append(public,
+			pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC),
+			pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, ecParams),
+		)
+		private = append(private, pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_EC))
+	case keymanager.KeyType_RSA_1024, keymanager.KeyType_RSA_2048, keymanager.KeyType_RSA_4096:
+		bits := 1024
+		switch keyType {
+		case keymanager.KeyType_RSA_2048:
+			bits = 2048
+		case keymanager.KeyType_RSA_4096:
+			bits = 4096
+		}
+		mechanism = pkcs11.CKM_RSA_PKCS_KEY_PAIR_GEN
+		public = append(public,
+			pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_RSA),
+			pkcs11.NewAttribute(pkcs11.CKA_MODULUS_BITS, bits),
+			pkcs11.NewAttribute(pkcs11.CKA_PUBLIC_EXPONENT, rsaExponent),
+		)
+		private = append(private, pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_RSA))
+	default:
+		return nil, nil, nil, fmt.Errorf("unknown key type %q", keyType)
+	}
+
+	return []*pkcs11.Mechanism{pkcs11.NewMechanism(mechanism, nil)}, public, private, nil
+}
+
+// readPublicKey reads the public key material of a public key object.
+func readPublicKey(ctx p11Context, session pkcs11.SessionHandle, handle pkcs11.ObjectHandle) (crypto.PublicKey, error) {
+	attrs, err := ctx.GetAttributeValue(session, handle, []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, nil),
+	})
+	if err != nil {
+		return nil, err
+	}
+	keyType := attributeBytes(attrs, pkcs11.CKA_KEY_TYPE)
+
+	switch {
+	case ulongEqual(keyType, pkcs11.CKK_EC):
+		attrs, err := ctx.GetAttributeValue(session, handle, []*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_EC_PARAMS, nil),
+			pkcs11.NewAttribute(pkcs11.CKA_EC_POINT, nil),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return parseECPublicKey(attributeBytes(attrs, pkcs11.CKA_EC_PARAMS), attributeBytes(attrs, pkcs11.CKA_EC_POINT))
+	case ulongEqual(keyType, pkcs11.CKK_RSA):
+		attrs, err := ctx.GetAttributeValue(session, handle, []*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_MODULUS, nil),
+			pkcs11.NewAttribute(pkcs11.CKA_PUBLIC_EXPONENT, nil),
+		})
+		if err != nil {
+			return nil, err
+		}
+		modulus := attributeBytes(attrs, pkcs11.CKA_MODULUS)
+		exponent := attributeBytes(attrs, pkcs11.CKA_PUBLIC_EXPONENT)
+		if len(modulus) == 0 || len(exponent) == 0 {
+			return nil, errors.New("RSA public key is missing modulus or exponent")
+		}
+		e := new(big.Int).SetBytes(exponent)
+		if !e.IsInt64() || e.Int64() > int64(^uint32(0)>>1) {
+			return nil, errors.New("RSA public exponent is too large")
+		}
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(modulus),
+			E: int(e.Int64()),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported PKCS#11 key type %x", keyType)
+	}
+}
+
+func parseECPublicKey(ecParams, ecPoint []byte) (*ecdsa.PublicKey, error) {
+	var oid asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(ecParams, &oid); err != nil {
+		return nil, fmt.Errorf("unable to parse EC parameters: %v", err)
+	}
+
+	var curve elliptic.Curve
+	switch {
+	case oid.Equal(oidNamedCurveP256):
+		curve = elliptic.P256()
+	case oid.Equal(oidNamedCurveP384):
+		curve = elliptic.P384()
+	default:
+		return nil, fmt.Errorf("unsupported EC curve %s", oid)
+	}
+
+	// CKA_EC_POINT is specified to be a DER-encoded OCTET STRING holding the
+	// uncompressed point, but some modules return the raw point.
+	point := ecPoint
+	var wrapped []byte
+	if rest, err := asn1.Unmarshal(ecPoint, &wrapped); err == nil && len(rest) == 0 {
+		point = wrapped
+	}
+
+	x, y := elliptic.Unmarshal(curve, point)
+	if x == nil {
+		return nil, errors.New("unable to parse EC point")
+	}
+	return &ecdsa.PublicKey{
+		Curve: curve,
+		X:     x,
+		Y:     y,
+	}, nil
+}
+
+func keyTypeFromPublicKey(publicKey crypto.PublicKey) (keymanager.KeyType, error) {
+	switch publicKey := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		switch publicKey.Curve {
+		case elliptic.P256():
+			return keymanager.KeyType_EC_P256, nil
+		case elliptic.P384():
+			return keymanager.KeyType_EC_P384, nil
+		}
+		return keymanager.KeyType_UNSPECIFIED_KEY_TYPE, fmt.Errorf("no EC key type for EC curve: %s", publicKey.Curve.Params().Name)
+	case *rsa.PublicKey:
+		switch bits := publicKey.N.BitLen(); bits {
+		case 1024:
+			return keymanager.KeyType_RSA_1024, nil
+		case 2048:
+			return keymanager.KeyType_RSA_2048, nil
+		case 4096:
+			return keymanager.KeyType_RSA_4096, nil
+		default:
+			return keymanager.KeyType_UNSPECIFIED_KEY_TYPE, fmt.Errorf("no RSA key type for key bit length: %d", bits)
+		}
+	default:
+		return keymanager.KeyType_UNSPECIFIED_KEY_TYPE, fmt.Errorf("unexpected public key type %T", publicKey)
+	}
+}
+
+type hashInfo struct {
+	mechanism uint
+	mgf       uint
+	size      int
+	// prefix is the DER encoded DigestInfo header prepended to the digest
+	// for PKCS#1 v1.5 signatures.
+	prefix []byte
+}
+
+var hashInfos = map[keymanager.HashAlgorithm]hashInfo{
+	keymanager.HashAlgorithm_SHA224: {
+		mechanism: pkcs11.CKM_SHA224,
+		mgf:       pkcs11.CKG_MGF1_SHA224,
+		size:      28,
+		prefix:    []byte{0x30, 0x2d, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x04, 0x05, 0x00, 0x04, 0x1c},
+	},
+	keymanager.HashAlgorithm_SHA256: {
+		mechanism: pkcs11.CKM_SHA256,
+		mgf:       pkcs11.CKG_MGF1_SHA256,
+		size:      32,
+		prefix:    []byte{0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x01, 0x05, 0x00, 0x04, 0x20},
+	},
+	keymanager.HashAlgorithm_SHA384: {
+		mechanism: pkcs11.CKM_SHA384,
+		mgf:       pkcs11.CKG_MGF1_SHA384,
+		size:      48,
+		prefix:    []byte{0x30, 0x41, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x02, 0x05, 0x00, 0x04, 0x30},
+	},
+	keymanager.HashAlgorithm_SHA512: {
+		mechanism: pkcs11.CKM_SHA512,
+		mgf:       pkcs11.CKG_MGF1_SHA512,
+		size:      64,
+		prefix:    []byte{0x30, 0x51, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x03, 0x05, 0x00, 0x04, 0x40},
+	},
+}
+
+// ecdsaToASN1 converts the raw r||s signature returned by CKM_ECDSA into the
+// ASN.1 encoding expected by crypto.Signer consumers.
+func ecdsaToASN1(signature []byte) ([]byte, error) {
+	if len(signature) == 0 || len(signature)%2 != 0 {
+		return nil, fmt.Errorf("malformed ECDSA signature of length %d", len(signature))
+	}
+	n := len(signature) / 2
+	return asn1.Marshal(struct {
+		R, S *big.Int
+	}{
+		R: new(big.Int).SetBytes(signature[:n]),
+		S: new(big.Int).SetBytes(signature[n:]),
+	})
+}
+
+func attributeBytes(attrs []*pkcs11.Attribute, typ uint) []byte {
+	for _, attr := range attrs {
+		if attr.Type == typ {
+			return attr.Value
+		}
+	}
+	return nil
+}
+
+// ulongEqual returns true if value holds the CK_ULONG v. CK_ULONG values are
+// encoded in the native byte order of the module, which is what
+// pkcs11.NewAttribute produces.
+func ulongEqual(value []byte, v uint) bool {
+	return bytes.Equal(value, pkcs11.NewAttribute(0, v).Value)
+}
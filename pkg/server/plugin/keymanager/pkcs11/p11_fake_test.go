@@ -0,0 +1,341 @@
+package pkcs11
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/asn1"
+	"errors"
+	"sync"
+
+	"github.com/miekg/pkcs11"
+)
+
+type fakeObject struct {
+	attrs      map[uint][]byte
+	privateKey crypto.Signer
+}
+
+// fakeP11Context is an in-memory PKCS#11 module backed by the Go crypto
+// library. It implements just enough of the API for the plugin.
+type fakeP11Context struct {
+	mu sync.Mutex
+
+	tokens      map[uint]string
+	pin         string
+	initialized bool
+	destroyed   bool
+	sessions    map[pkcs11.SessionHandle]uint
+	nextHandle  uint
+	objects     map[pkcs11.ObjectHandle]*fakeObject
+	findResults map[pkcs11.SessionHandle][]pkcs11.ObjectHandle
+	signKey     map[pkcs11.SessionHandle]*fakeObject
+	signMech    map[pkcs11.SessionHandle]uint
+
+	generateErr error
+	// destroyErr, if set, fails the next DestroyObject call once
+	// destroyErrSkip calls have succeeded
+	destroyErr     error
+	destroyErrSkip int
+}
+
+func newFakeP11Context() *fakeP11Context {
+	return &fakeP11Context{
+		tokens: map[uint]string{
+			0: "other",
+			3: "spire",
+		},
+		pin:         "1234",
+		sessions:    make(map[pkcs11.SessionHandle]uint),
+		objects:     make(map[pkcs11.ObjectHandle]*fakeObject),
+		findResults: make(map[pkcs11.SessionHandle][]pkcs11.ObjectHandle),
+		signKey:     make(map[pkcs11.SessionHandle]*fakeObject),
+		signMech:    make(map[pkcs11.SessionHandle]uint),
+	}
+}
+
+func (c *fakeP11Context) Initialize() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.initialized {
+		return pkcs11.Error(pkcs11.CKR_CRYPTOKI_ALREADY_INITIALIZED)
+	}
+	c.initialized = true
+	return nil
+}
+
+func (c *fakeP11Context) Finalize() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.initialized = false
+	return nil
+}
+
+func (c *fakeP11Context) Destroy() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.destroyed = true
+}
+
+func (c *fakeP11Context) GetSlotList(tokenPresent bool) ([]uint, error) {
+	return []uint{0, 3}, nil
+}
+
+func (c *fakeP11Context) GetTokenInfo(slotID uint) (pkcs11.TokenInfo, error) {
+	label, ok := c.tokens[slotID]
+	if !ok {
+		return pkcs11.TokenInfo{}, pkcs11.Error(pkcs11.CKR_SLOT_ID_INVALID)
+	}
+	return pkcs11.TokenInfo{Label: label}, nil
+}
+
+func (c *fakeP11Context) OpenSession(slotID uint, flags uint) (pkcs11.SessionHandle, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.initialized {
+		return 0, pkcs11.Error(pkcs11.CKR_CRYPTOKI_NOT_INITIALIZED)
+	}
+	c.nextHandle++
+	session := pkcs11.SessionHandle(c.nextHandle)
+	c.sessions[session] = slotID
+	return session, nil
+}
+
+func (c *fakeP11Context) CloseSession(sh pkcs11.SessionHandle) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.sessions, sh)
+	return nil
+}
+
+func (c *fakeP11Context) Login(sh pkcs11.SessionHandle, userType uint, pin string) error {
+	if pin != c.pin {
+		return pkcs11.Error(pkcs11.CKR_PIN_INCORRECT)
+	}
+	return nil
+}
+
+func (c *fakeP11Context) FindObjectsInit(sh pkcs11.SessionHandle, temp []*pkcs11.Attribute) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var results []pkcs11.ObjectHandle
+	for handle, object := range c.objects {
+		if object.matches(temp) {
+			results = append(results, handle)
+		}
+	}
+	c.findResults[sh] = results
+	return nil
+}
+
+func (c *fakeP11Context) FindObjects(sh pkcs11.SessionHandle, max int) ([]pkcs11.ObjectHandle, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	results := c.findResults[sh]
+	if len(results) > max {
+		c.findResults[sh] = results[max:]
+		return results[:max], true, nil
+	}
+	c.findResults[sh] = nil
+	return results, false, nil
+}
+
+func (c *fakeP11Context) FindObjectsFinal(sh pkcs11.SessionHandle) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.findResults, sh)
+	return nil
+}
+
+func (c *fakeP11Context) GetAttributeValue(sh pkcs11.SessionHandle, o pkcs11.ObjectHandle, a []*pkcs11.Attribute) ([]*pkcs11.Attribute, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	object, ok := c.objects[o]
+	if !ok {
+		return nil, pkcs11.Error(pkcs11.CKR_OBJECT_HANDLE_INVALID)
+	}
+	var out []*pkcs11.Attribute
+	for _, attr := range a {
+		value, ok := object.attrs[attr.Type]
+		if !ok {
+			return nil, pkcs11.Error(pkcs11.CKR_ATTRIBUTE_TYPE_INVALID)
+		}
+		out = append(out, pkcs11.NewAttribute(attr.Type, value))
+	}
+	return out, nil
+}
+
+func (c *fakeP11Context) GenerateKeyPair(sh pkcs11.SessionHandle, m []*pkcs11.Mechanism, public, private []*pkcs11.Attribute) (pkcs11.ObjectHandle, pkcs11.ObjectHandle, error) {
+	if c.generateErr != nil {
+		return 0, 0, c.generateErr
+	}
+
+	publicObject := newFakeObject(public)
+	privateObject := newFakeObject(private)
+
+	switch m[0].Mechanism {
+	case pkcs11.CKM_EC_KEY_PAIR_GEN:
+		var oid asn1.ObjectIdentifier
+		if _, err := asn1.Unmarshal(publicObject.attrs[pkcs11.CKA_EC_PARAMS], &oid); err != nil {
+			return 0, 0, pkcs11.Error(pkcs11.CKR_DOMAIN_PARAMS_INVALID)
+		}
+		var curve elliptic.Curve
+		switch {
+		case oid.Equal(oidNamedCurveP256):
+			curve = elliptic.P256()
+		case oid.Equal(oidNamedCurveP384):
+			curve = elliptic.P384()
+		default:
+			return 0, 0, pkcs11.Error(pkcs11.CKR_CURVE_NOT_SUPPORTED)
+		}
+		key, err := ecdsa.GenerateKey(curve, rand.Reader)
+		if err != nil {
+			return 0, 0, err
+		}
+		point, err := asn1.Marshal(elliptic.Marshal(curve, key.X, key.Y))
+		if err != nil {
+			return 0, 0, err
+		}
+		publicObject.attrs[pkcs11.CKA_EC_POINT] = point
+		privateObject.privateKey = key
+	case pkcs11.CKM_RSA_PKCS_KEY_PAIR_GEN:
+		bits := 0
+		for _, b := range []int{1024, 2048, 4096} {
+			if bytes.Equal(publicObject.attrs[pkcs11.CKA_MODULUS_BITS], pkcs11.NewAttribute(0, b).Value) {
+				bits = b
+			}
+		}
+		if bits == 0 {
+			return 0, 0, pkcs11.Error(pkcs11.CKR_KEY_SIZE_RANGE)
+		}
+		key, err := rsa.GenerateKey(rand.Reader, bits)
+		if err != nil {
+			return 0, 0, err
+		}
+		publicObject.attrs[pkcs11.CKA_MODULUS] = key.N.Bytes()
+		privateObject.privateKey = key
+	default:
+		return 0, 0, pkcs11.Error(pkcs11.CKR_MECHANISM_INVALID)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nextHandle++
+	publicHandle := pkcs11.ObjectHandle(c.nextHandle)
+	c.objects[publicHandle] = publicObject
+	c.nextHandle++
+	privateHandle := pkcs11.ObjectHandle(c.nextHandle)
+	c.objects[privateHandle] = privateObject
+	return publicHandle, privateHandle, nil
+}
+
+func (c *fakeP11Context) DestroyObject(sh pkcs11.SessionHandle, oh pkcs11.ObjectHandle) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.destroyErr; err != nil {
+		if c.destroyErrSkip == 0 {
+			c.destroyErr = nil
+			return err
+		}
+		c.destroyErrSkip--
+	}
+	if _, ok := c.objects[oh]; !ok {
+		return pkcs11.Error(pkcs11.CKR_OBJECT_HANDLE_INVALID)
+	}
+	delete(c.objects, oh)
+	return nil
+}
+
+func (c *fakeP11Context) SignInit(sh pkcs11.SessionHandle, m []*pkcs11.Mechanism, o pkcs11.ObjectHandle) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	object, ok := c.objects[o]
+	if !ok || object.privateKey == nil {
+		return pkcs11.Error(pkcs11.CKR_KEY_HANDLE_INVALID)
+	}
+	c.signKey[sh] = object
+	c.signMech[sh] = m[0].Mechanism
+	return nil
+}
+
+func (c *fakeP11Context) Sign(sh pkcs11.SessionHandle, message []byte) ([]byte, error) {
+	c.mu.Lock()
+	object := c.signKey[sh]
+	mechanism := c.signMech[sh]
+	delete(c.signKey, sh)
+	delete(c.signMech, sh)
+	c.mu.Unlock()
+
+	if object == nil {
+		return nil, pkcs11.Error(pkcs11.CKR_OPERATION_NOT_INITIALIZED)
+	}
+
+	switch mechanism {
+	case pkcs11.CKM_ECDSA:
+		key := object.privateKey.(*ecdsa.PrivateKey)
+		r, s, err := ecdsa.Sign(rand.Reader, key, message)
+		if err != nil {
+			return nil, err
+		}
+		size := (key.Curve.Params().BitSize + 7) / 8
+		signature := make([]byte, 2*size)
+		rBytes, sBytes := r.Bytes(), s.Bytes()
+		copy(signature[size-len(rBytes):size], rBytes)
+		copy(signature[2*size-len(sBytes):], sBytes)
+		return signature, nil
+	case pkcs11.CKM_RSA_PKCS:
+		// the message already carries the DigestInfo prefix
+		return rsa.SignPKCS1v15(rand.Reader, object.privateKey.(*rsa.PrivateKey), crypto.Hash(0), message)
+	case pkcs11.CKM_RSA_PKCS_PSS:
+		var hash crypto.Hash
+		switch len(message) {
+		case 28:
+			hash = crypto.SHA224
+		case 32:
+			hash = crypto.SHA256
+		case 48:
+			hash = crypto.SHA384
+		case 64:
+			hash = crypto.SHA512
+		default:
+			return nil, pkcs11.Error(pkcs11.CKR_DATA_LEN_RANGE)
+		}
+		return rsa.SignPSS(rand.Reader, object.privateKey.(*rsa.PrivateKey), hash, message, &rsa.PSSOptions{
+			SaltLength: rsa.PSSSaltLengthEqualsHash,
+		})
+	default:
+		return nil, errors.New("unsupported mechanism")
+	}
+}
+
+func (c *fakeP11Context) objectCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.objects)
+}
+
+func newFakeObject(template []*pkcs11.Attribute) *fakeObject {
+	object := &fakeObject{
+		attrs: make(map[uint][]byte),
+	}
+	for _, attr := range template {
+		object.attrs[attr.Type] = attr.Value
+	}
+	return object
+}
+
+func (o *fakeObject) matches(template []*pkcs11.Attribute) bool {
+	for _, attr := range template {
+		value, ok := o.attrs[attr.Type]
+		if !ok || !bytes.Equal(value, attr.Value) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,535 @@
+package pkcs11
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/hcl"
+	"github.com/miekg/pkcs11"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/proto/spire/server/keymanager"
+)
+
+const (
+	pluginName = "pkcs11"
+
+	defaultKeyLabelPrefix = "spire-server-"
+)
+
+func BuiltIn() catalog.Plugin {
+	return builtin(New())
+}
+
+func builtin(p *KeyManager) catalog.Plugin {
+	return catalog.MakePlugin(pluginName, keymanager.PluginServer(p))
+}
+
+type configuration struct {
+	// ModulePath is the path to the PKCS#11 module (shared library)
+	ModulePath string `hcl:"module_path"`
+
+	// TokenLabel selects the slot holding the token with this label
+	TokenLabel string `hcl:"token_label"`
+
+	// SlotID selects the slot by ID. Mutually exclusive with TokenLabel.
+	SlotID *int `hcl:"slot_id"`
+
+	// Pin is the user PIN used to log into the token
+	Pin string `hcl:"pin"`
+
+	// KeyLabelPrefix is prepended to the key ID to form the object label.
+	// It keeps SPIRE keys apart from other objects on the token.
+	KeyLabelPrefix string `hcl:"key_label_prefix"`
+}
+
+type KeyManager struct {
+	mu      sync.Mutex
+	config  *configuration
+	p11     p11Context
+	session pkcs11.SessionHandle
+
+	hooks struct {
+		newContext func(modulePath string) (p11Context, error)
+	}
+}
+
+func New() *KeyManager {
+	return newKeyManager(newP11Context)
+}
+
+func newKeyManager(newContext func(modulePath string) (p11Context, error)) *KeyManager {
+	m := &KeyManager{}
+	m.hooks.newContext = newContext
+	return m
+}
+
+func (m *KeyManager) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
+	config := new(configuration)
+	if err := hcl.Decode(config, req.Configuration); err != nil {
+		return nil, newError("unable to decode configuration: %v", err)
+	}
+
+	if config.ModulePath == "" {
+		return nil, newError("module_path is required")
+	}
+	switch {
+	case config.TokenLabel == "" && config.SlotID == nil:
+		return nil, newError("one of token_label or slot_id is required")
+	case config.TokenLabel != "" && config.SlotID != nil:
+		return nil, newError("token_label and slot_id are mutually exclusive")
+	case config.SlotID != nil && *config.SlotID < 0:
+		return nil, newError("slot_id must not be negative")
+	}
+	if config.Pin == "" {
+		return nil, newError("pin is required")
+	}
+	if config.KeyLabelPrefix == "" {
+		config.KeyLabelPrefix = defaultKeyLabelPrefix
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// The module state is process wide, so any existing session has to be
+	// torn down before the module is initialized again.
+	m.closeSession()
+
+	p11, session, err := m.openSession(config)
+	if err != nil {
+		return nil, err
+	}
+
+	m.config = config
+	m.p11 = p11
+	m.session = session
+
+	return &plugin.ConfigureResponse{}, nil
+}
+
+func (m *KeyManager) GetPluginInfo(ctx context.Context, req *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error) {
+	return &plugin.GetPluginInfoResponse{}, nil
+}
+
+func (m *KeyManager) GenerateKey(ctx context.Context, req *keymanager.GenerateKeyRequest) (*keymanager.GenerateKeyResponse, error) {
+	if req.KeyId == "" {
+		return nil, newError("key id is required")
+	}
+	if req.KeyType == keymanager.KeyType_UNSPECIFIED_KEY_TYPE {
+		return nil, newError("key type is required")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.p11 == nil {
+		return nil, newError("not configured")
+	}
+
+	label := m.keyLabel(req.KeyId)
+	mechanism, publicTemplate, privateTemplate, err := keyPairTemplates(label, req.KeyType)
+	if err != nil {
+		return nil, newError("%v", err)
+	}
+
+	// Find the objects for any key with the same ID. They are destroyed only
+	// after the new key pair has been successfully generated so that a
+	// failure does not lose the existing key. Private keys come first so
+	// that the old key is unusable as soon as anything has been destroyed.
+	oldObjects, err := m.findObjectsOfClass(pkcs11.CKO_PRIVATE_KEY, label)
+	if err != nil {
+		return nil, err
+	}
+	oldPublicObjects, err := m.findObjectsOfClass(pkcs11.CKO_PUBLIC_KEY, label)
+	if err != nil {
+		return nil, err
+	}
+	oldObjects = append(oldObjects, oldPublicObjects...)
+
+	publicHandle, privateHandle, err := m.p11.GenerateKeyPair(m.session, mechanism, publicTemplate, privateTemplate)
+	if err != nil {
+		return nil, newError("unable to generate key pair %q: %v", req.KeyId, err)
+	}
+
+	destroyed, err := m.destroyObjects(oldObjects...)
+	if err != nil {
+		if destroyed > 0 {
+			// The previous key is already (partially) gone, so rolling back
+			// would leave no usable key at all. Keep the new key pair. Any
+			// leftover objects are cleaned up the next time the key is
+			// generated.
+			return nil, newError("unable to destroy previous key %q: %v", req.KeyId, err)
+		}
+		// Nothing of the previous key was destroyed. Roll back the new key
+		// pair so that there are never two keys with the same label on the
+		// token.
+		if _, rollbackErr := m.destroyObjects(privateHandle, publicHandle); rollbackErr != nil {
+			return nil, newError("unable to destroy previous key %q: %v (rollback of new key failed: %v)", req.KeyId, err, rollbackErr)
+		}
+		return nil, newError("unable to destroy previous key %q: %v", req.KeyId, err)
+	}
+
+	publicKey, err := m.makePublicKey(req.KeyId, publicHandle)
+	if err != nil {
+		return nil, err
+	}
+
+	return &keymanager.GenerateKeyResponse{
+		PublicKey: publicKey,
+	}, nil
+}
+
+func (m *KeyManager) GetPublicKey(ctx context.Context, req *keymanager.GetPublicKeyRequest) (*keymanager.GetPublicKeyResponse, error) {
+	if req.KeyId == "" {
+		return nil, newError("key id is required")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.p11 == nil {
+		return nil, newError("not configured")
+	}
+
+	resp := new(keymanager.GetPublicKeyResponse)
+	handle, ok, err := m.findObject(pkcs11.CKO_PUBLIC_KEY, m.keyLabel(req.KeyId))
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		resp.PublicKey, err = m.makePublicKey(req.KeyId, handle)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+func (m *KeyManager) GetPublicKeys(ctx context.Context, req *keymanager.GetPublicKeysRequest) (*keymanager.GetPublicKeysResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.p11 == nil {
+		return nil, newError("not configured")
+	}
+
+	handles, err := m.findAllObjects([]*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_PUBLIC_KEY),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := new(keymanager.GetPublicKeysResponse)
+	for _, handle := range handles {
+		attrs, err := m.p11.GetAttributeValue(m.session, handle, []*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_LABEL, nil),
+		})
+		if err != nil {
+			return nil, newError("unable to read key label: %v", err)
+		}
+		label := string(attributeBytes(attrs, pkcs11.CKA_LABEL))
+		if !strings.HasPrefix(label, m.config.KeyLabelPrefix) {
+			// not a key managed by this plugin
+			continue
+		}
+		publicKey, err := m.makePublicKey(strings.TrimPrefix(label, m.config.KeyLabelPrefix), handle)
+		if err != nil {
+			return nil, err
+		}
+		resp.PublicKeys = append(resp.PublicKeys, publicKey)
+	}
+
+	// return keys in sorted order for consistency
+	sort.Slice(resp.PublicKeys, func(i, j int) bool {
+		return resp.PublicKeys[i].Id < resp.PublicKeys[j].Id
+	})
+
+	return resp, nil
+}
+
+func (m *KeyManager) SignData(ctx context.Context, req *keymanager.SignDataRequest) (*keymanager.SignDataResponse, error) {
+	if req.KeyId == "" {
+		return nil, newError("key id is required")
+	}
+	if req.SignerOpts == nil {
+		return nil, newError("signer opts is required")
+	}
+
+	var hashAlgorithm keymanager.HashAlgorithm
+	var pssOptions *keymanager.PSSOptions
+	switch opts := req.SignerOpts.(type) {
+	case *keymanager.SignDataRequest_HashAlgorithm:
+		hashAlgorithm = opts.HashAlgorithm
+	case *keymanager.SignDataRequest_PssOptions:
+		if opts.PssOptions == nil {
+			return nil, newError("PSS options are nil")
+		}
+		hashAlgorithm = opts.PssOptions.HashAlgorithm
+		pssOptions = opts.PssOptions
+	default:
+		return nil, newError("unsupported signer opts type %T", opts)
+	}
+	if hashAlgorithm == keymanager.HashAlgorithm_UNSPECIFIED_HASH_ALGORITHM {
+		return nil, newError("hash algorithm is required")
+	}
+	hash, ok := hashInfos[hashAlgorithm]
+	if !ok {
+		return nil, newError("unsupported hash algorithm %q", hashAlgorithm)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.p11 == nil {
+		return nil, newError("not configured")
+	}
+
+	label := m.keyLabel(req.KeyId)
+	privateHandle, ok, err := m.findObject(pkcs11.CKO_PRIVATE_KEY, label)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, newError("no such key %q", req.KeyId)
+	}
+	if len(req.Data) != hash.size {
+		return nil, newError("data length %d does not match %s digest size", len(req.Data), hashAlgorithm)
+	}
+
+	// The key type determines the signing mechanism. It is read from the
+	// public key, which is always readable, unlike the private key.
+	publicHandle, ok, err := m.findObject(pkcs11.CKO_PUBLIC_KEY, label)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, newError("no public key for key %q", req.KeyId)
+	}
+	publicKey, err := readPublicKey(m.p11, m.session, publicHandle)
+	if err != nil {
+		return nil, newError("unable to read public key %q: %v", req.KeyId, err)
+	}
+
+	var mechanism *pkcs11.Mechanism
+	data := req.Data
+	isECDSA := false
+	switch publicKey.(type) {
+	case *rsa.PublicKey:
+		if pssOptions != nil {
+			saltLength := int(pssOptions.SaltLength)
+			switch saltLength {
+			case rsa.PSSSaltLengthAuto, rsa.PSSSaltLengthEqualsHash:
+				saltLength = hash.size
+			}
+			if saltLength < 0 {
+				return nil, newError("invalid PSS salt length %d", saltLength)
+			}
+			mechanism = pkcs11.NewMechanism(pkcs11.CKM_RSA_PKCS_PSS, pkcs11.NewPSSParams(hash.mechanism, hash.mgf, uint(saltLength)))
+		} else {
+			mechanism = pkcs11.NewMechanism(pkcs11.CKM_RSA_PKCS, nil)
+			data = append(append([]byte{}, hash.prefix...), req.Data...)
+		}
+	default:
+		if pssOptions != nil {
+			return nil, newError("PSS options are not supported for key %q", req.KeyId)
+		}
+		mechanism = pkcs11.NewMechanism(pkcs11.CKM_ECDSA, nil)
+		isECDSA = true
+	}
+
+	if err := m.p11.SignInit(m.session, []*pkcs11.Mechanism{mechanism}, privateHandle); err != nil {
+		return nil, newError("keypair %q signing operation failed: %v", req.KeyId, err)
+	}
+	signature, err := m.p11.Sign(m.session, data)
+	if err != nil {
+		return nil, newError("keypair %q signing operation failed: %v", req.KeyId, err)
+	}
+
+	if isECDSA {
+		signature, err = ecdsaToASN1(signature)
+		if err != nil {
+			return nil, newError("keypair %q signing operation failed: %v", req.KeyId, err)
+		}
+	}
+
+	return &keymanager.SignDataResponse{
+		Signature: signature,
+	}, nil
+}
+
+func (m *KeyManager) openSession(config *configuration) (p11Context, pkcs11.SessionHandle, error) {
+	p11, err := m.hooks.newContext(config.ModulePath)
+	if err != nil {
+		return nil, 0, newError("%v", err)
+	}
+
+	session, err := openSession(p11, config)
+	if err != nil {
+		p11.Destroy()
+		return nil, 0, err
+	}
+	return p11, session, nil
+}
+
+func openSession(p11 p11Context, config *configuration) (pkcs11.SessionHandle, error) {
+	if err := p11.Initialize(); err != nil && !isP11Error(err, pkcs11.CKR_CRYPTOKI_ALREADY_INITIALIZED) {
+		return 0, newError("unable to initialize module: %v", err)
+	}
+
+	slotID, err := findSlot(p11, config)
+	if err != nil {
+		p11.Finalize()
+		return 0, err
+	}
+
+	session, err := p11.OpenSession(slotID, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+	if err != nil {
+		p11.Finalize()
+		return 0, newError("unable to open session on slot %d: %v", slotID, err)
+	}
+
+	if err := p11.Login(session, pkcs11.CKU_USER, config.Pin); err != nil && !isP11Error(err, pkcs11.CKR_USER_ALREADY_LOGGED_IN) {
+		p11.CloseSession(session)
+		p11.Finalize()
+		return 0, newError("unable to log into token: %v", err)
+	}
+
+	return session, nil
+}
+
+func findSlot(p11 p11Context, config *configuration) (uint, error) {
+	slots, err := p11.GetSlotList(true)
+	if err != nil {
+		return 0, newError("unable to list slots: %v", err)
+	}
+
+	for _, slot := range slots {
+		if config.SlotID != nil {
+			if slot == uint(*config.SlotID) {
+				return slot, nil
+			}
+			continue
+		}
+		info, err := p11.GetTokenInfo(slot)
+		if err != nil {
+			return 0, newError("unable to get token info for slot %d: %v", slot, err)
+		}
+		if info.Label == config.TokenLabel {
+			return slot, nil
+		}
+	}
+
+	if config.SlotID != nil {
+		return 0, newError("no token present in slot %d", *config.SlotID)
+	}
+	return 0, newError("no token with label %q", config.TokenLabel)
+}
+
+// closeSession closes the current session, if any. The mutex must be held.
+func (m *KeyManager) closeSession() {
+	if m.p11 == nil {
+		return
+	}
+	m.p11.CloseSession(m.session)
+	m.p11.Finalize()
+	m.p11.Destroy()
+	m.p11 = nil
+}
+
+func (m *KeyManager) keyLabel(keyID string) string {
+	return m.config.KeyLabelPrefix + keyID
+}
+
+func (m *KeyManager) makePublicKey(keyID string, handle pkcs11.ObjectHandle) (*keymanager.PublicKey, error) {
+	publicKey, err := readPublicKey(m.p11, m.session, handle)
+	if err != nil {
+		return nil, newError("unable to read public key %q: %v", keyID, err)
+	}
+	keyType, err := keyTypeFromPublicKey(publicKey)
+	if err != nil {
+		return nil, newError("unable to determine key type of %q: %v", keyID, err)
+	}
+	pkixData, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return nil, newError("unable to marshal public key %q: %v", keyID, err)
+	}
+	return &keymanager.PublicKey{
+		Id:       keyID,
+		Type:     keyType,
+		PkixData: pkixData,
+	}, nil
+}
+
+// destroyObjects destroys the given objects in order, stopping at the first
+// error. It returns how many objects were destroyed.
+func (m *KeyManager) destroyObjects(handles ...pkcs11.ObjectHandle) (int, error) {
+	for i, handle := range handles {
+		if err := m.p11.DestroyObject(m.session, handle); err != nil {
+			return i, err
+		}
+	}
+	return len(handles), nil
+}
+
+// findObject returns the object of the given class with the given label.
+func (m *KeyManager) findObject(class uint, label string) (pkcs11.ObjectHandle, bool, error) {
+	handles, err := m.findObjectsOfClass(class, label)
+	if err != nil {
+		return 0, false, err
+	}
+	switch len(handles) {
+	case 0:
+		return 0, false, nil
+	case 1:
+		return handles[0], true, nil
+	default:
+		return 0, false, newError("found %d objects with label %q", len(handles), label)
+	}
+}
+
+// findObjectsOfClass returns the objects of the given class with the given
+// label.
+func (m *KeyManager) findObjectsOfClass(class uint, label string) ([]pkcs11.ObjectHandle, error) {
+	return m.findAllObjects([]*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, class),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+	})
+}
+
+func (m *KeyManager) findAllObjects(template []*pkcs11.Attribute) (handles []pkcs11.ObjectHandle, err error) {
+	if err := m.p11.FindObjectsInit(m.session, template); err != nil {
+		return nil, newError("unable to find objects: %v", err)
+	}
+	defer func() {
+		if finalErr := m.p11.FindObjectsFinal(m.session); finalErr != nil && err == nil {
+			err = newError("unable to find objects: %v", finalErr)
+		}
+	}()
+
+	for {
+		batch, _, err := m.p11.FindObjects(m.session, 16)
+		if err != nil {
+			return nil, newError("unable to find objects: %v", err)
+		}
+		if len(batch) == 0 {
+			return handles, nil
+		}
+		handles = append(handles, batch...)
+	}
+}
+
+func isP11Error(err error, code uint) bool {
+	p11Err, ok := err.(pkcs11.Error)
+	return ok && uint(p11Err) == code
+}
+
+func newError(format string, args ...interface{}) error {
+	return fmt.Errorf("keymanager(pkcs11): "+format, args...)
+}
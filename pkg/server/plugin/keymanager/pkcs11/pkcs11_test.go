@@ -0,0 +1,349 @@
+package pkcs11
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/miekg/pkcs11"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/server/plugin/keymanager/test"
+	"github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/proto/spire/server/keymanager"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	ctx = context.Background()
+)
+
+func TestKeyManager(t *testing.T) {
+	test.Run(t, makeKeyManager)
+}
+
+// TestKeyManagerSoftHSM runs the key manager test suite against a real
+// PKCS#11 module, e.g. SoftHSM. The token must already be initialized:
+//
+//	softhsm2-util --init-token --free --label spire --pin 1234 --so-pin 1234
+//	PKCS11_TEST_MODULE=/usr/lib/softhsm/libsofthsm2.so \
+//	PKCS11_TEST_TOKEN_LABEL=spire PKCS11_TEST_PIN=1234 go test .
+func TestKeyManagerSoftHSM(t *testing.T) {
+	modulePath := os.Getenv("PKCS11_TEST_MODULE")
+	if modulePath == "" {
+		t.Skip("PKCS11_TEST_MODULE not set")
+	}
+
+	test.Run(t, func(t *testing.T) catalog.Plugin {
+		m := New()
+		_, err := m.Configure(ctx, &plugin.ConfigureRequest{
+			Configuration: fmt.Sprintf(`
+				module_path = %q
+				token_label = %q
+				pin = %q
+				key_label_prefix = %q`,
+				modulePath,
+				os.Getenv("PKCS11_TEST_TOKEN_LABEL"),
+				os.Getenv("PKCS11_TEST_PIN"),
+				fmt.Sprintf("spire-test-%s-", t.Name())),
+		})
+		require.NoError(t, err)
+		removeAllKeys(t, m)
+		return builtin(m)
+	})
+}
+
+func TestConfigure(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{
+			name:   "malformed",
+			config: "{{",
+			err:    "keymanager(pkcs11): unable to decode configuration",
+		},
+		{
+			name:   "missing module path",
+			config: `token_label = "spire" pin = "1234"`,
+			err:    "keymanager(pkcs11): module_path is required",
+		},
+		{
+			name:   "missing token label and slot id",
+			config: `module_path = "fake" pin = "1234"`,
+			err:    "keymanager(pkcs11): one of token_label or slot_id is required",
+		},
+		{
+			name:   "both token label and slot id",
+			config: `module_path = "fake" token_label = "spire" slot_id = 3 pin = "1234"`,
+			err:    "keymanager(pkcs11): token_label and slot_id are mutually exclusive",
+		},
+		{
+			name:   "negative slot id",
+			config: `module_path = "fake" slot_id = -1 pin = "1234"`,
+			err:    "keymanager(pkcs11): slot_id must not be negative",
+		},
+		{
+			name:   "missing pin",
+			config: `module_path = "fake" token_label = "spire"`,
+			err:    "keymanager(pkcs11): pin is required",
+		},
+		{
+			name:   "unknown token label",
+			config: `module_path = "fake" token_label = "nope" pin = "1234"`,
+			err:    `keymanager(pkcs11): no token with label "nope"`,
+		},
+		{
+			name:   "unknown slot id",
+			config: `module_path = "fake" slot_id = 7 pin = "1234"`,
+			err:    "keymanager(pkcs11): no token present in slot 7",
+		},
+		{
+			name:   "bad pin",
+			config: `module_path = "fake" token_label = "spire" pin = "4321"`,
+			err:    "keymanager(pkcs11): unable to log into token",
+		},
+		{
+			name:   "by token label",
+			config: `module_path = "fake" token_label = "spire" pin = "1234"`,
+		},
+		{
+			name:   "by slot id",
+			config: `module_path = "fake" slot_id = 3 pin = "1234"`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			m := newKeyManager(func(modulePath string) (p11Context, error) {
+				require.Equal(t, "fake", modulePath)
+				return newFakeP11Context(), nil
+			})
+			_, err := m.Configure(ctx, &plugin.ConfigureRequest{
+				Configuration: tt.config,
+			})
+			if tt.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestConfigureModuleLoadFailure(t *testing.T) {
+	m := newKeyManager(func(modulePath string) (p11Context, error) {
+		return nil, errors.New("oh no")
+	})
+	_, err := m.Configure(ctx, &plugin.ConfigureRequest{
+		Configuration: `module_path = "fake" token_label = "spire" pin = "1234"`,
+	})
+	require.EqualError(t, err, "keymanager(pkcs11): oh no")
+}
+
+func TestNotConfigured(t *testing.T) {
+	m := New()
+	_, err := m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
+		KeyId:   "KEY",
+		KeyType: keymanager.KeyType_EC_P256,
+	})
+	require.EqualError(t, err, "keymanager(pkcs11): not configured")
+}
+
+func TestGenerateKeyReplacesExistingKey(t *testing.T) {
+	p11 := newFakeP11Context()
+	m := configureKeyManager(t, p11, "")
+
+	first, err := m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
+		KeyId:   "KEY",
+		KeyType: keymanager.KeyType_EC_P256,
+	})
+	require.NoError(t, err)
+	second, err := m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
+		KeyId:   "KEY",
+		KeyType: keymanager.KeyType_EC_P384,
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, first.PublicKey.PkixData, second.PublicKey.PkixData)
+
+	// only the objects of the replacement key remain on the token
+	require.Equal(t, 2, p11.objectCount())
+
+	resp, err := m.GetPublicKey(ctx, &keymanager.GetPublicKeyRequest{KeyId: "KEY"})
+	require.NoError(t, err)
+	require.Equal(t, second.PublicKey, resp.PublicKey)
+}
+
+func TestGenerateKeyFailureKeepsExistingKey(t *testing.T) {
+	p11 := newFakeP11Context()
+	m := configureKeyManager(t, p11, "")
+
+	first, err := m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
+		KeyId:   "KEY",
+		KeyType: keymanager.KeyType_EC_P256,
+	})
+	require.NoError(t, err)
+
+	p11.generateErr = pkcs11.Error(pkcs11.CKR_DEVICE_MEMORY)
+	_, err = m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
+		KeyId:   "KEY",
+		KeyType: keymanager.KeyType_EC_P256,
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `keymanager(pkcs11): unable to generate key pair "KEY"`)
+
+	resp, err := m.GetPublicKey(ctx, &keymanager.GetPublicKeyRequest{KeyId: "KEY"})
+	require.NoError(t, err)
+	require.Equal(t, first.PublicKey, resp.PublicKey)
+}
+
+func TestGenerateKeyDestroyFailureRollsBackNewKey(t *testing.T) {
+	p11 := newFakeP11Context()
+	m := configureKeyManager(t, p11, "")
+
+	first, err := m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
+		KeyId:   "KEY",
+		KeyType: keymanager.KeyType_EC_P256,
+	})
+	require.NoError(t, err)
+
+	p11.destroyErr = pkcs11.Error(pkcs11.CKR_DEVICE_ERROR)
+	_, err = m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
+		KeyId:   "KEY",
+		KeyType: keymanager.KeyType_EC_P256,
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `keymanager(pkcs11): unable to destroy previous key "KEY"`)
+
+	// the new key pair was destroyed so the label is not shared
+	require.Equal(t, 2, p11.objectCount())
+
+	resp, err := m.GetPublicKey(ctx, &keymanager.GetPublicKeyRequest{KeyId: "KEY"})
+	require.NoError(t, err)
+	require.Equal(t, first.PublicKey, resp.PublicKey)
+}
+
+func TestGenerateKeyPartialDestroyFailureKeepsNewKey(t *testing.T) {
+	p11 := newFakeP11Context()
+	m := configureKeyManager(t, p11, "")
+
+	_, err := m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
+		KeyId:   "KEY",
+		KeyType: keymanager.KeyType_EC_P256,
+	})
+	require.NoError(t, err)
+
+	// the old private key is destroyed but destroying the old public key
+	// fails
+	p11.destroyErr = pkcs11.Error(pkcs11.CKR_DEVICE_ERROR)
+	p11.destroyErrSkip = 1
+	_, err = m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
+		KeyId:   "KEY",
+		KeyType: keymanager.KeyType_EC_P256,
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `keymanager(pkcs11): unable to destroy previous key "KEY"`)
+
+	// the new key pair was kept alongside the leftover old public key
+	require.Equal(t, 3, p11.objectCount())
+	privateHandles, err := m.findObjectsOfClass(pkcs11.CKO_PRIVATE_KEY, m.keyLabel("KEY"))
+	require.NoError(t, err)
+	require.Len(t, privateHandles, 1)
+
+	// generating the key again cleans up the leftover
+	second, err := m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
+		KeyId:   "KEY",
+		KeyType: keymanager.KeyType_EC_P256,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, p11.objectCount())
+
+	resp, err := m.GetPublicKey(ctx, &keymanager.GetPublicKeyRequest{KeyId: "KEY"})
+	require.NoError(t, err)
+	require.Equal(t, second.PublicKey, resp.PublicKey)
+}
+
+func TestKeyLabelPrefixIsolatesKeys(t *testing.T) {
+	p11 := newFakeP11Context()
+	a := configureKeyManager(t, p11, "server-a-")
+	b := configureKeyManager(t, p11, "server-b-")
+
+	_, err := a.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
+		KeyId:   "KEY",
+		KeyType: keymanager.KeyType_EC_P256,
+	})
+	require.NoError(t, err)
+
+	resp, err := b.GetPublicKey(ctx, &keymanager.GetPublicKeyRequest{KeyId: "KEY"})
+	require.NoError(t, err)
+	require.Nil(t, resp.PublicKey)
+
+	keysResp, err := b.GetPublicKeys(ctx, &keymanager.GetPublicKeysRequest{})
+	require.NoError(t, err)
+	require.Empty(t, keysResp.PublicKeys)
+
+	keysResp, err = a.GetPublicKeys(ctx, &keymanager.GetPublicKeysRequest{})
+	require.NoError(t, err)
+	require.Len(t, keysResp.PublicKeys, 1)
+	require.Equal(t, "KEY", keysResp.PublicKeys[0].Id)
+}
+
+func TestSignDataDigestSizeMismatch(t *testing.T) {
+	m := configureKeyManager(t, newFakeP11Context(), "")
+	_, err := m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
+		KeyId:   "KEY",
+		KeyType: keymanager.KeyType_EC_P256,
+	})
+	require.NoError(t, err)
+
+	_, err = m.SignData(ctx, &keymanager.SignDataRequest{
+		KeyId: "KEY",
+		Data:  []byte("too short"),
+		SignerOpts: &keymanager.SignDataRequest_HashAlgorithm{
+			HashAlgorithm: keymanager.HashAlgorithm_SHA256,
+		},
+	})
+	require.EqualError(t, err, "keymanager(pkcs11): data length 9 does not match SHA256 digest size")
+}
+
+func makeKeyManager(t *testing.T) catalog.Plugin {
+	return builtin(configureKeyManager(t, newFakeP11Context(), ""))
+}
+
+func configureKeyManager(t *testing.T, p11 *fakeP11Context, keyLabelPrefix string) *KeyManager {
+	m := newKeyManager(func(modulePath string) (p11Context, error) {
+		return p11, nil
+	})
+	resp, err := m.Configure(ctx, &plugin.ConfigureRequest{
+		Configuration: fmt.Sprintf(`
+			module_path = "fake"
+			token_label = "spire"
+			pin = "1234"
+			key_label_prefix = %q`, keyLabelPrefix),
+	})
+	require.NoError(t, err)
+	require.Equal(t, &plugin.ConfigureResponse{}, resp)
+	return m
+}
+
+func removeAllKeys(t *testing.T, m *KeyManager) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	handles, err := m.findAllObjects(nil)
+	require.NoError(t, err)
+	for _, handle := range handles {
+		attrs, err := m.p11.GetAttributeValue(m.session, handle, []*pkcs11.Attribute{
+			pkcs11.NewAttribute(pkcs11.CKA_LABEL, nil),
+		})
+		if err != nil {
+			continue
+		}
+		if strings.HasPrefix(string(attributeBytes(attrs, pkcs11.CKA_LABEL)), m.config.KeyLabelPrefix) {
+			require.NoError(t, m.p11.DestroyObject(m.session, handle))
+		}
+	}
+}
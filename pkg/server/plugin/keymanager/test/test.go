@@ -22,17 +22,38 @@ var (
 
 type Maker func(t *testing.T) catalog.Plugin
 
+type Option func(*baseSuite)
+
+// UnsupportedKeyTypes declares the key types the key manager does not
+// support. Generating keys of those types is expected to fail and the signing
+// tests fall back to supported key types.
+func UnsupportedKeyTypes(keyTypes ...keymanager.KeyType) Option {
+	return func(s *baseSuite) {
+		for _, keyType := range keyTypes {
+			s.unsupported[keyType] = true
+		}
+	}
+}
+
 // the maker function is called. the returned key manager is expected to be
 // already configured.
-func Run(t *testing.T, maker Maker) {
-	spiretest.Run(t, &baseSuite{maker: maker})
+func Run(t *testing.T, maker Maker, opts ...Option) {
+	s := &baseSuite{
+		maker:       maker,
+		unsupported: make(map[keymanager.KeyType]bool),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	spiretest.Run(t, s)
 }
 
 type baseSuite struct {
 	spiretest.Suite
 
-	maker Maker
-	m     keymanager.Plugin
+	maker       Maker
+	unsupported map[keymanager.KeyType]bool
+	m           keymanager.Plugin
 }
 
 func (s *baseSuite) SetupTest() {
@@ -58,6 +79,10 @@ func (s *baseSuite) TestGenerateKeyMissingKeyType() {
 }
 
 func (s *baseSuite) TestGenerateKeyECP256() {
+	if s.unsupported[keymanager.KeyType_EC_P256] {
+		s.testGenerateKeyUnsupported(keymanager.KeyType_EC_P256)
+		return
+	}
 	resp, err := s.m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
 		KeyId:   "KEY",
 		KeyType: keymanager.KeyType_EC_P256,
@@ -75,6 +100,10 @@ func (s *baseSuite) TestGenerateKeyECP256() {
 }
 
 func (s *baseSuite) TestGenerateKeyECP384() {
+	if s.unsupported[keymanager.KeyType_EC_P384] {
+		s.testGenerateKeyUnsupported(keymanager.KeyType_EC_P384)
+		return
+	}
 	resp, err := s.m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
 		KeyId:   "KEY",
 		KeyType: keymanager.KeyType_EC_P384,
@@ -92,6 +121,10 @@ func (s *baseSuite) TestGenerateKeyECP384() {
 }
 
 func (s *baseSuite) TestGenerateKeyRSA1024() {
+	if s.unsupported[keymanager.KeyType_RSA_1024] {
+		s.testGenerateKeyUnsupported(keymanager.KeyType_RSA_1024)
+		return
+	}
 	resp, err := s.m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
 		KeyId:   "KEY",
 		KeyType: keymanager.KeyType_RSA_1024,
@@ -109,6 +142,10 @@ func (s *baseSuite) TestGenerateKeyRSA1024() {
 }
 
 func (s *baseSuite) TestGenerateKeyRSA2048() {
+	if s.unsupported[keymanager.KeyType_RSA_2048] {
+		s.testGenerateKeyUnsupported(keymanager.KeyType_RSA_2048)
+		return
+	}
 	resp, err := s.m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
 		KeyId:   "KEY",
 		KeyType: keymanager.KeyType_RSA_2048,
@@ -126,6 +163,10 @@ func (s *baseSuite) TestGenerateKeyRSA2048() {
 }
 
 func (s *baseSuite) TestGenerateKeyRSA4096() {
+	if s.unsupported[keymanager.KeyType_RSA_4096] {
+		s.testGenerateKeyUnsupported(keymanager.KeyType_RSA_4096)
+		return
+	}
 	resp, err := s.m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
 		KeyId:   "KEY",
 		KeyType: keymanager.KeyType_RSA_4096,
@@ -142,6 +183,15 @@ func (s *baseSuite) TestGenerateKeyRSA4096() {
 	s.Require().Equal(4096, rsaPublicKey.N.BitLen())
 }
 
+func (s *baseSuite) testGenerateKeyUnsupported(keyType keymanager.KeyType) {
+	resp, err := s.m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
+		KeyId:   "KEY",
+		KeyType: keyType,
+	})
+	s.requireErrorContains(err, "unsupported key type")
+	s.Require().Nil(resp)
+}
+
 func (s *baseSuite) TestGetPublicKeyMissingKeyId() {
 	resp, err := s.m.GetPublicKey(ctx, &keymanager.GetPublicKeyRequest{})
 	s.Require().Error(err)
@@ -204,11 +254,11 @@ func (s *baseSuite) TestSignDataECDSA() {
 }
 
 func (s *baseSuite) TestSignDataRSAPKCS1v15() {
-	s.testSignData(keymanager.KeyType_RSA_1024, x509.SHA256WithRSA)
+	s.testSignData(s.rsaKeyType(), x509.SHA256WithRSA)
 }
 
 func (s *baseSuite) TestSignDataRSAPSS() {
-	s.testSignData(keymanager.KeyType_RSA_1024, x509.SHA256WithRSAPSS)
+	s.testSignData(s.rsaKeyType(), x509.SHA256WithRSAPSS)
 }
 
 func (s *baseSuite) testSignData(keyType keymanager.KeyType, signatureAlgorithm x509.SignatureAlgorithm) {
@@ -280,6 +330,22 @@ func (s *baseSuite) TestSignDataNoKey() {
 	s.Require().Nil(resp)
 }
 
+// rsaKeyType returns the smallest (i.e. quickest to generate) RSA key type
+// supported by the key manager
+func (s *baseSuite) rsaKeyType() keymanager.KeyType {
+	for _, keyType := range []keymanager.KeyType{
+		keymanager.KeyType_RSA_1024,
+		keymanager.KeyType_RSA_2048,
+		keymanager.KeyType_RSA_4096,
+	} {
+		if !s.unsupported[keyType] {
+			return keyType
+		}
+	}
+	s.FailNow("no supported RSA key type")
+	return keymanager.KeyType_UNSPECIFIED_KEY_TYPE
+}
+
 func (s *baseSuite) requireErrorContains(err error, contains string) {
 	s.Require().Error(err)
 	s.Require().Contains(err.Error(), contains)
@@ -0,0 +1,560 @@
+package vault
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/gofrs/uuid"
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/diskutil"
+	"github.com/spiffe/spire/pkg/common/plugin/vault"
+	"github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/proto/spire/server/keymanager"
+)
+
+const (
+	pluginName = "vault"
+
+	defaultTransitMountPoint = "transit"
+	defaultKeyNamePrefix     = "spire-server-"
+)
+
+// transitKeyTypes maps key types to Transit key types. Transit does not
+// support RSA keys smaller than 2048 bits.
+var transitKeyTypes = map[keymanager.KeyType]string{
+	keymanager.KeyType_EC_P256:  "ecdsa-p256",
+	keymanager.KeyType_EC_P384:  "ecdsa-p384",
+	keymanager.KeyType_RSA_2048: "rsa-2048",
+	keymanager.KeyType_RSA_4096: "rsa-4096",
+}
+
+// transitHashAlgorithms maps hash algorithms to the Transit hash algorithm
+// names used by the sign endpoint
+var transitHashAlgorithms = map[keymanager.HashAlgorithm]string{
+	keymanager.HashAlgorithm_SHA224:   "sha2-224",
+	keymanager.HashAlgorithm_SHA256:   "sha2-256",
+	keymanager.HashAlgorithm_SHA384:   "sha2-384",
+	keymanager.HashAlgorithm_SHA512:   "sha2-512",
+	keymanager.HashAlgorithm_SHA3_224: "sha3-224",
+	keymanager.HashAlgorithm_SHA3_256: "sha3-256",
+	keymanager.HashAlgorithm_SHA3_384: "sha3-384",
+	keymanager.HashAlgorithm_SHA3_512: "sha3-512",
+}
+
+func BuiltIn() catalog.Plugin {
+	return builtin(New())
+}
+
+func builtin(p *KeyManager) catalog.Plugin {
+	return catalog.MakePlugin(pluginName, keymanager.PluginServer(p))
+}
+
+type configuration struct {
+	vault.ClientConfig `hcl:",squash"`
+
+	// TransitMountPoint is the mount point of the Transit secrets engine
+	TransitMountPoint string `hcl:"transit_mount_point"`
+
+	// KeyNamePrefix is prepended to the key namespace and key ID to form the
+	// Transit key name
+	KeyNamePrefix string `hcl:"key_name_prefix"`
+
+	// KeyMetadataFile is the path to the file holding the server ID, which
+	// namespaces the keys of this server. It is created with a new server ID
+	// if it does not exist.
+	KeyMetadataFile string `hcl:"key_metadata_file"`
+
+	// SharedKeyNamespace, if set, namespaces the keys instead of the server
+	// ID. Servers configured with the same namespace share their keys, as
+	// needed when the CA journal is kept in the datastore.
+	SharedKeyNamespace string `hcl:"shared_key_namespace"`
+}
+
+// transitKey is the subset of the Transit key information used by the plugin
+type transitKey struct {
+	Type          string                       `json:"type"`
+	LatestVersion int                          `json:"latest_version"`
+	Keys          map[string]transitKeyVersion `json:"keys"`
+}
+
+type transitKeyVersion struct {
+	PublicKey string `json:"public_key"`
+}
+
+type signRequest struct {
+	Input               string `json:"input"`
+	Prehashed           bool   `json:"prehashed"`
+	SignatureAlgorithm  string `json:"signature_algorithm,omitempty"`
+	SaltLength          string `json:"salt_length,omitempty"`
+	MarshalingAlgorithm string `json:"marshaling_algorithm"`
+}
+
+type signData struct {
+	Signature string `json:"signature"`
+}
+
+type KeyManager struct {
+	mu            sync.Mutex
+	log           hclog.Logger
+	config        *configuration
+	namespace     string
+	client        *vault.Client
+	cancelRenewal context.CancelFunc
+
+	hooks struct {
+		clock  clock.Clock
+		getenv func(string) string
+	}
+}
+
+func New() *KeyManager {
+	m := &KeyManager{
+		log: hclog.NewNullLogger(),
+	}
+	m.hooks.clock = clock.New()
+	m.hooks.getenv = os.Getenv
+	return m
+}
+
+func (m *KeyManager) SetLogger(log hclog.Logger) {
+	m.log = log
+}
+
+func (m *KeyManager) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
+	config := new(configuration)
+	if err := hcl.Decode(config, req.Configuration); err != nil {
+		return nil, newError("unable to decode configuration: %v", err)
+	}
+	if err := config.Validate(m.hooks.getenv); err != nil {
+		return nil, newError("%v", err)
+	}
+	if config.TransitMountPoint == "" {
+		config.TransitMountPoint = defaultTransitMountPoint
+	}
+	if config.KeyNamePrefix == "" {
+		config.KeyNamePrefix = defaultKeyNamePrefix
+	}
+	var namespace string
+	switch {
+	case config.KeyMetadataFile != "" && config.SharedKeyNamespace != "":
+		return nil, newError("key_metadata_file and shared_key_namespace are mutually exclusive")
+	case config.SharedKeyNamespace != "":
+		namespace = config.SharedKeyNamespace
+	case config.KeyMetadataFile != "":
+		serverID, err := loadOrCreateServerID(config.KeyMetadataFile)
+		if err != nil {
+			return nil, newError("%v", err)
+		}
+		namespace = serverID
+	default:
+		return nil, newError("one of key_metadata_file or shared_key_namespace is required")
+	}
+
+	client, err := vault.NewClient(&config.ClientConfig)
+	if err != nil {
+		return nil, newError("%v", err)
+	}
+	auth, err := client.Login(ctx)
+	if err != nil {
+		return nil, newError("unable to authenticate with Vault: %v", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Stop renewing the token of the previous configuration
+	if m.cancelRenewal != nil {
+		m.cancelRenewal()
+		m.cancelRenewal = nil
+	}
+
+	m.config = config
+	m.namespace = namespace
+	m.client = client
+
+	if auth.Renewable && auth.TTL() > 0 {
+		renewalCtx, cancel := context.WithCancel(context.Background())
+		m.cancelRenewal = cancel
+		go client.RenewToken(renewalCtx, m.log, m.hooks.clock, auth.TTL())
+	}
+
+	return &plugin.ConfigureResponse{}, nil
+}
+
+func (m *KeyManager) GetPluginInfo(ctx context.Context, req *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error) {
+	return &plugin.GetPluginInfoResponse{}, nil
+}
+
+func (m *KeyManager) GenerateKey(ctx context.Context, req *keymanager.GenerateKeyRequest) (*keymanager.GenerateKeyResponse, error) {
+	if req.KeyId == "" {
+		return nil, newError("key id is required")
+	}
+	if req.KeyType == keymanager.KeyType_UNSPECIFIED_KEY_TYPE {
+		return nil, newError("key type is required")
+	}
+	transitType, ok := transitKeyTypes[req.KeyType]
+	if !ok {
+		return nil, newError("unsupported key type %q", req.KeyType)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.client == nil {
+		return nil, newError("not configured")
+	}
+
+	name := m.keyName(req.KeyId)
+	existing, err := m.readKey(ctx, name)
+	if err != nil {
+		return nil, newError("unable to read key %q: %v", req.KeyId, err)
+	}
+
+	switch {
+	case existing == nil:
+		if err := m.createKey(ctx, name, transitType); err != nil {
+			return nil, newError("unable to create key %q: %v", req.KeyId, err)
+		}
+	case existing.Type == transitType:
+		// Rotating the key creates a new version that is used for signing
+		// from then on.
+		if _, err := m.client.Write(ctx, m.transitPath("keys", name, "rotate"), struct{}{}); err != nil {
+			return nil, newError("unable to rotate key %q: %v", req.KeyId, err)
+		}
+	default:
+		// The type of a Transit key cannot be changed, so the key is replaced.
+		if err := m.deleteKey(ctx, name); err != nil {
+			return nil, newError("unable to delete previous key %q: %v", req.KeyId, err)
+		}
+		if err := m.createKey(ctx, name, transitType); err != nil {
+			return nil, newError("unable to create key %q: %v", req.KeyId, err)
+		}
+	}
+
+	key, err := m.readKey(ctx, name)
+	if err != nil {
+		return nil, newError("unable to read key %q: %v", req.KeyId, err)
+	}
+	if key == nil {
+		return nil, newError("key %q not found after being generated", req.KeyId)
+	}
+
+	publicKey, err := makePublicKey(req.KeyId, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &keymanager.GenerateKeyResponse{
+		PublicKey: publicKey,
+	}, nil
+}
+
+func (m *KeyManager) GetPublicKey(ctx context.Context, req *keymanager.GetPublicKeyRequest) (*keymanager.GetPublicKeyResponse, error) {
+	if req.KeyId == "" {
+		return nil, newError("key id is required")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.client == nil {
+		return nil, newError("not configured")
+	}
+
+	key, err := m.readKey(ctx, m.keyName(req.KeyId))
+	if err != nil {
+		return nil, newError("unable to read key %q: %v", req.KeyId, err)
+	}
+
+	resp := new(keymanager.GetPublicKeyResponse)
+	if key != nil {
+		resp.PublicKey, err = makePublicKey(req.KeyId, key)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+func (m *KeyManager) GetPublicKeys(ctx context.Context, req *keymanager.GetPublicKeysRequest) (*keymanager.GetPublicKeysResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.client == nil {
+		return nil, newError("not configured")
+	}
+
+	names, err := m.client.List(ctx, m.transitPath("keys"))
+	switch {
+	case vault.IsNotFound(err):
+		// Vault responds with a 404 when there are no keys at all
+		names = nil
+	case err != nil:
+		return nil, newError("unable to list keys: %v", err)
+	}
+
+	resp := new(keymanager.GetPublicKeysResponse)
+	keyNamePrefix := m.keyName("")
+	for _, name := range names {
+		if !strings.HasPrefix(name, keyNamePrefix) {
+			// not a key managed by this server
+			continue
+		}
+		keyID := strings.TrimPrefix(name, keyNamePrefix)
+		key, err := m.readKey(ctx, name)
+		if err != nil {
+			return nil, newError("unable to read key %q: %v", keyID, err)
+		}
+		if key == nil {
+			// deleted since being listed
+			continue
+		}
+		publicKey, err := makePublicKey(keyID, key)
+		if err != nil {
+			return nil, err
+		}
+		resp.PublicKeys = append(resp.PublicKeys, publicKey)
+	}
+
+	// return keys in sorted order for consistency
+	sort.Slice(resp.PublicKeys, func(i, j int) bool {
+		return resp.PublicKeys[i].Id < resp.PublicKeys[j].Id
+	})
+
+	return resp, nil
+}
+
+func (m *KeyManager) SignData(ctx context.Context, req *keymanager.SignDataRequest) (*keymanager.SignDataResponse, error) {
+	if req.KeyId == "" {
+		return nil, newError("key id is required")
+	}
+	if req.SignerOpts == nil {
+		return nil, newError("signer opts is required")
+	}
+
+	var hashAlgorithm keymanager.HashAlgorithm
+	var pssOptions *keymanager.PSSOptions
+	switch opts := req.SignerOpts.(type) {
+	case *keymanager.SignDataRequest_HashAlgorithm:
+		hashAlgorithm = opts.HashAlgorithm
+	case *keymanager.SignDataRequest_PssOptions:
+		if opts.PssOptions == nil {
+			return nil, newError("PSS options are nil")
+		}
+		hashAlgorithm = opts.PssOptions.HashAlgorithm
+		pssOptions = opts.PssOptions
+	default:
+		return nil, newError("unsupported signer opts type %T", opts)
+	}
+	if hashAlgorithm == keymanager.HashAlgorithm_UNSPECIFIED_HASH_ALGORITHM {
+		return nil, newError("hash algorithm is required")
+	}
+	transitHash, ok := transitHashAlgorithms[hashAlgorithm]
+	if !ok {
+		return nil, newError("unsupported hash algorithm %q", hashAlgorithm)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.client == nil {
+		return nil, newError("not configured")
+	}
+
+	name := m.keyName(req.KeyId)
+	key, err := m.readKey(ctx, name)
+	if err != nil {
+		return nil, newError("unable to read key %q: %v", req.KeyId, err)
+	}
+	if key == nil {
+		return nil, newError("no such key %q", req.KeyId)
+	}
+	// The hash algorithm enum values line up with crypto.Hash
+	if len(req.Data) != crypto.Hash(hashAlgorithm).Size() {
+		return nil, newError("data length %d does not match %s digest size", len(req.Data), hashAlgorithm)
+	}
+
+	signReq := &signRequest{
+		Input:               base64.StdEncoding.EncodeToString(req.Data),
+		Prehashed:           true,
+		MarshalingAlgorithm: "asn1",
+	}
+	switch {
+	case strings.HasPrefix(key.Type, "rsa-"):
+		if pssOptions != nil {
+			signReq.SignatureAlgorithm = "pss"
+			switch saltLength := int(pssOptions.SaltLength); {
+			case saltLength == rsa.PSSSaltLengthAuto:
+				signReq.SaltLength = "auto"
+			case saltLength == rsa.PSSSaltLengthEqualsHash:
+				signReq.SaltLength = "hash"
+			case saltLength > 0:
+				signReq.SaltLength = strconv.Itoa(saltLength)
+			default:
+				return nil, newError("invalid PSS salt length %d", saltLength)
+			}
+		} else {
+			signReq.SignatureAlgorithm = "pkcs1v15"
+		}
+	case pssOptions != nil:
+		return nil, newError("PSS options are not supported for key %q", req.KeyId)
+	}
+
+	secret, err := m.client.Write(ctx, m.transitPath("sign", name, transitHash), signReq)
+	if err != nil {
+		return nil, newError("keypair %q signing operation failed: %v", req.KeyId, err)
+	}
+	data := new(signData)
+	if err := secret.DecodeData(data); err != nil {
+		return nil, newError("keypair %q signing operation failed: %v", req.KeyId, err)
+	}
+	signature, err := parseSignature(data.Signature)
+	if err != nil {
+		return nil, newError("keypair %q signing operation failed: %v", req.KeyId, err)
+	}
+
+	return &keymanager.SignDataResponse{
+		Signature: signature,
+	}, nil
+}
+
+// keyName returns the Transit key name for the given key ID. Keys are
+// namespaced by server ID so that servers sharing the Transit secrets engine
+// do not overwrite each other's keys, unless a shared key namespace is
+// configured.
+func (m *KeyManager) keyName(keyID string) string {
+	return fmt.Sprintf("%s%s-%s", m.config.KeyNamePrefix, m.namespace, keyID)
+}
+
+func (m *KeyManager) transitPath(elems ...string) string {
+	return strings.Join(append([]string{m.config.TransitMountPoint}, elems...), "/")
+}
+
+// readKey reads the Transit key with the given name. It returns nil if the
+// key does not exist.
+func (m *KeyManager) readKey(ctx context.Context, name string) (*transitKey, error) {
+	secret, err := m.client.Read(ctx, m.transitPath("keys", name))
+	switch {
+	case vault.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	key := new(transitKey)
+	if err := secret.DecodeData(key); err != nil {
+		return nil, fmt.Errorf("unable to decode key data: %v", err)
+	}
+	return key, nil
+}
+
+func (m *KeyManager) createKey(ctx context.Context, name, transitType string) error {
+	_, err := m.client.Write(ctx, m.transitPath("keys", name), map[string]interface{}{
+		"type":       transitType,
+		"exportable": false,
+	})
+	return err
+}
+
+func (m *KeyManager) deleteKey(ctx context.Context, name string) error {
+	// Transit keys cannot be deleted unless explicitly allowed
+	if _, err := m.client.Write(ctx, m.transitPath("keys", name, "config"), map[string]interface{}{
+		"deletion_allowed": true,
+	}); err != nil {
+		return err
+	}
+	return m.client.Delete(ctx, m.transitPath("keys", name))
+}
+
+func makePublicKey(keyID string, key *transitKey) (*keymanager.PublicKey, error) {
+	keyType, ok := keyTypeFromTransitType(key.Type)
+	if !ok {
+		return nil, newError("unsupported Transit key type %q for key %q", key.Type, keyID)
+	}
+	version, ok := key.Keys[strconv.Itoa(key.LatestVersion)]
+	if !ok || version.PublicKey == "" {
+		return nil, newError("no public key for latest version of key %q", keyID)
+	}
+	block, _ := pem.Decode([]byte(version.PublicKey))
+	if block == nil {
+		return nil, newError("unable to decode public key %q PEM", keyID)
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, newError("unable to parse public key %q: %v", keyID, err)
+	}
+	pkixData, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return nil, newError("unable to marshal public key %q: %v", keyID, err)
+	}
+	return &keymanager.PublicKey{
+		Id:       keyID,
+		Type:     keyType,
+		PkixData: pkixData,
+	}, nil
+}
+
+func keyTypeFromTransitType(transitType string) (keymanager.KeyType, bool) {
+	for keyType, t := range transitKeyTypes {
+		if t == transitType {
+			return keyType, true
+		}
+	}
+	return keymanager.KeyType_UNSPECIFIED_KEY_TYPE, false
+}
+
+// parseSignature parses a Transit signature of the form
+// "vault:v<version>:<base64 signature>"
+func parseSignature(signature string) ([]byte, error) {
+	parts := strings.Split(signature, ":")
+	if len(parts) != 3 || parts[0] != "vault" {
+		return nil, fmt.Errorf("malformed signature %q", signature)
+	}
+	return base64.StdEncoding.DecodeString(parts[2])
+}
+
+// loadOrCreateServerID loads the server ID from the key metadata file,
+// creating the file with a new server ID if it does not exist
+func loadOrCreateServerID(path string) (string, error) {
+	data, err := ioutil.ReadFile(path)
+	switch {
+	case err == nil:
+		serverID := strings.TrimSpace(string(data))
+		if serverID == "" {
+			return "", fmt.Errorf("key metadata file %q is empty", path)
+		}
+		return serverID, nil
+	case !os.IsNotExist(err):
+		return "", fmt.Errorf("unable to read key metadata file: %v", err)
+	}
+
+	serverID, err := uuid.NewV4()
+	if err != nil {
+		return "", fmt.Errorf("unable to generate server ID: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("unable to create key metadata file directory: %v", err)
+	}
+	if err := diskutil.AtomicWriteFile(path, []byte(serverID.String()), 0644); err != nil {
+		return "", fmt.Errorf("unable to write key metadata file: %v", err)
+	}
+	return serverID.String(), nil
+}
+
+func newError(format string, args ...interface{}) error {
+	return fmt.Errorf("keymanager(vault): "+format, args...)
+}
@@ -0,0 +1,341 @@
+package vault
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/spiffe/spire/pkg/common/plugin/vault"
+)
+
+const (
+	fakeRootToken = "root-token"
+)
+
+var fakeTransitHashes = map[string]crypto.Hash{
+	"sha2-224": crypto.SHA224,
+	"sha2-256": crypto.SHA256,
+	"sha2-384": crypto.SHA384,
+	"sha2-512": crypto.SHA512,
+	"sha3-224": crypto.SHA3_224,
+	"sha3-256": crypto.SHA3_256,
+	"sha3-384": crypto.SHA3_384,
+	"sha3-512": crypto.SHA3_512,
+}
+
+type fakeTransitKey struct {
+	transitType     string
+	versions        []crypto.Signer
+	deletionAllowed bool
+}
+
+// fakeTransit is a stand-in for the parts of the Vault HTTP API used by the
+// plugin. The Transit secrets engine is mounted at "transit" and the root
+// token is the only token accepted.
+type fakeTransit struct {
+	*httptest.Server
+
+	mu   sync.Mutex
+	keys map[string]*fakeTransitKey
+}
+
+func newFakeTransit() *fakeTransit {
+	v := &fakeTransit{
+		keys: make(map[string]*fakeTransitKey),
+	}
+	v.Server = httptest.NewTLSServer(http.HandlerFunc(v.serveHTTP))
+	return v
+}
+
+func (v *fakeTransit) KeyNames() []string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	var names []string
+	for name := range v.keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (v *fakeTransit) KeyVersions(name string) int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	key, ok := v.keys[name]
+	if !ok {
+		return 0
+	}
+	return len(key.versions)
+}
+
+func (v *fakeTransit) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Header.Get(vault.TokenHeader) != fakeRootToken {
+		writeError(w, http.StatusForbidden, "permission denied")
+		return
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	path := strings.Split(strings.TrimPrefix(req.URL.Path, "/v1/"), "/")
+	switch {
+	case len(path) == 3 && path[0] == "auth" && path[1] == "token" && path[2] == "lookup-self":
+		// the root token never expires
+		writeResponse(w, map[string]interface{}{
+			"data": map[string]interface{}{
+				"ttl":       0,
+				"renewable": false,
+			},
+		})
+	case len(path) == 2 && path[0] == "transit" && path[1] == "keys" && req.Method == "LIST":
+		v.listKeys(w)
+	case len(path) == 3 && path[0] == "transit" && path[1] == "keys":
+		switch req.Method {
+		case http.MethodGet:
+			v.readKey(w, path[2])
+		case http.MethodPost:
+			v.createKey(w, req, path[2])
+		case http.MethodDelete:
+			v.deleteKey(w, path[2])
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "unsupported operation")
+		}
+	case len(path) == 4 && path[0] == "transit" && path[1] == "keys" && path[3] == "rotate":
+		v.rotateKey(w, path[2])
+	case len(path) == 4 && path[0] == "transit" && path[1] == "keys" && path[3] == "config":
+		v.configureKey(w, req, path[2])
+	case len(path) == 4 && path[0] == "transit" && path[1] == "sign":
+		v.sign(w, req, path[2], path[3])
+	default:
+		writeError(w, http.StatusNotFound, "no handler for route")
+	}
+}
+
+func (v *fakeTransit) listKeys(w http.ResponseWriter) {
+	if len(v.keys) == 0 {
+		writeError(w, http.StatusNotFound)
+		return
+	}
+	var names []string
+	for name := range v.keys {
+		names = append(names, name)
+	}
+	writeResponse(w, map[string]interface{}{
+		"data": map[string]interface{}{
+			"keys": names,
+		},
+	})
+}
+
+func (v *fakeTransit) readKey(w http.ResponseWriter, name string) {
+	key, ok := v.keys[name]
+	if !ok {
+		writeError(w, http.StatusNotFound)
+		return
+	}
+
+	versions := make(map[string]interface{})
+	for i, signer := range key.versions {
+		pkixData, err := x509.MarshalPKIXPublicKey(signer.Public())
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		versions[strconv.Itoa(i+1)] = map[string]interface{}{
+			"public_key": string(pem.EncodeToMemory(&pem.Block{
+				Type:  "PUBLIC KEY",
+				Bytes: pkixData,
+			})),
+		}
+	}
+	writeResponse(w, map[string]interface{}{
+		"data": map[string]interface{}{
+			"type":           key.transitType,
+			"latest_version": len(key.versions),
+			"keys":           versions,
+		},
+	})
+}
+
+func (v *fakeTransit) createKey(w http.ResponseWriter, req *http.Request, name string) {
+	body := new(struct {
+		Type       string `json:"type"`
+		Exportable bool   `json:"exportable"`
+	})
+	if err := json.NewDecoder(req.Body).Decode(body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if body.Exportable {
+		writeError(w, http.StatusBadRequest, "keys should not be exportable")
+		return
+	}
+
+	// like Vault, creating a key that already exists is a no-op
+	if _, ok := v.keys[name]; !ok {
+		signer, err := generateSigner(body.Type)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		v.keys[name] = &fakeTransitKey{
+			transitType: body.Type,
+			versions:    []crypto.Signer{signer},
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (v *fakeTransit) deleteKey(w http.ResponseWriter, name string) {
+	key, ok := v.keys[name]
+	if !ok {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if !key.deletionAllowed {
+		writeError(w, http.StatusBadRequest, "deletion is not allowed for this key")
+		return
+	}
+	delete(v.keys, name)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (v *fakeTransit) rotateKey(w http.ResponseWriter, name string) {
+	key, ok := v.keys[name]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "key not found")
+		return
+	}
+	signer, err := generateSigner(key.transitType)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	key.versions = append(key.versions, signer)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (v *fakeTransit) configureKey(w http.ResponseWriter, req *http.Request, name string) {
+	key, ok := v.keys[name]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "no existing key named "+name+" could be found")
+		return
+	}
+	body := new(struct {
+		DeletionAllowed *bool `json:"deletion_allowed"`
+	})
+	if err := json.NewDecoder(req.Body).Decode(body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if body.DeletionAllowed != nil {
+		key.deletionAllowed = *body.DeletionAllowed
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (v *fakeTransit) sign(w http.ResponseWriter, req *http.Request, name, hashName string) {
+	key, ok := v.keys[name]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "signing key not found")
+		return
+	}
+	hash, ok := fakeTransitHashes[hashName]
+	if !ok {
+		writeError(w, http.StatusBadRequest, "unsupported hash algorithm "+hashName)
+		return
+	}
+
+	body := new(signRequest)
+	if err := json.NewDecoder(req.Body).Decode(body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if !body.Prehashed || body.MarshalingAlgorithm != "asn1" {
+		writeError(w, http.StatusBadRequest, "expected prehashed input and asn1 marshaling")
+		return
+	}
+	digest, err := base64.StdEncoding.DecodeString(body.Input)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var opts crypto.SignerOpts = hash
+	if strings.HasPrefix(key.transitType, "rsa-") {
+		switch body.SignatureAlgorithm {
+		case "pkcs1v15":
+		case "pss":
+			pssOpts := &rsa.PSSOptions{Hash: hash}
+			switch body.SaltLength {
+			case "auto":
+				pssOpts.SaltLength = rsa.PSSSaltLengthAuto
+			case "hash":
+				pssOpts.SaltLength = rsa.PSSSaltLengthEqualsHash
+			default:
+				pssOpts.SaltLength, err = strconv.Atoi(body.SaltLength)
+				if err != nil {
+					writeError(w, http.StatusBadRequest, "invalid salt length")
+					return
+				}
+			}
+			opts = pssOpts
+		default:
+			writeError(w, http.StatusBadRequest, "unsupported signature algorithm "+body.SignatureAlgorithm)
+			return
+		}
+	}
+
+	version := len(key.versions)
+	signature, err := key.versions[version-1].Sign(rand.Reader, digest, opts)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeResponse(w, map[string]interface{}{
+		"data": map[string]interface{}{
+			"signature": fmt.Sprintf("vault:v%d:%s", version, base64.StdEncoding.EncodeToString(signature)),
+		},
+	})
+}
+
+func generateSigner(transitType string) (crypto.Signer, error) {
+	switch transitType {
+	case "ecdsa-p256":
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case "ecdsa-p384":
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case "rsa-2048":
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case "rsa-4096":
+		return rsa.GenerateKey(rand.Reader, 4096)
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", transitType)
+	}
+}
+
+func writeResponse(w http.ResponseWriter, resp interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func writeError(w http.ResponseWriter, code int, msgs ...string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(vault.ErrorResponse{
+		Errors: msgs,
+	})
+}
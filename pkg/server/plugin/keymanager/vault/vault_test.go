@@ -0,0 +1,389 @@
+package vault
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/spiffe/spire/pkg/server/plugin/keymanager/test"
+	"github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/proto/spire/server/keymanager"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	ctx = context.Background()
+)
+
+func TestKeyManager(t *testing.T) {
+	transit := newFakeTransit()
+	defer transit.Close()
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	// each key manager gets a new metadata file, and therefore a new server
+	// ID, so the tests do not see the keys of the tests before them
+	n := 0
+	test.Run(t, func(t *testing.T) catalog.Plugin {
+		n++
+		return builtin(configureKeyManager(t, transit, filepath.Join(dir, fmt.Sprintf("metadata-%d", n))))
+	}, test.UnsupportedKeyTypes(keymanager.KeyType_RSA_1024))
+}
+
+func TestConfigure(t *testing.T) {
+	transit := newFakeTransit()
+	defer transit.Close()
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	caCertPath := filepath.Join(dir, "vault-ca.pem")
+	require.NoError(t, ioutil.WriteFile(caCertPath, pemutil.EncodeCertificate(transit.Certificate()), 0600))
+	emptyMetadataPath := filepath.Join(dir, "empty")
+	require.NoError(t, ioutil.WriteFile(emptyMetadataPath, nil, 0600))
+
+	for _, tt := range []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{
+			name:   "malformed",
+			config: "{{",
+			err:    "keymanager(vault): unable to decode configuration",
+		},
+		{
+			name:   "missing vault address",
+			config: `token_auth { token = "foo" } key_metadata_file = "metadata"`,
+			err:    "keymanager(vault): vault_addr is required",
+		},
+		{
+			name:   "missing auth method",
+			config: `vault_addr = "https://vault" key_metadata_file = "metadata"`,
+			err:    "keymanager(vault): one of token_auth, approle_auth or cert_auth is required",
+		},
+		{
+			name:   "missing key metadata file",
+			config: `vault_addr = "https://vault" token_auth { token = "foo" }`,
+			err:    "keymanager(vault): one of key_metadata_file or shared_key_namespace is required",
+		},
+		{
+			name:   "key metadata file and shared key namespace",
+			config: `vault_addr = "https://vault" token_auth { token = "foo" } key_metadata_file = "metadata" shared_key_namespace = "spire"`,
+			err:    "keymanager(vault): key_metadata_file and shared_key_namespace are mutually exclusive",
+		},
+		{
+			name:   "empty key metadata file",
+			config: fmt.Sprintf(`vault_addr = "https://vault" token_auth { token = "foo" } key_metadata_file = %q`, emptyMetadataPath),
+			err:    fmt.Sprintf("keymanager(vault): key metadata file %q is empty", emptyMetadataPath),
+		},
+		{
+			name: "bad token",
+			config: fmt.Sprintf(`
+				vault_addr = %q
+				ca_cert_path = %q
+				token_auth { token = "bad" }
+				key_metadata_file = %q`, transit.URL, caCertPath, filepath.Join(dir, "metadata")),
+			err: "keymanager(vault): unable to authenticate with Vault: GET auth/token/lookup-self failed (403): permission denied",
+		},
+		{
+			name: "success",
+			config: fmt.Sprintf(`
+				vault_addr = %q
+				ca_cert_path = %q
+				token_auth { token = %q }
+				key_metadata_file = %q`, transit.URL, caCertPath, fakeRootToken, filepath.Join(dir, "metadata")),
+		},
+		{
+			name: "success with shared key namespace",
+			config: fmt.Sprintf(`
+				vault_addr = %q
+				ca_cert_path = %q
+				token_auth { token = %q }
+				shared_key_namespace = "spire"`, transit.URL, caCertPath, fakeRootToken),
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			m := New()
+			m.hooks.getenv = func(string) string { return "" }
+			_, err := m.Configure(ctx, &plugin.ConfigureRequest{
+				Configuration: tt.config,
+			})
+			if tt.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestNotConfigured(t *testing.T) {
+	m := New()
+	_, err := m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
+		KeyId:   "KEY",
+		KeyType: keymanager.KeyType_EC_P256,
+	})
+	require.EqualError(t, err, "keymanager(vault): not configured")
+}
+
+func TestServerIDIsPersisted(t *testing.T) {
+	transit := newFakeTransit()
+	defer transit.Close()
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	metadataPath := filepath.Join(dir, "metadata")
+	a := configureKeyManager(t, transit, metadataPath)
+	generated, err := a.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
+		KeyId:   "KEY",
+		KeyType: keymanager.KeyType_EC_P256,
+	})
+	require.NoError(t, err)
+
+	serverID, err := ioutil.ReadFile(metadataPath)
+	require.NoError(t, err)
+	require.Equal(t, []string{fmt.Sprintf("spire-server-%s-KEY", serverID)}, transit.KeyNames())
+
+	// a key manager configured with the same metadata file (e.g. after a
+	// restart) finds the key
+	b := configureKeyManager(t, transit, metadataPath)
+	resp, err := b.GetPublicKey(ctx, &keymanager.GetPublicKeyRequest{KeyId: "KEY"})
+	require.NoError(t, err)
+	require.Equal(t, generated.PublicKey, resp.PublicKey)
+}
+
+func TestServersDoNotShareKeys(t *testing.T) {
+	transit := newFakeTransit()
+	defer transit.Close()
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	a := configureKeyManager(t, transit, filepath.Join(dir, "metadata-a"))
+	b := configureKeyManager(t, transit, filepath.Join(dir, "metadata-b"))
+
+	_, err := a.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
+		KeyId:   "KEY",
+		KeyType: keymanager.KeyType_EC_P256,
+	})
+	require.NoError(t, err)
+
+	resp, err := b.GetPublicKey(ctx, &keymanager.GetPublicKeyRequest{KeyId: "KEY"})
+	require.NoError(t, err)
+	require.Nil(t, resp.PublicKey)
+
+	keysResp, err := b.GetPublicKeys(ctx, &keymanager.GetPublicKeysRequest{})
+	require.NoError(t, err)
+	require.Empty(t, keysResp.PublicKeys)
+
+	keysResp, err = a.GetPublicKeys(ctx, &keymanager.GetPublicKeysRequest{})
+	require.NoError(t, err)
+	require.Len(t, keysResp.PublicKeys, 1)
+	require.Equal(t, "KEY", keysResp.PublicKeys[0].Id)
+}
+
+func TestServersShareKeysInSharedNamespace(t *testing.T) {
+	transit := newFakeTransit()
+	defer transit.Close()
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	a := configureSharedKeyManager(t, transit, dir, "spire")
+	b := configureSharedKeyManager(t, transit, dir, "spire")
+	other := configureSharedKeyManager(t, transit, dir, "other")
+
+	generated, err := a.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
+		KeyId:   "KEY",
+		KeyType: keymanager.KeyType_EC_P256,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"spire-server-spire-KEY"}, transit.KeyNames())
+
+	resp, err := b.GetPublicKey(ctx, &keymanager.GetPublicKeyRequest{KeyId: "KEY"})
+	require.NoError(t, err)
+	require.Equal(t, generated.PublicKey, resp.PublicKey)
+	requireSignatureVerifies(t, b, "KEY", generated.PublicKey)
+
+	resp, err = other.GetPublicKey(ctx, &keymanager.GetPublicKeyRequest{KeyId: "KEY"})
+	require.NoError(t, err)
+	require.Nil(t, resp.PublicKey)
+}
+
+func TestGenerateKeyRotatesExistingKey(t *testing.T) {
+	transit := newFakeTransit()
+	defer transit.Close()
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	m := configureKeyManager(t, transit, filepath.Join(dir, "metadata"))
+	first, err := m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
+		KeyId:   "KEY",
+		KeyType: keymanager.KeyType_EC_P256,
+	})
+	require.NoError(t, err)
+	second, err := m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
+		KeyId:   "KEY",
+		KeyType: keymanager.KeyType_EC_P256,
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, first.PublicKey.PkixData, second.PublicKey.PkixData)
+
+	// the key was rotated rather than replaced
+	require.Equal(t, 2, transit.KeyVersions(m.keyName("KEY")))
+
+	// and data is signed using the latest version
+	requireSignatureVerifies(t, m, "KEY", second.PublicKey)
+}
+
+func TestGenerateKeyReplacesKeyOfDifferentType(t *testing.T) {
+	transit := newFakeTransit()
+	defer transit.Close()
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	m := configureKeyManager(t, transit, filepath.Join(dir, "metadata"))
+	_, err := m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
+		KeyId:   "KEY",
+		KeyType: keymanager.KeyType_EC_P256,
+	})
+	require.NoError(t, err)
+	second, err := m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
+		KeyId:   "KEY",
+		KeyType: keymanager.KeyType_EC_P384,
+	})
+	require.NoError(t, err)
+	require.Equal(t, keymanager.KeyType_EC_P384, second.PublicKey.Type)
+	require.Equal(t, 1, transit.KeyVersions(m.keyName("KEY")))
+
+	resp, err := m.GetPublicKey(ctx, &keymanager.GetPublicKeyRequest{KeyId: "KEY"})
+	require.NoError(t, err)
+	require.Equal(t, second.PublicKey, resp.PublicKey)
+}
+
+func TestSignDataDigestSizeMismatch(t *testing.T) {
+	transit := newFakeTransit()
+	defer transit.Close()
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	m := configureKeyManager(t, transit, filepath.Join(dir, "metadata"))
+	_, err := m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
+		KeyId:   "KEY",
+		KeyType: keymanager.KeyType_EC_P256,
+	})
+	require.NoError(t, err)
+
+	_, err = m.SignData(ctx, &keymanager.SignDataRequest{
+		KeyId: "KEY",
+		Data:  []byte("too short"),
+		SignerOpts: &keymanager.SignDataRequest_HashAlgorithm{
+			HashAlgorithm: keymanager.HashAlgorithm_SHA256,
+		},
+	})
+	require.EqualError(t, err, "keymanager(vault): data length 9 does not match SHA256 digest size")
+}
+
+func TestSignDataUnsupportedHashAlgorithm(t *testing.T) {
+	m := New()
+	_, err := m.SignData(ctx, &keymanager.SignDataRequest{
+		KeyId: "KEY",
+		Data:  make([]byte, 32),
+		SignerOpts: &keymanager.SignDataRequest_HashAlgorithm{
+			HashAlgorithm: keymanager.HashAlgorithm_SHA512_256,
+		},
+	})
+	require.EqualError(t, err, `keymanager(vault): unsupported hash algorithm "SHA512_256"`)
+}
+
+func TestSignDataPSSWithECKey(t *testing.T) {
+	transit := newFakeTransit()
+	defer transit.Close()
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	m := configureKeyManager(t, transit, filepath.Join(dir, "metadata"))
+	_, err := m.GenerateKey(ctx, &keymanager.GenerateKeyRequest{
+		KeyId:   "KEY",
+		KeyType: keymanager.KeyType_EC_P256,
+	})
+	require.NoError(t, err)
+
+	_, err = m.SignData(ctx, &keymanager.SignDataRequest{
+		KeyId: "KEY",
+		Data:  make([]byte, 32),
+		SignerOpts: &keymanager.SignDataRequest_PssOptions{
+			PssOptions: &keymanager.PSSOptions{
+				HashAlgorithm: keymanager.HashAlgorithm_SHA256,
+			},
+		},
+	})
+	require.EqualError(t, err, `keymanager(vault): PSS options are not supported for key "KEY"`)
+}
+
+func configureKeyManager(t *testing.T, transit *fakeTransit, metadataPath string) *KeyManager {
+	caCertPath := filepath.Join(filepath.Dir(metadataPath), "vault-ca.pem")
+	require.NoError(t, ioutil.WriteFile(caCertPath, pemutil.EncodeCertificate(transit.Certificate()), 0600))
+
+	m := New()
+	resp, err := m.Configure(ctx, &plugin.ConfigureRequest{
+		Configuration: fmt.Sprintf(`
+			vault_addr = %q
+			ca_cert_path = %q
+			token_auth { token = %q }
+			key_metadata_file = %q`, transit.URL, caCertPath, fakeRootToken, metadataPath),
+	})
+	require.NoError(t, err)
+	require.Equal(t, &plugin.ConfigureResponse{}, resp)
+	return m
+}
+
+func configureSharedKeyManager(t *testing.T, transit *fakeTransit, dir, namespace string) *KeyManager {
+	caCertPath := filepath.Join(dir, "vault-ca.pem")
+	require.NoError(t, ioutil.WriteFile(caCertPath, pemutil.EncodeCertificate(transit.Certificate()), 0600))
+
+	m := New()
+	_, err := m.Configure(ctx, &plugin.ConfigureRequest{
+		Configuration: fmt.Sprintf(`
+			vault_addr = %q
+			ca_cert_path = %q
+			token_auth { token = %q }
+			shared_key_namespace = %q`, transit.URL, caCertPath, fakeRootToken, namespace),
+	})
+	require.NoError(t, err)
+	return m
+}
+
+func requireSignatureVerifies(t *testing.T, m *KeyManager, keyID string, publicKey *keymanager.PublicKey) {
+	digest := sha256.Sum256([]byte("DATA"))
+	resp, err := m.SignData(ctx, &keymanager.SignDataRequest{
+		KeyId: keyID,
+		Data:  digest[:],
+		SignerOpts: &keymanager.SignDataRequest_HashAlgorithm{
+			HashAlgorithm: keymanager.HashAlgorithm(crypto.SHA256),
+		},
+	})
+	require.NoError(t, err)
+
+	key, err := x509.ParsePKIXPublicKey(publicKey.PkixData)
+	require.NoError(t, err)
+	ecdsaKey, ok := key.(*ecdsa.PublicKey)
+	require.True(t, ok)
+	require.True(t, ecdsa.VerifyASN1(ecdsaKey, digest[:], resp.Signature))
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", strings.Replace(t.Name(), "/", "-", -1))
+	require.NoError(t, err)
+	return dir
+}
@@ -0,0 +1,135 @@
+package upstreamauthority
+
+import (
+	"context"
+	"crypto/x509"
+	"io"
+
+	"github.com/spiffe/spire/proto/spire/server/upstreamauthority"
+	"github.com/spiffe/spire/proto/spire/server/upstreamca"
+	"github.com/zeebo/errs"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Wrap adapts an UpstreamCA plugin to the UpstreamAuthority interface. The
+// CSR is submitted to the UpstreamCA and the signed certificate chain and
+// upstream roots are sent in a single response, after which the stream is
+// closed, since UpstreamCA plugins cannot notify about changes to the
+// upstream roots. UpstreamCA plugins cannot publish JWT signing keys.
+func Wrap(upstreamCA upstreamca.UpstreamCA) upstreamauthority.UpstreamAuthority {
+	return &wrapper{upstreamCA: upstreamCA}
+}
+
+type wrapper struct {
+	upstreamCA upstreamca.UpstreamCA
+}
+
+func (w *wrapper) MintX509CA(ctx context.Context, req *upstreamauthority.MintX509CARequest) (upstreamauthority.UpstreamAuthority_MintX509CAClient, error) {
+	resp, err := w.upstreamCA.SubmitCSR(ctx, &upstreamca.SubmitCSRRequest{
+		Csr: req.Csr,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	certChain, trustBundle, err := parseSubmitCSRResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mintX509CAClient{
+		ctx: ctx,
+		resp: &upstreamauthority.MintX509CAResponse{
+			X509CaChain:       rawCertificates(certChain),
+			UpstreamX509Roots: rawCertificates(trustBundle),
+		},
+	}, nil
+}
+
+func (w *wrapper) PublishJWTKey(context.Context, *upstreamauthority.PublishJWTKeyRequest) (upstreamauthority.UpstreamAuthority_PublishJWTKeyClient, error) {
+	return nil, status.Error(codes.Unimplemented, "publishing JWT keys is not supported by UpstreamCA plugins")
+}
+
+// mintX509CAClient is a stream that returns a single response.
+type mintX509CAClient struct {
+	grpc.ClientStream
+
+	ctx  context.Context
+	resp *upstreamauthority.MintX509CAResponse
+}
+
+func (c *mintX509CAClient) Recv() (*upstreamauthority.MintX509CAResponse, error) {
+	if c.resp == nil {
+		return nil, io.EOF
+	}
+	resp := c.resp
+	c.resp = nil
+	return resp, nil
+}
+
+func (c *mintX509CAClient) Context() context.Context {
+	return c.ctx
+}
+
+func (c *mintX509CAClient) CloseSend() error {
+	return nil
+}
+
+func parseSubmitCSRResponse(resp *upstreamca.SubmitCSRResponse) ([]*x509.Certificate, []*x509.Certificate, error) {
+	if resp.SignedCertificate != nil {
+		certChain, err := x509.ParseCertificates(resp.SignedCertificate.CertChain)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(certChain) == 0 {
+			return nil, nil, errs.New("upstream CA returned an empty cert chain")
+		}
+		trustBundle, err := x509.ParseCertificates(resp.SignedCertificate.Bundle)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(trustBundle) == 0 {
+			return nil, nil, errs.New("upstream CA returned an empty trust bundle")
+		}
+		return certChain, trustBundle, nil
+	}
+
+	// This is an old response from the upstream CA. The assumption from the
+	// manager was that Cert contained a single certificate representing the
+	// newly signed CA certificate and UpstreamTrustBundle contained the rest
+	// of the full chain back to the upstream "root".
+	cert, err := x509.ParseCertificate(resp.DEPRECATEDCert)
+	if err != nil {
+		return nil, nil, err
+	}
+	trustBundle, err := x509.ParseCertificates(resp.DEPRECATEDUpstreamTrustBundle)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	certChain := []*x509.Certificate{cert}
+
+	switch len(trustBundle) {
+	case 0:
+		return nil, nil, errs.New("upstream CA returned an empty trust bundle")
+	case 1:
+		return certChain, trustBundle, nil
+	default:
+		// append the "intermediates" at the start of the upstream bundle
+		certChain = append(certChain, trustBundle[:len(trustBundle)-1]...)
+		// only consider the "root" of the upstream bundle as part of the
+		// trust bundle
+		trustBundle = trustBundle[len(trustBundle)-1:]
+		return certChain, trustBundle, nil
+	}
+}
+
+func rawCertificates(certs []*x509.Certificate) [][]byte {
+	var rawCerts [][]byte
+	for _, cert := range certs {
+		rawCerts = append(rawCerts, cert.Raw)
+	}
+	return rawCerts
+}
@@ -0,0 +1,82 @@
+package upstreamauthority
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net/url"
+	"testing"
+
+	"github.com/spiffe/spire/proto/spire/server/upstreamauthority"
+	"github.com/spiffe/spire/proto/spire/server/upstreamca"
+	"github.com/spiffe/spire/test/fakes/fakeupstreamca"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestMintX509CA(t *testing.T) {
+	for _, useIntermediate := range []bool{false, true} {
+		for _, useDeprecatedFields := range []bool{false, true} {
+			upstreamCA := fakeupstreamca.New(t, fakeupstreamca.Config{
+				TrustDomain:         "example.org",
+				UseIntermediate:     useIntermediate,
+				UseDeprecatedFields: useDeprecatedFields,
+			})
+
+			stream, err := Wrap(upstreamCA).MintX509CA(context.Background(), &upstreamauthority.MintX509CARequest{
+				Csr: createCSR(t),
+			})
+			require.NoError(t, err)
+
+			// the signed certificate and intermediates are in the chain and
+			// only the root is part of the upstream roots.
+			resp, err := stream.Recv()
+			require.NoError(t, err)
+			require.Len(t, resp.UpstreamX509Roots, 1)
+			require.Equal(t, upstreamCA.Root().Raw, resp.UpstreamX509Roots[0])
+			if useIntermediate {
+				require.Len(t, resp.X509CaChain, 2)
+				require.Equal(t, upstreamCA.Intermediate().Raw, resp.X509CaChain[1])
+			} else {
+				require.Len(t, resp.X509CaChain, 1)
+			}
+
+			// the stream is closed after the first response
+			_, err = stream.Recv()
+			require.Equal(t, io.EOF, err)
+		}
+	}
+}
+
+func TestMintX509CAFailure(t *testing.T) {
+	_, err := Wrap(failingUpstreamCA{}).MintX509CA(context.Background(), &upstreamauthority.MintX509CARequest{
+		Csr: []byte("CSR"),
+	})
+	require.EqualError(t, err, "OHNO")
+}
+
+func TestPublishJWTKeyIsUnimplemented(t *testing.T) {
+	_, err := Wrap(failingUpstreamCA{}).PublishJWTKey(context.Background(), &upstreamauthority.PublishJWTKeyRequest{})
+	spiretest.RequireGRPCStatus(t, err, codes.Unimplemented, "publishing JWT keys is not supported by UpstreamCA plugins")
+}
+
+func createCSR(t *testing.T) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		URIs: []*url.URL{{Scheme: "spiffe", Host: "example.org"}},
+	}, key)
+	require.NoError(t, err)
+	return csr
+}
+
+type failingUpstreamCA struct{}
+
+func (failingUpstreamCA) SubmitCSR(context.Context, *upstreamca.SubmitCSRRequest) (*upstreamca.SubmitCSRResponse, error) {
+	return nil, errors.New("OHNO")
+}
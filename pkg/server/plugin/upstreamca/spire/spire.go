@@ -12,6 +12,7 @@ import (
 	"github.com/spiffe/spire/proto/spire/api/node"
 	"github.com/spiffe/spire/proto/spire/common/plugin"
 	"github.com/spiffe/spire/proto/spire/server/upstreamca"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 )
 
@@ -76,12 +77,7 @@ func (m *spirePlugin) SubmitCSR(ctx context.Context, request *upstreamca.SubmitC
 	m.mtx.RLock()
 	defer m.mtx.RUnlock()
 
-	wCert, wKey, wBundle, err := m.getWorkloadSVID(ctx, m.config)
-	if err != nil {
-		return nil, err
-	}
-
-	conn, err := m.newNodeClientConn(ctx, wCert, wKey, wBundle)
+	conn, err := m.dialUpstream(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -101,6 +97,16 @@ func (m *spirePlugin) SubmitCSR(ctx context.Context, request *upstreamca.SubmitC
 	}, nil
 }
 
+// dialUpstream dials the node API of the upstream SPIRE server using the
+// X509-SVID fetched from the Workload API.
+func (m *spirePlugin) dialUpstream(ctx context.Context) (*grpc.ClientConn, error) {
+	wCert, wKey, wBundle, err := m.getWorkloadSVID(ctx, m.config)
+	if err != nil {
+		return nil, err
+	}
+	return m.newNodeClientConn(ctx, wCert, wKey, wBundle)
+}
+
 func certificatesDER(certs []*x509.Certificate) (der []byte) {
 	for _, cert := range certs {
 		der = append(der, cert.Raw...)
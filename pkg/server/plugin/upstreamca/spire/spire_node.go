@@ -15,6 +15,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/idutil"
 	"github.com/spiffe/spire/pkg/common/util"
 	"github.com/spiffe/spire/proto/spire/api/node"
+	"github.com/spiffe/spire/proto/spire/common"
 )
 
 func (m *spirePlugin) submitCSRUpstreamCA(ctx context.Context, nodeClient node.NodeClient, csr []byte) ([]*x509.Certificate, *bundleutil.Bundle, error) {
@@ -28,6 +29,16 @@ func (m *spirePlugin) submitCSRUpstreamCA(ctx context.Context, nodeClient node.N
 	return m.getCertFromResponse(resp)
 }
 
+func (m *spirePlugin) pushJWTKeyUpstream(ctx context.Context, nodeClient node.NodeClient, jwtKey *common.PublicKey) ([]*common.PublicKey, error) {
+	resp, err := nodeClient.PushJWTKeyUpstream(ctx, &node.PushJWTKeyUpstreamRequest{
+		JwtKey: jwtKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.JwtSigningKeys, nil
+}
+
 func (m *spirePlugin) getCertFromResponse(response *node.FetchX509CASVIDResponse) ([]*x509.Certificate, *bundleutil.Bundle, error) {
 	if response.Svid == nil {
 		return nil, nil, errors.New("response missing svid")
@@ -7,6 +7,7 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net"
 	"os"
@@ -23,6 +24,7 @@ import (
 	w_pb "github.com/spiffe/spire/proto/spire/api/workload"
 	"github.com/spiffe/spire/proto/spire/common"
 	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/proto/spire/server/upstreamauthority"
 	"github.com/spiffe/spire/proto/spire/server/upstreamca"
 	"github.com/spiffe/spire/test/spiretest"
 	"github.com/spiffe/spire/test/util"
@@ -46,6 +48,8 @@ const (
 
 var (
 	ctx = context.Background()
+
+	upstreamJWTKey = &common.PublicKey{Kid: "UPSTREAM", PkixBytes: []byte("UPSTREAM")}
 )
 
 type handler struct {
@@ -205,6 +209,12 @@ func (h *handler) FetchX509CASVID(ctx context.Context, req *node.FetchX509CASVID
 	}, nil
 }
 
+func (h *handler) PushJWTKeyUpstream(ctx context.Context, req *node_pb.PushJWTKeyUpstreamRequest) (*node_pb.PushJWTKeyUpstreamResponse, error) {
+	return &node_pb.PushJWTKeyUpstreamResponse{
+		JwtSigningKeys: []*common.PublicKey{upstreamJWTKey, req.JwtKey},
+	}, nil
+}
+
 func (h *handler) FetchJWTSVID(ctx context.Context, req *node_pb.FetchJWTSVIDRequest) (*node_pb.FetchJWTSVIDResponse, error) {
 	return nil, errors.New("NOT IMPLEMENTED")
 }
@@ -282,6 +292,95 @@ func TestSpirePlugin_SubmitInvalidCSR(t *testing.T) {
 	require.Nil(t, resp)
 }
 
+func TestSpireUpstreamAuthority_MintX509CA(t *testing.T) {
+	server := testHandler{}
+	server.startTestServers(t)
+	defer server.stopTestServers()
+
+	ua, done := newUpstreamAuthorityWithDefault(t, server.napiServer.addr, server.wapiServer.socketPath)
+	defer done()
+
+	csr, pubKey, err := util.NewCSRTemplate("spiffe://localhost")
+	require.NoError(t, err)
+
+	stream, err := ua.MintX509CA(ctx, &upstreamauthority.MintX509CARequest{Csr: csr})
+	require.NoError(t, err)
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	require.Len(t, resp.X509CaChain, 1)
+	require.Len(t, resp.UpstreamX509Roots, 1)
+
+	cert, err := x509.ParseCertificate(resp.X509CaChain[0])
+	require.NoError(t, err)
+	isEqual, err := cryptoutil.PublicKeyEqual(cert.PublicKey, pubKey)
+	require.NoError(t, err)
+	require.True(t, isEqual)
+
+	// the node API does not stream updates to the upstream roots
+	_, err = stream.Recv()
+	require.Equal(t, io.EOF, err)
+}
+
+func TestSpireUpstreamAuthority_MintX509CAWithInvalidCSR(t *testing.T) {
+	server := testHandler{}
+	server.startTestServers(t)
+	defer server.stopTestServers()
+
+	ua, done := newUpstreamAuthorityWithDefault(t, server.napiServer.addr, server.wapiServer.socketPath)
+	defer done()
+
+	stream, err := ua.MintX509CA(ctx, &upstreamauthority.MintX509CARequest{Csr: []byte("invalid-csr")})
+	require.NoError(t, err)
+	resp, err := stream.Recv()
+	require.Error(t, err)
+	require.Nil(t, resp)
+}
+
+func TestSpireUpstreamAuthority_PublishJWTKey(t *testing.T) {
+	server := testHandler{}
+	server.startTestServers(t)
+	defer server.stopTestServers()
+
+	ua, done := newUpstreamAuthorityWithDefault(t, server.napiServer.addr, server.wapiServer.socketPath)
+	defer done()
+
+	jwtKey := &common.PublicKey{Kid: "DOWNSTREAM", PkixBytes: []byte("DOWNSTREAM")}
+	stream, err := ua.PublishJWTKey(ctx, &upstreamauthority.PublishJWTKeyRequest{JwtKey: jwtKey})
+	require.NoError(t, err)
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	spiretest.RequireProtoListEqual(t, []*common.PublicKey{upstreamJWTKey, jwtKey}, resp.UpstreamJwtKeys)
+
+	_, err = stream.Recv()
+	require.Equal(t, io.EOF, err)
+}
+
+func newUpstreamAuthorityWithDefault(t *testing.T, addr string, socketPath string) (upstreamauthority.Plugin, func()) {
+	host, port, _ := net.SplitHostPort(addr)
+
+	config := Configuration{
+		ServerAddr:        host,
+		ServerPort:        port,
+		WorkloadAPISocket: socketPath,
+	}
+
+	jsonConfig, err := json.Marshal(config)
+	require.NoError(t, err)
+
+	pluginConfig := &spi.ConfigureRequest{
+		Configuration: string(jsonConfig),
+		GlobalConfig:  &spi.ConfigureRequest_GlobalConfig{TrustDomain: "localhost"},
+	}
+
+	var plugin upstreamauthority.Plugin
+	done := spiretest.LoadPlugin(t, BuiltInUpstreamAuthority(), &plugin)
+	if _, err = plugin.Configure(ctx, pluginConfig); err != nil {
+		done()
+		require.NoError(t, err)
+	}
+	return plugin, done
+}
+
 func newWithDefault(t *testing.T, addr string, socketPath string) (upstreamca.Plugin, func()) {
 	host, port, _ := net.SplitHostPort(addr)
 
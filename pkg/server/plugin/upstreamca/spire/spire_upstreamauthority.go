@@ -0,0 +1,83 @@
+package spireplugin
+
+import (
+	"crypto/x509"
+
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/proto/spire/api/node"
+	"github.com/spiffe/spire/proto/spire/server/upstreamauthority"
+)
+
+// BuiltInUpstreamAuthority returns the spire plugin serving the
+// UpstreamAuthority interface. On top of minting X509 CAs, it publishes the
+// JWT signing keys of the downstream server to the upstream SPIRE server.
+func BuiltInUpstreamAuthority() catalog.Plugin {
+	return catalog.MakePlugin(pluginName, upstreamauthority.PluginServer(NewUpstreamAuthority()))
+}
+
+type upstreamAuthorityPlugin struct {
+	*spirePlugin
+}
+
+func NewUpstreamAuthority() upstreamauthority.UpstreamAuthorityServer {
+	return &upstreamAuthorityPlugin{
+		spirePlugin: &spirePlugin{},
+	}
+}
+
+// MintX509CA sends the signed X509 CA and the upstream roots in a single
+// response. The node API does not notify about changes to the upstream
+// roots, so the stream is closed afterwards.
+func (m *upstreamAuthorityPlugin) MintX509CA(req *upstreamauthority.MintX509CARequest, stream upstreamauthority.UpstreamAuthority_MintX509CAServer) error {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	ctx := stream.Context()
+	conn, err := m.dialUpstream(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	certChain, bundle, err := m.submitCSRUpstreamCA(ctx, node.NewNodeClient(conn), req.Csr)
+	if err != nil {
+		return err
+	}
+
+	return stream.Send(&upstreamauthority.MintX509CAResponse{
+		X509CaChain:       rawCertificates(certChain),
+		UpstreamX509Roots: rawCertificates(bundle.RootCAs()),
+	})
+}
+
+// PublishJWTKey pushes the JWT signing key into the bundle of the upstream
+// SPIRE server and sends the upstream JWT signing keys in a single response,
+// after which the stream is closed.
+func (m *upstreamAuthorityPlugin) PublishJWTKey(req *upstreamauthority.PublishJWTKeyRequest, stream upstreamauthority.UpstreamAuthority_PublishJWTKeyServer) error {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	ctx := stream.Context()
+	conn, err := m.dialUpstream(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	upstreamJWTKeys, err := m.pushJWTKeyUpstream(ctx, node.NewNodeClient(conn), req.JwtKey)
+	if err != nil {
+		return err
+	}
+
+	return stream.Send(&upstreamauthority.PublishJWTKeyResponse{
+		UpstreamJwtKeys: upstreamJWTKeys,
+	})
+}
+
+func rawCertificates(certs []*x509.Certificate) [][]byte {
+	var rawCerts [][]byte
+	for _, cert := range certs {
+		rawCerts = append(rawCerts, cert.Raw)
+	}
+	return rawCerts
+}
@@ -0,0 +1,250 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	hclog "github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl"
+	"github.com/spiffe/spire/pkg/common/catalog"
+	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/spiffe/spire/pkg/common/plugin/vault"
+	"github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/proto/spire/server/upstreamca"
+)
+
+const (
+	pluginName = "vault"
+
+	defaultPKIMountPoint = "pki"
+)
+
+func BuiltIn() catalog.Plugin {
+	return builtin(New())
+}
+
+func builtin(p *Plugin) catalog.Plugin {
+	return catalog.MakePlugin(pluginName, upstreamca.PluginServer(p))
+}
+
+type configuration struct {
+	vault.ClientConfig `hcl:",squash"`
+
+	// PKIMountPoint is the mount point of the PKI secrets engine
+	PKIMountPoint string `hcl:"pki_mount_point"`
+
+	// TTL is the TTL requested for the intermediate CA certificate. Defaults
+	// to the TTL configured on the PKI secrets engine.
+	TTL string `hcl:"ttl"`
+}
+
+type signIntermediateRequest struct {
+	CSR          string `json:"csr"`
+	Format       string `json:"format"`
+	TTL          string `json:"ttl,omitempty"`
+	URISANs      string `json:"uri_sans,omitempty"`
+	UseCSRValues bool   `json:"use_csr_values"`
+}
+
+type signIntermediateData struct {
+	Certificate string   `json:"certificate"`
+	IssuingCA   string   `json:"issuing_ca"`
+	CAChain     []string `json:"ca_chain"`
+}
+
+type Plugin struct {
+	mu            sync.RWMutex
+	log           hclog.Logger
+	config        *configuration
+	client        *vault.Client
+	cancelRenewal context.CancelFunc
+
+	hooks struct {
+		clock  clock.Clock
+		getenv func(string) string
+	}
+}
+
+func New() *Plugin {
+	p := &Plugin{
+		log: hclog.NewNullLogger(),
+	}
+	p.hooks.clock = clock.New()
+	p.hooks.getenv = os.Getenv
+	return p
+}
+
+func (p *Plugin) SetLogger(log hclog.Logger) {
+	p.log = log
+}
+
+func (p *Plugin) Configure(ctx context.Context, req *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
+	config, err := p.parseConfig(req.Configuration)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := vault.NewClient(&config.ClientConfig)
+	if err != nil {
+		return nil, newError("%v", err)
+	}
+	auth, err := client.Login(ctx)
+	if err != nil {
+		return nil, newError("unable to authenticate with Vault: %v", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Stop renewing the token of the previous configuration
+	if p.cancelRenewal != nil {
+		p.cancelRenewal()
+		p.cancelRenewal = nil
+	}
+
+	p.config = config
+	p.client = client
+
+	if auth.Renewable && auth.TTL() > 0 {
+		renewalCtx, cancel := context.WithCancel(context.Background())
+		p.cancelRenewal = cancel
+		go client.RenewToken(renewalCtx, p.log, p.hooks.clock, auth.TTL())
+	}
+
+	return &plugin.ConfigureResponse{}, nil
+}
+
+func (p *Plugin) GetPluginInfo(context.Context, *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error) {
+	return &plugin.GetPluginInfoResponse{}, nil
+}
+
+func (p *Plugin) SubmitCSR(ctx context.Context, req *upstreamca.SubmitCSRRequest) (*upstreamca.SubmitCSRResponse, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.client == nil {
+		return nil, newError("not configured")
+	}
+
+	csr, err := x509.ParseCertificateRequest(req.Csr)
+	if err != nil {
+		return nil, newError("unable to parse CSR: %v", err)
+	}
+
+	var uriSANs []string
+	for _, uri := range csr.URIs {
+		uriSANs = append(uriSANs, uri.String())
+	}
+
+	secret, err := p.client.Write(ctx, fmt.Sprintf("%s/root/sign-intermediate", p.config.PKIMountPoint), &signIntermediateRequest{
+		CSR: string(pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE REQUEST",
+			Bytes: req.Csr,
+		})),
+		Format:       "pem",
+		TTL:          p.config.TTL,
+		URISANs:      strings.Join(uriSANs, ","),
+		UseCSRValues: true,
+	})
+	if err != nil {
+		return nil, newError("unable to sign CSR: %v", err)
+	}
+	resp := new(signIntermediateData)
+	if err := secret.DecodeData(resp); err != nil {
+		return nil, newError("unable to decode sign-intermediate data: %v", err)
+	}
+
+	cert, err := pemutil.ParseCertificate([]byte(resp.Certificate))
+	if err != nil {
+		return nil, newError("unable to parse signed certificate: %v", err)
+	}
+
+	// The CA chain holds the issuing CA and the CAs above it, if Vault
+	// knows about them. Only the issuing CA is returned otherwise.
+	chainPEM := resp.CAChain
+	if len(chainPEM) == 0 {
+		chainPEM = []string{resp.IssuingCA}
+	}
+	var caChain []*x509.Certificate
+	for _, caPEM := range chainPEM {
+		certs, err := pemutil.ParseCertificates([]byte(caPEM))
+		if err != nil {
+			return nil, newError("unable to parse CA chain: %v", err)
+		}
+		caChain = append(caChain, certs...)
+	}
+	if len(caChain) == 0 {
+		return nil, newError("response is missing the CA chain")
+	}
+
+	intermediates, roots := splitCAChain(caChain)
+	return &upstreamca.SubmitCSRResponse{
+		SignedCertificate: &upstreamca.SignedCertificate{
+			CertChain: certificatesDER(append([]*x509.Certificate{cert}, intermediates...)),
+			Bundle:    certificatesDER(roots),
+		},
+	}, nil
+}
+
+func (p *Plugin) parseConfig(hclConfig string) (*configuration, error) {
+	config := new(configuration)
+	if err := hcl.Decode(config, hclConfig); err != nil {
+		return nil, newError("unable to decode configuration: %v", err)
+	}
+
+	if err := config.Validate(p.hooks.getenv); err != nil {
+		return nil, newError("%v", err)
+	}
+	if config.PKIMountPoint == "" {
+		config.PKIMountPoint = defaultPKIMountPoint
+	}
+	if config.TTL != "" {
+		if _, err := time.ParseDuration(config.TTL); err != nil {
+			return nil, newError("invalid ttl: %v", err)
+		}
+	}
+
+	return config, nil
+}
+
+// splitCAChain splits the CA chain into the intermediates needed to chain
+// back to the upstream trust bundle and the upstream trust bundle itself. The
+// self-signed certificates in the chain make up the bundle. If there are
+// none, the last certificate in the chain is trusted instead.
+func splitCAChain(caChain []*x509.Certificate) (intermediates, roots []*x509.Certificate) {
+	for _, caCert := range caChain {
+		if isSelfSigned(caCert) {
+			roots = append(roots, caCert)
+		} else {
+			intermediates = append(intermediates, caCert)
+		}
+	}
+	if len(roots) == 0 {
+		last := len(intermediates) - 1
+		intermediates, roots = intermediates[:last], intermediates[last:]
+	}
+	return intermediates, roots
+}
+
+func isSelfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, cert.RawSubject) && cert.CheckSignatureFrom(cert) == nil
+}
+
+func certificatesDER(certs []*x509.Certificate) (der []byte) {
+	for _, cert := range certs {
+		der = append(der, cert.Raw...)
+	}
+	return der
+}
+
+func newError(format string, args ...interface{}) error {
+	return fmt.Errorf("upstreamca(vault): "+format, args...)
+}
@@ -0,0 +1,298 @@
+package vault
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/pemutil"
+	"github.com/spiffe/spire/pkg/common/plugin/vault"
+	"github.com/spiffe/spire/pkg/common/x509svid"
+	"github.com/spiffe/spire/pkg/common/x509util"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	fakeRootToken    = "root-token"
+	fakeAppRoleID    = "role-id"
+	fakeAppSecretID  = "secret-id"
+	fakeCertRoleName = "spire"
+	fakeTokenTTL     = 60
+)
+
+// fakeVault is a stand-in for the parts of the Vault HTTP API used by the
+// plugin. The PKI secrets engine is mounted at "pki" and is either a root CA
+// or an intermediate CA whose root Vault may or may not know about.
+type fakeVault struct {
+	*httptest.Server
+
+	mu              sync.Mutex
+	tokens          map[string]bool
+	nextToken       int
+	logins          int
+	renewals        int
+	failRenewals    bool
+	lastSignRequest *signIntermediateRequest
+
+	root         *x509.Certificate
+	intermediate *x509.Certificate
+	caChain      []*x509.Certificate
+	issuingCA    *x509svid.UpstreamCA
+}
+
+func newFakeVault(t *testing.T, trustDomain string, intermediateMount bool, includeRoot bool) *fakeVault {
+	rootKey := newKey(t)
+	root := newCACert(t, "ROOT", rootKey.Public(), nil, rootKey)
+
+	v := &fakeVault{
+		tokens: map[string]bool{
+			fakeRootToken: true,
+		},
+		root: root,
+	}
+
+	if intermediateMount {
+		intermediateKey := newKey(t)
+		v.intermediate = newCACert(t, "INTERMEDIATE", intermediateKey.Public(), root, rootKey)
+		v.caChain = []*x509.Certificate{v.intermediate}
+		if includeRoot {
+			v.caChain = append(v.caChain, root)
+		}
+		v.issuingCA = x509svid.NewUpstreamCA(x509util.NewMemoryKeypair(v.intermediate, intermediateKey), trustDomain, x509svid.UpstreamCAOptions{})
+	} else {
+		v.caChain = []*x509.Certificate{root}
+		v.issuingCA = x509svid.NewUpstreamCA(x509util.NewMemoryKeypair(root, rootKey), trustDomain, x509svid.UpstreamCAOptions{})
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/auth/token/lookup-self", v.handleLookupSelf)
+	mux.HandleFunc("/v1/auth/token/renew-self", v.handleRenewSelf)
+	mux.HandleFunc("/v1/auth/approle/login", v.handleAppRoleLogin)
+	mux.HandleFunc("/v1/auth/cert/login", v.handleCertLogin)
+	mux.HandleFunc("/v1/pki/root/sign-intermediate", v.handleSignIntermediate)
+
+	v.Server = httptest.NewUnstartedServer(mux)
+	v.Server.TLS = &tls.Config{
+		ClientAuth: tls.RequestClientCert,
+	}
+	v.Server.StartTLS()
+	return v
+}
+
+func (v *fakeVault) Logins() int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.logins
+}
+
+func (v *fakeVault) Renewals() int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.renewals
+}
+
+func (v *fakeVault) SetFailRenewals(fail bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.failRenewals = fail
+}
+
+func (v *fakeVault) LastSignRequest() *signIntermediateRequest {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.lastSignRequest
+}
+
+func (v *fakeVault) handleLookupSelf(w http.ResponseWriter, req *http.Request) {
+	if !v.authorized(w, req) {
+		return
+	}
+	// the root token never expires
+	writeResponse(w, map[string]interface{}{
+		"data": map[string]interface{}{
+			"ttl":       0,
+			"renewable": false,
+		},
+	})
+}
+
+func (v *fakeVault) handleRenewSelf(w http.ResponseWriter, req *http.Request) {
+	if !v.authorized(w, req) {
+		return
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.failRenewals {
+		writeError(w, http.StatusBadRequest, "lease is not renewable")
+		return
+	}
+	v.renewals++
+	writeResponse(w, map[string]interface{}{
+		"auth": vault.AuthInfo{
+			ClientToken:   req.Header.Get(vault.TokenHeader),
+			LeaseDuration: fakeTokenTTL,
+			Renewable:     true,
+		},
+	})
+}
+
+func (v *fakeVault) handleAppRoleLogin(w http.ResponseWriter, req *http.Request) {
+	var body map[string]string
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if body["role_id"] != fakeAppRoleID || body["secret_id"] != fakeAppSecretID {
+		writeError(w, http.StatusBadRequest, "invalid role or secret ID")
+		return
+	}
+	v.login(w)
+}
+
+func (v *fakeVault) handleCertLogin(w http.ResponseWriter, req *http.Request) {
+	var body map[string]string
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.TLS == nil || len(req.TLS.PeerCertificates) == 0 {
+		writeError(w, http.StatusBadRequest, "client certificate must be supplied")
+		return
+	}
+	if body["name"] != fakeCertRoleName || req.TLS.PeerCertificates[0].Subject.CommonName != "spire-server" {
+		writeError(w, http.StatusBadRequest, "invalid certificate or no client certificate supplied")
+		return
+	}
+	v.login(w)
+}
+
+func (v *fakeVault) login(w http.ResponseWriter) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.logins++
+	v.nextToken++
+	token := fmt.Sprintf("token-%d", v.nextToken)
+	v.tokens[token] = true
+	writeResponse(w, map[string]interface{}{
+		"auth": vault.AuthInfo{
+			ClientToken:   token,
+			LeaseDuration: fakeTokenTTL,
+			Renewable:     true,
+		},
+	})
+}
+
+func (v *fakeVault) handleSignIntermediate(w http.ResponseWriter, req *http.Request) {
+	if !v.authorized(w, req) {
+		return
+	}
+
+	signReq := new(signIntermediateRequest)
+	if err := json.NewDecoder(req.Body).Decode(signReq); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	v.mu.Lock()
+	v.lastSignRequest = signReq
+	v.mu.Unlock()
+
+	csr, err := pemutil.ParseCertificateRequest([]byte(signReq.CSR))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	cert, err := v.issuingCA.SignCSR(req.Context(), csr.Raw)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var caChain []string
+	for _, caCert := range v.caChain {
+		caChain = append(caChain, string(pemutil.EncodeCertificate(caCert)))
+	}
+	writeResponse(w, map[string]interface{}{
+		"data": signIntermediateData{
+			Certificate: string(pemutil.EncodeCertificate(cert)),
+			IssuingCA:   caChain[0],
+			CAChain:     caChain,
+		},
+	})
+}
+
+func (v *fakeVault) authorized(w http.ResponseWriter, req *http.Request) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if !v.tokens[req.Header.Get(vault.TokenHeader)] {
+		writeError(w, http.StatusForbidden, "permission denied")
+		return false
+	}
+	return true
+}
+
+func writeResponse(w http.ResponseWriter, resp interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func writeError(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(vault.ErrorResponse{
+		Errors: []string{msg},
+	})
+}
+
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func newCACert(t *testing.T, cn string, publicKey crypto.PublicKey, parent *x509.Certificate, parentKey crypto.Signer) *x509.Certificate {
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+	if parent == nil {
+		parent = template
+	}
+	return spiretest.CreateCertificate(t, template, parent, publicKey, parentKey)
+}
+
+// newClientCert returns a PEM encoded client certificate and key for the
+// cert auth method
+func newClientCert(t *testing.T) (certPEM, keyPEM []byte) {
+	key := newKey(t)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "spire-server"},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	cert := spiretest.SelfSignCertificateWithKey(t, template, key)
+	keyPEM, err := pemutil.EncodePKCS8PrivateKey(key)
+	require.NoError(t, err)
+	return pemutil.EncodeCertificate(cert), keyPEM
+}
@@ -0,0 +1,313 @@
+package vault
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spiffe/spire/pkg/common/pemutil"
+	spi "github.com/spiffe/spire/proto/spire/common/plugin"
+	"github.com/spiffe/spire/proto/spire/server/upstreamca"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/spiretest"
+	"github.com/spiffe/spire/test/util"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	trustDomain = "example.org"
+)
+
+var (
+	ctx = context.Background()
+)
+
+func TestVault(t *testing.T) {
+	spiretest.Run(t, new(VaultSuite))
+}
+
+type VaultSuite struct {
+	spiretest.Suite
+
+	dir   string
+	clock *clock.Mock
+	env   map[string]string
+
+	vault *fakeVault
+	p     upstreamca.Plugin
+}
+
+func (s *VaultSuite) SetupTest() {
+	s.dir = s.TempDir()
+	s.clock = clock.NewMock(s.T())
+	s.env = map[string]string{}
+	s.setVault(newFakeVault(s.T(), trustDomain, false, false))
+}
+
+func (s *VaultSuite) TearDownTest() {
+	s.vault.Close()
+}
+
+func (s *VaultSuite) TestConfigureErrors() {
+	for _, tt := range []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{
+			name:   "malformed configuration",
+			config: "{{",
+			err:    "upstreamca(vault): unable to decode configuration",
+		},
+		{
+			name:   "missing vault address",
+			config: `token_auth { token = "foo" }`,
+			err:    "upstreamca(vault): vault_addr is required",
+		},
+		{
+			name:   "invalid ttl",
+			config: `vault_addr = "https://vault" ttl = "forever" token_auth { token = "foo" }`,
+			err:    "upstreamca(vault): invalid ttl",
+		},
+		{
+			name:   "missing auth method",
+			config: `vault_addr = "https://vault"`,
+			err:    "upstreamca(vault): one of token_auth, approle_auth or cert_auth is required",
+		},
+		{
+			name:   "more than one auth method",
+			config: `vault_addr = "https://vault" token_auth { token = "foo" } approle_auth { approle_id = "id" approle_secret_id = "secret" }`,
+			err:    "upstreamca(vault): only one of token_auth, approle_auth or cert_auth can be configured",
+		},
+		{
+			name:   "missing token",
+			config: `vault_addr = "https://vault" token_auth {}`,
+			err:    "upstreamca(vault): token is required for token_auth",
+		},
+		{
+			name:   "missing approle ID",
+			config: `vault_addr = "https://vault" approle_auth { approle_secret_id = "secret" }`,
+			err:    "upstreamca(vault): approle_id is required for approle_auth",
+		},
+		{
+			name:   "missing approle secret ID",
+			config: `vault_addr = "https://vault" approle_auth { approle_id = "id" }`,
+			err:    "upstreamca(vault): approle_secret_id is required for approle_auth",
+		},
+		{
+			name:   "missing client certificate",
+			config: `vault_addr = "https://vault" cert_auth { client_key_path = "key.pem" }`,
+			err:    "upstreamca(vault): client_cert_path and client_key_path are required for cert_auth",
+		},
+		{
+			name:   "unloadable CA certificates",
+			config: `vault_addr = "https://vault" ca_cert_path = "/does/not/exist" token_auth { token = "foo" }`,
+			err:    "upstreamca(vault): unable to load CA certificates",
+		},
+		{
+			name:   "bad token",
+			config: s.vaultConfig(`token_auth { token = "bad" }`),
+			err:    "upstreamca(vault): unable to authenticate with Vault: GET auth/token/lookup-self failed (403): permission denied",
+		},
+		{
+			name:   "bad approle secret ID",
+			config: s.vaultConfig(fmt.Sprintf(`approle_auth { approle_id = %q approle_secret_id = "bad" }`, fakeAppRoleID)),
+			err:    "upstreamca(vault): unable to authenticate with Vault: POST auth/approle/login failed (400): invalid role or secret ID",
+		},
+	} {
+		tt := tt
+		s.T().Run(tt.name, func(t *testing.T) {
+			_, err := s.p.Configure(ctx, &spi.ConfigureRequest{
+				Configuration: tt.config,
+			})
+			spiretest.RequireGRPCStatusContains(t, err, codes.Unknown, tt.err)
+		})
+	}
+}
+
+func (s *VaultSuite) TestConfigureFromEnvironment() {
+	s.env["VAULT_ADDR"] = s.vault.URL
+	s.env["VAULT_TOKEN"] = fakeRootToken
+	s.configure(fmt.Sprintf(`ca_cert_path = %q token_auth {}`, s.caCertPath()))
+	s.requireSubmitCSR()
+}
+
+func (s *VaultSuite) TestSubmitCSRWithTokenAuth() {
+	s.configure(s.vaultConfig(fmt.Sprintf(`token_auth { token = %q }`, fakeRootToken)))
+	s.requireSubmitCSR()
+	s.Require().Equal(0, s.vault.Logins())
+}
+
+func (s *VaultSuite) TestSubmitCSRWithAppRoleAuth() {
+	s.configure(s.vaultConfig(fmt.Sprintf(`approle_auth { approle_id = %q approle_secret_id = %q }`, fakeAppRoleID, fakeAppSecretID)))
+	s.requireSubmitCSR()
+	s.Require().Equal(1, s.vault.Logins())
+}
+
+func (s *VaultSuite) TestSubmitCSRWithCertAuth() {
+	certPEM, keyPEM := newClientCert(s.T())
+	certPath := s.writeFile("client.pem", certPEM)
+	keyPath := s.writeFile("client.key", keyPEM)
+
+	s.configure(s.vaultConfig(fmt.Sprintf(`cert_auth {
+		cert_auth_role_name = %q
+		client_cert_path = %q
+		client_key_path = %q
+	}`, fakeCertRoleName, certPath, keyPath)))
+	s.requireSubmitCSR()
+	s.Require().Equal(1, s.vault.Logins())
+}
+
+func (s *VaultSuite) TestSubmitCSRPassesTTLAndURISANs() {
+	s.configure(s.vaultConfig(fmt.Sprintf(`ttl = "1h" token_auth { token = %q }`, fakeRootToken)))
+	s.requireSubmitCSR()
+
+	signReq := s.vault.LastSignRequest()
+	s.Require().NotNil(signReq)
+	s.Require().Equal("1h", signReq.TTL)
+	s.Require().Equal("spiffe://example.org", signReq.URISANs)
+	s.Require().Equal("pem", signReq.Format)
+	s.Require().True(signReq.UseCSRValues)
+}
+
+func (s *VaultSuite) TestSubmitCSRFromIntermediateMount() {
+	s.vault.Close()
+	s.setVault(newFakeVault(s.T(), trustDomain, true, true))
+
+	s.configure(s.vaultConfig(fmt.Sprintf(`token_auth { token = %q }`, fakeRootToken)))
+	certChain, bundle := s.requireSubmitCSR()
+
+	// the intermediate mount certificate is part of the chain and the root
+	// makes up the bundle
+	s.Require().Len(certChain, 2)
+	s.Require().Equal(s.vault.intermediate.Raw, certChain[1].Raw)
+	s.Require().Len(bundle, 1)
+	s.Require().Equal(s.vault.root.Raw, bundle[0].Raw)
+}
+
+func (s *VaultSuite) TestSubmitCSRFromIntermediateMountWithoutRoot() {
+	s.vault.Close()
+	s.setVault(newFakeVault(s.T(), trustDomain, true, false))
+
+	s.configure(s.vaultConfig(fmt.Sprintf(`token_auth { token = %q }`, fakeRootToken)))
+	certChain, bundle := s.requireSubmitCSR()
+
+	// without the root, the intermediate mount certificate is trusted
+	s.Require().Len(certChain, 1)
+	s.Require().Len(bundle, 1)
+	s.Require().Equal(s.vault.intermediate.Raw, bundle[0].Raw)
+}
+
+func (s *VaultSuite) TestSubmitCSRNotConfigured() {
+	csr, _, err := util.NewCSRTemplate("spiffe://" + trustDomain)
+	s.Require().NoError(err)
+
+	_, err = s.p.SubmitCSR(ctx, &upstreamca.SubmitCSRRequest{Csr: csr})
+	s.RequireGRPCStatus(err, codes.Unknown, "upstreamca(vault): not configured")
+}
+
+func (s *VaultSuite) TestSubmitCSRWithMalformedCSR() {
+	s.configure(s.vaultConfig(fmt.Sprintf(`token_auth { token = %q }`, fakeRootToken)))
+
+	_, err := s.p.SubmitCSR(ctx, &upstreamca.SubmitCSRRequest{Csr: []byte("malformed")})
+	s.RequireGRPCStatusContains(err, codes.Unknown, "upstreamca(vault): unable to parse CSR")
+}
+
+func (s *VaultSuite) TestTokenRenewal() {
+	s.configure(s.vaultConfig(fmt.Sprintf(`approle_auth { approle_id = %q approle_secret_id = %q }`, fakeAppRoleID, fakeAppSecretID)))
+	s.Require().Equal(1, s.vault.Logins())
+
+	// the token is renewed at half of its TTL
+	s.clock.WaitForAfter(time.Minute, "timed out waiting for renewal to be scheduled")
+	s.clock.Add(fakeTokenTTL * time.Second / 2)
+	s.clock.WaitForAfter(time.Minute, "timed out waiting for renewal to be rescheduled")
+	s.Require().Equal(1, s.vault.Renewals())
+	s.Require().Equal(1, s.vault.Logins())
+
+	// if the token can't be renewed, the plugin logs in again
+	s.vault.SetFailRenewals(true)
+	s.clock.Add(fakeTokenTTL * time.Second / 2)
+	s.clock.WaitForAfter(time.Minute, "timed out waiting for renewal to be rescheduled")
+	s.Require().Equal(1, s.vault.Renewals())
+	s.Require().Equal(2, s.vault.Logins())
+
+	// and keeps signing CSRs with the new token
+	s.requireSubmitCSR()
+}
+
+func (s *VaultSuite) setVault(vault *fakeVault) {
+	s.vault = vault
+
+	p := New()
+	p.hooks.clock = s.clock
+	p.hooks.getenv = func(key string) string {
+		return s.env[key]
+	}
+	s.LoadPlugin(builtin(p), &s.p)
+}
+
+func (s *VaultSuite) configure(config string) {
+	resp, err := s.p.Configure(ctx, &spi.ConfigureRequest{
+		Configuration: config,
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(&spi.ConfigureResponse{}, resp)
+}
+
+// vaultConfig returns configuration for the fake Vault server with the given
+// auth method configuration
+func (s *VaultSuite) vaultConfig(authConfig string) string {
+	return fmt.Sprintf(`
+		vault_addr = %q
+		ca_cert_path = %q
+		%s
+	`, s.vault.URL, s.caCertPath(), authConfig)
+}
+
+func (s *VaultSuite) caCertPath() string {
+	return s.writeFile("vault-ca.pem", pemutil.EncodeCertificate(s.vault.Certificate()))
+}
+
+func (s *VaultSuite) writeFile(name string, data []byte) string {
+	path := filepath.Join(s.dir, name)
+	s.Require().NoError(ioutil.WriteFile(path, data, 0600))
+	return path
+}
+
+func (s *VaultSuite) requireSubmitCSR() (certChain, bundle []*x509.Certificate) {
+	csr, pubKey, err := util.NewCSRTemplate("spiffe://" + trustDomain)
+	s.Require().NoError(err)
+
+	resp, err := s.p.SubmitCSR(ctx, &upstreamca.SubmitCSRRequest{Csr: csr})
+	s.Require().NoError(err)
+	s.Require().NotNil(resp.SignedCertificate)
+
+	certChain, err = x509.ParseCertificates(resp.SignedCertificate.CertChain)
+	s.Require().NoError(err)
+	bundle, err = x509.ParseCertificates(resp.SignedCertificate.Bundle)
+	s.Require().NoError(err)
+	s.Require().NotEmpty(certChain)
+	s.Require().NotEmpty(bundle)
+
+	// the signed certificate is for the CSR key and chains back to the bundle
+	s.Require().Equal(pubKey, certChain[0].PublicKey)
+	s.Require().True(certChain[0].IsCA)
+	roots := x509.NewCertPool()
+	for _, root := range bundle {
+		roots.AddCert(root)
+	}
+	intermediates := x509.NewCertPool()
+	for _, intermediate := range certChain[1:] {
+		intermediates.AddCert(intermediate)
+	}
+	_, err = certChain[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+	})
+	s.Require().NoError(err)
+	return certChain, bundle
+}
@@ -0,0 +1,110 @@
+package registration
+
+import (
+	"context"
+	"time"
+
+	"github.com/andres-erbsen/clock"
+	"github.com/sirupsen/logrus"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	telemetry_server "github.com/spiffe/spire/pkg/common/telemetry/server"
+	"github.com/spiffe/spire/proto/spire/server/datastore"
+)
+
+const (
+	pruneInterval = 10 * time.Second
+
+	// tombstoneRetention is how long records of deleted registration entries
+	// are kept around so agents can be told about the deletion. Agents that
+	// fall further behind detect the missed deletions and resynchronize.
+	tombstoneRetention = 24 * time.Hour
+
+	// defaultEventRetention is how long registration entry change events are
+	// kept when no retention is configured
+	defaultEventRetention = 24 * time.Hour
+)
+
+type ManagerConfig struct {
+	DataStore datastore.DataStore
+	Log       logrus.FieldLogger
+	Metrics   telemetry.Metrics
+	Clock     clock.Clock
+
+	// EventRetention is how long registration entry change events are kept.
+	// Watchers that fall further behind have to take a new snapshot.
+	EventRetention time.Duration
+}
+
+// Manager prunes registration entries that have expired, along with old
+// records of deleted and changed registration entries
+type Manager struct {
+	c ManagerConfig
+}
+
+func NewManager(c ManagerConfig) *Manager {
+	if c.Clock == nil {
+		c.Clock = clock.New()
+	}
+	if c.EventRetention == 0 {
+		c.EventRetention = defaultEventRetention
+	}
+	return &Manager{
+		c: c,
+	}
+}
+
+func (m *Manager) Run(ctx context.Context) error {
+	return m.pruneEvery(ctx)
+}
+
+func (m *Manager) pruneEvery(ctx context.Context) error {
+	ticker := m.c.Clock.Ticker(pruneInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := m.prune(ctx); err != nil {
+				m.c.Log.WithError(err).Error("Could not prune registration entries")
+			}
+			if err := m.pruneTombstones(ctx); err != nil {
+				m.c.Log.WithError(err).Error("Could not prune registration entry tombstones")
+			}
+			if err := m.pruneEvents(ctx); err != nil {
+				m.c.Log.WithError(err).Error("Could not prune registration entry events")
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (m *Manager) prune(ctx context.Context) (err error) {
+	counter := telemetry_server.StartRegistrationManagerPruneEntryCall(m.c.Metrics)
+	defer counter.Done(&err)
+
+	_, err = m.c.DataStore.PruneRegistrationEntries(ctx, &datastore.PruneRegistrationEntriesRequest{
+		ExpiresBefore: m.c.Clock.Now().Unix(),
+	})
+	return err
+}
+
+func (m *Manager) pruneTombstones(ctx context.Context) (err error) {
+	counter := telemetry_server.StartRegistrationManagerPruneEntryTombstoneCall(m.c.Metrics)
+	defer counter.Done(&err)
+
+	_, err = m.c.DataStore.PruneRegistrationEntryTombstones(ctx, &datastore.PruneRegistrationEntryTombstonesRequest{
+		DeletedBefore: m.c.Clock.Now().Add(-tombstoneRetention).Unix(),
+	})
+	return err
+}
+
+func (m *Manager) pruneEvents(ctx context.Context) (err error) {
+	counter := telemetry_server.StartRegistrationManagerPruneEntryEventCall(m.c.Metrics)
+	defer counter.Done(&err)
+
+	_, err = m.c.DataStore.PruneRegistrationEntryEvents(ctx, &datastore.PruneRegistrationEntryEventsRequest{
+		CreatedBefore: m.c.Clock.Now().Add(-m.c.EventRetention).Unix(),
+	})
+	return err
+}
@@ -0,0 +1,150 @@
+package registration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/spiffe/spire/pkg/common/telemetry"
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/proto/spire/server/datastore"
+	"github.com/spiffe/spire/test/clock"
+	"github.com/spiffe/spire/test/fakes/fakedatastore"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrune(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewMock(t)
+	ds := fakedatastore.New()
+	log, _ := test.NewNullLogger()
+
+	expiring := createEntry(t, ds, "spiffe://example.org/expiring", clk.Now().Add(time.Minute).Unix())
+	lasting := createEntry(t, ds, "spiffe://example.org/lasting", clk.Now().Add(time.Hour).Unix())
+	forever := createEntry(t, ds, "spiffe://example.org/forever", 0)
+
+	m := NewManager(ManagerConfig{
+		DataStore: ds,
+		Log:       log,
+		Metrics:   telemetry.Blackhole{},
+		Clock:     clk,
+	})
+
+	// nothing has expired yet
+	require.NoError(t, m.prune(ctx))
+	requireEntries(t, ds, expiring, lasting, forever)
+
+	// only the expired entry is pruned
+	clk.Add(2 * time.Minute)
+	require.NoError(t, m.prune(ctx))
+	requireEntries(t, ds, lasting, forever)
+}
+
+func TestPruneTombstones(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewMock(t)
+	ds := fakedatastore.New()
+	log, _ := test.NewNullLogger()
+
+	entryID := createEntry(t, ds, "spiffe://example.org/deleted", 0)
+	_, err := ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{
+		EntryId: entryID,
+	})
+	require.NoError(t, err)
+	deletedAt := time.Now()
+
+	m := NewManager(ManagerConfig{
+		DataStore: ds,
+		Log:       log,
+		Metrics:   telemetry.Blackhole{},
+		Clock:     clk,
+	})
+
+	// the tombstone is retained until the retention period has elapsed
+	clk.Set(deletedAt.Add(tombstoneRetention - time.Minute))
+	require.NoError(t, m.pruneTombstones(ctx))
+	requireTombstones(t, ds, entryID)
+
+	clk.Set(deletedAt.Add(tombstoneRetention + time.Minute))
+	require.NoError(t, m.pruneTombstones(ctx))
+	requireTombstones(t, ds)
+}
+
+func TestPruneEvents(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewMock(t)
+	ds := fakedatastore.New()
+	log, _ := test.NewNullLogger()
+
+	entryID := createEntry(t, ds, "spiffe://example.org/deleted", 0)
+	_, err := ds.DeleteRegistrationEntry(ctx, &datastore.DeleteRegistrationEntryRequest{
+		EntryId: entryID,
+	})
+	require.NoError(t, err)
+	changedAt := time.Now()
+
+	m := NewManager(ManagerConfig{
+		DataStore:      ds,
+		Log:            log,
+		Metrics:        telemetry.Blackhole{},
+		Clock:          clk,
+		EventRetention: time.Hour,
+	})
+
+	// events are retained until the retention period has elapsed
+	clk.Set(changedAt.Add(time.Hour - time.Minute))
+	require.NoError(t, m.pruneEvents(ctx))
+	requireEvents(t, ds, datastore.RegistrationEntryEvent_CREATE, datastore.RegistrationEntryEvent_DELETE)
+
+	// the latest event is never pruned
+	clk.Set(changedAt.Add(time.Hour + time.Minute))
+	require.NoError(t, m.pruneEvents(ctx))
+	requireEvents(t, ds, datastore.RegistrationEntryEvent_DELETE)
+}
+
+func TestEventRetentionDefault(t *testing.T) {
+	m := NewManager(ManagerConfig{})
+	require.Equal(t, defaultEventRetention, m.c.EventRetention)
+}
+
+func createEntry(t *testing.T, ds datastore.DataStore, spiffeID string, expiry int64) string {
+	resp, err := ds.CreateRegistrationEntry(context.Background(), &datastore.CreateRegistrationEntryRequest{
+		Entry: &common.RegistrationEntry{
+			SpiffeId:    spiffeID,
+			ParentId:    "spiffe://example.org/parent",
+			Selectors:   []*common.Selector{{Type: "unix", Value: "uid:1000"}},
+			EntryExpiry: expiry,
+		},
+	})
+	require.NoError(t, err)
+	return resp.Entry.EntryId
+}
+
+func requireEntries(t *testing.T, ds datastore.DataStore, entryIDs ...string) {
+	resp, err := ds.ListRegistrationEntries(context.Background(), &datastore.ListRegistrationEntriesRequest{})
+	require.NoError(t, err)
+
+	var actual []string
+	for _, entry := range resp.Entries {
+		actual = append(actual, entry.EntryId)
+	}
+	require.ElementsMatch(t, entryIDs, actual)
+}
+
+func requireTombstones(t *testing.T, ds datastore.DataStore, entryIDs ...string) {
+	resp, err := ds.ListRegistrationEntryTombstones(context.Background(), &datastore.ListRegistrationEntryTombstonesRequest{})
+	require.NoError(t, err)
+	require.ElementsMatch(t, entryIDs, resp.EntryIds)
+}
+
+func requireEvents(t *testing.T, ds datastore.DataStore, types ...datastore.RegistrationEntryEvent_Type) {
+	resp, err := ds.ListRegistrationEntryEvents(context.Background(), &datastore.ListRegistrationEntryEventsRequest{})
+	require.NoError(t, err)
+
+	var actual []datastore.RegistrationEntryEvent_Type
+	for _, event := range resp.Events {
+		actual = append(actual, event.Type)
+	}
+	require.Equal(t, types, actual)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/x509/pkix"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"net/http/pprof"
@@ -13,6 +14,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/gofrs/uuid"
 	"github.com/sirupsen/logrus"
 	common "github.com/spiffe/spire/pkg/common/catalog"
 	"github.com/spiffe/spire/pkg/common/health"
@@ -20,16 +22,24 @@ import (
 	"github.com/spiffe/spire/pkg/common/profiling"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/common/util"
+	"github.com/spiffe/spire/pkg/server/audit"
+	"github.com/spiffe/spire/pkg/server/authpolicy"
 	bundle_client "github.com/spiffe/spire/pkg/server/bundle/client"
 	"github.com/spiffe/spire/pkg/server/ca"
 	"github.com/spiffe/spire/pkg/server/catalog"
 	"github.com/spiffe/spire/pkg/server/endpoints"
+	"github.com/spiffe/spire/pkg/server/endpoints/node"
+	"github.com/spiffe/spire/pkg/server/entrycache"
 	"github.com/spiffe/spire/pkg/server/hostservices/agentstore"
 	"github.com/spiffe/spire/pkg/server/hostservices/identityprovider"
+	"github.com/spiffe/spire/pkg/server/issuancelog"
+	"github.com/spiffe/spire/pkg/server/leader"
+	"github.com/spiffe/spire/pkg/server/registration"
 	"github.com/spiffe/spire/pkg/server/svid"
 	common_services "github.com/spiffe/spire/proto/spire/common/hostservices"
 	"github.com/spiffe/spire/proto/spire/server/datastore"
 	"github.com/spiffe/spire/proto/spire/server/hostservices"
+	"github.com/spiffe/spire/proto/spire/server/keymanager"
 	"google.golang.org/grpc"
 )
 
@@ -89,6 +99,40 @@ type Config struct {
 	// CASubject is the subject used in the CA certificate
 	CASubject pkix.Name
 
+	// TaintedRemovalDelay is how long a tainted authority is kept in the
+	// bundle before it is removed
+	TaintedRemovalDelay time.Duration
+
+	// IssuanceLogRetention is how long records of issued SVIDs are kept
+	// after the SVIDs expire
+	IssuanceLogRetention time.Duration
+
+	// IssuanceLogFailOpen, if set, keeps signing SVIDs that can't be
+	// recorded in the issuance log
+	IssuanceLogFailOpen bool
+
+	// RegistrationPolicyPath is the path of the authorization policy file
+	// for the Registration API. If unset, callers over TCP must be admins.
+	RegistrationPolicyPath string
+
+	// AuditLogPath is the path of the file the audit log is written to. If
+	// unset, API calls aren't audited.
+	AuditLogPath string
+
+	// EntryEventRetention is how long registration entry change events are
+	// kept for watchers of the registration entries
+	EntryEventRetention time.Duration
+
+	// CAKeyType is the key type used for the X509 CA signing keys
+	CAKeyType keymanager.KeyType
+
+	// JWTKeyType is the key type used for the JWT signing keys
+	JWTKeyType keymanager.KeyType
+
+	// JWTSigningAlgorithm is the JWS algorithm JWT-SVIDs are signed with. If
+	// empty, the default algorithm for JWTKeyType is used.
+	JWTSigningAlgorithm string
+
 	// Telemetry provides the configuration for metrics exporting
 	Telemetry telemetry.FileConfig
 
@@ -107,9 +151,32 @@ type ExperimentalConfig struct {
 	// bundle endpoint.
 	BundleEndpointAddress *net.TCPAddr
 
+	// RegistrationGatewayEnabled, if true, serves the Registration API as
+	// JSON over HTTPS.
+	RegistrationGatewayEnabled bool
+
+	// RegistrationGatewayAddress is the address on which to serve the
+	// registration gateway.
+	RegistrationGatewayAddress *net.TCPAddr
+
 	// FederatesWith holds the federation configuration for trust domains this
 	// server federates with.
 	FederatesWith map[string]bundle_client.TrustDomainConfig
+
+	// CAJournalInDataStore, if true, keeps the CA journal in the datastore
+	// so that servers sharing the datastore and KeyManager share the same
+	// X509 CA and JWT key.
+	CAJournalInDataStore bool
+
+	// LeaderElection, if true, elects a leader among the servers sharing
+	// the datastore. Singleton background tasks (e.g. bundle pruning,
+	// registration entry pruning, federated bundle refreshing) only run on
+	// the leader.
+	LeaderElection bool
+
+	// LeaderLeaseTTL is how long the leader holds the leader lease without
+	// renewing it.
+	LeaderLeaseTTL time.Duration
 }
 
 type Server struct {
@@ -188,11 +255,21 @@ func (s *Server) run(ctx context.Context) (err error) {
 		return err
 	}
 
-	serverCA := s.newCA(metrics)
+	issuanceLog := s.newIssuanceLog(cat, metrics)
+
+	serverCA := s.newCA(metrics, issuanceLog)
+
+	var elector *leader.Elector
+	if s.config.Experimental.LeaderElection {
+		elector, err = s.newElector(cat, metrics)
+		if err != nil {
+			return err
+		}
+	}
 
 	// CA manager needs to be initialized before the rotator, otherwise the
 	// server CA plugin won't be able to sign CSRs
-	caManager, err := s.newCAManager(ctx, cat, metrics, serverCA)
+	caManager, err := s.newCAManager(ctx, cat, metrics, serverCA, elector)
 	if err != nil {
 		return err
 	}
@@ -202,7 +279,27 @@ func (s *Server) run(ctx context.Context) (err error) {
 		return err
 	}
 
-	endpointsServer := s.newEndpointsServer(cat, svidRotator, serverCA, metrics)
+	entryCache := s.newEntryCache(cat, metrics)
+
+	var registrationPolicy *authpolicy.Watcher
+	if s.config.RegistrationPolicyPath != "" {
+		registrationPolicy, err = s.newRegistrationPolicy()
+		if err != nil {
+			return err
+		}
+	}
+
+	var auditLog *audit.Logger
+	if s.config.AuditLogPath != "" {
+		var auditLogFile io.Closer
+		auditLog, auditLogFile, err = s.newAuditLog()
+		if err != nil {
+			return err
+		}
+		defer auditLogFile.Close()
+	}
+
+	endpointsServer := s.newEndpointsServer(cat, svidRotator, serverCA, caManager, entryCache, registrationPolicy, auditLog, metrics)
 
 	// Set the identity provider dependencies
 	if err := identityProvider.SetDeps(identityprovider.Deps{
@@ -228,18 +325,45 @@ func (s *Server) run(ctx context.Context) (err error) {
 
 	bundleManager := s.newBundleManager(cat)
 
+	registrationManager := s.newRegistrationManager(cat, metrics)
+
 	if err := healthChecks.AddCheck("server", s, time.Minute); err != nil {
 		return fmt.Errorf("failed adding healthcheck: %v", err)
 	}
 
-	err = util.RunTasks(ctx,
+	tasks := []func(context.Context) error{
 		caManager.Run,
 		svidRotator.Run,
+		entryCache.Run,
 		endpointsServer.ListenAndServe,
 		metrics.ListenAndServe,
-		bundleManager.Run,
 		healthChecks.ListenAndServe,
-	)
+	}
+	if registrationPolicy != nil {
+		tasks = append(tasks, registrationPolicy.Run)
+	}
+
+	// Singleton tasks only run on the leader when leader election is enabled
+	singletonTasks := []func(context.Context) error{
+		bundleManager.Run,
+		registrationManager.Run,
+		issuanceLog.Run,
+	}
+	if elector != nil {
+		if err := healthChecks.AddCheck("leader", elector, time.Minute); err != nil {
+			return fmt.Errorf("failed adding healthcheck: %v", err)
+		}
+		tasks = append(tasks,
+			elector.Run,
+			func(ctx context.Context) error {
+				return elector.RunWhileLeader(ctx, singletonTasks...)
+			},
+		)
+	} else {
+		tasks = append(tasks, singletonTasks...)
+	}
+
+	err = util.RunTasks(ctx, tasks...)
 	if err == context.Canceled {
 		err = nil
 	}
@@ -314,17 +438,33 @@ func (s *Server) loadCatalog(ctx context.Context, identityProvider hostservices.
 	})
 }
 
-func (s *Server) newCA(metrics telemetry.Metrics) *ca.CA {
+func (s *Server) newIssuanceLog(cat catalog.Catalog, metrics telemetry.Metrics) *issuancelog.Log {
+	return issuancelog.New(issuancelog.Config{
+		DataStore: cat.GetDataStore(),
+		Log:       s.config.Log.WithField(telemetry.SubsystemName, telemetry.IssuanceLog),
+		Metrics:   metrics,
+		Retention: s.config.IssuanceLogRetention,
+	})
+}
+
+func (s *Server) newCA(metrics telemetry.Metrics, issuanceLog ca.IssuanceLog) *ca.CA {
 	return ca.NewCA(ca.CAConfig{
 		Log:         s.config.Log.WithField(telemetry.SubsystemName, telemetry.CA),
 		Metrics:     metrics,
 		X509SVIDTTL: s.config.SVIDTTL,
 		TrustDomain: s.config.TrustDomain,
 		CASubject:   s.config.CASubject,
+		IssuanceLog: issuanceLog,
+
+		IssuanceLogFailOpen: s.config.IssuanceLogFailOpen,
 	})
 }
 
-func (s *Server) newCAManager(ctx context.Context, cat catalog.Catalog, metrics telemetry.Metrics, serverCA *ca.CA) (*ca.Manager, error) {
+func (s *Server) newCAManager(ctx context.Context, cat catalog.Catalog, metrics telemetry.Metrics, serverCA *ca.CA, elector *leader.Elector) (*ca.Manager, error) {
+	var isLeader func() bool
+	if elector != nil {
+		isLeader = elector.IsLeader
+	}
 	caManager := ca.NewManager(ca.ManagerConfig{
 		CA:             serverCA,
 		Catalog:        cat,
@@ -334,7 +474,16 @@ func (s *Server) newCAManager(ctx context.Context, cat catalog.Catalog, metrics
 		UpstreamBundle: s.config.UpstreamBundle,
 		CATTL:          s.config.CATTL,
 		CASubject:      s.config.CASubject,
+		X509CAKeyType:  s.config.CAKeyType,
+		JWTKeyType:     s.config.JWTKeyType,
 		Dir:            s.config.DataDir,
+
+		JWTSigningAlgorithm: s.config.JWTSigningAlgorithm,
+
+		TaintedRemovalDelay: s.config.TaintedRemovalDelay,
+
+		JournalInDataStore: s.config.Experimental.CAJournalInDataStore,
+		IsLeader:           isLeader,
 	})
 	if err := caManager.Initialize(ctx); err != nil {
 		return nil, err
@@ -355,7 +504,15 @@ func (s *Server) newSVIDRotator(ctx context.Context, serverCA ca.ServerCA, metri
 	return svidRotator, nil
 }
 
-func (s *Server) newEndpointsServer(catalog catalog.Catalog, svidObserver svid.Observer, serverCA ca.ServerCA, metrics telemetry.Metrics) endpoints.Server {
+func (s *Server) newEntryCache(cat catalog.Catalog, metrics telemetry.Metrics) *entrycache.Cache {
+	return entrycache.New(entrycache.Config{
+		DataStore: cat.GetDataStore(),
+		Log:       s.config.Log.WithField(telemetry.SubsystemName, telemetry.EntryCache),
+		Metrics:   metrics,
+	})
+}
+
+func (s *Server) newEndpointsServer(catalog catalog.Catalog, svidObserver svid.Observer, serverCA ca.ServerCA, caManager *ca.Manager, entryFetcher node.EntryFetcher, registrationPolicy *authpolicy.Watcher, auditLog *audit.Logger, metrics telemetry.Metrics) endpoints.Server {
 	config := &endpoints.Config{
 		TCPAddr:                     s.config.BindAddress,
 		UDSAddr:                     s.config.BindUDSAddress,
@@ -363,16 +520,48 @@ func (s *Server) newEndpointsServer(catalog catalog.Catalog, svidObserver svid.O
 		TrustDomain:                 s.config.TrustDomain,
 		Catalog:                     catalog,
 		ServerCA:                    serverCA,
+		CAManager:                   caManager,
+		MaxMintedSVIDTTL:            s.config.SVIDTTL,
+		EntryFetcher:                entryFetcher,
 		Log:                         s.config.Log.WithField(telemetry.SubsystemName, telemetry.Endpoints),
 		Metrics:                     metrics,
+		AuditLog:                    auditLog,
 		AllowAgentlessNodeAttestors: s.config.Experimental.AllowAgentlessNodeAttestors,
 	}
 	if s.config.Experimental.BundleEndpointEnabled {
 		config.BundleEndpointAddress = s.config.Experimental.BundleEndpointAddress
 	}
+	if s.config.Experimental.RegistrationGatewayEnabled {
+		config.RegistrationGatewayAddress = s.config.Experimental.RegistrationGatewayAddress
+	}
+	if registrationPolicy != nil {
+		config.RegistrationPolicy = registrationPolicy
+	}
 	return endpoints.New(config)
 }
 
+func (s *Server) newRegistrationPolicy() (*authpolicy.Watcher, error) {
+	policy, err := authpolicy.NewWatcher(authpolicy.WatcherConfig{
+		Path: s.config.RegistrationPolicyPath,
+		Log:  s.config.Log.WithField(telemetry.SubsystemName, telemetry.RegistrationAPI),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to load registration policy: %v", err)
+	}
+	return policy, nil
+}
+
+func (s *Server) newAuditLog() (*audit.Logger, io.Closer, error) {
+	file, err := os.OpenFile(s.config.AuditLogPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to open audit log: %v", err)
+	}
+	return audit.New(audit.Config{
+		Writer: file,
+		Log:    s.config.Log.WithField(telemetry.SubsystemName, telemetry.AuditLog),
+	}), file, nil
+}
+
 func (s *Server) newBundleManager(cat catalog.Catalog) *bundle_client.Manager {
 	return bundle_client.NewManager(bundle_client.ManagerConfig{
 		Log:          s.config.Log.WithField("subsystem_name", "bundle_client"),
@@ -381,6 +570,36 @@ func (s *Server) newBundleManager(cat catalog.Catalog) *bundle_client.Manager {
 	})
 }
 
+func (s *Server) newRegistrationManager(cat catalog.Catalog, metrics telemetry.Metrics) *registration.Manager {
+	return registration.NewManager(registration.ManagerConfig{
+		DataStore:      cat.GetDataStore(),
+		Log:            s.config.Log.WithField(telemetry.SubsystemName, telemetry.RegistrationManager),
+		Metrics:        metrics,
+		EventRetention: s.config.EntryEventRetention,
+	})
+}
+
+func (s *Server) newElector(cat catalog.Catalog, metrics telemetry.Metrics) (*leader.Elector, error) {
+	// The holder ID needs to be unique among the servers sharing the
+	// datastore, including restarts of the same server.
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine hostname for leader election: %v", err)
+	}
+	u, err := uuid.NewV4()
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate leader election ID: %v", err)
+	}
+
+	return leader.New(leader.Config{
+		Log:       s.config.Log.WithField(telemetry.SubsystemName, telemetry.Leader),
+		Metrics:   metrics,
+		DataStore: cat.GetDataStore(),
+		ID:        fmt.Sprintf("%s-%s", hostname, u),
+		LeaseTTL:  s.config.Experimental.LeaderLeaseTTL,
+	}), nil
+}
+
 func (s *Server) validateTrustDomain(ctx context.Context, ds datastore.DataStore) error {
 	trustDomain := s.config.TrustDomain.Host
 
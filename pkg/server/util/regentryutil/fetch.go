@@ -3,6 +3,7 @@ package regentryutil
 import (
 	"context"
 	"errors"
+	"math"
 
 	"github.com/golang/protobuf/ptypes/wrappers"
 	"github.com/spiffe/spire/pkg/common/util"
@@ -18,6 +19,38 @@ func FetchRegistrationEntries(ctx context.Context,
 	return fetcher.Fetch(ctx, spiffeID)
 }
 
+// FetchRegistrationEntriesWithRevision is like FetchRegistrationEntries but
+// also returns the datastore revision the entries reflect. The revision is
+// read before the entries so that changes landing in between are picked up
+// by the next fetch instead of being missed.
+func FetchRegistrationEntriesWithRevision(ctx context.Context,
+	dataStore datastore.DataStore, spiffeID string) (
+	entries []*common.RegistrationEntry, revision int64, err error) {
+
+	revision, err = FetchRevision(ctx, dataStore)
+	if err != nil {
+		return nil, 0, err
+	}
+	entries, err = FetchRegistrationEntries(ctx, dataStore, spiffeID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return entries, revision, nil
+}
+
+// FetchRevision returns the current datastore revision.
+func FetchRevision(ctx context.Context, dataStore datastore.DataStore) (int64, error) {
+	// Tombstones are only listed after the requested revision, so asking
+	// past any possible revision only returns the current one.
+	resp, err := dataStore.ListRegistrationEntryTombstones(ctx, &datastore.ListRegistrationEntryTombstonesRequest{
+		AfterRevision: math.MaxInt64,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return resp.Revision, nil
+}
+
 type registrationEntryFetcher struct {
 	dataStore datastore.DataStore
 }
@@ -23,7 +23,7 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
-//* Trust domain bundle
+// Trust domain bundle
 type Bundle struct {
 	// bundle identifier, i.e. the SPIFFE ID for the trust domain
 	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -126,7 +126,7 @@ func (m *X509SVID) GetExpiresAt() int64 {
 }
 
 // A message returned by the Spire Server, which includes a map of signed SVIDs and
-//a list of all current Registration Entries which are relevant to the caller SPIFFE ID.
+// a list of all current Registration Entries which are relevant to the caller SPIFFE ID.
 type X509SVIDUpdate struct {
 	// A map containing SVID values keyed by:
 	//  - SPIFFE ID in message 'AttestResponse'        (Map[SPIFFE_ID] => SVID)
@@ -139,10 +139,27 @@ type X509SVIDUpdate struct {
 	// ID. Bundles included are the trust bundle for the server trust domain
 	// and any federated trust domain bundles applicable to the SVIDs.
 	// Supersedes the deprecated `bundle` field.
-	Bundles              map[string]*common.Bundle `protobuf:"bytes,5,rep,name=bundles,proto3" json:"bundles,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
-	XXX_unrecognized     []byte                    `json:"-"`
-	XXX_sizecache        int32                     `json:"-"`
+	Bundles map[string]*common.Bundle `protobuf:"bytes,5,rep,name=bundles,proto3" json:"bundles,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Revision of the server datastore the update was computed at. Agents
+	// send it back as `revision` in the next 'FetchX509SVIDRequest'.
+	Revision int64 `protobuf:"varint,6,opt,name=revision,proto3" json:"revision,omitempty"`
+	// If true, `registration_entries` and `bundles` only contain the entries
+	// and bundles added or changed since the revision requested by the
+	// agent, and `deleted_entry_ids` lists the entries that were removed.
+	// Otherwise, the update contains every authorized entry and applicable
+	// bundle, and any entries not included should be removed.
+	Delta bool `protobuf:"varint,7,opt,name=delta,proto3" json:"delta,omitempty"`
+	// IDs of registration entries deleted since the requested revision. Only
+	// set for delta updates.
+	DeletedEntryIds []string `protobuf:"bytes,8,rep,name=deleted_entry_ids,json=deletedEntryIds,proto3" json:"deleted_entry_ids,omitempty"`
+	// SHA-256 digest of the sorted, newline separated IDs of all entries the
+	// agent is authorized for. Agents compare it against the entries they
+	// hold after applying a delta update and request a full update on
+	// mismatch. Only set for delta updates.
+	EntryIdsDigest       []byte   `protobuf:"bytes,9,opt,name=entry_ids_digest,json=entryIdsDigest,proto3" json:"entry_ids_digest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *X509SVIDUpdate) Reset()         { *m = X509SVIDUpdate{} }
@@ -191,6 +208,34 @@ func (m *X509SVIDUpdate) GetBundles() map[string]*common.Bundle {
 	return nil
 }
 
+func (m *X509SVIDUpdate) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *X509SVIDUpdate) GetDelta() bool {
+	if m != nil {
+		return m.Delta
+	}
+	return false
+}
+
+func (m *X509SVIDUpdate) GetDeletedEntryIds() []string {
+	if m != nil {
+		return m.DeletedEntryIds
+	}
+	return nil
+}
+
+func (m *X509SVIDUpdate) GetEntryIdsDigest() []byte {
+	if m != nil {
+		return m.EntryIdsDigest
+	}
+	return nil
+}
+
 // JSR is a JWT SVID signing request.
 type JSR struct {
 	// SPIFFE ID of the workload
@@ -428,10 +473,14 @@ type FetchX509SVIDRequest struct {
 	// A list of CSRs (deprecated, use `csrs` map instead)
 	DEPRECATEDCsrs [][]byte `protobuf:"bytes,2,rep,name=DEPRECATED_csrs,json=DEPRECATEDCsrs,proto3" json:"DEPRECATED_csrs,omitempty"`
 	// A map of CSRs keyed by entry ID
-	Csrs                 map[string][]byte `protobuf:"bytes,3,rep,name=csrs,proto3" json:"csrs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	Csrs map[string][]byte `protobuf:"bytes,3,rep,name=csrs,proto3" json:"csrs,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Revision of the last update received by the agent. If set, the server
+	// may reply with only the changes since that revision. A full update is
+	// sent if unset or if the revision is too old.
+	Revision             int64    `protobuf:"varint,4,opt,name=revision,proto3" json:"revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *FetchX509SVIDRequest) Reset()         { *m = FetchX509SVIDRequest{} }
@@ -473,6 +522,13 @@ func (m *FetchX509SVIDRequest) GetCsrs() map[string][]byte {
 	return nil
 }
 
+func (m *FetchX509SVIDRequest) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
 // Represents a response that contains  map of signed SVIDs and an array
 // of all current Registration Entries which are relevant to the caller SPIFFE ID.
 type FetchX509SVIDResponse struct {
@@ -682,6 +738,87 @@ func (m *FetchX509CASVIDResponse) GetBundle() *common.Bundle {
 	return nil
 }
 
+type PushJWTKeyUpstreamRequest struct {
+	// The JWT signing key of the downstream SPIRE server
+	JwtKey               *common.PublicKey `protobuf:"bytes,1,opt,name=jwt_key,json=jwtKey,proto3" json:"jwt_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *PushJWTKeyUpstreamRequest) Reset()         { *m = PushJWTKeyUpstreamRequest{} }
+func (m *PushJWTKeyUpstreamRequest) String() string { return proto.CompactTextString(m) }
+func (*PushJWTKeyUpstreamRequest) ProtoMessage()    {}
+func (*PushJWTKeyUpstreamRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c843d59d2d938e7, []int{13}
+}
+
+func (m *PushJWTKeyUpstreamRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PushJWTKeyUpstreamRequest.Unmarshal(m, b)
+}
+func (m *PushJWTKeyUpstreamRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PushJWTKeyUpstreamRequest.Marshal(b, m, deterministic)
+}
+func (m *PushJWTKeyUpstreamRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PushJWTKeyUpstreamRequest.Merge(m, src)
+}
+func (m *PushJWTKeyUpstreamRequest) XXX_Size() int {
+	return xxx_messageInfo_PushJWTKeyUpstreamRequest.Size(m)
+}
+func (m *PushJWTKeyUpstreamRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PushJWTKeyUpstreamRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PushJWTKeyUpstreamRequest proto.InternalMessageInfo
+
+func (m *PushJWTKeyUpstreamRequest) GetJwtKey() *common.PublicKey {
+	if m != nil {
+		return m.JwtKey
+	}
+	return nil
+}
+
+type PushJWTKeyUpstreamResponse struct {
+	// The JWT signing keys of the trust domain bundle, including the pushed
+	// key
+	JwtSigningKeys       []*common.PublicKey `protobuf:"bytes,1,rep,name=jwt_signing_keys,json=jwtSigningKeys,proto3" json:"jwt_signing_keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *PushJWTKeyUpstreamResponse) Reset()         { *m = PushJWTKeyUpstreamResponse{} }
+func (m *PushJWTKeyUpstreamResponse) String() string { return proto.CompactTextString(m) }
+func (*PushJWTKeyUpstreamResponse) ProtoMessage()    {}
+func (*PushJWTKeyUpstreamResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_0c843d59d2d938e7, []int{14}
+}
+
+func (m *PushJWTKeyUpstreamResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PushJWTKeyUpstreamResponse.Unmarshal(m, b)
+}
+func (m *PushJWTKeyUpstreamResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PushJWTKeyUpstreamResponse.Marshal(b, m, deterministic)
+}
+func (m *PushJWTKeyUpstreamResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PushJWTKeyUpstreamResponse.Merge(m, src)
+}
+func (m *PushJWTKeyUpstreamResponse) XXX_Size() int {
+	return xxx_messageInfo_PushJWTKeyUpstreamResponse.Size(m)
+}
+func (m *PushJWTKeyUpstreamResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PushJWTKeyUpstreamResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PushJWTKeyUpstreamResponse proto.InternalMessageInfo
+
+func (m *PushJWTKeyUpstreamResponse) GetJwtSigningKeys() []*common.PublicKey {
+	if m != nil {
+		return m.JwtSigningKeys
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Bundle)(nil), "spire.api.node.Bundle")
 	proto.RegisterType((*X509SVID)(nil), "spire.api.node.X509SVID")
@@ -699,62 +836,75 @@ func init() {
 	proto.RegisterType((*FetchJWTSVIDResponse)(nil), "spire.api.node.FetchJWTSVIDResponse")
 	proto.RegisterType((*FetchX509CASVIDRequest)(nil), "spire.api.node.FetchX509CASVIDRequest")
 	proto.RegisterType((*FetchX509CASVIDResponse)(nil), "spire.api.node.FetchX509CASVIDResponse")
+	proto.RegisterType((*PushJWTKeyUpstreamRequest)(nil), "spire.api.node.PushJWTKeyUpstreamRequest")
+	proto.RegisterType((*PushJWTKeyUpstreamResponse)(nil), "spire.api.node.PushJWTKeyUpstreamResponse")
 }
 
 func init() { proto.RegisterFile("node.proto", fileDescriptor_0c843d59d2d938e7) }
 
 var fileDescriptor_0c843d59d2d938e7 = []byte{
-	// 797 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x55, 0xdf, 0x4e, 0x3a, 0x47,
-	0x14, 0xce, 0xb2, 0x82, 0x70, 0x40, 0x34, 0x23, 0xad, 0xb8, 0xad, 0xd6, 0x6c, 0xb5, 0x52, 0x35,
-	0x8b, 0xc1, 0x34, 0xfd, 0x93, 0x26, 0x06, 0x81, 0x46, 0x6d, 0xd2, 0x98, 0xc1, 0xb6, 0xb6, 0xbd,
-	0xa0, 0xc3, 0xee, 0x28, 0x5b, 0x71, 0x97, 0xee, 0xcc, 0x9a, 0xfa, 0x04, 0xbd, 0xef, 0xf3, 0xf4,
-	0xb1, 0xfa, 0x00, 0xcd, 0xfc, 0x01, 0x76, 0x11, 0xe4, 0x77, 0xf1, 0xbb, 0x62, 0xe6, 0x9b, 0xef,
-	0x7c, 0xe7, 0xcc, 0x39, 0xdf, 0x2c, 0x00, 0x41, 0xe8, 0x51, 0x67, 0x14, 0x85, 0x3c, 0x44, 0x65,
-	0x36, 0xf2, 0x23, 0xea, 0x90, 0x91, 0xef, 0x08, 0xd4, 0xda, 0x96, 0xfb, 0xba, 0x1b, 0x3e, 0x3d,
-	0x85, 0x81, 0xfe, 0x51, 0x54, 0xfb, 0x0c, 0x72, 0x17, 0x71, 0xe0, 0x0d, 0x29, 0x2a, 0x43, 0xc6,
-	0xf7, 0xaa, 0xc6, 0x9e, 0x51, 0x2b, 0xe0, 0x8c, 0xef, 0xa1, 0x6d, 0xc8, 0xbb, 0xa4, 0xe7, 0xd2,
-	0x88, 0xb3, 0x6a, 0x66, 0xcf, 0xa8, 0x95, 0xf0, 0xaa, 0x4b, 0x5a, 0x62, 0x6b, 0x5f, 0x42, 0xfe,
-	0xee, 0x8b, 0xd3, 0xaf, 0xbb, 0x3f, 0x5d, 0xb5, 0xd1, 0x0e, 0x80, 0xe0, 0xf4, 0xdc, 0x01, 0xf1,
-	0x83, 0xaa, 0x29, 0x89, 0x05, 0x81, 0xb4, 0x04, 0x20, 0x8e, 0xe9, 0x5f, 0x22, 0x3b, 0xeb, 0x11,
-	0x2e, 0x75, 0x4c, 0x5c, 0xd0, 0x48, 0x93, 0xdb, 0xff, 0x98, 0x50, 0x1e, 0x4b, 0xfd, 0x38, 0xf2,
-	0x08, 0xa7, 0xe8, 0x1c, 0xb2, 0xec, 0xd9, 0xf7, 0x58, 0xd5, 0xd8, 0x33, 0x6b, 0xc5, 0xc6, 0xe7,
-	0x4e, 0xfa, 0x32, 0x4e, 0x9a, 0xee, 0x74, 0x05, 0xb7, 0x13, 0xf0, 0xe8, 0x05, 0xab, 0x38, 0x84,
-	0xa1, 0x12, 0xd1, 0x07, 0x9f, 0xf1, 0x88, 0x70, 0x3f, 0x0c, 0x7a, 0x34, 0xe0, 0x91, 0x4f, 0x59,
-	0xd5, 0x94, 0x7a, 0x9f, 0x68, 0x3d, 0xdd, 0x05, 0x9c, 0x60, 0x2a, 0x95, 0xcd, 0x68, 0x06, 0xf2,
-	0x29, 0x43, 0x1d, 0x58, 0xed, 0xcb, 0x36, 0xb1, 0x6a, 0x56, 0xca, 0x1c, 0x2f, 0x29, 0x4b, 0x35,
-	0x55, 0x17, 0x36, 0x8e, 0xb5, 0x30, 0xc0, 0xb4, 0x5e, 0xb4, 0x01, 0xe6, 0x23, 0x7d, 0xd1, 0x2d,
-	0x17, 0x4b, 0xe4, 0x40, 0xf6, 0x99, 0x0c, 0x63, 0x2a, 0x1b, 0x55, 0x6c, 0x54, 0x17, 0x25, 0xc1,
-	0x8a, 0xf6, 0x4d, 0xe6, 0x2b, 0xc3, 0xba, 0x81, 0x52, 0x32, 0xd9, 0x1c, 0xd5, 0xa3, 0xb4, 0x6a,
-	0x25, 0xdd, 0x01, 0x15, 0x9c, 0x50, 0xb4, 0x6f, 0xc0, 0xbc, 0xee, 0x62, 0xf4, 0x11, 0x14, 0xd8,
-	0xc8, 0xbf, 0xbf, 0xa7, 0xbd, 0x89, 0x2f, 0xf2, 0x0a, 0xb8, 0xf2, 0x90, 0x05, 0x79, 0x12, 0x7b,
-	0x3e, 0x0d, 0x5c, 0x21, 0x6b, 0x8a, 0xb3, 0xf1, 0x5e, 0x54, 0xc0, 0xf9, 0x50, 0x7a, 0x21, 0x8b,
-	0xc5, 0xd2, 0xfe, 0x0d, 0x56, 0xaf, 0x7f, 0xbe, 0x95, 0x7e, 0xa9, 0x40, 0x96, 0x87, 0x8f, 0x34,
-	0xd0, 0x8a, 0x6a, 0xb3, 0xc4, 0x26, 0xa2, 0x14, 0x9f, 0xb1, 0x98, 0x7a, 0xe2, 0xd4, 0x94, 0xa7,
-	0x79, 0x05, 0x34, 0xb9, 0xfd, 0xb7, 0x01, 0x6b, 0x4d, 0xce, 0x29, 0xe3, 0x98, 0xfe, 0x19, 0x53,
-	0xc6, 0xd1, 0x25, 0x6c, 0x10, 0x09, 0x28, 0x03, 0x78, 0x84, 0x13, 0x99, 0xae, 0xd8, 0xd8, 0x49,
-	0xdf, 0xbd, 0x39, 0x65, 0xb5, 0x09, 0x27, 0x78, 0x9d, 0xa4, 0x01, 0x71, 0x15, 0x97, 0x45, 0xda,
-	0xff, 0x62, 0x29, 0x2e, 0x1e, 0x51, 0x36, 0x0a, 0x03, 0x46, 0xb5, 0xdb, 0x27, 0x7b, 0x3b, 0x84,
-	0xf2, 0xb8, 0x10, 0x85, 0xa0, 0x73, 0x28, 0x0a, 0x53, 0xf6, 0x62, 0xe9, 0x0a, 0x5d, 0xc4, 0xee,
-	0xdb, 0xde, 0xc1, 0x20, 0x42, 0xf4, 0x6b, 0xf8, 0x18, 0x0a, 0xee, 0x80, 0x0c, 0x87, 0x34, 0x78,
-	0xa0, 0xba, 0x8c, 0x29, 0x60, 0xff, 0x6b, 0x40, 0xe5, 0x3b, 0xca, 0xdd, 0xc1, 0xc4, 0x18, 0xba,
-	0x03, 0x87, 0xb0, 0xde, 0xee, 0xdc, 0xe0, 0x4e, 0xab, 0x79, 0xdb, 0x69, 0xf7, 0x5c, 0x16, 0x31,
-	0x39, 0xa5, 0x12, 0x2e, 0x4f, 0xe1, 0x16, 0x8b, 0x18, 0xba, 0x80, 0x15, 0x79, 0xaa, 0x1e, 0x87,
-	0x33, 0x5b, 0xd9, 0x3c, 0x71, 0x47, 0x04, 0x2a, 0x63, 0xcb, 0x58, 0xeb, 0x4b, 0x28, 0x4c, 0xa0,
-	0x39, 0xf6, 0xab, 0x24, 0xed, 0x57, 0x4a, 0x1a, 0xed, 0x0e, 0x3e, 0x98, 0x49, 0xf0, 0x9e, 0xda,
-	0x66, 0x7f, 0x0b, 0x9b, 0x52, 0x59, 0xbb, 0x6e, 0xdc, 0x96, 0x03, 0x30, 0xff, 0x60, 0x91, 0xd6,
-	0xdb, 0x9c, 0xd5, 0xbb, 0xee, 0x62, 0x2c, 0xce, 0xed, 0x96, 0xee, 0xea, 0x24, 0x5a, 0x97, 0x75,
-	0x0c, 0x2b, 0x22, 0x87, 0x8e, 0xdf, 0x7a, 0x15, 0xaf, 0xe9, 0x92, 0x64, 0x1f, 0xc1, 0x87, 0x93,
-	0xcb, 0xb5, 0x9a, 0xc9, 0x2a, 0xb4, 0xa9, 0x8c, 0x89, 0xa9, 0xec, 0x18, 0xb6, 0x5e, 0x71, 0x75,
-	0xce, 0x93, 0x54, 0xce, 0xc5, 0x5f, 0x04, 0xc9, 0x42, 0x27, 0x90, 0x53, 0xdf, 0x9a, 0x37, 0xdf,
-	0xba, 0xe6, 0x34, 0xfe, 0xcb, 0xc0, 0xca, 0x0f, 0xa1, 0x47, 0xd1, 0xf7, 0x90, 0x53, 0xc6, 0x45,
-	0x3b, 0xb3, 0x09, 0x52, 0x2f, 0xcb, 0xda, 0x5d, 0x74, 0xac, 0xaa, 0xad, 0x19, 0xa7, 0x06, 0xfa,
-	0x1d, 0xd6, 0x52, 0x53, 0x45, 0xfb, 0xef, 0xe2, 0x2a, 0xeb, 0x60, 0x09, 0x2b, 0x91, 0xe1, 0x17,
-	0x28, 0x25, 0xe7, 0x83, 0x3e, 0x9d, 0x1b, 0x9a, 0x9e, 0xbd, 0xb5, 0xff, 0x36, 0x49, 0xb7, 0xbb,
-	0x0f, 0xeb, 0x33, 0x93, 0x40, 0x9f, 0x2d, 0x2c, 0x2c, 0x35, 0x56, 0xeb, 0x70, 0x29, 0x4f, 0xe5,
-	0xb8, 0x70, 0x7e, 0x3d, 0x79, 0xf0, 0xf9, 0x20, 0xee, 0x8b, 0xb1, 0xd4, 0xd5, 0x27, 0xb5, 0xae,
-	0xfe, 0xa2, 0xe5, 0x9f, 0xb2, 0x5e, 0x93, 0x91, 0x5f, 0x17, 0x3a, 0xfd, 0x9c, 0x44, 0xcf, 0xfe,
-	0x0f, 0x00, 0x00, 0xff, 0xff, 0x88, 0x96, 0xb2, 0x52, 0xe3, 0x07, 0x00, 0x00,
+	// 968 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x56, 0xeb, 0x6e, 0xe3, 0x44,
+	0x14, 0x96, 0xeb, 0x5c, 0x4f, 0xb3, 0x69, 0x99, 0x06, 0xd6, 0x35, 0x74, 0x89, 0xcc, 0x2e, 0x1b,
+	0xba, 0x95, 0x5b, 0x75, 0x85, 0xb8, 0x08, 0x69, 0x95, 0x26, 0x41, 0xdb, 0x46, 0xa0, 0x68, 0xb2,
+	0x0b, 0x0b, 0xfc, 0x30, 0x8e, 0x3d, 0x9b, 0x4e, 0x9b, 0xda, 0xc1, 0x33, 0x6e, 0xc9, 0x13, 0xf0,
+	0x0e, 0x3c, 0x19, 0x7f, 0x79, 0x13, 0x34, 0x97, 0x5c, 0x9c, 0x26, 0x6d, 0x7f, 0xf0, 0x2b, 0x33,
+	0x67, 0xbe, 0xf3, 0x9d, 0xcb, 0x7c, 0x67, 0x62, 0x80, 0x28, 0x0e, 0x89, 0x3b, 0x4e, 0x62, 0x1e,
+	0xa3, 0x2a, 0x1b, 0xd3, 0x84, 0xb8, 0xfe, 0x98, 0xba, 0xc2, 0x6a, 0xef, 0xca, 0xfd, 0x61, 0x10,
+	0x5f, 0x5d, 0xc5, 0x91, 0xfe, 0x51, 0x50, 0xe7, 0x25, 0x14, 0x4e, 0xd2, 0x28, 0x1c, 0x11, 0x54,
+	0x85, 0x0d, 0x1a, 0x5a, 0x46, 0xdd, 0x68, 0x94, 0xf1, 0x06, 0x0d, 0xd1, 0x2e, 0x94, 0x02, 0xdf,
+	0x0b, 0x48, 0xc2, 0x99, 0xb5, 0x51, 0x37, 0x1a, 0x15, 0x5c, 0x0c, 0xfc, 0x96, 0xd8, 0x3a, 0xaf,
+	0xa1, 0xf4, 0xee, 0xcb, 0xa3, 0x6f, 0xfa, 0x3f, 0x9d, 0xb6, 0xd1, 0x1e, 0x80, 0xc0, 0x78, 0xc1,
+	0xb9, 0x4f, 0x23, 0xcb, 0x94, 0xc0, 0xb2, 0xb0, 0xb4, 0x84, 0x41, 0x1c, 0x93, 0x3f, 0x45, 0x74,
+	0xe6, 0xf9, 0x5c, 0xf2, 0x98, 0xb8, 0xac, 0x2d, 0x4d, 0xee, 0xfc, 0x9d, 0x83, 0xea, 0x94, 0xea,
+	0xed, 0x38, 0xf4, 0x39, 0x41, 0xaf, 0x20, 0xcf, 0xae, 0x69, 0xc8, 0x2c, 0xa3, 0x6e, 0x36, 0x36,
+	0x8f, 0xbf, 0x70, 0xb3, 0xc5, 0xb8, 0x59, 0xb8, 0xdb, 0x17, 0xd8, 0x4e, 0xc4, 0x93, 0x09, 0x56,
+	0x7e, 0x08, 0x43, 0x2d, 0x21, 0x43, 0xca, 0x78, 0xe2, 0x73, 0x1a, 0x47, 0x1e, 0x89, 0x78, 0x42,
+	0x09, 0xb3, 0x4c, 0xc9, 0xf7, 0xa9, 0xe6, 0xd3, 0x5d, 0xc0, 0x0b, 0x48, 0xc5, 0xb2, 0x93, 0x2c,
+	0x99, 0x28, 0x61, 0xa8, 0x03, 0xc5, 0x81, 0x6c, 0x13, 0xb3, 0xf2, 0x92, 0xe6, 0xc5, 0x3d, 0x69,
+	0xa9, 0xa6, 0xea, 0xc4, 0xa6, 0xbe, 0xc8, 0x86, 0x52, 0x42, 0xae, 0x29, 0xa3, 0x71, 0x64, 0x15,
+	0x64, 0x2f, 0x66, 0x7b, 0x54, 0x83, 0x7c, 0x48, 0x46, 0xdc, 0xb7, 0x8a, 0x75, 0xa3, 0x51, 0xc2,
+	0x6a, 0x83, 0xf6, 0xe1, 0x83, 0x90, 0x8c, 0x08, 0x27, 0xa1, 0xac, 0x63, 0xe2, 0x89, 0xce, 0x94,
+	0xea, 0x66, 0xa3, 0x8c, 0xb7, 0xf4, 0x81, 0x8c, 0x71, 0x1a, 0x32, 0xd4, 0x80, 0xed, 0x19, 0xc6,
+	0x0b, 0xe9, 0x90, 0x30, 0x6e, 0x95, 0xe5, 0x85, 0x54, 0x89, 0xc6, 0xb4, 0xa5, 0xd5, 0xc6, 0x00,
+	0xf3, 0xbe, 0xa1, 0x6d, 0x30, 0x2f, 0xc9, 0x44, 0x5f, 0xbd, 0x58, 0x22, 0x17, 0xf2, 0xd7, 0xfe,
+	0x28, 0x25, 0xf2, 0xc2, 0x36, 0x8f, 0xad, 0x75, 0xc5, 0x62, 0x05, 0xfb, 0x76, 0xe3, 0x6b, 0xc3,
+	0xee, 0x41, 0x65, 0xb1, 0xe8, 0x15, 0xac, 0xfb, 0x59, 0xd6, 0x5a, 0xf6, 0x26, 0x94, 0xf3, 0x02,
+	0xa3, 0xd3, 0x03, 0xf3, 0xac, 0x8f, 0xd1, 0xc7, 0x50, 0x66, 0x63, 0xfa, 0xfe, 0x3d, 0xf1, 0x66,
+	0xfa, 0x2c, 0x29, 0xc3, 0x69, 0x28, 0x3a, 0xea, 0xa7, 0x21, 0x25, 0x51, 0x20, 0x68, 0x45, 0x5b,
+	0x66, 0x7b, 0x91, 0x01, 0xe7, 0x23, 0xa9, 0xc9, 0x3c, 0x16, 0x4b, 0xe7, 0x37, 0x28, 0x9e, 0xfd,
+	0xfc, 0x46, 0xea, 0xb6, 0x06, 0x79, 0x1e, 0x5f, 0x92, 0x48, 0x33, 0xaa, 0xcd, 0x3d, 0x72, 0x15,
+	0xa9, 0x50, 0xc6, 0x52, 0x12, 0x8a, 0x53, 0x53, 0x5d, 0xa0, 0x32, 0x34, 0xb9, 0xf3, 0x97, 0x01,
+	0x8f, 0x9a, 0x9c, 0x13, 0xc6, 0x31, 0xf9, 0x23, 0x25, 0x8c, 0xa3, 0xd7, 0xb0, 0xed, 0x4b, 0x83,
+	0x12, 0x62, 0xe8, 0x73, 0x5f, 0x86, 0xdb, 0x3c, 0xde, 0xcb, 0xd6, 0xde, 0x9c, 0xa3, 0xda, 0x3e,
+	0xf7, 0xf1, 0x96, 0x9f, 0x35, 0x88, 0x52, 0x02, 0x96, 0xe8, 0x39, 0x14, 0x4b, 0x25, 0x25, 0x36,
+	0x8e, 0x23, 0x46, 0xf4, 0xd4, 0xcd, 0xf6, 0x4e, 0x0c, 0xd5, 0x69, 0x22, 0xca, 0x82, 0x5e, 0xc1,
+	0xa6, 0x18, 0x0e, 0x2f, 0x95, 0xea, 0xd4, 0x49, 0x3c, 0xb9, 0x5b, 0xc3, 0x18, 0x84, 0x8b, 0x5a,
+	0xa3, 0x4f, 0xa0, 0x1c, 0x9c, 0xfb, 0xa3, 0x11, 0x89, 0x86, 0x44, 0xa7, 0x31, 0x37, 0x38, 0xff,
+	0x18, 0x50, 0xfb, 0x9e, 0xf0, 0xe0, 0x7c, 0x26, 0x0c, 0xdd, 0x81, 0xe7, 0xb0, 0xd5, 0xee, 0xf4,
+	0x70, 0xa7, 0xd5, 0x7c, 0xd3, 0x69, 0x7b, 0x01, 0x4b, 0x98, 0xbc, 0xa5, 0x0a, 0xae, 0xce, 0xcd,
+	0x2d, 0x96, 0x30, 0x74, 0x02, 0x39, 0x79, 0xaa, 0x86, 0xd4, 0x5d, 0xce, 0x6c, 0x15, 0xb9, 0x2b,
+	0x1c, 0xd5, 0x80, 0x49, 0xdf, 0xcc, 0x74, 0xe5, 0xb2, 0xd3, 0x65, 0x7f, 0x05, 0xe5, 0x19, 0x7c,
+	0x85, 0x34, 0x6b, 0x8b, 0xd2, 0xac, 0x2c, 0x8a, 0xf0, 0x1d, 0x7c, 0xb8, 0x14, 0xfc, 0x7f, 0x6a,
+	0xa9, 0xf3, 0x1d, 0xec, 0x48, 0x66, 0xad, 0xc8, 0x69, 0xcb, 0x9e, 0x81, 0x79, 0xc1, 0x12, 0xcd,
+	0xb7, 0xb3, 0xcc, 0x77, 0xd6, 0xc7, 0x58, 0x9c, 0x3b, 0x2d, 0xa8, 0x65, 0xbd, 0x75, 0x5a, 0x2f,
+	0x20, 0x27, 0x62, 0x68, 0xff, 0xc7, 0xb7, 0xfc, 0x35, 0x5c, 0x82, 0x9c, 0x7d, 0xf8, 0x68, 0x56,
+	0x5c, 0xab, 0xb9, 0x98, 0x85, 0x16, 0x9c, 0x31, 0x13, 0x9c, 0x93, 0xc2, 0xe3, 0x5b, 0x58, 0x1d,
+	0xf3, 0x20, 0x13, 0x73, 0xfd, 0x6b, 0x21, 0x51, 0xe8, 0x00, 0x0a, 0xea, 0x3d, 0xbc, 0xf3, 0x1d,
+	0xd0, 0x18, 0xe7, 0x07, 0xd8, 0xed, 0xa5, 0x4c, 0x94, 0xd9, 0x25, 0x93, 0xb7, 0x63, 0xc6, 0x13,
+	0xe2, 0x5f, 0x4d, 0xb3, 0x3c, 0x82, 0xe2, 0xc5, 0x0d, 0xf7, 0xa6, 0x97, 0x39, 0xaf, 0x57, 0x73,
+	0xf5, 0xd2, 0xc1, 0x88, 0x06, 0x5d, 0x32, 0xc1, 0x85, 0x8b, 0x1b, 0xde, 0x25, 0x13, 0xc7, 0x03,
+	0x7b, 0x15, 0x9d, 0x2e, 0xa4, 0x09, 0xdb, 0x82, 0x8f, 0xd1, 0x61, 0x44, 0xa3, 0xa1, 0xe0, 0x9d,
+	0xfe, 0x0d, 0xad, 0x25, 0xae, 0x5e, 0xdc, 0xf0, 0xbe, 0xc2, 0x77, 0xc9, 0x84, 0x1d, 0xff, 0x6b,
+	0x42, 0xee, 0xc7, 0x38, 0x24, 0xa8, 0x0b, 0x05, 0x35, 0x84, 0x68, 0x6f, 0xb9, 0x21, 0x99, 0x57,
+	0xc2, 0x7e, 0xb2, 0xee, 0x58, 0x25, 0xd5, 0x30, 0x8e, 0x0c, 0xf4, 0x3b, 0x3c, 0xca, 0xa8, 0x10,
+	0x3d, 0x7d, 0xc8, 0x84, 0xd8, 0xcf, 0xee, 0x41, 0x2d, 0x44, 0xf8, 0x05, 0x2a, 0x8b, 0x7a, 0x42,
+	0x9f, 0xad, 0x74, 0xcd, 0x6a, 0xd5, 0x7e, 0x7a, 0x37, 0x48, 0x77, 0x75, 0x00, 0x5b, 0x4b, 0xca,
+	0x41, 0x9f, 0xaf, 0x4d, 0x2c, 0x23, 0x43, 0xfb, 0xf9, 0xbd, 0x38, 0x1d, 0xe3, 0x12, 0xd0, 0xed,
+	0x7b, 0x45, 0xb7, 0x3e, 0x1e, 0xd6, 0x4a, 0xc9, 0xde, 0x7f, 0x08, 0x54, 0x05, 0x3b, 0x71, 0x7f,
+	0x3d, 0x18, 0x52, 0x7e, 0x9e, 0x0e, 0x84, 0x1c, 0x0e, 0xd5, 0x7f, 0xd1, 0xa1, 0xfa, 0xc6, 0x92,
+	0x5f, 0x55, 0x7a, 0xed, 0x8f, 0xe9, 0xa1, 0xa0, 0x1a, 0x14, 0xa4, 0xf5, 0xe5, 0x7f, 0x03, 0x00,
+	0xcf, 0x7b, 0x1e, 0xfa, 0xa4, 0x09, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -779,6 +929,9 @@ type NodeClient interface {
 	FetchJWTSVID(ctx context.Context, in *FetchJWTSVIDRequest, opts ...grpc.CallOption) (*FetchJWTSVIDResponse, error)
 	// Fetches an X509 CA SVID for a downstream SPIRE server.
 	FetchX509CASVID(ctx context.Context, in *FetchX509CASVIDRequest, opts ...grpc.CallOption) (*FetchX509CASVIDResponse, error)
+	// Pushes a JWT signing key of a downstream SPIRE server into the trust
+	// domain bundle.
+	PushJWTKeyUpstream(ctx context.Context, in *PushJWTKeyUpstreamRequest, opts ...grpc.CallOption) (*PushJWTKeyUpstreamResponse, error)
 }
 
 type nodeClient struct {
@@ -869,6 +1022,15 @@ func (c *nodeClient) FetchX509CASVID(ctx context.Context, in *FetchX509CASVIDReq
 	return out, nil
 }
 
+func (c *nodeClient) PushJWTKeyUpstream(ctx context.Context, in *PushJWTKeyUpstreamRequest, opts ...grpc.CallOption) (*PushJWTKeyUpstreamResponse, error) {
+	out := new(PushJWTKeyUpstreamResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.node.Node/PushJWTKeyUpstream", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // NodeServer is the server API for Node service.
 type NodeServer interface {
 	// Attest the node, get base node SVID.
@@ -881,6 +1043,9 @@ type NodeServer interface {
 	FetchJWTSVID(context.Context, *FetchJWTSVIDRequest) (*FetchJWTSVIDResponse, error)
 	// Fetches an X509 CA SVID for a downstream SPIRE server.
 	FetchX509CASVID(context.Context, *FetchX509CASVIDRequest) (*FetchX509CASVIDResponse, error)
+	// Pushes a JWT signing key of a downstream SPIRE server into the trust
+	// domain bundle.
+	PushJWTKeyUpstream(context.Context, *PushJWTKeyUpstreamRequest) (*PushJWTKeyUpstreamResponse, error)
 }
 
 func RegisterNodeServer(s *grpc.Server, srv NodeServer) {
@@ -975,6 +1140,24 @@ func _Node_FetchX509CASVID_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Node_PushJWTKeyUpstream_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PushJWTKeyUpstreamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(NodeServer).PushJWTKeyUpstream(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.node.Node/PushJWTKeyUpstream",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(NodeServer).PushJWTKeyUpstream(ctx, req.(*PushJWTKeyUpstreamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Node_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "spire.api.node.Node",
 	HandlerType: (*NodeServer)(nil),
@@ -987,6 +1170,10 @@ var _Node_serviceDesc = grpc.ServiceDesc{
 			MethodName: "FetchX509CASVID",
 			Handler:    _Node_FetchX509CASVID_Handler,
 		},
+		{
+			MethodName: "PushJWTKeyUpstream",
+			Handler:    _Node_PushJWTKeyUpstream_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -7,6 +7,7 @@ import (
 	context "context"
 	fmt "fmt"
 	proto "github.com/golang/protobuf/proto"
+	wrappers "github.com/golang/protobuf/ptypes/wrappers"
 	common "github.com/spiffe/spire/proto/spire/common"
 	grpc "google.golang.org/grpc"
 	math "math"
@@ -23,6 +24,106 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// Identifies the kind of CA authority an operation applies to
+type CAKind int32
+
+const (
+	// X509 CA used to sign X509-SVIDs
+	CAKind_X509_CA CAKind = 0
+	// JWT signing key used to sign JWT-SVIDs
+	CAKind_JWT_KEY CAKind = 1
+)
+
+var CAKind_name = map[int32]string{
+	0: "X509_CA",
+	1: "JWT_KEY",
+}
+
+var CAKind_value = map[string]int32{
+	"X509_CA": 0,
+	"JWT_KEY": 1,
+}
+
+func (x CAKind) String() string {
+	return proto.EnumName(CAKind_name, int32(x))
+}
+
+func (CAKind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{0}
+}
+
+// Determines how entries are matched against the selectors filter
+type ListEntriesRequest_SelectorMatch int32
+
+const (
+	// SUPERSET matches entries that have all of the selectors, and
+	// possibly others
+	ListEntriesRequest_SUPERSET ListEntriesRequest_SelectorMatch = 0
+	// SUBSET matches entries whose selectors are all among the selectors
+	ListEntriesRequest_SUBSET ListEntriesRequest_SelectorMatch = 1
+	// EXACT matches entries that have exactly the selectors
+	ListEntriesRequest_EXACT ListEntriesRequest_SelectorMatch = 2
+)
+
+var ListEntriesRequest_SelectorMatch_name = map[int32]string{
+	0: "SUPERSET",
+	1: "SUBSET",
+	2: "EXACT",
+}
+
+var ListEntriesRequest_SelectorMatch_value = map[string]int32{
+	"SUPERSET": 0,
+	"SUBSET":   1,
+	"EXACT":    2,
+}
+
+func (x ListEntriesRequest_SelectorMatch) String() string {
+	return proto.EnumName(ListEntriesRequest_SelectorMatch_name, int32(x))
+}
+
+func (ListEntriesRequest_SelectorMatch) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{4, 0}
+}
+
+type EntryEvent_Type int32
+
+const (
+	// SNAPSHOT carries an entry that existed when the watch started
+	EntryEvent_SNAPSHOT EntryEvent_Type = 0
+	// SNAPSHOT_END marks the end of the snapshot
+	EntryEvent_SNAPSHOT_END EntryEvent_Type = 1
+	// CREATE carries an entry that was created
+	EntryEvent_CREATE EntryEvent_Type = 2
+	// UPDATE carries an entry that was updated
+	EntryEvent_UPDATE EntryEvent_Type = 3
+	// DELETE reports an entry that was deleted
+	EntryEvent_DELETE EntryEvent_Type = 4
+)
+
+var EntryEvent_Type_name = map[int32]string{
+	0: "SNAPSHOT",
+	1: "SNAPSHOT_END",
+	2: "CREATE",
+	3: "UPDATE",
+	4: "DELETE",
+}
+
+var EntryEvent_Type_value = map[string]int32{
+	"SNAPSHOT":     0,
+	"SNAPSHOT_END": 1,
+	"CREATE":       2,
+	"UPDATE":       3,
+	"DELETE":       4,
+}
+
+func (x EntryEvent_Type) String() string {
+	return proto.EnumName(EntryEvent_Type_name, int32(x))
+}
+
+func (EntryEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{14, 0}
+}
+
 // Mode controls the delete behavior if there are other records
 // associated with the bundle (e.g. registration entries).
 type DeleteFederatedBundleRequest_Mode int32
@@ -53,7 +154,71 @@ func (x DeleteFederatedBundleRequest_Mode) String() string {
 }
 
 func (DeleteFederatedBundleRequest_Mode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{6, 0}
+	return fileDescriptor_199f7aef77c18626, []int{17, 0}
+}
+
+// State of a CA slot
+type CASlot_State int32
+
+const (
+	// EMPTY means no authority is in the slot
+	CASlot_EMPTY CASlot_State = 0
+	// PREPARED means the authority is in the bundle but not yet used for signing
+	CASlot_PREPARED CASlot_State = 1
+	// ACTIVE means the authority is used for signing
+	CASlot_ACTIVE CASlot_State = 2
+)
+
+var CASlot_State_name = map[int32]string{
+	0: "EMPTY",
+	1: "PREPARED",
+	2: "ACTIVE",
+}
+
+var CASlot_State_value = map[string]int32{
+	"EMPTY":    0,
+	"PREPARED": 1,
+	"ACTIVE":   2,
+}
+
+func (x CASlot_State) String() string {
+	return proto.EnumName(CASlot_State_name, int32(x))
+}
+
+func (CASlot_State) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{34, 0}
+}
+
+// Type of an SVID
+type IssuedSVID_Type int32
+
+const (
+	// X509_SVID is an X509-SVID
+	IssuedSVID_X509_SVID IssuedSVID_Type = 0
+	// X509_CA_SVID is an X509-SVID for a downstream CA
+	IssuedSVID_X509_CA_SVID IssuedSVID_Type = 1
+	// JWT_SVID is a JWT-SVID
+	IssuedSVID_JWT_SVID IssuedSVID_Type = 2
+)
+
+var IssuedSVID_Type_name = map[int32]string{
+	0: "X509_SVID",
+	1: "X509_CA_SVID",
+	2: "JWT_SVID",
+}
+
+var IssuedSVID_Type_value = map[string]int32{
+	"X509_SVID":    0,
+	"X509_CA_SVID": 1,
+	"JWT_SVID":     2,
+}
+
+func (x IssuedSVID_Type) String() string {
+	return proto.EnumName(IssuedSVID_Type_name, int32(x))
+}
+
+func (IssuedSVID_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{43, 0}
 }
 
 // A type that represents the id of an entry.
@@ -181,11 +346,15 @@ func (m *SpiffeID) GetId() string {
 
 // A type used to update registration entries
 type UpdateEntryRequest struct {
-	// Registration entry to update
-	Entry                *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
-	XXX_unrecognized     []byte                    `json:"-"`
-	XXX_sizecache        int32                     `json:"-"`
+	// Registration entry to update. If its revision number is set, the
+	// update fails with FailedPrecondition unless it matches the stored one.
+	Entry *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	// Only the fields selected by the mask are updated. If unset, the whole
+	// entry is replaced.
+	Mask                 *common.RegistrationEntryMask `protobuf:"bytes,2,opt,name=mask,proto3" json:"mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
 }
 
 func (m *UpdateEntryRequest) Reset()         { *m = UpdateEntryRequest{} }
@@ -220,449 +389,2555 @@ func (m *UpdateEntryRequest) GetEntry() *common.RegistrationEntry {
 	return nil
 }
 
-// A CA bundle for a different Trust Domain than the one used and managed by the Server.
-type FederatedBundle struct {
-	// Common bundle format
-	Bundle               *common.Bundle `protobuf:"bytes,3,opt,name=bundle,proto3" json:"bundle,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+func (m *UpdateEntryRequest) GetMask() *common.RegistrationEntryMask {
+	if m != nil {
+		return m.Mask
+	}
+	return nil
 }
 
-func (m *FederatedBundle) Reset()         { *m = FederatedBundle{} }
-func (m *FederatedBundle) String() string { return proto.CompactTextString(m) }
-func (*FederatedBundle) ProtoMessage()    {}
-func (*FederatedBundle) Descriptor() ([]byte, []int) {
+// Represents a ListEntries request. Filters that are set must all match.
+type ListEntriesRequest struct {
+	// If set, only entries with this parent ID are listed
+	ParentId string `protobuf:"bytes,1,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	// If set, only entries with this SPIFFE ID are listed
+	SpiffeId string `protobuf:"bytes,2,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	// If set, only entries whose SPIFFE ID starts with this prefix are
+	// listed
+	SpiffeIdPrefix string `protobuf:"bytes,3,opt,name=spiffe_id_prefix,json=spiffeIdPrefix,proto3" json:"spiffe_id_prefix,omitempty"`
+	// If set, only entries matching these selectors are listed
+	Selectors []*common.Selector `protobuf:"bytes,4,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	// How entries are matched against the selectors
+	SelectorMatch ListEntriesRequest_SelectorMatch `protobuf:"varint,5,opt,name=selector_match,json=selectorMatch,proto3,enum=spire.api.registration.ListEntriesRequest_SelectorMatch" json:"selector_match,omitempty"`
+	// If set, only entries that federate with at least one of these trust
+	// domains are listed
+	FederatesWith []string `protobuf:"bytes,6,rep,name=federates_with,json=federatesWith,proto3" json:"federates_with,omitempty"`
+	// If set, only entries with a matching downstream flag are listed
+	Downstream *wrappers.BoolValue `protobuf:"bytes,7,opt,name=downstream,proto3" json:"downstream,omitempty"`
+	// If set, only entries with a matching admin flag are listed
+	Admin *wrappers.BoolValue `protobuf:"bytes,8,opt,name=admin,proto3" json:"admin,omitempty"`
+	// Token of the page to list, as returned in a previous response. If
+	// empty, the first page is listed.
+	PageToken string `protobuf:"bytes,9,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Maximum number of entries to list. If zero, all matching entries are
+	// listed.
+	PageSize             int32    `protobuf:"varint,10,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListEntriesRequest) Reset()         { *m = ListEntriesRequest{} }
+func (m *ListEntriesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListEntriesRequest) ProtoMessage()    {}
+func (*ListEntriesRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_199f7aef77c18626, []int{4}
 }
 
-func (m *FederatedBundle) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_FederatedBundle.Unmarshal(m, b)
+func (m *ListEntriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListEntriesRequest.Unmarshal(m, b)
 }
-func (m *FederatedBundle) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_FederatedBundle.Marshal(b, m, deterministic)
+func (m *ListEntriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListEntriesRequest.Marshal(b, m, deterministic)
 }
-func (m *FederatedBundle) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FederatedBundle.Merge(m, src)
+func (m *ListEntriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListEntriesRequest.Merge(m, src)
 }
-func (m *FederatedBundle) XXX_Size() int {
-	return xxx_messageInfo_FederatedBundle.Size(m)
+func (m *ListEntriesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListEntriesRequest.Size(m)
 }
-func (m *FederatedBundle) XXX_DiscardUnknown() {
-	xxx_messageInfo_FederatedBundle.DiscardUnknown(m)
+func (m *ListEntriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListEntriesRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_FederatedBundle proto.InternalMessageInfo
+var xxx_messageInfo_ListEntriesRequest proto.InternalMessageInfo
 
-func (m *FederatedBundle) GetBundle() *common.Bundle {
+func (m *ListEntriesRequest) GetParentId() string {
 	if m != nil {
-		return m.Bundle
+		return m.ParentId
+	}
+	return ""
+}
+
+func (m *ListEntriesRequest) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *ListEntriesRequest) GetSpiffeIdPrefix() string {
+	if m != nil {
+		return m.SpiffeIdPrefix
+	}
+	return ""
+}
+
+func (m *ListEntriesRequest) GetSelectors() []*common.Selector {
+	if m != nil {
+		return m.Selectors
 	}
 	return nil
 }
 
-// A type that represents a federated bundle id.
-type FederatedBundleID struct {
-	// SPIFFE ID of the federated bundle
-	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+func (m *ListEntriesRequest) GetSelectorMatch() ListEntriesRequest_SelectorMatch {
+	if m != nil {
+		return m.SelectorMatch
+	}
+	return ListEntriesRequest_SUPERSET
+}
+
+func (m *ListEntriesRequest) GetFederatesWith() []string {
+	if m != nil {
+		return m.FederatesWith
+	}
+	return nil
+}
+
+func (m *ListEntriesRequest) GetDownstream() *wrappers.BoolValue {
+	if m != nil {
+		return m.Downstream
+	}
+	return nil
+}
+
+func (m *ListEntriesRequest) GetAdmin() *wrappers.BoolValue {
+	if m != nil {
+		return m.Admin
+	}
+	return nil
+}
+
+func (m *ListEntriesRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *ListEntriesRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+// Represents a ListEntries response
+type ListEntriesResponse struct {
+	// Entries matching the request filters
+	Entries []*common.RegistrationEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// Token of the next page. Empty if there are no more entries.
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *FederatedBundleID) Reset()         { *m = FederatedBundleID{} }
-func (m *FederatedBundleID) String() string { return proto.CompactTextString(m) }
-func (*FederatedBundleID) ProtoMessage()    {}
-func (*FederatedBundleID) Descriptor() ([]byte, []int) {
+func (m *ListEntriesResponse) Reset()         { *m = ListEntriesResponse{} }
+func (m *ListEntriesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListEntriesResponse) ProtoMessage()    {}
+func (*ListEntriesResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_199f7aef77c18626, []int{5}
 }
 
-func (m *FederatedBundleID) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_FederatedBundleID.Unmarshal(m, b)
+func (m *ListEntriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListEntriesResponse.Unmarshal(m, b)
 }
-func (m *FederatedBundleID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_FederatedBundleID.Marshal(b, m, deterministic)
+func (m *ListEntriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListEntriesResponse.Marshal(b, m, deterministic)
 }
-func (m *FederatedBundleID) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FederatedBundleID.Merge(m, src)
+func (m *ListEntriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListEntriesResponse.Merge(m, src)
 }
-func (m *FederatedBundleID) XXX_Size() int {
-	return xxx_messageInfo_FederatedBundleID.Size(m)
+func (m *ListEntriesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListEntriesResponse.Size(m)
 }
-func (m *FederatedBundleID) XXX_DiscardUnknown() {
-	xxx_messageInfo_FederatedBundleID.DiscardUnknown(m)
+func (m *ListEntriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListEntriesResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_FederatedBundleID proto.InternalMessageInfo
+var xxx_messageInfo_ListEntriesResponse proto.InternalMessageInfo
 
-func (m *FederatedBundleID) GetId() string {
+func (m *ListEntriesResponse) GetEntries() []*common.RegistrationEntry {
 	if m != nil {
-		return m.Id
+		return m.Entries
+	}
+	return nil
+}
+
+func (m *ListEntriesResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
 	}
 	return ""
 }
 
-type DeleteFederatedBundleRequest struct {
-	Id                   string                            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Mode                 DeleteFederatedBundleRequest_Mode `protobuf:"varint,2,opt,name=mode,proto3,enum=spire.api.registration.DeleteFederatedBundleRequest_Mode" json:"mode,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
-	XXX_unrecognized     []byte                            `json:"-"`
-	XXX_sizecache        int32                             `json:"-"`
+// The outcome of the operation on a single entry of a batch
+type BatchEntryResult struct {
+	// gRPC status code of the operation. Zero (OK) on success.
+	Code int32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	// Describes why the operation failed. Empty on success.
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// The created, updated or deleted entry. Only set on success.
+	Entry                *common.RegistrationEntry `protobuf:"bytes,3,opt,name=entry,proto3" json:"entry,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
 }
 
-func (m *DeleteFederatedBundleRequest) Reset()         { *m = DeleteFederatedBundleRequest{} }
-func (m *DeleteFederatedBundleRequest) String() string { return proto.CompactTextString(m) }
-func (*DeleteFederatedBundleRequest) ProtoMessage()    {}
-func (*DeleteFederatedBundleRequest) Descriptor() ([]byte, []int) {
+func (m *BatchEntryResult) Reset()         { *m = BatchEntryResult{} }
+func (m *BatchEntryResult) String() string { return proto.CompactTextString(m) }
+func (*BatchEntryResult) ProtoMessage()    {}
+func (*BatchEntryResult) Descriptor() ([]byte, []int) {
 	return fileDescriptor_199f7aef77c18626, []int{6}
 }
 
-func (m *DeleteFederatedBundleRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteFederatedBundleRequest.Unmarshal(m, b)
+func (m *BatchEntryResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchEntryResult.Unmarshal(m, b)
 }
-func (m *DeleteFederatedBundleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteFederatedBundleRequest.Marshal(b, m, deterministic)
+func (m *BatchEntryResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchEntryResult.Marshal(b, m, deterministic)
 }
-func (m *DeleteFederatedBundleRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteFederatedBundleRequest.Merge(m, src)
+func (m *BatchEntryResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchEntryResult.Merge(m, src)
 }
-func (m *DeleteFederatedBundleRequest) XXX_Size() int {
-	return xxx_messageInfo_DeleteFederatedBundleRequest.Size(m)
+func (m *BatchEntryResult) XXX_Size() int {
+	return xxx_messageInfo_BatchEntryResult.Size(m)
 }
-func (m *DeleteFederatedBundleRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteFederatedBundleRequest.DiscardUnknown(m)
+func (m *BatchEntryResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchEntryResult.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteFederatedBundleRequest proto.InternalMessageInfo
+var xxx_messageInfo_BatchEntryResult proto.InternalMessageInfo
 
-func (m *DeleteFederatedBundleRequest) GetId() string {
+func (m *BatchEntryResult) GetCode() int32 {
 	if m != nil {
-		return m.Id
+		return m.Code
+	}
+	return 0
+}
+
+func (m *BatchEntryResult) GetMessage() string {
+	if m != nil {
+		return m.Message
 	}
 	return ""
 }
 
-func (m *DeleteFederatedBundleRequest) GetMode() DeleteFederatedBundleRequest_Mode {
+func (m *BatchEntryResult) GetEntry() *common.RegistrationEntry {
 	if m != nil {
-		return m.Mode
+		return m.Entry
 	}
-	return DeleteFederatedBundleRequest_RESTRICT
+	return nil
 }
 
-// JoinToken message is used for registering a new token
-type JoinToken struct {
-	// The join token. If not set, one will be generated
-	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	// TTL in seconds
-	Ttl                  int32    `protobuf:"varint,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+// Represents a BatchCreateEntry request
+type BatchCreateEntryRequest struct {
+	// Registration entries to create
+	Entries []*common.RegistrationEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// If set, no entries are created unless all of them can be
+	AllOrNothing         bool     `protobuf:"varint,2,opt,name=all_or_nothing,json=allOrNothing,proto3" json:"all_or_nothing,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *JoinToken) Reset()         { *m = JoinToken{} }
-func (m *JoinToken) String() string { return proto.CompactTextString(m) }
-func (*JoinToken) ProtoMessage()    {}
-func (*JoinToken) Descriptor() ([]byte, []int) {
+func (m *BatchCreateEntryRequest) Reset()         { *m = BatchCreateEntryRequest{} }
+func (m *BatchCreateEntryRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchCreateEntryRequest) ProtoMessage()    {}
+func (*BatchCreateEntryRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_199f7aef77c18626, []int{7}
 }
 
-func (m *JoinToken) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_JoinToken.Unmarshal(m, b)
+func (m *BatchCreateEntryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchCreateEntryRequest.Unmarshal(m, b)
 }
-func (m *JoinToken) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_JoinToken.Marshal(b, m, deterministic)
+func (m *BatchCreateEntryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchCreateEntryRequest.Marshal(b, m, deterministic)
 }
-func (m *JoinToken) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_JoinToken.Merge(m, src)
+func (m *BatchCreateEntryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchCreateEntryRequest.Merge(m, src)
 }
-func (m *JoinToken) XXX_Size() int {
-	return xxx_messageInfo_JoinToken.Size(m)
+func (m *BatchCreateEntryRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchCreateEntryRequest.Size(m)
 }
-func (m *JoinToken) XXX_DiscardUnknown() {
-	xxx_messageInfo_JoinToken.DiscardUnknown(m)
+func (m *BatchCreateEntryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchCreateEntryRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_JoinToken proto.InternalMessageInfo
+var xxx_messageInfo_BatchCreateEntryRequest proto.InternalMessageInfo
 
-func (m *JoinToken) GetToken() string {
+func (m *BatchCreateEntryRequest) GetEntries() []*common.RegistrationEntry {
 	if m != nil {
-		return m.Token
+		return m.Entries
 	}
-	return ""
+	return nil
 }
 
-func (m *JoinToken) GetTtl() int32 {
+func (m *BatchCreateEntryRequest) GetAllOrNothing() bool {
 	if m != nil {
-		return m.Ttl
+		return m.AllOrNothing
 	}
-	return 0
+	return false
 }
 
-// CA Bundle of the server
-type Bundle struct {
-	// Common bundle format
-	Bundle               *common.Bundle `protobuf:"bytes,2,opt,name=bundle,proto3" json:"bundle,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+// Represents a BatchCreateEntry response
+type BatchCreateEntryResponse struct {
+	// One result per requested entry, in request order
+	Results              []*BatchEntryResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
-func (m *Bundle) Reset()         { *m = Bundle{} }
-func (m *Bundle) String() string { return proto.CompactTextString(m) }
-func (*Bundle) ProtoMessage()    {}
-func (*Bundle) Descriptor() ([]byte, []int) {
+func (m *BatchCreateEntryResponse) Reset()         { *m = BatchCreateEntryResponse{} }
+func (m *BatchCreateEntryResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchCreateEntryResponse) ProtoMessage()    {}
+func (*BatchCreateEntryResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_199f7aef77c18626, []int{8}
 }
 
-func (m *Bundle) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Bundle.Unmarshal(m, b)
+func (m *BatchCreateEntryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchCreateEntryResponse.Unmarshal(m, b)
 }
-func (m *Bundle) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Bundle.Marshal(b, m, deterministic)
+func (m *BatchCreateEntryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchCreateEntryResponse.Marshal(b, m, deterministic)
 }
-func (m *Bundle) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Bundle.Merge(m, src)
+func (m *BatchCreateEntryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchCreateEntryResponse.Merge(m, src)
 }
-func (m *Bundle) XXX_Size() int {
-	return xxx_messageInfo_Bundle.Size(m)
+func (m *BatchCreateEntryResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchCreateEntryResponse.Size(m)
 }
-func (m *Bundle) XXX_DiscardUnknown() {
-	xxx_messageInfo_Bundle.DiscardUnknown(m)
+func (m *BatchCreateEntryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchCreateEntryResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Bundle proto.InternalMessageInfo
+var xxx_messageInfo_BatchCreateEntryResponse proto.InternalMessageInfo
 
-func (m *Bundle) GetBundle() *common.Bundle {
+func (m *BatchCreateEntryResponse) GetResults() []*BatchEntryResult {
 	if m != nil {
-		return m.Bundle
+		return m.Results
 	}
 	return nil
 }
 
-// Represents a ListAgents request
-type ListAgentsRequest struct {
+// Represents a BatchUpdateEntry request
+type BatchUpdateEntryRequest struct {
+	// Registration entries to update
+	Entries []*common.RegistrationEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// If set, no entries are updated unless all of them can be
+	AllOrNothing         bool     `protobuf:"varint,2,opt,name=all_or_nothing,json=allOrNothing,proto3" json:"all_or_nothing,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ListAgentsRequest) Reset()         { *m = ListAgentsRequest{} }
-func (m *ListAgentsRequest) String() string { return proto.CompactTextString(m) }
-func (*ListAgentsRequest) ProtoMessage()    {}
-func (*ListAgentsRequest) Descriptor() ([]byte, []int) {
+func (m *BatchUpdateEntryRequest) Reset()         { *m = BatchUpdateEntryRequest{} }
+func (m *BatchUpdateEntryRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchUpdateEntryRequest) ProtoMessage()    {}
+func (*BatchUpdateEntryRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_199f7aef77c18626, []int{9}
 }
 
-func (m *ListAgentsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListAgentsRequest.Unmarshal(m, b)
+func (m *BatchUpdateEntryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchUpdateEntryRequest.Unmarshal(m, b)
 }
-func (m *ListAgentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListAgentsRequest.Marshal(b, m, deterministic)
+func (m *BatchUpdateEntryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchUpdateEntryRequest.Marshal(b, m, deterministic)
 }
-func (m *ListAgentsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListAgentsRequest.Merge(m, src)
+func (m *BatchUpdateEntryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchUpdateEntryRequest.Merge(m, src)
 }
-func (m *ListAgentsRequest) XXX_Size() int {
-	return xxx_messageInfo_ListAgentsRequest.Size(m)
+func (m *BatchUpdateEntryRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchUpdateEntryRequest.Size(m)
 }
-func (m *ListAgentsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListAgentsRequest.DiscardUnknown(m)
+func (m *BatchUpdateEntryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchUpdateEntryRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListAgentsRequest proto.InternalMessageInfo
+var xxx_messageInfo_BatchUpdateEntryRequest proto.InternalMessageInfo
 
-// Represents a ListAgents response
-type ListAgentsResponse struct {
-	// List of all attested agents
-	Nodes                []*common.AttestedNode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
-	XXX_unrecognized     []byte                 `json:"-"`
-	XXX_sizecache        int32                  `json:"-"`
+func (m *BatchUpdateEntryRequest) GetEntries() []*common.RegistrationEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
 }
 
-func (m *ListAgentsResponse) Reset()         { *m = ListAgentsResponse{} }
-func (m *ListAgentsResponse) String() string { return proto.CompactTextString(m) }
-func (*ListAgentsResponse) ProtoMessage()    {}
-func (*ListAgentsResponse) Descriptor() ([]byte, []int) {
+func (m *BatchUpdateEntryRequest) GetAllOrNothing() bool {
+	if m != nil {
+		return m.AllOrNothing
+	}
+	return false
+}
+
+// Represents a BatchUpdateEntry response
+type BatchUpdateEntryResponse struct {
+	// One result per requested entry, in request order
+	Results              []*BatchEntryResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *BatchUpdateEntryResponse) Reset()         { *m = BatchUpdateEntryResponse{} }
+func (m *BatchUpdateEntryResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchUpdateEntryResponse) ProtoMessage()    {}
+func (*BatchUpdateEntryResponse) Descriptor() ([]byte, []int) {
 	return fileDescriptor_199f7aef77c18626, []int{10}
 }
 
-func (m *ListAgentsResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ListAgentsResponse.Unmarshal(m, b)
+func (m *BatchUpdateEntryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchUpdateEntryResponse.Unmarshal(m, b)
 }
-func (m *ListAgentsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ListAgentsResponse.Marshal(b, m, deterministic)
+func (m *BatchUpdateEntryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchUpdateEntryResponse.Marshal(b, m, deterministic)
 }
-func (m *ListAgentsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ListAgentsResponse.Merge(m, src)
+func (m *BatchUpdateEntryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchUpdateEntryResponse.Merge(m, src)
 }
-func (m *ListAgentsResponse) XXX_Size() int {
-	return xxx_messageInfo_ListAgentsResponse.Size(m)
+func (m *BatchUpdateEntryResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchUpdateEntryResponse.Size(m)
 }
-func (m *ListAgentsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ListAgentsResponse.DiscardUnknown(m)
+func (m *BatchUpdateEntryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchUpdateEntryResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ListAgentsResponse proto.InternalMessageInfo
+var xxx_messageInfo_BatchUpdateEntryResponse proto.InternalMessageInfo
 
-func (m *ListAgentsResponse) GetNodes() []*common.AttestedNode {
+func (m *BatchUpdateEntryResponse) GetResults() []*BatchEntryResult {
 	if m != nil {
-		return m.Nodes
+		return m.Results
 	}
 	return nil
 }
 
-// Represents an evict request
-type EvictAgentRequest struct {
-	// Agent identity of the node to be evicted.
-	// For example: "spiffe://example.org/spire/agent/join_token/feea6adc-3254-4052-9a18-5eeb74bf214f"
-	SpiffeID             string   `protobuf:"bytes,1,opt,name=spiffeID,proto3" json:"spiffeID,omitempty"`
+// Represents a BatchDeleteEntry request
+type BatchDeleteEntryRequest struct {
+	// IDs of the registration entries to delete
+	Ids []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	// If set, no entries are deleted unless all of them can be
+	AllOrNothing         bool     `protobuf:"varint,2,opt,name=all_or_nothing,json=allOrNothing,proto3" json:"all_or_nothing,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *EvictAgentRequest) Reset()         { *m = EvictAgentRequest{} }
-func (m *EvictAgentRequest) String() string { return proto.CompactTextString(m) }
-func (*EvictAgentRequest) ProtoMessage()    {}
-func (*EvictAgentRequest) Descriptor() ([]byte, []int) {
+func (m *BatchDeleteEntryRequest) Reset()         { *m = BatchDeleteEntryRequest{} }
+func (m *BatchDeleteEntryRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchDeleteEntryRequest) ProtoMessage()    {}
+func (*BatchDeleteEntryRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_199f7aef77c18626, []int{11}
 }
 
-func (m *EvictAgentRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_EvictAgentRequest.Unmarshal(m, b)
+func (m *BatchDeleteEntryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchDeleteEntryRequest.Unmarshal(m, b)
 }
-func (m *EvictAgentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_EvictAgentRequest.Marshal(b, m, deterministic)
+func (m *BatchDeleteEntryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchDeleteEntryRequest.Marshal(b, m, deterministic)
 }
-func (m *EvictAgentRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_EvictAgentRequest.Merge(m, src)
+func (m *BatchDeleteEntryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchDeleteEntryRequest.Merge(m, src)
+}
+func (m *BatchDeleteEntryRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchDeleteEntryRequest.Size(m)
+}
+func (m *BatchDeleteEntryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchDeleteEntryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchDeleteEntryRequest proto.InternalMessageInfo
+
+func (m *BatchDeleteEntryRequest) GetIds() []string {
+	if m != nil {
+		return m.Ids
+	}
+	return nil
+}
+
+func (m *BatchDeleteEntryRequest) GetAllOrNothing() bool {
+	if m != nil {
+		return m.AllOrNothing
+	}
+	return false
+}
+
+// Represents a BatchDeleteEntry response
+type BatchDeleteEntryResponse struct {
+	// One result per requested entry ID, in request order
+	Results              []*BatchEntryResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *BatchDeleteEntryResponse) Reset()         { *m = BatchDeleteEntryResponse{} }
+func (m *BatchDeleteEntryResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchDeleteEntryResponse) ProtoMessage()    {}
+func (*BatchDeleteEntryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{12}
+}
+
+func (m *BatchDeleteEntryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchDeleteEntryResponse.Unmarshal(m, b)
+}
+func (m *BatchDeleteEntryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchDeleteEntryResponse.Marshal(b, m, deterministic)
+}
+func (m *BatchDeleteEntryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchDeleteEntryResponse.Merge(m, src)
+}
+func (m *BatchDeleteEntryResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchDeleteEntryResponse.Size(m)
+}
+func (m *BatchDeleteEntryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchDeleteEntryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BatchDeleteEntryResponse proto.InternalMessageInfo
+
+func (m *BatchDeleteEntryResponse) GetResults() []*BatchEntryResult {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+// Represents a WatchEntries request
+type WatchEntriesRequest struct {
+	// Cursor of the last event received. If set, the watch resumes after
+	// that event. Otherwise, it starts with a snapshot of all entries.
+	Cursor               string   `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WatchEntriesRequest) Reset()         { *m = WatchEntriesRequest{} }
+func (m *WatchEntriesRequest) String() string { return proto.CompactTextString(m) }
+func (*WatchEntriesRequest) ProtoMessage()    {}
+func (*WatchEntriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{13}
+}
+
+func (m *WatchEntriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WatchEntriesRequest.Unmarshal(m, b)
+}
+func (m *WatchEntriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WatchEntriesRequest.Marshal(b, m, deterministic)
+}
+func (m *WatchEntriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WatchEntriesRequest.Merge(m, src)
+}
+func (m *WatchEntriesRequest) XXX_Size() int {
+	return xxx_messageInfo_WatchEntriesRequest.Size(m)
+}
+func (m *WatchEntriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_WatchEntriesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WatchEntriesRequest proto.InternalMessageInfo
+
+func (m *WatchEntriesRequest) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+// A change to the registration entries streamed by WatchEntries
+type EntryEvent struct {
+	Type EntryEvent_Type `protobuf:"varint,1,opt,name=type,proto3,enum=spire.api.registration.EntryEvent_Type" json:"type,omitempty"`
+	// ID of the entry. Not set for SNAPSHOT_END.
+	EntryId string `protobuf:"bytes,2,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	// The entry as of the event. Not set for SNAPSHOT_END and DELETE.
+	Entry *common.RegistrationEntry `protobuf:"bytes,3,opt,name=entry,proto3" json:"entry,omitempty"`
+	// Cursor to resume the watch after this event. Not set for SNAPSHOT.
+	Cursor               string   `protobuf:"bytes,4,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EntryEvent) Reset()         { *m = EntryEvent{} }
+func (m *EntryEvent) String() string { return proto.CompactTextString(m) }
+func (*EntryEvent) ProtoMessage()    {}
+func (*EntryEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{14}
+}
+
+func (m *EntryEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EntryEvent.Unmarshal(m, b)
+}
+func (m *EntryEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EntryEvent.Marshal(b, m, deterministic)
+}
+func (m *EntryEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EntryEvent.Merge(m, src)
+}
+func (m *EntryEvent) XXX_Size() int {
+	return xxx_messageInfo_EntryEvent.Size(m)
+}
+func (m *EntryEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_EntryEvent.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EntryEvent proto.InternalMessageInfo
+
+func (m *EntryEvent) GetType() EntryEvent_Type {
+	if m != nil {
+		return m.Type
+	}
+	return EntryEvent_SNAPSHOT
+}
+
+func (m *EntryEvent) GetEntryId() string {
+	if m != nil {
+		return m.EntryId
+	}
+	return ""
+}
+
+func (m *EntryEvent) GetEntry() *common.RegistrationEntry {
+	if m != nil {
+		return m.Entry
+	}
+	return nil
+}
+
+func (m *EntryEvent) GetCursor() string {
+	if m != nil {
+		return m.Cursor
+	}
+	return ""
+}
+
+// A CA bundle for a different Trust Domain than the one used and managed by the Server.
+type FederatedBundle struct {
+	// Common bundle format
+	Bundle               *common.Bundle `protobuf:"bytes,3,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *FederatedBundle) Reset()         { *m = FederatedBundle{} }
+func (m *FederatedBundle) String() string { return proto.CompactTextString(m) }
+func (*FederatedBundle) ProtoMessage()    {}
+func (*FederatedBundle) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{15}
+}
+
+func (m *FederatedBundle) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FederatedBundle.Unmarshal(m, b)
+}
+func (m *FederatedBundle) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FederatedBundle.Marshal(b, m, deterministic)
+}
+func (m *FederatedBundle) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FederatedBundle.Merge(m, src)
+}
+func (m *FederatedBundle) XXX_Size() int {
+	return xxx_messageInfo_FederatedBundle.Size(m)
+}
+func (m *FederatedBundle) XXX_DiscardUnknown() {
+	xxx_messageInfo_FederatedBundle.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FederatedBundle proto.InternalMessageInfo
+
+func (m *FederatedBundle) GetBundle() *common.Bundle {
+	if m != nil {
+		return m.Bundle
+	}
+	return nil
+}
+
+// A type that represents a federated bundle id.
+type FederatedBundleID struct {
+	// SPIFFE ID of the federated bundle
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FederatedBundleID) Reset()         { *m = FederatedBundleID{} }
+func (m *FederatedBundleID) String() string { return proto.CompactTextString(m) }
+func (*FederatedBundleID) ProtoMessage()    {}
+func (*FederatedBundleID) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{16}
+}
+
+func (m *FederatedBundleID) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FederatedBundleID.Unmarshal(m, b)
+}
+func (m *FederatedBundleID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FederatedBundleID.Marshal(b, m, deterministic)
+}
+func (m *FederatedBundleID) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FederatedBundleID.Merge(m, src)
+}
+func (m *FederatedBundleID) XXX_Size() int {
+	return xxx_messageInfo_FederatedBundleID.Size(m)
+}
+func (m *FederatedBundleID) XXX_DiscardUnknown() {
+	xxx_messageInfo_FederatedBundleID.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FederatedBundleID proto.InternalMessageInfo
+
+func (m *FederatedBundleID) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type DeleteFederatedBundleRequest struct {
+	Id                   string                            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Mode                 DeleteFederatedBundleRequest_Mode `protobuf:"varint,2,opt,name=mode,proto3,enum=spire.api.registration.DeleteFederatedBundleRequest_Mode" json:"mode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                          `json:"-"`
+	XXX_unrecognized     []byte                            `json:"-"`
+	XXX_sizecache        int32                             `json:"-"`
+}
+
+func (m *DeleteFederatedBundleRequest) Reset()         { *m = DeleteFederatedBundleRequest{} }
+func (m *DeleteFederatedBundleRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteFederatedBundleRequest) ProtoMessage()    {}
+func (*DeleteFederatedBundleRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{17}
+}
+
+func (m *DeleteFederatedBundleRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteFederatedBundleRequest.Unmarshal(m, b)
+}
+func (m *DeleteFederatedBundleRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteFederatedBundleRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteFederatedBundleRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteFederatedBundleRequest.Merge(m, src)
+}
+func (m *DeleteFederatedBundleRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteFederatedBundleRequest.Size(m)
+}
+func (m *DeleteFederatedBundleRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteFederatedBundleRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteFederatedBundleRequest proto.InternalMessageInfo
+
+func (m *DeleteFederatedBundleRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *DeleteFederatedBundleRequest) GetMode() DeleteFederatedBundleRequest_Mode {
+	if m != nil {
+		return m.Mode
+	}
+	return DeleteFederatedBundleRequest_RESTRICT
+}
+
+// JoinToken message is used for registering a new token
+type JoinToken struct {
+	// The join token. If not set, one will be generated
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// TTL in seconds
+	Ttl int32 `protobuf:"varint,2,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// Number of times the token can be used. If not set, the token can be
+	// used once.
+	MaxUses int32 `protobuf:"varint,3,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`
+	// Selectors given to the nodes attesting with the token
+	NodeSelectors []*common.Selector `protobuf:"bytes,4,rep,name=node_selectors,json=nodeSelectors,proto3" json:"node_selectors,omitempty"`
+	// Registration entries created when a node attests with the token. The
+	// parent ID of each entry is set to the agent ID of the node.
+	Entries []*common.RegistrationEntry `protobuf:"bytes,5,rep,name=entries,proto3" json:"entries,omitempty"`
+	// Expiration in seconds since unix epoch. Only set when listing.
+	Expiry int64 `protobuf:"varint,6,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	// Number of times the token has been used. Only set when listing.
+	Uses                 int32    `protobuf:"varint,7,opt,name=uses,proto3" json:"uses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JoinToken) Reset()         { *m = JoinToken{} }
+func (m *JoinToken) String() string { return proto.CompactTextString(m) }
+func (*JoinToken) ProtoMessage()    {}
+func (*JoinToken) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{18}
+}
+
+func (m *JoinToken) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JoinToken.Unmarshal(m, b)
+}
+func (m *JoinToken) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JoinToken.Marshal(b, m, deterministic)
+}
+func (m *JoinToken) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JoinToken.Merge(m, src)
+}
+func (m *JoinToken) XXX_Size() int {
+	return xxx_messageInfo_JoinToken.Size(m)
+}
+func (m *JoinToken) XXX_DiscardUnknown() {
+	xxx_messageInfo_JoinToken.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JoinToken proto.InternalMessageInfo
+
+func (m *JoinToken) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *JoinToken) GetTtl() int32 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+func (m *JoinToken) GetMaxUses() int32 {
+	if m != nil {
+		return m.MaxUses
+	}
+	return 0
+}
+
+func (m *JoinToken) GetNodeSelectors() []*common.Selector {
+	if m != nil {
+		return m.NodeSelectors
+	}
+	return nil
+}
+
+func (m *JoinToken) GetEntries() []*common.RegistrationEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+func (m *JoinToken) GetExpiry() int64 {
+	if m != nil {
+		return m.Expiry
+	}
+	return 0
+}
+
+func (m *JoinToken) GetUses() int32 {
+	if m != nil {
+		return m.Uses
+	}
+	return 0
+}
+
+// Represents a ListJoinTokens request
+type ListJoinTokensRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListJoinTokensRequest) Reset()         { *m = ListJoinTokensRequest{} }
+func (m *ListJoinTokensRequest) String() string { return proto.CompactTextString(m) }
+func (*ListJoinTokensRequest) ProtoMessage()    {}
+func (*ListJoinTokensRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{19}
+}
+
+func (m *ListJoinTokensRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJoinTokensRequest.Unmarshal(m, b)
+}
+func (m *ListJoinTokensRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJoinTokensRequest.Marshal(b, m, deterministic)
+}
+func (m *ListJoinTokensRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJoinTokensRequest.Merge(m, src)
+}
+func (m *ListJoinTokensRequest) XXX_Size() int {
+	return xxx_messageInfo_ListJoinTokensRequest.Size(m)
+}
+func (m *ListJoinTokensRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJoinTokensRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListJoinTokensRequest proto.InternalMessageInfo
+
+// Represents a ListJoinTokens response
+type ListJoinTokensResponse struct {
+	// The outstanding join tokens
+	JoinTokens           []*JoinToken `protobuf:"bytes,1,rep,name=join_tokens,json=joinTokens,proto3" json:"join_tokens,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ListJoinTokensResponse) Reset()         { *m = ListJoinTokensResponse{} }
+func (m *ListJoinTokensResponse) String() string { return proto.CompactTextString(m) }
+func (*ListJoinTokensResponse) ProtoMessage()    {}
+func (*ListJoinTokensResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{20}
+}
+
+func (m *ListJoinTokensResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJoinTokensResponse.Unmarshal(m, b)
+}
+func (m *ListJoinTokensResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJoinTokensResponse.Marshal(b, m, deterministic)
+}
+func (m *ListJoinTokensResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJoinTokensResponse.Merge(m, src)
+}
+func (m *ListJoinTokensResponse) XXX_Size() int {
+	return xxx_messageInfo_ListJoinTokensResponse.Size(m)
+}
+func (m *ListJoinTokensResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJoinTokensResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListJoinTokensResponse proto.InternalMessageInfo
+
+func (m *ListJoinTokensResponse) GetJoinTokens() []*JoinToken {
+	if m != nil {
+		return m.JoinTokens
+	}
+	return nil
+}
+
+// Represents a DeleteJoinToken request
+type DeleteJoinTokenRequest struct {
+	// The join token to delete
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteJoinTokenRequest) Reset()         { *m = DeleteJoinTokenRequest{} }
+func (m *DeleteJoinTokenRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteJoinTokenRequest) ProtoMessage()    {}
+func (*DeleteJoinTokenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{21}
+}
+
+func (m *DeleteJoinTokenRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteJoinTokenRequest.Unmarshal(m, b)
+}
+func (m *DeleteJoinTokenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteJoinTokenRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteJoinTokenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteJoinTokenRequest.Merge(m, src)
+}
+func (m *DeleteJoinTokenRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteJoinTokenRequest.Size(m)
+}
+func (m *DeleteJoinTokenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteJoinTokenRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteJoinTokenRequest proto.InternalMessageInfo
+
+func (m *DeleteJoinTokenRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+// Represents a DeleteJoinToken response
+type DeleteJoinTokenResponse struct {
+	// The deleted join token
+	JoinToken            *JoinToken `protobuf:"bytes,1,opt,name=join_token,json=joinToken,proto3" json:"join_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *DeleteJoinTokenResponse) Reset()         { *m = DeleteJoinTokenResponse{} }
+func (m *DeleteJoinTokenResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteJoinTokenResponse) ProtoMessage()    {}
+func (*DeleteJoinTokenResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{22}
+}
+
+func (m *DeleteJoinTokenResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteJoinTokenResponse.Unmarshal(m, b)
+}
+func (m *DeleteJoinTokenResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteJoinTokenResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteJoinTokenResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteJoinTokenResponse.Merge(m, src)
+}
+func (m *DeleteJoinTokenResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteJoinTokenResponse.Size(m)
+}
+func (m *DeleteJoinTokenResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteJoinTokenResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteJoinTokenResponse proto.InternalMessageInfo
+
+func (m *DeleteJoinTokenResponse) GetJoinToken() *JoinToken {
+	if m != nil {
+		return m.JoinToken
+	}
+	return nil
+}
+
+// CA Bundle of the server
+type Bundle struct {
+	// Common bundle format
+	Bundle               *common.Bundle `protobuf:"bytes,2,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *Bundle) Reset()         { *m = Bundle{} }
+func (m *Bundle) String() string { return proto.CompactTextString(m) }
+func (*Bundle) ProtoMessage()    {}
+func (*Bundle) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{23}
+}
+
+func (m *Bundle) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Bundle.Unmarshal(m, b)
+}
+func (m *Bundle) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Bundle.Marshal(b, m, deterministic)
+}
+func (m *Bundle) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Bundle.Merge(m, src)
+}
+func (m *Bundle) XXX_Size() int {
+	return xxx_messageInfo_Bundle.Size(m)
+}
+func (m *Bundle) XXX_DiscardUnknown() {
+	xxx_messageInfo_Bundle.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Bundle proto.InternalMessageInfo
+
+func (m *Bundle) GetBundle() *common.Bundle {
+	if m != nil {
+		return m.Bundle
+	}
+	return nil
+}
+
+// Represents a ListAgents request. Filters that are set must all match.
+type ListAgentsRequest struct {
+	// If set, only agents attested with this attestation type are listed
+	AttestationType string `protobuf:"bytes,1,opt,name=attestation_type,json=attestationType,proto3" json:"attestation_type,omitempty"`
+	// If non-zero, only agents whose SVID expires at or after this time are
+	// listed (seconds since unix epoch)
+	ExpiresAfter int64 `protobuf:"varint,2,opt,name=expires_after,json=expiresAfter,proto3" json:"expires_after,omitempty"`
+	// If non-zero, only agents whose SVID expires before this time are
+	// listed (seconds since unix epoch)
+	ExpiresBefore int64 `protobuf:"varint,3,opt,name=expires_before,json=expiresBefore,proto3" json:"expires_before,omitempty"`
+	// If set, only agents whose node selectors match these selectors are
+	// listed
+	Selectors []*common.Selector `protobuf:"bytes,4,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	// How agents are matched against the selectors
+	SelectorMatch ListEntriesRequest_SelectorMatch `protobuf:"varint,5,opt,name=selector_match,json=selectorMatch,proto3,enum=spire.api.registration.ListEntriesRequest_SelectorMatch" json:"selector_match,omitempty"`
+	// If set, only banned (or not banned) agents are listed
+	Banned *wrappers.BoolValue `protobuf:"bytes,6,opt,name=banned,proto3" json:"banned,omitempty"`
+	// Token of the page to list, as returned in a previous response. If
+	// empty, the first page is listed.
+	PageToken string `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Maximum number of agents to list. If zero, all matching agents are
+	// listed.
+	PageSize             int32    `protobuf:"varint,8,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAgentsRequest) Reset()         { *m = ListAgentsRequest{} }
+func (m *ListAgentsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListAgentsRequest) ProtoMessage()    {}
+func (*ListAgentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{24}
+}
+
+func (m *ListAgentsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAgentsRequest.Unmarshal(m, b)
+}
+func (m *ListAgentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAgentsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListAgentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAgentsRequest.Merge(m, src)
+}
+func (m *ListAgentsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListAgentsRequest.Size(m)
+}
+func (m *ListAgentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAgentsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAgentsRequest proto.InternalMessageInfo
+
+func (m *ListAgentsRequest) GetAttestationType() string {
+	if m != nil {
+		return m.AttestationType
+	}
+	return ""
+}
+
+func (m *ListAgentsRequest) GetExpiresAfter() int64 {
+	if m != nil {
+		return m.ExpiresAfter
+	}
+	return 0
+}
+
+func (m *ListAgentsRequest) GetExpiresBefore() int64 {
+	if m != nil {
+		return m.ExpiresBefore
+	}
+	return 0
+}
+
+func (m *ListAgentsRequest) GetSelectors() []*common.Selector {
+	if m != nil {
+		return m.Selectors
+	}
+	return nil
+}
+
+func (m *ListAgentsRequest) GetSelectorMatch() ListEntriesRequest_SelectorMatch {
+	if m != nil {
+		return m.SelectorMatch
+	}
+	return ListEntriesRequest_SUPERSET
+}
+
+func (m *ListAgentsRequest) GetBanned() *wrappers.BoolValue {
+	if m != nil {
+		return m.Banned
+	}
+	return nil
+}
+
+func (m *ListAgentsRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *ListAgentsRequest) GetPageSize() int32 {
+	if m != nil {
+		return m.PageSize
+	}
+	return 0
+}
+
+// Represents a ListAgents response
+type ListAgentsResponse struct {
+	// List of attested agents matching the request filters, along with their
+	// node selectors
+	Nodes []*common.AttestedNode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	// Token of the next page. Empty if there are no more agents.
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListAgentsResponse) Reset()         { *m = ListAgentsResponse{} }
+func (m *ListAgentsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListAgentsResponse) ProtoMessage()    {}
+func (*ListAgentsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{25}
+}
+
+func (m *ListAgentsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListAgentsResponse.Unmarshal(m, b)
+}
+func (m *ListAgentsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListAgentsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListAgentsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListAgentsResponse.Merge(m, src)
+}
+func (m *ListAgentsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListAgentsResponse.Size(m)
+}
+func (m *ListAgentsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListAgentsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListAgentsResponse proto.InternalMessageInfo
+
+func (m *ListAgentsResponse) GetNodes() []*common.AttestedNode {
+	if m != nil {
+		return m.Nodes
+	}
+	return nil
+}
+
+func (m *ListAgentsResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+// Represents a FetchAgent request
+type FetchAgentRequest struct {
+	// Agent identity of the node to fetch
+	SpiffeId             string   `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FetchAgentRequest) Reset()         { *m = FetchAgentRequest{} }
+func (m *FetchAgentRequest) String() string { return proto.CompactTextString(m) }
+func (*FetchAgentRequest) ProtoMessage()    {}
+func (*FetchAgentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{26}
+}
+
+func (m *FetchAgentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchAgentRequest.Unmarshal(m, b)
+}
+func (m *FetchAgentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchAgentRequest.Marshal(b, m, deterministic)
+}
+func (m *FetchAgentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchAgentRequest.Merge(m, src)
+}
+func (m *FetchAgentRequest) XXX_Size() int {
+	return xxx_messageInfo_FetchAgentRequest.Size(m)
+}
+func (m *FetchAgentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchAgentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchAgentRequest proto.InternalMessageInfo
+
+func (m *FetchAgentRequest) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+// Represents a FetchAgent response
+type FetchAgentResponse struct {
+	// The attested node, along with its node selectors
+	Node                 *common.AttestedNode `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *FetchAgentResponse) Reset()         { *m = FetchAgentResponse{} }
+func (m *FetchAgentResponse) String() string { return proto.CompactTextString(m) }
+func (*FetchAgentResponse) ProtoMessage()    {}
+func (*FetchAgentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{27}
+}
+
+func (m *FetchAgentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchAgentResponse.Unmarshal(m, b)
+}
+func (m *FetchAgentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchAgentResponse.Marshal(b, m, deterministic)
+}
+func (m *FetchAgentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchAgentResponse.Merge(m, src)
+}
+func (m *FetchAgentResponse) XXX_Size() int {
+	return xxx_messageInfo_FetchAgentResponse.Size(m)
+}
+func (m *FetchAgentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchAgentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchAgentResponse proto.InternalMessageInfo
+
+func (m *FetchAgentResponse) GetNode() *common.AttestedNode {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+// Represents an evict request
+type EvictAgentRequest struct {
+	// Agent identity of the node to be evicted.
+	// For example: "spiffe://example.org/spire/agent/join_token/feea6adc-3254-4052-9a18-5eeb74bf214f"
+	SpiffeID             string   `protobuf:"bytes,1,opt,name=spiffeID,proto3" json:"spiffeID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EvictAgentRequest) Reset()         { *m = EvictAgentRequest{} }
+func (m *EvictAgentRequest) String() string { return proto.CompactTextString(m) }
+func (*EvictAgentRequest) ProtoMessage()    {}
+func (*EvictAgentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{28}
+}
+
+func (m *EvictAgentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvictAgentRequest.Unmarshal(m, b)
+}
+func (m *EvictAgentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvictAgentRequest.Marshal(b, m, deterministic)
+}
+func (m *EvictAgentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvictAgentRequest.Merge(m, src)
+}
+func (m *EvictAgentRequest) XXX_Size() int {
+	return xxx_messageInfo_EvictAgentRequest.Size(m)
+}
+func (m *EvictAgentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvictAgentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvictAgentRequest proto.InternalMessageInfo
+
+func (m *EvictAgentRequest) GetSpiffeID() string {
+	if m != nil {
+		return m.SpiffeID
+	}
+	return ""
+}
+
+// Represents an evict response
+type EvictAgentResponse struct {
+	// Node contains the evicted node
+	Node                 *common.AttestedNode `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *EvictAgentResponse) Reset()         { *m = EvictAgentResponse{} }
+func (m *EvictAgentResponse) String() string { return proto.CompactTextString(m) }
+func (*EvictAgentResponse) ProtoMessage()    {}
+func (*EvictAgentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{29}
+}
+
+func (m *EvictAgentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvictAgentResponse.Unmarshal(m, b)
+}
+func (m *EvictAgentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvictAgentResponse.Marshal(b, m, deterministic)
+}
+func (m *EvictAgentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvictAgentResponse.Merge(m, src)
+}
+func (m *EvictAgentResponse) XXX_Size() int {
+	return xxx_messageInfo_EvictAgentResponse.Size(m)
+}
+func (m *EvictAgentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvictAgentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvictAgentResponse proto.InternalMessageInfo
+
+func (m *EvictAgentResponse) GetNode() *common.AttestedNode {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+// Represents a ban request
+type BanAgentRequest struct {
+	// Agent identity of the node to be banned
+	SpiffeId             string   `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BanAgentRequest) Reset()         { *m = BanAgentRequest{} }
+func (m *BanAgentRequest) String() string { return proto.CompactTextString(m) }
+func (*BanAgentRequest) ProtoMessage()    {}
+func (*BanAgentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{30}
+}
+
+func (m *BanAgentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BanAgentRequest.Unmarshal(m, b)
+}
+func (m *BanAgentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BanAgentRequest.Marshal(b, m, deterministic)
+}
+func (m *BanAgentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BanAgentRequest.Merge(m, src)
+}
+func (m *BanAgentRequest) XXX_Size() int {
+	return xxx_messageInfo_BanAgentRequest.Size(m)
+}
+func (m *BanAgentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BanAgentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BanAgentRequest proto.InternalMessageInfo
+
+func (m *BanAgentRequest) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+// Represents a ban response
+type BanAgentResponse struct {
+	// Node contains the banned node
+	Node                 *common.AttestedNode `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *BanAgentResponse) Reset()         { *m = BanAgentResponse{} }
+func (m *BanAgentResponse) String() string { return proto.CompactTextString(m) }
+func (*BanAgentResponse) ProtoMessage()    {}
+func (*BanAgentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{31}
+}
+
+func (m *BanAgentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BanAgentResponse.Unmarshal(m, b)
+}
+func (m *BanAgentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BanAgentResponse.Marshal(b, m, deterministic)
+}
+func (m *BanAgentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BanAgentResponse.Merge(m, src)
+}
+func (m *BanAgentResponse) XXX_Size() int {
+	return xxx_messageInfo_BanAgentResponse.Size(m)
+}
+func (m *BanAgentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BanAgentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BanAgentResponse proto.InternalMessageInfo
+
+func (m *BanAgentResponse) GetNode() *common.AttestedNode {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+// Represents an unban request
+type UnbanAgentRequest struct {
+	// Agent identity of the node to be unbanned
+	SpiffeId             string   `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UnbanAgentRequest) Reset()         { *m = UnbanAgentRequest{} }
+func (m *UnbanAgentRequest) String() string { return proto.CompactTextString(m) }
+func (*UnbanAgentRequest) ProtoMessage()    {}
+func (*UnbanAgentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{32}
+}
+
+func (m *UnbanAgentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnbanAgentRequest.Unmarshal(m, b)
+}
+func (m *UnbanAgentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnbanAgentRequest.Marshal(b, m, deterministic)
+}
+func (m *UnbanAgentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnbanAgentRequest.Merge(m, src)
+}
+func (m *UnbanAgentRequest) XXX_Size() int {
+	return xxx_messageInfo_UnbanAgentRequest.Size(m)
+}
+func (m *UnbanAgentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnbanAgentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnbanAgentRequest proto.InternalMessageInfo
+
+func (m *UnbanAgentRequest) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+// Represents an unban response
+type UnbanAgentResponse struct {
+	// Node contains the unbanned node
+	Node                 *common.AttestedNode `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *UnbanAgentResponse) Reset()         { *m = UnbanAgentResponse{} }
+func (m *UnbanAgentResponse) String() string { return proto.CompactTextString(m) }
+func (*UnbanAgentResponse) ProtoMessage()    {}
+func (*UnbanAgentResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{33}
+}
+
+func (m *UnbanAgentResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UnbanAgentResponse.Unmarshal(m, b)
+}
+func (m *UnbanAgentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UnbanAgentResponse.Marshal(b, m, deterministic)
+}
+func (m *UnbanAgentResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UnbanAgentResponse.Merge(m, src)
+}
+func (m *UnbanAgentResponse) XXX_Size() int {
+	return xxx_messageInfo_UnbanAgentResponse.Size(m)
+}
+func (m *UnbanAgentResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UnbanAgentResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UnbanAgentResponse proto.InternalMessageInfo
+
+func (m *UnbanAgentResponse) GetNode() *common.AttestedNode {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
+// Represents a CA slot of the server
+type CASlot struct {
+	// Slot identifier (e.g. "A" or "B")
+	SlotId string `protobuf:"bytes,1,opt,name=slot_id,json=slotId,proto3" json:"slot_id,omitempty"`
+	// State of the slot
+	State CASlot_State `protobuf:"varint,2,opt,name=state,proto3,enum=spire.api.registration.CASlot_State" json:"state,omitempty"`
+	// Authority identifier. For X509 CAs this is the hex encoded subject key
+	// ID of the CA certificate. For JWT keys this is the key ID.
+	AuthorityId string `protobuf:"bytes,3,opt,name=authority_id,json=authorityId,proto3" json:"authority_id,omitempty"`
+	// When the authority was prepared (seconds since unix epoch)
+	IssuedAt int64 `protobuf:"varint,4,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+	// When the authority expires (seconds since unix epoch)
+	ExpiresAt            int64    `protobuf:"varint,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CASlot) Reset()         { *m = CASlot{} }
+func (m *CASlot) String() string { return proto.CompactTextString(m) }
+func (*CASlot) ProtoMessage()    {}
+func (*CASlot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{34}
+}
+
+func (m *CASlot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CASlot.Unmarshal(m, b)
+}
+func (m *CASlot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CASlot.Marshal(b, m, deterministic)
+}
+func (m *CASlot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CASlot.Merge(m, src)
+}
+func (m *CASlot) XXX_Size() int {
+	return xxx_messageInfo_CASlot.Size(m)
+}
+func (m *CASlot) XXX_DiscardUnknown() {
+	xxx_messageInfo_CASlot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CASlot proto.InternalMessageInfo
+
+func (m *CASlot) GetSlotId() string {
+	if m != nil {
+		return m.SlotId
+	}
+	return ""
+}
+
+func (m *CASlot) GetState() CASlot_State {
+	if m != nil {
+		return m.State
+	}
+	return CASlot_EMPTY
+}
+
+func (m *CASlot) GetAuthorityId() string {
+	if m != nil {
+		return m.AuthorityId
+	}
+	return ""
+}
+
+func (m *CASlot) GetIssuedAt() int64 {
+	if m != nil {
+		return m.IssuedAt
+	}
+	return 0
+}
+
+func (m *CASlot) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+// Represents a ListCASlots request
+type ListCASlotsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListCASlotsRequest) Reset()         { *m = ListCASlotsRequest{} }
+func (m *ListCASlotsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListCASlotsRequest) ProtoMessage()    {}
+func (*ListCASlotsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{35}
+}
+
+func (m *ListCASlotsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCASlotsRequest.Unmarshal(m, b)
+}
+func (m *ListCASlotsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCASlotsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListCASlotsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCASlotsRequest.Merge(m, src)
+}
+func (m *ListCASlotsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListCASlotsRequest.Size(m)
+}
+func (m *ListCASlotsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCASlotsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCASlotsRequest proto.InternalMessageInfo
+
+// Represents a ListCASlots response
+type ListCASlotsResponse struct {
+	// Current and next X509 CA slots
+	X509Slots []*CASlot `protobuf:"bytes,1,rep,name=x509_slots,json=x509Slots,proto3" json:"x509_slots,omitempty"`
+	// Current and next JWT key slots
+	JwtSlots             []*CASlot `protobuf:"bytes,2,rep,name=jwt_slots,json=jwtSlots,proto3" json:"jwt_slots,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ListCASlotsResponse) Reset()         { *m = ListCASlotsResponse{} }
+func (m *ListCASlotsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListCASlotsResponse) ProtoMessage()    {}
+func (*ListCASlotsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{36}
+}
+
+func (m *ListCASlotsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListCASlotsResponse.Unmarshal(m, b)
+}
+func (m *ListCASlotsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListCASlotsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListCASlotsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListCASlotsResponse.Merge(m, src)
+}
+func (m *ListCASlotsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListCASlotsResponse.Size(m)
+}
+func (m *ListCASlotsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListCASlotsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListCASlotsResponse proto.InternalMessageInfo
+
+func (m *ListCASlotsResponse) GetX509Slots() []*CASlot {
+	if m != nil {
+		return m.X509Slots
+	}
+	return nil
+}
+
+func (m *ListCASlotsResponse) GetJwtSlots() []*CASlot {
+	if m != nil {
+		return m.JwtSlots
+	}
+	return nil
+}
+
+// Represents a PrepareCA request
+type PrepareCARequest struct {
+	// Kind of authority to prepare
+	Kind                 CAKind   `protobuf:"varint,1,opt,name=kind,proto3,enum=spire.api.registration.CAKind" json:"kind,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PrepareCARequest) Reset()         { *m = PrepareCARequest{} }
+func (m *PrepareCARequest) String() string { return proto.CompactTextString(m) }
+func (*PrepareCARequest) ProtoMessage()    {}
+func (*PrepareCARequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{37}
+}
+
+func (m *PrepareCARequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrepareCARequest.Unmarshal(m, b)
+}
+func (m *PrepareCARequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrepareCARequest.Marshal(b, m, deterministic)
+}
+func (m *PrepareCARequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrepareCARequest.Merge(m, src)
+}
+func (m *PrepareCARequest) XXX_Size() int {
+	return xxx_messageInfo_PrepareCARequest.Size(m)
+}
+func (m *PrepareCARequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrepareCARequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrepareCARequest proto.InternalMessageInfo
+
+func (m *PrepareCARequest) GetKind() CAKind {
+	if m != nil {
+		return m.Kind
+	}
+	return CAKind_X509_CA
+}
+
+// Represents a PrepareCA response
+type PrepareCAResponse struct {
+	// The slot holding the prepared authority
+	Slot                 *CASlot  `protobuf:"bytes,1,opt,name=slot,proto3" json:"slot,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PrepareCAResponse) Reset()         { *m = PrepareCAResponse{} }
+func (m *PrepareCAResponse) String() string { return proto.CompactTextString(m) }
+func (*PrepareCAResponse) ProtoMessage()    {}
+func (*PrepareCAResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{38}
+}
+
+func (m *PrepareCAResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PrepareCAResponse.Unmarshal(m, b)
+}
+func (m *PrepareCAResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PrepareCAResponse.Marshal(b, m, deterministic)
+}
+func (m *PrepareCAResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PrepareCAResponse.Merge(m, src)
+}
+func (m *PrepareCAResponse) XXX_Size() int {
+	return xxx_messageInfo_PrepareCAResponse.Size(m)
+}
+func (m *PrepareCAResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PrepareCAResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PrepareCAResponse proto.InternalMessageInfo
+
+func (m *PrepareCAResponse) GetSlot() *CASlot {
+	if m != nil {
+		return m.Slot
+	}
+	return nil
+}
+
+// Represents an ActivateCA request
+type ActivateCARequest struct {
+	// Kind of authority to activate
+	Kind                 CAKind   `protobuf:"varint,1,opt,name=kind,proto3,enum=spire.api.registration.CAKind" json:"kind,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ActivateCARequest) Reset()         { *m = ActivateCARequest{} }
+func (m *ActivateCARequest) String() string { return proto.CompactTextString(m) }
+func (*ActivateCARequest) ProtoMessage()    {}
+func (*ActivateCARequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{39}
+}
+
+func (m *ActivateCARequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ActivateCARequest.Unmarshal(m, b)
+}
+func (m *ActivateCARequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ActivateCARequest.Marshal(b, m, deterministic)
+}
+func (m *ActivateCARequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActivateCARequest.Merge(m, src)
+}
+func (m *ActivateCARequest) XXX_Size() int {
+	return xxx_messageInfo_ActivateCARequest.Size(m)
+}
+func (m *ActivateCARequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActivateCARequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ActivateCARequest proto.InternalMessageInfo
+
+func (m *ActivateCARequest) GetKind() CAKind {
+	if m != nil {
+		return m.Kind
+	}
+	return CAKind_X509_CA
+}
+
+// Represents an ActivateCA response
+type ActivateCAResponse struct {
+	// The slot holding the activated authority
+	Slot                 *CASlot  `protobuf:"bytes,1,opt,name=slot,proto3" json:"slot,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ActivateCAResponse) Reset()         { *m = ActivateCAResponse{} }
+func (m *ActivateCAResponse) String() string { return proto.CompactTextString(m) }
+func (*ActivateCAResponse) ProtoMessage()    {}
+func (*ActivateCAResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{40}
+}
+
+func (m *ActivateCAResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ActivateCAResponse.Unmarshal(m, b)
+}
+func (m *ActivateCAResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ActivateCAResponse.Marshal(b, m, deterministic)
+}
+func (m *ActivateCAResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActivateCAResponse.Merge(m, src)
+}
+func (m *ActivateCAResponse) XXX_Size() int {
+	return xxx_messageInfo_ActivateCAResponse.Size(m)
+}
+func (m *ActivateCAResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActivateCAResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ActivateCAResponse proto.InternalMessageInfo
+
+func (m *ActivateCAResponse) GetSlot() *CASlot {
+	if m != nil {
+		return m.Slot
+	}
+	return nil
+}
+
+// Represents a TaintCA request
+type TaintCARequest struct {
+	// Kind of authority to taint
+	Kind CAKind `protobuf:"varint,1,opt,name=kind,proto3,enum=spire.api.registration.CAKind" json:"kind,omitempty"`
+	// Identifier of the authority to taint (see CASlot.authority_id)
+	AuthorityId          string   `protobuf:"bytes,2,opt,name=authority_id,json=authorityId,proto3" json:"authority_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TaintCARequest) Reset()         { *m = TaintCARequest{} }
+func (m *TaintCARequest) String() string { return proto.CompactTextString(m) }
+func (*TaintCARequest) ProtoMessage()    {}
+func (*TaintCARequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{41}
+}
+
+func (m *TaintCARequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaintCARequest.Unmarshal(m, b)
+}
+func (m *TaintCARequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaintCARequest.Marshal(b, m, deterministic)
+}
+func (m *TaintCARequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaintCARequest.Merge(m, src)
+}
+func (m *TaintCARequest) XXX_Size() int {
+	return xxx_messageInfo_TaintCARequest.Size(m)
+}
+func (m *TaintCARequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaintCARequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaintCARequest proto.InternalMessageInfo
+
+func (m *TaintCARequest) GetKind() CAKind {
+	if m != nil {
+		return m.Kind
+	}
+	return CAKind_X509_CA
+}
+
+func (m *TaintCARequest) GetAuthorityId() string {
+	if m != nil {
+		return m.AuthorityId
+	}
+	return ""
+}
+
+// Represents a TaintCA response
+type TaintCAResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TaintCAResponse) Reset()         { *m = TaintCAResponse{} }
+func (m *TaintCAResponse) String() string { return proto.CompactTextString(m) }
+func (*TaintCAResponse) ProtoMessage()    {}
+func (*TaintCAResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{42}
+}
+
+func (m *TaintCAResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TaintCAResponse.Unmarshal(m, b)
+}
+func (m *TaintCAResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TaintCAResponse.Marshal(b, m, deterministic)
+}
+func (m *TaintCAResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TaintCAResponse.Merge(m, src)
+}
+func (m *TaintCAResponse) XXX_Size() int {
+	return xxx_messageInfo_TaintCAResponse.Size(m)
+}
+func (m *TaintCAResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TaintCAResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TaintCAResponse proto.InternalMessageInfo
+
+// Represents a record of an SVID signed by the server
+type IssuedSVID struct {
+	// Identifier of the SVID. For X509-SVIDs this is the serial number of the
+	// certificate (base 10 string). For JWT-SVIDs this is the "jti" claim.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Type of the SVID
+	Type IssuedSVID_Type `protobuf:"varint,2,opt,name=type,proto3,enum=spire.api.registration.IssuedSVID_Type" json:"type,omitempty"`
+	// SPIFFE ID of the SVID
+	SpiffeId string `protobuf:"bytes,3,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	// ID of the registration entry the SVID was issued for, if any
+	EntryId string `protobuf:"bytes,4,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	// SPIFFE ID of the agent that requested the SVID, if any
+	AgentId string `protobuf:"bytes,5,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// Identifier of the signing authority (see CASlot.authority_id)
+	AuthorityId string `protobuf:"bytes,6,opt,name=authority_id,json=authorityId,proto3" json:"authority_id,omitempty"`
+	// Time the SVID is valid from (seconds since unix epoch)
+	NotBefore int64 `protobuf:"varint,7,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	// Time the SVID expires (seconds since unix epoch)
+	NotAfter             int64    `protobuf:"varint,8,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IssuedSVID) Reset()         { *m = IssuedSVID{} }
+func (m *IssuedSVID) String() string { return proto.CompactTextString(m) }
+func (*IssuedSVID) ProtoMessage()    {}
+func (*IssuedSVID) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{43}
+}
+
+func (m *IssuedSVID) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IssuedSVID.Unmarshal(m, b)
+}
+func (m *IssuedSVID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IssuedSVID.Marshal(b, m, deterministic)
+}
+func (m *IssuedSVID) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IssuedSVID.Merge(m, src)
+}
+func (m *IssuedSVID) XXX_Size() int {
+	return xxx_messageInfo_IssuedSVID.Size(m)
+}
+func (m *IssuedSVID) XXX_DiscardUnknown() {
+	xxx_messageInfo_IssuedSVID.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IssuedSVID proto.InternalMessageInfo
+
+func (m *IssuedSVID) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *IssuedSVID) GetType() IssuedSVID_Type {
+	if m != nil {
+		return m.Type
+	}
+	return IssuedSVID_X509_SVID
+}
+
+func (m *IssuedSVID) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *IssuedSVID) GetEntryId() string {
+	if m != nil {
+		return m.EntryId
+	}
+	return ""
+}
+
+func (m *IssuedSVID) GetAgentId() string {
+	if m != nil {
+		return m.AgentId
+	}
+	return ""
+}
+
+func (m *IssuedSVID) GetAuthorityId() string {
+	if m != nil {
+		return m.AuthorityId
+	}
+	return ""
+}
+
+func (m *IssuedSVID) GetNotBefore() int64 {
+	if m != nil {
+		return m.NotBefore
+	}
+	return 0
+}
+
+func (m *IssuedSVID) GetNotAfter() int64 {
+	if m != nil {
+		return m.NotAfter
+	}
+	return 0
+}
+
+// Represents a ListIssuedSVIDs request. Filters that are set must all match.
+type ListIssuedSVIDsRequest struct {
+	// If set, only SVIDs for this SPIFFE ID are listed
+	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	// If set, only SVIDs requested by this agent are listed
+	AgentId string `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// If non-zero, only SVIDs issued at or after this time are listed
+	// (seconds since unix epoch)
+	IssuedAfter int64 `protobuf:"varint,3,opt,name=issued_after,json=issuedAfter,proto3" json:"issued_after,omitempty"`
+	// If non-zero, only SVIDs issued before this time are listed (seconds
+	// since unix epoch)
+	IssuedBefore         int64    `protobuf:"varint,4,opt,name=issued_before,json=issuedBefore,proto3" json:"issued_before,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListIssuedSVIDsRequest) Reset()         { *m = ListIssuedSVIDsRequest{} }
+func (m *ListIssuedSVIDsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListIssuedSVIDsRequest) ProtoMessage()    {}
+func (*ListIssuedSVIDsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{44}
+}
+
+func (m *ListIssuedSVIDsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListIssuedSVIDsRequest.Unmarshal(m, b)
+}
+func (m *ListIssuedSVIDsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListIssuedSVIDsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListIssuedSVIDsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListIssuedSVIDsRequest.Merge(m, src)
+}
+func (m *ListIssuedSVIDsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListIssuedSVIDsRequest.Size(m)
+}
+func (m *ListIssuedSVIDsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListIssuedSVIDsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListIssuedSVIDsRequest proto.InternalMessageInfo
+
+func (m *ListIssuedSVIDsRequest) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *ListIssuedSVIDsRequest) GetAgentId() string {
+	if m != nil {
+		return m.AgentId
+	}
+	return ""
+}
+
+func (m *ListIssuedSVIDsRequest) GetIssuedAfter() int64 {
+	if m != nil {
+		return m.IssuedAfter
+	}
+	return 0
+}
+
+func (m *ListIssuedSVIDsRequest) GetIssuedBefore() int64 {
+	if m != nil {
+		return m.IssuedBefore
+	}
+	return 0
+}
+
+// Represents a MintX509SVID request
+type MintX509SVIDRequest struct {
+	// SPIFFE ID of the SVID
+	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	// ASN.1 DER encoded certificate signing request. Only its public key is
+	// used.
+	Csr []byte `protobuf:"bytes,2,opt,name=csr,proto3" json:"csr,omitempty"`
+	// TTL of the SVID, in seconds. If zero, the server default is used. The
+	// TTL is capped by the server.
+	Ttl int32 `protobuf:"varint,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	// DNS names of the SVID. The first one is also used as the common name.
+	DnsNames             []string `protobuf:"bytes,4,rep,name=dns_names,json=dnsNames,proto3" json:"dns_names,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MintX509SVIDRequest) Reset()         { *m = MintX509SVIDRequest{} }
+func (m *MintX509SVIDRequest) String() string { return proto.CompactTextString(m) }
+func (*MintX509SVIDRequest) ProtoMessage()    {}
+func (*MintX509SVIDRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{45}
+}
+
+func (m *MintX509SVIDRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MintX509SVIDRequest.Unmarshal(m, b)
+}
+func (m *MintX509SVIDRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MintX509SVIDRequest.Marshal(b, m, deterministic)
+}
+func (m *MintX509SVIDRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MintX509SVIDRequest.Merge(m, src)
+}
+func (m *MintX509SVIDRequest) XXX_Size() int {
+	return xxx_messageInfo_MintX509SVIDRequest.Size(m)
+}
+func (m *MintX509SVIDRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MintX509SVIDRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MintX509SVIDRequest proto.InternalMessageInfo
+
+func (m *MintX509SVIDRequest) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *MintX509SVIDRequest) GetCsr() []byte {
+	if m != nil {
+		return m.Csr
+	}
+	return nil
+}
+
+func (m *MintX509SVIDRequest) GetTtl() int32 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
+}
+
+func (m *MintX509SVIDRequest) GetDnsNames() []string {
+	if m != nil {
+		return m.DnsNames
+	}
+	return nil
+}
+
+// Represents a MintX509SVID response
+type MintX509SVIDResponse struct {
+	// ASN.1 DER encoded certificate chain, starting with the SVID
+	SvidChain [][]byte `protobuf:"bytes,1,rep,name=svid_chain,json=svidChain,proto3" json:"svid_chain,omitempty"`
+	// ASN.1 DER encoded root CAs of the trust domain
+	RootCas              [][]byte `protobuf:"bytes,2,rep,name=root_cas,json=rootCas,proto3" json:"root_cas,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MintX509SVIDResponse) Reset()         { *m = MintX509SVIDResponse{} }
+func (m *MintX509SVIDResponse) String() string { return proto.CompactTextString(m) }
+func (*MintX509SVIDResponse) ProtoMessage()    {}
+func (*MintX509SVIDResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{46}
+}
+
+func (m *MintX509SVIDResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MintX509SVIDResponse.Unmarshal(m, b)
+}
+func (m *MintX509SVIDResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MintX509SVIDResponse.Marshal(b, m, deterministic)
+}
+func (m *MintX509SVIDResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MintX509SVIDResponse.Merge(m, src)
+}
+func (m *MintX509SVIDResponse) XXX_Size() int {
+	return xxx_messageInfo_MintX509SVIDResponse.Size(m)
+}
+func (m *MintX509SVIDResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MintX509SVIDResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MintX509SVIDResponse proto.InternalMessageInfo
+
+func (m *MintX509SVIDResponse) GetSvidChain() [][]byte {
+	if m != nil {
+		return m.SvidChain
+	}
+	return nil
+}
+
+func (m *MintX509SVIDResponse) GetRootCas() [][]byte {
+	if m != nil {
+		return m.RootCas
+	}
+	return nil
+}
+
+// Represents a MintJWTSVID request
+type MintJWTSVIDRequest struct {
+	// SPIFFE ID of the SVID
+	SpiffeId string `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	// Audience of the SVID. At least one is required.
+	Audience []string `protobuf:"bytes,2,rep,name=audience,proto3" json:"audience,omitempty"`
+	// TTL of the SVID, in seconds. If zero, the server default is used. The
+	// TTL is capped by the server.
+	Ttl                  int32    `protobuf:"varint,3,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MintJWTSVIDRequest) Reset()         { *m = MintJWTSVIDRequest{} }
+func (m *MintJWTSVIDRequest) String() string { return proto.CompactTextString(m) }
+func (*MintJWTSVIDRequest) ProtoMessage()    {}
+func (*MintJWTSVIDRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{47}
+}
+
+func (m *MintJWTSVIDRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MintJWTSVIDRequest.Unmarshal(m, b)
+}
+func (m *MintJWTSVIDRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MintJWTSVIDRequest.Marshal(b, m, deterministic)
+}
+func (m *MintJWTSVIDRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MintJWTSVIDRequest.Merge(m, src)
 }
-func (m *EvictAgentRequest) XXX_Size() int {
-	return xxx_messageInfo_EvictAgentRequest.Size(m)
+func (m *MintJWTSVIDRequest) XXX_Size() int {
+	return xxx_messageInfo_MintJWTSVIDRequest.Size(m)
 }
-func (m *EvictAgentRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_EvictAgentRequest.DiscardUnknown(m)
+func (m *MintJWTSVIDRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MintJWTSVIDRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_EvictAgentRequest proto.InternalMessageInfo
+var xxx_messageInfo_MintJWTSVIDRequest proto.InternalMessageInfo
 
-func (m *EvictAgentRequest) GetSpiffeID() string {
+func (m *MintJWTSVIDRequest) GetSpiffeId() string {
 	if m != nil {
-		return m.SpiffeID
+		return m.SpiffeId
 	}
 	return ""
 }
 
-// Represents an evict response
-type EvictAgentResponse struct {
-	// Node contains the evicted node
-	Node                 *common.AttestedNode `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+func (m *MintJWTSVIDRequest) GetAudience() []string {
+	if m != nil {
+		return m.Audience
+	}
+	return nil
 }
 
-func (m *EvictAgentResponse) Reset()         { *m = EvictAgentResponse{} }
-func (m *EvictAgentResponse) String() string { return proto.CompactTextString(m) }
-func (*EvictAgentResponse) ProtoMessage()    {}
-func (*EvictAgentResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_199f7aef77c18626, []int{12}
+func (m *MintJWTSVIDRequest) GetTtl() int32 {
+	if m != nil {
+		return m.Ttl
+	}
+	return 0
 }
 
-func (m *EvictAgentResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_EvictAgentResponse.Unmarshal(m, b)
+// Represents a MintJWTSVID response
+type MintJWTSVIDResponse struct {
+	// The JWT-SVID
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
-func (m *EvictAgentResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_EvictAgentResponse.Marshal(b, m, deterministic)
+
+func (m *MintJWTSVIDResponse) Reset()         { *m = MintJWTSVIDResponse{} }
+func (m *MintJWTSVIDResponse) String() string { return proto.CompactTextString(m) }
+func (*MintJWTSVIDResponse) ProtoMessage()    {}
+func (*MintJWTSVIDResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_199f7aef77c18626, []int{48}
 }
-func (m *EvictAgentResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_EvictAgentResponse.Merge(m, src)
+
+func (m *MintJWTSVIDResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MintJWTSVIDResponse.Unmarshal(m, b)
 }
-func (m *EvictAgentResponse) XXX_Size() int {
-	return xxx_messageInfo_EvictAgentResponse.Size(m)
+func (m *MintJWTSVIDResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MintJWTSVIDResponse.Marshal(b, m, deterministic)
 }
-func (m *EvictAgentResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_EvictAgentResponse.DiscardUnknown(m)
+func (m *MintJWTSVIDResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MintJWTSVIDResponse.Merge(m, src)
+}
+func (m *MintJWTSVIDResponse) XXX_Size() int {
+	return xxx_messageInfo_MintJWTSVIDResponse.Size(m)
+}
+func (m *MintJWTSVIDResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MintJWTSVIDResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_EvictAgentResponse proto.InternalMessageInfo
+var xxx_messageInfo_MintJWTSVIDResponse proto.InternalMessageInfo
 
-func (m *EvictAgentResponse) GetNode() *common.AttestedNode {
+func (m *MintJWTSVIDResponse) GetToken() string {
 	if m != nil {
-		return m.Node
+		return m.Token
 	}
-	return nil
+	return ""
 }
 
 func init() {
+	proto.RegisterEnum("spire.api.registration.CAKind", CAKind_name, CAKind_value)
+	proto.RegisterEnum("spire.api.registration.ListEntriesRequest_SelectorMatch", ListEntriesRequest_SelectorMatch_name, ListEntriesRequest_SelectorMatch_value)
+	proto.RegisterEnum("spire.api.registration.EntryEvent_Type", EntryEvent_Type_name, EntryEvent_Type_value)
 	proto.RegisterEnum("spire.api.registration.DeleteFederatedBundleRequest_Mode", DeleteFederatedBundleRequest_Mode_name, DeleteFederatedBundleRequest_Mode_value)
+	proto.RegisterEnum("spire.api.registration.CASlot_State", CASlot_State_name, CASlot_State_value)
+	proto.RegisterEnum("spire.api.registration.IssuedSVID_Type", IssuedSVID_Type_name, IssuedSVID_Type_value)
 	proto.RegisterType((*RegistrationEntryID)(nil), "spire.api.registration.RegistrationEntryID")
 	proto.RegisterType((*ParentID)(nil), "spire.api.registration.ParentID")
 	proto.RegisterType((*SpiffeID)(nil), "spire.api.registration.SpiffeID")
 	proto.RegisterType((*UpdateEntryRequest)(nil), "spire.api.registration.UpdateEntryRequest")
+	proto.RegisterType((*ListEntriesRequest)(nil), "spire.api.registration.ListEntriesRequest")
+	proto.RegisterType((*ListEntriesResponse)(nil), "spire.api.registration.ListEntriesResponse")
+	proto.RegisterType((*BatchEntryResult)(nil), "spire.api.registration.BatchEntryResult")
+	proto.RegisterType((*BatchCreateEntryRequest)(nil), "spire.api.registration.BatchCreateEntryRequest")
+	proto.RegisterType((*BatchCreateEntryResponse)(nil), "spire.api.registration.BatchCreateEntryResponse")
+	proto.RegisterType((*BatchUpdateEntryRequest)(nil), "spire.api.registration.BatchUpdateEntryRequest")
+	proto.RegisterType((*BatchUpdateEntryResponse)(nil), "spire.api.registration.BatchUpdateEntryResponse")
+	proto.RegisterType((*BatchDeleteEntryRequest)(nil), "spire.api.registration.BatchDeleteEntryRequest")
+	proto.RegisterType((*BatchDeleteEntryResponse)(nil), "spire.api.registration.BatchDeleteEntryResponse")
+	proto.RegisterType((*WatchEntriesRequest)(nil), "spire.api.registration.WatchEntriesRequest")
+	proto.RegisterType((*EntryEvent)(nil), "spire.api.registration.EntryEvent")
 	proto.RegisterType((*FederatedBundle)(nil), "spire.api.registration.FederatedBundle")
 	proto.RegisterType((*FederatedBundleID)(nil), "spire.api.registration.FederatedBundleID")
 	proto.RegisterType((*DeleteFederatedBundleRequest)(nil), "spire.api.registration.DeleteFederatedBundleRequest")
 	proto.RegisterType((*JoinToken)(nil), "spire.api.registration.JoinToken")
+	proto.RegisterType((*ListJoinTokensRequest)(nil), "spire.api.registration.ListJoinTokensRequest")
+	proto.RegisterType((*ListJoinTokensResponse)(nil), "spire.api.registration.ListJoinTokensResponse")
+	proto.RegisterType((*DeleteJoinTokenRequest)(nil), "spire.api.registration.DeleteJoinTokenRequest")
+	proto.RegisterType((*DeleteJoinTokenResponse)(nil), "spire.api.registration.DeleteJoinTokenResponse")
 	proto.RegisterType((*Bundle)(nil), "spire.api.registration.Bundle")
 	proto.RegisterType((*ListAgentsRequest)(nil), "spire.api.registration.ListAgentsRequest")
 	proto.RegisterType((*ListAgentsResponse)(nil), "spire.api.registration.ListAgentsResponse")
+	proto.RegisterType((*FetchAgentRequest)(nil), "spire.api.registration.FetchAgentRequest")
+	proto.RegisterType((*FetchAgentResponse)(nil), "spire.api.registration.FetchAgentResponse")
 	proto.RegisterType((*EvictAgentRequest)(nil), "spire.api.registration.EvictAgentRequest")
 	proto.RegisterType((*EvictAgentResponse)(nil), "spire.api.registration.EvictAgentResponse")
+	proto.RegisterType((*BanAgentRequest)(nil), "spire.api.registration.BanAgentRequest")
+	proto.RegisterType((*BanAgentResponse)(nil), "spire.api.registration.BanAgentResponse")
+	proto.RegisterType((*UnbanAgentRequest)(nil), "spire.api.registration.UnbanAgentRequest")
+	proto.RegisterType((*UnbanAgentResponse)(nil), "spire.api.registration.UnbanAgentResponse")
+	proto.RegisterType((*CASlot)(nil), "spire.api.registration.CASlot")
+	proto.RegisterType((*ListCASlotsRequest)(nil), "spire.api.registration.ListCASlotsRequest")
+	proto.RegisterType((*ListCASlotsResponse)(nil), "spire.api.registration.ListCASlotsResponse")
+	proto.RegisterType((*PrepareCARequest)(nil), "spire.api.registration.PrepareCARequest")
+	proto.RegisterType((*PrepareCAResponse)(nil), "spire.api.registration.PrepareCAResponse")
+	proto.RegisterType((*ActivateCARequest)(nil), "spire.api.registration.ActivateCARequest")
+	proto.RegisterType((*ActivateCAResponse)(nil), "spire.api.registration.ActivateCAResponse")
+	proto.RegisterType((*TaintCARequest)(nil), "spire.api.registration.TaintCARequest")
+	proto.RegisterType((*TaintCAResponse)(nil), "spire.api.registration.TaintCAResponse")
+	proto.RegisterType((*IssuedSVID)(nil), "spire.api.registration.IssuedSVID")
+	proto.RegisterType((*ListIssuedSVIDsRequest)(nil), "spire.api.registration.ListIssuedSVIDsRequest")
+	proto.RegisterType((*MintX509SVIDRequest)(nil), "spire.api.registration.MintX509SVIDRequest")
+	proto.RegisterType((*MintX509SVIDResponse)(nil), "spire.api.registration.MintX509SVIDResponse")
+	proto.RegisterType((*MintJWTSVIDRequest)(nil), "spire.api.registration.MintJWTSVIDRequest")
+	proto.RegisterType((*MintJWTSVIDResponse)(nil), "spire.api.registration.MintJWTSVIDResponse")
 }
 
 func init() { proto.RegisterFile("registration.proto", fileDescriptor_199f7aef77c18626) }
 
 var fileDescriptor_199f7aef77c18626 = []byte{
-	// 726 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x96, 0x61, 0x4f, 0xda, 0x5c,
-	0x14, 0xc7, 0x9f, 0xa2, 0x10, 0x3d, 0x10, 0xc4, 0x23, 0xfa, 0xf0, 0x34, 0x4f, 0x36, 0xec, 0xb2,
-	0xcc, 0xb9, 0xa5, 0x18, 0x74, 0x26, 0x7b, 0xb5, 0x88, 0x94, 0x84, 0xa9, 0xdb, 0x52, 0x70, 0x4b,
-	0xf4, 0x15, 0xd0, 0xa3, 0x76, 0x83, 0xb6, 0x6b, 0xaf, 0x4b, 0xfc, 0x54, 0xfb, 0x28, 0xfb, 0x4a,
-	0x4b, 0x7b, 0x6f, 0xb1, 0x94, 0x56, 0xba, 0x65, 0x7b, 0x45, 0x7b, 0xef, 0xff, 0xfc, 0xee, 0xff,
-	0x9e, 0x7b, 0xb8, 0xa7, 0x80, 0x2e, 0x5d, 0x9b, 0x1e, 0x73, 0x07, 0xcc, 0xb4, 0x2d, 0xd5, 0x71,
-	0x6d, 0x66, 0xe3, 0x96, 0xe7, 0x98, 0x2e, 0xa9, 0x03, 0xc7, 0x54, 0xa3, 0xb3, 0xf2, 0x7f, 0xc1,
-	0x78, 0x63, 0x64, 0x4f, 0x26, 0xb6, 0x25, 0x7e, 0x78, 0x88, 0xf2, 0x14, 0x36, 0xf4, 0x88, 0x54,
-	0xb3, 0x98, 0x7b, 0xd7, 0x6d, 0x63, 0x19, 0x72, 0xa6, 0x51, 0x93, 0xea, 0xd2, 0xce, 0xaa, 0x9e,
-	0x33, 0x0d, 0x45, 0x86, 0x95, 0x0f, 0x03, 0x97, 0x2c, 0x96, 0x3c, 0xd7, 0x73, 0xcc, 0xab, 0x2b,
-	0x4a, 0x98, 0x3b, 0x01, 0x3c, 0x77, 0x8c, 0x01, 0xa3, 0x00, 0xac, 0xd3, 0xd7, 0x5b, 0xf2, 0x18,
-	0xbe, 0x82, 0x3c, 0xf9, 0xef, 0x81, 0xb0, 0xd8, 0x7c, 0xac, 0x72, 0xdf, 0xc2, 0xd8, 0x9c, 0x1f,
-	0x9d, 0xab, 0x95, 0x37, 0xb0, 0xd6, 0x21, 0x83, 0xdc, 0x01, 0x23, 0xa3, 0x75, 0x6b, 0x19, 0x63,
-	0xc2, 0x97, 0x50, 0x18, 0x06, 0x4f, 0xb5, 0xa5, 0x00, 0x55, 0x9d, 0x45, 0x71, 0x95, 0x2e, 0x34,
-	0xca, 0x13, 0x58, 0x8f, 0x01, 0x12, 0x2c, 0x7f, 0x97, 0xe0, 0xff, 0x36, 0x8d, 0x89, 0x51, 0x4c,
-	0x1b, 0xba, 0x8f, 0x05, 0xe0, 0x19, 0x2c, 0x4f, 0x6c, 0x83, 0x6a, 0xb9, 0xba, 0xb4, 0x53, 0x6e,
-	0xbe, 0x56, 0x93, 0x0f, 0x41, 0x7d, 0x88, 0xa9, 0x9e, 0xd9, 0x06, 0xe9, 0x01, 0x46, 0xd9, 0x83,
-	0x65, 0xff, 0x0d, 0x4b, 0xb0, 0xa2, 0x6b, 0xbd, 0xbe, 0xde, 0x3d, 0xee, 0x57, 0xfe, 0x41, 0x80,
-	0x42, 0x5b, 0x3b, 0xd5, 0xfa, 0x5a, 0x45, 0xc2, 0x32, 0x40, 0xbb, 0xdb, 0xeb, 0xbd, 0x3f, 0xee,
-	0x1e, 0xf5, 0xb5, 0x4a, 0x4e, 0xd9, 0x87, 0xd5, 0xb7, 0xb6, 0x69, 0xf5, 0xed, 0x2f, 0x64, 0x61,
-	0x15, 0xf2, 0xcc, 0x7f, 0x10, 0x06, 0xf9, 0x0b, 0x56, 0x60, 0x89, 0xb1, 0x71, 0x60, 0x31, 0xaf,
-	0xfb, 0x8f, 0xca, 0x21, 0x14, 0xe6, 0x72, 0x98, 0xcb, 0x90, 0xc3, 0x0d, 0x58, 0x3f, 0x35, 0x3d,
-	0x76, 0x74, 0x4d, 0x16, 0xf3, 0x84, 0x7d, 0xa5, 0x03, 0x18, 0x1d, 0xf4, 0x1c, 0xdb, 0xf2, 0x08,
-	0xf7, 0x20, 0x6f, 0xd9, 0x06, 0x79, 0x35, 0xa9, 0xbe, 0xb4, 0x53, 0x6c, 0xca, 0xb3, 0xdc, 0x23,
-	0xc6, 0xc8, 0x63, 0x64, 0xbc, 0xf3, 0xb7, 0xce, 0x85, 0x4a, 0x03, 0xd6, 0xb5, 0x6f, 0xe6, 0x88,
-	0x83, 0xc2, 0x7c, 0xcb, 0xb0, 0xe2, 0x89, 0xfa, 0x12, 0x9b, 0x9a, 0xbe, 0x2b, 0x6d, 0xc0, 0x68,
-	0x80, 0x58, 0x58, 0x85, 0x65, 0x9f, 0x27, 0xca, 0xeb, 0xa1, 0x75, 0x03, 0x5d, 0xf3, 0x47, 0x09,
-	0x4a, 0xd1, 0xaa, 0xc3, 0x4b, 0x28, 0x1e, 0xbb, 0x14, 0x96, 0x2d, 0x2e, 0x2a, 0x50, 0xf9, 0x45,
-	0xda, 0xa1, 0x27, 0xfd, 0xb7, 0x2e, 0xa1, 0xc8, 0x6b, 0x81, 0xc3, 0x7f, 0x25, 0x56, 0x5e, 0xe4,
-	0x04, 0x2f, 0x00, 0x3a, 0xc4, 0x46, 0x37, 0x7f, 0x83, 0xdd, 0x81, 0xd2, 0x94, 0x6d, 0x92, 0x87,
-	0x1b, 0xb3, 0x01, 0xda, 0xc4, 0x61, 0x77, 0xf2, 0xf6, 0xc3, 0x14, 0x3f, 0xee, 0x02, 0x8a, 0x91,
-	0x4b, 0x01, 0x77, 0xd3, 0x4c, 0xce, 0xdf, 0x1c, 0x8b, 0x3d, 0x9e, 0x43, 0xd9, 0xaf, 0xc4, 0xd6,
-	0xdd, 0xf4, 0xba, 0xaa, 0xa7, 0xe1, 0x43, 0x45, 0x16, 0xcb, 0x27, 0x21, 0xb6, 0x47, 0x63, 0x1a,
-	0x31, 0xdb, 0xc5, 0xad, 0xd9, 0xa0, 0x70, 0x3c, 0x0b, 0xec, 0x0c, 0xd6, 0x66, 0x61, 0x1e, 0xfe,
-	0x9b, 0x4c, 0xf3, 0xb2, 0xe0, 0xa6, 0x5b, 0x9e, 0xde, 0xc2, 0xa9, 0x5b, 0x0e, 0x15, 0xd9, 0xb0,
-	0x9b, 0xfc, 0x3f, 0x10, 0xbf, 0x73, 0x9f, 0xa5, 0xd1, 0x63, 0x42, 0x39, 0xa9, 0x3e, 0xf0, 0x33,
-	0x54, 0x83, 0x22, 0x8a, 0x53, 0x9f, 0x67, 0xa4, 0x76, 0xdb, 0x72, 0x56, 0x03, 0xf8, 0x11, 0xaa,
-	0x7e, 0x66, 0x62, 0xc3, 0x29, 0x85, 0x9b, 0x95, 0xba, 0x27, 0xf9, 0xa9, 0xe1, 0xb5, 0xf9, 0x67,
-	0x53, 0x33, 0x84, 0xcd, 0xc4, 0x26, 0x81, 0x07, 0xbf, 0xd3, 0x53, 0x92, 0xd7, 0xf8, 0x04, 0x6b,
-	0xfc, 0x54, 0xef, 0x3b, 0xc6, 0x76, 0x1a, 0x7d, 0x2a, 0x91, 0x17, 0x4b, 0xb0, 0x05, 0xc5, 0xe0,
-	0x5c, 0x85, 0xe5, 0xc4, 0x14, 0x3f, 0x4a, 0xc3, 0x88, 0xa0, 0x11, 0xc0, 0xfd, 0x6d, 0x9e, 0x5e,
-	0x11, 0x73, 0x2d, 0x42, 0xde, 0xcd, 0x22, 0x15, 0xcd, 0x61, 0x04, 0x70, 0xdf, 0xab, 0xd2, 0x17,
-	0x99, 0x6b, 0x72, 0xe9, 0x8b, 0xcc, 0xb7, 0xbe, 0xd6, 0xe1, 0xc5, 0xc1, 0xb5, 0xc9, 0x6e, 0x6e,
-	0x87, 0x7e, 0x02, 0x1a, 0xbc, 0x5d, 0x35, 0xf8, 0x57, 0x58, 0xf0, 0xdd, 0x25, 0x9e, 0x07, 0x8e,
-	0xd9, 0x88, 0xa2, 0x86, 0x85, 0x60, 0x76, 0xff, 0x67, 0x00, 0x00, 0x00, 0xff, 0xff, 0xef, 0x0c,
-	0xc4, 0xc8, 0xde, 0x09, 0x00, 0x00,
+	// 2390 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x3a, 0xdd, 0x76, 0x13, 0xc9,
+	0xd1, 0xe8, 0x5f, 0x2a, 0xcb, 0xb2, 0xdc, 0x36, 0x46, 0x0c, 0x1f, 0xfb, 0x99, 0x61, 0x7f, 0x8c,
+	0x61, 0x65, 0x1d, 0x07, 0x36, 0x61, 0xf7, 0xec, 0x49, 0x64, 0x49, 0x64, 0x05, 0x6b, 0x50, 0x46,
+	0x32, 0xec, 0x42, 0x12, 0x65, 0xac, 0x69, 0xcb, 0x03, 0xd2, 0x8c, 0x32, 0xd3, 0xc2, 0x36, 0xd7,
+	0x79, 0x81, 0xbd, 0xc9, 0xc9, 0x5b, 0xe4, 0x1d, 0xf2, 0x0c, 0x79, 0x8a, 0x5c, 0xe7, 0x3e, 0x39,
+	0xfd, 0x33, 0x3f, 0x9a, 0xd1, 0x48, 0x83, 0x21, 0x39, 0xb9, 0xd2, 0x74, 0x75, 0xfd, 0x77, 0x55,
+	0x75, 0x75, 0x1d, 0x01, 0xb2, 0xf0, 0x50, 0xb7, 0x89, 0xa5, 0x12, 0xdd, 0x34, 0xaa, 0x13, 0xcb,
+	0x24, 0x26, 0xda, 0xb2, 0x27, 0xba, 0x85, 0xab, 0xea, 0x44, 0xaf, 0xfa, 0x77, 0xa5, 0x4f, 0x86,
+	0xa6, 0x39, 0x1c, 0xe1, 0x3d, 0x86, 0x75, 0x3c, 0x3d, 0xd9, 0x3b, 0xb3, 0xd4, 0xc9, 0x04, 0x5b,
+	0x36, 0xa7, 0x93, 0xae, 0x33, 0xba, 0xbd, 0x81, 0x39, 0x1e, 0x9b, 0x86, 0xf8, 0xe1, 0x5b, 0xf2,
+	0x67, 0xb0, 0xa1, 0xf8, 0x58, 0xb5, 0x0c, 0x62, 0x5d, 0xb4, 0x9b, 0xa8, 0x04, 0x49, 0x5d, 0xab,
+	0x24, 0xb6, 0x13, 0x3b, 0x05, 0x25, 0xa9, 0x6b, 0xb2, 0x04, 0xf9, 0x8e, 0x6a, 0x61, 0x83, 0xcc,
+	0xdf, 0xeb, 0x4e, 0xf4, 0x93, 0x13, 0x3c, 0x67, 0xef, 0x4f, 0x09, 0x40, 0x47, 0x13, 0x4d, 0x25,
+	0x98, 0x71, 0x56, 0xf0, 0x1f, 0xa7, 0xd8, 0x26, 0xe8, 0x01, 0x64, 0x30, 0x5d, 0x33, 0xcc, 0x95,
+	0xfd, 0xff, 0xaf, 0x72, 0xc3, 0x84, 0x66, 0x21, 0x85, 0x14, 0x8e, 0x8d, 0x7e, 0x0e, 0xe9, 0xb1,
+	0x6a, 0xbf, 0xa9, 0x24, 0x19, 0xd5, 0xed, 0x25, 0x54, 0x87, 0xaa, 0xfd, 0x46, 0x61, 0x04, 0xf2,
+	0x9f, 0xd3, 0x80, 0xbe, 0xd7, 0x6d, 0x42, 0xe1, 0x3a, 0xb6, 0x1d, 0x35, 0x6e, 0x40, 0x61, 0xc2,
+	0xac, 0xea, 0xbb, 0x4a, 0xe7, 0x39, 0xa0, 0xad, 0xd1, 0x4d, 0x9b, 0x99, 0x45, 0x37, 0x93, 0x7c,
+	0x93, 0x03, 0xda, 0x1a, 0xda, 0x81, 0xb2, 0xbb, 0xd9, 0x9f, 0x58, 0xf8, 0x44, 0x3f, 0xaf, 0xa4,
+	0x18, 0x4e, 0xc9, 0xc1, 0xe9, 0x30, 0x28, 0xba, 0x0f, 0x05, 0x1b, 0x8f, 0xf0, 0x80, 0x98, 0x96,
+	0x5d, 0x49, 0x6f, 0xa7, 0x76, 0x56, 0xf6, 0xb7, 0x66, 0x15, 0xef, 0x8a, 0x6d, 0xc5, 0x43, 0x44,
+	0x7d, 0x28, 0x39, 0x8b, 0xfe, 0x58, 0x25, 0x83, 0xd3, 0x4a, 0x66, 0x3b, 0xb1, 0x53, 0xda, 0xff,
+	0x45, 0x75, 0x7e, 0x08, 0x54, 0xc3, 0xd6, 0xb9, 0x7c, 0x0f, 0x29, 0xbd, 0xb2, 0x6a, 0xfb, 0x97,
+	0xe8, 0x33, 0x28, 0x9d, 0x60, 0x0d, 0x5b, 0x2a, 0xc1, 0x76, 0xff, 0x4c, 0x27, 0xa7, 0x95, 0xec,
+	0x76, 0x6a, 0xa7, 0xa0, 0xac, 0xba, 0xd0, 0x17, 0x3a, 0x39, 0x45, 0x5f, 0x03, 0x68, 0xe6, 0x99,
+	0x61, 0x13, 0x0b, 0xab, 0xe3, 0x4a, 0x8e, 0xf9, 0x5d, 0xaa, 0xf2, 0x70, 0xab, 0x3a, 0xe1, 0x56,
+	0x3d, 0x30, 0xcd, 0xd1, 0x73, 0x75, 0x34, 0xc5, 0x8a, 0x0f, 0x1b, 0xd5, 0x20, 0xa3, 0x6a, 0x63,
+	0xdd, 0xa8, 0xe4, 0x97, 0x92, 0x71, 0x44, 0x74, 0x13, 0x60, 0xa2, 0x0e, 0x71, 0x9f, 0x98, 0x6f,
+	0xb0, 0x51, 0x29, 0x30, 0x7f, 0x16, 0x28, 0xa4, 0x47, 0x01, 0xfc, 0xb8, 0x86, 0xb8, 0x6f, 0xeb,
+	0xef, 0x70, 0x05, 0xb6, 0x13, 0x3b, 0x19, 0x7a, 0x5c, 0x43, 0xdc, 0xd5, 0xdf, 0x61, 0xf9, 0x3e,
+	0xac, 0xce, 0x18, 0x8c, 0x8a, 0x90, 0xef, 0x1e, 0x75, 0x5a, 0x4a, 0xb7, 0xd5, 0x2b, 0x5f, 0x41,
+	0x00, 0xd9, 0xee, 0xd1, 0x01, 0xfd, 0x4e, 0xa0, 0x02, 0x64, 0x5a, 0x3f, 0xd4, 0x1b, 0xbd, 0x72,
+	0x52, 0x3e, 0x87, 0x8d, 0x19, 0xcf, 0xd9, 0x13, 0xd3, 0xb0, 0x31, 0x7a, 0x08, 0x39, 0xcc, 0x41,
+	0x95, 0xc4, 0x76, 0x2a, 0x4e, 0x84, 0x3a, 0xf8, 0xe8, 0x73, 0x58, 0x33, 0xf0, 0x39, 0xe9, 0xfb,
+	0x0c, 0xe1, 0xc1, 0xb3, 0x4a, 0xc1, 0x1d, 0xc7, 0x18, 0xf9, 0x0c, 0xca, 0x07, 0x54, 0x4f, 0x4e,
+	0x8e, 0xed, 0xe9, 0x88, 0x20, 0x04, 0xe9, 0x81, 0xa9, 0x61, 0x16, 0x8a, 0x19, 0x85, 0x7d, 0xa3,
+	0x0a, 0xe4, 0xc6, 0xd8, 0xb6, 0xd5, 0x21, 0x16, 0x7c, 0x9c, 0xa5, 0x97, 0x44, 0xa9, 0xf7, 0x49,
+	0x22, 0xf9, 0x1d, 0x5c, 0x63, 0x82, 0x1b, 0x16, 0x0e, 0xa6, 0xe5, 0x07, 0x98, 0xfd, 0x29, 0x94,
+	0xd4, 0xd1, 0xa8, 0x6f, 0x5a, 0x7d, 0xc3, 0x24, 0xa7, 0xba, 0x31, 0x64, 0xda, 0xe6, 0x95, 0xa2,
+	0x3a, 0x1a, 0x3d, 0xb3, 0x9e, 0x72, 0x98, 0xfc, 0x7b, 0xa8, 0x84, 0x65, 0x0b, 0x9f, 0x1f, 0x40,
+	0xce, 0x62, 0x6e, 0x70, 0x84, 0xef, 0x44, 0xc5, 0x7a, 0xd0, 0x6f, 0x8a, 0x43, 0xe8, 0xda, 0x36,
+	0xa7, 0xe4, 0xfc, 0xd7, 0x6c, 0x9b, 0x91, 0xfd, 0x11, 0x6d, 0xfb, 0x8d, 0xb0, 0xad, 0x89, 0x47,
+	0x38, 0x60, 0x5b, 0x19, 0x52, 0xba, 0xc6, 0x59, 0x17, 0x14, 0xfa, 0xf9, 0x9e, 0x2a, 0xcf, 0xb0,
+	0xfc, 0x88, 0x2a, 0x7f, 0x09, 0x1b, 0x2f, 0x9c, 0x4d, 0x5f, 0xd9, 0xdd, 0x82, 0xec, 0x60, 0x6a,
+	0xd9, 0xa6, 0x25, 0x6a, 0xae, 0x58, 0xc9, 0xff, 0x4a, 0x00, 0x30, 0x3e, 0xad, 0xb7, 0xd8, 0x20,
+	0xe8, 0x1b, 0x48, 0x93, 0x8b, 0x09, 0xcf, 0x86, 0xd2, 0xfe, 0x17, 0x51, 0xe2, 0x3d, 0x8a, 0x6a,
+	0xef, 0x62, 0x82, 0x15, 0x46, 0x84, 0xae, 0x43, 0x9e, 0x85, 0xbb, 0x57, 0xbc, 0xd9, 0x71, 0x5e,
+	0xb4, 0xb5, 0x4b, 0xe6, 0x8d, 0x4f, 0xeb, 0xf4, 0x8c, 0xd6, 0x8f, 0x21, 0x4d, 0xe5, 0xb2, 0x7a,
+	0xf3, 0xb4, 0xde, 0xe9, 0x7e, 0xf7, 0x8c, 0xd6, 0x9b, 0x32, 0x14, 0x9d, 0x55, 0xbf, 0xf5, 0xb4,
+	0x59, 0x4e, 0xd0, 0x0a, 0xd4, 0x50, 0x5a, 0xf5, 0x5e, 0xab, 0x9c, 0xa4, 0xdf, 0x47, 0x9d, 0x26,
+	0xfd, 0x4e, 0xd1, 0xef, 0x66, 0xeb, 0xfb, 0x56, 0xaf, 0x55, 0x4e, 0xcb, 0xbf, 0x84, 0xb5, 0x47,
+	0xa2, 0xfe, 0x6a, 0x07, 0x53, 0x43, 0x1b, 0x61, 0x74, 0x0f, 0xb2, 0xc7, 0xec, 0x4b, 0xa8, 0xbb,
+	0x39, 0xab, 0x2e, 0xc7, 0x52, 0x04, 0x8e, 0x7c, 0x1b, 0xd6, 0x03, 0x0c, 0xe6, 0x5c, 0xca, 0x7f,
+	0x4d, 0xc0, 0xff, 0xf1, 0x23, 0x0f, 0xe0, 0x3a, 0x07, 0x14, 0x20, 0x40, 0x87, 0x90, 0x1e, 0xd3,
+	0xba, 0x94, 0x64, 0x27, 0xf1, 0x30, 0xea, 0x24, 0x16, 0xf1, 0xac, 0x1e, 0x9a, 0x1a, 0x56, 0x18,
+	0x1b, 0xb9, 0x06, 0x69, 0xba, 0xa2, 0x1e, 0x53, 0x5a, 0xdd, 0x9e, 0xd2, 0x6e, 0x88, 0x0a, 0x2d,
+	0xfc, 0x90, 0x40, 0x25, 0x80, 0x66, 0xbb, 0xdb, 0x7d, 0xd6, 0x68, 0x33, 0x7f, 0xc9, 0xff, 0x4c,
+	0x40, 0xe1, 0xb1, 0xa9, 0x1b, 0xfc, 0x1e, 0xd8, 0x84, 0x0c, 0x2f, 0xac, 0x5c, 0x43, 0xbe, 0xa0,
+	0x49, 0x40, 0xc8, 0x88, 0xe9, 0x98, 0x51, 0xe8, 0x27, 0x8d, 0x81, 0xb1, 0x7a, 0xde, 0x9f, 0xda,
+	0xd8, 0x66, 0xce, 0xcb, 0x28, 0xb9, 0xb1, 0x7a, 0x7e, 0x64, 0x63, 0x1b, 0x7d, 0x0b, 0x25, 0xc3,
+	0xd4, 0x70, 0x3f, 0xee, 0xd5, 0xbc, 0x4a, 0xb1, 0xbb, 0xee, 0xf5, 0xec, 0x2b, 0x26, 0x99, 0xf7,
+	0x2c, 0x26, 0x5b, 0x90, 0xc5, 0xe7, 0x13, 0xdd, 0xba, 0xa8, 0x64, 0xb7, 0x13, 0x3b, 0x29, 0x45,
+	0xac, 0x68, 0xed, 0x67, 0x8a, 0xe6, 0x78, 0xed, 0xa7, 0xdf, 0xf2, 0x35, 0xb8, 0x4a, 0x6f, 0x27,
+	0xd7, 0x72, 0x27, 0x83, 0xe4, 0xdf, 0xc2, 0x56, 0x70, 0xc3, 0x4d, 0xdb, 0x95, 0xd7, 0xa6, 0x6e,
+	0xf0, 0x9b, 0xc7, 0x49, 0xdd, 0x5b, 0x51, 0x27, 0xe6, 0x32, 0x50, 0xe0, 0xb5, 0xcb, 0x4b, 0xae,
+	0xc2, 0x16, 0x3f, 0x4a, 0x6f, 0x5b, 0x04, 0xc6, 0x5c, 0xcf, 0xcb, 0xaf, 0xe0, 0x5a, 0x08, 0x5f,
+	0xa8, 0xf3, 0x2b, 0x00, 0x4f, 0x1d, 0xd1, 0xed, 0xc5, 0xd0, 0xa6, 0xe0, 0x6a, 0x23, 0x7f, 0x05,
+	0xd9, 0x50, 0x26, 0x24, 0x63, 0x64, 0xc2, 0x4f, 0x29, 0x58, 0xa7, 0x3e, 0xaa, 0x0f, 0xb1, 0x41,
+	0xdc, 0xd2, 0x73, 0x07, 0xca, 0x2a, 0x21, 0xd8, 0x26, 0x4c, 0x62, 0xdf, 0xad, 0x2f, 0x05, 0x65,
+	0xcd, 0x07, 0x67, 0xf9, 0x7c, 0x1b, 0x56, 0xd9, 0xd1, 0x60, 0xbb, 0xaf, 0x9e, 0x10, 0x6c, 0x31,
+	0xa9, 0x29, 0xa5, 0x28, 0x80, 0x75, 0x0a, 0xa3, 0x6d, 0x94, 0x83, 0x74, 0x8c, 0x4f, 0x4c, 0x8b,
+	0x67, 0x69, 0x4a, 0x71, 0x48, 0x0f, 0x18, 0xf0, 0x7f, 0xb5, 0x09, 0xdc, 0x87, 0xec, 0xb1, 0x6a,
+	0x18, 0x58, 0xab, 0x64, 0x97, 0xb6, 0x68, 0x02, 0x33, 0xd0, 0xa3, 0xe5, 0x16, 0xf6, 0x68, 0xf9,
+	0x40, 0x8f, 0x66, 0x00, 0xf2, 0x1f, 0x89, 0x88, 0x91, 0x1a, 0x64, 0x68, 0x76, 0x39, 0xc1, 0x2a,
+	0xcd, 0x3a, 0xa6, 0xce, 0x8e, 0x05, 0x6b, 0x4f, 0x69, 0xfd, 0xe0, 0x88, 0xb1, 0x7b, 0xac, 0x1a,
+	0xad, 0x86, 0x64, 0x70, 0xca, 0x04, 0xfa, 0x9a, 0x7e, 0xaf, 0xaf, 0x4f, 0xcc, 0xf6, 0xf5, 0x72,
+	0x13, 0x90, 0x9f, 0x42, 0x68, 0x58, 0x85, 0xb4, 0xe1, 0xf4, 0x65, 0x8b, 0x15, 0x64, 0x78, 0xf2,
+	0x1e, 0xac, 0xb7, 0xde, 0xea, 0x03, 0x32, 0x23, 0x57, 0x02, 0x47, 0x4c, 0x33, 0x20, 0xb6, 0x49,
+	0xc5, 0xfa, 0x09, 0x2e, 0x29, 0xb6, 0x0a, 0x6b, 0x07, 0xaa, 0x11, 0xdf, 0xd8, 0x03, 0x28, 0x7b,
+	0xf8, 0x97, 0x94, 0x59, 0x83, 0xf5, 0x23, 0xe3, 0xf8, 0x7d, 0xa4, 0x36, 0x01, 0xf9, 0x29, 0x2e,
+	0x29, 0xf7, 0x1f, 0x09, 0xc8, 0x36, 0xea, 0xdd, 0x91, 0x49, 0xd0, 0x35, 0xc8, 0xd9, 0x23, 0xd3,
+	0xf7, 0x86, 0xcb, 0xd2, 0x65, 0x5b, 0x43, 0x5f, 0x43, 0x86, 0x26, 0xb4, 0x73, 0x6f, 0x7d, 0x1a,
+	0x95, 0x36, 0x9c, 0x4f, 0xb5, 0x4b, 0x71, 0x15, 0x4e, 0x82, 0x6e, 0x41, 0x51, 0x9d, 0x92, 0x53,
+	0xd3, 0xd2, 0x09, 0xeb, 0x21, 0xf8, 0xe3, 0x6e, 0xc5, 0x85, 0xf1, 0x07, 0xa2, 0x6e, 0xdb, 0x53,
+	0xac, 0xf5, 0x55, 0xc2, 0x7a, 0x82, 0x94, 0x92, 0xe7, 0x80, 0x3a, 0xa1, 0x69, 0xe2, 0x56, 0x0f,
+	0xc2, 0xf2, 0x36, 0xa5, 0x14, 0x9c, 0xd2, 0x41, 0xe4, 0x7b, 0x90, 0x61, 0xe2, 0xd8, 0x5b, 0xe4,
+	0xb0, 0xd3, 0xfb, 0xb1, 0x7c, 0x85, 0x5e, 0x87, 0x1d, 0xa5, 0xd5, 0xa9, 0x2b, 0x2d, 0xd1, 0x2e,
+	0xd4, 0x1b, 0xbd, 0xf6, 0x73, 0x7a, 0xfd, 0x6d, 0xf2, 0xbc, 0xe1, 0x7a, 0xba, 0x97, 0xc0, 0x4f,
+	0x09, 0xd8, 0x98, 0x01, 0x0b, 0x57, 0x7e, 0x0b, 0x70, 0xfe, 0xa0, 0xf6, 0xb0, 0x4f, 0xbd, 0xe0,
+	0x24, 0xd5, 0x27, 0x8b, 0x6d, 0x57, 0x0a, 0x94, 0x82, 0xb1, 0x41, 0xdf, 0x40, 0xe1, 0xf5, 0x19,
+	0x11, 0xd4, 0xc9, 0x58, 0xd4, 0xf9, 0xd7, 0x67, 0x84, 0x11, 0xcb, 0x8f, 0xa0, 0xdc, 0xb1, 0xf0,
+	0x44, 0xb5, 0x70, 0xa3, 0xee, 0x44, 0xc3, 0x3e, 0xa4, 0xdf, 0xe8, 0x86, 0x26, 0xfa, 0xb8, 0x05,
+	0xbc, 0x9e, 0xe8, 0x86, 0xa6, 0x30, 0x5c, 0xf9, 0xd7, 0xb0, 0xee, 0xe3, 0x23, 0x0c, 0xdb, 0x87,
+	0x34, 0xd5, 0x4a, 0xc4, 0xc8, 0x32, 0xa5, 0x18, 0x2e, 0x65, 0x54, 0x1f, 0x10, 0xfd, 0xad, 0x4a,
+	0x3e, 0x50, 0xa3, 0xef, 0x00, 0xf9, 0x19, 0x7d, 0x80, 0x4a, 0x43, 0x28, 0xf5, 0x54, 0xdd, 0x20,
+	0x1f, 0xa4, 0x4f, 0x28, 0x40, 0x93, 0xa1, 0x00, 0x95, 0xd7, 0x61, 0xcd, 0x15, 0xc4, 0xf5, 0x95,
+	0xff, 0x96, 0x04, 0x68, 0xb3, 0x18, 0xed, 0x3e, 0x0f, 0x77, 0x86, 0x6e, 0xcb, 0x9d, 0x5c, 0xdc,
+	0x72, 0x7b, 0x1c, 0xfc, 0x2d, 0xf7, 0x4c, 0xd6, 0xa7, 0x02, 0x03, 0x13, 0x7f, 0x3f, 0x9e, 0x9e,
+	0xed, 0xc7, 0xaf, 0x43, 0x5e, 0x1d, 0x8a, 0x21, 0x4c, 0x86, 0x6f, 0xb1, 0x75, 0x3b, 0x6c, 0x64,
+	0x36, 0x9c, 0x85, 0x37, 0x01, 0x0c, 0x93, 0x38, 0xb7, 0x6f, 0x8e, 0x27, 0x9a, 0x61, 0x12, 0x71,
+	0xf3, 0xde, 0x00, 0xba, 0x10, 0x37, 0x78, 0x9e, 0x27, 0xa9, 0x61, 0x12, 0x76, 0x7b, 0xcb, 0x0f,
+	0x44, 0xeb, 0xbe, 0x0a, 0x85, 0x1f, 0x68, 0xc6, 0x50, 0x8b, 0x78, 0xef, 0xce, 0x96, 0x8d, 0x3a,
+	0x87, 0x24, 0x68, 0x6a, 0x3e, 0x7e, 0xd1, 0xe3, 0xab, 0xa4, 0xfc, 0x97, 0x04, 0x6f, 0xbf, 0x3c,
+	0x37, 0xd8, 0x71, 0x2a, 0xdf, 0x8c, 0xa1, 0xc9, 0x90, 0xa1, 0x4e, 0x2d, 0x61, 0x9a, 0xf2, 0x2e,
+	0x62, 0x45, 0x94, 0x13, 0x0a, 0xa2, 0xfd, 0x88, 0x40, 0x11, 0xb6, 0xf2, 0x92, 0x23, 0xe8, 0xb8,
+	0xb9, 0xb2, 0x0d, 0x1b, 0x87, 0xba, 0x41, 0xa8, 0xfa, 0x54, 0xaf, 0x58, 0x6a, 0x95, 0x21, 0x35,
+	0xb0, 0x79, 0x7b, 0x53, 0x54, 0xe8, 0xa7, 0xd3, 0x4a, 0xa7, 0xbc, 0x56, 0xfa, 0x06, 0x14, 0x34,
+	0xc3, 0xee, 0x1b, 0xea, 0x18, 0xf3, 0x06, 0xa6, 0xa0, 0xe4, 0x35, 0xc3, 0x7e, 0x4a, 0xd7, 0x72,
+	0x07, 0x36, 0x67, 0x85, 0x8a, 0xe4, 0xb8, 0x09, 0x60, 0xbf, 0xd5, 0xb5, 0xfe, 0xe0, 0x54, 0xd5,
+	0x0d, 0x56, 0x88, 0x8a, 0x4a, 0x81, 0x42, 0x1a, 0x14, 0x40, 0xdd, 0x61, 0x99, 0x26, 0xe9, 0x0f,
+	0x54, 0x5e, 0x67, 0x8a, 0x4a, 0x8e, 0xae, 0x1b, 0xaa, 0x2d, 0xf7, 0x01, 0x51, 0x8e, 0x8f, 0x5f,
+	0xf4, 0x62, 0x5b, 0x21, 0x41, 0x5e, 0x9d, 0x6a, 0x3a, 0x36, 0x06, 0x98, 0x71, 0x2b, 0x28, 0xee,
+	0x3a, 0x6c, 0x8f, 0x7c, 0x17, 0x36, 0x66, 0x04, 0x08, 0x8d, 0xe7, 0xf6, 0xb7, 0xbb, 0x32, 0x64,
+	0x79, 0xea, 0xa1, 0x15, 0xc8, 0x89, 0xc8, 0x28, 0x5f, 0xa1, 0x0b, 0x1a, 0x14, 0x4f, 0x5a, 0x3f,
+	0x96, 0x13, 0xfb, 0x7f, 0x97, 0xa0, 0xe8, 0xef, 0xfa, 0xd1, 0x2b, 0x58, 0xf1, 0x4d, 0x39, 0xd0,
+	0xb2, 0x07, 0x82, 0x74, 0x37, 0x2a, 0xd9, 0xe6, 0x8d, 0x67, 0x5f, 0xc1, 0x8a, 0xef, 0xcd, 0x8e,
+	0xde, 0x87, 0x56, 0x5a, 0xa6, 0x09, 0x7a, 0x09, 0xc0, 0x7a, 0xa0, 0xff, 0x04, 0xef, 0x47, 0x50,
+	0x74, 0x79, 0xeb, 0xd8, 0x46, 0x1b, 0xb3, 0x04, 0xad, 0xf1, 0x84, 0x5c, 0x48, 0xb7, 0x16, 0x73,
+	0xa1, 0x74, 0x2f, 0x61, 0xc5, 0x37, 0x67, 0x41, 0xbb, 0x51, 0x4a, 0x86, 0x07, 0x41, 0xcb, 0x75,
+	0x3c, 0x82, 0x12, 0xcd, 0xee, 0x83, 0x0b, 0x77, 0xe2, 0xbd, 0x1d, 0xc5, 0xde, 0xc1, 0x88, 0xa3,
+	0xf2, 0x13, 0x87, 0xad, 0xd3, 0x92, 0xa3, 0x88, 0x27, 0x40, 0x1c, 0x66, 0x87, 0xb0, 0x36, 0xcb,
+	0xcc, 0x46, 0xd7, 0xe6, 0x73, 0xb3, 0xe3, 0xb0, 0x73, 0x4d, 0x76, 0x07, 0xf9, 0x91, 0x26, 0x3b,
+	0x18, 0x71, 0xd8, 0x9e, 0xc0, 0x8a, 0xef, 0x49, 0x12, 0x7d, 0x4a, 0xe1, 0x77, 0x8b, 0x74, 0x37,
+	0x16, 0xae, 0x48, 0xdb, 0xa9, 0x98, 0xa5, 0xfa, 0x13, 0x6e, 0x6f, 0xe1, 0xb8, 0x2a, 0x3c, 0xfc,
+	0x94, 0x6a, 0xf1, 0x09, 0x02, 0x62, 0xfd, 0x91, 0xb8, 0x58, 0xec, 0x9c, 0x70, 0xac, 0xc5, 0x27,
+	0x08, 0x88, 0xf5, 0x57, 0x80, 0xc5, 0x62, 0xc3, 0x23, 0x43, 0xa9, 0x16, 0x9f, 0x40, 0x88, 0x55,
+	0xa1, 0xe8, 0x1f, 0xe6, 0x45, 0x17, 0x86, 0x39, 0x23, 0x3f, 0x49, 0x5e, 0x3e, 0xbd, 0xab, 0x25,
+	0xd0, 0x11, 0x5c, 0xe5, 0x7e, 0x0e, 0x0e, 0xc1, 0x22, 0x3b, 0x91, 0x00, 0xa2, 0x34, 0xaf, 0x9e,
+	0xa0, 0xd7, 0xb0, 0xc9, 0x8a, 0x4e, 0x90, 0xeb, 0x9d, 0x98, 0x5c, 0xdb, 0x4d, 0x29, 0xae, 0x02,
+	0xe8, 0x39, 0x6c, 0xd2, 0x08, 0x0d, 0x80, 0x23, 0x0a, 0x5d, 0x5c, 0xae, 0xdc, 0x35, 0x3c, 0x16,
+	0x3e, 0xae, 0x6b, 0x8e, 0xe1, 0xea, 0xdc, 0xa9, 0x1d, 0xba, 0x7f, 0x99, 0x21, 0xdf, 0x7c, 0x19,
+	0x2f, 0x60, 0x8d, 0x9f, 0xaa, 0x37, 0xc1, 0x5b, 0x3e, 0x02, 0x92, 0x96, 0xa3, 0x20, 0x93, 0x57,
+	0x2d, 0x17, 0x60, 0xa3, 0x2f, 0x17, 0x55, 0x8d, 0xd0, 0x18, 0x4d, 0xaa, 0xc6, 0x45, 0x17, 0x29,
+	0x60, 0xc1, 0x5a, 0x60, 0xd0, 0x85, 0xaa, 0x8b, 0xfd, 0x14, 0x9c, 0xa0, 0x49, 0x7b, 0xb1, 0xf1,
+	0xbd, 0x81, 0x1e, 0x0b, 0x5e, 0x71, 0x2e, 0x73, 0xe3, 0x28, 0xf2, 0xc5, 0x20, 0x88, 0x06, 0x00,
+	0xde, 0x78, 0x21, 0x3a, 0xec, 0x43, 0x33, 0x0b, 0x69, 0x37, 0x0e, 0xaa, 0x50, 0x74, 0x00, 0xe0,
+	0x0d, 0x77, 0xa2, 0x85, 0x84, 0x66, 0x72, 0xd2, 0x6e, 0x1c, 0x54, 0x4f, 0x88, 0x37, 0x9f, 0x59,
+	0x94, 0xc0, 0x81, 0xa9, 0x8f, 0xb4, 0x1b, 0x07, 0x55, 0x08, 0xf9, 0x1d, 0xe4, 0x9d, 0xb9, 0x48,
+	0x74, 0x7a, 0x05, 0x26, 0x2d, 0xd2, 0xce, 0x72, 0x44, 0xcf, 0x06, 0x6f, 0x00, 0x12, 0x6d, 0x43,
+	0x68, 0xac, 0x22, 0xed, 0xc6, 0x41, 0x15, 0x42, 0xc4, 0xd5, 0x2b, 0x66, 0x03, 0x8b, 0xaf, 0xde,
+	0xd9, 0xb9, 0x82, 0x74, 0x37, 0x16, 0xae, 0x90, 0xf3, 0x07, 0x28, 0xb8, 0x0f, 0x75, 0x14, 0xe9,
+	0x83, 0xe0, 0x4c, 0x40, 0xba, 0x13, 0x03, 0xd3, 0x73, 0x97, 0xf7, 0xf0, 0x8e, 0x76, 0x57, 0xe8,
+	0x95, 0x2f, 0xed, 0xc6, 0x41, 0x15, 0x42, 0x5e, 0x42, 0x4e, 0x3c, 0x95, 0xd1, 0xe7, 0x51, 0x64,
+	0xb3, 0x8f, 0x76, 0xe9, 0x8b, 0xa5, 0x78, 0x82, 0xf7, 0x10, 0xd6, 0x02, 0xaf, 0x45, 0xb4, 0xb0,
+	0xf0, 0x84, 0x9f, 0x95, 0xd1, 0xd7, 0xa7, 0x87, 0x5b, 0x4b, 0x20, 0x1d, 0x8a, 0xfe, 0x77, 0x58,
+	0xf4, 0x0d, 0x3d, 0xe7, 0x89, 0x28, 0xdd, 0x8b, 0x87, 0xec, 0x85, 0x97, 0xef, 0xfd, 0x14, 0x1d,
+	0x5e, 0xe1, 0x57, 0x9c, 0x74, 0x37, 0x16, 0x2e, 0x97, 0x73, 0xf0, 0xd5, 0xcb, 0xfb, 0x43, 0x9d,
+	0x9c, 0x4e, 0x8f, 0x69, 0xc1, 0xdb, 0xe3, 0x8f, 0xbd, 0x3d, 0xfe, 0x6f, 0x16, 0x36, 0xa3, 0x16,
+	0xdf, 0xea, 0x44, 0xdf, 0xf3, 0xf3, 0x3a, 0xce, 0xb2, 0xdd, 0x9f, 0xfd, 0x7b, 0x00, 0x97, 0x6e,
+	0x95, 0x2e, 0x46, 0x23, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -695,6 +2970,21 @@ type RegistrationClient interface {
 	ListBySelectors(ctx context.Context, in *common.Selectors, opts ...grpc.CallOption) (*common.RegistrationEntries, error)
 	// Return all registration entries for which SPIFFE ID matches.
 	ListBySpiffeID(ctx context.Context, in *SpiffeID, opts ...grpc.CallOption) (*common.RegistrationEntries, error)
+	// Lists registration entries matching the combined request filters, one
+	// page at a time.
+	ListEntries(ctx context.Context, in *ListEntriesRequest, opts ...grpc.CallOption) (*ListEntriesResponse, error)
+	// Creates several entries in a single transaction, returning the outcome
+	// for each entry.
+	BatchCreateEntry(ctx context.Context, in *BatchCreateEntryRequest, opts ...grpc.CallOption) (*BatchCreateEntryResponse, error)
+	// Updates several entries in a single transaction, returning the outcome
+	// for each entry.
+	BatchUpdateEntry(ctx context.Context, in *BatchUpdateEntryRequest, opts ...grpc.CallOption) (*BatchUpdateEntryResponse, error)
+	// Deletes several entries in a single transaction, returning the outcome
+	// for each entry.
+	BatchDeleteEntry(ctx context.Context, in *BatchDeleteEntryRequest, opts ...grpc.CallOption) (*BatchDeleteEntryResponse, error)
+	// Streams changes to the registration entries. Unless resuming from a
+	// cursor, the stream starts with a snapshot of all entries.
+	WatchEntries(ctx context.Context, in *WatchEntriesRequest, opts ...grpc.CallOption) (Registration_WatchEntriesClient, error)
 	// Creates an entry in the Federated bundle table to store the mappings of Federated SPIFFE IDs and their associated CA bundle.
 	CreateFederatedBundle(ctx context.Context, in *FederatedBundle, opts ...grpc.CallOption) (*common.Empty, error)
 	// Retrieves a single federated bundle
@@ -707,12 +2997,44 @@ type RegistrationClient interface {
 	DeleteFederatedBundle(ctx context.Context, in *DeleteFederatedBundleRequest, opts ...grpc.CallOption) (*common.Empty, error)
 	// Create a new join token
 	CreateJoinToken(ctx context.Context, in *JoinToken, opts ...grpc.CallOption) (*JoinToken, error)
+	// ListJoinTokens lists the outstanding join tokens
+	ListJoinTokens(ctx context.Context, in *ListJoinTokensRequest, opts ...grpc.CallOption) (*ListJoinTokensResponse, error)
+	// DeleteJoinToken deletes a join token so it can no longer be used
+	DeleteJoinToken(ctx context.Context, in *DeleteJoinTokenRequest, opts ...grpc.CallOption) (*DeleteJoinTokenResponse, error)
 	// Retrieves the CA bundle.
 	FetchBundle(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*Bundle, error)
 	// EvictAgent removes an attestation entry from the attested nodes store
 	EvictAgent(ctx context.Context, in *EvictAgentRequest, opts ...grpc.CallOption) (*EvictAgentResponse, error)
-	// ListAgents will list all attested nodes
+	// ListAgents will list attested nodes matching the request filters, one
+	// page at a time
 	ListAgents(ctx context.Context, in *ListAgentsRequest, opts ...grpc.CallOption) (*ListAgentsResponse, error)
+	// FetchAgent retrieves a single attested node and its node selectors
+	FetchAgent(ctx context.Context, in *FetchAgentRequest, opts ...grpc.CallOption) (*FetchAgentResponse, error)
+	// BanAgent bans an attested node and revokes its SVID along with any
+	// downstream CAs issued through it. Banned nodes can neither attest nor
+	// fetch SVIDs until unbanned.
+	BanAgent(ctx context.Context, in *BanAgentRequest, opts ...grpc.CallOption) (*BanAgentResponse, error)
+	// UnbanAgent unbans an attested node, allowing it to attest again
+	UnbanAgent(ctx context.Context, in *UnbanAgentRequest, opts ...grpc.CallOption) (*UnbanAgentResponse, error)
+	// ListCASlots lists the current and next X509 CA and JWT key slots
+	ListCASlots(ctx context.Context, in *ListCASlotsRequest, opts ...grpc.CallOption) (*ListCASlotsResponse, error)
+	// PrepareCA prepares a new authority in the next slot, replacing any
+	// authority already prepared there
+	PrepareCA(ctx context.Context, in *PrepareCARequest, opts ...grpc.CallOption) (*PrepareCAResponse, error)
+	// ActivateCA activates the authority prepared in the next slot ahead of
+	// schedule
+	ActivateCA(ctx context.Context, in *ActivateCARequest, opts ...grpc.CallOption) (*ActivateCAResponse, error)
+	// TaintCA marks an old authority as tainted. Agents rotate SVIDs signed
+	// by a tainted authority, after which it is removed from the bundle.
+	TaintCA(ctx context.Context, in *TaintCARequest, opts ...grpc.CallOption) (*TaintCAResponse, error)
+	// Lists the records of SVIDs signed by the server, oldest first
+	ListIssuedSVIDs(ctx context.Context, in *ListIssuedSVIDsRequest, opts ...grpc.CallOption) (Registration_ListIssuedSVIDsClient, error)
+	// MintX509SVID signs an X509-SVID for an arbitrary SPIFFE ID in the
+	// trust domain, without a registration entry. Only admins may call it.
+	MintX509SVID(ctx context.Context, in *MintX509SVIDRequest, opts ...grpc.CallOption) (*MintX509SVIDResponse, error)
+	// MintJWTSVID signs a JWT-SVID for an arbitrary SPIFFE ID in the trust
+	// domain, without a registration entry. Only admins may call it.
+	MintJWTSVID(ctx context.Context, in *MintJWTSVIDRequest, opts ...grpc.CallOption) (*MintJWTSVIDResponse, error)
 }
 
 type registrationClient struct {
@@ -804,6 +3126,74 @@ func (c *registrationClient) ListBySpiffeID(ctx context.Context, in *SpiffeID, o
 	return out, nil
 }
 
+func (c *registrationClient) ListEntries(ctx context.Context, in *ListEntriesRequest, opts ...grpc.CallOption) (*ListEntriesResponse, error) {
+	out := new(ListEntriesResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/ListEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationClient) BatchCreateEntry(ctx context.Context, in *BatchCreateEntryRequest, opts ...grpc.CallOption) (*BatchCreateEntryResponse, error) {
+	out := new(BatchCreateEntryResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/BatchCreateEntry", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationClient) BatchUpdateEntry(ctx context.Context, in *BatchUpdateEntryRequest, opts ...grpc.CallOption) (*BatchUpdateEntryResponse, error) {
+	out := new(BatchUpdateEntryResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/BatchUpdateEntry", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationClient) BatchDeleteEntry(ctx context.Context, in *BatchDeleteEntryRequest, opts ...grpc.CallOption) (*BatchDeleteEntryResponse, error) {
+	out := new(BatchDeleteEntryResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/BatchDeleteEntry", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationClient) WatchEntries(ctx context.Context, in *WatchEntriesRequest, opts ...grpc.CallOption) (Registration_WatchEntriesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Registration_serviceDesc.Streams[0], "/spire.api.registration.Registration/WatchEntries", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &registrationWatchEntriesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Registration_WatchEntriesClient interface {
+	Recv() (*EntryEvent, error)
+	grpc.ClientStream
+}
+
+type registrationWatchEntriesClient struct {
+	grpc.ClientStream
+}
+
+func (x *registrationWatchEntriesClient) Recv() (*EntryEvent, error) {
+	m := new(EntryEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *registrationClient) CreateFederatedBundle(ctx context.Context, in *FederatedBundle, opts ...grpc.CallOption) (*common.Empty, error) {
 	out := new(common.Empty)
 	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/CreateFederatedBundle", in, out, opts...)
@@ -823,7 +3213,7 @@ func (c *registrationClient) FetchFederatedBundle(ctx context.Context, in *Feder
 }
 
 func (c *registrationClient) ListFederatedBundles(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (Registration_ListFederatedBundlesClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Registration_serviceDesc.Streams[0], "/spire.api.registration.Registration/ListFederatedBundles", opts...)
+	stream, err := c.cc.NewStream(ctx, &_Registration_serviceDesc.Streams[1], "/spire.api.registration.Registration/ListFederatedBundles", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -881,6 +3271,24 @@ func (c *registrationClient) CreateJoinToken(ctx context.Context, in *JoinToken,
 	return out, nil
 }
 
+func (c *registrationClient) ListJoinTokens(ctx context.Context, in *ListJoinTokensRequest, opts ...grpc.CallOption) (*ListJoinTokensResponse, error) {
+	out := new(ListJoinTokensResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/ListJoinTokens", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationClient) DeleteJoinToken(ctx context.Context, in *DeleteJoinTokenRequest, opts ...grpc.CallOption) (*DeleteJoinTokenResponse, error) {
+	out := new(DeleteJoinTokenResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/DeleteJoinToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *registrationClient) FetchBundle(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*Bundle, error) {
 	out := new(Bundle)
 	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/FetchBundle", in, out, opts...)
@@ -908,6 +3316,119 @@ func (c *registrationClient) ListAgents(ctx context.Context, in *ListAgentsReque
 	return out, nil
 }
 
+func (c *registrationClient) FetchAgent(ctx context.Context, in *FetchAgentRequest, opts ...grpc.CallOption) (*FetchAgentResponse, error) {
+	out := new(FetchAgentResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/FetchAgent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationClient) BanAgent(ctx context.Context, in *BanAgentRequest, opts ...grpc.CallOption) (*BanAgentResponse, error) {
+	out := new(BanAgentResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/BanAgent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationClient) UnbanAgent(ctx context.Context, in *UnbanAgentRequest, opts ...grpc.CallOption) (*UnbanAgentResponse, error) {
+	out := new(UnbanAgentResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/UnbanAgent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationClient) ListCASlots(ctx context.Context, in *ListCASlotsRequest, opts ...grpc.CallOption) (*ListCASlotsResponse, error) {
+	out := new(ListCASlotsResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/ListCASlots", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationClient) PrepareCA(ctx context.Context, in *PrepareCARequest, opts ...grpc.CallOption) (*PrepareCAResponse, error) {
+	out := new(PrepareCAResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/PrepareCA", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationClient) ActivateCA(ctx context.Context, in *ActivateCARequest, opts ...grpc.CallOption) (*ActivateCAResponse, error) {
+	out := new(ActivateCAResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/ActivateCA", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationClient) TaintCA(ctx context.Context, in *TaintCARequest, opts ...grpc.CallOption) (*TaintCAResponse, error) {
+	out := new(TaintCAResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/TaintCA", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationClient) ListIssuedSVIDs(ctx context.Context, in *ListIssuedSVIDsRequest, opts ...grpc.CallOption) (Registration_ListIssuedSVIDsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Registration_serviceDesc.Streams[2], "/spire.api.registration.Registration/ListIssuedSVIDs", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &registrationListIssuedSVIDsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Registration_ListIssuedSVIDsClient interface {
+	Recv() (*IssuedSVID, error)
+	grpc.ClientStream
+}
+
+type registrationListIssuedSVIDsClient struct {
+	grpc.ClientStream
+}
+
+func (x *registrationListIssuedSVIDsClient) Recv() (*IssuedSVID, error) {
+	m := new(IssuedSVID)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *registrationClient) MintX509SVID(ctx context.Context, in *MintX509SVIDRequest, opts ...grpc.CallOption) (*MintX509SVIDResponse, error) {
+	out := new(MintX509SVIDResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/MintX509SVID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *registrationClient) MintJWTSVID(ctx context.Context, in *MintJWTSVIDRequest, opts ...grpc.CallOption) (*MintJWTSVIDResponse, error) {
+	out := new(MintJWTSVIDResponse)
+	err := c.cc.Invoke(ctx, "/spire.api.registration.Registration/MintJWTSVID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RegistrationServer is the server API for Registration service.
 type RegistrationServer interface {
 	// Creates an entry in the Registration table, used to assign SPIFFE IDs to nodes and workloads.
@@ -928,6 +3449,21 @@ type RegistrationServer interface {
 	ListBySelectors(context.Context, *common.Selectors) (*common.RegistrationEntries, error)
 	// Return all registration entries for which SPIFFE ID matches.
 	ListBySpiffeID(context.Context, *SpiffeID) (*common.RegistrationEntries, error)
+	// Lists registration entries matching the combined request filters, one
+	// page at a time.
+	ListEntries(context.Context, *ListEntriesRequest) (*ListEntriesResponse, error)
+	// Creates several entries in a single transaction, returning the outcome
+	// for each entry.
+	BatchCreateEntry(context.Context, *BatchCreateEntryRequest) (*BatchCreateEntryResponse, error)
+	// Updates several entries in a single transaction, returning the outcome
+	// for each entry.
+	BatchUpdateEntry(context.Context, *BatchUpdateEntryRequest) (*BatchUpdateEntryResponse, error)
+	// Deletes several entries in a single transaction, returning the outcome
+	// for each entry.
+	BatchDeleteEntry(context.Context, *BatchDeleteEntryRequest) (*BatchDeleteEntryResponse, error)
+	// Streams changes to the registration entries. Unless resuming from a
+	// cursor, the stream starts with a snapshot of all entries.
+	WatchEntries(*WatchEntriesRequest, Registration_WatchEntriesServer) error
 	// Creates an entry in the Federated bundle table to store the mappings of Federated SPIFFE IDs and their associated CA bundle.
 	CreateFederatedBundle(context.Context, *FederatedBundle) (*common.Empty, error)
 	// Retrieves a single federated bundle
@@ -940,12 +3476,44 @@ type RegistrationServer interface {
 	DeleteFederatedBundle(context.Context, *DeleteFederatedBundleRequest) (*common.Empty, error)
 	// Create a new join token
 	CreateJoinToken(context.Context, *JoinToken) (*JoinToken, error)
+	// ListJoinTokens lists the outstanding join tokens
+	ListJoinTokens(context.Context, *ListJoinTokensRequest) (*ListJoinTokensResponse, error)
+	// DeleteJoinToken deletes a join token so it can no longer be used
+	DeleteJoinToken(context.Context, *DeleteJoinTokenRequest) (*DeleteJoinTokenResponse, error)
 	// Retrieves the CA bundle.
 	FetchBundle(context.Context, *common.Empty) (*Bundle, error)
 	// EvictAgent removes an attestation entry from the attested nodes store
 	EvictAgent(context.Context, *EvictAgentRequest) (*EvictAgentResponse, error)
-	// ListAgents will list all attested nodes
+	// ListAgents will list attested nodes matching the request filters, one
+	// page at a time
 	ListAgents(context.Context, *ListAgentsRequest) (*ListAgentsResponse, error)
+	// FetchAgent retrieves a single attested node and its node selectors
+	FetchAgent(context.Context, *FetchAgentRequest) (*FetchAgentResponse, error)
+	// BanAgent bans an attested node and revokes its SVID along with any
+	// downstream CAs issued through it. Banned nodes can neither attest nor
+	// fetch SVIDs until unbanned.
+	BanAgent(context.Context, *BanAgentRequest) (*BanAgentResponse, error)
+	// UnbanAgent unbans an attested node, allowing it to attest again
+	UnbanAgent(context.Context, *UnbanAgentRequest) (*UnbanAgentResponse, error)
+	// ListCASlots lists the current and next X509 CA and JWT key slots
+	ListCASlots(context.Context, *ListCASlotsRequest) (*ListCASlotsResponse, error)
+	// PrepareCA prepares a new authority in the next slot, replacing any
+	// authority already prepared there
+	PrepareCA(context.Context, *PrepareCARequest) (*PrepareCAResponse, error)
+	// ActivateCA activates the authority prepared in the next slot ahead of
+	// schedule
+	ActivateCA(context.Context, *ActivateCARequest) (*ActivateCAResponse, error)
+	// TaintCA marks an old authority as tainted. Agents rotate SVIDs signed
+	// by a tainted authority, after which it is removed from the bundle.
+	TaintCA(context.Context, *TaintCARequest) (*TaintCAResponse, error)
+	// Lists the records of SVIDs signed by the server, oldest first
+	ListIssuedSVIDs(*ListIssuedSVIDsRequest, Registration_ListIssuedSVIDsServer) error
+	// MintX509SVID signs an X509-SVID for an arbitrary SPIFFE ID in the
+	// trust domain, without a registration entry. Only admins may call it.
+	MintX509SVID(context.Context, *MintX509SVIDRequest) (*MintX509SVIDResponse, error)
+	// MintJWTSVID signs a JWT-SVID for an arbitrary SPIFFE ID in the trust
+	// domain, without a registration entry. Only admins may call it.
+	MintJWTSVID(context.Context, *MintJWTSVIDRequest) (*MintJWTSVIDResponse, error)
 }
 
 func RegisterRegistrationServer(s *grpc.Server, srv RegistrationServer) {
@@ -994,126 +3562,219 @@ func _Registration_FetchEntry_Handler(srv interface{}, ctx context.Context, dec
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RegistrationServer).FetchEntry(ctx, in)
+		return srv.(RegistrationServer).FetchEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/FetchEntry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).FetchEntry(ctx, req.(*RegistrationEntryID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registration_FetchEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).FetchEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/FetchEntries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).FetchEntries(ctx, req.(*common.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registration_UpdateEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).UpdateEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/UpdateEntry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).UpdateEntry(ctx, req.(*UpdateEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registration_ListByParentID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ParentID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).ListByParentID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/ListByParentID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).ListByParentID(ctx, req.(*ParentID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registration_ListBySelector_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Selector)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).ListBySelector(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.api.registration.Registration/FetchEntry",
+		FullMethod: "/spire.api.registration.Registration/ListBySelector",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RegistrationServer).FetchEntry(ctx, req.(*RegistrationEntryID))
+		return srv.(RegistrationServer).ListBySelector(ctx, req.(*common.Selector))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Registration_FetchEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(common.Empty)
+func _Registration_ListBySelectors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Selectors)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RegistrationServer).FetchEntries(ctx, in)
+		return srv.(RegistrationServer).ListBySelectors(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.api.registration.Registration/FetchEntries",
+		FullMethod: "/spire.api.registration.Registration/ListBySelectors",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RegistrationServer).FetchEntries(ctx, req.(*common.Empty))
+		return srv.(RegistrationServer).ListBySelectors(ctx, req.(*common.Selectors))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Registration_UpdateEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateEntryRequest)
+func _Registration_ListBySpiffeID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SpiffeID)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RegistrationServer).UpdateEntry(ctx, in)
+		return srv.(RegistrationServer).ListBySpiffeID(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.api.registration.Registration/UpdateEntry",
+		FullMethod: "/spire.api.registration.Registration/ListBySpiffeID",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RegistrationServer).UpdateEntry(ctx, req.(*UpdateEntryRequest))
+		return srv.(RegistrationServer).ListBySpiffeID(ctx, req.(*SpiffeID))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Registration_ListByParentID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ParentID)
+func _Registration_ListEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListEntriesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RegistrationServer).ListByParentID(ctx, in)
+		return srv.(RegistrationServer).ListEntries(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.api.registration.Registration/ListByParentID",
+		FullMethod: "/spire.api.registration.Registration/ListEntries",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RegistrationServer).ListByParentID(ctx, req.(*ParentID))
+		return srv.(RegistrationServer).ListEntries(ctx, req.(*ListEntriesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Registration_ListBySelector_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(common.Selector)
+func _Registration_BatchCreateEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateEntryRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RegistrationServer).ListBySelector(ctx, in)
+		return srv.(RegistrationServer).BatchCreateEntry(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.api.registration.Registration/ListBySelector",
+		FullMethod: "/spire.api.registration.Registration/BatchCreateEntry",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RegistrationServer).ListBySelector(ctx, req.(*common.Selector))
+		return srv.(RegistrationServer).BatchCreateEntry(ctx, req.(*BatchCreateEntryRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Registration_ListBySelectors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(common.Selectors)
+func _Registration_BatchUpdateEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateEntryRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RegistrationServer).ListBySelectors(ctx, in)
+		return srv.(RegistrationServer).BatchUpdateEntry(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.api.registration.Registration/ListBySelectors",
+		FullMethod: "/spire.api.registration.Registration/BatchUpdateEntry",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RegistrationServer).ListBySelectors(ctx, req.(*common.Selectors))
+		return srv.(RegistrationServer).BatchUpdateEntry(ctx, req.(*BatchUpdateEntryRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Registration_ListBySpiffeID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SpiffeID)
+func _Registration_BatchDeleteEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteEntryRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RegistrationServer).ListBySpiffeID(ctx, in)
+		return srv.(RegistrationServer).BatchDeleteEntry(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.api.registration.Registration/ListBySpiffeID",
+		FullMethod: "/spire.api.registration.Registration/BatchDeleteEntry",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RegistrationServer).ListBySpiffeID(ctx, req.(*SpiffeID))
+		return srv.(RegistrationServer).BatchDeleteEntry(ctx, req.(*BatchDeleteEntryRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Registration_WatchEntries_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchEntriesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RegistrationServer).WatchEntries(m, &registrationWatchEntriesServer{stream})
+}
+
+type Registration_WatchEntriesServer interface {
+	Send(*EntryEvent) error
+	grpc.ServerStream
+}
+
+type registrationWatchEntriesServer struct {
+	grpc.ServerStream
+}
+
+func (x *registrationWatchEntriesServer) Send(m *EntryEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Registration_CreateFederatedBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FederatedBundle)
 	if err := dec(in); err != nil {
@@ -1225,6 +3886,42 @@ func _Registration_CreateJoinToken_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Registration_ListJoinTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJoinTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).ListJoinTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/ListJoinTokens",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).ListJoinTokens(ctx, req.(*ListJoinTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registration_DeleteJoinToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteJoinTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).DeleteJoinToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/DeleteJoinToken",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).DeleteJoinToken(ctx, req.(*DeleteJoinTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Registration_FetchBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(common.Empty)
 	if err := dec(in); err != nil {
@@ -1279,6 +3976,189 @@ func _Registration_ListAgents_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Registration_FetchAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchAgentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).FetchAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/FetchAgent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).FetchAgent(ctx, req.(*FetchAgentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registration_BanAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BanAgentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).BanAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/BanAgent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).BanAgent(ctx, req.(*BanAgentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registration_UnbanAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnbanAgentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).UnbanAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/UnbanAgent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).UnbanAgent(ctx, req.(*UnbanAgentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registration_ListCASlots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCASlotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).ListCASlots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/ListCASlots",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).ListCASlots(ctx, req.(*ListCASlotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registration_PrepareCA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrepareCARequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).PrepareCA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/PrepareCA",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).PrepareCA(ctx, req.(*PrepareCARequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registration_ActivateCA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ActivateCARequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).ActivateCA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/ActivateCA",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).ActivateCA(ctx, req.(*ActivateCARequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registration_TaintCA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TaintCARequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).TaintCA(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/TaintCA",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).TaintCA(ctx, req.(*TaintCARequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registration_ListIssuedSVIDs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListIssuedSVIDsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RegistrationServer).ListIssuedSVIDs(m, &registrationListIssuedSVIDsServer{stream})
+}
+
+type Registration_ListIssuedSVIDsServer interface {
+	Send(*IssuedSVID) error
+	grpc.ServerStream
+}
+
+type registrationListIssuedSVIDsServer struct {
+	grpc.ServerStream
+}
+
+func (x *registrationListIssuedSVIDsServer) Send(m *IssuedSVID) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Registration_MintX509SVID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MintX509SVIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).MintX509SVID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/MintX509SVID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).MintX509SVID(ctx, req.(*MintX509SVIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Registration_MintJWTSVID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MintJWTSVIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RegistrationServer).MintJWTSVID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.api.registration.Registration/MintJWTSVID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RegistrationServer).MintJWTSVID(ctx, req.(*MintJWTSVIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Registration_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "spire.api.registration.Registration",
 	HandlerType: (*RegistrationServer)(nil),
@@ -1319,6 +4199,22 @@ var _Registration_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListBySpiffeID",
 			Handler:    _Registration_ListBySpiffeID_Handler,
 		},
+		{
+			MethodName: "ListEntries",
+			Handler:    _Registration_ListEntries_Handler,
+		},
+		{
+			MethodName: "BatchCreateEntry",
+			Handler:    _Registration_BatchCreateEntry_Handler,
+		},
+		{
+			MethodName: "BatchUpdateEntry",
+			Handler:    _Registration_BatchUpdateEntry_Handler,
+		},
+		{
+			MethodName: "BatchDeleteEntry",
+			Handler:    _Registration_BatchDeleteEntry_Handler,
+		},
 		{
 			MethodName: "CreateFederatedBundle",
 			Handler:    _Registration_CreateFederatedBundle_Handler,
@@ -1339,6 +4235,14 @@ var _Registration_serviceDesc = grpc.ServiceDesc{
 			MethodName: "CreateJoinToken",
 			Handler:    _Registration_CreateJoinToken_Handler,
 		},
+		{
+			MethodName: "ListJoinTokens",
+			Handler:    _Registration_ListJoinTokens_Handler,
+		},
+		{
+			MethodName: "DeleteJoinToken",
+			Handler:    _Registration_DeleteJoinToken_Handler,
+		},
 		{
 			MethodName: "FetchBundle",
 			Handler:    _Registration_FetchBundle_Handler,
@@ -1351,13 +4255,59 @@ var _Registration_serviceDesc = grpc.ServiceDesc{
 			MethodName: "ListAgents",
 			Handler:    _Registration_ListAgents_Handler,
 		},
+		{
+			MethodName: "FetchAgent",
+			Handler:    _Registration_FetchAgent_Handler,
+		},
+		{
+			MethodName: "BanAgent",
+			Handler:    _Registration_BanAgent_Handler,
+		},
+		{
+			MethodName: "UnbanAgent",
+			Handler:    _Registration_UnbanAgent_Handler,
+		},
+		{
+			MethodName: "ListCASlots",
+			Handler:    _Registration_ListCASlots_Handler,
+		},
+		{
+			MethodName: "PrepareCA",
+			Handler:    _Registration_PrepareCA_Handler,
+		},
+		{
+			MethodName: "ActivateCA",
+			Handler:    _Registration_ActivateCA_Handler,
+		},
+		{
+			MethodName: "TaintCA",
+			Handler:    _Registration_TaintCA_Handler,
+		},
+		{
+			MethodName: "MintX509SVID",
+			Handler:    _Registration_MintX509SVID_Handler,
+		},
+		{
+			MethodName: "MintJWTSVID",
+			Handler:    _Registration_MintJWTSVID_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchEntries",
+			Handler:       _Registration_WatchEntries_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "ListFederatedBundles",
 			Handler:       _Registration_ListFederatedBundles_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ListIssuedSVIDs",
+			Handler:       _Registration_ListIssuedSVIDs_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "registration.proto",
 }
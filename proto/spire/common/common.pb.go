@@ -20,7 +20,7 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
-//* Represents an empty message
+// Represents an empty message
 type Empty struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -52,11 +52,11 @@ func (m *Empty) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Empty proto.InternalMessageInfo
 
-//* A type which contains attestation data for specific platform.
+// A type which contains attestation data for specific platform.
 type AttestationData struct {
-	//* Type of attestation to perform.
+	// Type of attestation to perform.
 	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	//* The attestation data.
+	// The attestation data.
 	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -102,13 +102,13 @@ func (m *AttestationData) GetData() []byte {
 	return nil
 }
 
-//* A type which describes the conditions under which a registration
-//entry is matched.
+// A type which describes the conditions under which a registration
+// entry is matched.
 type Selector struct {
-	//* A selector type represents the type of attestation used in attesting
-	//the entity (Eg: AWS, K8).
+	// A selector type represents the type of attestation used in attesting
+	// the entity (Eg: AWS, K8).
 	Type string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
-	//* The value to be attested.
+	// The value to be attested.
 	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -154,9 +154,9 @@ func (m *Selector) GetValue() string {
 	return ""
 }
 
-//* Represents a type with a list of Selector.
+// Represents a type with a list of Selector.
 type Selectors struct {
-	//* A list of Selector.
+	// A list of Selector.
 	Entries              []*Selector `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
 	XXX_unrecognized     []byte      `json:"-"`
@@ -204,7 +204,12 @@ type AttestedNode struct {
 	// Node certificate serial number
 	CertSerialNumber string `protobuf:"bytes,3,opt,name=cert_serial_number,json=certSerialNumber,proto3" json:"cert_serial_number,omitempty"`
 	// Node certificate not_after (seconds since unix epoch)
-	CertNotAfter         int64    `protobuf:"varint,4,opt,name=cert_not_after,json=certNotAfter,proto3" json:"cert_not_after,omitempty"`
+	CertNotAfter int64 `protobuf:"varint,4,opt,name=cert_not_after,json=certNotAfter,proto3" json:"cert_not_after,omitempty"`
+	// Node selectors
+	Selectors []*Selector `protobuf:"bytes,5,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	// Whether the node is banned. Banned nodes can neither attest nor fetch
+	// SVIDs until unbanned.
+	Banned               bool     `protobuf:"varint,6,opt,name=banned,proto3" json:"banned,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -263,34 +268,60 @@ func (m *AttestedNode) GetCertNotAfter() int64 {
 	return 0
 }
 
-//* This is a curated record that the Server uses to set up and
-//manage the various registered nodes and workloads that are controlled by it.
+func (m *AttestedNode) GetSelectors() []*Selector {
+	if m != nil {
+		return m.Selectors
+	}
+	return nil
+}
+
+func (m *AttestedNode) GetBanned() bool {
+	if m != nil {
+		return m.Banned
+	}
+	return false
+}
+
+// This is a curated record that the Server uses to set up and
+// manage the various registered nodes and workloads that are controlled by it.
 type RegistrationEntry struct {
-	//* A list of selectors.
+	// A list of selectors.
 	Selectors []*Selector `protobuf:"bytes,1,rep,name=selectors,proto3" json:"selectors,omitempty"`
-	//* The SPIFFE ID of an entity that is authorized to attest the validity
-	//of a selector
+	// The SPIFFE ID of an entity that is authorized to attest the validity
+	// of a selector
 	ParentId string `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
-	//* The SPIFFE ID is a structured string used to identify a resource or
-	//caller. It is defined as a URI comprising a “trust domain” and an
-	//associated path.
+	// The SPIFFE ID is a structured string used to identify a resource or
+	// caller. It is defined as a URI comprising a “trust domain” and an
+	// associated path.
 	SpiffeId string `protobuf:"bytes,3,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
-	//* Time to live.
+	// Time to live.
 	Ttl int32 `protobuf:"varint,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
-	//* A list of federated trust domain SPIFFE IDs.
+	// A list of federated trust domain SPIFFE IDs.
 	FederatesWith []string `protobuf:"bytes,5,rep,name=federates_with,json=federatesWith,proto3" json:"federates_with,omitempty"`
-	//* Entry ID
+	// Entry ID
 	EntryId string `protobuf:"bytes,6,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
-	//* Whether or not the workload is an admin workload. Admin workloads
-	//can use their SVID's to authenticate with the Registration API, for
-	//example.
+	// Whether or not the workload is an admin workload. Admin workloads
+	// can use their SVID's to authenticate with the Registration API, for
+	// example.
 	Admin bool `protobuf:"varint,7,opt,name=admin,proto3" json:"admin,omitempty"`
-	//* To enable signing CA CSR in upstream spire server
+	// To enable signing CA CSR in upstream spire server
 	Downstream bool `protobuf:"varint,8,opt,name=downstream,proto3" json:"downstream,omitempty"`
-	//* Expiration of this entry, in seconds from epoch
+	// Expiration of this entry, in seconds from epoch
 	EntryExpiry int64 `protobuf:"varint,9,opt,name=entryExpiry,proto3" json:"entryExpiry,omitempty"`
-	//* DNS entries
-	DnsNames             []string `protobuf:"bytes,10,rep,name=dns_names,json=dnsNames,proto3" json:"dns_names,omitempty"`
+	// DNS entries
+	DnsNames []string `protobuf:"bytes,10,rep,name=dns_names,json=dnsNames,proto3" json:"dns_names,omitempty"`
+	// Optional customizations of the X509-SVIDs issued for this entry
+	X509SvidTemplate *X509SVIDTemplate `protobuf:"bytes,11,opt,name=x509_svid_template,json=x509SvidTemplate,proto3" json:"x509_svid_template,omitempty"`
+	// Time to live of JWT-SVIDs, in seconds. If unset, the server default
+	// is used.
+	JwtSvidTtl int32 `protobuf:"varint,12,opt,name=jwt_svid_ttl,json=jwtSvidTtl,proto3" json:"jwt_svid_ttl,omitempty"`
+	// Static claims added to JWT-SVIDs. Registered claims (e.g. "sub" or
+	// "exp") cannot be set.
+	JwtSvidClaims map[string]string `protobuf:"bytes,13,rep,name=jwt_svid_claims,json=jwtSvidClaims,proto3" json:"jwt_svid_claims,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Revision of the datastore when the entry was last created or
+	// updated. Set by the datastore on every write. When updating an entry, a
+	// non-zero revision must match the stored one for the update to succeed.
+	RevisionNumber       int64    `protobuf:"varint,14,opt,name=revision_number,json=revisionNumber,proto3" json:"revision_number,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -391,9 +422,310 @@ func (m *RegistrationEntry) GetDnsNames() []string {
 	return nil
 }
 
-//* A list of registration entries.
+func (m *RegistrationEntry) GetX509SvidTemplate() *X509SVIDTemplate {
+	if m != nil {
+		return m.X509SvidTemplate
+	}
+	return nil
+}
+
+func (m *RegistrationEntry) GetJwtSvidTtl() int32 {
+	if m != nil {
+		return m.JwtSvidTtl
+	}
+	return 0
+}
+
+func (m *RegistrationEntry) GetJwtSvidClaims() map[string]string {
+	if m != nil {
+		return m.JwtSvidClaims
+	}
+	return nil
+}
+
+func (m *RegistrationEntry) GetRevisionNumber() int64 {
+	if m != nil {
+		return m.RevisionNumber
+	}
+	return 0
+}
+
+// RegistrationEntryMask selects the fields of a registration entry changed
+// by a partial update.
+type RegistrationEntryMask struct {
+	Selectors            bool     `protobuf:"varint,1,opt,name=selectors,proto3" json:"selectors,omitempty"`
+	ParentId             bool     `protobuf:"varint,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	SpiffeId             bool     `protobuf:"varint,3,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	Ttl                  bool     `protobuf:"varint,4,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	FederatesWith        bool     `protobuf:"varint,5,opt,name=federates_with,json=federatesWith,proto3" json:"federates_with,omitempty"`
+	Admin                bool     `protobuf:"varint,6,opt,name=admin,proto3" json:"admin,omitempty"`
+	Downstream           bool     `protobuf:"varint,7,opt,name=downstream,proto3" json:"downstream,omitempty"`
+	EntryExpiry          bool     `protobuf:"varint,8,opt,name=entry_expiry,json=entryExpiry,proto3" json:"entry_expiry,omitempty"`
+	DnsNames             bool     `protobuf:"varint,9,opt,name=dns_names,json=dnsNames,proto3" json:"dns_names,omitempty"`
+	X509SvidTemplate     bool     `protobuf:"varint,10,opt,name=x509_svid_template,json=x509SvidTemplate,proto3" json:"x509_svid_template,omitempty"`
+	JwtSvidTtl           bool     `protobuf:"varint,11,opt,name=jwt_svid_ttl,json=jwtSvidTtl,proto3" json:"jwt_svid_ttl,omitempty"`
+	JwtSvidClaims        bool     `protobuf:"varint,12,opt,name=jwt_svid_claims,json=jwtSvidClaims,proto3" json:"jwt_svid_claims,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RegistrationEntryMask) Reset()         { *m = RegistrationEntryMask{} }
+func (m *RegistrationEntryMask) String() string { return proto.CompactTextString(m) }
+func (*RegistrationEntryMask) ProtoMessage()    {}
+func (*RegistrationEntryMask) Descriptor() ([]byte, []int) {
+	return fileDescriptor_555bd8c177793206, []int{6}
+}
+
+func (m *RegistrationEntryMask) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegistrationEntryMask.Unmarshal(m, b)
+}
+func (m *RegistrationEntryMask) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegistrationEntryMask.Marshal(b, m, deterministic)
+}
+func (m *RegistrationEntryMask) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegistrationEntryMask.Merge(m, src)
+}
+func (m *RegistrationEntryMask) XXX_Size() int {
+	return xxx_messageInfo_RegistrationEntryMask.Size(m)
+}
+func (m *RegistrationEntryMask) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegistrationEntryMask.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RegistrationEntryMask proto.InternalMessageInfo
+
+func (m *RegistrationEntryMask) GetSelectors() bool {
+	if m != nil {
+		return m.Selectors
+	}
+	return false
+}
+
+func (m *RegistrationEntryMask) GetParentId() bool {
+	if m != nil {
+		return m.ParentId
+	}
+	return false
+}
+
+func (m *RegistrationEntryMask) GetSpiffeId() bool {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return false
+}
+
+func (m *RegistrationEntryMask) GetTtl() bool {
+	if m != nil {
+		return m.Ttl
+	}
+	return false
+}
+
+func (m *RegistrationEntryMask) GetFederatesWith() bool {
+	if m != nil {
+		return m.FederatesWith
+	}
+	return false
+}
+
+func (m *RegistrationEntryMask) GetAdmin() bool {
+	if m != nil {
+		return m.Admin
+	}
+	return false
+}
+
+func (m *RegistrationEntryMask) GetDownstream() bool {
+	if m != nil {
+		return m.Downstream
+	}
+	return false
+}
+
+func (m *RegistrationEntryMask) GetEntryExpiry() bool {
+	if m != nil {
+		return m.EntryExpiry
+	}
+	return false
+}
+
+func (m *RegistrationEntryMask) GetDnsNames() bool {
+	if m != nil {
+		return m.DnsNames
+	}
+	return false
+}
+
+func (m *RegistrationEntryMask) GetX509SvidTemplate() bool {
+	if m != nil {
+		return m.X509SvidTemplate
+	}
+	return false
+}
+
+func (m *RegistrationEntryMask) GetJwtSvidTtl() bool {
+	if m != nil {
+		return m.JwtSvidTtl
+	}
+	return false
+}
+
+func (m *RegistrationEntryMask) GetJwtSvidClaims() bool {
+	if m != nil {
+		return m.JwtSvidClaims
+	}
+	return false
+}
+
+// X509SVIDTemplate customizes the X509-SVIDs issued for a registration
+// entry. The URI SANs and the basic constraints of the SVID cannot be
+// changed, since an X509-SVID must have exactly one URI SAN, its SPIFFE ID.
+type X509SVIDTemplate struct {
+	// Subject of the X509-SVID. If unset, the default subject is used.
+	Subject *X509Subject `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	// Key usages added to the default ones. One of "content_commitment"
+	// or "data_encipherment".
+	ExtraKeyUsages []string `protobuf:"bytes,3,rep,name=extra_key_usages,json=extraKeyUsages,proto3" json:"extra_key_usages,omitempty"`
+	// Extended key usages added to the default ones. One of
+	// "code_signing", "email_protection" or "time_stamping".
+	ExtraExtKeyUsages    []string `protobuf:"bytes,4,rep,name=extra_ext_key_usages,json=extraExtKeyUsages,proto3" json:"extra_ext_key_usages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *X509SVIDTemplate) Reset()         { *m = X509SVIDTemplate{} }
+func (m *X509SVIDTemplate) String() string { return proto.CompactTextString(m) }
+func (*X509SVIDTemplate) ProtoMessage()    {}
+func (*X509SVIDTemplate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_555bd8c177793206, []int{7}
+}
+
+func (m *X509SVIDTemplate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_X509SVIDTemplate.Unmarshal(m, b)
+}
+func (m *X509SVIDTemplate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_X509SVIDTemplate.Marshal(b, m, deterministic)
+}
+func (m *X509SVIDTemplate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_X509SVIDTemplate.Merge(m, src)
+}
+func (m *X509SVIDTemplate) XXX_Size() int {
+	return xxx_messageInfo_X509SVIDTemplate.Size(m)
+}
+func (m *X509SVIDTemplate) XXX_DiscardUnknown() {
+	xxx_messageInfo_X509SVIDTemplate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_X509SVIDTemplate proto.InternalMessageInfo
+
+func (m *X509SVIDTemplate) GetSubject() *X509Subject {
+	if m != nil {
+		return m.Subject
+	}
+	return nil
+}
+
+func (m *X509SVIDTemplate) GetExtraKeyUsages() []string {
+	if m != nil {
+		return m.ExtraKeyUsages
+	}
+	return nil
+}
+
+func (m *X509SVIDTemplate) GetExtraExtKeyUsages() []string {
+	if m != nil {
+		return m.ExtraExtKeyUsages
+	}
+	return nil
+}
+
+// X509Subject is the subject of an X509 certificate
+type X509Subject struct {
+	Country            []string `protobuf:"bytes,1,rep,name=country,proto3" json:"country,omitempty"`
+	Organization       []string `protobuf:"bytes,2,rep,name=organization,proto3" json:"organization,omitempty"`
+	OrganizationalUnit []string `protobuf:"bytes,3,rep,name=organizational_unit,json=organizationalUnit,proto3" json:"organizational_unit,omitempty"`
+	Locality           []string `protobuf:"bytes,4,rep,name=locality,proto3" json:"locality,omitempty"`
+	Province           []string `protobuf:"bytes,5,rep,name=province,proto3" json:"province,omitempty"`
+	// Common name. If unset, the first DNS name of the entry is used.
+	CommonName           string   `protobuf:"bytes,6,opt,name=common_name,json=commonName,proto3" json:"common_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *X509Subject) Reset()         { *m = X509Subject{} }
+func (m *X509Subject) String() string { return proto.CompactTextString(m) }
+func (*X509Subject) ProtoMessage()    {}
+func (*X509Subject) Descriptor() ([]byte, []int) {
+	return fileDescriptor_555bd8c177793206, []int{8}
+}
+
+func (m *X509Subject) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_X509Subject.Unmarshal(m, b)
+}
+func (m *X509Subject) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_X509Subject.Marshal(b, m, deterministic)
+}
+func (m *X509Subject) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_X509Subject.Merge(m, src)
+}
+func (m *X509Subject) XXX_Size() int {
+	return xxx_messageInfo_X509Subject.Size(m)
+}
+func (m *X509Subject) XXX_DiscardUnknown() {
+	xxx_messageInfo_X509Subject.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_X509Subject proto.InternalMessageInfo
+
+func (m *X509Subject) GetCountry() []string {
+	if m != nil {
+		return m.Country
+	}
+	return nil
+}
+
+func (m *X509Subject) GetOrganization() []string {
+	if m != nil {
+		return m.Organization
+	}
+	return nil
+}
+
+func (m *X509Subject) GetOrganizationalUnit() []string {
+	if m != nil {
+		return m.OrganizationalUnit
+	}
+	return nil
+}
+
+func (m *X509Subject) GetLocality() []string {
+	if m != nil {
+		return m.Locality
+	}
+	return nil
+}
+
+func (m *X509Subject) GetProvince() []string {
+	if m != nil {
+		return m.Province
+	}
+	return nil
+}
+
+func (m *X509Subject) GetCommonName() string {
+	if m != nil {
+		return m.CommonName
+	}
+	return ""
+}
+
+// A list of registration entries.
 type RegistrationEntries struct {
-	//* A list of RegistrationEntry.
+	// A list of RegistrationEntry.
 	Entries              []*RegistrationEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
 	XXX_unrecognized     []byte               `json:"-"`
@@ -404,7 +736,7 @@ func (m *RegistrationEntries) Reset()         { *m = RegistrationEntries{} }
 func (m *RegistrationEntries) String() string { return proto.CompactTextString(m) }
 func (*RegistrationEntries) ProtoMessage()    {}
 func (*RegistrationEntries) Descriptor() ([]byte, []int) {
-	return fileDescriptor_555bd8c177793206, []int{6}
+	return fileDescriptor_555bd8c177793206, []int{9}
 }
 
 func (m *RegistrationEntries) XXX_Unmarshal(b []byte) error {
@@ -432,9 +764,12 @@ func (m *RegistrationEntries) GetEntries() []*RegistrationEntry {
 	return nil
 }
 
-//* Certificate represents a ASN.1/DER encoded X509 certificate
+// Certificate represents a ASN.1/DER encoded X509 certificate
 type Certificate struct {
-	DerBytes             []byte   `protobuf:"bytes,1,opt,name=der_bytes,json=derBytes,proto3" json:"der_bytes,omitempty"`
+	DerBytes []byte `protobuf:"bytes,1,opt,name=der_bytes,json=derBytes,proto3" json:"der_bytes,omitempty"`
+	// true if the CA key has been tainted by an operator. SVIDs signed by
+	// a tainted key should be rotated as soon as possible.
+	TaintedKey           bool     `protobuf:"varint,2,opt,name=tainted_key,json=taintedKey,proto3" json:"tainted_key,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -444,7 +779,7 @@ func (m *Certificate) Reset()         { *m = Certificate{} }
 func (m *Certificate) String() string { return proto.CompactTextString(m) }
 func (*Certificate) ProtoMessage()    {}
 func (*Certificate) Descriptor() ([]byte, []int) {
-	return fileDescriptor_555bd8c177793206, []int{7}
+	return fileDescriptor_555bd8c177793206, []int{10}
 }
 
 func (m *Certificate) XXX_Unmarshal(b []byte) error {
@@ -472,14 +807,27 @@ func (m *Certificate) GetDerBytes() []byte {
 	return nil
 }
 
-//* PublicKey represents a PKIX encoded public key
+func (m *Certificate) GetTaintedKey() bool {
+	if m != nil {
+		return m.TaintedKey
+	}
+	return false
+}
+
+// PublicKey represents a PKIX encoded public key
 type PublicKey struct {
-	//* PKIX encoded key data
+	// PKIX encoded key data
 	PkixBytes []byte `protobuf:"bytes,1,opt,name=pkix_bytes,json=pkixBytes,proto3" json:"pkix_bytes,omitempty"`
-	//* key identifier
+	// key identifier
 	Kid string `protobuf:"bytes,2,opt,name=kid,proto3" json:"kid,omitempty"`
-	//* not after (seconds since unix epoch, 0 means "never expires")
-	NotAfter             int64    `protobuf:"varint,3,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	// not after (seconds since unix epoch, 0 means "never expires")
+	NotAfter int64 `protobuf:"varint,3,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	// true if the key has been tainted by an operator. JWT-SVIDs signed by
+	// a tainted key should be rotated as soon as possible.
+	TaintedKey bool `protobuf:"varint,4,opt,name=tainted_key,json=taintedKey,proto3" json:"tainted_key,omitempty"`
+	// JWS algorithm of the JWT-SVIDs signed by the key (e.g. PS256). Empty
+	// if unknown, e.g. for keys published before it was recorded.
+	Alg                  string   `protobuf:"bytes,5,opt,name=alg,proto3" json:"alg,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -489,7 +837,7 @@ func (m *PublicKey) Reset()         { *m = PublicKey{} }
 func (m *PublicKey) String() string { return proto.CompactTextString(m) }
 func (*PublicKey) ProtoMessage()    {}
 func (*PublicKey) Descriptor() ([]byte, []int) {
-	return fileDescriptor_555bd8c177793206, []int{8}
+	return fileDescriptor_555bd8c177793206, []int{11}
 }
 
 func (m *PublicKey) XXX_Unmarshal(b []byte) error {
@@ -531,26 +879,52 @@ func (m *PublicKey) GetNotAfter() int64 {
 	return 0
 }
 
+func (m *PublicKey) GetTaintedKey() bool {
+	if m != nil {
+		return m.TaintedKey
+	}
+	return false
+}
+
+func (m *PublicKey) GetAlg() string {
+	if m != nil {
+		return m.Alg
+	}
+	return ""
+}
+
 type Bundle struct {
-	//* the SPIFFE ID of the trust domain the bundle belongs to
+	// the SPIFFE ID of the trust domain the bundle belongs to
 	TrustDomainId string `protobuf:"bytes,1,opt,name=trust_domain_id,json=trustDomainId,proto3" json:"trust_domain_id,omitempty"`
-	//* list of root CA certificates
+	// list of root CA certificates
 	RootCas []*Certificate `protobuf:"bytes,2,rep,name=root_cas,json=rootCas,proto3" json:"root_cas,omitempty"`
-	//* list of JWT signing keys
+	// list of JWT signing keys
 	JwtSigningKeys []*PublicKey `protobuf:"bytes,3,rep,name=jwt_signing_keys,json=jwtSigningKeys,proto3" json:"jwt_signing_keys,omitempty"`
-	//* refresh hint is a hint, in seconds, on how often a bundle consumer
+	// refresh hint is a hint, in seconds, on how often a bundle consumer
 	// should poll for bundle updates
-	RefreshHint          int64    `protobuf:"varint,4,opt,name=refresh_hint,json=refreshHint,proto3" json:"refresh_hint,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	RefreshHint int64 `protobuf:"varint,4,opt,name=refresh_hint,json=refreshHint,proto3" json:"refresh_hint,omitempty"`
+	// DER encoded certificate revocation lists listing revoked SVIDs and
+	// downstream CAs, one per X509 CA that may have issued unexpired SVIDs,
+	// each signed by that CA. Empty if no CRL has been published.
+	Crls [][]byte `protobuf:"bytes,5,rep,name=crls,proto3" json:"crls,omitempty"`
+	// revision of the datastore when the bundle was last created or
+	// updated. Set by the datastore; ignored on input.
+	RevisionNumber int64 `protobuf:"varint,6,opt,name=revision_number,json=revisionNumber,proto3" json:"revision_number,omitempty"`
+	// authority IDs (hex encoded subject key IDs) of tainted X509 CAs that
+	// are not root CAs in the bundle, e.g. intermediate CAs signed by an
+	// upstream root. Certificates issued by them are treated like
+	// certificates issued by a tainted root CA.
+	TaintedX509AuthorityIds []string `protobuf:"bytes,7,rep,name=tainted_x509_authority_ids,json=taintedX509AuthorityIds,proto3" json:"tainted_x509_authority_ids,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{} `json:"-"`
+	XXX_unrecognized        []byte   `json:"-"`
+	XXX_sizecache           int32    `json:"-"`
 }
 
 func (m *Bundle) Reset()         { *m = Bundle{} }
 func (m *Bundle) String() string { return proto.CompactTextString(m) }
 func (*Bundle) ProtoMessage()    {}
 func (*Bundle) Descriptor() ([]byte, []int) {
-	return fileDescriptor_555bd8c177793206, []int{9}
+	return fileDescriptor_555bd8c177793206, []int{12}
 }
 
 func (m *Bundle) XXX_Unmarshal(b []byte) error {
@@ -599,6 +973,27 @@ func (m *Bundle) GetRefreshHint() int64 {
 	return 0
 }
 
+func (m *Bundle) GetCrls() [][]byte {
+	if m != nil {
+		return m.Crls
+	}
+	return nil
+}
+
+func (m *Bundle) GetRevisionNumber() int64 {
+	if m != nil {
+		return m.RevisionNumber
+	}
+	return 0
+}
+
+func (m *Bundle) GetTaintedX509AuthorityIds() []string {
+	if m != nil {
+		return m.TaintedX509AuthorityIds
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Empty)(nil), "spire.common.Empty")
 	proto.RegisterType((*AttestationData)(nil), "spire.common.AttestationData")
@@ -606,6 +1001,10 @@ func init() {
 	proto.RegisterType((*Selectors)(nil), "spire.common.Selectors")
 	proto.RegisterType((*AttestedNode)(nil), "spire.common.AttestedNode")
 	proto.RegisterType((*RegistrationEntry)(nil), "spire.common.RegistrationEntry")
+	proto.RegisterMapType((map[string]string)(nil), "spire.common.RegistrationEntry.JwtSvidClaimsEntry")
+	proto.RegisterType((*RegistrationEntryMask)(nil), "spire.common.RegistrationEntryMask")
+	proto.RegisterType((*X509SVIDTemplate)(nil), "spire.common.X509SVIDTemplate")
+	proto.RegisterType((*X509Subject)(nil), "spire.common.X509Subject")
 	proto.RegisterType((*RegistrationEntries)(nil), "spire.common.RegistrationEntries")
 	proto.RegisterType((*Certificate)(nil), "spire.common.Certificate")
 	proto.RegisterType((*PublicKey)(nil), "spire.common.PublicKey")
@@ -615,47 +1014,78 @@ func init() {
 func init() { proto.RegisterFile("common.proto", fileDescriptor_555bd8c177793206) }
 
 var fileDescriptor_555bd8c177793206 = []byte{
-	// 657 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x54, 0xdb, 0x6e, 0xdb, 0x38,
-	0x10, 0x85, 0xa2, 0x38, 0x96, 0xc6, 0xce, 0x65, 0x99, 0xbd, 0x28, 0x58, 0xec, 0xae, 0x57, 0xd8,
-	0x2d, 0x8c, 0x20, 0x70, 0x8a, 0x34, 0x2f, 0x79, 0xe8, 0x43, 0x6e, 0x40, 0x8d, 0x00, 0x46, 0xa0,
-	0x14, 0x28, 0xda, 0x17, 0x81, 0x36, 0xc7, 0x36, 0x13, 0x8b, 0x12, 0xc8, 0x71, 0x1d, 0x7d, 0x57,
-	0x7f, 0xa3, 0x1f, 0x55, 0x90, 0xf2, 0x35, 0x2d, 0xd0, 0xb7, 0xe1, 0xd1, 0xcc, 0xf0, 0x9c, 0xc3,
-	0x03, 0x41, 0x73, 0x90, 0x67, 0x59, 0xae, 0x3a, 0x85, 0xce, 0x29, 0x67, 0x4d, 0x53, 0x48, 0x8d,
-	0x9d, 0x0a, 0x8b, 0xeb, 0x50, 0xbb, 0xcd, 0x0a, 0x2a, 0xe3, 0x0b, 0xd8, 0xbf, 0x24, 0x42, 0x43,
-	0x9c, 0x64, 0xae, 0x6e, 0x38, 0x71, 0xc6, 0x60, 0x9b, 0xca, 0x02, 0x23, 0xaf, 0xe5, 0xb5, 0xc3,
-	0xc4, 0xd5, 0x16, 0x13, 0x9c, 0x78, 0xb4, 0xd5, 0xf2, 0xda, 0xcd, 0xc4, 0xd5, 0xf1, 0x39, 0x04,
-	0x0f, 0x38, 0xc1, 0x01, 0xe5, 0xfa, 0x87, 0x33, 0xbf, 0x42, 0xed, 0x33, 0x9f, 0x4c, 0xd1, 0x0d,
-	0x85, 0x49, 0x75, 0x88, 0xdf, 0x42, 0xb8, 0x98, 0x32, 0xec, 0x35, 0xd4, 0x51, 0x91, 0x96, 0x68,
-	0x22, 0xaf, 0xe5, 0xb7, 0x1b, 0x67, 0xbf, 0x77, 0xd6, 0x69, 0x76, 0x16, 0x9d, 0xc9, 0xa2, 0x2d,
-	0xfe, 0xe2, 0x41, 0xb3, 0x22, 0x8c, 0xa2, 0x97, 0x0b, 0x64, 0x7f, 0x42, 0x68, 0x0a, 0x39, 0x1c,
-	0x62, 0x2a, 0xc5, 0xfc, 0xfa, 0xa0, 0x02, 0xba, 0x82, 0x9d, 0xc1, 0x6f, 0x7c, 0xa5, 0x2e, 0xb5,
-	0xb4, 0x53, 0xc7, 0xb3, 0xa2, 0x74, 0xc8, 0x37, 0xa5, 0xbf, 0xb7, 0xb4, 0x4f, 0x80, 0x0d, 0x50,
-	0x53, 0x6a, 0x50, 0x4b, 0x3e, 0x49, 0xd5, 0x34, 0xeb, 0xa3, 0x8e, 0x7c, 0x37, 0x70, 0x60, 0xbf,
-	0x3c, 0xb8, 0x0f, 0x3d, 0x87, 0xb3, 0xff, 0x60, 0xcf, 0x75, 0xab, 0x9c, 0x52, 0x3e, 0x24, 0xd4,
-	0xd1, 0x76, 0xcb, 0x6b, 0xfb, 0x49, 0xd3, 0xa2, 0xbd, 0x9c, 0x2e, 0x2d, 0x16, 0x7f, 0xdd, 0x82,
-	0x5f, 0x12, 0x1c, 0x49, 0x43, 0xda, 0x5d, 0x76, 0xab, 0x48, 0x97, 0xec, 0x1c, 0x42, 0xb3, 0xb0,
-	0xe2, 0x27, 0xfa, 0x57, 0x8d, 0x56, 0x70, 0xc1, 0x35, 0x2a, 0xb2, 0x82, 0x2b, 0x1d, 0x41, 0x05,
-	0x74, 0xc5, 0xa6, 0x1b, 0xfe, 0x0b, 0x37, 0x0e, 0xc0, 0x27, 0x9a, 0x38, 0x82, 0xb5, 0xc4, 0x96,
-	0xec, 0x7f, 0xd8, 0x1b, 0xa2, 0x40, 0xcd, 0x09, 0x4d, 0x3a, 0x93, 0x34, 0x8e, 0x6a, 0x2d, 0xbf,
-	0x1d, 0x26, 0xbb, 0x4b, 0xf4, 0x83, 0xa4, 0x31, 0x3b, 0x82, 0xc0, 0xfa, 0x5f, 0xda, 0xa5, 0x3b,
-	0x6e, 0xa9, 0x7b, 0x8f, 0xb2, 0x2b, 0xec, 0x23, 0x73, 0x91, 0x49, 0x15, 0xd5, 0x5b, 0x5e, 0x3b,
-	0x48, 0xaa, 0x03, 0xfb, 0x1b, 0x40, 0xe4, 0x33, 0x65, 0x48, 0x23, 0xcf, 0xa2, 0xc0, 0x7d, 0x5a,
-	0x43, 0x58, 0x0b, 0x1a, 0x6e, 0xc1, 0xed, 0x73, 0x21, 0x75, 0x19, 0x85, 0xce, 0xb2, 0x75, 0xc8,
-	0x0a, 0x11, 0xca, 0xa4, 0x8a, 0x67, 0x68, 0x22, 0x70, 0xa4, 0x02, 0xa1, 0x4c, 0xcf, 0x9e, 0xe3,
-	0x7b, 0x38, 0x7c, 0xe9, 0xa6, 0x44, 0xc3, 0x2e, 0x5e, 0xa6, 0xe9, 0x9f, 0x4d, 0x37, 0xbf, 0x7b,
-	0x81, 0x55, 0xac, 0x8e, 0xa1, 0x71, 0x8d, 0x9a, 0xe4, 0x50, 0x0e, 0x38, 0xb9, 0x50, 0x09, 0xd4,
-	0x69, 0xbf, 0x24, 0xb7, 0xcb, 0x66, 0x3e, 0x10, 0xa8, 0xaf, 0xec, 0x39, 0xfe, 0x08, 0xe1, 0xfd,
-	0xb4, 0x3f, 0x91, 0x83, 0x3b, 0x2c, 0xd9, 0x5f, 0x00, 0xc5, 0x93, 0x7c, 0xde, 0x68, 0x0d, 0x2d,
-	0xe2, 0x7a, 0xad, 0xe5, 0x4f, 0xcb, 0x67, 0xb2, 0xa5, 0x5d, 0xbd, 0xca, 0x8a, 0xef, 0x84, 0x07,
-	0x6a, 0x99, 0x13, 0x0f, 0x76, 0xae, 0xa6, 0x4a, 0x4c, 0x90, 0xbd, 0x82, 0x7d, 0xd2, 0x53, 0x43,
-	0xa9, 0xc8, 0x33, 0x2e, 0xd5, 0x2a, 0xdd, 0xbb, 0x0e, 0xbe, 0x71, 0x68, 0x57, 0xb0, 0x73, 0x08,
-	0x74, 0x9e, 0x53, 0x3a, 0xe0, 0x26, 0xda, 0x72, 0xaa, 0x8f, 0x36, 0x55, 0xaf, 0xe9, 0x4a, 0xea,
-	0xb6, 0xf5, 0x9a, 0x1b, 0x76, 0x09, 0x07, 0x8f, 0x33, 0x4a, 0x8d, 0x1c, 0x29, 0xa9, 0x46, 0xe9,
-	0x13, 0x96, 0x26, 0xf2, 0xdd, 0xf4, 0x1f, 0x9b, 0xd3, 0x4b, 0xa5, 0xc9, 0xde, 0xe3, 0x8c, 0x1e,
-	0xaa, 0xfe, 0x3b, 0x2c, 0x0d, 0xfb, 0x17, 0x9a, 0x1a, 0x87, 0x1a, 0xcd, 0x38, 0x1d, 0x4b, 0x45,
-	0xf3, 0xdc, 0x37, 0xe6, 0xd8, 0x3b, 0xa9, 0xe8, 0xea, 0xe4, 0xd3, 0xf1, 0x48, 0xd2, 0x78, 0xda,
-	0xb7, 0xdb, 0x4e, 0xab, 0x1c, 0x9e, 0xba, 0xf5, 0xa7, 0xee, 0xa7, 0x34, 0xaf, 0xab, 0xab, 0xfa,
-	0x3b, 0x0e, 0x7b, 0xf3, 0x2d, 0x00, 0x00, 0xff, 0xff, 0x21, 0xfa, 0x61, 0xe5, 0xb8, 0x04, 0x00,
+	// 1153 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0xdb, 0x6e, 0xdb, 0x46,
+	0x13, 0x06, 0x2d, 0xdb, 0x22, 0x47, 0xb4, 0xa3, 0x6c, 0x4e, 0x4c, 0xf2, 0xff, 0x89, 0x4a, 0xf4,
+	0x20, 0x14, 0x81, 0x1d, 0x28, 0x09, 0x50, 0xb7, 0x28, 0x50, 0x27, 0x31, 0x50, 0xc5, 0xad, 0x11,
+	0xd0, 0x49, 0x5b, 0xe4, 0x86, 0x58, 0x91, 0x2b, 0x69, 0x6d, 0x6a, 0x29, 0xec, 0x0e, 0x6d, 0xb1,
+	0x77, 0x45, 0x6f, 0x7b, 0xdd, 0x07, 0xe9, 0x43, 0xf4, 0x0d, 0xfa, 0x3e, 0xc5, 0xee, 0x52, 0xb2,
+	0x4e, 0x49, 0x7a, 0xb7, 0xf3, 0xcd, 0x2c, 0x77, 0x0e, 0xdf, 0xcc, 0x10, 0xfc, 0x24, 0x1f, 0x8d,
+	0x72, 0xb1, 0x37, 0x96, 0x39, 0xe6, 0xc4, 0x57, 0x63, 0x2e, 0xd9, 0x9e, 0xc5, 0xc2, 0x3a, 0x6c,
+	0x1d, 0x8d, 0xc6, 0x58, 0x86, 0x07, 0x70, 0xed, 0x10, 0x91, 0x29, 0xa4, 0xc8, 0x73, 0xf1, 0x92,
+	0x22, 0x25, 0x04, 0x36, 0xb1, 0x1c, 0xb3, 0xc0, 0x69, 0x39, 0x6d, 0x2f, 0x32, 0x67, 0x8d, 0xa5,
+	0x14, 0x69, 0xb0, 0xd1, 0x72, 0xda, 0x7e, 0x64, 0xce, 0xe1, 0x53, 0x70, 0x4f, 0x59, 0xc6, 0x12,
+	0xcc, 0xe5, 0xda, 0x3b, 0x37, 0x61, 0xeb, 0x82, 0x66, 0x05, 0x33, 0x97, 0xbc, 0xc8, 0x0a, 0xe1,
+	0xb7, 0xe0, 0x4d, 0x6f, 0x29, 0xf2, 0x18, 0xea, 0x4c, 0xa0, 0xe4, 0x4c, 0x05, 0x4e, 0xab, 0xd6,
+	0x6e, 0x74, 0x6e, 0xef, 0xcd, 0xbb, 0xb9, 0x37, 0xb5, 0x8c, 0xa6, 0x66, 0xe1, 0x6f, 0x1b, 0xe0,
+	0x5b, 0x87, 0x59, 0x7a, 0x92, 0xa7, 0x8c, 0xdc, 0x07, 0x4f, 0x8d, 0x79, 0xbf, 0xcf, 0x62, 0x9e,
+	0x56, 0xcf, 0xbb, 0x16, 0xe8, 0xa6, 0xa4, 0x03, 0xb7, 0xe8, 0x55, 0x74, 0xb1, 0x76, 0x3b, 0x36,
+	0x7e, 0x5a, 0x97, 0x6e, 0xd0, 0xc5, 0xd0, 0xdf, 0x68, 0xb7, 0x1f, 0x01, 0x49, 0x98, 0xc4, 0x58,
+	0x31, 0xc9, 0x69, 0x16, 0x8b, 0x62, 0xd4, 0x63, 0x32, 0xa8, 0x99, 0x0b, 0x4d, 0xad, 0x39, 0x35,
+	0x8a, 0x13, 0x83, 0x93, 0x4f, 0x61, 0xd7, 0x58, 0x8b, 0x1c, 0x63, 0xda, 0x47, 0x26, 0x83, 0xcd,
+	0x96, 0xd3, 0xae, 0x45, 0xbe, 0x46, 0x4f, 0x72, 0x3c, 0xd4, 0x18, 0x79, 0x0a, 0x9e, 0x9a, 0x06,
+	0x1d, 0x6c, 0x7d, 0x30, 0xd2, 0x2b, 0x43, 0x72, 0x1b, 0xb6, 0x7b, 0x54, 0x08, 0x96, 0x06, 0xdb,
+	0x2d, 0xa7, 0xed, 0x46, 0x95, 0x14, 0xfe, 0xbe, 0x05, 0xd7, 0x23, 0x36, 0xe0, 0x0a, 0xa5, 0x71,
+	0xfd, 0x48, 0xa0, 0x2c, 0x17, 0xdf, 0x70, 0xfe, 0xeb, 0x1b, 0xf7, 0xc1, 0x1b, 0x53, 0xc9, 0x04,
+	0xea, 0xf4, 0xd9, 0xac, 0xb8, 0x16, 0xe8, 0xa6, 0x8b, 0xb9, 0xad, 0x2d, 0xe5, 0xb6, 0x09, 0x35,
+	0xc4, 0xcc, 0x84, 0xbb, 0x15, 0xe9, 0x23, 0xf9, 0x0c, 0x76, 0xfb, 0x2c, 0x65, 0x92, 0x22, 0x53,
+	0xf1, 0x25, 0xc7, 0xa1, 0x09, 0xd5, 0x8b, 0x76, 0x66, 0xe8, 0xcf, 0x1c, 0x87, 0xe4, 0x2e, 0xb8,
+	0xba, 0x9a, 0x65, 0xcc, 0x6d, 0x60, 0x9e, 0xad, 0x6e, 0xd9, 0x4d, 0x35, 0x65, 0x68, 0x3a, 0xe2,
+	0x22, 0xa8, 0x9b, 0x80, 0xad, 0x40, 0x1e, 0x00, 0xa4, 0xf9, 0xa5, 0x50, 0x28, 0x19, 0x1d, 0x05,
+	0xae, 0x51, 0xcd, 0x21, 0xa4, 0x05, 0x0d, 0xf3, 0x81, 0xa3, 0xc9, 0x98, 0xcb, 0x32, 0xf0, 0x4c,
+	0x01, 0xe6, 0x21, 0x1d, 0x48, 0x2a, 0x54, 0x2c, 0xe8, 0x88, 0xa9, 0x00, 0x8c, 0x53, 0x6e, 0x2a,
+	0xd4, 0x89, 0x96, 0xc9, 0x0f, 0x40, 0x26, 0xcf, 0x1e, 0x1f, 0xc4, 0xea, 0x82, 0xa7, 0x31, 0xb2,
+	0xd1, 0x38, 0xa3, 0xc8, 0x82, 0x46, 0xcb, 0x69, 0x37, 0x3a, 0x0f, 0x16, 0x33, 0xf8, 0xcb, 0xb3,
+	0xc7, 0x07, 0xa7, 0x3f, 0x75, 0x5f, 0xbe, 0xa9, 0xac, 0xa2, 0xa6, 0xbe, 0x79, 0x7a, 0xc1, 0xd3,
+	0x29, 0x42, 0x5a, 0xe0, 0x9f, 0x5d, 0x62, 0xf5, 0x31, 0xcc, 0x02, 0xdf, 0xe4, 0x07, 0xce, 0x2e,
+	0xd1, 0x98, 0x61, 0x46, 0xde, 0xc1, 0xb5, 0x99, 0x45, 0x92, 0x51, 0x3e, 0x52, 0xc1, 0x8e, 0x29,
+	0x57, 0x67, 0xf1, 0xb1, 0x95, 0x12, 0xef, 0xbd, 0xb2, 0x1f, 0x79, 0x61, 0x2e, 0x19, 0x28, 0xda,
+	0x39, 0x9b, 0xc7, 0xc8, 0x17, 0x70, 0x4d, 0xb2, 0x0b, 0xae, 0x34, 0xdb, 0x2b, 0xe6, 0xee, 0x9a,
+	0x74, 0xec, 0x4e, 0x61, 0xcb, 0xdb, 0x7b, 0xdf, 0x01, 0x59, 0xfd, 0x9a, 0xae, 0xe9, 0x39, 0x2b,
+	0xab, 0x36, 0xd2, 0xc7, 0xf5, 0x4d, 0xfc, 0xf5, 0xc6, 0x57, 0x4e, 0xf8, 0x67, 0x0d, 0x6e, 0xad,
+	0xb8, 0xf8, 0x23, 0x55, 0xe7, 0xe4, 0x7f, 0x8b, 0x4c, 0xd4, 0xe5, 0xfa, 0x10, 0xe3, 0xdc, 0x0f,
+	0x31, 0xce, 0x5d, 0xcf, 0x38, 0xf7, 0xfd, 0x8c, 0xd3, 0xca, 0x25, 0xc6, 0xcd, 0x68, 0xb5, 0xfd,
+	0x7e, 0x5a, 0xd5, 0x57, 0x68, 0xf5, 0x09, 0xf8, 0x96, 0xa7, 0xcc, 0xf2, 0xca, 0x12, 0xef, 0xfd,
+	0xbc, 0xf2, 0xac, 0xbb, 0x33, 0x5e, 0x3d, 0x5a, 0xcb, 0x2b, 0x30, 0x56, 0x1f, 0xe7, 0x4d, 0xc3,
+	0xfa, 0x33, 0xc7, 0x9b, 0xcf, 0x57, 0x79, 0xe3, 0xdb, 0x68, 0x17, 0x38, 0x10, 0xfe, 0xe5, 0x40,
+	0x73, 0x99, 0xa8, 0xe4, 0x09, 0xd4, 0x55, 0xd1, 0x3b, 0x63, 0x09, 0x9a, 0x8a, 0x34, 0x3a, 0x77,
+	0xd7, 0x30, 0xdb, 0x1a, 0x44, 0x53, 0x4b, 0xd2, 0x86, 0x26, 0x9b, 0xa0, 0xa4, 0xf1, 0x39, 0x2b,
+	0xe3, 0x42, 0xd1, 0x01, 0x53, 0x41, 0xcd, 0x74, 0xcf, 0xae, 0xc1, 0x8f, 0x59, 0xf9, 0xd6, 0xa0,
+	0x64, 0x1f, 0x6e, 0x5a, 0x4b, 0x36, 0xc1, 0x79, 0xeb, 0x4d, 0x63, 0x7d, 0xdd, 0xe8, 0x8e, 0x26,
+	0x38, 0xbb, 0xf0, 0x6a, 0xd3, 0xdd, 0x68, 0xd6, 0x22, 0xb7, 0x90, 0x3c, 0x56, 0x54, 0xa8, 0xf0,
+	0x1f, 0x07, 0x1a, 0x73, 0x3e, 0x90, 0x00, 0xea, 0x49, 0x5e, 0xe8, 0x54, 0x9b, 0x59, 0xe6, 0x45,
+	0x53, 0x91, 0x84, 0xe0, 0xe7, 0x72, 0x40, 0x05, 0xff, 0xd5, 0xd0, 0x2e, 0xd8, 0x30, 0xea, 0x05,
+	0x8c, 0xec, 0xc3, 0x8d, 0x79, 0x99, 0x66, 0x71, 0x21, 0x38, 0x56, 0xbe, 0x93, 0x45, 0xd5, 0x5b,
+	0xc1, 0x91, 0xdc, 0x03, 0x37, 0xcb, 0x13, 0x9a, 0x71, 0x2c, 0x2b, 0x9f, 0x67, 0xb2, 0xd6, 0x8d,
+	0x65, 0x7e, 0xc1, 0x45, 0xc2, 0xaa, 0x81, 0x36, 0x93, 0xc9, 0x43, 0x68, 0xd8, 0x04, 0x1a, 0x0e,
+	0x54, 0xe3, 0x0c, 0x2c, 0xa4, 0x59, 0x10, 0xbe, 0x86, 0x1b, 0xcb, 0x4d, 0xc2, 0x99, 0x22, 0x07,
+	0xcb, 0x8b, 0xef, 0xe1, 0x47, 0x7a, 0xff, 0x6a, 0x03, 0x1e, 0x43, 0xe3, 0x05, 0x93, 0xc8, 0xfb,
+	0x3c, 0xd1, 0x85, 0xd5, 0x14, 0x64, 0x32, 0xee, 0x95, 0xc8, 0x6c, 0xb3, 0xf9, 0x91, 0x9b, 0x32,
+	0xf9, 0x5c, 0xcb, 0xda, 0x3d, 0xa4, 0x5c, 0x20, 0x4b, 0x75, 0x51, 0xaa, 0x6e, 0x83, 0x0a, 0x3a,
+	0x66, 0x65, 0xf8, 0x87, 0x03, 0xde, 0xeb, 0xa2, 0x97, 0xf1, 0xe4, 0x98, 0x95, 0xe4, 0xff, 0x00,
+	0xe3, 0x73, 0x3e, 0x59, 0xf8, 0x98, 0xa7, 0x11, 0xfb, 0x35, 0x3d, 0x1d, 0x66, 0x5b, 0x42, 0x1f,
+	0xf5, 0xe3, 0x57, 0x8b, 0xaf, 0x66, 0x06, 0x8d, 0x2b, 0xa6, 0x4b, 0x6f, 0xe9, 0xf1, 0xcd, 0xe5,
+	0xc7, 0xf5, 0xf7, 0x68, 0x36, 0x30, 0x2d, 0xeb, 0x45, 0xfa, 0x18, 0xfe, 0xbd, 0x01, 0xdb, 0xcf,
+	0x0b, 0x91, 0x66, 0x4c, 0xb3, 0x1d, 0x65, 0xa1, 0x30, 0x4e, 0xf3, 0x11, 0xe5, 0xe2, 0x6a, 0xbb,
+	0xef, 0x18, 0xf8, 0xa5, 0x41, 0xbb, 0x29, 0x79, 0x0a, 0xae, 0xcc, 0x73, 0x8c, 0x13, 0xaa, 0x0c,
+	0x15, 0x56, 0x98, 0x3d, 0x97, 0xac, 0xa8, 0xae, 0x4d, 0x5f, 0x50, 0x45, 0x0e, 0xa1, 0x69, 0x7a,
+	0x89, 0x0f, 0x04, 0x17, 0x03, 0xed, 0x9f, 0x65, 0x76, 0xa3, 0x73, 0x67, 0xf1, 0xf6, 0x2c, 0x39,
+	0xd1, 0xae, 0xee, 0x32, 0x6b, 0x7f, 0xcc, 0x4a, 0xa5, 0xc7, 0x83, 0x64, 0x7d, 0xc9, 0xd4, 0x30,
+	0x1e, 0x72, 0x81, 0xd5, 0xde, 0x6f, 0x54, 0xd8, 0xf7, 0x5c, 0xa0, 0xfe, 0x2b, 0x4a, 0x64, 0x66,
+	0x37, 0xbe, 0x1f, 0x99, 0xf3, 0xba, 0x09, 0xbd, 0xbd, 0x6e, 0x42, 0x93, 0x6f, 0xe0, 0xde, 0x34,
+	0x7d, 0x66, 0x8c, 0xd0, 0x02, 0x87, 0xb9, 0xe4, 0xa8, 0xf7, 0xa6, 0x0a, 0xea, 0x86, 0x88, 0x77,
+	0x2a, 0x0b, 0xdd, 0x39, 0x87, 0x53, 0x7d, 0x37, 0x55, 0xcf, 0x1f, 0xbd, 0xfb, 0x72, 0xc0, 0x71,
+	0x58, 0xf4, 0x74, 0x1c, 0xfb, 0x76, 0x82, 0xee, 0x9b, 0xc0, 0xf6, 0xcd, 0xef, 0x60, 0x75, 0xb6,
+	0x41, 0xf6, 0xb6, 0x0d, 0xf6, 0xe4, 0xdf, 0x01, 0x00, 0x7b, 0x8c, 0x66, 0xf7, 0x32, 0x0a, 0x00,
 	0x00,
 }
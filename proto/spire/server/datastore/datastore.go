@@ -17,9 +17,16 @@ const (
 
 // DataStore is the client interface for the service type DataStore interface.
 type DataStore interface {
+	AcquireLease(context.Context, *AcquireLeaseRequest) (*AcquireLeaseResponse, error)
 	AppendBundle(context.Context, *AppendBundleRequest) (*AppendBundleResponse, error)
+	BatchCreateRegistrationEntries(context.Context, *BatchCreateRegistrationEntriesRequest) (*BatchCreateRegistrationEntriesResponse, error)
+	BatchDeleteRegistrationEntries(context.Context, *BatchDeleteRegistrationEntriesRequest) (*BatchDeleteRegistrationEntriesResponse, error)
+	BatchUpdateRegistrationEntries(context.Context, *BatchUpdateRegistrationEntriesRequest) (*BatchUpdateRegistrationEntriesResponse, error)
+	ConsumeJoinToken(context.Context, *ConsumeJoinTokenRequest) (*ConsumeJoinTokenResponse, error)
 	CreateAttestedNode(context.Context, *CreateAttestedNodeRequest) (*CreateAttestedNodeResponse, error)
 	CreateBundle(context.Context, *CreateBundleRequest) (*CreateBundleResponse, error)
+	CreateDownstreamCA(context.Context, *CreateDownstreamCARequest) (*CreateDownstreamCAResponse, error)
+	CreateIssuedSVID(context.Context, *CreateIssuedSVIDRequest) (*CreateIssuedSVIDResponse, error)
 	CreateJoinToken(context.Context, *CreateJoinTokenRequest) (*CreateJoinTokenResponse, error)
 	CreateRegistrationEntry(context.Context, *CreateRegistrationEntryRequest) (*CreateRegistrationEntryResponse, error)
 	DeleteAttestedNode(context.Context, *DeleteAttestedNodeRequest) (*DeleteAttestedNodeResponse, error)
@@ -28,16 +35,34 @@ type DataStore interface {
 	DeleteRegistrationEntry(context.Context, *DeleteRegistrationEntryRequest) (*DeleteRegistrationEntryResponse, error)
 	FetchAttestedNode(context.Context, *FetchAttestedNodeRequest) (*FetchAttestedNodeResponse, error)
 	FetchBundle(context.Context, *FetchBundleRequest) (*FetchBundleResponse, error)
+	FetchCAJournal(context.Context, *FetchCAJournalRequest) (*FetchCAJournalResponse, error)
 	FetchJoinToken(context.Context, *FetchJoinTokenRequest) (*FetchJoinTokenResponse, error)
 	FetchRegistrationEntry(context.Context, *FetchRegistrationEntryRequest) (*FetchRegistrationEntryResponse, error)
+	FetchRevokedCertificate(context.Context, *FetchRevokedCertificateRequest) (*FetchRevokedCertificateResponse, error)
 	GetNodeSelectors(context.Context, *GetNodeSelectorsRequest) (*GetNodeSelectorsResponse, error)
 	ListAttestedNodes(context.Context, *ListAttestedNodesRequest) (*ListAttestedNodesResponse, error)
 	ListBundles(context.Context, *ListBundlesRequest) (*ListBundlesResponse, error)
+	ListDownstreamCAs(context.Context, *ListDownstreamCAsRequest) (*ListDownstreamCAsResponse, error)
+	ListIssuedSVIDs(context.Context, *ListIssuedSVIDsRequest) (*ListIssuedSVIDsResponse, error)
+	ListJoinTokens(context.Context, *ListJoinTokensRequest) (*ListJoinTokensResponse, error)
+	ListNodeSelectors(context.Context, *ListNodeSelectorsRequest) (*ListNodeSelectorsResponse, error)
 	ListRegistrationEntries(context.Context, *ListRegistrationEntriesRequest) (*ListRegistrationEntriesResponse, error)
+	ListRegistrationEntryEvents(context.Context, *ListRegistrationEntryEventsRequest) (*ListRegistrationEntryEventsResponse, error)
+	ListRegistrationEntryTombstones(context.Context, *ListRegistrationEntryTombstonesRequest) (*ListRegistrationEntryTombstonesResponse, error)
+	ListRevokedCertificates(context.Context, *ListRevokedCertificatesRequest) (*ListRevokedCertificatesResponse, error)
 	PruneBundle(context.Context, *PruneBundleRequest) (*PruneBundleResponse, error)
+	PruneDownstreamCAs(context.Context, *PruneDownstreamCAsRequest) (*PruneDownstreamCAsResponse, error)
+	PruneIssuedSVIDs(context.Context, *PruneIssuedSVIDsRequest) (*PruneIssuedSVIDsResponse, error)
 	PruneJoinTokens(context.Context, *PruneJoinTokensRequest) (*PruneJoinTokensResponse, error)
 	PruneRegistrationEntries(context.Context, *PruneRegistrationEntriesRequest) (*PruneRegistrationEntriesResponse, error)
+	PruneRegistrationEntryEvents(context.Context, *PruneRegistrationEntryEventsRequest) (*PruneRegistrationEntryEventsResponse, error)
+	PruneRegistrationEntryTombstones(context.Context, *PruneRegistrationEntryTombstonesRequest) (*PruneRegistrationEntryTombstonesResponse, error)
+	PruneRevokedCertificates(context.Context, *PruneRevokedCertificatesRequest) (*PruneRevokedCertificatesResponse, error)
+	ReleaseLease(context.Context, *ReleaseLeaseRequest) (*ReleaseLeaseResponse, error)
+	RevokeCertificate(context.Context, *RevokeCertificateRequest) (*RevokeCertificateResponse, error)
+	SetAttestedNodeBanned(context.Context, *SetAttestedNodeBannedRequest) (*SetAttestedNodeBannedResponse, error)
 	SetBundle(context.Context, *SetBundleRequest) (*SetBundleResponse, error)
+	SetCAJournal(context.Context, *SetCAJournalRequest) (*SetCAJournalResponse, error)
 	SetNodeSelectors(context.Context, *SetNodeSelectorsRequest) (*SetNodeSelectorsResponse, error)
 	UpdateAttestedNode(context.Context, *UpdateAttestedNodeRequest) (*UpdateAttestedNodeResponse, error)
 	UpdateBundle(context.Context, *UpdateBundleRequest) (*UpdateBundleResponse, error)
@@ -46,10 +71,17 @@ type DataStore interface {
 
 // Plugin is the client interface for the service with the plugin related methods used by the catalog to initialize the plugin.
 type Plugin interface {
+	AcquireLease(context.Context, *AcquireLeaseRequest) (*AcquireLeaseResponse, error)
 	AppendBundle(context.Context, *AppendBundleRequest) (*AppendBundleResponse, error)
+	BatchCreateRegistrationEntries(context.Context, *BatchCreateRegistrationEntriesRequest) (*BatchCreateRegistrationEntriesResponse, error)
+	BatchDeleteRegistrationEntries(context.Context, *BatchDeleteRegistrationEntriesRequest) (*BatchDeleteRegistrationEntriesResponse, error)
+	BatchUpdateRegistrationEntries(context.Context, *BatchUpdateRegistrationEntriesRequest) (*BatchUpdateRegistrationEntriesResponse, error)
 	Configure(context.Context, *spi.ConfigureRequest) (*spi.ConfigureResponse, error)
+	ConsumeJoinToken(context.Context, *ConsumeJoinTokenRequest) (*ConsumeJoinTokenResponse, error)
 	CreateAttestedNode(context.Context, *CreateAttestedNodeRequest) (*CreateAttestedNodeResponse, error)
 	CreateBundle(context.Context, *CreateBundleRequest) (*CreateBundleResponse, error)
+	CreateDownstreamCA(context.Context, *CreateDownstreamCARequest) (*CreateDownstreamCAResponse, error)
+	CreateIssuedSVID(context.Context, *CreateIssuedSVIDRequest) (*CreateIssuedSVIDResponse, error)
 	CreateJoinToken(context.Context, *CreateJoinTokenRequest) (*CreateJoinTokenResponse, error)
 	CreateRegistrationEntry(context.Context, *CreateRegistrationEntryRequest) (*CreateRegistrationEntryResponse, error)
 	DeleteAttestedNode(context.Context, *DeleteAttestedNodeRequest) (*DeleteAttestedNodeResponse, error)
@@ -58,17 +90,35 @@ type Plugin interface {
 	DeleteRegistrationEntry(context.Context, *DeleteRegistrationEntryRequest) (*DeleteRegistrationEntryResponse, error)
 	FetchAttestedNode(context.Context, *FetchAttestedNodeRequest) (*FetchAttestedNodeResponse, error)
 	FetchBundle(context.Context, *FetchBundleRequest) (*FetchBundleResponse, error)
+	FetchCAJournal(context.Context, *FetchCAJournalRequest) (*FetchCAJournalResponse, error)
 	FetchJoinToken(context.Context, *FetchJoinTokenRequest) (*FetchJoinTokenResponse, error)
 	FetchRegistrationEntry(context.Context, *FetchRegistrationEntryRequest) (*FetchRegistrationEntryResponse, error)
+	FetchRevokedCertificate(context.Context, *FetchRevokedCertificateRequest) (*FetchRevokedCertificateResponse, error)
 	GetNodeSelectors(context.Context, *GetNodeSelectorsRequest) (*GetNodeSelectorsResponse, error)
 	GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi.GetPluginInfoResponse, error)
 	ListAttestedNodes(context.Context, *ListAttestedNodesRequest) (*ListAttestedNodesResponse, error)
 	ListBundles(context.Context, *ListBundlesRequest) (*ListBundlesResponse, error)
+	ListDownstreamCAs(context.Context, *ListDownstreamCAsRequest) (*ListDownstreamCAsResponse, error)
+	ListIssuedSVIDs(context.Context, *ListIssuedSVIDsRequest) (*ListIssuedSVIDsResponse, error)
+	ListJoinTokens(context.Context, *ListJoinTokensRequest) (*ListJoinTokensResponse, error)
+	ListNodeSelectors(context.Context, *ListNodeSelectorsRequest) (*ListNodeSelectorsResponse, error)
 	ListRegistrationEntries(context.Context, *ListRegistrationEntriesRequest) (*ListRegistrationEntriesResponse, error)
+	ListRegistrationEntryEvents(context.Context, *ListRegistrationEntryEventsRequest) (*ListRegistrationEntryEventsResponse, error)
+	ListRegistrationEntryTombstones(context.Context, *ListRegistrationEntryTombstonesRequest) (*ListRegistrationEntryTombstonesResponse, error)
+	ListRevokedCertificates(context.Context, *ListRevokedCertificatesRequest) (*ListRevokedCertificatesResponse, error)
 	PruneBundle(context.Context, *PruneBundleRequest) (*PruneBundleResponse, error)
+	PruneDownstreamCAs(context.Context, *PruneDownstreamCAsRequest) (*PruneDownstreamCAsResponse, error)
+	PruneIssuedSVIDs(context.Context, *PruneIssuedSVIDsRequest) (*PruneIssuedSVIDsResponse, error)
 	PruneJoinTokens(context.Context, *PruneJoinTokensRequest) (*PruneJoinTokensResponse, error)
 	PruneRegistrationEntries(context.Context, *PruneRegistrationEntriesRequest) (*PruneRegistrationEntriesResponse, error)
+	PruneRegistrationEntryEvents(context.Context, *PruneRegistrationEntryEventsRequest) (*PruneRegistrationEntryEventsResponse, error)
+	PruneRegistrationEntryTombstones(context.Context, *PruneRegistrationEntryTombstonesRequest) (*PruneRegistrationEntryTombstonesResponse, error)
+	PruneRevokedCertificates(context.Context, *PruneRevokedCertificatesRequest) (*PruneRevokedCertificatesResponse, error)
+	ReleaseLease(context.Context, *ReleaseLeaseRequest) (*ReleaseLeaseResponse, error)
+	RevokeCertificate(context.Context, *RevokeCertificateRequest) (*RevokeCertificateResponse, error)
+	SetAttestedNodeBanned(context.Context, *SetAttestedNodeBannedRequest) (*SetAttestedNodeBannedResponse, error)
 	SetBundle(context.Context, *SetBundleRequest) (*SetBundleResponse, error)
+	SetCAJournal(context.Context, *SetCAJournalRequest) (*SetCAJournalResponse, error)
 	SetNodeSelectors(context.Context, *SetNodeSelectorsRequest) (*SetNodeSelectorsResponse, error)
 	UpdateAttestedNode(context.Context, *UpdateAttestedNodeRequest) (*UpdateAttestedNodeResponse, error)
 	UpdateBundle(context.Context, *UpdateBundleRequest) (*UpdateBundleResponse, error)
@@ -120,14 +170,34 @@ type pluginClientAdapter struct {
 	client DataStoreClient
 }
 
+func (a pluginClientAdapter) AcquireLease(ctx context.Context, in *AcquireLeaseRequest) (*AcquireLeaseResponse, error) {
+	return a.client.AcquireLease(ctx, in)
+}
+
 func (a pluginClientAdapter) AppendBundle(ctx context.Context, in *AppendBundleRequest) (*AppendBundleResponse, error) {
 	return a.client.AppendBundle(ctx, in)
 }
 
+func (a pluginClientAdapter) BatchCreateRegistrationEntries(ctx context.Context, in *BatchCreateRegistrationEntriesRequest) (*BatchCreateRegistrationEntriesResponse, error) {
+	return a.client.BatchCreateRegistrationEntries(ctx, in)
+}
+
+func (a pluginClientAdapter) BatchDeleteRegistrationEntries(ctx context.Context, in *BatchDeleteRegistrationEntriesRequest) (*BatchDeleteRegistrationEntriesResponse, error) {
+	return a.client.BatchDeleteRegistrationEntries(ctx, in)
+}
+
+func (a pluginClientAdapter) BatchUpdateRegistrationEntries(ctx context.Context, in *BatchUpdateRegistrationEntriesRequest) (*BatchUpdateRegistrationEntriesResponse, error) {
+	return a.client.BatchUpdateRegistrationEntries(ctx, in)
+}
+
 func (a pluginClientAdapter) Configure(ctx context.Context, in *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
 	return a.client.Configure(ctx, in)
 }
 
+func (a pluginClientAdapter) ConsumeJoinToken(ctx context.Context, in *ConsumeJoinTokenRequest) (*ConsumeJoinTokenResponse, error) {
+	return a.client.ConsumeJoinToken(ctx, in)
+}
+
 func (a pluginClientAdapter) CreateAttestedNode(ctx context.Context, in *CreateAttestedNodeRequest) (*CreateAttestedNodeResponse, error) {
 	return a.client.CreateAttestedNode(ctx, in)
 }
@@ -136,6 +206,14 @@ func (a pluginClientAdapter) CreateBundle(ctx context.Context, in *CreateBundleR
 	return a.client.CreateBundle(ctx, in)
 }
 
+func (a pluginClientAdapter) CreateDownstreamCA(ctx context.Context, in *CreateDownstreamCARequest) (*CreateDownstreamCAResponse, error) {
+	return a.client.CreateDownstreamCA(ctx, in)
+}
+
+func (a pluginClientAdapter) CreateIssuedSVID(ctx context.Context, in *CreateIssuedSVIDRequest) (*CreateIssuedSVIDResponse, error) {
+	return a.client.CreateIssuedSVID(ctx, in)
+}
+
 func (a pluginClientAdapter) CreateJoinToken(ctx context.Context, in *CreateJoinTokenRequest) (*CreateJoinTokenResponse, error) {
 	return a.client.CreateJoinToken(ctx, in)
 }
@@ -168,6 +246,10 @@ func (a pluginClientAdapter) FetchBundle(ctx context.Context, in *FetchBundleReq
 	return a.client.FetchBundle(ctx, in)
 }
 
+func (a pluginClientAdapter) FetchCAJournal(ctx context.Context, in *FetchCAJournalRequest) (*FetchCAJournalResponse, error) {
+	return a.client.FetchCAJournal(ctx, in)
+}
+
 func (a pluginClientAdapter) FetchJoinToken(ctx context.Context, in *FetchJoinTokenRequest) (*FetchJoinTokenResponse, error) {
 	return a.client.FetchJoinToken(ctx, in)
 }
@@ -176,6 +258,10 @@ func (a pluginClientAdapter) FetchRegistrationEntry(ctx context.Context, in *Fet
 	return a.client.FetchRegistrationEntry(ctx, in)
 }
 
+func (a pluginClientAdapter) FetchRevokedCertificate(ctx context.Context, in *FetchRevokedCertificateRequest) (*FetchRevokedCertificateResponse, error) {
+	return a.client.FetchRevokedCertificate(ctx, in)
+}
+
 func (a pluginClientAdapter) GetNodeSelectors(ctx context.Context, in *GetNodeSelectorsRequest) (*GetNodeSelectorsResponse, error) {
 	return a.client.GetNodeSelectors(ctx, in)
 }
@@ -192,14 +278,50 @@ func (a pluginClientAdapter) ListBundles(ctx context.Context, in *ListBundlesReq
 	return a.client.ListBundles(ctx, in)
 }
 
+func (a pluginClientAdapter) ListDownstreamCAs(ctx context.Context, in *ListDownstreamCAsRequest) (*ListDownstreamCAsResponse, error) {
+	return a.client.ListDownstreamCAs(ctx, in)
+}
+
+func (a pluginClientAdapter) ListIssuedSVIDs(ctx context.Context, in *ListIssuedSVIDsRequest) (*ListIssuedSVIDsResponse, error) {
+	return a.client.ListIssuedSVIDs(ctx, in)
+}
+
+func (a pluginClientAdapter) ListJoinTokens(ctx context.Context, in *ListJoinTokensRequest) (*ListJoinTokensResponse, error) {
+	return a.client.ListJoinTokens(ctx, in)
+}
+
+func (a pluginClientAdapter) ListNodeSelectors(ctx context.Context, in *ListNodeSelectorsRequest) (*ListNodeSelectorsResponse, error) {
+	return a.client.ListNodeSelectors(ctx, in)
+}
+
 func (a pluginClientAdapter) ListRegistrationEntries(ctx context.Context, in *ListRegistrationEntriesRequest) (*ListRegistrationEntriesResponse, error) {
 	return a.client.ListRegistrationEntries(ctx, in)
 }
 
+func (a pluginClientAdapter) ListRegistrationEntryEvents(ctx context.Context, in *ListRegistrationEntryEventsRequest) (*ListRegistrationEntryEventsResponse, error) {
+	return a.client.ListRegistrationEntryEvents(ctx, in)
+}
+
+func (a pluginClientAdapter) ListRegistrationEntryTombstones(ctx context.Context, in *ListRegistrationEntryTombstonesRequest) (*ListRegistrationEntryTombstonesResponse, error) {
+	return a.client.ListRegistrationEntryTombstones(ctx, in)
+}
+
+func (a pluginClientAdapter) ListRevokedCertificates(ctx context.Context, in *ListRevokedCertificatesRequest) (*ListRevokedCertificatesResponse, error) {
+	return a.client.ListRevokedCertificates(ctx, in)
+}
+
 func (a pluginClientAdapter) PruneBundle(ctx context.Context, in *PruneBundleRequest) (*PruneBundleResponse, error) {
 	return a.client.PruneBundle(ctx, in)
 }
 
+func (a pluginClientAdapter) PruneDownstreamCAs(ctx context.Context, in *PruneDownstreamCAsRequest) (*PruneDownstreamCAsResponse, error) {
+	return a.client.PruneDownstreamCAs(ctx, in)
+}
+
+func (a pluginClientAdapter) PruneIssuedSVIDs(ctx context.Context, in *PruneIssuedSVIDsRequest) (*PruneIssuedSVIDsResponse, error) {
+	return a.client.PruneIssuedSVIDs(ctx, in)
+}
+
 func (a pluginClientAdapter) PruneJoinTokens(ctx context.Context, in *PruneJoinTokensRequest) (*PruneJoinTokensResponse, error) {
 	return a.client.PruneJoinTokens(ctx, in)
 }
@@ -208,10 +330,38 @@ func (a pluginClientAdapter) PruneRegistrationEntries(ctx context.Context, in *P
 	return a.client.PruneRegistrationEntries(ctx, in)
 }
 
+func (a pluginClientAdapter) PruneRegistrationEntryEvents(ctx context.Context, in *PruneRegistrationEntryEventsRequest) (*PruneRegistrationEntryEventsResponse, error) {
+	return a.client.PruneRegistrationEntryEvents(ctx, in)
+}
+
+func (a pluginClientAdapter) PruneRegistrationEntryTombstones(ctx context.Context, in *PruneRegistrationEntryTombstonesRequest) (*PruneRegistrationEntryTombstonesResponse, error) {
+	return a.client.PruneRegistrationEntryTombstones(ctx, in)
+}
+
+func (a pluginClientAdapter) PruneRevokedCertificates(ctx context.Context, in *PruneRevokedCertificatesRequest) (*PruneRevokedCertificatesResponse, error) {
+	return a.client.PruneRevokedCertificates(ctx, in)
+}
+
+func (a pluginClientAdapter) ReleaseLease(ctx context.Context, in *ReleaseLeaseRequest) (*ReleaseLeaseResponse, error) {
+	return a.client.ReleaseLease(ctx, in)
+}
+
+func (a pluginClientAdapter) RevokeCertificate(ctx context.Context, in *RevokeCertificateRequest) (*RevokeCertificateResponse, error) {
+	return a.client.RevokeCertificate(ctx, in)
+}
+
+func (a pluginClientAdapter) SetAttestedNodeBanned(ctx context.Context, in *SetAttestedNodeBannedRequest) (*SetAttestedNodeBannedResponse, error) {
+	return a.client.SetAttestedNodeBanned(ctx, in)
+}
+
 func (a pluginClientAdapter) SetBundle(ctx context.Context, in *SetBundleRequest) (*SetBundleResponse, error) {
 	return a.client.SetBundle(ctx, in)
 }
 
+func (a pluginClientAdapter) SetCAJournal(ctx context.Context, in *SetCAJournalRequest) (*SetCAJournalResponse, error) {
+	return a.client.SetCAJournal(ctx, in)
+}
+
 func (a pluginClientAdapter) SetNodeSelectors(ctx context.Context, in *SetNodeSelectorsRequest) (*SetNodeSelectorsResponse, error) {
 	return a.client.SetNodeSelectors(ctx, in)
 }
@@ -63,16 +63,20 @@ type BySelectors_MatchBehavior int32
 const (
 	BySelectors_MATCH_EXACT  BySelectors_MatchBehavior = 0
 	BySelectors_MATCH_SUBSET BySelectors_MatchBehavior = 1
+	// Entries that have all of the selectors, and possibly others
+	BySelectors_MATCH_SUPERSET BySelectors_MatchBehavior = 2
 )
 
 var BySelectors_MatchBehavior_name = map[int32]string{
 	0: "MATCH_EXACT",
 	1: "MATCH_SUBSET",
+	2: "MATCH_SUPERSET",
 }
 
 var BySelectors_MatchBehavior_value = map[string]int32{
-	"MATCH_EXACT":  0,
-	"MATCH_SUBSET": 1,
+	"MATCH_EXACT":    0,
+	"MATCH_SUBSET":   1,
+	"MATCH_SUPERSET": 2,
 }
 
 func (x BySelectors_MatchBehavior) String() string {
@@ -80,7 +84,63 @@ func (x BySelectors_MatchBehavior) String() string {
 }
 
 func (BySelectors_MatchBehavior) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{35, 0}
+	return fileDescriptor_d08157cfd31fc929, []int{39, 0}
+}
+
+type RegistrationEntryEvent_Type int32
+
+const (
+	RegistrationEntryEvent_CREATE RegistrationEntryEvent_Type = 0
+	RegistrationEntryEvent_UPDATE RegistrationEntryEvent_Type = 1
+	RegistrationEntryEvent_DELETE RegistrationEntryEvent_Type = 2
+)
+
+var RegistrationEntryEvent_Type_name = map[int32]string{
+	0: "CREATE",
+	1: "UPDATE",
+	2: "DELETE",
+}
+
+var RegistrationEntryEvent_Type_value = map[string]int32{
+	"CREATE": 0,
+	"UPDATE": 1,
+	"DELETE": 2,
+}
+
+func (x RegistrationEntryEvent_Type) String() string {
+	return proto.EnumName(RegistrationEntryEvent_Type_name, int32(x))
+}
+
+func (RegistrationEntryEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{60, 0}
+}
+
+type IssuedSVID_Type int32
+
+const (
+	IssuedSVID_X509_SVID    IssuedSVID_Type = 0
+	IssuedSVID_X509_CA_SVID IssuedSVID_Type = 1
+	IssuedSVID_JWT_SVID     IssuedSVID_Type = 2
+)
+
+var IssuedSVID_Type_name = map[int32]string{
+	0: "X509_SVID",
+	1: "X509_CA_SVID",
+	2: "JWT_SVID",
+}
+
+var IssuedSVID_Type_value = map[string]int32{
+	"X509_SVID":    0,
+	"X509_CA_SVID": 1,
+	"JWT_SVID":     2,
+}
+
+func (x IssuedSVID_Type) String() string {
+	return proto.EnumName(IssuedSVID_Type_name, int32(x))
+}
+
+func (IssuedSVID_Type) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{104, 0}
 }
 
 type CreateBundleRequest struct {
@@ -914,6 +974,98 @@ func (m *GetNodeSelectorsResponse) GetSelectors() *NodeSelectors {
 	return nil
 }
 
+type ListNodeSelectorsRequest struct {
+	// If set, only the nodes whose selectors were set after the change
+	// event with this ID are listed, including nodes left without selectors
+	AfterEventId         *wrappers.Int64Value `protobuf:"bytes,1,opt,name=after_event_id,json=afterEventId,proto3" json:"after_event_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ListNodeSelectorsRequest) Reset()         { *m = ListNodeSelectorsRequest{} }
+func (m *ListNodeSelectorsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListNodeSelectorsRequest) ProtoMessage()    {}
+func (*ListNodeSelectorsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{21}
+}
+
+func (m *ListNodeSelectorsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListNodeSelectorsRequest.Unmarshal(m, b)
+}
+func (m *ListNodeSelectorsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListNodeSelectorsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListNodeSelectorsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListNodeSelectorsRequest.Merge(m, src)
+}
+func (m *ListNodeSelectorsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListNodeSelectorsRequest.Size(m)
+}
+func (m *ListNodeSelectorsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListNodeSelectorsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListNodeSelectorsRequest proto.InternalMessageInfo
+
+func (m *ListNodeSelectorsRequest) GetAfterEventId() *wrappers.Int64Value {
+	if m != nil {
+		return m.AfterEventId
+	}
+	return nil
+}
+
+type ListNodeSelectorsResponse struct {
+	// Selectors of the listed nodes, ordered by node SPIFFE ID. Unless
+	// after_event_id is set, only nodes that have selectors are listed.
+	Selectors []*NodeSelectors `protobuf:"bytes,1,rep,name=selectors,proto3" json:"selectors,omitempty"`
+	// ID of the latest node selectors change event, or zero if there are
+	// none. Passing it as after_event_id lists the changes made from then on.
+	LatestEventId        int64    `protobuf:"varint,2,opt,name=latest_event_id,json=latestEventId,proto3" json:"latest_event_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListNodeSelectorsResponse) Reset()         { *m = ListNodeSelectorsResponse{} }
+func (m *ListNodeSelectorsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListNodeSelectorsResponse) ProtoMessage()    {}
+func (*ListNodeSelectorsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{22}
+}
+
+func (m *ListNodeSelectorsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListNodeSelectorsResponse.Unmarshal(m, b)
+}
+func (m *ListNodeSelectorsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListNodeSelectorsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListNodeSelectorsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListNodeSelectorsResponse.Merge(m, src)
+}
+func (m *ListNodeSelectorsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListNodeSelectorsResponse.Size(m)
+}
+func (m *ListNodeSelectorsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListNodeSelectorsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListNodeSelectorsResponse proto.InternalMessageInfo
+
+func (m *ListNodeSelectorsResponse) GetSelectors() []*NodeSelectors {
+	if m != nil {
+		return m.Selectors
+	}
+	return nil
+}
+
+func (m *ListNodeSelectorsResponse) GetLatestEventId() int64 {
+	if m != nil {
+		return m.LatestEventId
+	}
+	return 0
+}
+
 type CreateAttestedNodeRequest struct {
 	Node                 *common.AttestedNode `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
@@ -925,7 +1077,7 @@ func (m *CreateAttestedNodeRequest) Reset()         { *m = CreateAttestedNodeReq
 func (m *CreateAttestedNodeRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateAttestedNodeRequest) ProtoMessage()    {}
 func (*CreateAttestedNodeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{21}
+	return fileDescriptor_d08157cfd31fc929, []int{23}
 }
 
 func (m *CreateAttestedNodeRequest) XXX_Unmarshal(b []byte) error {
@@ -964,7 +1116,7 @@ func (m *CreateAttestedNodeResponse) Reset()         { *m = CreateAttestedNodeRe
 func (m *CreateAttestedNodeResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateAttestedNodeResponse) ProtoMessage()    {}
 func (*CreateAttestedNodeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{22}
+	return fileDescriptor_d08157cfd31fc929, []int{24}
 }
 
 func (m *CreateAttestedNodeResponse) XXX_Unmarshal(b []byte) error {
@@ -1003,7 +1155,7 @@ func (m *FetchAttestedNodeRequest) Reset()         { *m = FetchAttestedNodeReque
 func (m *FetchAttestedNodeRequest) String() string { return proto.CompactTextString(m) }
 func (*FetchAttestedNodeRequest) ProtoMessage()    {}
 func (*FetchAttestedNodeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{23}
+	return fileDescriptor_d08157cfd31fc929, []int{25}
 }
 
 func (m *FetchAttestedNodeRequest) XXX_Unmarshal(b []byte) error {
@@ -1042,7 +1194,7 @@ func (m *FetchAttestedNodeResponse) Reset()         { *m = FetchAttestedNodeResp
 func (m *FetchAttestedNodeResponse) String() string { return proto.CompactTextString(m) }
 func (*FetchAttestedNodeResponse) ProtoMessage()    {}
 func (*FetchAttestedNodeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{24}
+	return fileDescriptor_d08157cfd31fc929, []int{26}
 }
 
 func (m *FetchAttestedNodeResponse) XXX_Unmarshal(b []byte) error {
@@ -1071,18 +1223,27 @@ func (m *FetchAttestedNodeResponse) GetNode() *common.AttestedNode {
 }
 
 type ListAttestedNodesRequest struct {
-	ByExpiresBefore      *wrappers.Int64Value `protobuf:"bytes,1,opt,name=by_expires_before,json=byExpiresBefore,proto3" json:"by_expires_before,omitempty"`
-	Pagination           *Pagination          `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+	ByExpiresBefore   *wrappers.Int64Value  `protobuf:"bytes,1,opt,name=by_expires_before,json=byExpiresBefore,proto3" json:"by_expires_before,omitempty"`
+	Pagination        *Pagination           `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	ByAttestationType *wrappers.StringValue `protobuf:"bytes,3,opt,name=by_attestation_type,json=byAttestationType,proto3" json:"by_attestation_type,omitempty"`
+	// Only nodes expiring at or after the time (seconds since unix epoch)
+	ByExpiresAfter *wrappers.Int64Value `protobuf:"bytes,4,opt,name=by_expires_after,json=byExpiresAfter,proto3" json:"by_expires_after,omitempty"`
+	// Only banned (or not banned) nodes
+	ByBanned *wrappers.BoolValue `protobuf:"bytes,5,opt,name=by_banned,json=byBanned,proto3" json:"by_banned,omitempty"`
+	// Only nodes whose node selectors match
+	BySelectorMatch *BySelectors `protobuf:"bytes,6,opt,name=by_selector_match,json=bySelectorMatch,proto3" json:"by_selector_match,omitempty"`
+	// Whether to populate the node selectors of the listed nodes
+	FetchSelectors       bool     `protobuf:"varint,7,opt,name=fetch_selectors,json=fetchSelectors,proto3" json:"fetch_selectors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ListAttestedNodesRequest) Reset()         { *m = ListAttestedNodesRequest{} }
 func (m *ListAttestedNodesRequest) String() string { return proto.CompactTextString(m) }
 func (*ListAttestedNodesRequest) ProtoMessage()    {}
 func (*ListAttestedNodesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{25}
+	return fileDescriptor_d08157cfd31fc929, []int{27}
 }
 
 func (m *ListAttestedNodesRequest) XXX_Unmarshal(b []byte) error {
@@ -1117,6 +1278,41 @@ func (m *ListAttestedNodesRequest) GetPagination() *Pagination {
 	return nil
 }
 
+func (m *ListAttestedNodesRequest) GetByAttestationType() *wrappers.StringValue {
+	if m != nil {
+		return m.ByAttestationType
+	}
+	return nil
+}
+
+func (m *ListAttestedNodesRequest) GetByExpiresAfter() *wrappers.Int64Value {
+	if m != nil {
+		return m.ByExpiresAfter
+	}
+	return nil
+}
+
+func (m *ListAttestedNodesRequest) GetByBanned() *wrappers.BoolValue {
+	if m != nil {
+		return m.ByBanned
+	}
+	return nil
+}
+
+func (m *ListAttestedNodesRequest) GetBySelectorMatch() *BySelectors {
+	if m != nil {
+		return m.BySelectorMatch
+	}
+	return nil
+}
+
+func (m *ListAttestedNodesRequest) GetFetchSelectors() bool {
+	if m != nil {
+		return m.FetchSelectors
+	}
+	return false
+}
+
 type ListAttestedNodesResponse struct {
 	Nodes                []*common.AttestedNode `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
 	Pagination           *Pagination            `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
@@ -1129,7 +1325,7 @@ func (m *ListAttestedNodesResponse) Reset()         { *m = ListAttestedNodesResp
 func (m *ListAttestedNodesResponse) String() string { return proto.CompactTextString(m) }
 func (*ListAttestedNodesResponse) ProtoMessage()    {}
 func (*ListAttestedNodesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{26}
+	return fileDescriptor_d08157cfd31fc929, []int{28}
 }
 
 func (m *ListAttestedNodesResponse) XXX_Unmarshal(b []byte) error {
@@ -1177,7 +1373,7 @@ func (m *UpdateAttestedNodeRequest) Reset()         { *m = UpdateAttestedNodeReq
 func (m *UpdateAttestedNodeRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateAttestedNodeRequest) ProtoMessage()    {}
 func (*UpdateAttestedNodeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{27}
+	return fileDescriptor_d08157cfd31fc929, []int{29}
 }
 
 func (m *UpdateAttestedNodeRequest) XXX_Unmarshal(b []byte) error {
@@ -1230,7 +1426,7 @@ func (m *UpdateAttestedNodeResponse) Reset()         { *m = UpdateAttestedNodeRe
 func (m *UpdateAttestedNodeResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateAttestedNodeResponse) ProtoMessage()    {}
 func (*UpdateAttestedNodeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{28}
+	return fileDescriptor_d08157cfd31fc929, []int{30}
 }
 
 func (m *UpdateAttestedNodeResponse) XXX_Unmarshal(b []byte) error {
@@ -1258,6 +1454,92 @@ func (m *UpdateAttestedNodeResponse) GetNode() *common.AttestedNode {
 	return nil
 }
 
+type SetAttestedNodeBannedRequest struct {
+	SpiffeId             string   `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	Banned               bool     `protobuf:"varint,2,opt,name=banned,proto3" json:"banned,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetAttestedNodeBannedRequest) Reset()         { *m = SetAttestedNodeBannedRequest{} }
+func (m *SetAttestedNodeBannedRequest) String() string { return proto.CompactTextString(m) }
+func (*SetAttestedNodeBannedRequest) ProtoMessage()    {}
+func (*SetAttestedNodeBannedRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{31}
+}
+
+func (m *SetAttestedNodeBannedRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetAttestedNodeBannedRequest.Unmarshal(m, b)
+}
+func (m *SetAttestedNodeBannedRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetAttestedNodeBannedRequest.Marshal(b, m, deterministic)
+}
+func (m *SetAttestedNodeBannedRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetAttestedNodeBannedRequest.Merge(m, src)
+}
+func (m *SetAttestedNodeBannedRequest) XXX_Size() int {
+	return xxx_messageInfo_SetAttestedNodeBannedRequest.Size(m)
+}
+func (m *SetAttestedNodeBannedRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetAttestedNodeBannedRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetAttestedNodeBannedRequest proto.InternalMessageInfo
+
+func (m *SetAttestedNodeBannedRequest) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *SetAttestedNodeBannedRequest) GetBanned() bool {
+	if m != nil {
+		return m.Banned
+	}
+	return false
+}
+
+type SetAttestedNodeBannedResponse struct {
+	Node                 *common.AttestedNode `protobuf:"bytes,1,opt,name=node,proto3" json:"node,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *SetAttestedNodeBannedResponse) Reset()         { *m = SetAttestedNodeBannedResponse{} }
+func (m *SetAttestedNodeBannedResponse) String() string { return proto.CompactTextString(m) }
+func (*SetAttestedNodeBannedResponse) ProtoMessage()    {}
+func (*SetAttestedNodeBannedResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{32}
+}
+
+func (m *SetAttestedNodeBannedResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetAttestedNodeBannedResponse.Unmarshal(m, b)
+}
+func (m *SetAttestedNodeBannedResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetAttestedNodeBannedResponse.Marshal(b, m, deterministic)
+}
+func (m *SetAttestedNodeBannedResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetAttestedNodeBannedResponse.Merge(m, src)
+}
+func (m *SetAttestedNodeBannedResponse) XXX_Size() int {
+	return xxx_messageInfo_SetAttestedNodeBannedResponse.Size(m)
+}
+func (m *SetAttestedNodeBannedResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetAttestedNodeBannedResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetAttestedNodeBannedResponse proto.InternalMessageInfo
+
+func (m *SetAttestedNodeBannedResponse) GetNode() *common.AttestedNode {
+	if m != nil {
+		return m.Node
+	}
+	return nil
+}
+
 type DeleteAttestedNodeRequest struct {
 	SpiffeId             string   `protobuf:"bytes,1,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -1269,7 +1551,7 @@ func (m *DeleteAttestedNodeRequest) Reset()         { *m = DeleteAttestedNodeReq
 func (m *DeleteAttestedNodeRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteAttestedNodeRequest) ProtoMessage()    {}
 func (*DeleteAttestedNodeRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{29}
+	return fileDescriptor_d08157cfd31fc929, []int{33}
 }
 
 func (m *DeleteAttestedNodeRequest) XXX_Unmarshal(b []byte) error {
@@ -1308,7 +1590,7 @@ func (m *DeleteAttestedNodeResponse) Reset()         { *m = DeleteAttestedNodeRe
 func (m *DeleteAttestedNodeResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteAttestedNodeResponse) ProtoMessage()    {}
 func (*DeleteAttestedNodeResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{30}
+	return fileDescriptor_d08157cfd31fc929, []int{34}
 }
 
 func (m *DeleteAttestedNodeResponse) XXX_Unmarshal(b []byte) error {
@@ -1347,7 +1629,7 @@ func (m *CreateRegistrationEntryRequest) Reset()         { *m = CreateRegistrati
 func (m *CreateRegistrationEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateRegistrationEntryRequest) ProtoMessage()    {}
 func (*CreateRegistrationEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{31}
+	return fileDescriptor_d08157cfd31fc929, []int{35}
 }
 
 func (m *CreateRegistrationEntryRequest) XXX_Unmarshal(b []byte) error {
@@ -1386,7 +1668,7 @@ func (m *CreateRegistrationEntryResponse) Reset()         { *m = CreateRegistrat
 func (m *CreateRegistrationEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateRegistrationEntryResponse) ProtoMessage()    {}
 func (*CreateRegistrationEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{32}
+	return fileDescriptor_d08157cfd31fc929, []int{36}
 }
 
 func (m *CreateRegistrationEntryResponse) XXX_Unmarshal(b []byte) error {
@@ -1425,7 +1707,7 @@ func (m *FetchRegistrationEntryRequest) Reset()         { *m = FetchRegistration
 func (m *FetchRegistrationEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*FetchRegistrationEntryRequest) ProtoMessage()    {}
 func (*FetchRegistrationEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{33}
+	return fileDescriptor_d08157cfd31fc929, []int{37}
 }
 
 func (m *FetchRegistrationEntryRequest) XXX_Unmarshal(b []byte) error {
@@ -1464,7 +1746,7 @@ func (m *FetchRegistrationEntryResponse) Reset()         { *m = FetchRegistratio
 func (m *FetchRegistrationEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*FetchRegistrationEntryResponse) ProtoMessage()    {}
 func (*FetchRegistrationEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{34}
+	return fileDescriptor_d08157cfd31fc929, []int{38}
 }
 
 func (m *FetchRegistrationEntryResponse) XXX_Unmarshal(b []byte) error {
@@ -1504,7 +1786,7 @@ func (m *BySelectors) Reset()         { *m = BySelectors{} }
 func (m *BySelectors) String() string { return proto.CompactTextString(m) }
 func (*BySelectors) ProtoMessage()    {}
 func (*BySelectors) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{35}
+	return fileDescriptor_d08157cfd31fc929, []int{39}
 }
 
 func (m *BySelectors) XXX_Unmarshal(b []byte) error {
@@ -1551,7 +1833,7 @@ func (m *Pagination) Reset()         { *m = Pagination{} }
 func (m *Pagination) String() string { return proto.CompactTextString(m) }
 func (*Pagination) ProtoMessage()    {}
 func (*Pagination) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{36}
+	return fileDescriptor_d08157cfd31fc929, []int{40}
 }
 
 func (m *Pagination) XXX_Unmarshal(b []byte) error {
@@ -1587,20 +1869,26 @@ func (m *Pagination) GetPageSize() int32 {
 }
 
 type ListRegistrationEntriesRequest struct {
-	ByParentId           *wrappers.StringValue `protobuf:"bytes,1,opt,name=by_parent_id,json=byParentId,proto3" json:"by_parent_id,omitempty"`
-	BySelectors          *BySelectors          `protobuf:"bytes,2,opt,name=by_selectors,json=bySelectors,proto3" json:"by_selectors,omitempty"`
-	BySpiffeId           *wrappers.StringValue `protobuf:"bytes,3,opt,name=by_spiffe_id,json=bySpiffeId,proto3" json:"by_spiffe_id,omitempty"`
-	Pagination           *Pagination           `protobuf:"bytes,4,opt,name=pagination,proto3" json:"pagination,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+	ByParentId  *wrappers.StringValue `protobuf:"bytes,1,opt,name=by_parent_id,json=byParentId,proto3" json:"by_parent_id,omitempty"`
+	BySelectors *BySelectors          `protobuf:"bytes,2,opt,name=by_selectors,json=bySelectors,proto3" json:"by_selectors,omitempty"`
+	BySpiffeId  *wrappers.StringValue `protobuf:"bytes,3,opt,name=by_spiffe_id,json=bySpiffeId,proto3" json:"by_spiffe_id,omitempty"`
+	Pagination  *Pagination           `protobuf:"bytes,4,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	// Only entries whose SPIFFE ID starts with the prefix
+	BySpiffeIdPrefix *wrappers.StringValue `protobuf:"bytes,5,opt,name=by_spiffe_id_prefix,json=bySpiffeIdPrefix,proto3" json:"by_spiffe_id_prefix,omitempty"`
+	// Only entries that federate with at least one of the trust domains
+	ByFederatesWith      []string            `protobuf:"bytes,6,rep,name=by_federates_with,json=byFederatesWith,proto3" json:"by_federates_with,omitempty"`
+	ByAdmin              *wrappers.BoolValue `protobuf:"bytes,7,opt,name=by_admin,json=byAdmin,proto3" json:"by_admin,omitempty"`
+	ByDownstream         *wrappers.BoolValue `protobuf:"bytes,8,opt,name=by_downstream,json=byDownstream,proto3" json:"by_downstream,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
 }
 
 func (m *ListRegistrationEntriesRequest) Reset()         { *m = ListRegistrationEntriesRequest{} }
 func (m *ListRegistrationEntriesRequest) String() string { return proto.CompactTextString(m) }
 func (*ListRegistrationEntriesRequest) ProtoMessage()    {}
 func (*ListRegistrationEntriesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{37}
+	return fileDescriptor_d08157cfd31fc929, []int{41}
 }
 
 func (m *ListRegistrationEntriesRequest) XXX_Unmarshal(b []byte) error {
@@ -1649,6 +1937,34 @@ func (m *ListRegistrationEntriesRequest) GetPagination() *Pagination {
 	return nil
 }
 
+func (m *ListRegistrationEntriesRequest) GetBySpiffeIdPrefix() *wrappers.StringValue {
+	if m != nil {
+		return m.BySpiffeIdPrefix
+	}
+	return nil
+}
+
+func (m *ListRegistrationEntriesRequest) GetByFederatesWith() []string {
+	if m != nil {
+		return m.ByFederatesWith
+	}
+	return nil
+}
+
+func (m *ListRegistrationEntriesRequest) GetByAdmin() *wrappers.BoolValue {
+	if m != nil {
+		return m.ByAdmin
+	}
+	return nil
+}
+
+func (m *ListRegistrationEntriesRequest) GetByDownstream() *wrappers.BoolValue {
+	if m != nil {
+		return m.ByDownstream
+	}
+	return nil
+}
+
 type ListRegistrationEntriesResponse struct {
 	Entries              []*common.RegistrationEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
 	Pagination           *Pagination                 `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
@@ -1661,7 +1977,7 @@ func (m *ListRegistrationEntriesResponse) Reset()         { *m = ListRegistratio
 func (m *ListRegistrationEntriesResponse) String() string { return proto.CompactTextString(m) }
 func (*ListRegistrationEntriesResponse) ProtoMessage()    {}
 func (*ListRegistrationEntriesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{38}
+	return fileDescriptor_d08157cfd31fc929, []int{42}
 }
 
 func (m *ListRegistrationEntriesResponse) XXX_Unmarshal(b []byte) error {
@@ -1697,17 +2013,20 @@ func (m *ListRegistrationEntriesResponse) GetPagination() *Pagination {
 }
 
 type UpdateRegistrationEntryRequest struct {
-	Entry                *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
-	XXX_unrecognized     []byte                    `json:"-"`
-	XXX_sizecache        int32                     `json:"-"`
+	Entry *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	// Only the fields selected by the mask are updated. If unset, the whole
+	// entry is replaced.
+	Mask                 *common.RegistrationEntryMask `protobuf:"bytes,2,opt,name=mask,proto3" json:"mask,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
 }
 
 func (m *UpdateRegistrationEntryRequest) Reset()         { *m = UpdateRegistrationEntryRequest{} }
 func (m *UpdateRegistrationEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdateRegistrationEntryRequest) ProtoMessage()    {}
 func (*UpdateRegistrationEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{39}
+	return fileDescriptor_d08157cfd31fc929, []int{43}
 }
 
 func (m *UpdateRegistrationEntryRequest) XXX_Unmarshal(b []byte) error {
@@ -1735,6 +2054,13 @@ func (m *UpdateRegistrationEntryRequest) GetEntry() *common.RegistrationEntry {
 	return nil
 }
 
+func (m *UpdateRegistrationEntryRequest) GetMask() *common.RegistrationEntryMask {
+	if m != nil {
+		return m.Mask
+	}
+	return nil
+}
+
 type UpdateRegistrationEntryResponse struct {
 	Entry                *common.RegistrationEntry `protobuf:"bytes,1,opt,name=entry,proto3" json:"entry,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
@@ -1746,7 +2072,7 @@ func (m *UpdateRegistrationEntryResponse) Reset()         { *m = UpdateRegistrat
 func (m *UpdateRegistrationEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdateRegistrationEntryResponse) ProtoMessage()    {}
 func (*UpdateRegistrationEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{40}
+	return fileDescriptor_d08157cfd31fc929, []int{44}
 }
 
 func (m *UpdateRegistrationEntryResponse) XXX_Unmarshal(b []byte) error {
@@ -1785,7 +2111,7 @@ func (m *DeleteRegistrationEntryRequest) Reset()         { *m = DeleteRegistrati
 func (m *DeleteRegistrationEntryRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteRegistrationEntryRequest) ProtoMessage()    {}
 func (*DeleteRegistrationEntryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{41}
+	return fileDescriptor_d08157cfd31fc929, []int{45}
 }
 
 func (m *DeleteRegistrationEntryRequest) XXX_Unmarshal(b []byte) error {
@@ -1824,7 +2150,7 @@ func (m *DeleteRegistrationEntryResponse) Reset()         { *m = DeleteRegistrat
 func (m *DeleteRegistrationEntryResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteRegistrationEntryResponse) ProtoMessage()    {}
 func (*DeleteRegistrationEntryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{42}
+	return fileDescriptor_d08157cfd31fc929, []int{46}
 }
 
 func (m *DeleteRegistrationEntryResponse) XXX_Unmarshal(b []byte) error {
@@ -1852,918 +2178,3870 @@ func (m *DeleteRegistrationEntryResponse) GetEntry() *common.RegistrationEntry {
 	return nil
 }
 
-type PruneRegistrationEntriesRequest struct {
-	ExpiresBefore        int64    `protobuf:"varint,1,opt,name=expires_before,json=expiresBefore,proto3" json:"expires_before,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type BatchRegistrationEntryResult struct {
+	// The gRPC status code of the operation on the entry. Zero (OK) on
+	// success.
+	Code int32 `protobuf:"varint,1,opt,name=code,proto3" json:"code,omitempty"`
+	// Describes why the operation failed. Empty on success.
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// The created, updated or deleted entry. Only set on success.
+	Entry                *common.RegistrationEntry `protobuf:"bytes,3,opt,name=entry,proto3" json:"entry,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
 }
 
-func (m *PruneRegistrationEntriesRequest) Reset()         { *m = PruneRegistrationEntriesRequest{} }
-func (m *PruneRegistrationEntriesRequest) String() string { return proto.CompactTextString(m) }
-func (*PruneRegistrationEntriesRequest) ProtoMessage()    {}
-func (*PruneRegistrationEntriesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{43}
+func (m *BatchRegistrationEntryResult) Reset()         { *m = BatchRegistrationEntryResult{} }
+func (m *BatchRegistrationEntryResult) String() string { return proto.CompactTextString(m) }
+func (*BatchRegistrationEntryResult) ProtoMessage()    {}
+func (*BatchRegistrationEntryResult) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{47}
 }
 
-func (m *PruneRegistrationEntriesRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PruneRegistrationEntriesRequest.Unmarshal(m, b)
+func (m *BatchRegistrationEntryResult) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchRegistrationEntryResult.Unmarshal(m, b)
 }
-func (m *PruneRegistrationEntriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PruneRegistrationEntriesRequest.Marshal(b, m, deterministic)
+func (m *BatchRegistrationEntryResult) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchRegistrationEntryResult.Marshal(b, m, deterministic)
 }
-func (m *PruneRegistrationEntriesRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PruneRegistrationEntriesRequest.Merge(m, src)
+func (m *BatchRegistrationEntryResult) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchRegistrationEntryResult.Merge(m, src)
 }
-func (m *PruneRegistrationEntriesRequest) XXX_Size() int {
-	return xxx_messageInfo_PruneRegistrationEntriesRequest.Size(m)
+func (m *BatchRegistrationEntryResult) XXX_Size() int {
+	return xxx_messageInfo_BatchRegistrationEntryResult.Size(m)
 }
-func (m *PruneRegistrationEntriesRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_PruneRegistrationEntriesRequest.DiscardUnknown(m)
+func (m *BatchRegistrationEntryResult) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchRegistrationEntryResult.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PruneRegistrationEntriesRequest proto.InternalMessageInfo
+var xxx_messageInfo_BatchRegistrationEntryResult proto.InternalMessageInfo
 
-func (m *PruneRegistrationEntriesRequest) GetExpiresBefore() int64 {
+func (m *BatchRegistrationEntryResult) GetCode() int32 {
 	if m != nil {
-		return m.ExpiresBefore
+		return m.Code
 	}
 	return 0
 }
 
-type PruneRegistrationEntriesResponse struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
-
-func (m *PruneRegistrationEntriesResponse) Reset()         { *m = PruneRegistrationEntriesResponse{} }
-func (m *PruneRegistrationEntriesResponse) String() string { return proto.CompactTextString(m) }
-func (*PruneRegistrationEntriesResponse) ProtoMessage()    {}
-func (*PruneRegistrationEntriesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{44}
+func (m *BatchRegistrationEntryResult) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
 }
 
-func (m *PruneRegistrationEntriesResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PruneRegistrationEntriesResponse.Unmarshal(m, b)
-}
-func (m *PruneRegistrationEntriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PruneRegistrationEntriesResponse.Marshal(b, m, deterministic)
-}
-func (m *PruneRegistrationEntriesResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PruneRegistrationEntriesResponse.Merge(m, src)
-}
-func (m *PruneRegistrationEntriesResponse) XXX_Size() int {
-	return xxx_messageInfo_PruneRegistrationEntriesResponse.Size(m)
-}
-func (m *PruneRegistrationEntriesResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_PruneRegistrationEntriesResponse.DiscardUnknown(m)
+func (m *BatchRegistrationEntryResult) GetEntry() *common.RegistrationEntry {
+	if m != nil {
+		return m.Entry
+	}
+	return nil
 }
 
-var xxx_messageInfo_PruneRegistrationEntriesResponse proto.InternalMessageInfo
-
-type JoinToken struct {
-	// Token value
-	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	// Expiration in seconds since unix epoch
-	Expiry               int64    `protobuf:"varint,2,opt,name=expiry,proto3" json:"expiry,omitempty"`
+type BatchCreateRegistrationEntriesRequest struct {
+	Entries []*common.RegistrationEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// If set, no entries are created unless all of them can be
+	AllOrNothing         bool     `protobuf:"varint,2,opt,name=all_or_nothing,json=allOrNothing,proto3" json:"all_or_nothing,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *JoinToken) Reset()         { *m = JoinToken{} }
-func (m *JoinToken) String() string { return proto.CompactTextString(m) }
-func (*JoinToken) ProtoMessage()    {}
-func (*JoinToken) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{45}
+func (m *BatchCreateRegistrationEntriesRequest) Reset()         { *m = BatchCreateRegistrationEntriesRequest{} }
+func (m *BatchCreateRegistrationEntriesRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchCreateRegistrationEntriesRequest) ProtoMessage()    {}
+func (*BatchCreateRegistrationEntriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{48}
 }
 
-func (m *JoinToken) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_JoinToken.Unmarshal(m, b)
+func (m *BatchCreateRegistrationEntriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchCreateRegistrationEntriesRequest.Unmarshal(m, b)
 }
-func (m *JoinToken) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_JoinToken.Marshal(b, m, deterministic)
+func (m *BatchCreateRegistrationEntriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchCreateRegistrationEntriesRequest.Marshal(b, m, deterministic)
 }
-func (m *JoinToken) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_JoinToken.Merge(m, src)
+func (m *BatchCreateRegistrationEntriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchCreateRegistrationEntriesRequest.Merge(m, src)
 }
-func (m *JoinToken) XXX_Size() int {
-	return xxx_messageInfo_JoinToken.Size(m)
+func (m *BatchCreateRegistrationEntriesRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchCreateRegistrationEntriesRequest.Size(m)
 }
-func (m *JoinToken) XXX_DiscardUnknown() {
-	xxx_messageInfo_JoinToken.DiscardUnknown(m)
+func (m *BatchCreateRegistrationEntriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchCreateRegistrationEntriesRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_JoinToken proto.InternalMessageInfo
+var xxx_messageInfo_BatchCreateRegistrationEntriesRequest proto.InternalMessageInfo
 
-func (m *JoinToken) GetToken() string {
+func (m *BatchCreateRegistrationEntriesRequest) GetEntries() []*common.RegistrationEntry {
 	if m != nil {
-		return m.Token
+		return m.Entries
 	}
-	return ""
+	return nil
 }
 
-func (m *JoinToken) GetExpiry() int64 {
+func (m *BatchCreateRegistrationEntriesRequest) GetAllOrNothing() bool {
 	if m != nil {
-		return m.Expiry
+		return m.AllOrNothing
 	}
-	return 0
+	return false
 }
 
-type CreateJoinTokenRequest struct {
-	JoinToken            *JoinToken `protobuf:"bytes,1,opt,name=join_token,json=joinToken,proto3" json:"join_token,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+type BatchCreateRegistrationEntriesResponse struct {
+	// One result per requested entry, in request order
+	Results              []*BatchRegistrationEntryResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
 }
 
-func (m *CreateJoinTokenRequest) Reset()         { *m = CreateJoinTokenRequest{} }
-func (m *CreateJoinTokenRequest) String() string { return proto.CompactTextString(m) }
-func (*CreateJoinTokenRequest) ProtoMessage()    {}
-func (*CreateJoinTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{46}
+func (m *BatchCreateRegistrationEntriesResponse) Reset() {
+	*m = BatchCreateRegistrationEntriesResponse{}
+}
+func (m *BatchCreateRegistrationEntriesResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchCreateRegistrationEntriesResponse) ProtoMessage()    {}
+func (*BatchCreateRegistrationEntriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{49}
 }
 
-func (m *CreateJoinTokenRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateJoinTokenRequest.Unmarshal(m, b)
+func (m *BatchCreateRegistrationEntriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchCreateRegistrationEntriesResponse.Unmarshal(m, b)
 }
-func (m *CreateJoinTokenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateJoinTokenRequest.Marshal(b, m, deterministic)
+func (m *BatchCreateRegistrationEntriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchCreateRegistrationEntriesResponse.Marshal(b, m, deterministic)
 }
-func (m *CreateJoinTokenRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateJoinTokenRequest.Merge(m, src)
+func (m *BatchCreateRegistrationEntriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchCreateRegistrationEntriesResponse.Merge(m, src)
 }
-func (m *CreateJoinTokenRequest) XXX_Size() int {
-	return xxx_messageInfo_CreateJoinTokenRequest.Size(m)
+func (m *BatchCreateRegistrationEntriesResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchCreateRegistrationEntriesResponse.Size(m)
 }
-func (m *CreateJoinTokenRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateJoinTokenRequest.DiscardUnknown(m)
+func (m *BatchCreateRegistrationEntriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchCreateRegistrationEntriesResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateJoinTokenRequest proto.InternalMessageInfo
+var xxx_messageInfo_BatchCreateRegistrationEntriesResponse proto.InternalMessageInfo
 
-func (m *CreateJoinTokenRequest) GetJoinToken() *JoinToken {
+func (m *BatchCreateRegistrationEntriesResponse) GetResults() []*BatchRegistrationEntryResult {
 	if m != nil {
-		return m.JoinToken
+		return m.Results
 	}
 	return nil
 }
 
-type CreateJoinTokenResponse struct {
-	JoinToken            *JoinToken `protobuf:"bytes,1,opt,name=join_token,json=joinToken,proto3" json:"join_token,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+type BatchUpdateRegistrationEntriesRequest struct {
+	Entries []*common.RegistrationEntry `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	// If set, no entries are updated unless all of them can be
+	AllOrNothing         bool     `protobuf:"varint,2,opt,name=all_or_nothing,json=allOrNothing,proto3" json:"all_or_nothing,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CreateJoinTokenResponse) Reset()         { *m = CreateJoinTokenResponse{} }
-func (m *CreateJoinTokenResponse) String() string { return proto.CompactTextString(m) }
-func (*CreateJoinTokenResponse) ProtoMessage()    {}
-func (*CreateJoinTokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{47}
+func (m *BatchUpdateRegistrationEntriesRequest) Reset()         { *m = BatchUpdateRegistrationEntriesRequest{} }
+func (m *BatchUpdateRegistrationEntriesRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchUpdateRegistrationEntriesRequest) ProtoMessage()    {}
+func (*BatchUpdateRegistrationEntriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{50}
 }
 
-func (m *CreateJoinTokenResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CreateJoinTokenResponse.Unmarshal(m, b)
+func (m *BatchUpdateRegistrationEntriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchUpdateRegistrationEntriesRequest.Unmarshal(m, b)
 }
-func (m *CreateJoinTokenResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CreateJoinTokenResponse.Marshal(b, m, deterministic)
+func (m *BatchUpdateRegistrationEntriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchUpdateRegistrationEntriesRequest.Marshal(b, m, deterministic)
 }
-func (m *CreateJoinTokenResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CreateJoinTokenResponse.Merge(m, src)
+func (m *BatchUpdateRegistrationEntriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchUpdateRegistrationEntriesRequest.Merge(m, src)
 }
-func (m *CreateJoinTokenResponse) XXX_Size() int {
-	return xxx_messageInfo_CreateJoinTokenResponse.Size(m)
+func (m *BatchUpdateRegistrationEntriesRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchUpdateRegistrationEntriesRequest.Size(m)
 }
-func (m *CreateJoinTokenResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_CreateJoinTokenResponse.DiscardUnknown(m)
+func (m *BatchUpdateRegistrationEntriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchUpdateRegistrationEntriesRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CreateJoinTokenResponse proto.InternalMessageInfo
+var xxx_messageInfo_BatchUpdateRegistrationEntriesRequest proto.InternalMessageInfo
 
-func (m *CreateJoinTokenResponse) GetJoinToken() *JoinToken {
+func (m *BatchUpdateRegistrationEntriesRequest) GetEntries() []*common.RegistrationEntry {
 	if m != nil {
-		return m.JoinToken
+		return m.Entries
 	}
 	return nil
 }
 
-type FetchJoinTokenRequest struct {
-	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
-
-func (m *FetchJoinTokenRequest) Reset()         { *m = FetchJoinTokenRequest{} }
-func (m *FetchJoinTokenRequest) String() string { return proto.CompactTextString(m) }
-func (*FetchJoinTokenRequest) ProtoMessage()    {}
-func (*FetchJoinTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{48}
-}
-
-func (m *FetchJoinTokenRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_FetchJoinTokenRequest.Unmarshal(m, b)
-}
-func (m *FetchJoinTokenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_FetchJoinTokenRequest.Marshal(b, m, deterministic)
-}
-func (m *FetchJoinTokenRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FetchJoinTokenRequest.Merge(m, src)
-}
-func (m *FetchJoinTokenRequest) XXX_Size() int {
-	return xxx_messageInfo_FetchJoinTokenRequest.Size(m)
-}
-func (m *FetchJoinTokenRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_FetchJoinTokenRequest.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_FetchJoinTokenRequest proto.InternalMessageInfo
-
-func (m *FetchJoinTokenRequest) GetToken() string {
+func (m *BatchUpdateRegistrationEntriesRequest) GetAllOrNothing() bool {
 	if m != nil {
-		return m.Token
+		return m.AllOrNothing
 	}
-	return ""
+	return false
 }
 
-type FetchJoinTokenResponse struct {
-	JoinToken            *JoinToken `protobuf:"bytes,1,opt,name=join_token,json=joinToken,proto3" json:"join_token,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+type BatchUpdateRegistrationEntriesResponse struct {
+	// One result per requested entry, in request order
+	Results              []*BatchRegistrationEntryResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
 }
 
-func (m *FetchJoinTokenResponse) Reset()         { *m = FetchJoinTokenResponse{} }
-func (m *FetchJoinTokenResponse) String() string { return proto.CompactTextString(m) }
-func (*FetchJoinTokenResponse) ProtoMessage()    {}
-func (*FetchJoinTokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{49}
+func (m *BatchUpdateRegistrationEntriesResponse) Reset() {
+	*m = BatchUpdateRegistrationEntriesResponse{}
+}
+func (m *BatchUpdateRegistrationEntriesResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchUpdateRegistrationEntriesResponse) ProtoMessage()    {}
+func (*BatchUpdateRegistrationEntriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{51}
 }
 
-func (m *FetchJoinTokenResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_FetchJoinTokenResponse.Unmarshal(m, b)
+func (m *BatchUpdateRegistrationEntriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchUpdateRegistrationEntriesResponse.Unmarshal(m, b)
 }
-func (m *FetchJoinTokenResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_FetchJoinTokenResponse.Marshal(b, m, deterministic)
+func (m *BatchUpdateRegistrationEntriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchUpdateRegistrationEntriesResponse.Marshal(b, m, deterministic)
 }
-func (m *FetchJoinTokenResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FetchJoinTokenResponse.Merge(m, src)
+func (m *BatchUpdateRegistrationEntriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchUpdateRegistrationEntriesResponse.Merge(m, src)
 }
-func (m *FetchJoinTokenResponse) XXX_Size() int {
-	return xxx_messageInfo_FetchJoinTokenResponse.Size(m)
+func (m *BatchUpdateRegistrationEntriesResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchUpdateRegistrationEntriesResponse.Size(m)
 }
-func (m *FetchJoinTokenResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_FetchJoinTokenResponse.DiscardUnknown(m)
+func (m *BatchUpdateRegistrationEntriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchUpdateRegistrationEntriesResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_FetchJoinTokenResponse proto.InternalMessageInfo
+var xxx_messageInfo_BatchUpdateRegistrationEntriesResponse proto.InternalMessageInfo
 
-func (m *FetchJoinTokenResponse) GetJoinToken() *JoinToken {
+func (m *BatchUpdateRegistrationEntriesResponse) GetResults() []*BatchRegistrationEntryResult {
 	if m != nil {
-		return m.JoinToken
+		return m.Results
 	}
 	return nil
 }
 
-type DeleteJoinTokenRequest struct {
-	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+type BatchDeleteRegistrationEntriesRequest struct {
+	EntryIds []string `protobuf:"bytes,1,rep,name=entry_ids,json=entryIds,proto3" json:"entry_ids,omitempty"`
+	// If set, no entries are deleted unless all of them can be
+	AllOrNothing         bool     `protobuf:"varint,2,opt,name=all_or_nothing,json=allOrNothing,proto3" json:"all_or_nothing,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *DeleteJoinTokenRequest) Reset()         { *m = DeleteJoinTokenRequest{} }
-func (m *DeleteJoinTokenRequest) String() string { return proto.CompactTextString(m) }
-func (*DeleteJoinTokenRequest) ProtoMessage()    {}
-func (*DeleteJoinTokenRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{50}
+func (m *BatchDeleteRegistrationEntriesRequest) Reset()         { *m = BatchDeleteRegistrationEntriesRequest{} }
+func (m *BatchDeleteRegistrationEntriesRequest) String() string { return proto.CompactTextString(m) }
+func (*BatchDeleteRegistrationEntriesRequest) ProtoMessage()    {}
+func (*BatchDeleteRegistrationEntriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{52}
 }
 
-func (m *DeleteJoinTokenRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteJoinTokenRequest.Unmarshal(m, b)
+func (m *BatchDeleteRegistrationEntriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchDeleteRegistrationEntriesRequest.Unmarshal(m, b)
 }
-func (m *DeleteJoinTokenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteJoinTokenRequest.Marshal(b, m, deterministic)
+func (m *BatchDeleteRegistrationEntriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchDeleteRegistrationEntriesRequest.Marshal(b, m, deterministic)
 }
-func (m *DeleteJoinTokenRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteJoinTokenRequest.Merge(m, src)
+func (m *BatchDeleteRegistrationEntriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchDeleteRegistrationEntriesRequest.Merge(m, src)
 }
-func (m *DeleteJoinTokenRequest) XXX_Size() int {
-	return xxx_messageInfo_DeleteJoinTokenRequest.Size(m)
+func (m *BatchDeleteRegistrationEntriesRequest) XXX_Size() int {
+	return xxx_messageInfo_BatchDeleteRegistrationEntriesRequest.Size(m)
 }
-func (m *DeleteJoinTokenRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteJoinTokenRequest.DiscardUnknown(m)
+func (m *BatchDeleteRegistrationEntriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchDeleteRegistrationEntriesRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteJoinTokenRequest proto.InternalMessageInfo
+var xxx_messageInfo_BatchDeleteRegistrationEntriesRequest proto.InternalMessageInfo
 
-func (m *DeleteJoinTokenRequest) GetToken() string {
+func (m *BatchDeleteRegistrationEntriesRequest) GetEntryIds() []string {
 	if m != nil {
-		return m.Token
+		return m.EntryIds
 	}
-	return ""
+	return nil
 }
 
-type DeleteJoinTokenResponse struct {
-	JoinToken            *JoinToken `protobuf:"bytes,1,opt,name=join_token,json=joinToken,proto3" json:"join_token,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+func (m *BatchDeleteRegistrationEntriesRequest) GetAllOrNothing() bool {
+	if m != nil {
+		return m.AllOrNothing
+	}
+	return false
 }
 
-func (m *DeleteJoinTokenResponse) Reset()         { *m = DeleteJoinTokenResponse{} }
-func (m *DeleteJoinTokenResponse) String() string { return proto.CompactTextString(m) }
-func (*DeleteJoinTokenResponse) ProtoMessage()    {}
-func (*DeleteJoinTokenResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{51}
+type BatchDeleteRegistrationEntriesResponse struct {
+	// One result per requested entry ID, in request order
+	Results              []*BatchRegistrationEntryResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                        `json:"-"`
+	XXX_unrecognized     []byte                          `json:"-"`
+	XXX_sizecache        int32                           `json:"-"`
 }
 
-func (m *DeleteJoinTokenResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_DeleteJoinTokenResponse.Unmarshal(m, b)
+func (m *BatchDeleteRegistrationEntriesResponse) Reset() {
+	*m = BatchDeleteRegistrationEntriesResponse{}
 }
-func (m *DeleteJoinTokenResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_DeleteJoinTokenResponse.Marshal(b, m, deterministic)
+func (m *BatchDeleteRegistrationEntriesResponse) String() string { return proto.CompactTextString(m) }
+func (*BatchDeleteRegistrationEntriesResponse) ProtoMessage()    {}
+func (*BatchDeleteRegistrationEntriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{53}
 }
-func (m *DeleteJoinTokenResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_DeleteJoinTokenResponse.Merge(m, src)
+
+func (m *BatchDeleteRegistrationEntriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BatchDeleteRegistrationEntriesResponse.Unmarshal(m, b)
 }
-func (m *DeleteJoinTokenResponse) XXX_Size() int {
-	return xxx_messageInfo_DeleteJoinTokenResponse.Size(m)
+func (m *BatchDeleteRegistrationEntriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BatchDeleteRegistrationEntriesResponse.Marshal(b, m, deterministic)
 }
-func (m *DeleteJoinTokenResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_DeleteJoinTokenResponse.DiscardUnknown(m)
+func (m *BatchDeleteRegistrationEntriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BatchDeleteRegistrationEntriesResponse.Merge(m, src)
+}
+func (m *BatchDeleteRegistrationEntriesResponse) XXX_Size() int {
+	return xxx_messageInfo_BatchDeleteRegistrationEntriesResponse.Size(m)
+}
+func (m *BatchDeleteRegistrationEntriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BatchDeleteRegistrationEntriesResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_DeleteJoinTokenResponse proto.InternalMessageInfo
+var xxx_messageInfo_BatchDeleteRegistrationEntriesResponse proto.InternalMessageInfo
 
-func (m *DeleteJoinTokenResponse) GetJoinToken() *JoinToken {
+func (m *BatchDeleteRegistrationEntriesResponse) GetResults() []*BatchRegistrationEntryResult {
 	if m != nil {
-		return m.JoinToken
+		return m.Results
 	}
 	return nil
 }
 
-type PruneJoinTokensRequest struct {
+type PruneRegistrationEntriesRequest struct {
 	ExpiresBefore        int64    `protobuf:"varint,1,opt,name=expires_before,json=expiresBefore,proto3" json:"expires_before,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PruneJoinTokensRequest) Reset()         { *m = PruneJoinTokensRequest{} }
-func (m *PruneJoinTokensRequest) String() string { return proto.CompactTextString(m) }
-func (*PruneJoinTokensRequest) ProtoMessage()    {}
-func (*PruneJoinTokensRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{52}
+func (m *PruneRegistrationEntriesRequest) Reset()         { *m = PruneRegistrationEntriesRequest{} }
+func (m *PruneRegistrationEntriesRequest) String() string { return proto.CompactTextString(m) }
+func (*PruneRegistrationEntriesRequest) ProtoMessage()    {}
+func (*PruneRegistrationEntriesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{54}
 }
 
-func (m *PruneJoinTokensRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PruneJoinTokensRequest.Unmarshal(m, b)
+func (m *PruneRegistrationEntriesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneRegistrationEntriesRequest.Unmarshal(m, b)
 }
-func (m *PruneJoinTokensRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PruneJoinTokensRequest.Marshal(b, m, deterministic)
+func (m *PruneRegistrationEntriesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneRegistrationEntriesRequest.Marshal(b, m, deterministic)
 }
-func (m *PruneJoinTokensRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PruneJoinTokensRequest.Merge(m, src)
+func (m *PruneRegistrationEntriesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneRegistrationEntriesRequest.Merge(m, src)
 }
-func (m *PruneJoinTokensRequest) XXX_Size() int {
-	return xxx_messageInfo_PruneJoinTokensRequest.Size(m)
+func (m *PruneRegistrationEntriesRequest) XXX_Size() int {
+	return xxx_messageInfo_PruneRegistrationEntriesRequest.Size(m)
 }
-func (m *PruneJoinTokensRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_PruneJoinTokensRequest.DiscardUnknown(m)
+func (m *PruneRegistrationEntriesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneRegistrationEntriesRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PruneJoinTokensRequest proto.InternalMessageInfo
+var xxx_messageInfo_PruneRegistrationEntriesRequest proto.InternalMessageInfo
 
-func (m *PruneJoinTokensRequest) GetExpiresBefore() int64 {
+func (m *PruneRegistrationEntriesRequest) GetExpiresBefore() int64 {
 	if m != nil {
 		return m.ExpiresBefore
 	}
 	return 0
 }
 
-type PruneJoinTokensResponse struct {
+type PruneRegistrationEntriesResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *PruneJoinTokensResponse) Reset()         { *m = PruneJoinTokensResponse{} }
-func (m *PruneJoinTokensResponse) String() string { return proto.CompactTextString(m) }
-func (*PruneJoinTokensResponse) ProtoMessage()    {}
-func (*PruneJoinTokensResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_d08157cfd31fc929, []int{53}
+func (m *PruneRegistrationEntriesResponse) Reset()         { *m = PruneRegistrationEntriesResponse{} }
+func (m *PruneRegistrationEntriesResponse) String() string { return proto.CompactTextString(m) }
+func (*PruneRegistrationEntriesResponse) ProtoMessage()    {}
+func (*PruneRegistrationEntriesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{55}
 }
 
-func (m *PruneJoinTokensResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_PruneJoinTokensResponse.Unmarshal(m, b)
+func (m *PruneRegistrationEntriesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneRegistrationEntriesResponse.Unmarshal(m, b)
 }
-func (m *PruneJoinTokensResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_PruneJoinTokensResponse.Marshal(b, m, deterministic)
+func (m *PruneRegistrationEntriesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneRegistrationEntriesResponse.Marshal(b, m, deterministic)
 }
-func (m *PruneJoinTokensResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_PruneJoinTokensResponse.Merge(m, src)
+func (m *PruneRegistrationEntriesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneRegistrationEntriesResponse.Merge(m, src)
 }
-func (m *PruneJoinTokensResponse) XXX_Size() int {
-	return xxx_messageInfo_PruneJoinTokensResponse.Size(m)
+func (m *PruneRegistrationEntriesResponse) XXX_Size() int {
+	return xxx_messageInfo_PruneRegistrationEntriesResponse.Size(m)
 }
-func (m *PruneJoinTokensResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_PruneJoinTokensResponse.DiscardUnknown(m)
+func (m *PruneRegistrationEntriesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneRegistrationEntriesResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_PruneJoinTokensResponse proto.InternalMessageInfo
+var xxx_messageInfo_PruneRegistrationEntriesResponse proto.InternalMessageInfo
 
-func init() {
-	proto.RegisterEnum("spire.server.datastore.DeleteBundleRequest_Mode", DeleteBundleRequest_Mode_name, DeleteBundleRequest_Mode_value)
-	proto.RegisterEnum("spire.server.datastore.BySelectors_MatchBehavior", BySelectors_MatchBehavior_name, BySelectors_MatchBehavior_value)
-	proto.RegisterType((*CreateBundleRequest)(nil), "spire.server.datastore.CreateBundleRequest")
-	proto.RegisterType((*CreateBundleResponse)(nil), "spire.server.datastore.CreateBundleResponse")
-	proto.RegisterType((*FetchBundleRequest)(nil), "spire.server.datastore.FetchBundleRequest")
-	proto.RegisterType((*FetchBundleResponse)(nil), "spire.server.datastore.FetchBundleResponse")
-	proto.RegisterType((*ListBundlesRequest)(nil), "spire.server.datastore.ListBundlesRequest")
-	proto.RegisterType((*ListBundlesResponse)(nil), "spire.server.datastore.ListBundlesResponse")
-	proto.RegisterType((*UpdateBundleRequest)(nil), "spire.server.datastore.UpdateBundleRequest")
-	proto.RegisterType((*UpdateBundleResponse)(nil), "spire.server.datastore.UpdateBundleResponse")
-	proto.RegisterType((*SetBundleRequest)(nil), "spire.server.datastore.SetBundleRequest")
-	proto.RegisterType((*SetBundleResponse)(nil), "spire.server.datastore.SetBundleResponse")
-	proto.RegisterType((*AppendBundleRequest)(nil), "spire.server.datastore.AppendBundleRequest")
-	proto.RegisterType((*AppendBundleResponse)(nil), "spire.server.datastore.AppendBundleResponse")
-	proto.RegisterType((*DeleteBundleRequest)(nil), "spire.server.datastore.DeleteBundleRequest")
-	proto.RegisterType((*DeleteBundleResponse)(nil), "spire.server.datastore.DeleteBundleResponse")
-	proto.RegisterType((*PruneBundleRequest)(nil), "spire.server.datastore.PruneBundleRequest")
-	proto.RegisterType((*PruneBundleResponse)(nil), "spire.server.datastore.PruneBundleResponse")
-	proto.RegisterType((*NodeSelectors)(nil), "spire.server.datastore.NodeSelectors")
-	proto.RegisterType((*SetNodeSelectorsRequest)(nil), "spire.server.datastore.SetNodeSelectorsRequest")
-	proto.RegisterType((*SetNodeSelectorsResponse)(nil), "spire.server.datastore.SetNodeSelectorsResponse")
-	proto.RegisterType((*GetNodeSelectorsRequest)(nil), "spire.server.datastore.GetNodeSelectorsRequest")
-	proto.RegisterType((*GetNodeSelectorsResponse)(nil), "spire.server.datastore.GetNodeSelectorsResponse")
-	proto.RegisterType((*CreateAttestedNodeRequest)(nil), "spire.server.datastore.CreateAttestedNodeRequest")
-	proto.RegisterType((*CreateAttestedNodeResponse)(nil), "spire.server.datastore.CreateAttestedNodeResponse")
-	proto.RegisterType((*FetchAttestedNodeRequest)(nil), "spire.server.datastore.FetchAttestedNodeRequest")
-	proto.RegisterType((*FetchAttestedNodeResponse)(nil), "spire.server.datastore.FetchAttestedNodeResponse")
-	proto.RegisterType((*ListAttestedNodesRequest)(nil), "spire.server.datastore.ListAttestedNodesRequest")
-	proto.RegisterType((*ListAttestedNodesResponse)(nil), "spire.server.datastore.ListAttestedNodesResponse")
-	proto.RegisterType((*UpdateAttestedNodeRequest)(nil), "spire.server.datastore.UpdateAttestedNodeRequest")
-	proto.RegisterType((*UpdateAttestedNodeResponse)(nil), "spire.server.datastore.UpdateAttestedNodeResponse")
-	proto.RegisterType((*DeleteAttestedNodeRequest)(nil), "spire.server.datastore.DeleteAttestedNodeRequest")
-	proto.RegisterType((*DeleteAttestedNodeResponse)(nil), "spire.server.datastore.DeleteAttestedNodeResponse")
-	proto.RegisterType((*CreateRegistrationEntryRequest)(nil), "spire.server.datastore.CreateRegistrationEntryRequest")
-	proto.RegisterType((*CreateRegistrationEntryResponse)(nil), "spire.server.datastore.CreateRegistrationEntryResponse")
-	proto.RegisterType((*FetchRegistrationEntryRequest)(nil), "spire.server.datastore.FetchRegistrationEntryRequest")
-	proto.RegisterType((*FetchRegistrationEntryResponse)(nil), "spire.server.datastore.FetchRegistrationEntryResponse")
-	proto.RegisterType((*BySelectors)(nil), "spire.server.datastore.BySelectors")
-	proto.RegisterType((*Pagination)(nil), "spire.server.datastore.Pagination")
-	proto.RegisterType((*ListRegistrationEntriesRequest)(nil), "spire.server.datastore.ListRegistrationEntriesRequest")
-	proto.RegisterType((*ListRegistrationEntriesResponse)(nil), "spire.server.datastore.ListRegistrationEntriesResponse")
-	proto.RegisterType((*UpdateRegistrationEntryRequest)(nil), "spire.server.datastore.UpdateRegistrationEntryRequest")
-	proto.RegisterType((*UpdateRegistrationEntryResponse)(nil), "spire.server.datastore.UpdateRegistrationEntryResponse")
-	proto.RegisterType((*DeleteRegistrationEntryRequest)(nil), "spire.server.datastore.DeleteRegistrationEntryRequest")
-	proto.RegisterType((*DeleteRegistrationEntryResponse)(nil), "spire.server.datastore.DeleteRegistrationEntryResponse")
-	proto.RegisterType((*PruneRegistrationEntriesRequest)(nil), "spire.server.datastore.PruneRegistrationEntriesRequest")
-	proto.RegisterType((*PruneRegistrationEntriesResponse)(nil), "spire.server.datastore.PruneRegistrationEntriesResponse")
-	proto.RegisterType((*JoinToken)(nil), "spire.server.datastore.JoinToken")
-	proto.RegisterType((*CreateJoinTokenRequest)(nil), "spire.server.datastore.CreateJoinTokenRequest")
-	proto.RegisterType((*CreateJoinTokenResponse)(nil), "spire.server.datastore.CreateJoinTokenResponse")
-	proto.RegisterType((*FetchJoinTokenRequest)(nil), "spire.server.datastore.FetchJoinTokenRequest")
-	proto.RegisterType((*FetchJoinTokenResponse)(nil), "spire.server.datastore.FetchJoinTokenResponse")
-	proto.RegisterType((*DeleteJoinTokenRequest)(nil), "spire.server.datastore.DeleteJoinTokenRequest")
-	proto.RegisterType((*DeleteJoinTokenResponse)(nil), "spire.server.datastore.DeleteJoinTokenResponse")
-	proto.RegisterType((*PruneJoinTokensRequest)(nil), "spire.server.datastore.PruneJoinTokensRequest")
-	proto.RegisterType((*PruneJoinTokensResponse)(nil), "spire.server.datastore.PruneJoinTokensResponse")
+type ListRegistrationEntryTombstonesRequest struct {
+	// Only tombstones of entries deleted after this revision are listed
+	AfterRevision        int64    `protobuf:"varint,1,opt,name=after_revision,json=afterRevision,proto3" json:"after_revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() { proto.RegisterFile("datastore.proto", fileDescriptor_d08157cfd31fc929) }
+func (m *ListRegistrationEntryTombstonesRequest) Reset() {
+	*m = ListRegistrationEntryTombstonesRequest{}
+}
+func (m *ListRegistrationEntryTombstonesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRegistrationEntryTombstonesRequest) ProtoMessage()    {}
+func (*ListRegistrationEntryTombstonesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{56}
+}
 
-var fileDescriptor_d08157cfd31fc929 = []byte{
-	// 1697 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x59, 0xdb, 0x57, 0xdb, 0x46,
-	0x13, 0xff, 0xc4, 0x2d, 0x78, 0xcc, 0x2d, 0x0b, 0x1f, 0xd8, 0xce, 0xf7, 0x01, 0x55, 0x4b, 0x4e,
-	0xae, 0x32, 0x71, 0x09, 0x49, 0xda, 0x9c, 0x26, 0x60, 0x1c, 0xe2, 0x86, 0xa4, 0x1c, 0x99, 0x34,
-	0x39, 0xc9, 0x39, 0x75, 0x65, 0xbc, 0x36, 0x4a, 0x41, 0x72, 0xa5, 0x75, 0x1a, 0x92, 0x3f, 0xa0,
-	0xa7, 0x3d, 0xed, 0x43, 0xff, 0x83, 0xbe, 0xf5, 0xa5, 0xaf, 0x7d, 0xef, 0x9f, 0xd6, 0xa3, 0x5d,
-	0xc9, 0x92, 0x2c, 0x8d, 0xf0, 0x85, 0x3e, 0x81, 0x76, 0xe7, 0xf2, 0x9b, 0xd9, 0x9d, 0xd9, 0x99,
-	0x31, 0xcc, 0xd6, 0x35, 0xa6, 0xd9, 0xcc, 0xb4, 0xa8, 0xd2, 0xb2, 0x4c, 0x66, 0x92, 0x45, 0xbb,
-	0xa5, 0x5b, 0x54, 0xb1, 0xa9, 0xf5, 0x96, 0x5a, 0x4a, 0x67, 0x37, 0xb7, 0xdc, 0x34, 0xcd, 0xe6,
-	0x31, 0xcd, 0x73, 0xaa, 0x5a, 0xbb, 0x91, 0xff, 0xc1, 0xd2, 0x5a, 0x2d, 0x6a, 0xd9, 0x82, 0x2f,
-	0xb7, 0xca, 0xf9, 0xf2, 0x87, 0xe6, 0xc9, 0x89, 0x69, 0xe4, 0x5b, 0xc7, 0xed, 0xa6, 0xee, 0xfd,
-	0x71, 0x29, 0xb2, 0x21, 0x0a, 0xf1, 0x47, 0x6c, 0xc9, 0x45, 0x98, 0x2f, 0x5a, 0x54, 0x63, 0x74,
-	0xbb, 0x6d, 0xd4, 0x8f, 0xa9, 0x4a, 0xbf, 0x6f, 0x53, 0x9b, 0x91, 0x1b, 0x30, 0x51, 0xe3, 0x0b,
-	0x19, 0x69, 0x55, 0xba, 0x92, 0x2e, 0x2c, 0x28, 0x02, 0x9c, 0xcb, 0xeb, 0x12, 0xbb, 0x34, 0xf2,
-	0x0e, 0x2c, 0x84, 0x85, 0xd8, 0x2d, 0xd3, 0xb0, 0x69, 0x9f, 0x52, 0xee, 0x03, 0x79, 0x44, 0xd9,
-	0xe1, 0x51, 0x18, 0xc9, 0x65, 0x98, 0x65, 0x56, 0xdb, 0x66, 0xd5, 0xba, 0x79, 0xa2, 0xe9, 0x46,
-	0x55, 0xaf, 0x73, 0x61, 0x29, 0x75, 0x9a, 0x2f, 0xef, 0xf0, 0xd5, 0x72, 0xdd, 0x31, 0x24, 0xc4,
-	0x3d, 0x10, 0x84, 0x05, 0x20, 0x7b, 0xba, 0xcd, 0xc4, 0xaa, 0xed, 0x42, 0x90, 0x4b, 0x30, 0x1f,
-	0x5a, 0x75, 0x45, 0x2b, 0x70, 0x41, 0xb0, 0xd9, 0x19, 0x69, 0x75, 0x14, 0x95, 0xed, 0x11, 0x39,
-	0x08, 0x9f, 0xb7, 0xea, 0xc3, 0xbb, 0x3a, 0x2c, 0x64, 0x20, 0x3b, 0x1f, 0xc2, 0x5c, 0x85, 0xb2,
-	0x61, 0x70, 0x6c, 0xc1, 0xc5, 0x80, 0x84, 0x81, 0x40, 0x14, 0x61, 0x7e, 0xab, 0xd5, 0xa2, 0x46,
-	0x7d, 0x48, 0x7f, 0x84, 0x85, 0x0c, 0x04, 0xe5, 0x2f, 0x09, 0xe6, 0x77, 0xe8, 0x31, 0xed, 0x3e,
-	0x9b, 0x1e, 0x2f, 0x1f, 0xd9, 0x81, 0xb1, 0x13, 0xb3, 0x4e, 0x33, 0x23, 0xab, 0xd2, 0x95, 0x99,
-	0xc2, 0xba, 0x12, 0x1f, 0xc9, 0x4a, 0x8c, 0x0a, 0xe5, 0xa9, 0x59, 0xa7, 0x2a, 0xe7, 0x96, 0xd7,
-	0x61, 0xcc, 0xf9, 0x22, 0x53, 0x30, 0xa9, 0x96, 0x2a, 0x07, 0x6a, 0xb9, 0x78, 0x30, 0xf7, 0x1f,
-	0x02, 0x30, 0xb1, 0x53, 0xda, 0x2b, 0x1d, 0x94, 0xe6, 0x24, 0x32, 0x03, 0xb0, 0x53, 0xae, 0x54,
-	0xbe, 0x2a, 0x96, 0xb7, 0x0e, 0x4a, 0x73, 0x23, 0x8e, 0xf5, 0x61, 0x99, 0x03, 0x59, 0x7f, 0x08,
-	0x64, 0xdf, 0x6a, 0x1b, 0x03, 0xda, 0xbe, 0x06, 0x33, 0xf4, 0x9d, 0x23, 0xdd, 0xae, 0xd6, 0x68,
-	0xc3, 0xb4, 0x84, 0x17, 0x46, 0xd5, 0x69, 0x77, 0x75, 0x9b, 0x2f, 0xca, 0xf7, 0x61, 0x3e, 0xa4,
-	0xc4, 0x45, 0xba, 0x06, 0x33, 0x02, 0x45, 0xf5, 0xf0, 0x48, 0x33, 0x9a, 0x54, 0x28, 0x99, 0x54,
-	0xa7, 0xc5, 0x6a, 0x51, 0x2c, 0xca, 0x35, 0x98, 0x7e, 0x66, 0xd6, 0x69, 0x85, 0x1e, 0xd3, 0x43,
-	0x66, 0x5a, 0x36, 0xb9, 0x04, 0x29, 0xbb, 0xa5, 0x37, 0x1a, 0xd4, 0xc7, 0x35, 0x29, 0x16, 0xca,
-	0x75, 0xb2, 0x01, 0x29, 0xdb, 0xa3, 0xcc, 0x8c, 0xf0, 0xd8, 0x5c, 0x0c, 0x7b, 0xc0, 0x13, 0xa4,
-	0xfa, 0x84, 0xf2, 0x37, 0xb0, 0x54, 0xa1, 0x2c, 0xa4, 0xc6, 0xf3, 0x45, 0x31, 0x28, 0x50, 0xb8,
-	0x74, 0x0d, 0x3b, 0xe4, 0xb0, 0x80, 0x80, 0xfc, 0x1c, 0x64, 0xa2, 0xf2, 0x85, 0x1b, 0xe4, 0x4d,
-	0x58, 0xda, 0x45, 0x74, 0x27, 0x59, 0x2a, 0x57, 0x21, 0xb3, 0x8b, 0xc8, 0x3c, 0x1f, 0xd0, 0x4f,
-	0x20, 0x2b, 0x52, 0xfb, 0x16, 0x63, 0xd4, 0x66, 0xb4, 0xee, 0x50, 0x7a, 0xd0, 0x14, 0x18, 0x33,
-	0x9c, 0x6b, 0x2f, 0x84, 0xe7, 0xc2, 0x2e, 0x0e, 0x31, 0x70, 0x3a, 0x79, 0x0f, 0x72, 0x71, 0xc2,
-	0x3a, 0xf9, 0xb4, 0x3f, 0x69, 0x77, 0x20, 0xc3, 0x33, 0x7e, 0x1c, 0xb2, 0x44, 0xa7, 0x3d, 0x81,
-	0x6c, 0x0c, 0xe3, 0x80, 0x28, 0xfe, 0x90, 0x20, 0xe3, 0xbc, 0x0e, 0xc1, 0xad, 0xce, 0xd9, 0xed,
-	0xc2, 0xc5, 0xda, 0x69, 0xb5, 0x2b, 0x3c, 0x84, 0xe4, 0x4b, 0x8a, 0x78, 0xd6, 0x15, 0xef, 0x59,
-	0x57, 0xca, 0x06, 0xdb, 0xdc, 0xf8, 0x5a, 0x3b, 0x6e, 0x53, 0x75, 0xb6, 0x76, 0x5a, 0x0a, 0x46,
-	0x0f, 0xd9, 0x06, 0x68, 0x69, 0x4d, 0xdd, 0xd0, 0x98, 0x6e, 0x1a, 0x3c, 0xc0, 0xd2, 0x05, 0x19,
-	0x3b, 0xcc, 0xfd, 0x0e, 0xa5, 0x1a, 0xe0, 0x92, 0x7f, 0x93, 0x20, 0x1b, 0x83, 0xd4, 0xb5, 0x7b,
-	0x1d, 0xc6, 0x1d, 0x7b, 0xbc, 0xb7, 0x2c, 0xc9, 0x70, 0x41, 0x78, 0x2e, 0x98, 0x7e, 0x91, 0x20,
-	0x2b, 0xde, 0xb3, 0x7e, 0x4f, 0x91, 0xdc, 0x00, 0x72, 0x48, 0x2d, 0x56, 0xb5, 0xa9, 0xa5, 0x6b,
-	0xc7, 0x55, 0xa3, 0x7d, 0x52, 0xa3, 0x16, 0x87, 0x91, 0x52, 0xe7, 0x9c, 0x9d, 0x0a, 0xdf, 0x78,
-	0xc6, 0xd7, 0xc9, 0x27, 0x30, 0xc3, 0xa9, 0x0d, 0x93, 0x55, 0xb5, 0x06, 0xa3, 0x56, 0x66, 0x94,
-	0x67, 0xa9, 0x29, 0x67, 0xf5, 0x99, 0xc9, 0xb6, 0x9c, 0x35, 0xe7, 0x82, 0xc6, 0xa1, 0x19, 0xf0,
-	0x6a, 0xdc, 0x85, 0xac, 0xc8, 0xce, 0x7d, 0xdf, 0xd0, 0x3d, 0xc8, 0xc5, 0x71, 0x0e, 0x88, 0xe3,
-	0x05, 0x2c, 0x8b, 0xb0, 0x53, 0x69, 0x53, 0xb7, 0x99, 0xc5, 0x5d, 0x5f, 0x32, 0x98, 0x75, 0xea,
-	0x81, 0xb9, 0x0d, 0xe3, 0xd4, 0xf9, 0x76, 0x45, 0xae, 0x84, 0x45, 0x46, 0xd9, 0x04, 0xb5, 0xfc,
-	0x12, 0x56, 0x50, 0xc1, 0x2e, 0xd6, 0x01, 0x25, 0x7f, 0x06, 0xff, 0xe7, 0x21, 0x8a, 0x22, 0xce,
-	0xc2, 0x24, 0xa7, 0xf4, 0xbd, 0x77, 0x81, 0x7f, 0x97, 0xeb, 0x8e, 0xb9, 0x18, 0xef, 0x70, 0xa0,
-	0xfe, 0x96, 0x20, 0xbd, 0x7d, 0xea, 0xbf, 0x41, 0x1b, 0xe1, 0x04, 0xdb, 0xdb, 0x33, 0x43, 0x76,
-	0x61, 0xfc, 0x44, 0x63, 0x87, 0x47, 0x6e, 0xb1, 0x70, 0x0b, 0x8b, 0x98, 0x80, 0x26, 0xe5, 0xa9,
-	0xc3, 0xb0, 0x4d, 0x8f, 0xb4, 0xb7, 0xba, 0x69, 0xa9, 0x82, 0x5f, 0x2e, 0xc0, 0x74, 0x68, 0x9d,
-	0xcc, 0x42, 0xfa, 0xe9, 0xd6, 0x41, 0xf1, 0x71, 0xb5, 0xf4, 0x72, 0x8b, 0x97, 0x0e, 0x73, 0x30,
-	0x25, 0x16, 0x2a, 0xcf, 0xb7, 0x2b, 0xa5, 0x83, 0x39, 0x49, 0x7e, 0x00, 0xe0, 0x47, 0x22, 0x59,
-	0x80, 0x71, 0x66, 0x7e, 0x47, 0x0d, 0xd7, 0x83, 0xe2, 0xc3, 0xb9, 0x99, 0x2d, 0xad, 0x49, 0xab,
-	0xb6, 0xfe, 0x5e, 0xbc, 0xe5, 0xe3, 0xea, 0xa4, 0xb3, 0x50, 0xd1, 0xdf, 0x53, 0xf9, 0xcf, 0x11,
-	0x58, 0x76, 0x92, 0x48, 0xb7, 0x93, 0x74, 0x3f, 0xe9, 0x7d, 0x01, 0x53, 0xb5, 0xd3, 0x6a, 0x4b,
-	0xb3, 0xa8, 0xc1, 0xbc, 0xe3, 0x49, 0x17, 0xfe, 0x17, 0xc9, 0x77, 0x15, 0x66, 0xe9, 0x46, 0x53,
-	0x24, 0x3c, 0xa8, 0x9d, 0xee, 0x73, 0x86, 0x72, 0x9d, 0x3c, 0xe2, 0xfc, 0xc1, 0x07, 0xdc, 0xe1,
-	0xff, 0xb8, 0x07, 0x3f, 0xa9, 0xe9, 0x5a, 0xe0, 0x78, 0x04, 0x0e, 0x3f, 0xc8, 0x46, 0x7b, 0xc3,
-	0x51, 0xf1, 0x12, 0x4c, 0x38, 0xbf, 0x8d, 0x0d, 0x94, 0xdf, 0x7e, 0x97, 0x60, 0x05, 0x75, 0x97,
-	0x7b, 0x1b, 0xef, 0x01, 0xbf, 0xba, 0x7a, 0x27, 0xf7, 0x9e, 0x79, 0x1f, 0x3d, 0xfa, 0x73, 0x49,
-	0xc1, 0x2f, 0x60, 0x59, 0xe4, 0xbc, 0x7f, 0x21, 0x3b, 0xa0, 0x82, 0x87, 0x0b, 0xc4, 0xcf, 0x61,
-	0x59, 0xa4, 0xc7, 0x41, 0xd2, 0xc3, 0x4b, 0x58, 0x41, 0x99, 0x87, 0x83, 0xf5, 0x18, 0x56, 0x78,
-	0x89, 0x9b, 0x10, 0x1b, 0xd1, 0x62, 0x59, 0x8a, 0x2b, 0x96, 0x65, 0x58, 0xc5, 0x25, 0xb9, 0x25,
-	0xe3, 0x3d, 0x48, 0x7d, 0x69, 0xea, 0xc6, 0x01, 0x8f, 0xd9, 0xf8, 0x48, 0x5e, 0x84, 0x09, 0x2e,
-	0xf7, 0xd4, 0x2d, 0xc9, 0xdd, 0x2f, 0xf9, 0x15, 0x2c, 0x8a, 0xbc, 0xdd, 0x11, 0xe0, 0xe1, 0x7b,
-	0x08, 0xf0, 0xc6, 0xd4, 0x8d, 0xaa, 0x2f, 0x2c, 0x5d, 0xf8, 0x08, 0xbb, 0x50, 0x3e, 0x77, 0xea,
-	0x8d, 0xf7, 0xaf, 0xfc, 0x1a, 0x96, 0x22, 0xb2, 0x5d, 0xb7, 0x0e, 0x2f, 0xfc, 0x26, 0xfc, 0x97,
-	0xa7, 0xf6, 0x08, 0xee, 0x58, 0xfb, 0x1d, 0x3b, 0xbb, 0xc9, 0xcf, 0x0d, 0x8a, 0x02, 0x8b, 0xe2,
-	0x1a, 0xf5, 0x88, 0xe5, 0x35, 0x2c, 0x45, 0xe8, 0xcf, 0x0d, 0xcc, 0x03, 0x58, 0xe4, 0xf7, 0xa5,
-	0xb3, 0xd9, 0xef, 0x85, 0xcb, 0xc2, 0x52, 0x44, 0x80, 0x40, 0x57, 0xf8, 0x29, 0x0b, 0xa9, 0x1d,
-	0x8d, 0x69, 0x15, 0x47, 0x3d, 0xd1, 0x61, 0x2a, 0x38, 0xea, 0x21, 0xd7, 0x31, 0x9c, 0x31, 0x53,
-	0xa5, 0xdc, 0x8d, 0xde, 0x88, 0x5d, 0xb7, 0x34, 0x20, 0x1d, 0x98, 0xe8, 0x90, 0x6b, 0x18, 0x73,
-	0x74, 0x68, 0x94, 0xbb, 0xde, 0x13, 0xad, 0xaf, 0x27, 0x30, 0xde, 0xc1, 0xf5, 0x44, 0x27, 0x43,
-	0xb8, 0x9e, 0xb8, 0x79, 0x91, 0x0e, 0x53, 0xc1, 0xd1, 0x0d, 0xee, 0xba, 0x98, 0x29, 0x11, 0xee,
-	0xba, 0xd8, 0x69, 0xd0, 0xb7, 0x90, 0xea, 0x4c, 0x67, 0xc8, 0x15, 0x8c, 0xb5, 0x7b, 0x04, 0x94,
-	0xbb, 0xda, 0x03, 0xa5, 0x6f, 0x4c, 0x70, 0xee, 0x82, 0x1b, 0x13, 0x33, 0xe2, 0xc1, 0x8d, 0x89,
-	0x1d, 0xe5, 0xe8, 0x30, 0x15, 0x1c, 0x72, 0xe0, 0xaa, 0x62, 0xc6, 0x2b, 0xb8, 0xaa, 0xd8, 0xb9,
-	0x49, 0x03, 0xd2, 0x81, 0x21, 0x05, 0x7e, 0x15, 0xa2, 0xe3, 0x12, 0xfc, 0x2a, 0xc4, 0x4d, 0x3d,
-	0x3e, 0x00, 0x89, 0x36, 0xc2, 0xe4, 0x56, 0x72, 0x78, 0xc4, 0x74, 0x11, 0xb9, 0x42, 0x3f, 0x2c,
-	0xae, 0xf2, 0x77, 0x70, 0x31, 0xd2, 0xfe, 0x92, 0xf5, 0xc4, 0x88, 0x89, 0x53, 0x7d, 0xab, 0x0f,
-	0x0e, 0x5f, 0x73, 0xa4, 0x01, 0xc5, 0x35, 0x63, 0x5d, 0x35, 0xae, 0x19, 0xef, 0x6e, 0x3f, 0x00,
-	0x89, 0x36, 0x76, 0xb8, 0xc3, 0xd1, 0x96, 0x14, 0x77, 0x78, 0x42, 0xdf, 0xf8, 0x01, 0x48, 0xb4,
-	0x9b, 0xc3, 0x95, 0xa3, 0x3d, 0x23, 0xae, 0x3c, 0xa1, 0x59, 0x6c, 0xf3, 0x51, 0x6f, 0x78, 0x78,
-	0x96, 0x4f, 0x88, 0xf3, 0xb8, 0x19, 0x54, 0x6e, 0xbd, 0x77, 0x06, 0x5f, 0xed, 0x6e, 0xcf, 0x6a,
-	0x77, 0xfb, 0x55, 0x8b, 0xce, 0xbc, 0x7e, 0x96, 0xbc, 0xf2, 0x23, 0x52, 0xa5, 0x91, 0xcd, 0xe4,
-	0x58, 0xc1, 0x6a, 0xc9, 0xdc, 0x9d, 0xbe, 0xf9, 0x5c, 0x30, 0x3f, 0x4a, 0x6e, 0xfd, 0x11, 0xc5,
-	0x72, 0x3b, 0x31, 0x78, 0x50, 0x28, 0x9b, 0xfd, 0xb2, 0x05, 0xdc, 0x82, 0xb4, 0x21, 0xb8, 0x5b,
-	0x92, 0xdb, 0x3c, 0xdc, 0x2d, 0x67, 0xf5, 0x3b, 0x0e, 0x18, 0xa4, 0x31, 0xc0, 0xc1, 0x24, 0xb7,
-	0x28, 0x38, 0x98, 0xb3, 0x3a, 0x10, 0x07, 0x0c, 0xd2, 0x0e, 0xe0, 0x60, 0x92, 0x9b, 0x0f, 0x1c,
-	0xcc, 0x59, 0x7d, 0xc7, 0xaf, 0x12, 0x64, 0xb0, 0xba, 0x9f, 0xdc, 0x49, 0x7c, 0x60, 0x12, 0x0e,
-	0xea, 0x6e, 0xff, 0x8c, 0x2e, 0x1e, 0x0b, 0x66, 0xbb, 0x6a, 0x79, 0xa2, 0x24, 0x07, 0x43, 0x77,
-	0x31, 0x9c, 0xcb, 0xf7, 0x4c, 0xef, 0xea, 0x34, 0x61, 0x26, 0x5c, 0xb3, 0x93, 0x9b, 0x89, 0x97,
-	0x3e, 0xa2, 0x51, 0xe9, 0x95, 0xdc, 0x37, 0xb2, 0xab, 0x30, 0xc7, 0x8d, 0x8c, 0xaf, 0xf8, 0x71,
-	0x23, 0xb1, 0x8a, 0xdf, 0x82, 0xd9, 0xae, 0x72, 0x1b, 0xd7, 0x19, 0x5f, 0xd8, 0xe3, 0x3a, 0x91,
-	0x3a, 0x9e, 0xbc, 0x82, 0x54, 0xd1, 0x34, 0x1a, 0x7a, 0xb3, 0x6d, 0x51, 0xb2, 0x16, 0x6e, 0x69,
-	0xdd, 0x5f, 0x8b, 0x3b, 0xfb, 0x9e, 0x92, 0xcb, 0x67, 0x91, 0x75, 0xea, 0xa6, 0xe9, 0x5d, 0xca,
-	0xf6, 0xf9, 0x76, 0xd9, 0x68, 0x98, 0xe4, 0x6a, 0x2c, 0x63, 0x88, 0xc6, 0xd3, 0x71, 0xad, 0x17,
-	0x52, 0xa1, 0x67, 0x7b, 0xf3, 0xd5, 0x46, 0x53, 0x67, 0x47, 0xed, 0x9a, 0x43, 0x9d, 0x17, 0xa3,
-	0x9d, 0xbc, 0xf8, 0x71, 0x9b, 0x8f, 0x73, 0xdc, 0xff, 0x85, 0x4f, 0xf2, 0x1d, 0x9f, 0xd4, 0x26,
-	0xf8, 0xee, 0xa7, 0xff, 0x04, 0x00, 0x00, 0xff, 0xff, 0x5c, 0xcd, 0xfb, 0x28, 0x74, 0x1f, 0x00,
-	0x00,
+func (m *ListRegistrationEntryTombstonesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRegistrationEntryTombstonesRequest.Unmarshal(m, b)
+}
+func (m *ListRegistrationEntryTombstonesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRegistrationEntryTombstonesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListRegistrationEntryTombstonesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRegistrationEntryTombstonesRequest.Merge(m, src)
+}
+func (m *ListRegistrationEntryTombstonesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListRegistrationEntryTombstonesRequest.Size(m)
+}
+func (m *ListRegistrationEntryTombstonesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRegistrationEntryTombstonesRequest.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
+var xxx_messageInfo_ListRegistrationEntryTombstonesRequest proto.InternalMessageInfo
 
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *ListRegistrationEntryTombstonesRequest) GetAfterRevision() int64 {
+	if m != nil {
+		return m.AfterRevision
+	}
+	return 0
+}
 
-// DataStoreClient is the client API for DataStore service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type DataStoreClient interface {
-	// Creates a bundle
-	CreateBundle(ctx context.Context, in *CreateBundleRequest, opts ...grpc.CallOption) (*CreateBundleResponse, error)
-	// Fetches a specific bundle
-	FetchBundle(ctx context.Context, in *FetchBundleRequest, opts ...grpc.CallOption) (*FetchBundleResponse, error)
-	// Lists bundles (optionally filtered)
-	ListBundles(ctx context.Context, in *ListBundlesRequest, opts ...grpc.CallOption) (*ListBundlesResponse, error)
-	// Updates a specific bundle
-	UpdateBundle(ctx context.Context, in *UpdateBundleRequest, opts ...grpc.CallOption) (*UpdateBundleResponse, error)
-	// Sets bundle contents (creates if it does not exist)
-	SetBundle(ctx context.Context, in *SetBundleRequest, opts ...grpc.CallOption) (*SetBundleResponse, error)
-	// Appends contents from a specific bundle (creates if it does not exist)
-	AppendBundle(ctx context.Context, in *AppendBundleRequest, opts ...grpc.CallOption) (*AppendBundleResponse, error)
-	// Deletes a specific bundle
-	DeleteBundle(ctx context.Context, in *DeleteBundleRequest, opts ...grpc.CallOption) (*DeleteBundleResponse, error)
-	// Prunes all expired certificates and JWT signing keys from a bundle
-	PruneBundle(ctx context.Context, in *PruneBundleRequest, opts ...grpc.CallOption) (*PruneBundleResponse, error)
-	// Creates an attested node
-	CreateAttestedNode(ctx context.Context, in *CreateAttestedNodeRequest, opts ...grpc.CallOption) (*CreateAttestedNodeResponse, error)
-	// Fetches a specific attested node
-	FetchAttestedNode(ctx context.Context, in *FetchAttestedNodeRequest, opts ...grpc.CallOption) (*FetchAttestedNodeResponse, error)
-	// Lists attested nodes (optionally filtered)
-	ListAttestedNodes(ctx context.Context, in *ListAttestedNodesRequest, opts ...grpc.CallOption) (*ListAttestedNodesResponse, error)
-	// Updates a specific attested node
-	UpdateAttestedNode(ctx context.Context, in *UpdateAttestedNodeRequest, opts ...grpc.CallOption) (*UpdateAttestedNodeResponse, error)
-	// Deletes a specific attested node
-	DeleteAttestedNode(ctx context.Context, in *DeleteAttestedNodeRequest, opts ...grpc.CallOption) (*DeleteAttestedNodeResponse, error)
-	// Sets the set of selectors for a specific node id
-	SetNodeSelectors(ctx context.Context, in *SetNodeSelectorsRequest, opts ...grpc.CallOption) (*SetNodeSelectorsResponse, error)
-	// Gets the set of node selectors for a specific node id
-	GetNodeSelectors(ctx context.Context, in *GetNodeSelectorsRequest, opts ...grpc.CallOption) (*GetNodeSelectorsResponse, error)
-	// Creates a registration entry
-	CreateRegistrationEntry(ctx context.Context, in *CreateRegistrationEntryRequest, opts ...grpc.CallOption) (*CreateRegistrationEntryResponse, error)
-	// Fetches a specific registration entry
-	FetchRegistrationEntry(ctx context.Context, in *FetchRegistrationEntryRequest, opts ...grpc.CallOption) (*FetchRegistrationEntryResponse, error)
-	// Lists registration entries (optionally filtered)
-	ListRegistrationEntries(ctx context.Context, in *ListRegistrationEntriesRequest, opts ...grpc.CallOption) (*ListRegistrationEntriesResponse, error)
-	// Updates a specific registration entry
-	UpdateRegistrationEntry(ctx context.Context, in *UpdateRegistrationEntryRequest, opts ...grpc.CallOption) (*UpdateRegistrationEntryResponse, error)
-	// Deletes a specific registration entry
-	DeleteRegistrationEntry(ctx context.Context, in *DeleteRegistrationEntryRequest, opts ...grpc.CallOption) (*DeleteRegistrationEntryResponse, error)
-	// Prunes all registration entries that expire before the specified timestamp
-	PruneRegistrationEntries(ctx context.Context, in *PruneRegistrationEntriesRequest, opts ...grpc.CallOption) (*PruneRegistrationEntriesResponse, error)
-	// Creates a join token
-	CreateJoinToken(ctx context.Context, in *CreateJoinTokenRequest, opts ...grpc.CallOption) (*CreateJoinTokenResponse, error)
-	// Fetches a specific join token
-	FetchJoinToken(ctx context.Context, in *FetchJoinTokenRequest, opts ...grpc.CallOption) (*FetchJoinTokenResponse, error)
-	// Delete a specific join token
-	DeleteJoinToken(ctx context.Context, in *DeleteJoinTokenRequest, opts ...grpc.CallOption) (*DeleteJoinTokenResponse, error)
-	// Prunes all join tokens that expire before the specified timestamp
-	PruneJoinTokens(ctx context.Context, in *PruneJoinTokensRequest, opts ...grpc.CallOption) (*PruneJoinTokensResponse, error)
-	// Applies the plugin configuration
-	Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error)
-	// Returns the version and related metadata of the installed plugin
-	GetPluginInfo(ctx context.Context, in *plugin.GetPluginInfoRequest, opts ...grpc.CallOption) (*plugin.GetPluginInfoResponse, error)
+type ListRegistrationEntryTombstonesResponse struct {
+	// The current revision of the datastore. Entries and bundles created or
+	// updated after the tombstones were listed have a greater revision.
+	Revision int64 `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	// IDs of the registration entries deleted after the requested revision.
+	// Tombstones are pruned over time, so the list may be incomplete for
+	// old revisions.
+	EntryIds             []string `protobuf:"bytes,2,rep,name=entry_ids,json=entryIds,proto3" json:"entry_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type dataStoreClient struct {
-	cc *grpc.ClientConn
+func (m *ListRegistrationEntryTombstonesResponse) Reset() {
+	*m = ListRegistrationEntryTombstonesResponse{}
+}
+func (m *ListRegistrationEntryTombstonesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListRegistrationEntryTombstonesResponse) ProtoMessage()    {}
+func (*ListRegistrationEntryTombstonesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{57}
 }
 
-func NewDataStoreClient(cc *grpc.ClientConn) DataStoreClient {
-	return &dataStoreClient{cc}
+func (m *ListRegistrationEntryTombstonesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRegistrationEntryTombstonesResponse.Unmarshal(m, b)
+}
+func (m *ListRegistrationEntryTombstonesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRegistrationEntryTombstonesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListRegistrationEntryTombstonesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRegistrationEntryTombstonesResponse.Merge(m, src)
+}
+func (m *ListRegistrationEntryTombstonesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListRegistrationEntryTombstonesResponse.Size(m)
+}
+func (m *ListRegistrationEntryTombstonesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRegistrationEntryTombstonesResponse.DiscardUnknown(m)
 }
 
-func (c *dataStoreClient) CreateBundle(ctx context.Context, in *CreateBundleRequest, opts ...grpc.CallOption) (*CreateBundleResponse, error) {
-	out := new(CreateBundleResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CreateBundle", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ListRegistrationEntryTombstonesResponse proto.InternalMessageInfo
+
+func (m *ListRegistrationEntryTombstonesResponse) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
 	}
-	return out, nil
+	return 0
 }
 
-func (c *dataStoreClient) FetchBundle(ctx context.Context, in *FetchBundleRequest, opts ...grpc.CallOption) (*FetchBundleResponse, error) {
-	out := new(FetchBundleResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/FetchBundle", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ListRegistrationEntryTombstonesResponse) GetEntryIds() []string {
+	if m != nil {
+		return m.EntryIds
 	}
-	return out, nil
+	return nil
 }
 
-func (c *dataStoreClient) ListBundles(ctx context.Context, in *ListBundlesRequest, opts ...grpc.CallOption) (*ListBundlesResponse, error) {
-	out := new(ListBundlesResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ListBundles", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type PruneRegistrationEntryTombstonesRequest struct {
+	// Prune tombstones of entries deleted before this time (seconds since
+	// unix epoch)
+	DeletedBefore        int64    `protobuf:"varint,1,opt,name=deleted_before,json=deletedBefore,proto3" json:"deleted_before,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *dataStoreClient) UpdateBundle(ctx context.Context, in *UpdateBundleRequest, opts ...grpc.CallOption) (*UpdateBundleResponse, error) {
-	out := new(UpdateBundleResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/UpdateBundle", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *PruneRegistrationEntryTombstonesRequest) Reset() {
+	*m = PruneRegistrationEntryTombstonesRequest{}
+}
+func (m *PruneRegistrationEntryTombstonesRequest) String() string { return proto.CompactTextString(m) }
+func (*PruneRegistrationEntryTombstonesRequest) ProtoMessage()    {}
+func (*PruneRegistrationEntryTombstonesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{58}
 }
 
-func (c *dataStoreClient) SetBundle(ctx context.Context, in *SetBundleRequest, opts ...grpc.CallOption) (*SetBundleResponse, error) {
-	out := new(SetBundleResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/SetBundle", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *PruneRegistrationEntryTombstonesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneRegistrationEntryTombstonesRequest.Unmarshal(m, b)
+}
+func (m *PruneRegistrationEntryTombstonesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneRegistrationEntryTombstonesRequest.Marshal(b, m, deterministic)
+}
+func (m *PruneRegistrationEntryTombstonesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneRegistrationEntryTombstonesRequest.Merge(m, src)
+}
+func (m *PruneRegistrationEntryTombstonesRequest) XXX_Size() int {
+	return xxx_messageInfo_PruneRegistrationEntryTombstonesRequest.Size(m)
+}
+func (m *PruneRegistrationEntryTombstonesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneRegistrationEntryTombstonesRequest.DiscardUnknown(m)
 }
 
-func (c *dataStoreClient) AppendBundle(ctx context.Context, in *AppendBundleRequest, opts ...grpc.CallOption) (*AppendBundleResponse, error) {
-	out := new(AppendBundleResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/AppendBundle", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_PruneRegistrationEntryTombstonesRequest proto.InternalMessageInfo
+
+func (m *PruneRegistrationEntryTombstonesRequest) GetDeletedBefore() int64 {
+	if m != nil {
+		return m.DeletedBefore
 	}
-	return out, nil
+	return 0
 }
 
-func (c *dataStoreClient) DeleteBundle(ctx context.Context, in *DeleteBundleRequest, opts ...grpc.CallOption) (*DeleteBundleResponse, error) {
-	out := new(DeleteBundleResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/DeleteBundle", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type PruneRegistrationEntryTombstonesResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *dataStoreClient) PruneBundle(ctx context.Context, in *PruneBundleRequest, opts ...grpc.CallOption) (*PruneBundleResponse, error) {
-	out := new(PruneBundleResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/PruneBundle", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *PruneRegistrationEntryTombstonesResponse) Reset() {
+	*m = PruneRegistrationEntryTombstonesResponse{}
+}
+func (m *PruneRegistrationEntryTombstonesResponse) String() string { return proto.CompactTextString(m) }
+func (*PruneRegistrationEntryTombstonesResponse) ProtoMessage()    {}
+func (*PruneRegistrationEntryTombstonesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{59}
 }
 
-func (c *dataStoreClient) CreateAttestedNode(ctx context.Context, in *CreateAttestedNodeRequest, opts ...grpc.CallOption) (*CreateAttestedNodeResponse, error) {
-	out := new(CreateAttestedNodeResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CreateAttestedNode", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *PruneRegistrationEntryTombstonesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneRegistrationEntryTombstonesResponse.Unmarshal(m, b)
+}
+func (m *PruneRegistrationEntryTombstonesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneRegistrationEntryTombstonesResponse.Marshal(b, m, deterministic)
+}
+func (m *PruneRegistrationEntryTombstonesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneRegistrationEntryTombstonesResponse.Merge(m, src)
+}
+func (m *PruneRegistrationEntryTombstonesResponse) XXX_Size() int {
+	return xxx_messageInfo_PruneRegistrationEntryTombstonesResponse.Size(m)
+}
+func (m *PruneRegistrationEntryTombstonesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneRegistrationEntryTombstonesResponse.DiscardUnknown(m)
 }
 
-func (c *dataStoreClient) FetchAttestedNode(ctx context.Context, in *FetchAttestedNodeRequest, opts ...grpc.CallOption) (*FetchAttestedNodeResponse, error) {
-	out := new(FetchAttestedNodeResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/FetchAttestedNode", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+var xxx_messageInfo_PruneRegistrationEntryTombstonesResponse proto.InternalMessageInfo
+
+type RegistrationEntryEvent struct {
+	// Monotonically increasing event ID
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Type of change made to the registration entry
+	Type RegistrationEntryEvent_Type `protobuf:"varint,2,opt,name=type,proto3,enum=spire.server.datastore.RegistrationEntryEvent_Type" json:"type,omitempty"`
+	// ID of the registration entry that changed
+	EntryId string `protobuf:"bytes,3,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	// When the event was recorded (seconds since unix epoch)
+	CreatedAt            int64    `protobuf:"varint,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *dataStoreClient) ListAttestedNodes(ctx context.Context, in *ListAttestedNodesRequest, opts ...grpc.CallOption) (*ListAttestedNodesResponse, error) {
-	out := new(ListAttestedNodesResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ListAttestedNodes", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *RegistrationEntryEvent) Reset()         { *m = RegistrationEntryEvent{} }
+func (m *RegistrationEntryEvent) String() string { return proto.CompactTextString(m) }
+func (*RegistrationEntryEvent) ProtoMessage()    {}
+func (*RegistrationEntryEvent) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{60}
 }
 
-func (c *dataStoreClient) UpdateAttestedNode(ctx context.Context, in *UpdateAttestedNodeRequest, opts ...grpc.CallOption) (*UpdateAttestedNodeResponse, error) {
-	out := new(UpdateAttestedNodeResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/UpdateAttestedNode", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (m *RegistrationEntryEvent) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RegistrationEntryEvent.Unmarshal(m, b)
+}
+func (m *RegistrationEntryEvent) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RegistrationEntryEvent.Marshal(b, m, deterministic)
+}
+func (m *RegistrationEntryEvent) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RegistrationEntryEvent.Merge(m, src)
+}
+func (m *RegistrationEntryEvent) XXX_Size() int {
+	return xxx_messageInfo_RegistrationEntryEvent.Size(m)
+}
+func (m *RegistrationEntryEvent) XXX_DiscardUnknown() {
+	xxx_messageInfo_RegistrationEntryEvent.DiscardUnknown(m)
 }
 
-func (c *dataStoreClient) DeleteAttestedNode(ctx context.Context, in *DeleteAttestedNodeRequest, opts ...grpc.CallOption) (*DeleteAttestedNodeResponse, error) {
-	out := new(DeleteAttestedNodeResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/DeleteAttestedNode", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_RegistrationEntryEvent proto.InternalMessageInfo
+
+func (m *RegistrationEntryEvent) GetId() int64 {
+	if m != nil {
+		return m.Id
 	}
-	return out, nil
+	return 0
 }
 
-func (c *dataStoreClient) SetNodeSelectors(ctx context.Context, in *SetNodeSelectorsRequest, opts ...grpc.CallOption) (*SetNodeSelectorsResponse, error) {
-	out := new(SetNodeSelectorsResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/SetNodeSelectors", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *RegistrationEntryEvent) GetType() RegistrationEntryEvent_Type {
+	if m != nil {
+		return m.Type
 	}
-	return out, nil
+	return RegistrationEntryEvent_CREATE
 }
 
-func (c *dataStoreClient) GetNodeSelectors(ctx context.Context, in *GetNodeSelectorsRequest, opts ...grpc.CallOption) (*GetNodeSelectorsResponse, error) {
-	out := new(GetNodeSelectorsResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/GetNodeSelectors", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *RegistrationEntryEvent) GetEntryId() string {
+	if m != nil {
+		return m.EntryId
 	}
-	return out, nil
+	return ""
 }
 
-func (c *dataStoreClient) CreateRegistrationEntry(ctx context.Context, in *CreateRegistrationEntryRequest, opts ...grpc.CallOption) (*CreateRegistrationEntryResponse, error) {
-	out := new(CreateRegistrationEntryResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CreateRegistrationEntry", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *RegistrationEntryEvent) GetCreatedAt() int64 {
+	if m != nil {
+		return m.CreatedAt
 	}
-	return out, nil
+	return 0
 }
 
-func (c *dataStoreClient) FetchRegistrationEntry(ctx context.Context, in *FetchRegistrationEntryRequest, opts ...grpc.CallOption) (*FetchRegistrationEntryResponse, error) {
-	out := new(FetchRegistrationEntryResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/FetchRegistrationEntry", in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+type ListRegistrationEntryEventsRequest struct {
+	// Only events with an ID greater than this are listed
+	AfterId int64 `protobuf:"varint,1,opt,name=after_id,json=afterId,proto3" json:"after_id,omitempty"`
+	// Maximum number of events to list. Zero means no limit.
+	Limit                int32    `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (c *dataStoreClient) ListRegistrationEntries(ctx context.Context, in *ListRegistrationEntriesRequest, opts ...grpc.CallOption) (*ListRegistrationEntriesResponse, error) {
-	out := new(ListRegistrationEntriesResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ListRegistrationEntries", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ListRegistrationEntryEventsRequest) Reset()         { *m = ListRegistrationEntryEventsRequest{} }
+func (m *ListRegistrationEntryEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRegistrationEntryEventsRequest) ProtoMessage()    {}
+func (*ListRegistrationEntryEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{61}
+}
+
+func (m *ListRegistrationEntryEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRegistrationEntryEventsRequest.Unmarshal(m, b)
+}
+func (m *ListRegistrationEntryEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRegistrationEntryEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListRegistrationEntryEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRegistrationEntryEventsRequest.Merge(m, src)
+}
+func (m *ListRegistrationEntryEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListRegistrationEntryEventsRequest.Size(m)
+}
+func (m *ListRegistrationEntryEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRegistrationEntryEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRegistrationEntryEventsRequest proto.InternalMessageInfo
+
+func (m *ListRegistrationEntryEventsRequest) GetAfterId() int64 {
+	if m != nil {
+		return m.AfterId
 	}
-	return out, nil
+	return 0
 }
 
-func (c *dataStoreClient) UpdateRegistrationEntry(ctx context.Context, in *UpdateRegistrationEntryRequest, opts ...grpc.CallOption) (*UpdateRegistrationEntryResponse, error) {
-	out := new(UpdateRegistrationEntryResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/UpdateRegistrationEntry", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ListRegistrationEntryEventsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
 	}
-	return out, nil
+	return 0
 }
 
-func (c *dataStoreClient) DeleteRegistrationEntry(ctx context.Context, in *DeleteRegistrationEntryRequest, opts ...grpc.CallOption) (*DeleteRegistrationEntryResponse, error) {
-	out := new(DeleteRegistrationEntryResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/DeleteRegistrationEntry", in, out, opts...)
-	if err != nil {
-		return nil, err
+type ListRegistrationEntryEventsResponse struct {
+	// Events in ID order
+	Events []*RegistrationEntryEvent `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+	// ID of the oldest event still retained, or zero if there are none
+	OldestId int64 `protobuf:"varint,2,opt,name=oldest_id,json=oldestId,proto3" json:"oldest_id,omitempty"`
+	// ID of the latest event recorded, or zero if there are none
+	LatestId int64 `protobuf:"varint,3,opt,name=latest_id,json=latestId,proto3" json:"latest_id,omitempty"`
+	// ID of the latest event pruned, or zero if none have been. Events may
+	// be missing up to this ID; IDs missing after it were never recorded.
+	PrunedId             int64    `protobuf:"varint,4,opt,name=pruned_id,json=prunedId,proto3" json:"pruned_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListRegistrationEntryEventsResponse) Reset()         { *m = ListRegistrationEntryEventsResponse{} }
+func (m *ListRegistrationEntryEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListRegistrationEntryEventsResponse) ProtoMessage()    {}
+func (*ListRegistrationEntryEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{62}
+}
+
+func (m *ListRegistrationEntryEventsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRegistrationEntryEventsResponse.Unmarshal(m, b)
+}
+func (m *ListRegistrationEntryEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRegistrationEntryEventsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListRegistrationEntryEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRegistrationEntryEventsResponse.Merge(m, src)
+}
+func (m *ListRegistrationEntryEventsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListRegistrationEntryEventsResponse.Size(m)
+}
+func (m *ListRegistrationEntryEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRegistrationEntryEventsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRegistrationEntryEventsResponse proto.InternalMessageInfo
+
+func (m *ListRegistrationEntryEventsResponse) GetEvents() []*RegistrationEntryEvent {
+	if m != nil {
+		return m.Events
 	}
-	return out, nil
+	return nil
 }
 
-func (c *dataStoreClient) PruneRegistrationEntries(ctx context.Context, in *PruneRegistrationEntriesRequest, opts ...grpc.CallOption) (*PruneRegistrationEntriesResponse, error) {
-	out := new(PruneRegistrationEntriesResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/PruneRegistrationEntries", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ListRegistrationEntryEventsResponse) GetOldestId() int64 {
+	if m != nil {
+		return m.OldestId
 	}
-	return out, nil
+	return 0
 }
 
-func (c *dataStoreClient) CreateJoinToken(ctx context.Context, in *CreateJoinTokenRequest, opts ...grpc.CallOption) (*CreateJoinTokenResponse, error) {
-	out := new(CreateJoinTokenResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CreateJoinToken", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ListRegistrationEntryEventsResponse) GetLatestId() int64 {
+	if m != nil {
+		return m.LatestId
 	}
-	return out, nil
+	return 0
 }
 
-func (c *dataStoreClient) FetchJoinToken(ctx context.Context, in *FetchJoinTokenRequest, opts ...grpc.CallOption) (*FetchJoinTokenResponse, error) {
-	out := new(FetchJoinTokenResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/FetchJoinToken", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *ListRegistrationEntryEventsResponse) GetPrunedId() int64 {
+	if m != nil {
+		return m.PrunedId
 	}
-	return out, nil
+	return 0
 }
 
-func (c *dataStoreClient) DeleteJoinToken(ctx context.Context, in *DeleteJoinTokenRequest, opts ...grpc.CallOption) (*DeleteJoinTokenResponse, error) {
-	out := new(DeleteJoinTokenResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/DeleteJoinToken", in, out, opts...)
-	if err != nil {
-		return nil, err
+type PruneRegistrationEntryEventsRequest struct {
+	// Prune events recorded before this time (seconds since unix epoch). The
+	// latest event is always retained.
+	CreatedBefore        int64    `protobuf:"varint,1,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PruneRegistrationEntryEventsRequest) Reset()         { *m = PruneRegistrationEntryEventsRequest{} }
+func (m *PruneRegistrationEntryEventsRequest) String() string { return proto.CompactTextString(m) }
+func (*PruneRegistrationEntryEventsRequest) ProtoMessage()    {}
+func (*PruneRegistrationEntryEventsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{63}
+}
+
+func (m *PruneRegistrationEntryEventsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneRegistrationEntryEventsRequest.Unmarshal(m, b)
+}
+func (m *PruneRegistrationEntryEventsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneRegistrationEntryEventsRequest.Marshal(b, m, deterministic)
+}
+func (m *PruneRegistrationEntryEventsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneRegistrationEntryEventsRequest.Merge(m, src)
+}
+func (m *PruneRegistrationEntryEventsRequest) XXX_Size() int {
+	return xxx_messageInfo_PruneRegistrationEntryEventsRequest.Size(m)
+}
+func (m *PruneRegistrationEntryEventsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneRegistrationEntryEventsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneRegistrationEntryEventsRequest proto.InternalMessageInfo
+
+func (m *PruneRegistrationEntryEventsRequest) GetCreatedBefore() int64 {
+	if m != nil {
+		return m.CreatedBefore
 	}
-	return out, nil
+	return 0
 }
 
-func (c *dataStoreClient) PruneJoinTokens(ctx context.Context, in *PruneJoinTokensRequest, opts ...grpc.CallOption) (*PruneJoinTokensResponse, error) {
-	out := new(PruneJoinTokensResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/PruneJoinTokens", in, out, opts...)
-	if err != nil {
-		return nil, err
+type PruneRegistrationEntryEventsResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PruneRegistrationEntryEventsResponse) Reset()         { *m = PruneRegistrationEntryEventsResponse{} }
+func (m *PruneRegistrationEntryEventsResponse) String() string { return proto.CompactTextString(m) }
+func (*PruneRegistrationEntryEventsResponse) ProtoMessage()    {}
+func (*PruneRegistrationEntryEventsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{64}
+}
+
+func (m *PruneRegistrationEntryEventsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneRegistrationEntryEventsResponse.Unmarshal(m, b)
+}
+func (m *PruneRegistrationEntryEventsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneRegistrationEntryEventsResponse.Marshal(b, m, deterministic)
+}
+func (m *PruneRegistrationEntryEventsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneRegistrationEntryEventsResponse.Merge(m, src)
+}
+func (m *PruneRegistrationEntryEventsResponse) XXX_Size() int {
+	return xxx_messageInfo_PruneRegistrationEntryEventsResponse.Size(m)
+}
+func (m *PruneRegistrationEntryEventsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneRegistrationEntryEventsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneRegistrationEntryEventsResponse proto.InternalMessageInfo
+
+type JoinToken struct {
+	// Token value
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// Expiration in seconds since unix epoch
+	Expiry int64 `protobuf:"varint,2,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	// Number of times the token can be used. Zero means a single use.
+	MaxUses int32 `protobuf:"varint,3,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`
+	// Number of times the token has been used
+	Uses int32 `protobuf:"varint,4,opt,name=uses,proto3" json:"uses,omitempty"`
+	// Selectors given to the nodes attesting with the token
+	NodeSelectors []*common.Selector `protobuf:"bytes,5,rep,name=node_selectors,json=nodeSelectors,proto3" json:"node_selectors,omitempty"`
+	// Registration entries created when a node attests with the token. The
+	// parent ID of each entry is set to the agent ID of the node.
+	Entries              []*common.RegistrationEntry `protobuf:"bytes,6,rep,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *JoinToken) Reset()         { *m = JoinToken{} }
+func (m *JoinToken) String() string { return proto.CompactTextString(m) }
+func (*JoinToken) ProtoMessage()    {}
+func (*JoinToken) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{65}
+}
+
+func (m *JoinToken) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JoinToken.Unmarshal(m, b)
+}
+func (m *JoinToken) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JoinToken.Marshal(b, m, deterministic)
+}
+func (m *JoinToken) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JoinToken.Merge(m, src)
+}
+func (m *JoinToken) XXX_Size() int {
+	return xxx_messageInfo_JoinToken.Size(m)
+}
+func (m *JoinToken) XXX_DiscardUnknown() {
+	xxx_messageInfo_JoinToken.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JoinToken proto.InternalMessageInfo
+
+func (m *JoinToken) GetToken() string {
+	if m != nil {
+		return m.Token
 	}
-	return out, nil
+	return ""
 }
 
-func (c *dataStoreClient) Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error) {
-	out := new(plugin.ConfigureResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/Configure", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *JoinToken) GetExpiry() int64 {
+	if m != nil {
+		return m.Expiry
 	}
-	return out, nil
+	return 0
 }
 
-func (c *dataStoreClient) GetPluginInfo(ctx context.Context, in *plugin.GetPluginInfoRequest, opts ...grpc.CallOption) (*plugin.GetPluginInfoResponse, error) {
-	out := new(plugin.GetPluginInfoResponse)
-	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/GetPluginInfo", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *JoinToken) GetMaxUses() int32 {
+	if m != nil {
+		return m.MaxUses
 	}
-	return out, nil
+	return 0
+}
+
+func (m *JoinToken) GetUses() int32 {
+	if m != nil {
+		return m.Uses
+	}
+	return 0
+}
+
+func (m *JoinToken) GetNodeSelectors() []*common.Selector {
+	if m != nil {
+		return m.NodeSelectors
+	}
+	return nil
+}
+
+func (m *JoinToken) GetEntries() []*common.RegistrationEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+type CreateJoinTokenRequest struct {
+	JoinToken            *JoinToken `protobuf:"bytes,1,opt,name=join_token,json=joinToken,proto3" json:"join_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *CreateJoinTokenRequest) Reset()         { *m = CreateJoinTokenRequest{} }
+func (m *CreateJoinTokenRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateJoinTokenRequest) ProtoMessage()    {}
+func (*CreateJoinTokenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{66}
+}
+
+func (m *CreateJoinTokenRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateJoinTokenRequest.Unmarshal(m, b)
+}
+func (m *CreateJoinTokenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateJoinTokenRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateJoinTokenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateJoinTokenRequest.Merge(m, src)
+}
+func (m *CreateJoinTokenRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateJoinTokenRequest.Size(m)
+}
+func (m *CreateJoinTokenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateJoinTokenRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateJoinTokenRequest proto.InternalMessageInfo
+
+func (m *CreateJoinTokenRequest) GetJoinToken() *JoinToken {
+	if m != nil {
+		return m.JoinToken
+	}
+	return nil
+}
+
+type CreateJoinTokenResponse struct {
+	JoinToken            *JoinToken `protobuf:"bytes,1,opt,name=join_token,json=joinToken,proto3" json:"join_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *CreateJoinTokenResponse) Reset()         { *m = CreateJoinTokenResponse{} }
+func (m *CreateJoinTokenResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateJoinTokenResponse) ProtoMessage()    {}
+func (*CreateJoinTokenResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{67}
+}
+
+func (m *CreateJoinTokenResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateJoinTokenResponse.Unmarshal(m, b)
+}
+func (m *CreateJoinTokenResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateJoinTokenResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateJoinTokenResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateJoinTokenResponse.Merge(m, src)
+}
+func (m *CreateJoinTokenResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateJoinTokenResponse.Size(m)
+}
+func (m *CreateJoinTokenResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateJoinTokenResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateJoinTokenResponse proto.InternalMessageInfo
+
+func (m *CreateJoinTokenResponse) GetJoinToken() *JoinToken {
+	if m != nil {
+		return m.JoinToken
+	}
+	return nil
+}
+
+type FetchJoinTokenRequest struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FetchJoinTokenRequest) Reset()         { *m = FetchJoinTokenRequest{} }
+func (m *FetchJoinTokenRequest) String() string { return proto.CompactTextString(m) }
+func (*FetchJoinTokenRequest) ProtoMessage()    {}
+func (*FetchJoinTokenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{68}
+}
+
+func (m *FetchJoinTokenRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchJoinTokenRequest.Unmarshal(m, b)
+}
+func (m *FetchJoinTokenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchJoinTokenRequest.Marshal(b, m, deterministic)
+}
+func (m *FetchJoinTokenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchJoinTokenRequest.Merge(m, src)
+}
+func (m *FetchJoinTokenRequest) XXX_Size() int {
+	return xxx_messageInfo_FetchJoinTokenRequest.Size(m)
+}
+func (m *FetchJoinTokenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchJoinTokenRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchJoinTokenRequest proto.InternalMessageInfo
+
+func (m *FetchJoinTokenRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+type FetchJoinTokenResponse struct {
+	JoinToken            *JoinToken `protobuf:"bytes,1,opt,name=join_token,json=joinToken,proto3" json:"join_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *FetchJoinTokenResponse) Reset()         { *m = FetchJoinTokenResponse{} }
+func (m *FetchJoinTokenResponse) String() string { return proto.CompactTextString(m) }
+func (*FetchJoinTokenResponse) ProtoMessage()    {}
+func (*FetchJoinTokenResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{69}
+}
+
+func (m *FetchJoinTokenResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchJoinTokenResponse.Unmarshal(m, b)
+}
+func (m *FetchJoinTokenResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchJoinTokenResponse.Marshal(b, m, deterministic)
+}
+func (m *FetchJoinTokenResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchJoinTokenResponse.Merge(m, src)
+}
+func (m *FetchJoinTokenResponse) XXX_Size() int {
+	return xxx_messageInfo_FetchJoinTokenResponse.Size(m)
+}
+func (m *FetchJoinTokenResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchJoinTokenResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchJoinTokenResponse proto.InternalMessageInfo
+
+func (m *FetchJoinTokenResponse) GetJoinToken() *JoinToken {
+	if m != nil {
+		return m.JoinToken
+	}
+	return nil
+}
+
+type ListJoinTokensRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListJoinTokensRequest) Reset()         { *m = ListJoinTokensRequest{} }
+func (m *ListJoinTokensRequest) String() string { return proto.CompactTextString(m) }
+func (*ListJoinTokensRequest) ProtoMessage()    {}
+func (*ListJoinTokensRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{70}
+}
+
+func (m *ListJoinTokensRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJoinTokensRequest.Unmarshal(m, b)
+}
+func (m *ListJoinTokensRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJoinTokensRequest.Marshal(b, m, deterministic)
+}
+func (m *ListJoinTokensRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJoinTokensRequest.Merge(m, src)
+}
+func (m *ListJoinTokensRequest) XXX_Size() int {
+	return xxx_messageInfo_ListJoinTokensRequest.Size(m)
+}
+func (m *ListJoinTokensRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJoinTokensRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListJoinTokensRequest proto.InternalMessageInfo
+
+type ListJoinTokensResponse struct {
+	JoinTokens           []*JoinToken `protobuf:"bytes,1,rep,name=join_tokens,json=joinTokens,proto3" json:"join_tokens,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ListJoinTokensResponse) Reset()         { *m = ListJoinTokensResponse{} }
+func (m *ListJoinTokensResponse) String() string { return proto.CompactTextString(m) }
+func (*ListJoinTokensResponse) ProtoMessage()    {}
+func (*ListJoinTokensResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{71}
+}
+
+func (m *ListJoinTokensResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJoinTokensResponse.Unmarshal(m, b)
+}
+func (m *ListJoinTokensResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJoinTokensResponse.Marshal(b, m, deterministic)
+}
+func (m *ListJoinTokensResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJoinTokensResponse.Merge(m, src)
+}
+func (m *ListJoinTokensResponse) XXX_Size() int {
+	return xxx_messageInfo_ListJoinTokensResponse.Size(m)
+}
+func (m *ListJoinTokensResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJoinTokensResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListJoinTokensResponse proto.InternalMessageInfo
+
+func (m *ListJoinTokensResponse) GetJoinTokens() []*JoinToken {
+	if m != nil {
+		return m.JoinTokens
+	}
+	return nil
+}
+
+type ConsumeJoinTokenRequest struct {
+	Token string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	// Agent ID of the node consuming the token, used as the parent ID of the
+	// token entries
+	AgentId              string   `protobuf:"bytes,2,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ConsumeJoinTokenRequest) Reset()         { *m = ConsumeJoinTokenRequest{} }
+func (m *ConsumeJoinTokenRequest) String() string { return proto.CompactTextString(m) }
+func (*ConsumeJoinTokenRequest) ProtoMessage()    {}
+func (*ConsumeJoinTokenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{72}
+}
+
+func (m *ConsumeJoinTokenRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConsumeJoinTokenRequest.Unmarshal(m, b)
+}
+func (m *ConsumeJoinTokenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConsumeJoinTokenRequest.Marshal(b, m, deterministic)
+}
+func (m *ConsumeJoinTokenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConsumeJoinTokenRequest.Merge(m, src)
+}
+func (m *ConsumeJoinTokenRequest) XXX_Size() int {
+	return xxx_messageInfo_ConsumeJoinTokenRequest.Size(m)
+}
+func (m *ConsumeJoinTokenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConsumeJoinTokenRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConsumeJoinTokenRequest proto.InternalMessageInfo
+
+func (m *ConsumeJoinTokenRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+func (m *ConsumeJoinTokenRequest) GetAgentId() string {
+	if m != nil {
+		return m.AgentId
+	}
+	return ""
+}
+
+type ConsumeJoinTokenResponse struct {
+	// The token as it was when consumed, with the use counted. Nil if there
+	// is no such token.
+	JoinToken *JoinToken `protobuf:"bytes,1,opt,name=join_token,json=joinToken,proto3" json:"join_token,omitempty"`
+	// The registration entries created for the node
+	Entries              []*common.RegistrationEntry `protobuf:"bytes,2,rep,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *ConsumeJoinTokenResponse) Reset()         { *m = ConsumeJoinTokenResponse{} }
+func (m *ConsumeJoinTokenResponse) String() string { return proto.CompactTextString(m) }
+func (*ConsumeJoinTokenResponse) ProtoMessage()    {}
+func (*ConsumeJoinTokenResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{73}
+}
+
+func (m *ConsumeJoinTokenResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConsumeJoinTokenResponse.Unmarshal(m, b)
+}
+func (m *ConsumeJoinTokenResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConsumeJoinTokenResponse.Marshal(b, m, deterministic)
+}
+func (m *ConsumeJoinTokenResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConsumeJoinTokenResponse.Merge(m, src)
+}
+func (m *ConsumeJoinTokenResponse) XXX_Size() int {
+	return xxx_messageInfo_ConsumeJoinTokenResponse.Size(m)
+}
+func (m *ConsumeJoinTokenResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConsumeJoinTokenResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConsumeJoinTokenResponse proto.InternalMessageInfo
+
+func (m *ConsumeJoinTokenResponse) GetJoinToken() *JoinToken {
+	if m != nil {
+		return m.JoinToken
+	}
+	return nil
+}
+
+func (m *ConsumeJoinTokenResponse) GetEntries() []*common.RegistrationEntry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+type DeleteJoinTokenRequest struct {
+	Token                string   `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeleteJoinTokenRequest) Reset()         { *m = DeleteJoinTokenRequest{} }
+func (m *DeleteJoinTokenRequest) String() string { return proto.CompactTextString(m) }
+func (*DeleteJoinTokenRequest) ProtoMessage()    {}
+func (*DeleteJoinTokenRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{74}
+}
+
+func (m *DeleteJoinTokenRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteJoinTokenRequest.Unmarshal(m, b)
+}
+func (m *DeleteJoinTokenRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteJoinTokenRequest.Marshal(b, m, deterministic)
+}
+func (m *DeleteJoinTokenRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteJoinTokenRequest.Merge(m, src)
+}
+func (m *DeleteJoinTokenRequest) XXX_Size() int {
+	return xxx_messageInfo_DeleteJoinTokenRequest.Size(m)
+}
+func (m *DeleteJoinTokenRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteJoinTokenRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteJoinTokenRequest proto.InternalMessageInfo
+
+func (m *DeleteJoinTokenRequest) GetToken() string {
+	if m != nil {
+		return m.Token
+	}
+	return ""
+}
+
+type DeleteJoinTokenResponse struct {
+	JoinToken            *JoinToken `protobuf:"bytes,1,opt,name=join_token,json=joinToken,proto3" json:"join_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *DeleteJoinTokenResponse) Reset()         { *m = DeleteJoinTokenResponse{} }
+func (m *DeleteJoinTokenResponse) String() string { return proto.CompactTextString(m) }
+func (*DeleteJoinTokenResponse) ProtoMessage()    {}
+func (*DeleteJoinTokenResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{75}
+}
+
+func (m *DeleteJoinTokenResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeleteJoinTokenResponse.Unmarshal(m, b)
+}
+func (m *DeleteJoinTokenResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeleteJoinTokenResponse.Marshal(b, m, deterministic)
+}
+func (m *DeleteJoinTokenResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeleteJoinTokenResponse.Merge(m, src)
+}
+func (m *DeleteJoinTokenResponse) XXX_Size() int {
+	return xxx_messageInfo_DeleteJoinTokenResponse.Size(m)
+}
+func (m *DeleteJoinTokenResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeleteJoinTokenResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeleteJoinTokenResponse proto.InternalMessageInfo
+
+func (m *DeleteJoinTokenResponse) GetJoinToken() *JoinToken {
+	if m != nil {
+		return m.JoinToken
+	}
+	return nil
+}
+
+type PruneJoinTokensRequest struct {
+	ExpiresBefore        int64    `protobuf:"varint,1,opt,name=expires_before,json=expiresBefore,proto3" json:"expires_before,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PruneJoinTokensRequest) Reset()         { *m = PruneJoinTokensRequest{} }
+func (m *PruneJoinTokensRequest) String() string { return proto.CompactTextString(m) }
+func (*PruneJoinTokensRequest) ProtoMessage()    {}
+func (*PruneJoinTokensRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{76}
+}
+
+func (m *PruneJoinTokensRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneJoinTokensRequest.Unmarshal(m, b)
+}
+func (m *PruneJoinTokensRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneJoinTokensRequest.Marshal(b, m, deterministic)
+}
+func (m *PruneJoinTokensRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneJoinTokensRequest.Merge(m, src)
+}
+func (m *PruneJoinTokensRequest) XXX_Size() int {
+	return xxx_messageInfo_PruneJoinTokensRequest.Size(m)
+}
+func (m *PruneJoinTokensRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneJoinTokensRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneJoinTokensRequest proto.InternalMessageInfo
+
+func (m *PruneJoinTokensRequest) GetExpiresBefore() int64 {
+	if m != nil {
+		return m.ExpiresBefore
+	}
+	return 0
+}
+
+type PruneJoinTokensResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PruneJoinTokensResponse) Reset()         { *m = PruneJoinTokensResponse{} }
+func (m *PruneJoinTokensResponse) String() string { return proto.CompactTextString(m) }
+func (*PruneJoinTokensResponse) ProtoMessage()    {}
+func (*PruneJoinTokensResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{77}
+}
+
+func (m *PruneJoinTokensResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneJoinTokensResponse.Unmarshal(m, b)
+}
+func (m *PruneJoinTokensResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneJoinTokensResponse.Marshal(b, m, deterministic)
+}
+func (m *PruneJoinTokensResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneJoinTokensResponse.Merge(m, src)
+}
+func (m *PruneJoinTokensResponse) XXX_Size() int {
+	return xxx_messageInfo_PruneJoinTokensResponse.Size(m)
+}
+func (m *PruneJoinTokensResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneJoinTokensResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneJoinTokensResponse proto.InternalMessageInfo
+
+type CAJournal struct {
+	// ID of the journal. Servers sharing a journal use the same ID (i.e.
+	// the trust domain ID).
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Serialized journal entries. The contents are opaque to the datastore.
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	// Revision of the journal. The datastore increments the revision every
+	// time the journal is set.
+	Revision             int64    `protobuf:"varint,3,opt,name=revision,proto3" json:"revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CAJournal) Reset()         { *m = CAJournal{} }
+func (m *CAJournal) String() string { return proto.CompactTextString(m) }
+func (*CAJournal) ProtoMessage()    {}
+func (*CAJournal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{78}
+}
+
+func (m *CAJournal) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CAJournal.Unmarshal(m, b)
+}
+func (m *CAJournal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CAJournal.Marshal(b, m, deterministic)
+}
+func (m *CAJournal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CAJournal.Merge(m, src)
+}
+func (m *CAJournal) XXX_Size() int {
+	return xxx_messageInfo_CAJournal.Size(m)
+}
+func (m *CAJournal) XXX_DiscardUnknown() {
+	xxx_messageInfo_CAJournal.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CAJournal proto.InternalMessageInfo
+
+func (m *CAJournal) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *CAJournal) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *CAJournal) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+type FetchCAJournalRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FetchCAJournalRequest) Reset()         { *m = FetchCAJournalRequest{} }
+func (m *FetchCAJournalRequest) String() string { return proto.CompactTextString(m) }
+func (*FetchCAJournalRequest) ProtoMessage()    {}
+func (*FetchCAJournalRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{79}
+}
+
+func (m *FetchCAJournalRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchCAJournalRequest.Unmarshal(m, b)
+}
+func (m *FetchCAJournalRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchCAJournalRequest.Marshal(b, m, deterministic)
+}
+func (m *FetchCAJournalRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchCAJournalRequest.Merge(m, src)
+}
+func (m *FetchCAJournalRequest) XXX_Size() int {
+	return xxx_messageInfo_FetchCAJournalRequest.Size(m)
+}
+func (m *FetchCAJournalRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchCAJournalRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchCAJournalRequest proto.InternalMessageInfo
+
+func (m *FetchCAJournalRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type FetchCAJournalResponse struct {
+	// The journal, or unset if there is no journal with the requested ID.
+	Journal              *CAJournal `protobuf:"bytes,1,opt,name=journal,proto3" json:"journal,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *FetchCAJournalResponse) Reset()         { *m = FetchCAJournalResponse{} }
+func (m *FetchCAJournalResponse) String() string { return proto.CompactTextString(m) }
+func (*FetchCAJournalResponse) ProtoMessage()    {}
+func (*FetchCAJournalResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{80}
+}
+
+func (m *FetchCAJournalResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchCAJournalResponse.Unmarshal(m, b)
+}
+func (m *FetchCAJournalResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchCAJournalResponse.Marshal(b, m, deterministic)
+}
+func (m *FetchCAJournalResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchCAJournalResponse.Merge(m, src)
+}
+func (m *FetchCAJournalResponse) XXX_Size() int {
+	return xxx_messageInfo_FetchCAJournalResponse.Size(m)
+}
+func (m *FetchCAJournalResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchCAJournalResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchCAJournalResponse proto.InternalMessageInfo
+
+func (m *FetchCAJournalResponse) GetJournal() *CAJournal {
+	if m != nil {
+		return m.Journal
+	}
+	return nil
+}
+
+type SetCAJournalRequest struct {
+	// The journal to set. If the revision is zero, a journal with the same
+	// ID must not already exist. Otherwise, the revision must match the
+	// stored revision. The request fails with ABORTED if either condition
+	// does not hold.
+	Journal              *CAJournal `protobuf:"bytes,1,opt,name=journal,proto3" json:"journal,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *SetCAJournalRequest) Reset()         { *m = SetCAJournalRequest{} }
+func (m *SetCAJournalRequest) String() string { return proto.CompactTextString(m) }
+func (*SetCAJournalRequest) ProtoMessage()    {}
+func (*SetCAJournalRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{81}
+}
+
+func (m *SetCAJournalRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetCAJournalRequest.Unmarshal(m, b)
+}
+func (m *SetCAJournalRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetCAJournalRequest.Marshal(b, m, deterministic)
+}
+func (m *SetCAJournalRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetCAJournalRequest.Merge(m, src)
+}
+func (m *SetCAJournalRequest) XXX_Size() int {
+	return xxx_messageInfo_SetCAJournalRequest.Size(m)
+}
+func (m *SetCAJournalRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetCAJournalRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetCAJournalRequest proto.InternalMessageInfo
+
+func (m *SetCAJournalRequest) GetJournal() *CAJournal {
+	if m != nil {
+		return m.Journal
+	}
+	return nil
+}
+
+type SetCAJournalResponse struct {
+	// The stored journal, including the new revision.
+	Journal              *CAJournal `protobuf:"bytes,1,opt,name=journal,proto3" json:"journal,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *SetCAJournalResponse) Reset()         { *m = SetCAJournalResponse{} }
+func (m *SetCAJournalResponse) String() string { return proto.CompactTextString(m) }
+func (*SetCAJournalResponse) ProtoMessage()    {}
+func (*SetCAJournalResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{82}
+}
+
+func (m *SetCAJournalResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetCAJournalResponse.Unmarshal(m, b)
+}
+func (m *SetCAJournalResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetCAJournalResponse.Marshal(b, m, deterministic)
+}
+func (m *SetCAJournalResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetCAJournalResponse.Merge(m, src)
+}
+func (m *SetCAJournalResponse) XXX_Size() int {
+	return xxx_messageInfo_SetCAJournalResponse.Size(m)
+}
+func (m *SetCAJournalResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetCAJournalResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetCAJournalResponse proto.InternalMessageInfo
+
+func (m *SetCAJournalResponse) GetJournal() *CAJournal {
+	if m != nil {
+		return m.Journal
+	}
+	return nil
+}
+
+type Lease struct {
+	// Name of the lease. Servers contending for the same role use the same
+	// name.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// ID of the current holder of the lease
+	HolderId string `protobuf:"bytes,2,opt,name=holder_id,json=holderId,proto3" json:"holder_id,omitempty"`
+	// Time the lease expires (seconds since unix epoch)
+	ExpiresAt            int64    `protobuf:"varint,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Lease) Reset()         { *m = Lease{} }
+func (m *Lease) String() string { return proto.CompactTextString(m) }
+func (*Lease) ProtoMessage()    {}
+func (*Lease) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{83}
+}
+
+func (m *Lease) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Lease.Unmarshal(m, b)
+}
+func (m *Lease) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Lease.Marshal(b, m, deterministic)
+}
+func (m *Lease) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Lease.Merge(m, src)
+}
+func (m *Lease) XXX_Size() int {
+	return xxx_messageInfo_Lease.Size(m)
+}
+func (m *Lease) XXX_DiscardUnknown() {
+	xxx_messageInfo_Lease.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Lease proto.InternalMessageInfo
+
+func (m *Lease) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Lease) GetHolderId() string {
+	if m != nil {
+		return m.HolderId
+	}
+	return ""
+}
+
+func (m *Lease) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+type AcquireLeaseRequest struct {
+	// Name of the lease to acquire
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// ID of the holder acquiring the lease
+	HolderId string `protobuf:"bytes,2,opt,name=holder_id,json=holderId,proto3" json:"holder_id,omitempty"`
+	// Current time (seconds since unix epoch). A lease held by another
+	// holder can only be acquired if it expires at or before this time.
+	Now int64 `protobuf:"varint,3,opt,name=now,proto3" json:"now,omitempty"`
+	// Time the acquired lease expires (seconds since unix epoch)
+	ExpiresAt            int64    `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AcquireLeaseRequest) Reset()         { *m = AcquireLeaseRequest{} }
+func (m *AcquireLeaseRequest) String() string { return proto.CompactTextString(m) }
+func (*AcquireLeaseRequest) ProtoMessage()    {}
+func (*AcquireLeaseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{84}
+}
+
+func (m *AcquireLeaseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AcquireLeaseRequest.Unmarshal(m, b)
+}
+func (m *AcquireLeaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AcquireLeaseRequest.Marshal(b, m, deterministic)
+}
+func (m *AcquireLeaseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AcquireLeaseRequest.Merge(m, src)
+}
+func (m *AcquireLeaseRequest) XXX_Size() int {
+	return xxx_messageInfo_AcquireLeaseRequest.Size(m)
+}
+func (m *AcquireLeaseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AcquireLeaseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AcquireLeaseRequest proto.InternalMessageInfo
+
+func (m *AcquireLeaseRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *AcquireLeaseRequest) GetHolderId() string {
+	if m != nil {
+		return m.HolderId
+	}
+	return ""
+}
+
+func (m *AcquireLeaseRequest) GetNow() int64 {
+	if m != nil {
+		return m.Now
+	}
+	return 0
+}
+
+func (m *AcquireLeaseRequest) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+type AcquireLeaseResponse struct {
+	// The lease after the request. The lease was acquired (or renewed) if
+	// the holder ID matches the requested holder ID. Otherwise, the lease
+	// is held by someone else.
+	Lease                *Lease   `protobuf:"bytes,1,opt,name=lease,proto3" json:"lease,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AcquireLeaseResponse) Reset()         { *m = AcquireLeaseResponse{} }
+func (m *AcquireLeaseResponse) String() string { return proto.CompactTextString(m) }
+func (*AcquireLeaseResponse) ProtoMessage()    {}
+func (*AcquireLeaseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{85}
+}
+
+func (m *AcquireLeaseResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AcquireLeaseResponse.Unmarshal(m, b)
+}
+func (m *AcquireLeaseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AcquireLeaseResponse.Marshal(b, m, deterministic)
+}
+func (m *AcquireLeaseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AcquireLeaseResponse.Merge(m, src)
+}
+func (m *AcquireLeaseResponse) XXX_Size() int {
+	return xxx_messageInfo_AcquireLeaseResponse.Size(m)
+}
+func (m *AcquireLeaseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_AcquireLeaseResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AcquireLeaseResponse proto.InternalMessageInfo
+
+func (m *AcquireLeaseResponse) GetLease() *Lease {
+	if m != nil {
+		return m.Lease
+	}
+	return nil
+}
+
+type ReleaseLeaseRequest struct {
+	// Name of the lease to release
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// ID of the holder releasing the lease. The lease is only released if
+	// it is held by this holder.
+	HolderId             string   `protobuf:"bytes,2,opt,name=holder_id,json=holderId,proto3" json:"holder_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReleaseLeaseRequest) Reset()         { *m = ReleaseLeaseRequest{} }
+func (m *ReleaseLeaseRequest) String() string { return proto.CompactTextString(m) }
+func (*ReleaseLeaseRequest) ProtoMessage()    {}
+func (*ReleaseLeaseRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{86}
+}
+
+func (m *ReleaseLeaseRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReleaseLeaseRequest.Unmarshal(m, b)
+}
+func (m *ReleaseLeaseRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReleaseLeaseRequest.Marshal(b, m, deterministic)
+}
+func (m *ReleaseLeaseRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReleaseLeaseRequest.Merge(m, src)
+}
+func (m *ReleaseLeaseRequest) XXX_Size() int {
+	return xxx_messageInfo_ReleaseLeaseRequest.Size(m)
+}
+func (m *ReleaseLeaseRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReleaseLeaseRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReleaseLeaseRequest proto.InternalMessageInfo
+
+func (m *ReleaseLeaseRequest) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ReleaseLeaseRequest) GetHolderId() string {
+	if m != nil {
+		return m.HolderId
+	}
+	return ""
+}
+
+type ReleaseLeaseResponse struct {
+	// The released lease, or unset if the lease was not held by the
+	// requested holder.
+	Lease                *Lease   `protobuf:"bytes,1,opt,name=lease,proto3" json:"lease,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReleaseLeaseResponse) Reset()         { *m = ReleaseLeaseResponse{} }
+func (m *ReleaseLeaseResponse) String() string { return proto.CompactTextString(m) }
+func (*ReleaseLeaseResponse) ProtoMessage()    {}
+func (*ReleaseLeaseResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{87}
+}
+
+func (m *ReleaseLeaseResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReleaseLeaseResponse.Unmarshal(m, b)
+}
+func (m *ReleaseLeaseResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReleaseLeaseResponse.Marshal(b, m, deterministic)
+}
+func (m *ReleaseLeaseResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReleaseLeaseResponse.Merge(m, src)
+}
+func (m *ReleaseLeaseResponse) XXX_Size() int {
+	return xxx_messageInfo_ReleaseLeaseResponse.Size(m)
+}
+func (m *ReleaseLeaseResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReleaseLeaseResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReleaseLeaseResponse proto.InternalMessageInfo
+
+func (m *ReleaseLeaseResponse) GetLease() *Lease {
+	if m != nil {
+		return m.Lease
+	}
+	return nil
+}
+
+type RevokedCertificate struct {
+	// Serial number of the revoked certificate (base 10 string)
+	SerialNumber string `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	// SPIFFE ID of the revoked certificate
+	SpiffeId string `protobuf:"bytes,2,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	// Time the revoked certificate expires (seconds since unix epoch). The
+	// revocation can be pruned once the certificate expires.
+	ExpiresAt int64 `protobuf:"varint,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	// Time the certificate was revoked (seconds since unix epoch)
+	RevokedAt            int64    `protobuf:"varint,4,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevokedCertificate) Reset()         { *m = RevokedCertificate{} }
+func (m *RevokedCertificate) String() string { return proto.CompactTextString(m) }
+func (*RevokedCertificate) ProtoMessage()    {}
+func (*RevokedCertificate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{88}
+}
+
+func (m *RevokedCertificate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokedCertificate.Unmarshal(m, b)
+}
+func (m *RevokedCertificate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokedCertificate.Marshal(b, m, deterministic)
+}
+func (m *RevokedCertificate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokedCertificate.Merge(m, src)
+}
+func (m *RevokedCertificate) XXX_Size() int {
+	return xxx_messageInfo_RevokedCertificate.Size(m)
+}
+func (m *RevokedCertificate) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokedCertificate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokedCertificate proto.InternalMessageInfo
+
+func (m *RevokedCertificate) GetSerialNumber() string {
+	if m != nil {
+		return m.SerialNumber
+	}
+	return ""
+}
+
+func (m *RevokedCertificate) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *RevokedCertificate) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+func (m *RevokedCertificate) GetRevokedAt() int64 {
+	if m != nil {
+		return m.RevokedAt
+	}
+	return 0
+}
+
+type RevokeCertificateRequest struct {
+	RevokedCertificate   *RevokedCertificate `protobuf:"bytes,1,opt,name=revoked_certificate,json=revokedCertificate,proto3" json:"revoked_certificate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *RevokeCertificateRequest) Reset()         { *m = RevokeCertificateRequest{} }
+func (m *RevokeCertificateRequest) String() string { return proto.CompactTextString(m) }
+func (*RevokeCertificateRequest) ProtoMessage()    {}
+func (*RevokeCertificateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{89}
+}
+
+func (m *RevokeCertificateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeCertificateRequest.Unmarshal(m, b)
+}
+func (m *RevokeCertificateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeCertificateRequest.Marshal(b, m, deterministic)
+}
+func (m *RevokeCertificateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeCertificateRequest.Merge(m, src)
+}
+func (m *RevokeCertificateRequest) XXX_Size() int {
+	return xxx_messageInfo_RevokeCertificateRequest.Size(m)
+}
+func (m *RevokeCertificateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeCertificateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeCertificateRequest proto.InternalMessageInfo
+
+func (m *RevokeCertificateRequest) GetRevokedCertificate() *RevokedCertificate {
+	if m != nil {
+		return m.RevokedCertificate
+	}
+	return nil
+}
+
+type RevokeCertificateResponse struct {
+	RevokedCertificate   *RevokedCertificate `protobuf:"bytes,1,opt,name=revoked_certificate,json=revokedCertificate,proto3" json:"revoked_certificate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *RevokeCertificateResponse) Reset()         { *m = RevokeCertificateResponse{} }
+func (m *RevokeCertificateResponse) String() string { return proto.CompactTextString(m) }
+func (*RevokeCertificateResponse) ProtoMessage()    {}
+func (*RevokeCertificateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{90}
+}
+
+func (m *RevokeCertificateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RevokeCertificateResponse.Unmarshal(m, b)
+}
+func (m *RevokeCertificateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RevokeCertificateResponse.Marshal(b, m, deterministic)
+}
+func (m *RevokeCertificateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevokeCertificateResponse.Merge(m, src)
+}
+func (m *RevokeCertificateResponse) XXX_Size() int {
+	return xxx_messageInfo_RevokeCertificateResponse.Size(m)
+}
+func (m *RevokeCertificateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevokeCertificateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevokeCertificateResponse proto.InternalMessageInfo
+
+func (m *RevokeCertificateResponse) GetRevokedCertificate() *RevokedCertificate {
+	if m != nil {
+		return m.RevokedCertificate
+	}
+	return nil
+}
+
+type FetchRevokedCertificateRequest struct {
+	SerialNumber         string   `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FetchRevokedCertificateRequest) Reset()         { *m = FetchRevokedCertificateRequest{} }
+func (m *FetchRevokedCertificateRequest) String() string { return proto.CompactTextString(m) }
+func (*FetchRevokedCertificateRequest) ProtoMessage()    {}
+func (*FetchRevokedCertificateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{91}
+}
+
+func (m *FetchRevokedCertificateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchRevokedCertificateRequest.Unmarshal(m, b)
+}
+func (m *FetchRevokedCertificateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchRevokedCertificateRequest.Marshal(b, m, deterministic)
+}
+func (m *FetchRevokedCertificateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchRevokedCertificateRequest.Merge(m, src)
+}
+func (m *FetchRevokedCertificateRequest) XXX_Size() int {
+	return xxx_messageInfo_FetchRevokedCertificateRequest.Size(m)
+}
+func (m *FetchRevokedCertificateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchRevokedCertificateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchRevokedCertificateRequest proto.InternalMessageInfo
+
+func (m *FetchRevokedCertificateRequest) GetSerialNumber() string {
+	if m != nil {
+		return m.SerialNumber
+	}
+	return ""
+}
+
+type FetchRevokedCertificateResponse struct {
+	// The revoked certificate, or unset if the serial number has not been
+	// revoked.
+	RevokedCertificate   *RevokedCertificate `protobuf:"bytes,1,opt,name=revoked_certificate,json=revokedCertificate,proto3" json:"revoked_certificate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *FetchRevokedCertificateResponse) Reset()         { *m = FetchRevokedCertificateResponse{} }
+func (m *FetchRevokedCertificateResponse) String() string { return proto.CompactTextString(m) }
+func (*FetchRevokedCertificateResponse) ProtoMessage()    {}
+func (*FetchRevokedCertificateResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{92}
+}
+
+func (m *FetchRevokedCertificateResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FetchRevokedCertificateResponse.Unmarshal(m, b)
+}
+func (m *FetchRevokedCertificateResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FetchRevokedCertificateResponse.Marshal(b, m, deterministic)
+}
+func (m *FetchRevokedCertificateResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FetchRevokedCertificateResponse.Merge(m, src)
+}
+func (m *FetchRevokedCertificateResponse) XXX_Size() int {
+	return xxx_messageInfo_FetchRevokedCertificateResponse.Size(m)
+}
+func (m *FetchRevokedCertificateResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_FetchRevokedCertificateResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FetchRevokedCertificateResponse proto.InternalMessageInfo
+
+func (m *FetchRevokedCertificateResponse) GetRevokedCertificate() *RevokedCertificate {
+	if m != nil {
+		return m.RevokedCertificate
+	}
+	return nil
+}
+
+type ListRevokedCertificatesRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListRevokedCertificatesRequest) Reset()         { *m = ListRevokedCertificatesRequest{} }
+func (m *ListRevokedCertificatesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListRevokedCertificatesRequest) ProtoMessage()    {}
+func (*ListRevokedCertificatesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{93}
+}
+
+func (m *ListRevokedCertificatesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRevokedCertificatesRequest.Unmarshal(m, b)
+}
+func (m *ListRevokedCertificatesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRevokedCertificatesRequest.Marshal(b, m, deterministic)
+}
+func (m *ListRevokedCertificatesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRevokedCertificatesRequest.Merge(m, src)
+}
+func (m *ListRevokedCertificatesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListRevokedCertificatesRequest.Size(m)
+}
+func (m *ListRevokedCertificatesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRevokedCertificatesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRevokedCertificatesRequest proto.InternalMessageInfo
+
+type ListRevokedCertificatesResponse struct {
+	RevokedCertificates  []*RevokedCertificate `protobuf:"bytes,1,rep,name=revoked_certificates,json=revokedCertificates,proto3" json:"revoked_certificates,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *ListRevokedCertificatesResponse) Reset()         { *m = ListRevokedCertificatesResponse{} }
+func (m *ListRevokedCertificatesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListRevokedCertificatesResponse) ProtoMessage()    {}
+func (*ListRevokedCertificatesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{94}
+}
+
+func (m *ListRevokedCertificatesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListRevokedCertificatesResponse.Unmarshal(m, b)
+}
+func (m *ListRevokedCertificatesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListRevokedCertificatesResponse.Marshal(b, m, deterministic)
+}
+func (m *ListRevokedCertificatesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListRevokedCertificatesResponse.Merge(m, src)
+}
+func (m *ListRevokedCertificatesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListRevokedCertificatesResponse.Size(m)
+}
+func (m *ListRevokedCertificatesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListRevokedCertificatesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListRevokedCertificatesResponse proto.InternalMessageInfo
+
+func (m *ListRevokedCertificatesResponse) GetRevokedCertificates() []*RevokedCertificate {
+	if m != nil {
+		return m.RevokedCertificates
+	}
+	return nil
+}
+
+type PruneRevokedCertificatesRequest struct {
+	// Prune revocations for certificates that expire before this time
+	// (seconds since unix epoch)
+	ExpiresBefore        int64    `protobuf:"varint,1,opt,name=expires_before,json=expiresBefore,proto3" json:"expires_before,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PruneRevokedCertificatesRequest) Reset()         { *m = PruneRevokedCertificatesRequest{} }
+func (m *PruneRevokedCertificatesRequest) String() string { return proto.CompactTextString(m) }
+func (*PruneRevokedCertificatesRequest) ProtoMessage()    {}
+func (*PruneRevokedCertificatesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{95}
+}
+
+func (m *PruneRevokedCertificatesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneRevokedCertificatesRequest.Unmarshal(m, b)
+}
+func (m *PruneRevokedCertificatesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneRevokedCertificatesRequest.Marshal(b, m, deterministic)
+}
+func (m *PruneRevokedCertificatesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneRevokedCertificatesRequest.Merge(m, src)
+}
+func (m *PruneRevokedCertificatesRequest) XXX_Size() int {
+	return xxx_messageInfo_PruneRevokedCertificatesRequest.Size(m)
+}
+func (m *PruneRevokedCertificatesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneRevokedCertificatesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneRevokedCertificatesRequest proto.InternalMessageInfo
+
+func (m *PruneRevokedCertificatesRequest) GetExpiresBefore() int64 {
+	if m != nil {
+		return m.ExpiresBefore
+	}
+	return 0
+}
+
+type PruneRevokedCertificatesResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PruneRevokedCertificatesResponse) Reset()         { *m = PruneRevokedCertificatesResponse{} }
+func (m *PruneRevokedCertificatesResponse) String() string { return proto.CompactTextString(m) }
+func (*PruneRevokedCertificatesResponse) ProtoMessage()    {}
+func (*PruneRevokedCertificatesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{96}
+}
+
+func (m *PruneRevokedCertificatesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneRevokedCertificatesResponse.Unmarshal(m, b)
+}
+func (m *PruneRevokedCertificatesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneRevokedCertificatesResponse.Marshal(b, m, deterministic)
+}
+func (m *PruneRevokedCertificatesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneRevokedCertificatesResponse.Merge(m, src)
+}
+func (m *PruneRevokedCertificatesResponse) XXX_Size() int {
+	return xxx_messageInfo_PruneRevokedCertificatesResponse.Size(m)
+}
+func (m *PruneRevokedCertificatesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneRevokedCertificatesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneRevokedCertificatesResponse proto.InternalMessageInfo
+
+type DownstreamCA struct {
+	// Serial number of the downstream CA certificate (base 10 string)
+	SerialNumber string `protobuf:"bytes,1,opt,name=serial_number,json=serialNumber,proto3" json:"serial_number,omitempty"`
+	// SPIFFE ID of the downstream CA certificate
+	SpiffeId string `protobuf:"bytes,2,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	// SPIFFE ID of the agent the downstream CA was issued through
+	AgentId string `protobuf:"bytes,3,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// Time the downstream CA certificate expires (seconds since unix epoch)
+	ExpiresAt            int64    `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DownstreamCA) Reset()         { *m = DownstreamCA{} }
+func (m *DownstreamCA) String() string { return proto.CompactTextString(m) }
+func (*DownstreamCA) ProtoMessage()    {}
+func (*DownstreamCA) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{97}
+}
+
+func (m *DownstreamCA) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DownstreamCA.Unmarshal(m, b)
+}
+func (m *DownstreamCA) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DownstreamCA.Marshal(b, m, deterministic)
+}
+func (m *DownstreamCA) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DownstreamCA.Merge(m, src)
+}
+func (m *DownstreamCA) XXX_Size() int {
+	return xxx_messageInfo_DownstreamCA.Size(m)
+}
+func (m *DownstreamCA) XXX_DiscardUnknown() {
+	xxx_messageInfo_DownstreamCA.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DownstreamCA proto.InternalMessageInfo
+
+func (m *DownstreamCA) GetSerialNumber() string {
+	if m != nil {
+		return m.SerialNumber
+	}
+	return ""
+}
+
+func (m *DownstreamCA) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *DownstreamCA) GetAgentId() string {
+	if m != nil {
+		return m.AgentId
+	}
+	return ""
+}
+
+func (m *DownstreamCA) GetExpiresAt() int64 {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return 0
+}
+
+type CreateDownstreamCARequest struct {
+	DownstreamCa         *DownstreamCA `protobuf:"bytes,1,opt,name=downstream_ca,json=downstreamCa,proto3" json:"downstream_ca,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *CreateDownstreamCARequest) Reset()         { *m = CreateDownstreamCARequest{} }
+func (m *CreateDownstreamCARequest) String() string { return proto.CompactTextString(m) }
+func (*CreateDownstreamCARequest) ProtoMessage()    {}
+func (*CreateDownstreamCARequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{98}
+}
+
+func (m *CreateDownstreamCARequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateDownstreamCARequest.Unmarshal(m, b)
+}
+func (m *CreateDownstreamCARequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateDownstreamCARequest.Marshal(b, m, deterministic)
+}
+func (m *CreateDownstreamCARequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateDownstreamCARequest.Merge(m, src)
+}
+func (m *CreateDownstreamCARequest) XXX_Size() int {
+	return xxx_messageInfo_CreateDownstreamCARequest.Size(m)
+}
+func (m *CreateDownstreamCARequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateDownstreamCARequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateDownstreamCARequest proto.InternalMessageInfo
+
+func (m *CreateDownstreamCARequest) GetDownstreamCa() *DownstreamCA {
+	if m != nil {
+		return m.DownstreamCa
+	}
+	return nil
+}
+
+type CreateDownstreamCAResponse struct {
+	DownstreamCa         *DownstreamCA `protobuf:"bytes,1,opt,name=downstream_ca,json=downstreamCa,proto3" json:"downstream_ca,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *CreateDownstreamCAResponse) Reset()         { *m = CreateDownstreamCAResponse{} }
+func (m *CreateDownstreamCAResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateDownstreamCAResponse) ProtoMessage()    {}
+func (*CreateDownstreamCAResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{99}
+}
+
+func (m *CreateDownstreamCAResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateDownstreamCAResponse.Unmarshal(m, b)
+}
+func (m *CreateDownstreamCAResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateDownstreamCAResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateDownstreamCAResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateDownstreamCAResponse.Merge(m, src)
+}
+func (m *CreateDownstreamCAResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateDownstreamCAResponse.Size(m)
+}
+func (m *CreateDownstreamCAResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateDownstreamCAResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateDownstreamCAResponse proto.InternalMessageInfo
+
+func (m *CreateDownstreamCAResponse) GetDownstreamCa() *DownstreamCA {
+	if m != nil {
+		return m.DownstreamCa
+	}
+	return nil
+}
+
+type ListDownstreamCAsRequest struct {
+	// If set, only downstream CAs issued through this agent are listed
+	AgentId              string   `protobuf:"bytes,1,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListDownstreamCAsRequest) Reset()         { *m = ListDownstreamCAsRequest{} }
+func (m *ListDownstreamCAsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListDownstreamCAsRequest) ProtoMessage()    {}
+func (*ListDownstreamCAsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{100}
+}
+
+func (m *ListDownstreamCAsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDownstreamCAsRequest.Unmarshal(m, b)
+}
+func (m *ListDownstreamCAsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDownstreamCAsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListDownstreamCAsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDownstreamCAsRequest.Merge(m, src)
+}
+func (m *ListDownstreamCAsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListDownstreamCAsRequest.Size(m)
+}
+func (m *ListDownstreamCAsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDownstreamCAsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDownstreamCAsRequest proto.InternalMessageInfo
+
+func (m *ListDownstreamCAsRequest) GetAgentId() string {
+	if m != nil {
+		return m.AgentId
+	}
+	return ""
+}
+
+type ListDownstreamCAsResponse struct {
+	DownstreamCas        []*DownstreamCA `protobuf:"bytes,1,rep,name=downstream_cas,json=downstreamCas,proto3" json:"downstream_cas,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ListDownstreamCAsResponse) Reset()         { *m = ListDownstreamCAsResponse{} }
+func (m *ListDownstreamCAsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListDownstreamCAsResponse) ProtoMessage()    {}
+func (*ListDownstreamCAsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{101}
+}
+
+func (m *ListDownstreamCAsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListDownstreamCAsResponse.Unmarshal(m, b)
+}
+func (m *ListDownstreamCAsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListDownstreamCAsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListDownstreamCAsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListDownstreamCAsResponse.Merge(m, src)
+}
+func (m *ListDownstreamCAsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListDownstreamCAsResponse.Size(m)
+}
+func (m *ListDownstreamCAsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListDownstreamCAsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListDownstreamCAsResponse proto.InternalMessageInfo
+
+func (m *ListDownstreamCAsResponse) GetDownstreamCas() []*DownstreamCA {
+	if m != nil {
+		return m.DownstreamCas
+	}
+	return nil
+}
+
+type PruneDownstreamCAsRequest struct {
+	// Prune downstream CAs that expire before this time (seconds since unix
+	// epoch)
+	ExpiresBefore        int64    `protobuf:"varint,1,opt,name=expires_before,json=expiresBefore,proto3" json:"expires_before,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PruneDownstreamCAsRequest) Reset()         { *m = PruneDownstreamCAsRequest{} }
+func (m *PruneDownstreamCAsRequest) String() string { return proto.CompactTextString(m) }
+func (*PruneDownstreamCAsRequest) ProtoMessage()    {}
+func (*PruneDownstreamCAsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{102}
+}
+
+func (m *PruneDownstreamCAsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneDownstreamCAsRequest.Unmarshal(m, b)
+}
+func (m *PruneDownstreamCAsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneDownstreamCAsRequest.Marshal(b, m, deterministic)
+}
+func (m *PruneDownstreamCAsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneDownstreamCAsRequest.Merge(m, src)
+}
+func (m *PruneDownstreamCAsRequest) XXX_Size() int {
+	return xxx_messageInfo_PruneDownstreamCAsRequest.Size(m)
+}
+func (m *PruneDownstreamCAsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneDownstreamCAsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneDownstreamCAsRequest proto.InternalMessageInfo
+
+func (m *PruneDownstreamCAsRequest) GetExpiresBefore() int64 {
+	if m != nil {
+		return m.ExpiresBefore
+	}
+	return 0
+}
+
+type PruneDownstreamCAsResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PruneDownstreamCAsResponse) Reset()         { *m = PruneDownstreamCAsResponse{} }
+func (m *PruneDownstreamCAsResponse) String() string { return proto.CompactTextString(m) }
+func (*PruneDownstreamCAsResponse) ProtoMessage()    {}
+func (*PruneDownstreamCAsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{103}
+}
+
+func (m *PruneDownstreamCAsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneDownstreamCAsResponse.Unmarshal(m, b)
+}
+func (m *PruneDownstreamCAsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneDownstreamCAsResponse.Marshal(b, m, deterministic)
+}
+func (m *PruneDownstreamCAsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneDownstreamCAsResponse.Merge(m, src)
+}
+func (m *PruneDownstreamCAsResponse) XXX_Size() int {
+	return xxx_messageInfo_PruneDownstreamCAsResponse.Size(m)
+}
+func (m *PruneDownstreamCAsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneDownstreamCAsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneDownstreamCAsResponse proto.InternalMessageInfo
+
+type IssuedSVID struct {
+	// Identifier of the SVID. For X509-SVIDs this is the serial number of the
+	// certificate (base 10 string). For JWT-SVIDs this is the "jti" claim.
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Type of the SVID
+	Type IssuedSVID_Type `protobuf:"varint,2,opt,name=type,proto3,enum=spire.server.datastore.IssuedSVID_Type" json:"type,omitempty"`
+	// SPIFFE ID of the SVID
+	SpiffeId string `protobuf:"bytes,3,opt,name=spiffe_id,json=spiffeId,proto3" json:"spiffe_id,omitempty"`
+	// ID of the registration entry the SVID was issued for. Unset for SVIDs
+	// not issued for a registration entry (e.g. agent SVIDs).
+	EntryId string `protobuf:"bytes,4,opt,name=entry_id,json=entryId,proto3" json:"entry_id,omitempty"`
+	// SPIFFE ID of the agent that requested the SVID. Unset for SVIDs the
+	// server issues to itself.
+	AgentId string `protobuf:"bytes,5,opt,name=agent_id,json=agentId,proto3" json:"agent_id,omitempty"`
+	// Identifier of the signing authority. For X509-SVIDs this is the hex
+	// encoded subject key ID of the signing CA. For JWT-SVIDs this is the
+	// key ID of the signing key.
+	AuthorityId string `protobuf:"bytes,6,opt,name=authority_id,json=authorityId,proto3" json:"authority_id,omitempty"`
+	// Time the SVID is valid from (seconds since unix epoch)
+	NotBefore int64 `protobuf:"varint,7,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	// Time the SVID expires (seconds since unix epoch)
+	NotAfter             int64    `protobuf:"varint,8,opt,name=not_after,json=notAfter,proto3" json:"not_after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *IssuedSVID) Reset()         { *m = IssuedSVID{} }
+func (m *IssuedSVID) String() string { return proto.CompactTextString(m) }
+func (*IssuedSVID) ProtoMessage()    {}
+func (*IssuedSVID) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{104}
+}
+
+func (m *IssuedSVID) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_IssuedSVID.Unmarshal(m, b)
+}
+func (m *IssuedSVID) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_IssuedSVID.Marshal(b, m, deterministic)
+}
+func (m *IssuedSVID) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_IssuedSVID.Merge(m, src)
+}
+func (m *IssuedSVID) XXX_Size() int {
+	return xxx_messageInfo_IssuedSVID.Size(m)
+}
+func (m *IssuedSVID) XXX_DiscardUnknown() {
+	xxx_messageInfo_IssuedSVID.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_IssuedSVID proto.InternalMessageInfo
+
+func (m *IssuedSVID) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *IssuedSVID) GetType() IssuedSVID_Type {
+	if m != nil {
+		return m.Type
+	}
+	return IssuedSVID_X509_SVID
+}
+
+func (m *IssuedSVID) GetSpiffeId() string {
+	if m != nil {
+		return m.SpiffeId
+	}
+	return ""
+}
+
+func (m *IssuedSVID) GetEntryId() string {
+	if m != nil {
+		return m.EntryId
+	}
+	return ""
+}
+
+func (m *IssuedSVID) GetAgentId() string {
+	if m != nil {
+		return m.AgentId
+	}
+	return ""
+}
+
+func (m *IssuedSVID) GetAuthorityId() string {
+	if m != nil {
+		return m.AuthorityId
+	}
+	return ""
+}
+
+func (m *IssuedSVID) GetNotBefore() int64 {
+	if m != nil {
+		return m.NotBefore
+	}
+	return 0
+}
+
+func (m *IssuedSVID) GetNotAfter() int64 {
+	if m != nil {
+		return m.NotAfter
+	}
+	return 0
+}
+
+type CreateIssuedSVIDRequest struct {
+	IssuedSvid           *IssuedSVID `protobuf:"bytes,1,opt,name=issued_svid,json=issuedSvid,proto3" json:"issued_svid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *CreateIssuedSVIDRequest) Reset()         { *m = CreateIssuedSVIDRequest{} }
+func (m *CreateIssuedSVIDRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateIssuedSVIDRequest) ProtoMessage()    {}
+func (*CreateIssuedSVIDRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{105}
+}
+
+func (m *CreateIssuedSVIDRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateIssuedSVIDRequest.Unmarshal(m, b)
+}
+func (m *CreateIssuedSVIDRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateIssuedSVIDRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateIssuedSVIDRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateIssuedSVIDRequest.Merge(m, src)
+}
+func (m *CreateIssuedSVIDRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateIssuedSVIDRequest.Size(m)
+}
+func (m *CreateIssuedSVIDRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateIssuedSVIDRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateIssuedSVIDRequest proto.InternalMessageInfo
+
+func (m *CreateIssuedSVIDRequest) GetIssuedSvid() *IssuedSVID {
+	if m != nil {
+		return m.IssuedSvid
+	}
+	return nil
+}
+
+type CreateIssuedSVIDResponse struct {
+	IssuedSvid           *IssuedSVID `protobuf:"bytes,1,opt,name=issued_svid,json=issuedSvid,proto3" json:"issued_svid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *CreateIssuedSVIDResponse) Reset()         { *m = CreateIssuedSVIDResponse{} }
+func (m *CreateIssuedSVIDResponse) String() string { return proto.CompactTextString(m) }
+func (*CreateIssuedSVIDResponse) ProtoMessage()    {}
+func (*CreateIssuedSVIDResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{106}
+}
+
+func (m *CreateIssuedSVIDResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateIssuedSVIDResponse.Unmarshal(m, b)
+}
+func (m *CreateIssuedSVIDResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateIssuedSVIDResponse.Marshal(b, m, deterministic)
+}
+func (m *CreateIssuedSVIDResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateIssuedSVIDResponse.Merge(m, src)
+}
+func (m *CreateIssuedSVIDResponse) XXX_Size() int {
+	return xxx_messageInfo_CreateIssuedSVIDResponse.Size(m)
+}
+func (m *CreateIssuedSVIDResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateIssuedSVIDResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateIssuedSVIDResponse proto.InternalMessageInfo
+
+func (m *CreateIssuedSVIDResponse) GetIssuedSvid() *IssuedSVID {
+	if m != nil {
+		return m.IssuedSvid
+	}
+	return nil
+}
+
+type ListIssuedSVIDsRequest struct {
+	// If set, only SVIDs for this SPIFFE ID are listed
+	BySpiffeId *wrappers.StringValue `protobuf:"bytes,1,opt,name=by_spiffe_id,json=bySpiffeId,proto3" json:"by_spiffe_id,omitempty"`
+	// If set, only SVIDs requested by this agent are listed
+	ByAgentId *wrappers.StringValue `protobuf:"bytes,2,opt,name=by_agent_id,json=byAgentId,proto3" json:"by_agent_id,omitempty"`
+	// If non-zero, only SVIDs issued (i.e. valid from) at or after this time
+	// are listed (seconds since unix epoch)
+	IssuedAfter int64 `protobuf:"varint,3,opt,name=issued_after,json=issuedAfter,proto3" json:"issued_after,omitempty"`
+	// If non-zero, only SVIDs issued (i.e. valid from) before this time are
+	// listed (seconds since unix epoch)
+	IssuedBefore         int64       `protobuf:"varint,4,opt,name=issued_before,json=issuedBefore,proto3" json:"issued_before,omitempty"`
+	Pagination           *Pagination `protobuf:"bytes,5,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *ListIssuedSVIDsRequest) Reset()         { *m = ListIssuedSVIDsRequest{} }
+func (m *ListIssuedSVIDsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListIssuedSVIDsRequest) ProtoMessage()    {}
+func (*ListIssuedSVIDsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{107}
+}
+
+func (m *ListIssuedSVIDsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListIssuedSVIDsRequest.Unmarshal(m, b)
+}
+func (m *ListIssuedSVIDsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListIssuedSVIDsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListIssuedSVIDsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListIssuedSVIDsRequest.Merge(m, src)
+}
+func (m *ListIssuedSVIDsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListIssuedSVIDsRequest.Size(m)
+}
+func (m *ListIssuedSVIDsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListIssuedSVIDsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListIssuedSVIDsRequest proto.InternalMessageInfo
+
+func (m *ListIssuedSVIDsRequest) GetBySpiffeId() *wrappers.StringValue {
+	if m != nil {
+		return m.BySpiffeId
+	}
+	return nil
+}
+
+func (m *ListIssuedSVIDsRequest) GetByAgentId() *wrappers.StringValue {
+	if m != nil {
+		return m.ByAgentId
+	}
+	return nil
+}
+
+func (m *ListIssuedSVIDsRequest) GetIssuedAfter() int64 {
+	if m != nil {
+		return m.IssuedAfter
+	}
+	return 0
+}
+
+func (m *ListIssuedSVIDsRequest) GetIssuedBefore() int64 {
+	if m != nil {
+		return m.IssuedBefore
+	}
+	return 0
+}
+
+func (m *ListIssuedSVIDsRequest) GetPagination() *Pagination {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
+}
+
+type ListIssuedSVIDsResponse struct {
+	IssuedSvids          []*IssuedSVID `protobuf:"bytes,1,rep,name=issued_svids,json=issuedSvids,proto3" json:"issued_svids,omitempty"`
+	Pagination           *Pagination   `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ListIssuedSVIDsResponse) Reset()         { *m = ListIssuedSVIDsResponse{} }
+func (m *ListIssuedSVIDsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListIssuedSVIDsResponse) ProtoMessage()    {}
+func (*ListIssuedSVIDsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{108}
+}
+
+func (m *ListIssuedSVIDsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListIssuedSVIDsResponse.Unmarshal(m, b)
+}
+func (m *ListIssuedSVIDsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListIssuedSVIDsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListIssuedSVIDsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListIssuedSVIDsResponse.Merge(m, src)
+}
+func (m *ListIssuedSVIDsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListIssuedSVIDsResponse.Size(m)
+}
+func (m *ListIssuedSVIDsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListIssuedSVIDsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListIssuedSVIDsResponse proto.InternalMessageInfo
+
+func (m *ListIssuedSVIDsResponse) GetIssuedSvids() []*IssuedSVID {
+	if m != nil {
+		return m.IssuedSvids
+	}
+	return nil
+}
+
+func (m *ListIssuedSVIDsResponse) GetPagination() *Pagination {
+	if m != nil {
+		return m.Pagination
+	}
+	return nil
+}
+
+type PruneIssuedSVIDsRequest struct {
+	// Prune records of SVIDs that expire before this time (seconds since
+	// unix epoch)
+	ExpiresBefore        int64    `protobuf:"varint,1,opt,name=expires_before,json=expiresBefore,proto3" json:"expires_before,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PruneIssuedSVIDsRequest) Reset()         { *m = PruneIssuedSVIDsRequest{} }
+func (m *PruneIssuedSVIDsRequest) String() string { return proto.CompactTextString(m) }
+func (*PruneIssuedSVIDsRequest) ProtoMessage()    {}
+func (*PruneIssuedSVIDsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{109}
+}
+
+func (m *PruneIssuedSVIDsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneIssuedSVIDsRequest.Unmarshal(m, b)
+}
+func (m *PruneIssuedSVIDsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneIssuedSVIDsRequest.Marshal(b, m, deterministic)
+}
+func (m *PruneIssuedSVIDsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneIssuedSVIDsRequest.Merge(m, src)
+}
+func (m *PruneIssuedSVIDsRequest) XXX_Size() int {
+	return xxx_messageInfo_PruneIssuedSVIDsRequest.Size(m)
+}
+func (m *PruneIssuedSVIDsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneIssuedSVIDsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneIssuedSVIDsRequest proto.InternalMessageInfo
+
+func (m *PruneIssuedSVIDsRequest) GetExpiresBefore() int64 {
+	if m != nil {
+		return m.ExpiresBefore
+	}
+	return 0
+}
+
+type PruneIssuedSVIDsResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PruneIssuedSVIDsResponse) Reset()         { *m = PruneIssuedSVIDsResponse{} }
+func (m *PruneIssuedSVIDsResponse) String() string { return proto.CompactTextString(m) }
+func (*PruneIssuedSVIDsResponse) ProtoMessage()    {}
+func (*PruneIssuedSVIDsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_d08157cfd31fc929, []int{110}
+}
+
+func (m *PruneIssuedSVIDsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneIssuedSVIDsResponse.Unmarshal(m, b)
+}
+func (m *PruneIssuedSVIDsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneIssuedSVIDsResponse.Marshal(b, m, deterministic)
+}
+func (m *PruneIssuedSVIDsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneIssuedSVIDsResponse.Merge(m, src)
+}
+func (m *PruneIssuedSVIDsResponse) XXX_Size() int {
+	return xxx_messageInfo_PruneIssuedSVIDsResponse.Size(m)
+}
+func (m *PruneIssuedSVIDsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneIssuedSVIDsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneIssuedSVIDsResponse proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterEnum("spire.server.datastore.DeleteBundleRequest_Mode", DeleteBundleRequest_Mode_name, DeleteBundleRequest_Mode_value)
+	proto.RegisterEnum("spire.server.datastore.BySelectors_MatchBehavior", BySelectors_MatchBehavior_name, BySelectors_MatchBehavior_value)
+	proto.RegisterEnum("spire.server.datastore.RegistrationEntryEvent_Type", RegistrationEntryEvent_Type_name, RegistrationEntryEvent_Type_value)
+	proto.RegisterEnum("spire.server.datastore.IssuedSVID_Type", IssuedSVID_Type_name, IssuedSVID_Type_value)
+	proto.RegisterType((*CreateBundleRequest)(nil), "spire.server.datastore.CreateBundleRequest")
+	proto.RegisterType((*CreateBundleResponse)(nil), "spire.server.datastore.CreateBundleResponse")
+	proto.RegisterType((*FetchBundleRequest)(nil), "spire.server.datastore.FetchBundleRequest")
+	proto.RegisterType((*FetchBundleResponse)(nil), "spire.server.datastore.FetchBundleResponse")
+	proto.RegisterType((*ListBundlesRequest)(nil), "spire.server.datastore.ListBundlesRequest")
+	proto.RegisterType((*ListBundlesResponse)(nil), "spire.server.datastore.ListBundlesResponse")
+	proto.RegisterType((*UpdateBundleRequest)(nil), "spire.server.datastore.UpdateBundleRequest")
+	proto.RegisterType((*UpdateBundleResponse)(nil), "spire.server.datastore.UpdateBundleResponse")
+	proto.RegisterType((*SetBundleRequest)(nil), "spire.server.datastore.SetBundleRequest")
+	proto.RegisterType((*SetBundleResponse)(nil), "spire.server.datastore.SetBundleResponse")
+	proto.RegisterType((*AppendBundleRequest)(nil), "spire.server.datastore.AppendBundleRequest")
+	proto.RegisterType((*AppendBundleResponse)(nil), "spire.server.datastore.AppendBundleResponse")
+	proto.RegisterType((*DeleteBundleRequest)(nil), "spire.server.datastore.DeleteBundleRequest")
+	proto.RegisterType((*DeleteBundleResponse)(nil), "spire.server.datastore.DeleteBundleResponse")
+	proto.RegisterType((*PruneBundleRequest)(nil), "spire.server.datastore.PruneBundleRequest")
+	proto.RegisterType((*PruneBundleResponse)(nil), "spire.server.datastore.PruneBundleResponse")
+	proto.RegisterType((*NodeSelectors)(nil), "spire.server.datastore.NodeSelectors")
+	proto.RegisterType((*SetNodeSelectorsRequest)(nil), "spire.server.datastore.SetNodeSelectorsRequest")
+	proto.RegisterType((*SetNodeSelectorsResponse)(nil), "spire.server.datastore.SetNodeSelectorsResponse")
+	proto.RegisterType((*GetNodeSelectorsRequest)(nil), "spire.server.datastore.GetNodeSelectorsRequest")
+	proto.RegisterType((*GetNodeSelectorsResponse)(nil), "spire.server.datastore.GetNodeSelectorsResponse")
+	proto.RegisterType((*ListNodeSelectorsRequest)(nil), "spire.server.datastore.ListNodeSelectorsRequest")
+	proto.RegisterType((*ListNodeSelectorsResponse)(nil), "spire.server.datastore.ListNodeSelectorsResponse")
+	proto.RegisterType((*CreateAttestedNodeRequest)(nil), "spire.server.datastore.CreateAttestedNodeRequest")
+	proto.RegisterType((*CreateAttestedNodeResponse)(nil), "spire.server.datastore.CreateAttestedNodeResponse")
+	proto.RegisterType((*FetchAttestedNodeRequest)(nil), "spire.server.datastore.FetchAttestedNodeRequest")
+	proto.RegisterType((*FetchAttestedNodeResponse)(nil), "spire.server.datastore.FetchAttestedNodeResponse")
+	proto.RegisterType((*ListAttestedNodesRequest)(nil), "spire.server.datastore.ListAttestedNodesRequest")
+	proto.RegisterType((*ListAttestedNodesResponse)(nil), "spire.server.datastore.ListAttestedNodesResponse")
+	proto.RegisterType((*UpdateAttestedNodeRequest)(nil), "spire.server.datastore.UpdateAttestedNodeRequest")
+	proto.RegisterType((*UpdateAttestedNodeResponse)(nil), "spire.server.datastore.UpdateAttestedNodeResponse")
+	proto.RegisterType((*SetAttestedNodeBannedRequest)(nil), "spire.server.datastore.SetAttestedNodeBannedRequest")
+	proto.RegisterType((*SetAttestedNodeBannedResponse)(nil), "spire.server.datastore.SetAttestedNodeBannedResponse")
+	proto.RegisterType((*DeleteAttestedNodeRequest)(nil), "spire.server.datastore.DeleteAttestedNodeRequest")
+	proto.RegisterType((*DeleteAttestedNodeResponse)(nil), "spire.server.datastore.DeleteAttestedNodeResponse")
+	proto.RegisterType((*CreateRegistrationEntryRequest)(nil), "spire.server.datastore.CreateRegistrationEntryRequest")
+	proto.RegisterType((*CreateRegistrationEntryResponse)(nil), "spire.server.datastore.CreateRegistrationEntryResponse")
+	proto.RegisterType((*FetchRegistrationEntryRequest)(nil), "spire.server.datastore.FetchRegistrationEntryRequest")
+	proto.RegisterType((*FetchRegistrationEntryResponse)(nil), "spire.server.datastore.FetchRegistrationEntryResponse")
+	proto.RegisterType((*BySelectors)(nil), "spire.server.datastore.BySelectors")
+	proto.RegisterType((*Pagination)(nil), "spire.server.datastore.Pagination")
+	proto.RegisterType((*ListRegistrationEntriesRequest)(nil), "spire.server.datastore.ListRegistrationEntriesRequest")
+	proto.RegisterType((*ListRegistrationEntriesResponse)(nil), "spire.server.datastore.ListRegistrationEntriesResponse")
+	proto.RegisterType((*UpdateRegistrationEntryRequest)(nil), "spire.server.datastore.UpdateRegistrationEntryRequest")
+	proto.RegisterType((*UpdateRegistrationEntryResponse)(nil), "spire.server.datastore.UpdateRegistrationEntryResponse")
+	proto.RegisterType((*DeleteRegistrationEntryRequest)(nil), "spire.server.datastore.DeleteRegistrationEntryRequest")
+	proto.RegisterType((*DeleteRegistrationEntryResponse)(nil), "spire.server.datastore.DeleteRegistrationEntryResponse")
+	proto.RegisterType((*BatchRegistrationEntryResult)(nil), "spire.server.datastore.BatchRegistrationEntryResult")
+	proto.RegisterType((*BatchCreateRegistrationEntriesRequest)(nil), "spire.server.datastore.BatchCreateRegistrationEntriesRequest")
+	proto.RegisterType((*BatchCreateRegistrationEntriesResponse)(nil), "spire.server.datastore.BatchCreateRegistrationEntriesResponse")
+	proto.RegisterType((*BatchUpdateRegistrationEntriesRequest)(nil), "spire.server.datastore.BatchUpdateRegistrationEntriesRequest")
+	proto.RegisterType((*BatchUpdateRegistrationEntriesResponse)(nil), "spire.server.datastore.BatchUpdateRegistrationEntriesResponse")
+	proto.RegisterType((*BatchDeleteRegistrationEntriesRequest)(nil), "spire.server.datastore.BatchDeleteRegistrationEntriesRequest")
+	proto.RegisterType((*BatchDeleteRegistrationEntriesResponse)(nil), "spire.server.datastore.BatchDeleteRegistrationEntriesResponse")
+	proto.RegisterType((*PruneRegistrationEntriesRequest)(nil), "spire.server.datastore.PruneRegistrationEntriesRequest")
+	proto.RegisterType((*PruneRegistrationEntriesResponse)(nil), "spire.server.datastore.PruneRegistrationEntriesResponse")
+	proto.RegisterType((*ListRegistrationEntryTombstonesRequest)(nil), "spire.server.datastore.ListRegistrationEntryTombstonesRequest")
+	proto.RegisterType((*ListRegistrationEntryTombstonesResponse)(nil), "spire.server.datastore.ListRegistrationEntryTombstonesResponse")
+	proto.RegisterType((*PruneRegistrationEntryTombstonesRequest)(nil), "spire.server.datastore.PruneRegistrationEntryTombstonesRequest")
+	proto.RegisterType((*PruneRegistrationEntryTombstonesResponse)(nil), "spire.server.datastore.PruneRegistrationEntryTombstonesResponse")
+	proto.RegisterType((*RegistrationEntryEvent)(nil), "spire.server.datastore.RegistrationEntryEvent")
+	proto.RegisterType((*ListRegistrationEntryEventsRequest)(nil), "spire.server.datastore.ListRegistrationEntryEventsRequest")
+	proto.RegisterType((*ListRegistrationEntryEventsResponse)(nil), "spire.server.datastore.ListRegistrationEntryEventsResponse")
+	proto.RegisterType((*PruneRegistrationEntryEventsRequest)(nil), "spire.server.datastore.PruneRegistrationEntryEventsRequest")
+	proto.RegisterType((*PruneRegistrationEntryEventsResponse)(nil), "spire.server.datastore.PruneRegistrationEntryEventsResponse")
+	proto.RegisterType((*JoinToken)(nil), "spire.server.datastore.JoinToken")
+	proto.RegisterType((*CreateJoinTokenRequest)(nil), "spire.server.datastore.CreateJoinTokenRequest")
+	proto.RegisterType((*CreateJoinTokenResponse)(nil), "spire.server.datastore.CreateJoinTokenResponse")
+	proto.RegisterType((*FetchJoinTokenRequest)(nil), "spire.server.datastore.FetchJoinTokenRequest")
+	proto.RegisterType((*FetchJoinTokenResponse)(nil), "spire.server.datastore.FetchJoinTokenResponse")
+	proto.RegisterType((*ListJoinTokensRequest)(nil), "spire.server.datastore.ListJoinTokensRequest")
+	proto.RegisterType((*ListJoinTokensResponse)(nil), "spire.server.datastore.ListJoinTokensResponse")
+	proto.RegisterType((*ConsumeJoinTokenRequest)(nil), "spire.server.datastore.ConsumeJoinTokenRequest")
+	proto.RegisterType((*ConsumeJoinTokenResponse)(nil), "spire.server.datastore.ConsumeJoinTokenResponse")
+	proto.RegisterType((*DeleteJoinTokenRequest)(nil), "spire.server.datastore.DeleteJoinTokenRequest")
+	proto.RegisterType((*DeleteJoinTokenResponse)(nil), "spire.server.datastore.DeleteJoinTokenResponse")
+	proto.RegisterType((*PruneJoinTokensRequest)(nil), "spire.server.datastore.PruneJoinTokensRequest")
+	proto.RegisterType((*PruneJoinTokensResponse)(nil), "spire.server.datastore.PruneJoinTokensResponse")
+	proto.RegisterType((*CAJournal)(nil), "spire.server.datastore.CAJournal")
+	proto.RegisterType((*FetchCAJournalRequest)(nil), "spire.server.datastore.FetchCAJournalRequest")
+	proto.RegisterType((*FetchCAJournalResponse)(nil), "spire.server.datastore.FetchCAJournalResponse")
+	proto.RegisterType((*SetCAJournalRequest)(nil), "spire.server.datastore.SetCAJournalRequest")
+	proto.RegisterType((*SetCAJournalResponse)(nil), "spire.server.datastore.SetCAJournalResponse")
+	proto.RegisterType((*Lease)(nil), "spire.server.datastore.Lease")
+	proto.RegisterType((*AcquireLeaseRequest)(nil), "spire.server.datastore.AcquireLeaseRequest")
+	proto.RegisterType((*AcquireLeaseResponse)(nil), "spire.server.datastore.AcquireLeaseResponse")
+	proto.RegisterType((*ReleaseLeaseRequest)(nil), "spire.server.datastore.ReleaseLeaseRequest")
+	proto.RegisterType((*ReleaseLeaseResponse)(nil), "spire.server.datastore.ReleaseLeaseResponse")
+	proto.RegisterType((*RevokedCertificate)(nil), "spire.server.datastore.RevokedCertificate")
+	proto.RegisterType((*RevokeCertificateRequest)(nil), "spire.server.datastore.RevokeCertificateRequest")
+	proto.RegisterType((*RevokeCertificateResponse)(nil), "spire.server.datastore.RevokeCertificateResponse")
+	proto.RegisterType((*FetchRevokedCertificateRequest)(nil), "spire.server.datastore.FetchRevokedCertificateRequest")
+	proto.RegisterType((*FetchRevokedCertificateResponse)(nil), "spire.server.datastore.FetchRevokedCertificateResponse")
+	proto.RegisterType((*ListRevokedCertificatesRequest)(nil), "spire.server.datastore.ListRevokedCertificatesRequest")
+	proto.RegisterType((*ListRevokedCertificatesResponse)(nil), "spire.server.datastore.ListRevokedCertificatesResponse")
+	proto.RegisterType((*PruneRevokedCertificatesRequest)(nil), "spire.server.datastore.PruneRevokedCertificatesRequest")
+	proto.RegisterType((*PruneRevokedCertificatesResponse)(nil), "spire.server.datastore.PruneRevokedCertificatesResponse")
+	proto.RegisterType((*DownstreamCA)(nil), "spire.server.datastore.DownstreamCA")
+	proto.RegisterType((*CreateDownstreamCARequest)(nil), "spire.server.datastore.CreateDownstreamCARequest")
+	proto.RegisterType((*CreateDownstreamCAResponse)(nil), "spire.server.datastore.CreateDownstreamCAResponse")
+	proto.RegisterType((*ListDownstreamCAsRequest)(nil), "spire.server.datastore.ListDownstreamCAsRequest")
+	proto.RegisterType((*ListDownstreamCAsResponse)(nil), "spire.server.datastore.ListDownstreamCAsResponse")
+	proto.RegisterType((*PruneDownstreamCAsRequest)(nil), "spire.server.datastore.PruneDownstreamCAsRequest")
+	proto.RegisterType((*PruneDownstreamCAsResponse)(nil), "spire.server.datastore.PruneDownstreamCAsResponse")
+	proto.RegisterType((*IssuedSVID)(nil), "spire.server.datastore.IssuedSVID")
+	proto.RegisterType((*CreateIssuedSVIDRequest)(nil), "spire.server.datastore.CreateIssuedSVIDRequest")
+	proto.RegisterType((*CreateIssuedSVIDResponse)(nil), "spire.server.datastore.CreateIssuedSVIDResponse")
+	proto.RegisterType((*ListIssuedSVIDsRequest)(nil), "spire.server.datastore.ListIssuedSVIDsRequest")
+	proto.RegisterType((*ListIssuedSVIDsResponse)(nil), "spire.server.datastore.ListIssuedSVIDsResponse")
+	proto.RegisterType((*PruneIssuedSVIDsRequest)(nil), "spire.server.datastore.PruneIssuedSVIDsRequest")
+	proto.RegisterType((*PruneIssuedSVIDsResponse)(nil), "spire.server.datastore.PruneIssuedSVIDsResponse")
+}
+
+func init() { proto.RegisterFile("datastore.proto", fileDescriptor_d08157cfd31fc929) }
+
+var fileDescriptor_d08157cfd31fc929 = []byte{
+	// 3659 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x5c, 0xeb, 0x6e, 0xdb, 0xc8,
+	0x15, 0x2e, 0x2d, 0xdf, 0x74, 0x7c, 0x89, 0x33, 0xce, 0xda, 0x32, 0x73, 0x5d, 0xe6, 0xba, 0x89,
+	0x57, 0x76, 0x9c, 0x8b, 0x77, 0x37, 0xd9, 0x24, 0xb2, 0xac, 0x78, 0x95, 0xab, 0x41, 0x39, 0x9b,
+	0x60, 0xb7, 0x5b, 0x95, 0x32, 0xc7, 0x32, 0x13, 0x89, 0xd4, 0x92, 0x54, 0x12, 0x6d, 0x81, 0xa2,
+	0x40, 0x8b, 0x76, 0xb1, 0x68, 0x81, 0x16, 0x28, 0x5a, 0xf4, 0x47, 0xd1, 0x62, 0xd1, 0x3e, 0x42,
+	0x5f, 0xa0, 0xe8, 0x23, 0x14, 0xe8, 0xff, 0xbe, 0x40, 0x5f, 0xa1, 0xe0, 0xcc, 0x50, 0xbc, 0x0e,
+	0x45, 0xca, 0xde, 0xf6, 0x57, 0xc4, 0xe1, 0xb9, 0x7c, 0xe7, 0xcc, 0x99, 0x39, 0xc3, 0x39, 0x27,
+	0x86, 0x23, 0xaa, 0x62, 0x2b, 0x96, 0x6d, 0x98, 0xb8, 0xd8, 0x31, 0x0d, 0xdb, 0x40, 0x0b, 0x56,
+	0x47, 0x33, 0x71, 0xd1, 0xc2, 0xe6, 0x6b, 0x6c, 0x16, 0xfb, 0x6f, 0xc5, 0x53, 0x4d, 0xc3, 0x68,
+	0xb6, 0xf0, 0x0a, 0xa1, 0x6a, 0x74, 0xf7, 0x56, 0xde, 0x98, 0x4a, 0xa7, 0x83, 0x4d, 0x8b, 0xf2,
+	0x89, 0x67, 0x08, 0xdf, 0xca, 0xae, 0xd1, 0x6e, 0x1b, 0xfa, 0x4a, 0xa7, 0xd5, 0x6d, 0x6a, 0xee,
+	0x3f, 0x8c, 0x62, 0x29, 0x40, 0x41, 0xff, 0xa1, 0xaf, 0xa4, 0x32, 0xcc, 0x97, 0x4d, 0xac, 0xd8,
+	0x78, 0xa3, 0xab, 0xab, 0x2d, 0x2c, 0xe3, 0x2f, 0xbb, 0xd8, 0xb2, 0xd1, 0x32, 0x8c, 0x37, 0xc8,
+	0x40, 0x41, 0x38, 0x23, 0x5c, 0x9a, 0x5a, 0x3b, 0x56, 0xa4, 0xe0, 0x18, 0x2f, 0x23, 0x66, 0x34,
+	0xd2, 0x26, 0x1c, 0x0b, 0x0a, 0xb1, 0x3a, 0x86, 0x6e, 0xe1, 0x8c, 0x52, 0x6e, 0x03, 0xba, 0x8f,
+	0xed, 0xdd, 0xfd, 0x20, 0x92, 0x0b, 0x70, 0xc4, 0x36, 0xbb, 0x96, 0x5d, 0x57, 0x8d, 0xb6, 0xa2,
+	0xe9, 0x75, 0x4d, 0x25, 0xc2, 0xf2, 0xf2, 0x0c, 0x19, 0xde, 0x24, 0xa3, 0x55, 0xd5, 0x31, 0x24,
+	0xc0, 0x3d, 0x14, 0x84, 0x63, 0x80, 0x1e, 0x69, 0x96, 0x4d, 0x47, 0x2d, 0x06, 0x41, 0xaa, 0xc0,
+	0x7c, 0x60, 0x94, 0x89, 0x2e, 0xc2, 0x04, 0x65, 0xb3, 0x0a, 0xc2, 0x99, 0x1c, 0x57, 0xb6, 0x4b,
+	0xe4, 0x20, 0x7c, 0xd6, 0x51, 0x0f, 0xee, 0xea, 0xa0, 0x90, 0xa1, 0xec, 0xbc, 0x07, 0x73, 0x35,
+	0x6c, 0x1f, 0x04, 0x47, 0x09, 0x8e, 0xfa, 0x24, 0x0c, 0x05, 0xa2, 0x0c, 0xf3, 0xa5, 0x4e, 0x07,
+	0xeb, 0xea, 0x01, 0xfd, 0x11, 0x14, 0x32, 0x14, 0x94, 0xbf, 0x09, 0x30, 0xbf, 0x89, 0x5b, 0xd8,
+	0xc6, 0x43, 0x05, 0x1f, 0xda, 0x84, 0xd1, 0xb6, 0xa1, 0xe2, 0xc2, 0xc8, 0x19, 0xe1, 0xd2, 0xec,
+	0xda, 0x6a, 0x31, 0x7e, 0x25, 0x17, 0x63, 0x54, 0x14, 0x1f, 0x1b, 0x2a, 0x96, 0x09, 0xb7, 0xb4,
+	0x0a, 0xa3, 0xce, 0x13, 0x9a, 0x86, 0x49, 0xb9, 0x52, 0xdb, 0x91, 0xab, 0xe5, 0x9d, 0xb9, 0xef,
+	0x21, 0x80, 0xf1, 0xcd, 0xca, 0xa3, 0xca, 0x4e, 0x65, 0x4e, 0x40, 0xb3, 0x00, 0x9b, 0xd5, 0x5a,
+	0xed, 0x69, 0xb9, 0x5a, 0xda, 0xa9, 0xcc, 0x8d, 0x38, 0xd6, 0x07, 0x65, 0x0e, 0x65, 0xfd, 0x2e,
+	0xa0, 0x6d, 0xb3, 0xab, 0x0f, 0x69, 0xfb, 0x79, 0x98, 0xc5, 0x6f, 0x1d, 0xe9, 0x56, 0xbd, 0x81,
+	0xf7, 0x0c, 0x93, 0x7a, 0x21, 0x27, 0xcf, 0xb0, 0xd1, 0x0d, 0x32, 0x28, 0xdd, 0x86, 0xf9, 0x80,
+	0x12, 0x86, 0xf4, 0x3c, 0xcc, 0x52, 0x14, 0xf5, 0xdd, 0x7d, 0x45, 0x6f, 0x62, 0xaa, 0x64, 0x52,
+	0x9e, 0xa1, 0xa3, 0x65, 0x3a, 0x28, 0x35, 0x60, 0xe6, 0x89, 0xa1, 0xe2, 0x1a, 0x6e, 0xe1, 0x5d,
+	0xdb, 0x30, 0x2d, 0x74, 0x1c, 0xf2, 0x56, 0x47, 0xdb, 0xdb, 0xc3, 0x1e, 0xae, 0x49, 0x3a, 0x50,
+	0x55, 0xd1, 0x75, 0xc8, 0x5b, 0x2e, 0x65, 0x61, 0x84, 0xac, 0xcd, 0x85, 0xa0, 0x07, 0x5c, 0x41,
+	0xb2, 0x47, 0x28, 0xfd, 0x00, 0x16, 0x6b, 0xd8, 0x0e, 0xa8, 0x71, 0x7d, 0x51, 0xf6, 0x0b, 0xa4,
+	0x2e, 0x3d, 0xcf, 0x9b, 0xe4, 0xa0, 0x00, 0x9f, 0x7c, 0x11, 0x0a, 0x51, 0xf9, 0xd4, 0x0d, 0xd2,
+	0x4d, 0x58, 0xdc, 0xe2, 0xe8, 0x4e, 0xb2, 0x54, 0xaa, 0x43, 0x61, 0x8b, 0x23, 0xf3, 0x70, 0x40,
+	0x7f, 0x01, 0x05, 0x67, 0xef, 0x8b, 0x45, 0x56, 0x82, 0x59, 0x65, 0xcf, 0xc6, 0x66, 0x1d, 0xbf,
+	0xc6, 0xba, 0xed, 0xc2, 0x9b, 0x5a, 0x3b, 0x5e, 0xa4, 0x19, 0xab, 0xe8, 0x66, 0xac, 0x62, 0x55,
+	0xb7, 0x6f, 0x5e, 0xff, 0x54, 0x69, 0x75, 0xb1, 0x3c, 0x4d, 0x58, 0x2a, 0x0e, 0x47, 0x55, 0x95,
+	0xbe, 0x16, 0x60, 0x29, 0x46, 0x7e, 0xbc, 0x05, 0xb9, 0x61, 0x2c, 0x70, 0xe2, 0xb8, 0xa5, 0xd8,
+	0xd8, 0xb2, 0x3d, 0x98, 0x2c, 0x40, 0xe9, 0xb0, 0x0b, 0xe5, 0x21, 0x2c, 0xd1, 0x24, 0x56, 0xb2,
+	0x9d, 0x61, 0xac, 0x3a, 0x12, 0x5d, 0x53, 0x8b, 0x30, 0xaa, 0x3b, 0x0b, 0x9c, 0x1a, 0x28, 0x06,
+	0x83, 0x29, 0xc0, 0x40, 0xe8, 0xa4, 0x47, 0x20, 0xc6, 0x09, 0xeb, 0x67, 0x8e, 0x6c, 0xd2, 0xd6,
+	0xa1, 0x40, 0x72, 0x5b, 0x1c, 0xb2, 0xc4, 0xf0, 0x78, 0x08, 0x4b, 0x31, 0x8c, 0x43, 0xa2, 0xf8,
+	0x4f, 0x8e, 0xc6, 0x82, 0xff, 0x55, 0x3f, 0x16, 0xb6, 0xe0, 0x68, 0xa3, 0x57, 0x0f, 0x6d, 0x04,
+	0x29, 0xc2, 0xe1, 0x48, 0xa3, 0x57, 0xf1, 0xef, 0x13, 0x68, 0x03, 0xa0, 0xa3, 0x34, 0x35, 0x5d,
+	0xb1, 0x35, 0x43, 0x27, 0x33, 0x35, 0xb5, 0x26, 0xf1, 0x26, 0x7d, 0xbb, 0x4f, 0x29, 0xfb, 0xb8,
+	0xd0, 0x23, 0x98, 0x6f, 0xf4, 0xea, 0x0a, 0xc1, 0x49, 0x46, 0xea, 0x76, 0xaf, 0x83, 0x0b, 0x39,
+	0x22, 0xec, 0x44, 0x04, 0x4e, 0xcd, 0x36, 0x35, 0xbd, 0x49, 0xf1, 0x1c, 0x6d, 0xf4, 0x4a, 0x1e,
+	0xdf, 0x4e, 0xaf, 0x83, 0x51, 0x05, 0xe6, 0x7c, 0xa6, 0x91, 0xf0, 0x2d, 0x8c, 0x0e, 0xb6, 0x6c,
+	0xb6, 0x6f, 0x59, 0xc9, 0x61, 0x41, 0xeb, 0x90, 0x6f, 0xf4, 0xea, 0x0d, 0x45, 0xd7, 0xb1, 0x5a,
+	0x18, 0x63, 0x3e, 0x0f, 0xf3, 0x6f, 0x18, 0x46, 0x8b, 0xb2, 0x4f, 0x36, 0x7a, 0x1b, 0x84, 0x16,
+	0x3d, 0x25, 0xae, 0x75, 0x03, 0xba, 0xde, 0x56, 0xec, 0xdd, 0xfd, 0xc2, 0x38, 0x11, 0x70, 0x96,
+	0xe7, 0x98, 0x8d, 0x9e, 0xb7, 0x16, 0x8e, 0x34, 0xfa, 0x0f, 0x8f, 0x1d, 0x5e, 0x74, 0x11, 0x8e,
+	0xec, 0x39, 0x51, 0x51, 0xf7, 0x16, 0xd7, 0x04, 0xd9, 0x74, 0x67, 0xc9, 0x70, 0x9f, 0x53, 0xfa,
+	0x0d, 0x5b, 0x9d, 0xa1, 0x19, 0x67, 0xf1, 0xb3, 0x0a, 0x63, 0x4e, 0x5c, 0xb8, 0x2b, 0x33, 0x29,
+	0x80, 0x28, 0xe1, 0x61, 0xcc, 0xad, 0xf4, 0x4b, 0x01, 0x96, 0xe8, 0x09, 0x28, 0xeb, 0x6a, 0x40,
+	0xcb, 0x80, 0x76, 0xb1, 0x69, 0xd7, 0x2d, 0x6c, 0x6a, 0x4a, 0xab, 0xae, 0x77, 0xdb, 0x0d, 0x6c,
+	0x12, 0x18, 0x79, 0x79, 0xce, 0x79, 0x53, 0x23, 0x2f, 0x9e, 0x90, 0x71, 0x74, 0x0e, 0x66, 0x09,
+	0xb5, 0x6e, 0xd8, 0x6c, 0xd2, 0x73, 0x64, 0xdb, 0x98, 0x76, 0x46, 0x9f, 0x18, 0x36, 0x99, 0x55,
+	0x67, 0xa1, 0xc7, 0xa1, 0x19, 0x72, 0x89, 0xd5, 0xe0, 0x44, 0x0d, 0x07, 0xdc, 0x4d, 0x63, 0x20,
+	0x95, 0x79, 0x0b, 0x30, 0xce, 0xa2, 0x6b, 0x84, 0xcc, 0x26, 0x7b, 0x92, 0x9e, 0xc2, 0x49, 0x8e,
+	0xd0, 0x21, 0x51, 0x7e, 0x00, 0x4b, 0xf4, 0xd4, 0x91, 0x79, 0x3f, 0x7a, 0x04, 0x62, 0x1c, 0xe7,
+	0x90, 0x38, 0x9e, 0xc3, 0x29, 0xba, 0xc9, 0xca, 0xb8, 0xa9, 0x59, 0xb6, 0x49, 0x02, 0xa4, 0xa2,
+	0xdb, 0x66, 0xcf, 0x05, 0x73, 0x03, 0xc6, 0xb0, 0xf3, 0xcc, 0x44, 0x9e, 0x0e, 0x8a, 0x8c, 0xb2,
+	0x51, 0x6a, 0xe9, 0x05, 0x9c, 0xe6, 0x0a, 0x66, 0x58, 0x87, 0x94, 0xfc, 0x11, 0x9c, 0x24, 0x1b,
+	0x32, 0x17, 0xf1, 0x12, 0x4c, 0x12, 0x4a, 0xcf, 0x7b, 0x13, 0xe4, 0xb9, 0xaa, 0x3a, 0xe6, 0xf2,
+	0x78, 0x0f, 0x06, 0xea, 0x9f, 0x02, 0x4c, 0xf9, 0x36, 0x8c, 0xe0, 0xf1, 0x49, 0x48, 0x79, 0x7c,
+	0x42, 0x5b, 0x30, 0x46, 0xb7, 0x26, 0x7a, 0x08, 0xbe, 0x9a, 0x62, 0x6b, 0x2a, 0x92, 0xfd, 0x68,
+	0x03, 0xef, 0x2b, 0xaf, 0x35, 0xc3, 0x94, 0x29, 0xbf, 0x74, 0x1f, 0x66, 0x02, 0xe3, 0xe8, 0x08,
+	0x4c, 0x3d, 0x2e, 0xed, 0x94, 0x3f, 0xa9, 0x57, 0x5e, 0x94, 0xc8, 0x91, 0x78, 0x0e, 0xa6, 0xe9,
+	0x40, 0xed, 0xd9, 0x46, 0xad, 0xb2, 0x33, 0x27, 0x20, 0x04, 0xb3, 0xee, 0xc8, 0x76, 0x45, 0x76,
+	0xc6, 0x46, 0xa4, 0xbb, 0x00, 0xde, 0x1e, 0x82, 0x8e, 0xc1, 0x98, 0x6d, 0xbc, 0xc2, 0x3a, 0xf3,
+	0x2a, 0x7d, 0x70, 0xa2, 0xb5, 0xa3, 0x34, 0x71, 0xdd, 0xd2, 0xbe, 0xa2, 0xe7, 0xd6, 0x31, 0x79,
+	0xd2, 0x19, 0xa8, 0x69, 0x5f, 0x61, 0xe9, 0x8f, 0xa3, 0x70, 0xca, 0xd9, 0xfe, 0xc2, 0x8e, 0xd3,
+	0xbc, 0xb4, 0x77, 0x07, 0xa6, 0x1b, 0xbd, 0x7a, 0x47, 0x31, 0x03, 0x07, 0xa0, 0xe4, 0x14, 0x03,
+	0x8d, 0xde, 0x36, 0x61, 0xa8, 0xaa, 0xe8, 0x3e, 0xe1, 0xf7, 0x1f, 0x56, 0x53, 0x6f, 0xeb, 0x53,
+	0xde, 0xb6, 0x6e, 0x31, 0x1c, 0xde, 0xc2, 0xcb, 0xa5, 0xc3, 0x51, 0x73, 0xf7, 0x8e, 0xe0, 0xce,
+	0x3c, 0x3a, 0x54, 0xd6, 0x7d, 0x08, 0xf3, 0x7e, 0x0c, 0xf5, 0x8e, 0x89, 0xf7, 0xb4, 0xb7, 0x85,
+	0xb1, 0x14, 0x50, 0xe6, 0x3c, 0x28, 0xdb, 0x84, 0x0b, 0x5d, 0x26, 0x49, 0x6f, 0x0f, 0xab, 0xd8,
+	0x54, 0x6c, 0x6c, 0xd5, 0xdf, 0x68, 0xb6, 0x93, 0xf4, 0x72, 0x97, 0xf2, 0x4e, 0x3e, 0xbb, 0xef,
+	0x8e, 0x3f, 0xd7, 0xec, 0x7d, 0x74, 0x03, 0x26, 0x9d, 0x74, 0xaf, 0xb6, 0x35, 0xbd, 0x30, 0xc1,
+	0xf6, 0x0e, 0x7e, 0x62, 0x9d, 0x68, 0xf4, 0x4a, 0x0e, 0x29, 0xba, 0x0b, 0x33, 0x8d, 0x5e, 0x5d,
+	0x35, 0xde, 0xe8, 0x96, 0x6d, 0x62, 0xa5, 0x5d, 0x98, 0x1c, 0xc8, 0x3b, 0xdd, 0xe8, 0x6d, 0xf6,
+	0xe9, 0xa5, 0x3f, 0x0b, 0x70, 0x9a, 0x1b, 0x1f, 0x6c, 0x49, 0x7e, 0x08, 0x64, 0xfd, 0x6a, 0xfd,
+	0x34, 0x39, 0x70, 0x51, 0xba, 0xf4, 0x87, 0x92, 0x2d, 0x7f, 0x2d, 0xc0, 0x29, 0x9a, 0x9f, 0x0e,
+	0x79, 0x8f, 0x44, 0xeb, 0x30, 0xda, 0x56, 0xac, 0x57, 0xa1, 0x88, 0xe5, 0x71, 0x3d, 0x56, 0xac,
+	0x57, 0x32, 0x61, 0x70, 0x36, 0x57, 0x2e, 0xa2, 0x83, 0xed, 0x63, 0xb7, 0xe0, 0x14, 0xcd, 0x2e,
+	0xc3, 0xec, 0xae, 0x2f, 0xe0, 0x34, 0x97, 0xf9, 0x60, 0xb0, 0x7e, 0x2a, 0xc0, 0x89, 0x0d, 0x85,
+	0xb3, 0x71, 0x77, 0x5b, 0x36, 0x42, 0x30, 0xba, 0xeb, 0xe6, 0xbd, 0x31, 0x99, 0xfc, 0x46, 0x05,
+	0x98, 0x68, 0x63, 0xcb, 0x52, 0x9a, 0x98, 0x1d, 0x50, 0xdc, 0x47, 0x0f, 0x45, 0x2e, 0x13, 0x8a,
+	0xaf, 0x05, 0x38, 0x4f, 0x50, 0xc4, 0x67, 0x36, 0xdf, 0x9e, 0x76, 0x80, 0x90, 0x3d, 0x07, 0xb3,
+	0x4a, 0xab, 0x55, 0x37, 0x4c, 0xe7, 0xd4, 0xb4, 0xaf, 0xe9, 0x4d, 0x76, 0x14, 0x99, 0x56, 0x5a,
+	0xad, 0xa7, 0xe6, 0x13, 0x3a, 0x26, 0xbd, 0x85, 0x0b, 0x83, 0x90, 0x30, 0x8f, 0x3f, 0x81, 0x09,
+	0x93, 0xf8, 0xc8, 0x85, 0x72, 0x9d, 0xbb, 0x33, 0x26, 0x38, 0x58, 0x76, 0x85, 0x78, 0x4e, 0x88,
+	0x8f, 0xc0, 0xff, 0x87, 0x13, 0x12, 0x90, 0x7c, 0x47, 0x4e, 0x78, 0xc9, 0x7c, 0x10, 0x1f, 0xee,
+	0x3e, 0x1f, 0x1c, 0x87, 0xbc, 0xbb, 0x5a, 0xa8, 0xea, 0xbc, 0x3c, 0xc9, 0x96, 0x4b, 0x56, 0x2b,
+	0x13, 0x74, 0x7d, 0x47, 0x56, 0x7e, 0x02, 0xa7, 0xc9, 0x7d, 0x53, 0x82, 0x7d, 0xd1, 0x9b, 0x2b,
+	0x21, 0xee, 0xe6, 0x4a, 0x82, 0x33, 0x7c, 0x49, 0xec, 0xfe, 0xe6, 0x29, 0x5c, 0x88, 0xcb, 0x04,
+	0xbd, 0x1d, 0xa3, 0xdd, 0xb0, 0x6c, 0x43, 0x0f, 0x28, 0xa5, 0x97, 0x26, 0x26, 0x7e, 0xad, 0x59,
+	0xce, 0xce, 0xce, 0x94, 0x92, 0x51, 0x99, 0x0d, 0x4a, 0x0d, 0xb8, 0x38, 0x50, 0x20, 0xf3, 0x9c,
+	0x08, 0x93, 0x21, 0x59, 0xfd, 0xe7, 0xe0, 0x14, 0x8e, 0x04, 0xa7, 0x50, 0xda, 0x86, 0x8b, 0xb1,
+	0x86, 0xc5, 0xa3, 0x56, 0xc9, 0x14, 0xaa, 0x21, 0x57, 0xb1, 0x51, 0xe6, 0xaa, 0xcb, 0x70, 0x69,
+	0xb0, 0x44, 0xe6, 0xb2, 0x7f, 0x09, 0xb0, 0x10, 0xa1, 0x23, 0x97, 0x31, 0x68, 0x16, 0x46, 0xd8,
+	0x06, 0x9d, 0x93, 0x47, 0x34, 0x15, 0x6d, 0xc1, 0x28, 0xf9, 0x80, 0xa7, 0x27, 0xcb, 0x6b, 0xbc,
+	0xc0, 0x88, 0x97, 0x56, 0x74, 0x3e, 0xe2, 0x65, 0x22, 0x20, 0xb0, 0xff, 0xe7, 0x02, 0xfb, 0x3f,
+	0x3a, 0x09, 0xb0, 0x4b, 0xf6, 0x23, 0xb5, 0xae, 0xd8, 0xe4, 0x04, 0x94, 0x93, 0xf3, 0x6c, 0xa4,
+	0x64, 0x4b, 0x97, 0x61, 0xd4, 0x91, 0xe3, 0xdc, 0xc6, 0x96, 0xe5, 0x8a, 0x73, 0xfb, 0x4a, 0x6e,
+	0x66, 0x9f, 0x6d, 0x6f, 0x96, 0xc8, 0xcd, 0xac, 0x77, 0x4b, 0x3b, 0x22, 0x3d, 0x03, 0x29, 0x76,
+	0xee, 0x08, 0x1c, 0xcb, 0x97, 0x8b, 0x68, 0x20, 0xf4, 0x4d, 0x9d, 0x20, 0xcf, 0x55, 0xd5, 0x39,
+	0xab, 0xb6, 0xb4, 0xb6, 0x66, 0xb3, 0x13, 0x29, 0x7d, 0x90, 0xfe, 0x21, 0xc0, 0xd9, 0x44, 0xb9,
+	0x2c, 0x1e, 0xee, 0xc3, 0x38, 0xb9, 0xe9, 0x72, 0x17, 0x52, 0x31, 0x9b, 0xbf, 0x64, 0xc6, 0xed,
+	0xc4, 0x8e, 0xd1, 0x52, 0xb1, 0xe5, 0xbb, 0x32, 0x9b, 0xa4, 0x03, 0x55, 0xd5, 0x79, 0xc9, 0x6e,
+	0xd5, 0x98, 0x2b, 0x73, 0xf2, 0x24, 0x1d, 0xa0, 0x2f, 0x3b, 0x4e, 0x18, 0xa8, 0xce, 0x4b, 0xea,
+	0xca, 0x49, 0x3a, 0x40, 0xbe, 0x01, 0xcf, 0xc6, 0xc7, 0x48, 0xd0, 0x3d, 0xe7, 0x61, 0xd6, 0x9d,
+	0x8f, 0x60, 0xc4, 0xb1, 0x51, 0x16, 0x71, 0x17, 0xe0, 0x5c, 0xb2, 0x34, 0x16, 0x6d, 0xff, 0x16,
+	0x20, 0xff, 0xc0, 0xd0, 0xf4, 0x1d, 0x72, 0xec, 0x8f, 0xff, 0x18, 0x58, 0x80, 0x71, 0xb2, 0xf2,
+	0x7b, 0xcc, 0x5a, 0xf6, 0xe4, 0xcc, 0x54, 0x5b, 0x79, 0x5b, 0xef, 0x5a, 0xd8, 0x22, 0xa6, 0x8e,
+	0xc9, 0x13, 0x6d, 0xe5, 0xed, 0x33, 0x0b, 0x5b, 0x4e, 0xea, 0x26, 0xc3, 0xa3, 0x34, 0x75, 0x3b,
+	0xbf, 0xd1, 0xc7, 0x30, 0xab, 0x1b, 0x2a, 0xf6, 0x9d, 0xea, 0xc7, 0x12, 0xbf, 0xa1, 0x66, 0xf4,
+	0xc0, 0xcd, 0xb6, 0x2f, 0xf3, 0x8c, 0x67, 0xcb, 0x3c, 0xd2, 0x67, 0xb0, 0x40, 0x73, 0x6a, 0xdf,
+	0x52, 0xd7, 0x9b, 0xf7, 0x00, 0x5e, 0x1a, 0x9a, 0x5e, 0xf7, 0xac, 0x9e, 0x5a, 0x7b, 0x97, 0x17,
+	0x17, 0x1e, 0x77, 0xfe, 0xa5, 0xfb, 0x53, 0xfa, 0x1c, 0x16, 0x23, 0xb2, 0x59, 0xc0, 0x1d, 0x5c,
+	0xf8, 0xfb, 0xf0, 0x0e, 0xf9, 0xb4, 0x8d, 0xe0, 0x8e, 0x9d, 0x28, 0xc7, 0xce, 0x30, 0xf9, 0xa1,
+	0x41, 0x59, 0x84, 0x77, 0x9c, 0x45, 0xd6, 0x7f, 0xd7, 0xaf, 0x02, 0x7e, 0x1f, 0x16, 0xc2, 0x2f,
+	0x98, 0xd2, 0x0d, 0x98, 0xf2, 0x94, 0xba, 0xab, 0x2e, 0x85, 0x56, 0xe8, 0x6b, 0xb5, 0xa4, 0x07,
+	0xb0, 0x58, 0x36, 0x74, 0xab, 0xdb, 0xc6, 0xe9, 0x7c, 0x40, 0xb6, 0x8f, 0xa6, 0xef, 0x3e, 0x3b,
+	0x2f, 0x4f, 0x90, 0xe7, 0xaa, 0x2a, 0xfd, 0x5e, 0x80, 0x42, 0x54, 0xd8, 0x61, 0x79, 0xc8, 0x1f,
+	0xa0, 0x23, 0x19, 0x03, 0xb4, 0x08, 0x0b, 0xf4, 0x24, 0x90, 0x72, 0xa2, 0x3f, 0x87, 0xc5, 0x08,
+	0xfd, 0xa1, 0xcd, 0xf4, 0x5d, 0x58, 0x20, 0x5b, 0x47, 0x64, 0xaa, 0xd3, 0x1e, 0x0c, 0x96, 0x60,
+	0x31, 0x22, 0x80, 0x6d, 0x37, 0x0f, 0x21, 0x5f, 0x2e, 0x3d, 0x30, 0xba, 0xa6, 0xae, 0xb4, 0x7c,
+	0xe9, 0x2c, 0x4f, 0xd2, 0x19, 0x82, 0x51, 0x07, 0x1a, 0x99, 0xb6, 0x69, 0x99, 0xfc, 0x0e, 0x24,
+	0xf1, 0x5c, 0x30, 0x89, 0x4b, 0x17, 0xd9, 0xea, 0xe8, 0x4b, 0x74, 0x71, 0x86, 0x04, 0x4b, 0xcf,
+	0x60, 0x21, 0x4c, 0xc8, 0xbc, 0x75, 0x0b, 0x26, 0x5e, 0xd2, 0xa1, 0x41, 0xae, 0xf2, 0x78, 0x5d,
+	0x0e, 0x49, 0x86, 0xf9, 0x1a, 0xb6, 0x23, 0xda, 0x0f, 0x24, 0xb3, 0x06, 0xc7, 0x82, 0x32, 0x0f,
+	0x03, 0xe8, 0x73, 0x18, 0x7b, 0x84, 0x15, 0x0b, 0x3b, 0x1e, 0xd6, 0x95, 0x36, 0x66, 0xae, 0x21,
+	0xbf, 0x9d, 0xa4, 0xb4, 0xef, 0xa4, 0x2f, 0xd3, 0x5b, 0x31, 0x93, 0x74, 0x80, 0x66, 0xff, 0xfe,
+	0x05, 0xbf, 0xcd, 0x26, 0x20, 0xcf, 0x46, 0x4a, 0xb6, 0xf4, 0x06, 0xe6, 0x4b, 0xbb, 0x5f, 0x76,
+	0x35, 0x13, 0x13, 0xf9, 0xae, 0x07, 0x32, 0xab, 0x99, 0x83, 0x9c, 0x6e, 0xbc, 0x61, 0xf2, 0x9d,
+	0x9f, 0x21, 0xc5, 0xa3, 0x61, 0xc5, 0x0f, 0xe1, 0x58, 0x50, 0x31, 0x73, 0xd3, 0x35, 0x18, 0x6b,
+	0x39, 0x03, 0xcc, 0x49, 0x27, 0x79, 0x4e, 0xa2, 0x5c, 0x94, 0x56, 0xba, 0x0f, 0xf3, 0x32, 0x26,
+	0x3f, 0x0f, 0x64, 0x85, 0x03, 0x2a, 0x28, 0xe7, 0x20, 0xa0, 0x7e, 0x2b, 0x00, 0x92, 0xf1, 0x6b,
+	0xe3, 0x15, 0x56, 0xcb, 0xd8, 0xb4, 0xb5, 0x3d, 0x6d, 0x57, 0xb1, 0x31, 0x3a, 0x0b, 0x33, 0xc1,
+	0x6b, 0x7a, 0x8a, 0x6e, 0xda, 0xf2, 0x5f, 0xd1, 0x07, 0xee, 0x9a, 0x47, 0x42, 0xd7, 0xe1, 0xc9,
+	0x53, 0xea, 0xbc, 0x36, 0xa9, 0x5a, 0x9f, 0xe3, 0xd9, 0x08, 0x99, 0xf1, 0x02, 0x45, 0xe5, 0x03,
+	0xe5, 0x3a, 0xec, 0x73, 0x98, 0x77, 0x59, 0x77, 0xbd, 0xb7, 0xcc, 0xea, 0xcb, 0xfc, 0xd3, 0x56,
+	0xd8, 0x48, 0x19, 0x99, 0x91, 0x31, 0xe9, 0x2d, 0x2c, 0xc5, 0x28, 0x66, 0x1e, 0xfe, 0x4e, 0x35,
+	0x57, 0xfa, 0xf7, 0xcb, 0x11, 0x72, 0x66, 0x78, 0x9a, 0x49, 0x91, 0x7e, 0x0c, 0xa7, 0xb9, 0x62,
+	0xfe, 0x17, 0x66, 0x9c, 0x71, 0x2f, 0x6d, 0xc3, 0x6f, 0xfa, 0x99, 0xfc, 0x27, 0xfd, 0x7b, 0xbb,
+	0x18, 0x12, 0x06, 0xf1, 0x0b, 0x38, 0x16, 0x03, 0xd1, 0x4d, 0xee, 0x59, 0x30, 0xce, 0x47, 0x31,
+	0xfa, 0xbf, 0x4e, 0x79, 0x28, 0xb3, 0x7f, 0x9d, 0x72, 0x8d, 0x91, 0xbe, 0x11, 0x60, 0xda, 0xbb,
+	0xb7, 0x2c, 0x97, 0x0e, 0x61, 0x75, 0xf9, 0x8f, 0x1f, 0xb9, 0xc0, 0xf1, 0x63, 0xd0, 0x96, 0xb6,
+	0xe7, 0xd6, 0xd9, 0xfd, 0x88, 0x5c, 0xa3, 0xab, 0x30, 0xe3, 0x5d, 0xc8, 0xd6, 0x77, 0x15, 0x16,
+	0x13, 0xe7, 0xb8, 0x1d, 0x35, 0x7e, 0x19, 0xd3, 0x1e, 0x6b, 0x59, 0x91, 0x9a, 0x6e, 0x09, 0x3e,
+	0xa8, 0x87, 0xcd, 0xef, 0x21, 0x2a, 0xba, 0x41, 0xcb, 0xe2, 0x7e, 0x8a, 0xc0, 0x47, 0x5e, 0xd3,
+	0x57, 0x1b, 0xf0, 0x9d, 0xd2, 0xf6, 0x61, 0x29, 0x86, 0x8d, 0xc1, 0x7b, 0x08, 0xb3, 0x01, 0x78,
+	0x6e, 0xe0, 0xa5, 0xc3, 0x37, 0xe3, 0xc7, 0x67, 0x49, 0x1b, 0xb0, 0x44, 0x42, 0x24, 0x16, 0x61,
+	0xca, 0x30, 0x3b, 0x01, 0x62, 0x9c, 0x0c, 0x16, 0x60, 0x7f, 0x1f, 0x01, 0xa8, 0x5a, 0x56, 0x17,
+	0xab, 0xb5, 0x4f, 0xab, 0x9b, 0x91, 0x03, 0xcf, 0xad, 0xc0, 0xf7, 0xfb, 0x45, 0x9e, 0x0d, 0x9e,
+	0x04, 0xff, 0x37, 0x7b, 0x20, 0x0c, 0x73, 0xd1, 0x30, 0xec, 0x7f, 0xd0, 0x8f, 0x06, 0x3f, 0xe8,
+	0xfd, 0xae, 0x1f, 0x0b, 0x46, 0xe8, 0xbb, 0x30, 0xad, 0x74, 0xed, 0x7d, 0xc3, 0xd4, 0x6c, 0xc2,
+	0x39, 0x4e, 0x5e, 0x4f, 0xf5, 0xc7, 0x68, 0x10, 0x3b, 0x85, 0x5f, 0xe6, 0x92, 0x09, 0x1a, 0xc4,
+	0xba, 0x61, 0xb3, 0x2e, 0x85, 0xe3, 0x90, 0xf7, 0xea, 0xc2, 0x93, 0xf4, 0xbc, 0xa6, 0xbb, 0x35,
+	0xe1, 0x1b, 0xec, 0xae, 0x60, 0x06, 0xf2, 0x2f, 0x6e, 0xac, 0x7e, 0x58, 0x77, 0x2c, 0xa2, 0x55,
+	0x2b, 0xf2, 0x58, 0x2e, 0xd1, 0x11, 0xc1, 0x69, 0xf4, 0x7a, 0xf0, 0x7c, 0x87, 0x3e, 0x8d, 0x38,
+	0xfd, 0x47, 0x34, 0x60, 0x3d, 0x3f, 0x78, 0xfd, 0x47, 0x53, 0x1a, 0x19, 0xac, 0x5b, 0xaf, 0xfb,
+	0x55, 0x26, 0x69, 0xb0, 0x1f, 0x65, 0xa0, 0x6c, 0xb5, 0xd7, 0x1a, 0xe9, 0x15, 0x8a, 0xca, 0xef,
+	0x77, 0xda, 0x1c, 0x82, 0x82, 0x6f, 0x47, 0xe8, 0x27, 0x92, 0xf7, 0x3a, 0x54, 0x27, 0x0b, 0x16,
+	0x86, 0xb3, 0xd4, 0xa7, 0x6e, 0xc3, 0x94, 0x53, 0xe2, 0xf1, 0x7f, 0xf0, 0x0c, 0x62, 0xcf, 0x37,
+	0x7a, 0x25, 0x6f, 0xbe, 0x99, 0x75, 0xfe, 0x42, 0x3e, 0xb3, 0x98, 0x76, 0x67, 0x9c, 0x85, 0x19,
+	0x46, 0xc2, 0xa6, 0x9c, 0xee, 0x5b, 0x8c, 0x2f, 0xb6, 0x37, 0x65, 0x6c, 0xa8, 0x8a, 0xcc, 0x5f,
+	0x04, 0x58, 0x8c, 0x38, 0x89, 0xcd, 0x42, 0x05, 0xa6, 0x7d, 0xb3, 0xe0, 0xae, 0xf9, 0x34, 0xd3,
+	0x30, 0xe5, 0x4d, 0xc3, 0xe1, 0x14, 0x8e, 0xee, 0xb1, 0x6f, 0x9b, 0x98, 0xb9, 0x4c, 0xb9, 0x63,
+	0x88, 0x50, 0x88, 0x4a, 0xa0, 0x86, 0xae, 0xfd, 0xfc, 0x1a, 0xe4, 0x37, 0x15, 0x5b, 0xa9, 0x39,
+	0x10, 0x90, 0x06, 0xd3, 0xfe, 0xf6, 0x61, 0x74, 0x85, 0x7b, 0xe4, 0x8f, 0x76, 0x2a, 0x8b, 0xcb,
+	0xe9, 0x88, 0x99, 0x87, 0xf7, 0x60, 0xca, 0xd7, 0x25, 0x8c, 0xb8, 0x79, 0x3c, 0xda, 0x88, 0x2c,
+	0x5e, 0x49, 0x45, 0xeb, 0xe9, 0xf1, 0xb5, 0x0c, 0xf3, 0xf5, 0x44, 0xbb, 0x8d, 0xc5, 0x2b, 0xa9,
+	0x68, 0x99, 0x1e, 0x0d, 0xa6, 0xfd, 0xed, 0xc0, 0x7c, 0xd7, 0xc5, 0x74, 0x1e, 0x8b, 0xcb, 0xe9,
+	0x88, 0x99, 0xaa, 0x1f, 0x42, 0xbe, 0xdf, 0xf1, 0x8b, 0x2e, 0xf1, 0x58, 0xc3, 0x6d, 0xc5, 0xe2,
+	0x7b, 0x29, 0x28, 0x3d, 0x63, 0xfc, 0xbd, 0xbc, 0x7c, 0x63, 0x62, 0xda, 0x86, 0xc5, 0xe5, 0x74,
+	0xc4, 0x9e, 0x2a, 0x7f, 0xe3, 0x2c, 0x5f, 0x55, 0x4c, 0xcb, 0xae, 0xb8, 0x9c, 0x8e, 0xd8, 0x0b,
+	0x05, 0x5f, 0xe3, 0x2b, 0x3f, 0x14, 0xa2, 0x2d, 0xb8, 0xe2, 0x95, 0x54, 0xb4, 0x4c, 0xcf, 0x8f,
+	0x00, 0x45, 0x5b, 0x0e, 0xd1, 0xd5, 0xe4, 0xe5, 0x11, 0xd3, 0xc1, 0x23, 0xae, 0x65, 0x61, 0x61,
+	0xca, 0xdf, 0xc2, 0xd1, 0x48, 0xa3, 0x21, 0x5a, 0x4d, 0x5c, 0x31, 0x71, 0xaa, 0xaf, 0x66, 0xe0,
+	0xf0, 0x34, 0x47, 0x5a, 0xd4, 0xf8, 0x9a, 0x79, 0xfd, 0x8b, 0xe2, 0xd5, 0x0c, 0x1c, 0x9e, 0xc3,
+	0xa3, 0xad, 0x5f, 0x7c, 0x87, 0x73, 0x9b, 0xd6, 0xc4, 0xb5, 0x2c, 0x2c, 0x4c, 0xf9, 0xcf, 0x04,
+	0x78, 0x27, 0xb6, 0xab, 0x0b, 0x5d, 0x4f, 0x58, 0x70, 0xdc, 0xce, 0x32, 0xf1, 0x46, 0x46, 0x2e,
+	0xcf, 0x07, 0xd1, 0x86, 0x2e, 0xbe, 0x0f, 0xb8, 0x6d, 0x63, 0xe2, 0x5a, 0x16, 0x16, 0xa6, 0xbc,
+	0x4b, 0xfe, 0x17, 0x43, 0xb0, 0x2f, 0x7c, 0x25, 0xc1, 0x8e, 0xb8, 0x26, 0x66, 0x71, 0x35, 0x3d,
+	0x83, 0xa7, 0x76, 0x2b, 0xb5, 0xda, 0xad, 0xac, 0x6a, 0xb9, 0xed, 0xdc, 0x2c, 0xd0, 0x83, 0x7a,
+	0x13, 0x03, 0x3d, 0x56, 0xf1, 0xd5, 0x0c, 0x1c, 0x4c, 0xf3, 0x37, 0x82, 0x7b, 0x32, 0x8d, 0x5c,
+	0xed, 0xa2, 0x9b, 0xc9, 0x9b, 0x05, 0xaf, 0x13, 0x43, 0x5c, 0xcf, 0xcc, 0xc7, 0xc0, 0xfc, 0x42,
+	0x60, 0x97, 0x9c, 0x51, 0x2c, 0x37, 0x12, 0x77, 0x0f, 0x2e, 0x94, 0x9b, 0x59, 0xd9, 0x7c, 0x6e,
+	0xe1, 0xb4, 0xff, 0xf0, 0xdd, 0x92, 0xdc, 0x4f, 0x26, 0xae, 0x67, 0xe6, 0xf3, 0x81, 0xe1, 0xb4,
+	0xd5, 0xf0, 0xc1, 0x24, 0x77, 0x06, 0x89, 0xeb, 0x99, 0xf9, 0x7c, 0x60, 0x38, 0xcd, 0x34, 0x7c,
+	0x30, 0xc9, 0xad, 0x3b, 0xe2, 0x7a, 0x66, 0x3e, 0x06, 0xe6, 0x4f, 0x02, 0x9c, 0x4a, 0x6e, 0x37,
+	0x41, 0x1f, 0x27, 0xb6, 0x1a, 0x0c, 0x6a, 0x98, 0x11, 0xef, 0x0c, 0xcb, 0x1e, 0x46, 0xc8, 0xed,
+	0x05, 0x19, 0x80, 0x70, 0x50, 0x37, 0x8b, 0x78, 0x67, 0x58, 0xf6, 0x30, 0x42, 0x6e, 0x1f, 0xc7,
+	0x00, 0x84, 0x83, 0x7a, 0x4d, 0xc4, 0x3b, 0xc3, 0xb2, 0x33, 0x84, 0xbf, 0x12, 0xd8, 0xe7, 0x46,
+	0x1c, 0xb6, 0xf5, 0xc4, 0x73, 0x54, 0x02, 0xaa, 0x0f, 0xb2, 0x33, 0x32, 0x3c, 0xdf, 0x72, 0x7a,
+	0x03, 0x7d, 0x9d, 0x10, 0xe8, 0x4e, 0x96, 0xc5, 0x1e, 0x6d, 0xca, 0x10, 0xef, 0x0e, 0xcd, 0xcf,
+	0x40, 0xfe, 0x55, 0xe0, 0xb4, 0xb6, 0xf8, 0x51, 0xde, 0xcd, 0xe4, 0x83, 0x18, 0x98, 0xf7, 0x86,
+	0x17, 0xc0, 0x70, 0xfe, 0x4e, 0x80, 0xe3, 0x09, 0x9d, 0x0f, 0xe8, 0xa3, 0x4c, 0x8e, 0x08, 0xf4,
+	0x19, 0x88, 0xb7, 0x86, 0xe2, 0x65, 0xc0, 0xfe, 0x20, 0xc0, 0x89, 0xa4, 0xf6, 0x03, 0x74, 0x2b,
+	0x9b, 0xed, 0x41, 0x68, 0xb7, 0x87, 0x63, 0x66, 0xd8, 0x4c, 0x38, 0x12, 0x2a, 0xd8, 0xa3, 0x62,
+	0x72, 0xd2, 0x0d, 0x17, 0x65, 0xc5, 0x95, 0xd4, 0xf4, 0x4c, 0xa7, 0x01, 0xb3, 0xc1, 0xc2, 0x3c,
+	0x7a, 0x3f, 0x31, 0xb9, 0x46, 0x34, 0x16, 0xd3, 0x92, 0x7b, 0x0a, 0x83, 0x45, 0x79, 0xbe, 0xc2,
+	0xd8, 0xaa, 0xbe, 0x58, 0x4c, 0x4b, 0xee, 0x1d, 0xfe, 0xc2, 0xa5, 0x75, 0xfe, 0xe1, 0x8f, 0x53,
+	0xd1, 0x17, 0x57, 0xd3, 0x33, 0x78, 0x93, 0x19, 0x2a, 0x84, 0xf3, 0x27, 0x33, 0xbe, 0xc2, 0x2e,
+	0xae, 0xa4, 0xa6, 0xf7, 0x74, 0x86, 0xca, 0xdb, 0x7c, 0x9d, 0xf1, 0x85, 0x74, 0x71, 0x25, 0x35,
+	0x7d, 0x28, 0x80, 0xbc, 0xe2, 0x79, 0x72, 0x00, 0x85, 0x8b, 0xd2, 0x62, 0x31, 0x2d, 0xb9, 0x77,
+	0x11, 0xe0, 0xaf, 0x43, 0xf3, 0x2f, 0x02, 0x62, 0x2a, 0xe0, 0xe2, 0x72, 0x3a, 0x62, 0x4f, 0x95,
+	0xbf, 0x96, 0x9b, 0x70, 0xbd, 0x11, 0x2d, 0x35, 0x8b, 0xcb, 0xe9, 0x88, 0x3d, 0x55, 0xfe, 0x0a,
+	0x2d, 0x5f, 0x55, 0x4c, 0x3d, 0x58, 0x5c, 0x4e, 0x47, 0xec, 0x7d, 0x96, 0x44, 0xea, 0x95, 0xfc,
+	0xcf, 0x12, 0x5e, 0x4d, 0x55, 0xbc, 0x9a, 0x81, 0xc3, 0x77, 0xca, 0xe4, 0x54, 0x1a, 0xd1, 0xa0,
+	0x33, 0x3d, 0xa7, 0xc2, 0x29, 0xae, 0x67, 0xe6, 0x8b, 0x7c, 0x0c, 0x84, 0x49, 0x06, 0x7e, 0x0c,
+	0xf0, 0x2a, 0x80, 0xe2, 0x7a, 0x66, 0xbe, 0xe8, 0x61, 0x28, 0x8a, 0x66, 0xd0, 0x61, 0x88, 0x0b,
+	0xe7, 0x83, 0xec, 0x8c, 0xe1, 0xab, 0xa9, 0x40, 0x11, 0x72, 0xc0, 0xd5, 0x54, 0x4c, 0x79, 0x50,
+	0x5c, 0xcb, 0xc2, 0x12, 0xfc, 0x6e, 0xf6, 0xbf, 0x1b, 0xf0, 0xdd, 0x1c, 0x57, 0x27, 0x13, 0xaf,
+	0x66, 0xe0, 0xf0, 0xcc, 0x8e, 0xd6, 0xcc, 0xf8, 0x66, 0x73, 0x6b, 0x74, 0xe2, 0x5a, 0x16, 0x16,
+	0x5f, 0xa2, 0x0a, 0x55, 0x7b, 0xd0, 0x80, 0x7c, 0x1e, 0xa9, 0x3b, 0x89, 0xab, 0xe9, 0x19, 0xbc,
+	0xa4, 0x11, 0xaa, 0x6e, 0xa0, 0xc4, 0x14, 0x1b, 0xad, 0x2f, 0x88, 0x2b, 0xa9, 0xe9, 0x3d, 0x53,
+	0xc3, 0x95, 0x06, 0x94, 0x9c, 0x79, 0x62, 0xb4, 0xae, 0xa6, 0x67, 0x60, 0x6a, 0x3f, 0x83, 0x7c,
+	0xd9, 0xd0, 0xf7, 0xb4, 0x66, 0xd7, 0xc4, 0xe8, 0x7c, 0xb0, 0x07, 0x8e, 0xfd, 0xf9, 0x95, 0xfe,
+	0x7b, 0x57, 0xcb, 0x85, 0x41, 0x64, 0xfd, 0x4b, 0xe3, 0x99, 0x2d, 0x6c, 0x6f, 0x93, 0xd7, 0x55,
+	0x7d, 0xcf, 0x40, 0xef, 0xc5, 0x32, 0x06, 0x68, 0x5c, 0x1d, 0x97, 0xd3, 0x90, 0x52, 0x3d, 0x1b,
+	0x37, 0x3f, 0xbb, 0xde, 0xd4, 0xec, 0xfd, 0x6e, 0xc3, 0xa1, 0x5e, 0xa1, 0xf5, 0xb9, 0x15, 0xfa,
+	0xd7, 0x62, 0x48, 0x51, 0x8d, 0xfd, 0xa6, 0x4e, 0x59, 0xe9, 0x3b, 0xa5, 0x31, 0x4e, 0xde, 0x5e,
+	0xfb, 0xef, 0x00, 0x1d, 0x21, 0x38, 0xa3, 0xc5, 0x46, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// DataStoreClient is the client API for DataStore service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type DataStoreClient interface {
+	// Creates a bundle
+	CreateBundle(ctx context.Context, in *CreateBundleRequest, opts ...grpc.CallOption) (*CreateBundleResponse, error)
+	// Fetches a specific bundle
+	FetchBundle(ctx context.Context, in *FetchBundleRequest, opts ...grpc.CallOption) (*FetchBundleResponse, error)
+	// Lists bundles (optionally filtered)
+	ListBundles(ctx context.Context, in *ListBundlesRequest, opts ...grpc.CallOption) (*ListBundlesResponse, error)
+	// Updates a specific bundle
+	UpdateBundle(ctx context.Context, in *UpdateBundleRequest, opts ...grpc.CallOption) (*UpdateBundleResponse, error)
+	// Sets bundle contents (creates if it does not exist)
+	SetBundle(ctx context.Context, in *SetBundleRequest, opts ...grpc.CallOption) (*SetBundleResponse, error)
+	// Appends contents from a specific bundle (creates if it does not exist)
+	AppendBundle(ctx context.Context, in *AppendBundleRequest, opts ...grpc.CallOption) (*AppendBundleResponse, error)
+	// Deletes a specific bundle
+	DeleteBundle(ctx context.Context, in *DeleteBundleRequest, opts ...grpc.CallOption) (*DeleteBundleResponse, error)
+	// Prunes all expired certificates and JWT signing keys from a bundle
+	PruneBundle(ctx context.Context, in *PruneBundleRequest, opts ...grpc.CallOption) (*PruneBundleResponse, error)
+	// Creates an attested node
+	CreateAttestedNode(ctx context.Context, in *CreateAttestedNodeRequest, opts ...grpc.CallOption) (*CreateAttestedNodeResponse, error)
+	// Fetches a specific attested node
+	FetchAttestedNode(ctx context.Context, in *FetchAttestedNodeRequest, opts ...grpc.CallOption) (*FetchAttestedNodeResponse, error)
+	// Lists attested nodes (optionally filtered)
+	ListAttestedNodes(ctx context.Context, in *ListAttestedNodesRequest, opts ...grpc.CallOption) (*ListAttestedNodesResponse, error)
+	// Updates a specific attested node
+	UpdateAttestedNode(ctx context.Context, in *UpdateAttestedNodeRequest, opts ...grpc.CallOption) (*UpdateAttestedNodeResponse, error)
+	// Bans or unbans a specific attested node
+	SetAttestedNodeBanned(ctx context.Context, in *SetAttestedNodeBannedRequest, opts ...grpc.CallOption) (*SetAttestedNodeBannedResponse, error)
+	// Deletes a specific attested node
+	DeleteAttestedNode(ctx context.Context, in *DeleteAttestedNodeRequest, opts ...grpc.CallOption) (*DeleteAttestedNodeResponse, error)
+	// Sets the set of selectors for a specific node id
+	SetNodeSelectors(ctx context.Context, in *SetNodeSelectorsRequest, opts ...grpc.CallOption) (*SetNodeSelectorsResponse, error)
+	// Gets the set of node selectors for a specific node id
+	GetNodeSelectors(ctx context.Context, in *GetNodeSelectorsRequest, opts ...grpc.CallOption) (*GetNodeSelectorsResponse, error)
+	// Lists the node selectors for all nodes, or for the nodes whose
+	// selectors changed after a change event
+	ListNodeSelectors(ctx context.Context, in *ListNodeSelectorsRequest, opts ...grpc.CallOption) (*ListNodeSelectorsResponse, error)
+	// Creates a registration entry
+	CreateRegistrationEntry(ctx context.Context, in *CreateRegistrationEntryRequest, opts ...grpc.CallOption) (*CreateRegistrationEntryResponse, error)
+	// Fetches a specific registration entry
+	FetchRegistrationEntry(ctx context.Context, in *FetchRegistrationEntryRequest, opts ...grpc.CallOption) (*FetchRegistrationEntryResponse, error)
+	// Lists registration entries (optionally filtered)
+	ListRegistrationEntries(ctx context.Context, in *ListRegistrationEntriesRequest, opts ...grpc.CallOption) (*ListRegistrationEntriesResponse, error)
+	// Updates a specific registration entry
+	UpdateRegistrationEntry(ctx context.Context, in *UpdateRegistrationEntryRequest, opts ...grpc.CallOption) (*UpdateRegistrationEntryResponse, error)
+	// Deletes a specific registration entry
+	DeleteRegistrationEntry(ctx context.Context, in *DeleteRegistrationEntryRequest, opts ...grpc.CallOption) (*DeleteRegistrationEntryResponse, error)
+	// Creates registration entries in a single transaction. Entries
+	// identical to an existing one (same SPIFFE ID, parent ID and
+	// selectors) are not created.
+	BatchCreateRegistrationEntries(ctx context.Context, in *BatchCreateRegistrationEntriesRequest, opts ...grpc.CallOption) (*BatchCreateRegistrationEntriesResponse, error)
+	// Updates registration entries in a single transaction
+	BatchUpdateRegistrationEntries(ctx context.Context, in *BatchUpdateRegistrationEntriesRequest, opts ...grpc.CallOption) (*BatchUpdateRegistrationEntriesResponse, error)
+	// Deletes registration entries in a single transaction
+	BatchDeleteRegistrationEntries(ctx context.Context, in *BatchDeleteRegistrationEntriesRequest, opts ...grpc.CallOption) (*BatchDeleteRegistrationEntriesResponse, error)
+	// Prunes all registration entries that expire before the specified timestamp
+	PruneRegistrationEntries(ctx context.Context, in *PruneRegistrationEntriesRequest, opts ...grpc.CallOption) (*PruneRegistrationEntriesResponse, error)
+	// Lists the IDs of registration entries deleted after a revision, along
+	// with the current revision
+	ListRegistrationEntryTombstones(ctx context.Context, in *ListRegistrationEntryTombstonesRequest, opts ...grpc.CallOption) (*ListRegistrationEntryTombstonesResponse, error)
+	// Prunes all registration entry tombstones older than the specified timestamp
+	PruneRegistrationEntryTombstones(ctx context.Context, in *PruneRegistrationEntryTombstonesRequest, opts ...grpc.CallOption) (*PruneRegistrationEntryTombstonesResponse, error)
+	// Lists registration entry change events recorded after an event ID
+	ListRegistrationEntryEvents(ctx context.Context, in *ListRegistrationEntryEventsRequest, opts ...grpc.CallOption) (*ListRegistrationEntryEventsResponse, error)
+	// Prunes all registration entry events older than the specified timestamp
+	PruneRegistrationEntryEvents(ctx context.Context, in *PruneRegistrationEntryEventsRequest, opts ...grpc.CallOption) (*PruneRegistrationEntryEventsResponse, error)
+	// Creates a join token
+	CreateJoinToken(ctx context.Context, in *CreateJoinTokenRequest, opts ...grpc.CallOption) (*CreateJoinTokenResponse, error)
+	// Fetches a specific join token
+	FetchJoinToken(ctx context.Context, in *FetchJoinTokenRequest, opts ...grpc.CallOption) (*FetchJoinTokenResponse, error)
+	// Lists all join tokens
+	ListJoinTokens(ctx context.Context, in *ListJoinTokensRequest, opts ...grpc.CallOption) (*ListJoinTokensResponse, error)
+	// Uses a specific join token once, creating its registration entries and
+	// deleting it when it has no uses left
+	ConsumeJoinToken(ctx context.Context, in *ConsumeJoinTokenRequest, opts ...grpc.CallOption) (*ConsumeJoinTokenResponse, error)
+	// Delete a specific join token
+	DeleteJoinToken(ctx context.Context, in *DeleteJoinTokenRequest, opts ...grpc.CallOption) (*DeleteJoinTokenResponse, error)
+	// Prunes all join tokens that expire before the specified timestamp
+	PruneJoinTokens(ctx context.Context, in *PruneJoinTokensRequest, opts ...grpc.CallOption) (*PruneJoinTokensResponse, error)
+	// Fetches a specific CA journal
+	FetchCAJournal(ctx context.Context, in *FetchCAJournalRequest, opts ...grpc.CallOption) (*FetchCAJournalResponse, error)
+	// Sets a CA journal if the revision matches the stored revision
+	SetCAJournal(ctx context.Context, in *SetCAJournalRequest, opts ...grpc.CallOption) (*SetCAJournalResponse, error)
+	// Acquires or renews a lease
+	AcquireLease(ctx context.Context, in *AcquireLeaseRequest, opts ...grpc.CallOption) (*AcquireLeaseResponse, error)
+	// Releases a lease held by the requested holder
+	ReleaseLease(ctx context.Context, in *ReleaseLeaseRequest, opts ...grpc.CallOption) (*ReleaseLeaseResponse, error)
+	// Revokes a certificate
+	RevokeCertificate(ctx context.Context, in *RevokeCertificateRequest, opts ...grpc.CallOption) (*RevokeCertificateResponse, error)
+	// Fetches a specific revoked certificate
+	FetchRevokedCertificate(ctx context.Context, in *FetchRevokedCertificateRequest, opts ...grpc.CallOption) (*FetchRevokedCertificateResponse, error)
+	// Lists revoked certificates
+	ListRevokedCertificates(ctx context.Context, in *ListRevokedCertificatesRequest, opts ...grpc.CallOption) (*ListRevokedCertificatesResponse, error)
+	// Prunes all revoked certificates that expire before the specified timestamp
+	PruneRevokedCertificates(ctx context.Context, in *PruneRevokedCertificatesRequest, opts ...grpc.CallOption) (*PruneRevokedCertificatesResponse, error)
+	// Records a downstream CA issued through an agent
+	CreateDownstreamCA(ctx context.Context, in *CreateDownstreamCARequest, opts ...grpc.CallOption) (*CreateDownstreamCAResponse, error)
+	// Lists downstream CAs (optionally filtered)
+	ListDownstreamCAs(ctx context.Context, in *ListDownstreamCAsRequest, opts ...grpc.CallOption) (*ListDownstreamCAsResponse, error)
+	// Prunes all downstream CAs that expire before the specified timestamp
+	PruneDownstreamCAs(ctx context.Context, in *PruneDownstreamCAsRequest, opts ...grpc.CallOption) (*PruneDownstreamCAsResponse, error)
+	// Records an issued SVID
+	CreateIssuedSVID(ctx context.Context, in *CreateIssuedSVIDRequest, opts ...grpc.CallOption) (*CreateIssuedSVIDResponse, error)
+	// Lists issued SVIDs (optionally filtered)
+	ListIssuedSVIDs(ctx context.Context, in *ListIssuedSVIDsRequest, opts ...grpc.CallOption) (*ListIssuedSVIDsResponse, error)
+	// Prunes records of issued SVIDs that expire before the specified timestamp
+	PruneIssuedSVIDs(ctx context.Context, in *PruneIssuedSVIDsRequest, opts ...grpc.CallOption) (*PruneIssuedSVIDsResponse, error)
+	// Applies the plugin configuration
+	Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error)
+	// Returns the version and related metadata of the installed plugin
+	GetPluginInfo(ctx context.Context, in *plugin.GetPluginInfoRequest, opts ...grpc.CallOption) (*plugin.GetPluginInfoResponse, error)
+}
+
+type dataStoreClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewDataStoreClient(cc *grpc.ClientConn) DataStoreClient {
+	return &dataStoreClient{cc}
+}
+
+func (c *dataStoreClient) CreateBundle(ctx context.Context, in *CreateBundleRequest, opts ...grpc.CallOption) (*CreateBundleResponse, error) {
+	out := new(CreateBundleResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CreateBundle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) FetchBundle(ctx context.Context, in *FetchBundleRequest, opts ...grpc.CallOption) (*FetchBundleResponse, error) {
+	out := new(FetchBundleResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/FetchBundle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ListBundles(ctx context.Context, in *ListBundlesRequest, opts ...grpc.CallOption) (*ListBundlesResponse, error) {
+	out := new(ListBundlesResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ListBundles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) UpdateBundle(ctx context.Context, in *UpdateBundleRequest, opts ...grpc.CallOption) (*UpdateBundleResponse, error) {
+	out := new(UpdateBundleResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/UpdateBundle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) SetBundle(ctx context.Context, in *SetBundleRequest, opts ...grpc.CallOption) (*SetBundleResponse, error) {
+	out := new(SetBundleResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/SetBundle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) AppendBundle(ctx context.Context, in *AppendBundleRequest, opts ...grpc.CallOption) (*AppendBundleResponse, error) {
+	out := new(AppendBundleResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/AppendBundle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) DeleteBundle(ctx context.Context, in *DeleteBundleRequest, opts ...grpc.CallOption) (*DeleteBundleResponse, error) {
+	out := new(DeleteBundleResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/DeleteBundle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) PruneBundle(ctx context.Context, in *PruneBundleRequest, opts ...grpc.CallOption) (*PruneBundleResponse, error) {
+	out := new(PruneBundleResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/PruneBundle", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) CreateAttestedNode(ctx context.Context, in *CreateAttestedNodeRequest, opts ...grpc.CallOption) (*CreateAttestedNodeResponse, error) {
+	out := new(CreateAttestedNodeResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CreateAttestedNode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) FetchAttestedNode(ctx context.Context, in *FetchAttestedNodeRequest, opts ...grpc.CallOption) (*FetchAttestedNodeResponse, error) {
+	out := new(FetchAttestedNodeResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/FetchAttestedNode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ListAttestedNodes(ctx context.Context, in *ListAttestedNodesRequest, opts ...grpc.CallOption) (*ListAttestedNodesResponse, error) {
+	out := new(ListAttestedNodesResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ListAttestedNodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) UpdateAttestedNode(ctx context.Context, in *UpdateAttestedNodeRequest, opts ...grpc.CallOption) (*UpdateAttestedNodeResponse, error) {
+	out := new(UpdateAttestedNodeResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/UpdateAttestedNode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) SetAttestedNodeBanned(ctx context.Context, in *SetAttestedNodeBannedRequest, opts ...grpc.CallOption) (*SetAttestedNodeBannedResponse, error) {
+	out := new(SetAttestedNodeBannedResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/SetAttestedNodeBanned", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) DeleteAttestedNode(ctx context.Context, in *DeleteAttestedNodeRequest, opts ...grpc.CallOption) (*DeleteAttestedNodeResponse, error) {
+	out := new(DeleteAttestedNodeResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/DeleteAttestedNode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) SetNodeSelectors(ctx context.Context, in *SetNodeSelectorsRequest, opts ...grpc.CallOption) (*SetNodeSelectorsResponse, error) {
+	out := new(SetNodeSelectorsResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/SetNodeSelectors", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) GetNodeSelectors(ctx context.Context, in *GetNodeSelectorsRequest, opts ...grpc.CallOption) (*GetNodeSelectorsResponse, error) {
+	out := new(GetNodeSelectorsResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/GetNodeSelectors", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ListNodeSelectors(ctx context.Context, in *ListNodeSelectorsRequest, opts ...grpc.CallOption) (*ListNodeSelectorsResponse, error) {
+	out := new(ListNodeSelectorsResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ListNodeSelectors", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) CreateRegistrationEntry(ctx context.Context, in *CreateRegistrationEntryRequest, opts ...grpc.CallOption) (*CreateRegistrationEntryResponse, error) {
+	out := new(CreateRegistrationEntryResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CreateRegistrationEntry", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) FetchRegistrationEntry(ctx context.Context, in *FetchRegistrationEntryRequest, opts ...grpc.CallOption) (*FetchRegistrationEntryResponse, error) {
+	out := new(FetchRegistrationEntryResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/FetchRegistrationEntry", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ListRegistrationEntries(ctx context.Context, in *ListRegistrationEntriesRequest, opts ...grpc.CallOption) (*ListRegistrationEntriesResponse, error) {
+	out := new(ListRegistrationEntriesResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ListRegistrationEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) UpdateRegistrationEntry(ctx context.Context, in *UpdateRegistrationEntryRequest, opts ...grpc.CallOption) (*UpdateRegistrationEntryResponse, error) {
+	out := new(UpdateRegistrationEntryResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/UpdateRegistrationEntry", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) DeleteRegistrationEntry(ctx context.Context, in *DeleteRegistrationEntryRequest, opts ...grpc.CallOption) (*DeleteRegistrationEntryResponse, error) {
+	out := new(DeleteRegistrationEntryResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/DeleteRegistrationEntry", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) BatchCreateRegistrationEntries(ctx context.Context, in *BatchCreateRegistrationEntriesRequest, opts ...grpc.CallOption) (*BatchCreateRegistrationEntriesResponse, error) {
+	out := new(BatchCreateRegistrationEntriesResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/BatchCreateRegistrationEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) BatchUpdateRegistrationEntries(ctx context.Context, in *BatchUpdateRegistrationEntriesRequest, opts ...grpc.CallOption) (*BatchUpdateRegistrationEntriesResponse, error) {
+	out := new(BatchUpdateRegistrationEntriesResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/BatchUpdateRegistrationEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) BatchDeleteRegistrationEntries(ctx context.Context, in *BatchDeleteRegistrationEntriesRequest, opts ...grpc.CallOption) (*BatchDeleteRegistrationEntriesResponse, error) {
+	out := new(BatchDeleteRegistrationEntriesResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/BatchDeleteRegistrationEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) PruneRegistrationEntries(ctx context.Context, in *PruneRegistrationEntriesRequest, opts ...grpc.CallOption) (*PruneRegistrationEntriesResponse, error) {
+	out := new(PruneRegistrationEntriesResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/PruneRegistrationEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ListRegistrationEntryTombstones(ctx context.Context, in *ListRegistrationEntryTombstonesRequest, opts ...grpc.CallOption) (*ListRegistrationEntryTombstonesResponse, error) {
+	out := new(ListRegistrationEntryTombstonesResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ListRegistrationEntryTombstones", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) PruneRegistrationEntryTombstones(ctx context.Context, in *PruneRegistrationEntryTombstonesRequest, opts ...grpc.CallOption) (*PruneRegistrationEntryTombstonesResponse, error) {
+	out := new(PruneRegistrationEntryTombstonesResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/PruneRegistrationEntryTombstones", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ListRegistrationEntryEvents(ctx context.Context, in *ListRegistrationEntryEventsRequest, opts ...grpc.CallOption) (*ListRegistrationEntryEventsResponse, error) {
+	out := new(ListRegistrationEntryEventsResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ListRegistrationEntryEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) PruneRegistrationEntryEvents(ctx context.Context, in *PruneRegistrationEntryEventsRequest, opts ...grpc.CallOption) (*PruneRegistrationEntryEventsResponse, error) {
+	out := new(PruneRegistrationEntryEventsResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/PruneRegistrationEntryEvents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) CreateJoinToken(ctx context.Context, in *CreateJoinTokenRequest, opts ...grpc.CallOption) (*CreateJoinTokenResponse, error) {
+	out := new(CreateJoinTokenResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CreateJoinToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) FetchJoinToken(ctx context.Context, in *FetchJoinTokenRequest, opts ...grpc.CallOption) (*FetchJoinTokenResponse, error) {
+	out := new(FetchJoinTokenResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/FetchJoinToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ListJoinTokens(ctx context.Context, in *ListJoinTokensRequest, opts ...grpc.CallOption) (*ListJoinTokensResponse, error) {
+	out := new(ListJoinTokensResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ListJoinTokens", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ConsumeJoinToken(ctx context.Context, in *ConsumeJoinTokenRequest, opts ...grpc.CallOption) (*ConsumeJoinTokenResponse, error) {
+	out := new(ConsumeJoinTokenResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ConsumeJoinToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) DeleteJoinToken(ctx context.Context, in *DeleteJoinTokenRequest, opts ...grpc.CallOption) (*DeleteJoinTokenResponse, error) {
+	out := new(DeleteJoinTokenResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/DeleteJoinToken", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) PruneJoinTokens(ctx context.Context, in *PruneJoinTokensRequest, opts ...grpc.CallOption) (*PruneJoinTokensResponse, error) {
+	out := new(PruneJoinTokensResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/PruneJoinTokens", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) FetchCAJournal(ctx context.Context, in *FetchCAJournalRequest, opts ...grpc.CallOption) (*FetchCAJournalResponse, error) {
+	out := new(FetchCAJournalResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/FetchCAJournal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) SetCAJournal(ctx context.Context, in *SetCAJournalRequest, opts ...grpc.CallOption) (*SetCAJournalResponse, error) {
+	out := new(SetCAJournalResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/SetCAJournal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) AcquireLease(ctx context.Context, in *AcquireLeaseRequest, opts ...grpc.CallOption) (*AcquireLeaseResponse, error) {
+	out := new(AcquireLeaseResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/AcquireLease", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ReleaseLease(ctx context.Context, in *ReleaseLeaseRequest, opts ...grpc.CallOption) (*ReleaseLeaseResponse, error) {
+	out := new(ReleaseLeaseResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ReleaseLease", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) RevokeCertificate(ctx context.Context, in *RevokeCertificateRequest, opts ...grpc.CallOption) (*RevokeCertificateResponse, error) {
+	out := new(RevokeCertificateResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/RevokeCertificate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) FetchRevokedCertificate(ctx context.Context, in *FetchRevokedCertificateRequest, opts ...grpc.CallOption) (*FetchRevokedCertificateResponse, error) {
+	out := new(FetchRevokedCertificateResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/FetchRevokedCertificate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ListRevokedCertificates(ctx context.Context, in *ListRevokedCertificatesRequest, opts ...grpc.CallOption) (*ListRevokedCertificatesResponse, error) {
+	out := new(ListRevokedCertificatesResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ListRevokedCertificates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) PruneRevokedCertificates(ctx context.Context, in *PruneRevokedCertificatesRequest, opts ...grpc.CallOption) (*PruneRevokedCertificatesResponse, error) {
+	out := new(PruneRevokedCertificatesResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/PruneRevokedCertificates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) CreateDownstreamCA(ctx context.Context, in *CreateDownstreamCARequest, opts ...grpc.CallOption) (*CreateDownstreamCAResponse, error) {
+	out := new(CreateDownstreamCAResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CreateDownstreamCA", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ListDownstreamCAs(ctx context.Context, in *ListDownstreamCAsRequest, opts ...grpc.CallOption) (*ListDownstreamCAsResponse, error) {
+	out := new(ListDownstreamCAsResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ListDownstreamCAs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) PruneDownstreamCAs(ctx context.Context, in *PruneDownstreamCAsRequest, opts ...grpc.CallOption) (*PruneDownstreamCAsResponse, error) {
+	out := new(PruneDownstreamCAsResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/PruneDownstreamCAs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) CreateIssuedSVID(ctx context.Context, in *CreateIssuedSVIDRequest, opts ...grpc.CallOption) (*CreateIssuedSVIDResponse, error) {
+	out := new(CreateIssuedSVIDResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/CreateIssuedSVID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) ListIssuedSVIDs(ctx context.Context, in *ListIssuedSVIDsRequest, opts ...grpc.CallOption) (*ListIssuedSVIDsResponse, error) {
+	out := new(ListIssuedSVIDsResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/ListIssuedSVIDs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) PruneIssuedSVIDs(ctx context.Context, in *PruneIssuedSVIDsRequest, opts ...grpc.CallOption) (*PruneIssuedSVIDsResponse, error) {
+	out := new(PruneIssuedSVIDsResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/PruneIssuedSVIDs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) Configure(ctx context.Context, in *plugin.ConfigureRequest, opts ...grpc.CallOption) (*plugin.ConfigureResponse, error) {
+	out := new(plugin.ConfigureResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/Configure", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dataStoreClient) GetPluginInfo(ctx context.Context, in *plugin.GetPluginInfoRequest, opts ...grpc.CallOption) (*plugin.GetPluginInfoResponse, error) {
+	out := new(plugin.GetPluginInfoResponse)
+	err := c.cc.Invoke(ctx, "/spire.server.datastore.DataStore/GetPluginInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
 // DataStoreServer is the server API for DataStore service.
@@ -2792,12 +6070,17 @@ type DataStoreServer interface {
 	ListAttestedNodes(context.Context, *ListAttestedNodesRequest) (*ListAttestedNodesResponse, error)
 	// Updates a specific attested node
 	UpdateAttestedNode(context.Context, *UpdateAttestedNodeRequest) (*UpdateAttestedNodeResponse, error)
+	// Bans or unbans a specific attested node
+	SetAttestedNodeBanned(context.Context, *SetAttestedNodeBannedRequest) (*SetAttestedNodeBannedResponse, error)
 	// Deletes a specific attested node
 	DeleteAttestedNode(context.Context, *DeleteAttestedNodeRequest) (*DeleteAttestedNodeResponse, error)
 	// Sets the set of selectors for a specific node id
 	SetNodeSelectors(context.Context, *SetNodeSelectorsRequest) (*SetNodeSelectorsResponse, error)
 	// Gets the set of node selectors for a specific node id
 	GetNodeSelectors(context.Context, *GetNodeSelectorsRequest) (*GetNodeSelectorsResponse, error)
+	// Lists the node selectors for all nodes, or for the nodes whose
+	// selectors changed after a change event
+	ListNodeSelectors(context.Context, *ListNodeSelectorsRequest) (*ListNodeSelectorsResponse, error)
 	// Creates a registration entry
 	CreateRegistrationEntry(context.Context, *CreateRegistrationEntryRequest) (*CreateRegistrationEntryResponse, error)
 	// Fetches a specific registration entry
@@ -2808,472 +6091,972 @@ type DataStoreServer interface {
 	UpdateRegistrationEntry(context.Context, *UpdateRegistrationEntryRequest) (*UpdateRegistrationEntryResponse, error)
 	// Deletes a specific registration entry
 	DeleteRegistrationEntry(context.Context, *DeleteRegistrationEntryRequest) (*DeleteRegistrationEntryResponse, error)
+	// Creates registration entries in a single transaction. Entries
+	// identical to an existing one (same SPIFFE ID, parent ID and
+	// selectors) are not created.
+	BatchCreateRegistrationEntries(context.Context, *BatchCreateRegistrationEntriesRequest) (*BatchCreateRegistrationEntriesResponse, error)
+	// Updates registration entries in a single transaction
+	BatchUpdateRegistrationEntries(context.Context, *BatchUpdateRegistrationEntriesRequest) (*BatchUpdateRegistrationEntriesResponse, error)
+	// Deletes registration entries in a single transaction
+	BatchDeleteRegistrationEntries(context.Context, *BatchDeleteRegistrationEntriesRequest) (*BatchDeleteRegistrationEntriesResponse, error)
 	// Prunes all registration entries that expire before the specified timestamp
 	PruneRegistrationEntries(context.Context, *PruneRegistrationEntriesRequest) (*PruneRegistrationEntriesResponse, error)
+	// Lists the IDs of registration entries deleted after a revision, along
+	// with the current revision
+	ListRegistrationEntryTombstones(context.Context, *ListRegistrationEntryTombstonesRequest) (*ListRegistrationEntryTombstonesResponse, error)
+	// Prunes all registration entry tombstones older than the specified timestamp
+	PruneRegistrationEntryTombstones(context.Context, *PruneRegistrationEntryTombstonesRequest) (*PruneRegistrationEntryTombstonesResponse, error)
+	// Lists registration entry change events recorded after an event ID
+	ListRegistrationEntryEvents(context.Context, *ListRegistrationEntryEventsRequest) (*ListRegistrationEntryEventsResponse, error)
+	// Prunes all registration entry events older than the specified timestamp
+	PruneRegistrationEntryEvents(context.Context, *PruneRegistrationEntryEventsRequest) (*PruneRegistrationEntryEventsResponse, error)
 	// Creates a join token
 	CreateJoinToken(context.Context, *CreateJoinTokenRequest) (*CreateJoinTokenResponse, error)
 	// Fetches a specific join token
 	FetchJoinToken(context.Context, *FetchJoinTokenRequest) (*FetchJoinTokenResponse, error)
+	// Lists all join tokens
+	ListJoinTokens(context.Context, *ListJoinTokensRequest) (*ListJoinTokensResponse, error)
+	// Uses a specific join token once, creating its registration entries and
+	// deleting it when it has no uses left
+	ConsumeJoinToken(context.Context, *ConsumeJoinTokenRequest) (*ConsumeJoinTokenResponse, error)
 	// Delete a specific join token
 	DeleteJoinToken(context.Context, *DeleteJoinTokenRequest) (*DeleteJoinTokenResponse, error)
 	// Prunes all join tokens that expire before the specified timestamp
 	PruneJoinTokens(context.Context, *PruneJoinTokensRequest) (*PruneJoinTokensResponse, error)
+	// Fetches a specific CA journal
+	FetchCAJournal(context.Context, *FetchCAJournalRequest) (*FetchCAJournalResponse, error)
+	// Sets a CA journal if the revision matches the stored revision
+	SetCAJournal(context.Context, *SetCAJournalRequest) (*SetCAJournalResponse, error)
+	// Acquires or renews a lease
+	AcquireLease(context.Context, *AcquireLeaseRequest) (*AcquireLeaseResponse, error)
+	// Releases a lease held by the requested holder
+	ReleaseLease(context.Context, *ReleaseLeaseRequest) (*ReleaseLeaseResponse, error)
+	// Revokes a certificate
+	RevokeCertificate(context.Context, *RevokeCertificateRequest) (*RevokeCertificateResponse, error)
+	// Fetches a specific revoked certificate
+	FetchRevokedCertificate(context.Context, *FetchRevokedCertificateRequest) (*FetchRevokedCertificateResponse, error)
+	// Lists revoked certificates
+	ListRevokedCertificates(context.Context, *ListRevokedCertificatesRequest) (*ListRevokedCertificatesResponse, error)
+	// Prunes all revoked certificates that expire before the specified timestamp
+	PruneRevokedCertificates(context.Context, *PruneRevokedCertificatesRequest) (*PruneRevokedCertificatesResponse, error)
+	// Records a downstream CA issued through an agent
+	CreateDownstreamCA(context.Context, *CreateDownstreamCARequest) (*CreateDownstreamCAResponse, error)
+	// Lists downstream CAs (optionally filtered)
+	ListDownstreamCAs(context.Context, *ListDownstreamCAsRequest) (*ListDownstreamCAsResponse, error)
+	// Prunes all downstream CAs that expire before the specified timestamp
+	PruneDownstreamCAs(context.Context, *PruneDownstreamCAsRequest) (*PruneDownstreamCAsResponse, error)
+	// Records an issued SVID
+	CreateIssuedSVID(context.Context, *CreateIssuedSVIDRequest) (*CreateIssuedSVIDResponse, error)
+	// Lists issued SVIDs (optionally filtered)
+	ListIssuedSVIDs(context.Context, *ListIssuedSVIDsRequest) (*ListIssuedSVIDsResponse, error)
+	// Prunes records of issued SVIDs that expire before the specified timestamp
+	PruneIssuedSVIDs(context.Context, *PruneIssuedSVIDsRequest) (*PruneIssuedSVIDsResponse, error)
 	// Applies the plugin configuration
 	Configure(context.Context, *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error)
 	// Returns the version and related metadata of the installed plugin
 	GetPluginInfo(context.Context, *plugin.GetPluginInfoRequest) (*plugin.GetPluginInfoResponse, error)
 }
 
-func RegisterDataStoreServer(s *grpc.Server, srv DataStoreServer) {
-	s.RegisterService(&_DataStore_serviceDesc, srv)
+func RegisterDataStoreServer(s *grpc.Server, srv DataStoreServer) {
+	s.RegisterService(&_DataStore_serviceDesc, srv)
+}
+
+func _DataStore_CreateBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).CreateBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/CreateBundle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).CreateBundle(ctx, req.(*CreateBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_FetchBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).FetchBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/FetchBundle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).FetchBundle(ctx, req.(*FetchBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_ListBundles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBundlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListBundles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/ListBundles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListBundles(ctx, req.(*ListBundlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_UpdateBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).UpdateBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/UpdateBundle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).UpdateBundle(ctx, req.(*UpdateBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_SetBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).SetBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/SetBundle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).SetBundle(ctx, req.(*SetBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_AppendBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppendBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).AppendBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/AppendBundle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).AppendBundle(ctx, req.(*AppendBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_DeleteBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).DeleteBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/DeleteBundle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).DeleteBundle(ctx, req.(*DeleteBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_PruneBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneBundleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).PruneBundle(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/PruneBundle",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).PruneBundle(ctx, req.(*PruneBundleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_CreateAttestedNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAttestedNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).CreateAttestedNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/CreateAttestedNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).CreateAttestedNode(ctx, req.(*CreateAttestedNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_FetchAttestedNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchAttestedNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).FetchAttestedNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/FetchAttestedNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).FetchAttestedNode(ctx, req.(*FetchAttestedNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_ListAttestedNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAttestedNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListAttestedNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/ListAttestedNodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListAttestedNodes(ctx, req.(*ListAttestedNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_UpdateAttestedNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateAttestedNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).UpdateAttestedNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/UpdateAttestedNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).UpdateAttestedNode(ctx, req.(*UpdateAttestedNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_SetAttestedNodeBanned_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAttestedNodeBannedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).SetAttestedNodeBanned(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/SetAttestedNodeBanned",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).SetAttestedNodeBanned(ctx, req.(*SetAttestedNodeBannedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_DeleteAttestedNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAttestedNodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).DeleteAttestedNode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/DeleteAttestedNode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).DeleteAttestedNode(ctx, req.(*DeleteAttestedNodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_SetNodeSelectors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetNodeSelectorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).SetNodeSelectors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/SetNodeSelectors",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).SetNodeSelectors(ctx, req.(*SetNodeSelectorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_GetNodeSelectors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNodeSelectorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).GetNodeSelectors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/GetNodeSelectors",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).GetNodeSelectors(ctx, req.(*GetNodeSelectorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_ListNodeSelectors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNodeSelectorsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListNodeSelectors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/ListNodeSelectors",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListNodeSelectors(ctx, req.(*ListNodeSelectorsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_CreateRegistrationEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRegistrationEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).CreateRegistrationEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/CreateRegistrationEntry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).CreateRegistrationEntry(ctx, req.(*CreateRegistrationEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_FetchRegistrationEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchRegistrationEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).FetchRegistrationEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/FetchRegistrationEntry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).FetchRegistrationEntry(ctx, req.(*FetchRegistrationEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_ListRegistrationEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRegistrationEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListRegistrationEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/ListRegistrationEntries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListRegistrationEntries(ctx, req.(*ListRegistrationEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_UpdateRegistrationEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRegistrationEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).UpdateRegistrationEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/UpdateRegistrationEntry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).UpdateRegistrationEntry(ctx, req.(*UpdateRegistrationEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_DeleteRegistrationEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRegistrationEntryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).DeleteRegistrationEntry(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/DeleteRegistrationEntry",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).DeleteRegistrationEntry(ctx, req.(*DeleteRegistrationEntryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_BatchCreateRegistrationEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateRegistrationEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).BatchCreateRegistrationEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/BatchCreateRegistrationEntries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).BatchCreateRegistrationEntries(ctx, req.(*BatchCreateRegistrationEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _DataStore_CreateBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateBundleRequest)
+func _DataStore_BatchUpdateRegistrationEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateRegistrationEntriesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataStoreServer).CreateBundle(ctx, in)
+		return srv.(DataStoreServer).BatchUpdateRegistrationEntries(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.server.datastore.DataStore/CreateBundle",
+		FullMethod: "/spire.server.datastore.DataStore/BatchUpdateRegistrationEntries",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataStoreServer).CreateBundle(ctx, req.(*CreateBundleRequest))
+		return srv.(DataStoreServer).BatchUpdateRegistrationEntries(ctx, req.(*BatchUpdateRegistrationEntriesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DataStore_FetchBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FetchBundleRequest)
+func _DataStore_BatchDeleteRegistrationEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteRegistrationEntriesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataStoreServer).FetchBundle(ctx, in)
+		return srv.(DataStoreServer).BatchDeleteRegistrationEntries(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.server.datastore.DataStore/FetchBundle",
+		FullMethod: "/spire.server.datastore.DataStore/BatchDeleteRegistrationEntries",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataStoreServer).FetchBundle(ctx, req.(*FetchBundleRequest))
+		return srv.(DataStoreServer).BatchDeleteRegistrationEntries(ctx, req.(*BatchDeleteRegistrationEntriesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DataStore_ListBundles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListBundlesRequest)
+func _DataStore_PruneRegistrationEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneRegistrationEntriesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataStoreServer).ListBundles(ctx, in)
+		return srv.(DataStoreServer).PruneRegistrationEntries(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.server.datastore.DataStore/ListBundles",
+		FullMethod: "/spire.server.datastore.DataStore/PruneRegistrationEntries",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataStoreServer).ListBundles(ctx, req.(*ListBundlesRequest))
+		return srv.(DataStoreServer).PruneRegistrationEntries(ctx, req.(*PruneRegistrationEntriesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DataStore_UpdateBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateBundleRequest)
+func _DataStore_ListRegistrationEntryTombstones_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRegistrationEntryTombstonesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataStoreServer).UpdateBundle(ctx, in)
+		return srv.(DataStoreServer).ListRegistrationEntryTombstones(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.server.datastore.DataStore/UpdateBundle",
+		FullMethod: "/spire.server.datastore.DataStore/ListRegistrationEntryTombstones",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataStoreServer).UpdateBundle(ctx, req.(*UpdateBundleRequest))
+		return srv.(DataStoreServer).ListRegistrationEntryTombstones(ctx, req.(*ListRegistrationEntryTombstonesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DataStore_SetBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetBundleRequest)
+func _DataStore_PruneRegistrationEntryTombstones_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneRegistrationEntryTombstonesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataStoreServer).SetBundle(ctx, in)
+		return srv.(DataStoreServer).PruneRegistrationEntryTombstones(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.server.datastore.DataStore/SetBundle",
+		FullMethod: "/spire.server.datastore.DataStore/PruneRegistrationEntryTombstones",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataStoreServer).SetBundle(ctx, req.(*SetBundleRequest))
+		return srv.(DataStoreServer).PruneRegistrationEntryTombstones(ctx, req.(*PruneRegistrationEntryTombstonesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DataStore_AppendBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AppendBundleRequest)
+func _DataStore_ListRegistrationEntryEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRegistrationEntryEventsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataStoreServer).AppendBundle(ctx, in)
+		return srv.(DataStoreServer).ListRegistrationEntryEvents(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.server.datastore.DataStore/AppendBundle",
+		FullMethod: "/spire.server.datastore.DataStore/ListRegistrationEntryEvents",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataStoreServer).AppendBundle(ctx, req.(*AppendBundleRequest))
+		return srv.(DataStoreServer).ListRegistrationEntryEvents(ctx, req.(*ListRegistrationEntryEventsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DataStore_DeleteBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteBundleRequest)
+func _DataStore_PruneRegistrationEntryEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneRegistrationEntryEventsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataStoreServer).DeleteBundle(ctx, in)
+		return srv.(DataStoreServer).PruneRegistrationEntryEvents(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.server.datastore.DataStore/DeleteBundle",
+		FullMethod: "/spire.server.datastore.DataStore/PruneRegistrationEntryEvents",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataStoreServer).DeleteBundle(ctx, req.(*DeleteBundleRequest))
+		return srv.(DataStoreServer).PruneRegistrationEntryEvents(ctx, req.(*PruneRegistrationEntryEventsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DataStore_PruneBundle_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PruneBundleRequest)
+func _DataStore_CreateJoinToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateJoinTokenRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataStoreServer).PruneBundle(ctx, in)
+		return srv.(DataStoreServer).CreateJoinToken(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.server.datastore.DataStore/PruneBundle",
+		FullMethod: "/spire.server.datastore.DataStore/CreateJoinToken",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataStoreServer).PruneBundle(ctx, req.(*PruneBundleRequest))
+		return srv.(DataStoreServer).CreateJoinToken(ctx, req.(*CreateJoinTokenRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DataStore_CreateAttestedNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateAttestedNodeRequest)
+func _DataStore_FetchJoinToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchJoinTokenRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataStoreServer).CreateAttestedNode(ctx, in)
+		return srv.(DataStoreServer).FetchJoinToken(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.server.datastore.DataStore/CreateAttestedNode",
+		FullMethod: "/spire.server.datastore.DataStore/FetchJoinToken",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataStoreServer).CreateAttestedNode(ctx, req.(*CreateAttestedNodeRequest))
+		return srv.(DataStoreServer).FetchJoinToken(ctx, req.(*FetchJoinTokenRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DataStore_FetchAttestedNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FetchAttestedNodeRequest)
+func _DataStore_ListJoinTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJoinTokensRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataStoreServer).FetchAttestedNode(ctx, in)
+		return srv.(DataStoreServer).ListJoinTokens(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.server.datastore.DataStore/FetchAttestedNode",
+		FullMethod: "/spire.server.datastore.DataStore/ListJoinTokens",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataStoreServer).FetchAttestedNode(ctx, req.(*FetchAttestedNodeRequest))
+		return srv.(DataStoreServer).ListJoinTokens(ctx, req.(*ListJoinTokensRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DataStore_ListAttestedNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListAttestedNodesRequest)
+func _DataStore_ConsumeJoinToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConsumeJoinTokenRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataStoreServer).ListAttestedNodes(ctx, in)
+		return srv.(DataStoreServer).ConsumeJoinToken(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.server.datastore.DataStore/ListAttestedNodes",
+		FullMethod: "/spire.server.datastore.DataStore/ConsumeJoinToken",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataStoreServer).ListAttestedNodes(ctx, req.(*ListAttestedNodesRequest))
+		return srv.(DataStoreServer).ConsumeJoinToken(ctx, req.(*ConsumeJoinTokenRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DataStore_UpdateAttestedNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateAttestedNodeRequest)
+func _DataStore_DeleteJoinToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteJoinTokenRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataStoreServer).UpdateAttestedNode(ctx, in)
+		return srv.(DataStoreServer).DeleteJoinToken(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.server.datastore.DataStore/UpdateAttestedNode",
+		FullMethod: "/spire.server.datastore.DataStore/DeleteJoinToken",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataStoreServer).UpdateAttestedNode(ctx, req.(*UpdateAttestedNodeRequest))
+		return srv.(DataStoreServer).DeleteJoinToken(ctx, req.(*DeleteJoinTokenRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DataStore_DeleteAttestedNode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteAttestedNodeRequest)
+func _DataStore_PruneJoinTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneJoinTokensRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataStoreServer).DeleteAttestedNode(ctx, in)
+		return srv.(DataStoreServer).PruneJoinTokens(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.server.datastore.DataStore/DeleteAttestedNode",
+		FullMethod: "/spire.server.datastore.DataStore/PruneJoinTokens",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataStoreServer).DeleteAttestedNode(ctx, req.(*DeleteAttestedNodeRequest))
+		return srv.(DataStoreServer).PruneJoinTokens(ctx, req.(*PruneJoinTokensRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DataStore_SetNodeSelectors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetNodeSelectorsRequest)
+func _DataStore_FetchCAJournal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchCAJournalRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataStoreServer).SetNodeSelectors(ctx, in)
+		return srv.(DataStoreServer).FetchCAJournal(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.server.datastore.DataStore/SetNodeSelectors",
+		FullMethod: "/spire.server.datastore.DataStore/FetchCAJournal",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataStoreServer).SetNodeSelectors(ctx, req.(*SetNodeSelectorsRequest))
+		return srv.(DataStoreServer).FetchCAJournal(ctx, req.(*FetchCAJournalRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DataStore_GetNodeSelectors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetNodeSelectorsRequest)
+func _DataStore_SetCAJournal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCAJournalRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataStoreServer).GetNodeSelectors(ctx, in)
+		return srv.(DataStoreServer).SetCAJournal(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.server.datastore.DataStore/GetNodeSelectors",
+		FullMethod: "/spire.server.datastore.DataStore/SetCAJournal",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataStoreServer).GetNodeSelectors(ctx, req.(*GetNodeSelectorsRequest))
+		return srv.(DataStoreServer).SetCAJournal(ctx, req.(*SetCAJournalRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DataStore_CreateRegistrationEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateRegistrationEntryRequest)
+func _DataStore_AcquireLease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcquireLeaseRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataStoreServer).CreateRegistrationEntry(ctx, in)
+		return srv.(DataStoreServer).AcquireLease(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.server.datastore.DataStore/CreateRegistrationEntry",
+		FullMethod: "/spire.server.datastore.DataStore/AcquireLease",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataStoreServer).CreateRegistrationEntry(ctx, req.(*CreateRegistrationEntryRequest))
+		return srv.(DataStoreServer).AcquireLease(ctx, req.(*AcquireLeaseRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DataStore_FetchRegistrationEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FetchRegistrationEntryRequest)
+func _DataStore_ReleaseLease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseLeaseRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataStoreServer).FetchRegistrationEntry(ctx, in)
+		return srv.(DataStoreServer).ReleaseLease(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.server.datastore.DataStore/FetchRegistrationEntry",
+		FullMethod: "/spire.server.datastore.DataStore/ReleaseLease",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataStoreServer).FetchRegistrationEntry(ctx, req.(*FetchRegistrationEntryRequest))
+		return srv.(DataStoreServer).ReleaseLease(ctx, req.(*ReleaseLeaseRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DataStore_ListRegistrationEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListRegistrationEntriesRequest)
+func _DataStore_RevokeCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeCertificateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataStoreServer).ListRegistrationEntries(ctx, in)
+		return srv.(DataStoreServer).RevokeCertificate(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.server.datastore.DataStore/ListRegistrationEntries",
+		FullMethod: "/spire.server.datastore.DataStore/RevokeCertificate",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataStoreServer).ListRegistrationEntries(ctx, req.(*ListRegistrationEntriesRequest))
+		return srv.(DataStoreServer).RevokeCertificate(ctx, req.(*RevokeCertificateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DataStore_UpdateRegistrationEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateRegistrationEntryRequest)
+func _DataStore_FetchRevokedCertificate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FetchRevokedCertificateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataStoreServer).UpdateRegistrationEntry(ctx, in)
+		return srv.(DataStoreServer).FetchRevokedCertificate(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.server.datastore.DataStore/UpdateRegistrationEntry",
+		FullMethod: "/spire.server.datastore.DataStore/FetchRevokedCertificate",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataStoreServer).UpdateRegistrationEntry(ctx, req.(*UpdateRegistrationEntryRequest))
+		return srv.(DataStoreServer).FetchRevokedCertificate(ctx, req.(*FetchRevokedCertificateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DataStore_DeleteRegistrationEntry_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteRegistrationEntryRequest)
+func _DataStore_ListRevokedCertificates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRevokedCertificatesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataStoreServer).DeleteRegistrationEntry(ctx, in)
+		return srv.(DataStoreServer).ListRevokedCertificates(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.server.datastore.DataStore/DeleteRegistrationEntry",
+		FullMethod: "/spire.server.datastore.DataStore/ListRevokedCertificates",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataStoreServer).DeleteRegistrationEntry(ctx, req.(*DeleteRegistrationEntryRequest))
+		return srv.(DataStoreServer).ListRevokedCertificates(ctx, req.(*ListRevokedCertificatesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DataStore_PruneRegistrationEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PruneRegistrationEntriesRequest)
+func _DataStore_PruneRevokedCertificates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneRevokedCertificatesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataStoreServer).PruneRegistrationEntries(ctx, in)
+		return srv.(DataStoreServer).PruneRevokedCertificates(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.server.datastore.DataStore/PruneRegistrationEntries",
+		FullMethod: "/spire.server.datastore.DataStore/PruneRevokedCertificates",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataStoreServer).PruneRegistrationEntries(ctx, req.(*PruneRegistrationEntriesRequest))
+		return srv.(DataStoreServer).PruneRevokedCertificates(ctx, req.(*PruneRevokedCertificatesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DataStore_CreateJoinToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateJoinTokenRequest)
+func _DataStore_CreateDownstreamCA_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateDownstreamCARequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataStoreServer).CreateJoinToken(ctx, in)
+		return srv.(DataStoreServer).CreateDownstreamCA(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.server.datastore.DataStore/CreateJoinToken",
+		FullMethod: "/spire.server.datastore.DataStore/CreateDownstreamCA",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataStoreServer).CreateJoinToken(ctx, req.(*CreateJoinTokenRequest))
+		return srv.(DataStoreServer).CreateDownstreamCA(ctx, req.(*CreateDownstreamCARequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DataStore_FetchJoinToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(FetchJoinTokenRequest)
+func _DataStore_ListDownstreamCAs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDownstreamCAsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataStoreServer).FetchJoinToken(ctx, in)
+		return srv.(DataStoreServer).ListDownstreamCAs(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.server.datastore.DataStore/FetchJoinToken",
+		FullMethod: "/spire.server.datastore.DataStore/ListDownstreamCAs",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataStoreServer).FetchJoinToken(ctx, req.(*FetchJoinTokenRequest))
+		return srv.(DataStoreServer).ListDownstreamCAs(ctx, req.(*ListDownstreamCAsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DataStore_DeleteJoinToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteJoinTokenRequest)
+func _DataStore_PruneDownstreamCAs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneDownstreamCAsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataStoreServer).DeleteJoinToken(ctx, in)
+		return srv.(DataStoreServer).PruneDownstreamCAs(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.server.datastore.DataStore/DeleteJoinToken",
+		FullMethod: "/spire.server.datastore.DataStore/PruneDownstreamCAs",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataStoreServer).DeleteJoinToken(ctx, req.(*DeleteJoinTokenRequest))
+		return srv.(DataStoreServer).PruneDownstreamCAs(ctx, req.(*PruneDownstreamCAsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DataStore_PruneJoinTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PruneJoinTokensRequest)
+func _DataStore_CreateIssuedSVID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateIssuedSVIDRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DataStoreServer).PruneJoinTokens(ctx, in)
+		return srv.(DataStoreServer).CreateIssuedSVID(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/spire.server.datastore.DataStore/PruneJoinTokens",
+		FullMethod: "/spire.server.datastore.DataStore/CreateIssuedSVID",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DataStoreServer).PruneJoinTokens(ctx, req.(*PruneJoinTokensRequest))
+		return srv.(DataStoreServer).CreateIssuedSVID(ctx, req.(*CreateIssuedSVIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_ListIssuedSVIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListIssuedSVIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).ListIssuedSVIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/ListIssuedSVIDs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).ListIssuedSVIDs(ctx, req.(*ListIssuedSVIDsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DataStore_PruneIssuedSVIDs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneIssuedSVIDsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DataStoreServer).PruneIssuedSVIDs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/spire.server.datastore.DataStore/PruneIssuedSVIDs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DataStoreServer).PruneIssuedSVIDs(ctx, req.(*PruneIssuedSVIDsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -3366,6 +7149,10 @@ var _DataStore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateAttestedNode",
 			Handler:    _DataStore_UpdateAttestedNode_Handler,
 		},
+		{
+			MethodName: "SetAttestedNodeBanned",
+			Handler:    _DataStore_SetAttestedNodeBanned_Handler,
+		},
 		{
 			MethodName: "DeleteAttestedNode",
 			Handler:    _DataStore_DeleteAttestedNode_Handler,
@@ -3378,6 +7165,10 @@ var _DataStore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetNodeSelectors",
 			Handler:    _DataStore_GetNodeSelectors_Handler,
 		},
+		{
+			MethodName: "ListNodeSelectors",
+			Handler:    _DataStore_ListNodeSelectors_Handler,
+		},
 		{
 			MethodName: "CreateRegistrationEntry",
 			Handler:    _DataStore_CreateRegistrationEntry_Handler,
@@ -3398,10 +7189,38 @@ var _DataStore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteRegistrationEntry",
 			Handler:    _DataStore_DeleteRegistrationEntry_Handler,
 		},
+		{
+			MethodName: "BatchCreateRegistrationEntries",
+			Handler:    _DataStore_BatchCreateRegistrationEntries_Handler,
+		},
+		{
+			MethodName: "BatchUpdateRegistrationEntries",
+			Handler:    _DataStore_BatchUpdateRegistrationEntries_Handler,
+		},
+		{
+			MethodName: "BatchDeleteRegistrationEntries",
+			Handler:    _DataStore_BatchDeleteRegistrationEntries_Handler,
+		},
 		{
 			MethodName: "PruneRegistrationEntries",
 			Handler:    _DataStore_PruneRegistrationEntries_Handler,
 		},
+		{
+			MethodName: "ListRegistrationEntryTombstones",
+			Handler:    _DataStore_ListRegistrationEntryTombstones_Handler,
+		},
+		{
+			MethodName: "PruneRegistrationEntryTombstones",
+			Handler:    _DataStore_PruneRegistrationEntryTombstones_Handler,
+		},
+		{
+			MethodName: "ListRegistrationEntryEvents",
+			Handler:    _DataStore_ListRegistrationEntryEvents_Handler,
+		},
+		{
+			MethodName: "PruneRegistrationEntryEvents",
+			Handler:    _DataStore_PruneRegistrationEntryEvents_Handler,
+		},
 		{
 			MethodName: "CreateJoinToken",
 			Handler:    _DataStore_CreateJoinToken_Handler,
@@ -3410,6 +7229,14 @@ var _DataStore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "FetchJoinToken",
 			Handler:    _DataStore_FetchJoinToken_Handler,
 		},
+		{
+			MethodName: "ListJoinTokens",
+			Handler:    _DataStore_ListJoinTokens_Handler,
+		},
+		{
+			MethodName: "ConsumeJoinToken",
+			Handler:    _DataStore_ConsumeJoinToken_Handler,
+		},
 		{
 			MethodName: "DeleteJoinToken",
 			Handler:    _DataStore_DeleteJoinToken_Handler,
@@ -3418,6 +7245,62 @@ var _DataStore_serviceDesc = grpc.ServiceDesc{
 			MethodName: "PruneJoinTokens",
 			Handler:    _DataStore_PruneJoinTokens_Handler,
 		},
+		{
+			MethodName: "FetchCAJournal",
+			Handler:    _DataStore_FetchCAJournal_Handler,
+		},
+		{
+			MethodName: "SetCAJournal",
+			Handler:    _DataStore_SetCAJournal_Handler,
+		},
+		{
+			MethodName: "AcquireLease",
+			Handler:    _DataStore_AcquireLease_Handler,
+		},
+		{
+			MethodName: "ReleaseLease",
+			Handler:    _DataStore_ReleaseLease_Handler,
+		},
+		{
+			MethodName: "RevokeCertificate",
+			Handler:    _DataStore_RevokeCertificate_Handler,
+		},
+		{
+			MethodName: "FetchRevokedCertificate",
+			Handler:    _DataStore_FetchRevokedCertificate_Handler,
+		},
+		{
+			MethodName: "ListRevokedCertificates",
+			Handler:    _DataStore_ListRevokedCertificates_Handler,
+		},
+		{
+			MethodName: "PruneRevokedCertificates",
+			Handler:    _DataStore_PruneRevokedCertificates_Handler,
+		},
+		{
+			MethodName: "CreateDownstreamCA",
+			Handler:    _DataStore_CreateDownstreamCA_Handler,
+		},
+		{
+			MethodName: "ListDownstreamCAs",
+			Handler:    _DataStore_ListDownstreamCAs_Handler,
+		},
+		{
+			MethodName: "PruneDownstreamCAs",
+			Handler:    _DataStore_PruneDownstreamCAs_Handler,
+		},
+		{
+			MethodName: "CreateIssuedSVID",
+			Handler:    _DataStore_CreateIssuedSVID_Handler,
+		},
+		{
+			MethodName: "ListIssuedSVIDs",
+			Handler:    _DataStore_ListIssuedSVIDs_Handler,
+		},
+		{
+			MethodName: "PruneIssuedSVIDs",
+			Handler:    _DataStore_PruneIssuedSVIDs_Handler,
+		},
 		{
 			MethodName: "Configure",
 			Handler:    _DataStore_Configure_Handler,
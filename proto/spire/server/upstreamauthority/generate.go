@@ -0,0 +1,2 @@
+//go:generate $GOPATH/bin/spire-plugingen . UpstreamAuthority
+package upstreamauthority
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -32,11 +34,15 @@ var (
 	ErrBundleAlreadyExists       = errors.New("bundle already exists")
 	ErrAttestedNodeAlreadyExists = errors.New("attested node entry already exists")
 	ErrTokenAlreadyExists        = errors.New("token already exists")
+	ErrDownstreamCAAlreadyExists = errors.New("downstream CA already exists")
 
 	ErrNoSuchBundle            = status.Error(codes.NotFound, "no such bundle")
 	ErrNoSuchAttestedNode      = status.Error(codes.NotFound, "no such attested node entry")
 	ErrNoSuchRegistrationEntry = status.Error(codes.NotFound, "no such registration entry")
 	ErrNoSuchToken             = status.Error(codes.NotFound, "no such token")
+
+	ErrCAJournalRevisionMismatch         = status.Error(codes.Aborted, "ca journal revision mismatch")
+	ErrRegistrationEntryRevisionMismatch = status.Error(codes.FailedPrecondition, "registration entry revision mismatch")
 )
 
 type DataStore struct {
@@ -45,8 +51,21 @@ type DataStore struct {
 	bundles             map[string]*common.Bundle
 	attestedNodes       map[string]*common.AttestedNode
 	nodeSelectors       map[string][]*common.Selector
+	nodeSelectorEvents  map[string]int64
 	registrationEntries map[string]*common.RegistrationEntry
 	tokens              map[string]*datastore.JoinToken
+	caJournals          map[string]*datastore.CAJournal
+	leases              map[string]*datastore.Lease
+	revokedCertificates map[string]*datastore.RevokedCertificate
+	downstreamCAs       map[string]*datastore.DownstreamCA
+	issuedSVIDs         []issuedSVIDRecord
+	nextIssuedSVIDID    int64
+	revision            int64
+	entryTombstones     []entryTombstone
+	entryEvents         []*datastore.RegistrationEntryEvent
+	nextEntryEventID    int64
+	prunedEntryEventID  int64
+	nodeSelectorEventID int64
 
 	// relates bundles with entries that federate with them
 	bundleEntries map[string]map[string]bool
@@ -59,8 +78,13 @@ func New() *DataStore {
 		bundles:             make(map[string]*common.Bundle),
 		attestedNodes:       make(map[string]*common.AttestedNode),
 		nodeSelectors:       make(map[string][]*common.Selector),
+		nodeSelectorEvents:  make(map[string]int64),
 		registrationEntries: make(map[string]*common.RegistrationEntry),
 		tokens:              make(map[string]*datastore.JoinToken),
+		caJournals:          make(map[string]*datastore.CAJournal),
+		leases:              make(map[string]*datastore.Lease),
+		revokedCertificates: make(map[string]*datastore.RevokedCertificate),
+		downstreamCAs:       make(map[string]*datastore.DownstreamCA),
 		bundleEntries:       make(map[string]map[string]bool),
 	}
 }
@@ -70,12 +94,13 @@ func (s *DataStore) CreateBundle(ctx context.Context, req *datastore.CreateBundl
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	bundle := req.Bundle
+	bundle := cloneBundle(req.Bundle)
 
 	if _, ok := s.bundles[bundle.TrustDomainId]; ok {
 		return nil, ErrBundleAlreadyExists
 	}
 
+	bundle.RevisionNumber = s.nextRevision()
 	s.bundles[bundle.TrustDomainId] = cloneBundle(bundle)
 
 	return &datastore.CreateBundleResponse{
@@ -89,12 +114,13 @@ func (s *DataStore) UpdateBundle(ctx context.Context, req *datastore.UpdateBundl
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	bundle := req.Bundle
+	bundle := cloneBundle(req.Bundle)
 
 	if _, ok := s.bundles[bundle.TrustDomainId]; !ok {
 		return nil, ErrNoSuchBundle
 	}
 
+	bundle.RevisionNumber = s.nextRevision()
 	s.bundles[bundle.TrustDomainId] = cloneBundle(bundle)
 
 	return &datastore.UpdateBundleResponse{
@@ -107,8 +133,9 @@ func (s *DataStore) SetBundle(ctx context.Context, req *datastore.SetBundleReque
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	bundle := req.Bundle
+	bundle := cloneBundle(req.Bundle)
 
+	bundle.RevisionNumber = s.nextRevision()
 	s.bundles[bundle.TrustDomainId] = cloneBundle(bundle)
 
 	return &datastore.SetBundleResponse{
@@ -122,13 +149,17 @@ func (s *DataStore) AppendBundle(ctx context.Context, req *datastore.AppendBundl
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	bundle := req.Bundle
+	bundle := cloneBundle(req.Bundle)
 
+	changed := true
 	if existingBundle, ok := s.bundles[bundle.TrustDomainId]; ok {
-		bundle, _ = bundleutil.MergeBundles(existingBundle, bundle)
+		bundle, changed = bundleutil.MergeBundles(existingBundle, bundle)
 	}
 
-	s.bundles[bundle.TrustDomainId] = cloneBundle(bundle)
+	if changed {
+		bundle.RevisionNumber = s.nextRevision()
+		s.bundles[bundle.TrustDomainId] = cloneBundle(bundle)
+	}
 
 	return &datastore.AppendBundleResponse{
 		Bundle: cloneBundle(bundle),
@@ -148,13 +179,17 @@ func (s *DataStore) DeleteBundle(ctx context.Context, req *datastore.DeleteBundl
 	if bundleEntries := s.bundleEntries[req.TrustDomainId]; len(bundleEntries) > 0 {
 		switch req.Mode {
 		case datastore.DeleteBundleRequest_DELETE:
+			revision := s.nextRevision()
 			for entryID := range bundleEntries {
-				delete(s.registrationEntries, entryID)
+				s.deleteRegistrationEntry(entryID, revision)
 			}
 		case datastore.DeleteBundleRequest_DISSOCIATE:
+			revision := s.nextRevision()
 			for entryID := range bundleEntries {
 				if entry := s.registrationEntries[entryID]; entry != nil {
 					entry.FederatesWith = removeString(entry.FederatesWith, req.TrustDomainId)
+					entry.RevisionNumber = revision
+					s.addEntryEvent(entryID, datastore.RegistrationEntryEvent_UPDATE)
 				}
 			}
 		default:
@@ -221,6 +256,7 @@ func (s *DataStore) PruneBundle(ctx context.Context, req *datastore.PruneBundleR
 
 	// If any cert was pruned, then update the bundle
 	if changed {
+		newBundle.RevisionNumber = s.nextRevision()
 		s.bundles[req.TrustDomainId] = newBundle
 	}
 
@@ -274,15 +310,56 @@ func (s *DataStore) ListAttestedNodes(ctx context.Context,
 	}
 	sort.Strings(keys)
 
-	resp := new(datastore.ListAttestedNodesResponse)
+	// the token is the SPIFFE ID of the last node of the previous page
+	p := req.Pagination
+	paginated := p != nil && p.PageSize > 0
+
+	resp := &datastore.ListAttestedNodesResponse{
+		Pagination: p,
+	}
 	for _, key := range keys {
+		if paginated && key <= p.Token {
+			continue
+		}
+
 		attestedNodeEntry := s.attestedNodes[key]
 		if req.ByExpiresBefore != nil {
 			if attestedNodeEntry.CertNotAfter >= req.ByExpiresBefore.Value {
 				continue
 			}
 		}
-		resp.Nodes = append(resp.Nodes, cloneAttestedNode(attestedNodeEntry))
+		if req.ByExpiresAfter != nil && attestedNodeEntry.CertNotAfter < req.ByExpiresAfter.Value {
+			continue
+		}
+		if req.ByAttestationType != nil && attestedNodeEntry.AttestationDataType != req.ByAttestationType.Value {
+			continue
+		}
+		if req.ByBanned != nil && attestedNodeEntry.Banned != req.ByBanned.Value {
+			continue
+		}
+		if req.BySelectorMatch != nil && len(req.BySelectorMatch.Selectors) > 0 {
+			matches, err := matchesBySelectors(s.nodeSelectors[key], req.BySelectorMatch)
+			if err != nil {
+				return nil, err
+			}
+			if !matches {
+				continue
+			}
+		}
+
+		node := cloneAttestedNode(attestedNodeEntry)
+		if req.FetchSelectors {
+			node.Selectors = cloneSelectors(s.nodeSelectors[key])
+		}
+		resp.Nodes = append(resp.Nodes, node)
+
+		if paginated && len(resp.Nodes) == int(p.PageSize) {
+			break
+		}
+	}
+
+	if paginated && len(resp.Nodes) > 0 {
+		p.Token = resp.Nodes[len(resp.Nodes)-1].SpiffeId
 	}
 
 	return resp, nil
@@ -306,6 +383,23 @@ func (s *DataStore) UpdateAttestedNode(ctx context.Context,
 	}, nil
 }
 
+func (s *DataStore) SetAttestedNodeBanned(ctx context.Context,
+	req *datastore.SetAttestedNodeBannedRequest) (*datastore.SetAttestedNodeBannedResponse, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	node, ok := s.attestedNodes[req.SpiffeId]
+	if !ok {
+		return nil, ErrNoSuchAttestedNode
+	}
+	node.Banned = req.Banned
+
+	return &datastore.SetAttestedNodeBannedResponse{
+		Node: cloneAttestedNode(node),
+	}, nil
+}
+
 func (s *DataStore) DeleteAttestedNode(ctx context.Context,
 	req *datastore.DeleteAttestedNodeRequest) (*datastore.DeleteAttestedNodeResponse, error) {
 
@@ -329,6 +423,8 @@ func (s *DataStore) SetNodeSelectors(ctx context.Context,
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	s.nodeSelectorEventID++
+	s.nodeSelectorEvents[req.Selectors.SpiffeId] = s.nodeSelectorEventID
 	s.nodeSelectors[req.Selectors.SpiffeId] = cloneSelectors(req.Selectors.Selectors)
 	return &datastore.SetNodeSelectorsResponse{}, nil
 }
@@ -349,27 +445,47 @@ func (s *DataStore) GetNodeSelectors(ctx context.Context,
 	}, nil
 }
 
-func (s *DataStore) CreateRegistrationEntry(ctx context.Context,
-	req *datastore.CreateRegistrationEntryRequest) (*datastore.CreateRegistrationEntryResponse, error) {
+func (s *DataStore) ListNodeSelectors(ctx context.Context,
+	req *datastore.ListNodeSelectorsRequest) (*datastore.ListNodeSelectorsResponse, error) {
 
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	entryID, err := newRegistrationEntryID()
-	if err != nil {
-		return nil, err
+	resp := &datastore.ListNodeSelectorsResponse{
+		LatestEventId: s.nodeSelectorEventID,
 	}
+	for spiffeID, selectors := range s.nodeSelectors {
+		if req.AfterEventId != nil {
+			if s.nodeSelectorEvents[spiffeID] <= req.AfterEventId.Value {
+				continue
+			}
+		} else if len(selectors) == 0 {
+			continue
+		}
+		resp.Selectors = append(resp.Selectors, &datastore.NodeSelectors{
+			SpiffeId:  spiffeID,
+			Selectors: cloneSelectors(selectors),
+		})
+	}
+	sort.Slice(resp.Selectors, func(i, j int) bool {
+		return resp.Selectors[i].SpiffeId < resp.Selectors[j].SpiffeId
+	})
+	return resp, nil
+}
 
-	entry := cloneRegistrationEntry(req.Entry)
-	entry.EntryId = entryID
-	s.registrationEntries[entryID] = entry
+func (s *DataStore) CreateRegistrationEntry(ctx context.Context,
+	req *datastore.CreateRegistrationEntryRequest) (*datastore.CreateRegistrationEntryResponse, error) {
 
-	if err := s.addBundleLinks(entryID, req.Entry.FederatesWith); err != nil {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, err := s.createRegistrationEntry(req.Entry)
+	if err != nil {
 		return nil, err
 	}
 
 	return &datastore.CreateRegistrationEntryResponse{
-		Entry: cloneRegistrationEntry(entry),
+		Entry: entry,
 	}, nil
 }
 
@@ -404,38 +520,29 @@ func (s *DataStore) ListRegistrationEntries(ctx context.Context,
 		if req.BySpiffeId != nil && entry.SpiffeId != req.BySpiffeId.Value {
 			continue
 		}
+		if req.BySpiffeIdPrefix != nil && !strings.HasPrefix(entry.SpiffeId, req.BySpiffeIdPrefix.Value) {
+			continue
+		}
+		if req.ByAdmin != nil && entry.Admin != req.ByAdmin.Value {
+			continue
+		}
+		if req.ByDownstream != nil && entry.Downstream != req.ByDownstream.Value {
+			continue
+		}
+		if !federatesWithAll(entry, req.ByFederatesWith) {
+			continue
+		}
 
 		entriesSet[entry.EntryId] = entry
 	}
 
 	if req.BySelectors != nil && len(req.BySelectors.Selectors) > 0 {
-		var selectorsList [][]*common.Selector
-		selectorSet := selector.NewSetFromRaw(req.BySelectors.Selectors)
-		switch req.BySelectors.Match {
-		case datastore.BySelectors_MATCH_EXACT:
-			selectorsList = append(selectorsList, selectorSet.Raw())
-		case datastore.BySelectors_MATCH_SUBSET:
-			for combination := range selectorSet.Power() {
-				selectorsList = append(selectorsList, combination.Raw())
-			}
-		default:
-			return nil, fmt.Errorf("unhandled match behavior %q", req.BySelectors.Match)
-		}
-
-		// filter entries that don't match at least one selector set
 		for entryID, entry := range entriesSet {
-			matchesOne := false
-			for _, selectors := range selectorsList {
-				if !matchesSelectors(entry.Selectors, selectors) {
-					continue
-				}
-				if len(entry.Selectors) != len(selectors) {
-					continue
-				}
-				matchesOne = true
-				break
+			matches, err := matchesBySelectors(entry.Selectors, req.BySelectors)
+			if err != nil {
+				return nil, err
 			}
-			if !matchesOne {
+			if !matches {
 				delete(entriesSet, entryID)
 			}
 		}
@@ -495,7 +602,7 @@ func indexOf(element string, entries []*common.RegistrationEntry) int {
 	return -1
 }
 
-func (s DataStore) UpdateRegistrationEntry(ctx context.Context,
+func (s *DataStore) UpdateRegistrationEntry(ctx context.Context,
 	req *datastore.UpdateRegistrationEntryRequest) (*datastore.UpdateRegistrationEntryResponse, error) {
 
 	s.mu.Lock()
@@ -505,13 +612,18 @@ func (s DataStore) UpdateRegistrationEntry(ctx context.Context,
 	if !ok {
 		return nil, ErrNoSuchRegistrationEntry
 	}
+	if req.Entry.RevisionNumber != 0 && req.Entry.RevisionNumber != oldEntry.RevisionNumber {
+		return nil, ErrRegistrationEntryRevisionMismatch
+	}
 
 	s.removeBundleLinks(oldEntry.EntryId, oldEntry.FederatesWith)
 
-	entry := cloneRegistrationEntry(req.Entry)
+	entry := util.ApplyRegistrationEntryMask(oldEntry, req.Entry, req.Mask)
+	entry.RevisionNumber = s.nextRevision()
 	s.registrationEntries[req.Entry.EntryId] = entry
+	s.addEntryEvent(entry.EntryId, datastore.RegistrationEntryEvent_UPDATE)
 
-	if err := s.addBundleLinks(entry.EntryId, req.Entry.FederatesWith); err != nil {
+	if err := s.addBundleLinks(entry.EntryId, entry.FederatesWith); err != nil {
 		return nil, err
 	}
 
@@ -530,28 +642,229 @@ func (s *DataStore) DeleteRegistrationEntry(ctx context.Context,
 	if !ok {
 		return nil, ErrNoSuchRegistrationEntry
 	}
-	delete(s.registrationEntries, req.EntryId)
-
-	s.removeBundleLinks(req.EntryId, registrationEntry.FederatesWith)
+	s.deleteRegistrationEntry(req.EntryId, s.nextRevision())
 
 	return &datastore.DeleteRegistrationEntryResponse{
 		Entry: cloneRegistrationEntry(registrationEntry),
 	}, nil
 }
 
+func (s *DataStore) BatchCreateRegistrationEntries(ctx context.Context,
+	req *datastore.BatchCreateRegistrationEntriesRequest) (*datastore.BatchCreateRegistrationEntriesResponse, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results := newBatchResults(len(req.Entries))
+	for i, entry := range req.Entries {
+		exists := false
+		for _, existing := range s.registrationEntries {
+			exists = exists || sameRegistrationEntry(entry, existing)
+		}
+		for j := 0; j < i; j++ {
+			exists = exists || (results[j].Code == int32(codes.OK) && sameRegistrationEntry(entry, req.Entries[j]))
+		}
+		switch {
+		case exists:
+			setBatchResultError(results[i], codes.AlreadyExists, "entry already exists")
+		case !s.hasBundles(entry.FederatesWith):
+			setBatchResultError(results[i], codes.FailedPrecondition, "unable to find federated bundle")
+		}
+	}
+
+	resp := &datastore.BatchCreateRegistrationEntriesResponse{
+		Results: results,
+	}
+	if abortBatch(results, req.AllOrNothing) {
+		return resp, nil
+	}
+
+	for i, entry := range req.Entries {
+		if results[i].Code != int32(codes.OK) {
+			continue
+		}
+		created, err := s.createRegistrationEntry(entry)
+		if err != nil {
+			return nil, err
+		}
+		results[i].Entry = created
+	}
+
+	return resp, nil
+}
+
+func (s *DataStore) BatchUpdateRegistrationEntries(ctx context.Context,
+	req *datastore.BatchUpdateRegistrationEntriesRequest) (*datastore.BatchUpdateRegistrationEntriesResponse, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results := newBatchResults(len(req.Entries))
+	updated := make(map[string]bool)
+	for i, entry := range req.Entries {
+		oldEntry, ok := s.registrationEntries[entry.EntryId]
+		switch {
+		case !ok:
+			setBatchResultError(results[i], codes.NotFound, "no such registration entry")
+		case entry.RevisionNumber != 0 && (updated[entry.EntryId] || entry.RevisionNumber != oldEntry.RevisionNumber):
+			setBatchResultError(results[i], codes.FailedPrecondition, "registration entry revision mismatch")
+		case !s.hasBundles(entry.FederatesWith):
+			setBatchResultError(results[i], codes.FailedPrecondition, "unable to find federated bundle")
+		}
+		updated[entry.EntryId] = true
+	}
+
+	resp := &datastore.BatchUpdateRegistrationEntriesResponse{
+		Results: results,
+	}
+	if abortBatch(results, req.AllOrNothing) {
+		return resp, nil
+	}
+
+	for i, entry := range req.Entries {
+		if results[i].Code != int32(codes.OK) {
+			continue
+		}
+		oldEntry := s.registrationEntries[entry.EntryId]
+		s.removeBundleLinks(oldEntry.EntryId, oldEntry.FederatesWith)
+
+		updated := cloneRegistrationEntry(entry)
+		updated.RevisionNumber = s.nextRevision()
+		s.registrationEntries[entry.EntryId] = updated
+		s.addEntryEvent(updated.EntryId, datastore.RegistrationEntryEvent_UPDATE)
+
+		if err := s.addBundleLinks(updated.EntryId, updated.FederatesWith); err != nil {
+			return nil, err
+		}
+		results[i].Entry = cloneRegistrationEntry(updated)
+	}
+
+	return resp, nil
+}
+
+func (s *DataStore) BatchDeleteRegistrationEntries(ctx context.Context,
+	req *datastore.BatchDeleteRegistrationEntriesRequest) (*datastore.BatchDeleteRegistrationEntriesResponse, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results := newBatchResults(len(req.EntryIds))
+	deleted := make(map[string]bool)
+	for i, entryID := range req.EntryIds {
+		if _, ok := s.registrationEntries[entryID]; !ok || deleted[entryID] {
+			setBatchResultError(results[i], codes.NotFound, "no such registration entry")
+			continue
+		}
+		deleted[entryID] = true
+	}
+
+	resp := &datastore.BatchDeleteRegistrationEntriesResponse{
+		Results: results,
+	}
+	if abortBatch(results, req.AllOrNothing) {
+		return resp, nil
+	}
+
+	for i, entryID := range req.EntryIds {
+		if results[i].Code != int32(codes.OK) {
+			continue
+		}
+		results[i].Entry = cloneRegistrationEntry(s.registrationEntries[entryID])
+		s.deleteRegistrationEntry(entryID, s.nextRevision())
+	}
+
+	return resp, nil
+}
+
 func (s *DataStore) PruneRegistrationEntries(ctx context.Context, req *datastore.PruneRegistrationEntriesRequest) (*datastore.PruneRegistrationEntriesResponse, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	var revision int64
 	for key, entry := range s.registrationEntries {
 		if entry.EntryExpiry != 0 && entry.EntryExpiry < req.ExpiresBefore {
-			delete(s.registrationEntries, key)
+			if revision == 0 {
+				revision = s.nextRevision()
+			}
+			s.deleteRegistrationEntry(key, revision)
 		}
 	}
 
 	return &datastore.PruneRegistrationEntriesResponse{}, nil
 }
 
+func (s *DataStore) ListRegistrationEntryTombstones(ctx context.Context, req *datastore.ListRegistrationEntryTombstonesRequest) (*datastore.ListRegistrationEntryTombstonesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := &datastore.ListRegistrationEntryTombstonesResponse{
+		Revision: s.revision,
+	}
+	for _, tombstone := range s.entryTombstones {
+		if tombstone.revision > req.AfterRevision {
+			resp.EntryIds = append(resp.EntryIds, tombstone.entryID)
+		}
+	}
+	return resp, nil
+}
+
+func (s *DataStore) PruneRegistrationEntryTombstones(ctx context.Context, req *datastore.PruneRegistrationEntryTombstonesRequest) (*datastore.PruneRegistrationEntryTombstonesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var tombstones []entryTombstone
+	for _, tombstone := range s.entryTombstones {
+		if tombstone.deletedAt.Unix() >= req.DeletedBefore {
+			tombstones = append(tombstones, tombstone)
+		}
+	}
+	s.entryTombstones = tombstones
+
+	return &datastore.PruneRegistrationEntryTombstonesResponse{}, nil
+}
+
+// ListRegistrationEntryEvents lists the registration entry events recorded
+// after the requested event ID
+func (s *DataStore) ListRegistrationEntryEvents(ctx context.Context, req *datastore.ListRegistrationEntryEventsRequest) (*datastore.ListRegistrationEntryEventsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := new(datastore.ListRegistrationEntryEventsResponse)
+	if len(s.entryEvents) > 0 {
+		resp.OldestId = s.entryEvents[0].Id
+		resp.LatestId = s.entryEvents[len(s.entryEvents)-1].Id
+	}
+	resp.PrunedId = s.prunedEntryEventID
+	for _, event := range s.entryEvents {
+		if req.Limit > 0 && len(resp.Events) >= int(req.Limit) {
+			break
+		}
+		if event.Id > req.AfterId {
+			resp.Events = append(resp.Events, proto.Clone(event).(*datastore.RegistrationEntryEvent))
+		}
+	}
+	return resp, nil
+}
+
+// PruneRegistrationEntryEvents prunes the registration entry events recorded
+// before the requested time, keeping the latest one
+func (s *DataStore) PruneRegistrationEntryEvents(ctx context.Context, req *datastore.PruneRegistrationEntryEventsRequest) (*datastore.PruneRegistrationEntryEventsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var events []*datastore.RegistrationEntryEvent
+	for i, event := range s.entryEvents {
+		if event.CreatedAt >= req.CreatedBefore || i == len(s.entryEvents)-1 {
+			events = append(events, event)
+		} else if event.Id > s.prunedEntryEventID {
+			s.prunedEntryEventID = event.Id
+		}
+	}
+	s.entryEvents = events
+
+	return &datastore.PruneRegistrationEntryEventsResponse{}, nil
+}
+
 // CreateJoinToken takes a Token message and stores it
 func (s *DataStore) CreateJoinToken(ctx context.Context, req *datastore.CreateJoinTokenRequest) (*datastore.CreateJoinTokenResponse, error) {
 	s.mu.Lock()
@@ -583,6 +896,57 @@ func (s *DataStore) FetchJoinToken(ctx context.Context, req *datastore.FetchJoin
 	}, nil
 }
 
+func (s *DataStore) ListJoinTokens(ctx context.Context, req *datastore.ListJoinTokensRequest) (*datastore.ListJoinTokensResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// get an ordered list of tokens by expiry, then token value
+	var tokens []*datastore.JoinToken
+	for _, token := range s.tokens {
+		tokens = append(tokens, cloneJoinToken(token))
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		if tokens[i].Expiry != tokens[j].Expiry {
+			return tokens[i].Expiry < tokens[j].Expiry
+		}
+		return tokens[i].Token < tokens[j].Token
+	})
+
+	return &datastore.ListJoinTokensResponse{
+		JoinTokens: tokens,
+	}, nil
+}
+
+func (s *DataStore) ConsumeJoinToken(ctx context.Context, req *datastore.ConsumeJoinTokenRequest) (*datastore.ConsumeJoinTokenResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	joinToken, ok := s.tokens[req.Token]
+	if !ok {
+		return &datastore.ConsumeJoinTokenResponse{}, nil
+	}
+
+	joinToken.Uses++
+	if joinToken.Uses >= joinToken.MaxUses {
+		delete(s.tokens, req.Token)
+	}
+
+	resp := &datastore.ConsumeJoinTokenResponse{
+		JoinToken: cloneJoinToken(joinToken),
+	}
+	for _, entry := range joinToken.Entries {
+		entry = cloneRegistrationEntry(entry)
+		entry.ParentId = req.AgentId
+		created, err := s.createRegistrationEntry(entry)
+		if err != nil {
+			return nil, err
+		}
+		resp.Entries = append(resp.Entries, created)
+	}
+
+	return resp, nil
+}
+
 func (s *DataStore) DeleteJoinToken(ctx context.Context, req *datastore.DeleteJoinTokenRequest) (*datastore.DeleteJoinTokenResponse, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -611,6 +975,250 @@ func (s *DataStore) PruneJoinTokens(ctx context.Context, req *datastore.PruneJoi
 	return &datastore.PruneJoinTokensResponse{}, nil
 }
 
+func (s *DataStore) FetchCAJournal(ctx context.Context, req *datastore.FetchCAJournalRequest) (*datastore.FetchCAJournalResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	journal, ok := s.caJournals[req.Id]
+	if !ok {
+		return &datastore.FetchCAJournalResponse{}, nil
+	}
+
+	return &datastore.FetchCAJournalResponse{
+		Journal: cloneCAJournal(journal),
+	}, nil
+}
+
+func (s *DataStore) SetCAJournal(ctx context.Context, req *datastore.SetCAJournalRequest) (*datastore.SetCAJournalResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var revision int64
+	if existing, ok := s.caJournals[req.Journal.Id]; ok {
+		revision = existing.Revision
+	}
+	if revision != req.Journal.Revision {
+		return nil, ErrCAJournalRevisionMismatch
+	}
+
+	journal := cloneCAJournal(req.Journal)
+	journal.Revision++
+	s.caJournals[journal.Id] = journal
+
+	return &datastore.SetCAJournalResponse{
+		Journal: cloneCAJournal(journal),
+	}, nil
+}
+
+func (s *DataStore) AcquireLease(ctx context.Context, req *datastore.AcquireLeaseRequest) (*datastore.AcquireLeaseResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lease, ok := s.leases[req.Name]
+	if !ok || lease.HolderId == req.HolderId || lease.ExpiresAt <= req.Now {
+		lease = &datastore.Lease{
+			Name:      req.Name,
+			HolderId:  req.HolderId,
+			ExpiresAt: req.ExpiresAt,
+		}
+		s.leases[req.Name] = lease
+	}
+
+	return &datastore.AcquireLeaseResponse{
+		Lease: cloneLease(lease),
+	}, nil
+}
+
+func (s *DataStore) ReleaseLease(ctx context.Context, req *datastore.ReleaseLeaseRequest) (*datastore.ReleaseLeaseResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lease, ok := s.leases[req.Name]
+	if !ok || lease.HolderId != req.HolderId {
+		return &datastore.ReleaseLeaseResponse{}, nil
+	}
+	delete(s.leases, req.Name)
+
+	return &datastore.ReleaseLeaseResponse{
+		Lease: cloneLease(lease),
+	}, nil
+}
+
+func (s *DataStore) RevokeCertificate(ctx context.Context, req *datastore.RevokeCertificateRequest) (*datastore.RevokeCertificateResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	revoked, ok := s.revokedCertificates[req.RevokedCertificate.SerialNumber]
+	if !ok {
+		revoked = cloneRevokedCertificate(req.RevokedCertificate)
+		s.revokedCertificates[revoked.SerialNumber] = revoked
+	}
+
+	return &datastore.RevokeCertificateResponse{
+		RevokedCertificate: cloneRevokedCertificate(revoked),
+	}, nil
+}
+
+func (s *DataStore) FetchRevokedCertificate(ctx context.Context, req *datastore.FetchRevokedCertificateRequest) (*datastore.FetchRevokedCertificateResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	revoked, ok := s.revokedCertificates[req.SerialNumber]
+	if !ok {
+		return &datastore.FetchRevokedCertificateResponse{}, nil
+	}
+
+	return &datastore.FetchRevokedCertificateResponse{
+		RevokedCertificate: cloneRevokedCertificate(revoked),
+	}, nil
+}
+
+func (s *DataStore) ListRevokedCertificates(ctx context.Context, req *datastore.ListRevokedCertificatesRequest) (*datastore.ListRevokedCertificatesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := new(datastore.ListRevokedCertificatesResponse)
+	for _, revoked := range s.revokedCertificates {
+		resp.RevokedCertificates = append(resp.RevokedCertificates, cloneRevokedCertificate(revoked))
+	}
+	sort.Slice(resp.RevokedCertificates, func(i, j int) bool {
+		return resp.RevokedCertificates[i].SerialNumber < resp.RevokedCertificates[j].SerialNumber
+	})
+
+	return resp, nil
+}
+
+func (s *DataStore) PruneRevokedCertificates(ctx context.Context, req *datastore.PruneRevokedCertificatesRequest) (*datastore.PruneRevokedCertificatesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, revoked := range s.revokedCertificates {
+		if revoked.ExpiresAt < req.ExpiresBefore {
+			delete(s.revokedCertificates, key)
+		}
+	}
+
+	return &datastore.PruneRevokedCertificatesResponse{}, nil
+}
+
+func (s *DataStore) CreateDownstreamCA(ctx context.Context, req *datastore.CreateDownstreamCARequest) (*datastore.CreateDownstreamCAResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.downstreamCAs[req.DownstreamCa.SerialNumber]; ok {
+		return nil, ErrDownstreamCAAlreadyExists
+	}
+	s.downstreamCAs[req.DownstreamCa.SerialNumber] = cloneDownstreamCA(req.DownstreamCa)
+
+	return &datastore.CreateDownstreamCAResponse{
+		DownstreamCa: cloneDownstreamCA(req.DownstreamCa),
+	}, nil
+}
+
+func (s *DataStore) ListDownstreamCAs(ctx context.Context, req *datastore.ListDownstreamCAsRequest) (*datastore.ListDownstreamCAsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	resp := new(datastore.ListDownstreamCAsResponse)
+	for _, downstreamCA := range s.downstreamCAs {
+		if req.AgentId != "" && downstreamCA.AgentId != req.AgentId {
+			continue
+		}
+		resp.DownstreamCas = append(resp.DownstreamCas, cloneDownstreamCA(downstreamCA))
+	}
+	sort.Slice(resp.DownstreamCas, func(i, j int) bool {
+		return resp.DownstreamCas[i].SerialNumber < resp.DownstreamCas[j].SerialNumber
+	})
+
+	return resp, nil
+}
+
+func (s *DataStore) PruneDownstreamCAs(ctx context.Context, req *datastore.PruneDownstreamCAsRequest) (*datastore.PruneDownstreamCAsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for key, downstreamCA := range s.downstreamCAs {
+		if downstreamCA.ExpiresAt < req.ExpiresBefore {
+			delete(s.downstreamCAs, key)
+		}
+	}
+
+	return &datastore.PruneDownstreamCAsResponse{}, nil
+}
+
+func (s *DataStore) CreateIssuedSVID(ctx context.Context, req *datastore.CreateIssuedSVIDRequest) (*datastore.CreateIssuedSVIDResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextIssuedSVIDID++
+	s.issuedSVIDs = append(s.issuedSVIDs, issuedSVIDRecord{
+		id:   s.nextIssuedSVIDID,
+		svid: cloneIssuedSVID(req.IssuedSvid),
+	})
+
+	return &datastore.CreateIssuedSVIDResponse{
+		IssuedSvid: cloneIssuedSVID(req.IssuedSvid),
+	}, nil
+}
+
+func (s *DataStore) ListIssuedSVIDs(ctx context.Context, req *datastore.ListIssuedSVIDsRequest) (*datastore.ListIssuedSVIDsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var after int64
+	p := req.Pagination
+	if p != nil && p.PageSize > 0 && p.Token != "" {
+		var err error
+		after, err = strconv.ParseInt(p.Token, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse token '%v'", p.Token)
+		}
+	}
+
+	resp := &datastore.ListIssuedSVIDsResponse{
+		Pagination: p,
+	}
+	for _, record := range s.issuedSVIDs {
+		svid := record.svid
+		switch {
+		case record.id <= after:
+			continue
+		case req.BySpiffeId != nil && svid.SpiffeId != req.BySpiffeId.Value:
+			continue
+		case req.ByAgentId != nil && svid.AgentId != req.ByAgentId.Value:
+			continue
+		case req.IssuedAfter != 0 && svid.NotBefore < req.IssuedAfter:
+			continue
+		case req.IssuedBefore != 0 && svid.NotBefore >= req.IssuedBefore:
+			continue
+		}
+		resp.IssuedSvids = append(resp.IssuedSvids, cloneIssuedSVID(svid))
+		if p != nil && p.PageSize > 0 {
+			p.Token = fmt.Sprint(record.id)
+			if len(resp.IssuedSvids) == int(p.PageSize) {
+				break
+			}
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *DataStore) PruneIssuedSVIDs(ctx context.Context, req *datastore.PruneIssuedSVIDsRequest) (*datastore.PruneIssuedSVIDsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var kept []issuedSVIDRecord
+	for _, record := range s.issuedSVIDs {
+		if record.svid.NotAfter >= req.ExpiresBefore {
+			kept = append(kept, record)
+		}
+	}
+	s.issuedSVIDs = kept
+
+	return &datastore.PruneIssuedSVIDsResponse{}, nil
+}
+
 func (s *DataStore) Configure(ctx context.Context, req *spi.ConfigureRequest) (*spi.ConfigureResponse, error) {
 	return &spi.ConfigureResponse{}, nil
 }
@@ -619,6 +1227,15 @@ func (DataStore) GetPluginInfo(context.Context, *spi.GetPluginInfoRequest) (*spi
 	return &spi.GetPluginInfoResponse{}, nil
 }
 
+func (s *DataStore) hasBundles(bundleIDs []string) bool {
+	for _, bundleID := range bundleIDs {
+		if _, ok := s.bundles[bundleID]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
 func (s *DataStore) addBundleLinks(entryID string, bundleIDs []string) error {
 	for _, bundleID := range bundleIDs {
 		if _, ok := s.bundles[bundleID]; !ok {
@@ -634,6 +1251,36 @@ func (s *DataStore) addBundleLinks(entryID string, bundleIDs []string) error {
 	return nil
 }
 
+func (s *DataStore) nextRevision() int64 {
+	s.revision++
+	return s.revision
+}
+
+func (s *DataStore) deleteRegistrationEntry(entryID string, revision int64) {
+	entry, ok := s.registrationEntries[entryID]
+	if !ok {
+		return
+	}
+	delete(s.registrationEntries, entryID)
+	s.removeBundleLinks(entryID, entry.FederatesWith)
+	s.entryTombstones = append(s.entryTombstones, entryTombstone{
+		entryID:   entryID,
+		revision:  revision,
+		deletedAt: time.Now(),
+	})
+	s.addEntryEvent(entryID, datastore.RegistrationEntryEvent_DELETE)
+}
+
+func (s *DataStore) addEntryEvent(entryID string, eventType datastore.RegistrationEntryEvent_Type) {
+	s.nextEntryEventID++
+	s.entryEvents = append(s.entryEvents, &datastore.RegistrationEntryEvent{
+		Id:        s.nextEntryEventID,
+		Type:      eventType,
+		EntryId:   entryID,
+		CreatedAt: time.Now().Unix(),
+	})
+}
+
 func (s *DataStore) removeBundleLinks(entryID string, bundleIDs []string) {
 	for _, bundleID := range bundleIDs {
 		delete(s.bundleEntries[bundleID], entryID)
@@ -660,10 +1307,100 @@ func cloneRegistrationEntry(registrationEntry *common.RegistrationEntry) *common
 	return proto.Clone(registrationEntry).(*common.RegistrationEntry)
 }
 
+func (s *DataStore) createRegistrationEntry(entry *common.RegistrationEntry) (*common.RegistrationEntry, error) {
+	entryID, err := newRegistrationEntryID()
+	if err != nil {
+		return nil, err
+	}
+
+	entry = cloneRegistrationEntry(entry)
+	entry.EntryId = entryID
+	entry.RevisionNumber = s.nextRevision()
+	s.registrationEntries[entryID] = entry
+	s.addEntryEvent(entryID, datastore.RegistrationEntryEvent_CREATE)
+
+	if err := s.addBundleLinks(entryID, entry.FederatesWith); err != nil {
+		return nil, err
+	}
+
+	return cloneRegistrationEntry(entry), nil
+}
+
+func sameRegistrationEntry(a, b *common.RegistrationEntry) bool {
+	return a.SpiffeId == b.SpiffeId &&
+		a.ParentId == b.ParentId &&
+		selector.NewSetFromRaw(a.Selectors).Equal(selector.NewSetFromRaw(b.Selectors))
+}
+
+func newBatchResults(n int) []*datastore.BatchRegistrationEntryResult {
+	results := make([]*datastore.BatchRegistrationEntryResult, 0, n)
+	for i := 0; i < n; i++ {
+		results = append(results, &datastore.BatchRegistrationEntryResult{})
+	}
+	return results
+}
+
+func setBatchResultError(result *datastore.BatchRegistrationEntryResult, code codes.Code, message string) {
+	result.Code = int32(code)
+	result.Message = message
+}
+
+func abortBatch(results []*datastore.BatchRegistrationEntryResult, allOrNothing bool) bool {
+	if !allOrNothing {
+		return false
+	}
+
+	failed := false
+	for _, result := range results {
+		failed = failed || result.Code != int32(codes.OK)
+	}
+	if !failed {
+		return false
+	}
+
+	for _, result := range results {
+		if result.Code == int32(codes.OK) {
+			setBatchResultError(result, codes.Aborted, "batch aborted because another operation failed")
+		}
+	}
+	return true
+}
+
 func cloneJoinToken(token *datastore.JoinToken) *datastore.JoinToken {
 	return proto.Clone(token).(*datastore.JoinToken)
 }
 
+func cloneCAJournal(journal *datastore.CAJournal) *datastore.CAJournal {
+	return proto.Clone(journal).(*datastore.CAJournal)
+}
+
+func cloneLease(lease *datastore.Lease) *datastore.Lease {
+	return proto.Clone(lease).(*datastore.Lease)
+}
+
+func cloneRevokedCertificate(revoked *datastore.RevokedCertificate) *datastore.RevokedCertificate {
+	return proto.Clone(revoked).(*datastore.RevokedCertificate)
+}
+
+func cloneDownstreamCA(downstreamCA *datastore.DownstreamCA) *datastore.DownstreamCA {
+	return proto.Clone(downstreamCA).(*datastore.DownstreamCA)
+}
+
+func cloneIssuedSVID(issuedSVID *datastore.IssuedSVID) *datastore.IssuedSVID {
+	return proto.Clone(issuedSVID).(*datastore.IssuedSVID)
+}
+
+type entryTombstone struct {
+	entryID   string
+	revision  int64
+	deletedAt time.Time
+}
+
+type issuedSVIDRecord struct {
+	id   int64
+	svid *datastore.IssuedSVID
+}
+
 func newRegistrationEntryID() (string, error) {
 	u, err := uuid.NewV4()
 	if err != nil {
@@ -672,6 +1409,22 @@ func newRegistrationEntryID() (string, error) {
 	return u.String(), nil
 }
 
+// matchesBySelectors returns true if the selectors match those in the
+// request according to its match behavior
+func matchesBySelectors(selectors []*common.Selector, by *datastore.BySelectors) (bool, error) {
+	selectorSet := selector.NewSetFromRaw(by.Selectors)
+	switch by.Match {
+	case datastore.BySelectors_MATCH_EXACT:
+		return len(selectors) == selectorSet.Size() && matchesSelectors(selectors, selectorSet.Raw()), nil
+	case datastore.BySelectors_MATCH_SUBSET:
+		return len(selectors) > 0 && selectorSet.IncludesSet(selector.NewSetFromRaw(selectors)), nil
+	case datastore.BySelectors_MATCH_SUPERSET:
+		return selector.NewSetFromRaw(selectors).IncludesSet(selectorSet), nil
+	default:
+		return false, fmt.Errorf("unhandled match behavior %q", by.Match)
+	}
+}
+
 func matchesSelectors(a, b []*common.Selector) bool {
 	a = append([]*common.Selector{}, a...)
 	util.SortSelectors(a)
@@ -688,6 +1441,22 @@ func matchesSelectors(a, b []*common.Selector) bool {
 	return true
 }
 
+func federatesWithAll(entry *common.RegistrationEntry, trustDomains []string) bool {
+	for _, trustDomain := range trustDomains {
+		found := false
+		for _, id := range entry.FederatesWith {
+			if id == trustDomain {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
 func removeString(list []string, s string) []string {
 	out := make([]string, 0, len(list))
 	for _, entry := range list {
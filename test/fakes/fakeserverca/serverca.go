@@ -12,7 +12,7 @@ import (
 	"github.com/spiffe/spire/pkg/common/pemutil"
 	"github.com/spiffe/spire/pkg/common/telemetry"
 	"github.com/spiffe/spire/pkg/server/ca"
-	"github.com/spiffe/spire/proto/spire/server/upstreamca"
+	"github.com/spiffe/spire/proto/spire/server/upstreamauthority"
 	"github.com/spiffe/spire/test/clock"
 	"github.com/stretchr/testify/require"
 )
@@ -29,10 +29,11 @@ qQDuoXqa8i3YOPk5fLib4ORzqD9NJFcrKjI+LLtipQe9yu/eY1K0yhBa
 )
 
 type Options struct {
-	Clock          clock.Clock
-	X509SVIDTTL    time.Duration
-	UpstreamCA     upstreamca.UpstreamCA
-	UpstreamBundle bool
+	Clock             clock.Clock
+	X509SVIDTTL       time.Duration
+	UpstreamAuthority upstreamauthority.UpstreamAuthority
+	UpstreamBundle    bool
+	IssuanceLog       ca.IssuanceLog
 }
 
 type CA struct {
@@ -60,8 +61,8 @@ func New(t *testing.T, trustDomain string, options *Options) *CA {
 	var x509CA *ca.X509CA
 	var bundle []*x509.Certificate
 	var err error
-	if options.UpstreamCA != nil {
-		x509CA, bundle, err = ca.UpstreamSignX509CA(context.Background(), signer, trustDomain, subject, options.UpstreamCA, options.UpstreamBundle)
+	if options.UpstreamAuthority != nil {
+		x509CA, bundle, err = ca.UpstreamSignX509CA(context.Background(), signer, trustDomain, subject, options.UpstreamAuthority, options.UpstreamBundle, notAfter.Sub(notBefore))
 	} else {
 		x509CA, bundle, err = ca.SelfSignX509CA(context.Background(), signer, trustDomain, subject, notBefore, notAfter)
 	}
@@ -73,6 +74,7 @@ func New(t *testing.T, trustDomain string, options *Options) *CA {
 		TrustDomain: url.URL{Scheme: "spiffe", Host: trustDomain},
 		X509SVIDTTL: options.X509SVIDTTL,
 		Clock:       options.Clock,
+		IssuanceLog: options.IssuanceLog,
 	})
 	serverCA.SetX509CA(x509CA)
 	serverCA.SetJWTKey(&ca.JWTKey{
@@ -8,6 +8,7 @@ import (
 	"github.com/spiffe/spire/proto/spire/server/nodeattestor"
 	"github.com/spiffe/spire/proto/spire/server/noderesolver"
 	"github.com/spiffe/spire/proto/spire/server/notifier"
+	"github.com/spiffe/spire/proto/spire/server/upstreamauthority"
 	"github.com/spiffe/spire/proto/spire/server/upstreamca"
 )
 
@@ -44,6 +45,14 @@ func (c *Catalog) SetUpstreamCA(upstreamCA upstreamca.UpstreamCA) {
 	}
 }
 
+func (c *Catalog) SetUpstreamAuthority(upstreamAuthority upstreamauthority.UpstreamAuthority) {
+	if upstreamAuthority == nil {
+		c.UpstreamAuthority = nil
+	} else {
+		c.UpstreamAuthority = &upstreamAuthority
+	}
+}
+
 func (c *Catalog) SetKeyManager(keyManager keymanager.KeyManager) {
 	c.KeyManager = keyManager
 }
@@ -0,0 +1,179 @@
+package fakeupstreamauthority
+
+import (
+	"context"
+	"crypto/x509"
+	"sync"
+	"testing"
+
+	"github.com/spiffe/spire/proto/spire/common"
+	"github.com/spiffe/spire/proto/spire/server/upstreamauthority"
+	"github.com/spiffe/spire/proto/spire/server/upstreamca"
+	"github.com/spiffe/spire/test/fakes/fakeupstreamca"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Config struct {
+	TrustDomain string
+
+	// UpstreamJWTKeys are the JWT keys of the upstream authority, which are
+	// returned along with the published JWT keys.
+	UpstreamJWTKeys []*common.PublicKey
+
+	// DisallowPublishJWTKey, if true, fails PublishJWTKey calls with an
+	// Unimplemented error.
+	DisallowPublishJWTKey bool
+}
+
+// UpstreamAuthority is an UpstreamAuthority that signs CSRs with an in-memory
+// upstream CA. The streams are kept open and receive updates when upstream
+// X509 roots or JWT keys are added.
+type UpstreamAuthority struct {
+	upstreamCA *fakeupstreamca.UpstreamCA
+	config     Config
+
+	mu               sync.Mutex
+	x509Roots        []*x509.Certificate
+	jwtKeys          []*common.PublicKey
+	publishedJWTKeys []*common.PublicKey
+	x509RootsStreams []chan *upstreamauthority.MintX509CAResponse
+	jwtKeysStreams   []chan *upstreamauthority.PublishJWTKeyResponse
+}
+
+func New(t *testing.T, config Config) *UpstreamAuthority {
+	upstreamCA := fakeupstreamca.New(t, fakeupstreamca.Config{
+		TrustDomain: config.TrustDomain,
+	})
+	return &UpstreamAuthority{
+		upstreamCA: upstreamCA,
+		config:     config,
+		x509Roots:  []*x509.Certificate{upstreamCA.Root()},
+		jwtKeys:    config.UpstreamJWTKeys,
+	}
+}
+
+func (ua *UpstreamAuthority) Root() *x509.Certificate {
+	return ua.upstreamCA.Root()
+}
+
+// AppendX509Root adds an upstream X509 root and sends the updated roots to
+// the open MintX509CA streams.
+func (ua *UpstreamAuthority) AppendX509Root(root *x509.Certificate) {
+	ua.mu.Lock()
+	defer ua.mu.Unlock()
+
+	ua.x509Roots = append(ua.x509Roots, root)
+	resp := &upstreamauthority.MintX509CAResponse{
+		UpstreamX509Roots: certsDER(ua.x509Roots),
+	}
+	for _, stream := range ua.x509RootsStreams {
+		stream <- resp
+	}
+}
+
+// AppendJWTKey adds an upstream JWT key and sends the updated keys to the
+// open PublishJWTKey streams.
+func (ua *UpstreamAuthority) AppendJWTKey(jwtKey *common.PublicKey) {
+	ua.mu.Lock()
+	defer ua.mu.Unlock()
+
+	ua.jwtKeys = append(ua.jwtKeys, jwtKey)
+	resp := &upstreamauthority.PublishJWTKeyResponse{
+		UpstreamJwtKeys: ua.jwtKeys,
+	}
+	for _, stream := range ua.jwtKeysStreams {
+		stream <- resp
+	}
+}
+
+// PublishedJWTKeys returns the JWT keys published to the upstream authority.
+func (ua *UpstreamAuthority) PublishedJWTKeys() []*common.PublicKey {
+	ua.mu.Lock()
+	defer ua.mu.Unlock()
+	return ua.publishedJWTKeys
+}
+
+func (ua *UpstreamAuthority) MintX509CA(ctx context.Context, req *upstreamauthority.MintX509CARequest) (upstreamauthority.UpstreamAuthority_MintX509CAClient, error) {
+	resp, err := ua.upstreamCA.SubmitCSR(ctx, &upstreamca.SubmitCSRRequest{
+		Csr: req.Csr,
+	})
+	if err != nil {
+		return nil, err
+	}
+	caChain, err := x509.ParseCertificates(resp.SignedCertificate.CertChain)
+	if err != nil {
+		return nil, err
+	}
+
+	ua.mu.Lock()
+	defer ua.mu.Unlock()
+
+	stream := make(chan *upstreamauthority.MintX509CAResponse, 10)
+	stream <- &upstreamauthority.MintX509CAResponse{
+		X509CaChain:       certsDER(caChain),
+		UpstreamX509Roots: certsDER(ua.x509Roots),
+	}
+	ua.x509RootsStreams = append(ua.x509RootsStreams, stream)
+	return &mintX509CAClient{ctx: ctx, stream: stream}, nil
+}
+
+func (ua *UpstreamAuthority) PublishJWTKey(ctx context.Context, req *upstreamauthority.PublishJWTKeyRequest) (upstreamauthority.UpstreamAuthority_PublishJWTKeyClient, error) {
+	if ua.config.DisallowPublishJWTKey {
+		return nil, status.Error(codes.Unimplemented, "publishing JWT keys is not supported")
+	}
+
+	ua.mu.Lock()
+	defer ua.mu.Unlock()
+
+	ua.publishedJWTKeys = append(ua.publishedJWTKeys, req.JwtKey)
+	ua.jwtKeys = append(ua.jwtKeys, req.JwtKey)
+
+	stream := make(chan *upstreamauthority.PublishJWTKeyResponse, 10)
+	stream <- &upstreamauthority.PublishJWTKeyResponse{
+		UpstreamJwtKeys: ua.jwtKeys,
+	}
+	ua.jwtKeysStreams = append(ua.jwtKeysStreams, stream)
+	return &publishJWTKeyClient{ctx: ctx, stream: stream}, nil
+}
+
+type mintX509CAClient struct {
+	grpc.ClientStream
+
+	ctx    context.Context
+	stream chan *upstreamauthority.MintX509CAResponse
+}
+
+func (c *mintX509CAClient) Recv() (*upstreamauthority.MintX509CAResponse, error) {
+	select {
+	case resp := <-c.stream:
+		return resp, nil
+	case <-c.ctx.Done():
+		return nil, c.ctx.Err()
+	}
+}
+
+type publishJWTKeyClient struct {
+	grpc.ClientStream
+
+	ctx    context.Context
+	stream chan *upstreamauthority.PublishJWTKeyResponse
+}
+
+func (c *publishJWTKeyClient) Recv() (*upstreamauthority.PublishJWTKeyResponse, error) {
+	select {
+	case resp := <-c.stream:
+		return resp, nil
+	case <-c.ctx.Done():
+		return nil, c.ctx.Err()
+	}
+}
+
+func certsDER(certs []*x509.Certificate) [][]byte {
+	var out [][]byte
+	for _, cert := range certs {
+		out = append(out, cert.Raw)
+	}
+	return out
+}
@@ -36,6 +36,106 @@ func (m *MockRegistrationClient) EXPECT() *MockRegistrationClientMockRecorder {
 	return m.recorder
 }
 
+// ActivateCA mocks base method
+func (m *MockRegistrationClient) ActivateCA(arg0 context.Context, arg1 *registration.ActivateCARequest, arg2 ...grpc.CallOption) (*registration.ActivateCAResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ActivateCA", varargs...)
+	ret0, _ := ret[0].(*registration.ActivateCAResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActivateCA indicates an expected call of ActivateCA
+func (mr *MockRegistrationClientMockRecorder) ActivateCA(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateCA", reflect.TypeOf((*MockRegistrationClient)(nil).ActivateCA), varargs...)
+}
+
+// BanAgent mocks base method
+func (m *MockRegistrationClient) BanAgent(arg0 context.Context, arg1 *registration.BanAgentRequest, arg2 ...grpc.CallOption) (*registration.BanAgentResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BanAgent", varargs...)
+	ret0, _ := ret[0].(*registration.BanAgentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BanAgent indicates an expected call of BanAgent
+func (mr *MockRegistrationClientMockRecorder) BanAgent(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BanAgent", reflect.TypeOf((*MockRegistrationClient)(nil).BanAgent), varargs...)
+}
+
+// BatchCreateEntry mocks base method
+func (m *MockRegistrationClient) BatchCreateEntry(arg0 context.Context, arg1 *registration.BatchCreateEntryRequest, arg2 ...grpc.CallOption) (*registration.BatchCreateEntryResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BatchCreateEntry", varargs...)
+	ret0, _ := ret[0].(*registration.BatchCreateEntryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchCreateEntry indicates an expected call of BatchCreateEntry
+func (mr *MockRegistrationClientMockRecorder) BatchCreateEntry(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchCreateEntry", reflect.TypeOf((*MockRegistrationClient)(nil).BatchCreateEntry), varargs...)
+}
+
+// BatchDeleteEntry mocks base method
+func (m *MockRegistrationClient) BatchDeleteEntry(arg0 context.Context, arg1 *registration.BatchDeleteEntryRequest, arg2 ...grpc.CallOption) (*registration.BatchDeleteEntryResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BatchDeleteEntry", varargs...)
+	ret0, _ := ret[0].(*registration.BatchDeleteEntryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchDeleteEntry indicates an expected call of BatchDeleteEntry
+func (mr *MockRegistrationClientMockRecorder) BatchDeleteEntry(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchDeleteEntry", reflect.TypeOf((*MockRegistrationClient)(nil).BatchDeleteEntry), varargs...)
+}
+
+// BatchUpdateEntry mocks base method
+func (m *MockRegistrationClient) BatchUpdateEntry(arg0 context.Context, arg1 *registration.BatchUpdateEntryRequest, arg2 ...grpc.CallOption) (*registration.BatchUpdateEntryResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "BatchUpdateEntry", varargs...)
+	ret0, _ := ret[0].(*registration.BatchUpdateEntryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchUpdateEntry indicates an expected call of BatchUpdateEntry
+func (mr *MockRegistrationClientMockRecorder) BatchUpdateEntry(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchUpdateEntry", reflect.TypeOf((*MockRegistrationClient)(nil).BatchUpdateEntry), varargs...)
+}
+
 // CreateEntry mocks base method
 func (m *MockRegistrationClient) CreateEntry(arg0 context.Context, arg1 *common.RegistrationEntry, arg2 ...grpc.CallOption) (*registration.RegistrationEntryID, error) {
 	m.ctrl.T.Helper()
@@ -136,6 +236,26 @@ func (mr *MockRegistrationClientMockRecorder) DeleteFederatedBundle(arg0, arg1 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFederatedBundle", reflect.TypeOf((*MockRegistrationClient)(nil).DeleteFederatedBundle), varargs...)
 }
 
+// DeleteJoinToken mocks base method
+func (m *MockRegistrationClient) DeleteJoinToken(arg0 context.Context, arg1 *registration.DeleteJoinTokenRequest, arg2 ...grpc.CallOption) (*registration.DeleteJoinTokenResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteJoinToken", varargs...)
+	ret0, _ := ret[0].(*registration.DeleteJoinTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteJoinToken indicates an expected call of DeleteJoinToken
+func (mr *MockRegistrationClientMockRecorder) DeleteJoinToken(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteJoinToken", reflect.TypeOf((*MockRegistrationClient)(nil).DeleteJoinToken), varargs...)
+}
+
 // EvictAgent mocks base method
 func (m *MockRegistrationClient) EvictAgent(arg0 context.Context, arg1 *registration.EvictAgentRequest, arg2 ...grpc.CallOption) (*registration.EvictAgentResponse, error) {
 	m.ctrl.T.Helper()
@@ -156,6 +276,26 @@ func (mr *MockRegistrationClientMockRecorder) EvictAgent(arg0, arg1 interface{},
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvictAgent", reflect.TypeOf((*MockRegistrationClient)(nil).EvictAgent), varargs...)
 }
 
+// FetchAgent mocks base method
+func (m *MockRegistrationClient) FetchAgent(arg0 context.Context, arg1 *registration.FetchAgentRequest, arg2 ...grpc.CallOption) (*registration.FetchAgentResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "FetchAgent", varargs...)
+	ret0, _ := ret[0].(*registration.FetchAgentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchAgent indicates an expected call of FetchAgent
+func (mr *MockRegistrationClientMockRecorder) FetchAgent(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAgent", reflect.TypeOf((*MockRegistrationClient)(nil).FetchAgent), varargs...)
+}
+
 // FetchBundle mocks base method
 func (m *MockRegistrationClient) FetchBundle(arg0 context.Context, arg1 *common.Empty, arg2 ...grpc.CallOption) (*registration.Bundle, error) {
 	m.ctrl.T.Helper()
@@ -336,6 +476,46 @@ func (mr *MockRegistrationClientMockRecorder) ListBySpiffeID(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBySpiffeID", reflect.TypeOf((*MockRegistrationClient)(nil).ListBySpiffeID), varargs...)
 }
 
+// ListCASlots mocks base method
+func (m *MockRegistrationClient) ListCASlots(arg0 context.Context, arg1 *registration.ListCASlotsRequest, arg2 ...grpc.CallOption) (*registration.ListCASlotsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListCASlots", varargs...)
+	ret0, _ := ret[0].(*registration.ListCASlotsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCASlots indicates an expected call of ListCASlots
+func (mr *MockRegistrationClientMockRecorder) ListCASlots(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCASlots", reflect.TypeOf((*MockRegistrationClient)(nil).ListCASlots), varargs...)
+}
+
+// ListEntries mocks base method
+func (m *MockRegistrationClient) ListEntries(arg0 context.Context, arg1 *registration.ListEntriesRequest, arg2 ...grpc.CallOption) (*registration.ListEntriesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListEntries", varargs...)
+	ret0, _ := ret[0].(*registration.ListEntriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntries indicates an expected call of ListEntries
+func (mr *MockRegistrationClientMockRecorder) ListEntries(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntries", reflect.TypeOf((*MockRegistrationClient)(nil).ListEntries), varargs...)
+}
+
 // ListFederatedBundles mocks base method
 func (m *MockRegistrationClient) ListFederatedBundles(arg0 context.Context, arg1 *common.Empty, arg2 ...grpc.CallOption) (registration.Registration_ListFederatedBundlesClient, error) {
 	m.ctrl.T.Helper()
@@ -356,6 +536,146 @@ func (mr *MockRegistrationClientMockRecorder) ListFederatedBundles(arg0, arg1 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFederatedBundles", reflect.TypeOf((*MockRegistrationClient)(nil).ListFederatedBundles), varargs...)
 }
 
+// ListIssuedSVIDs mocks base method
+func (m *MockRegistrationClient) ListIssuedSVIDs(arg0 context.Context, arg1 *registration.ListIssuedSVIDsRequest, arg2 ...grpc.CallOption) (registration.Registration_ListIssuedSVIDsClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListIssuedSVIDs", varargs...)
+	ret0, _ := ret[0].(registration.Registration_ListIssuedSVIDsClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIssuedSVIDs indicates an expected call of ListIssuedSVIDs
+func (mr *MockRegistrationClientMockRecorder) ListIssuedSVIDs(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIssuedSVIDs", reflect.TypeOf((*MockRegistrationClient)(nil).ListIssuedSVIDs), varargs...)
+}
+
+// ListJoinTokens mocks base method
+func (m *MockRegistrationClient) ListJoinTokens(arg0 context.Context, arg1 *registration.ListJoinTokensRequest, arg2 ...grpc.CallOption) (*registration.ListJoinTokensResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListJoinTokens", varargs...)
+	ret0, _ := ret[0].(*registration.ListJoinTokensResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJoinTokens indicates an expected call of ListJoinTokens
+func (mr *MockRegistrationClientMockRecorder) ListJoinTokens(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJoinTokens", reflect.TypeOf((*MockRegistrationClient)(nil).ListJoinTokens), varargs...)
+}
+
+// MintJWTSVID mocks base method
+func (m *MockRegistrationClient) MintJWTSVID(arg0 context.Context, arg1 *registration.MintJWTSVIDRequest, arg2 ...grpc.CallOption) (*registration.MintJWTSVIDResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MintJWTSVID", varargs...)
+	ret0, _ := ret[0].(*registration.MintJWTSVIDResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MintJWTSVID indicates an expected call of MintJWTSVID
+func (mr *MockRegistrationClientMockRecorder) MintJWTSVID(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MintJWTSVID", reflect.TypeOf((*MockRegistrationClient)(nil).MintJWTSVID), varargs...)
+}
+
+// MintX509SVID mocks base method
+func (m *MockRegistrationClient) MintX509SVID(arg0 context.Context, arg1 *registration.MintX509SVIDRequest, arg2 ...grpc.CallOption) (*registration.MintX509SVIDResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MintX509SVID", varargs...)
+	ret0, _ := ret[0].(*registration.MintX509SVIDResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MintX509SVID indicates an expected call of MintX509SVID
+func (mr *MockRegistrationClientMockRecorder) MintX509SVID(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MintX509SVID", reflect.TypeOf((*MockRegistrationClient)(nil).MintX509SVID), varargs...)
+}
+
+// PrepareCA mocks base method
+func (m *MockRegistrationClient) PrepareCA(arg0 context.Context, arg1 *registration.PrepareCARequest, arg2 ...grpc.CallOption) (*registration.PrepareCAResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PrepareCA", varargs...)
+	ret0, _ := ret[0].(*registration.PrepareCAResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PrepareCA indicates an expected call of PrepareCA
+func (mr *MockRegistrationClientMockRecorder) PrepareCA(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrepareCA", reflect.TypeOf((*MockRegistrationClient)(nil).PrepareCA), varargs...)
+}
+
+// TaintCA mocks base method
+func (m *MockRegistrationClient) TaintCA(arg0 context.Context, arg1 *registration.TaintCARequest, arg2 ...grpc.CallOption) (*registration.TaintCAResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "TaintCA", varargs...)
+	ret0, _ := ret[0].(*registration.TaintCAResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TaintCA indicates an expected call of TaintCA
+func (mr *MockRegistrationClientMockRecorder) TaintCA(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TaintCA", reflect.TypeOf((*MockRegistrationClient)(nil).TaintCA), varargs...)
+}
+
+// UnbanAgent mocks base method
+func (m *MockRegistrationClient) UnbanAgent(arg0 context.Context, arg1 *registration.UnbanAgentRequest, arg2 ...grpc.CallOption) (*registration.UnbanAgentResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UnbanAgent", varargs...)
+	ret0, _ := ret[0].(*registration.UnbanAgentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnbanAgent indicates an expected call of UnbanAgent
+func (mr *MockRegistrationClientMockRecorder) UnbanAgent(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnbanAgent", reflect.TypeOf((*MockRegistrationClient)(nil).UnbanAgent), varargs...)
+}
+
 // UpdateEntry mocks base method
 func (m *MockRegistrationClient) UpdateEntry(arg0 context.Context, arg1 *registration.UpdateEntryRequest, arg2 ...grpc.CallOption) (*common.RegistrationEntry, error) {
 	m.ctrl.T.Helper()
@@ -396,6 +716,26 @@ func (mr *MockRegistrationClientMockRecorder) UpdateFederatedBundle(arg0, arg1 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFederatedBundle", reflect.TypeOf((*MockRegistrationClient)(nil).UpdateFederatedBundle), varargs...)
 }
 
+// WatchEntries mocks base method
+func (m *MockRegistrationClient) WatchEntries(arg0 context.Context, arg1 *registration.WatchEntriesRequest, arg2 ...grpc.CallOption) (registration.Registration_WatchEntriesClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WatchEntries", varargs...)
+	ret0, _ := ret[0].(registration.Registration_WatchEntriesClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchEntries indicates an expected call of WatchEntries
+func (mr *MockRegistrationClientMockRecorder) WatchEntries(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchEntries", reflect.TypeOf((*MockRegistrationClient)(nil).WatchEntries), varargs...)
+}
+
 // MockRegistrationServer is a mock of RegistrationServer interface
 type MockRegistrationServer struct {
 	ctrl     *gomock.Controller
@@ -419,6 +759,81 @@ func (m *MockRegistrationServer) EXPECT() *MockRegistrationServerMockRecorder {
 	return m.recorder
 }
 
+// ActivateCA mocks base method
+func (m *MockRegistrationServer) ActivateCA(arg0 context.Context, arg1 *registration.ActivateCARequest) (*registration.ActivateCAResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivateCA", arg0, arg1)
+	ret0, _ := ret[0].(*registration.ActivateCAResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActivateCA indicates an expected call of ActivateCA
+func (mr *MockRegistrationServerMockRecorder) ActivateCA(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateCA", reflect.TypeOf((*MockRegistrationServer)(nil).ActivateCA), arg0, arg1)
+}
+
+// BanAgent mocks base method
+func (m *MockRegistrationServer) BanAgent(arg0 context.Context, arg1 *registration.BanAgentRequest) (*registration.BanAgentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BanAgent", arg0, arg1)
+	ret0, _ := ret[0].(*registration.BanAgentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BanAgent indicates an expected call of BanAgent
+func (mr *MockRegistrationServerMockRecorder) BanAgent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BanAgent", reflect.TypeOf((*MockRegistrationServer)(nil).BanAgent), arg0, arg1)
+}
+
+// BatchCreateEntry mocks base method
+func (m *MockRegistrationServer) BatchCreateEntry(arg0 context.Context, arg1 *registration.BatchCreateEntryRequest) (*registration.BatchCreateEntryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchCreateEntry", arg0, arg1)
+	ret0, _ := ret[0].(*registration.BatchCreateEntryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchCreateEntry indicates an expected call of BatchCreateEntry
+func (mr *MockRegistrationServerMockRecorder) BatchCreateEntry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchCreateEntry", reflect.TypeOf((*MockRegistrationServer)(nil).BatchCreateEntry), arg0, arg1)
+}
+
+// BatchDeleteEntry mocks base method
+func (m *MockRegistrationServer) BatchDeleteEntry(arg0 context.Context, arg1 *registration.BatchDeleteEntryRequest) (*registration.BatchDeleteEntryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchDeleteEntry", arg0, arg1)
+	ret0, _ := ret[0].(*registration.BatchDeleteEntryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchDeleteEntry indicates an expected call of BatchDeleteEntry
+func (mr *MockRegistrationServerMockRecorder) BatchDeleteEntry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchDeleteEntry", reflect.TypeOf((*MockRegistrationServer)(nil).BatchDeleteEntry), arg0, arg1)
+}
+
+// BatchUpdateEntry mocks base method
+func (m *MockRegistrationServer) BatchUpdateEntry(arg0 context.Context, arg1 *registration.BatchUpdateEntryRequest) (*registration.BatchUpdateEntryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchUpdateEntry", arg0, arg1)
+	ret0, _ := ret[0].(*registration.BatchUpdateEntryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchUpdateEntry indicates an expected call of BatchUpdateEntry
+func (mr *MockRegistrationServerMockRecorder) BatchUpdateEntry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchUpdateEntry", reflect.TypeOf((*MockRegistrationServer)(nil).BatchUpdateEntry), arg0, arg1)
+}
+
 // CreateEntry mocks base method
 func (m *MockRegistrationServer) CreateEntry(arg0 context.Context, arg1 *common.RegistrationEntry) (*registration.RegistrationEntryID, error) {
 	m.ctrl.T.Helper()
@@ -494,6 +909,21 @@ func (mr *MockRegistrationServerMockRecorder) DeleteFederatedBundle(arg0, arg1 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFederatedBundle", reflect.TypeOf((*MockRegistrationServer)(nil).DeleteFederatedBundle), arg0, arg1)
 }
 
+// DeleteJoinToken mocks base method
+func (m *MockRegistrationServer) DeleteJoinToken(arg0 context.Context, arg1 *registration.DeleteJoinTokenRequest) (*registration.DeleteJoinTokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteJoinToken", arg0, arg1)
+	ret0, _ := ret[0].(*registration.DeleteJoinTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteJoinToken indicates an expected call of DeleteJoinToken
+func (mr *MockRegistrationServerMockRecorder) DeleteJoinToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteJoinToken", reflect.TypeOf((*MockRegistrationServer)(nil).DeleteJoinToken), arg0, arg1)
+}
+
 // EvictAgent mocks base method
 func (m *MockRegistrationServer) EvictAgent(arg0 context.Context, arg1 *registration.EvictAgentRequest) (*registration.EvictAgentResponse, error) {
 	m.ctrl.T.Helper()
@@ -509,6 +939,21 @@ func (mr *MockRegistrationServerMockRecorder) EvictAgent(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EvictAgent", reflect.TypeOf((*MockRegistrationServer)(nil).EvictAgent), arg0, arg1)
 }
 
+// FetchAgent mocks base method
+func (m *MockRegistrationServer) FetchAgent(arg0 context.Context, arg1 *registration.FetchAgentRequest) (*registration.FetchAgentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchAgent", arg0, arg1)
+	ret0, _ := ret[0].(*registration.FetchAgentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchAgent indicates an expected call of FetchAgent
+func (mr *MockRegistrationServerMockRecorder) FetchAgent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchAgent", reflect.TypeOf((*MockRegistrationServer)(nil).FetchAgent), arg0, arg1)
+}
+
 // FetchBundle mocks base method
 func (m *MockRegistrationServer) FetchBundle(arg0 context.Context, arg1 *common.Empty) (*registration.Bundle, error) {
 	m.ctrl.T.Helper()
@@ -644,6 +1089,36 @@ func (mr *MockRegistrationServerMockRecorder) ListBySpiffeID(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBySpiffeID", reflect.TypeOf((*MockRegistrationServer)(nil).ListBySpiffeID), arg0, arg1)
 }
 
+// ListCASlots mocks base method
+func (m *MockRegistrationServer) ListCASlots(arg0 context.Context, arg1 *registration.ListCASlotsRequest) (*registration.ListCASlotsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCASlots", arg0, arg1)
+	ret0, _ := ret[0].(*registration.ListCASlotsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCASlots indicates an expected call of ListCASlots
+func (mr *MockRegistrationServerMockRecorder) ListCASlots(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCASlots", reflect.TypeOf((*MockRegistrationServer)(nil).ListCASlots), arg0, arg1)
+}
+
+// ListEntries mocks base method
+func (m *MockRegistrationServer) ListEntries(arg0 context.Context, arg1 *registration.ListEntriesRequest) (*registration.ListEntriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEntries", arg0, arg1)
+	ret0, _ := ret[0].(*registration.ListEntriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEntries indicates an expected call of ListEntries
+func (mr *MockRegistrationServerMockRecorder) ListEntries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEntries", reflect.TypeOf((*MockRegistrationServer)(nil).ListEntries), arg0, arg1)
+}
+
 // ListFederatedBundles mocks base method
 func (m *MockRegistrationServer) ListFederatedBundles(arg0 *common.Empty, arg1 registration.Registration_ListFederatedBundlesServer) error {
 	m.ctrl.T.Helper()
@@ -658,6 +1133,110 @@ func (mr *MockRegistrationServerMockRecorder) ListFederatedBundles(arg0, arg1 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFederatedBundles", reflect.TypeOf((*MockRegistrationServer)(nil).ListFederatedBundles), arg0, arg1)
 }
 
+// ListIssuedSVIDs mocks base method
+func (m *MockRegistrationServer) ListIssuedSVIDs(arg0 *registration.ListIssuedSVIDsRequest, arg1 registration.Registration_ListIssuedSVIDsServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIssuedSVIDs", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ListIssuedSVIDs indicates an expected call of ListIssuedSVIDs
+func (mr *MockRegistrationServerMockRecorder) ListIssuedSVIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIssuedSVIDs", reflect.TypeOf((*MockRegistrationServer)(nil).ListIssuedSVIDs), arg0, arg1)
+}
+
+// ListJoinTokens mocks base method
+func (m *MockRegistrationServer) ListJoinTokens(arg0 context.Context, arg1 *registration.ListJoinTokensRequest) (*registration.ListJoinTokensResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJoinTokens", arg0, arg1)
+	ret0, _ := ret[0].(*registration.ListJoinTokensResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJoinTokens indicates an expected call of ListJoinTokens
+func (mr *MockRegistrationServerMockRecorder) ListJoinTokens(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJoinTokens", reflect.TypeOf((*MockRegistrationServer)(nil).ListJoinTokens), arg0, arg1)
+}
+
+// MintJWTSVID mocks base method
+func (m *MockRegistrationServer) MintJWTSVID(arg0 context.Context, arg1 *registration.MintJWTSVIDRequest) (*registration.MintJWTSVIDResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MintJWTSVID", arg0, arg1)
+	ret0, _ := ret[0].(*registration.MintJWTSVIDResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MintJWTSVID indicates an expected call of MintJWTSVID
+func (mr *MockRegistrationServerMockRecorder) MintJWTSVID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MintJWTSVID", reflect.TypeOf((*MockRegistrationServer)(nil).MintJWTSVID), arg0, arg1)
+}
+
+// MintX509SVID mocks base method
+func (m *MockRegistrationServer) MintX509SVID(arg0 context.Context, arg1 *registration.MintX509SVIDRequest) (*registration.MintX509SVIDResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MintX509SVID", arg0, arg1)
+	ret0, _ := ret[0].(*registration.MintX509SVIDResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MintX509SVID indicates an expected call of MintX509SVID
+func (mr *MockRegistrationServerMockRecorder) MintX509SVID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MintX509SVID", reflect.TypeOf((*MockRegistrationServer)(nil).MintX509SVID), arg0, arg1)
+}
+
+// PrepareCA mocks base method
+func (m *MockRegistrationServer) PrepareCA(arg0 context.Context, arg1 *registration.PrepareCARequest) (*registration.PrepareCAResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PrepareCA", arg0, arg1)
+	ret0, _ := ret[0].(*registration.PrepareCAResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PrepareCA indicates an expected call of PrepareCA
+func (mr *MockRegistrationServerMockRecorder) PrepareCA(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PrepareCA", reflect.TypeOf((*MockRegistrationServer)(nil).PrepareCA), arg0, arg1)
+}
+
+// TaintCA mocks base method
+func (m *MockRegistrationServer) TaintCA(arg0 context.Context, arg1 *registration.TaintCARequest) (*registration.TaintCAResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TaintCA", arg0, arg1)
+	ret0, _ := ret[0].(*registration.TaintCAResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TaintCA indicates an expected call of TaintCA
+func (mr *MockRegistrationServerMockRecorder) TaintCA(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TaintCA", reflect.TypeOf((*MockRegistrationServer)(nil).TaintCA), arg0, arg1)
+}
+
+// UnbanAgent mocks base method
+func (m *MockRegistrationServer) UnbanAgent(arg0 context.Context, arg1 *registration.UnbanAgentRequest) (*registration.UnbanAgentResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnbanAgent", arg0, arg1)
+	ret0, _ := ret[0].(*registration.UnbanAgentResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnbanAgent indicates an expected call of UnbanAgent
+func (mr *MockRegistrationServerMockRecorder) UnbanAgent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnbanAgent", reflect.TypeOf((*MockRegistrationServer)(nil).UnbanAgent), arg0, arg1)
+}
+
 // UpdateEntry mocks base method
 func (m *MockRegistrationServer) UpdateEntry(arg0 context.Context, arg1 *registration.UpdateEntryRequest) (*common.RegistrationEntry, error) {
 	m.ctrl.T.Helper()
@@ -687,3 +1266,17 @@ func (mr *MockRegistrationServerMockRecorder) UpdateFederatedBundle(arg0, arg1 i
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFederatedBundle", reflect.TypeOf((*MockRegistrationServer)(nil).UpdateFederatedBundle), arg0, arg1)
 }
+
+// WatchEntries mocks base method
+func (m *MockRegistrationServer) WatchEntries(arg0 *registration.WatchEntriesRequest, arg1 registration.Registration_WatchEntriesServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchEntries", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchEntries indicates an expected call of WatchEntries
+func (mr *MockRegistrationServerMockRecorder) WatchEntries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchEntries", reflect.TypeOf((*MockRegistrationServer)(nil).WatchEntries), arg0, arg1)
+}
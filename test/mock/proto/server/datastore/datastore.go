@@ -35,6 +35,21 @@ func (m *MockDataStore) EXPECT() *MockDataStoreMockRecorder {
 	return m.recorder
 }
 
+// AcquireLease mocks base method
+func (m *MockDataStore) AcquireLease(arg0 context.Context, arg1 *datastore.AcquireLeaseRequest) (*datastore.AcquireLeaseResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireLease", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.AcquireLeaseResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcquireLease indicates an expected call of AcquireLease
+func (mr *MockDataStoreMockRecorder) AcquireLease(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireLease", reflect.TypeOf((*MockDataStore)(nil).AcquireLease), arg0, arg1)
+}
+
 // AppendBundle mocks base method
 func (m *MockDataStore) AppendBundle(arg0 context.Context, arg1 *datastore.AppendBundleRequest) (*datastore.AppendBundleResponse, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +65,66 @@ func (mr *MockDataStoreMockRecorder) AppendBundle(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendBundle", reflect.TypeOf((*MockDataStore)(nil).AppendBundle), arg0, arg1)
 }
 
+// BatchCreateRegistrationEntries mocks base method
+func (m *MockDataStore) BatchCreateRegistrationEntries(arg0 context.Context, arg1 *datastore.BatchCreateRegistrationEntriesRequest) (*datastore.BatchCreateRegistrationEntriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchCreateRegistrationEntries", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.BatchCreateRegistrationEntriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchCreateRegistrationEntries indicates an expected call of BatchCreateRegistrationEntries
+func (mr *MockDataStoreMockRecorder) BatchCreateRegistrationEntries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchCreateRegistrationEntries", reflect.TypeOf((*MockDataStore)(nil).BatchCreateRegistrationEntries), arg0, arg1)
+}
+
+// BatchDeleteRegistrationEntries mocks base method
+func (m *MockDataStore) BatchDeleteRegistrationEntries(arg0 context.Context, arg1 *datastore.BatchDeleteRegistrationEntriesRequest) (*datastore.BatchDeleteRegistrationEntriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchDeleteRegistrationEntries", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.BatchDeleteRegistrationEntriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchDeleteRegistrationEntries indicates an expected call of BatchDeleteRegistrationEntries
+func (mr *MockDataStoreMockRecorder) BatchDeleteRegistrationEntries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchDeleteRegistrationEntries", reflect.TypeOf((*MockDataStore)(nil).BatchDeleteRegistrationEntries), arg0, arg1)
+}
+
+// BatchUpdateRegistrationEntries mocks base method
+func (m *MockDataStore) BatchUpdateRegistrationEntries(arg0 context.Context, arg1 *datastore.BatchUpdateRegistrationEntriesRequest) (*datastore.BatchUpdateRegistrationEntriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchUpdateRegistrationEntries", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.BatchUpdateRegistrationEntriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchUpdateRegistrationEntries indicates an expected call of BatchUpdateRegistrationEntries
+func (mr *MockDataStoreMockRecorder) BatchUpdateRegistrationEntries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchUpdateRegistrationEntries", reflect.TypeOf((*MockDataStore)(nil).BatchUpdateRegistrationEntries), arg0, arg1)
+}
+
+// ConsumeJoinToken mocks base method
+func (m *MockDataStore) ConsumeJoinToken(arg0 context.Context, arg1 *datastore.ConsumeJoinTokenRequest) (*datastore.ConsumeJoinTokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeJoinToken", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.ConsumeJoinTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeJoinToken indicates an expected call of ConsumeJoinToken
+func (mr *MockDataStoreMockRecorder) ConsumeJoinToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeJoinToken", reflect.TypeOf((*MockDataStore)(nil).ConsumeJoinToken), arg0, arg1)
+}
+
 // CreateAttestedNode mocks base method
 func (m *MockDataStore) CreateAttestedNode(arg0 context.Context, arg1 *datastore.CreateAttestedNodeRequest) (*datastore.CreateAttestedNodeResponse, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +155,36 @@ func (mr *MockDataStoreMockRecorder) CreateBundle(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBundle", reflect.TypeOf((*MockDataStore)(nil).CreateBundle), arg0, arg1)
 }
 
+// CreateDownstreamCA mocks base method
+func (m *MockDataStore) CreateDownstreamCA(arg0 context.Context, arg1 *datastore.CreateDownstreamCARequest) (*datastore.CreateDownstreamCAResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDownstreamCA", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.CreateDownstreamCAResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDownstreamCA indicates an expected call of CreateDownstreamCA
+func (mr *MockDataStoreMockRecorder) CreateDownstreamCA(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDownstreamCA", reflect.TypeOf((*MockDataStore)(nil).CreateDownstreamCA), arg0, arg1)
+}
+
+// CreateIssuedSVID mocks base method
+func (m *MockDataStore) CreateIssuedSVID(arg0 context.Context, arg1 *datastore.CreateIssuedSVIDRequest) (*datastore.CreateIssuedSVIDResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIssuedSVID", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.CreateIssuedSVIDResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIssuedSVID indicates an expected call of CreateIssuedSVID
+func (mr *MockDataStoreMockRecorder) CreateIssuedSVID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIssuedSVID", reflect.TypeOf((*MockDataStore)(nil).CreateIssuedSVID), arg0, arg1)
+}
+
 // CreateJoinToken mocks base method
 func (m *MockDataStore) CreateJoinToken(arg0 context.Context, arg1 *datastore.CreateJoinTokenRequest) (*datastore.CreateJoinTokenResponse, error) {
 	m.ctrl.T.Helper()
@@ -200,6 +305,21 @@ func (mr *MockDataStoreMockRecorder) FetchBundle(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBundle", reflect.TypeOf((*MockDataStore)(nil).FetchBundle), arg0, arg1)
 }
 
+// FetchCAJournal mocks base method
+func (m *MockDataStore) FetchCAJournal(arg0 context.Context, arg1 *datastore.FetchCAJournalRequest) (*datastore.FetchCAJournalResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchCAJournal", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.FetchCAJournalResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchCAJournal indicates an expected call of FetchCAJournal
+func (mr *MockDataStoreMockRecorder) FetchCAJournal(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchCAJournal", reflect.TypeOf((*MockDataStore)(nil).FetchCAJournal), arg0, arg1)
+}
+
 // FetchJoinToken mocks base method
 func (m *MockDataStore) FetchJoinToken(arg0 context.Context, arg1 *datastore.FetchJoinTokenRequest) (*datastore.FetchJoinTokenResponse, error) {
 	m.ctrl.T.Helper()
@@ -230,6 +350,21 @@ func (mr *MockDataStoreMockRecorder) FetchRegistrationEntry(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchRegistrationEntry", reflect.TypeOf((*MockDataStore)(nil).FetchRegistrationEntry), arg0, arg1)
 }
 
+// FetchRevokedCertificate mocks base method
+func (m *MockDataStore) FetchRevokedCertificate(arg0 context.Context, arg1 *datastore.FetchRevokedCertificateRequest) (*datastore.FetchRevokedCertificateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchRevokedCertificate", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.FetchRevokedCertificateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchRevokedCertificate indicates an expected call of FetchRevokedCertificate
+func (mr *MockDataStoreMockRecorder) FetchRevokedCertificate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchRevokedCertificate", reflect.TypeOf((*MockDataStore)(nil).FetchRevokedCertificate), arg0, arg1)
+}
+
 // GetNodeSelectors mocks base method
 func (m *MockDataStore) GetNodeSelectors(arg0 context.Context, arg1 *datastore.GetNodeSelectorsRequest) (*datastore.GetNodeSelectorsResponse, error) {
 	m.ctrl.T.Helper()
@@ -275,6 +410,66 @@ func (mr *MockDataStoreMockRecorder) ListBundles(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBundles", reflect.TypeOf((*MockDataStore)(nil).ListBundles), arg0, arg1)
 }
 
+// ListDownstreamCAs mocks base method
+func (m *MockDataStore) ListDownstreamCAs(arg0 context.Context, arg1 *datastore.ListDownstreamCAsRequest) (*datastore.ListDownstreamCAsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDownstreamCAs", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.ListDownstreamCAsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDownstreamCAs indicates an expected call of ListDownstreamCAs
+func (mr *MockDataStoreMockRecorder) ListDownstreamCAs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDownstreamCAs", reflect.TypeOf((*MockDataStore)(nil).ListDownstreamCAs), arg0, arg1)
+}
+
+// ListIssuedSVIDs mocks base method
+func (m *MockDataStore) ListIssuedSVIDs(arg0 context.Context, arg1 *datastore.ListIssuedSVIDsRequest) (*datastore.ListIssuedSVIDsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIssuedSVIDs", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.ListIssuedSVIDsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIssuedSVIDs indicates an expected call of ListIssuedSVIDs
+func (mr *MockDataStoreMockRecorder) ListIssuedSVIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIssuedSVIDs", reflect.TypeOf((*MockDataStore)(nil).ListIssuedSVIDs), arg0, arg1)
+}
+
+// ListJoinTokens mocks base method
+func (m *MockDataStore) ListJoinTokens(arg0 context.Context, arg1 *datastore.ListJoinTokensRequest) (*datastore.ListJoinTokensResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJoinTokens", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.ListJoinTokensResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJoinTokens indicates an expected call of ListJoinTokens
+func (mr *MockDataStoreMockRecorder) ListJoinTokens(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJoinTokens", reflect.TypeOf((*MockDataStore)(nil).ListJoinTokens), arg0, arg1)
+}
+
+// ListNodeSelectors mocks base method
+func (m *MockDataStore) ListNodeSelectors(arg0 context.Context, arg1 *datastore.ListNodeSelectorsRequest) (*datastore.ListNodeSelectorsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNodeSelectors", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.ListNodeSelectorsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNodeSelectors indicates an expected call of ListNodeSelectors
+func (mr *MockDataStoreMockRecorder) ListNodeSelectors(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNodeSelectors", reflect.TypeOf((*MockDataStore)(nil).ListNodeSelectors), arg0, arg1)
+}
+
 // ListRegistrationEntries mocks base method
 func (m *MockDataStore) ListRegistrationEntries(arg0 context.Context, arg1 *datastore.ListRegistrationEntriesRequest) (*datastore.ListRegistrationEntriesResponse, error) {
 	m.ctrl.T.Helper()
@@ -290,6 +485,96 @@ func (mr *MockDataStoreMockRecorder) ListRegistrationEntries(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRegistrationEntries", reflect.TypeOf((*MockDataStore)(nil).ListRegistrationEntries), arg0, arg1)
 }
 
+// ListRegistrationEntryEvents mocks base method
+func (m *MockDataStore) ListRegistrationEntryEvents(arg0 context.Context, arg1 *datastore.ListRegistrationEntryEventsRequest) (*datastore.ListRegistrationEntryEventsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRegistrationEntryEvents", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.ListRegistrationEntryEventsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRegistrationEntryEvents indicates an expected call of ListRegistrationEntryEvents
+func (mr *MockDataStoreMockRecorder) ListRegistrationEntryEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRegistrationEntryEvents", reflect.TypeOf((*MockDataStore)(nil).ListRegistrationEntryEvents), arg0, arg1)
+}
+
+// ListRegistrationEntryTombstones mocks base method
+func (m *MockDataStore) ListRegistrationEntryTombstones(arg0 context.Context, arg1 *datastore.ListRegistrationEntryTombstonesRequest) (*datastore.ListRegistrationEntryTombstonesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRegistrationEntryTombstones", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.ListRegistrationEntryTombstonesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRegistrationEntryTombstones indicates an expected call of ListRegistrationEntryTombstones
+func (mr *MockDataStoreMockRecorder) ListRegistrationEntryTombstones(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRegistrationEntryTombstones", reflect.TypeOf((*MockDataStore)(nil).ListRegistrationEntryTombstones), arg0, arg1)
+}
+
+// ListRevokedCertificates mocks base method
+func (m *MockDataStore) ListRevokedCertificates(arg0 context.Context, arg1 *datastore.ListRevokedCertificatesRequest) (*datastore.ListRevokedCertificatesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevokedCertificates", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.ListRevokedCertificatesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevokedCertificates indicates an expected call of ListRevokedCertificates
+func (mr *MockDataStoreMockRecorder) ListRevokedCertificates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevokedCertificates", reflect.TypeOf((*MockDataStore)(nil).ListRevokedCertificates), arg0, arg1)
+}
+
+// PruneBundle mocks base method
+func (m *MockDataStore) PruneBundle(arg0 context.Context, arg1 *datastore.PruneBundleRequest) (*datastore.PruneBundleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneBundle", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.PruneBundleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneBundle indicates an expected call of PruneBundle
+func (mr *MockDataStoreMockRecorder) PruneBundle(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneBundle", reflect.TypeOf((*MockDataStore)(nil).PruneBundle), arg0, arg1)
+}
+
+// PruneDownstreamCAs mocks base method
+func (m *MockDataStore) PruneDownstreamCAs(arg0 context.Context, arg1 *datastore.PruneDownstreamCAsRequest) (*datastore.PruneDownstreamCAsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneDownstreamCAs", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.PruneDownstreamCAsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneDownstreamCAs indicates an expected call of PruneDownstreamCAs
+func (mr *MockDataStoreMockRecorder) PruneDownstreamCAs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneDownstreamCAs", reflect.TypeOf((*MockDataStore)(nil).PruneDownstreamCAs), arg0, arg1)
+}
+
+// PruneIssuedSVIDs mocks base method
+func (m *MockDataStore) PruneIssuedSVIDs(arg0 context.Context, arg1 *datastore.PruneIssuedSVIDsRequest) (*datastore.PruneIssuedSVIDsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneIssuedSVIDs", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.PruneIssuedSVIDsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneIssuedSVIDs indicates an expected call of PruneIssuedSVIDs
+func (mr *MockDataStoreMockRecorder) PruneIssuedSVIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneIssuedSVIDs", reflect.TypeOf((*MockDataStore)(nil).PruneIssuedSVIDs), arg0, arg1)
+}
+
 // PruneJoinTokens mocks base method
 func (m *MockDataStore) PruneJoinTokens(arg0 context.Context, arg1 *datastore.PruneJoinTokensRequest) (*datastore.PruneJoinTokensResponse, error) {
 	m.ctrl.T.Helper()
@@ -320,6 +605,126 @@ func (mr *MockDataStoreMockRecorder) PruneRegistrationEntries(arg0, arg1 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneRegistrationEntries", reflect.TypeOf((*MockDataStore)(nil).PruneRegistrationEntries), arg0, arg1)
 }
 
+// PruneRegistrationEntryEvents mocks base method
+func (m *MockDataStore) PruneRegistrationEntryEvents(arg0 context.Context, arg1 *datastore.PruneRegistrationEntryEventsRequest) (*datastore.PruneRegistrationEntryEventsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneRegistrationEntryEvents", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.PruneRegistrationEntryEventsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneRegistrationEntryEvents indicates an expected call of PruneRegistrationEntryEvents
+func (mr *MockDataStoreMockRecorder) PruneRegistrationEntryEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneRegistrationEntryEvents", reflect.TypeOf((*MockDataStore)(nil).PruneRegistrationEntryEvents), arg0, arg1)
+}
+
+// PruneRegistrationEntryTombstones mocks base method
+func (m *MockDataStore) PruneRegistrationEntryTombstones(arg0 context.Context, arg1 *datastore.PruneRegistrationEntryTombstonesRequest) (*datastore.PruneRegistrationEntryTombstonesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneRegistrationEntryTombstones", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.PruneRegistrationEntryTombstonesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneRegistrationEntryTombstones indicates an expected call of PruneRegistrationEntryTombstones
+func (mr *MockDataStoreMockRecorder) PruneRegistrationEntryTombstones(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneRegistrationEntryTombstones", reflect.TypeOf((*MockDataStore)(nil).PruneRegistrationEntryTombstones), arg0, arg1)
+}
+
+// PruneRevokedCertificates mocks base method
+func (m *MockDataStore) PruneRevokedCertificates(arg0 context.Context, arg1 *datastore.PruneRevokedCertificatesRequest) (*datastore.PruneRevokedCertificatesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneRevokedCertificates", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.PruneRevokedCertificatesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneRevokedCertificates indicates an expected call of PruneRevokedCertificates
+func (mr *MockDataStoreMockRecorder) PruneRevokedCertificates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneRevokedCertificates", reflect.TypeOf((*MockDataStore)(nil).PruneRevokedCertificates), arg0, arg1)
+}
+
+// ReleaseLease mocks base method
+func (m *MockDataStore) ReleaseLease(arg0 context.Context, arg1 *datastore.ReleaseLeaseRequest) (*datastore.ReleaseLeaseResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseLease", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.ReleaseLeaseResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseLease indicates an expected call of ReleaseLease
+func (mr *MockDataStoreMockRecorder) ReleaseLease(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseLease", reflect.TypeOf((*MockDataStore)(nil).ReleaseLease), arg0, arg1)
+}
+
+// RevokeCertificate mocks base method
+func (m *MockDataStore) RevokeCertificate(arg0 context.Context, arg1 *datastore.RevokeCertificateRequest) (*datastore.RevokeCertificateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeCertificate", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.RevokeCertificateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeCertificate indicates an expected call of RevokeCertificate
+func (mr *MockDataStoreMockRecorder) RevokeCertificate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeCertificate", reflect.TypeOf((*MockDataStore)(nil).RevokeCertificate), arg0, arg1)
+}
+
+// SetAttestedNodeBanned mocks base method
+func (m *MockDataStore) SetAttestedNodeBanned(arg0 context.Context, arg1 *datastore.SetAttestedNodeBannedRequest) (*datastore.SetAttestedNodeBannedResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAttestedNodeBanned", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.SetAttestedNodeBannedResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetAttestedNodeBanned indicates an expected call of SetAttestedNodeBanned
+func (mr *MockDataStoreMockRecorder) SetAttestedNodeBanned(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAttestedNodeBanned", reflect.TypeOf((*MockDataStore)(nil).SetAttestedNodeBanned), arg0, arg1)
+}
+
+// SetBundle mocks base method
+func (m *MockDataStore) SetBundle(arg0 context.Context, arg1 *datastore.SetBundleRequest) (*datastore.SetBundleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBundle", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.SetBundleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetBundle indicates an expected call of SetBundle
+func (mr *MockDataStoreMockRecorder) SetBundle(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBundle", reflect.TypeOf((*MockDataStore)(nil).SetBundle), arg0, arg1)
+}
+
+// SetCAJournal mocks base method
+func (m *MockDataStore) SetCAJournal(arg0 context.Context, arg1 *datastore.SetCAJournalRequest) (*datastore.SetCAJournalResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCAJournal", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.SetCAJournalResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCAJournal indicates an expected call of SetCAJournal
+func (mr *MockDataStoreMockRecorder) SetCAJournal(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCAJournal", reflect.TypeOf((*MockDataStore)(nil).SetCAJournal), arg0, arg1)
+}
+
 // SetNodeSelectors mocks base method
 func (m *MockDataStore) SetNodeSelectors(arg0 context.Context, arg1 *datastore.SetNodeSelectorsRequest) (*datastore.SetNodeSelectorsResponse, error) {
 	m.ctrl.T.Helper()
@@ -403,6 +808,21 @@ func (m *MockDataStoreServer) EXPECT() *MockDataStoreServerMockRecorder {
 	return m.recorder
 }
 
+// AcquireLease mocks base method
+func (m *MockDataStoreServer) AcquireLease(arg0 context.Context, arg1 *datastore.AcquireLeaseRequest) (*datastore.AcquireLeaseResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcquireLease", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.AcquireLeaseResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcquireLease indicates an expected call of AcquireLease
+func (mr *MockDataStoreServerMockRecorder) AcquireLease(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcquireLease", reflect.TypeOf((*MockDataStoreServer)(nil).AcquireLease), arg0, arg1)
+}
+
 // AppendBundle mocks base method
 func (m *MockDataStoreServer) AppendBundle(arg0 context.Context, arg1 *datastore.AppendBundleRequest) (*datastore.AppendBundleResponse, error) {
 	m.ctrl.T.Helper()
@@ -418,6 +838,51 @@ func (mr *MockDataStoreServerMockRecorder) AppendBundle(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppendBundle", reflect.TypeOf((*MockDataStoreServer)(nil).AppendBundle), arg0, arg1)
 }
 
+// BatchCreateRegistrationEntries mocks base method
+func (m *MockDataStoreServer) BatchCreateRegistrationEntries(arg0 context.Context, arg1 *datastore.BatchCreateRegistrationEntriesRequest) (*datastore.BatchCreateRegistrationEntriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchCreateRegistrationEntries", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.BatchCreateRegistrationEntriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchCreateRegistrationEntries indicates an expected call of BatchCreateRegistrationEntries
+func (mr *MockDataStoreServerMockRecorder) BatchCreateRegistrationEntries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchCreateRegistrationEntries", reflect.TypeOf((*MockDataStoreServer)(nil).BatchCreateRegistrationEntries), arg0, arg1)
+}
+
+// BatchDeleteRegistrationEntries mocks base method
+func (m *MockDataStoreServer) BatchDeleteRegistrationEntries(arg0 context.Context, arg1 *datastore.BatchDeleteRegistrationEntriesRequest) (*datastore.BatchDeleteRegistrationEntriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchDeleteRegistrationEntries", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.BatchDeleteRegistrationEntriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchDeleteRegistrationEntries indicates an expected call of BatchDeleteRegistrationEntries
+func (mr *MockDataStoreServerMockRecorder) BatchDeleteRegistrationEntries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchDeleteRegistrationEntries", reflect.TypeOf((*MockDataStoreServer)(nil).BatchDeleteRegistrationEntries), arg0, arg1)
+}
+
+// BatchUpdateRegistrationEntries mocks base method
+func (m *MockDataStoreServer) BatchUpdateRegistrationEntries(arg0 context.Context, arg1 *datastore.BatchUpdateRegistrationEntriesRequest) (*datastore.BatchUpdateRegistrationEntriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchUpdateRegistrationEntries", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.BatchUpdateRegistrationEntriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchUpdateRegistrationEntries indicates an expected call of BatchUpdateRegistrationEntries
+func (mr *MockDataStoreServerMockRecorder) BatchUpdateRegistrationEntries(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchUpdateRegistrationEntries", reflect.TypeOf((*MockDataStoreServer)(nil).BatchUpdateRegistrationEntries), arg0, arg1)
+}
+
 // Configure mocks base method
 func (m *MockDataStoreServer) Configure(arg0 context.Context, arg1 *plugin.ConfigureRequest) (*plugin.ConfigureResponse, error) {
 	m.ctrl.T.Helper()
@@ -433,6 +898,21 @@ func (mr *MockDataStoreServerMockRecorder) Configure(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Configure", reflect.TypeOf((*MockDataStoreServer)(nil).Configure), arg0, arg1)
 }
 
+// ConsumeJoinToken mocks base method
+func (m *MockDataStoreServer) ConsumeJoinToken(arg0 context.Context, arg1 *datastore.ConsumeJoinTokenRequest) (*datastore.ConsumeJoinTokenResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeJoinToken", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.ConsumeJoinTokenResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeJoinToken indicates an expected call of ConsumeJoinToken
+func (mr *MockDataStoreServerMockRecorder) ConsumeJoinToken(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeJoinToken", reflect.TypeOf((*MockDataStoreServer)(nil).ConsumeJoinToken), arg0, arg1)
+}
+
 // CreateAttestedNode mocks base method
 func (m *MockDataStoreServer) CreateAttestedNode(arg0 context.Context, arg1 *datastore.CreateAttestedNodeRequest) (*datastore.CreateAttestedNodeResponse, error) {
 	m.ctrl.T.Helper()
@@ -463,6 +943,36 @@ func (mr *MockDataStoreServerMockRecorder) CreateBundle(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBundle", reflect.TypeOf((*MockDataStoreServer)(nil).CreateBundle), arg0, arg1)
 }
 
+// CreateDownstreamCA mocks base method
+func (m *MockDataStoreServer) CreateDownstreamCA(arg0 context.Context, arg1 *datastore.CreateDownstreamCARequest) (*datastore.CreateDownstreamCAResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDownstreamCA", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.CreateDownstreamCAResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDownstreamCA indicates an expected call of CreateDownstreamCA
+func (mr *MockDataStoreServerMockRecorder) CreateDownstreamCA(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDownstreamCA", reflect.TypeOf((*MockDataStoreServer)(nil).CreateDownstreamCA), arg0, arg1)
+}
+
+// CreateIssuedSVID mocks base method
+func (m *MockDataStoreServer) CreateIssuedSVID(arg0 context.Context, arg1 *datastore.CreateIssuedSVIDRequest) (*datastore.CreateIssuedSVIDResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIssuedSVID", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.CreateIssuedSVIDResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIssuedSVID indicates an expected call of CreateIssuedSVID
+func (mr *MockDataStoreServerMockRecorder) CreateIssuedSVID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIssuedSVID", reflect.TypeOf((*MockDataStoreServer)(nil).CreateIssuedSVID), arg0, arg1)
+}
+
 // CreateJoinToken mocks base method
 func (m *MockDataStoreServer) CreateJoinToken(arg0 context.Context, arg1 *datastore.CreateJoinTokenRequest) (*datastore.CreateJoinTokenResponse, error) {
 	m.ctrl.T.Helper()
@@ -583,6 +1093,21 @@ func (mr *MockDataStoreServerMockRecorder) FetchBundle(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchBundle", reflect.TypeOf((*MockDataStoreServer)(nil).FetchBundle), arg0, arg1)
 }
 
+// FetchCAJournal mocks base method
+func (m *MockDataStoreServer) FetchCAJournal(arg0 context.Context, arg1 *datastore.FetchCAJournalRequest) (*datastore.FetchCAJournalResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchCAJournal", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.FetchCAJournalResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchCAJournal indicates an expected call of FetchCAJournal
+func (mr *MockDataStoreServerMockRecorder) FetchCAJournal(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchCAJournal", reflect.TypeOf((*MockDataStoreServer)(nil).FetchCAJournal), arg0, arg1)
+}
+
 // FetchJoinToken mocks base method
 func (m *MockDataStoreServer) FetchJoinToken(arg0 context.Context, arg1 *datastore.FetchJoinTokenRequest) (*datastore.FetchJoinTokenResponse, error) {
 	m.ctrl.T.Helper()
@@ -613,6 +1138,21 @@ func (mr *MockDataStoreServerMockRecorder) FetchRegistrationEntry(arg0, arg1 int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchRegistrationEntry", reflect.TypeOf((*MockDataStoreServer)(nil).FetchRegistrationEntry), arg0, arg1)
 }
 
+// FetchRevokedCertificate mocks base method
+func (m *MockDataStoreServer) FetchRevokedCertificate(arg0 context.Context, arg1 *datastore.FetchRevokedCertificateRequest) (*datastore.FetchRevokedCertificateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FetchRevokedCertificate", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.FetchRevokedCertificateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FetchRevokedCertificate indicates an expected call of FetchRevokedCertificate
+func (mr *MockDataStoreServerMockRecorder) FetchRevokedCertificate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FetchRevokedCertificate", reflect.TypeOf((*MockDataStoreServer)(nil).FetchRevokedCertificate), arg0, arg1)
+}
+
 // GetNodeSelectors mocks base method
 func (m *MockDataStoreServer) GetNodeSelectors(arg0 context.Context, arg1 *datastore.GetNodeSelectorsRequest) (*datastore.GetNodeSelectorsResponse, error) {
 	m.ctrl.T.Helper()
@@ -673,6 +1213,66 @@ func (mr *MockDataStoreServerMockRecorder) ListBundles(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBundles", reflect.TypeOf((*MockDataStoreServer)(nil).ListBundles), arg0, arg1)
 }
 
+// ListDownstreamCAs mocks base method
+func (m *MockDataStoreServer) ListDownstreamCAs(arg0 context.Context, arg1 *datastore.ListDownstreamCAsRequest) (*datastore.ListDownstreamCAsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDownstreamCAs", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.ListDownstreamCAsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDownstreamCAs indicates an expected call of ListDownstreamCAs
+func (mr *MockDataStoreServerMockRecorder) ListDownstreamCAs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDownstreamCAs", reflect.TypeOf((*MockDataStoreServer)(nil).ListDownstreamCAs), arg0, arg1)
+}
+
+// ListIssuedSVIDs mocks base method
+func (m *MockDataStoreServer) ListIssuedSVIDs(arg0 context.Context, arg1 *datastore.ListIssuedSVIDsRequest) (*datastore.ListIssuedSVIDsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIssuedSVIDs", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.ListIssuedSVIDsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIssuedSVIDs indicates an expected call of ListIssuedSVIDs
+func (mr *MockDataStoreServerMockRecorder) ListIssuedSVIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIssuedSVIDs", reflect.TypeOf((*MockDataStoreServer)(nil).ListIssuedSVIDs), arg0, arg1)
+}
+
+// ListJoinTokens mocks base method
+func (m *MockDataStoreServer) ListJoinTokens(arg0 context.Context, arg1 *datastore.ListJoinTokensRequest) (*datastore.ListJoinTokensResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListJoinTokens", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.ListJoinTokensResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListJoinTokens indicates an expected call of ListJoinTokens
+func (mr *MockDataStoreServerMockRecorder) ListJoinTokens(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListJoinTokens", reflect.TypeOf((*MockDataStoreServer)(nil).ListJoinTokens), arg0, arg1)
+}
+
+// ListNodeSelectors mocks base method
+func (m *MockDataStoreServer) ListNodeSelectors(arg0 context.Context, arg1 *datastore.ListNodeSelectorsRequest) (*datastore.ListNodeSelectorsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNodeSelectors", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.ListNodeSelectorsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNodeSelectors indicates an expected call of ListNodeSelectors
+func (mr *MockDataStoreServerMockRecorder) ListNodeSelectors(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNodeSelectors", reflect.TypeOf((*MockDataStoreServer)(nil).ListNodeSelectors), arg0, arg1)
+}
+
 // ListRegistrationEntries mocks base method
 func (m *MockDataStoreServer) ListRegistrationEntries(arg0 context.Context, arg1 *datastore.ListRegistrationEntriesRequest) (*datastore.ListRegistrationEntriesResponse, error) {
 	m.ctrl.T.Helper()
@@ -688,6 +1288,96 @@ func (mr *MockDataStoreServerMockRecorder) ListRegistrationEntries(arg0, arg1 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRegistrationEntries", reflect.TypeOf((*MockDataStoreServer)(nil).ListRegistrationEntries), arg0, arg1)
 }
 
+// ListRegistrationEntryEvents mocks base method
+func (m *MockDataStoreServer) ListRegistrationEntryEvents(arg0 context.Context, arg1 *datastore.ListRegistrationEntryEventsRequest) (*datastore.ListRegistrationEntryEventsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRegistrationEntryEvents", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.ListRegistrationEntryEventsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRegistrationEntryEvents indicates an expected call of ListRegistrationEntryEvents
+func (mr *MockDataStoreServerMockRecorder) ListRegistrationEntryEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRegistrationEntryEvents", reflect.TypeOf((*MockDataStoreServer)(nil).ListRegistrationEntryEvents), arg0, arg1)
+}
+
+// ListRegistrationEntryTombstones mocks base method
+func (m *MockDataStoreServer) ListRegistrationEntryTombstones(arg0 context.Context, arg1 *datastore.ListRegistrationEntryTombstonesRequest) (*datastore.ListRegistrationEntryTombstonesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRegistrationEntryTombstones", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.ListRegistrationEntryTombstonesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRegistrationEntryTombstones indicates an expected call of ListRegistrationEntryTombstones
+func (mr *MockDataStoreServerMockRecorder) ListRegistrationEntryTombstones(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRegistrationEntryTombstones", reflect.TypeOf((*MockDataStoreServer)(nil).ListRegistrationEntryTombstones), arg0, arg1)
+}
+
+// ListRevokedCertificates mocks base method
+func (m *MockDataStoreServer) ListRevokedCertificates(arg0 context.Context, arg1 *datastore.ListRevokedCertificatesRequest) (*datastore.ListRevokedCertificatesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRevokedCertificates", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.ListRevokedCertificatesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRevokedCertificates indicates an expected call of ListRevokedCertificates
+func (mr *MockDataStoreServerMockRecorder) ListRevokedCertificates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRevokedCertificates", reflect.TypeOf((*MockDataStoreServer)(nil).ListRevokedCertificates), arg0, arg1)
+}
+
+// PruneBundle mocks base method
+func (m *MockDataStoreServer) PruneBundle(arg0 context.Context, arg1 *datastore.PruneBundleRequest) (*datastore.PruneBundleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneBundle", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.PruneBundleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneBundle indicates an expected call of PruneBundle
+func (mr *MockDataStoreServerMockRecorder) PruneBundle(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneBundle", reflect.TypeOf((*MockDataStoreServer)(nil).PruneBundle), arg0, arg1)
+}
+
+// PruneDownstreamCAs mocks base method
+func (m *MockDataStoreServer) PruneDownstreamCAs(arg0 context.Context, arg1 *datastore.PruneDownstreamCAsRequest) (*datastore.PruneDownstreamCAsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneDownstreamCAs", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.PruneDownstreamCAsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneDownstreamCAs indicates an expected call of PruneDownstreamCAs
+func (mr *MockDataStoreServerMockRecorder) PruneDownstreamCAs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneDownstreamCAs", reflect.TypeOf((*MockDataStoreServer)(nil).PruneDownstreamCAs), arg0, arg1)
+}
+
+// PruneIssuedSVIDs mocks base method
+func (m *MockDataStoreServer) PruneIssuedSVIDs(arg0 context.Context, arg1 *datastore.PruneIssuedSVIDsRequest) (*datastore.PruneIssuedSVIDsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneIssuedSVIDs", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.PruneIssuedSVIDsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneIssuedSVIDs indicates an expected call of PruneIssuedSVIDs
+func (mr *MockDataStoreServerMockRecorder) PruneIssuedSVIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneIssuedSVIDs", reflect.TypeOf((*MockDataStoreServer)(nil).PruneIssuedSVIDs), arg0, arg1)
+}
+
 // PruneJoinTokens mocks base method
 func (m *MockDataStoreServer) PruneJoinTokens(arg0 context.Context, arg1 *datastore.PruneJoinTokensRequest) (*datastore.PruneJoinTokensResponse, error) {
 	m.ctrl.T.Helper()
@@ -718,6 +1408,126 @@ func (mr *MockDataStoreServerMockRecorder) PruneRegistrationEntries(arg0, arg1 i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneRegistrationEntries", reflect.TypeOf((*MockDataStoreServer)(nil).PruneRegistrationEntries), arg0, arg1)
 }
 
+// PruneRegistrationEntryEvents mocks base method
+func (m *MockDataStoreServer) PruneRegistrationEntryEvents(arg0 context.Context, arg1 *datastore.PruneRegistrationEntryEventsRequest) (*datastore.PruneRegistrationEntryEventsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneRegistrationEntryEvents", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.PruneRegistrationEntryEventsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneRegistrationEntryEvents indicates an expected call of PruneRegistrationEntryEvents
+func (mr *MockDataStoreServerMockRecorder) PruneRegistrationEntryEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneRegistrationEntryEvents", reflect.TypeOf((*MockDataStoreServer)(nil).PruneRegistrationEntryEvents), arg0, arg1)
+}
+
+// PruneRegistrationEntryTombstones mocks base method
+func (m *MockDataStoreServer) PruneRegistrationEntryTombstones(arg0 context.Context, arg1 *datastore.PruneRegistrationEntryTombstonesRequest) (*datastore.PruneRegistrationEntryTombstonesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneRegistrationEntryTombstones", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.PruneRegistrationEntryTombstonesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneRegistrationEntryTombstones indicates an expected call of PruneRegistrationEntryTombstones
+func (mr *MockDataStoreServerMockRecorder) PruneRegistrationEntryTombstones(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneRegistrationEntryTombstones", reflect.TypeOf((*MockDataStoreServer)(nil).PruneRegistrationEntryTombstones), arg0, arg1)
+}
+
+// PruneRevokedCertificates mocks base method
+func (m *MockDataStoreServer) PruneRevokedCertificates(arg0 context.Context, arg1 *datastore.PruneRevokedCertificatesRequest) (*datastore.PruneRevokedCertificatesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneRevokedCertificates", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.PruneRevokedCertificatesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneRevokedCertificates indicates an expected call of PruneRevokedCertificates
+func (mr *MockDataStoreServerMockRecorder) PruneRevokedCertificates(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneRevokedCertificates", reflect.TypeOf((*MockDataStoreServer)(nil).PruneRevokedCertificates), arg0, arg1)
+}
+
+// ReleaseLease mocks base method
+func (m *MockDataStoreServer) ReleaseLease(arg0 context.Context, arg1 *datastore.ReleaseLeaseRequest) (*datastore.ReleaseLeaseResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseLease", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.ReleaseLeaseResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseLease indicates an expected call of ReleaseLease
+func (mr *MockDataStoreServerMockRecorder) ReleaseLease(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseLease", reflect.TypeOf((*MockDataStoreServer)(nil).ReleaseLease), arg0, arg1)
+}
+
+// RevokeCertificate mocks base method
+func (m *MockDataStoreServer) RevokeCertificate(arg0 context.Context, arg1 *datastore.RevokeCertificateRequest) (*datastore.RevokeCertificateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeCertificate", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.RevokeCertificateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeCertificate indicates an expected call of RevokeCertificate
+func (mr *MockDataStoreServerMockRecorder) RevokeCertificate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeCertificate", reflect.TypeOf((*MockDataStoreServer)(nil).RevokeCertificate), arg0, arg1)
+}
+
+// SetAttestedNodeBanned mocks base method
+func (m *MockDataStoreServer) SetAttestedNodeBanned(arg0 context.Context, arg1 *datastore.SetAttestedNodeBannedRequest) (*datastore.SetAttestedNodeBannedResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAttestedNodeBanned", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.SetAttestedNodeBannedResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetAttestedNodeBanned indicates an expected call of SetAttestedNodeBanned
+func (mr *MockDataStoreServerMockRecorder) SetAttestedNodeBanned(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAttestedNodeBanned", reflect.TypeOf((*MockDataStoreServer)(nil).SetAttestedNodeBanned), arg0, arg1)
+}
+
+// SetBundle mocks base method
+func (m *MockDataStoreServer) SetBundle(arg0 context.Context, arg1 *datastore.SetBundleRequest) (*datastore.SetBundleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBundle", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.SetBundleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetBundle indicates an expected call of SetBundle
+func (mr *MockDataStoreServerMockRecorder) SetBundle(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBundle", reflect.TypeOf((*MockDataStoreServer)(nil).SetBundle), arg0, arg1)
+}
+
+// SetCAJournal mocks base method
+func (m *MockDataStoreServer) SetCAJournal(arg0 context.Context, arg1 *datastore.SetCAJournalRequest) (*datastore.SetCAJournalResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCAJournal", arg0, arg1)
+	ret0, _ := ret[0].(*datastore.SetCAJournalResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCAJournal indicates an expected call of SetCAJournal
+func (mr *MockDataStoreServerMockRecorder) SetCAJournal(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCAJournal", reflect.TypeOf((*MockDataStoreServer)(nil).SetCAJournal), arg0, arg1)
+}
+
 // SetNodeSelectors mocks base method
 func (m *MockDataStoreServer) SetNodeSelectors(arg0 context.Context, arg1 *datastore.SetNodeSelectorsRequest) (*datastore.SetNodeSelectorsResponse, error) {
 	m.ctrl.T.Helper()